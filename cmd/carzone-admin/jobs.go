@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/spf13/cobra"
+)
+
+// bookingsCommand groups the booking commands
+func bookingsCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bookings",
+		Short: "Booking maintenance",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "expire-holds",
+		Short: "Cancel pending bookings left unpaid past the payment window, releasing their dates",
+		Long: `Run the ExpireUnpaidBookings job once: pending bookings left unpaid past
+BOOKING_PAYMENT_TTL are cancelled and their dates released, as the API's scheduler does.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := a.bookings.ExpireUnpaidBookings(cmd.Context()); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Expired unpaid booking holds")
+			return nil
+		},
+	})
+	return cmd
+}
+
+// paymentsCommand groups the payment commands
+func paymentsCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "payments",
+		Short: "Payment maintenance",
+	}
+	cmd.AddCommand(reconcileCommand(a))
+	return cmd
+}
+
+// reconcileCommand resyncs pending payments with the outcome Razorpay recorded for them
+func reconcileCommand(a *app) *cobra.Command {
+	var olderThan time.Duration
+	cmd := &cobra.Command{
+		Use:   "reconcile [payment-id...]",
+		Short: "Apply Razorpay's outcome to payments stuck in pending",
+		Long: `Fetch the attempts Razorpay recorded for pending payments and complete, hold or fail
+them accordingly, for payments whose checkout verification or webhook never arrived.
+
+With payment IDs, those payments are resynced. Without, every Razorpay payment pending and
+untouched for longer than --older-than is.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var result models.MaintenanceResult
+			if len(args) == 0 {
+				var err error
+				if result, err = a.payments.ReconcilePendingPayments(cmd.Context(), olderThan); err != nil {
+					return err
+				}
+			}
+			for _, id := range args {
+				resynced, err := a.payments.ResyncPayment(cmd.Context(), id)
+				if err != nil {
+					return fmt.Errorf("payment %s: %w", id, err)
+				}
+				result.Add(resynced)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Checked %d payments, updated %d\n", result.Examined, result.Changed)
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&olderThan, "older-than", 30*time.Minute, "how long a payment must have been pending untouched")
+	return cmd
+}
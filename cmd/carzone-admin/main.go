@@ -0,0 +1,133 @@
+// Command carzone-admin runs operational tasks against a CarZone database through the same
+// stores and services as the API, so production fixes need no hand-written SQL:
+//
+//	carzone-admin user create-admin --email ops@example.com --username ops --phone +919800000000
+//	carzone-admin user set-password --email someone@example.com
+//	carzone-admin migrate [--dir migrations] [--dry-run | --baseline]
+//	carzone-admin bookings expire-holds
+//	carzone-admin payments reconcile [payment-id...] [--older-than 30m]
+//
+// It reads the same environment and secrets as the API. Commands act as the platform, not as
+// a user, so they are not restricted to one operator's rows.
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/PrateekKumar15/CarZone/driver"
+	"github.com/PrateekKumar15/CarZone/encryption"
+	"github.com/PrateekKumar15/CarZone/secrets"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+
+	alertService "github.com/PrateekKumar15/CarZone/service/alert"
+	authService "github.com/PrateekKumar15/CarZone/service/auth"
+	bookingService "github.com/PrateekKumar15/CarZone/service/booking"
+	disputeService "github.com/PrateekKumar15/CarZone/service/dispute"
+	emailTemplateService "github.com/PrateekKumar15/CarZone/service/emailtemplate"
+	"github.com/PrateekKumar15/CarZone/service/events"
+	geocodingService "github.com/PrateekKumar15/CarZone/service/geocoding"
+	notificationService "github.com/PrateekKumar15/CarZone/service/notification"
+	paymentService "github.com/PrateekKumar15/CarZone/service/payment"
+	riskService "github.com/PrateekKumar15/CarZone/service/risk"
+	securityService "github.com/PrateekKumar15/CarZone/service/security"
+	sequenceService "github.com/PrateekKumar15/CarZone/service/sequence"
+	smartLockService "github.com/PrateekKumar15/CarZone/service/smartlock"
+	telemetryService "github.com/PrateekKumar15/CarZone/service/telemetry"
+
+	alertStore "github.com/PrateekKumar15/CarZone/store/alert"
+	blockStore "github.com/PrateekKumar15/CarZone/store/block"
+	bookingStore "github.com/PrateekKumar15/CarZone/store/booking"
+	carStore "github.com/PrateekKumar15/CarZone/store/car"
+	checklistStore "github.com/PrateekKumar15/CarZone/store/checklist"
+	disputeStore "github.com/PrateekKumar15/CarZone/store/dispute"
+	emailTemplateStore "github.com/PrateekKumar15/CarZone/store/emailtemplate"
+	fleetStore "github.com/PrateekKumar15/CarZone/store/fleet"
+	kycStore "github.com/PrateekKumar15/CarZone/store/kyc"
+	locationStore "github.com/PrateekKumar15/CarZone/store/location"
+	migrationStore "github.com/PrateekKumar15/CarZone/store/migration"
+	notificationStore "github.com/PrateekKumar15/CarZone/store/notification"
+	paymentStore "github.com/PrateekKumar15/CarZone/store/payment"
+	payoutStore "github.com/PrateekKumar15/CarZone/store/payout"
+	securityStore "github.com/PrateekKumar15/CarZone/store/security"
+	sequenceStore "github.com/PrateekKumar15/CarZone/store/sequence"
+	smartLockStore "github.com/PrateekKumar15/CarZone/store/smartlock"
+	telemetryStore "github.com/PrateekKumar15/CarZone/store/telemetry"
+	userStore "github.com/PrateekKumar15/CarZone/store/user"
+	vacationStore "github.com/PrateekKumar15/CarZone/store/vacation"
+)
+
+// app holds the stores and services the commands use
+type app struct {
+	auth       service.AuthServiceInterface
+	bookings   service.BookingServiceInterface
+	payments   service.PaymentServiceInterface
+	migrations store.MigrationStoreInterface
+}
+
+func main() {
+	a := &app{}
+	root := &cobra.Command{
+		Use:          "carzone-admin",
+		Short:        "Operational tasks for a CarZone deployment",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return a.connect(cmd.Context())
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			driver.CloseDB()
+		},
+	}
+	root.AddCommand(userCommand(a), migrateCommand(a), bookingsCommand(a), paymentsCommand(a))
+
+	if err := root.ExecuteContext(context.Background()); err != nil {
+		os.Exit(1)
+	}
+}
+
+// connect loads the configuration, opens the database and wires the services as in the API
+func (a *app) connect(ctx context.Context) error {
+	if err := godotenv.Load(); err != nil {
+		log.Printf("No .env file loaded, using process environment: %v", err)
+	}
+	if err := secrets.Init(ctx); err != nil {
+		return err
+	}
+
+	driver.InitDB()
+	db := driver.GetDB()
+
+	cipher, err := encryption.NewCipherFromEnv()
+	if err != nil {
+		return err
+	}
+
+	carStore := carStore.New(db)
+	bookingStore := bookingStore.New(db)
+	userStore := userStore.New(db, cipher)
+	paymentStore := paymentStore.New(db)
+	payoutStore := payoutStore.New(db, cipher)
+	securityStore := securityStore.New(db)
+	notificationStore := notificationStore.New(db)
+
+	notificationService := notificationService.NewNotificationService(notificationStore)
+	emailTemplateService := emailTemplateService.NewEmailTemplateService(emailTemplateStore.New(db), userStore, notificationService)
+	securityService := securityService.NewSecurityService(securityStore, userStore, notificationService, securityService.ThresholdsFromEnv())
+	riskService := riskService.NewRiskService(userStore, riskService.ReviewThresholdFromEnv(), riskService.RulesFromEnv(securityStore)...)
+	carEvents := events.NewCarEventBus(alertService.NewAlertService(alertStore.New(db), carStore, bookingStore, userStore, notificationService))
+	telemetryService := telemetryService.NewTelemetryService(telemetryStore.New(db, cipher), carStore, userStore, notificationService)
+	sequenceService := sequenceService.NewSequenceService(sequenceStore.New(db))
+	disputeService := disputeService.NewDisputeService(disputeStore.New(db), paymentStore, bookingStore, payoutStore, userStore, notificationService)
+	paymentService := paymentService.NewPaymentService(paymentStore, bookingStore, securityService, riskService, userStore, disputeService, sequenceService)
+
+	a.auth = authService.NewAuthService(userStore)
+	a.payments = paymentService
+	smartLockService := smartLockService.NewSmartLockService(smartLockStore.New(db), bookingStore, carStore, smartLockService.ProvidersFromEnv()...)
+	a.bookings = bookingService.NewBookingService(bookingStore, carStore, securityService, riskService, telemetryService, locationStore.New(db), geocodingService.NewGeocodingService(), carEvents, emailTemplateService, vacationStore.New(db), fleetStore.New(db), paymentService, sequenceService, kycStore.New(db), blockStore.New(db), smartLockService, checklistStore.New(db))
+	a.migrations = migrationStore.New(db)
+	return nil
+}
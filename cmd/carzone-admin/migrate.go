@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// migrateCommand applies the pending SQL migrations of a directory in file name order.
+// store/schema.sql builds new databases; migrations bring existing ones up to date.
+func migrateCommand(a *app) *cobra.Command {
+	var dir string
+	var dryRun, baseline bool
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply pending SQL migrations, each in its own transaction",
+		Long: `Apply the *.sql files of the migrations directory that have not run yet, in file name
+order (e.g. 20250101_add_car_vin.sql). Each file runs in a transaction with its record in
+schema_migration, so a failed migration leaves nothing behind and can be fixed and rerun.
+
+Use --baseline on a database just built from store/schema.sql: it records every migration as
+applied without running it, since the schema already contains their changes.
+
+Every migration has a rollback script of the same name in the down subdirectory. Those are never
+applied; run one by hand and delete the migration's schema_migration row to undo it.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if dryRun && baseline {
+				return fmt.Errorf("--dry-run and --baseline cannot be combined")
+			}
+
+			files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+			if err != nil {
+				return err
+			}
+			sort.Strings(files)
+
+			applied, err := a.migrations.GetApplied(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			pending := 0
+			for _, file := range files {
+				name := filepath.Base(file)
+				if _, ok := applied[name]; ok {
+					continue
+				}
+				pending++
+
+				switch {
+				case dryRun:
+					fmt.Fprintf(out, "Pending %s\n", name)
+					continue
+				case baseline:
+					if _, err := a.migrations.Apply(cmd.Context(), name, ""); err != nil {
+						return fmt.Errorf("%s: %w", name, err)
+					}
+					fmt.Fprintf(out, "Recorded %s\n", name)
+					continue
+				}
+
+				script, err := os.ReadFile(file)
+				if err != nil {
+					return err
+				}
+				ran, err := a.migrations.Apply(cmd.Context(), name, string(script))
+				if err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				if ran {
+					fmt.Fprintf(out, "Applied %s\n", name)
+				} else {
+					fmt.Fprintf(out, "Skipped %s, applied by another run\n", name)
+				}
+			}
+
+			if pending == 0 {
+				fmt.Fprintf(out, "No pending migrations in %s\n", dir)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "migrations", "directory of *.sql migration files")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "list pending migrations without applying them")
+	cmd.Flags().BoolVar(&baseline, "baseline", false, "record pending migrations as applied without running them")
+	return cmd
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/spf13/cobra"
+)
+
+// userCommand groups the user account commands
+func userCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage user accounts",
+	}
+	cmd.AddCommand(createAdminCommand(a), setPasswordCommand(a))
+	return cmd
+}
+
+// createAdminCommand registers an admin, who cannot sign up through the public API
+func createAdminCommand(a *app) *cobra.Command {
+	var req models.UserRequest
+	cmd := &cobra.Command{
+		Use:   "create-admin",
+		Short: "Create an admin user; the password is read from standard input",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			password, err := readPassword(cmd)
+			if err != nil {
+				return err
+			}
+			req.Password = password
+			req.Role = "admin"
+
+			if err := a.auth.RegisterUser(cmd.Context(), req); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Created admin %s\n", req.Email)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&req.Email, "email", "", "e-mail address the admin signs in with")
+	cmd.Flags().StringVar(&req.UserName, "username", "", "display name")
+	cmd.Flags().StringVar(&req.Phone, "phone", "", "phone number, 10-15 digits")
+	_ = cmd.MarkFlagRequired("email")
+	_ = cmd.MarkFlagRequired("username")
+	_ = cmd.MarkFlagRequired("phone")
+	return cmd
+}
+
+// setPasswordCommand rotates a user's password and ends their sessions
+func setPasswordCommand(a *app) *cobra.Command {
+	var email string
+	cmd := &cobra.Command{
+		Use:   "set-password",
+		Short: "Replace a user's password; the new password is read from standard input",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			password, err := readPassword(cmd)
+			if err != nil {
+				return err
+			}
+
+			id, err := a.auth.SetPassword(cmd.Context(), email, password)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Password of %s (%s) replaced; their sessions have ended\n", email, id)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&email, "email", "", "e-mail address of the user")
+	_ = cmd.MarkFlagRequired("email")
+	return cmd
+}
+
+// readPassword reads a password from the first line of standard input, so it stays out of
+// the shell history and process list. Pipe it in or type it at the prompt.
+func readPassword(cmd *cobra.Command) (string, error) {
+	fmt.Fprint(cmd.ErrOrStderr(), "Password: ")
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", errors.New("no password on standard input")
+	}
+	fmt.Fprintln(cmd.ErrOrStderr())
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,460 @@
+// Command seed fills a CarZone database with demo data: an admin, car owners, renters, listed
+// cars with images, and bookings in every stage of a rental with their payments. Everything is
+// created through the service layer, so demo data passes the same validation as API requests.
+//
+// Seeding can be replayed. Existing users are logged in instead of registered, cars are matched
+// to their owner's listings by name, and cars that already have bookings get no new ones, so a
+// second run only fills in what is missing.
+//
+// Usage:
+//
+//	go run ./cmd/seed
+//
+// The command reads the same environment and secrets as the API. SEED_PASSWORD (default
+// "carzone-demo") is the password of every demo user.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/driver"
+	"github.com/PrateekKumar15/CarZone/encryption"
+	"github.com/PrateekKumar15/CarZone/identity"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/secrets"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/joho/godotenv"
+
+	alertService "github.com/PrateekKumar15/CarZone/service/alert"
+	attributeService "github.com/PrateekKumar15/CarZone/service/attribute"
+	authService "github.com/PrateekKumar15/CarZone/service/auth"
+	bookingService "github.com/PrateekKumar15/CarZone/service/booking"
+	brandService "github.com/PrateekKumar15/CarZone/service/brand"
+	carService "github.com/PrateekKumar15/CarZone/service/car"
+	disputeService "github.com/PrateekKumar15/CarZone/service/dispute"
+	emailTemplateService "github.com/PrateekKumar15/CarZone/service/emailtemplate"
+	"github.com/PrateekKumar15/CarZone/service/events"
+	featureService "github.com/PrateekKumar15/CarZone/service/feature"
+	geocodingService "github.com/PrateekKumar15/CarZone/service/geocoding"
+	notificationService "github.com/PrateekKumar15/CarZone/service/notification"
+	paymentService "github.com/PrateekKumar15/CarZone/service/payment"
+	riskService "github.com/PrateekKumar15/CarZone/service/risk"
+	securityService "github.com/PrateekKumar15/CarZone/service/security"
+	sequenceService "github.com/PrateekKumar15/CarZone/service/sequence"
+	settingService "github.com/PrateekKumar15/CarZone/service/setting"
+	smartLockService "github.com/PrateekKumar15/CarZone/service/smartlock"
+	telemetryService "github.com/PrateekKumar15/CarZone/service/telemetry"
+
+	alertStore "github.com/PrateekKumar15/CarZone/store/alert"
+	attributeStore "github.com/PrateekKumar15/CarZone/store/attribute"
+	blockStore "github.com/PrateekKumar15/CarZone/store/block"
+	bookingStore "github.com/PrateekKumar15/CarZone/store/booking"
+	brandStore "github.com/PrateekKumar15/CarZone/store/brand"
+	carStore "github.com/PrateekKumar15/CarZone/store/car"
+	carImageStore "github.com/PrateekKumar15/CarZone/store/carimage"
+	checklistStore "github.com/PrateekKumar15/CarZone/store/checklist"
+	disputeStore "github.com/PrateekKumar15/CarZone/store/dispute"
+	emailTemplateStore "github.com/PrateekKumar15/CarZone/store/emailtemplate"
+	featureStore "github.com/PrateekKumar15/CarZone/store/feature"
+	featuredStore "github.com/PrateekKumar15/CarZone/store/featured"
+	fleetStore "github.com/PrateekKumar15/CarZone/store/fleet"
+	kycStore "github.com/PrateekKumar15/CarZone/store/kyc"
+	locationStore "github.com/PrateekKumar15/CarZone/store/location"
+	notificationStore "github.com/PrateekKumar15/CarZone/store/notification"
+	paymentStore "github.com/PrateekKumar15/CarZone/store/payment"
+	payoutStore "github.com/PrateekKumar15/CarZone/store/payout"
+	securityStore "github.com/PrateekKumar15/CarZone/store/security"
+	sequenceStore "github.com/PrateekKumar15/CarZone/store/sequence"
+	settingStore "github.com/PrateekKumar15/CarZone/store/setting"
+	smartLockStore "github.com/PrateekKumar15/CarZone/store/smartlock"
+	telemetryStore "github.com/PrateekKumar15/CarZone/store/telemetry"
+	userStore "github.com/PrateekKumar15/CarZone/store/user"
+	vacationStore "github.com/PrateekKumar15/CarZone/store/vacation"
+)
+
+// demoUser is a user to register
+type demoUser struct {
+	key      string // Name the cars and bookings below refer to the user by
+	email    string
+	username string
+	phone    string
+	role     string
+}
+
+// demoCar is a car to list for an owner
+type demoCar struct {
+	owner    string
+	name     string
+	brand    string
+	model    string
+	year     int
+	fuelType string
+	engine   models.Engine
+	city     string
+	state    string
+	price    float64
+	mileage  int
+	features map[string]interface{}
+}
+
+// demoBooking is a booking to make and take to a status. Bookings start startInDays days from
+// the seed run at 10:00 and last days days.
+type demoBooking struct {
+	car         string
+	customer    string
+	startInDays int
+	days        int
+	status      models.BookingStatus
+}
+
+var demoUsers = []demoUser{
+	{key: "admin", email: "admin@demo.carzone.dev", username: "demoadmin", phone: "+919800000001", role: "admin"},
+	{key: "asha", email: "asha.owner@demo.carzone.dev", username: "asha_rentals", phone: "+919800000002", role: "owner"},
+	{key: "vikram", email: "vikram.owner@demo.carzone.dev", username: "vikram_drives", phone: "+919800000003", role: "owner"},
+	{key: "rohan", email: "rohan.renter@demo.carzone.dev", username: "rohan_k", phone: "+919800000004", role: "renter"},
+	{key: "meera", email: "meera.renter@demo.carzone.dev", username: "meera_s", phone: "+919800000005", role: "renter"},
+	{key: "kabir", email: "kabir.renter@demo.carzone.dev", username: "kabir_m", phone: "+919800000006", role: "renter"},
+}
+
+var demoCars = []demoCar{
+	{
+		owner: "asha", name: "Honda City ZX", brand: "Honda", model: "City", year: 2022, fuelType: "Petrol",
+		engine: models.Engine{EngineSize: 1.5, Cylinders: 4, Horsepower: 119, Transmission: "CVT"},
+		city:   "Bengaluru", state: "Karnataka", price: 2200, mileage: 18500,
+		features: map[string]interface{}{"air_conditioning": true, "bluetooth": true, "backup_camera": true},
+	},
+	{
+		owner: "asha", name: "Hyundai Creta SX", brand: "Hyundai", model: "Creta", year: 2023, fuelType: "Diesel",
+		engine: models.Engine{EngineSize: 1.5, Cylinders: 4, Horsepower: 114, Transmission: "Automatic"},
+		city:   "Bengaluru", state: "Karnataka", price: 3200, mileage: 9200,
+		features: map[string]interface{}{"air_conditioning": true, "apple_carplay": true, "android_auto": true, "cruise_control": true},
+	},
+	{
+		owner: "vikram", name: "Mahindra Thar LX", brand: "Mahindra", model: "Thar", year: 2021, fuelType: "Diesel",
+		engine: models.Engine{EngineSize: 2.2, Cylinders: 4, Horsepower: 130, Transmission: "Manual"},
+		city:   "Pune", state: "Maharashtra", price: 3500, mileage: 31000,
+		features: map[string]interface{}{"air_conditioning": true, "all_wheel_drive": true, "convertible": true},
+	},
+	{
+		owner: "vikram", name: "MG ZS EV Exclusive", brand: "MG", model: "ZS EV", year: 2023, fuelType: "Electric",
+		engine: models.Engine{EngineSize: 0.1, Cylinders: 1, Horsepower: 174, Transmission: "Automatic"},
+		city:   "Pune", state: "Maharashtra", price: 4000, mileage: 6400,
+		features: map[string]interface{}{"air_conditioning": true, "gps": true, "heated_seats": true, "bluetooth": true},
+	},
+}
+
+// Each car's bookings are apart, so none of them conflict
+var demoBookings = []demoBooking{
+	{car: "Honda City ZX", customer: "rohan", startInDays: 1, days: 3, status: models.BookingStatusCompleted},
+	{car: "Honda City ZX", customer: "meera", startInDays: 7, days: 2, status: models.BookingStatusConfirmed},
+	{car: "Hyundai Creta SX", customer: "kabir", startInDays: 1, days: 4, status: models.BookingStatusInProgress},
+	{car: "Hyundai Creta SX", customer: "rohan", startInDays: 10, days: 2, status: models.BookingStatusPending},
+	{car: "Mahindra Thar LX", customer: "meera", startInDays: 2, days: 2, status: models.BookingStatusCancelled},
+	{car: "Mahindra Thar LX", customer: "kabir", startInDays: 6, days: 3, status: models.BookingStatusConfirmed},
+	{car: "MG ZS EV Exclusive", customer: "rohan", startInDays: 3, days: 2, status: models.BookingStatusPending},
+}
+
+// seeder creates the demo data through the services
+type seeder struct {
+	auth     service.AuthServiceInterface
+	cars     service.CarServiceInterface
+	bookings service.BookingServiceInterface
+	payments service.PaymentServiceInterface
+	password string
+
+	users      map[string]models.User
+	carsByName map[string]models.Car
+}
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Printf("No .env file loaded, using process environment: %v", err)
+	}
+	if err := secrets.Init(context.Background()); err != nil {
+		log.Fatalf("Failed to load secrets: %v", err)
+	}
+
+	driver.InitDB()
+	defer driver.CloseDB()
+	db := driver.GetDB()
+	if db == nil {
+		log.Fatal("Database connection is nil - cannot proceed")
+	}
+
+	cipher, err := encryption.NewCipherFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize encryption: %v", err)
+	}
+
+	// The services demo data goes through, wired as in the API
+	carStore := carStore.New(db)
+	bookingStore := bookingStore.New(db)
+	userStore := userStore.New(db, cipher)
+	paymentStore := paymentStore.New(db)
+	payoutStore := payoutStore.New(db, cipher)
+	securityStore := securityStore.New(db)
+	telemetryStore := telemetryStore.New(db, cipher)
+	locationStore := locationStore.New(db)
+	alertStore := alertStore.New(db)
+	notificationStore := notificationStore.New(db)
+	emailTemplateStore := emailTemplateStore.New(db)
+	vacationStore := vacationStore.New(db)
+	fleetStore := fleetStore.New(db)
+	featureStore := featureStore.New(db)
+	attributeStore := attributeStore.New(db)
+	kycStore := kycStore.New(db)
+	settingStore := settingStore.New(db)
+	featuredStore := featuredStore.New(db)
+	blockStore := blockStore.New(db)
+	checklistStore := checklistStore.New(db)
+	brandStore := brandStore.New(db)
+	disputeStore := disputeStore.New(db)
+	sequenceStore := sequenceStore.New(db)
+
+	notificationService := notificationService.NewNotificationService(notificationStore)
+	emailTemplateService := emailTemplateService.NewEmailTemplateService(emailTemplateStore, userStore, notificationService)
+	securityService := securityService.NewSecurityService(securityStore, userStore, notificationService, securityService.ThresholdsFromEnv())
+	riskService := riskService.NewRiskService(userStore, riskService.ReviewThresholdFromEnv(), riskService.RulesFromEnv(securityStore)...)
+	alertService := alertService.NewAlertService(alertStore, carStore, bookingStore, userStore, notificationService)
+	carEvents := events.NewCarEventBus(alertService)
+	carService := carService.NewCarService(carStore, carEvents, featureService.NewFeatureService(featureStore), brandService.NewBrandService(brandStore), attributeService.NewCarAttributeService(attributeStore), settingService.NewSettingService(settingStore), featuredStore, carImageStore.New(db))
+	telemetryService := telemetryService.NewTelemetryService(telemetryStore, carStore, userStore, notificationService)
+	sequenceService := sequenceService.NewSequenceService(sequenceStore)
+	disputeService := disputeService.NewDisputeService(disputeStore, paymentStore, bookingStore, payoutStore, userStore, notificationService)
+	paymentService := paymentService.NewPaymentService(paymentStore, bookingStore, securityService, riskService, userStore, disputeService, sequenceService)
+	smartLockService := smartLockService.NewSmartLockService(smartLockStore.New(db), bookingStore, carStore, smartLockService.ProvidersFromEnv()...)
+	bookingService := bookingService.NewBookingService(bookingStore, carStore, securityService, riskService, telemetryService, locationStore, geocodingService.NewGeocodingService(), carEvents, emailTemplateService, vacationStore, fleetStore, paymentService, sequenceService, kycStore, blockStore, smartLockService, checklistStore)
+
+	password := os.Getenv("SEED_PASSWORD")
+	if password == "" {
+		password = "carzone-demo"
+	}
+
+	s := &seeder{
+		auth:       authService.NewAuthService(userStore),
+		cars:       carService,
+		bookings:   bookingService,
+		payments:   paymentService,
+		password:   password,
+		users:      map[string]models.User{},
+		carsByName: map[string]models.Car{},
+	}
+	if err := s.run(context.Background()); err != nil {
+		log.Fatalf("Seeding failed: %v", err)
+	}
+	log.Printf("Demo data ready; every demo user signs in with SEED_PASSWORD")
+}
+
+// run seeds users, then cars, then bookings
+func (s *seeder) run(ctx context.Context) error {
+	for _, user := range demoUsers {
+		if err := s.seedUser(ctx, user); err != nil {
+			return fmt.Errorf("user %s: %w", user.email, err)
+		}
+	}
+	for _, car := range demoCars {
+		if err := s.seedCar(ctx, car); err != nil {
+			return fmt.Errorf("car %s: %w", car.name, err)
+		}
+	}
+
+	seeded := map[string]bool{}
+	for _, booking := range demoBookings {
+		car := s.carsByName[booking.car]
+		if _, checked := seeded[booking.car]; !checked {
+			existing, err := s.bookings.GetBookingsByCarID(s.actAs(ctx, s.users[s.ownerOf(booking.car)]), car.ID.String())
+			if err != nil {
+				return fmt.Errorf("bookings of %s: %w", booking.car, err)
+			}
+			seeded[booking.car] = len(*existing) > 0
+			if seeded[booking.car] {
+				log.Printf("Car %s already has bookings, skipping its demo bookings", booking.car)
+			}
+		}
+		if seeded[booking.car] {
+			continue
+		}
+		if err := s.seedBooking(ctx, booking); err != nil {
+			return fmt.Errorf("%s booking of %s: %w", booking.status, booking.car, err)
+		}
+	}
+	return nil
+}
+
+// seedUser registers a demo user unless they can already log in
+func (s *seeder) seedUser(ctx context.Context, user demoUser) error {
+	login := models.LoginRequest{Email: user.email, Password: s.password}
+	existing, err := s.auth.LoginUser(ctx, login)
+	if err == nil {
+		s.users[user.key] = existing
+		return nil
+	}
+
+	err = s.auth.RegisterUser(ctx, models.UserRequest{
+		Email:    user.email,
+		Password: s.password,
+		UserName: user.username,
+		Phone:    user.phone,
+		Role:     user.role,
+	})
+	if err != nil {
+		return err
+	}
+	registered, err := s.auth.LoginUser(ctx, login)
+	if err != nil {
+		return err
+	}
+	s.users[user.key] = registered
+	log.Printf("Registered %s %s", user.role, user.email)
+	return nil
+}
+
+// seedCar lists a demo car for its owner and has the admin approve it, unless the owner
+// already has a car of the same name
+func (s *seeder) seedCar(ctx context.Context, car demoCar) error {
+	owner := s.users[car.owner]
+	ownerCtx := s.actAs(ctx, owner)
+
+	listed, err := s.cars.ListCars(ownerCtx, models.CarFilter{OwnerID: &owner.ID}, models.PageRequest{Limit: 100})
+	if err != nil {
+		return err
+	}
+	for _, existing := range listed.Data {
+		if existing.Name == car.name {
+			s.carsByName[car.name] = existing
+			return nil
+		}
+	}
+
+	created, err := s.cars.CreateCar(ownerCtx, models.CarRequest{
+		OwnerID:         &owner.ID,
+		Name:            car.name,
+		Brand:           car.brand,
+		Model:           car.model,
+		Year:            car.year,
+		FuelType:        car.fuelType,
+		Engine:          car.engine,
+		LocationCity:    car.city,
+		LocationState:   car.state,
+		LocationCountry: "India",
+		Price:           car.price,
+		Status:          models.CarStatusPendingReview,
+		Features:        car.features,
+		Description:     fmt.Sprintf("Well kept %d %s %s, available for self-drive rentals in %s.", car.year, car.brand, car.model, car.city),
+		Images:          demoImages(car),
+		Mileage:         car.mileage,
+	})
+	if err != nil {
+		return err
+	}
+
+	approved, err := s.cars.UpdateCarStatus(s.actAs(ctx, s.users["admin"]), created.ID.String(), models.CarStatusActive, true)
+	if err != nil {
+		return err
+	}
+	s.carsByName[car.name] = *approved
+	log.Printf("Listed %s for %s", car.name, owner.Email)
+	return nil
+}
+
+// seedBooking books a demo car for a renter and takes the booking to its demo status: the
+// owner collects a cash payment to confirm it, checks the car out to start the trip, and
+// checks it in to complete it. Cancelled bookings are cancelled by the renter.
+func (s *seeder) seedBooking(ctx context.Context, demo demoBooking) error {
+	car := s.carsByName[demo.car]
+	owner := s.users[s.ownerOf(demo.car)]
+	customer := s.users[demo.customer]
+	ownerCtx, customerCtx := s.actAs(ctx, owner), s.actAs(ctx, customer)
+
+	today := time.Now().Truncate(24 * time.Hour)
+	start := today.AddDate(0, 0, demo.startInDays).Add(10 * time.Hour)
+	booking, err := s.bookings.CreateBooking(customerCtx, models.BookingRequest{
+		CustomerID: customer.ID,
+		CarID:      car.ID,
+		OwnerID:    owner.ID,
+		StartDate:  start,
+		EndDate:    start.AddDate(0, 0, demo.days),
+		Notes:      "Demo booking",
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Booked %s for %s (%s)", demo.car, customer.Email, demo.status)
+
+	switch demo.status {
+	case models.BookingStatusPending:
+		return nil
+	case models.BookingStatusCancelled:
+		_, err := s.bookings.UpdateBookingStatus(customerCtx, booking.ID.String(), models.BookingStatusCancelled)
+		return err
+	}
+
+	// Confirmed, in progress and completed bookings are paid in cash at pickup
+	_, err = s.payments.CreatePayment(customerCtx, &models.PaymentRequest{
+		BookingID:   booking.ID,
+		Amount:      booking.TotalAmount,
+		Method:      models.PaymentMethodCash,
+		Description: "Demo rental payment",
+	})
+	if err != nil {
+		return err
+	}
+	payment, err := s.payments.GetPaymentByBookingID(customerCtx, booking.ID.String())
+	if err != nil {
+		return err
+	}
+	_, err = s.bookings.CollectPayment(ownerCtx, booking.ID.String(), payment.ID.String(), owner.ID.String(), owner.Role, models.PaymentCollectionRequest{
+		Amount:        payment.Amount,
+		ReceiptNumber: "DEMO-" + strings.ToUpper(booking.ID.String()[:8]),
+	})
+	if err != nil || demo.status == models.BookingStatusConfirmed {
+		return err
+	}
+
+	odometer, fuel := car.Mileage, 100.0
+	_, err = s.bookings.RecordInspection(ownerCtx, booking.ID.String(), owner.ID.String(), owner.Role, models.InspectionKindCheckout, models.InspectionRequest{
+		OdometerKm: &odometer,
+		FuelLevel:  &fuel,
+		Notes:      "Handed over clean with a full tank",
+	})
+	if err != nil || demo.status == models.BookingStatusInProgress {
+		return err
+	}
+
+	returned := odometer + 120*demo.days
+	_, err = s.bookings.RecordInspection(ownerCtx, booking.ID.String(), owner.ID.String(), owner.Role, models.InspectionKindCheckin, models.InspectionRequest{
+		OdometerKm: &returned,
+		FuelLevel:  &fuel,
+		Notes:      "Returned on time, refuelled",
+	})
+	return err
+}
+
+// actAs returns a context acting for a demo user, as the API's auth middleware would
+func (s *seeder) actAs(ctx context.Context, user models.User) context.Context {
+	return identity.WithUser(ctx, user.ID.String(), user.Role)
+}
+
+// ownerOf returns the demo user owning a demo car
+func (s *seeder) ownerOf(carName string) string {
+	for _, car := range demoCars {
+		if car.name == carName {
+			return car.owner
+		}
+	}
+	return ""
+}
+
+// demoImages returns placeholder photos labelled with the car's name
+func demoImages(car demoCar) []string {
+	label := strings.ReplaceAll(car.name, " ", "+")
+	return []string{
+		"https://placehold.co/1200x800/png?text=" + label,
+		"https://placehold.co/1200x800/png?text=" + label + "+Interior",
+	}
+}
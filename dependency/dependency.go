@@ -0,0 +1,67 @@
+// Package dependency instruments calls to the external services CarZone depends on, such as
+// Cloudinary, S3, Razorpay and smart-lock providers. Each call gets a span of its own, so traces
+// show how much of a request was spent waiting on the service, and its duration and failures are
+// recorded in Prometheus, so slow uploads or a degrading payment gateway show up on dashboards.
+package dependency
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Names of the external services, used as the dependency label
+const (
+	Cloudinary = "cloudinary"
+	S3         = "s3"
+	Razorpay   = "razorpay"
+	SmartLock  = "smart-lock"
+	BotCheck   = "bot-check"
+)
+
+var (
+	callDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "external_dependency_call_duration_seconds",
+			Help: "Duration of calls to external services by dependency and operation",
+			// Image uploads can take several seconds, beyond the default buckets
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"dependency", "operation"},
+	)
+	errorCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dependency_errors_total",
+			Help: "Total number of failed calls to external services by dependency and operation",
+		},
+		[]string{"dependency", "operation"},
+	)
+)
+
+func init() {
+	// Register the metrics with Prometheus's default registry
+	prometheus.MustRegister(callDuration, errorCounter)
+}
+
+// Start begins a call to an operation of an external service in a span named
+// "Operation-dependency". The returned function ends the call and must be called once with
+// its error, or nil, to record the duration and any failure.
+func Start(ctx context.Context, dependency, operation string) (context.Context, func(err error)) {
+	ctx, span := otel.Tracer("Dependency").Start(ctx, operation+"-"+dependency)
+	span.SetAttributes(attribute.String("peer.service", dependency))
+	start := time.Now()
+
+	return ctx, func(err error) {
+		callDuration.WithLabelValues(dependency, operation).Observe(time.Since(start).Seconds())
+		if err != nil {
+			errorCounter.WithLabelValues(dependency, operation).Inc()
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -4,15 +4,19 @@
 package driver
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
 	"time"
 
-	// PostgreSQL driver - imported for side effects (driver registration)
-	_ "github.com/lib/pq"
+	"github.com/PrateekKumar15/CarZone/secrets"
+
+	// PostgreSQL driver
+	"github.com/lib/pq"
 )
 
 // db is a package-level variable that holds the database connection pool.
@@ -29,7 +33,7 @@ func InitDB() {
 	host := os.Getenv("DB_HOST")
 	portStr := os.Getenv("DB_PORT")
 	user := os.Getenv("DB_USER")
-	password := os.Getenv("DB_PASSWORD")
+	password := secrets.Get("DB_PASSWORD")
 	dbname := os.Getenv("DB_NAME")
 	sslmode := os.Getenv("DB_SSLMODE")
 
@@ -61,9 +65,10 @@ func InitDB() {
 		log.Fatal("DB_NAME environment variable is required")
 	}
 
-	// Construct PostgreSQL connection string
-	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		host, port, user, password, dbname, sslmode)
+	// Connection string without the password; the connector adds the current one
+	// for every new connection so a rotated DB_PASSWORD is picked up without a restart
+	connStr := fmt.Sprintf("host=%s port=%d user=%s dbname=%s sslmode=%s",
+		host, port, user, dbname, sslmode)
 
 	log.Printf("Connecting to database at %s:%d/%s...", host, port, dbname)
 
@@ -72,11 +77,8 @@ func InitDB() {
 	time.Sleep(5 * time.Second)
 
 	// Open database connection pool
-	// sql.Open() doesn't actually connect - it just prepares the database connection pool
-	db, err = sql.Open("postgres", connStr)
-	if err != nil {
-		log.Fatalf("Failed to open database connection: %v", err)
-	}
+	// sql.OpenDB() doesn't actually connect - it just prepares the database connection pool
+	db = sql.OpenDB(rotatingConnector{connStr: connStr})
 
 	// Configure connection pool settings for optimal performance
 	db.SetMaxOpenConns(25)                 // Maximum number of open connections
@@ -94,6 +96,44 @@ func InitDB() {
 		25, 10)
 }
 
+// rotatingConnector opens PostgreSQL connections with the DB_PASSWORD secret that is
+// current at connect time. Existing connections keep working and are replaced as they
+// reach ConnMaxLifetime, so password rotation needs no restart.
+type rotatingConnector struct {
+	connStr string
+}
+
+// Connect opens a new connection with the current password. The connection sets the
+// row-level security session variables of each request before running its statements.
+func (c rotatingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	connector, err := pq.NewConnector(c.connStr + " password='" + escapeConnValue(secrets.Get("DB_PASSWORD")) + "'")
+	if err != nil {
+		return nil, err
+	}
+	conn, err := connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionConn{Conn: conn}, nil
+}
+
+// Driver returns the underlying PostgreSQL driver
+func (c rotatingConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// escapeConnValue escapes a value for use inside single quotes in a connection string
+func escapeConnValue(value string) string {
+	escaped := make([]rune, 0, len(value))
+	for _, r := range value {
+		if r == '\\' || r == '\'' {
+			escaped = append(escaped, '\\')
+		}
+		escaped = append(escaped, r)
+	}
+	return string(escaped)
+}
+
 // GetDB returns the singleton database connection pool instance.
 // This function provides access to the database connection throughout the application.
 // It returns the same *sql.DB instance that was initialized by InitDB().
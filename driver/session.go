@@ -0,0 +1,215 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/PrateekKumar15/CarZone/identity"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"github.com/google/uuid"
+)
+
+// setSessionQuery applies a request's session variables, read by the row-level security
+// policies in schema.sql. is_local is false so the values outlive the statement's transaction.
+const setSessionQuery = `SELECT set_config('app.operator_id', $1, false),
+       set_config('app.user_id', $2, false),
+       set_config('app.role', $3, false)`
+
+// sessionVars are the values of the session variables for one request. Empty values leave the
+// policies unrestricted, as for background jobs and public requests.
+type sessionVars struct {
+	operatorID string
+	userID     string
+	role       string
+}
+
+// sessionVarsFrom returns the session variables of the operator and user a context acts for.
+// A user whose ID cannot be read gets the nil UUID, which matches no rows, as in identity.Scope.
+func sessionVarsFrom(ctx context.Context) sessionVars {
+	var vars sessionVars
+	if operatorID := tenant.Scope(ctx); operatorID != nil {
+		vars.operatorID = operatorID.String()
+	}
+	if user, ok := identity.FromContext(ctx); ok {
+		vars.role = user.Role
+		vars.userID = uuid.Nil.String()
+		if userID, err := uuid.Parse(user.ID); err == nil {
+			vars.userID = userID.String()
+		}
+	}
+	return vars
+}
+
+// sessionConn sets the session variables of the request a statement runs for before running
+// it, so the row-level security policies hold even for a query missing its own tenant or
+// ownership condition. The variables are only set when they differ from the ones the pooled
+// connection last ran with.
+type sessionConn struct {
+	driver.Conn
+	applied *sessionVars // Unknown when nil, e.g. after a rolled back transaction
+}
+
+// apply sets the session variables of ctx on the connection
+func (c *sessionConn) apply(ctx context.Context) error {
+	vars := sessionVarsFrom(ctx)
+	if c.applied != nil && *c.applied == vars {
+		return nil
+	}
+
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return errors.New("database driver cannot set session variables")
+	}
+	c.applied = nil
+	_, err := execer.ExecContext(ctx, setSessionQuery, []driver.NamedValue{
+		{Ordinal: 1, Value: vars.operatorID},
+		{Ordinal: 2, Value: vars.userID},
+		{Ordinal: 3, Value: vars.role},
+	})
+	if err != nil {
+		return err
+	}
+	c.applied = &vars
+	return nil
+}
+
+// QueryContext runs a query with the session variables of ctx
+func (c *sessionConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := c.apply(ctx); err != nil {
+		return nil, err
+	}
+	return queryer.QueryContext(ctx, query, args)
+}
+
+// ExecContext runs a statement with the session variables of ctx
+func (c *sessionConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := c.apply(ctx); err != nil {
+		return nil, err
+	}
+	return execer.ExecContext(ctx, query, args)
+}
+
+// PrepareContext prepares a statement that sets the session variables of the context it runs with
+func (c *sessionConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sessionStmt{Stmt: stmt, conn: c}, nil
+}
+
+// BeginTx starts a transaction with the session variables of ctx
+func (c *sessionConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	beginner, ok := c.Conn.(driver.ConnBeginTx)
+	if !ok {
+		return nil, errors.New("database driver does not support transaction options")
+	}
+	if err := c.apply(ctx); err != nil {
+		return nil, err
+	}
+	tx, err := beginner.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionTx{Tx: tx, conn: c}, nil
+}
+
+// Ping checks the connection is alive
+func (c *sessionConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession is called before the pool hands the connection to another caller
+func (c *sessionConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid reports whether the connection can be returned to the pool
+func (c *sessionConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// sessionTx forgets the applied session variables when rolled back, since Postgres rolls back
+// set_config calls made inside the transaction along with it
+type sessionTx struct {
+	driver.Tx
+	conn *sessionConn
+}
+
+// Rollback aborts the transaction
+func (t *sessionTx) Rollback() error {
+	t.conn.applied = nil
+	return t.Tx.Rollback()
+}
+
+// sessionStmt is a prepared statement that runs with the session variables of its context
+type sessionStmt struct {
+	driver.Stmt
+	conn *sessionConn
+}
+
+// QueryContext runs the prepared query with the session variables of ctx
+func (s *sessionStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if err := s.conn.apply(ctx); err != nil {
+		return nil, err
+	}
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+	values, err := namedValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.Query(values)
+}
+
+// ExecContext runs the prepared statement with the session variables of ctx
+func (s *sessionStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if err := s.conn.apply(ctx); err != nil {
+		return nil, err
+	}
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+	values, err := namedValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.Exec(values)
+}
+
+// namedValues converts positional arguments for statements without context support
+func namedValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("database driver does not support named parameters")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
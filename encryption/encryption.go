@@ -0,0 +1,260 @@
+// Package encryption provides application-level encryption for sensitive columns
+// such as bank account numbers, phone numbers and driving licence numbers. Values
+// are sealed with AES-256-GCM before they reach the database, so a leaked dump
+// never exposes them in plaintext.
+//
+// Every encrypted value carries the ID of the key that sealed it
+// ("enc:<key id>:<base64 nonce||ciphertext>"). The cipher holds a key ring: new
+// values are always sealed with the active key while values sealed with older keys
+// still decrypt, so keys can be rotated without downtime and old rows re-encrypted
+// in the background.
+//
+// Encrypted values cannot be searched, so columns that must be found by exact value
+// (such as phone numbers) also store a blind index: a keyed HMAC of the value that
+// is the same every time it is computed.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/secrets"
+)
+
+// valuePrefix marks a column value as encrypted by this package.
+// Values without it are treated as legacy plaintext and returned unchanged by Decrypt.
+const valuePrefix = "enc:"
+
+// Cipher encrypts and decrypts short string values with a ring of AES-256 keys
+type Cipher struct {
+	activeKeyID string
+	keys        map[string]cipher.AEAD
+	indexKey    []byte // HMAC key for blind indexes; nil disables them
+}
+
+// NewCipher creates a cipher from a set of 32-byte keys indexed by key ID.
+// activeKeyID selects the key used for new values; the others are kept for decryption.
+func NewCipher(activeKeyID string, keys map[string][]byte) (*Cipher, error) {
+	if _, ok := keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active encryption key %q is not in the key ring", activeKeyID)
+	}
+
+	c := &Cipher{activeKeyID: activeKeyID, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid encryption key ID %q", id)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("encryption key %q must be 32 bytes", id)
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		c.keys[id] = aead
+	}
+
+	return c, nil
+}
+
+// NewCipherFromEnv creates a cipher from the configured secrets (see package secrets).
+// Keys are read, in order of preference, from:
+//   - ENCRYPTION_KEYS_FILE: path to a file holding a key ring, e.g. written by a KMS or secrets agent
+//   - ENCRYPTION_KEYS: key ring of the form "v2:<base64 key>,v1:<base64 key>"; the first key is active
+//   - ENCRYPTION_KEY: a single base64 key, used with key ID "v1"
+//
+// BLIND_INDEX_KEY (base64, at least 32 bytes) enables blind indexes. It is kept apart
+// from the key ring because indexes must not change when encryption keys rotate.
+func NewCipherFromEnv() (*Cipher, error) {
+	c, err := newKeyRingCipherFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	if encoded := secrets.Get("BLIND_INDEX_KEY"); encoded != "" {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) < 32 {
+			return nil, errors.New("BLIND_INDEX_KEY must be a base64 encoded key of at least 32 bytes")
+		}
+		c.indexKey = key
+	}
+
+	return c, nil
+}
+
+// newKeyRingCipherFromEnv creates a cipher from whichever key ring source is configured
+func newKeyRingCipherFromEnv() (*Cipher, error) {
+	if path := os.Getenv("ENCRYPTION_KEYS_FILE"); path != "" {
+		ring, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ENCRYPTION_KEYS_FILE: %v", err)
+		}
+		return newCipherFromKeyRing(string(ring))
+	}
+
+	if ring := secrets.Get("ENCRYPTION_KEYS"); ring != "" {
+		return newCipherFromKeyRing(ring)
+	}
+
+	encoded := secrets.Get("ENCRYPTION_KEY")
+	if encoded == "" {
+		return nil, errors.New("no encryption key configured: set ENCRYPTION_KEYS or ENCRYPTION_KEY")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("ENCRYPTION_KEY must be base64 encoded")
+	}
+
+	return NewCipher("v1", map[string][]byte{"v1": key})
+}
+
+// newCipherFromKeyRing parses a comma or newline separated "id:base64key" list.
+// The first entry is the active key.
+func newCipherFromKeyRing(ring string) (*Cipher, error) {
+	keys := make(map[string][]byte)
+	var activeKeyID string
+
+	entries := strings.FieldsFunc(ring, func(r rune) bool { return r == ',' || r == '\n' })
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, errors.New("encryption key ring entries must look like id:base64key")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q must be base64 encoded", id)
+		}
+		if _, exists := keys[id]; exists {
+			return nil, fmt.Errorf("duplicate encryption key ID %q", id)
+		}
+
+		keys[id] = key
+		if activeKeyID == "" {
+			activeKeyID = id
+		}
+	}
+
+	if activeKeyID == "" {
+		return nil, errors.New("encryption key ring is empty")
+	}
+
+	return NewCipher(activeKeyID, keys)
+}
+
+// ActiveKeyID returns the ID of the key used for new values
+func (c *Cipher) ActiveKeyID() string {
+	return c.activeKeyID
+}
+
+// Encrypt seals plaintext with the active key and returns "enc:<key id>:<base64(nonce || ciphertext)>".
+// The empty string is returned unchanged so optional columns stay empty.
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	aead := c.keys[c.activeKeyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return valuePrefix + c.activeKeyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value produced by Encrypt with whichever key sealed it.
+// Values that were never encrypted (legacy plaintext rows) are returned as they are.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	keyID, encoded, ok := parseValue(value)
+	if !ok {
+		return value, nil
+	}
+
+	aead, found := c.keys[keyID]
+	if !found {
+		return "", fmt.Errorf("value was encrypted with unknown key %q", keyID)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", errors.New("invalid encrypted value")
+	}
+
+	nonceSize := aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", errors.New("invalid encrypted value")
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", errors.New("failed to decrypt value")
+	}
+
+	return string(plaintext), nil
+}
+
+// BlindIndex returns a hex HMAC-SHA256 of value for exact-match lookups of an encrypted column.
+// The empty string is returned for empty values and when no BLIND_INDEX_KEY is configured.
+func (c *Cipher) BlindIndex(value string) string {
+	if value == "" || c.indexKey == nil {
+		return ""
+	}
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NeedsRotation reports whether a stored value should be re-encrypted, i.e. it is
+// legacy plaintext or was sealed with a key other than the active one.
+func (c *Cipher) NeedsRotation(value string) bool {
+	if value == "" {
+		return false
+	}
+	keyID, _, ok := parseValue(value)
+	return !ok || keyID != c.activeKeyID
+}
+
+// Rotate re-encrypts a stored value with the active key.
+// Values that are already current are returned unchanged.
+func (c *Cipher) Rotate(value string) (string, error) {
+	if !c.NeedsRotation(value) {
+		return value, nil
+	}
+
+	plaintext, err := c.Decrypt(value)
+	if err != nil {
+		return "", err
+	}
+
+	return c.Encrypt(plaintext)
+}
+
+// parseValue splits an encrypted value into key ID and payload
+func parseValue(value string) (keyID, encoded string, ok bool) {
+	if !strings.HasPrefix(value, valuePrefix) {
+		return "", "", false
+	}
+	return strings.Cut(strings.TrimPrefix(value, valuePrefix), ":")
+}
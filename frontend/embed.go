@@ -0,0 +1,22 @@
+//go:build embedfrontend
+
+package frontend
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// dist is the SPA build copied into frontend/dist before compiling with -tags embedfrontend
+//
+//go:embed all:dist
+var dist embed.FS
+
+// embedded returns the build compiled into the binary
+func embedded() fs.FS {
+	files, err := fs.Sub(dist, "dist")
+	if err != nil {
+		return nil
+	}
+	return files
+}
@@ -0,0 +1,148 @@
+// Package frontend serves a single-page application build next to the API, so small
+// deployments can ship one binary. The build is compiled in from frontend/dist with
+// -tags embedfrontend, or read from FRONTEND_DIR at startup; without either, the
+// binary serves the API alone.
+//
+// Files under the bundler's hashed asset directories are cached for a year. Everything
+// else, index.html included, is revalidated on each load so a deploy takes effect at
+// once. Paths without a file extension that match no file are client-side routes and
+// get index.html.
+package frontend
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+const indexFile = "index.html"
+
+// immutableDirs hold bundler output (Vite's assets/, Create React App's static/) whose
+// file names change with their content
+var immutableDirs = []string{"assets/", "static/"}
+
+// file is one file of the build, held in memory with its validator
+type file struct {
+	name    string
+	content []byte
+	etag    string
+}
+
+// Handler serves the files of a frontend build
+type Handler struct {
+	files map[string]*file
+	index *file
+}
+
+// FromEnv returns the frontend to serve: the FRONTEND_DIR directory when set, otherwise
+// the embedded build. It returns nil when the binary has no embedded build and
+// FRONTEND_DIR is unset.
+func FromEnv() (*Handler, error) {
+	files, source := embedded(), "embedded build"
+	if dir := os.Getenv("FRONTEND_DIR"); dir != "" {
+		files, source = os.DirFS(dir), dir
+	}
+	if files == nil {
+		return nil, nil
+	}
+
+	h, err := New(files)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	log.Printf("Serving frontend from %s (%d files)", source, len(h.files))
+	return h, nil
+}
+
+// New loads a build into memory. The build must contain an index.html at its root.
+func New(files fs.FS) (*Handler, error) {
+	h := &Handler{files: make(map[string]*file)}
+	err := fs.WalkDir(files, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Skip directories and dotfiles such as the .gitignore that keeps dist/ in the repo
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+
+		content, err := fs.ReadFile(files, name)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		h.files[name] = &file{name: name, content: content, etag: `"` + hex.EncodeToString(sum[:16]) + `"`}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if h.index = h.files[indexFile]; h.index == nil {
+		return nil, errors.New("build has no " + indexFile)
+	}
+	return h, nil
+}
+
+// ServeHTTP serves a file of the build, or index.html for a client-side route
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = indexFile
+	}
+
+	f, ok := h.files[name]
+	switch {
+	case ok && isImmutable(name):
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	case ok:
+		w.Header().Set("Cache-Control", "no-cache")
+	case path.Ext(name) == "":
+		f = h.index
+		w.Header().Set("Cache-Control", "no-cache")
+	default:
+		// A missing asset must not be answered with index.html, or the browser would
+		// try to run the page as a script or stylesheet
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("ETag", f.etag)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, f.name, time.Time{}, bytes.NewReader(f.content))
+}
+
+// isImmutable reports whether a file lives in one of the hashed asset directories
+func isImmutable(name string) bool {
+	for _, dir := range immutableDirs {
+		if strings.HasPrefix(name, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNavigation reports whether a request is a browser loading a page of the app rather
+// than an API call or an asset fetch: a GET or HEAD for a path without a file extension
+// whose Sec-Fetch-Dest is document or, from browsers that do not send it, that accepts HTML.
+func IsNavigation(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if path.Ext(r.URL.Path) != "" {
+		return false
+	}
+	if dest := r.Header.Get("Sec-Fetch-Dest"); dest != "" {
+		return dest == "document"
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
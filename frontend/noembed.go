@@ -0,0 +1,10 @@
+//go:build !embedfrontend
+
+package frontend
+
+import "io/fs"
+
+// embedded returns nil: without the embedfrontend tag no build is compiled in
+func embedded() fs.FS {
+	return nil
+}
@@ -0,0 +1,57 @@
+package accounting
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"go.opentelemetry.io/otel"
+)
+
+// AccountingHandler handles HTTP requests for the accounting export
+type AccountingHandler struct {
+	accountingService service.AccountingServiceInterface
+}
+
+// NewAccountingHandler creates a new accounting export handler
+func NewAccountingHandler(accountingService service.AccountingServiceInterface) *AccountingHandler {
+	return &AccountingHandler{
+		accountingService: accountingService,
+	}
+}
+
+// Export handles requests for the ledger of a period as a Tally or QuickBooks import file
+// (?from=YYYY-MM-DD&to=YYYY-MM-DD&format=tally|iif|csv) (admin only)
+func (h *AccountingHandler) Export(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AccountingHandler")
+	ctx, span := tracer.Start(r.Context(), "Export-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	params := r.URL.Query()
+	req, err := models.ParseAccountingExportRequest(params.Get("from"), params.Get("to"), params.Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, err := h.accountingService.Export(ctx, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Exports hold customers' names and amounts, so they are never cached
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+file.Name+`"`)
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(file.Body); err != nil {
+		log.Println("Error writing accounting export:", err)
+	}
+}
@@ -0,0 +1,173 @@
+package adjustment
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// AdjustmentHandler handles HTTP requests for credit notes and manual charges on bookings
+type AdjustmentHandler struct {
+	adjustmentService service.AdjustmentServiceInterface
+}
+
+// NewAdjustmentHandler creates a new adjustment handler
+func NewAdjustmentHandler(adjustmentService service.AdjustmentServiceInterface) *AdjustmentHandler {
+	return &AdjustmentHandler{
+		adjustmentService: adjustmentService,
+	}
+}
+
+// CreateAdjustment handles admin requests to raise a credit note or charge against a booking
+func (h *AdjustmentHandler) CreateAdjustment(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AdjustmentHandler")
+	ctx, span := tracer.Start(r.Context(), "CreateAdjustment-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.BookingAdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	adjustment, err := h.adjustmentService.CreateAdjustment(ctx, mux.Vars(r)["id"], userID, req)
+	if err != nil {
+		writeAdjustmentError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, adjustment, adjustmentLinks(*adjustment))
+}
+
+// ReviewAdjustment handles a second admin's approval or rejection of a pending adjustment
+func (h *AdjustmentHandler) ReviewAdjustment(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AdjustmentHandler")
+	ctx, span := tracer.Start(r.Context(), "ReviewAdjustment-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.AdjustmentReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	adjustment, err := h.adjustmentService.ReviewAdjustment(ctx, mux.Vars(r)["id"], userID, req)
+	if err != nil {
+		writeAdjustmentError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, adjustment, adjustmentLinks(*adjustment))
+}
+
+// ListAdjustments handles admin requests for adjustments, optionally narrowed with ?status=
+func (h *AdjustmentHandler) ListAdjustments(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AdjustmentHandler")
+	ctx, span := tracer.Start(r.Context(), "ListAdjustments-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	filter := models.AdjustmentFilter{Status: models.AdjustmentStatus(r.URL.Query().Get("status"))}
+	adjustments, err := h.adjustmentService.ListAdjustments(ctx, filter)
+	if err != nil {
+		writeAdjustmentError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, adjustments, nil)
+}
+
+// GetBookingAdjustments handles requests for the adjustments of a booking
+func (h *AdjustmentHandler) GetBookingAdjustments(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AdjustmentHandler")
+	ctx, span := tracer.Start(r.Context(), "GetBookingAdjustments-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	adjustments, err := h.adjustmentService.GetBookingAdjustments(ctx, id, userID, middleware.RoleFromContext(ctx))
+	if err != nil {
+		writeAdjustmentError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, adjustments, response.Links{
+		"booking":   "/bookings/" + id,
+		"documents": "/bookings/" + id + "/documents",
+	})
+}
+
+// writeAdjustmentError maps adjustment service errors to HTTP status codes
+func writeAdjustmentError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no booking found") || strings.Contains(err.Error(), "no adjustment found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "different admin"):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case strings.Contains(err.Error(), "no longer pending") || strings.Contains(err.Error(), "cannot be adjusted"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must") ||
+		strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "cannot be greater"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case strings.Contains(err.Error(), "Razorpay") || strings.Contains(err.Error(), "razorpay"):
+		http.Error(w, err.Error(), http.StatusBadGateway)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// adjustmentLinks returns the related-resource links of an adjustment
+func adjustmentLinks(adjustment models.BookingAdjustment) response.Links {
+	links := response.Links{
+		"booking":     "/bookings/" + adjustment.BookingID.String(),
+		"adjustments": "/bookings/" + adjustment.BookingID.String() + "/adjustments",
+		"documents":   "/bookings/" + adjustment.BookingID.String() + "/documents",
+	}
+	if adjustment.PaymentID != nil {
+		links["payment"] = "/payments/" + adjustment.PaymentID.String()
+	}
+	return links
+}
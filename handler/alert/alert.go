@@ -0,0 +1,136 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// AlertHandler handles HTTP requests for price-drop and availability alerts
+type AlertHandler struct {
+	alertService service.AlertServiceInterface
+}
+
+// NewAlertHandler creates a new alert handler
+func NewAlertHandler(alertService service.AlertServiceInterface) *AlertHandler {
+	return &AlertHandler{
+		alertService: alertService,
+	}
+}
+
+// Subscribe handles requests to watch a car for price drops and opened-up dates
+func (h *AlertHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AlertHandler")
+	ctx, span := tracer.Start(r.Context(), "Subscribe-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CarAlertSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := models.ValidateCarAlertSubscriptionRequest(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	subscription, err := h.alertService.Subscribe(ctx, userID, mux.Vars(r)["id"], req)
+	if err != nil {
+		writeAlertError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, subscription, subscriptionLinks(*subscription))
+}
+
+// GetSubscriptions handles requests to list the user's car alerts
+func (h *AlertHandler) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AlertHandler")
+	ctx, span := tracer.Start(r.Context(), "GetSubscriptions-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	subscriptions, err := h.alertService.GetSubscriptions(ctx, userID)
+	if err != nil {
+		writeAlertError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, subscriptions, response.Links{
+		"self": "/users/me/car-alerts",
+	})
+}
+
+// Unsubscribe handles requests to stop a car alert
+func (h *AlertHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AlertHandler")
+	ctx, span := tracer.Start(r.Context(), "Unsubscribe-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.alertService.Unsubscribe(ctx, userID, mux.Vars(r)["id"]); err != nil {
+		writeAlertError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeAlertError maps service errors to HTTP status codes
+func writeAlertError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no car found"),
+		strings.Contains(err.Error(), "no alert subscription found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// subscriptionLinks returns the related-resource links of a subscription
+func subscriptionLinks(subscription models.CarAlertSubscription) response.Links {
+	return response.Links{
+		"self":   "/users/me/car-alerts/" + subscription.ID.String(),
+		"car":    "/cars/" + subscription.CarID.String(),
+		"alerts": "/users/me/car-alerts",
+	}
+}
@@ -0,0 +1,147 @@
+package analytics
+
+import (
+	"net/http"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"go.opentelemetry.io/otel"
+)
+
+// AnalyticsHandler handles HTTP requests for the admin dashboard data API
+type AnalyticsHandler struct {
+	analyticsService service.AnalyticsServiceInterface
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(analyticsService service.AnalyticsServiceInterface) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// GetTimeSeries handles requests for a bucketed metric, e.g. ?metric=bookings&interval=day&from=2024-01-01&to=2024-02-01
+func (h *AnalyticsHandler) GetTimeSeries(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AnalyticsHandler")
+	ctx, span := tracer.Start(r.Context(), "GetTimeSeries-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	params := r.URL.Query()
+	query, err := models.ParseTimeSeriesQuery(params.Get("metric"), params.Get("interval"), params.Get("from"), params.Get("to"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	series, err := h.analyticsService.GetTimeSeries(ctx, query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, series, response.Links{
+		"self": r.URL.RequestURI(),
+	})
+}
+
+// GetOwnerEarnings handles requests for the top earning owners, e.g. ?from=2024-01-01&to=2024-01-31&limit=20
+func (h *AnalyticsHandler) GetOwnerEarnings(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AnalyticsHandler")
+	ctx, span := tracer.Start(r.Context(), "GetOwnerEarnings-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	params := r.URL.Query()
+	dashboardRange, err := models.ParseDashboardRange(params.Get("from"), params.Get("to"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, err := models.ParsePageRequest(params.Get("limit"), "")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	earnings, err := h.analyticsService.GetOwnerEarnings(ctx, dashboardRange, page.Limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, earnings, response.Links{
+		"self": r.URL.RequestURI(),
+	})
+}
+
+// GetCarUtilization handles requests for the most booked cars, e.g. ?from=2024-01-01&to=2024-01-31&limit=20
+func (h *AnalyticsHandler) GetCarUtilization(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AnalyticsHandler")
+	ctx, span := tracer.Start(r.Context(), "GetCarUtilization-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	params := r.URL.Query()
+	dashboardRange, err := models.ParseDashboardRange(params.Get("from"), params.Get("to"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, err := models.ParsePageRequest(params.Get("limit"), "")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	utilization, err := h.analyticsService.GetCarUtilization(ctx, dashboardRange, page.Limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, utilization, response.Links{
+		"self": r.URL.RequestURI(),
+	})
+}
+
+// GetBookingFunnel handles requests for booking conversion, e.g. ?from=2024-01-01&to=2024-01-31
+func (h *AnalyticsHandler) GetBookingFunnel(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("AnalyticsHandler")
+	ctx, span := tracer.Start(r.Context(), "GetBookingFunnel-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	dashboardRange, err := models.ParseDashboardRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	funnel, err := h.analyticsService.GetBookingFunnel(ctx, dashboardRange)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, funnel, response.Links{
+		"self": r.URL.RequestURI(),
+	})
+}
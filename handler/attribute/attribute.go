@@ -0,0 +1,138 @@
+package attribute
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// CarAttributeHandler handles HTTP requests for the custom car attributes of an operator
+type CarAttributeHandler struct {
+	attributeService service.CarAttributeServiceInterface
+}
+
+// NewCarAttributeHandler creates a new car attribute handler
+func NewCarAttributeHandler(attributeService service.CarAttributeServiceInterface) *CarAttributeHandler {
+	return &CarAttributeHandler{
+		attributeService: attributeService,
+	}
+}
+
+// GetCarAttributes handles requests to list the attributes car forms render and listings can
+// be filtered by
+func (h *CarAttributeHandler) GetCarAttributes(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("CarAttributeHandler")
+	ctx, span := tracer.Start(r.Context(), "GetCarAttributes-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	attributes, err := h.attributeService.ListCarAttributes(ctx)
+	if err != nil {
+		writeAttributeError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, attributes, response.Links{
+		"cars": "/cars",
+	})
+}
+
+// CreateCarAttribute handles requests to define an attribute (admin only)
+func (h *CarAttributeHandler) CreateCarAttribute(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("CarAttributeHandler")
+	ctx, span := tracer.Start(r.Context(), "CreateCarAttribute-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.CarAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	attribute, err := h.attributeService.CreateCarAttribute(ctx, req)
+	if err != nil {
+		writeAttributeError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, attribute, response.Links{
+		"car_attributes": "/car-attributes",
+	})
+}
+
+// UpdateCarAttribute handles requests to replace an attribute's definition (admin only)
+func (h *CarAttributeHandler) UpdateCarAttribute(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("CarAttributeHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateCarAttribute-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.CarAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	attribute, err := h.attributeService.UpdateCarAttribute(ctx, mux.Vars(r)["key"], req)
+	if err != nil {
+		writeAttributeError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, attribute, response.Links{
+		"car_attributes": "/car-attributes",
+	})
+}
+
+// DeleteCarAttribute handles requests to remove an attribute and its values (admin only)
+func (h *CarAttributeHandler) DeleteCarAttribute(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("CarAttributeHandler")
+	ctx, span := tracer.Start(r.Context(), "DeleteCarAttribute-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	if err := h.attributeService.DeleteCarAttribute(ctx, mux.Vars(r)["key"]); err != nil {
+		writeAttributeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeAttributeError maps car attribute service errors to HTTP status codes
+func writeAttributeError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no attribute found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must") ||
+		strings.Contains(err.Error(), "only allowed") || strings.Contains(err.Error(), "cannot be changed"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -5,22 +5,32 @@ import (
 	"errors"
 	"log"
 	"net/http"
-	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/PrateekKumar15/CarZone/middleware"
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/secrets"
 	"github.com/PrateekKumar15/CarZone/service"
 	jwt "github.com/dgrijalva/jwt-go"
 	"go.opentelemetry.io/otel"
 )
 
 type AuthHandler struct {
-	service service.AuthServiceInterface
+	service              service.AuthServiceInterface
+	securityMonitor      service.SecurityMonitorInterface      // Flags logins from new devices
+	passwordResetService service.PasswordResetServiceInterface // E-mails reset links for forgotten passwords
+	refreshTokenService  service.RefreshTokenServiceInterface  // Keeps sessions going past the access token's expiry
+	twoFactorService     service.TwoFactorServiceInterface     // Asks users who enabled it for a TOTP code at login
+	botCheck             service.BotCheckInterface             // Keeps bots from registering and requesting reset e-mails
+	secureCookies        bool                                  // Mark the auth cookie Secure so browsers only send it over HTTPS
 }
 
 // NewCarHandler creates a new CarHandler with the provided service
-func NewAuthHandler(service service.AuthServiceInterface) *AuthHandler {
-	return &AuthHandler{service: service}
+func NewAuthHandler(service service.AuthServiceInterface, securityMonitor service.SecurityMonitorInterface, passwordResetService service.PasswordResetServiceInterface, refreshTokenService service.RefreshTokenServiceInterface, twoFactorService service.TwoFactorServiceInterface, botCheck service.BotCheckInterface, secureCookies bool) *AuthHandler {
+	return &AuthHandler{service: service, securityMonitor: securityMonitor, passwordResetService: passwordResetService, refreshTokenService: refreshTokenService, twoFactorService: twoFactorService, botCheck: botCheck, secureCookies: secureCookies}
 }
 
 func (h *AuthHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
@@ -38,41 +48,74 @@ func (h *AuthHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
 	// Use the login service to authenticate user
 	user, err := h.service.LoginUser(ctx, credentials)
 	if err != nil {
-		log.Println("Error logging in user:", err)
+		log.Printf("Error logging in user from %s: %v", middleware.ClientIPFromContext(ctx), err)
+		if writeLoginThrottled(w, err) {
+			return
+		}
 		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
 		return
 	}
 
-	tokenString, err := GenerateTokenAndSetCookie(w, credentials.Email)
+	// Users with two-factor authentication also need a code from their app or a backup code
+	if err := h.twoFactorService.VerifyLogin(ctx, user, credentials.TwoFactorCode); err != nil {
+		if strings.Contains(err.Error(), "two-factor code") {
+			log.Printf("Refused second factor of user %s from %s: %v", user.ID, middleware.ClientIPFromContext(ctx), err)
+			// A missing code is the normal first half of a two-factor login; wrong codes are guesses
+			if !strings.Contains(err.Error(), "is required") && writeLoginThrottled(w, h.service.RecordFailedLogin(ctx, credentials.Email)) {
+				return
+			}
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		log.Println("Error checking two-factor code:", err)
+		http.Error(w, "Could not log in, please try again", http.StatusInternalServerError)
+		return
+	}
+	h.service.ClearFailedLogins(ctx, credentials.Email)
+
+	tokenString, err := GenerateTokenAndSetCookie(w, user, h.secureCookies)
 	if err != nil {
 		log.Println("Error generating token:", err)
 		http.Error(w, "Error generating token", http.StatusInternalServerError)
 		return
 	}
 
-	response := map[string]interface{}{
-		"user":    user,
-		"token":   tokenString,
-		"message": "Login successful",
+	refreshToken, err := h.refreshTokenService.IssueRefreshToken(ctx, user)
+	if err != nil {
+		log.Println("Error issuing refresh token:", err)
+		http.Error(w, "Error generating token", http.StatusInternalServerError)
+		return
+	}
+	setRefreshCookie(w, refreshToken, h.secureCookies)
+
+	h.securityMonitor.RecordLogin(ctx, user, middleware.ClientIPFromContext(ctx), r.UserAgent())
+
+	data := map[string]interface{}{
+		"user":          user,
+		"token":         tokenString,
+		"expires_in":    int(middleware.AccessTokenTTL().Seconds()),
+		"refresh_token": refreshToken.Token,
+		"message":       "Login successful",
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	response.Resource(w, r, http.StatusOK, data, response.Links{"refresh": "/auth/refresh", "logout": "/auth/logout"})
 }
 
-func GenerateTokenAndSetCookie(w http.ResponseWriter, email string) (string, error) {
-	// Create the JWT claims, which includes the username and expiry time
-	secretKey := os.Getenv("SECRET_KEY")
-	expirationTime := time.Now().Add(24 * time.Hour)
-	claims := &jwt.StandardClaims{
-		ExpiresAt: expirationTime.Unix(),
-		IssuedAt:  time.Now().Unix(),
-		Issuer:    "CarZone",
-		Subject:   email,
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(secretKey))
+// writeLoginThrottled answers 429 with a Retry-After header when err is a lockout after too many
+// failed logins. It reports whether it wrote the response.
+func writeLoginThrottled(w http.ResponseWriter, err error) bool {
+	var throttled *models.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		return false
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(throttled.RetryAfterSeconds()))
+	http.Error(w, throttled.Error(), http.StatusTooManyRequests)
+	return true
+}
+
+func GenerateTokenAndSetCookie(w http.ResponseWriter, user models.User, secure bool) (string, error) {
+	// The JWT carries the user's identity, role and expiry time
+	signedToken, err := middleware.IssueToken(user)
 	if err != nil {
 		return "", err
 	}
@@ -82,10 +125,10 @@ func GenerateTokenAndSetCookie(w http.ResponseWriter, email string) (string, err
 		Name:     "auth_token",
 		Value:    signedToken,
 		Path:     "/",
-		HttpOnly: true,  // Prevents JavaScript access (XSS protection)
-		Secure:   false, // Set to true in production with HTTPS
+		HttpOnly: true,   // Prevents JavaScript access (XSS protection)
+		Secure:   secure, // Only sent over HTTPS when TLS is enabled
 		SameSite: http.SameSiteLaxMode,
-		MaxAge:   24 * 60 * 60, // 24 hours in seconds
+		MaxAge:   int(middleware.AccessTokenTTL().Seconds()), // Expires with the token
 	})
 
 	// Set token in header for easy access
@@ -106,7 +149,7 @@ func ValidateToken(tokenString string) (string, error) {
 		tokenString = tokenString[7:]
 	}
 
-	secretKey := os.Getenv("SECRET_KEY")
+	secretKey := secrets.Get("SECRET_KEY")
 	token, err := jwt.ParseWithClaims(tokenString, &jwt.StandardClaims{}, func(token *jwt.Token) (interface{}, error) {
 		// Validate signing method
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -149,9 +192,30 @@ func (h *AuthHandler) RegisterHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Only owners and renters sign up here; admins are created with carzone-admin
+	if err := models.ValidateSignupRole(userReq.Role); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !h.passBotCheck(ctx, w, userReq.CaptchaToken) {
+		return
+	}
+
 	// Use the registration service to create a new user
 	if err := h.service.RegisterUser(ctx, userReq); err != nil {
-		log.Println("Error registering user:", err)
+		log.Printf("Error registering user from %s: %v", middleware.ClientIPFromContext(ctx), err)
+		// Clients branch on the code to ask for a different address
+		var rejected *models.EmailRejectedError
+		if errors.As(err, &rejected) {
+			response.JSON(w, http.StatusUnprocessableEntity, response.Envelope{Data: rejected})
+			return
+		}
+		// The e-mail address or phone number belongs to another account
+		if strings.Contains(err.Error(), "already exists") {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -171,36 +235,63 @@ func (h *AuthHandler) RegisterHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate token and set cookie/headers
-	tokenString, err := GenerateTokenAndSetCookie(w, userReq.Email)
+	tokenString, err := GenerateTokenAndSetCookie(w, user, h.secureCookies)
 	if err != nil {
 		log.Println("Error generating token for new user:", err)
 		http.Error(w, "Registration successful but failed to generate token", http.StatusInternalServerError)
 		return
 	}
 
-	response := map[string]interface{}{
-		"user":    user,
-		"token":   tokenString,
-		"message": "User registered and logged in successfully",
+	refreshToken, err := h.refreshTokenService.IssueRefreshToken(ctx, user)
+	if err != nil {
+		log.Println("Error issuing refresh token for new user:", err)
+		http.Error(w, "Registration successful but failed to generate token", http.StatusInternalServerError)
+		return
+	}
+	setRefreshCookie(w, refreshToken, h.secureCookies)
+
+	// Remember the registration device so later logins can be compared against it
+	h.securityMonitor.RecordLogin(ctx, user, middleware.ClientIPFromContext(ctx), r.UserAgent())
+
+	data := map[string]interface{}{
+		"user":          user,
+		"token":         tokenString,
+		"expires_in":    int(middleware.AccessTokenTTL().Seconds()),
+		"refresh_token": refreshToken.Token,
+		"message":       "User registered and logged in successfully",
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(response)
+	response.Resource(w, r, http.StatusCreated, data, response.Links{"refresh": "/auth/refresh", "logout": "/auth/logout"})
 }
 
 func (h *AuthHandler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("AuthHandler")
+	ctx, span := tracer.Start(ctx, "Logout-Handler")
+	defer span.End()
+
+	// End the session so its refresh token cannot mint new access tokens. The access token
+	// already issued keeps working until it expires.
+	if err := h.refreshTokenService.RevokeRefreshToken(ctx, refreshTokenFromRequest(r)); err != nil {
+		log.Printf("Error revoking refresh token from %s: %v", middleware.ClientIPFromContext(ctx), err)
+		http.Error(w, "Could not log out, please try again", http.StatusInternalServerError)
+		return
+	}
+	clearRefreshCookie(w, h.secureCookies)
+
 	// Clear the auth_token cookie by setting its MaxAge to -1
 	http.SetCookie(w, &http.Cookie{
-		Name:   "auth_token",
-		Value:  "",
-		MaxAge: -1,
+		Name:     "auth_token",
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
 	})
 
-	response := map[string]interface{}{
+	data := map[string]interface{}{
 		"message": "Logout successful",
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	response.Resource(w, r, http.StatusOK, data, response.Links{"login": "/auth/login"})
 }
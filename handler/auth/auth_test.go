@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PrateekKumar15/CarZone/service/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestAuthHandler creates a handler whose services fail the test if they are called
+func newTestAuthHandler(t *testing.T) *AuthHandler {
+	ctrl := gomock.NewController(t)
+	return NewAuthHandler(
+		mocks.NewMockAuthServiceInterface(ctrl),
+		mocks.NewMockSecurityMonitorInterface(ctrl),
+		mocks.NewMockPasswordResetServiceInterface(ctrl),
+		mocks.NewMockRefreshTokenServiceInterface(ctrl),
+		mocks.NewMockTwoFactorServiceInterface(ctrl),
+		mocks.NewMockBotCheckInterface(ctrl),
+		false,
+	)
+}
+
+func TestRegisterHandlerRefusesRolesUsersCannotPick(t *testing.T) {
+	for _, role := range []string{"admin", "Admin", "", "superuser"} {
+		t.Run("role="+role, func(t *testing.T) {
+			h := newTestAuthHandler(t)
+			body := `{"email":"mallory@example.com","password":"SecurePassword123!","username":"mallory","phone":"+919800000099","role":"` + role + `"}`
+			req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			h.RegisterHandler(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d; body %q", rec.Code, http.StatusBadRequest, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), "role must be one of: owner, renter") {
+				t.Errorf("body = %q, want the allowed roles", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/response"
+	"go.opentelemetry.io/otel"
+)
+
+// BotChallengeHandler handles requests for the bot check clients pass before registering or
+// asking for a password reset: the CAPTCHA widget's site key, or a proof-of-work puzzle
+func (h *AuthHandler) BotChallengeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("AuthHandler")
+	ctx, span := tracer.Start(ctx, "BotChallenge-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	challenge, err := h.botCheck.Challenge(ctx)
+	if err != nil {
+		log.Println("Error generating bot challenge:", err)
+		http.Error(w, "Could not generate a challenge, please try again", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	response.Resource(w, r, http.StatusOK, challenge, response.Links{"register": "/auth/register", "forgot_password": "/auth/forgot-password"})
+}
+
+// passBotCheck verifies a request's bot check token, answering 400 when it is missing, 403 when
+// it is rejected and 503 when the provider cannot be asked. It reports whether the request may go on.
+func (h *AuthHandler) passBotCheck(ctx context.Context, w http.ResponseWriter, token string) bool {
+	err := h.botCheck.Verify(ctx, token, middleware.ClientIPFromContext(ctx))
+	switch {
+	case err == nil:
+		return true
+	case strings.Contains(err.Error(), "is required"):
+		http.Error(w, "captcha_token is required, see GET /auth/challenge", http.StatusBadRequest)
+	case strings.Contains(err.Error(), "bot check failed"):
+		log.Printf("Refused bot check from %s: %v", middleware.ClientIPFromContext(ctx), err)
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		// Fail closed: letting requests through while the provider is down is what bots wait for
+		log.Printf("Error verifying bot check with %s: %v", h.botCheck.Name(), err)
+		http.Error(w, "Could not verify the bot check, please try again", http.StatusServiceUnavailable)
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"go.opentelemetry.io/otel"
+)
+
+// ForgotPasswordHandler handles requests to e-mail a password reset link. The response is the
+// same whether or not the address has an account.
+func (h *AuthHandler) ForgotPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("AuthHandler")
+	ctx, span := tracer.Start(ctx, "ForgotPassword-Handler")
+	defer span.End()
+
+	var req models.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	// Checked before the e-mail is looked up, so bots cannot make the server send mail
+	if !h.passBotCheck(ctx, w, req.CaptchaToken) {
+		return
+	}
+
+	if err := h.passwordResetService.RequestPasswordReset(ctx, req); err != nil {
+		if strings.Contains(err.Error(), "invalid email") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Error requesting password reset from %s: %v", middleware.ClientIPFromContext(ctx), err)
+		http.Error(w, "Could not process the request, please try again", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"message": "If an account uses this e-mail address, a link to reset its password has been sent",
+	}
+	response.Resource(w, r, http.StatusAccepted, data, response.Links{"reset_password": "/auth/reset-password"})
+}
+
+// ResetPasswordHandler handles requests to set a new password with the token of a reset link
+func (h *AuthHandler) ResetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("AuthHandler")
+	ctx, span := tracer.Start(ctx, "ResetPassword-Handler")
+	defer span.End()
+
+	var req models.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.passwordResetService.ResetPassword(ctx, req); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "invalid or has expired"), strings.Contains(err.Error(), "required"),
+			strings.Contains(err.Error(), "must"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			log.Printf("Error resetting password from %s: %v", middleware.ClientIPFromContext(ctx), err)
+			http.Error(w, "Could not reset the password, please try again", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	data := map[string]interface{}{
+		"message": "Password reset successfully, please log in with the new password",
+	}
+	response.Resource(w, r, http.StatusOK, data, response.Links{"login": "/auth/login"})
+}
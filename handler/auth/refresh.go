@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"go.opentelemetry.io/otel"
+)
+
+// refreshCookiePath limits the refresh_token cookie to the auth routes that take it, so it is
+// not sent with every API request
+const refreshCookiePath = "/auth"
+
+// RefreshHandler handles requests to exchange a refresh token for a new access token. The
+// refresh token comes from the body or, for browsers, the refresh_token cookie; it is used up
+// and a new one is returned in its place.
+func (h *AuthHandler) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("AuthHandler")
+	ctx, span := tracer.Start(ctx, "Refresh-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	user, refreshToken, err := h.refreshTokenService.RotateRefreshToken(ctx, refreshTokenFromRequest(r))
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "refresh token"):
+			log.Printf("Refused token refresh from %s: %v", middleware.ClientIPFromContext(ctx), err)
+			clearRefreshCookie(w, h.secureCookies)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		default:
+			log.Println("Error refreshing token:", err)
+			http.Error(w, "Could not refresh the session, please try again", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	tokenString, err := GenerateTokenAndSetCookie(w, user, h.secureCookies)
+	if err != nil {
+		log.Println("Error generating token:", err)
+		http.Error(w, "Error generating token", http.StatusInternalServerError)
+		return
+	}
+	setRefreshCookie(w, refreshToken, h.secureCookies)
+
+	data := map[string]interface{}{
+		"token":         tokenString,
+		"expires_in":    int(middleware.AccessTokenTTL().Seconds()),
+		"refresh_token": refreshToken.Token,
+	}
+	response.Resource(w, r, http.StatusOK, data, response.Links{"logout": "/auth/logout"})
+}
+
+// refreshTokenFromRequest returns the refresh token from a JSON body, falling back to the
+// refresh_token cookie. It is empty when neither carries one.
+func refreshTokenFromRequest(r *http.Request) string {
+	var req models.RefreshRequest
+	if r.Body != nil {
+		body, _ := io.ReadAll(io.LimitReader(r.Body, 4<<10))
+		if len(body) > 0 && json.Unmarshal(body, &req) == nil && req.RefreshToken != "" {
+			return req.RefreshToken
+		}
+	}
+	if cookie, err := r.Cookie("refresh_token"); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// setRefreshCookie hands a refresh token to browsers as an HTTP-only cookie on the auth routes
+func setRefreshCookie(w http.ResponseWriter, token models.IssuedRefreshToken, secure bool) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "refresh_token",
+		Value:    token.Token,
+		Path:     refreshCookiePath,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteStrictMode, // Only sent by the app's own pages, never by cross-site requests
+		MaxAge:   int(time.Until(token.ExpiresAt).Seconds()),
+	})
+}
+
+// clearRefreshCookie removes the refresh_token cookie
+func clearRefreshCookie(w http.ResponseWriter, secure bool) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "refresh_token",
+		Value:    "",
+		Path:     refreshCookiePath,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   -1,
+	})
+}
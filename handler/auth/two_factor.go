@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"go.opentelemetry.io/otel"
+)
+
+// EnableTwoFactorHandler handles requests to start two-factor setup. The response carries the
+// secret for the user's authenticator app; login does not ask for codes until
+// VerifyTwoFactorHandler confirms it.
+func (h *AuthHandler) EnableTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("AuthHandler")
+	ctx, span := tracer.Start(ctx, "EnableTwoFactor-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	setup, err := h.twoFactorService.BeginSetup(ctx, userID)
+	if err != nil {
+		writeTwoFactorError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, setup, response.Links{"verify": "/auth/2fa/verify"})
+}
+
+// VerifyTwoFactorHandler handles requests to confirm two-factor setup with a code from the
+// authenticator app. The backup codes in the response are shown only this once.
+func (h *AuthHandler) VerifyTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("AuthHandler")
+	ctx, span := tracer.Start(ctx, "VerifyTwoFactor-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.TwoFactorVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	backupCodes, err := h.twoFactorService.VerifySetup(ctx, userID, req.Code)
+	if err != nil {
+		writeTwoFactorError(w, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"backup_codes": backupCodes,
+		"message":      "Two-factor authentication enabled. Store the backup codes somewhere safe; each works once.",
+	}
+	response.Resource(w, r, http.StatusOK, data, response.Links{"login": "/auth/login"})
+}
+
+// writeTwoFactorError maps two-factor service errors to HTTP status codes
+func writeTwoFactorError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "user not found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already enabled"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "two-factor code") || strings.Contains(err.Error(), "not started"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Println("Error setting up two-factor authentication:", err)
+		http.Error(w, "Could not set up two-factor authentication, please try again", http.StatusInternalServerError)
+	}
+}
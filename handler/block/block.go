@@ -0,0 +1,125 @@
+package block
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// BlockHandler handles HTTP requests for the caller's block list
+type BlockHandler struct {
+	blockService service.BlockServiceInterface
+}
+
+// NewBlockHandler creates a new block handler
+func NewBlockHandler(blockService service.BlockServiceInterface) *BlockHandler {
+	return &BlockHandler{
+		blockService: blockService,
+	}
+}
+
+// GetBlocks handles requests to list the users the caller has blocked
+func (h *BlockHandler) GetBlocks(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("BlockHandler")
+	ctx, span := tracer.Start(r.Context(), "GetBlocks-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	blocks, err := h.blockService.GetBlocks(ctx, userID)
+	if err != nil {
+		writeBlockError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, blocks, nil)
+}
+
+// BlockUser handles requests to block a user
+func (h *BlockHandler) BlockUser(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("BlockHandler")
+	ctx, span := tracer.Start(r.Context(), "BlockUser-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.BlockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	block, err := h.blockService.BlockUser(ctx, userID, req)
+	if err != nil {
+		writeBlockError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, block, response.Links{
+		"self":   "/users/me/blocks/" + block.BlockedID.String(),
+		"blocks": "/users/me/blocks",
+	})
+}
+
+// UnblockUser handles requests to lift a block
+func (h *BlockHandler) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("BlockHandler")
+	ctx, span := tracer.Start(r.Context(), "UnblockUser-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.blockService.UnblockUser(ctx, userID, mux.Vars(r)["id"]); err != nil {
+		writeBlockError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeBlockError maps block service errors to HTTP status codes
+func writeBlockError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "no block found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "required") ||
+		strings.Contains(err.Error(), "cannot"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -2,11 +2,16 @@ package booking
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/PrateekKumar15/CarZone/middleware"
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
 	"github.com/PrateekKumar15/CarZone/service"
 	"github.com/gorilla/mux"
 	"go.opentelemetry.io/otel"
@@ -45,22 +50,7 @@ func (h *BookingHandler) GetBookingByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	body, err := json.Marshal(resp)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	_, err = w.Write(body)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error writing response:", err)
-		return
-	}
+	response.Resource(w, r, http.StatusOK, resp, bookingLinks(*resp))
 }
 
 // GetBookingsByCustomerID retrieves all bookings for a specific customer
@@ -73,30 +63,22 @@ func (h *BookingHandler) GetBookingsByCustomerID(w http.ResponseWriter, r *http.
 	vars := mux.Vars(r)
 	customerID := vars["customerID"]
 
-	resp, err := h.service.GetBookingsByCustomerID(ctx, customerID)
+	page, err := models.ParsePageRequest(r.URL.Query().Get("limit"), r.URL.Query().Get("cursor"))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.Println("Error retrieving bookings by customer ID:", err)
+		log.Println("Invalid pagination parameters:", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	body, err := json.Marshal(resp)
+	resp, err := h.service.ListBookings(ctx, models.BookingFilter{CustomerID: customerID, Status: models.BookingStatus(r.URL.Query().Get("status"))}, page)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
+		w.WriteHeader(http.StatusBadRequest)
+		log.Println("Error retrieving bookings by customer ID:", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	_, err = w.Write(body)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error writing response:", err)
-		return
-	}
+	response.List(w, r, resp)
 }
 
 // GetBookingsByCarID retrieves all bookings for a specific car
@@ -109,30 +91,22 @@ func (h *BookingHandler) GetBookingsByCarID(w http.ResponseWriter, r *http.Reque
 	vars := mux.Vars(r)
 	carID := vars["carID"]
 
-	resp, err := h.service.GetBookingsByCarID(ctx, carID)
+	page, err := models.ParsePageRequest(r.URL.Query().Get("limit"), r.URL.Query().Get("cursor"))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.Println("Error retrieving bookings by car ID:", err)
+		log.Println("Invalid pagination parameters:", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	body, err := json.Marshal(resp)
+	resp, err := h.service.ListBookings(ctx, models.BookingFilter{CarID: carID}, page)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
+		w.WriteHeader(http.StatusBadRequest)
+		log.Println("Error retrieving bookings by car ID:", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	_, err = w.Write(body)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error writing response:", err)
-		return
-	}
+	response.List(w, r, resp)
 }
 
 // GetBookingsByOwnerID retrieves all bookings for cars owned by a specific owner
@@ -145,30 +119,22 @@ func (h *BookingHandler) GetBookingsByOwnerID(w http.ResponseWriter, r *http.Req
 	vars := mux.Vars(r)
 	ownerID := vars["ownerID"]
 
-	resp, err := h.service.GetBookingsByOwnerID(ctx, ownerID)
+	page, err := models.ParsePageRequest(r.URL.Query().Get("limit"), r.URL.Query().Get("cursor"))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.Println("Error retrieving bookings by owner ID:", err)
+		log.Println("Invalid pagination parameters:", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	body, err := json.Marshal(resp)
+	resp, err := h.service.ListBookings(ctx, models.BookingFilter{OwnerID: ownerID, Status: models.BookingStatus(r.URL.Query().Get("status"))}, page)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
+		w.WriteHeader(http.StatusBadRequest)
+		log.Println("Error retrieving bookings by owner ID:", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	_, err = w.Write(body)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error writing response:", err)
-		return
-	}
+	response.List(w, r, resp)
 }
 
 // CreateBooking creates a new booking
@@ -195,6 +161,28 @@ func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp, err := h.service.CreateBooking(ctx, bookingReq)
+	var ineligible *models.EligibilityError
+	if errors.As(err, &ineligible) {
+		// Clients branch on the code to send the renter to identity verification or other cars
+		response.JSON(w, http.StatusUnprocessableEntity, response.Envelope{Data: ineligible, Links: response.Links{
+			"eligibility": "/cars/" + bookingReq.CarID.String() + "/eligibility",
+		}})
+		return
+	}
+	if err != nil && strings.Contains(err.Error(), "are blocked") {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	// A concurrent request booked the car for an overlapping period after the conflict check
+	if err != nil && strings.Contains(err.Error(), "overlaps another rental") {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil && strings.Contains(err.Error(), "no signing key") {
+		log.Println("Error creating booking:", err)
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		log.Println("Error creating booking:", err)
@@ -202,22 +190,179 @@ func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responseBody, err := json.Marshal(resp)
+	links := bookingLinks(*resp)
+	links["self"] = "/bookings/" + resp.ID.String()
+	response.Resource(w, r, http.StatusCreated, resp, links)
+}
+
+// QuoteBooking returns the itemised price of a prospective booking without creating it
+func (h *BookingHandler) QuoteBooking(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("BookingHandler")
+	ctx, span := tracer.Start(r.Context(), "QuoteBooking-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var bookingReq models.BookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&bookingReq); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	quote, err := h.service.QuoteBooking(ctx, bookingReq)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "car not found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case strings.Contains(err.Error(), "geocoding"):
+			log.Println("Error geocoding delivery address:", err)
+			http.Error(w, "Delivery address could not be verified right now, please try again", http.StatusBadGateway)
+		case strings.Contains(err.Error(), "no signing key"):
+			log.Println("Error quoting booking:", err)
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, quote, response.Links{
+		"car":      "/cars/" + quote.CarID.String(),
+		"bookings": "/bookings",
+	})
+}
+
+// CheckConflicts tells whether a car can be booked for a period and what blocks it, so clients
+// can explain unavailability before a booking is submitted
+func (h *BookingHandler) CheckConflicts(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("BookingHandler")
+	ctx, span := tracer.Start(r.Context(), "CheckConflicts-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	start, end, err := models.ParseBookingPeriod(r.URL.Query().Get("start"), r.URL.Query().Get("end"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	carID := mux.Vars(r)["id"]
+	availability, err := h.service.CheckConflicts(ctx, carID, start, end)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "car not found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case strings.Contains(err.Error(), "failed to"):
+			log.Println("Error checking booking conflicts:", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, availability, response.Links{
+		"car":   "/cars/" + carID,
+		"quote": "/bookings/quote",
+	})
+}
+
+// GetExtensionQuote prices extending a trip in progress to a new end date and tells whether the
+// car is free for the extra days
+func (h *BookingHandler) GetExtensionQuote(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("BookingHandler")
+	ctx, span := tracer.Start(r.Context(), "GetExtensionQuote-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	end, err := time.Parse(time.RFC3339, r.URL.Query().Get("end_date"))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
+		http.Error(w, "end_date must be an RFC 3339 timestamp", http.StatusBadRequest)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	quote, err := h.service.QuoteExtension(ctx, id, userID, middleware.RoleFromContext(ctx), end)
+	if err != nil {
+		writeExtensionError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, quote, response.Links{
+		"trip":   "/bookings/" + id,
+		"extend": "/bookings/" + id + "/extension",
+	})
+}
+
+// ExtendBooking extends a trip in progress with a pending extension booking and returns the
+// payment order for its price
+func (h *BookingHandler) ExtendBooking(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("BookingHandler")
+	ctx, span := tracer.Start(r.Context(), "ExtendBooking-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	var req models.ExtensionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
 
-	_, err = w.Write(responseBody)
+	id := mux.Vars(r)["id"]
+	resp, err := h.service.ExtendBooking(ctx, id, userID, middleware.RoleFromContext(ctx), req)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error writing response:", err)
+		writeExtensionError(w, err)
 		return
 	}
+
+	response.Resource(w, r, http.StatusCreated, resp, response.Links{
+		"self": "/bookings/" + resp.Booking.ID.String(),
+		"trip": "/bookings/" + id,
+		"pay":  "/payments/booking/" + resp.Booking.ID.String(),
+	})
+}
+
+// writeExtensionError maps trip extension errors to HTTP statuses
+func writeExtensionError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no booking found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "not available") || strings.Contains(err.Error(), "already awaiting") ||
+		strings.Contains(err.Error(), "overlaps another rental"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "failed to"):
+		log.Println("Error extending trip:", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
 }
 
 // UpdateBookingStatus updates the status of an existing booking
@@ -248,30 +393,27 @@ func (h *BookingHandler) UpdateBookingStatus(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	resp, err := h.service.UpdateBookingStatus(ctx, id, statusUpdate.Status)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.Println("Error updating booking status:", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	// Bookings held by risk scoring can only be released or rejected by an admin
+	current, err := h.service.GetBookingByID(ctx, id)
+	if err == nil && current != nil && current.Status == models.BookingStatusUnderReview &&
+		middleware.RoleFromContext(ctx) != "admin" {
+		http.Error(w, "Booking is under review and can only be updated by an admin", http.StatusForbidden)
 		return
 	}
 
-	responseBody, err := json.Marshal(resp)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
+	resp, err := h.service.UpdateBookingStatus(ctx, id, statusUpdate.Status)
+	if err != nil && strings.Contains(err.Error(), "overlaps another rental") {
+		http.Error(w, err.Error(), http.StatusConflict)
 		return
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	_, err = w.Write(responseBody)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error writing response:", err)
+		w.WriteHeader(http.StatusBadRequest)
+		log.Println("Error updating booking status:", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+
+	response.Resource(w, r, http.StatusOK, resp, bookingLinks(*resp))
 }
 
 // DeleteBooking deletes a booking
@@ -286,59 +428,272 @@ func (h *BookingHandler) DeleteBooking(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := h.service.DeleteBooking(ctx, id)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
 		log.Println("Error deleting booking:", err)
+		// Rental and payment history keeps the booking from being deleted
+		status := http.StatusBadRequest
+		if strings.Contains(err.Error(), "cannot be deleted") {
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, resp, nil)
+}
+
+// GetAllBookings retrieves all bookings (admin function)
+func (h *BookingHandler) GetAllBookings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("BookingHandler")
+	ctx, span := tracer.Start(ctx, "GetAllBookings-Handler")
+	defer span.End()
+
+	page, err := models.ParsePageRequest(r.URL.Query().Get("limit"), r.URL.Query().Get("cursor"))
+	if err != nil {
+		log.Println("Invalid pagination parameters:", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	body, err := json.Marshal(resp)
+	resp, err := h.service.ListBookings(ctx, models.BookingFilter{}, page)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
+		w.WriteHeader(http.StatusBadRequest)
+		log.Println("Error retrieving all bookings:", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	response.List(w, r, resp)
+}
+
+// GetActiveTrips lists the trips currently under way with the authenticated owner's cars
+func (h *BookingHandler) GetActiveTrips(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("BookingHandler")
+	ctx, span := tracer.Start(ctx, "GetActiveTrips-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
 
-	_, err = w.Write(body)
+	trips, err := h.service.GetActiveTrips(ctx, ownerID)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error writing response:", err)
+		log.Println("Error retrieving active trips:", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+
+	response.Resource(w, r, http.StatusOK, trips, response.Links{
+		"bookings": "/bookings/owner/" + ownerID,
+	})
 }
 
-// GetAllBookings retrieves all bookings (admin function)
-func (h *BookingHandler) GetAllBookings(w http.ResponseWriter, r *http.Request) {
+// Checkout records handing the car over to the customer
+func (h *BookingHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	h.recordInspection(w, r, models.InspectionKindCheckout)
+}
+
+// Checkin records the car's return at the end of the rental
+func (h *BookingHandler) Checkin(w http.ResponseWriter, r *http.Request) {
+	h.recordInspection(w, r, models.InspectionKindCheckin)
+}
+
+// recordInspection handles checkout and check-in requests
+func (h *BookingHandler) recordInspection(w http.ResponseWriter, r *http.Request, kind models.InspectionKind) {
 	ctx := r.Context()
 	tracer := otel.Tracer("BookingHandler")
-	ctx, span := tracer.Start(ctx, "GetAllBookings-Handler")
+	ctx, span := tracer.Start(ctx, "RecordInspection-Handler")
 	defer span.End()
 
-	resp, err := h.service.GetAllBookings(ctx)
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.InspectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	resp, err := h.service.RecordInspection(ctx, id, userID, middleware.RoleFromContext(ctx), kind, req)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.Println("Error retrieving all bookings:", err)
+		log.Printf("Error recording booking %s: %v", kind, err)
+		switch {
+		case strings.Contains(err.Error(), "no booking found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case strings.Contains(err.Error(), "already has a"):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case strings.Contains(err.Error(), "could not be captured") || strings.Contains(err.Error(), "to capture"):
+			http.Error(w, err.Error(), http.StatusPaymentRequired)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, resp, response.Links{
+		"booking":     "/bookings/" + id,
+		"inspections": "/bookings/" + id + "/inspections",
+	})
+}
+
+// GetInspections retrieves the checkout and check-in records of a booking
+func (h *BookingHandler) GetInspections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("BookingHandler")
+	ctx, span := tracer.Start(ctx, "GetInspections-Handler")
+	defer span.End()
+
+	id := mux.Vars(r)["id"]
+
+	resp, err := h.service.GetInspections(ctx, id)
+	if err != nil {
+		log.Println("Error retrieving booking inspections:", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	body, err := json.Marshal(resp)
+	response.Resource(w, r, http.StatusOK, resp, response.Links{
+		"booking": "/bookings/" + id,
+	})
+}
+
+// CollectPayment records the car owner or an admin collecting an offline payment in person
+func (h *BookingHandler) CollectPayment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("BookingHandler")
+	ctx, span := tracer.Start(ctx, "CollectPayment-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.PaymentCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	resp, err := h.service.CollectPayment(ctx, vars["id"], vars["paymentID"], userID, middleware.RoleFromContext(ctx), req)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
+		log.Printf("Error recording payment collection: %v", err)
+		switch {
+		case strings.Contains(err.Error(), "no booking found") || strings.Contains(err.Error(), "no payment found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case strings.Contains(err.Error(), "no longer pending") || strings.Contains(err.Error(), "invalid status transition"):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	response.Resource(w, r, http.StatusCreated, resp, response.Links{
+		"booking":     "/bookings/" + resp.Booking.ID.String(),
+		"payment":     "/payments/" + resp.Payment.ID.String(),
+		"collections": "/bookings/" + resp.Booking.ID.String() + "/payment-collections",
+	})
+}
 
-	_, err = w.Write(body)
+// GetPaymentCollections retrieves the offline collections recorded for a booking
+func (h *BookingHandler) GetPaymentCollections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("BookingHandler")
+	ctx, span := tracer.Start(ctx, "GetPaymentCollections-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	resp, err := h.service.GetPaymentCollections(ctx, id, userID, middleware.RoleFromContext(ctx))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error writing response:", err)
+		log.Println("Error retrieving payment collections:", err)
+		if strings.Contains(err.Error(), "no booking found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+
+	response.Resource(w, r, http.StatusOK, resp, response.Links{
+		"booking": "/bookings/" + id,
+	})
+}
+
+// GetDocuments retrieves the numbered invoice and receipts of a booking
+func (h *BookingHandler) GetDocuments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tracer := otel.Tracer("BookingHandler")
+	ctx, span := tracer.Start(ctx, "GetDocuments-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	resp, err := h.service.GetDocuments(ctx, id, userID, middleware.RoleFromContext(ctx))
+	if err != nil {
+		log.Println("Error retrieving booking documents:", err)
+		if strings.Contains(err.Error(), "no booking found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, resp, response.Links{
+		"booking": "/bookings/" + id,
+	})
+}
+
+// bookingLinks returns the related-resource links for a booking
+func bookingLinks(booking models.Booking) response.Links {
+	return response.Links{
+		"car":     "/cars/" + booking.CarID.String(),
+		"payment": "/payments/booking/" + booking.ID.String(),
+	}
 }
@@ -0,0 +1,77 @@
+package brand
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// BrandHandler handles HTTP requests for the car brand and model reference data
+type BrandHandler struct {
+	brandService service.BrandServiceInterface
+}
+
+// NewBrandHandler creates a new brand handler
+func NewBrandHandler(brandService service.BrandServiceInterface) *BrandHandler {
+	return &BrandHandler{
+		brandService: brandService,
+	}
+}
+
+// GetBrands handles brand autocomplete requests
+func (h *BrandHandler) GetBrands(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("BrandHandler")
+	ctx, span := tracer.Start(r.Context(), "GetBrands-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	brands, err := h.brandService.ListBrands(ctx, r.URL.Query().Get("q"))
+	if err != nil {
+		writeBrandError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, brands, nil)
+}
+
+// GetModels handles model autocomplete requests for a brand
+func (h *BrandHandler) GetModels(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("BrandHandler")
+	ctx, span := tracer.Start(r.Context(), "GetModels-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	models, err := h.brandService.ListModels(ctx, mux.Vars(r)["id"], r.URL.Query().Get("q"))
+	if err != nil {
+		writeBrandError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, models, response.Links{
+		"brands": "/brands",
+	})
+}
+
+// writeBrandError maps brand service errors to HTTP status codes
+func writeBrandError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no brand found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
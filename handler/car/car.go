@@ -2,11 +2,16 @@ package car
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/PrateekKumar15/CarZone/middleware"
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
 	"github.com/PrateekKumar15/CarZone/service"
 	"github.com/gorilla/mux"
 	"go.opentelemetry.io/otel"
@@ -46,21 +51,8 @@ func (h *CarHandler) GetCarByID(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Car not found", http.StatusNotFound)
 		return
 	}
-	body, err := json.Marshal(resp)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	_, err = w.Write(body)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error writing response:", err)
-		return
-	}
+	h.service.RecordFeaturedClick(ctx, *resp, middleware.UserIDFromContext(ctx))
+	response.Resource(w, r, http.StatusOK, resp, carLinks(*resp))
 }
 
 func (h *CarHandler) GetCarByBrand(w http.ResponseWriter, r *http.Request) {
@@ -69,28 +61,20 @@ func (h *CarHandler) GetCarByBrand(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracer.Start(ctx, "GetCarByBrand-Handler")
 	defer span.End()
 	brand := r.URL.Query().Get("brand")
-
-	resp, err := h.service.GetCarByBrand(ctx, brand)
+	fuzzy, err := parseFuzzy(r.URL.Query().Get("fuzzy"))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.Println("Error retrieving car by brand:", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	body, err := json.Marshal(resp)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, err = w.Write(body)
+
+	resp, err := h.service.GetCarByBrand(ctx, brand, fuzzy)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error writing response:", err)
+		w.WriteHeader(http.StatusBadRequest)
+		log.Println("Error retrieving car by brand:", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	response.Resource(w, r, http.StatusOK, resp, nil)
 }
 
 func (h *CarHandler) CreateCar(w http.ResponseWriter, r *http.Request) {
@@ -114,22 +98,17 @@ func (h *CarHandler) CreateCar(w http.ResponseWriter, r *http.Request) {
 
 	createdCar, err := h.service.CreateCar(ctx, carRequest)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
 		log.Println("Error creating car:", err)
+		if strings.Contains(err.Error(), "authentication required") {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	createdCarJSON, err := json.Marshal(createdCar)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	// Write the created car JSON to the response
-	_, _ = w.Write(createdCarJSON)
-
+	links := carLinks(*createdCar)
+	links["self"] = "/cars/" + createdCar.ID.String()
+	response.Resource(w, r, http.StatusCreated, createdCar, links)
 }
 
 func (h *CarHandler) UpdateCar(w http.ResponseWriter, r *http.Request) {
@@ -155,22 +134,290 @@ func (h *CarHandler) UpdateCar(w http.ResponseWriter, r *http.Request) {
 
 	updatedCar, err := h.service.UpdateCar(ctx, id, carRequest)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
 		log.Println("Error updating car:", err)
+		switch {
+		case strings.Contains(err.Error(), "authentication required"):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case strings.Contains(err.Error(), "only the car's owner"):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case strings.Contains(err.Error(), "car not found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+	response.Resource(w, r, http.StatusAccepted, updatedCar, carLinks(*updatedCar))
+}
+
+// ListImageUploads lists the images sent with a car and how far their upload got (car owner or admin)
+func (h *CarHandler) ListImageUploads(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(r.Context(), "ListImageUploads-Handler")
+	defer span.End()
+
+	carID := mux.Vars(r)["id"]
+	uploads, err := h.service.ListImageUploads(ctx, carID)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "authentication required"):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case strings.Contains(err.Error(), "only the car's owner"):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case strings.Contains(err.Error(), "car not found") || strings.Contains(err.Error(), "invalid car ID"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			log.Println("Error listing car image uploads:", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	response.Resource(w, r, http.StatusOK, uploads, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// GetPriceHistory lists every change of a car's daily price, newest first
+func (h *CarHandler) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(r.Context(), "GetPriceHistory-Handler")
+	defer span.End()
+
+	carID := mux.Vars(r)["id"]
+	history, err := h.service.GetPriceHistory(ctx, carID)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "car not found") || strings.Contains(err.Error(), "invalid car ID"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			log.Println("Error retrieving car price history:", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	response.Resource(w, r, http.StatusOK, history, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// GetQuality scores a car's listing and returns hints to improve it (car owner or admin)
+func (h *CarHandler) GetQuality(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(r.Context(), "GetQuality-Handler")
+	defer span.End()
+
+	carID := mux.Vars(r)["id"]
+	quality, err := h.service.GetQuality(ctx, carID)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "authentication required"):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case strings.Contains(err.Error(), "only the car's owner"):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case strings.Contains(err.Error(), "car not found") || strings.Contains(err.Error(), "invalid car ID") ||
+			strings.Contains(err.Error(), "no car found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			log.Println("Error scoring car listing quality:", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	response.Resource(w, r, http.StatusOK, quality, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// UpdateCarStatus moves a car through its lifecycle (draft, pending_review, active, maintenance, retired)
+func (h *CarHandler) UpdateCarStatus(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateCarStatus-Handler")
+	defer span.End()
+
+	var req models.CarStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	byAdmin := middleware.RoleFromContext(ctx) == "admin"
+	updatedCar, err := h.service.UpdateCarStatus(ctx, mux.Vars(r)["id"], req.Status, byAdmin)
+	if err != nil {
+		log.Println("Error updating car status:", err)
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case strings.Contains(err.Error(), "authentication required"):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case strings.Contains(err.Error(), "only admins"),
+			strings.Contains(err.Error(), "only the car's owner"):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case strings.Contains(err.Error(), "changed by someone else"):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+	response.Resource(w, r, http.StatusOK, updatedCar, carLinks(*updatedCar))
+}
+
+// GetFuelPolicy retrieves a car's fuel policy
+func (h *CarHandler) GetFuelPolicy(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(r.Context(), "GetFuelPolicy-Handler")
+	defer span.End()
+
+	carID := mux.Vars(r)["id"]
+	policy, err := h.service.GetFuelPolicy(ctx, carID)
+	if err != nil {
+		writeFuelPolicyError(w, err)
+		return
+	}
+	response.Resource(w, r, http.StatusOK, policy, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// UpdateFuelPolicy configures a car's fuel policy (car owner or admin)
+func (h *CarHandler) UpdateFuelPolicy(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateFuelPolicy-Handler")
+	defer span.End()
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CarFuelPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	carID := mux.Vars(r)["id"]
+	policy, err := h.service.UpdateFuelPolicy(ctx, userID, middleware.RoleFromContext(ctx), carID, req)
+	if err != nil {
+		writeFuelPolicyError(w, err)
+		return
+	}
+	response.Resource(w, r, http.StatusOK, policy, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// writeFuelPolicyError maps fuel policy errors to HTTP status codes
+func writeFuelPolicyError(w http.ResponseWriter, err error) {
+	log.Println("Error handling fuel policy:", err)
+	switch {
+	case strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "no fuel policy"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "must") || strings.Contains(err.Error(), "invalid"):
 		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetEligibility retrieves the minimum renter age and licence years of a car
+func (h *CarHandler) GetEligibility(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(r.Context(), "GetEligibility-Handler")
+	defer span.End()
+
+	carID := mux.Vars(r)["id"]
+	eligibility, err := h.service.GetEligibility(ctx, carID)
+	if err != nil {
+		writeEligibilityError(w, err)
+		return
+	}
+	response.Resource(w, r, http.StatusOK, eligibility, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// UpdateEligibility sets the minimum renter age and licence years of a car (car owner or admin)
+func (h *CarHandler) UpdateEligibility(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateEligibility-Handler")
+	defer span.End()
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CarEligibilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
-	updatedCarJSON, err := json.Marshal(updatedCar)
+
+	carID := mux.Vars(r)["id"]
+	eligibility, err := h.service.UpdateEligibility(ctx, userID, middleware.RoleFromContext(ctx), carID, req)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
+		writeEligibilityError(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusAccepted)
-	// Write the updated car JSON to the response
-	_, _ = w.Write(updatedCarJSON)
+	response.Resource(w, r, http.StatusOK, eligibility, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
 
+// writeEligibilityError maps eligibility rule errors to HTTP status codes
+func writeEligibilityError(w http.ResponseWriter, err error) {
+	log.Println("Error handling eligibility rules:", err)
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "must") || strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
 
 func (h *CarHandler) DeleteCar(w http.ResponseWriter, r *http.Request) {
@@ -182,25 +429,200 @@ func (h *CarHandler) DeleteCar(w http.ResponseWriter, r *http.Request) {
 	id := vars["id"]
 	deletedCar, err := h.service.DeleteCar(ctx, id)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
 		log.Println("Error deleting car:", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		switch {
+		case strings.Contains(err.Error(), "authentication required"):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case strings.Contains(err.Error(), "only the car's owner"):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case strings.Contains(err.Error(), "no car found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		// Rental history keeps the car from being deleted
+		case strings.Contains(err.Error(), "cannot be deleted"):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
 		return
 	}
 	// Return the deleted car for audit purposes
-	body, err := json.Marshal(deletedCar)
+	response.Resource(w, r, http.StatusOK, deletedCar, nil)
+}
+
+func (h *CarHandler) GetAllCars(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	ctx := r.Context()
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(ctx, "GetAllCars-Handler")
+	defer span.End()
+	page, err := models.ParsePageRequest(r.URL.Query().Get("limit"), r.URL.Query().Get("cursor"))
+	if err != nil {
+		log.Println("Invalid pagination parameters:", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	features, err := models.ParseFeatureFilter(r.URL.Query().Get("features"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fuzzy, err := parseFuzzy(r.URL.Query().Get("fuzzy"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	attributes, err := models.ParseAttributeFilter(r.URL.Query().Get("attributes"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sort, err := models.ParseCarSort(r.URL.Query().Get("sort"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Explain mode shows admins why each car ranks where it does
+	explain, err := parseExplain(r.URL.Query().Get("explain"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if explain && middleware.RoleFromContext(ctx) != "admin" {
+		http.Error(w, "Only admins can explain rankings", http.StatusForbidden)
+		return
+	}
+
+	count, err := parseCount(r.URL.Query().Get("count"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := models.CarFilter{
+		Features:   features,
+		Attributes: attributes,
+		Brand:      strings.TrimSpace(r.URL.Query().Get("brand")),
+		BrandFuzzy: fuzzy,
+		Sort:       sort,
+		Explain:    explain,
+		Count:      count,
+	}
+	// An optional period reports whether each listed car can be booked for it
+	if start, end := r.URL.Query().Get("start"), r.URL.Query().Get("end"); start != "" || end != "" {
+		from, to, err := models.ParseBookingPeriod(start, end)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter.AvailableFrom, filter.AvailableTo = &from, &to
+	}
+	cars, err := h.service.ListCars(ctx, filter, page)
 	if err != nil {
+		if strings.Contains(err.Error(), "unknown feature") || strings.Contains(err.Error(), "attribute") ||
+			strings.Contains(err.Error(), "end must be after start") ||
+			strings.Contains(err.Error(), "availability can be checked") ||
+			strings.Contains(err.Error(), "cannot be combined with cursor") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
+		log.Println("Error retrieving all cars:", err)
+		return
+	}
+	response.List(w, r, cars)
+}
+
+// SearchCars lists the cars matching the search criteria in the query parameters, filtered
+// in the database rather than by the client
+func (h *CarHandler) SearchCars(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	ctx := r.Context()
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(ctx, "SearchCars-Handler")
+	defer span.End()
+
+	page, err := models.ParsePageRequest(r.URL.Query().Get("limit"), r.URL.Query().Get("cursor"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write(body)
 
+	filter, err := models.ParseCarSearch(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filter.Count, err = parseCount(r.URL.Query().Get("count")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cars, err := h.service.ListCars(ctx, filter, page)
+	if err != nil {
+		log.Println("Error searching cars:", err)
+		http.Error(w, "Error searching cars", http.StatusInternalServerError)
+		return
+	}
+	response.List(w, r, cars)
 }
 
-func (h *CarHandler) GetAllCars(w http.ResponseWriter, r *http.Request) {
+// parseFuzzy parses the optional fuzzy query parameter that turns on typo-tolerant brand matching
+func parseFuzzy(raw string) (bool, error) {
+	if raw == "" {
+		return false, nil
+	}
+	fuzzy, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, errors.New("fuzzy must be true or false")
+	}
+	return fuzzy, nil
+}
+
+// parseExplain parses the optional explain query parameter that attaches each ranked car's score
+func parseExplain(raw string) (bool, error) {
+	if raw == "" {
+		return false, nil
+	}
+	explain, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, errors.New("explain must be true or false")
+	}
+	return explain, nil
+}
+
+// parseCount parses the optional count query parameter that adds the total number of matching
+// cars to the page meta
+func parseCount(raw string) (bool, error) {
+	if raw == "" {
+		return false, nil
+	}
+	count, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, errors.New("count must be true or false")
+	}
+	return count, nil
+}
+
+// publicCacheControl lets browsers reuse catalog responses briefly while CDNs
+// hold them longer and may serve stale copies during revalidation
+const publicCacheControl = "public, max-age=60, s-maxage=300, stale-while-revalidate=600"
+
+// GetPublicCars serves the unauthenticated, cacheable car catalog, narrowed by the search
+// criteria of GET /cars/search
+func (h *CarHandler) GetPublicCars(w http.ResponseWriter, r *http.Request) {
 	// Handle OPTIONS request for CORS preflight
 	if r.Method == http.MethodOptions {
 		return // CORS middleware will handle the response
@@ -208,26 +630,131 @@ func (h *CarHandler) GetAllCars(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 	tracer := otel.Tracer("CarHandler")
-	ctx, span := tracer.Start(ctx, "GetAllCars-Handler")
+	ctx, span := tracer.Start(ctx, "GetPublicCars-Handler")
 	defer span.End()
-	cars, err := h.service.GetAllCars(ctx)
+
+	page, err := models.ParsePageRequest(r.URL.Query().Get("limit"), r.URL.Query().Get("cursor"))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error retrieving all cars:", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	body, err := json.Marshal(cars)
+
+	filter, err := models.ParseCarSearch(r.URL.Query())
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error marshalling response:", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filter.Count, err = parseCount(r.URL.Query().Get("count")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cars, err := h.service.ListPublicCars(ctx, filter, page)
+	if err != nil {
+		log.Println("Error retrieving public cars:", err)
+		http.Error(w, "Error retrieving cars", http.StatusInternalServerError)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, err = w.Write(body)
+
+	response.Cached(w, r, response.PageEnvelope(r, cars), publicCacheControl)
+}
+
+// GetPublicCarByID serves the unauthenticated, cacheable view of a single car
+func (h *CarHandler) GetPublicCarByID(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	ctx := r.Context()
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(ctx, "GetPublicCarByID-Handler")
+	defer span.End()
+
+	id := mux.Vars(r)["id"]
+	car, err := h.service.GetPublicCarByID(ctx, id)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("Error writing response:", err)
+		log.Println("Error retrieving public car by ID:", err)
+		http.Error(w, "Invalid car ID", http.StatusBadRequest)
+		return
+	}
+	if car == nil {
+		http.Error(w, "Car not found", http.StatusNotFound)
+		return
+	}
+
+	response.Cached(w, r, response.Envelope{
+		Data:  car,
+		Links: response.Links{"self": r.URL.RequestURI(), "catalog": "/public/cars"},
+	}, publicCacheControl)
+}
+
+// GetCarBySlug retrieves a car by its URL slug
+func (h *CarHandler) GetCarBySlug(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	ctx := r.Context()
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(ctx, "GetCarBySlug-Handler")
+	defer span.End()
+
+	slug := mux.Vars(r)["slug"]
+	car, err := h.service.GetCarBySlug(ctx, slug)
+	if err != nil {
+		log.Println("Error retrieving car by slug:", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if car == nil {
+		http.Error(w, "Car not found", http.StatusNotFound)
+		return
+	}
+
+	links := carLinks(*car)
+	links["canonical"] = "/cars/" + car.ID.String()
+	response.Resource(w, r, http.StatusOK, car, links)
+}
+
+// GetPublicCarBySlug serves the unauthenticated, cacheable view of a single car by slug
+func (h *CarHandler) GetPublicCarBySlug(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	ctx := r.Context()
+	tracer := otel.Tracer("CarHandler")
+	ctx, span := tracer.Start(ctx, "GetPublicCarBySlug-Handler")
+	defer span.End()
+
+	slug := mux.Vars(r)["slug"]
+	car, err := h.service.GetPublicCarBySlug(ctx, slug)
+	if err != nil {
+		log.Println("Error retrieving public car by slug:", err)
+		http.Error(w, "Invalid car slug", http.StatusBadRequest)
+		return
+	}
+	if car == nil {
+		http.Error(w, "Car not found", http.StatusNotFound)
 		return
 	}
+
+	response.Cached(w, r, response.Envelope{
+		Data: car,
+		Links: response.Links{
+			"self":      r.URL.RequestURI(),
+			"canonical": "/public/cars/" + car.ID.String(),
+			"catalog":   "/public/cars",
+		},
+	}, publicCacheControl)
+}
+
+// carLinks returns the related-resource links for a car
+func carLinks(car models.Car) response.Links {
+	return response.Links{
+		"bookings": "/bookings/car/" + car.ID.String(),
+	}
 }
@@ -0,0 +1,152 @@
+package checklist
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"go.opentelemetry.io/otel"
+)
+
+// ChecklistHandler handles HTTP requests for the handover checklist
+type ChecklistHandler struct {
+	checklistService service.ChecklistServiceInterface
+}
+
+// NewChecklistHandler creates a new checklist handler
+func NewChecklistHandler(checklistService service.ChecklistServiceInterface) *ChecklistHandler {
+	return &ChecklistHandler{
+		checklistService: checklistService,
+	}
+}
+
+// GetCurrentTemplate handles requests for the checklist version new checkouts answer
+func (h *ChecklistHandler) GetCurrentTemplate(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("ChecklistHandler")
+	ctx, span := tracer.Start(r.Context(), "GetCurrentTemplate-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	tmpl, err := h.checklistService.GetCurrentTemplate(ctx)
+	if err != nil {
+		writeChecklistError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, tmpl, checklistLinks(tmpl.Version))
+}
+
+// GetTemplateVersion handles requests for one version of the checklist, e.g. the one a
+// checkout answered and its check-in has to answer too
+func (h *ChecklistHandler) GetTemplateVersion(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("ChecklistHandler")
+	ctx, span := tracer.Start(r.Context(), "GetTemplateVersion-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	version, err := strconv.Atoi(mux.Vars(r)["version"])
+	if err != nil || version < 1 {
+		http.Error(w, "invalid checklist version", http.StatusBadRequest)
+		return
+	}
+
+	tmpl, err := h.checklistService.GetTemplateVersion(ctx, version)
+	if err != nil {
+		writeChecklistError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, tmpl, checklistLinks(tmpl.Version))
+}
+
+// ListTemplateVersions handles admin requests for the history of the checklist
+func (h *ChecklistHandler) ListTemplateVersions(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("ChecklistHandler")
+	ctx, span := tracer.Start(r.Context(), "ListTemplateVersions-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	versions, err := h.checklistService.ListTemplateVersions(ctx)
+	if err != nil {
+		writeChecklistError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, versions, response.Links{
+		"current": "/checklist-templates/current",
+	})
+}
+
+// SaveTemplate handles admin requests to save a new version of the checklist
+func (h *ChecklistHandler) SaveTemplate(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("ChecklistHandler")
+	ctx, span := tracer.Start(r.Context(), "SaveTemplate-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	adminID := middleware.UserIDFromContext(ctx)
+	if adminID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.ChecklistTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	tmpl, err := h.checklistService.SaveTemplate(ctx, req, adminID)
+	if err != nil {
+		writeChecklistError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, tmpl, checklistLinks(tmpl.Version))
+}
+
+// writeChecklistError maps checklist service errors to HTTP status codes
+func writeChecklistError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no checklist template found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "changed by someone else"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must") ||
+		strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "more than once"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// checklistLinks returns the related-resource links of a checklist version
+func checklistLinks(version int) response.Links {
+	return response.Links{
+		"self":    "/checklist-templates/" + strconv.Itoa(version),
+		"current": "/checklist-templates/current",
+	}
+}
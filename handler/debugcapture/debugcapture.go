@@ -0,0 +1,95 @@
+package debugcapture
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// DebugCaptureHandler handles HTTP requests for admins reading debug captures
+type DebugCaptureHandler struct {
+	debugCaptureService service.DebugCaptureServiceInterface
+}
+
+// NewDebugCaptureHandler creates a new debug capture handler
+func NewDebugCaptureHandler(debugCaptureService service.DebugCaptureServiceInterface) *DebugCaptureHandler {
+	return &DebugCaptureHandler{
+		debugCaptureService: debugCaptureService,
+	}
+}
+
+// ListDebugCaptures handles requests for the unexpired captures, filtered by user, path or status
+func (h *DebugCaptureHandler) ListDebugCaptures(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("DebugCaptureHandler")
+	ctx, span := tracer.Start(r.Context(), "ListDebugCaptures-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	query := r.URL.Query()
+	page, err := models.ParsePageRequest(query.Get("limit"), query.Get("cursor"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := models.DebugCaptureFilter{
+		UserID: query.Get("user_id"),
+		Path:   query.Get("path"),
+	}
+	if raw := query.Get("min_status"); raw != "" {
+		if filter.MinStatus, err = strconv.Atoi(raw); err != nil || filter.MinStatus < 100 || filter.MinStatus > 599 {
+			http.Error(w, "min_status must be an HTTP status code such as 500", http.StatusBadRequest)
+			return
+		}
+	}
+
+	captures, err := h.debugCaptureService.ListCaptures(ctx, filter, page)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid user_id") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.List(w, r, captures)
+}
+
+// GetDebugCaptureByID handles requests for a single capture
+func (h *DebugCaptureHandler) GetDebugCaptureByID(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("DebugCaptureHandler")
+	ctx, span := tracer.Start(r.Context(), "GetDebugCaptureByID-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	capture, err := h.debugCaptureService.GetCapture(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		if strings.Contains(err.Error(), "no debug capture found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	links := response.Links{"self": "/admin/debug-captures/" + capture.ID.String()}
+	if capture.UserID != nil {
+		links["user_captures"] = "/admin/debug-captures?user_id=" + capture.UserID.String()
+	}
+	response.Resource(w, r, http.StatusOK, capture, links)
+}
@@ -0,0 +1,150 @@
+package dispute
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// DisputeHandler handles admin HTTP requests for payment disputes
+type DisputeHandler struct {
+	disputeService service.DisputeServiceInterface
+}
+
+// NewDisputeHandler creates a new dispute handler
+func NewDisputeHandler(disputeService service.DisputeServiceInterface) *DisputeHandler {
+	return &DisputeHandler{
+		disputeService: disputeService,
+	}
+}
+
+// ListDisputes handles requests for disputes, optionally narrowed with ?status=
+func (h *DisputeHandler) ListDisputes(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("DisputeHandler")
+	ctx, span := tracer.Start(r.Context(), "ListDisputes-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	filter := models.DisputeFilter{Status: models.DisputeStatus(r.URL.Query().Get("status"))}
+	disputes, err := h.disputeService.ListDisputes(ctx, filter)
+	if err != nil {
+		writeDisputeError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, disputes, nil)
+}
+
+// GetDispute handles requests for a dispute with its evidence
+func (h *DisputeHandler) GetDispute(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("DisputeHandler")
+	ctx, span := tracer.Start(r.Context(), "GetDispute-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	dispute, err := h.disputeService.GetDispute(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		writeDisputeError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, dispute, disputeLinks(*dispute))
+}
+
+// AddEvidence handles requests to attach a document to a dispute
+func (h *DisputeHandler) AddEvidence(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("DisputeHandler")
+	ctx, span := tracer.Start(r.Context(), "AddEvidence-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.DisputeEvidenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	dispute, err := h.disputeService.AddEvidence(ctx, mux.Vars(r)["id"], userID, req)
+	if err != nil {
+		writeDisputeError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, dispute, disputeLinks(*dispute))
+}
+
+// RecordOutcome handles requests to record how a dispute ended
+func (h *DisputeHandler) RecordOutcome(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("DisputeHandler")
+	ctx, span := tracer.Start(r.Context(), "RecordOutcome-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.DisputeOutcomeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	dispute, err := h.disputeService.RecordOutcome(ctx, mux.Vars(r)["id"], req)
+	if err != nil {
+		writeDisputeError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, dispute, disputeLinks(*dispute))
+}
+
+// writeDisputeError maps dispute service errors to HTTP status codes
+func writeDisputeError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no dispute found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already resolved"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must") ||
+		strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// disputeLinks returns the related-resource links of a dispute
+func disputeLinks(dispute models.Dispute) response.Links {
+	return response.Links{
+		"self":     "/admin/disputes/" + dispute.ID.String(),
+		"payment":  "/admin/payments/" + dispute.PaymentID.String(),
+		"booking":  "/bookings/" + dispute.BookingID.String(),
+		"disputes": "/admin/disputes",
+	}
+}
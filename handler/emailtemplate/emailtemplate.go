@@ -0,0 +1,229 @@
+package emailtemplate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"go.opentelemetry.io/otel"
+)
+
+// EmailTemplateHandler handles HTTP requests for the admin e-mail template API
+type EmailTemplateHandler struct {
+	templateService service.EmailTemplateServiceInterface
+}
+
+// NewEmailTemplateHandler creates a new e-mail template handler
+func NewEmailTemplateHandler(templateService service.EmailTemplateServiceInterface) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// ListTemplates handles requests for the current version of every template
+func (h *EmailTemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("EmailTemplateHandler")
+	ctx, span := tracer.Start(r.Context(), "ListTemplates-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	templates, err := h.templateService.ListTemplates(ctx)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, templates, nil)
+}
+
+// GetTemplate handles requests for the current version of a template
+func (h *EmailTemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("EmailTemplateHandler")
+	ctx, span := tracer.Start(r.Context(), "GetTemplate-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	tmpl, err := h.templateService.GetTemplate(ctx, mux.Vars(r)["key"])
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, tmpl, templateLinks(tmpl.Key))
+}
+
+// ListTemplateVersions handles requests for the history of a template
+func (h *EmailTemplateHandler) ListTemplateVersions(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("EmailTemplateHandler")
+	ctx, span := tracer.Start(r.Context(), "ListTemplateVersions-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	key := mux.Vars(r)["key"]
+	versions, err := h.templateService.ListTemplateVersions(ctx, key)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, versions, response.Links{
+		"template": "/admin/email-templates/" + key,
+	})
+}
+
+// CreateTemplate handles requests to add a template for a new key
+func (h *EmailTemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("EmailTemplateHandler")
+	ctx, span := tracer.Start(r.Context(), "CreateTemplate-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	adminID := middleware.UserIDFromContext(ctx)
+	if adminID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.EmailTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	tmpl, err := h.templateService.CreateTemplate(ctx, req, adminID)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	links := templateLinks(tmpl.Key)
+	links["self"] = "/admin/email-templates/" + tmpl.Key
+	response.Resource(w, r, http.StatusCreated, tmpl, links)
+}
+
+// UpdateTemplate handles requests to save a new version of a template
+func (h *EmailTemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("EmailTemplateHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateTemplate-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	adminID := middleware.UserIDFromContext(ctx)
+	if adminID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.EmailTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	tmpl, err := h.templateService.UpdateTemplate(ctx, mux.Vars(r)["key"], req, adminID)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, tmpl, templateLinks(tmpl.Key))
+}
+
+// DeleteTemplate handles requests to remove a template, reverting its key to the built-in copy
+func (h *EmailTemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("EmailTemplateHandler")
+	ctx, span := tracer.Start(r.Context(), "DeleteTemplate-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	if err := h.templateService.DeleteTemplate(ctx, mux.Vars(r)["key"]); err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PreviewTemplate handles requests to render a template or a draft of it with sample variables
+func (h *EmailTemplateHandler) PreviewTemplate(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("EmailTemplateHandler")
+	ctx, span := tracer.Start(r.Context(), "PreviewTemplate-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	// An empty body previews the current template with its sample variables
+	var req models.EmailTemplatePreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	key := mux.Vars(r)["key"]
+	rendered, err := h.templateService.PreviewTemplate(ctx, key, req)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, rendered, templateLinks(key))
+}
+
+// writeTemplateError maps e-mail template service errors to HTTP status codes
+func writeTemplateError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no email template found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "changed by someone else"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must") ||
+		strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "cannot render"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// templateLinks returns the related-resource links of a template key
+func templateLinks(key string) response.Links {
+	return response.Links{
+		"template":  "/admin/email-templates/" + key,
+		"versions":  "/admin/email-templates/" + key + "/versions",
+		"preview":   "/admin/email-templates/" + key + "/preview",
+		"templates": "/admin/email-templates",
+	}
+}
@@ -0,0 +1,134 @@
+package favorite
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// FavoriteHandler handles HTTP requests for users' wishlists of cars
+type FavoriteHandler struct {
+	favoriteService service.FavoriteServiceInterface
+}
+
+// NewFavoriteHandler creates a new favorite handler
+func NewFavoriteHandler(favoriteService service.FavoriteServiceInterface) *FavoriteHandler {
+	return &FavoriteHandler{
+		favoriteService: favoriteService,
+	}
+}
+
+// AddFavorite handles requests to save a car to the user's wishlist. Saving a car that is
+// already saved answers 200 with the original favorite instead of 201.
+func (h *FavoriteHandler) AddFavorite(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FavoriteHandler")
+	ctx, span := tracer.Start(r.Context(), "AddFavorite-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	favorite, created, err := h.favoriteService.AddFavorite(ctx, userID, mux.Vars(r)["carID"])
+	if err != nil {
+		writeFavoriteError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	response.Resource(w, r, status, favorite, favoriteLinks(*favorite))
+}
+
+// RemoveFavorite handles requests to remove a car from the user's wishlist
+func (h *FavoriteHandler) RemoveFavorite(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FavoriteHandler")
+	ctx, span := tracer.Start(r.Context(), "RemoveFavorite-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.favoriteService.RemoveFavorite(ctx, userID, mux.Vars(r)["carID"]); err != nil {
+		writeFavoriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListFavorites handles requests for the user's wishlist
+func (h *FavoriteHandler) ListFavorites(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FavoriteHandler")
+	ctx, span := tracer.Start(r.Context(), "ListFavorites-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	page, err := models.ParsePageRequest(query.Get("limit"), query.Get("cursor"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	favorites, err := h.favoriteService.ListFavorites(ctx, userID, page)
+	if err != nil {
+		writeFavoriteError(w, err)
+		return
+	}
+
+	response.List(w, r, favorites)
+}
+
+// writeFavoriteError maps service errors to HTTP status codes
+func writeFavoriteError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no car found"),
+		strings.Contains(err.Error(), "no favorite found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// favoriteLinks returns the related-resource links of a favorite
+func favoriteLinks(favorite models.Favorite) response.Links {
+	return response.Links{
+		"self":      "/users/me/favorites/" + favorite.CarID.String(),
+		"car":       "/cars/" + favorite.CarID.String(),
+		"favorites": "/users/me/favorites",
+	}
+}
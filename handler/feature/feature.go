@@ -0,0 +1,117 @@
+package feature
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// FeatureHandler handles HTTP requests for the curated features taxonomy
+type FeatureHandler struct {
+	featureService service.FeatureServiceInterface
+}
+
+// NewFeatureHandler creates a new feature handler
+func NewFeatureHandler(featureService service.FeatureServiceInterface) *FeatureHandler {
+	return &FeatureHandler{
+		featureService: featureService,
+	}
+}
+
+// GetFeatures handles requests to list the features cars may offer and be filtered by
+func (h *FeatureHandler) GetFeatures(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FeatureHandler")
+	ctx, span := tracer.Start(r.Context(), "GetFeatures-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	features, err := h.featureService.ListFeatures(ctx)
+	if err != nil {
+		writeFeatureError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, features, response.Links{
+		"cars": "/cars",
+	})
+}
+
+// CreateFeature handles requests to add a feature to the taxonomy (admin only)
+func (h *FeatureHandler) CreateFeature(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FeatureHandler")
+	ctx, span := tracer.Start(r.Context(), "CreateFeature-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.FeatureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	feature, err := h.featureService.CreateFeature(ctx, req)
+	if err != nil {
+		writeFeatureError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, feature, response.Links{
+		"features": "/features",
+	})
+}
+
+// UpdateFeature handles requests to replace a feature's name and aliases (admin only)
+func (h *FeatureHandler) UpdateFeature(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FeatureHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateFeature-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.FeatureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	feature, err := h.featureService.UpdateFeature(ctx, mux.Vars(r)["key"], req)
+	if err != nil {
+		writeFeatureError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, feature, response.Links{
+		"features": "/features",
+	})
+}
+
+// writeFeatureError maps feature service errors to HTTP status codes
+func writeFeatureError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no feature found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
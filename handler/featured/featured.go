@@ -0,0 +1,139 @@
+package featured
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// FeaturedHandler handles HTTP requests for featured placement
+type FeaturedHandler struct {
+	featuredService service.FeaturedServiceInterface
+}
+
+// NewFeaturedHandler creates a new featured listing handler
+func NewFeaturedHandler(featuredService service.FeaturedServiceInterface) *FeaturedHandler {
+	return &FeaturedHandler{
+		featuredService: featuredService,
+	}
+}
+
+// PurchaseFeatured handles requests to buy featured placement for a car (car owner or admin)
+func (h *FeaturedHandler) PurchaseFeatured(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FeaturedHandler")
+	ctx, span := tracer.Start(r.Context(), "PurchaseFeatured-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.FeaturedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	purchase, err := h.featuredService.PurchaseFeatured(ctx, userID, middleware.RoleFromContext(ctx), mux.Vars(r)["id"], req)
+	if err != nil {
+		writeFeaturedError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, purchase, response.Links{
+		"car":    "/cars/" + purchase.Featured.CarID.String(),
+		"verify": "/featured/" + purchase.Featured.ID.String() + "/verify",
+	})
+}
+
+// ConfirmFeatured handles the Razorpay checkout result of a featured placement
+func (h *FeaturedHandler) ConfirmFeatured(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FeaturedHandler")
+	ctx, span := tracer.Start(r.Context(), "ConfirmFeatured-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.PaymentVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	featured, err := h.featuredService.ConfirmFeatured(ctx, userID, middleware.RoleFromContext(ctx), mux.Vars(r)["id"], req)
+	if err != nil {
+		writeFeaturedError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, featured, response.Links{
+		"car":      "/cars/" + featured.CarID.String(),
+		"featured": "/owners/me/featured",
+	})
+}
+
+// GetOwnerFeatured handles requests to list the owner's placements with impressions and clicks
+func (h *FeaturedHandler) GetOwnerFeatured(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FeaturedHandler")
+	ctx, span := tracer.Start(r.Context(), "GetOwnerFeatured-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	listings, err := h.featuredService.GetOwnerFeatured(ctx, ownerID)
+	if err != nil {
+		writeFeaturedError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, listings, nil)
+}
+
+// writeFeaturedError maps featured listing service errors to HTTP status codes
+func writeFeaturedError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "no featured listing found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already featured"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "must") ||
+		strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "cannot") ||
+		strings.Contains(err.Error(), "only active") || strings.Contains(err.Error(), "does not belong") ||
+		strings.Contains(err.Error(), "verification failed"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,249 @@
+package fleet
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// FleetHandler handles HTTP requests for owners' fleets and their membership
+type FleetHandler struct {
+	fleetService service.FleetServiceInterface
+}
+
+// NewFleetHandler creates a new fleet handler
+func NewFleetHandler(fleetService service.FleetServiceInterface) *FleetHandler {
+	return &FleetHandler{
+		fleetService: fleetService,
+	}
+}
+
+// CreateFleet handles requests to create a fleet with shared settings
+func (h *FleetHandler) CreateFleet(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FleetHandler")
+	ctx, span := tracer.Start(r.Context(), "CreateFleet-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.FleetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	fleet, err := h.fleetService.CreateFleet(ctx, ownerID, req)
+	if err != nil {
+		writeFleetError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, fleet, fleetLinks(*fleet))
+}
+
+// GetFleets handles requests to list the owner's fleets
+func (h *FleetHandler) GetFleets(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FleetHandler")
+	ctx, span := tracer.Start(r.Context(), "GetFleets-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	fleets, err := h.fleetService.GetFleets(ctx, ownerID)
+	if err != nil {
+		writeFleetError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, fleets, nil)
+}
+
+// GetFleet handles requests for one of the owner's fleets
+func (h *FleetHandler) GetFleet(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FleetHandler")
+	ctx, span := tracer.Start(r.Context(), "GetFleet-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	fleet, err := h.fleetService.GetFleet(ctx, ownerID, mux.Vars(r)["id"])
+	if err != nil {
+		writeFleetError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, fleet, fleetLinks(*fleet))
+}
+
+// UpdateFleet handles requests to replace a fleet's name and settings
+func (h *FleetHandler) UpdateFleet(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FleetHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateFleet-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.FleetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	fleet, err := h.fleetService.UpdateFleet(ctx, ownerID, mux.Vars(r)["id"], req)
+	if err != nil {
+		writeFleetError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, fleet, fleetLinks(*fleet))
+}
+
+// DeleteFleet handles requests to remove a fleet
+func (h *FleetHandler) DeleteFleet(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FleetHandler")
+	ctx, span := tracer.Start(r.Context(), "DeleteFleet-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.fleetService.DeleteFleet(ctx, ownerID, mux.Vars(r)["id"]); err != nil {
+		writeFleetError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddCar handles requests to move one of the owner's cars into a fleet
+func (h *FleetHandler) AddCar(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FleetHandler")
+	ctx, span := tracer.Start(r.Context(), "AddCar-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.FleetMembershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	fleet, err := h.fleetService.AddCar(ctx, ownerID, mux.Vars(r)["id"], req.CarID.String())
+	if err != nil {
+		writeFleetError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, fleet, fleetLinks(*fleet))
+}
+
+// RemoveCar handles requests to take a car out of a fleet
+func (h *FleetHandler) RemoveCar(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("FleetHandler")
+	ctx, span := tracer.Start(r.Context(), "RemoveCar-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.fleetService.RemoveCar(ctx, ownerID, vars["id"], vars["carID"]); err != nil {
+		writeFleetError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeFleetError maps fleet service errors to HTTP status codes
+func writeFleetError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no fleet found") || strings.Contains(err.Error(), "no car found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already exists"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must") ||
+		strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// fleetLinks returns the related-resource links of a fleet
+func fleetLinks(fleet models.Fleet) response.Links {
+	return response.Links{
+		"self":   "/owners/me/fleets/" + fleet.ID.String(),
+		"cars":   "/owners/me/fleets/" + fleet.ID.String() + "/cars",
+		"fleets": "/owners/me/fleets",
+	}
+}
@@ -0,0 +1,227 @@
+package incident
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// IncidentHandler handles HTTP requests for incidents reported against bookings
+type IncidentHandler struct {
+	incidentService service.IncidentServiceInterface
+}
+
+// NewIncidentHandler creates a new incident handler
+func NewIncidentHandler(incidentService service.IncidentServiceInterface) *IncidentHandler {
+	return &IncidentHandler{
+		incidentService: incidentService,
+	}
+}
+
+// ReportIncident handles requests to report an incident during a booking
+func (h *IncidentHandler) ReportIncident(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("IncidentHandler")
+	ctx, span := tracer.Start(r.Context(), "ReportIncident-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.IncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	incident, err := h.incidentService.ReportIncident(ctx, userID, middleware.RoleFromContext(ctx), mux.Vars(r)["id"], req)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, incident, incidentLinks(*incident))
+}
+
+// GetBookingIncidents handles requests for the incidents reported against a booking
+func (h *IncidentHandler) GetBookingIncidents(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("IncidentHandler")
+	ctx, span := tracer.Start(r.Context(), "GetBookingIncidents-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	bookingID := mux.Vars(r)["id"]
+	incidents, err := h.incidentService.GetBookingIncidents(ctx, userID, middleware.RoleFromContext(ctx), bookingID)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, incidents, response.Links{
+		"booking": "/bookings/" + bookingID,
+	})
+}
+
+// GetIncident handles requests for an incident with its documents
+func (h *IncidentHandler) GetIncident(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("IncidentHandler")
+	ctx, span := tracer.Start(r.Context(), "GetIncident-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	incident, err := h.incidentService.GetIncident(ctx, userID, middleware.RoleFromContext(ctx), mux.Vars(r)["id"])
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, incident, incidentLinks(*incident))
+}
+
+// AddDocument handles requests to attach an insurance, police or other document to an incident
+func (h *IncidentHandler) AddDocument(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("IncidentHandler")
+	ctx, span := tracer.Start(r.Context(), "AddDocument-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.IncidentDocumentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	incident, err := h.incidentService.AddIncidentDocument(ctx, userID, middleware.RoleFromContext(ctx), mux.Vars(r)["id"], req)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, incident, incidentLinks(*incident))
+}
+
+// UpdateStatus handles requests to move an incident on
+func (h *IncidentHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("IncidentHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateStatus-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.IncidentStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	incident, err := h.incidentService.UpdateIncidentStatus(ctx, userID, middleware.RoleFromContext(ctx), mux.Vars(r)["id"], req)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, incident, incidentLinks(*incident))
+}
+
+// ListIncidents handles admin requests for incidents, optionally narrowed with ?status=
+func (h *IncidentHandler) ListIncidents(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("IncidentHandler")
+	ctx, span := tracer.Start(r.Context(), "ListIncidents-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	filter := models.IncidentFilter{Status: models.IncidentStatus(r.URL.Query().Get("status"))}
+	incidents, err := h.incidentService.ListIncidents(ctx, filter)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, incidents, nil)
+}
+
+// writeIncidentError maps incident service errors to HTTP status codes
+func writeIncidentError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no incident found"), strings.Contains(err.Error(), "no booking found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "only admins"), strings.Contains(err.Error(), "only the car's owner"),
+		strings.Contains(err.Error(), "must be reported by"):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case strings.Contains(err.Error(), "can only be reported"), strings.Contains(err.Error(), "is already"),
+		strings.Contains(err.Error(), "invalid status transition"), strings.Contains(err.Error(), "changed by someone else"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "required"), strings.Contains(err.Error(), "must"),
+		strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// incidentLinks returns the related-resource links of an incident
+func incidentLinks(incident models.Incident) response.Links {
+	return response.Links{
+		"self":      "/incidents/" + incident.ID.String(),
+		"booking":   "/bookings/" + incident.BookingID.String(),
+		"car":       "/cars/" + incident.CarID.String(),
+		"documents": "/incidents/" + incident.ID.String() + "/documents",
+	}
+}
@@ -0,0 +1,96 @@
+package kyc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// KYCHandler handles HTTP requests for renters' verified identity data
+type KYCHandler struct {
+	kycService service.KYCServiceInterface
+}
+
+// NewKYCHandler creates a new KYC handler
+func NewKYCHandler(kycService service.KYCServiceInterface) *KYCHandler {
+	return &KYCHandler{
+		kycService: kycService,
+	}
+}
+
+// GetVerifiedKYC handles requests to read a user's verified identity data (admin only)
+func (h *KYCHandler) GetVerifiedKYC(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("KYCHandler")
+	ctx, span := tracer.Start(r.Context(), "GetVerifiedKYC-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := mux.Vars(r)["id"]
+	kyc, err := h.kycService.GetVerifiedKYC(ctx, userID)
+	if err != nil {
+		writeKYCError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, kyc, response.Links{
+		"self": "/admin/users/" + userID + "/kyc",
+	})
+}
+
+// VerifyKYC handles requests to record the dates read from a user's documents (admin only)
+func (h *KYCHandler) VerifyKYC(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("KYCHandler")
+	ctx, span := tracer.Start(r.Context(), "VerifyKYC-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	adminID := middleware.UserIDFromContext(ctx)
+	if adminID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.KYCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID := mux.Vars(r)["id"]
+	kyc, err := h.kycService.VerifyKYC(ctx, adminID, userID, req)
+	if err != nil {
+		writeKYCError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, kyc, response.Links{
+		"self": "/admin/users/" + userID + "/kyc",
+	})
+}
+
+// writeKYCError maps KYC service errors to HTTP status codes
+func writeKYCError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no verified KYC found") || strings.Contains(err.Error(), "no user found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "must"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
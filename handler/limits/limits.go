@@ -0,0 +1,39 @@
+package limits
+
+import (
+	"net/http"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/response"
+	"go.opentelemetry.io/otel"
+)
+
+// LimitsHandler describes the caller's rate limit quota
+type LimitsHandler struct{}
+
+// NewLimitsHandler creates a new limits handler
+func NewLimitsHandler() *LimitsHandler {
+	return &LimitsHandler{}
+}
+
+// GetLimits returns the caller's quota in the current window, as counted for this request
+func (h *LimitsHandler) GetLimits(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LimitsHandler")
+	_, span := tracer.Start(r.Context(), "GetLimits-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	status, ok := middleware.RateLimitFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Rate limiting is not enabled", http.StatusNotFound)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, status, response.Links{
+		"self": r.URL.RequestURI(),
+	})
+}
@@ -0,0 +1,182 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// LoadTestHandler handles HTTP requests for admins preparing load tests
+type LoadTestHandler struct {
+	loadTestService service.LoadTestServiceInterface
+}
+
+// NewLoadTestHandler creates a new load test handler
+func NewLoadTestHandler(loadTestService service.LoadTestServiceInterface) *LoadTestHandler {
+	return &LoadTestHandler{
+		loadTestService: loadTestService,
+	}
+}
+
+// CreateFixtures handles requests for a new run of disposable fixtures and the targets to load
+// them with. ?format=vegeta answers with the targets alone, one JSON target per line, for
+// vegeta attack -format=json; the run's URL is then in the Location header.
+func (h *LoadTestHandler) CreateFixtures(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LoadTestHandler")
+	ctx, span := tracer.Start(r.Context(), "CreateFixtures-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "vegeta" {
+		http.Error(w, "format must be json or vegeta", http.StatusBadRequest)
+		return
+	}
+
+	// An empty body creates a run of the default size
+	var req models.LoadTestFixtureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	baseURL := strings.TrimSuffix(req.BaseURL, "/")
+	if baseURL == "" {
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		baseURL = scheme + "://" + r.Host
+	} else if parsed, err := url.Parse(baseURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		http.Error(w, "base_url must be an http or https URL", http.StatusBadRequest)
+		return
+	}
+
+	fixtures, err := h.loadTestService.CreateFixtures(ctx, req)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "disabled") || strings.Contains(err.Error(), "only admins"):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case strings.Contains(err.Error(), "must be between") || strings.HasPrefix(err.Error(), "ttl must be"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			log.Println("Error creating load test fixtures:", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if fixtures.Targets, err = loadTestTargets(baseURL, fixtures); err != nil {
+		log.Println("Error signing load test targets:", err)
+		http.Error(w, "failed to sign in the load test renters", http.StatusInternalServerError)
+		return
+	}
+
+	self := "/admin/loadtest/fixtures/" + fixtures.Run.ID.String()
+	if format == "vegeta" {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Location", self)
+		w.WriteHeader(http.StatusCreated)
+		encoder := json.NewEncoder(w)
+		for _, target := range fixtures.Targets {
+			if err := encoder.Encode(target); err != nil {
+				log.Println("Error writing load test targets:", err)
+				return
+			}
+		}
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, fixtures, response.Links{
+		"self":    self,
+		"budgets": "/admin/loadtest/budgets",
+	})
+}
+
+// DeleteFixtures handles requests to delete a run with its users, cars and bookings
+func (h *LoadTestHandler) DeleteFixtures(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LoadTestHandler")
+	ctx, span := tracer.Start(r.Context(), "DeleteFixtures-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	if err := h.loadTestService.DeleteFixtures(ctx, mux.Vars(r)["id"]); err != nil {
+		if strings.Contains(err.Error(), "no load test run found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Println("Error deleting load test fixtures:", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetBudgets handles requests for the performance budgets of the hot methods
+func (h *LoadTestHandler) GetBudgets(w http.ResponseWriter, r *http.Request) {
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	response.Resource(w, r, http.StatusOK, models.PerformanceBudgets, response.Links{"self": "/admin/loadtest/budgets"})
+}
+
+// loadTestTargets lists the read requests a load test replays against the fixtures: each car's
+// page and conflict check, and listings filtered the way the budgeted methods serve.
+// Requests are sent as the renters in turn.
+func loadTestTargets(baseURL string, fixtures *models.LoadTestFixtures) ([]models.LoadTestTarget, error) {
+	headers := make([]http.Header, len(fixtures.Renters))
+	for i, renter := range fixtures.Renters {
+		token, err := middleware.IssueToken(renter)
+		if err != nil {
+			return nil, err
+		}
+		headers[i] = http.Header{"Authorization": []string{"Bearer " + token}}
+	}
+
+	// The first booking of every car runs from day 30 to day 33, so this period conflicts
+	start := time.Now().UTC().AddDate(0, 0, 30).Format("2006-01-02")
+	end := time.Now().UTC().AddDate(0, 0, 33).Format("2006-01-02")
+	period := url.Values{"start": {start}, "end": {end}}.Encode()
+
+	paths := []string{"/cars?limit=20", "/cars?limit=20&" + period}
+	searched := map[string]bool{}
+	for _, car := range fixtures.Cars {
+		search := url.Values{"brand": {car.Brand}, "city": {car.LocationCity}}.Encode()
+		if !searched[search] {
+			searched[search] = true
+			paths = append(paths, "/cars/search?"+search)
+		}
+		paths = append(paths,
+			"/cars/"+car.ID.String(),
+			fmt.Sprintf("/cars/%s/conflicts?%s", car.ID, period))
+	}
+
+	targets := make([]models.LoadTestTarget, len(paths))
+	for i, path := range paths {
+		targets[i] = models.LoadTestTarget{Method: http.MethodGet, URL: baseURL + path, Header: headers[i%len(headers)]}
+	}
+	return targets, nil
+}
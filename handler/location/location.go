@@ -0,0 +1,388 @@
+package location
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// LocationHandler handles HTTP requests for pickup/drop-off locations
+type LocationHandler struct {
+	locationService service.LocationServiceInterface
+}
+
+// NewLocationHandler creates a new location handler
+func NewLocationHandler(locationService service.LocationServiceInterface) *LocationHandler {
+	return &LocationHandler{
+		locationService: locationService,
+	}
+}
+
+// GetLocations handles requests to list locations, optionally filtered by ?city=.
+// Admins may add ?include_inactive=true to see disabled locations.
+func (h *LocationHandler) GetLocations(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "GetLocations-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	query := r.URL.Query()
+	includeInactive := query.Get("include_inactive") == "true" && middleware.RoleFromContext(ctx) == "admin"
+
+	locations, err := h.locationService.ListLocations(ctx, query.Get("city"), includeInactive)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, locations, nil)
+}
+
+// GetLocationByID handles requests for a single location
+func (h *LocationHandler) GetLocationByID(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "GetLocationByID-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	location, err := h.locationService.GetLocationByID(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, location, locationLinks(*location))
+}
+
+// CreateLocation handles requests to add a location (admin only)
+func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "CreateLocation-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.LocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := models.ValidateLocationRequest(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	location, err := h.locationService.CreateLocation(ctx, req)
+	if err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, location, locationLinks(*location))
+}
+
+// UpdateLocation handles requests to change a location (admin only)
+func (h *LocationHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateLocation-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.LocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := models.ValidateLocationRequest(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	location, err := h.locationService.UpdateLocation(ctx, mux.Vars(r)["id"], req)
+	if err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, location, locationLinks(*location))
+}
+
+// DeleteLocation handles requests to remove a location (admin only)
+func (h *LocationHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "DeleteLocation-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	if err := h.locationService.DeleteLocation(ctx, mux.Vars(r)["id"]); err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCarLocations handles requests for the locations a car can be picked up from or returned to
+func (h *LocationHandler) GetCarLocations(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "GetCarLocations-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	carID := mux.Vars(r)["id"]
+	locations, err := h.locationService.GetCarLocations(ctx, carID)
+	if err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, locations, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// AttachCar handles requests to make a car available at a location (car owner or admin)
+func (h *LocationHandler) AttachCar(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "AttachCar-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.locationService.AttachCar(ctx, userID, middleware.RoleFromContext(ctx), vars["id"], vars["locationID"]); err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DetachCar handles requests to remove a car from a location (car owner or admin)
+func (h *LocationHandler) DetachCar(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "DetachCar-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.locationService.DetachCar(ctx, userID, middleware.RoleFromContext(ctx), vars["id"], vars["locationID"]); err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeliveryOption handles requests for a car's door delivery settings
+func (h *LocationHandler) GetDeliveryOption(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "GetDeliveryOption-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	carID := mux.Vars(r)["id"]
+	option, err := h.locationService.GetDeliveryOption(ctx, carID)
+	if err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, option, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// UpdateDeliveryOption handles requests to configure door delivery for a car (car owner or admin)
+func (h *LocationHandler) UpdateDeliveryOption(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateDeliveryOption-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.DeliveryOptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := models.ValidateDeliveryOptionRequest(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	carID := mux.Vars(r)["id"]
+	option, err := h.locationService.UpdateDeliveryOption(ctx, userID, middleware.RoleFromContext(ctx), carID, req)
+	if err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, option, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// GetRelocationFees handles requests for the one-way fee matrix
+func (h *LocationHandler) GetRelocationFees(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "GetRelocationFees-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	fees, err := h.locationService.ListRelocationFees(ctx)
+	if err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, fees, response.Links{
+		"locations": "/locations",
+	})
+}
+
+// SetRelocationFee handles requests to set the one-way fee of a city pair (admin only)
+func (h *LocationHandler) SetRelocationFee(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "SetRelocationFee-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.RelocationFeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := models.ValidateRelocationFeeRequest(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fee, err := h.locationService.SetRelocationFee(ctx, req)
+	if err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, fee, response.Links{
+		"relocation_fees": "/relocation-fees",
+	})
+}
+
+// DeleteRelocationFee handles requests to stop offering one-way rentals between a city pair (admin only)
+func (h *LocationHandler) DeleteRelocationFee(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LocationHandler")
+	ctx, span := tracer.Start(r.Context(), "DeleteRelocationFee-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	if err := h.locationService.DeleteRelocationFee(ctx, mux.Vars(r)["id"]); err != nil {
+		writeLocationError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeLocationError maps service errors to HTTP status codes
+func writeLocationError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no location found"),
+		strings.Contains(err.Error(), "no relocation fee found"),
+		strings.Contains(err.Error(), "no car found"),
+		strings.Contains(err.Error(), "not attached"),
+		strings.Contains(err.Error(), "delivery is not offered"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already exists"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// locationLinks returns the related-resource links of a location
+func locationLinks(location models.Location) response.Links {
+	return response.Links{
+		"self":      "/locations/" + location.ID.String(),
+		"locations": "/locations?city=" + url.QueryEscape(location.City),
+	}
+}
@@ -0,0 +1,131 @@
+package maintenance
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// MaintenanceHandler handles HTTP requests for the admin operational runbook
+type MaintenanceHandler struct {
+	maintenanceService service.MaintenanceServiceInterface
+}
+
+// NewMaintenanceHandler creates a new maintenance handler
+func NewMaintenanceHandler(maintenanceService service.MaintenanceServiceInterface) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// RequestJob handles an admin queuing a maintenance task. A new job is answered with 202; the
+// same task already queued or running is answered with 200 and that job.
+func (h *MaintenanceHandler) RequestJob(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("MaintenanceHandler")
+	ctx, span := tracer.Start(r.Context(), "RequestJob-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	// The body is optional for tasks that take no target
+	var req models.MaintenanceJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	job, created, err := h.maintenanceService.RequestJob(ctx, mux.Vars(r)["task"], userID, req)
+	if err != nil {
+		writeMaintenanceError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusAccepted
+	}
+	response.Resource(w, r, status, job, maintenanceJobLinks(*job))
+}
+
+// GetJob handles requests for a maintenance job and its outcome
+func (h *MaintenanceHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("MaintenanceHandler")
+	ctx, span := tracer.Start(r.Context(), "GetJob-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	job, err := h.maintenanceService.GetJob(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		writeMaintenanceError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, job, maintenanceJobLinks(*job))
+}
+
+// ListJobs handles requests for the latest maintenance jobs, optionally filtered by task
+func (h *MaintenanceHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("MaintenanceHandler")
+	ctx, span := tracer.Start(r.Context(), "ListJobs-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	jobs, err := h.maintenanceService.ListJobs(ctx, r.URL.Query().Get("task"))
+	if err != nil {
+		writeMaintenanceError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, jobs, response.Links{
+		"self": "/admin/maintenance/jobs",
+	})
+}
+
+// writeMaintenanceError maps service errors to HTTP status codes
+func writeMaintenanceError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no maintenance job found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "unknown maintenance task"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "target_id"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case strings.Contains(err.Error(), "session does not identify"):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// maintenanceJobLinks returns the related-resource links of a maintenance job
+func maintenanceJobLinks(job models.MaintenanceJob) response.Links {
+	return response.Links{
+		"self": "/admin/maintenance/jobs/" + job.ID.String(),
+		"task": "/admin/maintenance/jobs?task=" + string(job.Task),
+	}
+}
@@ -0,0 +1,121 @@
+package notification
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// NotificationHandler handles HTTP requests for the in-app notification inbox
+type NotificationHandler struct {
+	inboxService service.InboxServiceInterface
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(inboxService service.InboxServiceInterface) *NotificationHandler {
+	return &NotificationHandler{
+		inboxService: inboxService,
+	}
+}
+
+// ListNotifications handles requests for the user's inbox, optionally only unread notifications
+func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("NotificationHandler")
+	ctx, span := tracer.Start(r.Context(), "ListNotifications-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	page, err := models.ParsePageRequest(query.Get("limit"), query.Get("cursor"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := models.NotificationFilter{UnreadOnly: query.Get("unread") == "true"}
+
+	notifications, err := h.inboxService.ListNotifications(ctx, userID, filter, page)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.List(w, r, notifications)
+}
+
+// MarkRead handles requests to mark a notification as read
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("NotificationHandler")
+	ctx, span := tracer.Start(r.Context(), "MarkRead-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	notification, err := h.inboxService.MarkRead(ctx, userID, mux.Vars(r)["id"])
+	if err != nil {
+		if strings.Contains(err.Error(), "no notification found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, notification, response.Links{
+		"self":          "/notifications/" + notification.ID.String() + "/read",
+		"notifications": "/notifications",
+	})
+}
+
+// GetUnreadCount handles requests for the number of unread notifications behind the bell icon
+func (h *NotificationHandler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("NotificationHandler")
+	ctx, span := tracer.Start(r.Context(), "GetUnreadCount-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	unread, err := h.inboxService.CountUnread(ctx, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, models.UnreadNotificationCount{Unread: unread}, response.Links{
+		"unread": "/notifications?unread=true",
+	})
+}
@@ -0,0 +1,62 @@
+package onboarding
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"go.opentelemetry.io/otel"
+)
+
+// OnboardingHandler handles HTTP requests for owner onboarding progress
+type OnboardingHandler struct {
+	onboardingService service.OnboardingServiceInterface
+}
+
+// NewOnboardingHandler creates a new onboarding handler
+func NewOnboardingHandler(onboardingService service.OnboardingServiceInterface) *OnboardingHandler {
+	return &OnboardingHandler{
+		onboardingService: onboardingService,
+	}
+}
+
+// GetMyProgress handles requests for the signed-in owner's onboarding progress
+func (h *OnboardingHandler) GetMyProgress(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("OnboardingHandler")
+	ctx, span := tracer.Start(r.Context(), "GetMyProgress-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	progress, err := h.onboardingService.GetProgress(ctx, ownerID)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "user not found") || strings.Contains(err.Error(), "invalid owner ID"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			log.Println("Error retrieving onboarding progress:", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	links := response.Links{"self": "/owners/me/onboarding"}
+	for _, step := range progress.Steps {
+		if progress.NextStep != nil && step.Key == *progress.NextStep && step.Link != "" {
+			links["next"] = step.Link
+		}
+	}
+	response.Resource(w, r, http.StatusOK, progress, links)
+}
@@ -0,0 +1,167 @@
+package operator
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"go.opentelemetry.io/otel"
+)
+
+// OperatorHandler handles HTTP requests for marketplace operators
+type OperatorHandler struct {
+	operatorService service.OperatorServiceInterface
+}
+
+// NewOperatorHandler creates a new operator handler
+func NewOperatorHandler(operatorService service.OperatorServiceInterface) *OperatorHandler {
+	return &OperatorHandler{
+		operatorService: operatorService,
+	}
+}
+
+// GetCurrentOperator handles requests for the branding of the marketplace being served.
+// Front ends call it on load to theme themselves for white-label domains.
+func (h *OperatorHandler) GetCurrentOperator(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("OperatorHandler")
+	ctx, span := tracer.Start(r.Context(), "GetCurrentOperator-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	operator, err := h.operatorService.GetOperator(ctx, tenant.ForInsert(ctx).String())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, operator.Public(), response.Links{
+		"self": r.URL.RequestURI(),
+	})
+}
+
+// ListOperators handles requests to list every operator
+func (h *OperatorHandler) ListOperators(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("OperatorHandler")
+	ctx, span := tracer.Start(r.Context(), "ListOperators-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	operators, err := h.operatorService.ListOperators(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, operators, response.Links{
+		"self": r.URL.RequestURI(),
+	})
+}
+
+// GetOperator handles requests for a single operator
+func (h *OperatorHandler) GetOperator(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("OperatorHandler")
+	ctx, span := tracer.Start(r.Context(), "GetOperator-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	operator, err := h.operatorService.GetOperator(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		writeOperatorError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, operator, response.Links{
+		"self":      r.URL.RequestURI(),
+		"operators": "/admin/operators",
+	})
+}
+
+// CreateOperator handles requests to onboard a new operator
+func (h *OperatorHandler) CreateOperator(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("OperatorHandler")
+	ctx, span := tracer.Start(r.Context(), "CreateOperator-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.OperatorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	operator, err := h.operatorService.CreateOperator(ctx, req)
+	if err != nil {
+		writeOperatorError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, operator, response.Links{
+		"self": "/admin/operators/" + operator.ID.String(),
+	})
+}
+
+// UpdateOperator handles requests to change an operator's branding, domain or commission
+func (h *OperatorHandler) UpdateOperator(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("OperatorHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateOperator-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.OperatorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	operator, err := h.operatorService.UpdateOperator(ctx, mux.Vars(r)["id"], req)
+	if err != nil {
+		writeOperatorError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, operator, response.Links{
+		"self": r.URL.RequestURI(),
+	})
+}
+
+// writeOperatorError maps operator service errors to HTTP status codes
+func writeOperatorError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no operator found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already exists"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must") ||
+		strings.Contains(err.Error(), "cannot") || strings.Contains(err.Error(), "not a valid") ||
+		strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
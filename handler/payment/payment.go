@@ -2,10 +2,14 @@ package payment
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
-	"strconv"
+	"strings"
 
+	"github.com/PrateekKumar15/CarZone/middleware"
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
 	"github.com/PrateekKumar15/CarZone/service"
 	"github.com/gorilla/mux"
 	"go.opentelemetry.io/otel"
@@ -46,9 +50,10 @@ func (h *PaymentHandler) CreatePayment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(razorpayOrder)
+	response.Resource(w, r, http.StatusCreated, razorpayOrder, response.Links{
+		"booking": "/bookings/" + paymentReq.BookingID.String(),
+		"verify":  "/payments/verify",
+	})
 }
 
 // VerifyPayment handles payment verification requests
@@ -74,12 +79,9 @@ func (h *PaymentHandler) VerifyPayment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"message": "Payment verified successfully",
-		"payment": payment,
-	})
+	links := paymentLinks(*payment)
+	links["self"] = "/payments/" + payment.ID.String()
+	response.Resource(w, r, http.StatusOK, payment, links)
 }
 
 // GetPaymentByID handles requests to get a payment by ID
@@ -106,9 +108,7 @@ func (h *PaymentHandler) GetPaymentByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(payment)
+	response.Resource(w, r, http.StatusOK, payment, paymentLinks(*payment))
 }
 
 // GetPaymentByBookingID handles requests to get payment by booking ID
@@ -135,9 +135,7 @@ func (h *PaymentHandler) GetPaymentByBookingID(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(payment)
+	response.Resource(w, r, http.StatusOK, payment, paymentLinks(*payment))
 }
 
 // GetPaymentsByUserID handles requests to get payments by user ID
@@ -154,15 +152,19 @@ func (h *PaymentHandler) GetPaymentsByUserID(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	payments, err := h.paymentService.GetPaymentsByUserID(ctx, userID)
+	page, err := models.ParsePageRequest(r.URL.Query().Get("limit"), r.URL.Query().Get("cursor"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	payments, err := h.paymentService.ListPayments(ctx, models.PaymentFilter{UserID: userID}, page)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(payments)
+	response.List(w, r, payments)
 }
 
 // ProcessRefund handles refund requests
@@ -194,63 +196,181 @@ func (h *PaymentHandler) ProcessRefund(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"message": "Refund processed successfully",
-		"payment": payment,
-	})
+	links := paymentLinks(*payment)
+	links["payment"] = "/payments/" + payment.ID.String()
+	response.Resource(w, r, http.StatusOK, payment, links)
 }
 
-// GetAllPayments handles requests to get all payments
+// GetAllPayments handles requests to get all payments, one cursor page at a time
 func (h *PaymentHandler) GetAllPayments(w http.ResponseWriter, r *http.Request) {
 	tracer := otel.Tracer("PaymentHandler")
 	ctx, span := tracer.Start(r.Context(), "GetAllPayments-Handler")
 	defer span.End()
 
-	// Parse query parameters for pagination (optional)
-	limit := 50 // default limit
-	offset := 0 // default offset
+	page, err := models.ParsePageRequest(r.URL.Query().Get("limit"), r.URL.Query().Get("cursor"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
-		if l, err := strconv.Atoi(limitParam); err == nil && l > 0 {
-			limit = l
-		}
+	payments, err := h.paymentService.ListPayments(ctx, models.PaymentFilter{}, page)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.List(w, r, payments)
+}
+
+// RetryPayment handles requests to pay a failed payment again with a new Razorpay order
+func (h *PaymentHandler) RetryPayment(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("PaymentHandler")
+	ctx, span := tracer.Start(r.Context(), "RetryPayment-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
 	}
 
-	if offsetParam := r.URL.Query().Get("offset"); offsetParam != "" {
-		if o, err := strconv.Atoi(offsetParam); err == nil && o >= 0 {
-			offset = o
+	id := mux.Vars(r)["id"]
+	razorpayOrder, err := h.paymentService.RetryPayment(ctx, id, userID, middleware.RoleFromContext(ctx))
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "no payment found") || strings.Contains(err.Error(), "no booking found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case strings.Contains(err.Error(), "invalid payment ID"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case strings.Contains(err.Error(), "only") || strings.Contains(err.Error(), "cannot be retried") ||
+			strings.Contains(err.Error(), "no longer failed"):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case strings.Contains(err.Error(), "Razorpay"):
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
+		return
 	}
 
-	payments, err := h.paymentService.GetAllPayments(ctx)
+	response.Resource(w, r, http.StatusCreated, razorpayOrder, response.Links{
+		"payment": "/payments/" + id,
+		"verify":  "/payments/verify",
+	})
+}
+
+// GetPaymentDetail handles admin requests for a payment with its gateway attempt history
+func (h *PaymentHandler) GetPaymentDetail(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("PaymentHandler")
+	ctx, span := tracer.Start(r.Context(), "GetPaymentDetail-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	detail, err := h.paymentService.GetPaymentDetail(ctx, mux.Vars(r)["id"])
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		switch {
+		case strings.Contains(err.Error(), "no payment found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case strings.Contains(err.Error(), "invalid"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 
-	// Apply pagination (simple slice-based pagination for now)
-	totalPayments := len(*payments)
-	start := offset
-	end := offset + limit
+	links := paymentLinks(detail.Payment)
+	links["payment"] = "/payments/" + detail.Payment.ID.String()
+	response.Resource(w, r, http.StatusOK, detail, links)
+}
+
+// maxWebhookBodyBytes bounds a single Razorpay webhook delivery
+const maxWebhookBodyBytes = 1 << 20
 
-	if start > totalPayments {
-		start = totalPayments
+// CreatePaymentLink handles admin requests to send a customer a payment link for a booking
+// taken over the phone. The body is optional; the amount defaults to the booking total.
+func (h *PaymentHandler) CreatePaymentLink(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("PaymentHandler")
+	ctx, span := tracer.Start(r.Context(), "CreatePaymentLink-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
 	}
-	if end > totalPayments {
-		end = totalPayments
+
+	var req models.PaymentLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
 	}
 
-	paginatedPayments := (*payments)[start:end]
+	link, err := h.paymentService.CreatePaymentLink(ctx, mux.Vars(r)["id"], req)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "booking not found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "must") ||
+			strings.Contains(err.Error(), "only"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case strings.Contains(err.Error(), "Razorpay"):
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"payments": paginatedPayments,
-		"total":    totalPayments,
-		"limit":    limit,
-		"offset":   offset,
-		"has_more": end < totalPayments,
+	response.Resource(w, r, http.StatusCreated, link, response.Links{
+		"booking": "/bookings/" + link.BookingID.String(),
+		"payment": "/payments/" + link.PaymentID.String(),
 	})
 }
+
+// RazorpayWebhook handles payment link events posted by Razorpay. Razorpay authenticates with
+// the X-Razorpay-Signature header instead of a user session.
+func (h *PaymentHandler) RazorpayWebhook(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("PaymentHandler")
+	ctx, span := tracer.Start(r.Context(), "RazorpayWebhook-Handler")
+	defer span.End()
+
+	// The signature covers the raw bytes, so the body is read before decoding
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.paymentService.HandleRazorpayWebhook(ctx, r.Header.Get("X-Razorpay-Signature"), body); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "signature"):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case strings.Contains(err.Error(), "not configured"):
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		case strings.Contains(err.Error(), "invalid webhook payload"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// paymentLinks returns the related-resource links for a payment
+func paymentLinks(payment models.Payment) response.Links {
+	return response.Links{
+		"booking": "/bookings/" + payment.BookingID.String(),
+		"refund":  "/payments/" + payment.ID.String() + "/refund",
+	}
+}
@@ -0,0 +1,185 @@
+package payout
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// PayoutHandler handles HTTP requests for owner payout accounts
+type PayoutHandler struct {
+	payoutService service.PayoutServiceInterface
+}
+
+// NewPayoutHandler creates a new payout handler
+func NewPayoutHandler(payoutService service.PayoutServiceInterface) *PayoutHandler {
+	return &PayoutHandler{
+		payoutService: payoutService,
+	}
+}
+
+// RegisterPayoutAccount handles registration of a bank account or UPI ID for payouts
+func (h *PayoutHandler) RegisterPayoutAccount(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("PayoutHandler")
+	ctx, span := tracer.Start(r.Context(), "RegisterPayoutAccount-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.PayoutAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := models.ValidatePayoutAccountRequest(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	account, err := h.payoutService.RegisterPayoutAccount(ctx, ownerID, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, account, payoutAccountLinks(*account))
+}
+
+// GetPayoutAccounts handles requests to list the owner's payout accounts
+func (h *PayoutHandler) GetPayoutAccounts(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("PayoutHandler")
+	ctx, span := tracer.Start(r.Context(), "GetPayoutAccounts-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	accounts, err := h.payoutService.GetPayoutAccounts(ctx, ownerID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, accounts, nil)
+}
+
+// GetPayoutHolds handles requests for the holds disputes placed on the owner's payouts
+func (h *PayoutHandler) GetPayoutHolds(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("PayoutHandler")
+	ctx, span := tracer.Start(r.Context(), "GetPayoutHolds-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	holds, err := h.payoutService.GetPayoutHolds(ctx, ownerID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, holds, nil)
+}
+
+// VerifyPayoutAccount handles requests to refresh the verification status of a payout account
+func (h *PayoutHandler) VerifyPayoutAccount(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("PayoutHandler")
+	ctx, span := tracer.Start(r.Context(), "VerifyPayoutAccount-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	account, err := h.payoutService.VerifyPayoutAccount(ctx, ownerID, mux.Vars(r)["id"])
+	if err != nil {
+		writePayoutError(w, err)
+		return
+	}
+
+	links := payoutAccountLinks(*account)
+	links["self"] = r.URL.RequestURI()
+	response.Resource(w, r, http.StatusOK, account, links)
+}
+
+// DeletePayoutAccount handles removal of a payout account
+func (h *PayoutHandler) DeletePayoutAccount(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("PayoutHandler")
+	ctx, span := tracer.Start(r.Context(), "DeletePayoutAccount-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.payoutService.DeletePayoutAccount(ctx, ownerID, mux.Vars(r)["id"]); err != nil {
+		writePayoutError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writePayoutError maps service errors to HTTP status codes
+func writePayoutError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), "no payout account found") {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// payoutAccountLinks returns the related-resource links of a payout account
+func payoutAccountLinks(account models.PayoutAccount) response.Links {
+	return response.Links{
+		"self":   "/owners/me/payout-accounts/" + account.ID.String(),
+		"verify": "/owners/me/payout-accounts/" + account.ID.String() + "/verify",
+	}
+}
@@ -0,0 +1,57 @@
+package profile
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// profileCacheControl matches the public catalog: profiles change as slowly as listings
+const profileCacheControl = "public, max-age=60, s-maxage=300, stale-while-revalidate=600"
+
+// ProfileHandler handles HTTP requests for public owner profiles
+type ProfileHandler struct {
+	profileService service.ProfileServiceInterface
+}
+
+// NewProfileHandler creates a new profile handler
+func NewProfileHandler(profileService service.ProfileServiceInterface) *ProfileHandler {
+	return &ProfileHandler{
+		profileService: profileService,
+	}
+}
+
+// GetPublicProfile serves the unauthenticated, cacheable profile of a car owner
+func (h *ProfileHandler) GetPublicProfile(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("ProfileHandler")
+	ctx, span := tracer.Start(r.Context(), "GetPublicProfile-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == http.MethodOptions {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := mux.Vars(r)["id"]
+	profile, err := h.profileService.GetPublicProfile(ctx, ownerID)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "invalid"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case strings.Contains(err.Error(), "no owner found"):
+			http.Error(w, "Owner not found", http.StatusNotFound)
+		default:
+			log.Println("Error retrieving public profile:", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	links := response.Links{"self": r.URL.RequestURI(), "catalog": "/public/cars"}
+	response.Cached(w, r, response.Envelope{Data: profile, Links: links}, profileCacheControl)
+}
@@ -0,0 +1,48 @@
+package search
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"go.opentelemetry.io/otel"
+)
+
+// SearchHandler handles HTTP requests for admin support search
+type SearchHandler struct {
+	searchService service.SearchServiceInterface
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(searchService service.SearchServiceInterface) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// Search handles requests like ?q=jane@example.com, ?q=9876543210, ?q=KA01AB1234 or ?q=pay_29QQoUBi66xm2f
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SearchHandler")
+	ctx, span := tracer.Start(r.Context(), "Search-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	hits, err := h.searchService.Search(ctx, r.URL.Query().Get("q"))
+	if err != nil {
+		if strings.Contains(err.Error(), "search term") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, hits, response.Links{
+		"self": r.URL.RequestURI(),
+	})
+}
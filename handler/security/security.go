@@ -0,0 +1,141 @@
+package security
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// SecurityHandler handles HTTP requests for the admin security event review queue
+type SecurityHandler struct {
+	securityService service.SecurityServiceInterface
+}
+
+// NewSecurityHandler creates a new security handler
+func NewSecurityHandler(securityService service.SecurityServiceInterface) *SecurityHandler {
+	return &SecurityHandler{
+		securityService: securityService,
+	}
+}
+
+// ListSecurityEvents handles requests for the review queue, filtered by status, type or user
+func (h *SecurityHandler) ListSecurityEvents(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SecurityHandler")
+	ctx, span := tracer.Start(r.Context(), "ListSecurityEvents-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	query := r.URL.Query()
+	page, err := models.ParsePageRequest(query.Get("limit"), query.Get("cursor"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := models.SecurityEventFilter{
+		Status: query.Get("status"),
+		Type:   query.Get("type"),
+		UserID: query.Get("user_id"),
+	}
+
+	events, err := h.securityService.ListSecurityEvents(ctx, filter, page)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid user_id") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.List(w, r, events)
+}
+
+// GetSecurityEventByID handles requests for a single security event
+func (h *SecurityHandler) GetSecurityEventByID(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SecurityHandler")
+	ctx, span := tracer.Start(r.Context(), "GetSecurityEventByID-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	event, err := h.securityService.GetSecurityEventByID(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		writeSecurityError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, event, securityEventLinks(*event))
+}
+
+// ReviewSecurityEvent handles an admin closing a security event as reviewed or dismissed
+func (h *SecurityHandler) ReviewSecurityEvent(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SecurityHandler")
+	ctx, span := tracer.Start(r.Context(), "ReviewSecurityEvent-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	reviewerID := middleware.UserIDFromContext(ctx)
+	if reviewerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var review models.SecurityEventReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := models.ValidateSecurityEventReview(review); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.securityService.ReviewSecurityEvent(ctx, mux.Vars(r)["id"], reviewerID, review)
+	if err != nil {
+		writeSecurityError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, event, securityEventLinks(*event))
+}
+
+// writeSecurityError maps service errors to HTTP status codes
+func writeSecurityError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no security event found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already been reviewed"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// securityEventLinks returns the related-resource links of a security event
+func securityEventLinks(event models.SecurityEvent) response.Links {
+	return response.Links{
+		"self":        "/admin/security-events/" + event.ID.String(),
+		"review":      "/admin/security-events/" + event.ID.String() + "/review",
+		"user_events": "/admin/security-events?user_id=" + event.UserID.String(),
+	}
+}
@@ -0,0 +1,134 @@
+package setting
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"go.opentelemetry.io/otel"
+)
+
+// SettingHandler handles HTTP requests for operator settings
+type SettingHandler struct {
+	settingService service.SettingServiceInterface
+}
+
+// NewSettingHandler creates a new setting handler
+func NewSettingHandler(settingService service.SettingServiceInterface) *SettingHandler {
+	return &SettingHandler{
+		settingService: settingService,
+	}
+}
+
+// GetSearchRanking handles requests to read the weights ranked car listings use (admin only)
+func (h *SettingHandler) GetSearchRanking(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SettingHandler")
+	ctx, span := tracer.Start(r.Context(), "GetSearchRanking-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	weights, err := h.settingService.GetRankingWeights(ctx)
+	if err != nil {
+		writeSettingError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, weights, response.Links{
+		"ranked_cars": "/cars?sort=rank&explain=true",
+	})
+}
+
+// UpdateSearchRanking handles requests to replace the weights ranked car listings use (admin only)
+func (h *SettingHandler) UpdateSearchRanking(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SettingHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateSearchRanking-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.RankingWeights
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	weights, err := h.settingService.UpdateRankingWeights(ctx, req)
+	if err != nil {
+		writeSettingError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, weights, response.Links{
+		"ranked_cars": "/cars?sort=rank&explain=true",
+	})
+}
+
+// GetDepreciation handles requests to read the depreciation curves fleet valuations use (admin only)
+func (h *SettingHandler) GetDepreciation(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SettingHandler")
+	ctx, span := tracer.Start(r.Context(), "GetDepreciation-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	settings, err := h.settingService.GetDepreciationSettings(ctx)
+	if err != nil {
+		writeSettingError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, settings, response.Links{
+		"fleet_valuation": "/owners/me/fleet-valuation",
+	})
+}
+
+// UpdateDepreciation handles requests to replace the depreciation curves fleet valuations use (admin only)
+func (h *SettingHandler) UpdateDepreciation(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SettingHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateDepreciation-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.DepreciationSettings
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.settingService.UpdateDepreciationSettings(ctx, req)
+	if err != nil {
+		writeSettingError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, settings, response.Links{
+		"fleet_valuation": "/owners/me/fleet-valuation",
+	})
+}
+
+// writeSettingError maps setting service errors to HTTP status codes
+func writeSettingError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "must"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,73 @@
+package sitemap
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/service"
+	"go.opentelemetry.io/otel"
+)
+
+// sitemapCacheControl lets crawlers and CDNs keep the documents for an hour
+const sitemapCacheControl = "public, max-age=3600"
+
+// SitemapHandler serves search-engine documents for the public catalog
+type SitemapHandler struct {
+	service service.SitemapServiceInterface
+}
+
+// NewSitemapHandler creates a new SitemapHandler with the provided service
+func NewSitemapHandler(service service.SitemapServiceInterface) *SitemapHandler {
+	return &SitemapHandler{service: service}
+}
+
+// GetSitemap serves sitemap.xml
+func (h *SitemapHandler) GetSitemap(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SitemapHandler")
+	ctx, span := tracer.Start(r.Context(), "GetSitemap-Handler")
+	defer span.End()
+
+	body, generatedAt, err := h.service.Sitemap(ctx)
+	if err != nil {
+		log.Println("Error generating sitemap:", err)
+		http.Error(w, "Error generating sitemap", http.StatusInternalServerError)
+		return
+	}
+
+	writeDocument(w, r, "application/xml; charset=utf-8", body, generatedAt)
+}
+
+// GetFeed serves the JSON feed of active listings
+func (h *SitemapHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SitemapHandler")
+	ctx, span := tracer.Start(r.Context(), "GetFeed-Handler")
+	defer span.End()
+
+	body, generatedAt, err := h.service.Feed(ctx)
+	if err != nil {
+		log.Println("Error generating listings feed:", err)
+		http.Error(w, "Error generating feed", http.StatusInternalServerError)
+		return
+	}
+
+	writeDocument(w, r, "application/json", body, generatedAt)
+}
+
+// writeDocument writes a pre-rendered document with caching headers,
+// answering 304 when the client already has this generation
+func writeDocument(w http.ResponseWriter, r *http.Request, contentType string, body []byte, generatedAt time.Time) {
+	w.Header().Set("Cache-Control", sitemapCacheControl)
+	w.Header().Set("Last-Modified", generatedAt.UTC().Format(http.TimeFormat))
+
+	if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !generatedAt.Truncate(time.Second).After(since) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		log.Println("Error writing response:", err)
+	}
+}
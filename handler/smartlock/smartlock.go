@@ -0,0 +1,258 @@
+package smartlock
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// maxWebhookBodyBytes bounds a single lock event delivery
+const maxWebhookBodyBytes = 64 << 10
+
+// SmartLockHandler handles HTTP requests for smart locks, digital keys and lock events
+type SmartLockHandler struct {
+	smartLockService service.SmartLockServiceInterface
+}
+
+// NewSmartLockHandler creates a new smart lock handler
+func NewSmartLockHandler(smartLockService service.SmartLockServiceInterface) *SmartLockHandler {
+	return &SmartLockHandler{
+		smartLockService: smartLockService,
+	}
+}
+
+// GetSmartLock handles requests for the smart lock fitted in a car
+func (h *SmartLockHandler) GetSmartLock(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SmartLockHandler")
+	ctx, span := tracer.Start(r.Context(), "GetSmartLock-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	carID := mux.Vars(r)["id"]
+	lock, err := h.smartLockService.GetSmartLock(ctx, userID, middleware.RoleFromContext(ctx), carID)
+	if err != nil {
+		writeSmartLockError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, lock, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// SetSmartLock handles requests to link a car to its smart lock
+func (h *SmartLockHandler) SetSmartLock(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SmartLockHandler")
+	ctx, span := tracer.Start(r.Context(), "SetSmartLock-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.SmartLockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	carID := mux.Vars(r)["id"]
+	lock, err := h.smartLockService.SetSmartLock(ctx, userID, middleware.RoleFromContext(ctx), carID, req)
+	if err != nil {
+		writeSmartLockError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, lock, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// DeleteSmartLock handles requests to unlink a car from its smart lock
+func (h *SmartLockHandler) DeleteSmartLock(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SmartLockHandler")
+	ctx, span := tracer.Start(r.Context(), "DeleteSmartLock-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.smartLockService.DeleteSmartLock(ctx, userID, middleware.RoleFromContext(ctx), mux.Vars(r)["id"]); err != nil {
+		writeSmartLockError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDigitalKeys handles requests for the digital keys of a booking
+func (h *SmartLockHandler) GetDigitalKeys(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SmartLockHandler")
+	ctx, span := tracer.Start(r.Context(), "GetDigitalKeys-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	bookingID := mux.Vars(r)["id"]
+	keys, err := h.smartLockService.GetBookingDigitalKeys(ctx, userID, middleware.RoleFromContext(ctx), bookingID)
+	if err != nil {
+		writeSmartLockError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, keys, response.Links{
+		"booking":     "/bookings/" + bookingID,
+		"lock_events": "/bookings/" + bookingID + "/lock-events",
+	})
+}
+
+// ReissueDigitalKey handles requests to replace the digital key of a booking
+func (h *SmartLockHandler) ReissueDigitalKey(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SmartLockHandler")
+	ctx, span := tracer.Start(r.Context(), "ReissueDigitalKey-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	bookingID := mux.Vars(r)["id"]
+	key, err := h.smartLockService.ReissueBookingKey(ctx, userID, middleware.RoleFromContext(ctx), bookingID)
+	if err != nil {
+		writeSmartLockError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, key, response.Links{
+		"booking":      "/bookings/" + bookingID,
+		"digital_keys": "/bookings/" + bookingID + "/digital-keys",
+	})
+}
+
+// GetLockEvents handles requests for the lock and unlock events of a booking
+func (h *SmartLockHandler) GetLockEvents(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SmartLockHandler")
+	ctx, span := tracer.Start(r.Context(), "GetLockEvents-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	bookingID := mux.Vars(r)["id"]
+	events, err := h.smartLockService.GetBookingLockEvents(ctx, userID, middleware.RoleFromContext(ctx), bookingID)
+	if err != nil {
+		writeSmartLockError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, events, response.Links{
+		"booking":      "/bookings/" + bookingID,
+		"digital_keys": "/bookings/" + bookingID + "/digital-keys",
+	})
+}
+
+// Webhook handles lock and unlock events posted by smart-lock providers.
+// Providers sign each delivery with SMART_LOCK_WEBHOOK_SECRET in X-Smart-Lock-Signature.
+func (h *SmartLockHandler) Webhook(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("SmartLockHandler")
+	ctx, span := tracer.Start(r.Context(), "Webhook-Handler")
+	defer span.End()
+
+	// The signature covers the raw bytes, so the body is read before decoding
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.smartLockService.HandleWebhook(ctx, r.Header.Get("X-Smart-Lock-Signature"), body); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "signature"):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case strings.Contains(err.Error(), "not configured"):
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		case strings.Contains(err.Error(), "invalid webhook payload"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			log.Println("Error handling smart lock webhook:", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// writeSmartLockError maps smart lock service errors to HTTP status codes
+func writeSmartLockError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no car found"), strings.Contains(err.Error(), "no booking found"),
+		strings.Contains(err.Error(), "no smart lock found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already linked"), strings.Contains(err.Error(), "can only be issued"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "is required"), strings.Contains(err.Error(), "must be"),
+		strings.Contains(err.Error(), "is not configured"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
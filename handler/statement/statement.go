@@ -0,0 +1,156 @@
+package statement
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// StatementHandler handles HTTP requests for customers' payment statements
+type StatementHandler struct {
+	statementService service.StatementServiceInterface
+}
+
+// NewStatementHandler creates a new statement handler
+func NewStatementHandler(statementService service.StatementServiceInterface) *StatementHandler {
+	return &StatementHandler{
+		statementService: statementService,
+	}
+}
+
+// RequestStatement handles requests for a statement of the caller's payments and refunds
+// (?from=YYYY-MM-DD&to=YYYY-MM-DD&format=pdf|csv). Short periods are downloaded at once;
+// longer ones are queued and answered with 202 Accepted.
+func (h *StatementHandler) RequestStatement(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("StatementHandler")
+	ctx, span := tracer.Start(r.Context(), "RequestStatement-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	params := r.URL.Query()
+	req, err := models.ParseStatementRequest(params.Get("from"), params.Get("to"), params.Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, statement, err := h.statementService.RequestStatement(ctx, userID, req)
+	if err != nil {
+		writeStatementError(w, err)
+		return
+	}
+
+	if statement != nil {
+		response.Resource(w, r, http.StatusAccepted, statement, statementLinks(*statement))
+		return
+	}
+
+	writeFile(w, file)
+}
+
+// GetStatement handles requests for the status of a queued statement
+func (h *StatementHandler) GetStatement(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("StatementHandler")
+	ctx, span := tracer.Start(r.Context(), "GetStatement-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	statement, err := h.statementService.GetStatement(ctx, mux.Vars(r)["id"], userID)
+	if err != nil {
+		writeStatementError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, statement, statementLinks(*statement))
+}
+
+// DownloadStatement handles requests for the file of a queued statement
+func (h *StatementHandler) DownloadStatement(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("StatementHandler")
+	ctx, span := tracer.Start(r.Context(), "DownloadStatement-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	file, err := h.statementService.DownloadStatement(ctx, mux.Vars(r)["id"], userID)
+	if err != nil {
+		writeStatementError(w, err)
+		return
+	}
+
+	writeFile(w, file)
+}
+
+// writeFile sends a statement as a download. Statements hold payment details, so they are
+// never cached.
+func writeFile(w http.ResponseWriter, file *models.StatementFile) {
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+file.Name+`"`)
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(file.Body); err != nil {
+		log.Println("Error writing statement:", err)
+	}
+}
+
+// writeStatementError maps statement service errors to HTTP status codes
+func writeStatementError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no statement found") || strings.Contains(err.Error(), "no user found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "not ready yet") || strings.Contains(err.Error(), "could not be rendered"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// statementLinks returns the related-resource links of a queued statement
+func statementLinks(statement models.PaymentStatement) response.Links {
+	links := response.Links{
+		"self":     "/payments/me/statements/" + statement.ID.String(),
+		"payments": "/payments/user/" + statement.UserID.String(),
+	}
+	if statement.Status == models.StatementStatusReady {
+		links["download"] = "/payments/me/statements/" + statement.ID.String() + "/download"
+	}
+	return links
+}
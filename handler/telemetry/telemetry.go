@@ -0,0 +1,267 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// maxTelemetryBodyBytes bounds a single device payload
+const maxTelemetryBodyBytes = 64 << 10
+
+// TelemetryHandler handles HTTP requests for car telematics
+type TelemetryHandler struct {
+	telemetryService service.TelemetryServiceInterface
+}
+
+// NewTelemetryHandler creates a new telemetry handler
+func NewTelemetryHandler(telemetryService service.TelemetryServiceInterface) *TelemetryHandler {
+	return &TelemetryHandler{
+		telemetryService: telemetryService,
+	}
+}
+
+// IngestReading handles signed payloads posted by telematics devices.
+// Devices authenticate with the X-Telemetry-Device, X-Telemetry-Timestamp and
+// X-Telemetry-Signature headers instead of a user session.
+func (h *TelemetryHandler) IngestReading(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("TelemetryHandler")
+	ctx, span := tracer.Start(r.Context(), "IngestReading-Handler")
+	defer span.End()
+
+	// The signature covers the raw bytes, so the body is read before decoding
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxTelemetryBodyBytes))
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	reading, err := h.telemetryService.IngestReading(ctx,
+		r.Header.Get("X-Telemetry-Device"),
+		r.Header.Get("X-Telemetry-Timestamp"),
+		r.Header.Get("X-Telemetry-Signature"),
+		body)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "telemetry signature"):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case strings.Contains(err.Error(), "invalid telemetry payload"),
+			strings.Contains(err.Error(), " must "),
+			strings.Contains(err.Error(), "recorded_at"),
+			strings.Contains(err.Error(), "no readings"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, reading, nil)
+}
+
+// ProvisionDevice handles requests to issue or rotate the signing secret of a car's device
+func (h *TelemetryHandler) ProvisionDevice(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("TelemetryHandler")
+	ctx, span := tracer.Start(r.Context(), "ProvisionDevice-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	carID := mux.Vars(r)["id"]
+	credentials, err := h.telemetryService.ProvisionDevice(ctx, userID, middleware.RoleFromContext(ctx), carID)
+	if err != nil {
+		writeTelemetryError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, credentials, response.Links{
+		"latest": "/cars/" + carID + "/telemetry/latest",
+	})
+}
+
+// GetLatestSnapshot handles requests for the latest telemetry of a car
+func (h *TelemetryHandler) GetLatestSnapshot(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("TelemetryHandler")
+	ctx, span := tracer.Start(r.Context(), "GetLatestSnapshot-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	carID := mux.Vars(r)["id"]
+	snapshot, err := h.telemetryService.GetLatestSnapshot(ctx, userID, middleware.RoleFromContext(ctx), carID)
+	if err != nil {
+		writeTelemetryError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, snapshot, response.Links{
+		"car": "/cars/" + carID,
+	})
+}
+
+// CreateGeofence handles requests to define a geofence on a car
+func (h *TelemetryHandler) CreateGeofence(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("TelemetryHandler")
+	ctx, span := tracer.Start(r.Context(), "CreateGeofence-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.GeofenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := models.ValidateGeofenceRequest(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	carID := mux.Vars(r)["id"]
+	geofence, err := h.telemetryService.CreateGeofence(ctx, userID, middleware.RoleFromContext(ctx), carID, req)
+	if err != nil {
+		if strings.Contains(err.Error(), "does not belong to this car") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeTelemetryError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, geofence, response.Links{
+		"geofences": "/cars/" + carID + "/geofences",
+		"breaches":  "/cars/" + carID + "/geofences/breaches",
+	})
+}
+
+// GetGeofences handles requests to list the geofences of a car
+func (h *TelemetryHandler) GetGeofences(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("TelemetryHandler")
+	ctx, span := tracer.Start(r.Context(), "GetGeofences-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	carID := mux.Vars(r)["id"]
+	geofences, err := h.telemetryService.GetGeofences(ctx, userID, middleware.RoleFromContext(ctx), carID)
+	if err != nil {
+		writeTelemetryError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, geofences, response.Links{
+		"car":      "/cars/" + carID,
+		"breaches": "/cars/" + carID + "/geofences/breaches",
+	})
+}
+
+// DeleteGeofence handles requests to remove a geofence from a car
+func (h *TelemetryHandler) DeleteGeofence(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("TelemetryHandler")
+	ctx, span := tracer.Start(r.Context(), "DeleteGeofence-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.telemetryService.DeleteGeofence(ctx, userID, middleware.RoleFromContext(ctx), vars["id"], vars["geofenceID"]); err != nil {
+		writeTelemetryError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetGeofenceBreaches handles requests for the recent geofence breaches of a car
+func (h *TelemetryHandler) GetGeofenceBreaches(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("TelemetryHandler")
+	ctx, span := tracer.Start(r.Context(), "GetGeofenceBreaches-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	carID := mux.Vars(r)["id"]
+	breaches, err := h.telemetryService.GetGeofenceBreaches(ctx, userID, middleware.RoleFromContext(ctx), carID)
+	if err != nil {
+		writeTelemetryError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, breaches, response.Links{
+		"car":       "/cars/" + carID,
+		"geofences": "/cars/" + carID + "/geofences",
+	})
+}
+
+// writeTelemetryError maps service errors to HTTP status codes
+func writeTelemetryError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), "no car found") || strings.Contains(err.Error(), "no telemetry found") ||
+		strings.Contains(err.Error(), "no geofence found") {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,310 @@
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// maxProfilePatchBytes bounds the body of a profile update
+const maxProfilePatchBytes = 16 << 10
+
+// UserHandler handles HTTP requests to manage user accounts, by admins and by users for their
+// own account
+type UserHandler struct {
+	userService   service.UserServiceInterface
+	secureCookies bool
+}
+
+// NewUserHandler creates a new user handler. secureCookies must match the auth handler's, so
+// closing an account clears the same session cookie login set.
+func NewUserHandler(userService service.UserServiceInterface, secureCookies bool) *UserHandler {
+	return &UserHandler{
+		userService:   userService,
+		secureCookies: secureCookies,
+	}
+}
+
+// ListUsers handles requests for a page of all users (admin only)
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("UserHandler")
+	ctx, span := tracer.Start(r.Context(), "ListUsers-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	query := r.URL.Query()
+	page, err := models.ParsePageRequest(query.Get("limit"), query.Get("cursor"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	users, err := h.userService.ListUsers(ctx, page)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	response.List(w, r, users)
+}
+
+// GetUsersByRole handles requests for all users with a role (admin only)
+func (h *UserHandler) GetUsersByRole(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("UserHandler")
+	ctx, span := tracer.Start(r.Context(), "GetUsersByRole-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	users, err := h.userService.GetUsersByRole(ctx, mux.Vars(r)["role"])
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, users, response.Links{
+		"users": "/admin/users",
+	})
+}
+
+// GetUser handles requests for a user (admin only)
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("UserHandler")
+	ctx, span := tracer.Start(r.Context(), "GetUser-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	user, err := h.userService.GetUserByID(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, user, userLinks(*user))
+}
+
+// UpdateUser handles requests to replace a user's account details (admin only)
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("UserHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateUser-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.UserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.userService.UpdateUser(ctx, mux.Vars(r)["id"], req)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, user, userLinks(*user))
+}
+
+// DeleteUser handles requests to delete a user (admin only)
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("UserHandler")
+	ctx, span := tracer.Start(r.Context(), "DeleteUser-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	if _, err := h.userService.DeleteUser(ctx, mux.Vars(r)["id"]); err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetMe handles requests for the signed-in user's own account
+func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("UserHandler")
+	ctx, span := tracer.Start(r.Context(), "GetMe-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := h.userService.GetCurrentUser(ctx, userID)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, user, meLinks())
+}
+
+// UpdateMe handles requests to replace the signed-in user's own account details
+func (h *UserHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("UserHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateMe-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.UserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.userService.UpdateCurrentUser(ctx, userID, req)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, user, meLinks())
+}
+
+// UpdateMyProfile handles requests to change fields of the signed-in user's profile data. The
+// body is a JSON merge patch: fields it leaves out are kept and fields set to null are removed.
+func (h *UserHandler) UpdateMyProfile(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("UserHandler")
+	ctx, span := tracer.Start(r.Context(), "UpdateMyProfile-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var patch map[string]interface{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxProfilePatchBytes)).Decode(&patch); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.userService.UpdateCurrentProfile(ctx, userID, patch)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, user, meLinks())
+}
+
+// DeleteMe handles requests to close the signed-in user's own account. The session cookie is
+// cleared with it.
+func (h *UserHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("UserHandler")
+	ctx, span := tracer.Start(r.Context(), "DeleteMe-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.userService.DeleteCurrentUser(ctx, userID); err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeUserError maps user service errors to HTTP status codes
+func writeUserError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no user found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "already exists"),
+		strings.Contains(err.Error(), "cannot be deleted"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "admins cannot"),
+		strings.Contains(err.Error(), "users cannot"),
+		strings.Contains(err.Error(), "current password is incorrect"):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "must") ||
+		strings.Contains(err.Error(), "cannot be empty") || strings.Contains(err.Error(), "is required"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// userLinks returns the related-resource links of a user
+func userLinks(user models.User) response.Links {
+	return response.Links{
+		"self":  "/admin/users/" + user.ID.String(),
+		"kyc":   "/admin/users/" + user.ID.String() + "/kyc",
+		"users": "/admin/users",
+	}
+}
+
+// meLinks returns the related-resource links of the signed-in user's own account
+func meLinks() response.Links {
+	return response.Links{
+		"self":      "/users/me",
+		"favorites": "/users/me/favorites",
+	}
+}
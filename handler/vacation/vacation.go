@@ -0,0 +1,134 @@
+package vacation
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// VacationHandler handles HTTP requests for owner vacation mode
+type VacationHandler struct {
+	vacationService service.VacationServiceInterface
+}
+
+// NewVacationHandler creates a new vacation handler
+func NewVacationHandler(vacationService service.VacationServiceInterface) *VacationHandler {
+	return &VacationHandler{
+		vacationService: vacationService,
+	}
+}
+
+// StartVacation handles requests to block all of the owner's cars for a period
+func (h *VacationHandler) StartVacation(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("VacationHandler")
+	ctx, span := tracer.Start(r.Context(), "StartVacation-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.VacationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	vacation, err := h.vacationService.StartVacation(ctx, ownerID, req)
+	if err != nil {
+		writeVacationError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusCreated, vacation, vacationLinks(*vacation))
+}
+
+// GetVacations handles requests to list the owner's vacations
+func (h *VacationHandler) GetVacations(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("VacationHandler")
+	ctx, span := tracer.Start(r.Context(), "GetVacations-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	vacations, err := h.vacationService.GetVacations(ctx, ownerID)
+	if err != nil {
+		writeVacationError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, vacations, nil)
+}
+
+// EndVacation handles requests to lift a vacation and unblock its cars
+func (h *VacationHandler) EndVacation(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("VacationHandler")
+	ctx, span := tracer.Start(r.Context(), "EndVacation-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	if _, err := h.vacationService.EndVacation(ctx, ownerID, mux.Vars(r)["id"]); err != nil {
+		writeVacationError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeVacationError maps vacation service errors to HTTP status codes
+func writeVacationError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no vacation found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "overlaps") || strings.Contains(err.Error(), "conflicts with"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must") ||
+		strings.Contains(err.Error(), "cannot be") || strings.Contains(err.Error(), "invalid") ||
+		strings.Contains(err.Error(), "no cars"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// vacationLinks returns the related-resource links of a vacation
+func vacationLinks(vacation models.OwnerVacation) response.Links {
+	return response.Links{
+		"self":      "/owners/me/vacation/" + vacation.ID.String(),
+		"vacations": "/owners/me/vacation",
+		"bookings":  "/bookings/owner/" + vacation.OwnerID.String(),
+	}
+}
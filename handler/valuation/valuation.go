@@ -0,0 +1,130 @@
+package valuation
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+// ValuationHandler handles HTTP requests for owners' fleet valuation report
+type ValuationHandler struct {
+	valuationService service.ValuationServiceInterface
+}
+
+// NewValuationHandler creates a new valuation handler
+func NewValuationHandler(valuationService service.ValuationServiceInterface) *ValuationHandler {
+	return &ValuationHandler{
+		valuationService: valuationService,
+	}
+}
+
+// GetFleetValuation handles requests for the estimated value of the owner's cars, as JSON or,
+// with ?format=csv, as a CSV download
+func (h *ValuationHandler) GetFleetValuation(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("ValuationHandler")
+	ctx, span := tracer.Start(r.Context(), "GetFleetValuation-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+	case "csv":
+		body, err := h.valuationService.ExportFleetValuation(ctx, ownerID)
+		if err != nil {
+			writeValuationError(w, err)
+			return
+		}
+
+		// Valuations hold what owners paid for their cars, so they are never cached
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="carzone-fleet-valuation-`+time.Now().Format("2006-01-02")+`.csv"`)
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body); err != nil {
+			log.Println("Error writing fleet valuation export:", err)
+		}
+		return
+	default:
+		http.Error(w, "format must be json or csv", http.StatusBadRequest)
+		return
+	}
+
+	valuation, err := h.valuationService.GetFleetValuation(ctx, ownerID)
+	if err != nil {
+		writeValuationError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-store")
+	response.Resource(w, r, http.StatusOK, valuation, response.Links{
+		"self":   "/owners/me/fleet-valuation",
+		"export": "/owners/me/fleet-valuation?format=csv",
+	})
+}
+
+// SetCarPurchase handles requests to record what the owner paid for one of their cars
+func (h *ValuationHandler) SetCarPurchase(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("ValuationHandler")
+	ctx, span := tracer.Start(r.Context(), "SetCarPurchase-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	ownerID := middleware.UserIDFromContext(ctx)
+	if ownerID == "" {
+		http.Error(w, "Session does not identify a user, please log in again", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CarPurchaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	purchase, err := h.valuationService.SetCarPurchase(ctx, ownerID, mux.Vars(r)["carID"], req)
+	if err != nil {
+		writeValuationError(w, err)
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, purchase, response.Links{
+		"car":             "/cars/" + purchase.CarID.String(),
+		"fleet_valuation": "/owners/me/fleet-valuation",
+	})
+}
+
+// writeValuationError maps valuation service errors to HTTP status codes
+func writeValuationError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no car found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "must") || strings.Contains(err.Error(), "cannot") ||
+		strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,62 @@
+package warehouse
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/response"
+	"github.com/PrateekKumar15/CarZone/service"
+	"go.opentelemetry.io/otel"
+)
+
+// WarehouseHandler handles HTTP requests for the data warehouse export
+type WarehouseHandler struct {
+	warehouseService service.WarehouseExportServiceInterface
+}
+
+// NewWarehouseHandler creates a new warehouse export handler
+func NewWarehouseHandler(warehouseService service.WarehouseExportServiceInterface) *WarehouseHandler {
+	return &WarehouseHandler{
+		warehouseService: warehouseService,
+	}
+}
+
+// Backfill handles requests to re-export rows changed in a range
+func (h *WarehouseHandler) Backfill(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("WarehouseHandler")
+	ctx, span := tracer.Start(r.Context(), "Backfill-Handler")
+	defer span.End()
+
+	// Handle OPTIONS request for CORS preflight
+	if r.Method == "OPTIONS" {
+		return // CORS middleware will handle the response
+	}
+
+	var req models.WarehouseBackfillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	exports, err := h.warehouseService.Backfill(ctx, req)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not configured"):
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		case strings.Contains(err.Error(), "failed to upload"):
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		case strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must be") ||
+			strings.Contains(err.Error(), "cannot be") || strings.Contains(err.Error(), "unknown entity"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response.Resource(w, r, http.StatusOK, exports, response.Links{
+		"self": r.URL.RequestURI(),
+	})
+}
@@ -0,0 +1,312 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+)
+
+// apiProcess is the API built from the working tree and running for the suite
+type apiProcess struct {
+	cmd     *exec.Cmd
+	baseURL string
+	logPath string
+	logFile *os.File
+}
+
+// startAPI builds the API, starts it from the repository root with the given environment on
+// top of this process's and waits until its health check passes
+func startAPI(ctx context.Context, workDir string, env []string) (*apiProcess, error) {
+	binary := filepath.Join(workDir, "carzone")
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, ".")
+	build.Dir = repoRoot
+	if out, err := build.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to build the API: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	api := &apiProcess{baseURL: "http://127.0.0.1:" + port, logPath: filepath.Join(workDir, "api.log")}
+	if api.logFile, err = os.Create(api.logPath); err != nil {
+		return nil, err
+	}
+
+	// The API reads store/schema.sql relative to its working directory
+	api.cmd = exec.Command(binary)
+	api.cmd.Dir = repoRoot
+	api.cmd.Env = append(os.Environ(), append(env, "PORT="+port)...)
+	api.cmd.Stdout, api.cmd.Stderr = api.logFile, api.logFile
+	if err := api.cmd.Start(); err != nil {
+		api.logFile.Close()
+		return nil, fmt.Errorf("failed to start the API: %v", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- api.cmd.Wait() }()
+	deadline := time.Now().Add(90 * time.Second)
+	for {
+		select {
+		case err := <-exited:
+			api.logFile.Close()
+			return nil, fmt.Errorf("API exited before becoming healthy (%v), see %s", err, api.logPath)
+		case <-time.After(time.Second):
+		}
+		if resp, err := http.Get(api.baseURL + "/health"); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				log.Printf("API is up at %s, logging to %s", api.baseURL, api.logPath)
+				return api, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			api.stop()
+			return nil, fmt.Errorf("API did not become healthy within 90s, see %s", api.logPath)
+		}
+	}
+}
+
+// stop ends the API
+func (api *apiProcess) stop() {
+	if api.cmd.Process != nil {
+		_ = api.cmd.Process.Kill()
+	}
+	api.logFile.Close()
+}
+
+// freePort returns a local TCP port nothing listens on
+func freePort() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer listener.Close()
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	return port, err
+}
+
+// adminCommand is the carzone-admin command built from the working tree. It runs with the API's
+// environment, outside the repository so a developer's .env is not loaded.
+type adminCommand struct {
+	binary  string
+	workDir string
+	env     []string
+}
+
+// buildAdminCommand builds carzone-admin into workDir
+func buildAdminCommand(ctx context.Context, workDir string, env []string) (*adminCommand, error) {
+	binary := filepath.Join(workDir, "carzone-admin")
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, "./cmd/carzone-admin")
+	build.Dir = repoRoot
+	if out, err := build.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to build carzone-admin: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return &adminCommand{binary: binary, workDir: workDir, env: env}, nil
+}
+
+// run runs carzone-admin with the given arguments and standard input and returns its output
+func (a *adminCommand) run(ctx context.Context, stdin string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, a.binary, args...)
+	cmd.Dir = a.workDir
+	cmd.Env = append(os.Environ(), a.env...)
+	cmd.Stdin = strings.NewReader(stdin)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("carzone-admin %s: %v: %s", strings.Join(args[:min(2, len(args))], " "), err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// createAdmin creates an admin with carzone-admin, as admins cannot sign up through the API,
+// and signs them in
+func createAdmin(ctx context.Context, adminCmd *adminCommand) (*session, error) {
+	suffix := uuid.NewString()[:8]
+	email := "admin-" + suffix + "@integration.carzone.dev"
+	password := "integration-" + suffix
+	if _, err := adminCmd.run(ctx, password+"\n", "user", "create-admin",
+		"--email", email, "--username", "admin_"+suffix, "--phone", fmt.Sprintf("+9198%08d", time.Now().UnixNano()%100000000)); err != nil {
+		return nil, err
+	}
+
+	return newAPIClient(time.Minute).login(ctx, email, password)
+}
+
+// migrate checks the migrations against the schema the API applied, which already contains
+// them. It rolls each back with its script in migrations/down, newest first, as the README
+// describes, then applies them all again with carzone-admin migrate, as deployments do. A
+// migration or rollback that no longer fits the schema stops the suite.
+func migrate(ctx context.Context, adminCmd *adminCommand) error {
+	dir, err := filepath.Abs(filepath.Join(repoRoot, "migrations"))
+	if err != nil {
+		return err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+
+	// schema.sql does not drop schema_migration, so a server an earlier run used still has it
+	var recorded bool
+	if err := db.QueryRowContext(ctx, `SELECT to_regclass('schema_migration') IS NOT NULL`).Scan(&recorded); err != nil {
+		return err
+	}
+	for _, file := range files {
+		name := filepath.Base(file)
+		script, err := os.ReadFile(filepath.Join(dir, "down", name))
+		if err != nil {
+			return fmt.Errorf("migration %s has no rollback script: %v", name, err)
+		}
+		if _, err := db.ExecContext(ctx, string(script)); err != nil {
+			return fmt.Errorf("failed to roll back migration %s: %v", name, err)
+		}
+		if recorded {
+			if _, err := db.ExecContext(ctx, `DELETE FROM schema_migration WHERE name = $1`, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	out, err := adminCmd.run(ctx, "", "migrate", "--dir", dir)
+	if err != nil {
+		return err
+	}
+	if applied := strings.Count(out, "Applied "); applied != len(files) {
+		return fmt.Errorf("carzone-admin migrate applied %d of %d migrations: %s", applied, len(files), strings.TrimSpace(out))
+	}
+	log.Printf("Rolled back and reapplied %d migrations", len(files))
+	return nil
+}
+
+// session is a user signed in to the API
+type session struct {
+	user  models.User
+	token string
+}
+
+// apiClient calls the API and unwraps its response envelope
+type apiClient struct {
+	baseURL string
+	http    *http.Client
+}
+
+// newAPIClient returns a client of the suite's API whose requests time out after timeout
+func newAPIClient(timeout time.Duration) *apiClient {
+	return &apiClient{baseURL: api.baseURL, http: &http.Client{Timeout: timeout}}
+}
+
+// send sends a JSON request as the session's user, or anonymously for a nil session, and
+// returns the status and body of the response
+func (c *apiClient) send(ctx context.Context, as *session, method, path string, body interface{}) (int, []byte, error) {
+	var payload io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, err
+		}
+		payload = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if as != nil {
+		req.Header.Set("Authorization", "Bearer "+as.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s %s: %v", method, path, err)
+	}
+	return resp.StatusCode, raw, nil
+}
+
+// call sends a request as send does and decodes the envelope's data into out. A status other
+// than want is an error.
+func (c *apiClient) call(ctx context.Context, as *session, method, path string, body, out interface{}, want int) error {
+	status, raw, err := c.send(ctx, as, method, path, body)
+	if err != nil {
+		return err
+	}
+	if status != want {
+		return fmt.Errorf("%s %s: expected status %d, got %d: %s", method, path, want, status, bytes.TrimSpace(raw))
+	}
+	if out == nil {
+		return nil
+	}
+
+	envelope := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return fmt.Errorf("%s %s: undecodable response: %v", method, path, err)
+	}
+	if err := json.Unmarshal(envelope.Data, out); err != nil {
+		return fmt.Errorf("%s %s: undecodable data: %v", method, path, err)
+	}
+	return nil
+}
+
+// signedIn is the data of a registration or login response
+type signedIn struct {
+	User  models.User `json:"user"`
+	Token string      `json:"token"`
+}
+
+// register signs up an owner or renter with a random e-mail address and returns their session
+func (c *apiClient) register(ctx context.Context, role string) (*session, error) {
+	suffix := uuid.NewString()[:8]
+	var registered signedIn
+	err := c.call(ctx, nil, http.MethodPost, "/auth/register", models.UserRequest{
+		Email:    fmt.Sprintf("%s-%s@integration.carzone.dev", role, suffix),
+		Password: "integration-" + suffix,
+		UserName: role + "_" + suffix,
+		Phone:    fmt.Sprintf("+9197%08d", time.Now().UnixNano()%100000000),
+		Role:     role,
+	}, &registered, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+	if registered.Token == "" || registered.User.ID == uuid.Nil {
+		return nil, fmt.Errorf("registering a %s returned no user or token", role)
+	}
+	return &session{user: registered.User, token: registered.Token}, nil
+}
+
+// login signs a user in with their password and returns their session
+func (c *apiClient) login(ctx context.Context, email, password string) (*session, error) {
+	var loggedIn signedIn
+	err := c.call(ctx, nil, http.MethodPost, "/auth/login", models.LoginRequest{Email: email, Password: password},
+		&loggedIn, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	if loggedIn.Token == "" || loggedIn.User.ID == uuid.Nil {
+		return nil, fmt.Errorf("signing in %s returned no user or token", email)
+	}
+	return &session{user: loggedIn.User, token: loggedIn.Token}, nil
+}
@@ -0,0 +1,181 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+
+	bookingService "github.com/PrateekKumar15/CarZone/service/booking"
+	bookingStore "github.com/PrateekKumar15/CarZone/store/booking"
+	carStore "github.com/PrateekKumar15/CarZone/store/car"
+	fleetStore "github.com/PrateekKumar15/CarZone/store/fleet"
+	vacationStore "github.com/PrateekKumar15/CarZone/store/vacation"
+)
+
+// budgetBenchmarks are the benchmarks of models.PerformanceBudgets, by budget name
+var budgetBenchmarks = map[string]func(b *testing.B){
+	"car-listing-filtered":   BenchmarkCarListingFiltered,
+	"car-listing-bookable":   BenchmarkCarListingBookable,
+	"booking-conflict-check": BenchmarkBookingConflictCheck,
+}
+
+// The benchmarks share one default run of load test fixtures, created through the API on first
+// use and deleted by TestMain after the tests
+var (
+	fixturesOnce sync.Once
+	fixtures     models.LoadTestFixtures
+	fixturesErr  error
+)
+
+// loadTestFixtures returns the fixtures of the benchmarks, creating them on first use
+func loadTestFixtures(tb testing.TB) models.LoadTestFixtures {
+	tb.Helper()
+	fixturesOnce.Do(func() {
+		c := newAPIClient(5 * time.Minute)
+		fixturesErr = c.call(context.Background(), admin, http.MethodPost, "/admin/loadtest/fixtures",
+			models.LoadTestFixtureRequest{}, &fixtures, http.StatusCreated)
+		if fixturesErr == nil && (len(fixtures.Cars) == 0 || len(fixtures.Targets) == 0) {
+			fixturesErr = fmt.Errorf("load test run %s has no cars or no targets", fixtures.Run.ID)
+		}
+	})
+	if fixturesErr != nil {
+		tb.Fatal(fixturesErr)
+	}
+	return fixtures
+}
+
+// deleteLoadTestFixtures deletes the fixtures of the benchmarks, if they were created
+func deleteLoadTestFixtures(ctx context.Context) {
+	if fixtures.Run.ID == uuid.Nil {
+		return
+	}
+	c := newAPIClient(5 * time.Minute)
+	if err := c.call(ctx, admin, http.MethodDelete, "/admin/loadtest/fixtures/"+fixtures.Run.ID.String(), nil, nil, http.StatusNoContent); err != nil {
+		log.Printf("Failed to delete load test run %s: %v", fixtures.Run.ID, err)
+	}
+}
+
+// budgetPeriod returns a rental period far enough ahead to be free of lead time rules
+func budgetPeriod() (time.Time, time.Time) {
+	start := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 30)
+	return start, start.AddDate(0, 0, 3)
+}
+
+// BenchmarkCarListingFiltered searches the cars of one brand in one city, as
+// GET /cars/search?brand=...&city=... does
+func BenchmarkCarListingFiltered(b *testing.B) {
+	car := loadTestFixtures(b).Cars[0]
+	cars := carStore.New(db)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := cars.ListCars(ctx, models.CarFilter{Brand: car.Brand, City: car.LocationCity}, models.PageRequest{Limit: 20}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCarListingBookable checks the bookability of a listing page of cars together, as
+// GET /cars?start=...&end=... does
+func BenchmarkCarListingBookable(b *testing.B) {
+	listed := loadTestFixtures(b).Cars
+	var pageIDs []uuid.UUID
+	for _, car := range listed[:min(20, len(listed))] {
+		pageIDs = append(pageIDs, car.ID)
+	}
+	cars := carStore.New(db)
+	start, end := budgetPeriod()
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := cars.GetBookableCarIDs(ctx, pageIDs, start, end); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkBookingConflictCheck runs the booking service's conflict check of a booked car, as
+// GET /cars/{id}/conflicts does: the car, its fleet and owner blackouts and its bookings. The
+// dependencies the check does not use are left out.
+func BenchmarkBookingConflictCheck(b *testing.B) {
+	car := loadTestFixtures(b).Cars[0]
+	bookings := bookingService.NewBookingService(bookingStore.New(db), carStore.New(db), nil, nil, nil, nil, nil, nil, nil,
+		vacationStore.New(db), fleetStore.New(db), nil, nil, nil, nil, nil, nil)
+	start, end := budgetPeriod()
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := bookings.CheckConflicts(ctx, car.ID.String(), start, end); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestPerformanceBudgets replays the targets of the load test fixtures once, then runs the
+// benchmark of each budget the API lists. A benchmark over its budget, or a budget without a
+// benchmark, fails the test.
+func TestPerformanceBudgets(t *testing.T) {
+	ctx := context.Background()
+	c := newAPIClient(5 * time.Minute)
+
+	for _, target := range loadTestFixtures(t).Targets {
+		if err := replay(ctx, c.http, target); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var budgets []models.PerformanceBudget
+	if err := c.call(ctx, admin, http.MethodGet, "/admin/loadtest/budgets", nil, &budgets, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, budget := range budgets {
+		t.Run(budget.Name, func(t *testing.T) {
+			benchmark, ok := budgetBenchmarks[budget.Name]
+			if !ok {
+				t.Fatalf("performance budget %s has no benchmark", budget.Name)
+			}
+			// A benchmark that fails stops without a result; go test -bench shows why
+			result := testing.Benchmark(benchmark)
+			if result.N == 0 {
+				t.Fatalf("benchmark of %s failed, run it with go test -bench", budget.Name)
+			}
+			perCall := time.Duration(result.NsPerOp())
+			limit := time.Duration(budget.BudgetMs) * time.Millisecond
+			if perCall > limit {
+				t.Fatalf("%s takes %s per call, over its budget of %s", budget.Operation, perCall, limit)
+			}
+			t.Logf("%s takes %s per call, within its budget of %s", budget.Operation, perCall, limit)
+		})
+	}
+}
+
+// replay sends a load test target once, as vegeta would
+func replay(ctx context.Context, client *http.Client, target models.LoadTestTarget) error {
+	req, err := http.NewRequestWithContext(ctx, target.Method, target.URL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header = target.Header.Clone()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %v", target.Method, target.URL, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: expected status 200, got %d", target.Method, target.URL, resp.StatusCode)
+	}
+	return nil
+}
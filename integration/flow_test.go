@@ -0,0 +1,209 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+)
+
+// TestRentalFlow takes a car from listing to a refunded rental through the API. Each step
+// needs the ones before it, so the first failing step ends the test.
+func TestRentalFlow(t *testing.T) {
+	ctx := context.Background()
+	c := newAPIClient(30 * time.Second)
+
+	var owner, renter *session
+	if !t.Run("owner and renter register", func(t *testing.T) {
+		var err error
+		if owner, err = c.register(ctx, "owner"); err != nil {
+			t.Fatal(err)
+		}
+		if renter, err = c.register(ctx, "renter"); err != nil {
+			t.Fatal(err)
+		}
+	}) {
+		return
+	}
+
+	// The owner lists a car for review and the admin approves it
+	var car models.Car
+	if !t.Run("owner lists a car and admin approves it", func(t *testing.T) {
+		err := c.call(ctx, owner, http.MethodPost, "/cars", newCarRequest(owner), &car, http.StatusCreated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if car.Status != models.CarStatusPendingReview {
+			t.Fatalf("new car: expected status %s, got %s", models.CarStatusPendingReview, car.Status)
+		}
+		if car.OwnerID == nil || *car.OwnerID != owner.user.ID {
+			t.Fatalf("new car: expected it to belong to the owner %s, got %v", owner.user.ID, car.OwnerID)
+		}
+
+		err = c.call(ctx, admin, http.MethodPut, "/cars/"+car.ID.String()+"/status",
+			map[string]string{"status": string(models.CarStatusActive)}, &car, http.StatusOK)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fetched models.Car
+		if err := c.call(ctx, renter, http.MethodGet, "/cars/"+car.ID.String(), nil, &fetched, http.StatusOK); err != nil {
+			t.Fatal(err)
+		}
+		if fetched.Status != models.CarStatusActive {
+			t.Fatalf("approved car: expected status %s, got %s", models.CarStatusActive, fetched.Status)
+		}
+	}) {
+		return
+	}
+
+	// Far enough ahead that the payment is captured at once rather than held until pickup
+	start := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 30).Add(10 * time.Hour)
+	var booking models.Booking
+	if !t.Run("renter books the car and an overlapping booking is refused", func(t *testing.T) {
+		err := c.call(ctx, renter, http.MethodPost, "/bookings", models.BookingRequest{
+			CustomerID: renter.user.ID,
+			CarID:      car.ID,
+			OwnerID:    owner.user.ID,
+			StartDate:  start,
+			EndDate:    start.AddDate(0, 0, 3),
+			Notes:      "Booked by the integration suite",
+		}, &booking, http.StatusCreated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if booking.Status != models.BookingStatusPending || booking.TotalAmount <= 0 {
+			t.Fatalf("new booking: expected a pending booking with an amount, got %s for %.2f", booking.Status, booking.TotalAmount)
+		}
+
+		// The car is now held for the period, so a second booking of it must be refused
+		err = c.call(ctx, renter, http.MethodPost, "/bookings", models.BookingRequest{
+			CustomerID: renter.user.ID,
+			CarID:      car.ID,
+			OwnerID:    owner.user.ID,
+			StartDate:  start.Add(24 * time.Hour),
+			EndDate:    start.AddDate(0, 0, 2),
+		}, nil, http.StatusBadRequest)
+		if err != nil {
+			t.Fatalf("overlapping booking: %v", err)
+		}
+	}) {
+		return
+	}
+
+	// Concurrent requests for one free period can all pass the service's conflict check; the
+	// database's exclusion constraint must still let only one of them through
+	if !t.Run("only one of concurrent bookings for a period is created", func(t *testing.T) {
+		raceStart := start.AddDate(0, 0, 10)
+		statuses := make(chan int, 5)
+		var wg sync.WaitGroup
+		for i := 0; i < cap(statuses); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				status, _, err := c.send(ctx, renter, http.MethodPost, "/bookings", models.BookingRequest{
+					CustomerID: renter.user.ID,
+					CarID:      car.ID,
+					OwnerID:    owner.user.ID,
+					StartDate:  raceStart,
+					EndDate:    raceStart.AddDate(0, 0, 2),
+				})
+				if err != nil {
+					t.Logf("Concurrent booking: %v", err)
+				}
+				statuses <- status
+			}()
+		}
+		wg.Wait()
+		close(statuses)
+
+		created := 0
+		for status := range statuses {
+			switch status {
+			case http.StatusCreated:
+				created++
+			case http.StatusBadRequest, http.StatusConflict:
+			default:
+				t.Errorf("concurrent booking: expected status 201, 400 or 409, got %d", status)
+			}
+		}
+		if created != 1 {
+			t.Errorf("concurrent bookings of one period: expected 1 to be created, got %d", created)
+		}
+	}) {
+		return
+	}
+
+	// Checkout: the API opens a Razorpay order, the renter pays it and returns the signed result
+	var payment models.Payment
+	if !t.Run("renter pays through Razorpay checkout", func(t *testing.T) {
+		var order models.RazorpayOrderResponse
+		err := c.call(ctx, renter, http.MethodPost, "/payments", models.PaymentRequest{
+			BookingID:   booking.ID,
+			Amount:      booking.TotalAmount,
+			Method:      models.PaymentMethodRazorpay,
+			Description: "Integration rental payment",
+		}, &order, http.StatusCreated)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		razorpayPaymentID := "pay_" + uuid.NewString()[:14]
+		signature := hmac.New(sha256.New, []byte(razorpayKeySecret))
+		signature.Write([]byte(order.ID + "|" + razorpayPaymentID))
+		err = c.call(ctx, renter, http.MethodPost, "/payments/verify", models.PaymentVerificationRequest{
+			RazorpayOrderID:   order.ID,
+			RazorpayPaymentID: razorpayPaymentID,
+			RazorpaySignature: hex.EncodeToString(signature.Sum(nil)),
+		}, &payment, http.StatusOK)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if payment.Status != models.PaymentStatusCompleted {
+			t.Fatalf("verified payment: expected status %s, got %s", models.PaymentStatusCompleted, payment.Status)
+		}
+	}) {
+		return
+	}
+
+	t.Run("admin refunds the payment", func(t *testing.T) {
+		var refunded models.Payment
+		err := c.call(ctx, admin, http.MethodPost, "/payments/"+payment.ID.String()+"/refund",
+			map[string]float64{"amount": payment.Amount}, &refunded, http.StatusOK)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if refunded.Status != models.PaymentStatusRefunded {
+			t.Fatalf("refunded payment: expected status %s, got %s", models.PaymentStatusRefunded, refunded.Status)
+		}
+	})
+}
+
+// newCarRequest returns a listing of a car by owner, for review
+func newCarRequest(owner *session) models.CarRequest {
+	return models.CarRequest{
+		Name:            "Integration City " + owner.user.ID.String()[:8],
+		Brand:           "Honda",
+		Model:           "City",
+		Year:            2022,
+		FuelType:        "Petrol",
+		Engine:          models.Engine{EngineSize: 1.5, Cylinders: 4, Horsepower: 119, Transmission: "CVT"},
+		LocationCity:    "Bengaluru",
+		LocationState:   "Karnataka",
+		LocationCountry: "India",
+		Price:           2200,
+		Status:          models.CarStatusPendingReview,
+		Features:        map[string]interface{}{"air_conditioning": true},
+		Description:     "Listed by the integration suite",
+		Images:          []string{"https://placehold.co/1200x800/png?text=Integration"},
+		Mileage:         18500,
+	}
+}
@@ -0,0 +1,118 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+
+	bookingStore "github.com/PrateekKumar15/CarZone/store/booking"
+	carStore "github.com/PrateekKumar15/CarZone/store/car"
+	paymentStore "github.com/PrateekKumar15/CarZone/store/payment"
+)
+
+// benchmarkPrepared runs a lookup from many goroutines, once through stores that prepare their
+// hot queries and once through stores that send them unprepared. newLookup creates the stores
+// the lookup uses.
+func benchmarkPrepared(b *testing.B, newLookup func() func(ctx context.Context) error) {
+	for _, prepared := range []bool{true, false} {
+		name := "prepared"
+		if !prepared {
+			name = "unprepared"
+		}
+		b.Run(name, func(b *testing.B) {
+			store.PrepareQueries = prepared
+			lookup := newLookup()
+			store.PrepareQueries = true
+			ctx := context.Background()
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if err := lookup(ctx); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+// bookedCar returns a car of the load test fixtures with bookings
+func bookedCar(b *testing.B) (models.Car, models.Booking) {
+	ctx := context.Background()
+	bookings := bookingStore.New(db)
+	for _, car := range loadTestFixtures(b).Cars {
+		carBookings, err := bookings.GetBookingsByCarID(ctx, car.ID.String())
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(carBookings) > 0 {
+			return car, carBookings[0]
+		}
+	}
+	b.Fatal("the load test fixtures have no booked car")
+	return models.Car{}, models.Booking{}
+}
+
+// BenchmarkGetCarByID reads a car by ID, as every car page and booking does
+func BenchmarkGetCarByID(b *testing.B) {
+	car := loadTestFixtures(b).Cars[0]
+	benchmarkPrepared(b, func() func(ctx context.Context) error {
+		cars := carStore.New(db)
+		return func(ctx context.Context) error {
+			_, err := cars.GetCarByID(ctx, car.ID.String())
+			return err
+		}
+	})
+}
+
+// BenchmarkGetBookingsByCarID reads the bookings of a booked car, as every availability check
+// does
+func BenchmarkGetBookingsByCarID(b *testing.B) {
+	car, _ := bookedCar(b)
+	benchmarkPrepared(b, func() func(ctx context.Context) error {
+		bookings := bookingStore.New(db)
+		return func(ctx context.Context) error {
+			_, err := bookings.GetBookingsByCarID(ctx, car.ID.String())
+			return err
+		}
+	})
+}
+
+// BenchmarkGetPaymentByRazorpayOrderID reads a payment by its Razorpay order, as payment
+// verification and every Razorpay webhook do. It pays a fixture booking for the lookups and
+// deletes the payment afterwards, so the fixtures can still be deleted.
+func BenchmarkGetPaymentByRazorpayOrderID(b *testing.B) {
+	_, booking := bookedCar(b)
+	ctx := context.Background()
+	payments := paymentStore.New(db)
+
+	payment, err := payments.CreatePayment(ctx, models.PaymentRequest{BookingID: booking.ID, Amount: booking.TotalAmount,
+		Method: models.PaymentMethodRazorpay, Description: "Prepared query benchmark"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() {
+		if _, err := payments.DeletePayment(context.Background(), payment.ID.String()); err != nil {
+			b.Errorf("delete benchmark payment %s: %v", payment.ID, err)
+		}
+	})
+	orderID := "order_bench_" + uuid.NewString()[:8]
+	if _, err := payments.UpdatePaymentWithRazorpayDetails(ctx, payment.ID, orderID); err != nil {
+		b.Fatal(err)
+	}
+
+	benchmarkPrepared(b, func() func(ctx context.Context) error {
+		payments := paymentStore.New(db)
+		return func(ctx context.Context) error {
+			_, err := payments.GetPaymentByRazorpayOrderID(ctx, orderID)
+			return err
+		}
+	})
+}
@@ -0,0 +1,36 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+)
+
+// newRazorpayStub serves the Razorpay Orders API the checkout flow calls. Orders are created
+// as Razorpay would, without checking credentials; other calls answer 404 so a flow that
+// starts using them fails visibly rather than reaching Razorpay.
+func newRazorpayStub() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/orders", func(w http.ResponseWriter, r *http.Request) {
+		var req models.RazorpayOrderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, `{"error":{"code":"BAD_REQUEST_ERROR"}}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.RazorpayOrderResponse{
+			ID:       "order_" + uuid.NewString()[:14],
+			Entity:   "order",
+			Amount:   req.Amount,
+			Currency: req.Currency,
+			Receipt:  req.Receipt,
+			Status:   "created",
+		})
+	})
+	return httptest.NewServer(mux)
+}
@@ -0,0 +1,84 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PrateekKumar15/CarZone/encryption"
+	"github.com/PrateekKumar15/CarZone/store/storetest"
+
+	attributeStore "github.com/PrateekKumar15/CarZone/store/attribute"
+	bookingStore "github.com/PrateekKumar15/CarZone/store/booking"
+	carStore "github.com/PrateekKumar15/CarZone/store/car"
+	debugCaptureStore "github.com/PrateekKumar15/CarZone/store/debugcapture"
+	emailTemplateStore "github.com/PrateekKumar15/CarZone/store/emailtemplate"
+	featureStore "github.com/PrateekKumar15/CarZone/store/feature"
+	maintenanceStore "github.com/PrateekKumar15/CarZone/store/maintenance"
+	migrationStore "github.com/PrateekKumar15/CarZone/store/migration"
+	paymentStore "github.com/PrateekKumar15/CarZone/store/payment"
+	securityStore "github.com/PrateekKumar15/CarZone/store/security"
+	settingStore "github.com/PrateekKumar15/CarZone/store/setting"
+	userStore "github.com/PrateekKumar15/CarZone/store/user"
+)
+
+// checkContract fails a test with every violation a storetest check found
+func checkContract(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUserStore(t *testing.T) {
+	cipher, err := encryption.NewCipher("v1", map[string][]byte{"v1": encryptionKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkContract(t, storetest.TestUserStore(context.Background(), userStore.New(db, cipher)))
+}
+
+func TestCarStore(t *testing.T) {
+	checkContract(t, storetest.TestCarStore(context.Background(), carStore.New(db)))
+}
+
+func TestBookingStore(t *testing.T) {
+	checkContract(t, storetest.TestBookingStore(context.Background(), bookingStore.New(db)))
+}
+
+func TestPaymentStore(t *testing.T) {
+	checkContract(t, storetest.TestPaymentStore(context.Background(), paymentStore.New(db)))
+}
+
+func TestFeatureStore(t *testing.T) {
+	checkContract(t, storetest.TestFeatureStore(context.Background(), featureStore.New(db)))
+}
+
+func TestCarAttributeStore(t *testing.T) {
+	checkContract(t, storetest.TestCarAttributeStore(context.Background(), attributeStore.New(db)))
+}
+
+func TestEmailTemplateStore(t *testing.T) {
+	checkContract(t, storetest.TestEmailTemplateStore(context.Background(), emailTemplateStore.New(db)))
+}
+
+func TestSettingStore(t *testing.T) {
+	checkContract(t, storetest.TestSettingStore(context.Background(), settingStore.New(db)))
+}
+
+func TestSecurityStore(t *testing.T) {
+	checkContract(t, storetest.TestSecurityStore(context.Background(), securityStore.New(db)))
+}
+
+func TestMaintenanceStore(t *testing.T) {
+	checkContract(t, storetest.TestMaintenanceStore(context.Background(), maintenanceStore.New(db)))
+}
+
+func TestDebugCaptureStore(t *testing.T) {
+	checkContract(t, storetest.TestDebugCaptureStore(context.Background(), debugCaptureStore.New(db)))
+}
+
+func TestMigrationStore(t *testing.T) {
+	checkContract(t, storetest.TestMigrationStore(context.Background(), migrationStore.New(db)))
+}
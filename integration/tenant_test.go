@@ -0,0 +1,124 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/driver"
+	"github.com/PrateekKumar15/CarZone/identity"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"github.com/google/uuid"
+)
+
+// TestTenantIsolation checks that the row-level security policies keep a session of one
+// operator off another operator's rows, through the connections of package driver that the
+// API uses. The statements have no tenant condition of their own, so only the policies stop
+// them.
+func TestTenantIsolation(t *testing.T) {
+	ctx := context.Background()
+
+	// Policies never restrict superusers and BYPASSRLS roles, so the API must not connect as one
+	var superuser, bypassRLS bool
+	err := db.QueryRowContext(ctx, `SELECT rolsuper, rolbypassrls FROM pg_roles WHERE rolname = current_user`).Scan(&superuser, &bypassRLS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if superuser || bypassRLS {
+		t.Fatalf("the API's role %s is a superuser (%t) or bypasses row-level security (%t)", appRole, superuser, bypassRLS)
+	}
+
+	// A car of the default operator, listed through the API
+	c := newAPIClient(30 * time.Second)
+	owner, err := c.register(ctx, "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var car models.Car
+	if err := c.call(ctx, owner, http.MethodPost, "/cars", newCarRequest(owner), &car, http.StatusCreated); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second operator, whose admin is the most privileged user of its tenant
+	var otherOperatorID uuid.UUID
+	slug := "integration-" + uuid.NewString()[:8]
+	err = db.QueryRowContext(ctx, `INSERT INTO operator (slug, name) VALUES ($1, $2) RETURNING id`, slug, "Integration "+slug).Scan(&otherOperatorID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("SECRETS_PROVIDER", "env")
+	t.Setenv("DB_HOST", database.host)
+	t.Setenv("DB_PORT", database.port)
+	t.Setenv("DB_USER", database.appUser)
+	t.Setenv("DB_PASSWORD", database.appPassword)
+	t.Setenv("DB_NAME", database.name)
+	t.Setenv("DB_SSLMODE", "disable")
+	driver.InitDB()
+	defer driver.CloseDB()
+	sessions := driver.GetDB()
+
+	ownerCtx := identity.WithUser(tenant.WithOperator(ctx, tenant.DefaultOperatorID), owner.user.ID.String(), owner.user.Role)
+	otherCtx := identity.WithUser(tenant.WithOperator(ctx, otherOperatorID), uuid.NewString(), "admin")
+
+	// countCar returns how many rows of the car a session sees
+	countCar := func(t *testing.T, ctx context.Context) int {
+		t.Helper()
+		var count int
+		if err := sessions.QueryRowContext(ctx, `SELECT count(*) FROM car WHERE id = $1`, car.ID).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	t.Run("first tenant sees its car", func(t *testing.T) {
+		if count := countCar(t, ownerCtx); count != 1 {
+			t.Fatalf("owner's session: expected to see their car, saw %d rows", count)
+		}
+	})
+
+	t.Run("second tenant cannot read the car", func(t *testing.T) {
+		if count := countCar(t, otherCtx); count != 0 {
+			t.Fatalf("second operator's session: expected to see no rows of the car, saw %d", count)
+		}
+		var count int
+		err := sessions.QueryRowContext(otherCtx, `SELECT count(*) FROM car WHERE operator_id = $1`, tenant.DefaultOperatorID).Scan(&count)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Fatalf("second operator's session: expected to see no cars of the first operator, saw %d", count)
+		}
+	})
+
+	t.Run("second tenant cannot modify the car", func(t *testing.T) {
+		for _, statement := range []string{
+			`UPDATE car SET price = price + 1000 WHERE id = $1`,
+			`UPDATE car SET operator_id = '` + otherOperatorID.String() + `' WHERE id = $1`,
+			`DELETE FROM car WHERE id = $1`,
+		} {
+			result, err := sessions.ExecContext(otherCtx, statement, car.ID)
+			if err != nil {
+				t.Fatalf("%s: %v", statement, err)
+			}
+			if affected, err := result.RowsAffected(); err != nil || affected != 0 {
+				t.Errorf("%s: expected to change no rows, changed %d (%v)", statement, affected, err)
+			}
+		}
+
+		var price float64
+		var operatorID uuid.UUID
+		err := sessions.QueryRowContext(ownerCtx, `SELECT price, operator_id FROM car WHERE id = $1`, car.ID).Scan(&price, &operatorID)
+		if err != nil {
+			t.Fatalf("car after the second operator's writes: %v", err)
+		}
+		if price != car.Price || operatorID != tenant.DefaultOperatorID {
+			t.Fatalf("car after the second operator's writes: expected price %.2f of operator %s, got %.2f of %s",
+				car.Price, tenant.DefaultOperatorID, price, operatorID)
+		}
+	})
+}
@@ -8,8 +8,8 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	// Database connection management
@@ -18,6 +18,21 @@ import (
 	// Routes layer
 	"github.com/PrateekKumar15/CarZone/routes"
 
+	// Optional SPA build served next to the API
+	"github.com/PrateekKumar15/CarZone/frontend"
+
+	// HTTP listener with TLS support and transport security middleware
+	"github.com/PrateekKumar15/CarZone/identity"
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/server"
+
+	// Store and service contracts
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+
+	// Secrets backend (env, Vault or AWS Secrets Manager)
+	"github.com/PrateekKumar15/CarZone/secrets"
+
 	// HTTP handlers for car endpoints
 	carHandler "github.com/PrateekKumar15/CarZone/handler/car"
 
@@ -27,6 +42,9 @@ import (
 	// Business logic services
 	carService "github.com/PrateekKumar15/CarZone/service/car"
 
+	// Car event fan-out to alerts and the car lookup cache
+	"github.com/PrateekKumar15/CarZone/service/events"
+
 	// Business logic services for booking
 	bookingService "github.com/PrateekKumar15/CarZone/service/booking"
 
@@ -47,6 +65,194 @@ import (
 	// Payment components
 	paymentHandler "github.com/PrateekKumar15/CarZone/handler/payment"
 	paymentService "github.com/PrateekKumar15/CarZone/service/payment"
+
+	// Owner payout components and field encryption
+	"github.com/PrateekKumar15/CarZone/encryption"
+	payoutHandler "github.com/PrateekKumar15/CarZone/handler/payout"
+	payoutService "github.com/PrateekKumar15/CarZone/service/payout"
+	payoutStore "github.com/PrateekKumar15/CarZone/store/payout"
+
+	// Suspicious activity detection and user notifications
+	notificationHandler "github.com/PrateekKumar15/CarZone/handler/notification"
+	securityHandler "github.com/PrateekKumar15/CarZone/handler/security"
+	notificationService "github.com/PrateekKumar15/CarZone/service/notification"
+	riskService "github.com/PrateekKumar15/CarZone/service/risk"
+	securityService "github.com/PrateekKumar15/CarZone/service/security"
+	notificationStore "github.com/PrateekKumar15/CarZone/store/notification"
+	securityStore "github.com/PrateekKumar15/CarZone/store/security"
+
+	// Versioned e-mail templates
+	emailTemplateHandler "github.com/PrateekKumar15/CarZone/handler/emailtemplate"
+	emailTemplateService "github.com/PrateekKumar15/CarZone/service/emailtemplate"
+	emailTemplateStore "github.com/PrateekKumar15/CarZone/store/emailtemplate"
+
+	// Owner vacation mode
+	vacationHandler "github.com/PrateekKumar15/CarZone/handler/vacation"
+	vacationService "github.com/PrateekKumar15/CarZone/service/vacation"
+	vacationStore "github.com/PrateekKumar15/CarZone/store/vacation"
+
+	// Owner fleets with shared pricing rules, add-ons and blackout dates
+	fleetHandler "github.com/PrateekKumar15/CarZone/handler/fleet"
+	fleetService "github.com/PrateekKumar15/CarZone/service/fleet"
+	fleetStore "github.com/PrateekKumar15/CarZone/store/fleet"
+
+	// Curated car features taxonomy
+	featureHandler "github.com/PrateekKumar15/CarZone/handler/feature"
+	attributeService "github.com/PrateekKumar15/CarZone/service/attribute"
+	blockService "github.com/PrateekKumar15/CarZone/service/block"
+	featureService "github.com/PrateekKumar15/CarZone/service/feature"
+	featuredService "github.com/PrateekKumar15/CarZone/service/featured"
+	kycService "github.com/PrateekKumar15/CarZone/service/kyc"
+	maintenanceService "github.com/PrateekKumar15/CarZone/service/maintenance"
+	profileService "github.com/PrateekKumar15/CarZone/service/profile"
+	settingService "github.com/PrateekKumar15/CarZone/service/setting"
+	attributeStore "github.com/PrateekKumar15/CarZone/store/attribute"
+	blockStore "github.com/PrateekKumar15/CarZone/store/block"
+	featureStore "github.com/PrateekKumar15/CarZone/store/feature"
+	featuredStore "github.com/PrateekKumar15/CarZone/store/featured"
+	kycStore "github.com/PrateekKumar15/CarZone/store/kyc"
+	maintenanceStore "github.com/PrateekKumar15/CarZone/store/maintenance"
+	settingStore "github.com/PrateekKumar15/CarZone/store/setting"
+
+	// Car brand and model reference data
+	brandHandler "github.com/PrateekKumar15/CarZone/handler/brand"
+	brandService "github.com/PrateekKumar15/CarZone/service/brand"
+	brandStore "github.com/PrateekKumar15/CarZone/store/brand"
+
+	// Chargebacks raised against payments
+	disputeHandler "github.com/PrateekKumar15/CarZone/handler/dispute"
+	disputeService "github.com/PrateekKumar15/CarZone/service/dispute"
+	disputeStore "github.com/PrateekKumar15/CarZone/store/dispute"
+
+	// Gapless invoice and receipt numbering
+	sequenceService "github.com/PrateekKumar15/CarZone/service/sequence"
+	sequenceStore "github.com/PrateekKumar15/CarZone/store/sequence"
+
+	// Admin credit notes and manual charges
+	adjustmentHandler "github.com/PrateekKumar15/CarZone/handler/adjustment"
+	adjustmentService "github.com/PrateekKumar15/CarZone/service/adjustment"
+	adjustmentStore "github.com/PrateekKumar15/CarZone/store/adjustment"
+
+	// Payment statements for expense reporting
+	attributeHandler "github.com/PrateekKumar15/CarZone/handler/attribute"
+	blockHandler "github.com/PrateekKumar15/CarZone/handler/block"
+	featuredHandler "github.com/PrateekKumar15/CarZone/handler/featured"
+	kycHandler "github.com/PrateekKumar15/CarZone/handler/kyc"
+	maintenanceHandler "github.com/PrateekKumar15/CarZone/handler/maintenance"
+	profileHandler "github.com/PrateekKumar15/CarZone/handler/profile"
+	settingHandler "github.com/PrateekKumar15/CarZone/handler/setting"
+	statementHandler "github.com/PrateekKumar15/CarZone/handler/statement"
+	statementService "github.com/PrateekKumar15/CarZone/service/statement"
+	statementStore "github.com/PrateekKumar15/CarZone/store/statement"
+
+	// Ledger export for Tally and QuickBooks
+	accountingHandler "github.com/PrateekKumar15/CarZone/handler/accounting"
+	accountingService "github.com/PrateekKumar15/CarZone/service/accounting"
+	accountingStore "github.com/PrateekKumar15/CarZone/store/accounting"
+
+	// Password reset links e-mailed to users who forgot their password
+	passwordResetService "github.com/PrateekKumar15/CarZone/service/passwordreset"
+	passwordResetStore "github.com/PrateekKumar15/CarZone/store/passwordreset"
+
+	// Refresh tokens exchanged for short-lived access tokens, rotated on every use
+	refreshTokenService "github.com/PrateekKumar15/CarZone/service/refreshtoken"
+	refreshTokenStore "github.com/PrateekKumar15/CarZone/store/refreshtoken"
+
+	// TOTP two-factor authentication with backup codes
+	twoFactorService "github.com/PrateekKumar15/CarZone/service/twofactor"
+
+	// CAPTCHA or proof-of-work check on registration and password reset requests
+	botCheckService "github.com/PrateekKumar15/CarZone/service/botcheck"
+
+	// Fleet valuation from purchase prices, age and check-in mileage
+	valuationHandler "github.com/PrateekKumar15/CarZone/handler/valuation"
+	valuationService "github.com/PrateekKumar15/CarZone/service/valuation"
+	valuationStore "github.com/PrateekKumar15/CarZone/store/valuation"
+
+	// Keyless entry: smart locks fitted in cars and digital keys issued for bookings
+	smartLockHandler "github.com/PrateekKumar15/CarZone/handler/smartlock"
+	smartLockService "github.com/PrateekKumar15/CarZone/service/smartlock"
+	smartLockStore "github.com/PrateekKumar15/CarZone/store/smartlock"
+
+	// Accidents and other incidents reported during bookings, tracked until resolved
+	incidentHandler "github.com/PrateekKumar15/CarZone/handler/incident"
+	incidentService "github.com/PrateekKumar15/CarZone/service/incident"
+	incidentStore "github.com/PrateekKumar15/CarZone/store/incident"
+
+	// Versioned checklists answered at checkout and check-in
+	checklistHandler "github.com/PrateekKumar15/CarZone/handler/checklist"
+	checklistService "github.com/PrateekKumar15/CarZone/service/checklist"
+	checklistStore "github.com/PrateekKumar15/CarZone/store/checklist"
+
+	// Owner onboarding progress for guided onboarding UIs
+	onboardingHandler "github.com/PrateekKumar15/CarZone/handler/onboarding"
+	onboardingService "github.com/PrateekKumar15/CarZone/service/onboarding"
+
+	// Sampled request and response captures for troubleshooting clients
+	debugCaptureHandler "github.com/PrateekKumar15/CarZone/handler/debugcapture"
+	loadTestHandler "github.com/PrateekKumar15/CarZone/handler/loadtest"
+	debugCaptureService "github.com/PrateekKumar15/CarZone/service/debugcapture"
+	loadTestService "github.com/PrateekKumar15/CarZone/service/loadtest"
+	debugCaptureStore "github.com/PrateekKumar15/CarZone/store/debugcapture"
+	loadTestStore "github.com/PrateekKumar15/CarZone/store/loadtest"
+
+	// Car telematics ingestion and snapshots
+	telemetryHandler "github.com/PrateekKumar15/CarZone/handler/telemetry"
+	telemetryService "github.com/PrateekKumar15/CarZone/service/telemetry"
+	telemetryStore "github.com/PrateekKumar15/CarZone/store/telemetry"
+
+	// Pickup/drop-off locations and door delivery
+	locationHandler "github.com/PrateekKumar15/CarZone/handler/location"
+	geocodingService "github.com/PrateekKumar15/CarZone/service/geocoding"
+	locationService "github.com/PrateekKumar15/CarZone/service/location"
+	locationStore "github.com/PrateekKumar15/CarZone/store/location"
+
+	// Price-drop and availability alerts
+	alertHandler "github.com/PrateekKumar15/CarZone/handler/alert"
+	alertService "github.com/PrateekKumar15/CarZone/service/alert"
+	alertStore "github.com/PrateekKumar15/CarZone/store/alert"
+
+	// Wishlists of saved cars
+	favoriteHandler "github.com/PrateekKumar15/CarZone/handler/favorite"
+	favoriteService "github.com/PrateekKumar15/CarZone/service/favorite"
+	favoriteStore "github.com/PrateekKumar15/CarZone/store/favorite"
+
+	// Admin management of user accounts
+	userHandler "github.com/PrateekKumar15/CarZone/handler/user"
+	userService "github.com/PrateekKumar15/CarZone/service/user"
+
+	// Background upload of car images
+	carImageService "github.com/PrateekKumar15/CarZone/service/carimage"
+	carImageStore "github.com/PrateekKumar15/CarZone/store/carimage"
+
+	// Admin dashboard aggregates
+	analyticsHandler "github.com/PrateekKumar15/CarZone/handler/analytics"
+	analyticsService "github.com/PrateekKumar15/CarZone/service/analytics"
+	analyticsStore "github.com/PrateekKumar15/CarZone/store/analytics"
+
+	// Data warehouse export
+	warehouseHandler "github.com/PrateekKumar15/CarZone/handler/warehouse"
+	s3Service "github.com/PrateekKumar15/CarZone/service/s3"
+	warehouseService "github.com/PrateekKumar15/CarZone/service/warehouse"
+	warehouseStore "github.com/PrateekKumar15/CarZone/store/warehouse"
+
+	// Admin support search
+	searchHandler "github.com/PrateekKumar15/CarZone/handler/search"
+	searchService "github.com/PrateekKumar15/CarZone/service/search"
+	searchStore "github.com/PrateekKumar15/CarZone/store/search"
+
+	// Marketplace operators (tenants)
+	operatorHandler "github.com/PrateekKumar15/CarZone/handler/operator"
+	operatorService "github.com/PrateekKumar15/CarZone/service/operator"
+	operatorStore "github.com/PrateekKumar15/CarZone/store/operator"
+
+	// Rate limit quota
+	limitsHandler "github.com/PrateekKumar15/CarZone/handler/limits"
+
+	// Background jobs and search-engine documents
+	sitemapHandler "github.com/PrateekKumar15/CarZone/handler/sitemap"
+	"github.com/PrateekKumar15/CarZone/service/scheduler"
+	sitemapService "github.com/PrateekKumar15/CarZone/service/sitemap"
 	"github.com/joho/godotenv" // Environment variable loader
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
@@ -67,9 +273,27 @@ import (
 func main() {
 	// Step 1: Load environment variables from .env file
 	// This allows configuration without hardcoding values
+	// Deployments that use a secrets backend may run without a .env file
 	err := godotenv.Load()
 	if err != nil {
-		log.Fatalf("Error loading .env file: %v", err)
+		log.Printf("No .env file loaded, using process environment: %v", err)
+	}
+
+	// Load secrets (DB password, JWT key, gateway and encryption keys) before anything reads them
+	if err := secrets.Init(context.Background()); err != nil {
+		log.Fatalf("Failed to load secrets: %v", err)
+	}
+
+	// Listener, TLS and HSTS settings
+	serverConfig, err := server.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid server configuration: %v", err)
+	}
+
+	// Load balancers and reverse proxies allowed to report the client IP
+	trustedProxies, err := middleware.ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
 	}
 
 	traceProvider, err := startTracing()
@@ -104,25 +328,189 @@ func main() {
 
 	bookingStore := bookingStore.New(db)
 
-	userStore := userStore.New(db)
+	// PII columns (phone and licence numbers, payout details) are encrypted at rest
+	cipher, err := encryption.NewCipherFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize encryption: %v", err)
+	}
+
+	userStore := userStore.New(db, cipher)
 
 	paymentStore := paymentStore.New(db)
 
+	payoutStore := payoutStore.New(db, cipher)
+
+	securityStore := securityStore.New(db)
+
+	telemetryStore := telemetryStore.New(db, cipher)
+
+	locationStore := locationStore.New(db)
+
+	alertStore := alertStore.New(db)
+
+	analyticsStore := analyticsStore.New(db)
+
+	warehouseStore := warehouseStore.New(db)
+
+	searchStore := searchStore.New(db, cipher)
+
+	operatorStore := operatorStore.New(db)
+
+	notificationStore := notificationStore.New(db)
+
+	emailTemplateStore := emailTemplateStore.New(db)
+	vacationStore := vacationStore.New(db)
+	fleetStore := fleetStore.New(db)
+	featureStore := featureStore.New(db)
+	attributeStore := attributeStore.New(db)
+	kycStore := kycStore.New(db)
+	settingStore := settingStore.New(db)
+	featuredStore := featuredStore.New(db)
+	blockStore := blockStore.New(db)
+	maintenanceStore := maintenanceStore.New(db)
+	brandStore := brandStore.New(db)
+	disputeStore := disputeStore.New(db)
+	sequenceStore := sequenceStore.New(db)
+	adjustmentStore := adjustmentStore.New(db)
+	statementStore := statementStore.New(db)
+	accountingStore := accountingStore.New(db)
+	valuationStore := valuationStore.New(db)
+	passwordResetStore := passwordResetStore.New(db)
+	refreshTokenStore := refreshTokenStore.New(db)
+	smartLockStore := smartLockStore.New(db)
+	incidentStore := incidentStore.New(db)
+	checklistStore := checklistStore.New(db)
+	debugCaptureStore := debugCaptureStore.New(db)
+	loadTestStore := loadTestStore.New(db)
+	favoriteStore := favoriteStore.New(db)
+	carImageStore := carImageStore.New(db)
+
 	// Business Logic Layer (Services) - Handle domain logic and validation
-	carService := carService.NewCarService(carStore)
-	bookingService := bookingService.NewBookingService(bookingStore, carStore)
+	// Security monitoring is created first so booking, payment and login flows can report to it
+	notificationService := notificationService.NewNotificationService(notificationStore)
+	emailTemplateService := emailTemplateService.NewEmailTemplateService(emailTemplateStore, userStore, notificationService)
+	securityService := securityService.NewSecurityService(securityStore, userStore, notificationService, securityService.ThresholdsFromEnv())
+	riskService := riskService.NewRiskService(userStore, riskService.ReviewThresholdFromEnv(), riskService.RulesFromEnv(securityStore)...)
+	// Car alerts listen to car and booking changes, so they are created before both services
+	alertService := alertService.NewAlertService(alertStore, carStore, bookingStore, userStore, notificationService)
+	// Car events reach alerts and the car lookup cache through one bus
+	carEvents := events.NewCarEventBus(alertService)
+	// Car features and brand/model pairs are validated against reference data
+	featureService := featureService.NewFeatureService(featureStore)
+	attributeService := attributeService.NewCarAttributeService(attributeStore)
+	kycService := kycService.NewKYCService(kycStore)
+	settingService := settingService.NewSettingService(settingStore)
+	brandService := brandService.NewBrandService(brandStore)
+	carService := carService.NewCachedCarService(carService.NewCarService(carStore, carEvents, featureService, brandService, attributeService, settingService, featuredStore, carImageStore), carService.CacheTTLsFromEnv())
+	carEvents.Subscribe(carService)
+	// Images sent with cars are uploaded in the background; uploaded ones reach the car caches through the bus
+	carImageService := carImageService.NewCarImageUploadService(carImageStore, carStore, userStore, notificationService, carEvents)
+	telemetryService := telemetryService.NewTelemetryService(telemetryStore, carStore, userStore, notificationService)
+	geocodingService := geocodingService.NewGeocodingService()
+	// Confirmed bookings are invoiced and completed payments receipted from numbered series
+	sequenceService := sequenceService.NewSequenceService(sequenceStore)
+	// Razorpay dispute webhooks arrive with payment events and are handed to the dispute service
+	disputeService := disputeService.NewDisputeService(disputeStore, paymentStore, bookingStore, payoutStore, userStore, notificationService)
+	incidentService := incidentService.NewIncidentService(incidentStore, bookingStore, userStore, notificationService)
+	checklistService := checklistService.NewChecklistService(checklistStore)
+	onboardingService := onboardingService.NewOnboardingService(userStore, payoutStore, carStore, kycStore)
+	// Check-in requests late return and refuel fees through the payment service
+	paymentService := paymentService.NewPaymentService(paymentStore, bookingStore, securityService, riskService, userStore, disputeService, sequenceService)
+	// Confirmed bookings of cars with smart locks get digital keys, revoked when the booking ends
+	smartLockService := smartLockService.NewSmartLockService(smartLockStore, bookingStore, carStore, smartLockService.ProvidersFromEnv()...)
+	bookingService := bookingService.NewBookingService(bookingStore, carStore, securityService, riskService, telemetryService, locationStore, geocodingService, carEvents, emailTemplateService, vacationStore, fleetStore, paymentService, sequenceService, kycStore, blockStore, smartLockService, checklistStore)
+	featuredService := featuredService.NewFeaturedService(featuredStore, carStore, paymentService)
+	profileService := profileService.NewProfileService(userStore, carStore)
+	blockService := blockService.NewBlockService(blockStore, userStore)
+	maintenanceService := maintenanceService.NewMaintenanceService(maintenanceStore, carStore, userStore, bookingService, paymentService)
+	debugCaptureService := debugCaptureService.NewDebugCaptureService(debugCaptureStore)
+	adjustmentService := adjustmentService.NewAdjustmentService(adjustmentStore, bookingStore, paymentStore, paymentService, sequenceService)
+	statementService := statementService.NewStatementService(statementStore, userStore, notificationService)
+	accountingService := accountingService.NewAccountingService(accountingStore)
+	valuationService := valuationService.NewValuationService(valuationStore, carStore, settingService)
+	vacationService := vacationService.NewVacationService(vacationStore, carEvents)
+	fleetService := fleetService.NewFleetService(fleetStore)
 	authService := authService.NewAuthService(userStore)
-	paymentService := paymentService.NewPaymentService(paymentStore, bookingStore)
+	passwordResetService := passwordResetService.NewPasswordResetService(passwordResetStore, userStore, notificationService, os.Getenv("PUBLIC_BASE_URL"))
+	refreshTokenService := refreshTokenService.NewRefreshTokenService(refreshTokenStore, userStore)
+	twoFactorService := twoFactorService.NewTwoFactorService(userStore)
+	botCheck := botCheckService.FromEnv()
+	// Load test fixtures are made through the same services as real users, cars and bookings
+	loadTestEnabled, _ := strconv.ParseBool(os.Getenv("LOADTEST_ENABLED"))
+	loadTestService := loadTestService.NewLoadTestService(loadTestStore, authService, carService, bookingService, loadTestEnabled)
+	favoriteService := favoriteService.NewFavoriteService(favoriteStore)
+	userService := userService.NewUserService(userStore)
+	sitemapService := sitemapService.NewSitemapService(carStore, os.Getenv("PUBLIC_BASE_URL"))
+	payoutService := payoutService.NewPayoutService(payoutStore, userStore)
+	locationService := locationService.NewLocationService(locationStore, carStore)
+	analyticsService := analyticsService.NewAnalyticsService(analyticsStore)
+	// The warehouse export stays disabled until a bucket is configured
+	var warehouseStorage service.ObjectStorageInterface
+	if bucket := os.Getenv("WAREHOUSE_EXPORT_BUCKET"); bucket != "" {
+		exportBucket, err := s3Service.NewS3Service(bucket, os.Getenv("AWS_REGION"))
+		if err != nil {
+			log.Fatalf("Failed to configure warehouse export bucket: %v", err)
+		}
+		warehouseStorage = exportBucket
+	}
+	warehouseService := warehouseService.NewWarehouseExportService(warehouseStore, warehouseStorage, os.Getenv("WAREHOUSE_EXPORT_PREFIX"))
+	searchService := searchService.NewSearchService(searchStore)
+	operatorService := operatorService.NewOperatorService(operatorStore)
 
 	// Presentation Layer (Handlers) - Handle HTTP requests/responses
 	carHandler := carHandler.NewCarHandler(carService)
 	bookingHandler := bookingHandler.NewBookingHandler(bookingService)
-	authHandler := authHandler.NewAuthHandler(authService)
+	authHandler := authHandler.NewAuthHandler(authService, securityService, passwordResetService, refreshTokenService, twoFactorService, botCheck, serverConfig.SecureCookies())
 	paymentHandler := paymentHandler.NewPaymentHandler(paymentService)
+	sitemapHandler := sitemapHandler.NewSitemapHandler(sitemapService)
+	payoutHandler := payoutHandler.NewPayoutHandler(payoutService)
+	securityHandler := securityHandler.NewSecurityHandler(securityService)
+	telemetryHandler := telemetryHandler.NewTelemetryHandler(telemetryService)
+	locationHandler := locationHandler.NewLocationHandler(locationService)
+	alertHandler := alertHandler.NewAlertHandler(alertService)
+	analyticsHandler := analyticsHandler.NewAnalyticsHandler(analyticsService)
+	warehouseHandler := warehouseHandler.NewWarehouseHandler(warehouseService)
+	searchHandler := searchHandler.NewSearchHandler(searchService)
+	limitsHandler := limitsHandler.NewLimitsHandler()
+	operatorHandler := operatorHandler.NewOperatorHandler(operatorService)
+	notificationHandler := notificationHandler.NewNotificationHandler(notificationService)
+	emailTemplateHandler := emailTemplateHandler.NewEmailTemplateHandler(emailTemplateService)
+	vacationHandler := vacationHandler.NewVacationHandler(vacationService)
+	fleetHandler := fleetHandler.NewFleetHandler(fleetService)
+	featureHandler := featureHandler.NewFeatureHandler(featureService)
+	attributeHandler := attributeHandler.NewCarAttributeHandler(attributeService)
+	kycHandler := kycHandler.NewKYCHandler(kycService)
+	settingHandler := settingHandler.NewSettingHandler(settingService)
+	featuredHandler := featuredHandler.NewFeaturedHandler(featuredService)
+	profileHandler := profileHandler.NewProfileHandler(profileService)
+	blockHandler := blockHandler.NewBlockHandler(blockService)
+	maintenanceHandler := maintenanceHandler.NewMaintenanceHandler(maintenanceService)
+	debugCaptureHandler := debugCaptureHandler.NewDebugCaptureHandler(debugCaptureService)
+	loadTestHandler := loadTestHandler.NewLoadTestHandler(loadTestService)
+	favoriteHandler := favoriteHandler.NewFavoriteHandler(favoriteService)
+	userHandler := userHandler.NewUserHandler(userService, serverConfig.SecureCookies())
+	brandHandler := brandHandler.NewBrandHandler(brandService)
+	disputeHandler := disputeHandler.NewDisputeHandler(disputeService)
+	adjustmentHandler := adjustmentHandler.NewAdjustmentHandler(adjustmentService)
+	statementHandler := statementHandler.NewStatementHandler(statementService)
+	accountingHandler := accountingHandler.NewAccountingHandler(accountingService)
+	valuationHandler := valuationHandler.NewValuationHandler(valuationService)
+	smartLockHandler := smartLockHandler.NewSmartLockHandler(smartLockService)
+	incidentHandler := incidentHandler.NewIncidentHandler(incidentService)
+	checklistHandler := checklistHandler.NewChecklistHandler(checklistService)
+	onboardingHandler := onboardingHandler.NewOnboardingHandler(onboardingService)
 
 	// Step 4: Initialize routes using the routes layer
 	// Create router with all handler dependencies injected
-	routeManager := routes.NewRouter(authHandler, carHandler, bookingHandler, paymentHandler)
+	routeManager := routes.NewRouter(authHandler, carHandler, bookingHandler, paymentHandler, sitemapHandler, payoutHandler, securityHandler, telemetryHandler, locationHandler, alertHandler, analyticsHandler, warehouseHandler, searchHandler, limitsHandler, operatorHandler, notificationHandler, emailTemplateHandler, vacationHandler, fleetHandler, featureHandler, brandHandler, disputeHandler, adjustmentHandler, statementHandler, attributeHandler, kycHandler, settingHandler, featuredHandler, profileHandler, blockHandler, maintenanceHandler, debugCaptureHandler, loadTestHandler, favoriteHandler, userHandler, accountingHandler, valuationHandler, smartLockHandler, incidentHandler, checklistHandler, onboardingHandler)
+	// Serve the frontend build, when one is embedded or FRONTEND_DIR is set, next to the API
+	site, err := frontend.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load frontend build: %v", err)
+	}
+	if site != nil {
+		routeManager.Frontend = site
+	}
 	router := routeManager.SetupRoutes()
 
 	// Execute schema file to set up database structure
@@ -148,40 +536,216 @@ func main() {
 		log.Fatalf("Failed to execute schema file %s: %v", schemaFile, err)
 	}
 
-	// Step 5: Start the HTTP server
-	// Get port from environment variables with fallback to default
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080" // Default port if not set in environment variables
+	// Start background jobs once the schema is in place
+	// Jobs stop when the application context is cancelled on exit
+	sitemapInterval, err := time.ParseDuration(os.Getenv("SITEMAP_REFRESH_INTERVAL"))
+	if err != nil || sitemapInterval <= 0 {
+		sitemapInterval = time.Hour // Default refresh interval
+	}
+	jobs := scheduler.NewScheduler()
+	jobs.Register(scheduler.Job{Name: "RefreshSitemap", Interval: sitemapInterval, Run: sitemapService.Refresh})
+
+	// Re-fetch secrets so rotated credentials take effect without a restart
+	secretsInterval, err := time.ParseDuration(os.Getenv("SECRETS_REFRESH_INTERVAL"))
+	if err != nil || secretsInterval <= 0 {
+		secretsInterval = 5 * time.Minute // Default secrets refresh interval
+	}
+	jobs.Register(scheduler.Job{Name: "RefreshSecrets", Interval: secretsInterval, Run: secrets.Refresh})
+
+	// Move encrypted columns onto the active key after a key rotation
+	rotationInterval, err := time.ParseDuration(os.Getenv("ENCRYPTION_ROTATION_INTERVAL"))
+	if err != nil || rotationInterval <= 0 {
+		rotationInterval = 24 * time.Hour // Default rotation sweep interval
+	}
+	encryptedStores := map[string]store.EncryptedStoreInterface{"users": userStore, "payout_account": payoutStore, "telemetry_device": telemetryStore}
+	jobs.Register(scheduler.Job{Name: "RotateEncryptionKeys", Interval: rotationInterval, Run: func(ctx context.Context) error {
+		for table, encryptedStore := range encryptedStores {
+			rotated, err := encryptedStore.RotateEncryptionKeys(ctx)
+			if err != nil {
+				return fmt.Errorf("%s: %v", table, err)
+			}
+			if rotated > 0 {
+				log.Printf("Re-encrypted %d %s rows with key %s", rotated, table, cipher.ActiveKeyID())
+			}
+		}
+		return nil
+	}})
+
+	// Alert owners when a rented car leaves its geofence
+	geofenceInterval, err := time.ParseDuration(os.Getenv("GEOFENCE_CHECK_INTERVAL"))
+	if err != nil || geofenceInterval <= 0 {
+		geofenceInterval = time.Minute // Default geofence evaluation interval
+	}
+	jobs.Register(scheduler.Job{Name: "EvaluateGeofences", Interval: geofenceInterval, Run: telemetryService.EvaluateGeofences})
+
+	// Flag trips still in progress after their end date and warn the customer and owner
+	overdueInterval, err := time.ParseDuration(os.Getenv("OVERDUE_TRIP_CHECK_INTERVAL"))
+	if err != nil || overdueInterval <= 0 {
+		overdueInterval = 15 * time.Minute // Default overdue trip check interval
+	}
+	jobs.Register(scheduler.Job{Name: "DetectOverdueTrips", Interval: overdueInterval, Run: bookingService.DetectOverdueTrips})
+
+	// Cancel pending bookings left unpaid and remind customers before theirs expire
+	expiryInterval, err := time.ParseDuration(os.Getenv("BOOKING_EXPIRY_CHECK_INTERVAL"))
+	if err != nil || expiryInterval <= 0 {
+		expiryInterval = 5 * time.Minute // Default unpaid booking check interval
+	}
+	jobs.Register(scheduler.Job{Name: "ExpireUnpaidBookings", Interval: expiryInterval, Run: bookingService.ExpireUnpaidBookings})
+
+	// Remind renters to return the car shortly before their trip ends, offering an extension
+	handBackInterval, err := time.ParseDuration(os.Getenv("HANDBACK_REMINDER_INTERVAL"))
+	if err != nil || handBackInterval <= 0 {
+		handBackInterval = 15 * time.Minute // Default hand-back reminder interval
+	}
+	jobs.Register(scheduler.Job{Name: "RemindHandBacks", Interval: handBackInterval, Run: bookingService.RemindHandBacks})
+
+	// Rewrite feature aliases saved before the taxonomy (e.g. "AC") to canonical keys
+	featureInterval, err := time.ParseDuration(os.Getenv("FEATURE_NORMALIZE_INTERVAL"))
+	if err != nil || featureInterval <= 0 {
+		featureInterval = 24 * time.Hour // Default normalization sweep interval
+	}
+	jobs.Register(scheduler.Job{Name: "NormalizeCarFeatures", Interval: featureInterval, Run: featureService.NormalizeCarFeatures})
+
+	// Render statements queued for long periods and tell customers they are ready
+	statementInterval, err := time.ParseDuration(os.Getenv("STATEMENT_RENDER_INTERVAL"))
+	if err != nil || statementInterval <= 0 {
+		statementInterval = time.Minute // Default statement render interval
+	}
+	jobs.Register(scheduler.Job{Name: "RenderStatements", Interval: statementInterval, Run: statementService.RenderPendingStatements})
+
+	// Run the maintenance tasks admins queued through the runbook endpoints
+	maintenanceInterval, err := time.ParseDuration(os.Getenv("MAINTENANCE_JOB_INTERVAL"))
+	if err != nil || maintenanceInterval <= 0 {
+		maintenanceInterval = time.Minute // Default maintenance job interval
+	}
+	jobs.Register(scheduler.Job{Name: "RunMaintenanceJobs", Interval: maintenanceInterval, Run: maintenanceService.RunQueuedJobs})
+
+	// Upload the images sent with cars to Cloudinary, retrying failed attempts
+	carImageInterval, err := time.ParseDuration(os.Getenv("CAR_IMAGE_UPLOAD_INTERVAL"))
+	if err != nil || carImageInterval <= 0 {
+		carImageInterval = 30 * time.Second // Default car image upload interval
+	}
+	jobs.Register(scheduler.Job{Name: "UploadCarImages", Interval: carImageInterval, Run: carImageService.ProcessPendingUploads})
+
+	// Delete debug captures past DEBUG_CAPTURE_TTL
+	debugCapturePurgeInterval, err := time.ParseDuration(os.Getenv("DEBUG_CAPTURE_PURGE_INTERVAL"))
+	if err != nil || debugCapturePurgeInterval <= 0 {
+		debugCapturePurgeInterval = time.Hour // Default debug capture purge interval
+	}
+	jobs.Register(scheduler.Job{Name: "PurgeDebugCaptures", Interval: debugCapturePurgeInterval, Run: debugCaptureService.PurgeExpired})
+
+	// Delete load test fixtures past their ttl
+	loadTestPurgeInterval, err := time.ParseDuration(os.Getenv("LOADTEST_PURGE_INTERVAL"))
+	if err != nil || loadTestPurgeInterval <= 0 {
+		loadTestPurgeInterval = time.Hour // Default load test purge interval
 	}
+	jobs.Register(scheduler.Job{Name: "PurgeLoadTestFixtures", Interval: loadTestPurgeInterval, Run: loadTestService.PurgeExpired})
 
+	// Fold booking, payment and signup changes into the dashboard read model
+	dashboardInterval, err := time.ParseDuration(os.Getenv("DASHBOARD_REFRESH_INTERVAL"))
+	if err != nil || dashboardInterval <= 0 {
+		dashboardInterval = 5 * time.Minute // Default dashboard refresh interval
+	}
+	jobs.Register(scheduler.Job{Name: "RefreshDashboards", Interval: dashboardInterval, Run: analyticsService.RefreshDashboards})
+
+	// Export changed rows to the data warehouse bucket
+	if warehouseStorage != nil {
+		warehouseInterval, err := time.ParseDuration(os.Getenv("WAREHOUSE_EXPORT_INTERVAL"))
+		if err != nil || warehouseInterval <= 0 {
+			warehouseInterval = 24 * time.Hour // Default nightly export
+		}
+		jobs.Register(scheduler.Job{Name: "ExportWarehouse", Interval: warehouseInterval, Run: warehouseService.ExportIncremental})
+	}
+
+	appCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+	jobs.Start(appCtx)
+
+	// Step 5: Start the HTTP server
 	// Log server startup information with organized route categories
-	log.Printf("Starting CarZone server on port %s", port)
+	log.Printf("Starting CarZone server on port %s (TLS mode: %s)", serverConfig.Port, serverConfig.TLSMode)
 	log.Println("🚀 CarZone API Server Started Successfully!")
 	log.Println("")
 	log.Println("📋 Available API Routes:")
 	log.Println("  🔐 Authentication (Public):")
+	log.Println("    GET  /auth/challenge - Bot check to pass before registering or resetting a password")
 	log.Println("    POST /auth/register  - Register new user account")
 	log.Println("    POST /auth/login     - User authentication")
-	log.Println("    GET  /auth/logout    - User logout")
+	log.Println("    POST /auth/refresh   - Exchange a refresh token for a new access token")
+	log.Println("    GET  /auth/logout    - User logout, ending the session's refresh token")
+	log.Println("    POST /auth/forgot-password - E-mail a password reset link")
+	log.Println("    POST /auth/reset-password  - Set a new password with a reset link's token")
+	log.Println("")
+	log.Println("  🔑 Two-Factor Authentication (Protected):")
+	log.Println("    POST /auth/2fa/enable - Start TOTP setup, returning the secret for an authenticator app")
+	log.Println("    POST /auth/2fa/verify - Confirm setup with a code and receive backup codes")
+	log.Println("")
+	log.Println("  🌐 Public Catalog (Public, cacheable):")
+	log.Println("    GET  /public/cars      - Browse active cars")
+	log.Println("    GET  /public/cars/search - Search active cars by brand, city, price and more")
+	log.Println("    GET  /public/cars/{id} - View a single active car")
+	log.Println("    GET  /public/cars/slug/{slug} - View a single active car by slug")
+	log.Println("    GET  /users/{id}/public-profile - Owner's rating, response metrics and active listings")
+	log.Println("    GET  /public/feed.json - JSON feed of active listings")
+	log.Println("    GET  /sitemap.xml      - Sitemap of active listings")
+	log.Println("    GET  /limits           - Caller's rate limit quota")
+	log.Println("    GET  /operator         - Branding of the marketplace serving this host")
 	log.Println("")
 	log.Println("   Car Management (Protected):")
-	log.Println("    GET    /cars           - Get all cars")
+	log.Println("    GET    /cars           - Get all cars (?features=sunroof,gps, ?sort=rank[&explain=true])")
+	log.Println("    GET    /features       - Features taxonomy with aliases and car counts")
+	log.Println("    GET    /car-attributes - Operator's custom car attributes (?attributes=pet_friendly:true on /cars)")
+	log.Println("    GET    /brands         - Brand autocomplete (?q=)")
+	log.Println("    GET    /brands/{id}/models - Model autocomplete for a brand (?q=)")
 	log.Println("    GET    /cars/{id}      - Get car by ID")
+	log.Println("    GET    /cars/slug/{slug} - Get car by slug")
 	log.Println("    GET    /cars/brand     - Get cars by brand")
 	log.Println("    POST   /cars           - Create new car")
 	log.Println("    PUT    /cars/{id}      - Update car")
+	log.Println("    PUT    /cars/{id}/status - Move car through its lifecycle")
+	log.Println("    GET    /cars/{id}/image-uploads - Upload progress of images sent as base64 (owner/admin)")
+	log.Println("    GET    /cars/{id}/price-history - Daily price changes with who made them")
+	log.Println("    GET    /cars/{id}/quality - Listing quality score with hints (owner or admin)")
+	log.Println("    GET    /cars/{id}/fuel-policy - Get a car's fuel policy")
+	log.Println("    PUT    /cars/{id}/fuel-policy - Configure a car's fuel policy (owner/admin)")
+	log.Println("    GET    /cars/{id}/eligibility - Get a car's minimum renter age and licence years")
+	log.Println("    PUT    /cars/{id}/eligibility - Set a car's minimum renter age and licence years (owner/admin)")
+	log.Println("    POST   /cars/{id}/featured    - Buy featured placement for a period (owner/admin)")
+	log.Println("    POST   /featured/{id}/verify  - Confirm a featured placement's Razorpay payment")
+	log.Println("    GET    /owners/me/featured    - Featured placements with impressions and clicks")
+	log.Println("    GET    /users/me/blocks       - Users you have blocked")
+	log.Println("    POST   /users/me/blocks       - Block a user from booking with you")
+	log.Println("    DELETE /users/me/blocks/{id}  - Unblock a user")
 	log.Println("    DELETE /cars/{id}      - Delete car")
 	log.Println("")
 	log.Println("  📅 Booking Management (Protected):")
 	log.Println("    GET    /bookings                    - Get all bookings")
 	log.Println("    GET    /bookings/{id}               - Get booking by ID")
 	log.Println("    POST   /bookings                    - Create new booking")
+	log.Println("    POST   /bookings/quote              - Itemised price incl. location and delivery fees")
+	log.Println("    GET    /cars/{id}/conflicts         - Whether a period is bookable and what blocks it")
 	log.Println("    DELETE /bookings/{id}               - Delete booking")
 	log.Println("    PUT    /bookings/{id}/status        - Update booking status")
 	log.Println("    GET    /bookings/customer/{id}      - Get bookings by customer")
 	log.Println("    GET    /bookings/car/{id}           - Get bookings by car")
 	log.Println("    GET    /bookings/owner/{id}         - Get bookings by owner")
+	log.Println("    POST   /bookings/{id}/checkout      - Record car handover to customer")
+	log.Println("    POST   /bookings/{id}/checkin       - Record car return")
+	log.Println("    GET    /bookings/{id}/inspections   - Get checkout and check-in records")
+	log.Println("    GET    /checklist-templates/current - Checklist answered at checkout")
+	log.Println("    GET    /checklist-templates/{version} - Checklist version a check-in answers")
+	log.Println("    GET    /admin/checklist-templates   - Checklist version history (admin)")
+	log.Println("    POST   /admin/checklist-templates   - Save a new checklist version (admin)")
+	log.Println("    GET    /bookings/{id}/extension     - Price extending a trip in progress (customer/admin)")
+	log.Println("    POST   /bookings/{id}/extension     - Extend a trip with a paid extension booking (customer/admin)")
+	log.Println("    POST   /bookings/{id}/payments/{paymentID}/collect - Record an offline payment collected (owner/admin)")
+	log.Println("    GET    /bookings/{id}/payment-collections - Offline collection audit trail (owner/admin)")
+	log.Println("    GET    /bookings/{id}/documents      - Numbered invoice, receipts and credit notes")
+	log.Println("    GET    /bookings/{id}/adjustments    - Credit notes and manual charges")
+	log.Println("    POST   /admin/bookings/{id}/adjustments - Raise a credit note or charge (admin)")
+	log.Println("    GET    /admin/adjustments            - Adjustment approval queue (admin, ?status=)")
+	log.Println("    POST   /admin/adjustments/{id}/review - Approve or reject as a second admin")
+	log.Println("    GET    /owners/me/trips             - Trips under way with my cars (owner/admin)")
 	log.Println("")
 	log.Println("  💳 Payment Management (Protected):")
 	log.Println("    POST   /payments                     - Create payment and Razorpay order")
@@ -189,8 +753,171 @@ func main() {
 	log.Println("    GET    /payments/{id}                - Get payment by ID")
 	log.Println("    GET    /payments/booking/{booking_id} - Get payment by booking ID")
 	log.Println("    GET    /payments/user/{user_id}      - Get payments by user ID")
+	log.Println("    POST   /payments/{id}/retry          - Retry a failed payment with a new order")
 	log.Println("    POST   /payments/{payment_id}/refund - Process payment refund")
 	log.Println("    GET    /payments                     - Get all payments")
+	log.Println("    GET    /payments/me/statement        - Statement of my payments and refunds (?from=&to=&format=pdf|csv)")
+	log.Println("    GET    /payments/me/statements/{id}  - Status of a statement rendered in the background")
+	log.Println("    GET    /payments/me/statements/{id}/download - Download a rendered statement")
+	log.Println("    POST   /admin/bookings/{id}/payment-link - Send a Razorpay payment link (admin)")
+	log.Println("    GET    /admin/payments/{id}          - Payment with gateway attempt history (admin)")
+	log.Println("    GET    /admin/accounting/export      - Ledger of a period for Tally or QuickBooks (?from=&to=&format=tally|iif|csv) (admin)")
+	log.Println("    POST   /webhooks/razorpay            - Payment link and dispute events (Razorpay signature, no session)")
+	log.Println("    POST   /webhooks/smart-locks         - Lock and unlock events (provider signature, no session)")
+	log.Println("")
+	log.Println("  ⚖️ Payment Disputes (Protected, admin):")
+	log.Println("    GET    /admin/disputes               - List disputes (?status=)")
+	log.Println("    GET    /admin/disputes/{id}          - Dispute with its evidence")
+	log.Println("    POST   /admin/disputes/{id}/evidence - Attach evidence")
+	log.Println("    PUT    /admin/disputes/{id}/outcome  - Record the outcome and release held payouts")
+	log.Println("")
+	log.Println("  🏦 Owner Payouts (Protected, owner/admin):")
+	log.Println("    GET    /owners/me/payout-accounts             - List payout accounts")
+	log.Println("    POST   /owners/me/payout-accounts             - Register bank account or UPI ID")
+	log.Println("    POST   /owners/me/payout-accounts/{id}/verify - Re-check verification status")
+	log.Println("    DELETE /owners/me/payout-accounts/{id}        - Remove payout account")
+	log.Println("    GET    /owners/me/payout-holds                - Payout holds placed by disputes")
+	log.Println("    GET    /owners/me/onboarding                  - Onboarding steps with the next one to take")
+	log.Println("")
+	log.Println("  🏖️ Vacation Mode (Protected, owner/admin):")
+	log.Println("    GET    /owners/me/vacation                    - List vacations")
+	log.Println("    POST   /owners/me/vacation                    - Block all of your cars for a period")
+	log.Println("    DELETE /owners/me/vacation/{id}               - Lift a vacation")
+	log.Println("")
+	log.Println("  🚙 Fleets (Protected, owner/admin):")
+	log.Println("    GET    /owners/me/fleets                      - List fleets")
+	log.Println("    POST   /owners/me/fleets                      - Create fleet with shared settings")
+	log.Println("    GET    /owners/me/fleets/{id}                 - Get fleet with its cars")
+	log.Println("    PUT    /owners/me/fleets/{id}                 - Replace name, pricing rules, add-ons and blackouts")
+	log.Println("    DELETE /owners/me/fleets/{id}                 - Remove fleet")
+	log.Println("    POST   /owners/me/fleets/{id}/cars            - Add car to fleet")
+	log.Println("    DELETE /owners/me/fleets/{id}/cars/{carID}    - Remove car from fleet")
+	log.Println("    GET    /owners/me/fleet-valuation             - Estimated value of my cars (?format=csv)")
+	log.Println("    PUT    /owners/me/fleet-valuation/cars/{carID} - Record what I paid for a car")
+	log.Println("")
+	log.Println("  🛡️ Security Events (Protected, admin):")
+	log.Println("    GET    /admin/security-events             - Review queue (filter by status, type, user_id)")
+	log.Println("    GET    /admin/security-events/{id}        - Get security event")
+	log.Println("    PUT    /admin/security-events/{id}/review - Mark event reviewed or dismissed")
+	log.Println("")
+	log.Println("  📈 Dashboard Metrics (Protected, admin):")
+	log.Println("    GET    /admin/metrics/timeseries          - Bucketed bookings, revenue, signups or cancellations")
+	log.Println("")
+	log.Println("  🏭 Data Warehouse Export (Protected, admin):")
+	log.Println("    POST   /admin/warehouse/backfill          - Re-export rows changed in a range")
+	log.Println("")
+	log.Println("  🔎 Support Search (Protected, admin):")
+	log.Println("    GET    /admin/search?q=                   - Find users, cars, bookings and payments")
+	log.Println("")
+	log.Println("  🧰 Maintenance Runbook (Protected, admin):")
+	log.Println("    POST   /admin/maintenance/{task}          - Queue rebuild_car_availability, recompute_booking_totals,")
+	log.Println("                                                resync_payment or reindex_search")
+	log.Println("    GET    /admin/maintenance/jobs            - Latest jobs with who ran them and what changed (filter by task)")
+	log.Println("    GET    /admin/maintenance/jobs/{id}       - Get maintenance job")
+	log.Println("")
+	log.Println("  🐞 Debug Captures (Protected, admin):")
+	log.Println("    GET    /admin/debug-captures              - Sampled, sanitized request/response captures (filter by user_id, path, min_status)")
+	log.Println("    GET    /admin/debug-captures/{id}         - Get debug capture")
+	log.Println("")
+	log.Println("  🏷️ Features Taxonomy (Protected, admin):")
+	log.Println("    POST   /admin/features                    - Add a feature")
+	log.Println("    PUT    /admin/features/{key}              - Replace a feature's name and aliases")
+	log.Println("")
+	log.Println("  🧩 Custom Car Attributes (Protected, admin):")
+	log.Println("    POST   /admin/car-attributes              - Define an attribute")
+	log.Println("    PUT    /admin/car-attributes/{key}        - Replace an attribute's name, options and required flag")
+	log.Println("    DELETE /admin/car-attributes/{key}        - Remove an attribute and its values")
+	log.Println("")
+	log.Println("  ⚖️ Settings (Protected, admin):")
+	log.Println("    GET    /admin/settings/search-ranking     - Weights of ranked car listings")
+	log.Println("    PUT    /admin/settings/search-ranking     - Replace the ranking weights")
+	log.Println("    GET    /admin/settings/depreciation       - Depreciation curves of fleet valuations")
+	log.Println("    PUT    /admin/settings/depreciation       - Replace the depreciation curves")
+	log.Println("")
+	log.Println("  👥 Users (Protected, admin):")
+	log.Println("    GET    /admin/users                       - All users, newest first")
+	log.Println("    GET    /admin/users/role/{role}           - All owners, renters or admins")
+	log.Println("    GET    /admin/users/{id}                  - A user's account")
+	log.Println("    PUT    /admin/users/{id}                  - Replace a user's account details")
+	log.Println("    DELETE /admin/users/{id}                  - Delete a user without bookings")
+	log.Println("")
+	log.Println("  🪪 Renter KYC (Protected, admin):")
+	log.Println("    GET    /admin/users/{id}/kyc              - A user's verified date of birth and licence issue date")
+	log.Println("    PUT    /admin/users/{id}/kyc              - Record the dates read from a user's documents")
+	log.Println("")
+	log.Println("  ✉️ Email Templates (Protected, admin):")
+	log.Println("    GET    /admin/email-templates             - Current version of every template")
+	log.Println("    POST   /admin/email-templates             - Add a template for a new key")
+	log.Println("    GET    /admin/email-templates/{key}       - Current version of a template")
+	log.Println("    PUT    /admin/email-templates/{key}       - Save a new version")
+	log.Println("    DELETE /admin/email-templates/{key}       - Revert to the built-in copy")
+	log.Println("    GET    /admin/email-templates/{key}/versions - Version history")
+	log.Println("    POST   /admin/email-templates/{key}/preview  - Render with sample variables")
+	log.Println("")
+	log.Println("  🏢 Marketplace Operators (Protected, platform admin):")
+	log.Println("    GET    /admin/operators                   - List operators")
+	log.Println("    POST   /admin/operators                   - Onboard an operator")
+	log.Println("    GET    /admin/operators/{id}              - Get operator")
+	log.Println("    PUT    /admin/operators/{id}              - Update branding, domain and commission")
+	log.Println("")
+	log.Println("  📬 Notifications (Protected):")
+	log.Println("    GET    /notifications                 - Inbox, newest first (?unread=true)")
+	log.Println("    GET    /notifications/unread-count    - Unread count for the bell icon")
+	log.Println("    POST   /notifications/{id}/read       - Mark a notification as read")
+	log.Println("")
+	log.Println("  📍 Pickup Locations (Protected):")
+	log.Println("    GET    /locations                     - List locations (filter by city)")
+	log.Println("    GET    /locations/{id}                - Get location")
+	log.Println("    POST   /locations                     - Create location (admin)")
+	log.Println("    PUT    /locations/{id}                - Update location (admin)")
+	log.Println("    DELETE /locations/{id}                - Delete location (admin)")
+	log.Println("    GET    /cars/{id}/locations           - Locations a car is available at")
+	log.Println("    PUT    /cars/{id}/locations/{locationID} - Attach car to location (owner/admin)")
+	log.Println("    DELETE /cars/{id}/locations/{locationID} - Detach car from location (owner/admin)")
+	log.Println("    GET    /cars/{id}/delivery            - Door delivery settings of a car")
+	log.Println("    PUT    /cars/{id}/delivery            - Configure door delivery (owner/admin)")
+	log.Println("    GET    /relocation-fees               - One-way fee matrix between cities")
+	log.Println("    PUT    /relocation-fees               - Set one-way fee of a city pair (admin)")
+	log.Println("    DELETE /relocation-fees/{id}          - Stop one-way rentals between a city pair (admin)")
+	log.Println("")
+	log.Println("  🔔 Car Alerts (Protected):")
+	log.Println("    PUT    /cars/{id}/alerts              - Watch a car for price drops or open dates")
+	log.Println("    GET    /users/me/car-alerts           - List your car alerts")
+	log.Println("    DELETE /users/me/car-alerts/{id}      - Stop a car alert")
+	log.Println("  👤 Account (Protected):")
+	log.Println("    GET    /users/me                      - Your account")
+	log.Println("    PUT    /users/me                      - Update your account details")
+	log.Println("    PATCH  /users/me/profile              - Merge fields into your profile data")
+	log.Println("    DELETE /users/me                      - Close your account")
+	log.Println("  ⭐ Favorites (Protected):")
+	log.Println("    GET    /users/me/favorites            - List your saved cars")
+	log.Println("    POST   /users/me/favorites/{carID}    - Save a car")
+	log.Println("    DELETE /users/me/favorites/{carID}    - Remove a saved car")
+	log.Println("")
+	log.Println("  📡 Telemetry:")
+	log.Println("    POST   /telemetry                     - Signed device reading (device HMAC, no session)")
+	log.Println("    POST   /cars/{id}/telemetry/device    - Provision device or rotate its secret (owner/admin)")
+	log.Println("    GET    /cars/{id}/telemetry/latest    - Latest telemetry snapshot (owner/admin)")
+	log.Println("    GET    /cars/{id}/geofences           - List geofences (owner/admin)")
+	log.Println("    POST   /cars/{id}/geofences           - Define geofence for rentals (owner/admin)")
+	log.Println("    DELETE /cars/{id}/geofences/{geofenceID} - Remove geofence (owner/admin)")
+	log.Println("    GET    /cars/{id}/geofences/breaches  - Recent geofence exits (owner/admin)")
+	log.Println("")
+	log.Println("  🔑 Keyless Entry:")
+	log.Println("    GET    /cars/{id}/smart-lock          - Smart lock fitted in the car (owner/admin)")
+	log.Println("    PUT    /cars/{id}/smart-lock          - Link the car to its smart lock (owner/admin)")
+	log.Println("    DELETE /cars/{id}/smart-lock          - Unlink the smart lock (owner/admin)")
+	log.Println("    GET    /bookings/{id}/digital-keys    - Digital keys issued for the booking")
+	log.Println("    POST   /bookings/{id}/digital-keys    - Revoke the booking's key and issue a new one (owner/admin)")
+	log.Println("    GET    /bookings/{id}/lock-events     - Lock and unlock events of the booking")
+	log.Println("")
+	log.Println("  🚨 Incidents:")
+	log.Println("    POST   /bookings/{id}/incidents       - Report an accident, theft or damage during the booking")
+	log.Println("    GET    /bookings/{id}/incidents       - Incidents reported against the booking")
+	log.Println("    GET    /incidents/{id}                - Incident with its insurance and police documents")
+	log.Println("    POST   /incidents/{id}/documents      - Attach an insurance, police or repair document")
+	log.Println("    PUT    /incidents/{id}/status         - Move the incident on until resolved (owner/admin)")
+	log.Println("    GET    /admin/incidents               - Incidents by status (admin only)")
 	log.Println("")
 	log.Println("  📊 Monitoring:")
 	log.Println("    GET /metrics - Prometheus metrics")
@@ -198,7 +925,13 @@ func main() {
 	log.Println("✨ Routes are organized using the new routes layer for better maintainability!")
 
 	// Start the HTTP server - this blocks until server shuts down
-	if err := http.ListenAndServe(":"+port, router); err != nil {
+	// HSTS, client IP, debug capture, rate limit, operator and country resolution wrap the router so they also cover 404 and 405 responses
+	geoCountry := middleware.GeoCountryMiddleware(os.Getenv("GEO_COUNTRY_HEADER"), trustedProxies)
+	rateLimiter := middleware.RateLimiterFromEnv()
+	tenantScope := middleware.TenantMiddleware(operatorService.ResolveDomain)
+	debugCapture := middleware.DebugCaptureFromEnv(debugCaptureService.SaveCapture)
+	handler := middleware.HSTSMiddleware(serverConfig.HSTSHeader())(middleware.ClientIPMiddleware(trustedProxies)(debugCapture.Middleware(rateLimiter.Middleware(tenantScope(geoCountry(router))))))
+	if err := server.ListenAndServe(serverConfig, handler); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
@@ -224,6 +957,8 @@ func startTracing() (*trace.TracerProvider, error) {
 			trace.WithMaxExportBatchSize(trace.DefaultMaxExportBatchSize),
 			trace.WithBatchTimeout(trace.DefaultScheduleDelay*time.Millisecond),
 		),
+		// Stamp every span of an authenticated request with the user it acts for
+		trace.WithSpanProcessor(identity.SpanProcessor{}),
 		trace.WithResource(resource.NewWithAttributes(
 			semconv.SchemaURL,
 			semconv.ServiceNameKey.String("CarZone"),
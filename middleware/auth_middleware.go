@@ -8,28 +8,74 @@ import (
 	"strings"
 	"time"
 
+	"github.com/PrateekKumar15/CarZone/identity"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/secrets"
+	"github.com/PrateekKumar15/CarZone/tenant"
 	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 )
 
 // Define a custom type for context keys to avoid collisions
 type contextKey string
 
-const (
-	emailContextKey contextKey = "email"
-)
+const emailContextKey contextKey = "email"
+
+// Claims is the JWT payload issued at login. The email stays in Subject for
+// compatibility; UserID and Role let handlers authorize without a database lookup.
+type Claims struct {
+	UserID     string `json:"uid,omitempty"`
+	Role       string `json:"role,omitempty"`
+	OperatorID string `json:"tid,omitempty"` // Marketplace operator; empty in tokens issued before operators existed
+	jwt.StandardClaims
+}
+
+// EmailFromContext returns the authenticated user's email set by AuthMiddleware
+func EmailFromContext(ctx context.Context) string {
+	email, _ := ctx.Value(emailContextKey).(string)
+	return email
+}
+
+// UserIDFromContext returns the authenticated user's ID set by AuthMiddleware.
+// It is empty for tokens issued before user IDs were added to the claims.
+func UserIDFromContext(ctx context.Context) string {
+	user, _ := identity.FromContext(ctx)
+	return user.ID
+}
+
+// RoleFromContext returns the authenticated user's role set by AuthMiddleware
+func RoleFromContext(ctx context.Context) string {
+	user, _ := identity.FromContext(ctx)
+	return user.Role
+}
 
 func getSecretKey() string {
-	secret := os.Getenv("SECRET_KEY")
-	if secret == "" {
-		return "your_secret_key" // fallback for development
+	return secrets.GetOrDefault("SECRET_KEY", "your_secret_key") // fallback for development
+}
+
+// verificationKeys returns the keys a token signature is checked against.
+// SECRET_KEY_PREVIOUS keeps tokens issued before a signing-key rotation valid until they expire.
+func verificationKeys() []string {
+	keys := []string{getSecretKey()}
+	if previous := secrets.Get("SECRET_KEY_PREVIOUS"); previous != "" {
+		keys = append(keys, previous)
 	}
-	return secret
+	return keys
 }
 
 // ValidateToken validates a JWT token and returns the email (stored in Subject) if valid
 func ValidateToken(tokenString string) (string, error) {
+	claims, err := ParseToken(tokenString)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+// ParseToken validates a JWT token and returns all of its claims
+func ParseToken(tokenString string) (*Claims, error) {
 	if tokenString == "" {
-		return "", errors.New("empty token")
+		return nil, errors.New("empty token")
 	}
 
 	// Accept tokens prefixed with "Bearer "
@@ -37,35 +83,85 @@ func ValidateToken(tokenString string) (string, error) {
 		tokenString = tokenString[7:]
 	}
 
-	secretKey := getSecretKey()
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.StandardClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Validate signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid signing method")
+	var token *jwt.Token
+	var err error
+	for _, secretKey := range verificationKeys() {
+		token, err = jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+			// Validate signing method
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, errors.New("invalid signing method")
+			}
+			return []byte(secretKey), nil
+		})
+
+		// Only a signature mismatch is worth retrying with an older key
+		var validationErr *jwt.ValidationError
+		if err == nil || !errors.As(err, &validationErr) || validationErr.Errors&jwt.ValidationErrorSignatureInvalid == 0 {
+			break
 		}
-		return []byte(secretKey), nil
-	})
+	}
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	claims, ok := token.Claims.(*jwt.StandardClaims)
+	claims, ok := token.Claims.(*Claims)
 	if !ok || !token.Valid {
-		return "", errors.New("invalid token")
+		return nil, errors.New("invalid token")
 	}
 
 	// Check expiry if present
 	if claims.ExpiresAt != 0 && time.Now().Unix() > claims.ExpiresAt {
-		return "", errors.New("token expired")
+		return nil, errors.New("token expired")
 	}
 
 	if claims.Subject == "" {
-		return "", errors.New("email not found in token")
+		return nil, errors.New("email not found in token")
 	}
 
-	// Subject contains the email
-	return claims.Subject, nil
+	return claims, nil
+}
+
+// AccessTokenTTL is how long an access token works: ACCESS_TOKEN_TTL, 15 minutes by default.
+// Clients keep a session going by exchanging their refresh token at /auth/refresh.
+func AccessTokenTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("ACCESS_TOKEN_TTL"))
+	if err != nil || ttl <= 0 {
+		return 15 * time.Minute
+	}
+	return ttl
+}
+
+// IssueToken signs an access token for a user with the current SECRET_KEY, valid for AccessTokenTTL
+func IssueToken(user models.User) (string, error) {
+	claims := &Claims{
+		UserID: user.ID.String(),
+		Role:   user.Role,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(AccessTokenTTL()).Unix(),
+			IssuedAt:  time.Now().Unix(),
+			Issuer:    "CarZone",
+			Subject:   user.Email,
+		},
+	}
+	// The operator is carried in the token so later requests are scoped to its marketplace
+	if user.OperatorID != nil {
+		claims.OperatorID = user.OperatorID.String()
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secrets.Get("SECRET_KEY")))
+}
+
+// tokenFromRequest returns the bearer token from the Authorization header, falling back to
+// the auth_token cookie. It is empty when neither is present.
+func tokenFromRequest(r *http.Request) string {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader != "" && strings.HasPrefix(authHeader, "Bearer ") {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	if cookie, err := r.Cookie("auth_token"); err == nil {
+		return cookie.Value
+	}
+	return ""
 }
 
 func AuthMiddleware(next http.Handler) http.Handler {
@@ -76,18 +172,7 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		var tokenString string
-
-		// Try to get token from Authorization header first
-		authHeader := r.Header.Get("Authorization")
-		if authHeader != "" && strings.HasPrefix(authHeader, "Bearer ") {
-			tokenString = strings.TrimPrefix(authHeader, "Bearer ")
-		} else {
-			// If no Authorization header, try to get from cookie
-			if cookie, err := r.Cookie("auth_token"); err == nil {
-				tokenString = cookie.Value
-			}
-		}
+		tokenString := tokenFromRequest(r)
 
 		// If no token found, return unauthorized
 		if tokenString == "" {
@@ -96,16 +181,65 @@ func AuthMiddleware(next http.Handler) http.Handler {
 		}
 
 		// Validate the token using the same logic as in auth handler
-		email, err := ValidateToken(tokenString)
+		claims, err := ParseToken(tokenString)
 		if err != nil {
 			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
 			return
 		}
 
-		// Add the email to the request context
-		ctx := context.WithValue(r.Context(), emailContextKey, email)
+		// Scope the request to the token's operator. On a white-label domain the token must
+		// have been issued by that domain's operator.
+		operatorID := tenant.DefaultOperatorID
+		if claims.OperatorID != "" {
+			if operatorID, err = uuid.Parse(claims.OperatorID); err != nil {
+				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+				return
+			}
+		}
+		if hostOperator, ok := tenant.OperatorFromContext(r.Context()); ok && hostOperator != operatorID {
+			http.Error(w, "Token was issued for a different operator", http.StatusUnauthorized)
+			return
+		}
+
+		// Add the caller's identity to the request context, where stores can scope queries to it
+		ctx := tenant.WithOperator(r.Context(), operatorID)
+		ctx = context.WithValue(ctx, emailContextKey, claims.Subject)
+		ctx = identity.WithUser(ctx, claims.UserID, claims.Role)
 		r = r.WithContext(ctx)
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+// RequireRole rejects requests whose authenticated role is not one of roles.
+// The admin role only counts for admins of the default operator: routes guarded by it
+// expose platform-wide data, while other operators' admins are limited to their own
+// cars, bookings and payments. It must run after AuthMiddleware.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Skip authorization for OPTIONS requests (CORS preflight)
+			if r.Method == "OPTIONS" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			role := RoleFromContext(r.Context())
+			for _, allowed := range roles {
+				if role == allowed && (role != "admin" || IsPlatformOperator(r.Context())) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			http.Error(w, "Insufficient permissions", http.StatusForbidden)
+		})
+	}
+}
+
+// IsPlatformOperator reports whether the request acts for the default operator, whose
+// admins run the platform
+func IsPlatformOperator(ctx context.Context) bool {
+	operatorID, ok := tenant.OperatorFromContext(ctx)
+	return ok && operatorID == tenant.DefaultOperatorID
+}
@@ -0,0 +1,355 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+)
+
+// debugCaptureHeader asks for a request to be captured; its value must be DEBUG_CAPTURE_TOKEN
+const debugCaptureHeader = "X-Debug-Capture"
+
+// redacted replaces sensitive values in captures
+const redacted = "[redacted]"
+
+// sensitiveNames are substrings of header, query parameter and JSON field names whose values
+// are credentials or personal data and never leave the request in a capture
+var sensitiveNames = []string{"authorization", "cookie", "password", "secret", "token", "signature",
+	"otp", "cvv", "card", "account_number", "ifsc", "aadhaar", "license", "date_of_birth", "phone", "email",
+	"debug-capture", "api-key", "api_key"}
+
+// DebugCapture stores sanitized copies of requests and their responses for troubleshooting
+// intermittent client issues. It captures a sampled share of all traffic, every request of
+// chosen users or under chosen paths, and requests that carry X-Debug-Capture with the
+// configured token. Credentials and personal data are redacted, bodies are truncated and
+// bodies that are not JSON, form or text are omitted. Captures are saved after the response
+// is sent, so they never slow it down.
+//
+// It is off unless configured, and must run after ClientIPMiddleware.
+type DebugCapture struct {
+	sampleRate float64
+	users      map[string]bool
+	routes     []string
+	token      string
+	maxBody    int
+	ttl        time.Duration
+	save       func(ctx context.Context, capture models.DebugCapture) error
+}
+
+// DebugCaptureFromEnv creates a debug capture configured by DEBUG_CAPTURE_SAMPLE_RATE (share
+// of requests from 0 to 1, default 0), DEBUG_CAPTURE_USERS (comma-separated user IDs),
+// DEBUG_CAPTURE_ROUTES (comma-separated path prefixes), DEBUG_CAPTURE_TOKEN,
+// DEBUG_CAPTURE_MAX_BODY (bytes kept per body, default 16384) and DEBUG_CAPTURE_TTL
+// (default 24h). Captures are handed to save.
+func DebugCaptureFromEnv(save func(ctx context.Context, capture models.DebugCapture) error) *DebugCapture {
+	sampleRate, err := strconv.ParseFloat(os.Getenv("DEBUG_CAPTURE_SAMPLE_RATE"), 64)
+	if err != nil || sampleRate < 0 || sampleRate > 1 {
+		sampleRate = 0
+	}
+	maxBody, err := strconv.Atoi(os.Getenv("DEBUG_CAPTURE_MAX_BODY"))
+	if err != nil || maxBody <= 0 {
+		maxBody = 16 << 10
+	}
+	ttl, err := time.ParseDuration(os.Getenv("DEBUG_CAPTURE_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
+	c := &DebugCapture{
+		sampleRate: sampleRate,
+		users:      make(map[string]bool),
+		token:      os.Getenv("DEBUG_CAPTURE_TOKEN"),
+		maxBody:    maxBody,
+		ttl:        ttl,
+		save:       save,
+	}
+	for _, user := range strings.Split(os.Getenv("DEBUG_CAPTURE_USERS"), ",") {
+		if user = strings.TrimSpace(user); user != "" {
+			c.users[user] = true
+		}
+	}
+	for _, route := range strings.Split(os.Getenv("DEBUG_CAPTURE_ROUTES"), ",") {
+		if route = strings.TrimSpace(route); route != "" {
+			c.routes = append(c.routes, route)
+		}
+	}
+	return c
+}
+
+// Enabled reports whether any request can be captured
+func (c *DebugCapture) Enabled() bool {
+	return c.sampleRate > 0 || len(c.users) > 0 || len(c.routes) > 0 || c.token != ""
+}
+
+// Middleware captures the selected requests and their responses
+func (c *DebugCapture) Middleware(next http.Handler) http.Handler {
+	if !c.Enabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reason, userID := c.selects(r)
+		if reason == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Bodies are copied as the handler reads and writes them, up to maxBody bytes each
+		start := time.Now()
+		requestBody := &cappedBuffer{max: c.maxBody}
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = &teeReadCloser{ReadCloser: r.Body, copy: requestBody}
+		}
+		recorder := &captureWriter{ResponseWriter: w, status: http.StatusOK, body: &cappedBuffer{max: c.maxBody}}
+
+		next.ServeHTTP(recorder, r)
+
+		capture := models.DebugCapture{
+			ID:              uuid.New(),
+			Reason:          reason,
+			UserID:          userID,
+			Method:          r.Method,
+			Path:            r.URL.Path,
+			Query:           redactQuery(r.URL.RawQuery),
+			ClientIP:        ClientIPFromContext(r.Context()),
+			RequestHeaders:  redactHeaders(r.Header),
+			RequestBody:     redactBody(r.Header.Get("Content-Type"), requestBody),
+			Status:          recorder.status,
+			ResponseHeaders: redactHeaders(recorder.Header()),
+			ResponseBody:    redactBody(recorder.Header().Get("Content-Type"), recorder.body),
+			DurationMs:      time.Since(start).Milliseconds(),
+			CreatedAt:       start,
+			ExpiresAt:       start.Add(c.ttl),
+		}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), 10*time.Second)
+			defer cancel()
+			if err := c.save(ctx, capture); err != nil {
+				log.Printf("Failed to save debug capture of %s %s: %v", capture.Method, capture.Path, err)
+			}
+		}()
+	})
+}
+
+// selects decides whether a request is captured and why, and returns its caller when known
+func (c *DebugCapture) selects(r *http.Request) (string, *uuid.UUID) {
+	var userID *uuid.UUID
+	if token := tokenFromRequest(r); token != "" {
+		if claims, err := ParseToken(token); err == nil {
+			if id, err := uuid.Parse(claims.UserID); err == nil {
+				userID = &id
+			}
+		}
+	}
+
+	if header := r.Header.Get(debugCaptureHeader); header != "" && c.token != "" &&
+		subtle.ConstantTimeCompare([]byte(header), []byte(c.token)) == 1 {
+		return models.DebugCaptureReasonHeader, userID
+	}
+	if userID != nil && c.users[userID.String()] {
+		return models.DebugCaptureReasonUser, userID
+	}
+	for _, route := range c.routes {
+		if strings.HasPrefix(r.URL.Path, route) {
+			return models.DebugCaptureReasonRoute, userID
+		}
+	}
+	if c.sampleRate > 0 && rand.Float64() < c.sampleRate {
+		return models.DebugCaptureReasonSampled, userID
+	}
+	return "", nil
+}
+
+// cappedBuffer keeps the first max bytes written to it and counts the rest
+type cappedBuffer struct {
+	bytes.Buffer
+	max   int
+	total int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	b.total += len(p)
+	if room := b.max - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+// truncated reports whether bytes were dropped
+func (b *cappedBuffer) truncated() bool {
+	return b.total > b.Len()
+}
+
+// teeReadCloser copies what the handler reads from a request body
+type teeReadCloser struct {
+	io.ReadCloser
+	copy *cappedBuffer
+}
+
+func (t *teeReadCloser) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	t.copy.Write(p[:n])
+	return n, err
+}
+
+// captureWriter records the status and copies the body of a response as it is written
+type captureWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        *cappedBuffer
+}
+
+func (w *captureWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.status, w.wroteHeader = statusCode, true
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *captureWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	w.body.Write(p)
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush keeps streamed responses streaming
+func (w *captureWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *captureWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// isSensitive reports whether a header, parameter or field name holds a secret or personal data
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactHeaders flattens headers, hiding the values of sensitive ones
+func redactHeaders(header http.Header) map[string]string {
+	flat := make(map[string]string, len(header))
+	for name, values := range header {
+		if isSensitive(name) {
+			flat[name] = redacted
+			continue
+		}
+		flat[name] = strings.Join(values, ", ")
+	}
+	return flat
+}
+
+// redactQuery hides the values of sensitive query parameters
+func redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "[unparseable query omitted]"
+	}
+	return redactValues(values).Encode()
+}
+
+// redactValues hides the values of sensitive form or query parameters
+func redactValues(values url.Values) url.Values {
+	for name := range values {
+		if isSensitive(name) {
+			values[name] = []string{redacted}
+		}
+	}
+	return values
+}
+
+// redactBody returns a body as it may be stored: JSON and forms with sensitive values hidden,
+// text as is, and a note in place of anything else
+func redactBody(contentType string, body *cappedBuffer) string {
+	if body.total == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		// A truncated document cannot be parsed, so its values cannot be checked
+		if body.truncated() {
+			return fmt.Sprintf("[JSON body of %d bytes omitted, over the capture limit]", body.total)
+		}
+		var document interface{}
+		if err := json.Unmarshal(body.Bytes(), &document); err != nil {
+			return "[unparseable JSON body omitted]"
+		}
+		sanitized, err := json.Marshal(redactJSON(document))
+		if err != nil {
+			return "[unparseable JSON body omitted]"
+		}
+		return string(sanitized)
+	case mediaType == "application/x-www-form-urlencoded":
+		if body.truncated() {
+			return fmt.Sprintf("[form body of %d bytes omitted, over the capture limit]", body.total)
+		}
+		values, err := url.ParseQuery(body.String())
+		if err != nil {
+			return "[unparseable form body omitted]"
+		}
+		return redactValues(values).Encode()
+	case strings.HasPrefix(mediaType, "text/"):
+		if body.truncated() {
+			return body.String() + fmt.Sprintf("... [truncated, %d bytes in total]", body.total)
+		}
+		return body.String()
+	default:
+		if mediaType == "" {
+			mediaType = "untyped"
+		}
+		return fmt.Sprintf("[%s body of %d bytes omitted]", mediaType, body.total)
+	}
+}
+
+// redactJSON hides the values of sensitive fields at any depth of a decoded JSON document
+func redactJSON(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, field := range typed {
+			if isSensitive(key) {
+				typed[key] = redacted
+				continue
+			}
+			typed[key] = redactJSON(field)
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = redactJSON(item)
+		}
+	}
+	return value
+}
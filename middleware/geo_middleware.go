@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const countryContextKey contextKey = "country"
+
+// GeoCountryMiddleware stores the client's country code, as reported by a CDN or load
+// balancer header (e.g. CF-IPCountry or CloudFront-Viewer-Country), in the request context.
+// Like forwarding headers, the country header is only honoured from trusted proxies.
+// An empty header name disables the lookup. Read the result with CountryFromContext.
+func GeoCountryMiddleware(header string, trustedProxies []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if header == "" {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			remote := r.RemoteAddr
+			if host, _, err := net.SplitHostPort(remote); err == nil {
+				remote = host
+			}
+
+			country := strings.ToUpper(strings.TrimSpace(r.Header.Get(header)))
+			// Two-letter ISO codes only; CDNs use values such as "XX" or "T1" for unknown or Tor
+			if isTrustedProxy(remote, trustedProxies) && len(country) == 2 && country != "XX" && country != "T1" {
+				ctx := context.WithValue(r.Context(), countryContextKey, country)
+				r = r.WithContext(ctx)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CountryFromContext returns the ISO 3166-1 alpha-2 country set by GeoCountryMiddleware,
+// or an empty string when it is unknown
+func CountryFromContext(ctx context.Context) string {
+	country, _ := ctx.Value(countryContextKey).(string)
+	return country
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// HSTSMiddleware sets the Strict-Transport-Security header on responses to HTTPS requests.
+// Requests count as HTTPS when they arrived over TLS or a TLS-terminating proxy reports
+// X-Forwarded-Proto: https. An empty header value disables the middleware.
+func HSTSMiddleware(header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if header == "" {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+				w.Header().Set("Strict-Transport-Security", header)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+)
+
+const rateLimitContextKey contextKey = "rate_limit"
+
+// RateLimiter counts requests per caller in fixed windows and reports the quota in
+// X-RateLimit-* headers on every response. Authenticated callers are counted per user,
+// everyone else per client IP. Unless enforcing, requests over the limit are still served,
+// so clients can use the headers to slow down before limits are switched on.
+//
+// Counters live in memory, so each instance counts separately.
+type RateLimiter struct {
+	anonymousLimit     int
+	authenticatedLimit int
+	window             time.Duration
+	enforce            bool
+
+	mu        sync.Mutex
+	counters  map[string]*rateWindow
+	lastSweep time.Time
+}
+
+// rateWindow is one caller's request count in the window starting at start
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// NewRateLimiter creates a rate limiter allowing the given number of requests per window
+func NewRateLimiter(anonymousLimit, authenticatedLimit int, window time.Duration, enforce bool) *RateLimiter {
+	return &RateLimiter{
+		anonymousLimit:     anonymousLimit,
+		authenticatedLimit: authenticatedLimit,
+		window:             window,
+		enforce:            enforce,
+		counters:           make(map[string]*rateWindow),
+	}
+}
+
+// RateLimiterFromEnv creates a rate limiter configured by RATE_LIMIT_ANONYMOUS (default 60),
+// RATE_LIMIT_AUTHENTICATED (default 600), RATE_LIMIT_WINDOW (default 1m) and RATE_LIMIT_ENFORCE
+func RateLimiterFromEnv() *RateLimiter {
+	anonymousLimit, err := strconv.Atoi(os.Getenv("RATE_LIMIT_ANONYMOUS"))
+	if err != nil || anonymousLimit <= 0 {
+		anonymousLimit = 60
+	}
+	authenticatedLimit, err := strconv.Atoi(os.Getenv("RATE_LIMIT_AUTHENTICATED"))
+	if err != nil || authenticatedLimit <= 0 {
+		authenticatedLimit = 600
+	}
+	window, err := time.ParseDuration(os.Getenv("RATE_LIMIT_WINDOW"))
+	if err != nil || window <= 0 {
+		window = time.Minute
+	}
+	enforce, _ := strconv.ParseBool(os.Getenv("RATE_LIMIT_ENFORCE"))
+
+	return NewRateLimiter(anonymousLimit, authenticatedLimit, window, enforce)
+}
+
+// Middleware counts the request, sets the X-RateLimit-Limit, X-RateLimit-Remaining and
+// X-RateLimit-Reset (Unix seconds) headers and stores the quota in the request context.
+// It must run after ClientIPMiddleware. Read the result with RateLimitFromContext.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// CORS preflights are sent by browsers, not API consumers, so they are not counted
+		if r.Method == "OPTIONS" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		status, exceeded := l.take(r, time.Now())
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(status.Limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(status.Remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(status.Reset.Unix(), 10))
+
+		if exceeded && l.enforce {
+			retryAfter := int(time.Until(status.Reset).Seconds()) + 1
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			http.Error(w, fmt.Sprintf("Rate limit exceeded, retry in %d seconds", retryAfter), http.StatusTooManyRequests)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), rateLimitContextKey, status)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RateLimitFromContext returns the caller's quota set by RateLimiter.Middleware
+func RateLimitFromContext(ctx context.Context) (models.RateLimitStatus, bool) {
+	status, ok := ctx.Value(rateLimitContextKey).(models.RateLimitStatus)
+	return status, ok
+}
+
+// take counts a request against its caller and returns the resulting quota, and whether the
+// caller is over the limit
+func (l *RateLimiter) take(r *http.Request, now time.Time) (models.RateLimitStatus, bool) {
+	key, scope, limit := l.caller(r)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop finished windows once per window so idle callers do not accumulate
+	if now.Sub(l.lastSweep) >= l.window {
+		for k, counter := range l.counters {
+			if now.Sub(counter.start) >= l.window {
+				delete(l.counters, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	counter, ok := l.counters[key]
+	if !ok || now.Sub(counter.start) >= l.window {
+		counter = &rateWindow{start: now}
+		l.counters[key] = counter
+	}
+	counter.count++
+
+	remaining := limit - counter.count
+	if remaining < 0 {
+		remaining = 0
+	}
+	return models.RateLimitStatus{
+		Scope:     scope,
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     counter.start.Add(l.window),
+		Window:    l.window.String(),
+		Enforced:  l.enforce,
+	}, counter.count > limit
+}
+
+// caller identifies who a request is counted against. A valid token counts against its user
+// on every route, including public ones; anything else counts against the client IP.
+func (l *RateLimiter) caller(r *http.Request) (key, scope string, limit int) {
+	if token := tokenFromRequest(r); token != "" {
+		if claims, err := ParseToken(token); err == nil {
+			user := claims.UserID
+			if user == "" {
+				user = claims.Subject
+			}
+			return "user:" + user, "user", l.authenticatedLimit
+		}
+	}
+
+	ip := ClientIPFromContext(r.Context())
+	if ip == "" {
+		ip = r.RemoteAddr
+	}
+	return "ip:" + ip, "ip", l.anonymousLimit
+}
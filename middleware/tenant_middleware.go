@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/tenant"
+)
+
+// OperatorResolver looks up the operator serving a host name; nil when the host is not a
+// white-label domain
+type OperatorResolver func(ctx context.Context, host string) (*models.Operator, error)
+
+// TenantMiddleware scopes requests to a white-label domain to that domain's operator, so
+// registrations, logins and public listings stay within it. Requests to other hosts are
+// left unscoped until AuthMiddleware scopes them by their token.
+func TenantMiddleware(resolve OperatorResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			operator, err := resolve(r.Context(), models.NormalizeHost(r.Host))
+			if err != nil {
+				log.Printf("Failed to resolve operator for host %s: %v", r.Host, err)
+				http.Error(w, "Service temporarily unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			if operator == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !operator.IsActive {
+				http.Error(w, "This marketplace is not available", http.StatusNotFound)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(tenant.WithOperator(r.Context(), operator.ID)))
+		})
+	}
+}
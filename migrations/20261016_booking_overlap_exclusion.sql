@@ -0,0 +1,33 @@
+-- Reject overlapping rentals of a car in the database. The booking service checks for
+-- conflicts before inserting, but two concurrent requests can both pass that check; with the
+-- constraint the second insert fails and the API answers 409.
+--
+-- Only bookings that hold the car take part: pending, under_review, confirmed and in_progress,
+-- as in BookingService.holdsCar. Periods are half-open timestamp ranges, so a rental may
+-- start when the previous one ends, and same-day rentals are compared by the hour.
+
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Overlaps that slipped in before the constraint would make adding it fail with a bare index
+-- error, so name them first. Cancel or move the listed bookings, then rerun the migration.
+DO $$
+DECLARE
+    overlaps TEXT;
+BEGIN
+    SELECT string_agg(a.id || ' and ' || b.id || ' (car ' || a.car_id || ')', ', ')
+    INTO overlaps
+    FROM booking a
+    JOIN booking b ON b.car_id = a.car_id AND b.id > a.id
+    WHERE a.status IN ('pending', 'under_review', 'confirmed', 'in_progress')
+      AND b.status IN ('pending', 'under_review', 'confirmed', 'in_progress')
+      AND tsrange(a.start_date, a.end_date) && tsrange(b.start_date, b.end_date);
+
+    IF overlaps IS NOT NULL THEN
+        RAISE EXCEPTION 'overlapping rentals must be resolved first: %', overlaps;
+    END IF;
+END $$;
+
+ALTER TABLE booking
+ADD CONSTRAINT exclude_booking_car_period
+EXCLUDE USING gist (car_id WITH =, tsrange(start_date, end_date) WITH &&)
+WHERE (status IN ('pending', 'under_review', 'confirmed', 'in_progress'));
@@ -0,0 +1,26 @@
+-- Wishlists: cars users saved to look at again. A car is saved once per user, and favorites go
+-- with the user or the car when either is deleted.
+
+CREATE TABLE car_favorite (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    car_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_car_favorite UNIQUE (user_id, car_id)
+);
+
+ALTER TABLE car_favorite
+ADD CONSTRAINT fk_car_favorite_user_id
+FOREIGN KEY (user_id)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+ALTER TABLE car_favorite
+ADD CONSTRAINT fk_car_favorite_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+CREATE INDEX idx_car_favorite_user_created_at_id ON car_favorite(user_id, created_at DESC, id DESC);
+CREATE INDEX idx_car_favorite_car_id ON car_favorite(car_id);
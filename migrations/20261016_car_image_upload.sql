@@ -0,0 +1,27 @@
+-- Images sent with a car are kept here until the upload job has stored them in Cloudinary and
+-- added their URLs to the car, so a Cloudinary outage delays images instead of losing them.
+
+CREATE TABLE car_image_upload (
+    id UUID PRIMARY KEY,
+    car_id UUID NOT NULL,
+    position INTEGER NOT NULL,
+    image_data TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    url TEXT,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (status IN ('pending', 'uploading', 'uploaded', 'failed'))
+);
+
+ALTER TABLE car_image_upload
+ADD CONSTRAINT fk_car_image_upload_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+CREATE INDEX idx_car_image_upload_car_id ON car_image_upload(car_id, created_at, position);
+CREATE INDEX idx_car_image_upload_due ON car_image_upload(next_attempt_at) WHERE status IN ('pending', 'uploading');
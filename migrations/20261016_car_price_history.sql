@@ -0,0 +1,30 @@
+-- Car price history: every change of a car's daily price with who made it, behind
+-- GET /cars/{id}/price-history and the price drop badges of car listings.
+
+CREATE TABLE car_price_history (
+    id UUID PRIMARY KEY,
+    car_id UUID NOT NULL,
+    old_price DECIMAL(10,2),
+    new_price DECIMAL(10,2) NOT NULL,
+    changed_by UUID,
+    changed_by_role VARCHAR(20) NOT NULL DEFAULT 'system',
+    changed_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE car_price_history
+ADD CONSTRAINT fk_car_price_history_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE car_price_history
+ADD CONSTRAINT fk_car_price_history_changed_by
+FOREIGN KEY (changed_by)
+REFERENCES users(id)
+ON DELETE SET NULL;
+
+CREATE INDEX idx_car_price_history_car_changed_at ON car_price_history(car_id, changed_at DESC);
+
+-- Existing cars start their history at their current price, as of when they were listed
+INSERT INTO car_price_history (id, car_id, old_price, new_price, changed_at)
+SELECT gen_random_uuid(), id, NULL, price, COALESCE(created_at, CURRENT_TIMESTAMP) FROM car;
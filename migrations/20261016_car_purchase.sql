@@ -0,0 +1,17 @@
+-- Fleet valuation: what owners paid for their cars, depreciated by the curves of the operator's
+-- depreciation setting. A car's purchase goes with the car when it is deleted.
+
+CREATE TABLE car_purchase (
+    car_id UUID PRIMARY KEY,
+    purchase_price DECIMAL(12,2) NOT NULL,
+    purchased_on DATE,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (purchase_price > 0)
+);
+
+ALTER TABLE car_purchase
+ADD CONSTRAINT fk_car_purchase_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
@@ -0,0 +1,32 @@
+-- Checklist templates: admin-defined, versioned checklists of exterior, interior and fluid level
+-- items answered at checkout and check-in. Inspections record the version they answered.
+
+CREATE TABLE checklist_template (
+    id UUID PRIMARY KEY,
+    version INTEGER NOT NULL,
+    items JSONB NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_checklist_template_version UNIQUE (version)
+);
+
+ALTER TABLE checklist_template
+ADD CONSTRAINT fk_checklist_template_created_by
+FOREIGN KEY (created_by)
+REFERENCES users(id)
+ON DELETE SET NULL;
+
+ALTER TABLE booking_inspection
+ADD COLUMN checklist_version INTEGER,
+ADD COLUMN checklist JSONB;
+
+ALTER TABLE booking_inspection
+ADD CONSTRAINT fk_booking_inspection_checklist_version
+FOREIGN KEY (checklist_version)
+REFERENCES checklist_template(version)
+ON DELETE RESTRICT;
+
+ALTER TABLE booking_inspection
+ADD CONSTRAINT check_booking_inspection_checklist
+CHECK ((checklist_version IS NULL) = (checklist IS NULL));
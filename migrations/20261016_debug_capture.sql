@@ -0,0 +1,25 @@
+-- Debug captures: sanitized copies of sampled requests and their responses, purged after
+-- DEBUG_CAPTURE_TTL. No foreign keys: captures are throwaway and must not block deleting users.
+
+CREATE TABLE debug_capture (
+    id UUID PRIMARY KEY,
+    reason VARCHAR(20) NOT NULL,
+    user_id UUID,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    query TEXT NOT NULL DEFAULT '',
+    client_ip VARCHAR(45) NOT NULL DEFAULT '',
+    request_headers JSONB NOT NULL DEFAULT '{}',
+    request_body TEXT NOT NULL DEFAULT '',
+    status INTEGER NOT NULL,
+    response_headers JSONB NOT NULL DEFAULT '{}',
+    response_body TEXT NOT NULL DEFAULT '',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    CHECK (reason IN ('sampled', 'user', 'route', 'header'))
+);
+
+CREATE INDEX idx_debug_capture_created_at ON debug_capture(created_at DESC, id DESC);
+CREATE INDEX idx_debug_capture_user_id ON debug_capture(user_id, created_at DESC) WHERE user_id IS NOT NULL;
+CREATE INDEX idx_debug_capture_expires_at ON debug_capture(expires_at);
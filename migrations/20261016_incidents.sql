@@ -0,0 +1,96 @@
+-- Incidents: accidents, thefts and other mishaps reported against active bookings, with the
+-- insurance and police documents attached to them, tracked until resolved.
+
+CREATE TABLE incident (
+    id UUID PRIMARY KEY,
+    booking_id UUID NOT NULL,
+    car_id UUID NOT NULL,
+    customer_id UUID NOT NULL,
+    owner_id UUID NOT NULL,
+    reported_by UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    description TEXT NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    location TEXT NOT NULL DEFAULT '',
+    police_report_number VARCHAR(100) NOT NULL DEFAULT '',
+    photo_urls TEXT[] NOT NULL DEFAULT '{}',
+    insurance_claim_number VARCHAR(100) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'reported',
+    resolution_notes TEXT NOT NULL DEFAULT '',
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE incident_document (
+    id UUID PRIMARY KEY,
+    incident_id UUID NOT NULL,
+    added_by UUID NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL,
+    document_url TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE incident
+ADD CONSTRAINT fk_incident_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;
+
+ALTER TABLE incident
+ADD CONSTRAINT fk_incident_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE incident
+ADD CONSTRAINT fk_incident_customer_id
+FOREIGN KEY (customer_id)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+ALTER TABLE incident
+ADD CONSTRAINT fk_incident_owner_id
+FOREIGN KEY (owner_id)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+ALTER TABLE incident
+ADD CONSTRAINT fk_incident_reported_by
+FOREIGN KEY (reported_by)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+ALTER TABLE incident_document
+ADD CONSTRAINT fk_incident_document_incident_id
+FOREIGN KEY (incident_id)
+REFERENCES incident(id)
+ON DELETE CASCADE;
+
+ALTER TABLE incident_document
+ADD CONSTRAINT fk_incident_document_added_by
+FOREIGN KEY (added_by)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+ALTER TABLE incident
+ADD CONSTRAINT check_incident_kind
+CHECK (kind IN ('accident', 'damage', 'theft', 'breakdown', 'other'));
+
+ALTER TABLE incident
+ADD CONSTRAINT check_incident_status
+CHECK (status IN ('reported', 'under_review', 'claim_filed', 'resolved', 'closed'));
+
+ALTER TABLE incident_document
+ADD CONSTRAINT check_incident_document_kind
+CHECK (kind IN ('insurance_policy', 'insurance_claim', 'police_report', 'repair_estimate', 'other'));
+
+CREATE INDEX idx_incident_booking_id ON incident(booking_id, created_at);
+CREATE INDEX idx_incident_status_created_at ON incident(status, created_at);
+CREATE INDEX idx_incident_document_incident_id ON incident_document(incident_id, created_at);
+
+CREATE TRIGGER update_incident_updated_at
+    BEFORE UPDATE ON incident
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,71 @@
+-- One account per e-mail address and phone number, one payment per Razorpay order, and rental
+-- and financial history that deleting a renter, car or booking can no longer take with it.
+--
+-- Phone numbers are encrypted, so the unique constraint is on their blind index, phone_hash.
+-- If BLIND_INDEX_KEY was changed, run the reindex_search maintenance task first.
+--
+-- Bookings previously went with their customer or car, and payments with their booking. Now
+-- such deletes fail: retire cars and cancel bookings instead. Bookings keep going with their
+-- owner's account (owner_id is set to NULL) and extensions with their trip.
+
+-- Duplicates would make adding the constraints fail with a bare index error, so name them first.
+-- Merge or correct the listed rows, then rerun the migration.
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    SELECT string_agg(email || ' (' || n || ' users)', ', ')
+    INTO duplicates
+    FROM (SELECT email, COUNT(*) AS n FROM users GROUP BY email HAVING COUNT(*) > 1) d;
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'e-mail addresses registered more than once must be resolved first: %', duplicates;
+    END IF;
+
+    SELECT string_agg(ids, '; ')
+    INTO duplicates
+    FROM (SELECT string_agg(id::text, ', ') AS ids FROM users
+          WHERE phone_hash IS NOT NULL GROUP BY phone_hash HAVING COUNT(*) > 1) d;
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'users sharing a phone number must be resolved first: %', duplicates;
+    END IF;
+
+    SELECT string_agg(razorpay_order_id || ' (' || n || ' payments)', ', ')
+    INTO duplicates
+    FROM (SELECT razorpay_order_id, COUNT(*) AS n FROM payment
+          WHERE razorpay_order_id IS NOT NULL GROUP BY razorpay_order_id HAVING COUNT(*) > 1) d;
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'Razorpay orders recorded on more than one payment must be resolved first: %', duplicates;
+    END IF;
+END $$;
+
+-- The unique constraints replace the plain indexes on the same columns
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users ADD CONSTRAINT unique_users_email UNIQUE (email);
+DROP INDEX IF EXISTS idx_users_email;
+
+ALTER TABLE users ADD CONSTRAINT unique_users_phone_hash UNIQUE (phone_hash);
+DROP INDEX IF EXISTS idx_users_phone_hash;
+
+ALTER TABLE payment ADD CONSTRAINT unique_payment_razorpay_order_id UNIQUE (razorpay_order_id);
+DROP INDEX IF EXISTS idx_payment_razorpay_order_id;
+
+ALTER TABLE booking DROP CONSTRAINT fk_booking_customer_id;
+ALTER TABLE booking
+ADD CONSTRAINT fk_booking_customer_id
+FOREIGN KEY (customer_id)
+REFERENCES users(id)
+ON DELETE RESTRICT;
+
+ALTER TABLE booking DROP CONSTRAINT fk_booking_car_id;
+ALTER TABLE booking
+ADD CONSTRAINT fk_booking_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE RESTRICT;
+
+ALTER TABLE payment DROP CONSTRAINT fk_payment_booking_id;
+ALTER TABLE payment
+ADD CONSTRAINT fk_payment_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE RESTRICT;
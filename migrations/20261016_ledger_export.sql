@@ -0,0 +1,3 @@
+-- Accounting export: documents issued in a period are read for the Tally and QuickBooks files
+
+CREATE INDEX idx_financial_document_issued_at ON financial_document(issued_at);
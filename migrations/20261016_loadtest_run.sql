@@ -0,0 +1,14 @@
+-- Load test runs: the users and cars created by POST /admin/loadtest/fixtures, deleted together
+-- when the run is deleted or expires. Their bookings go with them through the cascades of
+-- booking. No foreign keys: the IDs are what is left to delete, not references to keep valid.
+
+CREATE TABLE loadtest_run (
+    id UUID PRIMARY KEY,
+    created_by UUID NOT NULL,
+    user_ids UUID[] NOT NULL DEFAULT '{}',
+    car_ids UUID[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_loadtest_run_expires_at ON loadtest_run(expires_at);
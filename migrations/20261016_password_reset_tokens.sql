@@ -0,0 +1,19 @@
+-- Password reset: single-use links e-mailed by POST /auth/forgot-password. Only hashes of the
+-- tokens are stored, and a user's links go with the account.
+
+CREATE TABLE password_reset_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE password_reset_tokens
+ADD CONSTRAINT fk_password_reset_tokens_user_id
+FOREIGN KEY (user_id)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+CREATE INDEX idx_password_reset_tokens_user_created_at ON password_reset_tokens(user_id, created_at);
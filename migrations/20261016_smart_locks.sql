@@ -0,0 +1,85 @@
+-- Smart locks: the lock fitted in each car, time-boxed digital keys issued to it for confirmed
+-- bookings, and the lock and unlock events its provider reports.
+
+CREATE TABLE car_smart_lock (
+    car_id UUID PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    lock_ref VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_car_smart_lock_provider_lock_ref UNIQUE (provider, lock_ref)
+);
+
+CREATE TABLE digital_key (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id UUID NOT NULL,
+    car_id UUID NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    lock_ref VARCHAR(100) NOT NULL,
+    provider_key_id VARCHAR(255),
+    valid_from TIMESTAMP NOT NULL,
+    valid_until TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE TABLE lock_event (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    car_id UUID NOT NULL,
+    booking_id UUID,
+    digital_key_id UUID,
+    action VARCHAR(10) NOT NULL,
+    provider_event_id VARCHAR(255) NOT NULL UNIQUE,
+    occurred_at TIMESTAMP NOT NULL,
+    received_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE car_smart_lock
+ADD CONSTRAINT fk_car_smart_lock_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE digital_key
+ADD CONSTRAINT fk_digital_key_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;
+
+ALTER TABLE digital_key
+ADD CONSTRAINT fk_digital_key_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE lock_event
+ADD CONSTRAINT fk_lock_event_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE lock_event
+ADD CONSTRAINT fk_lock_event_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE SET NULL;
+
+ALTER TABLE lock_event
+ADD CONSTRAINT fk_lock_event_digital_key_id
+FOREIGN KEY (digital_key_id)
+REFERENCES digital_key(id)
+ON DELETE SET NULL;
+
+ALTER TABLE digital_key
+ADD CONSTRAINT check_digital_key_status
+CHECK (status IN ('issued', 'revoked', 'failed'));
+
+ALTER TABLE lock_event
+ADD CONSTRAINT check_lock_event_action
+CHECK (action IN ('lock', 'unlock'));
+
+CREATE INDEX idx_digital_key_booking_id ON digital_key(booking_id);
+CREATE INDEX idx_digital_key_car_valid_from ON digital_key(car_id, valid_from);
+CREATE INDEX idx_lock_event_booking_occurred_at ON lock_event(booking_id, occurred_at);
@@ -0,0 +1,8 @@
+-- Two-factor authentication: a TOTP secret per user, encrypted by the application, enforced at
+-- login once a code from the authenticator app confirmed it, plus hashed single-use backup codes.
+
+ALTER TABLE users
+ADD COLUMN totp_secret TEXT,
+ADD COLUMN totp_enabled_at TIMESTAMP,
+ADD COLUMN totp_last_step BIGINT,
+ADD COLUMN totp_backup_codes TEXT[];
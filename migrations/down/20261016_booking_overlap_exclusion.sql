@@ -0,0 +1,4 @@
+-- Reverts 20261016_booking_overlap_exclusion.sql. Concurrent bookings of one period can both be
+-- created again. btree_gist stays installed, as other indexes may use it.
+
+ALTER TABLE booking DROP CONSTRAINT IF EXISTS exclude_booking_car_period;
@@ -0,0 +1,3 @@
+-- Reverts 20261016_car_favorite.sql. Users' wishlists are deleted with the table.
+
+DROP TABLE IF EXISTS car_favorite;
@@ -0,0 +1,4 @@
+-- Reverts 20261016_car_image_upload.sql. Images the upload job has not stored yet are lost, so
+-- wait until no upload is pending or uploading first.
+
+DROP TABLE IF EXISTS car_image_upload;
@@ -0,0 +1,4 @@
+-- Reverts 20261016_car_price_history.sql. Cars keep their current price; the history of their
+-- price changes is deleted.
+
+DROP TABLE IF EXISTS car_price_history;
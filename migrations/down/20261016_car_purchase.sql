@@ -0,0 +1,4 @@
+-- Reverts 20261016_car_purchase.sql. The purchase prices owners entered are deleted, so export
+-- the fleet valuation first if it is still needed.
+
+DROP TABLE IF EXISTS car_purchase;
@@ -0,0 +1,8 @@
+-- Reverts 20261016_checklist_templates.sql. Inspections lose the checklists answered at checkout
+-- and check-in; their other fields are kept. Dropping the columns drops their constraints.
+
+ALTER TABLE booking_inspection
+DROP COLUMN IF EXISTS checklist_version,
+DROP COLUMN IF EXISTS checklist;
+
+DROP TABLE IF EXISTS checklist_template;
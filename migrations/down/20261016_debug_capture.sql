@@ -0,0 +1,3 @@
+-- Reverts 20261016_debug_capture.sql. The captures are throwaway, so nothing is kept.
+
+DROP TABLE IF EXISTS debug_capture;
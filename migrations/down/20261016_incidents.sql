@@ -0,0 +1,5 @@
+-- Reverts 20261016_incidents.sql. Incidents and their documents are deleted; the documents
+-- themselves stay wherever their URLs point.
+
+DROP TABLE IF EXISTS incident_document;
+DROP TABLE IF EXISTS incident;
@@ -0,0 +1,35 @@
+-- Reverts 20261016_integrity_constraints.sql. E-mail addresses stay unique, as they were before,
+-- but phone numbers and Razorpay orders no longer are, and deleting a renter or car deletes
+-- their bookings again, and deleting a booking its payments.
+
+ALTER TABLE payment DROP CONSTRAINT fk_payment_booking_id;
+ALTER TABLE payment
+ADD CONSTRAINT fk_payment_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;
+
+ALTER TABLE booking DROP CONSTRAINT fk_booking_car_id;
+ALTER TABLE booking
+ADD CONSTRAINT fk_booking_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE booking DROP CONSTRAINT fk_booking_customer_id;
+ALTER TABLE booking
+ADD CONSTRAINT fk_booking_customer_id
+FOREIGN KEY (customer_id)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+-- The plain indexes replace the unique constraints on the same columns
+CREATE INDEX IF NOT EXISTS idx_payment_razorpay_order_id ON payment(razorpay_order_id);
+ALTER TABLE payment DROP CONSTRAINT IF EXISTS unique_payment_razorpay_order_id;
+
+CREATE INDEX IF NOT EXISTS idx_users_phone_hash ON users(phone_hash);
+ALTER TABLE users DROP CONSTRAINT IF EXISTS unique_users_phone_hash;
+
+CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+ALTER TABLE users DROP CONSTRAINT IF EXISTS unique_users_email;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
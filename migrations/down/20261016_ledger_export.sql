@@ -0,0 +1,4 @@
+-- Reverts 20261016_ledger_export.sql. Exports still work, reading every document of a period
+-- without the index.
+
+DROP INDEX IF EXISTS idx_financial_document_issued_at;
@@ -0,0 +1,4 @@
+-- Reverts 20261016_loadtest_run.sql. Delete the runs through DELETE /admin/loadtest/fixtures/{id}
+-- first, or their users and cars stay behind without a record of them.
+
+DROP TABLE IF EXISTS loadtest_run;
@@ -0,0 +1,3 @@
+-- Reverts 20261016_password_reset_tokens.sql. Reset links already e-mailed stop working.
+
+DROP TABLE IF EXISTS password_reset_tokens;
@@ -0,0 +1,4 @@
+-- Reverts 20261016_refresh_tokens.sql. Every session ends once its access token expires, and
+-- users sign in again.
+
+DROP TABLE IF EXISTS refresh_tokens;
@@ -0,0 +1,7 @@
+-- Reverts 20261016_smart_locks.sql. Lock events, digital keys and the locks fitted in cars are
+-- deleted; revoke issued keys with their provider first, or they keep opening the cars until
+-- they expire.
+
+DROP TABLE IF EXISTS lock_event;
+DROP TABLE IF EXISTS digital_key;
+DROP TABLE IF EXISTS car_smart_lock;
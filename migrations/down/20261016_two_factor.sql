@@ -0,0 +1,8 @@
+-- Reverts 20261016_two_factor.sql. Users who turned on two-factor authentication sign in with
+-- their password alone again, and their secrets and backup codes are deleted.
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS totp_secret,
+DROP COLUMN IF EXISTS totp_enabled_at,
+DROP COLUMN IF EXISTS totp_last_step,
+DROP COLUMN IF EXISTS totp_backup_codes;
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountingFormat is the file format ledger entries are exported in for accounting software
+type AccountingFormat string
+
+const (
+	AccountingFormatTally AccountingFormat = "tally" // Tally XML vouchers, imported from Tally's Import > Transactions
+	AccountingFormatIIF   AccountingFormat = "iif"   // QuickBooks Desktop IIF general journal transactions
+	AccountingFormatCSV   AccountingFormat = "csv"   // QuickBooks Online journal entry import
+)
+
+// ContentType returns the MIME type of an export file
+func (f AccountingFormat) ContentType() string {
+	switch f {
+	case AccountingFormatTally:
+		return "application/xml; charset=utf-8"
+	case AccountingFormatIIF:
+		return "text/plain; charset=utf-8"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// extension returns the file name extension of an export file
+func (f AccountingFormat) extension() string {
+	if f == AccountingFormatTally {
+		return "xml"
+	}
+	return string(f)
+}
+
+// MaxAccountingExportDays is the longest period one export covers, a fiscal year
+const MaxAccountingExportDays = 366
+
+// Ledgers the entries are posted to. Amounts owed by customers are posted to
+// AccountsReceivableLedger against the customer: Tally posts them to a party ledger named after
+// the customer's username, QuickBooks to the receivables account with the username as name.
+const (
+	RentalIncomeLedger       = "Car Rental Income"
+	PaymentGatewayLedger     = "Razorpay"
+	AccountsReceivableLedger = "Accounts Receivable"
+)
+
+// AccountingEntryKind is the kind of transaction a ledger entry records
+type AccountingEntryKind string
+
+const (
+	AccountingEntryInvoice    AccountingEntryKind = "invoice"     // Booking billed to the customer
+	AccountingEntryReceipt    AccountingEntryKind = "receipt"     // Payment received through Razorpay
+	AccountingEntryCreditNote AccountingEntryKind = "credit_note" // Bill reduced by an adjustment
+	AccountingEntryRefund     AccountingEntryKind = "refund"      // Payment returned through Razorpay
+)
+
+// DebitLedger returns the ledger an entry of the kind debits
+func (k AccountingEntryKind) DebitLedger() string {
+	switch k {
+	case AccountingEntryReceipt:
+		return PaymentGatewayLedger
+	case AccountingEntryCreditNote:
+		return RentalIncomeLedger
+	default:
+		return AccountsReceivableLedger
+	}
+}
+
+// CreditLedger returns the ledger an entry of the kind credits
+func (k AccountingEntryKind) CreditLedger() string {
+	switch k {
+	case AccountingEntryInvoice:
+		return RentalIncomeLedger
+	case AccountingEntryRefund:
+		return PaymentGatewayLedger
+	default:
+		return AccountsReceivableLedger
+	}
+}
+
+// AccountingEntry is one invoice, receipt, credit note or refund posted to the ledgers. Each
+// entry debits and credits the same amount.
+type AccountingEntry struct {
+	Kind      AccountingEntryKind `json:"kind"`
+	Number    string              `json:"number"` // Document number; refunds are numbered RF- and the refunded receipt's number
+	Date      time.Time           `json:"date"`
+	BookingID uuid.UUID           `json:"booking_id"`
+	PaymentID *uuid.UUID          `json:"payment_id,omitempty"` // Set on receipts and refunds
+	Customer  string              `json:"customer"`             // Username of the booking's customer
+	Amount    float64             `json:"amount"`               // INR, always positive
+}
+
+// BookDate returns the day an entry is booked on, in India time
+func (e AccountingEntry) BookDate() time.Time {
+	return e.Date.In(indiaStandardTime)
+}
+
+// AccountingExportFile is a rendered export
+type AccountingExportFile struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"-"`
+}
+
+// AccountingExportRequest is a validated request for an export. From is the first day covered
+// and To the day after the last one, both at midnight India time.
+type AccountingExportRequest struct {
+	From   time.Time
+	To     time.Time
+	Format AccountingFormat
+}
+
+// FileName returns the download name of the export file, e.g.
+// carzone-ledger-2024-04-01-to-2024-06-30.xml
+func (r AccountingExportRequest) FileName() string {
+	return fmt.Sprintf("carzone-ledger-%s-to-%s.%s", r.From.Format("2006-01-02"),
+		r.To.AddDate(0, 0, -1).Format("2006-01-02"), r.Format.extension())
+}
+
+// ParseAccountingExportRequest validates the from, to and format query parameters of an
+// export. Dates are YYYY-MM-DD in India time and both are included; format is required, as
+// each accounting package reads only its own.
+func ParseAccountingExportRequest(fromParam, toParam, formatParam string) (AccountingExportRequest, error) {
+	var req AccountingExportRequest
+
+	req.Format = AccountingFormat(formatParam)
+	switch req.Format {
+	case AccountingFormatTally, AccountingFormatIIF, AccountingFormatCSV:
+	default:
+		return req, errors.New("format must be one of: tally, iif, csv")
+	}
+
+	from, to, err := parsePeriod(fromParam, toParam, MaxAccountingExportDays)
+	if err != nil {
+		return req, err
+	}
+
+	req.From = from
+	req.To = to
+	return req, nil
+}
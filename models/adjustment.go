@@ -0,0 +1,99 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdjustmentKind is the direction of a manual correction to a booking's bill
+type AdjustmentKind string
+
+const (
+	AdjustmentKindCreditNote AdjustmentKind = "credit_note" // Reduces what the customer owes; a CN document is issued
+	AdjustmentKindCharge     AdjustmentKind = "charge"      // Adds to what the customer owes; a payment is requested
+)
+
+// AdjustmentReason is the reason code an admin gives for an adjustment
+type AdjustmentReason string
+
+const (
+	AdjustmentReasonBillingError AdjustmentReason = "billing_error" // The booking was priced or charged wrongly
+	AdjustmentReasonServiceIssue AdjustmentReason = "service_issue" // The car or handover fell short
+	AdjustmentReasonGoodwill     AdjustmentReason = "goodwill"      // Discretionary credit
+	AdjustmentReasonDamage       AdjustmentReason = "damage"        // Damage found after the trip
+	AdjustmentReasonCleaning     AdjustmentReason = "cleaning"      // Car returned needing cleaning
+	AdjustmentReasonTraffic      AdjustmentReason = "traffic_fine"  // Fines incurred during the trip
+	AdjustmentReasonOther        AdjustmentReason = "other"
+)
+
+// AdjustmentStatus is where an adjustment stands
+type AdjustmentStatus string
+
+const (
+	AdjustmentStatusPendingApproval AdjustmentStatus = "pending_approval" // Above the threshold, waiting for a second admin
+	AdjustmentStatusApplied         AdjustmentStatus = "applied"          // Credit note issued or payment requested
+	AdjustmentStatusRejected        AdjustmentStatus = "rejected"         // Turned down by the second admin
+)
+
+// BookingAdjustment is a credit note or manual charge an admin raised against a booking
+type BookingAdjustment struct {
+	ID             uuid.UUID        `json:"id"`
+	BookingID      uuid.UUID        `json:"booking_id"`
+	Kind           AdjustmentKind   `json:"kind"`
+	Amount         float64          `json:"amount"` // INR, always positive
+	ReasonCode     AdjustmentReason `json:"reason_code"`
+	Notes          string           `json:"notes,omitempty"`
+	Status         AdjustmentStatus `json:"status"`
+	RequestedBy    uuid.UUID        `json:"requested_by"`
+	ReviewedBy     *uuid.UUID       `json:"reviewed_by,omitempty"` // Second admin, for adjustments above the threshold
+	ReviewNote     string           `json:"review_note,omitempty"`
+	PaymentID      *uuid.UUID       `json:"payment_id,omitempty"`      // Payment requested for a charge
+	DocumentNumber *string          `json:"document_number,omitempty"` // Number of the credit note
+	CreatedAt      time.Time        `json:"created_at"`
+	AppliedAt      *time.Time       `json:"applied_at,omitempty"`
+}
+
+// BookingAdjustmentRequest is the payload an admin sends to raise an adjustment
+type BookingAdjustmentRequest struct {
+	Kind       AdjustmentKind   `json:"kind"`
+	Amount     float64          `json:"amount"`
+	ReasonCode AdjustmentReason `json:"reason_code"`
+	Notes      string           `json:"notes,omitempty"`
+}
+
+// AdjustmentReviewRequest is the payload a second admin sends to approve or reject an adjustment
+type AdjustmentReviewRequest struct {
+	Approve bool   `json:"approve"`
+	Note    string `json:"note,omitempty"`
+}
+
+// AdjustmentFilter narrows the admin adjustment list
+type AdjustmentFilter struct {
+	Status AdjustmentStatus // Empty for every status
+}
+
+// ValidateBookingAdjustmentRequest validates a BookingAdjustmentRequest. Returns nil when valid, otherwise an error.
+func ValidateBookingAdjustmentRequest(req BookingAdjustmentRequest) error {
+	if req.Kind != AdjustmentKindCreditNote && req.Kind != AdjustmentKindCharge {
+		return errors.New("kind must be credit_note or charge")
+	}
+	if req.Amount <= 0 {
+		return errors.New("amount must be greater than 0")
+	}
+	switch req.ReasonCode {
+	case AdjustmentReasonBillingError, AdjustmentReasonServiceIssue, AdjustmentReasonGoodwill, AdjustmentReasonDamage,
+		AdjustmentReasonCleaning, AdjustmentReasonTraffic, AdjustmentReasonOther:
+	default:
+		return errors.New("reason_code must be one of: billing_error, service_issue, goodwill, damage, cleaning, traffic_fine, other")
+	}
+	if req.ReasonCode == AdjustmentReasonOther && strings.TrimSpace(req.Notes) == "" {
+		return errors.New("notes are required when reason_code is other")
+	}
+	if len(req.Notes) > 1000 {
+		return errors.New("notes must be at most 1000 characters")
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserBlock records that one user blocked another. A block works both ways: the blocked
+// renter cannot book the blocker's cars, and the blocked owner's cars cannot be booked by
+// the blocker.
+type UserBlock struct {
+	BlockerID   uuid.UUID `json:"blocker_id"`
+	BlockedID   uuid.UUID `json:"blocked_id"`
+	BlockedName string    `json:"blocked_name"` // Username of the blocked user, for the block list
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// BlockRequest is the payload a user sends to block another user
+type BlockRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
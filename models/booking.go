@@ -1,8 +1,13 @@
 package models
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
+	"github.com/PrateekKumar15/CarZone/statemachine"
 	"github.com/google/uuid"
 )
 
@@ -10,12 +15,26 @@ import (
 type BookingStatus string
 
 const (
-	BookingStatusPending   BookingStatus = "pending"
-	BookingStatusConfirmed BookingStatus = "confirmed"
-	BookingStatusCompleted BookingStatus = "completed"
-	BookingStatusCancelled BookingStatus = "cancelled"
+	BookingStatusPending     BookingStatus = "pending"
+	BookingStatusConfirmed   BookingStatus = "confirmed"
+	BookingStatusCompleted   BookingStatus = "completed"
+	BookingStatusCancelled   BookingStatus = "cancelled"
+	BookingStatusUnderReview BookingStatus = "under_review" // Held by risk scoring until an admin confirms or cancels it
+	BookingStatusInProgress  BookingStatus = "in_progress"  // Car handed to the customer at checkout, until check-in
 )
 
+// BookingStatusMachine declares the lifecycle of a booking. Bookings only enter under_review
+// through risk scoring, never through a client request; an admin then confirms or cancels them.
+// Checkout moves a confirmed booking to in_progress and check-in completes it; a trip under way
+// can no longer be cancelled.
+var BookingStatusMachine = statemachine.New[BookingStatus, Booking]("booking").
+	State(BookingStatusPending, BookingStatusConfirmed, BookingStatusCancelled).
+	State(BookingStatusConfirmed, BookingStatusInProgress, BookingStatusCompleted, BookingStatusCancelled).
+	State(BookingStatusUnderReview, BookingStatusConfirmed, BookingStatusCancelled).
+	State(BookingStatusInProgress, BookingStatusCompleted).
+	State(BookingStatusCompleted).
+	State(BookingStatusCancelled)
+
 // Booking represents a car rental booking in the system
 type Booking struct {
 	ID          uuid.UUID     `json:"id"`
@@ -29,6 +48,81 @@ type Booking struct {
 	Notes       string        `json:"notes"`
 	CreatedAt   time.Time     `json:"created_at"`
 	UpdatedAt   time.Time     `json:"updated_at"`
+
+	PickupLocationID  *uuid.UUID       `json:"pickup_location_id,omitempty"`
+	DropoffLocationID *uuid.UUID       `json:"dropoff_location_id,omitempty"`
+	Delivery          *BookingDelivery `json:"delivery,omitempty"` // Set when the car is delivered to the renter
+	AddOns            []BookingAddOn   `json:"add_ons,omitempty"`  // Fleet add-ons chosen at booking time
+	PriceBreakdown    PriceBreakdown   `json:"price_breakdown"`
+	OverdueAt         *time.Time       `json:"overdue_at,omitempty"`        // When the trip was found still in progress after its end date
+	Car               *CarSnapshot     `json:"car,omitempty"`               // The car as it was listed when booked
+	Customer          *UserSnapshot    `json:"customer,omitempty"`          // The customer as they were when booking
+	Owner             *UserSnapshot    `json:"owner,omitempty"`             // The car's owner when it was booked
+	ParentBookingID   *uuid.UUID       `json:"parent_booking_id,omitempty"` // Set on extensions: the trip they extend
+}
+
+// UserSnapshot is a user's display name and email recorded on a booking when it was made, so
+// lists stay readable and unchanged if the user is later edited or anonymized
+type UserSnapshot struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// ParseUserSnapshot decodes a stored user snapshot, which is null when none was recorded
+func ParseUserSnapshot(data []byte) (*UserSnapshot, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var snapshot *UserSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// CarSnapshot records the booked car's listing when the booking was made, so booking history
+// keeps showing what was booked after the car is edited
+type CarSnapshot struct {
+	Name   string   `json:"name"`
+	Brand  string   `json:"brand"`
+	Model  string   `json:"model"`
+	Year   int      `json:"year"`
+	Price  float64  `json:"price"` // Daily rental price at booking time
+	Images []string `json:"images"`
+	Slug   string   `json:"slug"`
+}
+
+// PriceBreakdown itemises a booking's total amount
+type PriceBreakdown struct {
+	RentalAmount   float64 `json:"rental_amount"`    // Daily rate times rental days, after fleet pricing rules
+	PickupFee      float64 `json:"pickup_fee"`       // Fee of the pickup location, if any
+	DropoffFee     float64 `json:"dropoff_fee"`      // Fee of the drop-off location, if any
+	DeliveryFee    float64 `json:"delivery_fee"`     // Distance-based fee for delivery to the renter, if any
+	RelocationFee  float64 `json:"relocation_fee"`   // One-way fee when the car is returned in another city
+	AddOnsFee      float64 `json:"add_ons_fee"`      // Sum of the chosen fleet add-ons
+	PrepaidFuelFee float64 `json:"prepaid_fuel_fee"` // A full tank, for cars with the prepaid fuel policy
+	LateFee        float64 `json:"late_fee"`         // Charged at check-in for a return after the grace period
+	RefuelFee      float64 `json:"refuel_fee"`       // Charged at check-in for fuel missing from a full-to-full car
+	Total          float64 `json:"total"`
+}
+
+// BookingQuote is the itemised price of a prospective booking
+type BookingQuote struct {
+	CarID     uuid.UUID        `json:"car_id"`
+	StartDate time.Time        `json:"start_date"`
+	EndDate   time.Time        `json:"end_date"`
+	Delivery  *BookingDelivery `json:"delivery,omitempty"`
+	AddOns    []BookingAddOn   `json:"add_ons,omitempty"`
+	Price     PriceBreakdown   `json:"price_breakdown"`
+
+	// Where the rental starts and ends; they differ for one-way rentals
+	PickupCity string `json:"pickup_city"`
+	ReturnCity string `json:"return_city"`
+	OneWay     bool   `json:"one_way"`
+
+	// Signed price lock; sending it with the booking guarantees this price until it expires
+	Token     string    `json:"quote_token"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // BookingRequest represents the payload to create a rental booking
@@ -39,4 +133,111 @@ type BookingRequest struct {
 	StartDate  time.Time `json:"start_date"`
 	EndDate    time.Time `json:"end_date"`
 	Notes      string    `json:"notes"`
+
+	// Optional pickup/drop-off points; they must be attached to the car
+	PickupLocationID  *uuid.UUID `json:"pickup_location_id,omitempty"`
+	DropoffLocationID *uuid.UUID `json:"dropoff_location_id,omitempty"`
+
+	// Optional address the owner delivers the car to instead of a pickup location
+	DeliveryAddress string `json:"delivery_address,omitempty"`
+
+	// Codes of add-ons offered by the car's fleet
+	AddOns []string `json:"add_ons,omitempty"`
+
+	// Optional quote_token from POST /bookings/quote; the booking is charged the quoted price
+	// if the token is valid, unexpired and was issued for the same terms
+	QuoteToken string `json:"quote_token,omitempty"`
+
+	// Set by the service when the booking extends a trip; clients cannot send it
+	ParentBookingID *uuid.UUID `json:"-"`
+}
+
+// BookingFilter narrows a booking list to a customer, car, owner or status.
+// Empty fields are ignored.
+type BookingFilter struct {
+	CustomerID string
+	CarID      string
+	OwnerID    string
+	Status     BookingStatus
+}
+
+// LateFeePolicy prices returns after a booking's end date. Returns within the grace period are
+// free; later ones pay for the whole delay at the car's rate times the multiplier: full days at the
+// daily rate and the remaining started hours at the hourly rate (a 24th of it), capped at a day.
+type LateFeePolicy struct {
+	GracePeriod time.Duration
+	Multiplier  float64 // Surcharge on the car's rate, e.g. 1.5 for 50% over the regular price
+}
+
+// LateFee returns the fee for a car with the given daily rate, due at end and returned at returned
+func (p LateFeePolicy) LateFee(dailyRate float64, end, returned time.Time) float64 {
+	late := returned.Sub(end)
+	if late <= p.GracePeriod {
+		return 0
+	}
+
+	rate := dailyRate * p.Multiplier
+	days := int(late / (24 * time.Hour))
+	hours := math.Ceil((late - time.Duration(days)*24*time.Hour).Hours())
+	fee := float64(days)*rate + math.Min(hours*rate/24, rate)
+
+	return math.Round(fee*100) / 100
+}
+
+// maxExtensionDays bounds how far a single extension may move a trip's end
+const maxExtensionDays = 30
+
+// ExtensionRequest is the payload a renter sends to extend a trip
+type ExtensionRequest struct {
+	EndDate time.Time `json:"end_date"`
+}
+
+// ValidateExtension checks an extension of a trip ending at tripEnd. Returns nil when valid,
+// otherwise an error.
+func ValidateExtension(tripEnd, end time.Time) error {
+	if end.IsZero() {
+		return errors.New("end_date is required")
+	}
+	if end.Sub(tripEnd) < 24*time.Hour {
+		return errors.New("end_date must be at least a day after the trip's current end")
+	}
+	if end.Sub(tripEnd) > maxExtensionDays*24*time.Hour {
+		return fmt.Errorf("a trip can be extended by at most %d days at a time", maxExtensionDays)
+	}
+	return nil
+}
+
+// ExtensionQuote prices extending a trip from its current end to a new end date and reports
+// whether the car is free for those days
+type ExtensionQuote struct {
+	BookingID uuid.UUID         `json:"booking_id"`
+	StartDate time.Time         `json:"start_date"` // The trip's current end
+	EndDate   time.Time         `json:"end_date"`
+	Price     PriceBreakdown    `json:"price"`
+	Available bool              `json:"available"`
+	Conflicts []BookingConflict `json:"conflicts"`
+}
+
+// BookingExtension is an accepted extension: the sub-booking for the extra days and the
+// Razorpay order for their price
+type BookingExtension struct {
+	Booking      Booking                `json:"booking"`
+	PaymentOrder *RazorpayOrderResponse `json:"payment_order"`
+}
+
+// TripEnd returns when a trip is due back: its own end date, or the end of its latest
+// confirmed extension
+func (b Booking) TripEnd(extensions []Booking) time.Time {
+	end := b.EndDate
+	for _, extension := range extensions {
+		if extension.Status == BookingStatusConfirmed && extension.EndDate.After(end) {
+			end = extension.EndDate
+		}
+	}
+	return end
+}
+
+// PageCursor returns the pagination cursor pointing at this booking
+func (b Booking) PageCursor() Cursor {
+	return Cursor{CreatedAt: b.CreatedAt, ID: b.ID}
 }
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookingConflictReason names the rule that keeps a car from being booked for a period
+type BookingConflictReason string
+
+const (
+	BookingConflictCarUnavailable  BookingConflictReason = "car_unavailable"  // The car is not open for bookings at all
+	BookingConflictLeadTime        BookingConflictReason = "lead_time"        // The period starts sooner than bookings are accepted
+	BookingConflictBlackout        BookingConflictReason = "blackout"         // The owner or the car's fleet blocked part of the period
+	BookingConflictExistingBooking BookingConflictReason = "existing_booking" // Another rental holds part of the period
+)
+
+// BookingConflict is one reason a period cannot be booked. The dates are those of the blocking
+// blackout or rental when the reason has them.
+type BookingConflict struct {
+	Reason    BookingConflictReason `json:"reason"`
+	Message   string                `json:"message"`
+	StartDate *time.Time            `json:"start_date,omitempty"`
+	EndDate   *time.Time            `json:"end_date,omitempty"`
+}
+
+// BookingAvailability tells whether a car can be booked for a period and, if not, every reason
+// why, so clients can explain it before submitting a booking
+type BookingAvailability struct {
+	CarID     uuid.UUID         `json:"car_id"`
+	StartDate time.Time         `json:"start_date"`
+	EndDate   time.Time         `json:"end_date"`
+	Bookable  bool              `json:"bookable"`
+	Conflicts []BookingConflict `json:"conflicts"`
+}
+
+// CarBlackout is a period in which an owner vacation blocks a car
+type CarBlackout struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// NewPeriodConflict returns a conflict blocking the given period
+func NewPeriodConflict(reason BookingConflictReason, message string, start, end time.Time) BookingConflict {
+	return BookingConflict{Reason: reason, Message: message, StartDate: &start, EndDate: &end}
+}
+
+// ParseBookingPeriod parses the start and end query parameters of a conflict check. Both are
+// required and may be RFC 3339 timestamps or YYYY-MM-DD dates.
+func ParseBookingPeriod(startParam, endParam string) (time.Time, time.Time, error) {
+	if startParam == "" || endParam == "" {
+		return time.Time{}, time.Time{}, errors.New("start and end are required")
+	}
+	start, err := parseTimeSeriesDate(startParam)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("start %v", err)
+	}
+	end, err := parseTimeSeriesDate(endParam)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("end %v", err)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Bot check providers, set with BOT_CHECK_PROVIDER
+const (
+	BotCheckNone        = "none"      // Not configured; every request passes
+	BotCheckHCaptcha    = "hcaptcha"  // hCaptcha widget, verified with hCaptcha's siteverify API
+	BotCheckTurnstile   = "turnstile" // Cloudflare Turnstile widget, verified with Cloudflare's siteverify API
+	BotCheckProofOfWork = "pow"       // A hash puzzle the client solves, verified locally
+)
+
+// BotChallenge tells clients how to prove they are not a bot before registering or asking for a
+// password reset: which widget to render, or which puzzle to solve
+type BotChallenge struct {
+	Provider   string     `json:"provider"`
+	SiteKey    string     `json:"site_key,omitempty"`   // Public key the CAPTCHA widget is rendered with
+	Challenge  string     `json:"challenge,omitempty"`  // Proof of work: the string to find a nonce for
+	Difficulty int        `json:"difficulty,omitempty"` // Proof of work: leading zero bits SHA-256(challenge + ":" + nonce) needs
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"` // Proof of work: when the challenge stops being accepted
+}
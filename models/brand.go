@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CarBrand is a manufacturer of the reference data cars' brands are checked against
+type CarBrand struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CarModel is a model of a reference brand
+type CarModel struct {
+	ID        uuid.UUID `json:"id"`
+	BrandID   uuid.UUID `json:"brand_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MaxAutocompleteResults bounds the entries one brand or model autocomplete returns
+const MaxAutocompleteResults = 20
@@ -2,13 +2,61 @@
 package models
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
+	"github.com/PrateekKumar15/CarZone/statemachine"
 	"github.com/google/uuid"
 )
 
+// CarStatus is the lifecycle state of a car listing
+type CarStatus string
+
+const (
+	CarStatusDraft         CarStatus = "draft"          // Being set up by the owner, not listed
+	CarStatusPendingReview CarStatus = "pending_review" // Submitted by the owner, waiting for an admin
+	CarStatusActive        CarStatus = "active"         // Listed and bookable
+	CarStatusMaintenance   CarStatus = "maintenance"    // Temporarily off the catalog
+	CarStatusRetired       CarStatus = "retired"        // Permanently off the catalog
+)
+
+// CarStatusMachine declares the lifecycle of a car listing. Retired is final, and only admins
+// move a car out of pending_review, approving (active) or rejecting (draft) it.
+var CarStatusMachine = statemachine.New[CarStatus, Car]("car").
+	State(CarStatusDraft, CarStatusPendingReview).
+	State(CarStatusPendingReview, CarStatusActive, CarStatusDraft).
+	State(CarStatusActive, CarStatusMaintenance, CarStatusRetired).
+	State(CarStatusMaintenance, CarStatusActive, CarStatusRetired).
+	State(CarStatusRetired).
+	Guard(CarStatusPendingReview, CarStatusActive, requireAdminForReview).
+	Guard(CarStatusPendingReview, CarStatusDraft, requireAdminForReview)
+
+// requireAdminForReview reserves approving and rejecting cars under review for admins
+func requireAdminForReview(_ context.Context, _ Car, t statemachine.Transition[CarStatus]) error {
+	if !t.ByAdmin {
+		return errors.New("only admins can approve or reject a car under review")
+	}
+	return nil
+}
+
+// CarAvailability returns the availability a car has after moving between statuses: only active
+// cars are available, a car entering active becomes available, and an active car staying active
+// keeps the requested availability.
+func CarAvailability(from, to CarStatus, requested bool) bool {
+	if to != CarStatusActive {
+		return false
+	}
+	if from != CarStatusActive {
+		return true
+	}
+	return requested
+}
+
 // Engine represents the engine specifications embedded within a car
 type Engine struct {
 	EngineSize   float64 `json:"engine_size"`  // Engine displacement in liters
@@ -27,6 +75,7 @@ type Car struct {
 	OwnerID  *uuid.UUID `json:"owner_id"`        // ID of the user who owns this car
 	Owner    *User      `json:"owner,omitempty"` // Owner user information (populated when needed)
 	Name     string     `json:"name"`            // Display name/model of the car
+	Slug     string     `json:"slug"`            // URL-friendly unique identifier, generated on insert
 	Brand    string     `json:"brand"`           // Manufacturer brand name
 	Model    string     `json:"model"`           // Specific model name
 	Year     int        `json:"year"`            // Manufacturing year
@@ -44,15 +93,28 @@ type Car struct {
 	Price float64 `json:"rental_price"` // Pricing information
 
 	// Status and availability
-	Status      string `json:"status"`       // active, maintenance, inactive
-	IsAvailable bool   `json:"is_available"` // Current availability status
+	Status      CarStatus `json:"status"`       // Lifecycle state, see CarStatus
+	IsAvailable bool      `json:"is_available"` // Listed and not rented or blacked out right now; derived from bookings when read
+	Listed      bool      `json:"listed"`       // Owner's switch taking bookings; always false unless the car is active
 
 	// Additional information
 	Features    map[string]interface{} `json:"features"`    // Car features as JSON (GPS, AC, etc.)
+	Attributes  map[string]interface{} `json:"attributes"`  // Values of the operator's custom attributes, see CarAttribute
 	Description string                 `json:"description"` // Detailed description
 	Images      []string               `json:"images"`      // Array of image URLs
 	Mileage     int                    `json:"mileage"`     // Current mileage
 
+	// Set by listings filtered by a period: whether the car can be booked for it
+	Bookable *bool `json:"bookable,omitempty"`
+
+	// Set by ranked listings: whether a paid featured placement boosts the car, and in explain
+	// mode why the car ranks where it does
+	Featured bool        `json:"featured,omitempty"`
+	Ranking  *CarRanking `json:"ranking,omitempty"`
+
+	// Set by listings when the price was lowered recently
+	PriceDrop *CarPriceDrop `json:"price_drop,omitempty"`
+
 	// Timestamps
 	CreatedAt time.Time `json:"created_at"` // When the car record was created
 	UpdatedAt time.Time `json:"updated_at"` // When the car record was last updated
@@ -80,14 +142,29 @@ type CarRequest struct {
 	Price float64 `json:"rental_price"` // Pricing information
 
 	// Status and availability
-	Status      string `json:"status"`       // active, maintenance, inactive
-	IsAvailable bool   `json:"is_available"` // Current availability
+	Status      CarStatus `json:"status"`           // Initial status on create (draft or pending_review); on update, an allowed transition
+	IsAvailable bool      `json:"is_available"`     // Sets the car's listed switch; only honoured while the car is active
+	Listed      *bool     `json:"listed,omitempty"` // Preferred over is_available, which cars now report as derived availability
 
 	// Additional information
 	Features    map[string]interface{} `json:"features"`    // Car features as JSON
+	Attributes  map[string]interface{} `json:"attributes"`  // Values of the operator's custom attributes; null clears one
 	Description string                 `json:"description"` // Detailed description
 	Images      []string               `json:"images"`      // Array of image URLs
 	Mileage     int                    `json:"mileage"`     // Current mileage
+
+	// Registration plate, used by support staff to find the car. Optional; when empty on
+	// update the stored plate is kept. Not returned in car responses.
+	LicensePlate string `json:"license_plate,omitempty"`
+
+	// Brand and model must be a pair from the reference data unless this is set, which is the
+	// "other" choice of the autocomplete; the brand and model are then kept as typed
+	OtherBrandModel bool `json:"other_brand_model,omitempty"`
+}
+
+// CarStatusRequest is the payload to move a car to another lifecycle status
+type CarStatusRequest struct {
+	Status CarStatus `json:"status"`
 }
 
 // ValidateRequest performs comprehensive validation on a CarRequest
@@ -124,6 +201,9 @@ func ValidateRequest(carRequest CarRequest) error {
 	if err := validateMileage(carRequest.Mileage); err != nil {
 		return err
 	}
+	if err := validateLicensePlate(carRequest.LicensePlate); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -170,9 +250,11 @@ func validateYear(year string) error {
 	return nil
 }
 
+// validFuelTypes are the accepted fuel types, as stored
+var validFuelTypes = []string{"Petrol", "Diesel", "Electric", "Hybrid", "CNG", "LPG"}
+
 // validateFuelType ensures the fuel type is one of the accepted values
 func validateFuelType(fuelType string) error {
-	validFuelTypes := []string{"Petrol", "Diesel", "Electric", "Hybrid", "CNG", "LPG"}
 	for _, validType := range validFuelTypes {
 		if fuelType == validType {
 			return nil
@@ -240,14 +322,8 @@ func validatePrice(price float64) error {
 }
 
 // validateStatus ensures the status is valid
-func validateStatus(status string) error {
-	validStatuses := []string{"active", "maintenance", "inactive"}
-	for _, validStatus := range validStatuses {
-		if status == validStatus {
-			return nil
-		}
-	}
-	return errors.New("status must be one of: active, maintenance, inactive")
+func validateStatus(status CarStatus) error {
+	return CarStatusMachine.CheckState(status)
 }
 
 // validateMileage validates car mileage
@@ -257,3 +333,171 @@ func validateMileage(mileage int) error {
 	}
 	return nil
 }
+
+// validateLicensePlate checks an optional registration plate after normalization
+func validateLicensePlate(plate string) error {
+	if plate == "" {
+		return nil
+	}
+	normalized := NormalizeLicensePlate(plate)
+	if len(normalized) < 4 || len(normalized) > 12 {
+		return errors.New("license plate must have 4-12 letters or digits")
+	}
+	for _, r := range normalized {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return errors.New("license plate may only contain letters, digits, spaces and hyphens")
+		}
+	}
+	return nil
+}
+
+// NormalizeLicensePlate upper-cases a plate and drops spaces and hyphens, so
+// "ka 01-ab 1234" and "KA01AB1234" are the same plate
+func NormalizeLicensePlate(plate string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, plate)
+}
+
+// PageCursor returns the pagination cursor pointing at this car
+func (c Car) PageCursor() Cursor {
+	return Cursor{CreatedAt: c.CreatedAt, ID: c.ID}
+}
+
+// CarFilter narrows a car listing; zero values apply no restriction
+type CarFilter struct {
+	Status     CarStatus              // Only cars with this status
+	Features   []string               // Only cars with every one of these features set to true
+	Attributes map[string]interface{} // Only cars with these custom attribute values
+	Brand      string                 // Only cars of this brand, ignoring case
+	OwnerID    *uuid.UUID             // Only cars of this owner
+	BrandFuzzy bool                   // Also match brands similar to Brand, tolerating typos such as "toyta"
+
+	// Search criteria of GET /cars/search; zero values do not filter
+	Model       string   // Only cars of this model, ignoring case
+	FuelType    string   // Only cars with this fuel type, ignoring case
+	City        string   // Only cars located in this city, ignoring case
+	State       string   // Only cars located in this state, ignoring case
+	MinPrice    *float64 // Only cars with a daily price of at least this
+	MaxPrice    *float64 // Only cars with a daily price of at most this
+	MinYear     *int     // Only cars built in or after this year
+	MaxYear     *int     // Only cars built in or before this year
+	IsAvailable *bool    // Only cars that are, or are not, available right now
+
+	Sort    CarSort         // Order of the listing; CarSortRank ranks by score
+	Explain bool            // Keep each ranked car's Ranking in the response
+	Count   bool            // Also count every matching car into the page's Total
+	Ranking *RankingWeights // Set by the service for ranked listings; the store orders by score under them
+
+	// When both are set, each listed car reports whether it can be booked for this period
+	AvailableFrom *time.Time
+	AvailableTo   *time.Time
+}
+
+// maxAvailabilityDays bounds the period a listing checks availability for
+const maxAvailabilityDays = 365
+
+// ValidateAvailabilityPeriod checks the period of a listing's availability filter
+func ValidateAvailabilityPeriod(start, end time.Time) error {
+	if !end.After(start) {
+		return errors.New("end must be after start")
+	}
+	if end.Sub(start) > maxAvailabilityDays*24*time.Hour {
+		return fmt.Errorf("availability can be checked for at most %d days", maxAvailabilityDays)
+	}
+	return nil
+}
+
+// maxFeatureFilters bounds how many features one listing may be filtered by
+const maxFeatureFilters = 20
+
+// ParseFeatureFilter parses the comma-separated feature keys of a listing's features query
+// parameter, e.g. "sunroof,gps". Keys are normalized with FeatureKey and repeated keys are
+// dropped; aliases are resolved against the taxonomy later.
+func ParseFeatureFilter(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var features []string
+	seen := map[string]bool{}
+	for _, key := range strings.Split(raw, ",") {
+		key = FeatureKey(key)
+		if key == "" || seen[key] {
+			continue
+		}
+		for _, r := range key {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_') {
+				return nil, errors.New("features must be comma-separated keys of lowercase letters, digits and underscores")
+			}
+		}
+		seen[key] = true
+		features = append(features, key)
+	}
+	if len(features) > maxFeatureFilters {
+		return nil, errors.New("features must list at most 20 keys")
+	}
+
+	return features, nil
+}
+
+// PublicCar is the catalog view of a car exposed to unauthenticated clients.
+// It deliberately omits owner details so contact information never leaks.
+type PublicCar struct {
+	ID              uuid.UUID              `json:"id"`
+	Slug            string                 `json:"slug"`
+	Name            string                 `json:"name"`
+	Brand           string                 `json:"brand"`
+	Model           string                 `json:"model"`
+	Year            int                    `json:"year"`
+	FuelType        string                 `json:"fuel_type"`
+	Engine          Engine                 `json:"engine"`
+	LocationCity    string                 `json:"location_city"`
+	LocationState   string                 `json:"location_state"`
+	LocationCountry string                 `json:"location_country"`
+	Price           float64                `json:"rental_price"`
+	IsAvailable     bool                   `json:"is_available"`
+	Features        map[string]interface{} `json:"features"`
+	Attributes      map[string]interface{} `json:"attributes"`
+	Description     string                 `json:"description"`
+	Images          []string               `json:"images"`
+	Mileage         int                    `json:"mileage"`
+	PriceDrop       *CarPriceDrop          `json:"price_drop,omitempty"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+// Public returns the catalog-safe projection of the car
+func (c Car) Public() PublicCar {
+	return PublicCar{
+		ID:              c.ID,
+		Slug:            c.Slug,
+		Name:            c.Name,
+		Brand:           c.Brand,
+		Model:           c.Model,
+		Year:            c.Year,
+		FuelType:        c.FuelType,
+		Engine:          c.Engine,
+		LocationCity:    c.LocationCity,
+		LocationState:   c.LocationState,
+		LocationCountry: c.LocationCountry,
+		Price:           c.Price,
+		IsAvailable:     c.IsAvailable,
+		Features:        c.Features,
+		Attributes:      c.Attributes,
+		Description:     c.Description,
+		Images:          c.Images,
+		Mileage:         c.Mileage,
+		PriceDrop:       c.PriceDrop,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+// CarSitemapEntry is the minimal listing data needed for sitemap.xml and the listings feed
+type CarSitemapEntry struct {
+	ID        uuid.UUID `json:"id"`
+	Slug      string    `json:"slug"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CarEventType identifies what changed about a car
+type CarEventType string
+
+const (
+	CarEventUpdated       CarEventType = "car_updated"    // The owner edited the car's details
+	CarEventDatesReleased CarEventType = "dates_released" // A booking was cancelled or deleted, freeing its dates
+)
+
+// CarEvent describes a change to a car that subscribers may want to hear about
+type CarEvent struct {
+	Type  CarEventType
+	CarID uuid.UUID
+
+	// Set for car_updated
+	Previous *Car
+	Current  *Car
+
+	// Set for dates_released
+	ReleasedStart time.Time
+	ReleasedEnd   time.Time
+}
+
+// CarAlertSubscription is a user's request to hear when a car gets cheaper or becomes bookable
+type CarAlertSubscription struct {
+	ID             uuid.UUID  `json:"id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	CarID          uuid.UUID  `json:"car_id"`
+	PriceDrop      bool       `json:"price_drop"`             // Alert when the daily price goes down
+	TargetPrice    *float64   `json:"target_price,omitempty"` // Only alert once the price is at or below this
+	Availability   bool       `json:"availability"`           // Alert when blocked dates open up
+	StartDate      *time.Time `json:"start_date,omitempty"`   // Dates the user wants; all of them must be free
+	EndDate        *time.Time `json:"end_date,omitempty"`
+	LastNotifiedAt *time.Time `json:"last_notified_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// CarAlertSubscriptionRequest is the payload to subscribe to a car. Subscribing again replaces the criteria.
+type CarAlertSubscriptionRequest struct {
+	PriceDrop    bool       `json:"price_drop"`
+	TargetPrice  *float64   `json:"target_price,omitempty"`
+	Availability bool       `json:"availability"`
+	StartDate    *time.Time `json:"start_date,omitempty"`
+	EndDate      *time.Time `json:"end_date,omitempty"`
+}
+
+// WantsDates reports whether an availability subscription covers the given period. Subscriptions
+// without dates cover any period.
+func (s CarAlertSubscription) WantsDates(start, end time.Time) bool {
+	if s.StartDate == nil || s.EndDate == nil {
+		return true
+	}
+	return s.StartDate.Before(end) && s.EndDate.After(start)
+}
+
+// ValidateCarAlertSubscriptionRequest validates a CarAlertSubscriptionRequest. Returns nil when valid, otherwise an error.
+func ValidateCarAlertSubscriptionRequest(req CarAlertSubscriptionRequest) error {
+	if !req.PriceDrop && !req.Availability {
+		return errors.New("choose price_drop, availability or both")
+	}
+	if req.TargetPrice != nil {
+		if !req.PriceDrop {
+			return errors.New("target_price requires price_drop")
+		}
+		if *req.TargetPrice <= 0 {
+			return errors.New("target_price must be greater than 0")
+		}
+	}
+	if (req.StartDate == nil) != (req.EndDate == nil) {
+		return errors.New("start_date and end_date must be given together")
+	}
+	if req.StartDate != nil {
+		if !req.Availability {
+			return errors.New("start_date and end_date require availability")
+		}
+		if !req.EndDate.After(*req.StartDate) {
+			return errors.New("end_date must be after start_date")
+		}
+		if req.EndDate.Before(time.Now()) {
+			return errors.New("end_date cannot be in the past")
+		}
+	}
+	return nil
+}
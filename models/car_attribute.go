@@ -0,0 +1,231 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CarAttributeType is the kind of value a custom car attribute holds
+type CarAttributeType string
+
+const (
+	CarAttributeBoolean CarAttributeType = "boolean" // true or false, e.g. pet_friendly
+	CarAttributeNumber  CarAttributeType = "number"  // Any number, e.g. child_seats
+	CarAttributeText    CarAttributeType = "text"    // Free text up to 200 characters
+	CarAttributeSelect  CarAttributeType = "select"  // One of the attribute's options, e.g. roof_box
+)
+
+// Limits of custom attribute definitions and values
+const (
+	maxCarAttributeOptions   = 50
+	maxCarAttributeTextLen   = 200
+	maxCarAttributeFilters   = 20
+	maxCarAttributeOptionLen = 50
+)
+
+// CarAttribute is an extra car attribute an operator defines beyond the built-in fields, e.g.
+// "Pet friendly". Cars of the operator store values for it in their attributes, which are
+// validated against the definition when a car is saved and can be filtered on in listings.
+type CarAttribute struct {
+	Key       string           `json:"key"`
+	Name      string           `json:"name"`              // Label shown on car forms and pages
+	Type      CarAttributeType `json:"type"`              // boolean, number, text or select
+	Options   []string         `json:"options,omitempty"` // Allowed values of select attributes
+	Required  bool             `json:"required"`          // Cars cannot be saved without a value
+	CarCount  int              `json:"car_count"`         // Cars with a value for it
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// CarAttributeRequest is the payload to define a custom attribute or replace its definition
+type CarAttributeRequest struct {
+	Key      string           `json:"key"`
+	Name     string           `json:"name"`
+	Type     CarAttributeType `json:"type"`
+	Options  []string         `json:"options"`
+	Required bool             `json:"required"`
+}
+
+// ValidateCarAttributeRequest validates a CarAttributeRequest and normalizes its key and
+// options. Returns nil when valid, otherwise an error.
+func ValidateCarAttributeRequest(req *CarAttributeRequest) error {
+	req.Key = FeatureKey(req.Key)
+	if !featureKeyPattern.MatchString(req.Key) {
+		return errors.New("key must be 2-50 lowercase letters, digits and underscores, starting with a letter")
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > 100 {
+		return errors.New("name is required and must be at most 100 characters")
+	}
+
+	switch req.Type {
+	case CarAttributeBoolean, CarAttributeNumber, CarAttributeText:
+		if len(req.Options) > 0 {
+			return errors.New("options are only allowed for select attributes")
+		}
+		req.Options = nil
+	case CarAttributeSelect:
+		seen := map[string]bool{}
+		options := make([]string, 0, len(req.Options))
+		for _, option := range req.Options {
+			option = strings.TrimSpace(option)
+			if option == "" || seen[option] {
+				continue
+			}
+			if len(option) > maxCarAttributeOptionLen {
+				return fmt.Errorf("options must be at most %d characters", maxCarAttributeOptionLen)
+			}
+			seen[option] = true
+			options = append(options, option)
+		}
+		if len(options) == 0 || len(options) > maxCarAttributeOptions {
+			return fmt.Errorf("select attributes must have 1-%d options", maxCarAttributeOptions)
+		}
+		req.Options = options
+	default:
+		return errors.New("type must be one of: boolean, number, text, select")
+	}
+
+	return nil
+}
+
+// CarAttributeSchema indexes an operator's custom attribute definitions by key
+type CarAttributeSchema map[string]CarAttribute
+
+// NewCarAttributeSchema indexes attribute definitions by key
+func NewCarAttributeSchema(attributes []CarAttribute) CarAttributeSchema {
+	schema := make(CarAttributeSchema, len(attributes))
+	for _, attribute := range attributes {
+		schema[attribute.Key] = attribute
+	}
+	return schema
+}
+
+// NormalizeAttributes validates a car's attribute values against the schema. Keys are
+// normalized with FeatureKey, text is trimmed, and null or empty values are dropped so they
+// clear the attribute. Every required attribute must have a value.
+func (s CarAttributeSchema) NormalizeAttributes(values map[string]interface{}) (map[string]interface{}, error) {
+	normalized := make(map[string]interface{}, len(values))
+	for raw, value := range values {
+		key := FeatureKey(raw)
+		attribute, ok := s[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown attribute %s, see GET /car-attributes for the supported keys", raw)
+		}
+		if value == nil {
+			continue
+		}
+		valid, err := attribute.value(value)
+		if err != nil {
+			return nil, err
+		}
+		if valid != nil {
+			normalized[key] = valid
+		}
+	}
+
+	for key, attribute := range s {
+		if _, ok := normalized[key]; attribute.Required && !ok {
+			return nil, fmt.Errorf("attribute %s is required", key)
+		}
+	}
+
+	return normalized, nil
+}
+
+// value checks one value of the attribute, returning nil for an empty text or option
+func (a CarAttribute) value(value interface{}) (interface{}, error) {
+	switch a.Type {
+	case CarAttributeBoolean:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("attribute %s must be true or false", a.Key)
+	case CarAttributeNumber:
+		if n, ok := value.(float64); ok {
+			return n, nil
+		}
+		return nil, fmt.Errorf("attribute %s must be a number", a.Key)
+	case CarAttributeText:
+		text, ok := value.(string)
+		if !ok || len(strings.TrimSpace(text)) > maxCarAttributeTextLen {
+			return nil, fmt.Errorf("attribute %s must be text of at most %d characters", a.Key, maxCarAttributeTextLen)
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			return text, nil
+		}
+		return nil, nil
+	default:
+		option, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("attribute %s must be one of: %s", a.Key, strings.Join(a.Options, ", "))
+		}
+		if option = strings.TrimSpace(option); option == "" {
+			return nil, nil
+		}
+		for _, allowed := range a.Options {
+			if option == allowed {
+				return option, nil
+			}
+		}
+		return nil, fmt.Errorf("attribute %s must be one of: %s", a.Key, strings.Join(a.Options, ", "))
+	}
+}
+
+// ParseFilter converts the values of a listing's attribute filter, parsed by
+// ParseAttributeFilter, to the types of their attributes
+func (s CarAttributeSchema) ParseFilter(filter map[string]interface{}) (map[string]interface{}, error) {
+	typed := make(map[string]interface{}, len(filter))
+	for key, raw := range filter {
+		attribute, ok := s[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown attribute %s, see GET /car-attributes for the supported keys", key)
+		}
+		text, _ := raw.(string)
+
+		var value interface{} = text
+		switch attribute.Type {
+		case CarAttributeBoolean:
+			b, err := strconv.ParseBool(text)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s must be true or false", key)
+			}
+			value = b
+		case CarAttributeNumber:
+			n, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s must be a number", key)
+			}
+			value = n
+		}
+		typed[key] = value
+	}
+	return typed, nil
+}
+
+// ParseAttributeFilter parses a listing's attributes query parameter: comma-separated
+// key:value pairs, e.g. "pet_friendly:true,roof_box:Large". Values are converted to the types
+// of their attributes later, with CarAttributeSchema.ParseFilter.
+func ParseAttributeFilter(raw string) (map[string]interface{}, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	filter := map[string]interface{}{}
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(pair, ":")
+		key = FeatureKey(key)
+		if !ok || !featureKeyPattern.MatchString(key) {
+			return nil, errors.New("attributes must be comma-separated key:value pairs")
+		}
+		filter[key] = strings.TrimSpace(value)
+	}
+	if len(filter) > maxCarAttributeFilters {
+		return nil, fmt.Errorf("attributes must list at most %d keys", maxCarAttributeFilters)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxCarImageBytes bounds the decoded size of an image sent with a car
+const MaxCarImageBytes = 10 << 20
+
+// CarImageUploadStatus tracks an image on its way to Cloudinary
+type CarImageUploadStatus string
+
+const (
+	CarImageUploadPending   CarImageUploadStatus = "pending"   // Waiting for its next attempt
+	CarImageUploadUploading CarImageUploadStatus = "uploading" // Claimed by the upload job
+	CarImageUploadUploaded  CarImageUploadStatus = "uploaded"  // Added to the car's images, see url
+	CarImageUploadFailed    CarImageUploadStatus = "failed"    // Given up after the last attempt, see error
+)
+
+// CarImageUpload is an image sent with a car as base64 data. The image is kept until the
+// upload job has stored it in Cloudinary and added its URL to the car, retrying while
+// Cloudinary fails.
+type CarImageUpload struct {
+	ID            uuid.UUID            `json:"id"`
+	CarID         uuid.UUID            `json:"car_id"`
+	Position      int                  `json:"position"` // Order of the image in the request
+	Data          string               `json:"-"`        // Base64 image as sent; only read by the upload job
+	Status        CarImageUploadStatus `json:"status"`
+	Attempts      int                  `json:"attempts"`
+	NextAttemptAt *time.Time           `json:"next_attempt_at,omitempty"` // Set while pending
+	URL           *string              `json:"url,omitempty"`
+	Error         string               `json:"error,omitempty"` // Why the last attempt failed
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+// IsImageURL reports whether an entry of a car's images is a URL rather than image data
+func IsImageURL(image string) bool {
+	return strings.HasPrefix(image, "http://") || strings.HasPrefix(image, "https://")
+}
+
+// ValidateBase64Image checks that image data, optionally a data URI, decodes to an image no
+// larger than MaxCarImageBytes
+func ValidateBase64Image(image string) error {
+	if idx := strings.Index(image, ","); idx != -1 {
+		image = image[idx+1:]
+	}
+	data, err := base64.StdEncoding.DecodeString(image)
+	if err != nil || len(data) == 0 {
+		return errors.New("image is neither a URL nor valid base64 data")
+	}
+	if len(data) > MaxCarImageBytes {
+		return errors.New("image is larger than 10 MB")
+	}
+	return nil
+}
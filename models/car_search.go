@@ -0,0 +1,91 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ParseCarSearch parses the criteria of GET /cars/search: brand, model, fuel_type, city,
+// state, min_price, max_price, min_year, max_year and is_available. Absent or empty
+// parameters do not filter; text criteria match whole values, ignoring case.
+func ParseCarSearch(values url.Values) (CarFilter, error) {
+	filter := CarFilter{
+		Brand: strings.TrimSpace(values.Get("brand")),
+		Model: strings.TrimSpace(values.Get("model")),
+		City:  strings.TrimSpace(values.Get("city")),
+		State: strings.TrimSpace(values.Get("state")),
+	}
+
+	if fuelType := strings.TrimSpace(values.Get("fuel_type")); fuelType != "" {
+		// Stored fuel types are capitalized as in car requests, e.g. Petrol or CNG
+		for _, validType := range validFuelTypes {
+			if strings.EqualFold(fuelType, validType) {
+				filter.FuelType = validType
+			}
+		}
+		if filter.FuelType == "" {
+			return filter, validateFuelType(fuelType)
+		}
+	}
+
+	var err error
+	if filter.MinPrice, err = parsePriceParam(values, "min_price"); err != nil {
+		return filter, err
+	}
+	if filter.MaxPrice, err = parsePriceParam(values, "max_price"); err != nil {
+		return filter, err
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return filter, errors.New("min_price must not exceed max_price")
+	}
+
+	if filter.MinYear, err = parseYearParam(values, "min_year"); err != nil {
+		return filter, err
+	}
+	if filter.MaxYear, err = parseYearParam(values, "max_year"); err != nil {
+		return filter, err
+	}
+	if filter.MinYear != nil && filter.MaxYear != nil && *filter.MinYear > *filter.MaxYear {
+		return filter, errors.New("min_year must not exceed max_year")
+	}
+
+	if raw := strings.TrimSpace(values.Get("is_available")); raw != "" {
+		available, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, errors.New("is_available must be true or false")
+		}
+		filter.IsAvailable = &available
+	}
+
+	return filter, nil
+}
+
+// parsePriceParam parses an optional non-negative daily price bound
+func parsePriceParam(values url.Values, name string) (*float64, error) {
+	raw := strings.TrimSpace(values.Get(name))
+	if raw == "" {
+		return nil, nil
+	}
+	price, err := strconv.ParseFloat(raw, 64)
+	if err != nil || price < 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		return nil, fmt.Errorf("%s must be a non-negative number", name)
+	}
+	return &price, nil
+}
+
+// parseYearParam parses an optional manufacturing year bound
+func parseYearParam(values url.Values, name string) (*int, error) {
+	raw := strings.TrimSpace(values.Get(name))
+	if raw == "" {
+		return nil, nil
+	}
+	year, err := strconv.Atoi(raw)
+	if err != nil || year < 1886 || year > 9999 {
+		return nil, fmt.Errorf("%s must be a year such as 2020", name)
+	}
+	return &year, nil
+}
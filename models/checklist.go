@@ -0,0 +1,145 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChecklistSection groups the items of a handover checklist
+type ChecklistSection string
+
+const (
+	ChecklistSectionExterior ChecklistSection = "exterior" // Body, glass, lights, tyres
+	ChecklistSectionInterior ChecklistSection = "interior" // Seats, dashboard, cleanliness
+	ChecklistSectionFluids   ChecklistSection = "fluids"   // Oil, coolant, washer and brake fluid
+)
+
+// ChecklistItemType decides which answers a checklist item accepts
+type ChecklistItemType string
+
+const (
+	ChecklistItemCondition ChecklistItemType = "condition" // good, damaged, missing
+	ChecklistItemLevel     ChecklistItemType = "level"     // full, ok, low, empty
+)
+
+// checklistAnswers lists the values each item type accepts
+var checklistAnswers = map[ChecklistItemType][]string{
+	ChecklistItemCondition: {"good", "damaged", "missing"},
+	ChecklistItemLevel:     {"full", "ok", "low", "empty"},
+}
+
+// checklistItemKeyPattern allows lowercase snake_case item keys
+var checklistItemKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// ChecklistItem is one point to check at a handover
+type ChecklistItem struct {
+	Key     string            `json:"key"` // Stable identifier answers refer to, e.g. "front_bumper"
+	Label   string            `json:"label"`
+	Section ChecklistSection  `json:"section"`
+	Type    ChecklistItemType `json:"type"`
+}
+
+// ChecklistTemplate is one version of the checklist filled in at checkout and check-in. Saving
+// the checklist adds a new version; checkouts use the highest version, and a check-in answers
+// the version its checkout used so both ends of a trip compare item by item.
+type ChecklistTemplate struct {
+	ID        uuid.UUID       `json:"id"`
+	Version   int             `json:"version"`
+	Items     []ChecklistItem `json:"items"`
+	CreatedBy *uuid.UUID      `json:"created_by,omitempty"` // Admin who saved this version
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ChecklistTemplateRequest is the payload to save a new version of the checklist
+type ChecklistTemplateRequest struct {
+	Items []ChecklistItem `json:"items"`
+}
+
+// ChecklistAnswer is the response to one checklist item at a handover
+type ChecklistAnswer struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Note  string `json:"note,omitempty"`
+}
+
+// ValidateChecklistTemplateRequest validates a ChecklistTemplateRequest. Returns nil when valid, otherwise an error.
+func ValidateChecklistTemplateRequest(req ChecklistTemplateRequest) error {
+	if len(req.Items) == 0 {
+		return errors.New("checklist must have at least one item")
+	}
+	if len(req.Items) > 100 {
+		return errors.New("checklist must have at most 100 items")
+	}
+
+	seen := make(map[string]bool, len(req.Items))
+	for _, item := range req.Items {
+		if !checklistItemKeyPattern.MatchString(item.Key) {
+			return fmt.Errorf("invalid checklist item key %q: use lowercase letters, digits and underscores", item.Key)
+		}
+		if seen[item.Key] {
+			return fmt.Errorf("checklist item key %q is used more than once", item.Key)
+		}
+		seen[item.Key] = true
+
+		if label := strings.TrimSpace(item.Label); label == "" || len(label) > 100 {
+			return fmt.Errorf("checklist item %q label is required and must be at most 100 characters", item.Key)
+		}
+		switch item.Section {
+		case ChecklistSectionExterior, ChecklistSectionInterior, ChecklistSectionFluids:
+		default:
+			return fmt.Errorf("checklist item %q section must be exterior, interior or fluids", item.Key)
+		}
+		if _, ok := checklistAnswers[item.Type]; !ok {
+			return fmt.Errorf("checklist item %q type must be condition or level", item.Key)
+		}
+	}
+	return nil
+}
+
+// ValidateChecklistAnswers checks that answers cover every item of a checklist exactly once,
+// with a value the item's type accepts. Returns nil when valid, otherwise an error.
+func ValidateChecklistAnswers(template ChecklistTemplate, answers []ChecklistAnswer) error {
+	items := make(map[string]ChecklistItem, len(template.Items))
+	for _, item := range template.Items {
+		items[item.Key] = item
+	}
+
+	answered := make(map[string]bool, len(answers))
+	for _, answer := range answers {
+		item, ok := items[answer.Key]
+		if !ok {
+			return fmt.Errorf("checklist version %d has no item %q", template.Version, answer.Key)
+		}
+		if answered[answer.Key] {
+			return fmt.Errorf("checklist item %q is answered more than once", answer.Key)
+		}
+		answered[answer.Key] = true
+
+		accepted := checklistAnswers[item.Type]
+		valid := false
+		for _, value := range accepted {
+			if answer.Value == value {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("checklist item %q must be one of %s", answer.Key, strings.Join(accepted, ", "))
+		}
+		if len(answer.Note) > 500 {
+			return fmt.Errorf("checklist item %q note must be at most 500 characters", answer.Key)
+		}
+	}
+
+	for _, item := range template.Items {
+		if !answered[item.Key] {
+			return fmt.Errorf("checklist item %q is required", item.Key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DashboardMetric names a daily aggregate kept in the dashboard read model
+type DashboardMetric string
+
+const (
+	DashboardBookings       DashboardMetric = "bookings"         // Bookings created, platform-wide
+	DashboardRevenue        DashboardMetric = "revenue"          // Completed payment amounts in INR, platform-wide
+	DashboardSignups        DashboardMetric = "signups"          // Users registered
+	DashboardCancellations  DashboardMetric = "cancellations"    // Bookings cancelled, by day of cancellation
+	DashboardFunnelConfirm  DashboardMetric = "funnel_confirmed" // Bookings created that day which were confirmed
+	DashboardFunnelComplete DashboardMetric = "funnel_completed" // Bookings created that day which were completed
+	DashboardFunnelCancel   DashboardMetric = "funnel_cancelled" // Bookings created that day which were cancelled
+	DashboardOwnerBookings  DashboardMetric = "owner_bookings"   // Bookings created, per owner
+	DashboardOwnerRevenue   DashboardMetric = "owner_revenue"    // Completed payment amounts in INR, per owner
+	DashboardCarBookedHours DashboardMetric = "car_booked_hours" // Hours a car was held by bookings, per car
+)
+
+// MaxDashboardDays bounds the period one dashboard query covers
+const MaxDashboardDays = 366
+
+// DashboardRange is a period of whole UTC days. From is the first day and To the day after the
+// last one.
+type DashboardRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Days returns the number of days in the range
+func (r DashboardRange) Days() int {
+	return int(r.To.Sub(r.From).Hours() / 24)
+}
+
+// ParseDashboardRange parses the from and to query parameters of a dashboard request. Both are
+// YYYY-MM-DD dates in UTC and included; they default to the 30 days up to today.
+func ParseDashboardRange(fromParam, toParam string) (DashboardRange, error) {
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if toParam != "" {
+		parsed, err := time.Parse("2006-01-02", toParam)
+		if err != nil {
+			return DashboardRange{}, errors.New("to must be a YYYY-MM-DD date")
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -29)
+	if fromParam != "" {
+		parsed, err := time.Parse("2006-01-02", fromParam)
+		if err != nil {
+			return DashboardRange{}, errors.New("from must be a YYYY-MM-DD date")
+		}
+		from = parsed
+	}
+
+	if to.Before(from) {
+		return DashboardRange{}, errors.New("from must not be after to")
+	}
+	r := DashboardRange{From: from, To: to.AddDate(0, 0, 1)}
+	if r.Days() > MaxDashboardDays {
+		return DashboardRange{}, fmt.Errorf("range must be at most %d days", MaxDashboardDays)
+	}
+	return r, nil
+}
+
+// DashboardRefresh describes one refresh of the dashboard read model
+type DashboardRefresh struct {
+	Full        bool      `json:"full"`         // Every day was rebuilt rather than only changed ones
+	Days        int       `json:"days"`         // Days recomputed
+	RefreshedAt time.Time `json:"refreshed_at"` // Source changes up to this time are included
+}
+
+// OwnerEarnings is an owner's bookings and completed payments over a range
+type OwnerEarnings struct {
+	OwnerID  uuid.UUID     `json:"owner_id"`
+	Owner    *UserSnapshot `json:"owner,omitempty"`
+	Bookings int           `json:"bookings"`
+	Revenue  float64       `json:"revenue"` // INR
+}
+
+// CarUtilization is the share of a range a car was held by bookings
+type CarUtilization struct {
+	CarID       uuid.UUID    `json:"car_id"`
+	Car         *CarSnapshot `json:"car,omitempty"`
+	BookedHours float64      `json:"booked_hours"`
+	Utilization float64      `json:"utilization"` // Booked hours over the hours in the range, 0 to 1
+}
+
+// BookingFunnel follows the bookings created over a range to where they ended up
+type BookingFunnel struct {
+	Created   int `json:"created"`
+	Confirmed int `json:"confirmed"` // Confirmed, whether or not they went on to complete
+	Completed int `json:"completed"`
+	Cancelled int `json:"cancelled"`
+
+	ConfirmationRate float64 `json:"confirmation_rate"` // Confirmed over created
+	CompletionRate   float64 `json:"completion_rate"`   // Completed over created
+}
+
+// Dashboard wraps a read model result with the range it covers and how fresh it is
+type Dashboard[T any] struct {
+	Range DashboardRange `json:"range"`
+	AsOf  *time.Time     `json:"as_of"` // Last refresh of the read model; null before the first
+	Data  T              `json:"data"`
+}
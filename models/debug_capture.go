@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Why a request was captured
+const (
+	DebugCaptureReasonSampled = "sampled" // Picked by DEBUG_CAPTURE_SAMPLE_RATE
+	DebugCaptureReasonUser    = "user"    // Sent by a user listed in DEBUG_CAPTURE_USERS
+	DebugCaptureReasonRoute   = "route"   // Sent to a path under DEBUG_CAPTURE_ROUTES
+	DebugCaptureReasonHeader  = "header"  // Carried X-Debug-Capture with DEBUG_CAPTURE_TOKEN
+)
+
+// DebugCapture is a sanitized copy of one request and its response, kept for a short time to
+// troubleshoot intermittent client issues. Credentials and personal data are redacted and
+// bodies are truncated before it is stored.
+type DebugCapture struct {
+	ID              uuid.UUID         `json:"id"`
+	Reason          string            `json:"reason"`            // sampled, user, route or header
+	UserID          *uuid.UUID        `json:"user_id,omitempty"` // Caller, when the request carried a valid token
+	Method          string            `json:"method"`
+	Path            string            `json:"path"`
+	Query           string            `json:"query"` // Query string with sensitive parameters redacted
+	ClientIP        string            `json:"client_ip"`
+	RequestHeaders  map[string]string `json:"request_headers"`
+	RequestBody     string            `json:"request_body"` // Redacted, truncated body, or a note of what was omitted
+	Status          int               `json:"status"`
+	ResponseHeaders map[string]string `json:"response_headers"`
+	ResponseBody    string            `json:"response_body"`
+	DurationMs      int64             `json:"duration_ms"`
+	CreatedAt       time.Time         `json:"created_at"`
+	ExpiresAt       time.Time         `json:"expires_at"` // Purged after this
+}
+
+// PageCursor returns the keyset position of the capture in a listing
+func (c DebugCapture) PageCursor() Cursor {
+	return Cursor{CreatedAt: c.CreatedAt, ID: c.ID}
+}
+
+// DebugCaptureFilter restricts a listing of debug captures
+type DebugCaptureFilter struct {
+	UserID    string // Only captures of this user
+	Path      string // Only captures of paths starting with this
+	MinStatus int    // Only captures answered with at least this status, e.g. 500
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryOption is an owner's offer to deliver a car to the renter's address. The fee is
+// BaseFee plus FeePerKm for every kilometre of straight-line distance from the origin.
+type DeliveryOption struct {
+	CarID           uuid.UUID `json:"car_id"`
+	Enabled         bool      `json:"enabled"`
+	OriginLatitude  float64   `json:"origin_latitude"`  // Where deliveries start, usually the car's parking spot
+	OriginLongitude float64   `json:"origin_longitude"` // Where deliveries start, usually the car's parking spot
+	MaxRadiusKm     float64   `json:"max_radius_km"`
+	BaseFee         float64   `json:"base_fee"`   // INR
+	FeePerKm        float64   `json:"fee_per_km"` // INR
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// DeliveryOptionRequest is the payload an owner sends to configure delivery for a car
+type DeliveryOptionRequest struct {
+	Enabled         bool    `json:"enabled"`
+	OriginLatitude  float64 `json:"origin_latitude"`
+	OriginLongitude float64 `json:"origin_longitude"`
+	MaxRadiusKm     float64 `json:"max_radius_km"`
+	BaseFee         float64 `json:"base_fee"`
+	FeePerKm        float64 `json:"fee_per_km"`
+}
+
+// GeocodedAddress is a free-form address resolved to coordinates
+type GeocodedAddress struct {
+	FormattedAddress string  `json:"formatted_address"`
+	Latitude         float64 `json:"latitude"`
+	Longitude        float64 `json:"longitude"`
+}
+
+// BookingDelivery records where a booked car is delivered and how far that is from its origin
+type BookingDelivery struct {
+	Address    string  `json:"address"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// Quote prices a delivery to the given address. It fails when delivery is disabled or the
+// address lies beyond the owner's radius.
+func (o DeliveryOption) Quote(address GeocodedAddress) (BookingDelivery, float64, error) {
+	if !o.Enabled {
+		return BookingDelivery{}, 0, errors.New("delivery is not offered for this car")
+	}
+
+	distance := DistanceKm(o.OriginLatitude, o.OriginLongitude, address.Latitude, address.Longitude)
+	if distance > o.MaxRadiusKm {
+		return BookingDelivery{}, 0, errors.New("delivery address is outside the delivery radius for this car")
+	}
+
+	delivery := BookingDelivery{
+		Address:    address.FormattedAddress,
+		Latitude:   address.Latitude,
+		Longitude:  address.Longitude,
+		DistanceKm: math.Round(distance*10) / 10,
+	}
+	fee := math.Round((o.BaseFee+o.FeePerKm*distance)*100) / 100
+	return delivery, fee, nil
+}
+
+// ValidateDeliveryOptionRequest validates a DeliveryOptionRequest. Returns nil when valid, otherwise an error.
+func ValidateDeliveryOptionRequest(req DeliveryOptionRequest) error {
+	if req.OriginLatitude < -90 || req.OriginLatitude > 90 {
+		return errors.New("origin_latitude must be between -90 and 90")
+	}
+	if req.OriginLongitude < -180 || req.OriginLongitude > 180 {
+		return errors.New("origin_longitude must be between -180 and 180")
+	}
+	if req.MaxRadiusKm <= 0 || req.MaxRadiusKm > 200 {
+		return errors.New("max_radius_km must be greater than 0 and at most 200")
+	}
+	if req.BaseFee < 0 || req.FeePerKm < 0 {
+		return errors.New("base_fee and fee_per_km cannot be negative")
+	}
+	return nil
+}
+
+// ValidateDeliveryAddress checks that a delivery address is worth geocoding
+func ValidateDeliveryAddress(address string) error {
+	address = strings.TrimSpace(address)
+	if len(address) < 10 {
+		return errors.New("delivery_address must include street, area and city")
+	}
+	if len(address) > 300 {
+		return errors.New("delivery_address must be at most 300 characters")
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DisputeStatus is where a chargeback stands with the customer's bank, as reported by Razorpay
+type DisputeStatus string
+
+const (
+	DisputeStatusOpen        DisputeStatus = "open"         // Raised by the customer; evidence is due by respond_by
+	DisputeStatusUnderReview DisputeStatus = "under_review" // Evidence submitted, the bank is deciding
+	DisputeStatusWon         DisputeStatus = "won"          // Decided for CarZone; the amount stays with us
+	DisputeStatusLost        DisputeStatus = "lost"         // Decided for the customer; the amount is debited
+	DisputeStatusClosed      DisputeStatus = "closed"       // Withdrawn or closed without a decision
+)
+
+// Resolved reports whether the dispute has reached an outcome and no longer holds payouts
+func (s DisputeStatus) Resolved() bool {
+	return s == DisputeStatusWon || s == DisputeStatusLost || s == DisputeStatusClosed
+}
+
+// DisputeEvidenceKind is the type of a document attached to a dispute
+type DisputeEvidenceKind string
+
+const (
+	DisputeEvidenceInvoice       DisputeEvidenceKind = "invoice"       // Booking invoice or receipt
+	DisputeEvidenceAgreement     DisputeEvidenceKind = "agreement"     // Rental agreement accepted by the customer
+	DisputeEvidenceInspection    DisputeEvidenceKind = "inspection"    // Checkout and check-in records, photos
+	DisputeEvidenceCommunication DisputeEvidenceKind = "communication" // Messages with the customer
+	DisputeEvidenceOther         DisputeEvidenceKind = "other"
+)
+
+// Dispute is a chargeback a customer raised against a payment
+type Dispute struct {
+	ID                uuid.UUID         `json:"id"`
+	PaymentID         uuid.UUID         `json:"payment_id"`
+	BookingID         uuid.UUID         `json:"booking_id"`
+	OwnerID           *uuid.UUID        `json:"owner_id,omitempty"` // Owner whose payouts are held while the dispute is open
+	RazorpayDisputeID string            `json:"razorpay_dispute_id"`
+	Amount            float64           `json:"amount"` // INR
+	ReasonCode        string            `json:"reason_code"`
+	ReasonDescription string            `json:"reason_description,omitempty"`
+	Phase             string            `json:"phase,omitempty"` // e.g. chargeback, pre_arbitration, arbitration
+	Status            DisputeStatus     `json:"status"`
+	RespondBy         *time.Time        `json:"respond_by,omitempty"`
+	OutcomeNotes      string            `json:"outcome_notes,omitempty"`
+	ResolvedAt        *time.Time        `json:"resolved_at,omitempty"`
+	Evidence          []DisputeEvidence `json:"evidence,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+}
+
+// DisputeEvidence is a document an admin attached to contest a dispute
+type DisputeEvidence struct {
+	ID          uuid.UUID           `json:"id"`
+	DisputeID   uuid.UUID           `json:"dispute_id"`
+	Kind        DisputeEvidenceKind `json:"kind"`
+	Description string              `json:"description"`
+	DocumentURL string              `json:"document_url,omitempty"`
+	AddedBy     uuid.UUID           `json:"added_by"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// DisputeEvidenceRequest is the payload an admin sends to attach evidence
+type DisputeEvidenceRequest struct {
+	Kind        DisputeEvidenceKind `json:"kind"`
+	Description string              `json:"description"`
+	DocumentURL string              `json:"document_url,omitempty"`
+}
+
+// DisputeOutcomeRequest is the payload an admin sends to record how a dispute ended
+type DisputeOutcomeRequest struct {
+	Status DisputeStatus `json:"status"` // won, lost or closed
+	Notes  string        `json:"notes,omitempty"`
+}
+
+// DisputeFilter narrows the admin dispute list
+type DisputeFilter struct {
+	Status DisputeStatus // Empty for every status
+}
+
+// PayoutHold freezes an owner's payouts while a dispute on one of their bookings is open
+type PayoutHold struct {
+	ID         uuid.UUID  `json:"id"`
+	OwnerID    uuid.UUID  `json:"owner_id"`
+	DisputeID  uuid.UUID  `json:"dispute_id"`
+	BookingID  uuid.UUID  `json:"booking_id"`
+	Amount     float64    `json:"amount"` // Disputed amount in INR
+	CreatedAt  time.Time  `json:"created_at"`
+	ReleasedAt *time.Time `json:"released_at,omitempty"`
+}
+
+// ValidateDisputeEvidenceRequest validates a DisputeEvidenceRequest. Returns nil when valid, otherwise an error.
+func ValidateDisputeEvidenceRequest(req DisputeEvidenceRequest) error {
+	switch req.Kind {
+	case DisputeEvidenceInvoice, DisputeEvidenceAgreement, DisputeEvidenceInspection,
+		DisputeEvidenceCommunication, DisputeEvidenceOther:
+	default:
+		return errors.New("kind must be one of: invoice, agreement, inspection, communication, other")
+	}
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return errors.New("description is required")
+	}
+	if len(description) > 2000 {
+		return errors.New("description must be at most 2000 characters")
+	}
+	if req.DocumentURL != "" {
+		parsed, err := url.Parse(req.DocumentURL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return errors.New("document_url must be an http or https URL")
+		}
+	}
+	return nil
+}
+
+// ValidateDisputeOutcomeRequest validates a DisputeOutcomeRequest. Returns nil when valid, otherwise an error.
+func ValidateDisputeOutcomeRequest(req DisputeOutcomeRequest) error {
+	if !req.Status.Resolved() {
+		return errors.New("status must be one of: won, lost, closed")
+	}
+	if len(req.Notes) > 2000 {
+		return errors.New("notes must be at most 2000 characters")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DocumentSeries is a numbered series of financial documents. Each series is numbered from 1
+// every fiscal year without gaps.
+type DocumentSeries string
+
+const (
+	DocumentSeriesInvoice    DocumentSeries = "INV" // Issued when a booking is confirmed
+	DocumentSeriesReceipt    DocumentSeries = "RCP" // Issued when a payment is completed
+	DocumentSeriesCreditNote DocumentSeries = "CN"  // Issued when an admin credits a booking
+)
+
+// indiaStandardTime is the zone fiscal years are reckoned in
+var indiaStandardTime = time.FixedZone("IST", 5*60*60+30*60)
+
+// FinancialDocument is a numbered invoice, receipt or credit note. Documents are never changed once issued.
+type FinancialDocument struct {
+	ID           uuid.UUID      `json:"id"`
+	Series       DocumentSeries `json:"series"`
+	FiscalYear   string         `json:"fiscal_year"` // e.g. 2024-25
+	Sequence     int64          `json:"sequence"`    // Position in the series within the fiscal year, from 1
+	Number       string         `json:"number"`      // e.g. INV/2024-25/000042
+	BookingID    uuid.UUID      `json:"booking_id"`
+	PaymentID    *uuid.UUID     `json:"payment_id,omitempty"`    // Set on receipts
+	AdjustmentID *uuid.UUID     `json:"adjustment_id,omitempty"` // Set on credit notes
+	Amount       float64        `json:"amount"`                  // INR
+	IssuedAt     time.Time      `json:"issued_at"`
+}
+
+// FiscalYear returns the Indian fiscal year (April to March) a time falls in, e.g. 2024-25
+func FiscalYear(t time.Time) string {
+	t = t.In(indiaStandardTime)
+	start := t.Year()
+	if t.Month() < time.April {
+		start--
+	}
+	return fmt.Sprintf("%d-%02d", start, (start+1)%100)
+}
+
+// DocumentNumber formats the number printed on a document, e.g. RCP/2024-25/000042
+func DocumentNumber(series DocumentSeries, fiscalYear string, sequence int64) string {
+	return fmt.Sprintf("%s/%s/%06d", series, fiscalYear, sequence)
+}
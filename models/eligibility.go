@@ -0,0 +1,124 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VerifiedKYC is the identity data of a renter an admin checked against their documents
+type VerifiedKYC struct {
+	UserID          uuid.UUID `json:"user_id"`
+	DateOfBirth     time.Time `json:"date_of_birth"`     // As a date
+	LicenseIssuedOn time.Time `json:"license_issued_on"` // Date the driving licence was first issued
+	VerifiedBy      uuid.UUID `json:"verified_by"`       // Admin who checked the documents
+	VerifiedAt      time.Time `json:"verified_at"`
+}
+
+// KYCRequest is the payload an admin sends after checking a renter's documents. Dates are
+// YYYY-MM-DD.
+type KYCRequest struct {
+	DateOfBirth     string `json:"date_of_birth"`
+	LicenseIssuedOn string `json:"license_issued_on"`
+}
+
+// ParseKYCRequest validates a KYCRequest and returns its dates
+func ParseKYCRequest(req KYCRequest, now time.Time) (dateOfBirth, licenseIssuedOn time.Time, err error) {
+	dateOfBirth, err = time.Parse("2006-01-02", req.DateOfBirth)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("date_of_birth must be a YYYY-MM-DD date")
+	}
+	licenseIssuedOn, err = time.Parse("2006-01-02", req.LicenseIssuedOn)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("license_issued_on must be a YYYY-MM-DD date")
+	}
+	if !dateOfBirth.Before(now) || dateOfBirth.Before(now.AddDate(-120, 0, 0)) {
+		return time.Time{}, time.Time{}, errors.New("date_of_birth must be in the last 120 years")
+	}
+	if licenseIssuedOn.After(now) || licenseIssuedOn.Before(dateOfBirth) {
+		return time.Time{}, time.Time{}, errors.New("license_issued_on must be after date_of_birth and not in the future")
+	}
+	return dateOfBirth, licenseIssuedOn, nil
+}
+
+// CarEligibility is an owner's rules on who may rent a car. Zero means no restriction.
+type CarEligibility struct {
+	CarID           uuid.UUID `json:"car_id"`
+	MinRenterAge    int       `json:"min_renter_age"`    // Years of age at the start of the rental
+	MinLicenseYears int       `json:"min_license_years"` // Years the licence has been held at the start of the rental
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// CarEligibilityRequest is the payload an owner sends to set a car's eligibility rules
+type CarEligibilityRequest struct {
+	MinRenterAge    int `json:"min_renter_age"`
+	MinLicenseYears int `json:"min_license_years"`
+}
+
+// ValidateCarEligibilityRequest validates a CarEligibilityRequest. Returns nil when valid, otherwise an error.
+func ValidateCarEligibilityRequest(req CarEligibilityRequest) error {
+	if req.MinRenterAge != 0 && (req.MinRenterAge < 18 || req.MinRenterAge > 99) {
+		return errors.New("min_renter_age must be 0 or between 18 and 99")
+	}
+	if req.MinLicenseYears < 0 || req.MinLicenseYears > 50 {
+		return errors.New("min_license_years must be between 0 and 50")
+	}
+	return nil
+}
+
+// Restricts reports whether the rules limit who may rent the car
+func (e CarEligibility) Restricts() bool {
+	return e.MinRenterAge > 0 || e.MinLicenseYears > 0
+}
+
+// EligibilityCode tells clients which rule kept a renter from booking a car
+type EligibilityCode string
+
+const (
+	EligibilityKYCRequired    EligibilityCode = "kyc_not_verified"     // The car has rules and the renter's documents were not checked
+	EligibilityRenterTooYoung EligibilityCode = "renter_under_min_age" // The renter is younger than the car's minimum age
+	EligibilityLicenseTooNew  EligibilityCode = "license_too_recent"   // The renter's licence was issued too recently
+)
+
+// EligibilityError is returned when a renter does not meet a car's eligibility rules
+type EligibilityError struct {
+	Code    EligibilityCode `json:"code"`
+	Message string          `json:"message"`
+}
+
+func (e *EligibilityError) Error() string {
+	return e.Message
+}
+
+// CheckEligibility checks a renter's verified KYC data against a car's rules on the day the
+// rental starts. kyc is nil when the renter has none.
+func (e CarEligibility) CheckEligibility(kyc *VerifiedKYC, start time.Time) error {
+	if !e.Restricts() {
+		return nil
+	}
+	if kyc == nil {
+		return &EligibilityError{Code: EligibilityKYCRequired,
+			Message: "this car requires a verified date of birth and driving licence, please complete identity verification"}
+	}
+	if e.MinRenterAge > 0 && yearsBetween(kyc.DateOfBirth, start) < e.MinRenterAge {
+		return &EligibilityError{Code: EligibilityRenterTooYoung,
+			Message: fmt.Sprintf("renters of this car must be at least %d years old when the rental starts", e.MinRenterAge)}
+	}
+	if e.MinLicenseYears > 0 && yearsBetween(kyc.LicenseIssuedOn, start) < e.MinLicenseYears {
+		return &EligibilityError{Code: EligibilityLicenseTooNew,
+			Message: fmt.Sprintf("renters of this car must have held a driving licence for at least %d years when the rental starts", e.MinLicenseYears)}
+	}
+	return nil
+}
+
+// yearsBetween returns the whole years from the calendar date of since to that of at, as an
+// age is counted
+func yearsBetween(since, at time.Time) int {
+	years := at.Year() - since.Year()
+	if at.Month() < since.Month() || (at.Month() == since.Month() && at.Day() < since.Day()) {
+		years--
+	}
+	return years
+}
@@ -0,0 +1,22 @@
+package models
+
+// EmailRejectedCode tells clients why a sign-up e-mail address was refused, so they can ask for
+// a different one
+type EmailRejectedCode string
+
+const (
+	EmailDisposable EmailRejectedCode = "email_disposable"   // The domain hands out throwaway inboxes
+	EmailNoMailHost EmailRejectedCode = "email_domain_no_mx" // The domain does not exist or accepts no mail
+)
+
+// EmailRejectedError is returned when registration refuses an e-mail address that is well formed
+// but cannot be used to reach the user
+type EmailRejectedError struct {
+	Code    EmailRejectedCode `json:"code"`
+	Message string            `json:"message"`
+	Domain  string            `json:"domain"`
+}
+
+func (e *EmailRejectedError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Template keys of the e-mails the application sends from templates
+const (
+	EmailTemplateBookingConfirmed = "booking_confirmed" // Sent to the customer when their booking is confirmed
+	EmailTemplateTripOverdue      = "trip_overdue"      // Sent to the customer and owner when a car is not back by the end date
+	EmailTemplateReturnCharges    = "return_charges"    // Sent to the customer when check-in charges late return or refuel fees
+	EmailTemplateBookingExpiring  = "booking_expiring"  // Sent to the customer before an unpaid pending booking is cancelled
+	EmailTemplateHandBackReminder = "handback_reminder" // Sent to the customer before a trip is due back, with an extension offer when the car is free
+)
+
+// EmailTemplate is one version of the subject and body of an e-mail. Subject and body are Go
+// text templates whose variables are referenced as {{.variable_name}}. Saving a template adds a
+// new version; the highest version of a key is the one that is sent.
+type EmailTemplate struct {
+	ID        uuid.UUID  `json:"id"`
+	Key       string     `json:"key"`
+	Version   int        `json:"version"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"` // Admin who saved this version
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// EmailTemplateRequest is the payload to create a template or save a new version of it.
+// Key is only read on create; updates take the key from the URL.
+type EmailTemplateRequest struct {
+	Key     string `json:"key"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// EmailTemplatePreviewRequest renders a template with sample variables. Subject and body
+// override the stored template so unsaved drafts can be previewed.
+type EmailTemplatePreviewRequest struct {
+	Subject *string                `json:"subject,omitempty"`
+	Body    *string                `json:"body,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty"` // Defaults to the key's sample variables
+}
+
+// RenderedEmail is a template filled in with variables
+type RenderedEmail struct {
+	Key     string `json:"key"`
+	Version int    `json:"version"` // 0 when the built-in default or an unsaved draft was rendered
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// DefaultEmailTemplate is the copy an e-mail is sent with until an admin saves a template for its key
+type DefaultEmailTemplate struct {
+	Subject    string
+	Body       string
+	SampleData map[string]interface{} // Variables the application passes, with example values for previews
+}
+
+// DefaultEmailTemplates holds the built-in copy of every templated e-mail
+var DefaultEmailTemplates = map[string]DefaultEmailTemplate{
+	EmailTemplateBookingConfirmed: {
+		Subject: "Your booking of {{.car_name}} is confirmed",
+		Body: "Your booking of {{.car_name}} from {{.start_date}} to {{.end_date}} is confirmed.\n\n" +
+			"Total: ₹{{.total_amount}}\nBooking reference: {{.booking_id}}",
+		SampleData: map[string]interface{}{
+			"user_name":    "Asha",
+			"car_name":     "Toyota Camry",
+			"start_date":   "1 Mar 2024",
+			"end_date":     "4 Mar 2024",
+			"total_amount": "7500.00",
+			"booking_id":   "00000000-0000-0000-0000-000000000000",
+		},
+	},
+	EmailTemplateTripOverdue: {
+		Subject: "{{.car_name}} was due back on {{.end_date}}",
+		Body: "The rental of {{.car_name}} ended on {{.end_date}}, but the car has not been checked in yet.\n\n" +
+			"Please return the car or agree on an extension as soon as possible.\nBooking reference: {{.booking_id}}",
+		SampleData: map[string]interface{}{
+			"user_name":  "Asha",
+			"car_name":   "Toyota Camry",
+			"end_date":   "4 Mar 2024 10:00",
+			"booking_id": "00000000-0000-0000-0000-000000000000",
+		},
+	},
+	EmailTemplateReturnCharges: {
+		Subject: "Return charges for {{.car_name}}",
+		Body: "{{.car_name}} was due back on {{.end_date}} and was returned on {{.returned_at}}.\n\n" +
+			"The following charges have been added to your booking:\n" +
+			"Late return fee: ₹{{.late_fee}}\n" +
+			"Refuel fee ({{.refuel_liters}} L at ₹{{.price_per_liter}}/L): ₹{{.refuel_fee}}\n" +
+			"Total due: ₹{{.total_due}}\n\n" +
+			"Please pay it from your payments page.\nBooking reference: {{.booking_id}}",
+		SampleData: map[string]interface{}{
+			"user_name":       "Asha",
+			"car_name":        "Toyota Camry",
+			"end_date":        "4 Mar 2024 10:00",
+			"returned_at":     "4 Mar 2024 14:30",
+			"late_fee":        "781.25",
+			"refuel_liters":   "12.5",
+			"price_per_liter": "105.00",
+			"refuel_fee":      "1312.50",
+			"total_due":       "2093.75",
+			"booking_id":      "00000000-0000-0000-0000-000000000000",
+		},
+	},
+	EmailTemplateBookingExpiring: {
+		Subject: "Pay for {{.car_name}} by {{.expires_at}} to keep your booking",
+		Body: "Your booking of {{.car_name}} from {{.start_date}} to {{.end_date}} has not been paid yet.\n\n" +
+			"Pay ₹{{.total_amount}} by {{.expires_at}}, or the booking will be cancelled and the dates released.\n" +
+			"Booking reference: {{.booking_id}}",
+		SampleData: map[string]interface{}{
+			"user_name":    "Asha",
+			"car_name":     "Toyota Camry",
+			"start_date":   "1 Mar 2024",
+			"end_date":     "4 Mar 2024",
+			"total_amount": "7500.00",
+			"expires_at":   "28 Feb 2024 18:00",
+			"booking_id":   "00000000-0000-0000-0000-000000000000",
+		},
+	},
+	EmailTemplateHandBackReminder: {
+		Subject: "{{.car_name}} is due back on {{.end_date}}",
+		Body: "Your rental of {{.car_name}} ends on {{.end_date}}. Please return the car on time to avoid a late return fee.\n\n" +
+			"{{if .extension_price}}Need it longer? The car is free until {{.extension_end_date}}, " +
+			"and you can extend your trip for ₹{{.extension_price}} from your booking.\n\n{{end}}" +
+			"Booking reference: {{.booking_id}}",
+		SampleData: map[string]interface{}{
+			"user_name":          "Asha",
+			"car_name":           "Toyota Camry",
+			"end_date":           "4 Mar 2024 10:00",
+			"extension_end_date": "5 Mar 2024 10:00",
+			"extension_price":    "2500.00",
+			"booking_id":         "00000000-0000-0000-0000-000000000000",
+		},
+	},
+}
+
+var emailTemplateKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// ValidateEmailTemplateKey validates a template key. Returns nil when valid, otherwise an error.
+func ValidateEmailTemplateKey(key string) error {
+	if !emailTemplateKeyPattern.MatchString(key) {
+		return errors.New("key must be 2-50 lowercase letters, digits and underscores, starting with a letter")
+	}
+	return nil
+}
+
+// ValidateEmailTemplateRequest validates the subject and body of an EmailTemplateRequest.
+// Returns nil when valid, otherwise an error.
+func ValidateEmailTemplateRequest(req EmailTemplateRequest) error {
+	subject := strings.TrimSpace(req.Subject)
+	if subject == "" || len(subject) > 255 {
+		return errors.New("subject is required and must be at most 255 characters")
+	}
+	if strings.ContainsAny(req.Subject, "\r\n") {
+		return errors.New("subject must be a single line")
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		return errors.New("body is required")
+	}
+	if _, err := ParseEmailTemplate("subject", req.Subject); err != nil {
+		return err
+	}
+	if _, err := ParseEmailTemplate("body", req.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ParseEmailTemplate parses a subject or body. Variables missing from the data are reported
+// as errors when the template is executed rather than rendered as "<no value>".
+func ParseEmailTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.New("invalid " + name + " template: " + err.Error())
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Favorite is a car a user saved to their wishlist
+type Favorite struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	CarID     uuid.UUID    `json:"car_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	Car       *FavoriteCar `json:"car,omitempty"` // The car as listed now; included when listing favorites
+}
+
+// FavoriteCar is the current listing of a saved car, enough to show it in the wishlist. Status
+// and availability tell the user when a saved car can no longer be booked.
+type FavoriteCar struct {
+	Name        string    `json:"name"`
+	Brand       string    `json:"brand"`
+	Model       string    `json:"model"`
+	Year        int       `json:"year"`
+	Price       float64   `json:"rental_price"`
+	Images      []string  `json:"images"`
+	Slug        string    `json:"slug"`
+	Status      CarStatus `json:"status"`
+	IsAvailable bool      `json:"is_available"`
+}
+
+// PageCursor returns the pagination cursor pointing at this favorite
+func (f Favorite) PageCursor() Cursor {
+	return Cursor{CreatedAt: f.CreatedAt, ID: f.ID}
+}
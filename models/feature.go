@@ -0,0 +1,145 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Feature is an entry of the curated features taxonomy. Cars may only list canonical keys;
+// aliases such as "ac" for air_conditioning are rewritten to their key when a car is saved.
+// Admins extend the taxonomy as new equipment comes up.
+type Feature struct {
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`    // Display name, e.g. "Air conditioning"
+	Aliases   []string  `json:"aliases"` // Other keys owners send for the feature
+	CarCount  int       `json:"car_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FeatureRequest is the payload to add a feature to the taxonomy or replace its name and aliases
+type FeatureRequest struct {
+	Key     string   `json:"key"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+// CarFeatures is the features map of one car
+type CarFeatures struct {
+	CarID    string
+	Features map[string]interface{}
+}
+
+var featureKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// FeatureKey normalizes a submitted feature key: trimmed, lowercased, and with spaces, hyphens
+// and slashes turned into underscores, so "Air Conditioning" becomes air_conditioning
+func FeatureKey(raw string) string {
+	key := strings.ToLower(strings.TrimSpace(raw))
+	return strings.NewReplacer(" ", "_", "-", "_", "/", "_").Replace(key)
+}
+
+// ValidateFeatureRequest validates a FeatureRequest and normalizes its key and aliases.
+// Returns nil when valid, otherwise an error.
+func ValidateFeatureRequest(req *FeatureRequest) error {
+	req.Key = FeatureKey(req.Key)
+	if !featureKeyPattern.MatchString(req.Key) {
+		return errors.New("key must be 2-50 lowercase letters, digits and underscores, starting with a letter")
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > 100 {
+		return errors.New("name is required and must be at most 100 characters")
+	}
+
+	seen := map[string]bool{req.Key: true}
+	aliases := make([]string, 0, len(req.Aliases))
+	for _, alias := range req.Aliases {
+		alias = FeatureKey(alias)
+		if alias == "" || seen[alias] {
+			continue
+		}
+		if len(alias) > 50 {
+			return errors.New("aliases must be at most 50 characters")
+		}
+		seen[alias] = true
+		aliases = append(aliases, alias)
+	}
+	req.Aliases = aliases
+
+	return nil
+}
+
+// FeatureTaxonomy maps every canonical key and alias of the taxonomy to its canonical key
+type FeatureTaxonomy map[string]string
+
+// NewFeatureTaxonomy indexes the features of the taxonomy by key and alias
+func NewFeatureTaxonomy(features []Feature) FeatureTaxonomy {
+	taxonomy := FeatureTaxonomy{}
+	for _, feature := range features {
+		taxonomy[feature.Key] = feature.Key
+		for _, alias := range feature.Aliases {
+			taxonomy[alias] = feature.Key
+		}
+	}
+	return taxonomy
+}
+
+// Canonical returns the canonical key of a submitted key or alias
+func (t FeatureTaxonomy) Canonical(raw string) (string, bool) {
+	key, ok := t[FeatureKey(raw)]
+	return key, ok
+}
+
+// NormalizeFeatures validates a car's features against the taxonomy and rewrites aliases to
+// their canonical keys. Values must be true or false; a feature sent under several aliases
+// is offered when any of them is true.
+func (t FeatureTaxonomy) NormalizeFeatures(features map[string]interface{}) (map[string]interface{}, error) {
+	if features == nil {
+		return nil, nil
+	}
+
+	normalized := make(map[string]interface{}, len(features))
+	for raw, value := range features {
+		key, ok := t.Canonical(raw)
+		if !ok {
+			return nil, fmt.Errorf("unknown feature %s, see GET /features for the supported keys", raw)
+		}
+		offered, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("feature %s must be true or false", raw)
+		}
+		if previous, ok := normalized[key].(bool); ok {
+			offered = offered || previous
+		}
+		normalized[key] = offered
+	}
+
+	return normalized, nil
+}
+
+// RenameAliases rewrites the aliases among a car's stored features to their canonical keys,
+// leaving keys outside the taxonomy as they are. It reports whether anything changed.
+func (t FeatureTaxonomy) RenameAliases(features map[string]interface{}) (map[string]interface{}, bool) {
+	renamed := make(map[string]interface{}, len(features))
+	changed := false
+	for raw, value := range features {
+		key, ok := t.Canonical(raw)
+		if !ok {
+			key = raw
+		}
+		if key != raw {
+			changed = true
+		}
+		if existing, ok := renamed[key]; ok {
+			// Keep true over false when two aliases of a feature disagree
+			if offered, _ := existing.(bool); offered {
+				continue
+			}
+		}
+		renamed[key] = value
+	}
+	return renamed, changed
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeaturedStatus is the payment state of a featured placement
+type FeaturedStatus string
+
+const (
+	FeaturedStatusPending FeaturedStatus = "pending" // Razorpay order created, waiting for the owner to pay
+	FeaturedStatusPaid    FeaturedStatus = "paid"    // Paid; the car is boosted while the period runs
+	FeaturedStatusFailed  FeaturedStatus = "failed"  // The payment could not be verified
+)
+
+// maxFeaturedDays bounds how long one featured placement may run
+const maxFeaturedDays = 90
+
+// FeaturedListing is a period an owner paid to have a car boosted in ranked listings, with the
+// impressions and clicks it collected
+type FeaturedListing struct {
+	ID                uuid.UUID      `json:"id"`
+	CarID             uuid.UUID      `json:"car_id"`
+	OwnerID           uuid.UUID      `json:"owner_id"`
+	StartDate         time.Time      `json:"start_date"`
+	EndDate           time.Time      `json:"end_date"`
+	Amount            float64        `json:"amount"` // Price in INR, charged per started day
+	Status            FeaturedStatus `json:"status"`
+	RazorpayOrderID   *string        `json:"razorpay_order_id,omitempty"`
+	RazorpayPaymentID *string        `json:"razorpay_payment_id,omitempty"`
+	Impressions       int            `json:"impressions"` // Times the car was shown in a ranked listing while featured
+	Clicks            int            `json:"clicks"`      // Times its page was opened by someone other than the owner while featured
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+// Active reports whether the placement boosts the car at the given time
+func (f FeaturedListing) Active(at time.Time) bool {
+	return f.Status == FeaturedStatusPaid && !at.Before(f.StartDate) && at.Before(f.EndDate)
+}
+
+// FeaturedRequest is the payload an owner sends to buy featured placement for a car
+type FeaturedRequest struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// ValidateFeaturedRequest validates a FeaturedRequest. Returns nil when valid, otherwise an error.
+func ValidateFeaturedRequest(req FeaturedRequest, now time.Time) error {
+	if req.StartDate.IsZero() || req.EndDate.IsZero() {
+		return errors.New("start_date and end_date are required")
+	}
+	if !req.EndDate.After(req.StartDate) {
+		return errors.New("end_date must be after start_date")
+	}
+	if req.StartDate.Before(now.Add(-time.Hour)) {
+		return errors.New("start_date cannot be in the past")
+	}
+	if req.EndDate.Sub(req.StartDate) > maxFeaturedDays*24*time.Hour {
+		return errors.New("featured placement must run for at most 90 days")
+	}
+	return nil
+}
+
+// FeaturedDays returns the number of days a featured period is charged for; a started day
+// counts in full
+func FeaturedDays(start, end time.Time) int {
+	return int(math.Ceil(end.Sub(start).Hours() / 24))
+}
+
+// FeaturedPurchase is a pending featured placement with the Razorpay order the owner pays it
+// through; confirm it with the verification details Razorpay checkout returns
+type FeaturedPurchase struct {
+	Featured     FeaturedListing        `json:"featured"`
+	PaymentOrder *RazorpayOrderResponse `json:"payment_order"`
+}
@@ -0,0 +1,213 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FleetPricingRuleType identifies how a pricing rule adjusts the rental amount
+type FleetPricingRuleType string
+
+const (
+	FleetPricingRuleWeekend    FleetPricingRuleType = "weekend"     // Adjusts the daily rate of Saturdays and Sundays
+	FleetPricingRuleLongRental FleetPricingRuleType = "long_rental" // Adjusts the whole rental amount from MinDays on
+)
+
+// Fleet is an owner's group of cars sharing pricing rules, add-ons and blackout dates. Cars
+// have no settings of their own; a car in a fleet inherits the fleet's settings when it is
+// quoted, so changing the fleet changes the price of every car in it.
+type Fleet struct {
+	ID           uuid.UUID          `json:"id"`
+	OwnerID      uuid.UUID          `json:"owner_id"`
+	Name         string             `json:"name"`
+	PricingRules []FleetPricingRule `json:"pricing_rules"`
+	AddOns       []FleetAddOn       `json:"add_ons"`
+	Blackouts    []FleetBlackout    `json:"blackouts"`
+	CarIDs       []uuid.UUID        `json:"car_ids"` // Members of the fleet
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+// FleetPricingRule adjusts the rental amount by a percentage; negative percentages are discounts
+type FleetPricingRule struct {
+	Type    FleetPricingRuleType `json:"type"`
+	Percent float64              `json:"percent"`            // e.g. 20 for +20%, -10 for a 10% discount
+	MinDays int                  `json:"min_days,omitempty"` // Rental length from which a long_rental rule applies
+}
+
+// FleetAddOn is an optional extra renters can book with any car of the fleet
+type FleetAddOn struct {
+	Code   string  `json:"code"` // Identifier renters send in a booking's add_ons, e.g. child_seat
+	Name   string  `json:"name"`
+	Price  float64 `json:"price"`
+	PerDay bool    `json:"per_day"` // Charged per rental day instead of once
+}
+
+// FleetBlackout is a period in which no car of the fleet can be booked
+type FleetBlackout struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// FleetRequest is the payload to create a fleet or replace its settings
+type FleetRequest struct {
+	Name         string             `json:"name"`
+	PricingRules []FleetPricingRule `json:"pricing_rules"`
+	AddOns       []FleetAddOn       `json:"add_ons"`
+	Blackouts    []FleetBlackout    `json:"blackouts"`
+}
+
+// FleetMembershipRequest is the payload to add a car to a fleet
+type FleetMembershipRequest struct {
+	CarID uuid.UUID `json:"car_id"`
+}
+
+// BookingAddOn is an add-on chosen for a booking, priced for the rental's length
+type BookingAddOn struct {
+	Code   string  `json:"code"`
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+var fleetAddOnCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,29}$`)
+
+// ValidateFleetRequest validates a FleetRequest. Returns nil when valid, otherwise an error.
+func ValidateFleetRequest(req FleetRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return errors.New("name is required and must be at most 100 characters")
+	}
+
+	weekendRules := 0
+	longRentalDays := map[int]bool{}
+	for _, rule := range req.PricingRules {
+		if rule.Percent == 0 || rule.Percent < -90 || rule.Percent > 200 {
+			return errors.New("pricing rule percent must be between -90 and 200 and not 0")
+		}
+		switch rule.Type {
+		case FleetPricingRuleWeekend:
+			if rule.MinDays != 0 {
+				return errors.New("min_days must only be set on long_rental rules")
+			}
+			if weekendRules++; weekendRules > 1 {
+				return errors.New("a fleet must have at most one weekend rule")
+			}
+		case FleetPricingRuleLongRental:
+			if rule.MinDays < 2 {
+				return errors.New("long_rental rules must have min_days of at least 2")
+			}
+			if longRentalDays[rule.MinDays] {
+				return fmt.Errorf("a fleet must have at most one long_rental rule for %d days", rule.MinDays)
+			}
+			longRentalDays[rule.MinDays] = true
+		default:
+			return errors.New("pricing rule type must be weekend or long_rental")
+		}
+	}
+
+	codes := map[string]bool{}
+	for _, addOn := range req.AddOns {
+		if !fleetAddOnCodePattern.MatchString(addOn.Code) {
+			return errors.New("add-on code must be 2-30 lowercase letters, digits and underscores, starting with a letter")
+		}
+		if codes[addOn.Code] {
+			return fmt.Errorf("add-on code %s must be unique", addOn.Code)
+		}
+		codes[addOn.Code] = true
+		if strings.TrimSpace(addOn.Name) == "" {
+			return errors.New("add-on name is required")
+		}
+		if addOn.Price <= 0 {
+			return errors.New("add-on price must be greater than 0")
+		}
+	}
+
+	for _, blackout := range req.Blackouts {
+		if !blackout.EndDate.After(blackout.StartDate) {
+			return errors.New("blackout end_date must be after start_date")
+		}
+	}
+
+	return nil
+}
+
+// RentalAmount prices a rental of the given number of days at the daily rate with the fleet's
+// pricing rules applied. The weekend rule adjusts each Saturday and Sunday counted from the
+// start date; the long_rental rule with the highest min_days reached then adjusts the total.
+func (f Fleet) RentalAmount(dailyRate float64, start time.Time, days int) float64 {
+	var weekend *FleetPricingRule
+	var longRental *FleetPricingRule
+	for i, rule := range f.PricingRules {
+		switch rule.Type {
+		case FleetPricingRuleWeekend:
+			weekend = &f.PricingRules[i]
+		case FleetPricingRuleLongRental:
+			if days >= rule.MinDays && (longRental == nil || rule.MinDays > longRental.MinDays) {
+				longRental = &f.PricingRules[i]
+			}
+		}
+	}
+
+	amount := 0.0
+	for day := 0; day < days; day++ {
+		rate := dailyRate
+		weekday := start.AddDate(0, 0, day).Weekday()
+		if weekend != nil && (weekday == time.Saturday || weekday == time.Sunday) {
+			rate *= 1 + weekend.Percent/100
+		}
+		amount += rate
+	}
+	if longRental != nil {
+		amount *= 1 + longRental.Percent/100
+	}
+
+	return math.Round(amount*100) / 100
+}
+
+// QuoteAddOns prices the add-ons chosen by their codes for a rental of the given number of days
+func (f Fleet) QuoteAddOns(codes []string, days int) ([]BookingAddOn, float64, error) {
+	offered := make(map[string]FleetAddOn, len(f.AddOns))
+	for _, addOn := range f.AddOns {
+		offered[addOn.Code] = addOn
+	}
+
+	chosen := make([]BookingAddOn, 0, len(codes))
+	seen := map[string]bool{}
+	total := 0.0
+	for _, code := range codes {
+		addOn, ok := offered[code]
+		if !ok {
+			return nil, 0, fmt.Errorf("add-on %s is not offered for this car", code)
+		}
+		if seen[code] {
+			return nil, 0, fmt.Errorf("add-on %s must only be chosen once", code)
+		}
+		seen[code] = true
+
+		amount := addOn.Price
+		if addOn.PerDay {
+			amount *= float64(days)
+		}
+		chosen = append(chosen, BookingAddOn{Code: addOn.Code, Name: addOn.Name, Amount: amount})
+		total += amount
+	}
+
+	return chosen, total, nil
+}
+
+// BlackoutDuring returns the first blackout overlapping the period, or nil if there is none
+func (f Fleet) BlackoutDuring(start, end time.Time) *FleetBlackout {
+	for i, blackout := range f.Blackouts {
+		if start.Before(blackout.EndDate) && end.After(blackout.StartDate) {
+			return &f.Blackouts[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FuelPolicy is how a car's fuel is paid for
+type FuelPolicy string
+
+const (
+	FuelPolicyFullToFull FuelPolicy = "full_to_full" // Handed over full and returned full; missing fuel is charged at check-in
+	FuelPolicyPrepaid    FuelPolicy = "prepaid"      // A full tank is paid with the booking; the car may come back at any level
+)
+
+// CarFuelPolicy is an owner's fuel settings for a car. Cars without settings are not charged
+// for fuel.
+type CarFuelPolicy struct {
+	CarID              uuid.UUID  `json:"car_id"`
+	Policy             FuelPolicy `json:"policy"`
+	TankCapacityLiters float64    `json:"tank_capacity_liters"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// CarFuelPolicyRequest is the payload an owner sends to configure a car's fuel policy
+type CarFuelPolicyRequest struct {
+	Policy             FuelPolicy `json:"policy"`
+	TankCapacityLiters float64    `json:"tank_capacity_liters"`
+}
+
+// FuelPricing prices fuel at a flat rate per liter
+type FuelPricing struct {
+	PricePerLiter float64 // INR
+}
+
+// PrepaidFuelFee returns the fee charged with the booking, a full tank for prepaid cars and
+// nothing otherwise
+func (p FuelPricing) PrepaidFuelFee(policy CarFuelPolicy) float64 {
+	if policy.Policy != FuelPolicyPrepaid {
+		return 0
+	}
+	return math.Round(policy.TankCapacityLiters*p.PricePerLiter*100) / 100
+}
+
+// RefuelFee returns the fee for the fuel missing at check-in of a full-to-full car. Levels are
+// percentages of the tank as recorded at checkout and check-in; a fuller tank is not refunded.
+func (p FuelPricing) RefuelFee(policy CarFuelPolicy, checkoutLevel, checkinLevel float64) (liters, fee float64) {
+	if policy.Policy != FuelPolicyFullToFull || checkinLevel >= checkoutLevel {
+		return 0, 0
+	}
+	liters = math.Round((checkoutLevel-checkinLevel)/100*policy.TankCapacityLiters*10) / 10
+	return liters, math.Round(liters*p.PricePerLiter*100) / 100
+}
+
+// ValidateCarFuelPolicyRequest validates a CarFuelPolicyRequest. Returns nil when valid, otherwise an error.
+func ValidateCarFuelPolicyRequest(req CarFuelPolicyRequest) error {
+	if req.Policy != FuelPolicyFullToFull && req.Policy != FuelPolicyPrepaid {
+		return errors.New("policy must be full_to_full or prepaid")
+	}
+	if req.TankCapacityLiters <= 0 || req.TankCapacityLiters > 200 {
+		return errors.New("tank_capacity_liters must be greater than 0 and at most 200")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// earthRadiusKm is the mean Earth radius used for great-circle distances
+const earthRadiusKm = 6371.0
+
+// Geofence is a circular region a rented car is allowed to move in. A geofence without a
+// booking applies to every rental of the car; one with a booking applies to that rental only.
+type Geofence struct {
+	ID              uuid.UUID  `json:"id"`
+	CarID           uuid.UUID  `json:"car_id"`
+	BookingID       *uuid.UUID `json:"booking_id,omitempty"`
+	Name            string     `json:"name"`
+	CenterLatitude  float64    `json:"center_latitude"`
+	CenterLongitude float64    `json:"center_longitude"`
+	RadiusKm        float64    `json:"radius_km"`
+	CreatedBy       uuid.UUID  `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// GeofenceRequest is the payload to define a geofence on a car
+type GeofenceRequest struct {
+	BookingID       *uuid.UUID `json:"booking_id,omitempty"`
+	Name            string     `json:"name"`
+	CenterLatitude  float64    `json:"center_latitude"`
+	CenterLongitude float64    `json:"center_longitude"`
+	RadiusKm        float64    `json:"radius_km"`
+}
+
+// GeofenceBreach records a car leaving a geofence during a rental. It stays open until a
+// later reading shows the car back inside the region.
+type GeofenceBreach struct {
+	ID         uuid.UUID  `json:"id"`
+	GeofenceID uuid.UUID  `json:"geofence_id"`
+	BookingID  uuid.UUID  `json:"booking_id"`
+	CarID      uuid.UUID  `json:"car_id"`
+	Latitude   float64    `json:"latitude"`
+	Longitude  float64    `json:"longitude"`
+	DistanceKm float64    `json:"distance_km"` // Distance from the geofence centre when detected
+	DetectedAt time.Time  `json:"detected_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// GeofenceCheck pairs a geofence with an active rental it applies to and the car's latest position
+type GeofenceCheck struct {
+	Geofence     Geofence
+	BookingID    uuid.UUID
+	OwnerID      uuid.UUID
+	Latitude     float64
+	Longitude    float64
+	RecordedAt   time.Time
+	OpenBreachID *uuid.UUID // Set while the car is already known to be outside
+}
+
+// Contains reports whether a position lies inside the geofence, and its distance from the centre
+func (g Geofence) Contains(latitude, longitude float64) (bool, float64) {
+	distance := DistanceKm(g.CenterLatitude, g.CenterLongitude, latitude, longitude)
+	return distance <= g.RadiusKm, distance
+}
+
+// DistanceKm returns the great-circle (haversine) distance between two coordinates
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// ValidateGeofenceRequest validates a GeofenceRequest. Returns nil when valid, otherwise an error.
+func ValidateGeofenceRequest(req GeofenceRequest) error {
+	if len(req.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	if req.CenterLatitude < -90 || req.CenterLatitude > 90 {
+		return errors.New("center_latitude must be between -90 and 90")
+	}
+	if req.CenterLongitude < -180 || req.CenterLongitude > 180 {
+		return errors.New("center_longitude must be between -180 and 180")
+	}
+	if req.RadiusKm < 0.1 || req.RadiusKm > 2000 {
+		return errors.New("radius_km must be between 0.1 and 2000")
+	}
+	return nil
+}
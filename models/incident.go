@@ -0,0 +1,212 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/statemachine"
+	"github.com/google/uuid"
+)
+
+// IncidentKind is what happened to a car during a booking
+type IncidentKind string
+
+const (
+	IncidentKindAccident  IncidentKind = "accident"
+	IncidentKindDamage    IncidentKind = "damage" // Damage found without a collision, e.g. vandalism or hail
+	IncidentKindTheft     IncidentKind = "theft"
+	IncidentKindBreakdown IncidentKind = "breakdown"
+	IncidentKindOther     IncidentKind = "other"
+)
+
+// IncidentStatus is where an incident stands until it is resolved
+type IncidentStatus string
+
+const (
+	IncidentStatusReported    IncidentStatus = "reported"     // Reported by the customer or owner, not yet looked at
+	IncidentStatusUnderReview IncidentStatus = "under_review" // An admin is gathering the facts
+	IncidentStatusClaimFiled  IncidentStatus = "claim_filed"  // An insurance claim was filed; see insurance_claim_number
+	IncidentStatusResolved    IncidentStatus = "resolved"     // Settled, repaired or paid out
+	IncidentStatusClosed      IncidentStatus = "closed"       // Dismissed without further action
+)
+
+// IncidentStatusMachine declares the lifecycle of an incident. Only admins triage new reports,
+// taking them under review or dismissing them; the car's owner then files the claim and
+// resolves the incident with them.
+var IncidentStatusMachine = statemachine.New[IncidentStatus, Incident]("incident").
+	State(IncidentStatusReported, IncidentStatusUnderReview, IncidentStatusClosed).
+	State(IncidentStatusUnderReview, IncidentStatusClaimFiled, IncidentStatusResolved, IncidentStatusClosed).
+	State(IncidentStatusClaimFiled, IncidentStatusResolved, IncidentStatusClosed).
+	State(IncidentStatusResolved).
+	State(IncidentStatusClosed).
+	Guard(IncidentStatusReported, IncidentStatusUnderReview, requireAdminForTriage).
+	Guard(IncidentStatusReported, IncidentStatusClosed, requireAdminForTriage)
+
+// requireAdminForTriage reserves taking up and dismissing new incident reports for admins
+func requireAdminForTriage(_ context.Context, _ Incident, t statemachine.Transition[IncidentStatus]) error {
+	if !t.ByAdmin {
+		return errors.New("only admins can review or dismiss a new incident report")
+	}
+	return nil
+}
+
+// IncidentDocumentKind is the type of a document attached to an incident
+type IncidentDocumentKind string
+
+const (
+	IncidentDocumentInsurancePolicy IncidentDocumentKind = "insurance_policy" // Policy covering the car
+	IncidentDocumentInsuranceClaim  IncidentDocumentKind = "insurance_claim"  // Claim form or the insurer's acknowledgement
+	IncidentDocumentPoliceReport    IncidentDocumentKind = "police_report"    // FIR or police report copy
+	IncidentDocumentRepairEstimate  IncidentDocumentKind = "repair_estimate"  // Garage estimate or invoice
+	IncidentDocumentOther           IncidentDocumentKind = "other"
+)
+
+// maxIncidentPhotos bounds the photos attached to one report
+const maxIncidentPhotos = 10
+
+// Incident is an accident, theft or other mishap reported against an active booking
+type Incident struct {
+	ID                   uuid.UUID          `json:"id"`
+	BookingID            uuid.UUID          `json:"booking_id"`
+	CarID                uuid.UUID          `json:"car_id"`
+	CustomerID           uuid.UUID          `json:"customer_id"`
+	OwnerID              uuid.UUID          `json:"owner_id"`
+	ReportedBy           uuid.UUID          `json:"reported_by"` // The customer or owner who reported it
+	Kind                 IncidentKind       `json:"kind"`
+	Description          string             `json:"description"`
+	OccurredAt           time.Time          `json:"occurred_at"`
+	Location             string             `json:"location,omitempty"` // Where it happened, as described by the reporter
+	PoliceReportNumber   string             `json:"police_report_number,omitempty"`
+	PhotoURLs            []string           `json:"photo_urls"`
+	InsuranceClaimNumber string             `json:"insurance_claim_number,omitempty"`
+	Status               IncidentStatus     `json:"status"`
+	ResolutionNotes      string             `json:"resolution_notes,omitempty"`
+	ResolvedAt           *time.Time         `json:"resolved_at,omitempty"`
+	Documents            []IncidentDocument `json:"documents,omitempty"`
+	CreatedAt            time.Time          `json:"created_at"`
+	UpdatedAt            time.Time          `json:"updated_at"`
+}
+
+// IncidentDocument is an insurance or other document attached to an incident
+type IncidentDocument struct {
+	ID          uuid.UUID            `json:"id"`
+	IncidentID  uuid.UUID            `json:"incident_id"`
+	Kind        IncidentDocumentKind `json:"kind"`
+	Description string               `json:"description"`
+	DocumentURL string               `json:"document_url"`
+	AddedBy     uuid.UUID            `json:"added_by"`
+	CreatedAt   time.Time            `json:"created_at"`
+}
+
+// IncidentRequest is the payload a customer or owner sends to report an incident
+type IncidentRequest struct {
+	Kind               IncidentKind `json:"kind"`
+	Description        string       `json:"description"`
+	OccurredAt         time.Time    `json:"occurred_at"`
+	Location           string       `json:"location,omitempty"`
+	PoliceReportNumber string       `json:"police_report_number,omitempty"`
+	PhotoURLs          []string     `json:"photo_urls,omitempty"`
+}
+
+// IncidentDocumentRequest is the payload to attach a document to an incident
+type IncidentDocumentRequest struct {
+	Kind        IncidentDocumentKind `json:"kind"`
+	Description string               `json:"description"`
+	DocumentURL string               `json:"document_url"`
+}
+
+// IncidentStatusRequest is the payload to move an incident on
+type IncidentStatusRequest struct {
+	Status               IncidentStatus `json:"status"`
+	InsuranceClaimNumber string         `json:"insurance_claim_number,omitempty"` // Required to enter claim_filed
+	Notes                string         `json:"notes,omitempty"`                  // Replace the resolution notes when given
+}
+
+// IncidentFilter narrows the admin incident list
+type IncidentFilter struct {
+	Status IncidentStatus // Empty for every status
+}
+
+// ValidateIncidentRequest validates an IncidentRequest. Returns nil when valid, otherwise an error.
+// Whether the booking was active when the incident occurred is checked by the incident service.
+func ValidateIncidentRequest(req IncidentRequest) error {
+	switch req.Kind {
+	case IncidentKindAccident, IncidentKindDamage, IncidentKindTheft, IncidentKindBreakdown, IncidentKindOther:
+	default:
+		return errors.New("kind must be one of: accident, damage, theft, breakdown, other")
+	}
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return errors.New("description is required")
+	}
+	if len(description) > 4000 {
+		return errors.New("description must be at most 4000 characters")
+	}
+	if req.OccurredAt.IsZero() {
+		return errors.New("occurred_at is required")
+	}
+	if len(req.Location) > 500 {
+		return errors.New("location must be at most 500 characters")
+	}
+	if len(strings.TrimSpace(req.PoliceReportNumber)) > 100 {
+		return errors.New("police_report_number must be at most 100 characters")
+	}
+	if len(req.PhotoURLs) > maxIncidentPhotos {
+		return errors.New("photo_urls must hold at most 10 photos")
+	}
+	for _, photo := range req.PhotoURLs {
+		if !isHTTPURL(photo) {
+			return errors.New("photo_urls must be http or https URLs")
+		}
+	}
+	return nil
+}
+
+// ValidateIncidentDocumentRequest validates an IncidentDocumentRequest. Returns nil when valid, otherwise an error.
+func ValidateIncidentDocumentRequest(req IncidentDocumentRequest) error {
+	switch req.Kind {
+	case IncidentDocumentInsurancePolicy, IncidentDocumentInsuranceClaim, IncidentDocumentPoliceReport,
+		IncidentDocumentRepairEstimate, IncidentDocumentOther:
+	default:
+		return errors.New("kind must be one of: insurance_policy, insurance_claim, police_report, repair_estimate, other")
+	}
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return errors.New("description is required")
+	}
+	if len(description) > 2000 {
+		return errors.New("description must be at most 2000 characters")
+	}
+	if !isHTTPURL(req.DocumentURL) {
+		return errors.New("document_url must be an http or https URL")
+	}
+	return nil
+}
+
+// ValidateIncidentStatusRequest validates an IncidentStatusRequest. Returns nil when valid, otherwise an error.
+// Whether the move is allowed is checked against IncidentStatusMachine by the incident service.
+func ValidateIncidentStatusRequest(req IncidentStatusRequest) error {
+	if err := IncidentStatusMachine.CheckState(req.Status); err != nil {
+		return err
+	}
+	claimNumber := strings.TrimSpace(req.InsuranceClaimNumber)
+	if req.Status == IncidentStatusClaimFiled && claimNumber == "" {
+		return errors.New("insurance_claim_number is required to file a claim")
+	}
+	if len(claimNumber) > 100 {
+		return errors.New("insurance_claim_number must be at most 100 characters")
+	}
+	if len(req.Notes) > 2000 {
+		return errors.New("notes must be at most 2000 characters")
+	}
+	return nil
+}
+
+// isHTTPURL reports whether s is an absolute http or https URL
+func isHTTPURL(s string) bool {
+	parsed, err := url.Parse(s)
+	return err == nil && (parsed.Scheme == "https" || parsed.Scheme == "http") && parsed.Host != ""
+}
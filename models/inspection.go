@@ -0,0 +1,70 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InspectionKind distinguishes the two handovers of a rental
+type InspectionKind string
+
+const (
+	InspectionKindCheckout InspectionKind = "checkout" // Car handed to the customer
+	InspectionKindCheckin  InspectionKind = "checkin"  // Car returned by the customer
+)
+
+// ReadingSource records where an inspection reading came from
+type ReadingSource string
+
+const (
+	ReadingSourceManual    ReadingSource = "manual"    // Entered by the person doing the handover
+	ReadingSourceTelemetry ReadingSource = "telemetry" // Auto-filled from the car's latest telemetry snapshot
+)
+
+// BookingInspection records the car's odometer, fuel level and checklist answers at checkout or check-in
+type BookingInspection struct {
+	ID             uuid.UUID      `json:"id"`
+	BookingID      uuid.UUID      `json:"booking_id"`
+	Kind           InspectionKind `json:"kind"`
+	OdometerKm     *int           `json:"odometer_km,omitempty"`
+	OdometerSource *ReadingSource `json:"odometer_source,omitempty"`
+	FuelLevel      *float64       `json:"fuel_level,omitempty"` // Percentage of tank capacity, 0-100
+	FuelSource     *ReadingSource `json:"fuel_source,omitempty"`
+	Notes          string         `json:"notes,omitempty"`
+
+	// The checklist version answered, and the answers; both are absent when no checklist was
+	// saved yet at checkout
+	ChecklistVersion *int              `json:"checklist_version,omitempty"`
+	Checklist        []ChecklistAnswer `json:"checklist,omitempty"`
+
+	RecordedBy uuid.UUID `json:"recorded_by"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// InspectionRequest is the payload for a checkout or check-in. Omitted readings are
+// auto-filled from the car's latest telemetry snapshot when one is recent enough. Checklist
+// answers every item of the checklist version the handover uses.
+type InspectionRequest struct {
+	OdometerKm *int              `json:"odometer_km,omitempty"`
+	FuelLevel  *float64          `json:"fuel_level,omitempty"`
+	Notes      string            `json:"notes,omitempty"`
+	Checklist  []ChecklistAnswer `json:"checklist,omitempty"`
+}
+
+// ValidateInspectionRequest validates an InspectionRequest. Returns nil when valid, otherwise an error.
+func ValidateInspectionRequest(req InspectionRequest) error {
+	if req.OdometerKm != nil {
+		if err := validateMileage(*req.OdometerKm); err != nil {
+			return errors.New("odometer_km must be between 0 and 1,000,000")
+		}
+	}
+	if req.FuelLevel != nil && (*req.FuelLevel < 0 || *req.FuelLevel > 100) {
+		return errors.New("fuel_level must be between 0 and 100")
+	}
+	if len(req.Notes) > 2000 {
+		return errors.New("notes must be at most 2000 characters")
+	}
+	return nil
+}
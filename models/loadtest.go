@@ -0,0 +1,116 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits of a single fixture run, so a load test cannot flood the database by accident
+const (
+	maxLoadTestOwners         = 20
+	maxLoadTestCarsPerOwner   = 100
+	maxLoadTestRenters        = 100
+	maxLoadTestBookingsPerCar = 10
+	maxLoadTestTTL            = 7 * 24 * time.Hour
+)
+
+// LoadTestFixtureRequest sizes the disposable entities created for a load test. Zero values
+// take the defaults of ApplyDefaults.
+type LoadTestFixtureRequest struct {
+	Owners         int    `json:"owners"`           // Owners listing cars, default 5
+	CarsPerOwner   int    `json:"cars_per_owner"`   // Active cars per owner, default 20
+	Renters        int    `json:"renters"`          // Renters booking the cars and sending the load, default 20
+	BookingsPerCar int    `json:"bookings_per_car"` // Pending bookings per car, so conflict checks find some, default 2
+	TTL            string `json:"ttl"`              // How long the fixtures live before the purge job removes them, default 24h
+	BaseURL        string `json:"base_url"`         // Prefix of the target URLs, default the host the request was sent to
+}
+
+// ApplyDefaults fills in the sizes left at zero
+func (r *LoadTestFixtureRequest) ApplyDefaults() {
+	if r.Owners == 0 {
+		r.Owners = 5
+	}
+	if r.CarsPerOwner == 0 {
+		r.CarsPerOwner = 20
+	}
+	if r.Renters == 0 {
+		r.Renters = 20
+	}
+	if r.BookingsPerCar == 0 {
+		r.BookingsPerCar = 2
+	}
+	if r.TTL == "" {
+		r.TTL = "24h"
+	}
+}
+
+// Validate checks the sizes against the limits of a single run and returns the parsed TTL
+func (r LoadTestFixtureRequest) Validate() (time.Duration, error) {
+	switch {
+	case r.Owners < 1 || r.Owners > maxLoadTestOwners:
+		return 0, fmt.Errorf("owners must be between 1 and %d", maxLoadTestOwners)
+	case r.CarsPerOwner < 1 || r.CarsPerOwner > maxLoadTestCarsPerOwner:
+		return 0, fmt.Errorf("cars_per_owner must be between 1 and %d", maxLoadTestCarsPerOwner)
+	case r.Renters < 1 || r.Renters > maxLoadTestRenters:
+		return 0, fmt.Errorf("renters must be between 1 and %d", maxLoadTestRenters)
+	case r.BookingsPerCar < 1 || r.BookingsPerCar > maxLoadTestBookingsPerCar:
+		return 0, fmt.Errorf("bookings_per_car must be between 1 and %d", maxLoadTestBookingsPerCar)
+	}
+	ttl, err := time.ParseDuration(r.TTL)
+	if err != nil || ttl <= 0 || ttl > maxLoadTestTTL {
+		return 0, errors.New("ttl must be a duration such as 2h, of at most 168h")
+	}
+	return ttl, nil
+}
+
+// LoadTestRun records the entities created for one load test so they can be removed together
+type LoadTestRun struct {
+	ID        uuid.UUID   `json:"id"`
+	CreatedBy uuid.UUID   `json:"created_by"` // Admin who created the fixtures
+	UserIDs   []uuid.UUID `json:"user_ids"`   // Owners and renters
+	CarIDs    []uuid.UUID `json:"car_ids"`
+	CreatedAt time.Time   `json:"created_at"`
+	ExpiresAt time.Time   `json:"expires_at"` // Purged with its users, cars and bookings after this
+}
+
+// LoadTestFixtures are the entities of a new run. Renters are returned so load can be sent as
+// them; the run itself only keeps IDs.
+type LoadTestFixtures struct {
+	Run      LoadTestRun `json:"run"`
+	Renters  []User      `json:"renters"`
+	Cars     []Car       `json:"cars"`
+	Bookings int         `json:"bookings"` // Bookings made across the cars
+
+	// Requests to replay against the fixtures, signed in as the renters; set by the handler
+	Targets []LoadTestTarget `json:"targets"`
+}
+
+// LoadTestTarget is one request of a load test, in the JSON target format of vegeta
+// (vegeta attack -format=json). k6 scripts can read the same list with JSON.parse.
+type LoadTestTarget struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"` // Base64 in JSON, as vegeta expects
+}
+
+// PerformanceBudget is the time a hot store or service method may take per call against the
+// data of a default fixture run
+type PerformanceBudget struct {
+	Name      string `json:"name"`
+	Operation string `json:"operation"` // Method measured
+	Route     string `json:"route"`     // Request the method serves
+	BudgetMs  int    `json:"budget_ms"`
+}
+
+// PerformanceBudgets are enforced by the benchmarks of package integration; a method over its
+// budget fails TestPerformanceBudgets
+var PerformanceBudgets = []PerformanceBudget{
+	{Name: "car-listing-filtered", Operation: "CarStore.ListCars", Route: "GET /cars/search?brand=...&city=...", BudgetMs: 50},
+	{Name: "car-listing-bookable", Operation: "CarStore.GetBookableCarIDs", Route: "GET /cars?start=...&end=...", BudgetMs: 25},
+	{Name: "booking-conflict-check", Operation: "BookingService.CheckConflicts", Route: "GET /cars/{id}/conflicts", BudgetMs: 10},
+}
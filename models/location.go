@@ -0,0 +1,112 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LocationType distinguishes staffed pickup points
+type LocationType string
+
+const (
+	LocationTypeAirport LocationType = "airport" // Airport counter or parking
+	LocationTypeBranch  LocationType = "branch"  // City branch office
+)
+
+// Location is a named pickup/drop-off point. Its fees are added to the booking price when
+// customers pick a car up or return it there.
+type Location struct {
+	ID         uuid.UUID    `json:"id"`
+	Code       string       `json:"code"` // Short unique code, e.g. BLR-T1
+	Name       string       `json:"name"`
+	Type       LocationType `json:"type"`
+	Address    string       `json:"address"`
+	City       string       `json:"city"`
+	Latitude   float64      `json:"latitude"`
+	Longitude  float64      `json:"longitude"`
+	PickupFee  float64      `json:"pickup_fee"`  // INR charged when the rental starts here
+	DropoffFee float64      `json:"dropoff_fee"` // INR charged when the rental ends here
+	IsActive   bool         `json:"is_active"`   // Inactive locations cannot be selected for new bookings
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// LocationRequest is the payload to create or update a location
+type LocationRequest struct {
+	Code       string       `json:"code"`
+	Name       string       `json:"name"`
+	Type       LocationType `json:"type"`
+	Address    string       `json:"address"`
+	City       string       `json:"city"`
+	Latitude   float64      `json:"latitude"`
+	Longitude  float64      `json:"longitude"`
+	PickupFee  float64      `json:"pickup_fee"`
+	DropoffFee float64      `json:"dropoff_fee"`
+	IsActive   bool         `json:"is_active"`
+}
+
+// ValidateLocationRequest validates a LocationRequest. Returns nil when valid, otherwise an error.
+func ValidateLocationRequest(req LocationRequest) error {
+	if code := strings.TrimSpace(req.Code); code == "" || len(code) > 20 {
+		return errors.New("code is required and must be at most 20 characters")
+	}
+	if name := strings.TrimSpace(req.Name); name == "" || len(name) > 100 {
+		return errors.New("name is required and must be at most 100 characters")
+	}
+	if req.Type != LocationTypeAirport && req.Type != LocationTypeBranch {
+		return errors.New("type must be airport or branch")
+	}
+	if strings.TrimSpace(req.City) == "" {
+		return errors.New("city is required")
+	}
+	if req.Latitude < -90 || req.Latitude > 90 {
+		return errors.New("latitude must be between -90 and 90")
+	}
+	if req.Longitude < -180 || req.Longitude > 180 {
+		return errors.New("longitude must be between -180 and 180")
+	}
+	if req.PickupFee < 0 || req.DropoffFee < 0 {
+		return errors.New("pickup_fee and dropoff_fee cannot be negative")
+	}
+	return nil
+}
+
+// RelocationFee is the surcharge for a one-way rental that starts in FromCity and ends in
+// ToCity. City pairs without a fee are not offered as one-way rentals.
+type RelocationFee struct {
+	ID        uuid.UUID `json:"id"`
+	FromCity  string    `json:"from_city"`
+	ToCity    string    `json:"to_city"`
+	Fee       float64   `json:"fee"` // INR
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RelocationFeeRequest is the payload to set the fee of a city pair
+type RelocationFeeRequest struct {
+	FromCity string  `json:"from_city"`
+	ToCity   string  `json:"to_city"`
+	Fee      float64 `json:"fee"`
+}
+
+// SameCity reports whether two city names refer to the same city
+func SameCity(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// ValidateRelocationFeeRequest validates a RelocationFeeRequest. Returns nil when valid, otherwise an error.
+func ValidateRelocationFeeRequest(req RelocationFeeRequest) error {
+	if strings.TrimSpace(req.FromCity) == "" || strings.TrimSpace(req.ToCity) == "" {
+		return errors.New("from_city and to_city are required")
+	}
+	if SameCity(req.FromCity, req.ToCity) {
+		return errors.New("from_city and to_city must be different cities")
+	}
+	if req.Fee < 0 {
+		return errors.New("fee cannot be negative")
+	}
+	return nil
+}
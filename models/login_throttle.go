@@ -0,0 +1,25 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoginThrottledError is returned when login is refused without checking the password, because
+// of too many recent failures for the e-mail address or from the client IP
+type LoginThrottledError struct {
+	RetryAfter time.Duration // How long until login is accepted again
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, try again in %d seconds", e.RetryAfterSeconds())
+}
+
+// RetryAfterSeconds rounds RetryAfter up to whole seconds, for the Retry-After header
+func (e *LoginThrottledError) RetryAfterSeconds() int {
+	seconds := int((e.RetryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return seconds
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceTask is an operational runbook task admins run to repair data that has drifted
+// from the rules that derive it. Every task can be run again safely: records that are already
+// right are left alone.
+type MaintenanceTask string
+
+const (
+	MaintenanceTaskRebuildCarAvailability MaintenanceTask = "rebuild_car_availability" // Unlists cars that are not active, as status changes do
+	MaintenanceTaskRecomputeBookingTotals MaintenanceTask = "recompute_booking_totals" // Reprices unpaid pending bookings from the car's rate and fleet pricing rules
+	MaintenanceTaskResyncPayment          MaintenanceTask = "resync_payment"           // Applies the outcome Razorpay recorded for a pending payment
+	MaintenanceTaskReindexSearch          MaintenanceTask = "reindex_search"           // Recomputes the phone number blind index support search looks users up by
+)
+
+// MaintenanceJobStatus tracks a maintenance job run in the background
+type MaintenanceJobStatus string
+
+const (
+	MaintenanceJobQueued    MaintenanceJobStatus = "queued"    // Waiting for the maintenance job runner
+	MaintenanceJobRunning   MaintenanceJobStatus = "running"   // Claimed by the runner
+	MaintenanceJobSucceeded MaintenanceJobStatus = "succeeded" // Finished, see examined and changed
+	MaintenanceJobFailed    MaintenanceJobStatus = "failed"    // Stopped by an error, see error
+)
+
+// MaintenanceJob is one run of a maintenance task. Jobs are kept as the audit trail of who
+// repaired what, when, and how many records it changed.
+type MaintenanceJob struct {
+	ID          uuid.UUID            `json:"id"`
+	Task        MaintenanceTask      `json:"task"`
+	TargetID    *uuid.UUID           `json:"target_id,omitempty"`   // Record the task is limited to; all records when unset
+	OperatorID  *uuid.UUID           `json:"operator_id,omitempty"` // Marketplace the task is limited to; all when unset
+	RequestedBy uuid.UUID            `json:"requested_by"`
+	Status      MaintenanceJobStatus `json:"status"`
+	Examined    int                  `json:"examined"` // Records checked
+	Changed     int                  `json:"changed"`  // Records repaired
+	Error       string               `json:"error,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	StartedAt   *time.Time           `json:"started_at,omitempty"`
+	CompletedAt *time.Time           `json:"completed_at,omitempty"`
+}
+
+// MaintenanceJobRequest is the body of a request to run a maintenance task
+type MaintenanceJobRequest struct {
+	TargetID *uuid.UUID `json:"target_id,omitempty"` // Car, booking or payment to limit the task to
+}
+
+// MaintenanceResult counts the records a maintenance task checked and repaired
+type MaintenanceResult struct {
+	Examined int
+	Changed  int
+}
+
+// Add counts the records of another part of a task
+func (r *MaintenanceResult) Add(other MaintenanceResult) {
+	r.Examined += other.Examined
+	r.Changed += other.Changed
+}
+
+// ParseMaintenanceTask validates a maintenance task name
+func ParseMaintenanceTask(name string) (MaintenanceTask, error) {
+	switch task := MaintenanceTask(name); task {
+	case MaintenanceTaskRebuildCarAvailability, MaintenanceTaskRecomputeBookingTotals,
+		MaintenanceTaskResyncPayment, MaintenanceTaskReindexSearch:
+		return task, nil
+	}
+	return "", errors.New("unknown maintenance task")
+}
+
+// Validate checks the target of a maintenance task: a payment resync needs the payment, a
+// search reindex covers every user, and the other tasks optionally take the car or booking
+func (r MaintenanceJobRequest) Validate(task MaintenanceTask) error {
+	switch {
+	case task == MaintenanceTaskResyncPayment && r.TargetID == nil:
+		return errors.New("target_id of the payment to resync is required")
+	case task == MaintenanceTaskReindexSearch && r.TargetID != nil:
+		return errors.New("reindex_search does not take a target_id")
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification is a message kept in a user's in-app inbox. Every message sent to a user
+// through the notification service is stored here as well as e-mailed.
+type Notification struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Subject   string     `json:"subject"`
+	Message   string     `json:"message"`
+	ReadAt    *time.Time `json:"read_at,omitempty"` // Nil while the notification is unread
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// PageCursor returns the pagination cursor pointing at this notification
+func (n Notification) PageCursor() Cursor {
+	return Cursor{CreatedAt: n.CreatedAt, ID: n.ID}
+}
+
+// NotificationFilter narrows a user's inbox
+type NotificationFilter struct {
+	UnreadOnly bool // Only return notifications that have not been read
+}
+
+// UnreadNotificationCount is the number behind the inbox bell icon
+type UnreadNotificationCount struct {
+	Unread int `json:"unread"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OnboardingStepKey names a step owners go through before they earn from their cars
+type OnboardingStepKey string
+
+const (
+	OnboardingProfileComplete   OnboardingStepKey = "profile_complete"   // Phone, display name and city filled in
+	OnboardingPayoutDetails     OnboardingStepKey = "payout_details"     // A payout account the gateway verified
+	OnboardingFirstCarListed    OnboardingStepKey = "first_car_listed"   // At least one active car
+	OnboardingDocumentsVerified OnboardingStepKey = "documents_verified" // Identity documents checked by an admin (KYC)
+)
+
+// OnboardingStep is one step of an owner's onboarding. Steps are derived from the owner's
+// account each time they are read, so they complete as soon as the underlying data does.
+type OnboardingStep struct {
+	Key         OnboardingStepKey `json:"key"`
+	Title       string            `json:"title"`
+	Done        bool              `json:"done"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"` // Set for steps whose completion time is recorded
+	Hint        string            `json:"hint,omitempty"`         // What to do next; empty once done
+	Method      string            `json:"method,omitempty"`       // HTTP method of the request that moves the step on
+	Link        string            `json:"link,omitempty"`         // Path of that request; empty while the owner can only wait
+}
+
+// OnboardingProgress is an owner's progress through onboarding, for guided onboarding UIs
+type OnboardingProgress struct {
+	OwnerID   uuid.UUID          `json:"owner_id"`
+	Steps     []OnboardingStep   `json:"steps"`
+	Completed int                `json:"completed"`
+	Total     int                `json:"total"`
+	Percent   int                `json:"percent"`
+	NextStep  *OnboardingStepKey `json:"next_step,omitempty"` // First step not done; nil once onboarding is complete
+}
+
+// NewOnboardingProgress totals the steps of an owner's onboarding and picks the next one
+func NewOnboardingProgress(ownerID uuid.UUID, steps []OnboardingStep) OnboardingProgress {
+	progress := OnboardingProgress{OwnerID: ownerID, Steps: steps, Total: len(steps)}
+	for _, step := range steps {
+		if step.Done {
+			progress.Completed++
+		} else if progress.NextStep == nil {
+			key := step.Key
+			progress.NextStep = &key
+		}
+	}
+	if progress.Total > 0 {
+		progress.Percent = progress.Completed * 100 / progress.Total
+	}
+	return progress
+}
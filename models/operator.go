@@ -0,0 +1,100 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxCommissionRate caps the share of a booking the platform may take from an operator
+const MaxCommissionRate = 0.5
+
+// OperatorBranding is what a white-label frontend needs to look like the operator's own site
+type OperatorBranding struct {
+	LogoURL      string `json:"logo_url,omitempty"`
+	PrimaryColor string `json:"primary_color,omitempty"` // Hex color, e.g. #0A7CFF
+	SupportEmail string `json:"support_email,omitempty"`
+}
+
+// Operator is a marketplace operator (tenant). Its cars, bookings, payments and users are
+// invisible to other operators.
+type Operator struct {
+	ID             uuid.UUID        `json:"id"`
+	Slug           string           `json:"slug"`
+	Name           string           `json:"name"`
+	Domain         *string          `json:"domain,omitempty"` // White-label host name, e.g. rentals.example.com
+	Branding       OperatorBranding `json:"branding"`
+	CommissionRate float64          `json:"commission_rate"` // Platform share of booking totals, 0 to 0.5
+	IsActive       bool             `json:"is_active"`       // Inactive operators' domains stop serving requests
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// PublicOperator is the part of an operator shown to its customers
+type PublicOperator struct {
+	Slug     string           `json:"slug"`
+	Name     string           `json:"name"`
+	Branding OperatorBranding `json:"branding"`
+}
+
+// Public returns the customer-facing view of the operator
+func (o Operator) Public() PublicOperator {
+	return PublicOperator{Slug: o.Slug, Name: o.Name, Branding: o.Branding}
+}
+
+// OperatorRequest is the payload to create or update an operator
+type OperatorRequest struct {
+	Slug           string           `json:"slug"`
+	Name           string           `json:"name"`
+	Domain         *string          `json:"domain"`
+	Branding       OperatorBranding `json:"branding"`
+	CommissionRate float64          `json:"commission_rate"`
+	IsActive       bool             `json:"is_active"`
+}
+
+var (
+	operatorSlugPattern   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	operatorDomainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+	hexColorPattern       = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+// ValidateOperatorRequest validates an OperatorRequest. Returns nil when valid, otherwise an error.
+func ValidateOperatorRequest(req OperatorRequest) error {
+	if len(req.Slug) < 2 || len(req.Slug) > 50 || !operatorSlugPattern.MatchString(req.Slug) {
+		return errors.New("slug must be 2-50 lowercase letters, digits and single hyphens")
+	}
+	if name := strings.TrimSpace(req.Name); name == "" || len(name) > 100 {
+		return errors.New("name is required and must be at most 100 characters")
+	}
+	if req.Domain != nil && !operatorDomainPattern.MatchString(NormalizeHost(*req.Domain)) {
+		return errors.New("domain must be a host name such as rentals.example.com")
+	}
+	if req.CommissionRate < 0 || req.CommissionRate > MaxCommissionRate {
+		return errors.New("commission_rate must be between 0 and 0.5")
+	}
+	if req.Branding.PrimaryColor != "" && !hexColorPattern.MatchString(req.Branding.PrimaryColor) {
+		return errors.New("branding primary_color must be a hex color such as #0A7CFF")
+	}
+	if req.Branding.SupportEmail != "" {
+		if err := validateEmail(req.Branding.SupportEmail); err != nil {
+			return errors.New("branding support_email is not a valid e-mail address")
+		}
+	}
+	if req.Branding.LogoURL != "" && !strings.HasPrefix(req.Branding.LogoURL, "https://") {
+		return errors.New("branding logo_url must be an https URL")
+	}
+	return nil
+}
+
+// NormalizeHost lower-cases a host name and strips any port, so Host headers compare equal
+// to stored operator domains
+func NormalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if i := strings.LastIndex(host, ":"); i != -1 && !strings.Contains(host[i:], "]") {
+		host = host[:i]
+	}
+	return strings.TrimSuffix(host, ".")
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultPageLimit is used when the client does not request a page size
+	DefaultPageLimit = 20
+	// MaxPageLimit caps the page size a client may request
+	MaxPageLimit = 100
+)
+
+// Cursor identifies a position in a list ordered by (created_at DESC, id DESC).
+// The id acts as a tie-breaker so rows created in the same instant are never
+// skipped or repeated when new rows are inserted between page requests.
+// A Backward cursor walks towards newer rows and is used for "prev" links.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+	Backward  bool
+}
+
+// PageRequest describes which page of a list the client is asking for
+type PageRequest struct {
+	Limit  int     // Number of items to return
+	Cursor *Cursor // Position after which to start; nil for the first page
+}
+
+// Backward reports whether the request walks towards newer rows
+func (p PageRequest) Backward() bool {
+	return p.Cursor != nil && p.Cursor.Backward
+}
+
+// KeysetComparator returns the SQL row comparison operator for the cursor condition
+func (p PageRequest) KeysetComparator() string {
+	if p.Backward() {
+		return ">"
+	}
+	return "<"
+}
+
+// SortOrder returns the SQL sort direction the store must use to fetch this page
+func (p PageRequest) SortOrder() string {
+	if p.Backward() {
+		return "ASC"
+	}
+	return "DESC"
+}
+
+// Page is one slice of a list together with the cursors of its neighbours
+type Page[T any] struct {
+	Data       []T    `json:"data"`                  // Items on this page
+	NextCursor string `json:"next_cursor,omitempty"` // Opaque cursor for the next (older) page, empty on the last page
+	PrevCursor string `json:"prev_cursor,omitempty"` // Opaque cursor for the previous (newer) page, empty on the first page
+	HasMore    bool   `json:"has_more"`              // Whether another page exists
+	Limit      int    `json:"limit"`                 // Page size that was applied
+	Total      *int   `json:"total,omitempty"`       // Number of items on all pages, when the list counted them
+}
+
+// EncodeCursor serializes a cursor into an opaque, URL-safe string
+func EncodeCursor(c Cursor) string {
+	direction := "n"
+	if c.Backward {
+		direction = "p"
+	}
+	raw := direction + "|" + strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses an opaque cursor produced by EncodeCursor
+func DecodeCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, errors.New("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), "|", 3)
+	if len(parts) != 3 || (parts[0] != "n" && parts[0] != "p") {
+		return Cursor{}, errors.New("invalid cursor")
+	}
+
+	nanos, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return Cursor{}, errors.New("invalid cursor")
+	}
+
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return Cursor{}, errors.New("invalid cursor")
+	}
+
+	return Cursor{CreatedAt: time.Unix(0, nanos).UTC(), ID: id, Backward: parts[0] == "p"}, nil
+}
+
+// ParsePageRequest builds a PageRequest from raw "limit" and "cursor" query values.
+// An empty limit falls back to DefaultPageLimit and values above MaxPageLimit are capped.
+func ParsePageRequest(limitParam, cursorParam string) (PageRequest, error) {
+	page := PageRequest{Limit: DefaultPageLimit}
+
+	if limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 {
+			return page, errors.New("limit must be a positive integer")
+		}
+		if limit > MaxPageLimit {
+			limit = MaxPageLimit
+		}
+		page.Limit = limit
+	}
+
+	if cursorParam != "" {
+		cursor, err := DecodeCursor(cursorParam)
+		if err != nil {
+			return page, err
+		}
+		page.Cursor = &cursor
+	}
+
+	return page, nil
+}
+
+// NewPage builds a Page from rows fetched with a LIMIT of page.Limit+1 in the
+// order given by page.SortOrder. The extra row only signals that another page
+// exists in the direction of travel and is not returned.
+func NewPage[T any](rows []T, page PageRequest, cursorOf func(T) Cursor) Page[T] {
+	limit := page.Limit
+	extra := len(rows) > limit
+	if extra {
+		rows = rows[:limit]
+	}
+
+	// Backward pages are fetched oldest-first; restore newest-first order
+	if page.Backward() {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	result := Page[T]{Data: rows, Limit: limit}
+	if result.Data == nil {
+		result.Data = []T{}
+	}
+	if len(rows) == 0 {
+		return result
+	}
+
+	// Older rows exist if we fetched an extra one going forward, or if we came from them going backward
+	if (extra && !page.Backward()) || page.Backward() {
+		result.HasMore = true
+		result.NextCursor = EncodeCursor(cursorOf(rows[len(rows)-1]))
+	}
+
+	// Newer rows exist if we started from a forward cursor, or fetched an extra one going backward
+	if (page.Cursor != nil && !page.Backward()) || (extra && page.Backward()) {
+		prev := cursorOf(rows[0])
+		prev.Backward = true
+		result.PrevCursor = EncodeCursor(prev)
+	}
+
+	return result
+}
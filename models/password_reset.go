@@ -0,0 +1,48 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetTokenTTL is how long a password reset link works
+const PasswordResetTokenTTL = time.Hour
+
+// MaxPasswordResetRequests bounds the reset e-mails one account is sent per
+// PasswordResetRequestWindow, so the endpoint cannot be used to flood an inbox
+const (
+	MaxPasswordResetRequests   = 3
+	PasswordResetRequestWindow = time.Hour
+)
+
+// PasswordResetToken is an issued password reset link. Only the SHA-256 hash of the token is
+// stored; the token itself is only in the e-mail.
+type PasswordResetToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"` // Set once the password was reset with it
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ForgotPasswordRequest is the payload to ask for a password reset link
+type ForgotPasswordRequest struct {
+	Email        string `json:"email"`
+	CaptchaToken string `json:"captcha_token,omitempty"` // Proves a person is asking when a bot check is configured
+}
+
+// ResetPasswordRequest is the payload to set a new password with a reset link's token
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ValidateResetPasswordRequest validates ResetPasswordRequest. Returns nil when valid, otherwise an error.
+func ValidateResetPasswordRequest(req ResetPasswordRequest) error {
+	if req.Token == "" {
+		return errors.New("token is required")
+	}
+	return validatePassword(req.Password)
+}
@@ -1,8 +1,11 @@
 package models
 
 import (
+	"errors"
+	"strings"
 	"time"
 
+	"github.com/PrateekKumar15/CarZone/statemachine"
 	"github.com/google/uuid"
 )
 
@@ -10,13 +13,28 @@ import (
 type PaymentStatus string
 
 const (
-	PaymentStatusPending   PaymentStatus = "pending"
-	PaymentStatusCompleted PaymentStatus = "completed"
-	PaymentStatusFailed    PaymentStatus = "failed"
-	PaymentStatusRefunded  PaymentStatus = "refunded"
-	PaymentStatusCancelled PaymentStatus = "cancelled"
+	PaymentStatusPending    PaymentStatus = "pending"
+	PaymentStatusAuthorized PaymentStatus = "authorized" // Held on the customer's card until pickup
+	PaymentStatusCompleted  PaymentStatus = "completed"
+	PaymentStatusFailed     PaymentStatus = "failed"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+	PaymentStatusCancelled  PaymentStatus = "cancelled"
+	PaymentStatusVoided     PaymentStatus = "voided" // Hold released without capture
 )
 
+// PaymentStatusMachine declares the lifecycle of a payment. Only completed payments can be
+// refunded; a failed payment goes back to pending when it is retried with a new gateway order.
+// A manual-capture payment is authorized first and captured at pickup, or voided when the
+// booking is cancelled before it. Refunded, cancelled and voided payments are final.
+var PaymentStatusMachine = statemachine.New[PaymentStatus, Payment]("payment").
+	State(PaymentStatusPending, PaymentStatusAuthorized, PaymentStatusCompleted, PaymentStatusFailed, PaymentStatusCancelled).
+	State(PaymentStatusAuthorized, PaymentStatusCompleted, PaymentStatusVoided).
+	State(PaymentStatusCompleted, PaymentStatusRefunded).
+	State(PaymentStatusFailed, PaymentStatusPending).
+	State(PaymentStatusRefunded).
+	State(PaymentStatusCancelled).
+	State(PaymentStatusVoided)
+
 // PaymentMethod represents the payment method used
 type PaymentMethod string
 
@@ -43,6 +61,10 @@ type Payment struct {
 	Notes             *string       `json:"notes,omitempty" db:"notes"`
 	CreatedAt         time.Time     `json:"created_at" db:"created_at"`
 	UpdatedAt         time.Time     `json:"updated_at" db:"updated_at"`
+
+	// Set by payment lists from the booking's snapshots, so admins see who paid whom
+	Customer *UserSnapshot `json:"customer,omitempty" db:"-"`
+	Owner    *UserSnapshot `json:"owner,omitempty" db:"-"`
 }
 
 // PaymentRequest represents the request to create a payment
@@ -56,9 +78,22 @@ type PaymentRequest struct {
 
 // RazorpayOrderRequest represents the request to create a Razorpay order
 type RazorpayOrderRequest struct {
-	Amount   int    `json:"amount"`   // Amount in paise (smallest currency unit)
-	Currency string `json:"currency"` // INR
-	Receipt  string `json:"receipt"`  // Unique receipt ID
+	Amount   int                   `json:"amount"`            // Amount in paise (smallest currency unit)
+	Currency string                `json:"currency"`          // INR
+	Receipt  string                `json:"receipt"`           // Unique receipt ID
+	Payment  *RazorpayOrderPayment `json:"payment,omitempty"` // Capture settings; automatic capture when nil
+}
+
+// RazorpayOrderPayment sets how payments made on an order are captured
+type RazorpayOrderPayment struct {
+	Capture        string                `json:"capture"` // automatic, manual
+	CaptureOptions RazorpayCaptureOption `json:"capture_options"`
+}
+
+// RazorpayCaptureOption bounds how long Razorpay keeps an uncaptured payment before refunding it
+type RazorpayCaptureOption struct {
+	ManualExpiryPeriod int    `json:"manual_expiry_period"` // Minutes
+	RefundSpeed        string `json:"refund_speed"`         // normal, optimum
 }
 
 // RazorpayOrderResponse represents the response from Razorpay order creation
@@ -71,9 +106,233 @@ type RazorpayOrderResponse struct {
 	Status   string `json:"status"`
 }
 
+// RazorpayOrderPayments represents Razorpay's list of the attempts made to pay an order
+type RazorpayOrderPayments struct {
+	Items []RazorpayPaymentEntity `json:"items"`
+}
+
+// RazorpayPaymentEntity represents one attempt to pay a Razorpay order
+type RazorpayPaymentEntity struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"` // created, authorized, captured, refunded or failed
+	ErrorCode        string `json:"error_code"`
+	ErrorDescription string `json:"error_description"`
+}
+
 // PaymentVerificationRequest represents the request to verify a payment
 type PaymentVerificationRequest struct {
 	RazorpayOrderID   string `json:"razorpay_order_id" validate:"required"`
 	RazorpayPaymentID string `json:"razorpay_payment_id" validate:"required"`
 	RazorpaySignature string `json:"razorpay_signature" validate:"required"`
 }
+
+// PaymentFilter narrows a payment list to a single paying user.
+// Empty fields are ignored.
+type PaymentFilter struct {
+	UserID string
+}
+
+// PageCursor returns the pagination cursor pointing at this payment
+func (p Payment) PageCursor() Cursor {
+	return Cursor{CreatedAt: p.CreatedAt, ID: p.ID}
+}
+
+// PaymentLinkStatus tracks a payment link as reported by Razorpay's webhooks
+type PaymentLinkStatus string
+
+const (
+	PaymentLinkStatusCreated   PaymentLinkStatus = "created"
+	PaymentLinkStatusPaid      PaymentLinkStatus = "paid"
+	PaymentLinkStatusExpired   PaymentLinkStatus = "expired"
+	PaymentLinkStatusCancelled PaymentLinkStatus = "cancelled"
+)
+
+// PaymentLink is a hosted Razorpay checkout page sent to a customer by e-mail and SMS, for
+// bookings taken over the phone. It backs a pending payment that Razorpay's webhook settles.
+type PaymentLink struct {
+	ID             uuid.UUID         `json:"id"`
+	PaymentID      uuid.UUID         `json:"payment_id"`
+	BookingID      uuid.UUID         `json:"booking_id"`
+	RazorpayLinkID string            `json:"razorpay_link_id"`
+	ShortURL       string            `json:"short_url"` // Page the customer pays on
+	Amount         float64           `json:"amount"`    // Amount in INR
+	Status         PaymentLinkStatus `json:"status"`
+	ExpiresAt      time.Time         `json:"expires_at"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// PaymentLinkRequest is the payload an admin sends to create a payment link for a booking
+type PaymentLinkRequest struct {
+	Amount      float64 `json:"amount,omitempty"`      // Amount in INR; defaults to the booking's total amount
+	Description string  `json:"description,omitempty"` // Shown on the payment page
+}
+
+// RazorpayPaymentLinkRequest represents the request to create a Razorpay Payment Link
+type RazorpayPaymentLinkRequest struct {
+	Amount         int              `json:"amount"` // Amount in paise
+	Currency       string           `json:"currency"`
+	Description    string           `json:"description"`
+	ReferenceID    string           `json:"reference_id"` // Our payment ID
+	ExpireBy       int64            `json:"expire_by"`    // Unix time
+	Customer       RazorpayCustomer `json:"customer"`
+	Notify         RazorpayNotify   `json:"notify"`
+	ReminderEnable bool             `json:"reminder_enable"`
+}
+
+// RazorpayCustomer identifies who a payment link is sent to
+type RazorpayCustomer struct {
+	Name    string `json:"name,omitempty"`
+	Email   string `json:"email,omitempty"`
+	Contact string `json:"contact,omitempty"`
+}
+
+// RazorpayNotify selects the channels Razorpay sends a payment link on
+type RazorpayNotify struct {
+	SMS   bool `json:"sms"`
+	Email bool `json:"email"`
+}
+
+// RazorpayPaymentLinkResponse represents the response from Razorpay Payment Link creation
+type RazorpayPaymentLinkResponse struct {
+	ID       string `json:"id"`
+	ShortURL string `json:"short_url"`
+	Status   string `json:"status"`
+}
+
+// RazorpayWebhookEvent is the part of a Razorpay webhook body read for payment links, failed
+// payments and disputes
+type RazorpayWebhookEvent struct {
+	Event   string `json:"event"` // e.g. payment_link.paid
+	Payload struct {
+		PaymentLink struct {
+			Entity struct {
+				ID string `json:"id"`
+			} `json:"entity"`
+		} `json:"payment_link"`
+		Payment struct {
+			Entity struct {
+				ID               string `json:"id"`
+				OrderID          string `json:"order_id"`
+				ErrorCode        string `json:"error_code"`
+				ErrorDescription string `json:"error_description"`
+			} `json:"entity"`
+		} `json:"payment"`
+		Dispute struct {
+			Entity struct {
+				ID                string `json:"id"`
+				PaymentID         string `json:"payment_id"`
+				Amount            int    `json:"amount"` // Paise
+				ReasonCode        string `json:"reason_code"`
+				ReasonDescription string `json:"reason_description"`
+				RespondBy         int64  `json:"respond_by"` // Unix time, 0 when not set
+				Status            string `json:"status"`
+				Phase             string `json:"phase"`
+			} `json:"entity"`
+		} `json:"dispute"`
+	} `json:"payload"`
+}
+
+// PaymentCollection records an owner or admin taking an offline payment in person. Rows are
+// never changed, so they form the audit trail of who collected what, when and against which
+// receipt.
+type PaymentCollection struct {
+	ID            uuid.UUID `json:"id"`
+	PaymentID     uuid.UUID `json:"payment_id"`
+	BookingID     uuid.UUID `json:"booking_id"`
+	Amount        float64   `json:"amount"`         // INR
+	ReceiptNumber string    `json:"receipt_number"` // Also stored as the payment's transaction_id
+	Notes         string    `json:"notes,omitempty"`
+	CollectedBy   uuid.UUID `json:"collected_by"`
+	CollectedAt   time.Time `json:"collected_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// PaymentCollectionRequest is the payload an owner or admin sends after collecting an offline
+// payment. CollectedAt defaults to now.
+type PaymentCollectionRequest struct {
+	Amount        float64    `json:"amount"`
+	ReceiptNumber string     `json:"receipt_number"`
+	Notes         string     `json:"notes,omitempty"`
+	CollectedAt   *time.Time `json:"collected_at,omitempty"`
+}
+
+// PaymentCollectionResult is the outcome of recording a collection: the collection, the
+// completed payment and the booking, confirmed if it was still pending
+type PaymentCollectionResult struct {
+	Collection PaymentCollection `json:"collection"`
+	Payment    Payment           `json:"payment"`
+	Booking    Booking           `json:"booking"`
+}
+
+// ValidatePaymentCollectionRequest validates a PaymentCollectionRequest. Returns nil when valid, otherwise an error.
+func ValidatePaymentCollectionRequest(req PaymentCollectionRequest) error {
+	if req.Amount <= 0 {
+		return errors.New("amount must be greater than 0")
+	}
+	receipt := strings.TrimSpace(req.ReceiptNumber)
+	if receipt == "" {
+		return errors.New("receipt_number is required")
+	}
+	if len(receipt) > 64 {
+		return errors.New("receipt_number must be at most 64 characters")
+	}
+	if len(req.Notes) > 1000 {
+		return errors.New("notes must be at most 1000 characters")
+	}
+	if req.CollectedAt != nil && req.CollectedAt.After(time.Now().Add(5*time.Minute)) {
+		return errors.New("collected_at must not be in the future")
+	}
+	return nil
+}
+
+// PaymentAttempt is one gateway attempt of a payment: a Razorpay order and what became of it.
+// The payment row only holds the latest attempt, so disputes are resolved from these.
+type PaymentAttempt struct {
+	ID                uuid.UUID     `json:"id"`
+	PaymentID         uuid.UUID     `json:"payment_id"`
+	RazorpayOrderID   string        `json:"razorpay_order_id"`
+	RazorpayPaymentID *string       `json:"razorpay_payment_id,omitempty"`
+	Status            PaymentStatus `json:"status"`                      // pending until verified or failed
+	ErrorCode         *string       `json:"error_code,omitempty"`        // Razorpay error code, or SIGNATURE_MISMATCH
+	ErrorDescription  *string       `json:"error_description,omitempty"` // Razorpay error description
+	CreatedAt         time.Time     `json:"created_at"`                  // When the order was created
+	UpdatedAt         time.Time     `json:"updated_at"`                  // When the attempt last changed status
+}
+
+// PaymentDetail is the admin view of a payment with every gateway attempt, oldest first
+type PaymentDetail struct {
+	Payment       Payment               `json:"payment"`
+	Attempts      []PaymentAttempt      `json:"attempts"`
+	Authorization *PaymentAuthorization `json:"authorization,omitempty"` // Set on manual-capture payments
+}
+
+// PaymentAuthorizationStatus tracks the hold of a manual-capture payment
+type PaymentAuthorizationStatus string
+
+const (
+	PaymentAuthorizationPending    PaymentAuthorizationStatus = "pending"    // Order created, customer has not paid yet
+	PaymentAuthorizationAuthorized PaymentAuthorizationStatus = "authorized" // Amount held on the customer's card
+	PaymentAuthorizationCaptured   PaymentAuthorizationStatus = "captured"   // Captured at pickup
+	PaymentAuthorizationVoided     PaymentAuthorizationStatus = "voided"     // Released, the booking was cancelled before pickup
+)
+
+// PaymentAuthorization marks a payment as captured at pickup rather than when the customer
+// pays, and tracks its hold
+type PaymentAuthorization struct {
+	ID                uuid.UUID                  `json:"id"`
+	PaymentID         uuid.UUID                  `json:"payment_id"`
+	BookingID         uuid.UUID                  `json:"booking_id"`
+	Amount            float64                    `json:"amount"` // INR
+	Status            PaymentAuthorizationStatus `json:"status"`
+	RazorpayPaymentID *string                    `json:"razorpay_payment_id,omitempty"`
+	ExpiresAt         *time.Time                 `json:"expires_at,omitempty"` // When Razorpay releases the hold uncaptured
+	AuthorizedAt      *time.Time                 `json:"authorized_at,omitempty"`
+	CapturedAt        *time.Time                 `json:"captured_at,omitempty"`
+	VoidedAt          *time.Time                 `json:"voided_at,omitempty"`
+	CreatedAt         time.Time                  `json:"created_at"`
+	UpdatedAt         time.Time                  `json:"updated_at"`
+}
+
+// SignatureMismatchErrorCode is recorded on attempts whose checkout signature did not verify
+const SignatureMismatchErrorCode = "SIGNATURE_MISMATCH"
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayoutAccountType represents how an owner receives payouts
+type PayoutAccountType string
+
+const (
+	PayoutAccountTypeBank PayoutAccountType = "bank_account"
+	PayoutAccountTypeVPA  PayoutAccountType = "vpa"
+)
+
+// PayoutAccountStatus represents the verification state of a payout account
+type PayoutAccountStatus string
+
+const (
+	PayoutAccountStatusPending  PayoutAccountStatus = "pending"  // Registered, verification not finished
+	PayoutAccountStatusVerified PayoutAccountStatus = "verified" // Penny-drop or gateway check succeeded
+	PayoutAccountStatusFailed   PayoutAccountStatus = "failed"   // Account could not be verified
+)
+
+// PayoutAccount represents an owner's bank account or UPI ID used for payouts.
+// AccountNumber and VPA are stored encrypted and never serialized; clients only
+// ever see the masked identifier.
+type PayoutAccount struct {
+	ID                    uuid.UUID           `json:"id"`
+	OwnerID               uuid.UUID           `json:"owner_id"`
+	AccountType           PayoutAccountType   `json:"account_type"`
+	AccountHolderName     string              `json:"account_holder_name"`
+	IFSC                  string              `json:"ifsc,omitempty"`
+	AccountNumber         string              `json:"-"`                 // Decrypted bank account number
+	VPA                   string              `json:"-"`                 // Decrypted UPI ID
+	MaskedIdentifier      string              `json:"masked_identifier"` // e.g. XXXXXX1234 or ab***@okhdfc
+	Status                PayoutAccountStatus `json:"status"`
+	RazorpayContactID     *string             `json:"razorpay_contact_id,omitempty"`
+	RazorpayFundAccountID *string             `json:"razorpay_fund_account_id,omitempty"`
+	RazorpayValidationID  *string             `json:"razorpay_validation_id,omitempty"`
+	RegisteredName        *string             `json:"registered_name,omitempty"` // Name returned by the bank on verification
+	FailureReason         *string             `json:"failure_reason,omitempty"`
+	VerifiedAt            *time.Time          `json:"verified_at,omitempty"`
+	CreatedAt             time.Time           `json:"created_at"`
+	UpdatedAt             time.Time           `json:"updated_at"`
+}
+
+// PayoutAccountRequest represents the payload an owner submits to register payout details
+type PayoutAccountRequest struct {
+	AccountType       PayoutAccountType `json:"account_type"`
+	AccountHolderName string            `json:"account_holder_name"`
+	IFSC              string            `json:"ifsc,omitempty"`
+	AccountNumber     string            `json:"account_number,omitempty"`
+	VPA               string            `json:"vpa,omitempty"`
+}
+
+// PayoutVerificationUpdate carries the gateway identifiers and outcome of a verification attempt
+type PayoutVerificationUpdate struct {
+	RazorpayContactID     *string
+	RazorpayFundAccountID *string
+	RazorpayValidationID  *string
+	RegisteredName        *string
+	FailureReason         *string
+	Status                PayoutAccountStatus
+}
+
+var (
+	ifscPattern          = regexp.MustCompile(`^[A-Z]{4}0[A-Z0-9]{6}$`)
+	accountNumberPattern = regexp.MustCompile(`^[0-9]{9,18}$`)
+	vpaPattern           = regexp.MustCompile(`^[a-zA-Z0-9.\-_]{2,256}@[a-zA-Z]{2,64}$`)
+)
+
+// ValidatePayoutAccountRequest validates a PayoutAccountRequest. Returns nil when valid, otherwise an error.
+func ValidatePayoutAccountRequest(req PayoutAccountRequest) error {
+	if strings.TrimSpace(req.AccountHolderName) == "" {
+		return errors.New("account holder name is required")
+	}
+
+	switch req.AccountType {
+	case PayoutAccountTypeBank:
+		if !ifscPattern.MatchString(strings.ToUpper(req.IFSC)) {
+			return errors.New("invalid IFSC code")
+		}
+		if !accountNumberPattern.MatchString(req.AccountNumber) {
+			return errors.New("account number must be 9 to 18 digits")
+		}
+	case PayoutAccountTypeVPA:
+		if !vpaPattern.MatchString(req.VPA) {
+			return errors.New("invalid UPI ID")
+		}
+	default:
+		return errors.New("account type must be one of: bank_account, vpa")
+	}
+
+	return nil
+}
+
+// MaskAccountNumber hides all but the last four digits of an account number
+func MaskAccountNumber(accountNumber string) string {
+	if len(accountNumber) <= 4 {
+		return accountNumber
+	}
+	return strings.Repeat("X", len(accountNumber)-4) + accountNumber[len(accountNumber)-4:]
+}
+
+// MaskVPA hides most of the handle part of a UPI ID
+func MaskVPA(vpa string) string {
+	at := strings.Index(vpa, "@")
+	if at <= 2 {
+		return vpa
+	}
+	return vpa[:2] + strings.Repeat("*", at-2) + vpa[at:]
+}
+
+// RazorpayContactRequest represents the request to create a RazorpayX contact
+type RazorpayContactRequest struct {
+	Name        string `json:"name"`
+	Email       string `json:"email,omitempty"`
+	Contact     string `json:"contact,omitempty"`
+	Type        string `json:"type"`         // vendor for owners receiving payouts
+	ReferenceID string `json:"reference_id"` // CarZone user ID
+}
+
+// RazorpayBankAccount is the bank account block of a fund account request
+type RazorpayBankAccount struct {
+	Name          string `json:"name"`
+	IFSC          string `json:"ifsc"`
+	AccountNumber string `json:"account_number"`
+}
+
+// RazorpayVPA is the UPI block of a fund account request
+type RazorpayVPA struct {
+	Address string `json:"address"`
+}
+
+// RazorpayFundAccountRequest represents the request to create a RazorpayX fund account
+type RazorpayFundAccountRequest struct {
+	ContactID   string               `json:"contact_id"`
+	AccountType string               `json:"account_type"` // bank_account or vpa
+	BankAccount *RazorpayBankAccount `json:"bank_account,omitempty"`
+	VPA         *RazorpayVPA         `json:"vpa,omitempty"`
+}
+
+// RazorpayEntity is the common part of RazorpayX create responses
+type RazorpayEntity struct {
+	ID     string `json:"id"`
+	Entity string `json:"entity"`
+}
+
+// RazorpayValidationRequest represents a penny-drop validation request for a fund account
+type RazorpayValidationRequest struct {
+	AccountNumber string         `json:"account_number"` // RazorpayX business account debited for the penny drop
+	FundAccount   RazorpayEntity `json:"fund_account"`
+	Amount        int            `json:"amount"` // In paise
+	Currency      string         `json:"currency"`
+}
+
+// RazorpayValidationResponse represents the state of a fund account validation
+type RazorpayValidationResponse struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"` // created, completed, failed
+	Results struct {
+		AccountStatus  string `json:"account_status"` // active or invalid
+		RegisteredName string `json:"registered_name"`
+	} `json:"results"`
+}
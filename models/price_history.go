@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CarPriceChange is one change of a car's daily price
+type CarPriceChange struct {
+	ID            uuid.UUID  `json:"id"`
+	CarID         uuid.UUID  `json:"car_id"`
+	OldPrice      *float64   `json:"old_price,omitempty"` // Absent for the price the car was listed at
+	NewPrice      float64    `json:"new_price"`
+	ChangedBy     *uuid.UUID `json:"changed_by,omitempty"` // Absent for background jobs and deleted users
+	ChangedByRole string     `json:"changed_by_role"`      // owner, admin or system
+	ChangedAt     time.Time  `json:"changed_at"`
+}
+
+// CarPriceDrop marks a car whose latest price change was a reduction, shown as a "price
+// dropped" badge on listings
+type CarPriceDrop struct {
+	PreviousPrice float64   `json:"previous_price"`
+	PercentOff    float64   `json:"percent_off"` // Rounded to whole percent
+	DroppedAt     time.Time `json:"dropped_at"`
+}
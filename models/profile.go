@@ -0,0 +1,50 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PublicProfile is what anyone may see about a car owner, shown on car detail pages.
+// It is filled field by field rather than from User, so fields added to User are never
+// exposed by accident.
+type PublicProfile struct {
+	ID             uuid.UUID       `json:"id"`
+	DisplayName    string          `json:"display_name"`
+	MemberSince    time.Time       `json:"member_since"` // First day of the month the account was created
+	Rating         *float64        `json:"rating"`       // Out of 5; null until the owner's first completed trip
+	CompletedTrips int             `json:"completed_trips"`
+	Response       ResponseMetrics `json:"response"`
+	ActiveListings []PublicCar     `json:"active_listings"`
+}
+
+// ResponseMetrics describes how an owner handles booking requests
+type ResponseMetrics struct {
+	AcceptanceRate  *float64 `json:"acceptance_rate"` // Share of decided bookings not cancelled; null until the first decision
+	DecidedBookings int      `json:"decided_bookings"`
+}
+
+// OwnerStats are the booking counts an owner's public profile is derived from
+type OwnerStats struct {
+	CompletedTrips   int // Completed bookings of the owner's cars
+	CleanTrips       int // Completed bookings returned on time without a payment dispute
+	DecidedBookings  int // Bookings confirmed or cancelled, including those that went on to start or complete
+	AcceptedBookings int // Decided bookings that were not cancelled
+}
+
+// Apply fills the profile's rating and response metrics from the stats. The rating is the
+// share of clean trips on a scale of 5, as there are no renter reviews yet.
+func (s OwnerStats) Apply(profile *PublicProfile) {
+	profile.CompletedTrips = s.CompletedTrips
+	if s.CompletedTrips > 0 {
+		rating := math.Round(50*float64(s.CleanTrips)/float64(s.CompletedTrips)) / 10
+		profile.Rating = &rating
+	}
+	profile.Response.DecidedBookings = s.DecidedBookings
+	if s.DecidedBookings > 0 {
+		rate := math.Round(100*float64(s.AcceptedBookings)/float64(s.DecidedBookings)) / 100
+		profile.Response.AcceptanceRate = &rate
+	}
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Profile data keys users may set themselves. Other keys in profile_data, such as verified or
+// rating, are kept by the system and cannot be changed through a profile update.
+const (
+	ProfileDisplayName   = "display_name"   // Name shown on an owner's public profile
+	ProfileAddress       = "address"        // Postal address, see profileAddressKeys
+	ProfilePreferences   = "preferences"    // Free-form settings of the user's apps, e.g. language
+	ProfileLicenseNumber = "license_number" // Driving licence number; kept encrypted in its own column, not in profile_data
+)
+
+// profileAddressKeys are the fields of a profile address
+var profileAddressKeys = map[string]bool{
+	"line1": true, "line2": true, "city": true, "state": true, "postal_code": true, "country": true,
+}
+
+// Limits on what users keep in their profile
+const (
+	maxDisplayNameLength = 50
+	maxAddressLength     = 100
+	maxPreferences       = 20
+	maxPreferenceLength  = 100
+)
+
+var preferenceKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+
+// ValidateProfileDataPatch validates a merge patch of a user's profile data (RFC 7396): keys
+// set to null are removed, objects are merged key by key and other values replace what is
+// stored. Returns nil when valid, otherwise an error.
+func ValidateProfileDataPatch(patch map[string]interface{}) error {
+	if len(patch) == 0 {
+		return errors.New("profile update must set at least one of: display_name, address, preferences, license_number")
+	}
+
+	for key, value := range patch {
+		switch key {
+		case ProfileDisplayName:
+			if value == nil {
+				continue
+			}
+			name, ok := value.(string)
+			if !ok || strings.TrimSpace(name) == "" || utf8.RuneCountInString(name) > maxDisplayNameLength {
+				return fmt.Errorf("display_name must be a string of 1 to %d characters", maxDisplayNameLength)
+			}
+		case ProfileAddress:
+			if err := validateProfileAddress(value); err != nil {
+				return err
+			}
+		case ProfilePreferences:
+			if err := validateProfilePreferences(value); err != nil {
+				return err
+			}
+		case ProfileLicenseNumber:
+			if value == nil {
+				continue
+			}
+			license, ok := value.(string)
+			if !ok {
+				return errors.New("license_number must be a string")
+			}
+			if err := validateLicenseNumber(license); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("profile field %q cannot be set; allowed fields are display_name, address, preferences, license_number", key)
+		}
+	}
+	return nil
+}
+
+// validateProfileAddress checks an address patch: null, or an object of known address fields
+// holding strings or null
+func validateProfileAddress(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	address, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New("address must be an object")
+	}
+	for key, field := range address {
+		if !profileAddressKeys[key] {
+			return fmt.Errorf("address field %q is not allowed; allowed fields are line1, line2, city, state, postal_code, country", key)
+		}
+		if field == nil {
+			continue
+		}
+		text, ok := field.(string)
+		if !ok || utf8.RuneCountInString(text) > maxAddressLength {
+			return fmt.Errorf("address %s must be a string of at most %d characters", key, maxAddressLength)
+		}
+	}
+	return nil
+}
+
+// validateProfilePreferences checks a preferences patch: null, or an object of lower-case keys
+// holding short strings, numbers, booleans or null
+func validateProfilePreferences(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	preferences, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New("preferences must be an object")
+	}
+	if len(preferences) > maxPreferences {
+		return fmt.Errorf("preferences must have at most %d keys", maxPreferences)
+	}
+	for key, preference := range preferences {
+		if !preferenceKeyPattern.MatchString(key) {
+			return fmt.Errorf("preference key %q must be lower-case letters, digits and underscores, at most 40 characters", key)
+		}
+		switch v := preference.(type) {
+		case nil, bool, float64:
+		case string:
+			if utf8.RuneCountInString(v) > maxPreferenceLength {
+				return fmt.Errorf("preference %s must be at most %d characters", key, maxPreferenceLength)
+			}
+		default:
+			return fmt.Errorf("preference %s must be a string, number or boolean", key)
+		}
+	}
+	return nil
+}
+
+// MergeProfileData applies a validated merge patch to a user's profile data and returns the
+// result. The licence number is not profile data and is left out.
+func MergeProfileData(current, patch map[string]interface{}) (map[string]interface{}, error) {
+	merged := make(map[string]interface{}, len(current)+len(patch))
+	for key, value := range current {
+		merged[key] = value
+	}
+	for key, value := range patch {
+		if key == ProfileLicenseNumber {
+			continue
+		}
+		merged[key] = mergePatchValue(merged[key], value)
+		if merged[key] == nil {
+			delete(merged, key)
+		}
+	}
+	// Preferences are merged, so the limit applies to what they add up to
+	if preferences, ok := merged[ProfilePreferences].(map[string]interface{}); ok && len(preferences) > maxPreferences {
+		return nil, fmt.Errorf("preferences must have at most %d keys", maxPreferences)
+	}
+	return merged, nil
+}
+
+// mergePatchValue merges one patch value into the stored one as RFC 7396 describes
+func mergePatchValue(current, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	currentObject, _ := current.(map[string]interface{})
+	merged := make(map[string]interface{}, len(currentObject)+len(patchObject))
+	for key, value := range currentObject {
+		merged[key] = value
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = mergePatchValue(merged[key], value)
+	}
+	return merged
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Listing quality thresholds. The store scores the same checks in SQL for ranked listings, so
+// changing them means changing carQualitySignal in the car store too.
+const (
+	QualityPhotoTarget       = 5   // Photos that earn the full photo share
+	QualityDescriptionTarget = 300 // Description characters that earn the full description share
+)
+
+// Share of the quality score each check can earn; they add up to 1
+const (
+	qualityPhotosShare      = 0.3
+	qualityDescriptionShare = 0.25
+	qualitySpecsShare       = 0.25
+	qualityVerifiedShare    = 0.2
+)
+
+// CarQualityCheck names one check of a listing's quality
+type CarQualityCheck string
+
+const (
+	CarQualityPhotos      CarQualityCheck = "photos"             // Number of photos
+	CarQualityDescription CarQualityCheck = "description"        // Length of the description
+	CarQualitySpecs       CarQualityCheck = "specs"              // Horsepower, mileage, features and registration plate filled in
+	CarQualityVerified    CarQualityCheck = "verified_documents" // Owner's identity documents checked by an admin
+)
+
+// CarQualityFacts is what a listing's quality is computed from
+type CarQualityFacts struct {
+	PhotoCount        int
+	DescriptionLength int  // In characters
+	HasHorsepower     bool // Horsepower above 0
+	HasMileage        bool // Mileage above 0
+	HasFeatures       bool // At least one feature
+	HasLicensePlate   bool
+	OwnerVerified     bool // Owner has verified KYC; cars without an owner are run by the operator and count as verified
+}
+
+// CarQualityItem is the result of one check: the share of the score it earned out of the most
+// it can earn, and what the owner can do to earn the rest
+type CarQualityItem struct {
+	Check  CarQualityCheck `json:"check"`
+	Earned float64         `json:"earned"`
+	Max    float64         `json:"max"`
+	Hint   string          `json:"hint,omitempty"` // Empty once the check earns its full share
+}
+
+// CarQuality is a listing's quality score between 0 and 1 with the checks it was computed from.
+// Search ranking can weigh the score, see RankingWeights.Quality.
+type CarQuality struct {
+	CarID  uuid.UUID        `json:"car_id"`
+	Score  float64          `json:"score"`
+	Checks []CarQualityItem `json:"checks"`
+}
+
+// ScoreCarQuality computes a listing's quality score and the hints to improve it
+func ScoreCarQuality(carID uuid.UUID, facts CarQualityFacts) CarQuality {
+	quality := CarQuality{CarID: carID}
+
+	photos := CarQualityItem{Check: CarQualityPhotos, Max: qualityPhotosShare,
+		Earned: qualityPhotosShare * float64(min(facts.PhotoCount, QualityPhotoTarget)) / QualityPhotoTarget}
+	if facts.PhotoCount < QualityPhotoTarget {
+		photos.Hint = fmt.Sprintf("Add %d more photo(s); listings with %d photos rank better", QualityPhotoTarget-facts.PhotoCount, QualityPhotoTarget)
+	}
+
+	description := CarQualityItem{Check: CarQualityDescription, Max: qualityDescriptionShare,
+		Earned: qualityDescriptionShare * float64(min(facts.DescriptionLength, QualityDescriptionTarget)) / QualityDescriptionTarget}
+	if facts.DescriptionLength < QualityDescriptionTarget {
+		description.Hint = fmt.Sprintf("Write a description of at least %d characters covering the car's condition, pickup and rules", QualityDescriptionTarget)
+	}
+
+	var missing []string
+	for _, spec := range []struct {
+		set  bool
+		name string
+	}{
+		{facts.HasHorsepower, "horsepower"},
+		{facts.HasMileage, "mileage"},
+		{facts.HasFeatures, "features"},
+		{facts.HasLicensePlate, "registration plate"},
+	} {
+		if !spec.set {
+			missing = append(missing, spec.name)
+		}
+	}
+	specs := CarQualityItem{Check: CarQualitySpecs, Max: qualitySpecsShare,
+		Earned: qualitySpecsShare * float64(4-len(missing)) / 4}
+	if len(missing) > 0 {
+		specs.Hint = "Fill in the missing specs: " + strings.Join(missing, ", ")
+	}
+
+	verified := CarQualityItem{Check: CarQualityVerified, Max: qualityVerifiedShare}
+	if facts.OwnerVerified {
+		verified.Earned = qualityVerifiedShare
+	} else {
+		verified.Hint = "Complete identity verification so renters see a verified owner"
+	}
+
+	quality.Checks = []CarQualityItem{photos, description, specs, verified}
+	for i, item := range quality.Checks {
+		quality.Checks[i].Earned = math.Round(item.Earned*1000) / 1000
+		quality.Score += item.Earned
+	}
+	quality.Score = math.Round(quality.Score*1000) / 1000
+	return quality
+}
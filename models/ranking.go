@@ -0,0 +1,114 @@
+package models
+
+import (
+	"errors"
+	"math"
+)
+
+// CarSort names the order of a car listing
+type CarSort string
+
+const (
+	CarSortNewest CarSort = "newest" // Newest first, cursor-paginated (the default)
+	CarSortRank   CarSort = "rank"   // Highest ranking score first; a single page without cursors
+)
+
+// ParseCarSort parses the sort query parameter of a car listing
+func ParseCarSort(raw string) (CarSort, error) {
+	switch CarSort(raw) {
+	case "", CarSortNewest:
+		return CarSortNewest, nil
+	case CarSortRank:
+		return CarSortRank, nil
+	}
+	return "", errors.New("sort must be newest or rank")
+}
+
+// maxRankingWeight bounds each ranking weight so no signal can swamp the others by accident
+const maxRankingWeight = 10
+
+// RankingWeights weighs each ranking signal of a car. A car's score is the sum of its signals,
+// each between 0 and 1, times their weights.
+type RankingWeights struct {
+	Recency              float64 `json:"recency"`               // Newer listings
+	Rating               float64 `json:"rating"`                // Trips completed on time and without a dispute
+	AcceptanceRate       float64 `json:"acceptance_rate"`       // Owners who accept the bookings they receive
+	PriceCompetitiveness float64 `json:"price_competitiveness"` // Cheaper than the city's median daily price
+	PhotoCount           float64 `json:"photo_count"`           // Listings with more photos, up to five
+	Featured             float64 `json:"featured"`              // Cars whose owner paid for featured placement
+	Quality              float64 `json:"quality"`               // Complete listings by verified owners, see CarQuality
+}
+
+// DefaultRankingWeights apply until an admin configures the search ranking
+var DefaultRankingWeights = RankingWeights{
+	Recency:              1,
+	Rating:               3,
+	AcceptanceRate:       2,
+	PriceCompetitiveness: 2,
+	PhotoCount:           1,
+	Featured:             5,
+	Quality:              0, // Off until an admin opts in; photo count already rewards part of it
+}
+
+// ValidateRankingWeights validates RankingWeights. Returns nil when valid, otherwise an error.
+func ValidateRankingWeights(w RankingWeights) error {
+	var total float64
+	for _, weight := range w.Values() {
+		if math.IsNaN(weight) || weight < 0 || weight > maxRankingWeight {
+			return errors.New("ranking weights must be between 0 and 10")
+		}
+		total += weight
+	}
+	if total == 0 {
+		return errors.New("at least one ranking weight must be above 0")
+	}
+	return nil
+}
+
+// Values returns the weights in RankingSignals order, for stores that score in SQL
+func (w RankingWeights) Values() []float64 {
+	return []float64{w.Recency, w.Rating, w.AcceptanceRate, w.PriceCompetitiveness, w.PhotoCount, w.Featured, w.Quality}
+}
+
+// RankingSignals are what a car is ranked on, each between 0 and 1. Cars without booking
+// history get a neutral 0.5 for rating and acceptance rate; featured is 1 while a paid
+// placement runs and 0 otherwise. Quality is the listing's CarQuality score.
+type RankingSignals struct {
+	Recency              float64 `json:"recency"`
+	Rating               float64 `json:"rating"`
+	AcceptanceRate       float64 `json:"acceptance_rate"`
+	PriceCompetitiveness float64 `json:"price_competitiveness"`
+	PhotoCount           float64 `json:"photo_count"`
+	Featured             float64 `json:"featured"`
+	Quality              float64 `json:"quality"`
+}
+
+// CarRanking explains a car's place in a ranked listing: its score, the signals it was computed
+// from and how much each contributed under the weights in force
+type CarRanking struct {
+	Score         float64        `json:"score"`
+	Signals       RankingSignals `json:"signals"`
+	Contributions RankingSignals `json:"contributions"` // Signal times weight; they add up to the score
+	Weights       RankingWeights `json:"weights"`
+}
+
+// Rank scores a car's signals under the weights
+func (w RankingWeights) Rank(signals RankingSignals) CarRanking {
+	contributions := RankingSignals{
+		Recency:              w.Recency * signals.Recency,
+		Rating:               w.Rating * signals.Rating,
+		AcceptanceRate:       w.AcceptanceRate * signals.AcceptanceRate,
+		PriceCompetitiveness: w.PriceCompetitiveness * signals.PriceCompetitiveness,
+		PhotoCount:           w.PhotoCount * signals.PhotoCount,
+		Featured:             w.Featured * signals.Featured,
+		Quality:              w.Quality * signals.Quality,
+	}
+	return CarRanking{
+		Score: contributions.Recency + contributions.Rating + contributions.AcceptanceRate +
+			contributions.PriceCompetitiveness + contributions.PhotoCount + contributions.Featured +
+			contributions.Quality,
+		Signals:       signals,
+		Contributions: contributions,
+		Weights:       w,
+	}
+}
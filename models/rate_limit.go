@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// RateLimitStatus describes the caller's quota in the current rate limit window
+type RateLimitStatus struct {
+	Scope     string    `json:"scope"`     // "user" for authenticated callers, "ip" otherwise
+	Limit     int       `json:"limit"`     // Requests allowed per window
+	Remaining int       `json:"remaining"` // Requests left in the current window
+	Reset     time.Time `json:"reset"`     // When the current window ends
+	Window    string    `json:"window"`    // Window length, e.g. "1m0s"
+	Enforced  bool      `json:"enforced"`  // Whether requests over the limit are rejected with 429
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is a long-lived credential exchanged at /auth/refresh for a new access token.
+// Only the SHA-256 hash of the token is stored. Each exchange rotates it: the token is used up
+// and a new one of the same family, the chain started by one login, takes its place.
+type RefreshToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	FamilyID  uuid.UUID  `json:"family_id"` // Shared by every token rotated from the same login
+	ExpiresAt time.Time  `json:"expires_at"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty"` // When it was exchanged for its successor
+	RevokedAt *time.Time `json:"revoked_at,omitempty"` // When its session was ended by logout, reuse or a password reset
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IssuedRefreshToken is a refresh token as handed to a client, the only time the token itself
+// is known
+type IssuedRefreshToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// RefreshRequest is the payload to exchange a refresh token. Browser clients may leave it empty
+// and rely on the refresh_token cookie instead.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// RiskStage identifies the point in the booking flow at which risk is assessed
+type RiskStage string
+
+const (
+	RiskStageBookingConfirmation RiskStage = "booking_confirmation" // Before a pending booking is confirmed
+	RiskStagePaymentCapture      RiskStage = "payment_capture"      // Before a verified payment is marked completed
+)
+
+// RiskSubject is everything a risk rule may inspect about a booking attempt
+type RiskSubject struct {
+	Stage     RiskStage
+	Booking   Booking
+	Customer  User
+	IPAddress string // Client IP of the request, empty when unknown
+	Country   string // ISO 3166-1 alpha-2 country of the request, empty when unknown
+}
+
+// RiskSignal is one rule's contribution to a risk score
+type RiskSignal struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Reason string `json:"reason"`
+}
+
+// RiskAssessment is the combined outcome of all risk rules for one booking attempt
+type RiskAssessment struct {
+	Stage          RiskStage    `json:"stage"`
+	Score          int          `json:"score"`
+	Threshold      int          `json:"threshold"`
+	RequiresReview bool         `json:"requires_review"` // Score reached the threshold; hold for manual review
+	Signals        []RiskSignal `json:"signals,omitempty"`
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SearchHitType names the kind of record a support search hit points at
+type SearchHitType string
+
+const (
+	SearchHitUser    SearchHitType = "user"
+	SearchHitCar     SearchHitType = "car"
+	SearchHitBooking SearchHitType = "booking"
+	SearchHitPayment SearchHitType = "payment"
+)
+
+// MaxSearchHits caps how many hits one support search returns
+const MaxSearchHits = 50
+
+// SearchQuery is a support search term with every interpretation it can be matched as.
+// Interpretations that do not apply are nil, so their lookups match nothing.
+type SearchQuery struct {
+	Text         string     // Trimmed term, matched against payment gateway and transaction IDs
+	Email        *string    // Lower-cased e-mail address
+	PhoneKey     *string    // PhoneSearchKey of a phone number
+	LicensePlate *string    // NormalizeLicensePlate of a registration plate
+	ID           *uuid.UUID // Record ID of any type
+}
+
+// SearchHit is one record found by a support search
+type SearchHit struct {
+	Type      SearchHitType `json:"type"`
+	ID        uuid.UUID     `json:"id"`
+	Title     string        `json:"title"`
+	Detail    string        `json:"detail"`
+	MatchedOn string        `json:"matched_on"` // e.g. email, phone, license_plate, payment_id, customer_email
+	Link      string        `json:"link"`       // API path of the record
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// ParseSearchQuery interprets a support search term. Returns an error when the term is too short
+// or too long to search for.
+func ParseSearchQuery(q string) (SearchQuery, error) {
+	text := strings.TrimSpace(q)
+	if len(text) < 3 {
+		return SearchQuery{}, errors.New("search term must be at least 3 characters long")
+	}
+	if len(text) > 100 {
+		return SearchQuery{}, errors.New("search term must be at most 100 characters long")
+	}
+
+	query := SearchQuery{Text: text}
+	if strings.Contains(text, "@") {
+		email := strings.ToLower(text)
+		query.Email = &email
+	}
+	if strings.Trim(text, "+0123456789 -()") == "" {
+		if key := PhoneSearchKey(text); len(key) == 10 {
+			query.PhoneKey = &key
+		}
+	}
+	if validateLicensePlate(text) == nil {
+		plate := NormalizeLicensePlate(text)
+		query.LicensePlate = &plate
+	}
+	if id, err := uuid.Parse(text); err == nil {
+		query.ID = &id
+	}
+
+	return query, nil
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventType identifies the suspicious pattern that raised a security event
+type SecurityEventType string
+
+const (
+	SecurityEventNewLoginLocation          SecurityEventType = "login_new_device"            // Login from an IP address or device not seen before
+	SecurityEventRepeatedPaymentFailures   SecurityEventType = "repeated_payment_failures"   // Many failed payment verifications in a short window
+	SecurityEventRapidBookingCancellations SecurityEventType = "rapid_booking_cancellations" // Many bookings cancelled in a short window
+	SecurityEventHighRiskBooking           SecurityEventType = "high_risk_booking"           // Booking held for manual review by risk scoring
+)
+
+// SecurityEventSeverity ranks how urgently an event should be reviewed
+type SecurityEventSeverity string
+
+const (
+	SecurityEventSeverityLow    SecurityEventSeverity = "low"
+	SecurityEventSeverityMedium SecurityEventSeverity = "medium"
+	SecurityEventSeverityHigh   SecurityEventSeverity = "high"
+)
+
+// SecurityEventStatus represents where an event is in the admin review queue
+type SecurityEventStatus string
+
+const (
+	SecurityEventStatusOpen      SecurityEventStatus = "open"      // Waiting for admin review
+	SecurityEventStatusReviewed  SecurityEventStatus = "reviewed"  // Confirmed and acted upon
+	SecurityEventStatusDismissed SecurityEventStatus = "dismissed" // Reviewed and judged harmless
+)
+
+// SecurityEvent records suspicious account activity for admin review
+type SecurityEvent struct {
+	ID          uuid.UUID              `json:"id"`
+	UserID      uuid.UUID              `json:"user_id"`
+	Type        SecurityEventType      `json:"type"`
+	Severity    SecurityEventSeverity  `json:"severity"`
+	Status      SecurityEventStatus    `json:"status"`
+	IPAddress   string                 `json:"ip_address,omitempty"`
+	UserAgent   string                 `json:"user_agent,omitempty"`
+	Details     map[string]interface{} `json:"details,omitempty"` // Type-specific context, e.g. failure counts
+	ReviewedBy  *uuid.UUID             `json:"reviewed_by,omitempty"`
+	ReviewNotes *string                `json:"review_notes,omitempty"`
+	ReviewedAt  *time.Time             `json:"reviewed_at,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// PageCursor returns the pagination cursor pointing at this security event
+func (e SecurityEvent) PageCursor() Cursor {
+	return Cursor{CreatedAt: e.CreatedAt, ID: e.ID}
+}
+
+// SecurityEventFilter narrows the admin review queue; empty fields are ignored
+type SecurityEventFilter struct {
+	Status string
+	Type   string
+	UserID string
+}
+
+// SecurityEventReview is the payload an admin submits to close a security event
+type SecurityEventReview struct {
+	Status SecurityEventStatus `json:"status"`
+	Notes  string              `json:"notes,omitempty"`
+}
+
+// ValidateSecurityEventReview validates a SecurityEventReview. Returns nil when valid, otherwise an error.
+func ValidateSecurityEventReview(review SecurityEventReview) error {
+	if review.Status != SecurityEventStatusReviewed && review.Status != SecurityEventStatusDismissed {
+		return errors.New("status must be 'reviewed' or 'dismissed'")
+	}
+	if len(strings.TrimSpace(review.Notes)) > 1000 {
+		return errors.New("notes must be at most 1000 characters")
+	}
+	return nil
+}
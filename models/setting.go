@@ -0,0 +1,19 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Setting keys an operator can configure through /admin/settings
+const (
+	SettingSearchRanking = "search_ranking" // RankingWeights of ranked car listings
+	SettingDepreciation  = "depreciation"   // DepreciationSettings of the fleet valuation report
+)
+
+// Setting is one configurable value of an operator, stored as JSON
+type Setting struct {
+	Key       string          `json:"key"`
+	Value     json.RawMessage `json:"value"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SmartLock links a car to the lock fitted in it at a smart-lock provider
+type SmartLock struct {
+	CarID     uuid.UUID `json:"car_id"`
+	Provider  string    `json:"provider"` // Name of the provider integration, e.g. "http"
+	LockRef   string    `json:"lock_ref"` // The provider's identifier of the lock
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SmartLockRequest is the payload to link a car to its lock
+type SmartLockRequest struct {
+	Provider string `json:"provider"`
+	LockRef  string `json:"lock_ref"`
+}
+
+var lockRefPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,100}$`)
+
+// ValidateSmartLockRequest validates SmartLockRequest. Returns nil when valid, otherwise an error.
+// Whether the provider is configured is checked by the smart lock service.
+func ValidateSmartLockRequest(req SmartLockRequest) error {
+	if req.Provider == "" {
+		return errors.New("provider is required")
+	}
+	if !lockRefPattern.MatchString(req.LockRef) {
+		return errors.New("lock_ref must be 1 to 100 letters, digits or . _ : - characters")
+	}
+	return nil
+}
+
+// DigitalKeyStatus is the state of a digital key at its provider
+type DigitalKeyStatus string
+
+const (
+	DigitalKeyStatusIssued  DigitalKeyStatus = "issued"  // Opens the car between valid_from and valid_until
+	DigitalKeyStatusRevoked DigitalKeyStatus = "revoked" // Withdrawn when the booking was completed or cancelled
+	DigitalKeyStatusFailed  DigitalKeyStatus = "failed"  // The provider did not issue it; see error
+)
+
+// DigitalKey is a time-boxed key to a car's smart lock issued for a booking
+type DigitalKey struct {
+	ID            uuid.UUID        `json:"id"`
+	BookingID     uuid.UUID        `json:"booking_id"`
+	CarID         uuid.UUID        `json:"car_id"`
+	Provider      string           `json:"provider"`
+	LockRef       string           `json:"lock_ref"`
+	ProviderKeyID *string          `json:"provider_key_id,omitempty"` // The provider's identifier of the key; unset when issuing failed
+	ValidFrom     time.Time        `json:"valid_from"`
+	ValidUntil    time.Time        `json:"valid_until"`
+	Status        DigitalKeyStatus `json:"status"`
+	Error         *string          `json:"error,omitempty"` // Why the provider did not issue the key
+	CreatedAt     time.Time        `json:"created_at"`
+	RevokedAt     *time.Time       `json:"revoked_at,omitempty"`
+}
+
+// DigitalKeyGrant is what a provider is asked to issue for a booking
+type DigitalKeyGrant struct {
+	BookingID  uuid.UUID
+	LockRef    string
+	ValidFrom  time.Time
+	ValidUntil time.Time
+}
+
+// LockAction is what happened to a lock
+type LockAction string
+
+const (
+	LockActionLock   LockAction = "lock"
+	LockActionUnlock LockAction = "unlock"
+)
+
+// LockEvent is a lock or unlock of a car reported by its smart-lock provider. Events during a
+// booking's key are logged against the booking; others, such as an owner opening the car between
+// rentals, have none.
+type LockEvent struct {
+	ID              uuid.UUID  `json:"id"`
+	CarID           uuid.UUID  `json:"car_id"`
+	BookingID       *uuid.UUID `json:"booking_id,omitempty"`
+	DigitalKeyID    *uuid.UUID `json:"digital_key_id,omitempty"` // The key that was used, when the provider said or it could be told from the time
+	Action          LockAction `json:"action"`
+	ProviderEventID string     `json:"provider_event_id"`
+	OccurredAt      time.Time  `json:"occurred_at"` // When the lock reported it
+	ReceivedAt      time.Time  `json:"received_at"` // When the API stored it
+}
+
+// SmartLockWebhookEvent is the JSON body a smart-lock provider posts to /webhooks/smart-locks
+type SmartLockWebhookEvent struct {
+	ID            string     `json:"id"`       // The provider's event ID; deliveries of the same event are stored once
+	Provider      string     `json:"provider"` // Name of the provider integration the lock is linked with
+	LockRef       string     `json:"lock_ref"`
+	Action        LockAction `json:"action"`
+	ProviderKeyID string     `json:"key_id,omitempty"` // The digital key used, if the provider knows
+	OccurredAt    time.Time  `json:"occurred_at"`
+}
+
+// ValidateSmartLockWebhookEvent validates SmartLockWebhookEvent. Returns nil when valid, otherwise an error.
+func ValidateSmartLockWebhookEvent(event SmartLockWebhookEvent) error {
+	if event.ID == "" || event.Provider == "" || event.LockRef == "" {
+		return errors.New("invalid webhook payload: id, provider and lock_ref are required")
+	}
+	if event.Action != LockActionLock && event.Action != LockActionUnlock {
+		return errors.New("invalid webhook payload: action must be lock or unlock")
+	}
+	if event.OccurredAt.IsZero() {
+		return errors.New("invalid webhook payload: occurred_at is required")
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatementFormat is the file format a payment statement is rendered in
+type StatementFormat string
+
+const (
+	StatementFormatPDF StatementFormat = "pdf"
+	StatementFormatCSV StatementFormat = "csv"
+)
+
+// ContentType returns the MIME type of a statement file
+func (f StatementFormat) ContentType() string {
+	if f == StatementFormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/pdf"
+}
+
+// StatementStatus tracks a statement rendered in the background
+type StatementStatus string
+
+const (
+	StatementStatusPending   StatementStatus = "pending"   // Waiting for the render job
+	StatementStatusRendering StatementStatus = "rendering" // Claimed by the render job
+	StatementStatusReady     StatementStatus = "ready"     // File can be downloaded
+	StatementStatusFailed    StatementStatus = "failed"    // Rendering failed, see error
+)
+
+// MaxStatementDays is the longest period one statement covers
+const MaxStatementDays = 5 * 366
+
+// StatementRequest is a validated request for a statement. From is the first day covered and
+// To the day after the last one, both at midnight India time.
+type StatementRequest struct {
+	From   time.Time
+	To     time.Time
+	Format StatementFormat
+}
+
+// Days returns the number of days a statement covers
+func (r StatementRequest) Days() int {
+	return int(r.To.Sub(r.From).Hours()/24 + 0.5)
+}
+
+// FileName returns the download name of the statement file, e.g.
+// carzone-statement-2024-04-01-to-2024-06-30.pdf
+func (r StatementRequest) FileName() string {
+	return fmt.Sprintf("carzone-statement-%s-to-%s.%s", r.From.Format("2006-01-02"),
+		r.To.AddDate(0, 0, -1).Format("2006-01-02"), r.Format)
+}
+
+// PaymentStatement is a statement queued for rendering because its period was too long to
+// render during the request
+type PaymentStatement struct {
+	ID          uuid.UUID       `json:"id"`
+	UserID      uuid.UUID       `json:"user_id"`
+	From        time.Time       `json:"from"` // First day covered, as a date
+	To          time.Time       `json:"to"`   // Day after the last day covered, as a date
+	Format      StatementFormat `json:"format"`
+	Status      StatementStatus `json:"status"`
+	Error       string          `json:"error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+// Request returns the period and format the statement was requested for. The period is stored
+// as dates, which are read back at midnight UTC, so they are moved to midnight India time.
+func (s PaymentStatement) Request() StatementRequest {
+	return StatementRequest{From: indiaMidnight(s.From), To: indiaMidnight(s.To), Format: s.Format}
+}
+
+// indiaMidnight returns midnight India time on the calendar date of t
+func indiaMidnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, indiaStandardTime)
+}
+
+// StatementFile is a rendered statement
+type StatementFile struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"-"`
+}
+
+// StatementLineKind tells payments and refunds apart on a statement
+type StatementLineKind string
+
+const (
+	StatementLinePayment StatementLineKind = "payment"
+	StatementLineRefund  StatementLineKind = "refund"
+)
+
+// StatementLine is one payment or refund on a statement. Refunds carry a negative amount.
+type StatementLine struct {
+	Date          time.Time         `json:"date"`
+	Kind          StatementLineKind `json:"kind"`
+	PaymentID     uuid.UUID         `json:"payment_id"`
+	BookingID     uuid.UUID         `json:"booking_id"`
+	Description   string            `json:"description"`
+	Method        PaymentMethod     `json:"method"`
+	TransactionID string            `json:"transaction_id,omitempty"`
+	Amount        float64           `json:"amount"` // INR
+}
+
+// Statement is the content of a statement file
+type Statement struct {
+	CustomerName  string          `json:"customer_name"`
+	CustomerEmail string          `json:"customer_email"`
+	From          time.Time       `json:"from"`
+	To            time.Time       `json:"to"`
+	Lines         []StatementLine `json:"lines"`
+	TotalPaid     float64         `json:"total_paid"`
+	TotalRefunded float64         `json:"total_refunded"`
+	GeneratedAt   time.Time       `json:"generated_at"`
+}
+
+// ParseStatementRequest validates the from, to and format query parameters of a statement
+// request. Dates are YYYY-MM-DD in India time and both are included; format defaults to pdf.
+func ParseStatementRequest(fromParam, toParam, formatParam string) (StatementRequest, error) {
+	var req StatementRequest
+
+	req.Format = StatementFormat(formatParam)
+	switch req.Format {
+	case "":
+		req.Format = StatementFormatPDF
+	case StatementFormatPDF, StatementFormatCSV:
+	default:
+		return req, errors.New("format must be one of: pdf, csv")
+	}
+
+	from, to, err := parsePeriod(fromParam, toParam, MaxStatementDays)
+	if err != nil {
+		return req, err
+	}
+
+	req.From = from
+	req.To = to
+	return req, nil
+}
+
+// parsePeriod validates from and to dates, YYYY-MM-DD in India time and both included, of a
+// period of at most maxDays. It returns midnight of the first day and of the day after the last.
+func parsePeriod(fromParam, toParam string, maxDays int) (time.Time, time.Time, error) {
+	if fromParam == "" || toParam == "" {
+		return time.Time{}, time.Time{}, errors.New("from and to are required")
+	}
+	from, err := time.ParseInLocation("2006-01-02", fromParam, indiaStandardTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("from must be a YYYY-MM-DD date")
+	}
+	to, err := time.ParseInLocation("2006-01-02", toParam, indiaStandardTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("to must be a YYYY-MM-DD date")
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+
+	to = to.AddDate(0, 0, 1)
+	if int(to.Sub(from).Hours()/24+0.5) > maxDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("period must be at most %d days", maxDays)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TelemetryReading is one data point reported by a car's telematics device
+type TelemetryReading struct {
+	ID           uuid.UUID `json:"id"`
+	CarID        uuid.UUID `json:"car_id"`
+	DeviceID     uuid.UUID `json:"device_id"`
+	RecordedAt   time.Time `json:"recorded_at"` // When the device took the reading
+	ReceivedAt   time.Time `json:"received_at"` // When the API stored it
+	Latitude     *float64  `json:"latitude,omitempty"`
+	Longitude    *float64  `json:"longitude,omitempty"`
+	OdometerKm   *int      `json:"odometer_km,omitempty"`
+	FuelLevel    *float64  `json:"fuel_level,omitempty"`    // Percentage of tank capacity, 0-100
+	BatteryLevel *float64  `json:"battery_level,omitempty"` // State of charge for EVs, 0-100
+}
+
+// TelemetryPayload is the JSON body a device posts to /telemetry
+type TelemetryPayload struct {
+	RecordedAt   time.Time `json:"recorded_at"`
+	Latitude     *float64  `json:"latitude,omitempty"`
+	Longitude    *float64  `json:"longitude,omitempty"`
+	OdometerKm   *int      `json:"odometer_km,omitempty"`
+	FuelLevel    *float64  `json:"fuel_level,omitempty"`
+	BatteryLevel *float64  `json:"battery_level,omitempty"`
+}
+
+// TelemetryDevice is a telematics unit bound to one car. Its secret signs every payload.
+type TelemetryDevice struct {
+	ID         uuid.UUID  `json:"id"`
+	CarID      uuid.UUID  `json:"car_id"`
+	Secret     string     `json:"-"` // Decrypted HMAC signing secret
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TelemetryDeviceCredentials is returned once when a device is provisioned; the secret is never shown again
+type TelemetryDeviceCredentials struct {
+	DeviceID uuid.UUID `json:"device_id"`
+	CarID    uuid.UUID `json:"car_id"`
+	Secret   string    `json:"secret"`
+}
+
+// ValidateTelemetryPayload validates a TelemetryPayload. Returns nil when valid, otherwise an error.
+func ValidateTelemetryPayload(payload TelemetryPayload) error {
+	if payload.RecordedAt.IsZero() {
+		return errors.New("recorded_at is required")
+	}
+	if payload.RecordedAt.After(time.Now().Add(5 * time.Minute)) {
+		return errors.New("recorded_at cannot be in the future")
+	}
+	if (payload.Latitude == nil) != (payload.Longitude == nil) {
+		return errors.New("latitude and longitude must be sent together")
+	}
+	if payload.Latitude != nil && (*payload.Latitude < -90 || *payload.Latitude > 90) {
+		return errors.New("latitude must be between -90 and 90")
+	}
+	if payload.Longitude != nil && (*payload.Longitude < -180 || *payload.Longitude > 180) {
+		return errors.New("longitude must be between -180 and 180")
+	}
+	if payload.OdometerKm != nil {
+		if err := validateMileage(*payload.OdometerKm); err != nil {
+			return errors.New("odometer_km must be between 0 and 1,000,000")
+		}
+	}
+	if payload.FuelLevel != nil && (*payload.FuelLevel < 0 || *payload.FuelLevel > 100) {
+		return errors.New("fuel_level must be between 0 and 100")
+	}
+	if payload.BatteryLevel != nil && (*payload.BatteryLevel < 0 || *payload.BatteryLevel > 100) {
+		return errors.New("battery_level must be between 0 and 100")
+	}
+	if payload.Latitude == nil && payload.OdometerKm == nil && payload.FuelLevel == nil && payload.BatteryLevel == nil {
+		return errors.New("payload contains no readings")
+	}
+	return nil
+}
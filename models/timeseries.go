@@ -0,0 +1,128 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeSeriesMetric names an aggregate the admin dashboards can chart
+type TimeSeriesMetric string
+
+const (
+	TimeSeriesBookings      TimeSeriesMetric = "bookings"      // Bookings created
+	TimeSeriesRevenue       TimeSeriesMetric = "revenue"       // Completed payment amounts in INR
+	TimeSeriesSignups       TimeSeriesMetric = "signups"       // Users registered
+	TimeSeriesCancellations TimeSeriesMetric = "cancellations" // Bookings cancelled
+)
+
+// TimeSeriesInterval is the bucket width; each value is a valid Postgres date_trunc field
+type TimeSeriesInterval string
+
+const (
+	TimeSeriesHour  TimeSeriesInterval = "hour"
+	TimeSeriesDay   TimeSeriesInterval = "day"
+	TimeSeriesWeek  TimeSeriesInterval = "week"
+	TimeSeriesMonth TimeSeriesInterval = "month"
+)
+
+// MaxTimeSeriesBuckets caps how many buckets one query may return
+const MaxTimeSeriesBuckets = 1000
+
+// TimeSeriesQuery selects a metric, bucket width and half-open [From, To) range
+type TimeSeriesQuery struct {
+	Metric   TimeSeriesMetric
+	Interval TimeSeriesInterval
+	From     time.Time
+	To       time.Time
+}
+
+// TimeSeriesPoint is one bucket of a time series. Buckets without data have a zero value.
+type TimeSeriesPoint struct {
+	BucketStart time.Time `json:"bucket_start"`
+	Value       float64   `json:"value"`
+}
+
+// TimeSeries is a bucketed aggregate ready for charting
+type TimeSeries struct {
+	Metric   TimeSeriesMetric   `json:"metric"`
+	Interval TimeSeriesInterval `json:"interval"`
+	From     time.Time          `json:"from"`
+	To       time.Time          `json:"to"`
+	Total    float64            `json:"total"`
+	Points   []TimeSeriesPoint  `json:"points"`
+}
+
+// approximateDuration is used to bound the number of buckets a query can produce
+func (i TimeSeriesInterval) approximateDuration() time.Duration {
+	switch i {
+	case TimeSeriesHour:
+		return time.Hour
+	case TimeSeriesWeek:
+		return 7 * 24 * time.Hour
+	case TimeSeriesMonth:
+		return 28 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// ParseTimeSeriesQuery builds a TimeSeriesQuery from raw query values. The interval defaults to
+// day, to defaults to now and from defaults to 30 buckets before to. Dates may be RFC 3339
+// timestamps or YYYY-MM-DD.
+func ParseTimeSeriesQuery(metricParam, intervalParam, fromParam, toParam string) (TimeSeriesQuery, error) {
+	query := TimeSeriesQuery{Metric: TimeSeriesMetric(metricParam), Interval: TimeSeriesDay, To: time.Now()}
+
+	switch query.Metric {
+	case TimeSeriesBookings, TimeSeriesRevenue, TimeSeriesSignups, TimeSeriesCancellations:
+	default:
+		return query, errors.New("metric must be bookings, revenue, signups or cancellations")
+	}
+
+	if intervalParam != "" {
+		query.Interval = TimeSeriesInterval(intervalParam)
+	}
+	switch query.Interval {
+	case TimeSeriesHour, TimeSeriesDay, TimeSeriesWeek, TimeSeriesMonth:
+	default:
+		return query, errors.New("interval must be hour, day, week or month")
+	}
+
+	if toParam != "" {
+		to, err := parseTimeSeriesDate(toParam)
+		if err != nil {
+			return query, fmt.Errorf("to %v", err)
+		}
+		query.To = to
+	}
+
+	query.From = query.To.Add(-30 * query.Interval.approximateDuration())
+	if fromParam != "" {
+		from, err := parseTimeSeriesDate(fromParam)
+		if err != nil {
+			return query, fmt.Errorf("from %v", err)
+		}
+		query.From = from
+	}
+
+	if !query.From.Before(query.To) {
+		return query, errors.New("from must be before to")
+	}
+	if query.To.Sub(query.From)/query.Interval.approximateDuration() > MaxTimeSeriesBuckets {
+		return query, fmt.Errorf("range is too long for %s buckets, at most %d buckets are returned", query.Interval, MaxTimeSeriesBuckets)
+	}
+
+	return query, nil
+}
+
+// parseTimeSeriesDate accepts an RFC 3339 timestamp or a plain date in UTC
+func parseTimeSeriesDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.New("must be an RFC 3339 timestamp or YYYY-MM-DD date")
+	}
+	return t, nil
+}
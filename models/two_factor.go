@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// TwoFactor is a user's TOTP two-factor authentication state. Setting it up stores a secret
+// that is not enforced until a code from the authenticator app confirms it; from then on login
+// asks for a code. The secret is encrypted at rest and backup codes are stored as SHA-256 hashes.
+type TwoFactor struct {
+	Secret           string     // Base32 TOTP secret; empty until setup starts
+	EnabledAt        *time.Time // When a code confirmed the secret; nil while setup is pending
+	LastUsedStep     int64      // Time step of the last accepted code, so a code works only once
+	BackupCodeHashes []string   // Unused backup codes
+}
+
+// Enabled reports whether login asks for a second factor
+func (t TwoFactor) Enabled() bool {
+	return t.EnabledAt != nil
+}
+
+// TwoFactorSetup is what an authenticator app needs to start producing codes: the secret to
+// type in, or the otpauth URL to show as a QR code
+type TwoFactorSetup struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauth_url"`
+}
+
+// TwoFactorVerifyRequest is the payload confirming two-factor setup with a code from the app
+type TwoFactorVerifyRequest struct {
+	Code string `json:"code"`
+}
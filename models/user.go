@@ -11,30 +11,39 @@ import (
 // User represents a user account in the system.
 // Fields follow the style used in existing models (UUID, JSON tags, timestamps).
 type User struct {
-	ID           uuid.UUID              `json:"id"`
-	Email        string                 `json:"email"`
-	PasswordHash string                 `json:"password_hash"`
-	UserName     string                 `json:"username"`
-	Phone        string                 `json:"phone"`
-	Role         string                 `json:"role"`
-	ProfileData  map[string]interface{} `json:"profile_data"`
-	CreatedAt    time.Time              `json:"created_at"`
-	UpdatedAt    time.Time              `json:"updated_at"`
+	ID            uuid.UUID              `json:"id"`
+	Email         string                 `json:"email"`
+	PasswordHash  string                 `json:"password_hash"`
+	UserName      string                 `json:"username"`
+	Phone         string                 `json:"phone"`                    // Encrypted at rest
+	LicenseNumber string                 `json:"license_number,omitempty"` // Driving licence number, encrypted at rest
+	Role          string                 `json:"role"`
+	ProfileData   map[string]interface{} `json:"profile_data"`
+	OperatorID    *uuid.UUID             `json:"operator_id,omitempty"` // Marketplace operator; loaded at login
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
 }
 
 // UserRequest represents the payload used to create or update a user.
 // It intentionally excludes fields like ID and timestamps which are managed by the system.
 type UserRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	UserName string `json:"username"`
-	Phone    string `json:"phone"`
-	Role     string `json:"role"`
+	Email         string `json:"email"`
+	Password      string `json:"password"`
+	UserName      string `json:"username"`
+	Phone         string `json:"phone"`
+	LicenseNumber string `json:"license_number,omitempty"` // Optional driving licence number
+	Role          string `json:"role"`
+	CaptchaToken  string `json:"captcha_token,omitempty"` // Proves a person is registering when a bot check is configured
+	// CurrentPassword confirms a signed-in user's own change of e-mail address or password
+	CurrentPassword string `json:"current_password,omitempty"`
 }
 
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+
+	// Code from the authenticator app, or a backup code, for users with two-factor authentication
+	TwoFactorCode string `json:"two_factor_code,omitempty"`
 }
 
 // ValidateUserRequest validates a UserRequest. Returns nil when valid, otherwise an error.
@@ -54,6 +63,9 @@ func ValidateUserRequest(req UserRequest) error {
 	if err := validateRole(req.Role); err != nil {
 		return err
 	}
+	if err := validateLicenseNumber(req.LicenseNumber); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -92,6 +104,34 @@ func validatePhone(phone string) error {
 	return nil
 }
 
+// PhoneSearchKey reduces a phone number to the form that is blind-indexed for search:
+// its last 10 digits, so "+91 98765-43210" and "9876543210" find the same user.
+func PhoneSearchKey(phone string) string {
+	digits := make([]rune, 0, len(phone))
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+	if len(digits) > 10 {
+		digits = digits[len(digits)-10:]
+	}
+	return string(digits)
+}
+
+// validateLicenseNumber checks an optional driving licence number.
+// Formats differ by country, so only the character set and length are enforced.
+func validateLicenseNumber(license string) error {
+	if license == "" {
+		return nil
+	}
+	re := regexp.MustCompile(`^[A-Za-z0-9 -]{5,20}$`)
+	if !re.MatchString(license) {
+		return errors.New("invalid license number format (5-20 letters, digits, spaces or hyphens)")
+	}
+	return nil
+}
+
 // validateRole ensures role is one of the allowed values.
 func validateRole(role string) error {
 	if role == "" {
@@ -106,17 +146,27 @@ func validateRole(role string) error {
 	return errors.New("role must be one of: owner, renter, admin")
 }
 
+// ValidateSignupRole ensures a role is one users may pick for themselves when they sign up.
+// Admins are created with the carzone-admin CLI, never through public registration.
+func ValidateSignupRole(role string) error {
+	if role != "owner" && role != "renter" {
+		return errors.New("role must be one of: owner, renter")
+	}
+	return nil
+}
+
 // NewUserFromRequest creates a new User from a validated UserRequest.
 // Note: this does NOT hash the password; hashing should be performed by the caller
 // before assigning to PasswordHash (to avoid importing crypto libraries in models).
 func NewUserFromRequest(req UserRequest) *User {
 	return &User{
-		ID:          uuid.New(),
-		Email:       req.Email,
-		UserName:    req.UserName,
-		Phone:       req.Phone,
-		Role:        req.Role,
-		ProfileData: make(map[string]interface{}), // Initialize empty profile data
+		ID:            uuid.New(),
+		Email:         req.Email,
+		UserName:      req.UserName,
+		Phone:         req.Phone,
+		LicenseNumber: req.LicenseNumber,
+		Role:          req.Role,
+		ProfileData:   make(map[string]interface{}), // Initialize empty profile data
 		// PasswordHash should be set by caller after hashing the provided password.
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -132,3 +182,8 @@ func ValidateLoginRequest(req LoginRequest) error {
 	}
 	return nil
 }
+
+// PageCursor returns the pagination cursor pointing at this user
+func (u User) PageCursor() Cursor {
+	return Cursor{CreatedAt: u.CreatedAt, ID: u.ID}
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OwnerVacation is a period in which an owner has blocked every car they own. Each car gets
+// its own blackout row so booking checks look at one car only; removing the vacation removes
+// them all at once.
+type OwnerVacation struct {
+	ID        uuid.UUID   `json:"id"`
+	OwnerID   uuid.UUID   `json:"owner_id"`
+	StartDate time.Time   `json:"start_date"`
+	EndDate   time.Time   `json:"end_date"`
+	Note      *string     `json:"note,omitempty"`
+	CarIDs    []uuid.UUID `json:"car_ids"` // Cars blocked by this vacation
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// VacationRequest is the payload an owner submits to block all their cars for a period
+type VacationRequest struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Note      *string   `json:"note,omitempty"`
+}
+
+// ValidateVacationRequest validates a VacationRequest. Returns nil when valid, otherwise an error.
+func ValidateVacationRequest(req VacationRequest) error {
+	if req.StartDate.IsZero() || req.EndDate.IsZero() {
+		return errors.New("start_date and end_date are required")
+	}
+	if !req.EndDate.After(req.StartDate) {
+		return errors.New("end_date must be after start_date")
+	}
+	if req.EndDate.Before(time.Now()) {
+		return errors.New("end_date cannot be in the past")
+	}
+	if req.Note != nil && len(*req.Note) > 500 {
+		return errors.New("note must be at most 500 characters")
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DepreciationCurve describes how a car loses value with age and mileage
+type DepreciationCurve struct {
+	AnnualRates       []float64 `json:"annual_rates"`         // Percent of value lost in each year of age, first year first; the last rate repeats
+	ExpectedKmPerYear int       `json:"expected_km_per_year"` // Mileage the annual rates allow for
+	ExcessKmRate      float64   `json:"excess_km_rate"`       // Percent of value lost per 10,000 km driven beyond the expected mileage
+	FloorPercent      float64   `json:"floor_percent"`        // Share of the purchase price no car is valued below
+}
+
+// DepreciationSettings are the curves an operator values its owners' cars with
+type DepreciationSettings struct {
+	Default    DepreciationCurve            `json:"default"`
+	ByFuelType map[string]DepreciationCurve `json:"by_fuel_type,omitempty"` // Fuel types that lose value differently, e.g. Electric
+}
+
+// DefaultDepreciationSettings apply until an admin configures depreciation
+var DefaultDepreciationSettings = DepreciationSettings{
+	Default: DepreciationCurve{
+		AnnualRates:       []float64{15, 12, 10, 8},
+		ExpectedKmPerYear: 15000,
+		ExcessKmRate:      2,
+		FloorPercent:      10,
+	},
+}
+
+// ValidateDepreciationSettings validates DepreciationSettings. Returns nil when valid, otherwise an error.
+func ValidateDepreciationSettings(s DepreciationSettings) error {
+	if err := validateDepreciationCurve(s.Default); err != nil {
+		return fmt.Errorf("default curve: %w", err)
+	}
+	for fuelType, curve := range s.ByFuelType {
+		if strings.TrimSpace(fuelType) == "" {
+			return errors.New("by_fuel_type keys must be fuel types")
+		}
+		if err := validateDepreciationCurve(curve); err != nil {
+			return fmt.Errorf("%s curve: %w", fuelType, err)
+		}
+	}
+	return nil
+}
+
+// validateDepreciationCurve validates one DepreciationCurve
+func validateDepreciationCurve(c DepreciationCurve) error {
+	if len(c.AnnualRates) == 0 || len(c.AnnualRates) > 30 {
+		return errors.New("annual_rates must list between 1 and 30 rates")
+	}
+	for _, rate := range c.AnnualRates {
+		if math.IsNaN(rate) || rate < 0 || rate > 100 {
+			return errors.New("annual_rates must be between 0 and 100")
+		}
+	}
+	if c.ExpectedKmPerYear < 0 || c.ExpectedKmPerYear > 200000 {
+		return errors.New("expected_km_per_year must be between 0 and 200,000")
+	}
+	if math.IsNaN(c.ExcessKmRate) || c.ExcessKmRate < 0 || c.ExcessKmRate > 100 {
+		return errors.New("excess_km_rate must be between 0 and 100")
+	}
+	if math.IsNaN(c.FloorPercent) || c.FloorPercent < 0 || c.FloorPercent > 100 {
+		return errors.New("floor_percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// Curve returns the curve of a fuel type, matched case-insensitively, or the default curve
+func (s DepreciationSettings) Curve(fuelType string) DepreciationCurve {
+	for key, curve := range s.ByFuelType {
+		if strings.EqualFold(key, fuelType) {
+			return curve
+		}
+	}
+	return s.Default
+}
+
+// Estimate depreciates a purchase price over a car's age in years and the kilometres it was
+// driven. Each year of age takes its annual rate, the current year in proportion to the part
+// of it gone by; kilometres beyond the expected mileage for the age then take the excess rate.
+func (c DepreciationCurve) Estimate(purchasePrice, ageYears float64, mileageKm int) float64 {
+	value := purchasePrice
+	for year := 0; float64(year) < ageYears; year++ {
+		rate := c.AnnualRates[min(year, len(c.AnnualRates)-1)]
+		value *= 1 - rate/100*math.Min(ageYears-float64(year), 1)
+	}
+
+	if excessKm := float64(mileageKm) - float64(c.ExpectedKmPerYear)*ageYears; excessKm > 0 {
+		value *= math.Max(1-c.ExcessKmRate/100*excessKm/10000, 0)
+	}
+
+	return math.Round(math.Max(value, purchasePrice*c.FloorPercent/100)*100) / 100
+}
+
+// CarPurchase is what an owner paid for a car. It is only shown to the owner.
+type CarPurchase struct {
+	CarID         uuid.UUID  `json:"car_id"`
+	PurchasePrice float64    `json:"purchase_price"`         // INR
+	PurchasedOn   *time.Time `json:"purchased_on,omitempty"` // Without it, age counts from the middle of the model year
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// CarPurchaseRequest is the payload to record what an owner paid for a car
+type CarPurchaseRequest struct {
+	PurchasePrice float64 `json:"purchase_price"`
+	PurchasedOn   string  `json:"purchased_on,omitempty"` // YYYY-MM-DD
+}
+
+// ParseCarPurchaseRequest validates a CarPurchaseRequest for a car of the given model year and
+// returns the purchase date, nil when none was sent
+func ParseCarPurchaseRequest(req CarPurchaseRequest, modelYear int, now time.Time) (*time.Time, error) {
+	if math.IsNaN(req.PurchasePrice) || req.PurchasePrice <= 0 || req.PurchasePrice > 1e9 {
+		return nil, errors.New("purchase_price must be above 0 and at most 1,000,000,000")
+	}
+	if req.PurchasedOn == "" {
+		return nil, nil
+	}
+	purchasedOn, err := time.Parse("2006-01-02", req.PurchasedOn)
+	if err != nil {
+		return nil, errors.New("purchased_on must be a YYYY-MM-DD date")
+	}
+	if purchasedOn.After(now) {
+		return nil, errors.New("purchased_on cannot be in the future")
+	}
+	// Cars of a model year are sold from the year before
+	if purchasedOn.Year() < modelYear-1 {
+		return nil, errors.New("purchased_on must not be before the car's model year")
+	}
+	return &purchasedOn, nil
+}
+
+// CarValuation is one car of a fleet valuation. The estimate is missing until the owner records
+// what they paid for the car.
+type CarValuation struct {
+	CarID          uuid.UUID  `json:"car_id"`
+	Name           string     `json:"name"`
+	Brand          string     `json:"brand"`
+	Model          string     `json:"model"`
+	Year           int        `json:"year"`
+	FuelType       string     `json:"fuel_type"`
+	MileageKm      int        `json:"mileage_km"`                // Advanced by check-in odometer readings
+	LastCheckinAt  *time.Time `json:"last_checkin_at,omitempty"` // Latest check-in with an odometer reading
+	PurchasePrice  *float64   `json:"purchase_price,omitempty"`
+	PurchasedOn    *time.Time `json:"purchased_on,omitempty"`
+	AgeYears       float64    `json:"age_years"`
+	EstimatedValue *float64   `json:"estimated_value,omitempty"`
+	Depreciation   *float64   `json:"depreciation_percent,omitempty"` // Share of the purchase price lost
+}
+
+// AgeAt returns a car's age at now in years: from its purchase date, or else from the middle
+// of its model year
+func (v CarValuation) AgeAt(now time.Time) float64 {
+	since := time.Date(v.Year, time.July, 1, 0, 0, 0, 0, time.UTC)
+	if v.PurchasedOn != nil {
+		since = *v.PurchasedOn
+	}
+	age := now.Sub(since).Hours() / 24 / 365.25
+	return math.Max(math.Round(age*100)/100, 0)
+}
+
+// FleetValuation estimates the current value of an owner's cars. Totals only count the cars
+// with a purchase price.
+type FleetValuation struct {
+	Cars                []CarValuation `json:"cars"`
+	TotalPurchasePrice  float64        `json:"total_purchase_price"`
+	TotalEstimatedValue float64        `json:"total_estimated_value"`
+	UnpricedCars        int            `json:"unpriced_cars"` // Cars without a purchase price, left out of the totals
+	ValuedAt            time.Time      `json:"valued_at"`
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// WarehouseEntity names a table exported to the data warehouse
+type WarehouseEntity string
+
+const (
+	WarehouseCars     WarehouseEntity = "cars"
+	WarehouseBookings WarehouseEntity = "bookings"
+	WarehousePayments WarehouseEntity = "payments"
+	WarehouseUsers    WarehouseEntity = "users" // Exported without PII
+)
+
+// WarehouseEntities lists every exported entity in export order
+var WarehouseEntities = []WarehouseEntity{WarehouseUsers, WarehouseCars, WarehouseBookings, WarehousePayments}
+
+// WarehouseRows is one exported batch: a header and the rows changed in a time range
+type WarehouseRows struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// WarehouseExport describes one file written for the warehouse
+type WarehouseExport struct {
+	Entity    WarehouseEntity `json:"entity"`
+	From      time.Time       `json:"from"` // Exclusive lower bound of updated_at
+	To        time.Time       `json:"to"`   // Inclusive upper bound of updated_at
+	Rows      int             `json:"rows"`
+	ObjectKey string          `json:"object_key,omitempty"` // Empty when there were no changed rows
+}
+
+// WarehouseBackfillRequest re-exports rows changed in a range without moving the nightly watermark
+type WarehouseBackfillRequest struct {
+	Entities []WarehouseEntity `json:"entities"` // Empty means all entities
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+}
+
+// ValidateWarehouseBackfillRequest validates a WarehouseBackfillRequest. Returns nil when valid, otherwise an error.
+func ValidateWarehouseBackfillRequest(req WarehouseBackfillRequest) error {
+	if req.From.IsZero() || req.To.IsZero() {
+		return errors.New("from and to are required")
+	}
+	if !req.From.Before(req.To) {
+		return errors.New("from must be before to")
+	}
+	if req.To.After(time.Now()) {
+		return errors.New("to cannot be in the future")
+	}
+	for _, entity := range req.Entities {
+		if !IsWarehouseEntity(entity) {
+			return fmt.Errorf("unknown entity %q, expected cars, bookings, payments or users", entity)
+		}
+	}
+	return nil
+}
+
+// IsWarehouseEntity reports whether an entity is exported to the warehouse
+func IsWarehouseEntity(entity WarehouseEntity) bool {
+	for _, e := range WarehouseEntities {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+// Package response provides the standard JSON envelope used by every CarZone handler.
+// Successful responses are shaped as {data, meta, links} so clients can rely on a
+// single structure for detail and list endpoints alike.
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+)
+
+// Envelope is the top-level body of every successful JSON response
+type Envelope struct {
+	Data  interface{} `json:"data"`            // Resource or list of resources
+	Meta  *Meta       `json:"meta,omitempty"`  // Pagination details, present on list responses
+	Links Links       `json:"links,omitempty"` // Navigation and related-resource links
+}
+
+// Meta carries list metadata for paginated responses
+type Meta struct {
+	Limit      int    `json:"limit"`                 // Page size that was applied
+	Count      int    `json:"count"`                 // Number of items on this page
+	HasMore    bool   `json:"has_more"`              // Whether an older page exists
+	NextCursor string `json:"next_cursor,omitempty"` // Cursor of the next (older) page, empty on the last page
+	PrevCursor string `json:"prev_cursor,omitempty"` // Cursor of the previous (newer) page, empty on the first page
+	Total      *int   `json:"total,omitempty"`       // Number of items on all pages, when counted
+}
+
+// Links maps a relation name (self, next, prev, car, ...) to a URL
+type Links map[string]string
+
+// JSON writes the envelope with the given status code
+func JSON(w http.ResponseWriter, status int, env Envelope) {
+	body, err := json.Marshal(env)
+	if err != nil {
+		log.Println("Error marshalling response:", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err = w.Write(body); err != nil {
+		log.Println("Error writing response:", err)
+	}
+}
+
+// Resource writes a single resource with its self link and any related links
+func Resource(w http.ResponseWriter, r *http.Request, status int, data interface{}, related Links) {
+	links := Links{"self": r.URL.RequestURI()}
+	for rel, href := range related {
+		links[rel] = href
+	}
+	JSON(w, status, Envelope{Data: data, Links: links})
+}
+
+// List writes one page of a list with pagination meta and self/next/prev links
+func List[T any](w http.ResponseWriter, r *http.Request, page *models.Page[T]) {
+	JSON(w, http.StatusOK, PageEnvelope(r, page))
+}
+
+// PageEnvelope builds the envelope for one page of a list.
+// The next and prev links reuse the request's path and query, swapping in the page cursor.
+func PageEnvelope[T any](r *http.Request, page *models.Page[T]) Envelope {
+	links := Links{"self": r.URL.RequestURI()}
+	if page.NextCursor != "" {
+		links["next"] = withCursor(r.URL, page.NextCursor)
+	}
+	if page.PrevCursor != "" {
+		links["prev"] = withCursor(r.URL, page.PrevCursor)
+	}
+
+	meta := &Meta{
+		Limit:      page.Limit,
+		Count:      len(page.Data),
+		HasMore:    page.HasMore,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		Total:      page.Total,
+	}
+	return Envelope{Data: page.Data, Meta: meta, Links: links}
+}
+
+// Cached writes a 200 response that shared caches and CDNs may store.
+// The ETag is a hash of the body, so identical content always gets the same tag;
+// a matching If-None-Match header is answered with 304 Not Modified and no body.
+func Cached(w http.ResponseWriter, r *http.Request, env Envelope, cacheControl string) {
+	body, err := json.Marshal(env)
+	if err != nil {
+		log.Println("Error marshalling response:", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Vary", "Accept-Encoding")
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(body); err != nil {
+		log.Println("Error writing response:", err)
+	}
+}
+
+// etagMatches reports whether an If-None-Match header value matches the ETag.
+// CDNs may forward weak validators (W/"...") so those compare equal too.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// withCursor returns the request URI of u with its cursor query parameter replaced
+func withCursor(u *url.URL, cursor string) string {
+	next := *u
+	query := next.Query()
+	query.Set("cursor", cursor)
+	next.RawQuery = query.Encode()
+	return next.RequestURI()
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupAccountingRoutes configures the routes finance admins download the ledger from
+func (r *Router) setupAccountingRoutes(router *mux.Router) {
+	accounting := router.PathPrefix("/admin/accounting").Subrouter()
+	accounting.Use(middleware.RequireRole("admin"))
+
+	// GET /admin/accounting/export - Invoices, receipts, credit notes and refunds of a period
+	// (?from=YYYY-MM-DD&to=YYYY-MM-DD&format=tally|iif|csv), at most a year
+	accounting.HandleFunc("/export", r.AccountingHandler.Export).Methods("GET", "OPTIONS")
+}
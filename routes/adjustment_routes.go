@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupAdjustmentRoutes configures credit note and manual charge routes
+func (r *Router) setupAdjustmentRoutes(router *mux.Router) {
+	// Adjustments of a booking, for its customer, car owner or an admin
+	router.HandleFunc("/bookings/{id}/adjustments", r.AdjustmentHandler.GetBookingAdjustments).Methods("GET", "OPTIONS")
+
+	bookings := router.PathPrefix("/admin/bookings").Subrouter()
+	bookings.Use(middleware.RequireRole("admin"))
+
+	// Raise a credit note or charge; amounts above ADJUSTMENT_APPROVAL_THRESHOLD wait for a second admin
+	// Body: { "kind": "credit_note|charge", "amount": 500, "reason_code": "goodwill", "notes": "..." }
+	bookings.HandleFunc("/{id}/adjustments", r.AdjustmentHandler.CreateAdjustment).Methods("POST", "OPTIONS")
+
+	adjustments := router.PathPrefix("/admin/adjustments").Subrouter()
+	adjustments.Use(middleware.RequireRole("admin"))
+
+	// Approval queue (?status=pending_approval|applied|rejected)
+	adjustments.HandleFunc("", r.AdjustmentHandler.ListAdjustments).Methods("GET", "OPTIONS")
+
+	// Approve or reject as an admin other than the one who raised it
+	// Body: { "approve": true, "note": "..." }
+	adjustments.HandleFunc("/{id}/review", r.AdjustmentHandler.ReviewAdjustment).Methods("POST", "OPTIONS")
+}
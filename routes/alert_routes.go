@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// setupAlertRoutes configures price-drop and availability alert routes
+func (r *Router) setupAlertRoutes(router *mux.Router) {
+	// PUT /cars/{id}/alerts - Watch a car; subscribing again replaces the criteria
+	router.HandleFunc("/cars/{id}/alerts", r.AlertHandler.Subscribe).Methods("PUT", "OPTIONS")
+
+	// GET /users/me/car-alerts - The user's car alerts
+	router.HandleFunc("/users/me/car-alerts", r.AlertHandler.GetSubscriptions).Methods("GET", "OPTIONS")
+
+	// DELETE /users/me/car-alerts/{id} - Stop a car alert
+	router.HandleFunc("/users/me/car-alerts/{id}", r.AlertHandler.Unsubscribe).Methods("DELETE", "OPTIONS")
+}
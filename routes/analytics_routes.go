@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupAnalyticsRoutes configures the admin dashboard data API
+func (r *Router) setupAnalyticsRoutes(router *mux.Router) {
+	// Platform-wide aggregates are visible to admins only
+	metrics := router.PathPrefix("/admin/metrics").Subrouter()
+	metrics.Use(middleware.RequireRole("admin"))
+
+	// Bucketed bookings, revenue, signups or cancellations for charts
+	// Query parameters: ?metric=bookings&interval=day&from=2024-01-01&to=2024-02-01
+	metrics.HandleFunc("/timeseries", r.AnalyticsHandler.GetTimeSeries).Methods("GET", "OPTIONS")
+
+	// Dashboards served from the daily read model, with the time of its last refresh as as_of
+	// Query parameters: ?from=2024-01-01&to=2024-01-31 (both included, UTC), plus &limit=20 for rankings
+	metrics.HandleFunc("/owner-earnings", r.AnalyticsHandler.GetOwnerEarnings).Methods("GET", "OPTIONS")
+	metrics.HandleFunc("/car-utilization", r.AnalyticsHandler.GetCarUtilization).Methods("GET", "OPTIONS")
+	metrics.HandleFunc("/funnel", r.AnalyticsHandler.GetBookingFunnel).Methods("GET", "OPTIONS")
+}
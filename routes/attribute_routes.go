@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupCarAttributeRoutes configures the custom car attribute routes. Definitions belong to the
+// operator the request acts for.
+func (r *Router) setupCarAttributeRoutes(router *mux.Router) {
+	// GET /car-attributes - Custom attributes car forms render and listings filter by
+	router.HandleFunc("/car-attributes", r.CarAttributeHandler.GetCarAttributes).Methods("GET", "OPTIONS")
+
+	attributes := router.PathPrefix("/admin/car-attributes").Subrouter()
+	attributes.Use(middleware.RequireRole("admin"))
+
+	// POST /admin/car-attributes - Define an attribute
+	// Body: { "key": "roof_box", "name": "Roof box", "type": "select", "options": ["Small", "Large"], "required": false }
+	attributes.HandleFunc("", r.CarAttributeHandler.CreateCarAttribute).Methods("POST", "OPTIONS")
+
+	// PUT /admin/car-attributes/{key} - Replace the name, options and required flag of an attribute
+	attributes.HandleFunc("/{key}", r.CarAttributeHandler.UpdateCarAttribute).Methods("PUT", "OPTIONS")
+
+	// DELETE /admin/car-attributes/{key} - Remove an attribute and clear its values from cars
+	attributes.HandleFunc("/{key}", r.CarAttributeHandler.DeleteCarAttribute).Methods("DELETE", "OPTIONS")
+}
@@ -6,12 +6,29 @@ import (
 
 // setupAuthRoutes configures all authentication-related routes
 func (r *Router) setupAuthRoutes(router *mux.Router) {
+	// GET /auth/challenge - Bot check to pass before registering or asking for a reset link:
+	// a CAPTCHA site key or a proof-of-work puzzle, answered as "captcha_token" in those bodies
+	router.HandleFunc("/auth/challenge", r.AuthHandler.BotChallengeHandler).Methods("GET", "OPTIONS")
+
 	// POST /auth/register - Register a new user account
 	router.HandleFunc("/auth/register", r.AuthHandler.RegisterHandler).Methods("POST", "OPTIONS")
 
 	// POST /auth/login - Authenticate user and receive access token
 	router.HandleFunc("/auth/login", r.AuthHandler.LoginHandler).Methods("POST", "OPTIONS")
 
-	// GET /auth/logout - Logout user (invalidate session)
-	router.HandleFunc("/auth/logout", r.AuthHandler.LogoutHandler).Methods("GET", "OPTIONS")
+	// POST /auth/forgot-password - E-mail a single-use password reset link
+	// Body: { "email": "..." }
+	router.HandleFunc("/auth/forgot-password", r.AuthHandler.ForgotPasswordHandler).Methods("POST", "OPTIONS")
+
+	// POST /auth/reset-password - Set a new password with the token of a reset link
+	// Body: { "token": "...", "password": "..." }
+	router.HandleFunc("/auth/reset-password", r.AuthHandler.ResetPasswordHandler).Methods("POST", "OPTIONS")
+
+	// POST /auth/refresh - Exchange a refresh token for a new access token and refresh token
+	// Body: { "refresh_token": "..." }, or empty to use the refresh_token cookie
+	router.HandleFunc("/auth/refresh", r.AuthHandler.RefreshHandler).Methods("POST", "OPTIONS")
+
+	// GET/POST /auth/logout - Logout user, revoking the session's refresh token
+	// POST may carry { "refresh_token": "..." } for clients that do not use cookies
+	router.HandleFunc("/auth/logout", r.AuthHandler.LogoutHandler).Methods("GET", "POST", "OPTIONS")
 }
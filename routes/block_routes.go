@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// setupBlockRoutes configures the routes users manage their block list with
+func (r *Router) setupBlockRoutes(router *mux.Router) {
+	// GET /users/me/blocks - Users the caller has blocked
+	router.HandleFunc("/users/me/blocks", r.BlockHandler.GetBlocks).Methods("GET", "OPTIONS")
+
+	// POST /users/me/blocks - Block a user; neither side can then book with the other
+	// Body: { "user_id": "uuid" }
+	router.HandleFunc("/users/me/blocks", r.BlockHandler.BlockUser).Methods("POST", "OPTIONS")
+
+	// DELETE /users/me/blocks/{id} - Unblock a user
+	// Path parameter: UUID of the blocked user
+	router.HandleFunc("/users/me/blocks/{id}", r.BlockHandler.UnblockUser).Methods("DELETE", "OPTIONS")
+}
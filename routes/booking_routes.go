@@ -2,6 +2,8 @@ package routes
 
 import (
 	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
 )
 
 // setupBookingRoutes configures all booking-related routes
@@ -20,6 +22,14 @@ func (r *Router) setupBookingRoutes(router *mux.Router) {
 	// Body: Booking JSON data with customer_id, car_id, booking details
 	router.HandleFunc("/bookings", r.BookingHandler.CreateBooking).Methods("POST", "OPTIONS")
 
+	// POST /bookings/quote - Price a booking before creating it
+	// Body: car_id, start_date, end_date and optional pickup/drop-off locations or delivery_address
+	router.HandleFunc("/bookings/quote", r.BookingHandler.QuoteBooking).Methods("POST", "OPTIONS")
+
+	// GET /cars/{id}/conflicts - Whether a period is bookable and which rules block it
+	// Query parameters: ?start=2024-03-01&end=2024-03-05 (RFC 3339 timestamps or dates)
+	router.HandleFunc("/cars/{id}/conflicts", r.BookingHandler.CheckConflicts).Methods("GET", "OPTIONS")
+
 	// DELETE /bookings/{id} - Delete a booking by its UUID
 	// Path parameter: UUID of the booking to delete
 	router.HandleFunc("/bookings/{id}", r.BookingHandler.DeleteBooking).Methods("DELETE", "OPTIONS")
@@ -28,13 +38,47 @@ func (r *Router) setupBookingRoutes(router *mux.Router) {
 
 	// PUT /bookings/{id}/status - Update booking status
 	// Path parameter: UUID of the booking
-	// Body: { "status": "confirmed|cancelled|completed" }
+	// Body: { "status": "confirmed|cancelled|completed" }; in_progress is set by checkout
 	router.HandleFunc("/bookings/{id}/status", r.BookingHandler.UpdateBookingStatus).Methods("PUT", "OPTIONS")
 
+	// Vehicle handover
+
+	// POST /bookings/{id}/checkout - Record handing the car to the customer, starting the trip (car owner or admin)
+	// POST /bookings/{id}/checkin - Record the car's return, completing the booking (car owner or admin)
+	// Body: { "odometer_km": 12345, "fuel_level": 80, "notes": "..." }; omitted readings
+	// are auto-filled from recent telemetry
+	router.HandleFunc("/bookings/{id}/checkout", r.BookingHandler.Checkout).Methods("POST", "OPTIONS")
+	router.HandleFunc("/bookings/{id}/checkin", r.BookingHandler.Checkin).Methods("POST", "OPTIONS")
+
+	// Trip extensions
+
+	// GET /bookings/{id}/extension - Price extending a trip in progress and check the car is free (customer or admin)
+	// Query parameter: ?end_date=2024-03-07T10:00:00Z
+	// POST /bookings/{id}/extension - Book the extra days as a pending extension and get its payment order
+	// Body: { "end_date": "2024-03-07T10:00:00Z" }
+	router.HandleFunc("/bookings/{id}/extension", r.BookingHandler.GetExtensionQuote).Methods("GET", "OPTIONS")
+	router.HandleFunc("/bookings/{id}/extension", r.BookingHandler.ExtendBooking).Methods("POST", "OPTIONS")
+
+	// GET /bookings/{id}/inspections - Checkout and check-in records of a booking
+	router.HandleFunc("/bookings/{id}/inspections", r.BookingHandler.GetInspections).Methods("GET", "OPTIONS")
+
+	// Offline payments
+
+	// POST /bookings/{id}/payments/{paymentID}/collect - Record collecting a cash or other offline payment (car owner or admin)
+	// Body: { "amount": 4500, "receipt_number": "R-0042", "notes": "...", "collected_at": "..." };
+	// completes the payment and confirms a pending booking
+	router.HandleFunc("/bookings/{id}/payments/{paymentID}/collect", r.BookingHandler.CollectPayment).Methods("POST", "OPTIONS")
+
+	// GET /bookings/{id}/payment-collections - Audit trail of offline collections (car owner or admin)
+	router.HandleFunc("/bookings/{id}/payment-collections", r.BookingHandler.GetPaymentCollections).Methods("GET", "OPTIONS")
+
+	// GET /bookings/{id}/documents - Numbered invoice and receipts (customer, car owner or admin)
+	router.HandleFunc("/bookings/{id}/documents", r.BookingHandler.GetDocuments).Methods("GET", "OPTIONS")
+
 	// Booking query endpoints
 
 	// GET /bookings/customer/{customerID} - Get all bookings for a specific customer
-	// Path parameter: UUID of the customer
+	// Path parameter: UUID of the customer; optional ?status=in_progress
 	router.HandleFunc("/bookings/customer/{customerID}", r.BookingHandler.GetBookingsByCustomerID).Methods("GET", "OPTIONS")
 
 	// GET /bookings/car/{carID} - Get all bookings for a specific car
@@ -42,6 +86,11 @@ func (r *Router) setupBookingRoutes(router *mux.Router) {
 	router.HandleFunc("/bookings/car/{carID}", r.BookingHandler.GetBookingsByCarID).Methods("GET", "OPTIONS")
 
 	// GET /bookings/owner/{ownerID} - Get all bookings for cars owned by a specific owner
-	// Path parameter: UUID of the car owner
+	// Path parameter: UUID of the car owner; optional ?status=in_progress
 	router.HandleFunc("/bookings/owner/{ownerID}", r.BookingHandler.GetBookingsByOwnerID).Methods("GET", "OPTIONS")
+
+	// GET /owners/me/trips - Trips under way with the owner's cars, due back first; overdue ones carry overdue_at
+	trips := router.PathPrefix("/owners/me/trips").Subrouter()
+	trips.Use(middleware.RequireRole("owner", "admin"))
+	trips.HandleFunc("", r.BookingHandler.GetActiveTrips).Methods("GET", "OPTIONS")
 }
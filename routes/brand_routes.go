@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// setupBrandRoutes configures the brand and model autocomplete routes
+func (r *Router) setupBrandRoutes(router *mux.Router) {
+	// GET /brands - Brands for an autocomplete
+	// Query parameters: ?q=toy (case-insensitive start of the name)
+	router.HandleFunc("/brands", r.BrandHandler.GetBrands).Methods("GET", "OPTIONS")
+
+	// GET /brands/{id}/models - A brand's models for an autocomplete
+	// Query parameters: ?q=cam
+	router.HandleFunc("/brands/{id}/models", r.BrandHandler.GetModels).Methods("GET", "OPTIONS")
+}
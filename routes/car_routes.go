@@ -1,9 +1,6 @@
 package routes
 
 import (
-	"net/http"
-
-	"github.com/PrateekKumar15/CarZone/middleware"
 	"github.com/gorilla/mux"
 )
 
@@ -12,25 +9,61 @@ func (r *Router) setupCarRoutes(router *mux.Router) {
 	// Car CRUD operations
 
 	// GET /cars - Retrieve all cars with optional filtering
-	// Query parameters: ?brand=Toyota&fuel_type=Petrol&location=California
+	// Query parameters: ?features=sunroof,gps lists only cars with every feature set to true
 	router.HandleFunc("/cars", r.CarHandler.GetAllCars).Methods("GET", "OPTIONS")
 
+	// GET /cars/search - Search cars with filters applied in the database
+	// Query parameters: ?brand=&model=&fuel_type=&city=&state=&min_price=&max_price=&min_year=&max_year=&is_available=&limit=&cursor=
+	// Registered before /cars/{id} so "search" is not taken for an ID
+	router.HandleFunc("/cars/search", r.CarHandler.SearchCars).Methods("GET", "OPTIONS")
+
 	// GET /cars/{id} - Retrieve a specific car by its UUID
 	// Path parameter: UUID of the car
 	router.HandleFunc("/cars/{id}", r.CarHandler.GetCarByID).Methods("GET", "OPTIONS")
 
+	// GET /cars/slug/{slug} - Retrieve a specific car by its URL slug
+	// Path parameter: slug of the car (e.g. toyota-camry-2023-c0000001)
+	router.HandleFunc("/cars/slug/{slug}", r.CarHandler.GetCarBySlug).Methods("GET", "OPTIONS")
+
 	// GET /cars/brand - Retrieve cars by brand with optional engine details
 	// Query parameters: ?brand={brand}&engine={true/false}
 	router.HandleFunc("/carsbybrand", r.CarHandler.GetCarByBrand).Methods("GET")
 
 	// POST /cars - Create a new car record
-	// Body: Car JSON data, supports multipart/form-data for image uploads
-	router.Handle("/cars", middleware.ImageUploadMiddleware(http.HandlerFunc(r.CarHandler.CreateCar))).Methods("POST", "OPTIONS")
+	// Body: Car JSON data; images may be URLs or base64 data, which is uploaded in the background
+	router.HandleFunc("/cars", r.CarHandler.CreateCar).Methods("POST", "OPTIONS")
 
 	// PUT /cars/{id} - Update an existing car by its UUID
 	// Path parameter: UUID of the car to update
-	// Body: Updated car JSON data, supports multipart/form-data for image uploads
-	router.Handle("/cars/{id}", middleware.ImageUploadMiddleware(http.HandlerFunc(r.CarHandler.UpdateCar))).Methods("PUT", "OPTIONS")
+	// Body: Updated car JSON data; images may be URLs or base64 data, which is uploaded in the background
+	router.HandleFunc("/cars/{id}", r.CarHandler.UpdateCar).Methods("PUT", "OPTIONS")
+
+	// GET /cars/{id}/image-uploads - Images sent as base64 data and how far their upload got (car owner or admin)
+	router.HandleFunc("/cars/{id}/image-uploads", r.CarHandler.ListImageUploads).Methods("GET", "OPTIONS")
+
+	// GET /cars/{id}/price-history - Every change of the car's daily price with who made it, newest first
+	router.HandleFunc("/cars/{id}/price-history", r.CarHandler.GetPriceHistory).Methods("GET", "OPTIONS")
+
+	// GET /cars/{id}/quality - Listing quality score with hints to improve it (car owner or admin)
+	router.HandleFunc("/cars/{id}/quality", r.CarHandler.GetQuality).Methods("GET", "OPTIONS")
+
+	// PUT /cars/{id}/status - Move a car through its lifecycle
+	// Body: {"status": "pending_review"}; only admins approve or reject cars under review
+	router.HandleFunc("/cars/{id}/status", r.CarHandler.UpdateCarStatus).Methods("PUT", "OPTIONS")
+
+	// GET /cars/{id}/fuel-policy - Retrieve a car's fuel policy
+	router.HandleFunc("/cars/{id}/fuel-policy", r.CarHandler.GetFuelPolicy).Methods("GET", "OPTIONS")
+
+	// PUT /cars/{id}/fuel-policy - Configure a car's fuel policy (car owner or admin)
+	// Body: {"policy": "full_to_full", "tank_capacity_liters": 45}
+	router.HandleFunc("/cars/{id}/fuel-policy", r.CarHandler.UpdateFuelPolicy).Methods("PUT", "OPTIONS")
+
+	// GET /cars/{id}/eligibility - Retrieve who may rent a car
+	router.HandleFunc("/cars/{id}/eligibility", r.CarHandler.GetEligibility).Methods("GET", "OPTIONS")
+
+	// PUT /cars/{id}/eligibility - Set the minimum renter age and licence years (car owner or admin)
+	// Body: {"min_renter_age": 25, "min_license_years": 3}; 0 lifts a rule
+	router.HandleFunc("/cars/{id}/eligibility", r.CarHandler.UpdateEligibility).Methods("PUT", "OPTIONS")
 
 	// DELETE /cars/{id} - Delete a car by its UUID
 	// Path parameter: UUID of the car to delete
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupChecklistRoutes configures routes for the checklist answered at checkout and check-in
+func (r *Router) setupChecklistRoutes(router *mux.Router) {
+	// GET /checklist-templates/current - The checklist version new checkouts answer
+	router.HandleFunc("/checklist-templates/current", r.ChecklistHandler.GetCurrentTemplate).Methods("GET", "OPTIONS")
+
+	// GET /checklist-templates/{version} - One version, e.g. the one a check-in answers after its checkout
+	router.HandleFunc("/checklist-templates/{version:[0-9]+}", r.ChecklistHandler.GetTemplateVersion).Methods("GET", "OPTIONS")
+
+	admin := router.PathPrefix("/admin/checklist-templates").Subrouter()
+	admin.Use(middleware.RequireRole("admin"))
+
+	// GET /admin/checklist-templates - Version history, newest first
+	admin.HandleFunc("", r.ChecklistHandler.ListTemplateVersions).Methods("GET", "OPTIONS")
+
+	// POST /admin/checklist-templates - Save a new version of the checklist
+	// Body: { "items": [{ "key": "front_bumper", "label": "Front bumper", "section": "exterior|interior|fluids",
+	//         "type": "condition|level" }] }
+	admin.HandleFunc("", r.ChecklistHandler.SaveTemplate).Methods("POST", "OPTIONS")
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupDebugCaptureRoutes configures admin access to sampled request and response captures
+func (r *Router) setupDebugCaptureRoutes(router *mux.Router) {
+	// Captures hold other users' traffic and are read by admins only
+	captures := router.PathPrefix("/admin/debug-captures").Subrouter()
+	captures.Use(middleware.RequireRole("admin"))
+
+	// Unexpired captures, newest first, filterable by user_id, path prefix and min_status
+	captures.HandleFunc("", r.DebugCaptureHandler.ListDebugCaptures).Methods("GET", "OPTIONS")
+
+	// Get a single capture with its sanitized bodies
+	captures.HandleFunc("/{id}", r.DebugCaptureHandler.GetDebugCaptureByID).Methods("GET", "OPTIONS")
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupDisputeRoutes configures admin routes for chargebacks raised against payments
+func (r *Router) setupDisputeRoutes(router *mux.Router) {
+	// Disputes arrive through the Razorpay webhook; admins contest and close them here
+	disputes := router.PathPrefix("/admin/disputes").Subrouter()
+	disputes.Use(middleware.RequireRole("admin"))
+
+	// List disputes, those due for a response soonest first (?status=open|under_review|won|lost|closed)
+	disputes.HandleFunc("", r.DisputeHandler.ListDisputes).Methods("GET", "OPTIONS")
+
+	// A dispute with the evidence attached so far
+	disputes.HandleFunc("/{id}", r.DisputeHandler.GetDispute).Methods("GET", "OPTIONS")
+
+	// Attach evidence
+	// Body: { "kind": "invoice|agreement|inspection|communication|other", "description": "...", "document_url": "..." }
+	disputes.HandleFunc("/{id}/evidence", r.DisputeHandler.AddEvidence).Methods("POST", "OPTIONS")
+
+	// Record the outcome, which releases the payouts the dispute held
+	// Body: { "status": "won|lost|closed", "notes": "..." }
+	disputes.HandleFunc("/{id}/outcome", r.DisputeHandler.RecordOutcome).Methods("PUT", "OPTIONS")
+}
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupEmailTemplateRoutes configures the admin API for e-mail copy
+func (r *Router) setupEmailTemplateRoutes(router *mux.Router) {
+	templates := router.PathPrefix("/admin/email-templates").Subrouter()
+	templates.Use(middleware.RequireRole("admin"))
+
+	// GET /admin/email-templates - Current version of every template
+	templates.HandleFunc("", r.EmailTemplateHandler.ListTemplates).Methods("GET", "OPTIONS")
+
+	// POST /admin/email-templates - Add a template for a new key
+	// Body: { "key": "booking_confirmed", "subject": "...", "body": "..." }
+	templates.HandleFunc("", r.EmailTemplateHandler.CreateTemplate).Methods("POST", "OPTIONS")
+
+	// GET /admin/email-templates/{key} - Current version of a template
+	templates.HandleFunc("/{key}", r.EmailTemplateHandler.GetTemplate).Methods("GET", "OPTIONS")
+
+	// PUT /admin/email-templates/{key} - Save a new version of a template
+	templates.HandleFunc("/{key}", r.EmailTemplateHandler.UpdateTemplate).Methods("PUT", "OPTIONS")
+
+	// DELETE /admin/email-templates/{key} - Remove every version, reverting to the built-in copy
+	templates.HandleFunc("/{key}", r.EmailTemplateHandler.DeleteTemplate).Methods("DELETE", "OPTIONS")
+
+	// GET /admin/email-templates/{key}/versions - Version history, newest first
+	templates.HandleFunc("/{key}/versions", r.EmailTemplateHandler.ListTemplateVersions).Methods("GET", "OPTIONS")
+
+	// POST /admin/email-templates/{key}/preview - Render the template or a draft with sample variables
+	// Body (optional): { "subject": "...", "body": "...", "data": { "car_name": "..." } }
+	templates.HandleFunc("/{key}/preview", r.EmailTemplateHandler.PreviewTemplate).Methods("POST", "OPTIONS")
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// setupFavoriteRoutes configures wishlist routes
+func (r *Router) setupFavoriteRoutes(router *mux.Router) {
+	// GET /users/me/favorites - The user's saved cars, most recently saved first
+	router.HandleFunc("/users/me/favorites", r.FavoriteHandler.ListFavorites).Methods("GET", "OPTIONS")
+
+	// POST /users/me/favorites/{carID} - Save a car; saving it again is a no-op
+	router.HandleFunc("/users/me/favorites/{carID}", r.FavoriteHandler.AddFavorite).Methods("POST", "OPTIONS")
+
+	// DELETE /users/me/favorites/{carID} - Remove a saved car
+	router.HandleFunc("/users/me/favorites/{carID}", r.FavoriteHandler.RemoveFavorite).Methods("DELETE", "OPTIONS")
+}
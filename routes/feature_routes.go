@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupFeatureRoutes configures the features taxonomy routes
+func (r *Router) setupFeatureRoutes(router *mux.Router) {
+	// GET /features - Features cars may offer, with their aliases and how many cars offer each
+	router.HandleFunc("/features", r.FeatureHandler.GetFeatures).Methods("GET", "OPTIONS")
+
+	features := router.PathPrefix("/admin/features").Subrouter()
+	features.Use(middleware.RequireRole("admin"))
+
+	// POST /admin/features - Add a feature to the taxonomy
+	// Body: { "key": "heated_seats", "name": "Heated seats", "aliases": ["seat_heating"] }
+	features.HandleFunc("", r.FeatureHandler.CreateFeature).Methods("POST", "OPTIONS")
+
+	// PUT /admin/features/{key} - Replace the name and aliases of a feature
+	features.HandleFunc("/{key}", r.FeatureHandler.UpdateFeature).Methods("PUT", "OPTIONS")
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupFeaturedRoutes configures featured placement routes. Buying and confirming placement is
+// limited to the car's owner or an admin by the service.
+func (r *Router) setupFeaturedRoutes(router *mux.Router) {
+	// POST /cars/{id}/featured - Buy featured placement for a period; returns a Razorpay order
+	// Body: { "start_date": "2024-03-01T00:00:00Z", "end_date": "2024-03-08T00:00:00Z" }
+	router.HandleFunc("/cars/{id}/featured", r.FeaturedHandler.PurchaseFeatured).Methods("POST", "OPTIONS")
+
+	// POST /featured/{id}/verify - Confirm the Razorpay payment and start the boost
+	// Body: { "razorpay_order_id": "...", "razorpay_payment_id": "...", "razorpay_signature": "..." }
+	router.HandleFunc("/featured/{id}/verify", r.FeaturedHandler.ConfirmFeatured).Methods("POST", "OPTIONS")
+
+	// GET /owners/me/featured - The owner's placements with impressions and clicks
+	featured := router.PathPrefix("/owners/me/featured").Subrouter()
+	featured.Use(middleware.RequireRole("owner", "admin"))
+	featured.HandleFunc("", r.FeaturedHandler.GetOwnerFeatured).Methods("GET", "OPTIONS")
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupFleetRoutes configures owner fleet routes
+func (r *Router) setupFleetRoutes(router *mux.Router) {
+	// Fleets group the logged-in owner's cars, so only owners (and admins with cars) use them
+	fleets := router.PathPrefix("/owners/me/fleets").Subrouter()
+	fleets.Use(middleware.RequireRole("owner", "admin"))
+
+	// List and create fleets
+	// Body: { "name": "...", "pricing_rules": [...], "add_ons": [...], "blackouts": [...] }
+	fleets.HandleFunc("", r.FleetHandler.GetFleets).Methods("GET", "OPTIONS")
+	fleets.HandleFunc("", r.FleetHandler.CreateFleet).Methods("POST", "OPTIONS")
+
+	// Read, replace the settings of, or remove a fleet
+	fleets.HandleFunc("/{id}", r.FleetHandler.GetFleet).Methods("GET", "OPTIONS")
+	fleets.HandleFunc("/{id}", r.FleetHandler.UpdateFleet).Methods("PUT", "OPTIONS")
+	fleets.HandleFunc("/{id}", r.FleetHandler.DeleteFleet).Methods("DELETE", "OPTIONS")
+
+	// Fleet membership; a car is in at most one fleet, so adding it moves it
+	// Body: { "car_id": "..." }
+	fleets.HandleFunc("/{id}/cars", r.FleetHandler.AddCar).Methods("POST", "OPTIONS")
+	fleets.HandleFunc("/{id}/cars/{carID}", r.FleetHandler.RemoveCar).Methods("DELETE", "OPTIONS")
+}
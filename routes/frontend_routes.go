@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/frontend"
+)
+
+// setupPageRoutes sends browser page loads to the frontend before any API route can
+// claim their path, so client-side routes such as /cars/{id} may share paths with the API
+func (r *Router) setupPageRoutes(router *mux.Router) {
+	if r.Frontend == nil {
+		return
+	}
+
+	// GET /{page} - index.html for a document navigation to a path without a file extension
+	router.MatcherFunc(func(req *http.Request, _ *mux.RouteMatch) bool {
+		return frontend.IsNavigation(req)
+	}).Handler(r.Frontend)
+}
+
+// setupFrontendRoutes serves the frontend build for the GET requests no API route matched
+func (r *Router) setupFrontendRoutes(router *mux.Router) {
+	if r.Frontend == nil {
+		return
+	}
+
+	// GET / - index.html
+	// GET /assets/{file} - Build assets; hashed ones are cached for a year
+	router.PathPrefix("/").Methods("GET", "HEAD").Handler(r.Frontend)
+}
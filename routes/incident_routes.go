@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupIncidentRoutes configures routes for accidents and other incidents reported during bookings
+func (r *Router) setupIncidentRoutes(router *mux.Router) {
+	// POST /bookings/{id}/incidents - Report an incident while the booking is in progress (customer or owner)
+	// Body: { "kind": "accident|damage|theft|breakdown|other", "description": "...", "occurred_at": "...",
+	//         "location": "...", "police_report_number": "...", "photo_urls": ["https://..."] }
+	// GET lists the booking's incidents (customer, owner or admin)
+	router.HandleFunc("/bookings/{id}/incidents", r.IncidentHandler.ReportIncident).Methods("POST", "OPTIONS")
+	router.HandleFunc("/bookings/{id}/incidents", r.IncidentHandler.GetBookingIncidents).Methods("GET", "OPTIONS")
+
+	// GET /incidents/{id} - An incident with its documents
+	router.HandleFunc("/incidents/{id}", r.IncidentHandler.GetIncident).Methods("GET", "OPTIONS")
+
+	// POST /incidents/{id}/documents - Attach an insurance, police or other document until it is resolved
+	// Body: { "kind": "insurance_policy|insurance_claim|police_report|repair_estimate|other", "description": "...", "document_url": "..." }
+	router.HandleFunc("/incidents/{id}/documents", r.IncidentHandler.AddDocument).Methods("POST", "OPTIONS")
+
+	// PUT /incidents/{id}/status - Move the incident on (owner or admin; only admins triage new reports)
+	// Body: { "status": "under_review|claim_filed|resolved|closed", "insurance_claim_number": "...", "notes": "..." }
+	router.HandleFunc("/incidents/{id}/status", r.IncidentHandler.UpdateStatus).Methods("PUT", "OPTIONS")
+
+	// Incidents waiting on admins, the longest outstanding first
+	// (?status=reported|under_review|claim_filed|resolved|closed)
+	admin := router.PathPrefix("/admin/incidents").Subrouter()
+	admin.Use(middleware.RequireRole("admin"))
+	admin.HandleFunc("", r.IncidentHandler.ListIncidents).Methods("GET", "OPTIONS")
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupKYCRoutes configures the routes admins use to record renters' verified identity data
+func (r *Router) setupKYCRoutes(router *mux.Router) {
+	kyc := router.PathPrefix("/admin/users/{id}/kyc").Subrouter()
+	kyc.Use(middleware.RequireRole("admin"))
+
+	// GET /admin/users/{id}/kyc - The user's verified date of birth and licence issue date
+	kyc.HandleFunc("", r.KYCHandler.GetVerifiedKYC).Methods("GET", "OPTIONS")
+
+	// PUT /admin/users/{id}/kyc - Record the dates read from the user's documents
+	// Body: { "date_of_birth": "1995-04-12", "license_issued_on": "2014-06-01" }
+	kyc.HandleFunc("", r.KYCHandler.VerifyKYC).Methods("PUT", "OPTIONS")
+}
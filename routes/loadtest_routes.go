@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupLoadTestRoutes configures admin routes that prepare load tests against disposable data
+func (r *Router) setupLoadTestRoutes(router *mux.Router) {
+	// Fixtures create and delete users and cars, so only admins can use them
+	loadtest := router.PathPrefix("/admin/loadtest").Subrouter()
+	loadtest.Use(middleware.RequireRole("admin"))
+
+	// Create a run of fixtures and the targets to load them with; needs LOADTEST_ENABLED
+	loadtest.HandleFunc("/fixtures", r.LoadTestHandler.CreateFixtures).Methods("POST", "OPTIONS")
+
+	// Delete a run with its users, cars and bookings
+	loadtest.HandleFunc("/fixtures/{id}", r.LoadTestHandler.DeleteFixtures).Methods("DELETE", "OPTIONS")
+
+	// Performance budgets of the hot methods
+	loadtest.HandleFunc("/budgets", r.LoadTestHandler.GetBudgets).Methods("GET", "OPTIONS")
+}
@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/gorilla/mux"
+)
+
+// setupLocationRoutes configures pickup/drop-off location routes
+func (r *Router) setupLocationRoutes(router *mux.Router) {
+	adminOnly := middleware.RequireRole("admin")
+
+	// GET /locations - List active locations
+	// Query parameters: ?city=Bengaluru&include_inactive=true (admin only)
+	router.HandleFunc("/locations", r.LocationHandler.GetLocations).Methods("GET", "OPTIONS")
+
+	// GET /locations/{id} - Retrieve a single location
+	router.HandleFunc("/locations/{id}", r.LocationHandler.GetLocationByID).Methods("GET", "OPTIONS")
+
+	// POST /locations, PUT/DELETE /locations/{id} - Manage locations (admin only)
+	router.Handle("/locations", adminOnly(http.HandlerFunc(r.LocationHandler.CreateLocation))).Methods("POST", "OPTIONS")
+	router.Handle("/locations/{id}", adminOnly(http.HandlerFunc(r.LocationHandler.UpdateLocation))).Methods("PUT", "OPTIONS")
+	router.Handle("/locations/{id}", adminOnly(http.HandlerFunc(r.LocationHandler.DeleteLocation))).Methods("DELETE", "OPTIONS")
+
+	// GET /cars/{id}/locations - Locations the car can be picked up from or returned to
+	router.HandleFunc("/cars/{id}/locations", r.LocationHandler.GetCarLocations).Methods("GET", "OPTIONS")
+
+	// PUT/DELETE /cars/{id}/locations/{locationID} - Attach or detach a car (car owner or admin)
+	router.HandleFunc("/cars/{id}/locations/{locationID}", r.LocationHandler.AttachCar).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/cars/{id}/locations/{locationID}", r.LocationHandler.DetachCar).Methods("DELETE", "OPTIONS")
+
+	// GET /cars/{id}/delivery - Door delivery settings of a car
+	// PUT /cars/{id}/delivery - Configure door delivery (car owner or admin)
+	router.HandleFunc("/cars/{id}/delivery", r.LocationHandler.GetDeliveryOption).Methods("GET", "OPTIONS")
+	router.HandleFunc("/cars/{id}/delivery", r.LocationHandler.UpdateDeliveryOption).Methods("PUT", "OPTIONS")
+
+	// GET /relocation-fees - One-way fee matrix between cities
+	// PUT /relocation-fees - Set the fee of a city pair, DELETE /relocation-fees/{id} - Remove it (admin only)
+	router.HandleFunc("/relocation-fees", r.LocationHandler.GetRelocationFees).Methods("GET", "OPTIONS")
+	router.Handle("/relocation-fees", adminOnly(http.HandlerFunc(r.LocationHandler.SetRelocationFee))).Methods("PUT", "OPTIONS")
+	router.Handle("/relocation-fees/{id}", adminOnly(http.HandlerFunc(r.LocationHandler.DeleteRelocationFee))).Methods("DELETE", "OPTIONS")
+}
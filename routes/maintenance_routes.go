@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupMaintenanceRoutes configures the admin operational runbook
+func (r *Router) setupMaintenanceRoutes(router *mux.Router) {
+	// Maintenance tasks rewrite other users' records and are run by admins only
+	maintenance := router.PathPrefix("/admin/maintenance").Subrouter()
+	maintenance.Use(middleware.RequireRole("admin"))
+
+	// Latest jobs with who requested them and what they changed, filterable by task
+	maintenance.HandleFunc("/jobs", r.MaintenanceHandler.ListJobs).Methods("GET", "OPTIONS")
+
+	// Get a single job and its outcome
+	maintenance.HandleFunc("/jobs/{id}", r.MaintenanceHandler.GetJob).Methods("GET", "OPTIONS")
+
+	// Queue a task: rebuild_car_availability, recompute_booking_totals, resync_payment or reindex_search
+	// Body: { "target_id": "..." } to limit it to one car, booking or payment
+	maintenance.HandleFunc("/{task}", r.MaintenanceHandler.RequestJob).Methods("POST", "OPTIONS")
+}
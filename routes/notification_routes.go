@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// setupNotificationRoutes configures the in-app notification inbox routes
+func (r *Router) setupNotificationRoutes(router *mux.Router) {
+	// GET /notifications - The user's inbox, newest first
+	// Query parameters: ?unread=true&limit=20&cursor=...
+	router.HandleFunc("/notifications", r.NotificationHandler.ListNotifications).Methods("GET", "OPTIONS")
+
+	// GET /notifications/unread-count - Number of unread notifications for the bell icon
+	router.HandleFunc("/notifications/unread-count", r.NotificationHandler.GetUnreadCount).Methods("GET", "OPTIONS")
+
+	// POST /notifications/{id}/read - Mark a notification as read
+	router.HandleFunc("/notifications/{id}/read", r.NotificationHandler.MarkRead).Methods("POST", "OPTIONS")
+}
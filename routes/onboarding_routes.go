@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupOnboardingRoutes configures the onboarding progress of owners
+func (r *Router) setupOnboardingRoutes(router *mux.Router) {
+	onboarding := router.PathPrefix("/owners/me/onboarding").Subrouter()
+	onboarding.Use(middleware.RequireRole("owner", "admin"))
+
+	// GET /owners/me/onboarding - Profile, payout, first car and identity steps with the next one to take
+	onboarding.HandleFunc("", r.OnboardingHandler.GetMyProgress).Methods("GET", "OPTIONS")
+}
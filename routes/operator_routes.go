@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupOperatorRoutes configures the platform admin routes for marketplace operators
+func (r *Router) setupOperatorRoutes(router *mux.Router) {
+	operators := router.PathPrefix("/admin/operators").Subrouter()
+	operators.Use(middleware.RequireRole("admin"))
+
+	// GET /admin/operators - List every operator
+	operators.HandleFunc("", r.OperatorHandler.ListOperators).Methods("GET", "OPTIONS")
+
+	// POST /admin/operators - Onboard an operator with its slug, domain, branding and commission
+	operators.HandleFunc("", r.OperatorHandler.CreateOperator).Methods("POST", "OPTIONS")
+
+	// GET /admin/operators/{id} - Retrieve a single operator
+	operators.HandleFunc("/{id}", r.OperatorHandler.GetOperator).Methods("GET", "OPTIONS")
+
+	// PUT /admin/operators/{id} - Replace an operator's settings
+	operators.HandleFunc("/{id}", r.OperatorHandler.UpdateOperator).Methods("PUT", "OPTIONS")
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"github.com/PrateekKumar15/CarZone/middleware"
 	"github.com/gorilla/mux"
 )
 
@@ -26,6 +27,32 @@ func (r *Router) setupPaymentRoutes(router *mux.Router) {
 	// Get all payments for a user
 	router.HandleFunc("/payments/user/{user_id}", r.PaymentHandler.GetPaymentsByUserID).Methods("GET", "OPTIONS")
 
+	// Retry a failed payment with a new Razorpay order (booking's customer or admin)
+	router.HandleFunc("/payments/{id}/retry", r.PaymentHandler.RetryPayment).Methods("POST", "OPTIONS")
+
 	// Process refund for a payment
 	router.HandleFunc("/payments/{payment_id}/refund", r.PaymentHandler.ProcessRefund).Methods("POST", "OPTIONS")
+
+	// Admin-only payment links for bookings taken over the phone
+	admin := router.PathPrefix("/admin/bookings").Subrouter()
+	admin.Use(middleware.RequireRole("admin"))
+
+	// POST /admin/bookings/{id}/payment-link - Send the customer a Razorpay payment link by e-mail and SMS
+	// Body (optional): {"amount": 4500, "description": "..."}; amount defaults to the booking total
+	admin.HandleFunc("/{id}/payment-link", r.PaymentHandler.CreatePaymentLink).Methods("POST", "OPTIONS")
+
+	// Admin-only payment detail views for dispute resolution
+	payments := router.PathPrefix("/admin/payments").Subrouter()
+	payments.Use(middleware.RequireRole("admin"))
+
+	// GET /admin/payments/{id} - Payment with every gateway attempt (order, payment ID, status, error code)
+	payments.HandleFunc("/{id}", r.PaymentHandler.GetPaymentDetail).Methods("GET", "OPTIONS")
+}
+
+// setupPaymentWebhookRoutes configures the endpoint Razorpay posts payment link events to.
+// Razorpay signs each delivery with the webhook secret, so no user session is involved.
+func (r *Router) setupPaymentWebhookRoutes(router *mux.Router) {
+	// POST /webhooks/razorpay - payment.failed, payment_link.paid, payment_link.expired and payment_link.cancelled
+	// Headers: X-Razorpay-Signature
+	router.HandleFunc("/webhooks/razorpay", r.PaymentHandler.RazorpayWebhook).Methods("POST")
 }
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupPayoutRoutes configures owner payout account routes
+func (r *Router) setupPayoutRoutes(router *mux.Router) {
+	// Payout details are only managed by car owners (and admins acting on their own account)
+	payouts := router.PathPrefix("/owners/me/payout-accounts").Subrouter()
+	payouts.Use(middleware.RequireRole("owner", "admin"))
+
+	// List and register payout accounts
+	payouts.HandleFunc("", r.PayoutHandler.GetPayoutAccounts).Methods("GET", "OPTIONS")
+	payouts.HandleFunc("", r.PayoutHandler.RegisterPayoutAccount).Methods("POST", "OPTIONS")
+
+	// Re-check verification status with the payment gateway
+	payouts.HandleFunc("/{id}/verify", r.PayoutHandler.VerifyPayoutAccount).Methods("POST", "OPTIONS")
+
+	// Remove a payout account
+	payouts.HandleFunc("/{id}", r.PayoutHandler.DeletePayoutAccount).Methods("DELETE", "OPTIONS")
+
+	// Holds open disputes place on the owner's payouts
+	holds := router.PathPrefix("/owners/me/payout-holds").Subrouter()
+	holds.Use(middleware.RequireRole("owner", "admin"))
+	holds.HandleFunc("", r.PayoutHandler.GetPayoutHolds).Methods("GET", "OPTIONS")
+}
@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// setupPublicCatalogRoutes configures the unauthenticated, cache-friendly car catalog
+func (r *Router) setupPublicCatalogRoutes(router *mux.Router) {
+	// GET /public/cars - Paginated catalog of active cars, safe fields only
+	// Query parameters: ?limit=20&cursor={next_cursor}
+	router.HandleFunc("/public/cars", r.CarHandler.GetPublicCars).Methods("GET", "HEAD", "OPTIONS")
+
+	// GET /public/cars/search - Catalog search by brand and the other criteria of /cars/search
+	// Query parameters: ?brand=&model=&fuel_type=&city=&state=&min_price=&max_price=&min_year=&max_year=&is_available=&count=&limit=&cursor=
+	// Registered before /public/cars/{id} so "search" is not taken for an ID
+	router.HandleFunc("/public/cars/search", r.CarHandler.GetPublicCars).Methods("GET", "HEAD", "OPTIONS")
+
+	// GET /public/cars/{id} - Catalog view of a single active car
+	// Path parameter: UUID of the car
+	router.HandleFunc("/public/cars/{id}", r.CarHandler.GetPublicCarByID).Methods("GET", "HEAD", "OPTIONS")
+
+	// GET /public/cars/slug/{slug} - Catalog view of a single active car by slug
+	router.HandleFunc("/public/cars/slug/{slug}", r.CarHandler.GetPublicCarBySlug).Methods("GET", "HEAD", "OPTIONS")
+
+	// GET /users/{id}/public-profile - Owner's display name, rating, response metrics and active listings
+	// Path parameter: UUID of the owner
+	router.HandleFunc("/users/{id}/public-profile", r.ProfileHandler.GetPublicProfile).Methods("GET", "HEAD", "OPTIONS")
+
+	// GET /sitemap.xml - Sitemap of all active listings, refreshed on a schedule
+	router.HandleFunc("/sitemap.xml", r.SitemapHandler.GetSitemap).Methods("GET", "HEAD")
+
+	// GET /public/feed.json - JSON feed of active listings (id, slug, url, updated_at)
+	router.HandleFunc("/public/feed.json", r.SitemapHandler.GetFeed).Methods("GET", "HEAD", "OPTIONS")
+
+	// GET /limits - The caller's rate limit quota, per user when a token is sent, otherwise per IP
+	router.HandleFunc("/limits", r.LimitsHandler.GetLimits).Methods("GET", "OPTIONS")
+
+	// GET /operator - Name and branding of the marketplace serving this host
+	router.HandleFunc("/operator", r.OperatorHandler.GetCurrentOperator).Methods("GET", "OPTIONS")
+}
@@ -1,32 +1,146 @@
 package routes
 
 import (
+	"net/http"
+
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 
+	accountingHandler "github.com/PrateekKumar15/CarZone/handler/accounting"
+	adjustmentHandler "github.com/PrateekKumar15/CarZone/handler/adjustment"
+	alertHandler "github.com/PrateekKumar15/CarZone/handler/alert"
+	analyticsHandler "github.com/PrateekKumar15/CarZone/handler/analytics"
+	attributeHandler "github.com/PrateekKumar15/CarZone/handler/attribute"
 	authHandler "github.com/PrateekKumar15/CarZone/handler/auth"
+	blockHandler "github.com/PrateekKumar15/CarZone/handler/block"
 	bookingHandler "github.com/PrateekKumar15/CarZone/handler/booking"
+	brandHandler "github.com/PrateekKumar15/CarZone/handler/brand"
 	carHandler "github.com/PrateekKumar15/CarZone/handler/car"
+	checklistHandler "github.com/PrateekKumar15/CarZone/handler/checklist"
+	debugCaptureHandler "github.com/PrateekKumar15/CarZone/handler/debugcapture"
+	disputeHandler "github.com/PrateekKumar15/CarZone/handler/dispute"
+	emailTemplateHandler "github.com/PrateekKumar15/CarZone/handler/emailtemplate"
+	favoriteHandler "github.com/PrateekKumar15/CarZone/handler/favorite"
+	featureHandler "github.com/PrateekKumar15/CarZone/handler/feature"
+	featuredHandler "github.com/PrateekKumar15/CarZone/handler/featured"
+	fleetHandler "github.com/PrateekKumar15/CarZone/handler/fleet"
+	incidentHandler "github.com/PrateekKumar15/CarZone/handler/incident"
+	kycHandler "github.com/PrateekKumar15/CarZone/handler/kyc"
+	limitsHandler "github.com/PrateekKumar15/CarZone/handler/limits"
+	loadTestHandler "github.com/PrateekKumar15/CarZone/handler/loadtest"
+	locationHandler "github.com/PrateekKumar15/CarZone/handler/location"
+	maintenanceHandler "github.com/PrateekKumar15/CarZone/handler/maintenance"
+	notificationHandler "github.com/PrateekKumar15/CarZone/handler/notification"
+	onboardingHandler "github.com/PrateekKumar15/CarZone/handler/onboarding"
+	operatorHandler "github.com/PrateekKumar15/CarZone/handler/operator"
 	paymentHandler "github.com/PrateekKumar15/CarZone/handler/payment"
+	payoutHandler "github.com/PrateekKumar15/CarZone/handler/payout"
+	profileHandler "github.com/PrateekKumar15/CarZone/handler/profile"
+	searchHandler "github.com/PrateekKumar15/CarZone/handler/search"
+	securityHandler "github.com/PrateekKumar15/CarZone/handler/security"
+	settingHandler "github.com/PrateekKumar15/CarZone/handler/setting"
+	sitemapHandler "github.com/PrateekKumar15/CarZone/handler/sitemap"
+	smartLockHandler "github.com/PrateekKumar15/CarZone/handler/smartlock"
+	statementHandler "github.com/PrateekKumar15/CarZone/handler/statement"
+	telemetryHandler "github.com/PrateekKumar15/CarZone/handler/telemetry"
+	userHandler "github.com/PrateekKumar15/CarZone/handler/user"
+	vacationHandler "github.com/PrateekKumar15/CarZone/handler/vacation"
+	valuationHandler "github.com/PrateekKumar15/CarZone/handler/valuation"
+	warehouseHandler "github.com/PrateekKumar15/CarZone/handler/warehouse"
 	"github.com/PrateekKumar15/CarZone/middleware"
 )
 
 // Router holds all the handler dependencies
 type Router struct {
-	AuthHandler    *authHandler.AuthHandler
-	CarHandler     *carHandler.CarHandler
-	BookingHandler *bookingHandler.BookingHandler
-	PaymentHandler *paymentHandler.PaymentHandler
+	AuthHandler          *authHandler.AuthHandler
+	CarHandler           *carHandler.CarHandler
+	BookingHandler       *bookingHandler.BookingHandler
+	PaymentHandler       *paymentHandler.PaymentHandler
+	SitemapHandler       *sitemapHandler.SitemapHandler
+	PayoutHandler        *payoutHandler.PayoutHandler
+	SecurityHandler      *securityHandler.SecurityHandler
+	TelemetryHandler     *telemetryHandler.TelemetryHandler
+	LocationHandler      *locationHandler.LocationHandler
+	AlertHandler         *alertHandler.AlertHandler
+	AnalyticsHandler     *analyticsHandler.AnalyticsHandler
+	WarehouseHandler     *warehouseHandler.WarehouseHandler
+	SearchHandler        *searchHandler.SearchHandler
+	LimitsHandler        *limitsHandler.LimitsHandler
+	OperatorHandler      *operatorHandler.OperatorHandler
+	NotificationHandler  *notificationHandler.NotificationHandler
+	EmailTemplateHandler *emailTemplateHandler.EmailTemplateHandler
+	VacationHandler      *vacationHandler.VacationHandler
+	FleetHandler         *fleetHandler.FleetHandler
+	FeatureHandler       *featureHandler.FeatureHandler
+	BrandHandler         *brandHandler.BrandHandler
+	DisputeHandler       *disputeHandler.DisputeHandler
+	AdjustmentHandler    *adjustmentHandler.AdjustmentHandler
+	StatementHandler     *statementHandler.StatementHandler
+	CarAttributeHandler  *attributeHandler.CarAttributeHandler
+	KYCHandler           *kycHandler.KYCHandler
+	SettingHandler       *settingHandler.SettingHandler
+	FeaturedHandler      *featuredHandler.FeaturedHandler
+	ProfileHandler       *profileHandler.ProfileHandler
+	BlockHandler         *blockHandler.BlockHandler
+	MaintenanceHandler   *maintenanceHandler.MaintenanceHandler
+	DebugCaptureHandler  *debugCaptureHandler.DebugCaptureHandler
+	LoadTestHandler      *loadTestHandler.LoadTestHandler
+	FavoriteHandler      *favoriteHandler.FavoriteHandler
+	UserHandler          *userHandler.UserHandler
+	AccountingHandler    *accountingHandler.AccountingHandler
+	ValuationHandler     *valuationHandler.ValuationHandler
+	SmartLockHandler     *smartLockHandler.SmartLockHandler
+	IncidentHandler      *incidentHandler.IncidentHandler
+	ChecklistHandler     *checklistHandler.ChecklistHandler
+	OnboardingHandler    *onboardingHandler.OnboardingHandler
+	Frontend             http.Handler // Optional SPA build served at /; nil serves the API alone
 }
 
 // NewRouter creates a new router instance with handler dependencies
-func NewRouter(authHandler *authHandler.AuthHandler, carHandler *carHandler.CarHandler, bookingHandler *bookingHandler.BookingHandler, paymentHandler *paymentHandler.PaymentHandler) *Router {
+func NewRouter(authHandler *authHandler.AuthHandler, carHandler *carHandler.CarHandler, bookingHandler *bookingHandler.BookingHandler, paymentHandler *paymentHandler.PaymentHandler, sitemapHandler *sitemapHandler.SitemapHandler, payoutHandler *payoutHandler.PayoutHandler, securityHandler *securityHandler.SecurityHandler, telemetryHandler *telemetryHandler.TelemetryHandler, locationHandler *locationHandler.LocationHandler, alertHandler *alertHandler.AlertHandler, analyticsHandler *analyticsHandler.AnalyticsHandler, warehouseHandler *warehouseHandler.WarehouseHandler, searchHandler *searchHandler.SearchHandler, limitsHandler *limitsHandler.LimitsHandler, operatorHandler *operatorHandler.OperatorHandler, notificationHandler *notificationHandler.NotificationHandler, emailTemplateHandler *emailTemplateHandler.EmailTemplateHandler, vacationHandler *vacationHandler.VacationHandler, fleetHandler *fleetHandler.FleetHandler, featureHandler *featureHandler.FeatureHandler, brandHandler *brandHandler.BrandHandler, disputeHandler *disputeHandler.DisputeHandler, adjustmentHandler *adjustmentHandler.AdjustmentHandler, statementHandler *statementHandler.StatementHandler, carAttributeHandler *attributeHandler.CarAttributeHandler, kycHandler *kycHandler.KYCHandler, settingHandler *settingHandler.SettingHandler, featuredHandler *featuredHandler.FeaturedHandler, profileHandler *profileHandler.ProfileHandler, blockHandler *blockHandler.BlockHandler, maintenanceHandler *maintenanceHandler.MaintenanceHandler, debugCaptureHandler *debugCaptureHandler.DebugCaptureHandler, loadTestHandler *loadTestHandler.LoadTestHandler, favoriteHandler *favoriteHandler.FavoriteHandler, userHandler *userHandler.UserHandler, accountingHandler *accountingHandler.AccountingHandler, valuationHandler *valuationHandler.ValuationHandler, smartLockHandler *smartLockHandler.SmartLockHandler, incidentHandler *incidentHandler.IncidentHandler, checklistHandler *checklistHandler.ChecklistHandler, onboardingHandler *onboardingHandler.OnboardingHandler) *Router {
 	return &Router{
-		AuthHandler:    authHandler,
-		CarHandler:     carHandler,
-		BookingHandler: bookingHandler,
-		PaymentHandler: paymentHandler,
+		AuthHandler:          authHandler,
+		CarHandler:           carHandler,
+		BookingHandler:       bookingHandler,
+		PaymentHandler:       paymentHandler,
+		SitemapHandler:       sitemapHandler,
+		PayoutHandler:        payoutHandler,
+		SecurityHandler:      securityHandler,
+		TelemetryHandler:     telemetryHandler,
+		LocationHandler:      locationHandler,
+		AlertHandler:         alertHandler,
+		AnalyticsHandler:     analyticsHandler,
+		WarehouseHandler:     warehouseHandler,
+		SearchHandler:        searchHandler,
+		LimitsHandler:        limitsHandler,
+		OperatorHandler:      operatorHandler,
+		NotificationHandler:  notificationHandler,
+		EmailTemplateHandler: emailTemplateHandler,
+		VacationHandler:      vacationHandler,
+		FleetHandler:         fleetHandler,
+		FeatureHandler:       featureHandler,
+		BrandHandler:         brandHandler,
+		DisputeHandler:       disputeHandler,
+		AdjustmentHandler:    adjustmentHandler,
+		StatementHandler:     statementHandler,
+		CarAttributeHandler:  carAttributeHandler,
+		KYCHandler:           kycHandler,
+		SettingHandler:       settingHandler,
+		FeaturedHandler:      featuredHandler,
+		ProfileHandler:       profileHandler,
+		BlockHandler:         blockHandler,
+		MaintenanceHandler:   maintenanceHandler,
+		DebugCaptureHandler:  debugCaptureHandler,
+		LoadTestHandler:      loadTestHandler,
+		FavoriteHandler:      favoriteHandler,
+		UserHandler:          userHandler,
+		AccountingHandler:    accountingHandler,
+		ValuationHandler:     valuationHandler,
+		SmartLockHandler:     smartLockHandler,
+		IncidentHandler:      incidentHandler,
+		ChecklistHandler:     checklistHandler,
+		OnboardingHandler:    onboardingHandler,
 	}
 }
 
@@ -40,6 +154,9 @@ func (r *Router) SetupRoutes() *mux.Router {
 	// Add OpenTelemetry middleware for tracing
 	router.Use(otelmux.Middleware("CarZone"))
 
+	// Setup page loads of the frontend, ahead of the API routes
+	r.setupPageRoutes(router)
+
 	// Setup public routes (no authentication required)
 	r.setupPublicRoutes(router)
 
@@ -49,6 +166,9 @@ func (r *Router) SetupRoutes() *mux.Router {
 	// Setup monitoring routes
 	r.setupMonitoringRoutes(router)
 
+	// Setup frontend assets last, for GET requests no API route matched
+	r.setupFrontendRoutes(router)
+
 	return router
 }
 
@@ -59,6 +179,18 @@ func (r *Router) setupPublicRoutes(router *mux.Router) {
 
 	// Authentication routes
 	r.setupAuthRoutes(public)
+
+	// Public car catalog, sitemap and feed for marketing sites and crawlers
+	r.setupPublicCatalogRoutes(public)
+
+	// Signed readings from car telematics devices
+	r.setupTelemetryIngestRoutes(public)
+
+	// Signed payment link events from Razorpay
+	r.setupPaymentWebhookRoutes(public)
+
+	// Signed lock and unlock events from smart-lock providers
+	r.setupSmartLockWebhookRoutes(public)
 }
 
 // setupProtectedRoutes configures routes that require authentication
@@ -74,6 +206,41 @@ func (r *Router) setupProtectedRoutes(router *mux.Router) {
 	r.setupCarRoutes(protected)
 	r.setupBookingRoutes(protected)
 	r.setupPaymentRoutes(protected)
+	r.setupPayoutRoutes(protected)
+	r.setupSecurityRoutes(protected)
+	r.setupTelemetryRoutes(protected)
+	r.setupLocationRoutes(protected)
+	r.setupAlertRoutes(protected)
+	r.setupAnalyticsRoutes(protected)
+	r.setupWarehouseRoutes(protected)
+	r.setupSearchRoutes(protected)
+	r.setupOperatorRoutes(protected)
+	r.setupNotificationRoutes(protected)
+	r.setupEmailTemplateRoutes(protected)
+	r.setupVacationRoutes(protected)
+	r.setupFleetRoutes(protected)
+	r.setupFeatureRoutes(protected)
+	r.setupCarAttributeRoutes(protected)
+	r.setupKYCRoutes(protected)
+	r.setupSettingRoutes(protected)
+	r.setupFeaturedRoutes(protected)
+	r.setupBlockRoutes(protected)
+	r.setupMaintenanceRoutes(protected)
+	r.setupBrandRoutes(protected)
+	r.setupDisputeRoutes(protected)
+	r.setupAdjustmentRoutes(protected)
+	r.setupStatementRoutes(protected)
+	r.setupDebugCaptureRoutes(protected)
+	r.setupLoadTestRoutes(protected)
+	r.setupFavoriteRoutes(protected)
+	r.setupUserRoutes(protected)
+	r.setupAccountingRoutes(protected)
+	r.setupValuationRoutes(protected)
+	r.setupSmartLockRoutes(protected)
+	r.setupIncidentRoutes(protected)
+	r.setupChecklistRoutes(protected)
+	r.setupTwoFactorRoutes(protected)
+	r.setupOnboardingRoutes(protected)
 }
 
 // setupMonitoringRoutes configures monitoring and metrics routes
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupSearchRoutes configures admin support search
+func (r *Router) setupSearchRoutes(router *mux.Router) {
+	search := router.PathPrefix("/admin/search").Subrouter()
+	search.Use(middleware.RequireRole("admin"))
+
+	// Find users, cars, bookings and payments by e-mail, phone, license plate, payment ID or record ID
+	// Query parameters: ?q=<term>
+	search.HandleFunc("", r.SearchHandler.Search).Methods("GET", "OPTIONS")
+}
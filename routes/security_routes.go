@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupSecurityRoutes configures the admin review queue for security events
+func (r *Router) setupSecurityRoutes(router *mux.Router) {
+	// Security events expose other users' activity and are reviewed by admins only
+	events := router.PathPrefix("/admin/security-events").Subrouter()
+	events.Use(middleware.RequireRole("admin"))
+
+	// Review queue, filterable by status, type and user_id
+	events.HandleFunc("", r.SecurityHandler.ListSecurityEvents).Methods("GET", "OPTIONS")
+
+	// Get a single security event
+	events.HandleFunc("/{id}", r.SecurityHandler.GetSecurityEventByID).Methods("GET", "OPTIONS")
+
+	// Mark an event as reviewed or dismissed
+	events.HandleFunc("/{id}/review", r.SecurityHandler.ReviewSecurityEvent).Methods("PUT", "OPTIONS")
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupSettingRoutes configures the settings of the operator the request acts for
+func (r *Router) setupSettingRoutes(router *mux.Router) {
+	settings := router.PathPrefix("/admin/settings").Subrouter()
+	settings.Use(middleware.RequireRole("admin"))
+
+	// GET /admin/settings/search-ranking - Weights of ranked car listings (GET /cars?sort=rank)
+	settings.HandleFunc("/search-ranking", r.SettingHandler.GetSearchRanking).Methods("GET", "OPTIONS")
+
+	// PUT /admin/settings/search-ranking - Replace the weights, each between 0 and 10
+	// Body: { "recency": 1, "rating": 3, "acceptance_rate": 2, "price_competitiveness": 2, "photo_count": 1 }
+	settings.HandleFunc("/search-ranking", r.SettingHandler.UpdateSearchRanking).Methods("PUT", "OPTIONS")
+
+	// GET /admin/settings/depreciation - Curves fleet valuations depreciate cars with (GET /owners/me/fleet-valuation)
+	settings.HandleFunc("/depreciation", r.SettingHandler.GetDepreciation).Methods("GET", "OPTIONS")
+
+	// PUT /admin/settings/depreciation - Replace the curves; by_fuel_type overrides the default for a fuel type
+	// Body: { "default": { "annual_rates": [15, 12, 10, 8], "expected_km_per_year": 15000, "excess_km_rate": 2, "floor_percent": 10 },
+	//         "by_fuel_type": { "Electric": { "annual_rates": [20, 15, 12, 10], ... } } }
+	settings.HandleFunc("/depreciation", r.SettingHandler.UpdateDepreciation).Methods("PUT", "OPTIONS")
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// setupSmartLockWebhookRoutes configures the endpoint smart-lock providers post lock events to.
+// Providers sign each delivery with the webhook secret, so no user session is involved.
+func (r *Router) setupSmartLockWebhookRoutes(router *mux.Router) {
+	// POST /webhooks/smart-locks - A lock or unlock of a car's smart lock
+	// Headers: X-Smart-Lock-Signature
+	// Body: { "id": "evt_1", "provider": "http", "lock_ref": "...", "action": "unlock", "key_id": "optional", "occurred_at": "..." }
+	router.HandleFunc("/webhooks/smart-locks", r.SmartLockHandler.Webhook).Methods("POST")
+}
+
+// setupSmartLockRoutes configures keyless entry routes for car owners and their renters
+func (r *Router) setupSmartLockRoutes(router *mux.Router) {
+	// GET/PUT/DELETE /cars/{id}/smart-lock - The smart lock fitted in the car (owner or admin)
+	// Body: { "provider": "http", "lock_ref": "..." }
+	router.HandleFunc("/cars/{id}/smart-lock", r.SmartLockHandler.GetSmartLock).Methods("GET", "OPTIONS")
+	router.HandleFunc("/cars/{id}/smart-lock", r.SmartLockHandler.SetSmartLock).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/cars/{id}/smart-lock", r.SmartLockHandler.DeleteSmartLock).Methods("DELETE", "OPTIONS")
+
+	// GET /bookings/{id}/digital-keys - Keys issued for the booking (customer, owner or admin)
+	// POST revokes the current key and issues a new one (owner or admin)
+	router.HandleFunc("/bookings/{id}/digital-keys", r.SmartLockHandler.GetDigitalKeys).Methods("GET", "OPTIONS")
+	router.HandleFunc("/bookings/{id}/digital-keys", r.SmartLockHandler.ReissueDigitalKey).Methods("POST", "OPTIONS")
+
+	// GET /bookings/{id}/lock-events - Lock and unlock events made with the booking's keys
+	router.HandleFunc("/bookings/{id}/lock-events", r.SmartLockHandler.GetLockEvents).Methods("GET", "OPTIONS")
+}
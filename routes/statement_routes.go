@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// setupStatementRoutes configures routes for the caller's payment statements
+func (r *Router) setupStatementRoutes(router *mux.Router) {
+	// Statement of payments and refunds (?from=YYYY-MM-DD&to=YYYY-MM-DD&format=pdf|csv)
+	// Periods up to STATEMENT_SYNC_MAX_DAYS download at once; longer ones answer 202 and are rendered in the background
+	router.HandleFunc("/payments/me/statement", r.StatementHandler.RequestStatement).Methods("GET", "OPTIONS")
+
+	// Status of a statement rendered in the background
+	router.HandleFunc("/payments/me/statements/{id}", r.StatementHandler.GetStatement).Methods("GET", "OPTIONS")
+
+	// File of a statement once it is ready
+	router.HandleFunc("/payments/me/statements/{id}/download", r.StatementHandler.DownloadStatement).Methods("GET", "OPTIONS")
+}
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// setupTelemetryIngestRoutes configures the device-facing ingestion endpoint.
+// Devices sign each payload with their own secret, so no user session is involved.
+func (r *Router) setupTelemetryIngestRoutes(router *mux.Router) {
+	// POST /telemetry - Store one signed reading (location, odometer, fuel/battery level)
+	// Headers: X-Telemetry-Device, X-Telemetry-Timestamp, X-Telemetry-Signature
+	router.HandleFunc("/telemetry", r.TelemetryHandler.IngestReading).Methods("POST")
+}
+
+// setupTelemetryRoutes configures owner-facing telemetry routes
+func (r *Router) setupTelemetryRoutes(router *mux.Router) {
+	// POST /cars/{id}/telemetry/device - Provision the car's device or rotate its secret
+	// The secret is returned only in this response
+	router.HandleFunc("/cars/{id}/telemetry/device", r.TelemetryHandler.ProvisionDevice).Methods("POST", "OPTIONS")
+
+	// GET /cars/{id}/telemetry/latest - Latest value of every metric reported by the car
+	router.HandleFunc("/cars/{id}/telemetry/latest", r.TelemetryHandler.GetLatestSnapshot).Methods("GET", "OPTIONS")
+
+	// Geofences: regions a rented car must stay in; exits during active rentals alert the owner
+
+	// GET/POST /cars/{id}/geofences - List or define geofences of the car
+	// Body: { "name": "...", "center_latitude": 12.97, "center_longitude": 77.59, "radius_km": 50, "booking_id": "optional" }
+	router.HandleFunc("/cars/{id}/geofences", r.TelemetryHandler.GetGeofences).Methods("GET", "OPTIONS")
+	router.HandleFunc("/cars/{id}/geofences", r.TelemetryHandler.CreateGeofence).Methods("POST", "OPTIONS")
+
+	// GET /cars/{id}/geofences/breaches - Recent exits detected for the car
+	router.HandleFunc("/cars/{id}/geofences/breaches", r.TelemetryHandler.GetGeofenceBreaches).Methods("GET", "OPTIONS")
+
+	// DELETE /cars/{id}/geofences/{geofenceID} - Remove a geofence
+	router.HandleFunc("/cars/{id}/geofences/{geofenceID}", r.TelemetryHandler.DeleteGeofence).Methods("DELETE", "OPTIONS")
+}
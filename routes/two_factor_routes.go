@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// setupTwoFactorRoutes configures two-factor authentication setup for the logged-in user. Login
+// itself stays on the public /auth/login route, which takes the code in two_factor_code.
+func (r *Router) setupTwoFactorRoutes(router *mux.Router) {
+	// POST /auth/2fa/enable - Generate a TOTP secret and otpauth URL for an authenticator app
+	router.HandleFunc("/auth/2fa/enable", r.AuthHandler.EnableTwoFactorHandler).Methods("POST", "OPTIONS")
+
+	// POST /auth/2fa/verify - Confirm the secret with a code from the app and receive backup codes
+	// Body: { "code": "123456" }
+	router.HandleFunc("/auth/2fa/verify", r.AuthHandler.VerifyTwoFactorHandler).Methods("POST", "OPTIONS")
+}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupUserRoutes configures the routes users manage their own account with, and the routes
+// admins use to manage everyone's
+func (r *Router) setupUserRoutes(router *mux.Router) {
+	// GET /users/me - The signed-in user's account
+	router.HandleFunc("/users/me", r.UserHandler.GetMe).Methods("GET", "OPTIONS")
+
+	// PUT /users/me - Replace the signed-in user's account details, password included; the role cannot change
+	// Body: { "email": "...", "password": "...", "username": "...", "phone": "...", "license_number": "..." }
+	router.HandleFunc("/users/me", r.UserHandler.UpdateMe).Methods("PUT", "OPTIONS")
+
+	// PATCH /users/me/profile - Merge fields into the signed-in user's profile data; null removes a field
+	// Body: { "display_name": "...", "address": { "city": "Pune" }, "preferences": { "language": "hi" }, "license_number": "..." }
+	router.HandleFunc("/users/me/profile", r.UserHandler.UpdateMyProfile).Methods("PATCH", "OPTIONS")
+
+	// DELETE /users/me - Close the signed-in user's account and end the session
+	router.HandleFunc("/users/me", r.UserHandler.DeleteMe).Methods("DELETE", "OPTIONS")
+
+	users := router.PathPrefix("/admin/users").Subrouter()
+	users.Use(middleware.RequireRole("admin"))
+
+	// GET /admin/users - All users, newest first, paginated
+	users.HandleFunc("", r.UserHandler.ListUsers).Methods("GET", "OPTIONS")
+
+	// GET /admin/users/role/{role} - All owners, renters or admins
+	users.HandleFunc("/role/{role}", r.UserHandler.GetUsersByRole).Methods("GET", "OPTIONS")
+
+	// GET /admin/users/{id} - A user's account
+	users.HandleFunc("/{id}", r.UserHandler.GetUser).Methods("GET", "OPTIONS")
+
+	// PUT /admin/users/{id} - Replace a user's account details, password included
+	// Body: { "email": "...", "password": "...", "username": "...", "phone": "...", "license_number": "...", "role": "renter" }
+	users.HandleFunc("/{id}", r.UserHandler.UpdateUser).Methods("PUT", "OPTIONS")
+
+	// DELETE /admin/users/{id} - Delete a user who has no bookings
+	users.HandleFunc("/{id}", r.UserHandler.DeleteUser).Methods("DELETE", "OPTIONS")
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupVacationRoutes configures owner vacation mode routes
+func (r *Router) setupVacationRoutes(router *mux.Router) {
+	// Vacations block every car the owner has, so only owners (and admins with cars) use them
+	vacation := router.PathPrefix("/owners/me/vacation").Subrouter()
+	vacation.Use(middleware.RequireRole("owner", "admin"))
+
+	// POST /owners/me/vacation - Block all of the owner's cars for a period
+	// Body: { "start_date": "2024-03-01T00:00:00Z", "end_date": "2024-03-15T00:00:00Z", "note": "..." }
+	vacation.HandleFunc("", r.VacationHandler.StartVacation).Methods("POST", "OPTIONS")
+
+	// GET /owners/me/vacation - The owner's vacations, latest first
+	vacation.HandleFunc("", r.VacationHandler.GetVacations).Methods("GET", "OPTIONS")
+
+	// DELETE /owners/me/vacation/{id} - Lift a vacation, unblocking all its cars
+	vacation.HandleFunc("/{id}", r.VacationHandler.EndVacation).Methods("DELETE", "OPTIONS")
+}
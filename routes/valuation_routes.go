@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupValuationRoutes configures the owner fleet valuation report
+func (r *Router) setupValuationRoutes(router *mux.Router) {
+	// Valuations cover the logged-in owner's cars, so only owners (and admins with cars) use them
+	valuation := router.PathPrefix("/owners/me/fleet-valuation").Subrouter()
+	valuation.Use(middleware.RequireRole("owner", "admin"))
+
+	// Estimated value of each car that is not retired, with totals; ?format=csv downloads it
+	valuation.HandleFunc("", r.ValuationHandler.GetFleetValuation).Methods("GET", "OPTIONS")
+
+	// Record what the owner paid for a car, which estimates depreciate from
+	// Body: { "purchase_price": 850000, "purchased_on": "2022-03-15" }
+	valuation.HandleFunc("/cars/{carID}", r.ValuationHandler.SetCarPurchase).Methods("PUT", "OPTIONS")
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+)
+
+// setupWarehouseRoutes configures the data warehouse export routes
+func (r *Router) setupWarehouseRoutes(router *mux.Router) {
+	warehouse := router.PathPrefix("/admin/warehouse").Subrouter()
+	warehouse.Use(middleware.RequireRole("admin"))
+
+	// Re-export rows changed in a range; the nightly watermark is not moved
+	warehouse.HandleFunc("/backfill", r.WarehouseHandler.Backfill).Methods("POST", "OPTIONS")
+}
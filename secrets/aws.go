@@ -0,0 +1,105 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// AWSSecretsManagerProvider reads secrets from one AWS Secrets Manager secret whose
+// SecretString is a JSON object of name/value pairs. Credentials and region come from
+// the default AWS chain (environment, shared config or instance/task role).
+type AWSSecretsManagerProvider struct {
+	secretID string
+	cfg      aws.Config
+	signer   *v4.Signer
+	client   *http.Client
+}
+
+// NewAWSSecretsManagerProvider creates an AWS Secrets Manager provider for secretID (name or ARN)
+func NewAWSSecretsManagerProvider(ctx context.Context, secretID string) (*AWSSecretsManagerProvider, error) {
+	if secretID == "" {
+		return nil, errors.New("AWS_SECRETS_MANAGER_SECRET_ID is required for the aws secrets provider")
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %v", err)
+	}
+	if cfg.Region == "" {
+		return nil, errors.New("AWS_REGION is required for the aws secrets provider")
+	}
+
+	return &AWSSecretsManagerProvider{
+		secretID: secretID,
+		cfg:      cfg,
+		signer:   v4.NewSigner(),
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Name identifies the backend in logs
+func (p *AWSSecretsManagerProvider) Name() string {
+	return "aws-secrets-manager:" + p.secretID
+}
+
+// Fetch calls GetSecretValue for the current version of the secret
+func (p *AWSSecretsManagerProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	payload, err := json.Marshal(map[string]string{"SecretId": p.secretID})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("https://secretsmanager.%s.amazonaws.com/", p.cfg.Region)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	credentials, err := p.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve AWS credentials: %v", err)
+	}
+	hash := sha256.Sum256(payload)
+	if err := p.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(hash[:]), "secretsmanager", p.cfg.Region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign AWS request: %v", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("secrets manager returned status %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		SecretString string `json:"SecretString"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode secrets manager response: %v", err)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(result.SecretString), &data); err != nil {
+		return nil, errors.New("secret value must be a JSON object of name/value pairs")
+	}
+
+	return stringValues(data), nil
+}
@@ -0,0 +1,134 @@
+// Package secrets loads credentials (database password, JWT signing key, Razorpay and
+// Cloudinary keys, encryption keys) from a secrets backend instead of plaintext .env files.
+//
+// Secrets are fetched once at startup and cached in memory. Refresh re-fetches them so a
+// rotated secret takes effect without restarting the server; callers should therefore
+// read secrets with Get at the point of use rather than copying them into long-lived
+// fields. Names missing from the backend fall back to the environment, which keeps
+// local development on .env working unchanged.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+)
+
+// Provider fetches the full set of application secrets from a backend
+type Provider interface {
+	// Name identifies the backend in logs
+	Name() string
+
+	// Fetch returns every secret the backend holds for the application, keyed by name
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+// Store caches secrets fetched from a provider
+type Store struct {
+	provider Provider
+	mu       sync.RWMutex
+	values   map[string]string
+}
+
+// NewStore creates a store backed by provider. Call Refresh before reading secrets.
+func NewStore(provider Provider) *Store {
+	return &Store{provider: provider, values: make(map[string]string)}
+}
+
+// Refresh re-fetches all secrets. On failure the previously cached values stay in use.
+func (s *Store) Refresh(ctx context.Context) error {
+	if s.provider == nil {
+		return nil
+	}
+
+	values, err := s.provider.Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch secrets from %s: %v", s.provider.Name(), err)
+	}
+
+	s.mu.Lock()
+	changed := 0
+	for name, value := range values {
+		if s.values[name] != value {
+			changed++
+		}
+	}
+	s.values = values
+	s.mu.Unlock()
+
+	if changed > 0 {
+		log.Printf("Loaded %d updated secrets from %s", changed, s.provider.Name())
+	}
+	return nil
+}
+
+// Get returns the named secret, falling back to the environment variable of the same name
+func (s *Store) Get(name string) string {
+	s.mu.RLock()
+	value, ok := s.values[name]
+	s.mu.RUnlock()
+	if ok {
+		return value
+	}
+	return os.Getenv(name)
+}
+
+// defaultStore is the process-wide store used by the package-level helpers.
+// Until Init is called it has no provider and reads straight from the environment.
+var defaultStore = NewStore(nil)
+
+// Init selects the provider from SECRETS_PROVIDER (env, vault or aws) and loads the
+// initial secrets into the process-wide store. It should be called once at startup,
+// before anything reads a secret.
+func Init(ctx context.Context) error {
+	provider, err := providerFromEnv(ctx)
+	if err != nil {
+		return err
+	}
+
+	store := NewStore(provider)
+	if err := store.Refresh(ctx); err != nil {
+		return err
+	}
+
+	defaultStore = store
+	if provider != nil {
+		log.Printf("Secrets loaded from %s", provider.Name())
+	}
+	return nil
+}
+
+// Get returns a secret from the process-wide store
+func Get(name string) string {
+	return defaultStore.Get(name)
+}
+
+// GetOrDefault returns a secret from the process-wide store, or fallback when it is unset
+func GetOrDefault(name, fallback string) string {
+	if value := Get(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// Refresh re-fetches the process-wide secrets; it is run periodically by the scheduler
+func Refresh(ctx context.Context) error {
+	return defaultStore.Refresh(ctx)
+}
+
+// providerFromEnv builds the provider named by SECRETS_PROVIDER.
+// "env" (the default) returns nil so every secret is read from the environment.
+func providerFromEnv(ctx context.Context) (Provider, error) {
+	switch kind := os.Getenv("SECRETS_PROVIDER"); kind {
+	case "", "env":
+		return nil, nil
+	case "vault":
+		return NewVaultProvider(os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN"), os.Getenv("VAULT_SECRET_PATH"))
+	case "aws":
+		return NewAWSSecretsManagerProvider(ctx, os.Getenv("AWS_SECRETS_MANAGER_SECRET_ID"))
+	default:
+		return nil, fmt.Errorf("unknown SECRETS_PROVIDER %q (expected env, vault or aws)", kind)
+	}
+}
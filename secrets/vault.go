@@ -0,0 +1,89 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultProvider reads secrets from a HashiCorp Vault KV version 2 secret.
+// Every key of the secret (DB_PASSWORD, SECRET_KEY, ...) becomes one application secret.
+type VaultProvider struct {
+	addr   string
+	token  string
+	path   string // API path of the secret, e.g. secret/data/carzone
+	client *http.Client
+}
+
+// NewVaultProvider creates a Vault provider.
+// path may be given with or without the KV v2 "data/" segment ("secret/carzone" or "secret/data/carzone").
+func NewVaultProvider(addr, token, path string) (*VaultProvider, error) {
+	if addr == "" || token == "" || path == "" {
+		return nil, errors.New("VAULT_ADDR, VAULT_TOKEN and VAULT_SECRET_PATH are required for the vault secrets provider")
+	}
+
+	path = strings.Trim(path, "/")
+	if mount, rest, ok := strings.Cut(path, "/"); ok && !strings.HasPrefix(rest, "data/") {
+		path = mount + "/data/" + rest
+	}
+
+	return &VaultProvider{
+		addr:   strings.TrimRight(addr, "/"),
+		token:  token,
+		path:   path,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Name identifies the backend in logs
+func (p *VaultProvider) Name() string {
+	return "vault:" + p.path
+}
+
+// Fetch reads the latest version of the secret
+func (p *VaultProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.addr+"/v1/"+p.path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode vault response: %v", err)
+	}
+
+	return stringValues(body.Data.Data), nil
+}
+
+// stringValues converts a decoded JSON object into string secrets, skipping non-string values
+func stringValues(data map[string]interface{}) map[string]string {
+	values := make(map[string]string, len(data))
+	for name, value := range data {
+		switch v := value.(type) {
+		case string:
+			values[name] = v
+		case float64, bool:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,216 @@
+// Package server starts the CarZone HTTP listener. Depending on configuration it
+// serves plain HTTP, HTTPS with a certificate from disk, HTTPS with certificates
+// obtained automatically from Let's Encrypt, or plain HTTP behind a TLS-terminating
+// proxy. In the HTTPS modes an optional second listener redirects HTTP to HTTPS.
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// TLS modes selected with TLS_MODE
+const (
+	TLSModeOff      = "off"      // Plain HTTP (local development)
+	TLSModeFiles    = "files"    // HTTPS with TLS_CERT_FILE and TLS_KEY_FILE
+	TLSModeAutocert = "autocert" // HTTPS with Let's Encrypt certificates for TLS_DOMAINS
+	TLSModeProxy    = "proxy"    // Plain HTTP; TLS is terminated by a load balancer or reverse proxy
+)
+
+// Config controls how the server listens and which transport security headers it sends
+type Config struct {
+	Port                  string        // Main listener port
+	TLSMode               string        // One of the TLSMode constants
+	CertFile              string        // PEM certificate chain for TLSModeFiles
+	KeyFile               string        // PEM private key for TLSModeFiles
+	Domains               []string      // Host names autocert may request certificates for
+	CacheDir              string        // Where autocert stores issued certificates
+	ACMEEmail             string        // Contact address registered with Let's Encrypt
+	RedirectPort          string        // Port of the HTTP→HTTPS redirect listener; empty disables it
+	HSTSMaxAge            time.Duration // Strict-Transport-Security max-age; zero disables HSTS
+	HSTSIncludeSubdomains bool          // Add includeSubDomains to the HSTS header
+	HSTSPreload           bool          // Add preload to the HSTS header
+}
+
+// ConfigFromEnv reads the server configuration from environment variables
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Port:                  os.Getenv("PORT"),
+		TLSMode:               strings.ToLower(os.Getenv("TLS_MODE")),
+		CertFile:              os.Getenv("TLS_CERT_FILE"),
+		KeyFile:               os.Getenv("TLS_KEY_FILE"),
+		CacheDir:              os.Getenv("TLS_AUTOCERT_CACHE_DIR"),
+		ACMEEmail:             os.Getenv("TLS_ACME_EMAIL"),
+		RedirectPort:          os.Getenv("HTTP_REDIRECT_PORT"),
+		HSTSIncludeSubdomains: os.Getenv("HSTS_INCLUDE_SUBDOMAINS") == "true",
+		HSTSPreload:           os.Getenv("HSTS_PRELOAD") == "true",
+	}
+
+	for _, domain := range strings.Split(os.Getenv("TLS_DOMAINS"), ",") {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			cfg.Domains = append(cfg.Domains, domain)
+		}
+	}
+
+	if cfg.TLSMode == "" {
+		cfg.TLSMode = TLSModeOff
+	}
+
+	switch cfg.TLSMode {
+	case TLSModeOff, TLSModeProxy:
+		if cfg.Port == "" {
+			cfg.Port = "8080" // Default port if not set in environment variables
+		}
+	case TLSModeFiles:
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return cfg, errors.New("TLS_CERT_FILE and TLS_KEY_FILE are required when TLS_MODE=files")
+		}
+		if cfg.Port == "" {
+			cfg.Port = "443"
+		}
+	case TLSModeAutocert:
+		if len(cfg.Domains) == 0 {
+			return cfg, errors.New("TLS_DOMAINS is required when TLS_MODE=autocert")
+		}
+		if cfg.Port == "" {
+			cfg.Port = "443"
+		}
+		if cfg.CacheDir == "" {
+			cfg.CacheDir = "certs"
+		}
+		if cfg.RedirectPort == "" {
+			cfg.RedirectPort = "80" // Let's Encrypt HTTP-01 challenges always arrive on port 80
+		}
+	default:
+		return cfg, fmt.Errorf("unknown TLS_MODE %q (expected off, files, autocert or proxy)", cfg.TLSMode)
+	}
+
+	// HSTS defaults to one year whenever the site is served over HTTPS
+	if cfg.TLSMode != TLSModeOff {
+		cfg.HSTSMaxAge = 365 * 24 * time.Hour
+	}
+	if value := os.Getenv("HSTS_MAX_AGE"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return cfg, errors.New("HSTS_MAX_AGE must be a non-negative number of seconds")
+		}
+		cfg.HSTSMaxAge = time.Duration(seconds) * time.Second
+	}
+
+	return cfg, nil
+}
+
+// SecureCookies reports whether cookies should carry the Secure flag.
+// COOKIE_SECURE overrides the default, which is on whenever clients reach the site over HTTPS.
+func (c Config) SecureCookies() bool {
+	if value := os.Getenv("COOKIE_SECURE"); value != "" {
+		return value == "true"
+	}
+	return c.TLSMode != TLSModeOff
+}
+
+// HSTSHeader returns the Strict-Transport-Security header value, or "" when HSTS is disabled
+func (c Config) HSTSHeader() string {
+	if c.HSTSMaxAge <= 0 {
+		return ""
+	}
+	header := fmt.Sprintf("max-age=%d", int(c.HSTSMaxAge.Seconds()))
+	if c.HSTSIncludeSubdomains {
+		header += "; includeSubDomains"
+	}
+	if c.HSTSPreload {
+		header += "; preload"
+	}
+	return header
+}
+
+// ListenAndServe serves handler according to cfg. It blocks until the main listener stops.
+func ListenAndServe(cfg Config, handler http.Handler) error {
+	srv := &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	switch cfg.TLSMode {
+	case TLSModeFiles:
+		srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		startRedirectServer(cfg, http.HandlerFunc(redirectToHTTPS(cfg.Port)))
+		return srv.ListenAndServeTLS(cfg.CertFile, cfg.KeyFile)
+
+	case TLSModeAutocert:
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.Domains...),
+			Cache:      autocert.DirCache(cfg.CacheDir),
+			Email:      cfg.ACMEEmail,
+		}
+		srv.TLSConfig = manager.TLSConfig()
+		srv.TLSConfig.MinVersion = tls.VersionTLS12
+		// The redirect listener also answers ACME HTTP-01 challenges
+		startRedirectServer(cfg, manager.HTTPHandler(http.HandlerFunc(redirectToHTTPS(cfg.Port))))
+		return srv.ListenAndServeTLS("", "")
+
+	case TLSModeProxy:
+		srv.Handler = proxyRedirect(handler)
+		return srv.ListenAndServe()
+
+	default:
+		return srv.ListenAndServe()
+	}
+}
+
+// startRedirectServer runs the HTTP→HTTPS redirect listener in the background
+func startRedirectServer(cfg Config, handler http.Handler) {
+	if cfg.RedirectPort == "" {
+		return
+	}
+
+	redirect := &http.Server{
+		Addr:              ":" + cfg.RedirectPort,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		log.Printf("Redirecting HTTP on port %s to HTTPS", cfg.RedirectPort)
+		if err := redirect.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("HTTP redirect listener stopped: %v", err)
+		}
+	}()
+}
+
+// redirectToHTTPS permanently redirects a request to the same URL on the HTTPS port
+func redirectToHTTPS(httpsPort string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	}
+}
+
+// proxyRedirect redirects requests the proxy received over plain HTTP.
+// The proxy reports the original scheme in X-Forwarded-Proto.
+func proxyRedirect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Forwarded-Proto") == "http" {
+			http.Redirect(w, r, "https://"+r.Host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
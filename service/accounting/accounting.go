@@ -0,0 +1,213 @@
+// Package accounting exports the ledger, the invoices, receipts, credit notes and refunds of a
+// period, as files the finance team imports into Tally or QuickBooks instead of keying them in.
+// Every entry is a balanced journal of one debit and one credit.
+package accounting
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// AccountingService implements the AccountingServiceInterface
+type AccountingService struct {
+	accountingStore store.AccountingStoreInterface
+}
+
+// NewAccountingService creates a new accounting export service
+func NewAccountingService(accountingStore store.AccountingStoreInterface) *AccountingService {
+	return &AccountingService{
+		accountingStore: accountingStore,
+	}
+}
+
+// Export renders the ledger entries of a period in the requested format
+func (s *AccountingService) Export(ctx context.Context, req models.AccountingExportRequest) (*models.AccountingExportFile, error) {
+	tracer := otel.Tracer("AccountingService")
+	ctx, span := tracer.Start(ctx, "Export-Service")
+	defer span.End()
+
+	entries, err := s.accountingStore.GetLedgerEntries(ctx, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	switch req.Format {
+	case models.AccountingFormatTally:
+		body, err = encodeTally(entries)
+	case models.AccountingFormatIIF:
+		body = encodeIIF(entries)
+	default:
+		body, err = encodeQuickBooksCSV(entries)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AccountingExportFile{Name: req.FileName(), ContentType: req.Format.ContentType(), Body: body}, nil
+}
+
+// tallyVoucherTypes maps entry kinds to Tally's predefined voucher types
+var tallyVoucherTypes = map[models.AccountingEntryKind]string{
+	models.AccountingEntryInvoice:    "Sales",
+	models.AccountingEntryReceipt:    "Receipt",
+	models.AccountingEntryCreditNote: "Credit Note",
+	models.AccountingEntryRefund:     "Payment",
+}
+
+// tallyEnvelope is a Tally XML import request of vouchers
+type tallyEnvelope struct {
+	XMLName    xml.Name       `xml:"ENVELOPE"`
+	Request    string         `xml:"HEADER>TALLYREQUEST"`
+	ReportName string         `xml:"BODY>IMPORTDATA>REQUESTDESC>REPORTNAME"`
+	Messages   []tallyMessage `xml:"BODY>IMPORTDATA>REQUESTDATA>TALLYMESSAGE"`
+}
+
+type tallyMessage struct {
+	Voucher tallyVoucher `xml:"VOUCHER"`
+}
+
+type tallyVoucher struct {
+	VoucherType   string             `xml:"VCHTYPE,attr"`
+	Action        string             `xml:"ACTION,attr"`
+	Date          string             `xml:"DATE"`
+	TypeName      string             `xml:"VOUCHERTYPENAME"`
+	Number        string             `xml:"VOUCHERNUMBER"`
+	PartyLedger   string             `xml:"PARTYLEDGERNAME"`
+	Narration     string             `xml:"NARRATION"`
+	LedgerEntries []tallyLedgerEntry `xml:"ALLLEDGERENTRIES.LIST"`
+}
+
+// tallyLedgerEntry is one side of a voucher. Tally writes debits as negative amounts deemed
+// positive, and credits as positive amounts.
+type tallyLedgerEntry struct {
+	Ledger         string `xml:"LEDGERNAME"`
+	DeemedPositive string `xml:"ISDEEMEDPOSITIVE"`
+	Amount         string `xml:"AMOUNT"`
+}
+
+// encodeTally writes one voucher per entry. Customers' side is posted to their party ledger,
+// which must exist in Tally under Sundry Debtors.
+func encodeTally(entries []models.AccountingEntry) ([]byte, error) {
+	envelope := tallyEnvelope{Request: "Import Data", ReportName: "Vouchers", Messages: []tallyMessage{}}
+	for _, entry := range entries {
+		amount := strconv.FormatFloat(entry.Amount, 'f', 2, 64)
+		envelope.Messages = append(envelope.Messages, tallyMessage{Voucher: tallyVoucher{
+			VoucherType: tallyVoucherTypes[entry.Kind],
+			Action:      "Create",
+			Date:        entry.BookDate().Format("20060102"),
+			TypeName:    tallyVoucherTypes[entry.Kind],
+			Number:      entry.Number,
+			PartyLedger: entry.Customer,
+			Narration:   narration(entry),
+			LedgerEntries: []tallyLedgerEntry{
+				{Ledger: tallyLedger(entry.Kind.DebitLedger(), entry), DeemedPositive: "Yes", Amount: "-" + amount},
+				{Ledger: tallyLedger(entry.Kind.CreditLedger(), entry), DeemedPositive: "No", Amount: amount},
+			},
+		}})
+	}
+
+	body, err := xml.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// tallyLedger returns the Tally ledger of one side of an entry: the customer's party ledger for
+// receivables
+func tallyLedger(ledger string, entry models.AccountingEntry) string {
+	if ledger == models.AccountsReceivableLedger {
+		return entry.Customer
+	}
+	return ledger
+}
+
+// encodeIIF writes one general journal transaction per entry: the debit as the TRNS line and
+// the credit as its SPL line, with credits negative as IIF expects
+func encodeIIF(entries []models.AccountingEntry) []byte {
+	var buf bytes.Buffer
+	writeLine := func(fields ...string) {
+		buf.WriteString(strings.Join(fields, "\t"))
+		buf.WriteString("\r\n")
+	}
+
+	writeLine("!TRNS", "TRNSTYPE", "DATE", "ACCNT", "NAME", "AMOUNT", "DOCNUM", "MEMO")
+	writeLine("!SPL", "TRNSTYPE", "DATE", "ACCNT", "NAME", "AMOUNT", "DOCNUM", "MEMO")
+	writeLine("!ENDTRNS")
+	for _, entry := range entries {
+		date := entry.BookDate().Format("01/02/2006")
+		amount := strconv.FormatFloat(entry.Amount, 'f', 2, 64)
+		number := iifField(entry.Number)
+		memo := iifField(narration(entry))
+
+		debit, credit := entry.Kind.DebitLedger(), entry.Kind.CreditLedger()
+		writeLine("TRNS", "GENERAL JOURNAL", date, debit, iifField(customerName(debit, entry)), amount, number, memo)
+		writeLine("SPL", "GENERAL JOURNAL", date, credit, iifField(customerName(credit, entry)), "-"+amount, number, memo)
+		writeLine("ENDTRNS")
+	}
+
+	return buf.Bytes()
+}
+
+// iifField replaces the tabs and line breaks IIF cannot quote
+func iifField(s string) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(s)
+}
+
+// encodeQuickBooksCSV writes two rows per entry in the layout of QuickBooks Online's journal
+// entry import, which groups rows by journal number. Dates are DD/MM/YYYY, as set for Indian
+// companies.
+func encodeQuickBooksCSV(entries []models.AccountingEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{{"Journal No", "Journal Date", "Account", "Debits", "Credits", "Description", "Name"}}
+	for _, entry := range entries {
+		date := entry.BookDate().Format("02/01/2006")
+		amount := strconv.FormatFloat(entry.Amount, 'f', 2, 64)
+		debit, credit := entry.Kind.DebitLedger(), entry.Kind.CreditLedger()
+		rows = append(rows,
+			[]string{entry.Number, date, debit, amount, "", narration(entry), customerName(debit, entry)},
+			[]string{entry.Number, date, credit, "", amount, narration(entry), customerName(credit, entry)},
+		)
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// customerName returns the QuickBooks name of one side of an entry: the customer for
+// receivables, none for the other accounts
+func customerName(ledger string, entry models.AccountingEntry) string {
+	if ledger == models.AccountsReceivableLedger {
+		return entry.Customer
+	}
+	return ""
+}
+
+// narration describes an entry for the accountant, e.g. Invoice INV/2024-25/000042 for booking 1b2c...
+func narration(entry models.AccountingEntry) string {
+	var kind string
+	switch entry.Kind {
+	case models.AccountingEntryInvoice:
+		kind = "Invoice"
+	case models.AccountingEntryReceipt:
+		kind = "Receipt"
+	case models.AccountingEntryCreditNote:
+		kind = "Credit note"
+	default:
+		kind = "Refund"
+	}
+	return kind + " " + entry.Number + " for booking " + entry.BookingID.String()
+}
@@ -0,0 +1,222 @@
+package adjustment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// AdjustmentService implements the AdjustmentServiceInterface
+type AdjustmentService struct {
+	adjustmentStore   store.AdjustmentStoreInterface
+	bookingStore      store.BookingStoreInterface
+	paymentStore      store.PaymentStoreInterface
+	payments          service.PaymentServiceInterface
+	documents         service.SequenceServiceInterface
+	approvalThreshold float64 // Adjustments above this amount in INR need a second admin
+}
+
+// NewAdjustmentService creates a new adjustment service.
+// ADJUSTMENT_APPROVAL_THRESHOLD (default 5000) is the amount in INR above which a second
+// admin has to approve an adjustment before it is applied.
+func NewAdjustmentService(adjustmentStore store.AdjustmentStoreInterface, bookingStore store.BookingStoreInterface, paymentStore store.PaymentStoreInterface, payments service.PaymentServiceInterface, documents service.SequenceServiceInterface) *AdjustmentService {
+	threshold, err := strconv.ParseFloat(os.Getenv("ADJUSTMENT_APPROVAL_THRESHOLD"), 64)
+	if err != nil || threshold < 0 {
+		threshold = 5000
+	}
+	return &AdjustmentService{
+		adjustmentStore:   adjustmentStore,
+		bookingStore:      bookingStore,
+		paymentStore:      paymentStore,
+		payments:          payments,
+		documents:         documents,
+		approvalThreshold: threshold,
+	}
+}
+
+// CreateAdjustment raises a credit note or charge against a booking. Adjustments up to the
+// approval threshold are applied at once; larger ones wait for a second admin.
+func (s *AdjustmentService) CreateAdjustment(ctx context.Context, bookingID, userID string, req models.BookingAdjustmentRequest) (*models.BookingAdjustment, error) {
+	tracer := otel.Tracer("AdjustmentService")
+	ctx, span := tracer.Start(ctx, "CreateAdjustment-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		return nil, errors.New("invalid booking ID")
+	}
+	requestedBy, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	if err := models.ValidateBookingAdjustmentRequest(req); err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingStore.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status == models.BookingStatusCancelled {
+		return nil, errors.New("cancelled bookings cannot be adjusted")
+	}
+	if req.Kind == models.AdjustmentKindCreditNote && req.Amount > booking.TotalAmount {
+		return nil, errors.New("credit note amount cannot be greater than the booking total")
+	}
+
+	adjustment, err := s.adjustmentStore.CreateAdjustment(ctx, models.BookingAdjustment{
+		BookingID:   booking.ID,
+		Kind:        req.Kind,
+		Amount:      req.Amount,
+		ReasonCode:  req.ReasonCode,
+		Notes:       strings.TrimSpace(req.Notes),
+		RequestedBy: requestedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if adjustment.Amount > s.approvalThreshold {
+		return &adjustment, nil
+	}
+
+	// A failed apply leaves the adjustment pending, so a second admin can approve it instead
+	applied, err := s.apply(ctx, adjustment)
+	if err != nil {
+		log.Printf("Failed to apply adjustment %s, left for approval: %v", adjustment.ID, err)
+		return &adjustment, nil
+	}
+
+	return applied, nil
+}
+
+// ReviewAdjustment approves or rejects a pending adjustment. The reviewer must be a different
+// admin from the one who raised it.
+func (s *AdjustmentService) ReviewAdjustment(ctx context.Context, id, userID string, req models.AdjustmentReviewRequest) (*models.BookingAdjustment, error) {
+	tracer := otel.Tracer("AdjustmentService")
+	ctx, span := tracer.Start(ctx, "ReviewAdjustment-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid adjustment ID")
+	}
+	reviewedBy, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	if len(req.Note) > 1000 {
+		return nil, errors.New("note must be at most 1000 characters")
+	}
+
+	adjustment, err := s.adjustmentStore.GetAdjustment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if adjustment.RequestedBy == reviewedBy {
+		return nil, errors.New("adjustments must be approved by a different admin than the one who raised them")
+	}
+
+	claimed, err := s.adjustmentStore.ClaimAdjustmentReview(ctx, adjustment.ID, reviewedBy, strings.TrimSpace(req.Note))
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Approve {
+		rejected, err := s.adjustmentStore.CompleteAdjustment(ctx, claimed.ID, models.AdjustmentStatusRejected, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &rejected, nil
+	}
+
+	applied, err := s.apply(ctx, claimed)
+	if err != nil {
+		if releaseErr := s.adjustmentStore.ReleaseAdjustmentReview(ctx, claimed.ID); releaseErr != nil {
+			log.Printf("Failed to return adjustment %s to the approval queue: %v", claimed.ID, releaseErr)
+		}
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+// ListAdjustments retrieves adjustments for admins, oldest first
+func (s *AdjustmentService) ListAdjustments(ctx context.Context, filter models.AdjustmentFilter) ([]models.BookingAdjustment, error) {
+	tracer := otel.Tracer("AdjustmentService")
+	ctx, span := tracer.Start(ctx, "ListAdjustments-Service")
+	defer span.End()
+
+	switch filter.Status {
+	case "", models.AdjustmentStatusPendingApproval, models.AdjustmentStatusApplied, models.AdjustmentStatusRejected:
+	default:
+		return nil, errors.New("status must be one of: pending_approval, applied, rejected")
+	}
+
+	return s.adjustmentStore.ListAdjustments(ctx, filter)
+}
+
+// GetBookingAdjustments retrieves the adjustments of a booking for its customer, car owner or
+// an admin
+func (s *AdjustmentService) GetBookingAdjustments(ctx context.Context, bookingID, userID, role string) ([]models.BookingAdjustment, error) {
+	tracer := otel.Tracer("AdjustmentService")
+	ctx, span := tracer.Start(ctx, "GetBookingAdjustments-Service")
+	defer span.End()
+
+	booking, err := s.bookingStore.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if role != "admin" && booking.OwnerID.String() != userID && booking.CustomerID.String() != userID {
+		return nil, errors.New("no booking found with the given ID")
+	}
+
+	return s.adjustmentStore.ListAdjustmentsByBookingID(ctx, bookingID)
+}
+
+// apply issues the credit note or requests the payment of an adjustment and marks it applied
+func (s *AdjustmentService) apply(ctx context.Context, adjustment models.BookingAdjustment) (*models.BookingAdjustment, error) {
+	var paymentID *uuid.UUID
+	var documentNumber *string
+
+	switch adjustment.Kind {
+	case models.AdjustmentKindCreditNote:
+		creditNote, err := s.documents.IssueCreditNote(ctx, adjustment)
+		if err != nil {
+			return nil, err
+		}
+		documentNumber = &creditNote.Number
+	case models.AdjustmentKindCharge:
+		// Requested like return charges, so it is in the customer's payment history until paid
+		order, err := s.payments.CreatePayment(ctx, &models.PaymentRequest{
+			BookingID:   adjustment.BookingID,
+			Amount:      adjustment.Amount,
+			Method:      models.PaymentMethodRazorpay,
+			Description: fmt.Sprintf("Manual charge (%s) for booking %s", adjustment.ReasonCode, adjustment.BookingID),
+			Notes:       adjustment.Notes,
+		})
+		if err != nil {
+			return nil, err
+		}
+		payment, err := s.paymentStore.GetPaymentByRazorpayOrderID(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		paymentID = &payment.ID
+	}
+
+	applied, err := s.adjustmentStore.CompleteAdjustment(ctx, adjustment.ID, models.AdjustmentStatusApplied, paymentID, documentNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return &applied, nil
+}
@@ -0,0 +1,175 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// AlertService implements the AlertServiceInterface. It stores subscriptions and, as the car
+// event listener, compares car changes against them and notifies matching subscribers.
+type AlertService struct {
+	alertStore          store.AlertStoreInterface
+	carStore            store.CarStoreInterface
+	bookingStore        store.BookingStoreInterface
+	userStore           store.UserStoreInterface
+	notificationService service.NotificationServiceInterface
+}
+
+// NewAlertService creates a new alert service
+func NewAlertService(alertStore store.AlertStoreInterface, carStore store.CarStoreInterface, bookingStore store.BookingStoreInterface, userStore store.UserStoreInterface, notificationService service.NotificationServiceInterface) *AlertService {
+	return &AlertService{
+		alertStore:          alertStore,
+		carStore:            carStore,
+		bookingStore:        bookingStore,
+		userStore:           userStore,
+		notificationService: notificationService,
+	}
+}
+
+// Subscribe validates the criteria and subscribes the user to a car
+func (s *AlertService) Subscribe(ctx context.Context, userID, carID string, req models.CarAlertSubscriptionRequest) (*models.CarAlertSubscription, error) {
+	tracer := otel.Tracer("AlertService")
+	ctx, span := tracer.Start(ctx, "Subscribe-Service")
+	defer span.End()
+
+	if err := models.ValidateCarAlertSubscriptionRequest(req); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.alertStore.UpsertSubscription(ctx, userID, carID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// GetSubscriptions lists the user's subscriptions
+func (s *AlertService) GetSubscriptions(ctx context.Context, userID string) ([]models.CarAlertSubscription, error) {
+	tracer := otel.Tracer("AlertService")
+	ctx, span := tracer.Start(ctx, "GetSubscriptions-Service")
+	defer span.End()
+
+	return s.alertStore.GetSubscriptionsByUserID(ctx, userID)
+}
+
+// Unsubscribe removes one of the user's subscriptions
+func (s *AlertService) Unsubscribe(ctx context.Context, userID, id string) error {
+	tracer := otel.Tracer("AlertService")
+	ctx, span := tracer.Start(ctx, "Unsubscribe-Service")
+	defer span.End()
+
+	return s.alertStore.DeleteSubscription(ctx, id, userID)
+}
+
+// HandleCarEvent alerts subscribers whose criteria the change satisfies. A price drop is any
+// decrease that reaches the subscriber's target price, if one is set. Dates open up when the car
+// becomes available again or a booking is released, as long as the subscriber's dates are now free.
+func (s *AlertService) HandleCarEvent(ctx context.Context, event models.CarEvent) {
+	tracer := otel.Tracer("AlertService")
+	ctx, span := tracer.Start(ctx, "HandleCarEvent-Service")
+	defer span.End()
+
+	subscriptions, err := s.alertStore.GetSubscriptionsByCarID(ctx, event.CarID.String())
+	if err != nil {
+		log.Printf("Failed to load alert subscriptions for car %s: %v", event.CarID, err)
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	car := event.Current
+	if car == nil {
+		loaded, err := s.carStore.GetCarByID(ctx, event.CarID.String())
+		if err != nil {
+			log.Printf("Failed to load car %s for alerts: %v", event.CarID, err)
+			return
+		}
+		car = &loaded
+	}
+
+	priceDropped := event.Type == models.CarEventUpdated && event.Previous != nil && car.Price < event.Previous.Price
+	becameAvailable := event.Type == models.CarEventUpdated && event.Previous != nil && !event.Previous.Listed && car.Listed
+	datesReleased := event.Type == models.CarEventDatesReleased && car.Listed
+
+	var bookings []models.Booking
+	if becameAvailable || datesReleased {
+		bookings, err = s.bookingStore.GetBookingsByCarID(ctx, car.ID.String())
+		if err != nil {
+			log.Printf("Failed to load bookings of car %s for alerts: %v", car.ID, err)
+			return
+		}
+	}
+
+	now := time.Now()
+	for _, sub := range subscriptions {
+		switch {
+		case sub.PriceDrop && priceDropped && (sub.TargetPrice == nil || car.Price <= *sub.TargetPrice):
+			s.notify(ctx, sub, "Price drop on "+car.Name,
+				fmt.Sprintf("%s is now ₹%.2f per day, down from ₹%.2f.", car.Name, car.Price, event.Previous.Price))
+		case sub.Availability && (becameAvailable || datesReleased):
+			if sub.EndDate != nil && sub.EndDate.Before(now) {
+				continue // The dates the user wanted have passed
+			}
+			if datesReleased && !sub.WantsDates(event.ReleasedStart, event.ReleasedEnd) {
+				continue
+			}
+			if !datesFree(sub, bookings) {
+				continue
+			}
+			s.notify(ctx, sub, car.Name+" is available", availabilityMessage(*car, sub))
+		}
+	}
+}
+
+// notify alerts a subscriber and records when they were alerted
+func (s *AlertService) notify(ctx context.Context, sub models.CarAlertSubscription, subject, message string) {
+	user, err := s.userStore.GetUserByID(ctx, sub.UserID.String())
+	if err != nil {
+		log.Printf("Failed to load user %s for car alert: %v", sub.UserID, err)
+		return
+	}
+
+	message += fmt.Sprintf("\n\nBook it at /cars/%s before someone else does. You can stop these alerts by deleting subscription %s.", sub.CarID, sub.ID)
+	if err := s.notificationService.Notify(ctx, user, subject, message); err != nil {
+		log.Printf("Failed to send car alert for subscription %s: %v", sub.ID, err)
+		return
+	}
+
+	if err := s.alertStore.MarkNotified(ctx, sub.ID.String(), time.Now()); err != nil {
+		log.Printf("Failed to record car alert for subscription %s: %v", sub.ID, err)
+	}
+}
+
+// datesFree reports whether no active booking blocks the subscriber's dates
+func datesFree(sub models.CarAlertSubscription, bookings []models.Booking) bool {
+	if sub.StartDate == nil || sub.EndDate == nil {
+		return true
+	}
+	for _, booking := range bookings {
+		switch booking.Status {
+		case models.BookingStatusPending, models.BookingStatusConfirmed, models.BookingStatusUnderReview, models.BookingStatusInProgress:
+			if booking.StartDate.Before(*sub.EndDate) && booking.EndDate.After(*sub.StartDate) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// availabilityMessage describes which dates opened up
+func availabilityMessage(car models.Car, sub models.CarAlertSubscription) string {
+	if sub.StartDate == nil || sub.EndDate == nil {
+		return fmt.Sprintf("Dates have opened up on %s.", car.Name)
+	}
+	return fmt.Sprintf("%s is free from %s to %s, the dates you asked about.", car.Name,
+		sub.StartDate.Format("2 Jan 2006"), sub.EndDate.Format("2 Jan 2006"))
+}
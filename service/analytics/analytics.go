@@ -0,0 +1,134 @@
+package analytics
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// AnalyticsService implements the AnalyticsServiceInterface for the admin dashboards
+type AnalyticsService struct {
+	analyticsStore store.AnalyticsStoreInterface
+	rebuildEvery   time.Duration // How often the read model is rebuilt in full
+}
+
+// NewAnalyticsService creates a new analytics service.
+// DASHBOARD_REBUILD_INTERVAL (default 24h) is how often the dashboard read model is rebuilt
+// in full rather than only for the days that changed.
+func NewAnalyticsService(analyticsStore store.AnalyticsStoreInterface) *AnalyticsService {
+	rebuildEvery, err := time.ParseDuration(os.Getenv("DASHBOARD_REBUILD_INTERVAL"))
+	if err != nil || rebuildEvery <= 0 {
+		rebuildEvery = 24 * time.Hour
+	}
+	return &AnalyticsService{analyticsStore: analyticsStore, rebuildEvery: rebuildEvery}
+}
+
+// GetTimeSeries returns the buckets of a metric and their total. Daily and longer buckets are
+// read from the dashboard read model once it has been built; hourly ones from the live tables.
+func (s *AnalyticsService) GetTimeSeries(ctx context.Context, query models.TimeSeriesQuery) (*models.TimeSeries, error) {
+	tracer := otel.Tracer("AnalyticsService")
+	ctx, span := tracer.Start(ctx, "GetTimeSeries-Service")
+	defer span.End()
+
+	getPoints := s.analyticsStore.GetTimeSeries
+	if query.Interval != models.TimeSeriesHour {
+		asOf, err := s.analyticsStore.GetDashboardRefreshedAt(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if asOf != nil {
+			getPoints = s.analyticsStore.GetProjectedTimeSeries
+		}
+	}
+
+	points, err := getPoints(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	series := models.TimeSeries{
+		Metric:   query.Metric,
+		Interval: query.Interval,
+		From:     query.From,
+		To:       query.To,
+		Points:   make([]models.TimeSeriesPoint, 0, len(points)),
+	}
+	for _, point := range points {
+		series.Total += point.Value
+		series.Points = append(series.Points, point)
+	}
+
+	return &series, nil
+}
+
+// GetOwnerEarnings returns the owners with the most completed payments over a range
+func (s *AnalyticsService) GetOwnerEarnings(ctx context.Context, r models.DashboardRange, limit int) (*models.Dashboard[[]models.OwnerEarnings], error) {
+	tracer := otel.Tracer("AnalyticsService")
+	ctx, span := tracer.Start(ctx, "GetOwnerEarnings-Service")
+	defer span.End()
+
+	asOf, err := s.analyticsStore.GetDashboardRefreshedAt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	earnings, err := s.analyticsStore.GetOwnerEarnings(ctx, r, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Dashboard[[]models.OwnerEarnings]{Range: r, AsOf: asOf, Data: earnings}, nil
+}
+
+// GetCarUtilization returns the cars held longest by bookings over a range
+func (s *AnalyticsService) GetCarUtilization(ctx context.Context, r models.DashboardRange, limit int) (*models.Dashboard[[]models.CarUtilization], error) {
+	tracer := otel.Tracer("AnalyticsService")
+	ctx, span := tracer.Start(ctx, "GetCarUtilization-Service")
+	defer span.End()
+
+	asOf, err := s.analyticsStore.GetDashboardRefreshedAt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	utilization, err := s.analyticsStore.GetCarUtilization(ctx, r, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Dashboard[[]models.CarUtilization]{Range: r, AsOf: asOf, Data: utilization}, nil
+}
+
+// GetBookingFunnel returns how the bookings created over a range progressed
+func (s *AnalyticsService) GetBookingFunnel(ctx context.Context, r models.DashboardRange) (*models.Dashboard[models.BookingFunnel], error) {
+	tracer := otel.Tracer("AnalyticsService")
+	ctx, span := tracer.Start(ctx, "GetBookingFunnel-Service")
+	defer span.End()
+
+	asOf, err := s.analyticsStore.GetDashboardRefreshedAt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	funnel, err := s.analyticsStore.GetBookingFunnel(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Dashboard[models.BookingFunnel]{Range: r, AsOf: asOf, Data: funnel}, nil
+}
+
+// RefreshDashboards recomputes the changed days of the dashboard read model, rebuilding it in
+// full when due
+func (s *AnalyticsService) RefreshDashboards(ctx context.Context) error {
+	refresh, err := s.analyticsStore.RefreshDashboards(ctx, s.rebuildEvery)
+	if err != nil {
+		return err
+	}
+	if refresh.Full {
+		log.Printf("Rebuilt dashboard read model: %d days", refresh.Days)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package attribute
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// schemaCacheTTL bounds how long an operator's definitions are reused. Every car save
+// validates against them, so they are cached rather than read each time; changes made on
+// another instance show up within the TTL.
+const schemaCacheTTL = time.Minute
+
+// cachedSchema is the schema of one operator and when it must be read again
+type cachedSchema struct {
+	schema  models.CarAttributeSchema
+	expires time.Time
+}
+
+// CarAttributeService implements the CarAttributeServiceInterface
+type CarAttributeService struct {
+	attributeStore store.CarAttributeStoreInterface
+
+	mu      sync.Mutex
+	schemas map[uuid.UUID]cachedSchema
+}
+
+// NewCarAttributeService creates a new car attribute service
+func NewCarAttributeService(attributeStore store.CarAttributeStoreInterface) *CarAttributeService {
+	return &CarAttributeService{
+		attributeStore: attributeStore,
+		schemas:        map[uuid.UUID]cachedSchema{},
+	}
+}
+
+// ListCarAttributes retrieves the operator's attribute definitions
+func (s *CarAttributeService) ListCarAttributes(ctx context.Context) ([]models.CarAttribute, error) {
+	tracer := otel.Tracer("CarAttributeService")
+	ctx, span := tracer.Start(ctx, "ListCarAttributes-Service")
+	defer span.End()
+
+	return s.attributeStore.ListCarAttributes(ctx)
+}
+
+// CreateCarAttribute validates and defines an attribute for the operator
+func (s *CarAttributeService) CreateCarAttribute(ctx context.Context, req models.CarAttributeRequest) (*models.CarAttribute, error) {
+	tracer := otel.Tracer("CarAttributeService")
+	ctx, span := tracer.Start(ctx, "CreateCarAttribute-Service")
+	defer span.End()
+
+	if err := models.ValidateCarAttributeRequest(&req); err != nil {
+		return nil, err
+	}
+
+	attribute, err := s.attributeStore.CreateCarAttribute(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.clearSchemaCache(ctx)
+
+	return &attribute, nil
+}
+
+// UpdateCarAttribute validates and replaces the definition of an attribute. Its type cannot
+// change, as the values cars hold would no longer match it.
+func (s *CarAttributeService) UpdateCarAttribute(ctx context.Context, key string, req models.CarAttributeRequest) (*models.CarAttribute, error) {
+	tracer := otel.Tracer("CarAttributeService")
+	ctx, span := tracer.Start(ctx, "UpdateCarAttribute-Service")
+	defer span.End()
+
+	req.Key = key
+	if err := models.ValidateCarAttributeRequest(&req); err != nil {
+		return nil, err
+	}
+
+	schema, err := s.loadSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, ok := schema[req.Key]
+	if !ok {
+		return nil, errors.New("no attribute found with the given key")
+	}
+	if current.Type != req.Type {
+		return nil, errors.New("type of an attribute cannot be changed, delete it and define it again")
+	}
+
+	attribute, err := s.attributeStore.UpdateCarAttribute(ctx, req.Key, req)
+	if err != nil {
+		return nil, err
+	}
+	s.clearSchemaCache(ctx)
+
+	return &attribute, nil
+}
+
+// DeleteCarAttribute removes an attribute and clears its values from the operator's cars
+func (s *CarAttributeService) DeleteCarAttribute(ctx context.Context, key string) error {
+	tracer := otel.Tracer("CarAttributeService")
+	ctx, span := tracer.Start(ctx, "DeleteCarAttribute-Service")
+	defer span.End()
+
+	if err := s.attributeStore.DeleteCarAttribute(ctx, models.FeatureKey(key)); err != nil {
+		return err
+	}
+	s.clearSchemaCache(ctx)
+
+	return nil
+}
+
+// Schema retrieves the operator's definitions indexed by key, cached for schemaCacheTTL
+func (s *CarAttributeService) Schema(ctx context.Context) (models.CarAttributeSchema, error) {
+	tracer := otel.Tracer("CarAttributeService")
+	ctx, span := tracer.Start(ctx, "Schema-Service")
+	defer span.End()
+
+	operatorID := tenant.ForInsert(ctx)
+
+	s.mu.Lock()
+	if cached, ok := s.schemas[operatorID]; ok && time.Now().Before(cached.expires) {
+		s.mu.Unlock()
+		return cached.schema, nil
+	}
+	s.mu.Unlock()
+
+	schema, err := s.loadSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.schemas[operatorID] = cachedSchema{schema: schema, expires: time.Now().Add(schemaCacheTTL)}
+	s.mu.Unlock()
+
+	return schema, nil
+}
+
+// loadSchema reads the operator's definitions, bypassing the cache
+func (s *CarAttributeService) loadSchema(ctx context.Context) (models.CarAttributeSchema, error) {
+	attributes, err := s.attributeStore.ListCarAttributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewCarAttributeSchema(attributes), nil
+}
+
+// clearSchemaCache drops the operator's cached schema so changes apply to the next car save
+func (s *CarAttributeService) clearSchemaCache(ctx context.Context) {
+	s.mu.Lock()
+	delete(s.schemas, tenant.ForInsert(ctx))
+	s.mu.Unlock()
+}
@@ -1,23 +1,31 @@
 package auth
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/mail"
+	"strings"
 
 	"context"
+	"time"
 
+	"github.com/PrateekKumar15/CarZone/middleware"
 	"github.com/PrateekKumar15/CarZone/models"
 	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // Assuming models.UserRequest is defined in your models package
 type AuthService struct {
-	store store.UserStoreInterface
+	store    store.UserStoreInterface
+	throttle *loginThrottle    // Locks out e-mail addresses and IPs after repeated failed logins
+	emails   *emailDomainCheck // Refuses sign-ups from disposable domains and domains without mail hosts
 }
 
 func NewAuthService(store store.UserStoreInterface) *AuthService {
-	return &AuthService{store: store}
+	return &AuthService{store: store, throttle: newLoginThrottleFromEnv(), emails: newEmailDomainCheckFromEnv()}
 }
 
 func (s *AuthService) RegisterUser(ctx context.Context, userReq models.UserRequest) error {
@@ -25,10 +33,15 @@ func (s *AuthService) RegisterUser(ctx context.Context, userReq models.UserReque
 	if err := models.ValidateUserRequest(userReq); err != nil {
 		return err
 	}
-	// Validate email format
-	if _, err := mail.ParseAddress(userReq.Email); err != nil {
+	// Validate email format; a bare address only, not "Name <address>"
+	addr, err := mail.ParseAddress(userReq.Email)
+	if err != nil || addr.Address != strings.TrimSpace(userReq.Email) {
 		return errors.New("invalid email format")
 	}
+	// Refuse addresses that are well formed but will not reach the user
+	if err := s.emails.check(ctx, addr.Address); err != nil {
+		return err
+	}
 	// Create the user in the store
 	if err := s.store.CreateUser(ctx,userReq); err != nil {
 		return err
@@ -44,13 +57,46 @@ func (s *AuthService) LoginUser(ctx context.Context, loginReq models.LoginReques
 	if err := models.ValidateLoginRequest(loginReq); err != nil {
 		return user,  err
 	}
+	// Refuse without checking the password while the address or client IP is locked out
+	ip := middleware.ClientIPFromContext(ctx)
+	if wait := s.throttle.lockedFor(loginReq.Email, ip, time.Now()); wait > 0 {
+		return user, &models.LoginThrottledError{RetryAfter: wait}
+	}
 	// Authenticate the user in the store
 	user, err := s.store.GetUser(ctx, loginReq.Email, loginReq.Password)
 	if err != nil {
+		// Only wrong addresses and passwords count; a database outage must not lock users out
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			if wait := s.throttle.fail(loginReq.Email, ip, time.Now()); wait > 0 {
+				return user, &models.LoginThrottledError{RetryAfter: wait}
+			}
+		}
 		return user, err
 	}
 	return user, nil
 }
+
+// RecordFailedLogin counts a login that failed after the password was accepted, such as a wrong
+// two-factor code, towards the lockout of the address and client IP
+func (s *AuthService) RecordFailedLogin(ctx context.Context, email string) error {
+	if wait := s.throttle.fail(email, middleware.ClientIPFromContext(ctx), time.Now()); wait > 0 {
+		return &models.LoginThrottledError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// ClearFailedLogins forgets an address's failed logins once a login completed. LoginUser does
+// not do this itself, so a correct password alone does not reset the count of wrong second factors.
+func (s *AuthService) ClearFailedLogins(ctx context.Context, email string) {
+	s.throttle.succeed(email)
+}
 // UserStoreInterface defines the contract for user data persistence operations.
 // This interface abstracts the underlying data store (e.g., SQL, NoSQL) and provides
 
+// SetPassword replaces a user's password, checked against the rules applied at registration
+func (s *AuthService) SetPassword(ctx context.Context, email, password string) (uuid.UUID, error) {
+	if err := models.ValidateLoginRequest(models.LoginRequest{Email: email, Password: password}); err != nil {
+		return uuid.Nil, err
+	}
+	return s.store.SetPassword(ctx, email, password)
+}
@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+)
+
+// defaultDisposableDomains are well-known throwaway inbox providers, refused even without
+// configuration
+var defaultDisposableDomains = []string{
+	"10minutemail.com",
+	"dispostable.com",
+	"emailondeck.com",
+	"fakeinbox.com",
+	"getnada.com",
+	"guerrillamail.com",
+	"mailinator.com",
+	"maildrop.cc",
+	"mintemail.com",
+	"sharklasers.com",
+	"temp-mail.org",
+	"tempmail.com",
+	"throwawaymail.com",
+	"trashmail.com",
+	"yopmail.com",
+}
+
+// emailDomainCheck refuses sign-ups from disposable e-mail domains and, when enabled, from
+// domains without a mail host. A domain matches the blocklist when it is listed or is a
+// subdomain of a listed one.
+type emailDomainCheck struct {
+	disposable map[string]bool
+	checkMX    bool
+	timeout    time.Duration // How long an MX lookup may take before the address is let through
+	resolver   *net.Resolver
+}
+
+// newEmailDomainCheckFromEnv creates a check whose blocklist is the built-in one plus the
+// domains in EMAIL_DISPOSABLE_DOMAINS (comma-separated) and EMAIL_DISPOSABLE_DOMAINS_FILE (one
+// per line, # for comments). EMAIL_MX_CHECK=true also looks up each domain's mail host, waiting
+// at most EMAIL_MX_TIMEOUT (default 3s).
+func newEmailDomainCheckFromEnv() *emailDomainCheck {
+	disposable := make(map[string]bool)
+	for _, domain := range defaultDisposableDomains {
+		disposable[domain] = true
+	}
+	for _, domain := range strings.Split(os.Getenv("EMAIL_DISPOSABLE_DOMAINS"), ",") {
+		if domain = normalizeDomain(domain); domain != "" {
+			disposable[domain] = true
+		}
+	}
+	if path := os.Getenv("EMAIL_DISPOSABLE_DOMAINS_FILE"); path != "" {
+		if err := readDomainFile(path, disposable); err != nil {
+			log.Printf("Could not read EMAIL_DISPOSABLE_DOMAINS_FILE %s: %v", path, err)
+		}
+	}
+
+	checkMX, _ := strconv.ParseBool(os.Getenv("EMAIL_MX_CHECK"))
+	timeout, err := time.ParseDuration(os.Getenv("EMAIL_MX_TIMEOUT"))
+	if err != nil || timeout <= 0 {
+		timeout = 3 * time.Second
+	}
+
+	return &emailDomainCheck{
+		disposable: disposable,
+		checkMX:    checkMX,
+		timeout:    timeout,
+		resolver:   net.DefaultResolver,
+	}
+}
+
+// readDomainFile adds the domains listed in a file to set
+func readDomainFile(path string, set map[string]bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if domain := normalizeDomain(line); domain != "" {
+			set[domain] = true
+		}
+	}
+	return scanner.Err()
+}
+
+// normalizeDomain lowercases a domain and drops surrounding spaces and dots
+func normalizeDomain(domain string) string {
+	return strings.Trim(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// check returns an EmailRejectedError when an address's domain is disposable or cannot
+// receive mail. email is a bare address, as mail.ParseAddress returns it. DNS failures other than a missing domain let the address through, so a
+// resolver outage does not stop sign-ups.
+func (c *emailDomainCheck) check(ctx context.Context, email string) error {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return nil
+	}
+	domain := normalizeDomain(email[at+1:])
+
+	for d := domain; d != ""; {
+		if c.disposable[d] {
+			return &models.EmailRejectedError{Code: models.EmailDisposable, Domain: domain,
+				Message: "disposable e-mail addresses are not accepted, please use a permanent address"}
+		}
+		_, parent, ok := strings.Cut(d, ".")
+		if !ok {
+			break
+		}
+		d = parent
+	}
+
+	if !c.checkMX {
+		return nil
+	}
+	ok, err := c.acceptsMail(ctx, domain)
+	if err != nil {
+		log.Printf("Could not look up mail host of %s, accepting it: %v", domain, err)
+		return nil
+	}
+	if !ok {
+		return &models.EmailRejectedError{Code: models.EmailNoMailHost, Domain: domain,
+			Message: "the e-mail domain " + domain + " does not receive mail, please check the address"}
+	}
+	return nil
+}
+
+// acceptsMail reports whether a domain has a mail host: an MX record other than the null MX
+// (RFC 7505), or failing any MX records an address record, which senders fall back to
+// (RFC 5321). The error is set when DNS could not answer.
+func (c *emailDomainCheck) acceptsMail(ctx context.Context, domain string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	records, err := c.resolver.LookupMX(ctx, domain)
+	if err == nil {
+		for _, mx := range records {
+			if mx.Host != "." && mx.Host != "" {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	if !isNotFound(err) {
+		return false, err
+	}
+
+	addrs, err := c.resolver.LookupHost(ctx, domain)
+	if err == nil {
+		return len(addrs) > 0, nil
+	}
+	if isNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// isNotFound reports whether DNS answered that a domain or record does not exist
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// loginThrottle counts failed logins per e-mail address and per client IP. After too many
+// failures within the window the address, or the IP, is locked out for a while, and each
+// lockout in a row lasts twice as long as the one before, up to maxLoginLockout. Counting per
+// address stops guessing one account's password; counting per IP stops one client spraying
+// a common password across many accounts.
+//
+// Counters live in memory, so each instance counts separately, like RateLimiter.
+type loginThrottle struct {
+	maxFailures   int           // Failures per e-mail address before it is locked out
+	maxIPFailures int           // Failures per client IP before it is locked out
+	window        time.Duration // How long a failure counts
+	lockout       time.Duration // Length of the first lockout
+
+	mu        sync.Mutex
+	entries   map[string]*loginFailures
+	lastSweep time.Time
+}
+
+// loginFailures is the failure count of one e-mail address or IP
+type loginFailures struct {
+	count       int
+	firstAt     time.Time // Start of the counting window
+	lockouts    int       // Lockouts in a row; reset when a login succeeds or the record expires
+	lockedUntil time.Time
+}
+
+// maxLoginLockout caps how long repeated lockouts grow
+const maxLoginLockout = 24 * time.Hour
+
+// newLoginThrottleFromEnv creates a throttle configured by LOGIN_MAX_FAILURES (default 5),
+// LOGIN_IP_MAX_FAILURES (default 20), LOGIN_FAILURE_WINDOW (default 15m) and LOGIN_LOCKOUT
+// (default 15m)
+func newLoginThrottleFromEnv() *loginThrottle {
+	maxFailures, err := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILURES"))
+	if err != nil || maxFailures <= 0 {
+		maxFailures = 5
+	}
+	maxIPFailures, err := strconv.Atoi(os.Getenv("LOGIN_IP_MAX_FAILURES"))
+	if err != nil || maxIPFailures <= 0 {
+		maxIPFailures = 20
+	}
+	window, err := time.ParseDuration(os.Getenv("LOGIN_FAILURE_WINDOW"))
+	if err != nil || window <= 0 {
+		window = 15 * time.Minute
+	}
+	lockout, err := time.ParseDuration(os.Getenv("LOGIN_LOCKOUT"))
+	if err != nil || lockout <= 0 {
+		lockout = 15 * time.Minute
+	}
+
+	return &loginThrottle{
+		maxFailures:   maxFailures,
+		maxIPFailures: maxIPFailures,
+		window:        window,
+		lockout:       lockout,
+		entries:       make(map[string]*loginFailures),
+	}
+}
+
+// keys returns the counters a login counts against; the IP is empty outside HTTP requests
+func (t *loginThrottle) keys(email, ip string) []string {
+	keys := []string{"email:" + strings.ToLower(strings.TrimSpace(email))}
+	if ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+// lockedFor returns how long login stays refused for an e-mail address or IP, 0 when it is not
+func (t *loginThrottle) lockedFor(email, ip string, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var wait time.Duration
+	for _, key := range t.keys(email, ip) {
+		if entry, ok := t.entries[key]; ok && entry.lockedUntil.After(now) {
+			wait = max(wait, entry.lockedUntil.Sub(now))
+		}
+	}
+	return wait
+}
+
+// fail counts a failed login and returns how long login is now refused, 0 when the failure did
+// not cause a lockout
+func (t *loginThrottle) fail(email, ip string, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.sweep(now)
+
+	var wait time.Duration
+	for i, key := range t.keys(email, ip) {
+		limit := t.maxFailures
+		if i > 0 {
+			limit = t.maxIPFailures
+		}
+
+		entry, ok := t.entries[key]
+		if !ok {
+			entry = &loginFailures{firstAt: now}
+			t.entries[key] = entry
+		}
+		if now.Sub(entry.firstAt) >= t.window {
+			entry.count, entry.firstAt = 0, now
+		}
+		entry.count++
+
+		if entry.count >= limit {
+			lockout := t.lockout
+			for n := 0; n < entry.lockouts && lockout < maxLoginLockout; n++ {
+				lockout *= 2
+			}
+			lockout = min(lockout, maxLoginLockout)
+			entry.lockouts++
+			entry.count, entry.firstAt = 0, now
+			entry.lockedUntil = now.Add(lockout)
+			wait = max(wait, lockout)
+		}
+	}
+	return wait
+}
+
+// succeed forgets the failures of an e-mail address after a complete login. The IP keeps its
+// count, so logging in to one's own account does not clear guesses at others.
+func (t *loginThrottle) succeed(email string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.entries, t.keys(email, "")[0])
+}
+
+// sweep drops records that are neither locked nor counting once per window, so addresses that
+// stopped failing do not accumulate. Called with mu held.
+func (t *loginThrottle) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < t.window {
+		return
+	}
+	for key, entry := range t.entries {
+		if !entry.lockedUntil.After(now) && now.Sub(entry.firstAt) >= t.window && now.Sub(entry.lockedUntil) >= maxLoginLockout {
+			delete(t.entries, key)
+		}
+	}
+	t.lastSweep = now
+}
@@ -0,0 +1,77 @@
+package block
+
+import (
+	"context"
+	"errors"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// BlockService implements the BlockServiceInterface
+type BlockService struct {
+	blockStore store.BlockStoreInterface
+	userStore  store.UserStoreInterface
+}
+
+// NewBlockService creates a new block service
+func NewBlockService(blockStore store.BlockStoreInterface, userStore store.UserStoreInterface) *BlockService {
+	return &BlockService{
+		blockStore: blockStore,
+		userStore:  userStore,
+	}
+}
+
+// BlockUser adds a user to the caller's block list. Admins cannot be blocked, as they must be
+// able to act on any booking.
+func (s *BlockService) BlockUser(ctx context.Context, userID string, req models.BlockRequest) (*models.UserBlock, error) {
+	tracer := otel.Tracer("BlockService")
+	ctx, span := tracer.Start(ctx, "BlockUser-Service")
+	defer span.End()
+
+	if req.UserID == uuid.Nil {
+		return nil, errors.New("user_id is required")
+	}
+	if req.UserID.String() == userID {
+		return nil, errors.New("you cannot block yourself")
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, req.UserID.String())
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == "admin" {
+		return nil, errors.New("admins cannot be blocked")
+	}
+
+	block, err := s.blockStore.CreateBlock(ctx, userID, req.UserID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// UnblockUser removes a user from the caller's block list
+func (s *BlockService) UnblockUser(ctx context.Context, userID, blockedID string) error {
+	tracer := otel.Tracer("BlockService")
+	ctx, span := tracer.Start(ctx, "UnblockUser-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(blockedID); err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	return s.blockStore.DeleteBlock(ctx, userID, blockedID)
+}
+
+// GetBlocks retrieves the caller's block list
+func (s *BlockService) GetBlocks(ctx context.Context, userID string) ([]models.UserBlock, error) {
+	tracer := otel.Tracer("BlockService")
+	ctx, span := tracer.Start(ctx, "GetBlocks-Service")
+	defer span.End()
+
+	return s.blockStore.ListBlocks(ctx, userID)
+}
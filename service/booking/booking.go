@@ -3,24 +3,125 @@ package booking
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/statemachine"
 	"github.com/PrateekKumar15/CarZone/store"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 )
 
 type BookingService struct {
-	bookingStore store.BookingStoreInterface
-	carStore     store.CarStoreInterface
+	bookingStore    store.BookingStoreInterface
+	carStore        store.CarStoreInterface
+	securityMonitor service.SecurityMonitorInterface
+	riskScorer      service.RiskScorerInterface
+	telemetry       service.TelemetryServiceInterface
+	locationStore   store.LocationStoreInterface
+	geocoder        service.GeocoderInterface
+	carEvents       service.CarEventListenerInterface
+	notifier        service.TemplatedNotifierInterface
+	vacationStore   store.VacationStoreInterface
+	fleetStore      store.FleetStoreInterface
+	payments        service.PaymentServiceInterface
+	documents       service.SequenceServiceInterface
+	kycStore        store.KYCStoreInterface
+	blockStore      store.BlockStoreInterface
+	digitalKeys     service.DigitalKeyListenerInterface
+	checklistStore  store.ChecklistStoreInterface
+	lateFees        models.LateFeePolicy
+	fuel            models.FuelPricing
+	leadTime        time.Duration // Minimum notice before a rental starts; 0 accepts same-day starts
+	quoteTTL        time.Duration // How long a quote's price is guaranteed
+	paymentTTL      time.Duration // How long a pending booking may stay unpaid before it is cancelled
+	expiryWarning   time.Duration // How long before that the customer is reminded to pay; 0 sends no reminder
+	handBackLead    time.Duration // How long before a trip is due back the customer is reminded
+	statuses        *statemachine.Machine[models.BookingStatus, models.Booking]
 }
 
-func NewBookingService(bookingStore store.BookingStoreInterface, carStore store.CarStoreInterface) *BookingService {
-	return &BookingService{
-		bookingStore: bookingStore,
-		carStore:     carStore,
+// NewBookingService creates a new booking service.
+// LATE_RETURN_GRACE_PERIOD (default 1h) and LATE_FEE_MULTIPLIER (default 1.5) configure late return fees;
+// REFUEL_PRICE_PER_LITER (default 105) prices prepaid tanks and missing fuel;
+// BOOKING_LEAD_TIME (default 0, none) is the minimum notice before a rental starts and
+// QUOTE_TTL (default 15m) how long quoted prices are guaranteed.
+// BOOKING_PAYMENT_TTL (default 24h) is how long a pending booking may stay unpaid, and
+// BOOKING_EXPIRY_WARNING (default a quarter of it) how long before expiry the customer is reminded.
+// HANDBACK_REMINDER_LEAD (default 24h) is how long before a trip is due back its customer is reminded.
+func NewBookingService(bookingStore store.BookingStoreInterface, carStore store.CarStoreInterface, securityMonitor service.SecurityMonitorInterface, riskScorer service.RiskScorerInterface, telemetry service.TelemetryServiceInterface, locationStore store.LocationStoreInterface, geocoder service.GeocoderInterface, carEvents service.CarEventListenerInterface, notifier service.TemplatedNotifierInterface, vacationStore store.VacationStoreInterface, fleetStore store.FleetStoreInterface, payments service.PaymentServiceInterface, documents service.SequenceServiceInterface, kycStore store.KYCStoreInterface, blockStore store.BlockStoreInterface, digitalKeys service.DigitalKeyListenerInterface, checklistStore store.ChecklistStoreInterface) *BookingService {
+	gracePeriod, err := time.ParseDuration(os.Getenv("LATE_RETURN_GRACE_PERIOD"))
+	if err != nil || gracePeriod < 0 {
+		gracePeriod = time.Hour
 	}
+	multiplier, err := strconv.ParseFloat(os.Getenv("LATE_FEE_MULTIPLIER"), 64)
+	if err != nil || multiplier <= 0 {
+		multiplier = 1.5
+	}
+	pricePerLiter, err := strconv.ParseFloat(os.Getenv("REFUEL_PRICE_PER_LITER"), 64)
+	if err != nil || pricePerLiter <= 0 {
+		pricePerLiter = 105
+	}
+	leadTime, err := time.ParseDuration(os.Getenv("BOOKING_LEAD_TIME"))
+	if err != nil || leadTime < 0 {
+		leadTime = 0
+	}
+	quoteTTL, err := time.ParseDuration(os.Getenv("QUOTE_TTL"))
+	if err != nil || quoteTTL <= 0 {
+		quoteTTL = 15 * time.Minute
+	}
+	paymentTTL, err := time.ParseDuration(os.Getenv("BOOKING_PAYMENT_TTL"))
+	if err != nil || paymentTTL <= 0 {
+		paymentTTL = 24 * time.Hour
+	}
+	expiryWarning, err := time.ParseDuration(os.Getenv("BOOKING_EXPIRY_WARNING"))
+	if err != nil || expiryWarning < 0 || expiryWarning >= paymentTTL {
+		expiryWarning = paymentTTL / 4
+	}
+	handBackLead, err := time.ParseDuration(os.Getenv("HANDBACK_REMINDER_LEAD"))
+	if err != nil || handBackLead <= 0 {
+		handBackLead = 24 * time.Hour
+	}
+	if _, err := quoteKey(); err != nil {
+		log.Println("Neither QUOTE_SIGNING_KEY nor SECRET_KEY is set; quotes will be refused until one is")
+	}
+	s := &BookingService{
+		bookingStore:    bookingStore,
+		carStore:        carStore,
+		securityMonitor: securityMonitor,
+		riskScorer:      riskScorer,
+		telemetry:       telemetry,
+		locationStore:   locationStore,
+		geocoder:        geocoder,
+		carEvents:       carEvents,
+		notifier:        notifier,
+		vacationStore:   vacationStore,
+		fleetStore:      fleetStore,
+		payments:        payments,
+		documents:       documents,
+		kycStore:        kycStore,
+		blockStore:      blockStore,
+		digitalKeys:     digitalKeys,
+		checklistStore:  checklistStore,
+		lateFees:        models.LateFeePolicy{GracePeriod: gracePeriod, Multiplier: multiplier},
+		fuel:            models.FuelPricing{PricePerLiter: pricePerLiter},
+		leadTime:        leadTime,
+		quoteTTL:        quoteTTL,
+		paymentTTL:      paymentTTL,
+		expiryWarning:   expiryWarning,
+		handBackLead:    handBackLead,
+	}
+	s.statuses = models.BookingStatusMachine.Clone().
+		OnEnter(models.BookingStatusConfirmed, s.onConfirmed).
+		OnEnter(models.BookingStatusCompleted, s.onCompleted).
+		OnEnter(models.BookingStatusCancelled, s.onCancelled)
+	return s
 }
 
 func (s *BookingService) GetBookingByID(ctx context.Context, id string) (*models.Booking, error) {
@@ -103,6 +204,9 @@ func (s *BookingService) CreateBooking(ctx context.Context, bookingReq models.Bo
 	if err := s.validateBookingRequest(bookingReq); err != nil {
 		return nil, err
 	}
+	if conflict := s.leadTimeConflict(bookingReq.StartDate); conflict != nil {
+		return nil, errors.New(conflict.Message)
+	}
 
 	// Verify car exists and is available
 	car, err := s.carStore.GetCarByID(ctx, bookingReq.CarID.String())
@@ -114,7 +218,7 @@ func (s *BookingService) CreateBooking(ctx context.Context, bookingReq models.Bo
 		return nil, errors.New("car not found")
 	}
 
-	if !car.IsAvailable {
+	if !car.Listed {
 		return nil, errors.New("car is not available for booking")
 	}
 
@@ -123,18 +227,42 @@ func (s *BookingService) CreateBooking(ctx context.Context, bookingReq models.Bo
 		return nil, errors.New("owner ID does not match car owner")
 	}
 
-	// Check for booking conflicts (all bookings are rentals now)
-	if err := s.checkBookingConflicts(ctx, bookingReq); err != nil {
+	// Neither side of a blocked pair can book with the other
+	blocked, err := s.blockStore.IsBlocked(ctx, bookingReq.CustomerID.String(), car.OwnerID.String())
+	if err != nil {
 		return nil, err
 	}
+	if blocked {
+		return nil, errors.New("bookings between this renter and owner are blocked")
+	}
 
-	// Calculate total amount based on duration
-	totalAmount, err := s.calculateTotalAmount(car, bookingReq)
+	// The renter must meet the owner's age and licence rules on the day the rental starts
+	if err := s.checkEligibility(ctx, car, bookingReq); err != nil {
+		return nil, err
+	}
+
+	// Calculate total amount based on duration, pickup/drop-off fees, delivery and one-way relocation
+	quote, err := s.quoteBooking(ctx, car, bookingReq)
 	if err != nil {
 		return nil, err
 	}
 
-	booking, err := s.bookingStore.CreateBooking(ctx, bookingReq, totalAmount)
+	// A valid quote token locks the price the customer was shown, even if pricing changed since
+	if bookingReq.QuoteToken != "" {
+		claims, err := verifyQuote(bookingReq, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		quote.Price = claims.Price
+		quote.AddOns = claims.AddOns
+	}
+
+	// Check for booking conflicts, including where the car will be when the rental starts
+	if err := s.checkBookingConflicts(ctx, car, bookingReq, quote); err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingStore.CreateBooking(ctx, bookingReq, quote)
 	if err != nil {
 		return nil, err
 	}
@@ -142,22 +270,269 @@ func (s *BookingService) CreateBooking(ctx context.Context, bookingReq models.Bo
 	return &booking, nil
 }
 
-func (s *BookingService) calculateTotalAmount(car models.Car, bookingReq models.BookingRequest) (float64, error) {
+// QuoteBooking prices a prospective booking with the same rules CreateBooking applies
+func (s *BookingService) QuoteBooking(ctx context.Context, bookingReq models.BookingRequest) (*models.BookingQuote, error) {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "QuoteBooking-Service")
+	defer span.End()
+
+	if bookingReq.CarID == uuid.Nil {
+		return nil, errors.New("car ID is required")
+	}
+
+	if err := s.validateRentalRequest(bookingReq); err != nil {
+		return nil, err
+	}
+	if conflict := s.leadTimeConflict(bookingReq.StartDate); conflict != nil {
+		return nil, errors.New(conflict.Message)
+	}
+
+	car, err := s.carStore.GetCarByID(ctx, bookingReq.CarID.String())
+	if err != nil || car.ID == uuid.Nil {
+		return nil, errors.New("car not found")
+	}
+
+	if !car.Listed {
+		return nil, errors.New("car is not available for booking")
+	}
+
+	quote, err := s.quoteBooking(ctx, car, bookingReq)
+	if err != nil {
+		return nil, err
+	}
+
+	quote.ExpiresAt = time.Now().Add(s.quoteTTL)
+	if quote.Token, err = signQuote(bookingReq, quote, quote.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	return &quote, nil
+}
+
+func (s *BookingService) quoteBooking(ctx context.Context, car models.Car, bookingReq models.BookingRequest) (models.BookingQuote, error) {
+	// Cars in a fleet inherit its blackout dates, pricing rules and add-ons
+	fleet, err := s.carFleet(ctx, car)
+	if err != nil {
+		return models.BookingQuote{}, err
+	}
+	if fleet != nil {
+		if blackout := fleet.BlackoutDuring(bookingReq.StartDate, bookingReq.EndDate); blackout != nil {
+			return models.BookingQuote{}, fmt.Errorf("car is unavailable from %s to %s",
+				blackout.StartDate.Format("2 Jan 2006"), blackout.EndDate.Format("2 Jan 2006"))
+		}
+	}
+
+	rentalAmount, err := s.calculateTotalAmount(car, bookingReq, fleet)
+	if err != nil {
+		return models.BookingQuote{}, err
+	}
+
+	quote := models.BookingQuote{
+		CarID:      car.ID,
+		StartDate:  bookingReq.StartDate,
+		EndDate:    bookingReq.EndDate,
+		Price:      models.PriceBreakdown{RentalAmount: rentalAmount},
+		PickupCity: car.LocationCity,
+		ReturnCity: car.LocationCity,
+	}
+	price := &quote.Price
+
+	// Locations must be active and serve this car; their fees are charged on top of the rental
+	if bookingReq.PickupLocationID != nil {
+		pickup, err := s.selectableLocation(ctx, car, *bookingReq.PickupLocationID)
+		if err != nil {
+			return models.BookingQuote{}, err
+		}
+		price.PickupFee = pickup.PickupFee
+		quote.PickupCity = pickup.City
+		quote.ReturnCity = pickup.City
+	}
+	if bookingReq.DropoffLocationID != nil {
+		dropoff, err := s.selectableLocation(ctx, car, *bookingReq.DropoffLocationID)
+		if err != nil {
+			return models.BookingQuote{}, err
+		}
+		price.DropoffFee = dropoff.DropoffFee
+		quote.ReturnCity = dropoff.City
+	}
+
+	// Returning the car in another city is a one-way rental, charged from the relocation fee matrix
+	if !models.SameCity(quote.PickupCity, quote.ReturnCity) {
+		relocation, err := s.locationStore.GetRelocationFee(ctx, quote.PickupCity, quote.ReturnCity)
+		if err != nil {
+			return models.BookingQuote{}, err
+		}
+		quote.OneWay = true
+		price.RelocationFee = relocation.Fee
+	}
+
+	// Door delivery is charged by distance from the owner's delivery origin
+	if bookingReq.DeliveryAddress != "" {
+		delivery, fee, err := s.quoteDelivery(ctx, car, bookingReq.DeliveryAddress)
+		if err != nil {
+			return models.BookingQuote{}, err
+		}
+		quote.Delivery = &delivery
+		price.DeliveryFee = fee
+	}
+
+	if len(bookingReq.AddOns) > 0 {
+		if fleet == nil {
+			return models.BookingQuote{}, errors.New("add-ons are not offered for this car")
+		}
+		addOns, fee, err := fleet.QuoteAddOns(bookingReq.AddOns, rentalDays(bookingReq))
+		if err != nil {
+			return models.BookingQuote{}, err
+		}
+		quote.AddOns = addOns
+		price.AddOnsFee = fee
+	}
+
+	// Prepaid cars are paid a full tank up front; full-to-full cars are settled at check-in
+	fuelPolicy, err := s.carFuelPolicy(ctx, car)
+	if err != nil {
+		return models.BookingQuote{}, err
+	}
+	if fuelPolicy != nil {
+		price.PrepaidFuelFee = s.fuel.PrepaidFuelFee(*fuelPolicy)
+	}
+
+	price.Total = price.RentalAmount + price.PickupFee + price.DropoffFee + price.DeliveryFee + price.RelocationFee +
+		price.AddOnsFee + price.PrepaidFuelFee
+	return quote, nil
+}
+
+// carFuelPolicy returns the car's fuel policy, or nil if none is set
+func (s *BookingService) carFuelPolicy(ctx context.Context, car models.Car) (*models.CarFuelPolicy, error) {
+	policy, err := s.carStore.GetFuelPolicy(ctx, car.ID.String())
+	if err != nil {
+		if strings.Contains(err.Error(), "no fuel policy") {
+			return nil, nil
+		}
+		return nil, errors.New("failed to load fuel policy")
+	}
+	return &policy, nil
+}
+
+// carFleet returns the fleet whose settings the car inherits, or nil if it is in none
+func (s *BookingService) carFleet(ctx context.Context, car models.Car) (*models.Fleet, error) {
+	fleet, err := s.fleetStore.GetFleetByCarID(ctx, car.ID.String())
+	if err != nil {
+		if strings.Contains(err.Error(), "car is not in a fleet") {
+			return nil, nil
+		}
+		return nil, errors.New("failed to load fleet settings")
+	}
+	return &fleet, nil
+}
+
+// quoteDelivery geocodes the renter's address and prices delivering the car there
+func (s *BookingService) quoteDelivery(ctx context.Context, car models.Car, address string) (models.BookingDelivery, float64, error) {
+	option, err := s.locationStore.GetDeliveryOption(ctx, car.ID.String())
+	if err != nil {
+		return models.BookingDelivery{}, 0, err
+	}
+	if !option.Enabled {
+		// Checked before geocoding so a disabled car costs no geocoder request
+		return models.BookingDelivery{}, 0, errors.New("delivery is not offered for this car")
+	}
+
+	geocoded, err := s.geocoder.Geocode(ctx, address)
+	if err != nil {
+		return models.BookingDelivery{}, 0, err
+	}
+
+	return option.Quote(geocoded)
+}
+
+// selectableLocation returns a location customers may choose for this car
+func (s *BookingService) selectableLocation(ctx context.Context, car models.Car, locationID uuid.UUID) (models.Location, error) {
+	location, err := s.locationStore.GetCarLocation(ctx, car.ID.String(), locationID.String())
+	if err != nil {
+		return models.Location{}, err
+	}
+	if !location.IsActive {
+		return models.Location{}, errors.New("location is not available for this car")
+	}
+	return location, nil
+}
+
+func (s *BookingService) calculateTotalAmount(car models.Car, bookingReq models.BookingRequest, fleet *models.Fleet) (float64, error) {
 	// For rentals, calculate based on daily rate and duration
 	dailyRate := car.Price
 	if dailyRate <= 0 {
 		return 0, errors.New("invalid daily rental price for this car")
 	}
 
-	// Calculate duration in days
+	days := rentalDays(bookingReq)
+
+	// Fleet pricing rules adjust weekend days and long rentals
+	if fleet != nil {
+		return fleet.RentalAmount(dailyRate, bookingReq.StartDate, days), nil
+	}
+
+	totalAmount := dailyRate * float64(days)
+	return totalAmount, nil
+}
+
+// RecomputeBookingTotals reprices the pending bookings nobody has started paying from their
+// car's current daily rate and fleet pricing rules, keeping the fees charged at booking time.
+// Admins run it through the recompute_booking_totals maintenance task once a pricing mistake
+// is corrected. A booking whose car cannot be priced is logged and skipped.
+func (s *BookingService) RecomputeBookingTotals(ctx context.Context, bookingID *uuid.UUID) (models.MaintenanceResult, error) {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "RecomputeBookingTotals-Service")
+	defer span.End()
+
+	bookings, err := s.bookingStore.GetRepriceableBookings(ctx, bookingID)
+	if err != nil {
+		return models.MaintenanceResult{}, err
+	}
+
+	var result models.MaintenanceResult
+	for _, booking := range bookings {
+		result.Examined++
+
+		car, err := s.carStore.GetCarByID(ctx, booking.CarID.String())
+		if err != nil {
+			log.Printf("Failed to load car of booking %s for repricing: %v", booking.ID, err)
+			continue
+		}
+		fleet, err := s.carFleet(ctx, car)
+		if err != nil {
+			return result, err
+		}
+		rentalAmount, err := s.calculateTotalAmount(car, models.BookingRequest{StartDate: booking.StartDate, EndDate: booking.EndDate}, fleet)
+		if err != nil {
+			log.Printf("Failed to reprice booking %s: %v", booking.ID, err)
+			continue
+		}
+
+		total := math.Round((booking.TotalAmount-booking.PriceBreakdown.RentalAmount+rentalAmount)*100) / 100
+		if math.Abs(total-booking.TotalAmount) < 0.005 {
+			continue
+		}
+		updated, err := s.bookingStore.UpdateBookingTotal(ctx, booking.ID, booking.TotalAmount, total)
+		if err != nil {
+			return result, err
+		}
+		if updated {
+			log.Printf("Repriced booking %s from %.2f to %.2f", booking.ID, booking.TotalAmount, total)
+			result.Changed++
+		}
+	}
+
+	return result, nil
+}
+
+// rentalDays counts the charged days of a rental, at least 1
+func rentalDays(bookingReq models.BookingRequest) int {
 	duration := bookingReq.EndDate.Sub(bookingReq.StartDate)
 	days := int(duration.Hours() / 24)
 	if days < 1 {
 		days = 1 // Minimum 1 day
 	}
-
-	totalAmount := dailyRate * float64(days)
-	return totalAmount, nil
+	return days
 }
 
 func (s *BookingService) UpdateBookingStatus(ctx context.Context, id string, status models.BookingStatus) (*models.Booking, error) {
@@ -170,7 +545,7 @@ func (s *BookingService) UpdateBookingStatus(ctx context.Context, id string, sta
 	}
 
 	// Validate status
-	if err := s.validateBookingStatus(status); err != nil {
+	if err := s.statuses.CheckState(status); err != nil {
 		return nil, err
 	}
 
@@ -181,18 +556,67 @@ func (s *BookingService) UpdateBookingStatus(ctx context.Context, id string, sta
 	}
 
 	// Validate status transition
-	if err := s.validateStatusTransition(currentBooking.Status, status); err != nil {
+	transition := statemachine.Transition[models.BookingStatus]{From: currentBooking.Status, To: status}
+	if err := s.statuses.Validate(ctx, currentBooking, transition); err != nil {
 		return nil, err
 	}
 
+	// Score pending bookings before confirming them; risky ones are held for manual review.
+	// Bookings released from review were already checked by an admin and are not scored again.
+	if status == models.BookingStatusConfirmed && currentBooking.Status == models.BookingStatusPending {
+		assessment, err := s.riskScorer.AssessBooking(ctx, currentBooking, models.RiskStageBookingConfirmation)
+		if err != nil {
+			log.Printf("Risk assessment failed for booking %s, confirming without it: %v", id, err)
+		} else if assessment.RequiresReview {
+			status = models.BookingStatusUnderReview
+		}
+		if status == models.BookingStatusUnderReview {
+			held, err := s.bookingStore.UpdateBookingStatus(ctx, id, status)
+			if err != nil {
+				return nil, err
+			}
+			s.securityMonitor.RecordHighRiskBooking(ctx, held, assessment)
+			return &held, nil
+		}
+	}
+
 	booking, err := s.bookingStore.UpdateBookingStatus(ctx, id, status)
 	if err != nil {
 		return nil, err
 	}
 
+	s.statuses.Entered(ctx, booking, transition)
+
 	return &booking, nil
 }
 
+// onConfirmed tells the customer their booking is confirmed and hands them a digital key
+// when the car has a smart lock
+func (s *BookingService) onConfirmed(ctx context.Context, booking models.Booking, _ statemachine.Transition[models.BookingStatus]) {
+	if _, err := s.documents.IssueInvoice(ctx, booking); err != nil {
+		log.Printf("Failed to issue invoice for booking %s: %v", booking.ID, err)
+	}
+	s.sendConfirmation(ctx, booking)
+	s.digitalKeys.IssueBookingKey(ctx, booking)
+}
+
+// onCompleted takes back the digital key of a returned car
+func (s *BookingService) onCompleted(ctx context.Context, booking models.Booking, _ statemachine.Transition[models.BookingStatus]) {
+	s.digitalKeys.RevokeBookingKeys(ctx, booking)
+}
+
+// onCancelled tracks book-and-cancel cycles per customer for fraud review, releases the
+// booking's dates, which may be what someone is waiting for, voids payments held until pickup
+// and revokes the booking's digital key
+func (s *BookingService) onCancelled(ctx context.Context, booking models.Booking, _ statemachine.Transition[models.BookingStatus]) {
+	s.securityMonitor.RecordBookingCancellation(ctx, booking.CustomerID.String())
+	s.releaseDates(ctx, booking)
+	if err := s.payments.VoidAuthorizedPayments(ctx, booking.ID.String()); err != nil {
+		log.Printf("Failed to void held payments of cancelled booking %s: %v", booking.ID, err)
+	}
+	s.digitalKeys.RevokeBookingKeys(ctx, booking)
+}
+
 func (s *BookingService) DeleteBooking(ctx context.Context, id string) (*models.Booking, error) {
 	tracer := otel.Tracer("BookingService")
 	ctx, span := tracer.Start(ctx, "DeleteBooking-Service")
@@ -218,23 +642,468 @@ func (s *BookingService) DeleteBooking(ctx context.Context, id string) (*models.
 		return nil, err
 	}
 
+	// Deleting a pending booking frees its dates; cancelled ones were released when cancelled
+	if booking.Status == models.BookingStatusPending {
+		s.releaseDates(ctx, booking)
+	}
+
 	return &deletedBooking, nil
 }
 
-func (s *BookingService) GetAllBookings(ctx context.Context) (*[]models.Booking, error) {
+// sendConfirmation e-mails the customer the booking_confirmed template. Failures are only logged.
+func (s *BookingService) sendConfirmation(ctx context.Context, booking models.Booking) {
+	car, err := s.carStore.GetCarByID(ctx, booking.CarID.String())
+	if err != nil {
+		log.Printf("Failed to load car %s for booking confirmation %s: %v", booking.CarID, booking.ID, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"booking_id":   booking.ID.String(),
+		"car_name":     car.Name,
+		"start_date":   booking.StartDate.Format("2 Jan 2006"),
+		"end_date":     booking.EndDate.Format("2 Jan 2006"),
+		"total_amount": fmt.Sprintf("%.2f", booking.TotalAmount),
+	}
+	if err := s.notifier.NotifyWithTemplate(ctx, booking.CustomerID.String(), models.EmailTemplateBookingConfirmed, data); err != nil {
+		log.Printf("Failed to send booking confirmation for booking %s: %v", booking.ID, err)
+	}
+}
+
+// releaseDates tells car event listeners that a booking no longer blocks its dates
+func (s *BookingService) releaseDates(ctx context.Context, booking models.Booking) {
+	s.carEvents.HandleCarEvent(ctx, models.CarEvent{
+		Type:          models.CarEventDatesReleased,
+		CarID:         booking.CarID,
+		ReleasedStart: booking.StartDate,
+		ReleasedEnd:   booking.EndDate,
+	})
+}
+
+// ListBookings retrieves one cursor-paginated page of bookings matching the filter
+func (s *BookingService) ListBookings(ctx context.Context, filter models.BookingFilter, page models.PageRequest) (*models.Page[models.Booking], error) {
 	tracer := otel.Tracer("BookingService")
-	ctx, span := tracer.Start(ctx, "GetAllBookings-Service")
+	ctx, span := tracer.Start(ctx, "ListBookings-Service")
 	defer span.End()
 
-	bookings, err := s.bookingStore.GetAllBookings(ctx)
+	if filter.Status != "" {
+		if err := s.statuses.CheckState(filter.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	bookings, err := s.bookingStore.ListBookings(ctx, filter, page)
 	if err != nil {
 		return nil, err
 	}
 
-	return &bookings, nil
+	result := models.NewPage(bookings, page, models.Booking.PageCursor)
+	return &result, nil
 }
 
 // validateBookingRequest validates the booking request
+// RecordInspection records the checkout or check-in of a booking. Readings the caller leaves
+// out are auto-filled from the car's recent telemetry, and the source of each is recorded.
+// Once admins have saved a checklist, every item of it must be answered; see handoverChecklist.
+func (s *BookingService) RecordInspection(ctx context.Context, bookingID, userID, role string, kind models.InspectionKind, req models.InspectionRequest) (*models.BookingInspection, error) {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "RecordInspection-Service")
+	defer span.End()
+
+	if err := models.ValidateInspectionRequest(req); err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingStore.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handovers are done by the car's owner; other users see the booking as missing
+	if role != "admin" && booking.OwnerID.String() != userID {
+		return nil, errors.New("no booking found with the given ID")
+	}
+	if booking.ParentBookingID != nil {
+		return nil, errors.New("extensions continue their trip; check out and check in the original booking")
+	}
+
+	var checkout *models.BookingInspection
+	switch kind {
+	case models.InspectionKindCheckout:
+		if booking.Status != models.BookingStatusConfirmed {
+			return nil, errors.New("only confirmed bookings can be checked out")
+		}
+	case models.InspectionKindCheckin:
+		inspections, err := s.bookingStore.GetInspectionsByBookingID(ctx, bookingID)
+		if err != nil {
+			return nil, err
+		}
+		for i, inspection := range inspections {
+			if inspection.Kind == models.InspectionKindCheckout {
+				checkout = &inspections[i]
+			}
+		}
+		if checkout == nil {
+			return nil, errors.New("booking must be checked out before check-in")
+		}
+	default:
+		return nil, errors.New("invalid inspection kind")
+	}
+
+	checklist, err := s.handoverChecklist(ctx, checkout)
+	if err != nil {
+		return nil, err
+	}
+	if checklist == nil && len(req.Checklist) > 0 {
+		return nil, errors.New("checklist answers are not accepted: no checklist applies to this handover")
+	}
+	if checklist != nil {
+		if err := models.ValidateChecklistAnswers(*checklist, req.Checklist); err != nil {
+			return nil, err
+		}
+	}
+
+	recordedBy, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	inspection := models.BookingInspection{
+		BookingID:  booking.ID,
+		Kind:       kind,
+		OdometerKm: req.OdometerKm,
+		FuelLevel:  req.FuelLevel,
+		Notes:      req.Notes,
+		RecordedBy: recordedBy,
+	}
+	if checklist != nil {
+		inspection.ChecklistVersion, inspection.Checklist = &checklist.Version, req.Checklist
+	}
+	manual := models.ReadingSourceManual
+	if req.OdometerKm != nil {
+		inspection.OdometerSource = &manual
+	}
+	if req.FuelLevel != nil {
+		inspection.FuelSource = &manual
+	}
+
+	if req.OdometerKm == nil || req.FuelLevel == nil {
+		odometer, fuel, err := s.telemetry.LatestHandoverReadings(ctx, booking.CarID.String())
+		if err != nil {
+			// Auto-fill is a convenience; the handover is still recorded without it
+			log.Printf("Telemetry auto-fill failed for booking %s: %v", bookingID, err)
+		} else {
+			fromTelemetry := models.ReadingSourceTelemetry
+			if req.OdometerKm == nil && odometer != nil {
+				inspection.OdometerKm, inspection.OdometerSource = odometer, &fromTelemetry
+			}
+			if req.FuelLevel == nil && fuel != nil {
+				inspection.FuelLevel, inspection.FuelSource = fuel, &fromTelemetry
+			}
+		}
+	}
+
+	// Payments held on the customer's card are captured before the car is handed over
+	if kind == models.InspectionKindCheckout {
+		if err := s.payments.CaptureAuthorizedPayments(ctx, bookingID); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := s.bookingStore.CreateInspection(ctx, inspection, booking.CarID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// The store moved the booking along with the handover
+	transition := statemachine.Transition[models.BookingStatus]{From: models.BookingStatusConfirmed, To: models.BookingStatusInProgress}
+	if kind == models.InspectionKindCheckin {
+		transition = statemachine.Transition[models.BookingStatus]{From: models.BookingStatusInProgress, To: models.BookingStatusCompleted}
+	}
+	if booking.Status == transition.From {
+		booking.Status = transition.To
+		s.statuses.Entered(ctx, booking, transition)
+	}
+
+	if kind == models.InspectionKindCheckin {
+		extensions, err := s.bookingStore.GetBookingExtensions(ctx, bookingID)
+		if err != nil {
+			log.Printf("Failed to load extensions of booking %s, settling against its own end date: %v", bookingID, err)
+		}
+		s.closeExtensions(ctx, extensions)
+		s.settleReturn(ctx, booking, booking.TripEnd(extensions), *checkout, created)
+	}
+
+	return &created, nil
+}
+
+// handoverChecklist returns the checklist a handover answers: the current version at checkout,
+// and at check-in the version its checkout answered, so both ends of the trip compare item by
+// item. It is nil before admins have saved a checklist, and at check-ins of trips checked out
+// before then.
+func (s *BookingService) handoverChecklist(ctx context.Context, checkout *models.BookingInspection) (*models.ChecklistTemplate, error) {
+	if checkout != nil {
+		if checkout.ChecklistVersion == nil {
+			return nil, nil
+		}
+		checklist, err := s.checklistStore.GetTemplateVersion(ctx, *checkout.ChecklistVersion)
+		if err != nil {
+			return nil, err
+		}
+		return &checklist, nil
+	}
+
+	checklist, err := s.checklistStore.GetCurrentTemplate(ctx)
+	if err != nil {
+		if strings.Contains(err.Error(), "no checklist template found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &checklist, nil
+}
+
+// settleReturn charges a booking at check-in for a return after its grace period past due, the
+// end of the trip, and for fuel missing from a full-to-full car, and requests one payment for
+// both from the customer. The check-in is already recorded, so failures are only logged.
+func (s *BookingService) settleReturn(ctx context.Context, booking models.Booking, due time.Time, checkout, checkin models.BookingInspection) {
+	car, err := s.carStore.GetCarByID(ctx, booking.CarID.String())
+	if err != nil {
+		log.Printf("Failed to load car %s for return charges of booking %s: %v", booking.CarID, booking.ID, err)
+		return
+	}
+
+	lateFee := s.lateFees.LateFee(car.Price, due, checkin.RecordedAt)
+
+	var liters, refuelFee float64
+	fuelPolicy, err := s.carFuelPolicy(ctx, car)
+	if err != nil {
+		log.Printf("Failed to load fuel policy for booking %s: %v", booking.ID, err)
+	} else if fuelPolicy != nil {
+		if checkout.FuelLevel == nil || checkin.FuelLevel == nil {
+			log.Printf("Fuel level missing from handover of booking %s, refuel not charged", booking.ID)
+		} else {
+			liters, refuelFee = s.fuel.RefuelFee(*fuelPolicy, *checkout.FuelLevel, *checkin.FuelLevel)
+		}
+	}
+
+	total := lateFee + refuelFee
+	if total == 0 {
+		return
+	}
+
+	if _, err := s.bookingStore.AddReturnCharges(ctx, booking.ID.String(), lateFee, refuelFee); err != nil {
+		log.Printf("Failed to add return charges of %.2f to booking %s: %v", total, booking.ID, err)
+		return
+	}
+
+	_, err = s.payments.CreatePayment(ctx, &models.PaymentRequest{
+		BookingID:   booking.ID,
+		Amount:      total,
+		Method:      models.PaymentMethodRazorpay,
+		Description: "Return charges for booking " + booking.ID.String(),
+	})
+	if err != nil {
+		log.Printf("Failed to request return charges payment for booking %s: %v", booking.ID, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"booking_id":      booking.ID.String(),
+		"car_name":        car.Name,
+		"end_date":        due.Format("2 Jan 2006 15:04"),
+		"returned_at":     checkin.RecordedAt.Format("2 Jan 2006 15:04"),
+		"late_fee":        fmt.Sprintf("%.2f", lateFee),
+		"refuel_liters":   fmt.Sprintf("%.1f", liters),
+		"price_per_liter": fmt.Sprintf("%.2f", s.fuel.PricePerLiter),
+		"refuel_fee":      fmt.Sprintf("%.2f", refuelFee),
+		"total_due":       fmt.Sprintf("%.2f", total),
+	}
+	if err := s.notifier.NotifyWithTemplate(ctx, booking.CustomerID.String(), models.EmailTemplateReturnCharges, data); err != nil {
+		log.Printf("Failed to send return charges notice for booking %s: %v", booking.ID, err)
+	}
+}
+
+// GetInspections retrieves the checkout and check-in records of a booking
+func (s *BookingService) GetInspections(ctx context.Context, bookingID string) ([]models.BookingInspection, error) {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "GetInspections-Service")
+	defer span.End()
+
+	return s.bookingStore.GetInspectionsByBookingID(ctx, bookingID)
+}
+
+// CollectPayment records the car owner or an admin collecting an offline payment in person.
+// A pending booking is then confirmed, which may still hold it for review.
+func (s *BookingService) CollectPayment(ctx context.Context, bookingID, paymentID, userID, role string, req models.PaymentCollectionRequest) (*models.PaymentCollectionResult, error) {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "CollectPayment-Service")
+	defer span.End()
+
+	booking, err := s.bookingStore.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cash changes hands at the car, so only its owner records it; other users see the booking as missing
+	if role != "admin" && booking.OwnerID.String() != userID {
+		return nil, errors.New("no booking found with the given ID")
+	}
+	if booking.Status == models.BookingStatusCancelled {
+		return nil, errors.New("payments cannot be collected for cancelled bookings")
+	}
+
+	collection, payment, err := s.payments.RecordCollection(ctx, bookingID, paymentID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The money is already taken, so a failed confirmation is logged rather than returned
+	if booking.Status == models.BookingStatusPending {
+		confirmed, err := s.UpdateBookingStatus(ctx, bookingID, models.BookingStatusConfirmed)
+		if err != nil {
+			log.Printf("Failed to confirm booking %s after collecting payment %s: %v", bookingID, payment.ID, err)
+		} else {
+			booking = *confirmed
+		}
+	}
+
+	return &models.PaymentCollectionResult{
+		Collection: *collection,
+		Payment:    *payment,
+		Booking:    booking,
+	}, nil
+}
+
+// GetPaymentCollections retrieves the offline collections of a booking for its car owner or an admin
+func (s *BookingService) GetPaymentCollections(ctx context.Context, bookingID, userID, role string) ([]models.PaymentCollection, error) {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "GetPaymentCollections-Service")
+	defer span.End()
+
+	booking, err := s.bookingStore.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if role != "admin" && booking.OwnerID.String() != userID {
+		return nil, errors.New("no booking found with the given ID")
+	}
+
+	return s.payments.GetCollections(ctx, bookingID)
+}
+
+// GetDocuments retrieves the numbered invoice and receipts of a booking for its customer,
+// car owner or an admin
+func (s *BookingService) GetDocuments(ctx context.Context, bookingID, userID, role string) ([]models.FinancialDocument, error) {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "GetDocuments-Service")
+	defer span.End()
+
+	booking, err := s.bookingStore.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if role != "admin" && booking.OwnerID.String() != userID && booking.CustomerID.String() != userID {
+		return nil, errors.New("no booking found with the given ID")
+	}
+
+	return s.documents.GetBookingDocuments(ctx, bookingID)
+}
+
+// GetActiveTrips retrieves the trips currently under way with the owner's cars
+func (s *BookingService) GetActiveTrips(ctx context.Context, ownerID string) ([]models.Booking, error) {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "GetActiveTrips-Service")
+	defer span.End()
+
+	return s.bookingStore.ListActiveTrips(ctx, ownerID)
+}
+
+// DetectOverdueTrips flags trips still in progress after their end date and e-mails their
+// customer and owner once. Failed e-mails are only logged.
+func (s *BookingService) DetectOverdueTrips(ctx context.Context) error {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "DetectOverdueTrips-Service")
+	defer span.End()
+
+	overdue, err := s.bookingStore.MarkOverdueTrips(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, booking := range overdue {
+		carName := "your car"
+		if car, err := s.carStore.GetCarByID(ctx, booking.CarID.String()); err == nil && car.Name != "" {
+			carName = car.Name
+		}
+		data := map[string]interface{}{
+			"booking_id": booking.ID.String(),
+			"car_name":   carName,
+			"end_date":   booking.EndDate.Format("2 Jan 2006 15:04"),
+		}
+		for _, userID := range []string{booking.CustomerID.String(), booking.OwnerID.String()} {
+			if err := s.notifier.NotifyWithTemplate(ctx, userID, models.EmailTemplateTripOverdue, data); err != nil {
+				log.Printf("Failed to send overdue notice for booking %s to %s: %v", booking.ID, userID, err)
+			}
+		}
+	}
+
+	if len(overdue) > 0 {
+		log.Printf("Flagged %d overdue trips", len(overdue))
+	}
+	return nil
+}
+
+// ExpireUnpaidBookings cancels pending bookings that were not paid within the payment TTL,
+// releasing their dates, then reminds the customers of bookings about to expire. A booking
+// counts as paid once a payment of it completed or is held on the customer's card.
+func (s *BookingService) ExpireUnpaidBookings(ctx context.Context) error {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "ExpireUnpaidBookings-Service")
+	defer span.End()
+
+	now := time.Now()
+	expired, err := s.bookingStore.ExpireUnpaidBookings(ctx, now.Add(-s.paymentTTL), now)
+	if err != nil {
+		return err
+	}
+	// The store cancelled the bookings, so run what a cancellation sets off
+	transition := statemachine.Transition[models.BookingStatus]{From: models.BookingStatusPending, To: models.BookingStatusCancelled}
+	for _, booking := range expired {
+		s.statuses.Entered(ctx, booking, transition)
+	}
+	if len(expired) > 0 {
+		log.Printf("Cancelled %d unpaid bookings", len(expired))
+	}
+
+	if s.expiryWarning == 0 {
+		return nil
+	}
+	expiring, err := s.bookingStore.MarkExpiringBookings(ctx, now.Add(s.expiryWarning-s.paymentTTL), now)
+	if err != nil {
+		return err
+	}
+	for _, booking := range expiring {
+		carName := "your car"
+		if booking.Car != nil && booking.Car.Name != "" {
+			carName = booking.Car.Name
+		}
+		data := map[string]interface{}{
+			"booking_id":   booking.ID.String(),
+			"car_name":     carName,
+			"start_date":   booking.StartDate.Format("2 Jan 2006"),
+			"end_date":     booking.EndDate.Format("2 Jan 2006"),
+			"total_amount": fmt.Sprintf("%.2f", booking.TotalAmount),
+			"expires_at":   booking.CreatedAt.Add(s.paymentTTL).Format("2 Jan 2006 15:04"),
+		}
+		if err := s.notifier.NotifyWithTemplate(ctx, booking.CustomerID.String(), models.EmailTemplateBookingExpiring, data); err != nil {
+			log.Printf("Failed to send expiry reminder for booking %s: %v", booking.ID, err)
+		}
+	}
+
+	return nil
+}
+
 func (s *BookingService) validateBookingRequest(req models.BookingRequest) error {
 	if req.CustomerID == uuid.Nil {
 		return errors.New("customer ID is required")
@@ -269,78 +1138,218 @@ func (s *BookingService) validateRentalRequest(req models.BookingRequest) error
 		return errors.New("minimum rental duration is 1 day")
 	}
 
-	return nil
-}
-
-// validateBookingStatus validates booking status values
-func (s *BookingService) validateBookingStatus(status models.BookingStatus) error {
-	validStatuses := []models.BookingStatus{
-		models.BookingStatusPending,
-		models.BookingStatusConfirmed,
-		models.BookingStatusCompleted,
-		models.BookingStatusCancelled,
-	}
-
-	for _, validStatus := range validStatuses {
-		if status == validStatus {
-			return nil
+	// A delivered car is handed over at the renter's address, not at a pickup location
+	if req.DeliveryAddress != "" {
+		if req.PickupLocationID != nil {
+			return errors.New("choose either a pickup location or a delivery address, not both")
+		}
+		if err := models.ValidateDeliveryAddress(req.DeliveryAddress); err != nil {
+			return err
 		}
 	}
 
-	return errors.New("invalid booking status")
+	return nil
 }
 
-// validateStatusTransition validates if a status transition is allowed
-func (s *BookingService) validateStatusTransition(current, new models.BookingStatus) error {
-	// Define allowed status transitions
-	allowedTransitions := map[models.BookingStatus][]models.BookingStatus{
-		models.BookingStatusPending: {
-			models.BookingStatusConfirmed,
-			models.BookingStatusCancelled,
-		},
-		models.BookingStatusConfirmed: {
-			models.BookingStatusCompleted,
-			models.BookingStatusCancelled,
-		},
-		models.BookingStatusCompleted: {}, // Terminal state
-		models.BookingStatusCancelled: {}, // Terminal state
+// checkEligibility checks the customer's verified KYC data against the car's minimum renter
+// age and licence years. Cars without rules skip the lookup; failures are *models.EligibilityError.
+func (s *BookingService) checkEligibility(ctx context.Context, car models.Car, req models.BookingRequest) error {
+	eligibility, err := s.carStore.GetEligibility(ctx, car.ID.String())
+	if err != nil {
+		return fmt.Errorf("failed to check renter eligibility: %v", err)
 	}
-
-	allowed, exists := allowedTransitions[current]
-	if !exists {
-		return errors.New("invalid current booking status")
+	if !eligibility.Restricts() {
+		return nil
 	}
 
-	for _, status := range allowed {
-		if status == new {
-			return nil
-		}
+	var verified *models.VerifiedKYC
+	kyc, err := s.kycStore.GetVerifiedKYC(ctx, req.CustomerID.String())
+	if err == nil {
+		verified = &kyc
+	} else if !strings.Contains(err.Error(), "no verified KYC found") {
+		return fmt.Errorf("failed to check renter eligibility: %v", err)
 	}
 
-	return errors.New("invalid status transition from " + string(current) + " to " + string(new))
+	return eligibility.CheckEligibility(verified, req.StartDate)
 }
 
 // checkBookingConflicts checks for conflicting bookings for rental requests
-func (s *BookingService) checkBookingConflicts(ctx context.Context, req models.BookingRequest) error {
+func (s *BookingService) checkBookingConflicts(ctx context.Context, car models.Car, req models.BookingRequest, quote models.BookingQuote) error {
+	// Owners on vacation block their cars without any booking existing
+	blocked, err := s.vacationStore.IsCarBlocked(ctx, req.CarID.String(), req.StartDate, req.EndDate)
+	if err != nil {
+		return errors.New("failed to check booking conflicts")
+	}
+	if blocked {
+		return errors.New("car is blocked by its owner for part of this period")
+	}
+
 	// Get existing bookings for the car
 	existingBookings, err := s.bookingStore.GetBookingsByCarID(ctx, req.CarID.String())
 	if err != nil {
 		return errors.New("failed to check booking conflicts")
 	}
 
-	// Check for date conflicts with confirmed/active rentals
-	for _, booking := range existingBookings {
-		if booking.Status == models.BookingStatusConfirmed || booking.Status == models.BookingStatusPending {
+	// Check for date conflicts with confirmed/active rentals, remembering the closest
+	// one-way-capable rental before and the first rental after the requested period
+	var previous, next *models.Booking
+	for i, booking := range existingBookings {
+		if holdsCar(booking.Status) {
 			// Check if dates overlap
 			if s.datesOverlap(req.StartDate, req.EndDate, booking.StartDate, booking.EndDate) {
 				return errors.New("booking conflicts with existing rental for the same period")
 			}
+			if booking.DropoffLocationID != nil && !booking.EndDate.After(req.StartDate) &&
+				(previous == nil || booking.EndDate.After(previous.EndDate)) {
+				previous = &existingBookings[i]
+			}
+			if !booking.StartDate.Before(req.EndDate) && (next == nil || booking.StartDate.Before(next.StartDate)) {
+				next = &existingBookings[i]
+			}
+		}
+	}
+
+	// An earlier rental may leave the car in another city by the time this one starts
+	if previous != nil {
+		dropoff, err := s.locationStore.GetLocationByID(ctx, previous.DropoffLocationID.String())
+		if err != nil {
+			return errors.New("failed to check booking conflicts")
+		}
+		if !models.SameCity(dropoff.City, quote.PickupCity) {
+			return fmt.Errorf("car will be in %s when this rental starts", dropoff.City)
+		}
+	}
+
+	// A one-way rental must not strand the car away from where the next rental starts
+	if quote.OneWay && next != nil {
+		nextCity := car.LocationCity
+		if next.PickupLocationID != nil {
+			pickup, err := s.locationStore.GetLocationByID(ctx, next.PickupLocationID.String())
+			if err != nil {
+				return errors.New("failed to check booking conflicts")
+			}
+			nextCity = pickup.City
+		}
+		if !models.SameCity(nextCity, quote.ReturnCity) {
+			return fmt.Errorf("car has a later rental starting in %s and cannot be returned in %s", nextCity, quote.ReturnCity)
 		}
 	}
 
 	return nil
 }
 
+// CheckConflicts reports whether a car can be booked for a period and every rule that prevents
+// it. It applies CreateBooking's availability rules; location and delivery rules depend on the
+// full request and are only checked by quotes and bookings.
+func (s *BookingService) CheckConflicts(ctx context.Context, carID string, start, end time.Time) (*models.BookingAvailability, error) {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "CheckConflicts-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, errors.New("invalid car ID")
+	}
+	if err := s.validateRentalRequest(models.BookingRequest{StartDate: start, EndDate: end}); err != nil {
+		return nil, err
+	}
+
+	car, err := s.carStore.GetCarByID(ctx, carID)
+	if err != nil || car.ID == uuid.Nil {
+		return nil, errors.New("car not found")
+	}
+
+	conflicts := []models.BookingConflict{}
+	if !car.Listed {
+		conflicts = append(conflicts, models.BookingConflict{
+			Reason:  models.BookingConflictCarUnavailable,
+			Message: "car is not available for booking",
+		})
+	}
+	if conflict := s.leadTimeConflict(start); conflict != nil {
+		conflicts = append(conflicts, *conflict)
+	}
+
+	periodConflicts, err := s.periodConflicts(ctx, car, start, end)
+	if err != nil {
+		return nil, err
+	}
+	conflicts = append(conflicts, periodConflicts...)
+
+	return &models.BookingAvailability{
+		CarID:     car.ID,
+		StartDate: start,
+		EndDate:   end,
+		Bookable:  len(conflicts) == 0,
+		Conflicts: conflicts,
+	}, nil
+}
+
+// leadTimeConflict returns a conflict if a rental starting at start gives less notice than
+// the configured lead time, or nil if it gives enough
+func (s *BookingService) leadTimeConflict(start time.Time) *models.BookingConflict {
+	if s.leadTime == 0 {
+		return nil
+	}
+	earliest := time.Now().Add(s.leadTime)
+	if !start.Before(earliest) {
+		return nil
+	}
+	return &models.BookingConflict{
+		Reason:  models.BookingConflictLeadTime,
+		Message: fmt.Sprintf("rentals must be booked at least %s ahead, start at %s or later", s.leadTime, earliest.Format(time.RFC3339)),
+	}
+}
+
+// periodConflicts lists the fleet and owner blackouts and the rentals that keep a car from being
+// booked for a period
+func (s *BookingService) periodConflicts(ctx context.Context, car models.Car, start, end time.Time) ([]models.BookingConflict, error) {
+	var conflicts []models.BookingConflict
+
+	fleet, err := s.carFleet(ctx, car)
+	if err != nil {
+		return nil, err
+	}
+	if fleet != nil {
+		for _, blackout := range fleet.Blackouts {
+			if s.datesOverlap(start, end, blackout.StartDate, blackout.EndDate) {
+				conflicts = append(conflicts, models.NewPeriodConflict(models.BookingConflictBlackout,
+					fmt.Sprintf("car is unavailable from %s to %s", blackout.StartDate.Format("2 Jan 2006"), blackout.EndDate.Format("2 Jan 2006")),
+					blackout.StartDate, blackout.EndDate))
+			}
+		}
+	}
+
+	blackouts, err := s.vacationStore.ListCarBlackouts(ctx, car.ID.String(), start, end)
+	if err != nil {
+		return nil, errors.New("failed to check booking conflicts")
+	}
+	for _, blackout := range blackouts {
+		conflicts = append(conflicts, models.NewPeriodConflict(models.BookingConflictBlackout,
+			fmt.Sprintf("car is blocked by its owner from %s to %s", blackout.StartDate.Format("2 Jan 2006"), blackout.EndDate.Format("2 Jan 2006")),
+			blackout.StartDate, blackout.EndDate))
+	}
+
+	bookings, err := s.bookingStore.GetBookingsByCarID(ctx, car.ID.String())
+	if err != nil {
+		return nil, errors.New("failed to check booking conflicts")
+	}
+	for _, booking := range bookings {
+		if holdsCar(booking.Status) && s.datesOverlap(start, end, booking.StartDate, booking.EndDate) {
+			conflicts = append(conflicts, models.NewPeriodConflict(models.BookingConflictExistingBooking,
+				fmt.Sprintf("car is already booked from %s to %s", booking.StartDate.Format("2 Jan 2006"), booking.EndDate.Format("2 Jan 2006")),
+				booking.StartDate, booking.EndDate))
+		}
+	}
+
+	return conflicts, nil
+}
+
+// holdsCar reports whether a booking in this status keeps other rentals off its dates
+func holdsCar(status models.BookingStatus) bool {
+	return status == models.BookingStatusConfirmed || status == models.BookingStatusPending ||
+		status == models.BookingStatusUnderReview || status == models.BookingStatusInProgress
+}
+
 // datesOverlap checks if two date ranges overlap
 func (s *BookingService) datesOverlap(start1, end1, start2, end2 time.Time) bool {
 	return start1.Before(end2) && end1.After(start2)
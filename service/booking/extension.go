@@ -0,0 +1,239 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/statemachine"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// Trips are extended with sub-bookings. An extension is a booking of the same car and customer
+// from the trip's current end to the new end, priced at the car's daily rate and paid like any
+// booking. Once its owner confirms it, the trip is due back at its end.
+
+// QuoteExtension checks whether a trip in progress can be extended to a new end date and prices
+// the extra days
+func (s *BookingService) QuoteExtension(ctx context.Context, bookingID, userID, role string, end time.Time) (*models.ExtensionQuote, error) {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "QuoteExtension-Service")
+	defer span.End()
+
+	trip, extensions, err := s.extendableTrip(ctx, bookingID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.quoteExtension(ctx, trip, extensions, end)
+}
+
+// ExtendBooking books the extra days of a trip as an extension and creates the Razorpay order
+// for their price. The extension is returned even if the order could not be created; it can
+// then be paid through the payments API.
+func (s *BookingService) ExtendBooking(ctx context.Context, bookingID, userID, role string, req models.ExtensionRequest) (*models.BookingExtension, error) {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "ExtendBooking-Service")
+	defer span.End()
+
+	trip, extensions, err := s.extendableTrip(ctx, bookingID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := s.quoteExtension(ctx, trip, extensions, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if !quote.Available {
+		return nil, errors.New("car is not available for the extension: " + quote.Conflicts[0].Message)
+	}
+
+	bookingReq := models.BookingRequest{
+		CustomerID:      trip.CustomerID,
+		CarID:           trip.CarID,
+		OwnerID:         trip.OwnerID,
+		StartDate:       quote.StartDate,
+		EndDate:         quote.EndDate,
+		Notes:           "Extension of booking " + trip.ID.String(),
+		ParentBookingID: &trip.ID,
+	}
+	extension, err := s.bookingStore.CreateBooking(ctx, bookingReq, models.BookingQuote{
+		CarID:     trip.CarID,
+		StartDate: quote.StartDate,
+		EndDate:   quote.EndDate,
+		Price:     quote.Price,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.payments.CreatePayment(ctx, &models.PaymentRequest{
+		BookingID:   extension.ID,
+		Amount:      quote.Price.Total,
+		Method:      models.PaymentMethodRazorpay,
+		Description: "Extension of booking " + trip.ID.String(),
+	})
+	if err != nil {
+		log.Printf("Failed to request payment for extension %s of booking %s: %v", extension.ID, trip.ID, err)
+	}
+
+	return &models.BookingExtension{Booking: extension, PaymentOrder: order}, nil
+}
+
+// RemindHandBacks reminds the customers of trips due back within the hand-back lead to return
+// the car, offering to extend the trip by a day when the car is free for it. Run periodically
+// by the scheduler.
+func (s *BookingService) RemindHandBacks(ctx context.Context) error {
+	tracer := otel.Tracer("BookingService")
+	ctx, span := tracer.Start(ctx, "RemindHandBacks-Service")
+	defer span.End()
+
+	now := time.Now()
+	trips, err := s.bookingStore.MarkHandBackReminders(ctx, now.Add(s.handBackLead), now)
+	if err != nil {
+		return err
+	}
+
+	for _, trip := range trips {
+		extensions, err := s.bookingStore.GetBookingExtensions(ctx, trip.ID.String())
+		if err != nil {
+			log.Printf("Failed to load extensions of booking %s for its hand-back reminder: %v", trip.ID, err)
+			continue
+		}
+		end := trip.TripEnd(extensions)
+
+		carName := "your car"
+		if trip.Car != nil && trip.Car.Name != "" {
+			carName = trip.Car.Name
+		}
+		data := map[string]interface{}{
+			"booking_id":         trip.ID.String(),
+			"car_name":           carName,
+			"end_date":           end.Format("2 Jan 2006 15:04"),
+			"extension_end_date": "",
+			"extension_price":    "",
+		}
+		// Offer another day only when nothing stands in the way of booking it
+		if offer, err := s.quoteExtension(ctx, trip, extensions, end.Add(24*time.Hour)); err == nil && offer.Available {
+			data["extension_end_date"] = offer.EndDate.Format("2 Jan 2006 15:04")
+			data["extension_price"] = fmt.Sprintf("%.2f", offer.Price.Total)
+		}
+
+		if err := s.notifier.NotifyWithTemplate(ctx, trip.CustomerID.String(), models.EmailTemplateHandBackReminder, data); err != nil {
+			log.Printf("Failed to send hand-back reminder for booking %s: %v", trip.ID, err)
+		}
+	}
+
+	if len(trips) > 0 {
+		log.Printf("Sent %d hand-back reminders", len(trips))
+	}
+	return nil
+}
+
+// extendableTrip loads a trip the caller may extend, with its extensions. Only the customer or
+// an admin may extend a trip, and only while it is in progress.
+func (s *BookingService) extendableTrip(ctx context.Context, bookingID, userID, role string) (models.Booking, []models.Booking, error) {
+	if _, err := uuid.Parse(bookingID); err != nil {
+		return models.Booking{}, nil, errors.New("invalid booking ID")
+	}
+
+	trip, err := s.bookingStore.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return models.Booking{}, nil, err
+	}
+	// Other users see the booking as missing
+	if role != "admin" && trip.CustomerID.String() != userID {
+		return models.Booking{}, nil, errors.New("no booking found with the given ID")
+	}
+	if trip.ParentBookingID != nil {
+		return models.Booking{}, nil, errors.New("extend the original booking, not one of its extensions")
+	}
+	if trip.Status != models.BookingStatusInProgress {
+		return models.Booking{}, nil, errors.New("only trips in progress can be extended")
+	}
+
+	extensions, err := s.bookingStore.GetBookingExtensions(ctx, bookingID)
+	if err != nil {
+		return models.Booking{}, nil, err
+	}
+
+	return trip, extensions, nil
+}
+
+// quoteExtension prices extending a trip from its current end to end at the car's daily rate
+// and fleet pricing, and collects what keeps the car from being booked for those days. A trip
+// with an extension awaiting payment or confirmation cannot be extended again.
+func (s *BookingService) quoteExtension(ctx context.Context, trip models.Booking, extensions []models.Booking, end time.Time) (*models.ExtensionQuote, error) {
+	start := trip.TripEnd(extensions)
+	if err := models.ValidateExtension(start, end); err != nil {
+		return nil, err
+	}
+	for _, extension := range extensions {
+		if extension.Status == models.BookingStatusPending || extension.Status == models.BookingStatusUnderReview {
+			return nil, errors.New("an extension of this trip is already awaiting payment or confirmation")
+		}
+	}
+
+	car, err := s.carStore.GetCarByID(ctx, trip.CarID.String())
+	if err != nil || car.ID == uuid.Nil {
+		return nil, errors.New("car not found")
+	}
+
+	conflicts := []models.BookingConflict{}
+	if !car.Listed {
+		conflicts = append(conflicts, models.BookingConflict{
+			Reason:  models.BookingConflictCarUnavailable,
+			Message: "car is not available for booking",
+		})
+	}
+	periodConflicts, err := s.periodConflicts(ctx, car, start, end)
+	if err != nil {
+		return nil, err
+	}
+	conflicts = append(conflicts, periodConflicts...)
+
+	fleet, err := s.carFleet(ctx, car)
+	if err != nil {
+		return nil, err
+	}
+	rentalAmount, err := s.calculateTotalAmount(car, models.BookingRequest{StartDate: start, EndDate: end}, fleet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ExtensionQuote{
+		BookingID: trip.ID,
+		StartDate: start,
+		EndDate:   end,
+		Price:     models.PriceBreakdown{RentalAmount: rentalAmount, Total: rentalAmount},
+		Available: len(conflicts) == 0,
+		Conflicts: conflicts,
+	}, nil
+}
+
+// closeExtensions settles a trip's extensions at check-in: confirmed ones are completed with the
+// trip, and those still awaiting payment or confirmation are cancelled. Failures are logged.
+func (s *BookingService) closeExtensions(ctx context.Context, extensions []models.Booking) {
+	for _, extension := range extensions {
+		status := models.BookingStatusCancelled
+		switch extension.Status {
+		case models.BookingStatusConfirmed:
+			status = models.BookingStatusCompleted
+		case models.BookingStatusPending, models.BookingStatusUnderReview:
+		default:
+			continue
+		}
+
+		closed, err := s.bookingStore.UpdateBookingStatus(ctx, extension.ID.String(), status)
+		if err != nil {
+			log.Printf("Failed to close extension %s at check-in: %v", extension.ID, err)
+			continue
+		}
+		s.statuses.Entered(ctx, closed, statemachine.Transition[models.BookingStatus]{From: extension.Status, To: status})
+	}
+}
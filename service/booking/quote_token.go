@@ -0,0 +1,134 @@
+package booking
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/secrets"
+)
+
+// errNoQuoteKey refuses to sign or verify quotes when no key is configured, rather than using
+// a key anyone could read from the source
+var errNoQuoteKey = errors.New("quotes are unavailable, the server has no signing key")
+
+// quoteClaims is the signed payload of a quote token: the price shown to the customer, the
+// booking terms it was computed for and when the guarantee lapses
+type quoteClaims struct {
+	Terms     string                `json:"terms"` // Hex SHA-256 of the booking terms, see quoteTerms
+	Price     models.PriceBreakdown `json:"price"`
+	AddOns    []models.BookingAddOn `json:"add_ons,omitempty"`
+	ExpiresAt time.Time             `json:"expires_at"`
+}
+
+// signQuote issues a token locking the quote's price for the request's terms until expiresAt
+func signQuote(req models.BookingRequest, quote models.BookingQuote, expiresAt time.Time) (string, error) {
+	payload, err := json.Marshal(quoteClaims{
+		Terms:     quoteTerms(req),
+		Price:     quote.Price,
+		AddOns:    quote.AddOns,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	signature, err := quoteSignature(encoded)
+	if err != nil {
+		return "", err
+	}
+	return encoded + "." + signature, nil
+}
+
+// verifyQuote checks a quote token's signature, expiry and that it was issued for the
+// request's terms, returning the locked price
+func verifyQuote(req models.BookingRequest, now time.Time) (quoteClaims, error) {
+	encoded, signature, ok := strings.Cut(req.QuoteToken, ".")
+	if !ok {
+		return quoteClaims{}, errors.New("invalid quote token")
+	}
+	expected, err := quoteSignature(encoded)
+	if err != nil {
+		return quoteClaims{}, err
+	}
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return quoteClaims{}, errors.New("invalid quote token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return quoteClaims{}, errors.New("invalid quote token")
+	}
+	var claims quoteClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return quoteClaims{}, errors.New("invalid quote token")
+	}
+
+	if now.After(claims.ExpiresAt) {
+		return quoteClaims{}, fmt.Errorf("quote expired at %s, request a new quote", claims.ExpiresAt.Format(time.RFC3339))
+	}
+	if claims.Terms != quoteTerms(req) {
+		return quoteClaims{}, errors.New("quote was issued for a different car, period, locations, delivery address or add-ons")
+	}
+
+	return claims, nil
+}
+
+// quoteKey returns the key quotes are signed with. QUOTE_SIGNING_KEY is read at the point of use
+// so rotations apply at once; it falls back to SECRET_KEY, which also signs sessions, and
+// outstanding quotes stop verifying when the key changes. Without either it fails closed.
+func quoteKey() (string, error) {
+	key := secrets.GetOrDefault("QUOTE_SIGNING_KEY", secrets.Get("SECRET_KEY"))
+	if key == "" {
+		return "", errNoQuoteKey
+	}
+	return key, nil
+}
+
+// quoteSignature returns the base64url HMAC-SHA256 of an encoded payload
+func quoteSignature(encoded string) (string, error) {
+	key, err := quoteKey()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte("quote:" + encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// quoteTerms fingerprints everything a quote's price depends on, so a token cannot be replayed
+// for a longer period, another car or extra add-ons
+func quoteTerms(req models.BookingRequest) string {
+	var pickup, dropoff string
+	if req.PickupLocationID != nil {
+		pickup = req.PickupLocationID.String()
+	}
+	if req.DropoffLocationID != nil {
+		dropoff = req.DropoffLocationID.String()
+	}
+
+	addOns := append([]string{}, req.AddOns...)
+	sort.Strings(addOns)
+
+	terms := strings.Join([]string{
+		req.CarID.String(),
+		req.StartDate.UTC().Format(time.RFC3339Nano),
+		req.EndDate.UTC().Format(time.RFC3339Nano),
+		pickup,
+		dropoff,
+		strings.ToLower(strings.TrimSpace(req.DeliveryAddress)),
+		strings.Join(addOns, ","),
+	}, "|")
+
+	sum := sha256.Sum256([]byte(terms))
+	return hex.EncodeToString(sum[:])
+}
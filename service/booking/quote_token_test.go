@@ -0,0 +1,69 @@
+package booking
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+)
+
+// testQuoteRequest is the booking the tests quote: a three-day rental of one car
+var testQuoteRequest = models.BookingRequest{
+	CarID:     uuid.MustParse("2d9c4f7e-6a1b-4e3c-8d5f-0b7a9c1e3f52"),
+	StartDate: time.Date(2026, 11, 2, 10, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2026, 11, 5, 10, 0, 0, 0, time.UTC),
+}
+
+// signTestQuote signs a quote for testQuoteRequest with the current key and returns the
+// request carrying its token
+func signTestQuote(t *testing.T, now time.Time) models.BookingRequest {
+	t.Helper()
+	token, err := signQuote(testQuoteRequest, models.BookingQuote{Price: models.PriceBreakdown{Total: 6600}}, now.Add(15*time.Minute))
+	if err != nil {
+		t.Fatalf("signing a quote: %v", err)
+	}
+	req := testQuoteRequest
+	req.QuoteToken = token
+	return req
+}
+
+func TestVerifyQuoteAcceptsTokenSignedWithTheKey(t *testing.T) {
+	t.Setenv("QUOTE_SIGNING_KEY", "quote-key-a")
+	now := time.Now()
+
+	claims, err := verifyQuote(signTestQuote(t, now), now)
+	if err != nil {
+		t.Fatalf("expected the token to verify, got %v", err)
+	}
+	if claims.Price.Total != 6600 {
+		t.Fatalf("expected the locked total 6600, got %.2f", claims.Price.Total)
+	}
+}
+
+func TestVerifyQuoteRejectsTokenSignedWithAnotherKey(t *testing.T) {
+	t.Setenv("QUOTE_SIGNING_KEY", "quote-key-a")
+	now := time.Now()
+	req := signTestQuote(t, now)
+
+	t.Setenv("QUOTE_SIGNING_KEY", "quote-key-b")
+	if _, err := verifyQuote(req, now); err == nil || err.Error() != "invalid quote token" {
+		t.Fatalf("expected invalid quote token, got %v", err)
+	}
+}
+
+func TestQuotesFailClosedWithoutAKey(t *testing.T) {
+	t.Setenv("QUOTE_SIGNING_KEY", "quote-key-a")
+	now := time.Now()
+	req := signTestQuote(t, now)
+
+	t.Setenv("QUOTE_SIGNING_KEY", "")
+	t.Setenv("SECRET_KEY", "")
+	if _, err := signQuote(testQuoteRequest, models.BookingQuote{}, now.Add(15*time.Minute)); !errors.Is(err, errNoQuoteKey) {
+		t.Errorf("signing without a key: expected %v, got %v", errNoQuoteKey, err)
+	}
+	if _, err := verifyQuote(req, now); !errors.Is(err, errNoQuoteKey) {
+		t.Errorf("verifying without a key: expected %v, got %v", errNoQuoteKey, err)
+	}
+}
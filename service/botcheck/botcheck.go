@@ -0,0 +1,64 @@
+// Package botcheck keeps bots from registering accounts and asking for password resets, which
+// cost an SMS or e-mail each. Clients prove they are a person with a CAPTCHA (hCaptcha or
+// Cloudflare Turnstile), verified with the provider, or by solving a proof-of-work puzzle the
+// server hands out and checks itself. Without configuration every request passes.
+package botcheck
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/secrets"
+	"github.com/PrateekKumar15/CarZone/service"
+)
+
+// FromEnv returns the bot check BOT_CHECK_PROVIDER names: "hcaptcha" or "turnstile", verified
+// with BOT_CHECK_SECRET and rendered with BOT_CHECK_SITE_KEY, or "pow", whose puzzles need
+// BOT_CHECK_POW_DIFFICULTY leading zero bits (default 20). Unset, or a CAPTCHA without its
+// secret, turns the check off.
+func FromEnv() service.BotCheckInterface {
+	provider := strings.ToLower(strings.TrimSpace(os.Getenv("BOT_CHECK_PROVIDER")))
+	siteKey := strings.TrimSpace(os.Getenv("BOT_CHECK_SITE_KEY"))
+
+	switch provider {
+	case "", models.BotCheckNone:
+		return Off{}
+	case models.BotCheckHCaptcha, models.BotCheckTurnstile:
+		if secrets.Get("BOT_CHECK_SECRET") == "" {
+			log.Printf("BOT_CHECK_PROVIDER is %s but BOT_CHECK_SECRET is not set; bot check disabled", provider)
+			return Off{}
+		}
+		return NewSiteVerify(provider, siteKey)
+	case models.BotCheckProofOfWork:
+		difficulty, err := strconv.Atoi(os.Getenv("BOT_CHECK_POW_DIFFICULTY"))
+		if err != nil || difficulty < 1 || difficulty > maxDifficulty {
+			difficulty = defaultDifficulty
+		}
+		return NewProofOfWork(secrets.Get("BOT_CHECK_SECRET"), difficulty)
+	default:
+		log.Printf("Unknown BOT_CHECK_PROVIDER %q; bot check disabled", provider)
+		return Off{}
+	}
+}
+
+// Off lets every request through, for development and deployments without bot traffic
+type Off struct{}
+
+// Name identifies the disabled check
+func (Off) Name() string {
+	return models.BotCheckNone
+}
+
+// Challenge tells clients there is nothing to solve
+func (Off) Challenge(_ context.Context) (*models.BotChallenge, error) {
+	return &models.BotChallenge{Provider: models.BotCheckNone}, nil
+}
+
+// Verify accepts any token, including none
+func (Off) Verify(_ context.Context, _, _ string) error {
+	return nil
+}
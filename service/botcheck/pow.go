@@ -0,0 +1,164 @@
+package botcheck
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"log"
+	"math/bits"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+)
+
+const (
+	defaultDifficulty = 20 // About a million hashes, a second or two in a browser
+	maxDifficulty     = 32
+	challengeTTL      = 5 * time.Minute // How long a client has to solve a challenge and send it
+	maxNonceLength    = 64
+)
+
+// ProofOfWork makes clients spend CPU before each request instead of solving a CAPTCHA. A
+// challenge is an expiry time, the difficulty and random bytes, signed so the server keeps
+// nothing until it is redeemed. The client finds a nonce such that
+//
+//	SHA-256(challenge + ":" + nonce)
+//
+// starts with difficulty zero bits and sends "challenge:nonce" as its token. Redeemed challenges
+// are remembered in memory until they expire, so each one works once per instance.
+type ProofOfWork struct {
+	key        []byte
+	difficulty int
+
+	mu        sync.Mutex
+	spent     map[string]time.Time // Redeemed challenges and when they expire
+	lastSweep time.Time
+}
+
+// NewProofOfWork creates a proof-of-work check whose challenges are signed with secret and need
+// difficulty leading zero bits. Without a secret a random key is used, so only this instance,
+// until it restarts, accepts its challenges.
+func NewProofOfWork(secret string, difficulty int) *ProofOfWork {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			log.Fatalf("Failed to generate proof-of-work key: %v", err)
+		}
+		log.Println("BOT_CHECK_SECRET is not set; proof-of-work challenges are only accepted by the instance that issued them")
+	}
+	return &ProofOfWork{
+		key:        key,
+		difficulty: difficulty,
+		spent:      make(map[string]time.Time),
+	}
+}
+
+// Name identifies the proof-of-work check
+func (p *ProofOfWork) Name() string {
+	return models.BotCheckProofOfWork
+}
+
+// Challenge issues a signed puzzle valid for challengeTTL
+func (p *ProofOfWork) Challenge(_ context.Context) (*models.BotChallenge, error) {
+	expiresAt := time.Now().Add(challengeTTL).UTC().Truncate(time.Second)
+
+	payload := make([]byte, 25)
+	binary.BigEndian.PutUint64(payload[:8], uint64(expiresAt.Unix()))
+	payload[8] = byte(p.difficulty)
+	if _, err := rand.Read(payload[9:]); err != nil {
+		return nil, err
+	}
+
+	challenge := base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(p.sign(payload))
+	return &models.BotChallenge{
+		Provider:   models.BotCheckProofOfWork,
+		Challenge:  challenge,
+		Difficulty: p.difficulty,
+		ExpiresAt:  &expiresAt,
+	}, nil
+}
+
+// Verify checks the signature, expiry and work of a solved challenge and redeems it
+func (p *ProofOfWork) Verify(_ context.Context, token, _ string) error {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return errors.New("bot check token is required")
+	}
+
+	split := strings.LastIndex(token, ":")
+	if split < 0 || len(token)-split-1 > maxNonceLength {
+		return errors.New("bot check failed: token must be the challenge and nonce separated by a colon")
+	}
+	challenge := token[:split]
+
+	encodedPayload, encodedMAC, ok := strings.Cut(challenge, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if !ok || err != nil || len(payload) != 25 {
+		return errors.New("bot check failed: malformed challenge")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, p.sign(payload)) {
+		return errors.New("bot check failed: challenge was not issued by this server")
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[:8])), 0)
+	now := time.Now()
+	if !now.Before(expiresAt) {
+		return errors.New("bot check failed: challenge has expired, fetch a new one")
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	if leadingZeroBits(sum[:]) < int(payload[8]) {
+		return errors.New("bot check failed: nonce does not solve the challenge")
+	}
+
+	return p.redeem(challenge, expiresAt, now)
+}
+
+// redeem marks a challenge as used, refusing one that already was
+func (p *ProofOfWork) redeem(challenge string, expiresAt, now time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Forget expired challenges once per TTL; they fail the expiry check anyway
+	if now.Sub(p.lastSweep) >= challengeTTL {
+		for c, expiry := range p.spent {
+			if !now.Before(expiry) {
+				delete(p.spent, c)
+			}
+		}
+		p.lastSweep = now
+	}
+
+	if _, used := p.spent[challenge]; used {
+		return errors.New("bot check failed: challenge has already been used, fetch a new one")
+	}
+	p.spent[challenge] = expiresAt
+	return nil
+}
+
+// sign returns the HMAC-SHA256 of a challenge payload
+func (p *ProofOfWork) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// leadingZeroBits counts the zero bits a hash starts with
+func leadingZeroBits(sum []byte) int {
+	count := 0
+	for _, b := range sum {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+	return count
+}
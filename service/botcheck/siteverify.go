@@ -0,0 +1,122 @@
+package botcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/dependency"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/secrets"
+)
+
+// siteVerifyURLs are the verification endpoints of the CAPTCHA providers. Both take the same
+// form and answer in the same shape.
+var siteVerifyURLs = map[string]string{
+	models.BotCheckHCaptcha:  "https://api.hcaptcha.com/siteverify",
+	models.BotCheckTurnstile: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+}
+
+// SiteVerify checks CAPTCHA tokens with the provider that issued them:
+//
+//	POST {siteverify URL}  secret=BOT_CHECK_SECRET&response={token}&remoteip={ip}  -> {"success", "error-codes"}
+//
+// The provider rejects tokens it has already verified, so each one works once.
+type SiteVerify struct {
+	provider string
+	siteKey  string
+	client   *http.Client
+}
+
+// NewSiteVerify creates a check for provider, "hcaptcha" or "turnstile", whose widget is
+// rendered with siteKey
+func NewSiteVerify(provider, siteKey string) *SiteVerify {
+	return &SiteVerify{
+		provider: provider,
+		siteKey:  siteKey,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name identifies the CAPTCHA provider
+func (v *SiteVerify) Name() string {
+	return v.provider
+}
+
+// Challenge returns the site key clients render the provider's widget with
+func (v *SiteVerify) Challenge(_ context.Context) (*models.BotChallenge, error) {
+	return &models.BotChallenge{Provider: v.provider, SiteKey: v.siteKey}, nil
+}
+
+// Verify asks the provider whether the token was issued for a solved CAPTCHA
+func (v *SiteVerify) Verify(ctx context.Context, token, remoteIP string) error {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return errors.New("bot check token is required")
+	}
+
+	errorCodes, err := v.siteVerify(ctx, token, remoteIP)
+	if err != nil {
+		return err
+	}
+	for _, code := range errorCodes {
+		// The provider refused our secret rather than the client's token
+		if strings.Contains(code, "secret") {
+			return fmt.Errorf("%s rejected BOT_CHECK_SECRET: %s", v.provider, code)
+		}
+	}
+	if errorCodes != nil {
+		return fmt.Errorf("bot check failed: %s", strings.Join(errorCodes, ", "))
+	}
+	return nil
+}
+
+// siteVerify posts a token to the provider's siteverify endpoint. It returns nil error codes
+// when the provider accepted the token.
+func (v *SiteVerify) siteVerify(ctx context.Context, token, remoteIP string) (_ []string, err error) {
+	ctx, done := dependency.Start(ctx, dependency.BotCheck, "SiteVerify")
+	defer func() { done(err) }()
+
+	form := url.Values{}
+	form.Set("secret", secrets.Get("BOT_CHECK_SECRET"))
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, siteVerifyURLs[v.provider], strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %v", v.provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded with status %d", v.provider, resp.StatusCode)
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("%s returned an unreadable response: %v", v.provider, err)
+	}
+	if result.Success {
+		return nil, nil
+	}
+	if len(result.ErrorCodes) == 0 {
+		return []string{"invalid-input-response"}, nil
+	}
+	return result.ErrorCodes, nil
+}
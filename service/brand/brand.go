@@ -0,0 +1,67 @@
+package brand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// BrandService implements the BrandServiceInterface
+type BrandService struct {
+	brandStore store.BrandStoreInterface
+}
+
+// NewBrandService creates a new brand service
+func NewBrandService(brandStore store.BrandStoreInterface) *BrandService {
+	return &BrandService{
+		brandStore: brandStore,
+	}
+}
+
+// ListBrands retrieves the brands whose name starts with the query
+func (s *BrandService) ListBrands(ctx context.Context, query string) ([]models.CarBrand, error) {
+	tracer := otel.Tracer("BrandService")
+	ctx, span := tracer.Start(ctx, "ListBrands-Service")
+	defer span.End()
+
+	return s.brandStore.ListBrands(ctx, query, models.MaxAutocompleteResults)
+}
+
+// ListModels retrieves the models of a brand whose name starts with the query
+func (s *BrandService) ListModels(ctx context.Context, brandID, query string) ([]models.CarModel, error) {
+	tracer := otel.Tracer("BrandService")
+	ctx, span := tracer.Start(ctx, "ListModels-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(brandID); err != nil {
+		return nil, errors.New("invalid brand ID")
+	}
+
+	return s.brandStore.ListModels(ctx, brandID, query, models.MaxAutocompleteResults)
+}
+
+// ResolveBrandModel checks that a brand and model pair exists and returns the names as spelled
+// in the reference data, so "toyota camry" is stored as "Toyota Camry"
+func (s *BrandService) ResolveBrandModel(ctx context.Context, brand, model string) (string, string, error) {
+	tracer := otel.Tracer("BrandService")
+	ctx, span := tracer.Start(ctx, "ResolveBrandModel-Service")
+	defer span.End()
+
+	b, m, err := s.brandStore.FindBrandModel(ctx, brand, model)
+	if err != nil {
+		return "", "", err
+	}
+	if b == nil {
+		return "", "", fmt.Errorf("unknown car brand %s, choose one from GET /brands or set other_brand_model", brand)
+	}
+	if m == nil {
+		return "", "", fmt.Errorf("unknown %s model %s, choose one from GET /brands/%s/models or set other_brand_model", b.Name, model, b.ID)
+	}
+
+	return b.Name, m.Name, nil
+}
@@ -0,0 +1,165 @@
+// Package cache keeps the results of read-heavy service methods for a short time. Each cached
+// method gets its own Cache with a TTL, and hits and misses are counted in Prometheus so the
+// TTLs can be tuned. Decorators drop entries when the data behind them changes.
+package cache
+
+import (
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxEntries bounds the memory one method's cache may use
+const maxEntries = 10000
+
+var (
+	requestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "service_cache_requests_total",
+			Help: "Total number of cached service method calls by result (hit or miss)",
+		},
+		[]string{"service", "method", "result"},
+	)
+	invalidationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "service_cache_invalidations_total",
+			Help: "Total number of service cache entries dropped because their data changed",
+		},
+		[]string{"service", "method"},
+	)
+)
+
+func init() {
+	// Register the metrics with Prometheus's default registry
+	prometheus.MustRegister(requestCounter, invalidationCounter)
+}
+
+// TTLs maps a method, as Service.Method, to how long its results are kept. A TTL of zero
+// turns caching of the method off.
+type TTLs map[string]time.Duration
+
+// TTLsFromEnv returns the defaults overridden by SERVICE_CACHE_TTLS, a comma-separated list
+// such as "CarService.GetCarByID=30s,CarService.GetFuelPolicy=0". Malformed entries are
+// logged and ignored.
+func TTLsFromEnv(defaults TTLs) TTLs {
+	ttls := TTLs{}
+	for method, ttl := range defaults {
+		ttls[method] = ttl
+	}
+
+	for _, entry := range strings.Split(os.Getenv("SERVICE_CACHE_TTLS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(entry, "=")
+		ttl, err := time.ParseDuration(strings.TrimSpace(value))
+		if !ok || err != nil || ttl < 0 {
+			log.Printf("Ignoring malformed SERVICE_CACHE_TTLS entry %q", entry)
+			continue
+		}
+		ttls[strings.TrimSpace(method)] = ttl
+	}
+
+	return ttls
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache keeps the results of one service method by key until their TTL runs out
+type Cache[V any] struct {
+	service string
+	method  string
+	ttl     time.Duration
+
+	mu      sync.Mutex
+	entries map[string]entry[V]
+}
+
+// New creates the cache of a service method, with the TTL configured for it in ttls
+func New[V any](service, method string, ttls TTLs) *Cache[V] {
+	return &Cache[V]{
+		service: service,
+		method:  method,
+		ttl:     ttls[service+"."+method],
+		entries: map[string]entry[V]{},
+	}
+}
+
+// Load returns the cached value for key, or calls load and caches what it returns. Errors
+// are never cached. Calls are passed straight to load when caching of the method is off.
+func (c *Cache[V]) Load(key string, load func() (V, error)) (V, error) {
+	if c.ttl <= 0 {
+		return load()
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	cached, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		requestCounter.WithLabelValues(c.service, c.method, "hit").Inc()
+		return cached.value, nil
+	}
+	requestCounter.WithLabelValues(c.service, c.method, "miss").Inc()
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxEntries {
+		c.evict(now)
+	}
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+
+	return value, nil
+}
+
+// Delete drops the entry for key
+func (c *Cache[V]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; ok {
+		delete(c.entries, key)
+		invalidationCounter.WithLabelValues(c.service, c.method).Inc()
+	}
+}
+
+// DeleteFunc drops every entry for which match returns true
+func (c *Cache[V]) DeleteFunc(match func(key string, value V) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, cached := range c.entries {
+		if match(key, cached.value) {
+			delete(c.entries, key)
+			invalidationCounter.WithLabelValues(c.service, c.method).Inc()
+		}
+	}
+}
+
+// evict drops expired entries, and half of the rest when none have expired. The caller holds mu.
+func (c *Cache[V]) evict(now time.Time) {
+	for key, cached := range c.entries {
+		if !now.Before(cached.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	for key := range c.entries {
+		if len(c.entries) < maxEntries/2 {
+			break
+		}
+		delete(c.entries, key)
+	}
+}
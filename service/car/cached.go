@@ -0,0 +1,179 @@
+package car
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/service/cache"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// CacheTTLsFromEnv returns how long car lookups are cached, overridable through
+// SERVICE_CACHE_TTLS. Public listings change rarely and are read the most.
+func CacheTTLsFromEnv() cache.TTLs {
+	return cache.TTLsFromEnv(cache.TTLs{
+		"CarService.GetCarByID":         30 * time.Second,
+		"CarService.GetCarBySlug":       30 * time.Second,
+		"CarService.GetPublicCarByID":   2 * time.Minute,
+		"CarService.GetPublicCarBySlug": 2 * time.Minute,
+		"CarService.GetFuelPolicy":      5 * time.Minute,
+	})
+}
+
+// CachedCarService decorates a CarServiceInterface with a cache of single-car lookups.
+// Entries are dropped when the car is changed through the service, and when a car event
+// arrives for it, so changes made by other flows are not served stale either. Lists are
+// not cached, as their filters and pages rarely repeat.
+type CachedCarService struct {
+	service.CarServiceInterface
+
+	byID         *cache.Cache[*models.Car]
+	bySlug       *cache.Cache[*models.Car]
+	publicByID   *cache.Cache[*models.PublicCar]
+	publicBySlug *cache.Cache[*models.PublicCar]
+	fuelPolicies *cache.Cache[*models.CarFuelPolicy]
+}
+
+// NewCachedCarService wraps a car service with caches configured by ttls
+func NewCachedCarService(next service.CarServiceInterface, ttls cache.TTLs) *CachedCarService {
+	return &CachedCarService{
+		CarServiceInterface: next,
+		byID:                cache.New[*models.Car]("CarService", "GetCarByID", ttls),
+		bySlug:              cache.New[*models.Car]("CarService", "GetCarBySlug", ttls),
+		publicByID:          cache.New[*models.PublicCar]("CarService", "GetPublicCarByID", ttls),
+		publicBySlug:        cache.New[*models.PublicCar]("CarService", "GetPublicCarBySlug", ttls),
+		fuelPolicies:        cache.New[*models.CarFuelPolicy]("CarService", "GetFuelPolicy", ttls),
+	}
+}
+
+func (s *CachedCarService) GetCarByID(ctx context.Context, id string) (*models.Car, error) {
+	tracer := otel.Tracer("CachedCarService")
+	ctx, span := tracer.Start(ctx, "GetCarByID-Cache")
+	defer span.End()
+
+	car, err := s.byID.Load(cacheKey(ctx, strings.ToLower(id)), func() (*models.Car, error) {
+		return s.CarServiceInterface.GetCarByID(ctx, id)
+	})
+	return copyOf(car), err
+}
+
+func (s *CachedCarService) GetCarBySlug(ctx context.Context, slug string) (*models.Car, error) {
+	tracer := otel.Tracer("CachedCarService")
+	ctx, span := tracer.Start(ctx, "GetCarBySlug-Cache")
+	defer span.End()
+
+	car, err := s.bySlug.Load(cacheKey(ctx, slug), func() (*models.Car, error) {
+		return s.CarServiceInterface.GetCarBySlug(ctx, slug)
+	})
+	return copyOf(car), err
+}
+
+func (s *CachedCarService) GetPublicCarByID(ctx context.Context, id string) (*models.PublicCar, error) {
+	tracer := otel.Tracer("CachedCarService")
+	ctx, span := tracer.Start(ctx, "GetPublicCarByID-Cache")
+	defer span.End()
+
+	car, err := s.publicByID.Load(cacheKey(ctx, strings.ToLower(id)), func() (*models.PublicCar, error) {
+		return s.CarServiceInterface.GetPublicCarByID(ctx, id)
+	})
+	return copyOf(car), err
+}
+
+func (s *CachedCarService) GetPublicCarBySlug(ctx context.Context, slug string) (*models.PublicCar, error) {
+	tracer := otel.Tracer("CachedCarService")
+	ctx, span := tracer.Start(ctx, "GetPublicCarBySlug-Cache")
+	defer span.End()
+
+	car, err := s.publicBySlug.Load(cacheKey(ctx, slug), func() (*models.PublicCar, error) {
+		return s.CarServiceInterface.GetPublicCarBySlug(ctx, slug)
+	})
+	return copyOf(car), err
+}
+
+func (s *CachedCarService) GetFuelPolicy(ctx context.Context, carID string) (*models.CarFuelPolicy, error) {
+	tracer := otel.Tracer("CachedCarService")
+	ctx, span := tracer.Start(ctx, "GetFuelPolicy-Cache")
+	defer span.End()
+
+	policy, err := s.fuelPolicies.Load(cacheKey(ctx, strings.ToLower(carID)), func() (*models.CarFuelPolicy, error) {
+		return s.CarServiceInterface.GetFuelPolicy(ctx, carID)
+	})
+	return copyOf(policy), err
+}
+
+func (s *CachedCarService) CreateCar(ctx context.Context, carReq models.CarRequest) (*models.Car, error) {
+	car, err := s.CarServiceInterface.CreateCar(ctx, carReq)
+	if err == nil {
+		// A slug looked up before the car existed is cached as not found
+		s.bySlug.DeleteFunc(func(_ string, car *models.Car) bool { return car == nil })
+		s.publicBySlug.DeleteFunc(func(_ string, car *models.PublicCar) bool { return car == nil })
+	}
+	return car, err
+}
+
+func (s *CachedCarService) UpdateCar(ctx context.Context, id string, carReq models.CarRequest) (*models.Car, error) {
+	car, err := s.CarServiceInterface.UpdateCar(ctx, id, carReq)
+	s.invalidate(id)
+	return car, err
+}
+
+func (s *CachedCarService) UpdateCarStatus(ctx context.Context, id string, status models.CarStatus, byAdmin bool) (*models.Car, error) {
+	car, err := s.CarServiceInterface.UpdateCarStatus(ctx, id, status, byAdmin)
+	s.invalidate(id)
+	return car, err
+}
+
+func (s *CachedCarService) UpdateFuelPolicy(ctx context.Context, userID, role, carID string, req models.CarFuelPolicyRequest) (*models.CarFuelPolicy, error) {
+	policy, err := s.CarServiceInterface.UpdateFuelPolicy(ctx, userID, role, carID, req)
+	s.invalidate(carID)
+	return policy, err
+}
+
+func (s *CachedCarService) DeleteCar(ctx context.Context, id string) (*models.Car, error) {
+	car, err := s.CarServiceInterface.DeleteCar(ctx, id)
+	s.invalidate(id)
+	return car, err
+}
+
+// HandleCarEvent drops the cached lookups of the car an event is about
+func (s *CachedCarService) HandleCarEvent(ctx context.Context, event models.CarEvent) {
+	s.invalidate(event.CarID.String())
+}
+
+// invalidate drops every cached lookup of a car, for all operators. Slug entries are found
+// by the car they hold, as the slug may have just changed.
+func (s *CachedCarService) invalidate(id string) {
+	carID, err := uuid.Parse(id)
+	if err != nil {
+		return
+	}
+
+	ofCar := func(key string) bool { return strings.HasSuffix(key, "/"+carID.String()) }
+	s.byID.DeleteFunc(func(key string, _ *models.Car) bool { return ofCar(key) })
+	s.publicByID.DeleteFunc(func(key string, _ *models.PublicCar) bool { return ofCar(key) })
+	s.fuelPolicies.DeleteFunc(func(key string, _ *models.CarFuelPolicy) bool { return ofCar(key) })
+	s.bySlug.DeleteFunc(func(_ string, car *models.Car) bool { return car != nil && car.ID == carID })
+	s.publicBySlug.DeleteFunc(func(_ string, car *models.PublicCar) bool { return car != nil && car.ID == carID })
+}
+
+// cacheKey scopes a lookup to the operator of the request, as stores only return its cars
+func cacheKey(ctx context.Context, id string) string {
+	if operatorID := tenant.Scope(ctx); operatorID != nil {
+		return operatorID.String() + "/" + id
+	}
+	return "/" + id
+}
+
+// copyOf returns a copy of a cached value, so callers changing it do not change the cache
+func copyOf[V any](value *V) *V {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
@@ -3,18 +3,43 @@ package car
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/PrateekKumar15/CarZone/identity"
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/statemachine"
 	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 )
 
 type CarService struct {
-	store store.CarStoreInterface
+	store      store.CarStoreInterface
+	events     service.CarEventListenerInterface
+	features   service.FeatureServiceInterface
+	brands     service.BrandServiceInterface
+	attributes service.CarAttributeServiceInterface
+	settings   service.SettingServiceInterface
+	featured   store.FeaturedStoreInterface
+	// Images sent as base64 data wait here for the upload job
+	imageUploads store.CarImageUploadStoreInterface
+	// How long a price reduction is shown as a price drop on listings
+	priceDropWindow time.Duration
 }
 
-func NewCarService(store store.CarStoreInterface) *CarService {
-	return &CarService{store: store}
+// NewCarService creates a new car service. PRICE_DROP_WINDOW (default 168h) is how long after
+// a price reduction listings mark the car as dropped in price.
+func NewCarService(store store.CarStoreInterface, events service.CarEventListenerInterface, features service.FeatureServiceInterface, brands service.BrandServiceInterface, attributes service.CarAttributeServiceInterface, settings service.SettingServiceInterface, featured store.FeaturedStoreInterface, imageUploads store.CarImageUploadStoreInterface) *CarService {
+	priceDropWindow, err := time.ParseDuration(os.Getenv("PRICE_DROP_WINDOW"))
+	if err != nil || priceDropWindow < 0 {
+		priceDropWindow = 7 * 24 * time.Hour
+	}
+	return &CarService{store: store, events: events, features: features, brands: brands, attributes: attributes, settings: settings, featured: featured, imageUploads: imageUploads, priceDropWindow: priceDropWindow}
 }
 
 func (s *CarService) GetCarByID(ctx context.Context, id string) (*models.Car, error) {
@@ -40,16 +65,16 @@ func (s *CarService) GetCarByID(ctx context.Context, id string) (*models.Car, er
 	return &car, nil
 }
 
-func (s *CarService) GetCarByBrand(ctx context.Context, brand string) (*[]models.Car, error) {
+func (s *CarService) GetCarByBrand(ctx context.Context, brand string, fuzzy bool) (*[]models.Car, error) {
 	tracer := otel.Tracer("CarService")
 	ctx, span := tracer.Start(ctx, "GetCarByBrand-Service")
 	defer span.End()
 
-	if brand == "" {
+	if strings.TrimSpace(brand) == "" {
 		return nil, errors.New("brand cannot be empty")
 	}
 
-	cars, err := s.store.GetCarByBrand(ctx, brand)
+	cars, err := s.store.GetCarByBrand(ctx, brand, fuzzy)
 	if err != nil {
 		return nil, err
 	}
@@ -66,11 +91,55 @@ func (s *CarService) CreateCar(ctx context.Context, carReq models.CarRequest) (*
 	if err := s.validateCarRequest(carReq); err != nil {
 		return nil, err
 	}
+	features, err := s.normalizeFeatures(ctx, carReq.Features)
+	if err != nil {
+		return nil, err
+	}
+	carReq.Features = features
+	if carReq.Attributes, err = s.normalizeAttributes(ctx, carReq.Attributes); err != nil {
+		return nil, err
+	}
+	if !carReq.OtherBrandModel {
+		if carReq.Brand, carReq.Model, err = s.brands.ResolveBrandModel(ctx, carReq.Brand, carReq.Model); err != nil {
+			return nil, err
+		}
+	}
+
+	// Owners list cars under their own account whatever owner_id says; admins may list for
+	// any owner, or for the operator without one
+	user, ok := identity.FromContext(ctx)
+	if !ok {
+		return nil, errors.New("authentication required to create a car")
+	}
+	if !user.IsAdmin() {
+		ownerID, err := uuid.Parse(user.ID)
+		if err != nil {
+			return nil, errors.New("authentication required to create a car")
+		}
+		carReq.OwnerID = &ownerID
+	}
+
+	// New listings enter the lifecycle as drafts or straight into review, and only become
+	// bookable once an admin approves them
+	if carReq.Status == "" {
+		carReq.Status = models.CarStatusDraft
+	}
+	if carReq.Status != models.CarStatusDraft && carReq.Status != models.CarStatusPendingReview {
+		return nil, errors.New("new cars must start as draft or pending_review")
+	}
+	carReq.IsAvailable = false
+	var imageData []string
+	if carReq.Images, imageData, err = splitImages(carReq.Images); err != nil {
+		return nil, err
+	}
 
 	createdCar, err := s.store.CreateCar(ctx, carReq)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.queueImageUploads(ctx, createdCar.ID, imageData); err != nil {
+		return nil, err
+	}
 
 	return &createdCar, nil
 }
@@ -88,14 +157,214 @@ func (s *CarService) UpdateCar(ctx context.Context, id string, carReq models.Car
 	if err := s.validateCarRequest(carReq); err != nil {
 		return nil, err
 	}
+	features, err := s.normalizeFeatures(ctx, carReq.Features)
+	if err != nil {
+		return nil, err
+	}
+	carReq.Features = features
+	if carReq.Attributes, err = s.normalizeAttributes(ctx, carReq.Attributes); err != nil {
+		return nil, err
+	}
+	if !carReq.OtherBrandModel {
+		if carReq.Brand, carReq.Model, err = s.brands.ResolveBrandModel(ctx, carReq.Brand, carReq.Model); err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep the previous state so subscribers can be told about price drops and reopened dates
+	previousCar, err := s.store.GetCarByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if previousCar.ID == uuid.Nil {
+		return nil, errors.New("car not found")
+	}
+	if err := authorizeCarMutation(ctx, previousCar, "update"); err != nil {
+		return nil, err
+	}
+	// Owners cannot hand their car to someone else
+	if user, ok := identity.FromContext(ctx); ok && !user.IsAdmin() {
+		carReq.OwnerID = previousCar.OwnerID
+	}
+
+	// Status changes follow the lifecycle; owners cannot approve their own listings
+	if carReq.Status == "" {
+		carReq.Status = previousCar.Status
+	}
+	if carReq.Status != previousCar.Status {
+		transition := statemachine.Transition[models.CarStatus]{From: previousCar.Status, To: carReq.Status}
+		if err := models.CarStatusMachine.Validate(ctx, previousCar, transition); err != nil {
+			return nil, err
+		}
+	}
+	// A car sent back as it was read carries the derived is_available, so listed decides
+	if carReq.Listed != nil {
+		carReq.IsAvailable = *carReq.Listed
+	}
+	carReq.IsAvailable = models.CarAvailability(previousCar.Status, carReq.Status, carReq.IsAvailable)
+	var imageData []string
+	if carReq.Images, imageData, err = splitImages(carReq.Images); err != nil {
+		return nil, err
+	}
 
 	updatedCar, err := s.store.UpdateCar(ctx, id, carReq)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.queueImageUploads(ctx, updatedCar.ID, imageData); err != nil {
+		return nil, err
+	}
+
+	s.events.HandleCarEvent(ctx, models.CarEvent{
+		Type:     models.CarEventUpdated,
+		CarID:    updatedCar.ID,
+		Previous: &previousCar,
+		Current:  &updatedCar,
+	})
 
 	return &updatedCar, nil
 }
+
+// UpdateCarStatus moves a car through its lifecycle. Availability follows the status: a car
+// becomes bookable when it turns active and stops being bookable when it leaves active.
+func (s *CarService) UpdateCarStatus(ctx context.Context, id string, status models.CarStatus, byAdmin bool) (*models.Car, error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "UpdateCarStatus-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid car ID")
+	}
+
+	previousCar, err := s.store.GetCarByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if previousCar.ID == uuid.Nil {
+		return nil, errors.New("car not found")
+	}
+	if err := authorizeCarMutation(ctx, previousCar, "change the status of"); err != nil {
+		return nil, err
+	}
+
+	transition := statemachine.Transition[models.CarStatus]{From: previousCar.Status, To: status, ByAdmin: byAdmin}
+	if err := models.CarStatusMachine.Validate(ctx, previousCar, transition); err != nil {
+		return nil, err
+	}
+
+	available := models.CarAvailability(previousCar.Status, status, previousCar.Listed)
+	updatedCar, err := s.store.UpdateCarStatus(ctx, id, previousCar.Status, status, available)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.HandleCarEvent(ctx, models.CarEvent{
+		Type:     models.CarEventUpdated,
+		CarID:    updatedCar.ID,
+		Previous: &previousCar,
+		Current:  &updatedCar,
+	})
+
+	return &updatedCar, nil
+}
+
+// GetFuelPolicy retrieves a car's fuel policy
+func (s *CarService) GetFuelPolicy(ctx context.Context, carID string) (*models.CarFuelPolicy, error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "GetFuelPolicy-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, errors.New("invalid car ID")
+	}
+
+	policy, err := s.store.GetFuelPolicy(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// UpdateFuelPolicy stores a car's fuel policy; only the car's owner or an admin may do so
+func (s *CarService) UpdateFuelPolicy(ctx context.Context, userID, role, carID string, req models.CarFuelPolicyRequest) (*models.CarFuelPolicy, error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "UpdateFuelPolicy-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, errors.New("invalid car ID")
+	}
+	if err := models.ValidateCarFuelPolicyRequest(req); err != nil {
+		return nil, err
+	}
+
+	car, err := s.store.GetCarByID(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+	// Other owners see the car as missing
+	if car.ID == uuid.Nil || (role != "admin" && (car.OwnerID == nil || car.OwnerID.String() != userID)) {
+		return nil, errors.New("car not found")
+	}
+
+	policy, err := s.store.UpsertFuelPolicy(ctx, carID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// GetEligibility retrieves the rules on who may rent a car
+func (s *CarService) GetEligibility(ctx context.Context, carID string) (*models.CarEligibility, error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "GetEligibility-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, errors.New("invalid car ID")
+	}
+
+	eligibility, err := s.store.GetEligibility(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eligibility, nil
+}
+
+// UpdateEligibility stores the minimum renter age and licence years of a car; only the car's
+// owner or an admin may do so
+func (s *CarService) UpdateEligibility(ctx context.Context, userID, role, carID string, req models.CarEligibilityRequest) (*models.CarEligibility, error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "UpdateEligibility-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, errors.New("invalid car ID")
+	}
+	if err := models.ValidateCarEligibilityRequest(req); err != nil {
+		return nil, err
+	}
+
+	car, err := s.store.GetCarByID(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+	// Other owners see the car as missing
+	if car.ID == uuid.Nil || (role != "admin" && (car.OwnerID == nil || car.OwnerID.String() != userID)) {
+		return nil, errors.New("car not found")
+	}
+
+	eligibility, err := s.store.UpsertEligibility(ctx, carID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eligibility, nil
+}
+
 func (s *CarService) DeleteCar(ctx context.Context, id string) (*models.Car, error) {
 	tracer := otel.Tracer("CarService")
 	ctx, span := tracer.Start(ctx, "DeleteCar-Service")
@@ -105,6 +374,17 @@ func (s *CarService) DeleteCar(ctx context.Context, id string) (*models.Car, err
 		return nil, errors.New("car ID cannot be empty")
 	}
 
+	car, err := s.store.GetCarByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if car.ID == uuid.Nil {
+		return nil, errors.New("no car found with the given ID")
+	}
+	if err := authorizeCarMutation(ctx, car, "delete"); err != nil {
+		return nil, err
+	}
+
 	deletedCar, err := s.store.DeleteCar(ctx, id)
 	if err != nil {
 		return nil, err
@@ -113,18 +393,401 @@ func (s *CarService) DeleteCar(ctx context.Context, id string) (*models.Car, err
 	return &deletedCar, nil
 }
 
-func (s *CarService) GetAllCars(ctx context.Context) (*[]models.Car, error) {
+// ListImageUploads retrieves the images sent with a car and how far their upload got
+func (s *CarService) ListImageUploads(ctx context.Context, carID string) ([]models.CarImageUpload, error) {
 	tracer := otel.Tracer("CarService")
-	ctx, span := tracer.Start(ctx, "GetAllCars-Service")
+	ctx, span := tracer.Start(ctx, "ListImageUploads-Service")
 	defer span.End()
-	cars, err := s.store.GetAllCars(ctx)
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, errors.New("invalid car ID")
+	}
+	car, err := s.store.GetCarByID(ctx, carID)
 	if err != nil {
-		return nil, err // Return error if fetching all cars fails
+		return nil, err
 	}
-	return &cars, nil // Return the list of all cars
+	if car.ID == uuid.Nil {
+		return nil, errors.New("car not found")
+	}
+	if err := authorizeCarMutation(ctx, car, "see the image uploads of"); err != nil {
+		return nil, err
+	}
+
+	return s.imageUploads.ListCarImageUploads(ctx, carID)
+}
+
+// GetPriceHistory retrieves every change of a car's daily price, newest first
+func (s *CarService) GetPriceHistory(ctx context.Context, carID string) ([]models.CarPriceChange, error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "GetPriceHistory-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, errors.New("invalid car ID")
+	}
+	car, err := s.store.GetCarByID(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+	if car.ID == uuid.Nil {
+		return nil, errors.New("car not found")
+	}
+
+	return s.store.GetPriceHistory(ctx, carID)
+}
+
+// GetQuality scores a car's listing on its photos, description, specs and the owner's verified
+// documents, with a hint for each check that has not earned its full share
+func (s *CarService) GetQuality(ctx context.Context, carID string) (*models.CarQuality, error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "GetQuality-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, errors.New("invalid car ID")
+	}
+	car, err := s.store.GetCarByID(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+	if car.ID == uuid.Nil {
+		return nil, errors.New("car not found")
+	}
+	if err := authorizeCarMutation(ctx, car, "see the listing quality of"); err != nil {
+		return nil, err
+	}
+
+	facts, err := s.store.GetQualityFacts(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	quality := models.ScoreCarQuality(car.ID, facts)
+	return &quality, nil
+}
+
+// markPriceDrops sets the price drop of each car on a page whose price was lowered within the
+// price drop window. The badge is decoration, so failures are logged and the page is served without it.
+func (s *CarService) markPriceDrops(ctx context.Context, cars []models.Car) {
+	if len(cars) == 0 || s.priceDropWindow == 0 {
+		return
+	}
+
+	ids := make([]uuid.UUID, len(cars))
+	for i, car := range cars {
+		ids[i] = car.ID
+	}
+	drops, err := s.store.GetPriceDrops(ctx, ids, time.Now().Add(-s.priceDropWindow))
+	if err != nil {
+		log.Printf("Failed to load price drops for listing: %v", err)
+		return
+	}
+	for i := range cars {
+		if drop, ok := drops[cars[i].ID]; ok {
+			cars[i].PriceDrop = &drop
+		}
+	}
+}
+
+// splitImages separates the image URLs of a request, which are stored with the car, from base64
+// image data, which has to be uploaded first. Data that does not decode is rejected.
+func splitImages(images []string) (urls, data []string, err error) {
+	if images == nil {
+		return nil, nil, nil
+	}
+	urls = []string{}
+	for i, image := range images {
+		if models.IsImageURL(image) {
+			urls = append(urls, image)
+			continue
+		}
+		if err := models.ValidateBase64Image(image); err != nil {
+			return nil, nil, fmt.Errorf("image %d: %v", i+1, err)
+		}
+		data = append(data, image)
+	}
+	return urls, data, nil
+}
+
+// queueImageUploads keeps base64 images sent with a car for the upload job, which adds their
+// URLs to the car once Cloudinary has stored them. The car is already saved, so a failure here
+// says so rather than suggesting the request can be sent again as is.
+func (s *CarService) queueImageUploads(ctx context.Context, carID uuid.UUID, imageData []string) error {
+	if len(imageData) == 0 {
+		return nil
+	}
+	if _, err := s.imageUploads.CreateCarImageUploads(ctx, carID, imageData); err != nil {
+		log.Printf("Failed to queue %d image uploads for car %s: %v", len(imageData), carID, err)
+		return fmt.Errorf("car %s was saved but its images could not be queued for upload, send them again", carID)
+	}
+	return nil
+}
+
+// authorizeCarMutation checks that the user the context acts for may change the car: its owner
+// or an admin. Contexts without a user are refused; jobs that change cars act as a user.
+func authorizeCarMutation(ctx context.Context, car models.Car, action string) error {
+	user, ok := identity.FromContext(ctx)
+	if !ok {
+		return fmt.Errorf("authentication required to %s a car", action)
+	}
+	if user.IsAdmin() {
+		return nil
+	}
+	if car.OwnerID == nil || car.OwnerID.String() != user.ID {
+		return fmt.Errorf("only the car's owner or an admin can %s it", action)
+	}
+	return nil
+}
+
+// ListCars retrieves one cursor-paginated page of cars matching the filter, newest first
+func (s *CarService) ListCars(ctx context.Context, filter models.CarFilter, page models.PageRequest) (*models.Page[models.Car], error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "ListCars-Service")
+	defer span.End()
+
+	if len(filter.Features) > 0 {
+		taxonomy, err := s.features.Taxonomy(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// Filters may use aliases; stored features only use canonical keys
+		for i, key := range filter.Features {
+			canonical, ok := taxonomy.Canonical(key)
+			if !ok {
+				return nil, fmt.Errorf("unknown feature %s, see GET /features for the supported keys", key)
+			}
+			filter.Features[i] = canonical
+		}
+	}
+	if len(filter.Attributes) > 0 {
+		schema, err := s.attributes.Schema(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// Filter values arrive as text; stored values have their attribute's type
+		if filter.Attributes, err = schema.ParseFilter(filter.Attributes); err != nil {
+			return nil, err
+		}
+	}
+	if filter.AvailableFrom != nil && filter.AvailableTo != nil {
+		if err := models.ValidateAvailabilityPeriod(*filter.AvailableFrom, *filter.AvailableTo); err != nil {
+			return nil, err
+		}
+	}
+	if filter.Sort == models.CarSortRank {
+		if page.Cursor != nil {
+			return nil, errors.New("ranked listings are a single page and cannot be combined with cursor")
+		}
+		weights, err := s.settings.GetRankingWeights(ctx)
+		if err != nil {
+			return nil, err
+		}
+		filter.Ranking = weights
+	}
+
+	cars, err := s.store.ListCars(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+	if !filter.Explain {
+		for i := range cars {
+			cars[i].Ranking = nil
+		}
+	}
+	s.recordImpressions(ctx, cars, page.Limit)
+	s.markPriceDrops(ctx, cars)
+
+	// The whole page is checked in one query rather than one per car
+	if filter.AvailableFrom != nil && filter.AvailableTo != nil && len(cars) > 0 {
+		ids := make([]uuid.UUID, len(cars))
+		for i, car := range cars {
+			ids[i] = car.ID
+		}
+		bookable, err := s.store.GetBookableCarIDs(ctx, ids, *filter.AvailableFrom, *filter.AvailableTo)
+		if err != nil {
+			return nil, err
+		}
+		for i := range cars {
+			ok := bookable[cars[i].ID]
+			cars[i].Bookable = &ok
+		}
+	}
+
+	result := models.NewPage(cars, page, models.Car.PageCursor)
+	// Cursors follow creation order, which a ranked page does not; has_more still tells clients
+	// that lower-ranked cars exist
+	if filter.Ranking != nil {
+		result.NextCursor, result.PrevCursor = "", ""
+	}
+	// Counting scans every match, so it is only done when asked for
+	if filter.Count {
+		total, err := s.store.CountCars(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+	return &result, nil
+}
+
+// recordImpressions counts an impression for each featured car shown on a page. The extra row
+// fetched to detect another page is not shown. Failures only lose a count, so they are logged.
+func (s *CarService) recordImpressions(ctx context.Context, cars []models.Car, limit int) {
+	var shown []uuid.UUID
+	for i, car := range cars {
+		if i < limit && car.Featured {
+			shown = append(shown, car.ID)
+		}
+	}
+	if len(shown) == 0 {
+		return
+	}
+	if err := s.featured.RecordImpressions(ctx, shown); err != nil {
+		log.Println("Error recording featured impressions:", err)
+	}
+}
+
+// RecordFeaturedClick counts a visit to a car's page against its running featured placement.
+// The owner's own visits are not counted.
+func (s *CarService) RecordFeaturedClick(ctx context.Context, car models.Car, viewerID string) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "RecordFeaturedClick-Service")
+	defer span.End()
+
+	if car.OwnerID != nil && car.OwnerID.String() == viewerID {
+		return
+	}
+	if err := s.featured.RecordClick(ctx, car.ID.String()); err != nil {
+		log.Println("Error recording featured click:", err)
+	}
+}
+
+// ListPublicCars retrieves one page of the public catalog: active cars matching the search
+// criteria, projected to the fields that are safe to show unauthenticated visitors
+func (s *CarService) ListPublicCars(ctx context.Context, filter models.CarFilter, page models.PageRequest) (*models.Page[models.PublicCar], error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "ListPublicCars-Service")
+	defer span.End()
+
+	// Visitors only ever see listed cars, whatever they ask for
+	filter.Status = models.CarStatusActive
+
+	cars, err := s.store.ListCars(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+	s.markPriceDrops(ctx, cars)
+
+	carPage := models.NewPage(cars, page, models.Car.PageCursor)
+	result := models.Page[models.PublicCar]{
+		Data:       make([]models.PublicCar, 0, len(carPage.Data)),
+		NextCursor: carPage.NextCursor,
+		PrevCursor: carPage.PrevCursor,
+		HasMore:    carPage.HasMore,
+		Limit:      carPage.Limit,
+	}
+	for _, car := range carPage.Data {
+		result.Data = append(result.Data, car.Public())
+	}
+	if filter.Count {
+		total, err := s.store.CountCars(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+
+	return &result, nil
+}
+
+// GetPublicCarByID retrieves the catalog view of a single active car.
+// Returns nil if the car does not exist or is not listed publicly.
+func (s *CarService) GetPublicCarByID(ctx context.Context, id string) (*models.PublicCar, error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "GetPublicCarByID-Service")
+	defer span.End()
+
+	if id == "" {
+		return nil, errors.New("car ID cannot be empty")
+	}
+
+	car, err := s.store.GetCarByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hide missing cars and cars that are not currently listed
+	if car.ID == uuid.Nil || car.Status != models.CarStatusActive {
+		return nil, nil
+	}
+
+	publicCar := car.Public()
+	return &publicCar, nil
+}
+
+// GetCarBySlug retrieves a car by its URL slug
+func (s *CarService) GetCarBySlug(ctx context.Context, slug string) (*models.Car, error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "GetCarBySlug-Service")
+	defer span.End()
+
+	if slug == "" {
+		return nil, errors.New("car slug cannot be empty")
+	}
+
+	car, err := s.store.GetCarBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store returns an empty car if not found
+	if car.ID == uuid.Nil {
+		return nil, nil
+	}
+
+	return &car, nil
+}
+
+// GetPublicCarBySlug retrieves the catalog view of a single active car by slug.
+// Returns nil if the car does not exist or is not listed publicly.
+func (s *CarService) GetPublicCarBySlug(ctx context.Context, slug string) (*models.PublicCar, error) {
+	tracer := otel.Tracer("CarService")
+	ctx, span := tracer.Start(ctx, "GetPublicCarBySlug-Service")
+	defer span.End()
+
+	car, err := s.GetCarBySlug(ctx, slug)
+	if err != nil || car == nil || car.Status != models.CarStatusActive {
+		return nil, err
+	}
+
+	publicCar := car.Public()
+	return &publicCar, nil
 }
 
 // validateCarRequest validates the car request data
+// normalizeFeatures validates a car's features against the taxonomy and rewrites aliases to
+// their canonical keys
+func (s *CarService) normalizeFeatures(ctx context.Context, features map[string]interface{}) (map[string]interface{}, error) {
+	if len(features) == 0 {
+		return features, nil
+	}
+	taxonomy, err := s.features.Taxonomy(ctx)
+	if err != nil {
+		return nil, errors.New("failed to load the features taxonomy")
+	}
+	return taxonomy.NormalizeFeatures(features)
+}
+
+// normalizeAttributes validates a car's custom attribute values against the definitions of
+// the operator the request acts for
+func (s *CarService) normalizeAttributes(ctx context.Context, attributes map[string]interface{}) (map[string]interface{}, error) {
+	schema, err := s.attributes.Schema(ctx)
+	if err != nil {
+		return nil, errors.New("failed to load the car attribute definitions")
+	}
+	return schema.NormalizeAttributes(attributes)
+}
+
 func (s *CarService) validateCarRequest(carReq models.CarRequest) error {
 	if carReq.Name == "" {
 		return errors.New("car name is required")
@@ -150,8 +813,10 @@ func (s *CarService) validateCarRequest(carReq models.CarRequest) error {
 	if carReq.LocationCountry == "" {
 		return errors.New("location country is required")
 	}
-	if carReq.Status == "" {
-		return errors.New("car status is required")
+	if carReq.Status != "" {
+		if err := models.CarStatusMachine.CheckState(carReq.Status); err != nil {
+			return err
+		}
 	}
 
 	// Validate engine data
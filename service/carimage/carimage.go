@@ -0,0 +1,151 @@
+// Package carimage uploads the images sent with cars to Cloudinary in the background. Images
+// are kept in the database until Cloudinary has stored them, so an outage delays them instead
+// of losing them: failed attempts are retried with a growing delay, and the owner is told
+// when an image is given up on.
+package carimage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/secrets"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/service/cloudinary"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// Uploads claimed per run, how long a claim lasts before another instance may take the upload
+// over, and how often and how far apart an upload is tried. Eight attempts span about two hours.
+const (
+	uploadBatchSize   = 10
+	uploadClaimStale  = 10 * time.Minute
+	maxUploadAttempts = 8
+	firstRetryDelay   = time.Minute
+	maxRetryDelay     = time.Hour
+)
+
+// CarImageUploadService implements the CarImageUploadServiceInterface
+type CarImageUploadService struct {
+	imageUploadStore    store.CarImageUploadStoreInterface
+	carStore            store.CarStoreInterface
+	userStore           store.UserStoreInterface
+	notificationService service.NotificationServiceInterface
+	events              service.CarEventListenerInterface
+}
+
+// NewCarImageUploadService creates a new car image upload service. Uploaded images are
+// announced to events so cached lookups of the car are dropped.
+func NewCarImageUploadService(imageUploadStore store.CarImageUploadStoreInterface, carStore store.CarStoreInterface, userStore store.UserStoreInterface, notificationService service.NotificationServiceInterface, events service.CarEventListenerInterface) *CarImageUploadService {
+	return &CarImageUploadService{
+		imageUploadStore:    imageUploadStore,
+		carStore:            carStore,
+		userStore:           userStore,
+		notificationService: notificationService,
+		events:              events,
+	}
+}
+
+// ProcessPendingUploads uploads the due images and adds their URLs to the cars. Run
+// periodically by the scheduler.
+func (s *CarImageUploadService) ProcessPendingUploads(ctx context.Context) error {
+	tracer := otel.Tracer("CarImageUploadService")
+	ctx, span := tracer.Start(ctx, "ProcessPendingUploads-Service")
+	defer span.End()
+
+	uploads, err := s.imageUploadStore.ClaimDueCarImageUploads(ctx, uploadBatchSize, uploadClaimStale)
+	if err != nil {
+		return err
+	}
+	if len(uploads) == 0 {
+		return nil
+	}
+
+	// Credentials are read on every run so rotated keys take effect without a restart
+	cld, err := cloudinary.NewCloudinaryService(
+		os.Getenv("CLOUDINARY_CLOUD_NAME"),
+		secrets.Get("CLOUDINARY_API_KEY"),
+		secrets.Get("CLOUDINARY_API_SECRET"),
+		os.Getenv("CLOUDINARY_FOLDER"),
+	)
+
+	uploaded := 0
+	for _, upload := range uploads {
+		var url string
+		uploadErr := err
+		if uploadErr == nil {
+			url, uploadErr = cld.UploadBase64Image(ctx, upload.Data, "car_image.jpg")
+		}
+		if uploadErr != nil {
+			s.retryOrFail(ctx, upload, uploadErr)
+			continue
+		}
+
+		carID, err := s.imageUploadStore.AttachCarImageUpload(ctx, upload.ID, url)
+		if err != nil {
+			// Cloudinary has the image, but the car may be gone; the claim runs out and it is tried again
+			log.Printf("Failed to add uploaded image %s to car %s: %v", upload.ID, upload.CarID, err)
+			continue
+		}
+		s.events.HandleCarEvent(ctx, models.CarEvent{Type: models.CarEventUpdated, CarID: carID})
+		uploaded++
+	}
+
+	log.Printf("Uploaded %d of %d car images", uploaded, len(uploads))
+	return nil
+}
+
+// retryOrFail queues an upload whose attempt failed again, or gives up on it after the last
+// attempt and tells the owner
+func (s *CarImageUploadService) retryOrFail(ctx context.Context, upload models.CarImageUpload, uploadErr error) {
+	if upload.Attempts < maxUploadAttempts {
+		nextAttemptAt := time.Now().Add(retryDelay(upload.Attempts))
+		if err := s.imageUploadStore.RescheduleCarImageUpload(ctx, upload.ID, nextAttemptAt, uploadErr.Error()); err != nil {
+			log.Printf("Failed to reschedule image upload %s: %v", upload.ID, err)
+		}
+		return
+	}
+
+	log.Printf("Giving up on image upload %s of car %s after %d attempts: %v", upload.ID, upload.CarID, upload.Attempts, uploadErr)
+	if err := s.imageUploadStore.FailCarImageUpload(ctx, upload.ID, uploadErr.Error()); err != nil {
+		log.Printf("Failed to record failed image upload %s: %v", upload.ID, err)
+		return
+	}
+	s.notifyOwner(ctx, upload)
+}
+
+// notifyOwner tells the car's owner that an image could not be uploaded. Delivery failures are only logged.
+func (s *CarImageUploadService) notifyOwner(ctx context.Context, upload models.CarImageUpload) {
+	car, err := s.carStore.GetCarByID(ctx, upload.CarID.String())
+	if err != nil || car.ID == uuid.Nil || car.OwnerID == nil {
+		log.Printf("No owner to tell about failed image upload %s of car %s: %v", upload.ID, upload.CarID, err)
+		return
+	}
+	owner, err := s.userStore.GetUserByID(ctx, car.OwnerID.String())
+	if err != nil {
+		log.Printf("Failed to load owner %s for failed image upload %s: %v", car.OwnerID, upload.ID, err)
+		return
+	}
+
+	message := fmt.Sprintf("Image %d of the photos you added to %s on %s could not be uploaded after %d attempts, "+
+		"so it is not shown with the car.\n\nPlease add it again from the car's page.",
+		upload.Position+1, car.Name, upload.CreatedAt.UTC().Format(time.RFC1123), upload.Attempts)
+	if err := s.notificationService.Notify(ctx, owner, "CarZone image upload failed", message); err != nil {
+		log.Printf("Failed to send failed image upload notice for upload %s: %v", upload.ID, err)
+	}
+}
+
+// retryDelay is how long to wait after a failed attempt: a minute after the first, doubling
+// after each further attempt up to an hour
+func retryDelay(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
@@ -0,0 +1,85 @@
+// Package checklist manages the checklist answered when a car is handed over at checkout and
+// returned at check-in. Admins define the items to check on the exterior, the interior and the
+// fluid levels; every save is a new version, so recorded handovers keep pointing at the exact
+// items they answered.
+package checklist
+
+import (
+	"context"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// ChecklistService implements the ChecklistServiceInterface
+type ChecklistService struct {
+	checklistStore store.ChecklistStoreInterface
+}
+
+// NewChecklistService creates a new checklist service
+func NewChecklistService(checklistStore store.ChecklistStoreInterface) *ChecklistService {
+	return &ChecklistService{
+		checklistStore: checklistStore,
+	}
+}
+
+// SaveTemplate validates and stores the next version of the checklist. Checkouts from then on
+// answer it; trips already checked out are checked in against the version they started with.
+func (s *ChecklistService) SaveTemplate(ctx context.Context, req models.ChecklistTemplateRequest, createdBy string) (*models.ChecklistTemplate, error) {
+	tracer := otel.Tracer("ChecklistService")
+	ctx, span := tracer.Start(ctx, "SaveTemplate-Service")
+	defer span.End()
+
+	if err := models.ValidateChecklistTemplateRequest(req); err != nil {
+		return nil, err
+	}
+	for i := range req.Items {
+		req.Items[i].Label = strings.TrimSpace(req.Items[i].Label)
+	}
+
+	tmpl, err := s.checklistStore.AddTemplateVersion(ctx, req, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tmpl, nil
+}
+
+// GetCurrentTemplate retrieves the version new checkouts answer
+func (s *ChecklistService) GetCurrentTemplate(ctx context.Context) (*models.ChecklistTemplate, error) {
+	tracer := otel.Tracer("ChecklistService")
+	ctx, span := tracer.Start(ctx, "GetCurrentTemplate-Service")
+	defer span.End()
+
+	tmpl, err := s.checklistStore.GetCurrentTemplate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tmpl, nil
+}
+
+// GetTemplateVersion retrieves one version of the checklist
+func (s *ChecklistService) GetTemplateVersion(ctx context.Context, version int) (*models.ChecklistTemplate, error) {
+	tracer := otel.Tracer("ChecklistService")
+	ctx, span := tracer.Start(ctx, "GetTemplateVersion-Service")
+	defer span.End()
+
+	tmpl, err := s.checklistStore.GetTemplateVersion(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tmpl, nil
+}
+
+// ListTemplateVersions retrieves the history of the checklist, newest first
+func (s *ChecklistService) ListTemplateVersions(ctx context.Context) ([]models.ChecklistTemplate, error) {
+	tracer := otel.Tracer("ChecklistService")
+	ctx, span := tracer.Start(ctx, "ListTemplateVersions-Service")
+	defer span.End()
+
+	return s.checklistStore.ListTemplateVersions(ctx)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/PrateekKumar15/CarZone/dependency"
 	"github.com/cloudinary/cloudinary-go/v2"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 	"github.com/google/uuid"
@@ -56,11 +57,13 @@ func (s *CloudinaryService) UploadBase64Image(ctx context.Context, base64Data, f
 	dataURI := fmt.Sprintf("data:image/jpeg;base64,%s", base64.StdEncoding.EncodeToString(imageData))
 
 	// Upload to Cloudinary using data URI
-	uploadResult, err := s.cld.Upload.Upload(ctx, dataURI, uploader.UploadParams{
+	callCtx, done := dependency.Start(ctx, dependency.Cloudinary, "Upload")
+	uploadResult, err := s.cld.Upload.Upload(callCtx, dataURI, uploader.UploadParams{
 		PublicID:     publicID,
 		Folder:       s.folder,
 		ResourceType: "image",
 	})
+	done(err)
 
 	if err != nil {
 		return "", fmt.Errorf("failed to upload image to Cloudinary: %w", err)
@@ -80,10 +83,12 @@ func (s *CloudinaryService) DeleteImage(ctx context.Context, imageURL string) er
 	}
 
 	// Delete from Cloudinary
-	_, err := s.cld.Upload.Destroy(ctx, uploader.DestroyParams{
+	callCtx, done := dependency.Start(ctx, dependency.Cloudinary, "Destroy")
+	_, err := s.cld.Upload.Destroy(callCtx, uploader.DestroyParams{
 		PublicID:     publicID,
 		ResourceType: "image",
 	})
+	done(err)
 
 	if err != nil {
 		return fmt.Errorf("failed to delete image from Cloudinary: %w", err)
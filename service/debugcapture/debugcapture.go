@@ -0,0 +1,90 @@
+package debugcapture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// DebugCaptureService implements the DebugCaptureServiceInterface. Captures are taken by
+// middleware.DebugCapture, stored until they expire and read by admins troubleshooting a client.
+type DebugCaptureService struct {
+	debugCaptureStore store.DebugCaptureStoreInterface
+}
+
+// NewDebugCaptureService creates a new debug capture service
+func NewDebugCaptureService(debugCaptureStore store.DebugCaptureStoreInterface) *DebugCaptureService {
+	return &DebugCaptureService{
+		debugCaptureStore: debugCaptureStore,
+	}
+}
+
+// SaveCapture stores a capture taken by the debug capture middleware
+func (s *DebugCaptureService) SaveCapture(ctx context.Context, capture models.DebugCapture) error {
+	tracer := otel.Tracer("DebugCaptureService")
+	ctx, span := tracer.Start(ctx, "SaveCapture-Service")
+	defer span.End()
+
+	return s.debugCaptureStore.CreateDebugCapture(ctx, capture)
+}
+
+// ListCaptures retrieves one page of unexpired captures, newest first
+func (s *DebugCaptureService) ListCaptures(ctx context.Context, filter models.DebugCaptureFilter, page models.PageRequest) (*models.Page[models.DebugCapture], error) {
+	tracer := otel.Tracer("DebugCaptureService")
+	ctx, span := tracer.Start(ctx, "ListCaptures-Service")
+	defer span.End()
+
+	if filter.UserID != "" {
+		if _, err := uuid.Parse(filter.UserID); err != nil {
+			return nil, fmt.Errorf("invalid user_id: %v", err)
+		}
+	}
+
+	captures, err := s.debugCaptureStore.ListDebugCaptures(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.NewPage(captures, page, models.DebugCapture.PageCursor)
+	return &result, nil
+}
+
+// GetCapture retrieves a single unexpired capture
+func (s *DebugCaptureService) GetCapture(ctx context.Context, id string) (*models.DebugCapture, error) {
+	tracer := otel.Tracer("DebugCaptureService")
+	ctx, span := tracer.Start(ctx, "GetCapture-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("no debug capture found with the given ID")
+	}
+
+	capture, err := s.debugCaptureStore.GetDebugCaptureByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &capture, nil
+}
+
+// PurgeExpired deletes the captures past their expiry. Run periodically by the scheduler.
+func (s *DebugCaptureService) PurgeExpired(ctx context.Context) error {
+	tracer := otel.Tracer("DebugCaptureService")
+	ctx, span := tracer.Start(ctx, "PurgeExpired-Service")
+	defer span.End()
+
+	purged, err := s.debugCaptureStore.DeleteExpiredDebugCaptures(ctx)
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("Purged %d expired debug captures", purged)
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package dispute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// DisputeService implements the DisputeServiceInterface
+type DisputeService struct {
+	disputeStore        store.DisputeStoreInterface
+	paymentStore        store.PaymentStoreInterface
+	bookingStore        store.BookingStoreInterface
+	payoutStore         store.PayoutStoreInterface
+	userStore           store.UserStoreInterface
+	notificationService service.NotificationServiceInterface
+}
+
+// NewDisputeService creates a new dispute service
+func NewDisputeService(disputeStore store.DisputeStoreInterface, paymentStore store.PaymentStoreInterface, bookingStore store.BookingStoreInterface, payoutStore store.PayoutStoreInterface, userStore store.UserStoreInterface, notificationService service.NotificationServiceInterface) *DisputeService {
+	return &DisputeService{
+		disputeStore:        disputeStore,
+		paymentStore:        paymentStore,
+		bookingStore:        bookingStore,
+		payoutStore:         payoutStore,
+		userStore:           userStore,
+		notificationService: notificationService,
+	}
+}
+
+// HandleDisputeEvent applies a payment.dispute.* webhook event. Razorpay sends the dispute's
+// current state with every event, so the stored dispute simply follows it; events for
+// payments CarZone does not know are ignored.
+func (s *DisputeService) HandleDisputeEvent(ctx context.Context, event models.RazorpayWebhookEvent) error {
+	tracer := otel.Tracer("DisputeService")
+	ctx, span := tracer.Start(ctx, "HandleDisputeEvent-Service")
+	defer span.End()
+
+	entity := event.Payload.Dispute.Entity
+	status := models.DisputeStatus(entity.Status)
+	switch status {
+	case models.DisputeStatusOpen, models.DisputeStatusUnderReview, models.DisputeStatusWon,
+		models.DisputeStatusLost, models.DisputeStatusClosed:
+	default:
+		// Older payloads omit the status; the event name carries it
+		status = models.DisputeStatus(strings.TrimPrefix(event.Event, "payment.dispute."))
+		if status == "created" || status == "action_required" {
+			status = models.DisputeStatusOpen
+		}
+	}
+
+	dispute, err := s.disputeStore.GetDisputeByRazorpayID(ctx, entity.ID)
+	if err != nil {
+		if !strings.Contains(err.Error(), "no dispute found") {
+			return err
+		}
+		return s.openDispute(ctx, event, status)
+	}
+
+	if dispute.Status.Resolved() {
+		return nil
+	}
+	// A delivery that failed after the dispute was stored may have left it without its hold
+	if err := s.holdPayouts(ctx, dispute); err != nil {
+		return err
+	}
+	if dispute.Status == status {
+		return nil
+	}
+	return s.updateStatus(ctx, dispute, status, entity.Phase, nil)
+}
+
+// ListDisputes retrieves disputes for admins, those due for a response soonest first
+func (s *DisputeService) ListDisputes(ctx context.Context, filter models.DisputeFilter) ([]models.Dispute, error) {
+	tracer := otel.Tracer("DisputeService")
+	ctx, span := tracer.Start(ctx, "ListDisputes-Service")
+	defer span.End()
+
+	switch filter.Status {
+	case "", models.DisputeStatusOpen, models.DisputeStatusUnderReview, models.DisputeStatusWon,
+		models.DisputeStatusLost, models.DisputeStatusClosed:
+	default:
+		return nil, errors.New("status must be one of: open, under_review, won, lost, closed")
+	}
+
+	return s.disputeStore.ListDisputes(ctx, filter)
+}
+
+// GetDispute retrieves a dispute with its evidence
+func (s *DisputeService) GetDispute(ctx context.Context, id string) (*models.Dispute, error) {
+	tracer := otel.Tracer("DisputeService")
+	ctx, span := tracer.Start(ctx, "GetDispute-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid dispute ID")
+	}
+
+	dispute, err := s.disputeStore.GetDispute(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dispute, nil
+}
+
+// AddEvidence attaches a document to a dispute that is still being contested
+func (s *DisputeService) AddEvidence(ctx context.Context, id, userID string, req models.DisputeEvidenceRequest) (*models.Dispute, error) {
+	tracer := otel.Tracer("DisputeService")
+	ctx, span := tracer.Start(ctx, "AddEvidence-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid dispute ID")
+	}
+	addedBy, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	if err := models.ValidateDisputeEvidenceRequest(req); err != nil {
+		return nil, err
+	}
+	req.Description = strings.TrimSpace(req.Description)
+
+	dispute, err := s.disputeStore.GetDispute(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if dispute.Status.Resolved() {
+		return nil, errors.New("dispute is already resolved")
+	}
+
+	evidence, err := s.disputeStore.AddEvidence(ctx, dispute.ID, addedBy, req)
+	if err != nil {
+		return nil, err
+	}
+	dispute.Evidence = append(dispute.Evidence, evidence)
+
+	return &dispute, nil
+}
+
+// RecordOutcome resolves a dispute by hand, for outcomes learned outside Razorpay's webhooks,
+// and releases the payouts it held
+func (s *DisputeService) RecordOutcome(ctx context.Context, id string, req models.DisputeOutcomeRequest) (*models.Dispute, error) {
+	tracer := otel.Tracer("DisputeService")
+	ctx, span := tracer.Start(ctx, "RecordOutcome-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid dispute ID")
+	}
+	if err := models.ValidateDisputeOutcomeRequest(req); err != nil {
+		return nil, err
+	}
+
+	dispute, err := s.disputeStore.GetDispute(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if dispute.Status.Resolved() {
+		return nil, errors.New("dispute is already resolved")
+	}
+
+	notes := strings.TrimSpace(req.Notes)
+	if err := s.updateStatus(ctx, dispute, req.Status, "", &notes); err != nil {
+		return nil, err
+	}
+
+	return s.GetDispute(ctx, id)
+}
+
+// openDispute stores a dispute seen for the first time, holds the owner's payouts and alerts
+// admins. Disputes that arrive already resolved are stored without a hold.
+func (s *DisputeService) openDispute(ctx context.Context, event models.RazorpayWebhookEvent, status models.DisputeStatus) error {
+	entity := event.Payload.Dispute.Entity
+
+	payment, err := s.paymentStore.GetPaymentByRazorpayPaymentID(ctx, entity.PaymentID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no payment found") {
+			log.Printf("Ignoring %s webhook for unknown payment %s", event.Event, entity.PaymentID)
+			return nil
+		}
+		return err
+	}
+
+	booking, err := s.bookingStore.GetBookingByID(ctx, payment.BookingID.String())
+	if err != nil {
+		return err
+	}
+
+	dispute, created, err := s.disputeStore.CreateDispute(ctx, models.Dispute{
+		PaymentID:         payment.ID,
+		BookingID:         booking.ID,
+		OwnerID:           &booking.OwnerID,
+		RazorpayDisputeID: entity.ID,
+		Amount:            float64(entity.Amount) / 100,
+		ReasonCode:        entity.ReasonCode,
+		ReasonDescription: entity.ReasonDescription,
+		Phase:             entity.Phase,
+		Status:            status,
+		RespondBy:         respondBy(entity.RespondBy),
+	})
+	if err != nil {
+		return err
+	}
+	if !status.Resolved() {
+		if err := s.holdPayouts(ctx, dispute); err != nil {
+			return err
+		}
+	}
+	if !created {
+		return nil
+	}
+
+	message := fmt.Sprintf("A dispute of INR %.2f was raised on payment %s for booking %s (reason: %s).",
+		dispute.Amount, payment.ID, booking.ID, dispute.ReasonCode)
+	if status.Resolved() {
+		message += fmt.Sprintf(" Razorpay reports it as already %s.", status)
+	} else if dispute.RespondBy != nil {
+		message += " Payouts to the owner are on hold; evidence is due by " + dispute.RespondBy.Format(time.RFC1123) + "."
+	} else {
+		message += " Payouts to the owner are on hold."
+	}
+	s.notifyAdmins(ctx, "CarZone dispute opened", message)
+	return nil
+}
+
+// holdPayouts freezes the payouts of the dispute's owner; a dispute holds them only once
+func (s *DisputeService) holdPayouts(ctx context.Context, dispute models.Dispute) error {
+	if dispute.OwnerID == nil {
+		return nil
+	}
+	return s.payoutStore.CreatePayoutHold(ctx, models.PayoutHold{
+		OwnerID:   *dispute.OwnerID,
+		DisputeID: dispute.ID,
+		BookingID: dispute.BookingID,
+		Amount:    dispute.Amount,
+	})
+}
+
+// updateStatus stores a new dispute status, releases the payout hold once the dispute is
+// resolved and alerts admins about the change
+func (s *DisputeService) updateStatus(ctx context.Context, dispute models.Dispute, status models.DisputeStatus, phase string, notes *string) error {
+	// Released first: a resolved dispute is never revisited, so a failed release must fail
+	// the update for Razorpay to retry it
+	if status.Resolved() {
+		if err := s.payoutStore.ReleasePayoutHold(ctx, dispute.ID); err != nil {
+			return err
+		}
+	}
+
+	updated, err := s.disputeStore.UpdateDisputeStatus(ctx, dispute.ID, status, phase, notes)
+	if err != nil {
+		return err
+	}
+
+	s.notifyAdmins(ctx, fmt.Sprintf("CarZone dispute %s", status),
+		fmt.Sprintf("Dispute %s on payment %s (INR %.2f) is now %s.", updated.RazorpayDisputeID, updated.PaymentID, updated.Amount, status))
+	return nil
+}
+
+// notifyAdmins alerts every admin. Failures are logged so they never fail the webhook.
+func (s *DisputeService) notifyAdmins(ctx context.Context, subject, message string) {
+	admins, err := s.userStore.GetUsersByRole(ctx, "admin")
+	if err != nil {
+		log.Printf("Failed to load admins for dispute alert: %v", err)
+		return
+	}
+	for _, admin := range admins {
+		if err := s.notificationService.Notify(ctx, admin, subject, message); err != nil {
+			log.Printf("Failed to notify admin %s about dispute: %v", admin.ID, err)
+		}
+	}
+}
+
+// respondBy converts Razorpay's deadline to a time, nil when not set
+func respondBy(unix int64) *time.Time {
+	if unix <= 0 {
+		return nil
+	}
+	t := time.Unix(unix, 0)
+	return &t
+}
@@ -0,0 +1,235 @@
+package emailtemplate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// EmailTemplateService implements the EmailTemplateServiceInterface. It manages the versions of
+// e-mail templates and sends templated notifications, falling back to the built-in copy of a key
+// until an admin saves a template for it.
+type EmailTemplateService struct {
+	templateStore       store.EmailTemplateStoreInterface
+	userStore           store.UserStoreInterface
+	notificationService service.NotificationServiceInterface
+}
+
+// NewEmailTemplateService creates a new e-mail template service
+func NewEmailTemplateService(templateStore store.EmailTemplateStoreInterface, userStore store.UserStoreInterface, notificationService service.NotificationServiceInterface) *EmailTemplateService {
+	return &EmailTemplateService{
+		templateStore:       templateStore,
+		userStore:           userStore,
+		notificationService: notificationService,
+	}
+}
+
+// CreateTemplate validates and stores version 1 of a new template key
+func (s *EmailTemplateService) CreateTemplate(ctx context.Context, req models.EmailTemplateRequest, createdBy string) (*models.EmailTemplate, error) {
+	tracer := otel.Tracer("EmailTemplateService")
+	ctx, span := tracer.Start(ctx, "CreateTemplate-Service")
+	defer span.End()
+
+	if err := models.ValidateEmailTemplateKey(req.Key); err != nil {
+		return nil, err
+	}
+	if err := models.ValidateEmailTemplateRequest(req); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := s.templateStore.CreateTemplate(ctx, req, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tmpl, nil
+}
+
+// UpdateTemplate validates and stores the next version of a template key
+func (s *EmailTemplateService) UpdateTemplate(ctx context.Context, key string, req models.EmailTemplateRequest, createdBy string) (*models.EmailTemplate, error) {
+	tracer := otel.Tracer("EmailTemplateService")
+	ctx, span := tracer.Start(ctx, "UpdateTemplate-Service")
+	defer span.End()
+
+	if err := models.ValidateEmailTemplateRequest(req); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := s.templateStore.AddTemplateVersion(ctx, key, req, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tmpl, nil
+}
+
+// GetTemplate retrieves the current version of a template key
+func (s *EmailTemplateService) GetTemplate(ctx context.Context, key string) (*models.EmailTemplate, error) {
+	tracer := otel.Tracer("EmailTemplateService")
+	ctx, span := tracer.Start(ctx, "GetTemplate-Service")
+	defer span.End()
+
+	tmpl, err := s.templateStore.GetTemplate(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tmpl, nil
+}
+
+// ListTemplates retrieves the current version of every template key
+func (s *EmailTemplateService) ListTemplates(ctx context.Context) ([]models.EmailTemplate, error) {
+	tracer := otel.Tracer("EmailTemplateService")
+	ctx, span := tracer.Start(ctx, "ListTemplates-Service")
+	defer span.End()
+
+	return s.templateStore.ListTemplates(ctx)
+}
+
+// ListTemplateVersions retrieves the history of a template key
+func (s *EmailTemplateService) ListTemplateVersions(ctx context.Context, key string) ([]models.EmailTemplate, error) {
+	tracer := otel.Tracer("EmailTemplateService")
+	ctx, span := tracer.Start(ctx, "ListTemplateVersions-Service")
+	defer span.End()
+
+	versions, err := s.templateStore.ListTemplateVersions(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, errors.New("no email template found with the given key")
+	}
+
+	return versions, nil
+}
+
+// DeleteTemplate removes every version of a template key, reverting it to the built-in copy
+func (s *EmailTemplateService) DeleteTemplate(ctx context.Context, key string) error {
+	tracer := otel.Tracer("EmailTemplateService")
+	ctx, span := tracer.Start(ctx, "DeleteTemplate-Service")
+	defer span.End()
+
+	return s.templateStore.DeleteTemplate(ctx, key)
+}
+
+// PreviewTemplate renders the current template of a key, or the draft subject and body in the
+// request, with the given variables or the key's sample variables
+func (s *EmailTemplateService) PreviewTemplate(ctx context.Context, key string, req models.EmailTemplatePreviewRequest) (*models.RenderedEmail, error) {
+	tracer := otel.Tracer("EmailTemplateService")
+	ctx, span := tracer.Start(ctx, "PreviewTemplate-Service")
+	defer span.End()
+
+	tmpl, err := s.currentTemplate(ctx, key)
+	if err != nil && (req.Subject == nil || req.Body == nil) {
+		return nil, err
+	}
+
+	if req.Subject != nil || req.Body != nil {
+		tmpl.Version = 0 // The draft is not a stored version
+	}
+	if req.Subject != nil {
+		tmpl.Subject = *req.Subject
+	}
+	if req.Body != nil {
+		tmpl.Body = *req.Body
+	}
+	tmpl.Key = key
+
+	data := req.Data
+	if data == nil {
+		data = models.DefaultEmailTemplates[key].SampleData
+	}
+
+	rendered, err := render(tmpl, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rendered, nil
+}
+
+// NotifyWithTemplate renders the current template of a key for a user and notifies them. The
+// user's name is available to templates as user_name.
+func (s *EmailTemplateService) NotifyWithTemplate(ctx context.Context, userID, key string, data map[string]interface{}) error {
+	tracer := otel.Tracer("EmailTemplateService")
+	ctx, span := tracer.Start(ctx, "NotifyWithTemplate-Service")
+	defer span.End()
+
+	user, err := s.userStore.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := s.currentTemplate(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	variables := map[string]interface{}{"user_name": user.UserName}
+	for name, value := range data {
+		variables[name] = value
+	}
+
+	rendered, err := render(tmpl, variables)
+	if err != nil {
+		return fmt.Errorf("email template %s version %d: %v", key, tmpl.Version, err)
+	}
+
+	return s.notificationService.Notify(ctx, user, rendered.Subject, rendered.Body)
+}
+
+// currentTemplate returns the stored template of a key, or its built-in copy as version 0
+func (s *EmailTemplateService) currentTemplate(ctx context.Context, key string) (models.EmailTemplate, error) {
+	tmpl, err := s.templateStore.GetTemplate(ctx, key)
+	if err == nil {
+		return tmpl, nil
+	}
+	if !strings.Contains(err.Error(), "no email template found") {
+		return models.EmailTemplate{}, err
+	}
+
+	fallback, ok := models.DefaultEmailTemplates[key]
+	if !ok {
+		return models.EmailTemplate{}, err
+	}
+	return models.EmailTemplate{Key: key, Subject: fallback.Subject, Body: fallback.Body}, nil
+}
+
+// render executes a template's subject and body with the given variables
+func render(tmpl models.EmailTemplate, data map[string]interface{}) (models.RenderedEmail, error) {
+	if err := models.ValidateEmailTemplateRequest(models.EmailTemplateRequest{Subject: tmpl.Subject, Body: tmpl.Body}); err != nil {
+		return models.RenderedEmail{}, err
+	}
+
+	subject, err := execute("subject", tmpl.Subject, data)
+	if err != nil {
+		return models.RenderedEmail{}, err
+	}
+	body, err := execute("body", tmpl.Body, data)
+	if err != nil {
+		return models.RenderedEmail{}, err
+	}
+
+	return models.RenderedEmail{Key: tmpl.Key, Version: tmpl.Version, Subject: subject, Body: body}, nil
+}
+
+// execute fills in one template with the given variables
+func execute(name, text string, data map[string]interface{}) (string, error) {
+	parsed, err := models.ParseEmailTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	if err := parsed.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("cannot render %s: %v", name, err)
+	}
+	return out.String(), nil
+}
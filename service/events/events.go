@@ -0,0 +1,43 @@
+// Package events fans domain events out to every service that listens for them, so the flows
+// raising an event do not need to know who is interested.
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+)
+
+// CarEventBus implements the CarEventListenerInterface by passing each car event on to its
+// subscribers, in the order they subscribed
+type CarEventBus struct {
+	mu          sync.RWMutex
+	subscribers []service.CarEventListenerInterface
+}
+
+// NewCarEventBus creates a car event bus with the given subscribers
+func NewCarEventBus(subscribers ...service.CarEventListenerInterface) *CarEventBus {
+	return &CarEventBus{subscribers: subscribers}
+}
+
+// Subscribe adds a listener for car events raised from now on
+func (b *CarEventBus) Subscribe(listener service.CarEventListenerInterface) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers = append(b.subscribers, listener)
+}
+
+// HandleCarEvent passes the event to every subscriber. Listeners never fail the calling
+// flow, so neither does the bus.
+func (b *CarEventBus) HandleCarEvent(ctx context.Context, event models.CarEvent) {
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
+		subscriber.HandleCarEvent(ctx, event)
+	}
+}
@@ -0,0 +1,69 @@
+package favorite
+
+import (
+	"context"
+	"errors"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// FavoriteService implements the FavoriteServiceInterface
+type FavoriteService struct {
+	favoriteStore store.FavoriteStoreInterface
+}
+
+// NewFavoriteService creates a new favorite service
+func NewFavoriteService(favoriteStore store.FavoriteStoreInterface) *FavoriteService {
+	return &FavoriteService{
+		favoriteStore: favoriteStore,
+	}
+}
+
+// AddFavorite saves a car to the user's wishlist
+func (s *FavoriteService) AddFavorite(ctx context.Context, userID, carID string) (*models.Favorite, bool, error) {
+	tracer := otel.Tracer("FavoriteService")
+	ctx, span := tracer.Start(ctx, "AddFavorite-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, false, errors.New("no car found with the given ID")
+	}
+
+	favorite, created, err := s.favoriteStore.AddFavorite(ctx, userID, carID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &favorite, created, nil
+}
+
+// RemoveFavorite removes a car from the user's wishlist
+func (s *FavoriteService) RemoveFavorite(ctx context.Context, userID, carID string) error {
+	tracer := otel.Tracer("FavoriteService")
+	ctx, span := tracer.Start(ctx, "RemoveFavorite-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return errors.New("no favorite found for the given car")
+	}
+
+	return s.favoriteStore.RemoveFavorite(ctx, userID, carID)
+}
+
+// ListFavorites retrieves one page of the user's wishlist, most recently saved first
+func (s *FavoriteService) ListFavorites(ctx context.Context, userID string, page models.PageRequest) (*models.Page[models.Favorite], error) {
+	tracer := otel.Tracer("FavoriteService")
+	ctx, span := tracer.Start(ctx, "ListFavorites-Service")
+	defer span.End()
+
+	favorites, err := s.favoriteStore.ListFavorites(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.NewPage(favorites, page, models.Favorite.PageCursor)
+	return &result, nil
+}
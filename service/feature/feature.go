@@ -0,0 +1,182 @@
+package feature
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// taxonomyCacheTTL bounds how long the taxonomy is reused. Every car save validates against
+// it, so it is cached rather than read each time; entries added on another instance show up
+// within the TTL.
+const taxonomyCacheTTL = time.Minute
+
+// FeatureService implements the FeatureServiceInterface
+type FeatureService struct {
+	featureStore store.FeatureStoreInterface
+
+	mu       sync.Mutex
+	taxonomy models.FeatureTaxonomy
+	expires  time.Time
+}
+
+// NewFeatureService creates a new feature service
+func NewFeatureService(featureStore store.FeatureStoreInterface) *FeatureService {
+	return &FeatureService{
+		featureStore: featureStore,
+	}
+}
+
+// ListFeatures retrieves the taxonomy
+func (s *FeatureService) ListFeatures(ctx context.Context) ([]models.Feature, error) {
+	tracer := otel.Tracer("FeatureService")
+	ctx, span := tracer.Start(ctx, "ListFeatures-Service")
+	defer span.End()
+
+	return s.featureStore.ListFeatures(ctx)
+}
+
+// CreateFeature validates and adds a feature to the taxonomy
+func (s *FeatureService) CreateFeature(ctx context.Context, req models.FeatureRequest) (*models.Feature, error) {
+	tracer := otel.Tracer("FeatureService")
+	ctx, span := tracer.Start(ctx, "CreateFeature-Service")
+	defer span.End()
+
+	if err := models.ValidateFeatureRequest(&req); err != nil {
+		return nil, err
+	}
+	if err := s.checkKeysFree(ctx, req); err != nil {
+		return nil, err
+	}
+
+	feature, err := s.featureStore.CreateFeature(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.clearTaxonomyCache()
+
+	return &feature, nil
+}
+
+// UpdateFeature validates and replaces the name and aliases of a feature
+func (s *FeatureService) UpdateFeature(ctx context.Context, key string, req models.FeatureRequest) (*models.Feature, error) {
+	tracer := otel.Tracer("FeatureService")
+	ctx, span := tracer.Start(ctx, "UpdateFeature-Service")
+	defer span.End()
+
+	req.Key = key
+	if err := models.ValidateFeatureRequest(&req); err != nil {
+		return nil, err
+	}
+	if err := s.checkKeysFree(ctx, req); err != nil {
+		return nil, err
+	}
+
+	feature, err := s.featureStore.UpdateFeature(ctx, req.Key, req)
+	if err != nil {
+		return nil, err
+	}
+	s.clearTaxonomyCache()
+
+	return &feature, nil
+}
+
+// checkKeysFree makes sure neither the key nor the aliases of a feature already stand for
+// another feature, so every alias resolves to exactly one key
+func (s *FeatureService) checkKeysFree(ctx context.Context, req models.FeatureRequest) error {
+	features, err := s.featureStore.ListFeatures(ctx)
+	if err != nil {
+		return err
+	}
+	taxonomy := models.NewFeatureTaxonomy(features)
+
+	for _, key := range append([]string{req.Key}, req.Aliases...) {
+		if owner, ok := taxonomy[key]; ok && owner != req.Key {
+			return fmt.Errorf("%s is already used by feature %s", key, owner)
+		}
+	}
+	return nil
+}
+
+// Taxonomy retrieves the index of canonical keys and aliases, cached for taxonomyCacheTTL
+func (s *FeatureService) Taxonomy(ctx context.Context) (models.FeatureTaxonomy, error) {
+	tracer := otel.Tracer("FeatureService")
+	ctx, span := tracer.Start(ctx, "Taxonomy-Service")
+	defer span.End()
+
+	s.mu.Lock()
+	if s.taxonomy != nil && time.Now().Before(s.expires) {
+		taxonomy := s.taxonomy
+		s.mu.Unlock()
+		return taxonomy, nil
+	}
+	s.mu.Unlock()
+
+	features, err := s.featureStore.ListFeatures(ctx)
+	if err != nil {
+		return nil, err
+	}
+	taxonomy := models.NewFeatureTaxonomy(features)
+
+	s.mu.Lock()
+	s.taxonomy = taxonomy
+	s.expires = time.Now().Add(taxonomyCacheTTL)
+	s.mu.Unlock()
+
+	return taxonomy, nil
+}
+
+// NormalizeCarFeatures rewrites aliases in stored car features to their canonical keys, e.g.
+// "AC" to air_conditioning. Keys that match no feature are left alone and logged so admins can
+// add them to the taxonomy; the next run then renames them.
+func (s *FeatureService) NormalizeCarFeatures(ctx context.Context) error {
+	tracer := otel.Tracer("FeatureService")
+	ctx, span := tracer.Start(ctx, "NormalizeCarFeatures-Service")
+	defer span.End()
+
+	s.clearTaxonomyCache()
+	taxonomy, err := s.Taxonomy(ctx)
+	if err != nil {
+		return err
+	}
+
+	cars, err := s.featureStore.ListNonCanonicalCarFeatures(ctx)
+	if err != nil {
+		return err
+	}
+
+	renamed := 0
+	for _, car := range cars {
+		features, changed := taxonomy.RenameAliases(car.Features)
+		for key := range features {
+			if _, ok := taxonomy[key]; !ok {
+				log.Printf("Car %s has feature %s outside the taxonomy", car.CarID, key)
+			}
+		}
+		if !changed {
+			continue
+		}
+		if err := s.featureStore.UpdateCarFeatures(ctx, car.CarID, features); err != nil {
+			return fmt.Errorf("car %s: %v", car.CarID, err)
+		}
+		renamed++
+	}
+	if renamed > 0 {
+		log.Printf("Normalized the features of %d cars", renamed)
+	}
+
+	return nil
+}
+
+// clearTaxonomyCache drops the cached taxonomy so changes apply to the next car save
+func (s *FeatureService) clearTaxonomyCache() {
+	s.mu.Lock()
+	s.taxonomy = nil
+	s.mu.Unlock()
+}
@@ -0,0 +1,138 @@
+package featured
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// FeaturedService implements the FeaturedServiceInterface. Owners buy featured placement
+// through a Razorpay order; once the payment is verified, ranked listings boost the car for
+// the period.
+type FeaturedService struct {
+	featuredStore store.FeaturedStoreInterface
+	carStore      store.CarStoreInterface
+	payments      service.PaymentServiceInterface
+	pricePerDay   float64       // Price of one featured day in INR
+	pendingTTL    time.Duration // How long an unpaid purchase holds its period
+}
+
+// NewFeaturedService creates a new featured listing service.
+// FEATURED_PRICE_PER_DAY (default 199) prices each started day of featured placement and
+// FEATURED_PENDING_TTL (default 30m) is how long an unpaid purchase holds its period.
+func NewFeaturedService(featuredStore store.FeaturedStoreInterface, carStore store.CarStoreInterface, payments service.PaymentServiceInterface) *FeaturedService {
+	pricePerDay, err := strconv.ParseFloat(os.Getenv("FEATURED_PRICE_PER_DAY"), 64)
+	if err != nil || pricePerDay <= 0 {
+		pricePerDay = 199
+	}
+	pendingTTL, err := time.ParseDuration(os.Getenv("FEATURED_PENDING_TTL"))
+	if err != nil || pendingTTL <= 0 {
+		pendingTTL = 30 * time.Minute
+	}
+	return &FeaturedService{
+		featuredStore: featuredStore,
+		carStore:      carStore,
+		payments:      payments,
+		pricePerDay:   pricePerDay,
+		pendingTTL:    pendingTTL,
+	}
+}
+
+// PurchaseFeatured prices the period, holds it for the car and creates the Razorpay order the
+// owner pays it through. Only the car's owner or an admin may buy placement for a car.
+func (s *FeaturedService) PurchaseFeatured(ctx context.Context, userID, role, carID string, req models.FeaturedRequest) (*models.FeaturedPurchase, error) {
+	tracer := otel.Tracer("FeaturedService")
+	ctx, span := tracer.Start(ctx, "PurchaseFeatured-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, errors.New("invalid car ID")
+	}
+	if err := models.ValidateFeaturedRequest(req, time.Now()); err != nil {
+		return nil, err
+	}
+
+	car, err := s.carStore.GetCarByID(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+	// Other owners see the car as missing
+	if car.ID == uuid.Nil || car.OwnerID == nil || (role != "admin" && car.OwnerID.String() != userID) {
+		return nil, errors.New("car not found")
+	}
+	if car.Status != models.CarStatusActive {
+		return nil, errors.New("only active cars can be featured")
+	}
+
+	amount := math.Round(float64(models.FeaturedDays(req.StartDate, req.EndDate))*s.pricePerDay*100) / 100
+	featured, err := s.featuredStore.CreateFeatured(ctx, car.ID, *car.OwnerID, req, amount, s.pendingTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	id := featured.ID.String()
+	order, err := s.payments.CreateOrder(ctx, amount, fmt.Sprintf("ft_%s_%d", id[len(id)-8:], time.Now().Unix()%10000))
+	if err != nil {
+		return nil, err
+	}
+	if featured, err = s.featuredStore.SetFeaturedOrder(ctx, featured.ID, order.ID); err != nil {
+		return nil, err
+	}
+
+	return &models.FeaturedPurchase{Featured: featured, PaymentOrder: order}, nil
+}
+
+// ConfirmFeatured verifies the Razorpay payment of a pending placement and starts the boost
+func (s *FeaturedService) ConfirmFeatured(ctx context.Context, userID, role, id string, req models.PaymentVerificationRequest) (*models.FeaturedListing, error) {
+	tracer := otel.Tracer("FeaturedService")
+	ctx, span := tracer.Start(ctx, "ConfirmFeatured-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid featured listing ID")
+	}
+
+	featured, err := s.featuredStore.GetFeatured(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role != "admin" && featured.OwnerID.String() != userID {
+		return nil, errors.New("no featured listing found with the given ID")
+	}
+	if featured.RazorpayOrderID == nil || *featured.RazorpayOrderID != req.RazorpayOrderID {
+		return nil, errors.New("razorpay_order_id does not belong to this featured listing")
+	}
+
+	if !s.payments.VerifyOrderSignature(req) {
+		if _, err := s.featuredStore.SettleFeatured(ctx, featured.ID, models.FeaturedStatusFailed, req.RazorpayPaymentID); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("payment verification failed")
+	}
+
+	featured, err = s.featuredStore.SettleFeatured(ctx, featured.ID, models.FeaturedStatusPaid, req.RazorpayPaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &featured, nil
+}
+
+// GetOwnerFeatured retrieves the owner's placements with their impressions and clicks
+func (s *FeaturedService) GetOwnerFeatured(ctx context.Context, ownerID string) ([]models.FeaturedListing, error) {
+	tracer := otel.Tracer("FeaturedService")
+	ctx, span := tracer.Start(ctx, "GetOwnerFeatured-Service")
+	defer span.End()
+
+	return s.featuredStore.ListFeaturedByOwner(ctx, ownerID)
+}
@@ -0,0 +1,143 @@
+package fleet
+
+import (
+	"context"
+	"errors"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// FleetService implements the FleetServiceInterface
+type FleetService struct {
+	fleetStore store.FleetStoreInterface
+}
+
+// NewFleetService creates a new fleet service
+func NewFleetService(fleetStore store.FleetStoreInterface) *FleetService {
+	return &FleetService{
+		fleetStore: fleetStore,
+	}
+}
+
+// CreateFleet validates and stores a new fleet
+func (s *FleetService) CreateFleet(ctx context.Context, ownerID string, req models.FleetRequest) (*models.Fleet, error) {
+	tracer := otel.Tracer("FleetService")
+	ctx, span := tracer.Start(ctx, "CreateFleet-Service")
+	defer span.End()
+
+	if err := models.ValidateFleetRequest(req); err != nil {
+		return nil, err
+	}
+
+	fleet, err := s.fleetStore.CreateFleet(ctx, ownerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fleet, nil
+}
+
+// GetFleet retrieves one of the owner's fleets
+func (s *FleetService) GetFleet(ctx context.Context, ownerID, id string) (*models.Fleet, error) {
+	tracer := otel.Tracer("FleetService")
+	ctx, span := tracer.Start(ctx, "GetFleet-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid fleet ID")
+	}
+
+	fleet, err := s.fleetStore.GetFleet(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fleet, nil
+}
+
+// GetFleets retrieves the owner's fleets
+func (s *FleetService) GetFleets(ctx context.Context, ownerID string) ([]models.Fleet, error) {
+	tracer := otel.Tracer("FleetService")
+	ctx, span := tracer.Start(ctx, "GetFleets-Service")
+	defer span.End()
+
+	return s.fleetStore.ListFleets(ctx, ownerID)
+}
+
+// UpdateFleet validates and replaces the name and settings of a fleet
+func (s *FleetService) UpdateFleet(ctx context.Context, ownerID, id string, req models.FleetRequest) (*models.Fleet, error) {
+	tracer := otel.Tracer("FleetService")
+	ctx, span := tracer.Start(ctx, "UpdateFleet-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid fleet ID")
+	}
+	if err := models.ValidateFleetRequest(req); err != nil {
+		return nil, err
+	}
+
+	fleet, err := s.fleetStore.UpdateFleet(ctx, id, ownerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fleet, nil
+}
+
+// DeleteFleet removes a fleet
+func (s *FleetService) DeleteFleet(ctx context.Context, ownerID, id string) error {
+	tracer := otel.Tracer("FleetService")
+	ctx, span := tracer.Start(ctx, "DeleteFleet-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return errors.New("invalid fleet ID")
+	}
+
+	return s.fleetStore.DeleteFleet(ctx, id, ownerID)
+}
+
+// AddCar moves one of the owner's cars into a fleet
+func (s *FleetService) AddCar(ctx context.Context, ownerID, id, carID string) (*models.Fleet, error) {
+	tracer := otel.Tracer("FleetService")
+	ctx, span := tracer.Start(ctx, "AddCar-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid fleet ID")
+	}
+	if parsed, err := uuid.Parse(carID); err != nil || parsed == uuid.Nil {
+		return nil, errors.New("car_id is required")
+	}
+
+	if err := s.fleetStore.AddCar(ctx, id, ownerID, carID); err != nil {
+		return nil, err
+	}
+
+	fleet, err := s.fleetStore.GetFleet(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fleet, nil
+}
+
+// RemoveCar takes a car out of a fleet
+func (s *FleetService) RemoveCar(ctx context.Context, ownerID, id, carID string) error {
+	tracer := otel.Tracer("FleetService")
+	ctx, span := tracer.Start(ctx, "RemoveCar-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return errors.New("invalid fleet ID")
+	}
+	if _, err := uuid.Parse(carID); err != nil {
+		return errors.New("invalid car ID")
+	}
+
+	return s.fleetStore.RemoveCar(ctx, id, ownerID, carID)
+}
@@ -0,0 +1,106 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"go.opentelemetry.io/otel"
+)
+
+// GeocodingService implements the GeocoderInterface against a Nominatim-compatible search API
+type GeocodingService struct {
+	baseURL      string
+	countryCodes string
+	userAgent    string
+	client       *http.Client
+}
+
+// NewGeocodingService creates a geocoder from GEOCODER_URL, GEOCODER_COUNTRY_CODES and GEOCODER_USER_AGENT
+func NewGeocodingService() *GeocodingService {
+	baseURL := os.Getenv("GEOCODER_URL")
+	if baseURL == "" {
+		baseURL = "https://nominatim.openstreetmap.org"
+	}
+	countryCodes := os.Getenv("GEOCODER_COUNTRY_CODES")
+	if countryCodes == "" {
+		countryCodes = "in"
+	}
+	// Nominatim's usage policy requires an identifying user agent
+	userAgent := os.Getenv("GEOCODER_USER_AGENT")
+	if userAgent == "" {
+		userAgent = "CarZone/1.0"
+	}
+
+	return &GeocodingService{
+		baseURL:      baseURL,
+		countryCodes: countryCodes,
+		userAgent:    userAgent,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Geocode resolves a free-form address to its best matching coordinates
+func (s *GeocodingService) Geocode(ctx context.Context, address string) (models.GeocodedAddress, error) {
+	tracer := otel.Tracer("GeocodingService")
+	ctx, span := tracer.Start(ctx, "Geocode-Service")
+	defer span.End()
+
+	params := url.Values{}
+	params.Set("q", address)
+	params.Set("format", "jsonv2")
+	params.Set("limit", "1")
+	params.Set("countrycodes", s.countryCodes)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return models.GeocodedAddress{}, err
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return models.GeocodedAddress{}, fmt.Errorf("geocoding request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return models.GeocodedAddress{}, fmt.Errorf("geocoding request failed with status %d", resp.StatusCode)
+	}
+
+	// Nominatim returns coordinates as strings
+	var results []struct {
+		DisplayName string `json:"display_name"`
+		Lat         string `json:"lat"`
+		Lon         string `json:"lon"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return models.GeocodedAddress{}, fmt.Errorf("failed to decode geocoding response: %v", err)
+	}
+	if len(results) == 0 {
+		return models.GeocodedAddress{}, errors.New("delivery address could not be found")
+	}
+
+	lat, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return models.GeocodedAddress{}, fmt.Errorf("invalid latitude in geocoding response: %v", err)
+	}
+	lon, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return models.GeocodedAddress{}, fmt.Errorf("invalid longitude in geocoding response: %v", err)
+	}
+
+	return models.GeocodedAddress{
+		FormattedAddress: results[0].DisplayName,
+		Latitude:         lat,
+		Longitude:        lon,
+	}, nil
+}
@@ -0,0 +1,305 @@
+// Package incident handles accidents, thefts and other mishaps reported during a rental. The
+// booking's customer or the car's owner reports an incident while the booking is active, with
+// photos and any police report number; the other party and admins are notified. Insurance and
+// police documents are attached as the incident is worked, and its status is tracked until it
+// is resolved or closed.
+package incident
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/statemachine"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// clockSkew is how far in the future an incident's occurred_at may be, for reporters whose
+// device clock runs ahead
+const clockSkew = 5 * time.Minute
+
+// IncidentService implements the IncidentServiceInterface
+type IncidentService struct {
+	incidentStore       store.IncidentStoreInterface
+	bookingStore        store.BookingStoreInterface
+	userStore           store.UserStoreInterface
+	notificationService service.NotificationServiceInterface
+}
+
+// NewIncidentService creates a new incident service
+func NewIncidentService(incidentStore store.IncidentStoreInterface, bookingStore store.BookingStoreInterface, userStore store.UserStoreInterface, notificationService service.NotificationServiceInterface) *IncidentService {
+	return &IncidentService{
+		incidentStore:       incidentStore,
+		bookingStore:        bookingStore,
+		userStore:           userStore,
+		notificationService: notificationService,
+	}
+}
+
+// ReportIncident records an incident against a booking that is under way: in progress, or
+// confirmed and past its start. The customer and owner notify each other through it, and
+// admins are alerted to triage it.
+func (s *IncidentService) ReportIncident(ctx context.Context, userID, role, bookingID string, req models.IncidentRequest) (*models.Incident, error) {
+	tracer := otel.Tracer("IncidentService")
+	ctx, span := tracer.Start(ctx, "ReportIncident-Service")
+	defer span.End()
+
+	reporter, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	if err := models.ValidateIncidentRequest(req); err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingStore.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	// Admins act on incidents but do not report them; the report is the party's own account
+	if booking.CustomerID != reporter && booking.OwnerID != reporter {
+		if role == "admin" {
+			return nil, errors.New("incidents must be reported by the booking's customer or the car's owner")
+		}
+		return nil, errors.New("no booking found with the given ID")
+	}
+
+	now := time.Now()
+	if !activeBooking(booking, now) {
+		return nil, errors.New("incidents can only be reported while a booking is in progress")
+	}
+	if req.OccurredAt.Before(booking.StartDate) || req.OccurredAt.After(now.Add(clockSkew)) {
+		return nil, errors.New("occurred_at must be between the booking's start and now")
+	}
+
+	photos := make([]string, 0, len(req.PhotoURLs))
+	for _, photo := range req.PhotoURLs {
+		photos = append(photos, strings.TrimSpace(photo))
+	}
+
+	incident, err := s.incidentStore.CreateIncident(ctx, models.Incident{
+		BookingID:          booking.ID,
+		CarID:              booking.CarID,
+		CustomerID:         booking.CustomerID,
+		OwnerID:            booking.OwnerID,
+		ReportedBy:         reporter,
+		Kind:               req.Kind,
+		Description:        strings.TrimSpace(req.Description),
+		OccurredAt:         req.OccurredAt,
+		Location:           strings.TrimSpace(req.Location),
+		PoliceReportNumber: strings.TrimSpace(req.PoliceReportNumber),
+		PhotoURLs:          photos,
+	})
+	if err != nil {
+		return nil, err
+	}
+	incident.Documents = []models.IncidentDocument{}
+
+	message := fmt.Sprintf("A %s was reported on booking %s, occurring %s: %s",
+		incident.Kind, booking.ID, incident.OccurredAt.Format(time.RFC1123), incident.Description)
+	if incident.PoliceReportNumber != "" {
+		message += fmt.Sprintf(" Police report number: %s.", incident.PoliceReportNumber)
+	}
+	// The reporter knows; the other party is told
+	other := booking.OwnerID
+	if reporter == booking.OwnerID {
+		other = booking.CustomerID
+	}
+	s.notifyUser(ctx, other, "CarZone incident reported", message)
+	s.notifyAdmins(ctx, "CarZone incident reported", message)
+
+	return &incident, nil
+}
+
+// GetBookingIncidents retrieves the incidents of a booking for its customer, owner or an admin
+func (s *IncidentService) GetBookingIncidents(ctx context.Context, userID, role, bookingID string) ([]models.Incident, error) {
+	tracer := otel.Tracer("IncidentService")
+	ctx, span := tracer.Start(ctx, "GetBookingIncidents-Service")
+	defer span.End()
+
+	booking, err := s.bookingStore.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if role != "admin" && booking.CustomerID.String() != userID && booking.OwnerID.String() != userID {
+		return nil, errors.New("no booking found with the given ID")
+	}
+
+	return s.incidentStore.GetIncidentsByBookingID(ctx, bookingID)
+}
+
+// GetIncident retrieves an incident with its documents for the booking's customer, owner or an admin
+func (s *IncidentService) GetIncident(ctx context.Context, userID, role, id string) (*models.Incident, error) {
+	tracer := otel.Tracer("IncidentService")
+	ctx, span := tracer.Start(ctx, "GetIncident-Service")
+	defer span.End()
+
+	incident, err := s.authorizeIncident(ctx, userID, role, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &incident, nil
+}
+
+// AddIncidentDocument attaches a document to an incident that is still open
+func (s *IncidentService) AddIncidentDocument(ctx context.Context, userID, role, id string, req models.IncidentDocumentRequest) (*models.Incident, error) {
+	tracer := otel.Tracer("IncidentService")
+	ctx, span := tracer.Start(ctx, "AddIncidentDocument-Service")
+	defer span.End()
+
+	addedBy, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	if err := models.ValidateIncidentDocumentRequest(req); err != nil {
+		return nil, err
+	}
+	req.Description = strings.TrimSpace(req.Description)
+
+	incident, err := s.authorizeIncident(ctx, userID, role, id)
+	if err != nil {
+		return nil, err
+	}
+	if models.IncidentStatusMachine.Terminal(incident.Status) {
+		return nil, errors.New("incident is already " + string(incident.Status))
+	}
+
+	document, err := s.incidentStore.AddDocument(ctx, incident.ID, addedBy, req)
+	if err != nil {
+		return nil, err
+	}
+	incident.Documents = append(incident.Documents, document)
+
+	return &incident, nil
+}
+
+// UpdateIncidentStatus moves an incident on for the car's owner or an admin, as
+// IncidentStatusMachine allows, and tells the customer and owner
+func (s *IncidentService) UpdateIncidentStatus(ctx context.Context, userID, role, id string, req models.IncidentStatusRequest) (*models.Incident, error) {
+	tracer := otel.Tracer("IncidentService")
+	ctx, span := tracer.Start(ctx, "UpdateIncidentStatus-Service")
+	defer span.End()
+
+	if err := models.ValidateIncidentStatusRequest(req); err != nil {
+		return nil, err
+	}
+
+	incident, err := s.authorizeIncident(ctx, userID, role, id)
+	if err != nil {
+		return nil, err
+	}
+	if role != "admin" && incident.OwnerID.String() != userID {
+		return nil, errors.New("only the car's owner or an admin can update an incident's status")
+	}
+
+	transition := statemachine.Transition[models.IncidentStatus]{From: incident.Status, To: req.Status, ByAdmin: role == "admin"}
+	if err := models.IncidentStatusMachine.Validate(ctx, incident, transition); err != nil {
+		return nil, err
+	}
+
+	var claimNumber, notes *string
+	if number := strings.TrimSpace(req.InsuranceClaimNumber); number != "" {
+		claimNumber = &number
+	}
+	if text := strings.TrimSpace(req.Notes); text != "" {
+		notes = &text
+	}
+
+	updated, err := s.incidentStore.UpdateIncidentStatus(ctx, incident.ID, incident.Status, req.Status, claimNumber, notes)
+	if err != nil {
+		return nil, err
+	}
+	updated.Documents = incident.Documents
+
+	message := fmt.Sprintf("The %s reported on booking %s is now %s.", updated.Kind, updated.BookingID, updated.Status)
+	if updated.Status == models.IncidentStatusClaimFiled {
+		message += fmt.Sprintf(" Insurance claim number: %s.", updated.InsuranceClaimNumber)
+	}
+	if notes != nil {
+		message += " " + *notes
+	}
+	subject := fmt.Sprintf("CarZone incident %s", strings.ReplaceAll(string(updated.Status), "_", " "))
+	s.notifyUser(ctx, updated.CustomerID, subject, message)
+	s.notifyUser(ctx, updated.OwnerID, subject, message)
+
+	return &updated, nil
+}
+
+// ListIncidents retrieves incidents for admins, the longest outstanding first
+func (s *IncidentService) ListIncidents(ctx context.Context, filter models.IncidentFilter) ([]models.Incident, error) {
+	tracer := otel.Tracer("IncidentService")
+	ctx, span := tracer.Start(ctx, "ListIncidents-Service")
+	defer span.End()
+
+	if filter.Status != "" {
+		if err := models.IncidentStatusMachine.CheckState(filter.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.incidentStore.ListIncidents(ctx, filter)
+}
+
+// authorizeIncident loads an incident for its booking's customer or owner, or an admin.
+// Anyone else is told it does not exist.
+func (s *IncidentService) authorizeIncident(ctx context.Context, userID, role, id string) (models.Incident, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Incident{}, errors.New("invalid incident ID")
+	}
+
+	incident, err := s.incidentStore.GetIncident(ctx, id)
+	if err != nil {
+		return models.Incident{}, err
+	}
+	if role == "admin" || incident.CustomerID.String() == userID || incident.OwnerID.String() == userID {
+		return incident, nil
+	}
+	return models.Incident{}, errors.New("no incident found with the given ID")
+}
+
+// notifyUser alerts one user. Failures are logged so they never fail the report.
+func (s *IncidentService) notifyUser(ctx context.Context, userID uuid.UUID, subject, message string) {
+	user, err := s.userStore.GetUserByID(ctx, userID.String())
+	if err != nil {
+		log.Printf("Failed to load user %s for incident notification: %v", userID, err)
+		return
+	}
+	if err := s.notificationService.Notify(ctx, user, subject, message); err != nil {
+		log.Printf("Failed to notify user %s about incident: %v", userID, err)
+	}
+}
+
+// notifyAdmins alerts every admin. Failures are logged so they never fail the report.
+func (s *IncidentService) notifyAdmins(ctx context.Context, subject, message string) {
+	admins, err := s.userStore.GetUsersByRole(ctx, "admin")
+	if err != nil {
+		log.Printf("Failed to load admins for incident alert: %v", err)
+		return
+	}
+	for _, admin := range admins {
+		if err := s.notificationService.Notify(ctx, admin, subject, message); err != nil {
+			log.Printf("Failed to notify admin %s about incident: %v", admin.ID, err)
+		}
+	}
+}
+
+// activeBooking reports whether a booking is under way: the car was handed over at checkout,
+// or the booking is confirmed and its rental period has started without a checkout
+func activeBooking(booking models.Booking, now time.Time) bool {
+	switch booking.Status {
+	case models.BookingStatusInProgress:
+		return true
+	case models.BookingStatusConfirmed:
+		return !now.Before(booking.StartDate) && !now.After(booking.EndDate)
+	default:
+		return false
+	}
+}
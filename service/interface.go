@@ -4,10 +4,14 @@
 // They encapsulate domain logic, validation, and business rules.
 package service
 
+//go:generate mockgen -source=interface.go -destination=mocks/mocks.go -package=mocks
+
 import (
 	"context"
+	"time"
 
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
 )
 
 // CarServiceInterface defines the contract for car business logic operations.
@@ -29,16 +33,19 @@ type CarServiceInterface interface {
 	// Applies business rules for data filtering and presentation logic.
 	// Parameters:
 	//   - ctx: Request context for cancellation and timeout
-	//   - brand: Brand name to filter by (case-sensitive)
+	//   - brand: Brand name to filter by (case-insensitive)
+	//   - fuzzy: Also match similar brand names to tolerate typos
 	// Returns:
-	//   - *[]models.Car: Pointer to slice of car records matching the criteria
+	//   - *[]models.Car: Pointer to slice of car records matching the criteria, best matches first
 	//   - error: Business logic error or data access error
-	GetCarByBrand(ctx context.Context, brand string) (*[]models.Car, error)
+	GetCarByBrand(ctx context.Context, brand string, fuzzy bool) (*[]models.Car, error)
 
 	// CreateCar creates a new car record with full business validation.
 	// Validates input data, enforces business rules, and coordinates with data persistence.
+	// Images sent as base64 data are queued for upload and added to the car once uploaded.
+	// The car belongs to the user the context acts for; only admins may name another owner.
 	// Parameters:
-	//   - ctx: Request context for transaction management
+	//   - ctx: Request context carrying the authenticated user
 	//   - carReq: Car creation request with all required fields
 	// Returns:
 	//   - *models.Car: Pointer to the created car record with generated fields
@@ -47,25 +54,164 @@ type CarServiceInterface interface {
 
 	// UpdateCar modifies an existing car record with business validation.
 	// Validates changes against business rules and ensures data consistency.
+	// Only the car's owner or an admin may update it, and only admins may change its owner.
+	// Images sent as base64 data are queued for upload and added to the car once uploaded.
 	// Parameters:
-	//   - ctx: Request context for transaction management
+	//   - ctx: Request context for transaction management; carries the acting user
 	//   - id: Unique identifier of the car to update
 	//   - carReq: Updated car data with new field values
 	// Returns:
 	//   - *models.Car: Pointer to the updated car record
-	//   - error: Validation error, business rule violation, or update failure
+	//   - error: Not the owner, validation error, business rule violation, or update failure
 	UpdateCar(ctx context.Context, id string, carReq models.CarRequest) (*models.Car, error)
 
+	// UpdateCarStatus moves a car through its lifecycle and syncs its availability.
+	// Only the car's owner or an admin may change it.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout; carries the acting user
+	//   - id: Unique identifier of the car
+	//   - status: New status; must be an allowed transition from the current one
+	//   - byAdmin: Whether an admin requested it; only admins approve or reject cars under review
+	// Returns:
+	//   - *models.Car: The updated car, nil if not found
+	//   - error: Not the owner, invalid transition, concurrent change or data access error
+	UpdateCarStatus(ctx context.Context, id string, status models.CarStatus, byAdmin bool) (*models.Car, error)
+
+	// ListImageUploads retrieves the images sent with a car and how far their upload got.
+	// Only the car's owner or an admin may see them.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout; carries the acting user
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - []models.CarImageUpload: Uploads, in the order the images were sent
+	//   - error: Not the owner, car not found or data access error
+	ListImageUploads(ctx context.Context, carID string) ([]models.CarImageUpload, error)
+
+	// GetPriceHistory retrieves every change of a car's daily price.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - []models.CarPriceChange: Changes with who made them, newest first
+	//   - error: Car not found or data access error
+	GetPriceHistory(ctx context.Context, carID string) ([]models.CarPriceChange, error)
+
+	// GetQuality scores a car's listing and returns hints to improve it.
+	// Only the car's owner or an admin may see it.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout; carries the acting user
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - *models.CarQuality: Score between 0 and 1 with each check and its hint
+	//   - error: Not the owner, car not found or data access error
+	GetQuality(ctx context.Context, carID string) (*models.CarQuality, error)
+
+	// GetFuelPolicy retrieves a car's fuel policy.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - *models.CarFuelPolicy: The car's fuel settings
+	//   - error: Error if no policy is set for the car or data access fails
+	GetFuelPolicy(ctx context.Context, carID string) (*models.CarFuelPolicy, error)
+
+	// UpdateFuelPolicy validates and stores a car's fuel policy.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID, role: Caller; only the car's owner or an admin may change the policy
+	//   - carID: Unique identifier of the car
+	//   - req: New fuel settings
+	// Returns:
+	//   - *models.CarFuelPolicy: The stored fuel settings
+	//   - error: Validation error, unknown car or data access error
+	UpdateFuelPolicy(ctx context.Context, userID, role, carID string, req models.CarFuelPolicyRequest) (*models.CarFuelPolicy, error)
+
+	// GetEligibility retrieves the rules on who may rent a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - *models.CarEligibility: The car's rules; zero minimums when none are set
+	//   - error: Invalid ID or data access error
+	GetEligibility(ctx context.Context, carID string) (*models.CarEligibility, error)
+
+	// UpdateEligibility validates and stores the minimum renter age and licence years of a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID, role: Caller; only the car's owner or an admin may change the rules
+	//   - carID: Unique identifier of the car
+	//   - req: New minimums; zero lifts a rule
+	// Returns:
+	//   - *models.CarEligibility: The stored rules
+	//   - error: Validation error, unknown car or data access error
+	UpdateEligibility(ctx context.Context, userID, role, carID string, req models.CarEligibilityRequest) (*models.CarEligibility, error)
+
 	// DeleteCar removes a car record with business rule validation.
-	// May enforce cascade rules, audit logging, and referential integrity checks.
+	// Only the car's owner or an admin may delete it, and cars with bookings cannot be deleted.
 	// Parameters:
-	//   - ctx: Request context for transaction management
+	//   - ctx: Request context for transaction management; carries the acting user
 	//   - id: Unique identifier of the car to delete
 	// Returns:
 	//   - *models.Car: Pointer to the deleted car record (for audit purposes)
-	//   - error: Business rule violation or deletion failure
+	//   - error: Not the owner, business rule violation or deletion failure
 	DeleteCar(ctx context.Context, id string) (*models.Car, error)
-	GetAllCars(ctx context.Context) (*[]models.Car, error)
+
+	// ListCars retrieves one cursor-paginated page of cars, newest first. Sorted by rank, it
+	// retrieves a single page of the highest-scoring cars under the operator's ranking weights.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional restrictions parsed from the request, e.g. required features, and the sort
+	//   - page: Page size and optional cursor parsed from the request
+	// Returns:
+	//   - *models.Page[models.Car]: Page of cars with the cursor for the next page, and the total
+	//     number of matching cars when filter.Count is set
+	//   - error: Business logic error or data access error
+	ListCars(ctx context.Context, filter models.CarFilter, page models.PageRequest) (*models.Page[models.Car], error)
+
+	// RecordFeaturedClick counts a visit to a car's page against its running featured placement.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - car: The car whose page was opened
+	//   - viewerID: ID of the authenticated user; the owner's own visits are not counted
+	RecordFeaturedClick(ctx context.Context, car models.Car, viewerID string)
+
+	// ListPublicCars retrieves one page of the unauthenticated catalog.
+	// Only active cars are listed and owner details are stripped.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Search criteria parsed with models.ParseCarSearch; the status is always active
+	//   - page: Page size and optional cursor parsed from the request
+	// Returns:
+	//   - *models.Page[models.PublicCar]: Page of catalog entries with the cursor for the next page
+	//   - error: Data access error
+	ListPublicCars(ctx context.Context, filter models.CarFilter, page models.PageRequest) (*models.Page[models.PublicCar], error)
+
+	// GetPublicCarByID retrieves the catalog view of a single active car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the car (UUID string format)
+	// Returns:
+	//   - *models.PublicCar: Catalog entry if the car is publicly listed, nil otherwise
+	//   - error: Validation error or data access error
+	GetPublicCarByID(ctx context.Context, id string) (*models.PublicCar, error)
+
+	// GetCarBySlug retrieves a car by its URL slug.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - slug: Unique URL-friendly identifier of the car
+	// Returns:
+	//   - *models.Car: Pointer to the car record if found, nil if not found
+	//   - error: Validation error or data access error
+	GetCarBySlug(ctx context.Context, slug string) (*models.Car, error)
+
+	// GetPublicCarBySlug retrieves the catalog view of a single active car by slug.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - slug: Unique URL-friendly identifier of the car
+	// Returns:
+	//   - *models.PublicCar: Catalog entry if the car is publicly listed, nil otherwise
+	//   - error: Validation error or data access error
+	GetPublicCarBySlug(ctx context.Context, slug string) (*models.PublicCar, error)
 }
 
 // AuthServiceInterface defines the contract for user authentication and management.
@@ -80,7 +226,8 @@ type AuthServiceInterface interface {
 	//   - ctx: Request context for transaction management
 	//   - userReq: User registration request with necessary fields (email, password, username, phone, role)
 	// Returns:
-	//   - error: Validation error, business rule violation, or data access error
+	//   - error: Validation error, *models.EmailRejectedError for disposable or undeliverable addresses,
+	//     business rule violation, or data access error
 	RegisterUser(ctx context.Context, userReq models.UserRequest) error
 
 	// LoginUser authenticates a user with email and password credentials.
@@ -90,8 +237,34 @@ type AuthServiceInterface interface {
 	//   - loginReq: Login request with email and password
 	// Returns:
 	//   - models.User: Complete user record including phone, role, and profile_data
-	//   - error: Authentication error or data access error
+	//   - error: Authentication error, *models.LoginThrottledError after too many failures, or data access error
 	LoginUser(ctx context.Context, loginReq models.LoginRequest) (models.User, error)
+
+	// RecordFailedLogin counts a login that failed after the password was accepted, e.g. a
+	// wrong two-factor code, towards the lockout of the e-mail address and client IP.
+	// Parameters:
+	//   - ctx: Request context carrying the client IP
+	//   - email: E-mail address the login was for
+	// Returns:
+	//   - error: *models.LoginThrottledError when this failure locked login out, otherwise nil
+	RecordFailedLogin(ctx context.Context, email string) error
+
+	// ClearFailedLogins forgets the failed logins of an e-mail address after a complete login.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - email: E-mail address that logged in
+	ClearFailedLogins(ctx context.Context, email string)
+
+	// SetPassword replaces a user's password, e.g. when an operator rotates it for them.
+	// The password must meet the rules applied at registration.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - email: E-mail address of the user
+	//   - password: New password
+	// Returns:
+	//   - uuid.UUID: ID of the updated user
+	//   - error: Validation error, unknown user or data access error
+	SetPassword(ctx context.Context, email, password string) (uuid.UUID, error)
 }
 
 // BookingServiceInterface defines the contract for booking business logic operations.
@@ -144,6 +317,28 @@ type BookingServiceInterface interface {
 	//   - error: Validation error, business rule violation, or data access error
 	CreateBooking(ctx context.Context, bookingReq models.BookingRequest) (*models.Booking, error)
 
+	// QuoteBooking prices a prospective booking without creating it, validating any
+	// pickup/drop-off locations and geocoding the delivery address. The quote carries a
+	// signed token that locks its price for CreateBooking until it expires.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingReq: Car, dates and the chosen locations or delivery address
+	// Returns:
+	//   - *models.BookingQuote: Itemised price and the resolved delivery address
+	//   - error: Validation error, unknown car, undeliverable address or data access error
+	QuoteBooking(ctx context.Context, bookingReq models.BookingRequest) (*models.BookingQuote, error)
+
+	// CheckConflicts reports whether a car can be booked for a period and every reason it
+	// cannot: the car being unavailable, the lead time, blackouts and existing rentals.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Car to check
+	//   - start, end: Requested rental period
+	// Returns:
+	//   - *models.BookingAvailability: Whether the period is bookable and the conflicts found
+	//   - error: Invalid period, unknown car or data access error
+	CheckConflicts(ctx context.Context, carID string, start, end time.Time) (*models.BookingAvailability, error)
+
 	// UpdateBookingStatus modifies booking status with business validation.
 	// Validates status transitions and enforces business rules.
 	// Parameters:
@@ -164,13 +359,246 @@ type BookingServiceInterface interface {
 	//   - error: Business rule violation or deletion failure
 	DeleteBooking(ctx context.Context, id string) (*models.Booking, error)
 
-	// GetAllBookings retrieves all booking records with business filtering.
+	// ListBookings retrieves one cursor-paginated page of bookings matching the filter.
 	// Parameters:
 	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional customer, car, owner, or status restriction
+	//   - page: Page size and optional cursor parsed from the request
 	// Returns:
-	//   - *[]models.Booking: Pointer to slice of all booking records
+	//   - *models.Page[models.Booking]: Page of bookings with the cursor for the next page
 	//   - error: Business logic error or data access error
-	GetAllBookings(ctx context.Context) (*[]models.Booking, error)
+	ListBookings(ctx context.Context, filter models.BookingFilter, page models.PageRequest) (*models.Page[models.Booking], error)
+
+	// RecordInspection records the checkout or check-in of a booking.
+	// Readings left out of the request are auto-filled from recent telemetry.
+	// Parameters:
+	//   - ctx: Request context for cancellation, timeout, and request scoping
+	//   - bookingID: Unique identifier of the booking
+	//   - userID: Authenticated user; must own the car unless role is admin
+	//   - role: Role of the authenticated user
+	//   - kind: Checkout (confirmed bookings, starts the trip) or check-in (after checkout, completes it
+	//     and charges a late fee for returns after the grace period)
+	//   - req: Manual readings, notes and checklist answers
+	// Returns:
+	//   - *models.BookingInspection: Recorded inspection with reading sources
+	//   - error: Error if the booking is missing, not in a valid state, or storage fails
+	RecordInspection(ctx context.Context, bookingID, userID, role string, kind models.InspectionKind, req models.InspectionRequest) (*models.BookingInspection, error)
+
+	// GetInspections retrieves the checkout and check-in records of a booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation, timeout, and request scoping
+	//   - bookingID: Unique identifier of the booking
+	// Returns:
+	//   - []models.BookingInspection: Records, oldest first
+	//   - error: Error if database operation fails
+	GetInspections(ctx context.Context, bookingID string) ([]models.BookingInspection, error)
+
+	// CollectPayment records the car owner or an admin collecting an offline payment of a
+	// booking in person, confirming the booking if it was still pending.
+	// Parameters:
+	//   - ctx: Request context for cancellation, timeout, and request scoping
+	//   - bookingID: Unique identifier of the booking
+	//   - paymentID: Offline payment that was collected
+	//   - userID: Authenticated user recording the collection
+	//   - role: Authenticated user's role
+	//   - req: Amount, receipt number, notes and collection time
+	// Returns:
+	//   - *models.PaymentCollectionResult: The collection, completed payment and booking
+	//   - error: Unknown booking or payment, validation error or data access error
+	CollectPayment(ctx context.Context, bookingID, paymentID, userID, role string, req models.PaymentCollectionRequest) (*models.PaymentCollectionResult, error)
+
+	// GetPaymentCollections retrieves the offline collections recorded for a booking, for its
+	// car owner or an admin.
+	// Parameters:
+	//   - ctx: Request context for cancellation, timeout, and request scoping
+	//   - bookingID: Unique identifier of the booking
+	//   - userID: Authenticated user
+	//   - role: Authenticated user's role
+	// Returns:
+	//   - []models.PaymentCollection: Collections, oldest first
+	//   - error: Unknown booking or data access error
+	GetPaymentCollections(ctx context.Context, bookingID, userID, role string) ([]models.PaymentCollection, error)
+
+	// GetDocuments retrieves the numbered invoice and receipts of a booking, for its customer,
+	// car owner or an admin.
+	// Parameters:
+	//   - ctx: Request context for cancellation, timeout, and request scoping
+	//   - bookingID: Unique identifier of the booking
+	//   - userID: Authenticated user
+	//   - role: Authenticated user's role
+	// Returns:
+	//   - []models.FinancialDocument: Documents, oldest first
+	//   - error: Unknown booking or data access error
+	GetDocuments(ctx context.Context, bookingID, userID, role string) ([]models.FinancialDocument, error)
+
+	// GetActiveTrips retrieves the trips currently under way with the owner's cars.
+	// Parameters:
+	//   - ctx: Request context for cancellation, timeout, and request scoping
+	//   - ownerID: Authenticated owner
+	// Returns:
+	//   - []models.Booking: In-progress bookings, due back first; overdue ones carry overdue_at
+	//   - error: Error if database operation fails
+	GetActiveTrips(ctx context.Context, ownerID string) ([]models.Booking, error)
+
+	// DetectOverdueTrips flags trips still in progress after their end date and e-mails the
+	// customer and owner of each. Run periodically by the scheduler.
+	// Parameters:
+	//   - ctx: Context of the scheduled run
+	// Returns:
+	//   - error: Error if overdue trips could not be flagged
+	DetectOverdueTrips(ctx context.Context) error
+
+	// ExpireUnpaidBookings cancels pending bookings left unpaid past the payment window and
+	// reminds customers whose window is about to close. Run periodically by the scheduler.
+	// Parameters:
+	//   - ctx: Context of the scheduled run
+	// Returns:
+	//   - error: Error if unpaid bookings could not be expired
+	ExpireUnpaidBookings(ctx context.Context) error
+
+	// QuoteExtension checks whether a trip in progress can be extended to a new end date and
+	// prices the extra days.
+	// Parameters:
+	//   - ctx: Request context for cancellation, timeout, and request scoping
+	//   - bookingID: Trip to extend
+	//   - userID: Authenticated user; must be the trip's customer unless role is admin
+	//   - role: Authenticated user's role
+	//   - end: Requested new end of the trip
+	// Returns:
+	//   - *models.ExtensionQuote: Price of the extra days and what keeps the car from being booked
+	//   - error: Unknown booking, trip not in progress or invalid end date
+	QuoteExtension(ctx context.Context, bookingID, userID, role string, end time.Time) (*models.ExtensionQuote, error)
+
+	// ExtendBooking books the extra days of a trip in progress as an extension booking and
+	// requests payment of their price. The car's owner confirms it like any booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation, timeout, and request scoping
+	//   - bookingID: Trip to extend
+	//   - userID: Authenticated user; must be the trip's customer unless role is admin
+	//   - role: Authenticated user's role
+	//   - req: New end of the trip
+	// Returns:
+	//   - *models.BookingExtension: The pending extension and its payment order
+	//   - error: Unknown booking, car unavailable for the extra days or validation error
+	ExtendBooking(ctx context.Context, bookingID, userID, role string, req models.ExtensionRequest) (*models.BookingExtension, error)
+
+	// RemindHandBacks reminds customers of trips due back soon to return the car, offering a
+	// one-day extension when the car is free. Run periodically by the scheduler.
+	// Parameters:
+	//   - ctx: Context of the scheduled run
+	// Returns:
+	//   - error: Error if due trips could not be found
+	RemindHandBacks(ctx context.Context) error
+
+	// RecomputeBookingTotals reprices unpaid pending bookings from their car's current rate and
+	// fleet pricing rules, keeping the fees charged at booking time.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Booking to reprice; every unpaid pending booking in scope when nil
+	// Returns:
+	//   - models.MaintenanceResult: Bookings checked and bookings repriced
+	//   - error: Data access error
+	RecomputeBookingTotals(ctx context.Context, bookingID *uuid.UUID) (models.MaintenanceResult, error)
+}
+
+// TelemetryServiceInterface defines the contract for car telematics: device provisioning,
+// signed reading ingestion and the latest snapshot of each car.
+type TelemetryServiceInterface interface {
+	// IngestReading verifies a device signature and stores the reading.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - deviceID: X-Telemetry-Device header
+	//   - timestamp: X-Telemetry-Timestamp header, Unix seconds
+	//   - signature: X-Telemetry-Signature header, hex HMAC-SHA256 of "<timestamp>.<body>"
+	//   - body: Raw request body
+	// Returns:
+	//   - *models.TelemetryReading: Stored reading
+	//   - error: Error if the signature is invalid or expired, the payload is invalid, or storage fails
+	IngestReading(ctx context.Context, deviceID, timestamp, signature string, body []byte) (*models.TelemetryReading, error)
+
+	// ProvisionDevice issues (or rotates) the signing secret of a car's device.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless role is admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - *models.TelemetryDeviceCredentials: Device ID and secret, shown only once
+	//   - error: Error if the car is not found or not owned by the user
+	ProvisionDevice(ctx context.Context, userID, role, carID string) (*models.TelemetryDeviceCredentials, error)
+
+	// GetLatestSnapshot returns the newest value of every metric reported for a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless role is admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - *models.TelemetryReading: Latest snapshot
+	//   - error: Error if the car is not found, not owned by the user, or never reported
+	GetLatestSnapshot(ctx context.Context, userID, role, carID string) (*models.TelemetryReading, error)
+
+	// LatestHandoverReadings returns odometer and fuel readings recent enough to auto-fill a handover.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - *int: Odometer in km, nil if no recent reading
+	//   - *float64: Fuel level percentage, nil if no recent reading
+	//   - error: Error if database operation fails
+	LatestHandoverReadings(ctx context.Context, carID string) (*int, *float64, error)
+
+	// CreateGeofence defines a circular region the car must stay in during rentals.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless role is admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	//   - req: Centre, radius and optional booking the geofence is limited to
+	// Returns:
+	//   - *models.Geofence: Created geofence
+	//   - error: Error if validation fails, the car is not found, or the booking belongs to another car
+	CreateGeofence(ctx context.Context, userID, role, carID string, req models.GeofenceRequest) (*models.Geofence, error)
+
+	// GetGeofences lists the geofences of a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless role is admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - []models.Geofence: Geofences, newest first
+	//   - error: Error if the car is not found or not owned by the user
+	GetGeofences(ctx context.Context, userID, role, carID string) ([]models.Geofence, error)
+
+	// DeleteGeofence removes a geofence of a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless role is admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	//   - id: Unique identifier of the geofence
+	// Returns:
+	//   - error: Error if the car or geofence is not found
+	DeleteGeofence(ctx context.Context, userID, role, carID, id string) error
+
+	// GetGeofenceBreaches lists the most recent geofence breaches of a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless role is admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - []models.GeofenceBreach: Breaches, newest first
+	//   - error: Error if the car is not found or not owned by the user
+	GetGeofenceBreaches(ctx context.Context, userID, role, carID string) ([]models.GeofenceBreach, error)
+
+	// EvaluateGeofences checks every active rental against its geofences and alerts owners of exits.
+	// Parameters:
+	//   - ctx: Context of the background job
+	// Returns:
+	//   - error: Error if the active rentals could not be loaded
+	EvaluateGeofences(ctx context.Context) error
 }
 
 // PaymentServiceInterface defines the contract for payment-related business logic operations.
@@ -231,11 +659,1977 @@ type PaymentServiceInterface interface {
 	//   - error: Business rule violation, Razorpay API error, or refund failure
 	ProcessRefund(ctx context.Context, paymentID string, amount float64) (*models.Payment, error)
 
-	// GetAllPayments retrieves all payment records with business filtering.
+	// ListPayments retrieves one cursor-paginated page of payments matching the filter.
 	// Parameters:
 	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional paying-user restriction
+	//   - page: Page size and optional cursor parsed from the request
 	// Returns:
-	//   - *[]models.Payment: Pointer to slice of all payment records
+	//   - *models.Page[models.Payment]: Page of payments with the cursor for the next page
 	//   - error: Business logic error or data access error
-	GetAllPayments(ctx context.Context) (*[]models.Payment, error)
+	ListPayments(ctx context.Context, filter models.PaymentFilter, page models.PageRequest) (*models.Page[models.Payment], error)
+
+	// CreatePaymentLink creates a pending payment for a booking and a Razorpay Payment Link for
+	// it, which Razorpay sends to the customer by e-mail and SMS.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Booking to collect payment for
+	//   - req: Optional amount (defaults to the booking total) and description
+	// Returns:
+	//   - *models.PaymentLink: The link with the URL the customer pays on
+	//   - error: Unknown or closed booking, invalid amount, Razorpay or data access error
+	CreatePaymentLink(ctx context.Context, bookingID string, req models.PaymentLinkRequest) (*models.PaymentLink, error)
+
+	// HandleRazorpayWebhook verifies a Razorpay webhook and applies payment link events to the
+	// link and its payment. Other events and repeated deliveries are ignored.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - signature: X-Razorpay-Signature header
+	//   - body: Raw request body the signature covers
+	// Returns:
+	//   - error: Invalid signature or payload, or data access error
+	HandleRazorpayWebhook(ctx context.Context, signature string, body []byte) error
+
+	// RecordCollection marks a pending offline payment of a booking collected and records who
+	// collected it against which receipt.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Booking the payment belongs to
+	//   - paymentID: Payment that was collected
+	//   - collectedBy: User who collected the payment
+	//   - req: Amount, receipt number, notes and collection time
+	// Returns:
+	//   - *models.PaymentCollection: The recorded collection
+	//   - *models.Payment: The completed payment
+	//   - error: Unknown, online or settled payment, amount mismatch or data access error
+	RecordCollection(ctx context.Context, bookingID, paymentID, collectedBy string, req models.PaymentCollectionRequest) (*models.PaymentCollection, *models.Payment, error)
+
+	// GetCollections retrieves the offline collections recorded for a booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Booking ID
+	// Returns:
+	//   - []models.PaymentCollection: Collections, oldest first
+	//   - error: Data access error
+	GetCollections(ctx context.Context, bookingID string) ([]models.PaymentCollection, error)
+
+	// RetryPayment creates a new Razorpay order for a failed payment, keeping the payment
+	// record and archiving the failed attempt.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Failed payment
+	//   - userID: Authenticated user retrying the payment
+	//   - role: Authenticated user's role
+	// Returns:
+	//   - *models.RazorpayOrderResponse: The new order to open checkout with
+	//   - error: Unknown, non-Razorpay or not failed payment, closed booking, Razorpay or data access error
+	RetryPayment(ctx context.Context, id, userID, role string) (*models.RazorpayOrderResponse, error)
+
+	// GetPaymentDetail retrieves a payment with every gateway attempt made on it.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Payment ID
+	// Returns:
+	//   - *models.PaymentDetail: The payment and its attempts, oldest first
+	//   - error: Invalid or unknown payment, or data access error
+	GetPaymentDetail(ctx context.Context, id string) (*models.PaymentDetail, error)
+
+	// CaptureAuthorizedPayments captures the payments held on the customer's card for a booking,
+	// at pickup.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Booking being handed over
+	// Returns:
+	//   - error: Payment gateway or data access error; the handover must not go ahead
+	CaptureAuthorizedPayments(ctx context.Context, bookingID string) error
+
+	// VoidAuthorizedPayments releases the payments held for a booking cancelled before pickup.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Cancelled booking
+	// Returns:
+	//   - error: Data access error
+	VoidAuthorizedPayments(ctx context.Context, bookingID string) error
+
+	// CreateOrder creates a Razorpay order for a charge that is not a booking payment.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - amount: Amount in INR
+	//   - receipt: Receipt reference shown in the Razorpay dashboard, at most 40 characters
+	// Returns:
+	//   - *models.RazorpayOrderResponse: The order for Razorpay checkout
+	//   - error: Invalid amount or payment gateway error
+	CreateOrder(ctx context.Context, amount float64, receipt string) (*models.RazorpayOrderResponse, error)
+
+	// VerifyOrderSignature checks the signature Razorpay checkout returned for an order's payment.
+	// Parameters:
+	//   - req: Order ID, payment ID and signature from Razorpay checkout
+	// Returns:
+	//   - bool: Whether the signature is genuine
+	VerifyOrderSignature(req models.PaymentVerificationRequest) bool
+
+	// ResyncPayment applies the outcome Razorpay recorded for a pending payment whose
+	// verification or webhook never arrived.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Payment to resync
+	// Returns:
+	//   - models.MaintenanceResult: One payment checked, changed when its status was updated
+	//   - error: Unknown payment, no Razorpay order or payment gateway error
+	ResyncPayment(ctx context.Context, id string) (models.MaintenanceResult, error)
+
+	// ReconcilePendingPayments resyncs the Razorpay payments left pending for longer than a
+	// duration. Payments that fail to resync are logged and skipped.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - olderThan: How long a payment must have been pending untouched
+	// Returns:
+	//   - models.MaintenanceResult: Payments checked and payments whose status was updated
+	//   - error: Data access error
+	ReconcilePendingPayments(ctx context.Context, olderThan time.Duration) (models.MaintenanceResult, error)
+}
+
+// SitemapServiceInterface defines the contract for search-engine documents
+// generated from the public catalog (sitemap.xml and the listings feed).
+type SitemapServiceInterface interface {
+	// Refresh regenerates both documents from the current active listings.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - error: Data access or rendering error
+	Refresh(ctx context.Context) error
+
+	// Sitemap returns the latest rendered sitemap.xml.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []byte: XML document following the sitemaps.org protocol
+	//   - time.Time: When the document was generated
+	//   - error: Rendering error if the document had to be generated on demand
+	Sitemap(ctx context.Context) ([]byte, time.Time, error)
+
+	// Feed returns the latest rendered JSON listings feed.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []byte: JSON document listing id, slug, url and updated_at per active car
+	//   - time.Time: When the document was generated
+	//   - error: Rendering error if the document had to be generated on demand
+	Feed(ctx context.Context) ([]byte, time.Time, error)
+}
+
+// PayoutServiceInterface defines the contract for owner payout details.
+// Account numbers and UPI IDs are verified through RazorpayX before payouts are sent to them.
+type PayoutServiceInterface interface {
+	// RegisterPayoutAccount validates and stores payout details, then starts gateway verification.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	//   - req: Bank account or UPI details
+	// Returns:
+	//   - *models.PayoutAccount: Stored account with masked identifier and verification status
+	//   - error: Validation, gateway or data access error
+	RegisterPayoutAccount(ctx context.Context, ownerID string, req models.PayoutAccountRequest) (*models.PayoutAccount, error)
+
+	// VerifyPayoutAccount refreshes the verification status of a payout account.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	//   - id: Payout account ID
+	// Returns:
+	//   - *models.PayoutAccount: Account with its latest verification status
+	//   - error: Not found, gateway or data access error
+	VerifyPayoutAccount(ctx context.Context, ownerID, id string) (*models.PayoutAccount, error)
+
+	// GetPayoutAccounts retrieves all payout accounts of an owner.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	// Returns:
+	//   - *[]models.PayoutAccount: Accounts, newest first
+	//   - error: Data access error
+	GetPayoutAccounts(ctx context.Context, ownerID string) (*[]models.PayoutAccount, error)
+
+	// DeletePayoutAccount removes one of the owner's payout accounts.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	//   - id: Payout account ID
+	// Returns:
+	//   - error: Not found or data access error
+	DeletePayoutAccount(ctx context.Context, ownerID, id string) error
+
+	// GetVerifiedPayoutAccount returns the account payouts to the owner should be sent to.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the owner
+	// Returns:
+	//   - *models.PayoutAccount: Most recent verified account with a RazorpayX fund account
+	//   - error: Error if the owner has no verified account or a dispute holds their payouts
+	GetVerifiedPayoutAccount(ctx context.Context, ownerID string) (*models.PayoutAccount, error)
+
+	// GetPayoutHolds retrieves the holds disputes placed on an owner's payouts.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the owner
+	// Returns:
+	//   - []models.PayoutHold: Holds, active ones first
+	//   - error: Data access error
+	GetPayoutHolds(ctx context.Context, ownerID string) ([]models.PayoutHold, error)
+}
+
+// NotificationServiceInterface defines the contract for messages sent to users outside the API,
+// such as security alerts.
+type NotificationServiceInterface interface {
+	// Notify delivers a message to a user.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - user: Recipient; their e-mail address is used for delivery
+	//   - subject: Short summary of the message
+	//   - message: Plain-text body
+	// Returns:
+	//   - error: Delivery error
+	Notify(ctx context.Context, user models.User, subject, message string) error
+}
+
+// EmailSenderInterface defines the contract for e-mail sent straight to an address, without the
+// copy Notify keeps in the user's inbox, for messages such as password reset links.
+type EmailSenderInterface interface {
+	// SendEmail sends a plain-text e-mail.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - to: Recipient's e-mail address
+	//   - subject: Short summary of the message
+	//   - body: Complete plain-text body
+	// Returns:
+	//   - error: Delivery error
+	SendEmail(ctx context.Context, to, subject, body string) error
+}
+
+// SecurityMonitorInterface defines the hooks other services call when activity may be suspicious.
+// Implementations never fail the calling flow; detection errors are only logged.
+type SecurityMonitorInterface interface {
+	// RecordLogin remembers the device of a successful login and flags logins from new devices.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - user: User who logged in
+	//   - ipAddress: Client IP address
+	//   - userAgent: Client user agent
+	RecordLogin(ctx context.Context, user models.User, ipAddress, userAgent string)
+
+	// RecordPaymentFailure flags customers who fail many payments in a short window.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - customerID: ID of the customer whose payment failed
+	RecordPaymentFailure(ctx context.Context, customerID string)
+
+	// RecordBookingCancellation flags customers who cancel many bookings in a short window.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - customerID: ID of the customer whose booking was cancelled
+	RecordBookingCancellation(ctx context.Context, customerID string)
+
+	// RecordHighRiskBooking queues a booking held by risk scoring for admin review.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - booking: Booking that was moved to under_review
+	//   - assessment: Risk score and the signals that contributed to it
+	RecordHighRiskBooking(ctx context.Context, booking models.Booking, assessment models.RiskAssessment)
+}
+
+// RiskScorerInterface defines the pluggable fraud risk scoring step that runs before a
+// booking is confirmed or its payment is captured.
+type RiskScorerInterface interface {
+	// AssessBooking scores a booking attempt with every configured risk rule.
+	// Parameters:
+	//   - ctx: Request context; the client IP and country are read from it when present
+	//   - booking: Booking about to be confirmed or paid for
+	//   - stage: Point in the flow at which the assessment runs
+	// Returns:
+	//   - models.RiskAssessment: Total score, contributing signals and whether manual review is required
+	//   - error: Error if the customer could not be loaded; individual rule failures are skipped
+	AssessBooking(ctx context.Context, booking models.Booking, stage models.RiskStage) (models.RiskAssessment, error)
+}
+
+// GeocoderInterface defines the contract for resolving free-form addresses to coordinates.
+type GeocoderInterface interface {
+	// Geocode resolves an address to its best matching coordinates.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - address: Free-form address entered by the user
+	// Returns:
+	//   - models.GeocodedAddress: Normalised address and coordinates
+	//   - error: Error if the address cannot be found or the geocoder is unreachable
+	Geocode(ctx context.Context, address string) (models.GeocodedAddress, error)
+}
+
+// SecurityServiceInterface defines the contract for suspicious activity detection
+// and the admin review queue of security events.
+type SecurityServiceInterface interface {
+	SecurityMonitorInterface
+
+	// ListSecurityEvents retrieves one page of security events, newest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional status, type and user constraints
+	//   - page: Page size and optional cursor parsed from the request
+	// Returns:
+	//   - *models.Page[models.SecurityEvent]: Page of events with the cursor for the next page
+	//   - error: Validation or data access error
+	ListSecurityEvents(ctx context.Context, filter models.SecurityEventFilter, page models.PageRequest) (*models.Page[models.SecurityEvent], error)
+
+	// GetSecurityEventByID retrieves a single security event.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Security event ID
+	// Returns:
+	//   - *models.SecurityEvent: The security event
+	//   - error: Not found or data access error
+	GetSecurityEventByID(ctx context.Context, id string) (*models.SecurityEvent, error)
+
+	// ReviewSecurityEvent records an admin's verdict on an open security event.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Security event ID
+	//   - reviewerID: ID of the authenticated admin
+	//   - review: Verdict (reviewed or dismissed) and optional notes
+	// Returns:
+	//   - *models.SecurityEvent: The updated security event
+	//   - error: Validation, not found, already reviewed or data access error
+	ReviewSecurityEvent(ctx context.Context, id, reviewerID string, review models.SecurityEventReview) (*models.SecurityEvent, error)
+}
+
+// LocationServiceInterface defines the contract for pickup/drop-off locations and the cars
+// available at them.
+type LocationServiceInterface interface {
+	// CreateLocation validates and stores a new location.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Location details
+	// Returns:
+	//   - *models.Location: Created location
+	//   - error: Validation error, duplicate code or data access error
+	CreateLocation(ctx context.Context, req models.LocationRequest) (*models.Location, error)
+
+	// GetLocationByID retrieves a location.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the location
+	// Returns:
+	//   - *models.Location: The location
+	//   - error: Error if not found or data access fails
+	GetLocationByID(ctx context.Context, id string) (*models.Location, error)
+
+	// ListLocations lists locations ordered by city and name.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - city: Only locations in this city when not empty
+	//   - includeInactive: Also return inactive locations
+	// Returns:
+	//   - []models.Location: Matching locations
+	//   - error: Data access error
+	ListLocations(ctx context.Context, city string, includeInactive bool) ([]models.Location, error)
+
+	// UpdateLocation validates and replaces the details of a location.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the location
+	//   - req: Location details
+	// Returns:
+	//   - *models.Location: Updated location
+	//   - error: Validation error, not found, duplicate code or data access error
+	UpdateLocation(ctx context.Context, id string, req models.LocationRequest) (*models.Location, error)
+
+	// DeleteLocation removes a location.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the location
+	// Returns:
+	//   - error: Error if not found or data access fails
+	DeleteLocation(ctx context.Context, id string) error
+
+	// AttachCar makes a car available at a location.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless role is admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	//   - locationID: Unique identifier of the location
+	// Returns:
+	//   - error: Error if the car or location is not found
+	AttachCar(ctx context.Context, userID, role, carID, locationID string) error
+
+	// DetachCar removes a car from a location.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless role is admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	//   - locationID: Unique identifier of the location
+	// Returns:
+	//   - error: Error if the car is not found or not attached to the location
+	DetachCar(ctx context.Context, userID, role, carID, locationID string) error
+
+	// GetCarLocations lists the locations a car is available at.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - []models.Location: Locations ordered by city and name
+	//   - error: Data access error
+	GetCarLocations(ctx context.Context, carID string) ([]models.Location, error)
+
+	// GetDeliveryOption retrieves a car's door delivery settings.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - *models.DeliveryOption: The delivery settings
+	//   - error: Error if the car does not offer delivery or data access fails
+	GetDeliveryOption(ctx context.Context, carID string) (*models.DeliveryOption, error)
+
+	// UpdateDeliveryOption validates and stores a car's door delivery settings.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless role is admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	//   - req: Delivery settings
+	// Returns:
+	//   - *models.DeliveryOption: The stored delivery settings
+	//   - error: Validation error, car not found or data access error
+	UpdateDeliveryOption(ctx context.Context, userID, role, carID string, req models.DeliveryOptionRequest) (*models.DeliveryOption, error)
+
+	// ListRelocationFees lists the one-way fee matrix.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.RelocationFee: Fees ordered by city pair
+	//   - error: Data access error
+	ListRelocationFees(ctx context.Context) ([]models.RelocationFee, error)
+
+	// SetRelocationFee validates and stores the fee of a city pair, offering one-way rentals between them.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: City pair and fee
+	// Returns:
+	//   - *models.RelocationFee: The stored fee
+	//   - error: Validation or data access error
+	SetRelocationFee(ctx context.Context, req models.RelocationFeeRequest) (*models.RelocationFee, error)
+
+	// DeleteRelocationFee stops offering one-way rentals between a city pair.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the relocation fee
+	// Returns:
+	//   - error: Error if not found or data access fails
+	DeleteRelocationFee(ctx context.Context, id string) error
+}
+
+// CarEventListenerInterface defines the hook car and booking flows call when a car changes in
+// a way subscribers may care about. Implementations never fail the calling flow; errors are only logged.
+type CarEventListenerInterface interface {
+	// HandleCarEvent compares a car change against stored criteria and alerts matching subscribers.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - event: What changed about the car
+	HandleCarEvent(ctx context.Context, event models.CarEvent)
+}
+
+// AlertServiceInterface defines the contract for price-drop and availability alerts on cars.
+type AlertServiceInterface interface {
+	CarEventListenerInterface
+
+	// Subscribe validates the criteria and subscribes the user to a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	//   - carID: Car to watch
+	//   - req: Alert criteria; subscribing again replaces them
+	// Returns:
+	//   - *models.CarAlertSubscription: The stored subscription
+	//   - error: Validation error, car not found or data access error
+	Subscribe(ctx context.Context, userID, carID string, req models.CarAlertSubscriptionRequest) (*models.CarAlertSubscription, error)
+
+	// GetSubscriptions lists the user's subscriptions.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	// Returns:
+	//   - []models.CarAlertSubscription: The user's subscriptions, newest first
+	//   - error: Data access error
+	GetSubscriptions(ctx context.Context, userID string) ([]models.CarAlertSubscription, error)
+
+	// Unsubscribe removes one of the user's subscriptions.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	//   - id: Unique identifier of the subscription
+	// Returns:
+	//   - error: Error if not found or data access fails
+	Unsubscribe(ctx context.Context, userID, id string) error
+}
+
+// FavoriteServiceInterface defines the contract for users' wishlists of cars.
+type FavoriteServiceInterface interface {
+	// AddFavorite saves a car to the user's wishlist.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	//   - carID: Car to save
+	// Returns:
+	//   - *models.Favorite: The favorite; saving a car again returns the original one
+	//   - bool: True if the car was newly saved
+	//   - error: Car not found or data access error
+	AddFavorite(ctx context.Context, userID, carID string) (*models.Favorite, bool, error)
+
+	// RemoveFavorite removes a car from the user's wishlist.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	//   - carID: Saved car
+	// Returns:
+	//   - error: Error if the car is not saved or data access fails
+	RemoveFavorite(ctx context.Context, userID, carID string) error
+
+	// ListFavorites retrieves one page of the user's wishlist.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	//   - page: Page size and cursor
+	// Returns:
+	//   - *models.Page[models.Favorite]: Favorites with their cars, most recently saved first
+	//   - error: Data access error
+	ListFavorites(ctx context.Context, userID string, page models.PageRequest) (*models.Page[models.Favorite], error)
+}
+
+// AnalyticsServiceInterface defines the contract for the admin dashboard data API.
+type AnalyticsServiceInterface interface {
+	// GetTimeSeries returns a bucketed aggregate for charting.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - query: Metric, bucket width and range
+	// Returns:
+	//   - *models.TimeSeries: Buckets and their total
+	//   - error: Data access error
+	GetTimeSeries(ctx context.Context, query models.TimeSeriesQuery) (*models.TimeSeries, error)
+
+	// GetOwnerEarnings returns the owners earning most over a range, from the read model.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - r: Days covered
+	//   - limit: Maximum number of owners
+	// Returns:
+	//   - *models.Dashboard[[]models.OwnerEarnings]: Owners, highest revenue first, and freshness
+	//   - error: Data access error
+	GetOwnerEarnings(ctx context.Context, r models.DashboardRange, limit int) (*models.Dashboard[[]models.OwnerEarnings], error)
+
+	// GetCarUtilization returns the most booked cars over a range, from the read model.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - r: Days covered
+	//   - limit: Maximum number of cars
+	// Returns:
+	//   - *models.Dashboard[[]models.CarUtilization]: Cars, most booked first, and freshness
+	//   - error: Data access error
+	GetCarUtilization(ctx context.Context, r models.DashboardRange, limit int) (*models.Dashboard[[]models.CarUtilization], error)
+
+	// GetBookingFunnel returns how the bookings created over a range progressed, from the read model.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - r: Days covered
+	// Returns:
+	//   - *models.Dashboard[models.BookingFunnel]: Counts, conversion rates and freshness
+	//   - error: Data access error
+	GetBookingFunnel(ctx context.Context, r models.DashboardRange) (*models.Dashboard[models.BookingFunnel], error)
+
+	// RefreshDashboards brings the dashboard read model up to date; run by the scheduler.
+	// Parameters:
+	//   - ctx: Context for cancellation
+	// Returns:
+	//   - error: Data access error
+	RefreshDashboards(ctx context.Context) error
+}
+
+// ObjectStorageInterface defines the contract for writing documents to object storage such as S3.
+type ObjectStorageInterface interface {
+	// PutObject uploads a document.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Object key, including any prefix
+	//   - body: Document contents
+	//   - contentType: MIME type of the document
+	// Returns:
+	//   - error: Upload error
+	PutObject(ctx context.Context, key string, body []byte, contentType string) error
+}
+
+// WarehouseExportServiceInterface defines the contract for exporting changed rows to the data warehouse.
+type WarehouseExportServiceInterface interface {
+	// ExportIncremental exports every entity's rows changed since its watermark and advances the watermarks.
+	// Parameters:
+	//   - ctx: Context of the background job
+	// Returns:
+	//   - error: First export, upload or data access error; entities exported before it keep their progress
+	ExportIncremental(ctx context.Context) error
+
+	// Backfill re-exports rows changed in a range without moving the watermarks.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Entities and range to export
+	// Returns:
+	//   - []models.WarehouseExport: One entry per exported entity
+	//   - error: Validation, export, upload or data access error
+	Backfill(ctx context.Context, req models.WarehouseBackfillRequest) ([]models.WarehouseExport, error)
+}
+
+// SearchServiceInterface defines the contract for admin support search.
+type SearchServiceInterface interface {
+	// Search finds users by e-mail or phone, cars by license plate, payments by gateway or
+	// transaction ID, any record by ID, and the bookings linked to each of them.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - q: Search term as typed by support staff
+	// Returns:
+	//   - []models.SearchHit: Typed hits with links, newest first
+	//   - error: Validation or data access error
+	Search(ctx context.Context, q string) ([]models.SearchHit, error)
+}
+
+// OperatorServiceInterface defines the contract for managing marketplace operators (tenants).
+type OperatorServiceInterface interface {
+	// CreateOperator validates and stores a new operator.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Operator settings
+	// Returns:
+	//   - *models.Operator: The created operator
+	//   - error: Validation, conflict or data access error
+	CreateOperator(ctx context.Context, req models.OperatorRequest) (*models.Operator, error)
+
+	// GetOperator retrieves an operator by ID.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Operator UUID
+	// Returns:
+	//   - *models.Operator: The operator
+	//   - error: Not found or data access error
+	GetOperator(ctx context.Context, id string) (*models.Operator, error)
+
+	// ListOperators retrieves every operator.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.Operator: Operators ordered by name
+	//   - error: Data access error
+	ListOperators(ctx context.Context) ([]models.Operator, error)
+
+	// UpdateOperator validates and replaces an operator's branding, domain and commission.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Operator UUID
+	//   - req: Operator settings
+	// Returns:
+	//   - *models.Operator: The updated operator
+	//   - error: Validation, not found, conflict or data access error
+	UpdateOperator(ctx context.Context, id string, req models.OperatorRequest) (*models.Operator, error)
+
+	// ResolveDomain returns the operator serving a white-label host name, for TenantMiddleware.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - host: Normalized host name
+	// Returns:
+	//   - *models.Operator: The operator, or nil when the host is not a white-label domain
+	//   - error: Data access error
+	ResolveDomain(ctx context.Context, host string) (*models.Operator, error)
+}
+
+// InboxServiceInterface defines the contract for the in-app notification inbox. Every message
+// sent through Notify also lands in the recipient's inbox.
+type InboxServiceInterface interface {
+	NotificationServiceInterface
+
+	// ListNotifications retrieves one page of the user's notifications, newest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	//   - filter: Optional unread-only filter
+	//   - page: Page size and cursor
+	// Returns:
+	//   - *models.Page[models.Notification]: The page with next/prev cursors
+	//   - error: Data access error
+	ListNotifications(ctx context.Context, userID string, filter models.NotificationFilter, page models.PageRequest) (*models.Page[models.Notification], error)
+
+	// MarkRead marks one of the user's notifications as read.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	//   - id: Unique identifier of the notification
+	// Returns:
+	//   - *models.Notification: The notification with its read time
+	//   - error: Not found or data access error
+	MarkRead(ctx context.Context, userID, id string) (*models.Notification, error)
+
+	// CountUnread counts the user's unread notifications for the inbox badge.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	// Returns:
+	//   - int: Number of unread notifications
+	//   - error: Data access error
+	CountUnread(ctx context.Context, userID string) (int, error)
+}
+
+// TemplatedNotifierInterface defines the hook flows call to send a user an e-mail whose copy is
+// managed as a template.
+type TemplatedNotifierInterface interface {
+	// NotifyWithTemplate renders the current template of a key and notifies the user.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Recipient
+	//   - key: Template key, e.g. models.EmailTemplateBookingConfirmed
+	//   - data: Template variables; user_name is added automatically
+	// Returns:
+	//   - error: User lookup, rendering or delivery error
+	NotifyWithTemplate(ctx context.Context, userID, key string, data map[string]interface{}) error
+}
+
+// EmailTemplateServiceInterface defines the contract for managing versioned e-mail templates.
+type EmailTemplateServiceInterface interface {
+	TemplatedNotifierInterface
+
+	// CreateTemplate validates and stores version 1 of a new template key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Key, subject and body
+	//   - createdBy: Admin saving the template
+	// Returns:
+	//   - *models.EmailTemplate: The stored version
+	//   - error: Validation, conflict or data access error
+	CreateTemplate(ctx context.Context, req models.EmailTemplateRequest, createdBy string) (*models.EmailTemplate, error)
+
+	// UpdateTemplate validates and stores the next version of a template key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Template key
+	//   - req: Subject and body
+	//   - createdBy: Admin saving the version
+	// Returns:
+	//   - *models.EmailTemplate: The stored version
+	//   - error: Validation, not found, conflict or data access error
+	UpdateTemplate(ctx context.Context, key string, req models.EmailTemplateRequest, createdBy string) (*models.EmailTemplate, error)
+
+	// GetTemplate retrieves the current version of a template key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Template key
+	// Returns:
+	//   - *models.EmailTemplate: The current version
+	//   - error: Not found or data access error
+	GetTemplate(ctx context.Context, key string) (*models.EmailTemplate, error)
+
+	// ListTemplates retrieves the current version of every template key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.EmailTemplate: Current versions ordered by key
+	//   - error: Data access error
+	ListTemplates(ctx context.Context) ([]models.EmailTemplate, error)
+
+	// ListTemplateVersions retrieves the history of a template key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Template key
+	// Returns:
+	//   - []models.EmailTemplate: Versions, newest first
+	//   - error: Not found or data access error
+	ListTemplateVersions(ctx context.Context, key string) ([]models.EmailTemplate, error)
+
+	// DeleteTemplate removes every version of a template key, reverting it to the built-in copy.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Template key
+	// Returns:
+	//   - error: Not found or data access error
+	DeleteTemplate(ctx context.Context, key string) error
+
+	// PreviewTemplate renders a template, or an unsaved draft of it, with sample variables.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Template key
+	//   - req: Optional draft subject/body and variables
+	// Returns:
+	//   - *models.RenderedEmail: The rendered subject and body
+	//   - error: Not found, template or data access error
+	PreviewTemplate(ctx context.Context, key string, req models.EmailTemplatePreviewRequest) (*models.RenderedEmail, error)
+}
+
+// KYCServiceInterface defines the contract for recording the identity data admins verified for
+// renters, which car eligibility rules are checked against.
+type KYCServiceInterface interface {
+	// GetVerifiedKYC retrieves the verified identity data of a user.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Unique identifier of the user
+	// Returns:
+	//   - *models.VerifiedKYC: Verified date of birth and licence issue date
+	//   - error: Invalid ID, unverified user or data access error
+	GetVerifiedKYC(ctx context.Context, userID string) (*models.VerifiedKYC, error)
+
+	// VerifyKYC validates and records the dates an admin read from a user's documents.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - adminID: Admin who checked the documents
+	//   - userID: User whose documents were checked
+	//   - req: Date of birth and licence issue date
+	// Returns:
+	//   - *models.VerifiedKYC: The stored record
+	//   - error: Validation error, unknown user or data access error
+	VerifyKYC(ctx context.Context, adminID, userID string, req models.KYCRequest) (*models.VerifiedKYC, error)
+}
+
+// VacationServiceInterface defines the contract for owner vacation mode, which blocks all of an
+// owner's cars for a period and lifts the block again.
+type VacationServiceInterface interface {
+	// StartVacation validates the period and blocks all of the owner's cars for it.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	//   - req: Period and optional note
+	// Returns:
+	//   - *models.OwnerVacation: The vacation with the cars it blocked
+	//   - error: Validation, conflict or data access error
+	StartVacation(ctx context.Context, ownerID string, req models.VacationRequest) (*models.OwnerVacation, error)
+
+	// GetVacations retrieves the owner's vacations.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	// Returns:
+	//   - []models.OwnerVacation: Vacations, latest first
+	//   - error: Data access error
+	GetVacations(ctx context.Context, ownerID string) ([]models.OwnerVacation, error)
+
+	// EndVacation lifts a vacation, unblocking its cars and alerting users waiting for the dates.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	//   - id: Vacation ID
+	// Returns:
+	//   - *models.OwnerVacation: The removed vacation
+	//   - error: Not found or data access error
+	EndVacation(ctx context.Context, ownerID, id string) (*models.OwnerVacation, error)
+}
+
+// FleetServiceInterface defines the contract for owners' fleets. Cars in a fleet inherit its
+// pricing rules, add-ons and blackout dates when they are quoted.
+type FleetServiceInterface interface {
+	// CreateFleet validates and stores a new fleet.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	//   - req: Name and settings
+	// Returns:
+	//   - *models.Fleet: The stored fleet
+	//   - error: Validation, conflict or data access error
+	CreateFleet(ctx context.Context, ownerID string, req models.FleetRequest) (*models.Fleet, error)
+
+	// GetFleet retrieves one of the owner's fleets.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	//   - id: Fleet ID
+	// Returns:
+	//   - *models.Fleet: The fleet with its member cars
+	//   - error: Not found or data access error
+	GetFleet(ctx context.Context, ownerID, id string) (*models.Fleet, error)
+
+	// GetFleets retrieves the owner's fleets.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	// Returns:
+	//   - []models.Fleet: Fleets ordered by name
+	//   - error: Data access error
+	GetFleets(ctx context.Context, ownerID string) ([]models.Fleet, error)
+
+	// UpdateFleet validates and replaces the name and settings of a fleet.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	//   - id: Fleet ID
+	//   - req: Name and settings
+	// Returns:
+	//   - *models.Fleet: The updated fleet
+	//   - error: Validation, not found, conflict or data access error
+	UpdateFleet(ctx context.Context, ownerID, id string, req models.FleetRequest) (*models.Fleet, error)
+
+	// DeleteFleet removes a fleet; its cars keep being listed without fleet settings.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	//   - id: Fleet ID
+	// Returns:
+	//   - error: Not found or data access error
+	DeleteFleet(ctx context.Context, ownerID, id string) error
+
+	// AddCar moves one of the owner's cars into a fleet.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	//   - id: Fleet ID
+	//   - carID: Car to add
+	// Returns:
+	//   - *models.Fleet: The fleet with its updated member cars
+	//   - error: Not found or data access error
+	AddCar(ctx context.Context, ownerID, id, carID string) (*models.Fleet, error)
+
+	// RemoveCar takes a car out of a fleet.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	//   - id: Fleet ID
+	//   - carID: Car to remove
+	// Returns:
+	//   - error: Not found or data access error
+	RemoveCar(ctx context.Context, ownerID, id, carID string) error
+}
+
+// CarAttributeServiceInterface defines the contract for the custom car attributes operators
+// define. Car attribute values are validated against them.
+type CarAttributeServiceInterface interface {
+	// ListCarAttributes retrieves the attribute definitions of the operator a request acts for.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.CarAttribute: Every definition with the number of cars using it
+	//   - error: Data access error
+	ListCarAttributes(ctx context.Context) ([]models.CarAttribute, error)
+
+	// CreateCarAttribute validates and defines an attribute.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Key, name, type, options and required flag
+	// Returns:
+	//   - *models.CarAttribute: The stored definition
+	//   - error: Validation, conflict or data access error
+	CreateCarAttribute(ctx context.Context, req models.CarAttributeRequest) (*models.CarAttribute, error)
+
+	// UpdateCarAttribute validates and replaces the definition of an attribute.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Key of the attribute
+	//   - req: Name, options and required flag; the key and type cannot change
+	// Returns:
+	//   - *models.CarAttribute: The updated definition
+	//   - error: Validation, not found or data access error
+	UpdateCarAttribute(ctx context.Context, key string, req models.CarAttributeRequest) (*models.CarAttribute, error)
+
+	// DeleteCarAttribute removes an attribute and clears its values from cars.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Key of the attribute
+	// Returns:
+	//   - error: Not found or data access error
+	DeleteCarAttribute(ctx context.Context, key string) error
+
+	// Schema retrieves the operator's definitions indexed by key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - models.CarAttributeSchema: Definition of every key
+	//   - error: Data access error
+	Schema(ctx context.Context) (models.CarAttributeSchema, error)
+}
+
+// FeatureServiceInterface defines the contract for the curated features taxonomy. Car features
+// are validated against it and aliases are rewritten to canonical keys.
+type FeatureServiceInterface interface {
+	// ListFeatures retrieves the taxonomy.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.Feature: Every feature with its aliases and the number of cars offering it
+	//   - error: Data access error
+	ListFeatures(ctx context.Context) ([]models.Feature, error)
+
+	// CreateFeature validates and adds a feature to the taxonomy.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Key, name and aliases
+	// Returns:
+	//   - *models.Feature: The stored feature
+	//   - error: Validation, conflict or data access error
+	CreateFeature(ctx context.Context, req models.FeatureRequest) (*models.Feature, error)
+
+	// UpdateFeature validates and replaces the name and aliases of a feature.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Canonical key of the feature
+	//   - req: Name and aliases; the key cannot change
+	// Returns:
+	//   - *models.Feature: The updated feature
+	//   - error: Validation, not found or data access error
+	UpdateFeature(ctx context.Context, key string, req models.FeatureRequest) (*models.Feature, error)
+
+	// Taxonomy retrieves the index of canonical keys and aliases.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - models.FeatureTaxonomy: Canonical key of every key and alias
+	//   - error: Data access error
+	Taxonomy(ctx context.Context) (models.FeatureTaxonomy, error)
+
+	// NormalizeCarFeatures rewrites aliases in stored car features to canonical keys.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - error: Data access error
+	NormalizeCarFeatures(ctx context.Context) error
+}
+
+// BrandServiceInterface defines the contract for the car brand and model reference data used
+// by autocompletes and to validate cars' brand and model.
+type BrandServiceInterface interface {
+	// ListBrands retrieves brands for an autocomplete.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - query: Start of the brand name typed so far
+	// Returns:
+	//   - []models.CarBrand: Up to models.MaxAutocompleteResults brands
+	//   - error: Data access error
+	ListBrands(ctx context.Context, query string) ([]models.CarBrand, error)
+
+	// ListModels retrieves a brand's models for an autocomplete.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - brandID: Brand ID
+	//   - query: Start of the model name typed so far
+	// Returns:
+	//   - []models.CarModel: Up to models.MaxAutocompleteResults models
+	//   - error: Invalid or unknown brand, or data access error
+	ListModels(ctx context.Context, brandID, query string) ([]models.CarModel, error)
+
+	// ResolveBrandModel checks that a brand and model pair exists in the reference data.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - brand: Submitted brand name
+	//   - model: Submitted model name
+	// Returns:
+	//   - string, string: The brand and model names as spelled in the reference data
+	//   - error: Unknown brand or model, or data access error
+	ResolveBrandModel(ctx context.Context, brand, model string) (string, string, error)
+}
+
+// DisputeServiceInterface defines the contract for chargebacks customers raise against payments.
+type DisputeServiceInterface interface {
+	// HandleDisputeEvent applies a payment.dispute.* Razorpay webhook event: new disputes are
+	// stored and hold the owner's payouts, resolved ones release them. Admins are notified.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - event: Verified webhook event
+	// Returns:
+	//   - error: Data access error; unknown payments and disputes are ignored
+	HandleDisputeEvent(ctx context.Context, event models.RazorpayWebhookEvent) error
+
+	// ListDisputes retrieves disputes for admins.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional status
+	// Returns:
+	//   - []models.Dispute: Disputes, those due for a response soonest first
+	//   - error: Invalid status or data access error
+	ListDisputes(ctx context.Context, filter models.DisputeFilter) ([]models.Dispute, error)
+
+	// GetDispute retrieves a dispute with its evidence.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Dispute ID
+	// Returns:
+	//   - *models.Dispute: The dispute
+	//   - error: Not found or data access error
+	GetDispute(ctx context.Context, id string) (*models.Dispute, error)
+
+	// AddEvidence attaches a document to an unresolved dispute.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Dispute ID
+	//   - userID: Admin attaching the document
+	//   - req: Kind, description and document URL
+	// Returns:
+	//   - *models.Dispute: The dispute with all its evidence
+	//   - error: Validation, resolved dispute, not found or data access error
+	AddEvidence(ctx context.Context, id, userID string, req models.DisputeEvidenceRequest) (*models.Dispute, error)
+
+	// RecordOutcome resolves a dispute and releases the payouts it held.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Dispute ID
+	//   - req: Final status and notes
+	// Returns:
+	//   - *models.Dispute: The resolved dispute
+	//   - error: Validation, already resolved, not found or data access error
+	RecordOutcome(ctx context.Context, id string, req models.DisputeOutcomeRequest) (*models.Dispute, error)
+}
+
+// SequenceServiceInterface defines the contract for numbered invoices and receipts.
+type SequenceServiceInterface interface {
+	// IssueInvoice numbers the invoice of a booking; a booking has one invoice.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - booking: Confirmed booking
+	// Returns:
+	//   - *models.FinancialDocument: The invoice
+	//   - error: Data access error
+	IssueInvoice(ctx context.Context, booking models.Booking) (*models.FinancialDocument, error)
+
+	// IssueReceipt numbers the receipt of a payment; a payment has one receipt.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - payment: Completed payment
+	// Returns:
+	//   - *models.FinancialDocument: The receipt
+	//   - error: Data access error
+	IssueReceipt(ctx context.Context, payment models.Payment) (*models.FinancialDocument, error)
+
+	// IssueCreditNote numbers the credit note of an adjustment; an adjustment has one credit note.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - adjustment: Applied credit note adjustment
+	// Returns:
+	//   - *models.FinancialDocument: The credit note
+	//   - error: Data access error
+	IssueCreditNote(ctx context.Context, adjustment models.BookingAdjustment) (*models.FinancialDocument, error)
+
+	// GetBookingDocuments retrieves the invoices, receipts and credit notes issued for a booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Booking ID
+	// Returns:
+	//   - []models.FinancialDocument: Documents, oldest first
+	//   - error: Data access error
+	GetBookingDocuments(ctx context.Context, bookingID string) ([]models.FinancialDocument, error)
+}
+
+// AdjustmentServiceInterface defines the contract for admin credit notes and manual charges.
+type AdjustmentServiceInterface interface {
+	// CreateAdjustment raises a credit note or charge against a booking; adjustments above the
+	// approval threshold wait for a second admin.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Booking ID
+	//   - userID: Admin raising the adjustment
+	//   - req: Kind, amount, reason code and notes
+	// Returns:
+	//   - *models.BookingAdjustment: The adjustment, applied or pending approval
+	//   - error: Validation, unknown booking or data access error
+	CreateAdjustment(ctx context.Context, bookingID, userID string, req models.BookingAdjustmentRequest) (*models.BookingAdjustment, error)
+
+	// ReviewAdjustment approves or rejects a pending adjustment as a second admin.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Adjustment ID
+	//   - userID: Reviewing admin, who must not have raised it
+	//   - req: Decision and note
+	// Returns:
+	//   - *models.BookingAdjustment: The applied or rejected adjustment
+	//   - error: Same admin, no longer pending, not found, payment gateway or data access error
+	ReviewAdjustment(ctx context.Context, id, userID string, req models.AdjustmentReviewRequest) (*models.BookingAdjustment, error)
+
+	// ListAdjustments retrieves adjustments for admins.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional status
+	// Returns:
+	//   - []models.BookingAdjustment: Adjustments, oldest first
+	//   - error: Invalid status or data access error
+	ListAdjustments(ctx context.Context, filter models.AdjustmentFilter) ([]models.BookingAdjustment, error)
+
+	// GetBookingAdjustments retrieves the adjustments of a booking for its customer, car owner
+	// or an admin.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Booking ID
+	//   - userID: Authenticated user
+	//   - role: Authenticated user's role
+	// Returns:
+	//   - []models.BookingAdjustment: Adjustments, oldest first
+	//   - error: Unknown booking or data access error
+	GetBookingAdjustments(ctx context.Context, bookingID, userID, role string) ([]models.BookingAdjustment, error)
+}
+
+// StatementServiceInterface defines the contract for customers' payment statements.
+type StatementServiceInterface interface {
+	// RequestStatement renders a statement of the customer's payments and refunds, or queues it
+	// when the period is too long to render during the request.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated customer
+	//   - req: Period and format
+	// Returns:
+	//   - *models.StatementFile: The rendered file, nil when queued
+	//   - *models.PaymentStatement: The queued statement, nil when rendered
+	//   - error: Data access error
+	RequestStatement(ctx context.Context, userID string, req models.StatementRequest) (*models.StatementFile, *models.PaymentStatement, error)
+
+	// GetStatement retrieves a queued statement of the customer.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Statement ID
+	//   - userID: Authenticated customer
+	// Returns:
+	//   - *models.PaymentStatement: The statement and its status
+	//   - error: Not found or data access error
+	GetStatement(ctx context.Context, id, userID string) (*models.PaymentStatement, error)
+
+	// DownloadStatement retrieves the file of a queued statement once rendered.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Statement ID
+	//   - userID: Authenticated customer
+	// Returns:
+	//   - *models.StatementFile: The rendered file
+	//   - error: Not ready, failed, not found or data access error
+	DownloadStatement(ctx context.Context, id, userID string) (*models.StatementFile, error)
+
+	// RenderPendingStatements renders queued statements and notifies their customers.
+	// Parameters:
+	//   - ctx: Job context for cancellation
+	// Returns:
+	//   - error: Error claiming statements; per-statement failures are recorded on the statement
+	RenderPendingStatements(ctx context.Context) error
+}
+
+// SettingServiceInterface defines the contract for operator settings, such as the weights of
+// ranked car listings.
+type SettingServiceInterface interface {
+	// GetRankingWeights retrieves the operator's search ranking weights.
+	// Parameters:
+	//   - ctx: Request context carrying the operator
+	// Returns:
+	//   - *models.RankingWeights: Configured weights, or the defaults when never configured
+	//   - error: Data access error
+	GetRankingWeights(ctx context.Context) (*models.RankingWeights, error)
+
+	// UpdateRankingWeights validates and stores the operator's search ranking weights.
+	// Parameters:
+	//   - ctx: Request context carrying the operator
+	//   - weights: New weights, each between 0 and 10
+	// Returns:
+	//   - *models.RankingWeights: The stored weights
+	//   - error: Validation or data access error
+	UpdateRankingWeights(ctx context.Context, weights models.RankingWeights) (*models.RankingWeights, error)
+
+	// GetDepreciationSettings retrieves the operator's depreciation curves.
+	// Parameters:
+	//   - ctx: Request context carrying the operator
+	// Returns:
+	//   - *models.DepreciationSettings: Configured curves, or the defaults when never configured
+	//   - error: Data access error
+	GetDepreciationSettings(ctx context.Context) (*models.DepreciationSettings, error)
+
+	// UpdateDepreciationSettings validates and stores the operator's depreciation curves.
+	// Parameters:
+	//   - ctx: Request context carrying the operator
+	//   - settings: Default curve and curves by fuel type
+	// Returns:
+	//   - *models.DepreciationSettings: The stored curves
+	//   - error: Validation or data access error
+	UpdateDepreciationSettings(ctx context.Context, settings models.DepreciationSettings) (*models.DepreciationSettings, error)
+}
+
+// FeaturedServiceInterface defines the contract for featured placement, which owners pay for to
+// have a car boosted in ranked listings for a period.
+type FeaturedServiceInterface interface {
+	// PurchaseFeatured prices a period and creates the Razorpay order to pay for it.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID, role: Caller; only the car's owner or an admin may buy placement
+	//   - carID: Car to feature
+	//   - req: Period to feature it for
+	// Returns:
+	//   - *models.FeaturedPurchase: The pending placement and its Razorpay order
+	//   - error: Validation error, unknown or inactive car, overlapping placement, payment
+	//     gateway or data access error
+	PurchaseFeatured(ctx context.Context, userID, role, carID string, req models.FeaturedRequest) (*models.FeaturedPurchase, error)
+
+	// ConfirmFeatured verifies the payment of a placement and starts its boost.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID, role: Caller; only the placement's owner or an admin may confirm it
+	//   - id: Placement ID
+	//   - req: Verification details Razorpay checkout returned
+	// Returns:
+	//   - *models.FeaturedListing: The paid placement
+	//   - error: Unknown placement, mismatched order, failed verification or data access error
+	ConfirmFeatured(ctx context.Context, userID, role, id string, req models.PaymentVerificationRequest) (*models.FeaturedListing, error)
+
+	// GetOwnerFeatured retrieves an owner's placements for reporting.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: ID of the authenticated owner
+	// Returns:
+	//   - []models.FeaturedListing: Placements with their impressions and clicks
+	//   - error: Data access error
+	GetOwnerFeatured(ctx context.Context, ownerID string) ([]models.FeaturedListing, error)
+}
+
+// ProfileServiceInterface defines the contract for public owner profiles shown on car detail
+// pages.
+type ProfileServiceInterface interface {
+	// GetPublicProfile builds the public profile of a car owner.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Owner's unique identifier (UUID)
+	// Returns:
+	//   - *models.PublicProfile: Display name, member-since month, rating, response metrics
+	//     and active listings
+	//   - error: Invalid ID, unknown owner or data access error
+	GetPublicProfile(ctx context.Context, ownerID string) (*models.PublicProfile, error)
+}
+
+// BlockServiceInterface defines the contract for the block list. Owners block renters and
+// renters block owners; a blocked pair cannot book with each other.
+type BlockServiceInterface interface {
+	// BlockUser adds a user to the caller's block list.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: ID of the authenticated user
+	//   - req: User to block
+	// Returns:
+	//   - *models.UserBlock: The block
+	//   - error: Unknown user, the caller themselves, an admin or data access error
+	BlockUser(ctx context.Context, userID string, req models.BlockRequest) (*models.UserBlock, error)
+
+	// UnblockUser removes a user from the caller's block list.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: ID of the authenticated user
+	//   - blockedID: User to unblock
+	// Returns:
+	//   - error: Invalid ID, no such block or data access error
+	UnblockUser(ctx context.Context, userID, blockedID string) error
+
+	// GetBlocks retrieves the caller's block list.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: ID of the authenticated user
+	// Returns:
+	//   - []models.UserBlock: Blocked users, most recent first
+	//   - error: Data access error
+	GetBlocks(ctx context.Context, userID string) ([]models.UserBlock, error)
+}
+
+// MaintenanceServiceInterface defines the contract for the operational runbook: maintenance
+// tasks admins queue to repair derived data, run in the background and kept as an audit trail.
+type MaintenanceServiceInterface interface {
+	// RequestJob queues a maintenance task, or returns the same task already queued or running.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - task: Task to run
+	//   - userID: Admin requesting it
+	//   - req: Optional car, booking or payment to limit the task to
+	// Returns:
+	//   - *models.MaintenanceJob: The queued or active job
+	//   - bool: True when a new job was queued
+	//   - error: Unknown task, invalid target or data access error
+	RequestJob(ctx context.Context, task, userID string, req models.MaintenanceJobRequest) (*models.MaintenanceJob, bool, error)
+
+	// GetJob retrieves a maintenance job.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the job
+	// Returns:
+	//   - *models.MaintenanceJob: The job with its outcome once finished
+	//   - error: Unknown job or data access error
+	GetJob(ctx context.Context, id string) (*models.MaintenanceJob, error)
+
+	// ListJobs retrieves the latest maintenance jobs.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - task: Task to list; every task when empty
+	// Returns:
+	//   - []models.MaintenanceJob: Jobs, newest first
+	//   - error: Unknown task or data access error
+	ListJobs(ctx context.Context, task string) ([]models.MaintenanceJob, error)
+
+	// RunQueuedJobs runs the queued maintenance jobs and records their outcome. Run
+	// periodically by the scheduler.
+	// Parameters:
+	//   - ctx: Context of the scheduled run
+	// Returns:
+	//   - error: Error if queued jobs could not be claimed
+	RunQueuedJobs(ctx context.Context) error
+}
+
+// DebugCaptureServiceInterface defines the contract for sampled request and response captures
+// kept briefly to troubleshoot intermittent client issues.
+type DebugCaptureServiceInterface interface {
+	// SaveCapture stores a sanitized capture taken by the debug capture middleware.
+	// Parameters:
+	//   - ctx: Context of the captured request, detached from its cancellation
+	//   - capture: Redacted request and response with its expiry
+	// Returns:
+	//   - error: Error if the capture could not be stored
+	SaveCapture(ctx context.Context, capture models.DebugCapture) error
+
+	// ListCaptures retrieves one page of unexpired captures, newest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional user, path prefix and minimum status
+	//   - page: Page size and optional cursor parsed from the request
+	// Returns:
+	//   - *models.Page[models.DebugCapture]: Page of captures with the cursor for the next page
+	//   - error: Invalid filter or data access error
+	ListCaptures(ctx context.Context, filter models.DebugCaptureFilter, page models.PageRequest) (*models.Page[models.DebugCapture], error)
+
+	// GetCapture retrieves a single unexpired capture.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the capture
+	// Returns:
+	//   - *models.DebugCapture: The capture
+	//   - error: Unknown or expired capture, or data access error
+	GetCapture(ctx context.Context, id string) (*models.DebugCapture, error)
+
+	// PurgeExpired deletes the captures past their expiry. Run periodically by the scheduler.
+	// Parameters:
+	//   - ctx: Context of the scheduled run
+	// Returns:
+	//   - error: Error if the captures could not be deleted
+	PurgeExpired(ctx context.Context) error
+}
+
+// LoadTestServiceInterface defines the business operations for load test fixtures.
+type LoadTestServiceInterface interface {
+	// CreateFixtures creates disposable owners, active cars, renters and bookings for a load test.
+	// Parameters:
+	//   - ctx: Request context acting for the admin creating the fixtures
+	//   - req: Sizes of the run, with zero values taking their defaults
+	// Returns:
+	//   - *models.LoadTestFixtures: The run with its renters and cars
+	//   - error: Disabled fixtures, invalid sizes, or an entity that could not be created
+	CreateFixtures(ctx context.Context, req models.LoadTestFixtureRequest) (*models.LoadTestFixtures, error)
+
+	// DeleteFixtures deletes a run with its users, cars and bookings.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the run
+	// Returns:
+	//   - error: Unknown run, or data access error
+	DeleteFixtures(ctx context.Context, id string) error
+
+	// PurgeExpired deletes the runs past their expiry. Run periodically by the scheduler.
+	// Parameters:
+	//   - ctx: Context of the scheduled run
+	// Returns:
+	//   - error: Error if the runs could not be deleted
+	PurgeExpired(ctx context.Context) error
+}
+
+// CarImageUploadServiceInterface defines the background job that uploads the images sent with
+// cars to Cloudinary.
+type CarImageUploadServiceInterface interface {
+	// ProcessPendingUploads uploads the due images and adds their URLs to the cars. Failed
+	// attempts are retried later; the owner is told when an image is given up on.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - error: Data access error while claiming uploads
+	ProcessPendingUploads(ctx context.Context) error
+}
+
+// UserServiceInterface defines the contract for managing user accounts, by admins and by users
+// for their own account.
+type UserServiceInterface interface {
+	// ListUsers retrieves one page of users.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - page: Page size and cursor
+	// Returns:
+	//   - *models.Page[models.User]: Users, newest first
+	//   - error: Data access error
+	ListUsers(ctx context.Context, page models.PageRequest) (*models.Page[models.User], error)
+
+	// GetUsersByRole retrieves all users with a role.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - role: owner, renter or admin
+	// Returns:
+	//   - []models.User: Users with the role
+	//   - error: Unknown role or data access error
+	GetUsersByRole(ctx context.Context, role string) ([]models.User, error)
+
+	// GetUserByID retrieves a user.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the user
+	// Returns:
+	//   - *models.User: The user
+	//   - error: User not found or data access error
+	GetUserByID(ctx context.Context, id string) (*models.User, error)
+
+	// UpdateUser replaces a user's account details, including the password.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout, acting for the admin
+	//   - id: Unique identifier of the user
+	//   - req: New account details
+	// Returns:
+	//   - *models.User: The updated user
+	//   - error: Validation error, user not found, e-mail or phone taken, an admin demoting
+	//     themselves, or data access error
+	UpdateUser(ctx context.Context, id string, req models.UserRequest) (*models.User, error)
+
+	// DeleteUser deletes a user.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout, acting for the admin
+	//   - id: Unique identifier of the user
+	// Returns:
+	//   - *models.User: The deleted user
+	//   - error: User not found, user has bookings, an admin deleting themselves, or data access error
+	DeleteUser(ctx context.Context, id string) (*models.User, error)
+
+	// GetCurrentUser retrieves the signed-in user's own account.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	// Returns:
+	//   - *models.User: The user
+	//   - error: User not found or data access error
+	GetCurrentUser(ctx context.Context, userID string) (*models.User, error)
+
+	// UpdateCurrentUser replaces the signed-in user's own account details, including the password.
+	// A new e-mail address or password needs the current password; a new password revokes the
+	// user's refresh tokens.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	//   - req: New account details; the role must be empty or the user's current role
+	// Returns:
+	//   - *models.User: The updated user
+	//   - error: Validation error, a changed role, a missing or wrong current password, e-mail or phone taken, or data access error
+	UpdateCurrentUser(ctx context.Context, userID string, req models.UserRequest) (*models.User, error)
+
+	// UpdateCurrentProfile merges a patch into the signed-in user's profile data.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Signed-in user
+	//   - patch: JSON merge patch of display_name, address, preferences and license_number; null removes a field
+	// Returns:
+	//   - *models.User: The user with the merged profile data
+	//   - error: Validation error for fields users cannot set, or data access error
+	UpdateCurrentProfile(ctx context.Context, userID string, patch map[string]interface{}) (*models.User, error)
+
+	// DeleteCurrentUser closes the signed-in user's own account.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user
+	// Returns:
+	//   - error: User not found, user has bookings, or data access error
+	DeleteCurrentUser(ctx context.Context, userID string) error
+}
+
+// AccountingServiceInterface defines the contract for exporting the ledger to accounting software.
+type AccountingServiceInterface interface {
+	// Export renders the invoices, receipts, credit notes and refunds of a period.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Validated period and format (tally, iif or csv)
+	// Returns:
+	//   - *models.AccountingExportFile: File to download
+	//   - error: Data access or encoding error
+	Export(ctx context.Context, req models.AccountingExportRequest) (*models.AccountingExportFile, error)
+}
+
+// ValuationServiceInterface defines the contract for owners' fleet valuation report.
+type ValuationServiceInterface interface {
+	// GetFleetValuation estimates the current value of an owner's cars.
+	// Parameters:
+	//   - ctx: Request context carrying the operator, whose depreciation curves apply
+	//   - ownerID: Authenticated owner
+	// Returns:
+	//   - *models.FleetValuation: Cars by name with their estimates and totals
+	//   - error: Data access error
+	GetFleetValuation(ctx context.Context, ownerID string) (*models.FleetValuation, error)
+
+	// ExportFleetValuation renders the fleet valuation as CSV.
+	// Parameters:
+	//   - ctx: Request context carrying the operator
+	//   - ownerID: Authenticated owner
+	// Returns:
+	//   - []byte: CSV with one row per car
+	//   - error: Data access or encoding error
+	ExportFleetValuation(ctx context.Context, ownerID string) ([]byte, error)
+
+	// SetCarPurchase records what an owner paid for one of their cars.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Authenticated owner
+	//   - carID: Car bought
+	//   - req: Purchase price and optional date
+	// Returns:
+	//   - *models.CarPurchase: The stored purchase
+	//   - error: Validation error, car not found among the owner's cars, or data access error
+	SetCarPurchase(ctx context.Context, ownerID, carID string, req models.CarPurchaseRequest) (*models.CarPurchase, error)
+}
+
+// PasswordResetServiceInterface defines the contract for resetting forgotten passwords with
+// single-use links sent by e-mail.
+type PasswordResetServiceInterface interface {
+	// RequestPasswordReset e-mails a reset link to the account with the address, if there is one.
+	// Parameters:
+	//   - ctx: Request context carrying the operator the account belongs to
+	//   - req: E-mail address of the account
+	// Returns:
+	//   - error: Validation error for a malformed address; unknown addresses are not reported
+	RequestPasswordReset(ctx context.Context, req models.ForgotPasswordRequest) error
+
+	// ResetPassword sets a new password with a reset token, which cannot be used again.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Token from the reset link and the new password
+	// Returns:
+	//   - error: Validation error, invalid, used or expired token, or data access error
+	ResetPassword(ctx context.Context, req models.ResetPasswordRequest) error
+}
+
+// RefreshTokenServiceInterface defines the contract for the refresh tokens that keep sessions
+// going after their short-lived access tokens expire.
+type RefreshTokenServiceInterface interface {
+	// IssueRefreshToken starts a session for a user who logged in or registered.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - user: The authenticated user
+	// Returns:
+	//   - models.IssuedRefreshToken: The token to hand to the client and its expiry
+	//   - error: Data access error
+	IssueRefreshToken(ctx context.Context, user models.User) (models.IssuedRefreshToken, error)
+
+	// RotateRefreshToken exchanges a refresh token for its successor; the token stops working.
+	// A token presented again after its exchange ends its session.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - token: The refresh token the client holds
+	// Returns:
+	//   - models.User: The user to issue a new access token for, as currently stored
+	//   - models.IssuedRefreshToken: The successor
+	//   - error: Missing, invalid, expired, revoked or reused token, or data access error
+	RotateRefreshToken(ctx context.Context, token string) (models.User, models.IssuedRefreshToken, error)
+
+	// RevokeRefreshToken ends the session of a refresh token at logout.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - token: The refresh token the client holds; unknown tokens are ignored
+	// Returns:
+	//   - error: Data access error
+	RevokeRefreshToken(ctx context.Context, token string) error
+}
+
+// TwoFactorServiceInterface defines the contract for TOTP two-factor authentication: setting
+// it up with an authenticator app and checking the second factor at login.
+type TwoFactorServiceInterface interface {
+	// BeginSetup generates a TOTP secret for a user, which is enforced once VerifySetup confirms it.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Unique identifier of the user
+	// Returns:
+	//   - *models.TwoFactorSetup: Secret and otpauth URL for the authenticator app
+	//   - error: Already enabled, user not found or data access error
+	BeginSetup(ctx context.Context, userID string) (*models.TwoFactorSetup, error)
+
+	// VerifySetup confirms a pending secret with a code from the authenticator app and enables
+	// two-factor authentication.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Unique identifier of the user
+	//   - code: Six-digit code from the app
+	// Returns:
+	//   - []string: Single-use backup codes, returned only this once
+	//   - error: Invalid code, setup not started, already enabled or data access error
+	VerifySetup(ctx context.Context, userID string, code string) ([]string, error)
+
+	// VerifyLogin checks the second factor of a user who logged in with their password. Users
+	// without two-factor authentication pass without a code.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - user: User whose password was accepted
+	//   - code: Code from the authenticator app or a backup code
+	// Returns:
+	//   - error: Code required, invalid or already used, or data access error
+	VerifyLogin(ctx context.Context, user models.User, code string) error
+}
+
+// OnboardingServiceInterface defines the contract for reporting an owner's progress through
+// onboarding to guided onboarding UIs.
+type OnboardingServiceInterface interface {
+	// GetProgress retrieves an owner's onboarding steps with the next step to take.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Unique identifier of the owner
+	// Returns:
+	//   - *models.OnboardingProgress: Profile, payout, first car and identity steps, each with a hint and link while open
+	//   - error: User not found or data access error
+	GetProgress(ctx context.Context, ownerID string) (*models.OnboardingProgress, error)
+}
+
+// SmartLockProviderInterface defines the contract for a smart-lock provider integration that
+// issues and revokes digital keys to the locks fitted in cars.
+type SmartLockProviderInterface interface {
+	// Name identifies the provider; cars' locks are linked with this name.
+	// Returns:
+	//   - string: Provider name, e.g. "http"
+	Name() string
+
+	// IssueKey asks the provider for a key that opens a lock during a booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - grant: Booking, lock and the window the key is valid in
+	// Returns:
+	//   - string: The provider's identifier of the key
+	//   - error: Error if the provider refuses or cannot be reached
+	IssueKey(ctx context.Context, grant models.DigitalKeyGrant) (string, error)
+
+	// RevokeKey withdraws a key before it expires. Keys the provider no longer knows count as revoked.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - lockRef: The provider's identifier of the lock
+	//   - providerKeyID: The provider's identifier of the key
+	// Returns:
+	//   - error: Error if the provider refuses or cannot be reached
+	RevokeKey(ctx context.Context, lockRef, providerKeyID string) error
+}
+
+// DigitalKeyListenerInterface defines the hook the booking lifecycle calls to hand out and
+// withdraw digital keys. Implementations never fail the calling flow; errors are only logged.
+type DigitalKeyListenerInterface interface {
+	// IssueBookingKey issues a time-boxed key to the car's smart lock for a confirmed booking.
+	// Cars without a smart lock are skipped.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - booking: The confirmed booking
+	IssueBookingKey(ctx context.Context, booking models.Booking)
+
+	// RevokeBookingKeys revokes the keys of a completed or cancelled booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - booking: The completed or cancelled booking
+	RevokeBookingKeys(ctx context.Context, booking models.Booking)
+}
+
+// SmartLockServiceInterface defines the contract for keyless entry: the smart locks fitted in
+// cars, the digital keys issued for bookings and the lock events logged against them.
+type SmartLockServiceInterface interface {
+	DigitalKeyListenerInterface
+
+	// GetSmartLock returns the lock fitted in a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - *models.SmartLock: The lock
+	//   - error: Error if the car or its lock is not found, or data access fails
+	GetSmartLock(ctx context.Context, userID, role, carID string) (*models.SmartLock, error)
+
+	// SetSmartLock links a car to its lock at a configured provider.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	//   - req: Provider and lock reference
+	// Returns:
+	//   - *models.SmartLock: The stored link
+	//   - error: Validation error, unknown provider, lock linked to another car, or data access error
+	SetSmartLock(ctx context.Context, userID, role, carID string, req models.SmartLockRequest) (*models.SmartLock, error)
+
+	// DeleteSmartLock unlinks a car from its lock.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the car unless admin
+	//   - role: Role of the authenticated user
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - error: Error if the car or its lock is not found, or data access fails
+	DeleteSmartLock(ctx context.Context, userID, role, carID string) error
+
+	// GetBookingDigitalKeys lists the keys requested for a booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must be the booking's customer or owner unless admin
+	//   - role: Role of the authenticated user
+	//   - bookingID: Unique identifier of the booking
+	// Returns:
+	//   - []models.DigitalKey: The keys, oldest first
+	//   - error: Error if the booking is not found or data access fails
+	GetBookingDigitalKeys(ctx context.Context, userID, role, bookingID string) ([]models.DigitalKey, error)
+
+	// ReissueBookingKey revokes a booking's current key and issues a new one, e.g. after the
+	// provider failed or the car's lock was replaced.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must own the booked car unless admin
+	//   - role: Role of the authenticated user
+	//   - bookingID: Unique identifier of the booking
+	// Returns:
+	//   - *models.DigitalKey: The new key, which may have failed
+	//   - error: Error if the booking is not found or not confirmed or in progress, the car has no lock, or data access fails
+	ReissueBookingKey(ctx context.Context, userID, role, bookingID string) (*models.DigitalKey, error)
+
+	// GetBookingLockEvents lists the lock and unlock events logged against a booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Authenticated user; must be the booking's customer or owner unless admin
+	//   - role: Role of the authenticated user
+	//   - bookingID: Unique identifier of the booking
+	// Returns:
+	//   - []models.LockEvent: The events, oldest first
+	//   - error: Error if the booking is not found or data access fails
+	GetBookingLockEvents(ctx context.Context, userID, role, bookingID string) ([]models.LockEvent, error)
+
+	// HandleWebhook verifies and logs a lock or unlock event posted by a provider.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - signature: Hex HMAC-SHA256 of the body with SMART_LOCK_WEBHOOK_SECRET
+	//   - body: Raw request body
+	// Returns:
+	//   - error: Signature, payload or configuration error, or data access error
+	HandleWebhook(ctx context.Context, signature string, body []byte) error
+}
+
+// IncidentServiceInterface defines the contract for accidents and other incidents reported
+// against active bookings, tracked until they are resolved.
+type IncidentServiceInterface interface {
+	// ReportIncident records an incident against an active booking and notifies the other party
+	// and admins.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: The booking's customer or owner reporting it
+	//   - role: The user's role
+	//   - bookingID: Booking ID
+	//   - req: Kind, description, time, place, police report number and photos
+	// Returns:
+	//   - *models.Incident: The reported incident
+	//   - error: Validation, inactive booking, not found or data access error
+	ReportIncident(ctx context.Context, userID, role, bookingID string, req models.IncidentRequest) (*models.Incident, error)
+
+	// GetBookingIncidents retrieves the incidents reported against a booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: The booking's customer or owner, or an admin
+	//   - role: The user's role
+	//   - bookingID: Booking ID
+	// Returns:
+	//   - []models.Incident: Incidents, oldest first
+	//   - error: Not found or data access error
+	GetBookingIncidents(ctx context.Context, userID, role, bookingID string) ([]models.Incident, error)
+
+	// GetIncident retrieves an incident with its documents.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: The booking's customer or owner, or an admin
+	//   - role: The user's role
+	//   - id: Incident ID
+	// Returns:
+	//   - *models.Incident: The incident
+	//   - error: Not found or data access error
+	GetIncident(ctx context.Context, userID, role, id string) (*models.Incident, error)
+
+	// AddIncidentDocument attaches an insurance, police or other document to an open incident.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: The booking's customer or owner, or an admin
+	//   - role: The user's role
+	//   - id: Incident ID
+	//   - req: Kind, description and document URL
+	// Returns:
+	//   - *models.Incident: The incident with all its documents
+	//   - error: Validation, resolved incident, not found or data access error
+	AddIncidentDocument(ctx context.Context, userID, role, id string, req models.IncidentDocumentRequest) (*models.Incident, error)
+
+	// UpdateIncidentStatus moves an incident on and notifies the customer and owner.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: The car's owner or an admin
+	//   - role: The user's role
+	//   - id: Incident ID
+	//   - req: New status, claim number and notes
+	// Returns:
+	//   - *models.Incident: The updated incident with its documents
+	//   - error: Validation, transition, not found or data access error
+	UpdateIncidentStatus(ctx context.Context, userID, role, id string, req models.IncidentStatusRequest) (*models.Incident, error)
+
+	// ListIncidents retrieves incidents for admins.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional status
+	// Returns:
+	//   - []models.Incident: Incidents, the longest outstanding first
+	//   - error: Invalid status or data access error
+	ListIncidents(ctx context.Context, filter models.IncidentFilter) ([]models.Incident, error)
+}
+
+// ChecklistServiceInterface defines the contract for the checklist answered at checkout and check-in.
+type ChecklistServiceInterface interface {
+	// SaveTemplate validates and stores the next version of the checklist.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Checklist items grouped by section
+	//   - createdBy: Admin saving the version
+	// Returns:
+	//   - *models.ChecklistTemplate: The stored version
+	//   - error: Validation, concurrent save or data access error
+	SaveTemplate(ctx context.Context, req models.ChecklistTemplateRequest, createdBy string) (*models.ChecklistTemplate, error)
+
+	// GetCurrentTemplate retrieves the version new checkouts answer.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - *models.ChecklistTemplate: The current version
+	//   - error: Not found or data access error
+	GetCurrentTemplate(ctx context.Context) (*models.ChecklistTemplate, error)
+
+	// GetTemplateVersion retrieves one version of the checklist.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - version: Checklist version
+	// Returns:
+	//   - *models.ChecklistTemplate: The version
+	//   - error: Not found or data access error
+	GetTemplateVersion(ctx context.Context, version int) (*models.ChecklistTemplate, error)
+
+	// ListTemplateVersions retrieves the history of the checklist.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.ChecklistTemplate: Versions, newest first
+	//   - error: Data access error
+	ListTemplateVersions(ctx context.Context) ([]models.ChecklistTemplate, error)
+}
+
+// BotCheckInterface defines the contract for checking that a registration or password reset
+// request comes from a person, before it costs an SMS or e-mail.
+type BotCheckInterface interface {
+	// Name identifies the check.
+	// Returns:
+	//   - string: Provider name, e.g. "hcaptcha", "turnstile", "pow" or "none"
+	Name() string
+
+	// Challenge describes what clients must do to pass the check.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - *models.BotChallenge: Widget site key, or a proof-of-work puzzle
+	//   - error: Error if a challenge cannot be generated
+	Challenge(ctx context.Context) (*models.BotChallenge, error)
+
+	// Verify checks the token a client sent after passing the challenge. Tokens work once.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - token: CAPTCHA response token, or proof-of-work challenge and nonce
+	//   - remoteIP: Client IP, which CAPTCHA providers compare with the one that solved it
+	// Returns:
+	//   - error: "bot check" error if the token is missing or rejected, or error if the provider cannot be reached
+	Verify(ctx context.Context, token, remoteIP string) error
 }
@@ -0,0 +1,65 @@
+package kyc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// KYCService implements the KYCServiceInterface. Admins record the dates they read from a
+// renter's documents; bookings check them against each car's eligibility rules.
+type KYCService struct {
+	kycStore store.KYCStoreInterface
+}
+
+// NewKYCService creates a new KYC service
+func NewKYCService(kycStore store.KYCStoreInterface) *KYCService {
+	return &KYCService{
+		kycStore: kycStore,
+	}
+}
+
+// GetVerifiedKYC retrieves the verified identity data of a user
+func (s *KYCService) GetVerifiedKYC(ctx context.Context, userID string) (*models.VerifiedKYC, error) {
+	tracer := otel.Tracer("KYCService")
+	ctx, span := tracer.Start(ctx, "GetVerifiedKYC-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	kyc, err := s.kycStore.GetVerifiedKYC(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kyc, nil
+}
+
+// VerifyKYC validates and records the dates an admin read from a user's documents
+func (s *KYCService) VerifyKYC(ctx context.Context, adminID, userID string, req models.KYCRequest) (*models.VerifiedKYC, error) {
+	tracer := otel.Tracer("KYCService")
+	ctx, span := tracer.Start(ctx, "VerifyKYC-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	dateOfBirth, licenseIssuedOn, err := models.ParseKYCRequest(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	kyc, err := s.kycStore.UpsertVerifiedKYC(ctx, userID, adminID, dateOfBirth, licenseIssuedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kyc, nil
+}
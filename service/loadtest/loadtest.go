@@ -0,0 +1,274 @@
+package loadtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/identity"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// fixtureEmailDomain marks the users of load test runs; .invalid never resolves, so no mail
+// sent to them leaves the outbox
+const fixtureEmailDomain = "loadtest.carzone.invalid"
+
+// fixtureCar is a listing load test cars are made from. Cars rotate through these so brand
+// and city filters select a share of the fixtures, as they would of real listings.
+type fixtureCar struct {
+	brand, model, fuelType string
+	engine                 models.Engine
+	city, state            string
+	price                  float64
+}
+
+var fixtureCars = []fixtureCar{
+	{brand: "Honda", model: "City", fuelType: "Petrol", engine: models.Engine{EngineSize: 1.5, Cylinders: 4, Horsepower: 119, Transmission: "CVT"}, city: "Bengaluru", state: "Karnataka", price: 2200},
+	{brand: "Hyundai", model: "Creta", fuelType: "Diesel", engine: models.Engine{EngineSize: 1.5, Cylinders: 4, Horsepower: 114, Transmission: "Automatic"}, city: "Bengaluru", state: "Karnataka", price: 3200},
+	{brand: "Mahindra", model: "Thar", fuelType: "Diesel", engine: models.Engine{EngineSize: 2.2, Cylinders: 4, Horsepower: 130, Transmission: "Manual"}, city: "Pune", state: "Maharashtra", price: 3500},
+	{brand: "MG", model: "ZS EV", fuelType: "Electric", engine: models.Engine{EngineSize: 0.1, Cylinders: 1, Horsepower: 174, Transmission: "Automatic"}, city: "Pune", state: "Maharashtra", price: 4000},
+}
+
+// LoadTestService implements the LoadTestServiceInterface. Fixtures are created through the
+// auth, car and booking services, so they pass the same validation as API requests, and are
+// recorded as a run so they can be deleted together.
+type LoadTestService struct {
+	loadTestStore  store.LoadTestStoreInterface
+	authService    service.AuthServiceInterface
+	carService     service.CarServiceInterface
+	bookingService service.BookingServiceInterface
+	enabled        bool
+}
+
+// NewLoadTestService creates a new load test service. Fixtures can only be created when
+// enabled, which main sets from LOADTEST_ENABLED; existing runs can always be deleted.
+func NewLoadTestService(loadTestStore store.LoadTestStoreInterface, authService service.AuthServiceInterface, carService service.CarServiceInterface, bookingService service.BookingServiceInterface, enabled bool) *LoadTestService {
+	return &LoadTestService{
+		loadTestStore:  loadTestStore,
+		authService:    authService,
+		carService:     carService,
+		bookingService: bookingService,
+		enabled:        enabled,
+	}
+}
+
+// CreateFixtures creates owners with active cars and renters with pending bookings of them,
+// as sized by the request. ctx must act for the admin creating them, who approves the cars.
+// If any entity cannot be created, those created so far are deleted again.
+func (s *LoadTestService) CreateFixtures(ctx context.Context, req models.LoadTestFixtureRequest) (*models.LoadTestFixtures, error) {
+	tracer := otel.Tracer("LoadTestService")
+	ctx, span := tracer.Start(ctx, "CreateFixtures-Service")
+	defer span.End()
+
+	if !s.enabled {
+		return nil, errors.New("load test fixtures are disabled, set LOADTEST_ENABLED=true to create them")
+	}
+	admin, ok := identity.FromContext(ctx)
+	if !ok || !admin.IsAdmin() {
+		return nil, errors.New("only admins can create load test fixtures")
+	}
+	adminID, err := uuid.Parse(admin.ID)
+	if err != nil {
+		return nil, errors.New("only admins can create load test fixtures")
+	}
+	req.ApplyDefaults()
+	ttl, err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	fixtures := &models.LoadTestFixtures{Run: models.LoadTestRun{
+		ID:        uuid.New(),
+		CreatedBy: adminID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}}
+	if err := s.populate(ctx, req, fixtures); err != nil {
+		s.discard(ctx, fixtures.Run)
+		return nil, err
+	}
+	if err := s.loadTestStore.CreateLoadTestRun(ctx, fixtures.Run); err != nil {
+		s.discard(ctx, fixtures.Run)
+		return nil, err
+	}
+
+	log.Printf("Created load test run %s: %d users, %d cars, %d bookings", fixtures.Run.ID,
+		len(fixtures.Run.UserIDs), len(fixtures.Run.CarIDs), fixtures.Bookings)
+	return fixtures, nil
+}
+
+// populate creates the users, cars and bookings of a run, recording each in fixtures as it is
+// created
+func (s *LoadTestService) populate(ctx context.Context, req models.LoadTestFixtureRequest, fixtures *models.LoadTestFixtures) error {
+	run := fixtures.Run.ID.String()[:8]
+	password, err := randomPassword()
+	if err != nil {
+		return err
+	}
+
+	owners := make([]models.User, 0, req.Owners)
+	for i := 0; i < req.Owners; i++ {
+		owner, err := s.createUser(ctx, fixtures, run, "owner", i, password)
+		if err != nil {
+			return fmt.Errorf("owner %d: %w", i+1, err)
+		}
+		owners = append(owners, owner)
+	}
+	for i := 0; i < req.Renters; i++ {
+		renter, err := s.createUser(ctx, fixtures, run, "renter", i, password)
+		if err != nil {
+			return fmt.Errorf("renter %d: %w", i+1, err)
+		}
+		fixtures.Renters = append(fixtures.Renters, renter)
+	}
+
+	for i, owner := range owners {
+		for j := 0; j < req.CarsPerOwner; j++ {
+			number := i*req.CarsPerOwner + j
+			car, err := s.createCar(ctx, fixtures, run, owner, number)
+			if err != nil {
+				return fmt.Errorf("car %d: %w", number+1, err)
+			}
+			fixtures.Cars = append(fixtures.Cars, *car)
+		}
+	}
+
+	// Each car's bookings are a week apart, so none of them conflict, and spread across renters
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for i, car := range fixtures.Cars {
+		for k := 0; k < req.BookingsPerCar; k++ {
+			renter := fixtures.Renters[(i*req.BookingsPerCar+k)%len(fixtures.Renters)]
+			start := today.AddDate(0, 0, 30+7*k).Add(10 * time.Hour)
+			_, err := s.bookingService.CreateBooking(identity.WithUser(ctx, renter.ID.String(), renter.Role), models.BookingRequest{
+				CustomerID: renter.ID,
+				CarID:      car.ID,
+				OwnerID:    *car.OwnerID,
+				StartDate:  start,
+				EndDate:    start.AddDate(0, 0, 3),
+				Notes:      "Load test " + run,
+			})
+			if err != nil {
+				return fmt.Errorf("booking %d of %s: %w", k+1, car.Name, err)
+			}
+			fixtures.Bookings++
+		}
+	}
+	return nil
+}
+
+// createUser registers a user of the run and records its ID
+func (s *LoadTestService) createUser(ctx context.Context, fixtures *models.LoadTestFixtures, run, role string, n int, password string) (models.User, error) {
+	email := fmt.Sprintf("lt-%s-%s-%d@%s", run, role, n+1, fixtureEmailDomain)
+	err := s.authService.RegisterUser(ctx, models.UserRequest{
+		Email:    email,
+		Password: password,
+		UserName: fmt.Sprintf("lt_%s_%s_%d", run, role, n+1),
+		Phone:    fixturePhone(fixtures.Run.ID, len(fixtures.Run.UserIDs)),
+		Role:     role,
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+	user, err := s.authService.LoginUser(ctx, models.LoginRequest{Email: email, Password: password})
+	if err != nil {
+		return models.User{}, err
+	}
+	fixtures.Run.UserIDs = append(fixtures.Run.UserIDs, user.ID)
+	return user, nil
+}
+
+// createCar lists a car for an owner of the run, has the admin in ctx approve it and records
+// its ID
+func (s *LoadTestService) createCar(ctx context.Context, fixtures *models.LoadTestFixtures, run string, owner models.User, n int) (*models.Car, error) {
+	template := fixtureCars[n%len(fixtureCars)]
+	created, err := s.carService.CreateCar(identity.WithUser(ctx, owner.ID.String(), owner.Role), models.CarRequest{
+		OwnerID:         &owner.ID,
+		Name:            fmt.Sprintf("Load Test %s #%d", run, n+1),
+		Brand:           template.brand,
+		Model:           template.model,
+		Year:            2022,
+		FuelType:        template.fuelType,
+		Engine:          template.engine,
+		LocationCity:    template.city,
+		LocationState:   template.state,
+		LocationCountry: "India",
+		Price:           template.price,
+		Status:          models.CarStatusPendingReview,
+		Features:        map[string]interface{}{"air_conditioning": true},
+		Description:     "Disposable car of load test run " + run,
+		Images:          []string{"https://placehold.co/1200x800/png?text=Load+Test"},
+		Mileage:         10000 + n,
+	})
+	if err != nil {
+		return nil, err
+	}
+	fixtures.Run.CarIDs = append(fixtures.Run.CarIDs, created.ID)
+
+	return s.carService.UpdateCarStatus(ctx, created.ID.String(), models.CarStatusActive, true)
+}
+
+// discard deletes the entities of a run that could not be completed
+func (s *LoadTestService) discard(ctx context.Context, run models.LoadTestRun) {
+	ctx = context.WithoutCancel(ctx)
+	if err := s.loadTestStore.CreateLoadTestRun(ctx, run); err != nil {
+		log.Printf("Failed to record incomplete load test run %s for deletion: %v", run.ID, err)
+		return
+	}
+	if err := s.loadTestStore.DeleteLoadTestRun(ctx, run.ID.String()); err != nil {
+		log.Printf("Failed to delete incomplete load test run %s: %v", run.ID, err)
+	}
+}
+
+// DeleteFixtures deletes a run with its users, cars and bookings
+func (s *LoadTestService) DeleteFixtures(ctx context.Context, id string) error {
+	tracer := otel.Tracer("LoadTestService")
+	ctx, span := tracer.Start(ctx, "DeleteFixtures-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return errors.New("no load test run found with the given ID")
+	}
+	return s.loadTestStore.DeleteLoadTestRun(ctx, id)
+}
+
+// PurgeExpired deletes the runs past their expiry. Run periodically by the scheduler.
+func (s *LoadTestService) PurgeExpired(ctx context.Context) error {
+	tracer := otel.Tracer("LoadTestService")
+	ctx, span := tracer.Start(ctx, "PurgeExpired-Service")
+	defer span.End()
+
+	purged, err := s.loadTestStore.DeleteExpiredLoadTestRuns(ctx)
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("Purged %d expired load test runs", purged)
+	}
+	return nil
+}
+
+// fixturePhone returns the phone number of the n-th user of a run. Phone numbers are unique
+// across users, so six digits come from the run and three count its users.
+func fixturePhone(run uuid.UUID, n int) string {
+	return fmt.Sprintf("+919%06d%03d", binary.BigEndian.Uint32(run[:4])%1000000, n+1)
+}
+
+// randomPassword returns the password of a run's users; nobody signs in with it, as tokens
+// are issued with the targets
+func randomPassword() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("failed to generate a password")
+	}
+	return hex.EncodeToString(b), nil
+}
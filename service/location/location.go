@@ -0,0 +1,214 @@
+package location
+
+import (
+	"context"
+	"errors"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// LocationService implements the LocationServiceInterface for pickup/drop-off points
+type LocationService struct {
+	locationStore store.LocationStoreInterface
+	carStore      store.CarStoreInterface
+}
+
+// NewLocationService creates a new location service
+func NewLocationService(locationStore store.LocationStoreInterface, carStore store.CarStoreInterface) *LocationService {
+	return &LocationService{
+		locationStore: locationStore,
+		carStore:      carStore,
+	}
+}
+
+// CreateLocation validates and stores a new location
+func (s *LocationService) CreateLocation(ctx context.Context, req models.LocationRequest) (*models.Location, error) {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "CreateLocation-Service")
+	defer span.End()
+
+	if err := models.ValidateLocationRequest(req); err != nil {
+		return nil, err
+	}
+
+	location, err := s.locationStore.CreateLocation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+// GetLocationByID retrieves a location
+func (s *LocationService) GetLocationByID(ctx context.Context, id string) (*models.Location, error) {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "GetLocationByID-Service")
+	defer span.End()
+
+	location, err := s.locationStore.GetLocationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+// ListLocations lists locations, optionally in one city; inactive ones are included only on request
+func (s *LocationService) ListLocations(ctx context.Context, city string, includeInactive bool) ([]models.Location, error) {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "ListLocations-Service")
+	defer span.End()
+
+	return s.locationStore.ListLocations(ctx, city, !includeInactive)
+}
+
+// UpdateLocation validates and replaces the details of a location
+func (s *LocationService) UpdateLocation(ctx context.Context, id string, req models.LocationRequest) (*models.Location, error) {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "UpdateLocation-Service")
+	defer span.End()
+
+	if err := models.ValidateLocationRequest(req); err != nil {
+		return nil, err
+	}
+
+	location, err := s.locationStore.UpdateLocation(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+// DeleteLocation removes a location
+func (s *LocationService) DeleteLocation(ctx context.Context, id string) error {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "DeleteLocation-Service")
+	defer span.End()
+
+	return s.locationStore.DeleteLocation(ctx, id)
+}
+
+// AttachCar makes a car available at a location; only the car's owner or an admin may do so
+func (s *LocationService) AttachCar(ctx context.Context, userID, role, carID, locationID string) error {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "AttachCar-Service")
+	defer span.End()
+
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return err
+	}
+
+	return s.locationStore.AttachCar(ctx, carID, locationID)
+}
+
+// DetachCar removes a car from a location; only the car's owner or an admin may do so
+func (s *LocationService) DetachCar(ctx context.Context, userID, role, carID, locationID string) error {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "DetachCar-Service")
+	defer span.End()
+
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return err
+	}
+
+	return s.locationStore.DetachCar(ctx, carID, locationID)
+}
+
+// GetCarLocations lists the locations a car can be picked up from or returned to
+func (s *LocationService) GetCarLocations(ctx context.Context, carID string) ([]models.Location, error) {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "GetCarLocations-Service")
+	defer span.End()
+
+	return s.locationStore.GetLocationsByCarID(ctx, carID)
+}
+
+// GetDeliveryOption retrieves a car's door delivery settings
+func (s *LocationService) GetDeliveryOption(ctx context.Context, carID string) (*models.DeliveryOption, error) {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "GetDeliveryOption-Service")
+	defer span.End()
+
+	option, err := s.locationStore.GetDeliveryOption(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &option, nil
+}
+
+// UpdateDeliveryOption stores a car's door delivery settings; only the car's owner or an admin may do so
+func (s *LocationService) UpdateDeliveryOption(ctx context.Context, userID, role, carID string, req models.DeliveryOptionRequest) (*models.DeliveryOption, error) {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "UpdateDeliveryOption-Service")
+	defer span.End()
+
+	if err := models.ValidateDeliveryOptionRequest(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return nil, err
+	}
+
+	option, err := s.locationStore.UpsertDeliveryOption(ctx, carID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &option, nil
+}
+
+// ListRelocationFees lists the one-way fee matrix
+func (s *LocationService) ListRelocationFees(ctx context.Context) ([]models.RelocationFee, error) {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "ListRelocationFees-Service")
+	defer span.End()
+
+	return s.locationStore.ListRelocationFees(ctx)
+}
+
+// SetRelocationFee validates and stores the fee of a city pair
+func (s *LocationService) SetRelocationFee(ctx context.Context, req models.RelocationFeeRequest) (*models.RelocationFee, error) {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "SetRelocationFee-Service")
+	defer span.End()
+
+	if err := models.ValidateRelocationFeeRequest(req); err != nil {
+		return nil, err
+	}
+
+	fee, err := s.locationStore.UpsertRelocationFee(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fee, nil
+}
+
+// DeleteRelocationFee removes a city pair from the one-way fee matrix
+func (s *LocationService) DeleteRelocationFee(ctx context.Context, id string) error {
+	tracer := otel.Tracer("LocationService")
+	ctx, span := tracer.Start(ctx, "DeleteRelocationFee-Service")
+	defer span.End()
+
+	return s.locationStore.DeleteRelocationFee(ctx, id)
+}
+
+// authorizeCar allows admins and the car's owner; other users see the car as missing
+func (s *LocationService) authorizeCar(ctx context.Context, userID, role, carID string) error {
+	car, err := s.carStore.GetCarByID(ctx, carID)
+	if err != nil {
+		return err
+	}
+	if role == "admin" {
+		return nil
+	}
+	if car.OwnerID == nil || car.OwnerID.String() != userID {
+		return errors.New("no car found with the given ID")
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Package maintenance runs the operational runbook: tasks admins queue to repair data that has
+// drifted from the rules deriving it, such as a car left listed while retired or a payment
+// Razorpay settled without telling CarZone. Tasks run in the background and every run is kept
+// as an audit trail of who repaired what. Tasks are idempotent, so running one twice is safe,
+// and a task already queued or running for the same target is not queued again.
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// Jobs claimed per run of the job runner, how long a claim lasts before another instance may
+// take the job over, and how many jobs are listed
+const (
+	runBatchSize  = 5
+	runClaimStale = 30 * time.Minute
+	listLimit     = 50
+)
+
+// MaintenanceService implements the MaintenanceServiceInterface
+type MaintenanceService struct {
+	maintenanceStore store.MaintenanceStoreInterface
+	carStore         store.CarStoreInterface
+	userStore        store.UserStoreInterface
+	bookingService   service.BookingServiceInterface
+	paymentService   service.PaymentServiceInterface
+}
+
+// NewMaintenanceService creates a new maintenance service
+func NewMaintenanceService(maintenanceStore store.MaintenanceStoreInterface, carStore store.CarStoreInterface, userStore store.UserStoreInterface, bookingService service.BookingServiceInterface, paymentService service.PaymentServiceInterface) *MaintenanceService {
+	return &MaintenanceService{
+		maintenanceStore: maintenanceStore,
+		carStore:         carStore,
+		userStore:        userStore,
+		bookingService:   bookingService,
+		paymentService:   paymentService,
+	}
+}
+
+// RequestJob queues a maintenance task for the caller's operator, or every operator for a
+// platform admin. The same task already queued or running for the target is returned instead.
+func (s *MaintenanceService) RequestJob(ctx context.Context, task, userID string, req models.MaintenanceJobRequest) (*models.MaintenanceJob, bool, error) {
+	tracer := otel.Tracer("MaintenanceService")
+	ctx, span := tracer.Start(ctx, "RequestJob-Service")
+	defer span.End()
+
+	maintenanceTask, err := models.ParseMaintenanceTask(task)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := req.Validate(maintenanceTask); err != nil {
+		return nil, false, err
+	}
+	requestedBy, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, false, errors.New("session does not identify a user")
+	}
+
+	job, created, err := s.maintenanceStore.CreateJob(ctx, models.MaintenanceJob{
+		Task:        maintenanceTask,
+		TargetID:    req.TargetID,
+		OperatorID:  tenant.Scope(ctx),
+		RequestedBy: requestedBy,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if created {
+		log.Printf("Admin %s queued maintenance job %s (%s)", userID, job.ID, job.Task)
+	}
+
+	return &job, created, nil
+}
+
+// GetJob retrieves a maintenance job
+func (s *MaintenanceService) GetJob(ctx context.Context, id string) (*models.MaintenanceJob, error) {
+	tracer := otel.Tracer("MaintenanceService")
+	ctx, span := tracer.Start(ctx, "GetJob-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid maintenance job ID")
+	}
+
+	job, err := s.maintenanceStore.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListJobs retrieves the latest maintenance jobs, optionally of one task
+func (s *MaintenanceService) ListJobs(ctx context.Context, task string) ([]models.MaintenanceJob, error) {
+	tracer := otel.Tracer("MaintenanceService")
+	ctx, span := tracer.Start(ctx, "ListJobs-Service")
+	defer span.End()
+
+	var maintenanceTask models.MaintenanceTask
+	if task != "" {
+		var err error
+		if maintenanceTask, err = models.ParseMaintenanceTask(task); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.maintenanceStore.ListJobs(ctx, maintenanceTask, listLimit)
+}
+
+// RunQueuedJobs runs the queued maintenance jobs, each limited to the operator it was
+// requested for, and records how many records each checked and repaired or why it failed
+func (s *MaintenanceService) RunQueuedJobs(ctx context.Context) error {
+	jobs, err := s.maintenanceStore.ClaimQueuedJobs(ctx, runBatchSize, runClaimStale)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		jobCtx := ctx
+		if job.OperatorID != nil {
+			jobCtx = tenant.WithOperator(ctx, *job.OperatorID)
+		}
+
+		status, jobError := models.MaintenanceJobSucceeded, ""
+		result, err := s.run(jobCtx, job)
+		if err != nil {
+			log.Printf("Maintenance job %s (%s) failed: %v", job.ID, job.Task, err)
+			status, jobError = models.MaintenanceJobFailed, err.Error()
+		} else {
+			log.Printf("Maintenance job %s (%s) checked %d records and changed %d", job.ID, job.Task, result.Examined, result.Changed)
+		}
+
+		if err := s.maintenanceStore.CompleteJob(ctx, job.ID, status, result, jobError); err != nil {
+			log.Printf("Failed to record outcome of maintenance job %s: %v", job.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// run performs the task of a maintenance job
+func (s *MaintenanceService) run(ctx context.Context, job models.MaintenanceJob) (models.MaintenanceResult, error) {
+	switch job.Task {
+	case models.MaintenanceTaskRebuildCarAvailability:
+		return s.carStore.RebuildListedFlags(ctx, job.TargetID)
+	case models.MaintenanceTaskRecomputeBookingTotals:
+		return s.bookingService.RecomputeBookingTotals(ctx, job.TargetID)
+	case models.MaintenanceTaskResyncPayment:
+		if job.TargetID == nil {
+			return models.MaintenanceResult{}, errors.New("no payment to resync")
+		}
+		return s.paymentService.ResyncPayment(ctx, job.TargetID.String())
+	case models.MaintenanceTaskReindexSearch:
+		return s.userStore.ReindexPhoneSearch(ctx)
+	}
+	return models.MaintenanceResult{}, errors.New("unknown maintenance task")
+}
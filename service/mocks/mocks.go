@@ -0,0 +1,5173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/PrateekKumar15/CarZone/models"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCarServiceInterface is a mock of CarServiceInterface interface.
+type MockCarServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCarServiceInterfaceMockRecorder is the mock recorder for MockCarServiceInterface.
+type MockCarServiceInterfaceMockRecorder struct {
+	mock *MockCarServiceInterface
+}
+
+// NewMockCarServiceInterface creates a new mock instance.
+func NewMockCarServiceInterface(ctrl *gomock.Controller) *MockCarServiceInterface {
+	mock := &MockCarServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCarServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarServiceInterface) EXPECT() *MockCarServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateCar mocks base method.
+func (m *MockCarServiceInterface) CreateCar(ctx context.Context, carReq models.CarRequest) (*models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCar", ctx, carReq)
+	ret0, _ := ret[0].(*models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCar indicates an expected call of CreateCar.
+func (mr *MockCarServiceInterfaceMockRecorder) CreateCar(ctx, carReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCar", reflect.TypeOf((*MockCarServiceInterface)(nil).CreateCar), ctx, carReq)
+}
+
+// DeleteCar mocks base method.
+func (m *MockCarServiceInterface) DeleteCar(ctx context.Context, id string) (*models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCar", ctx, id)
+	ret0, _ := ret[0].(*models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCar indicates an expected call of DeleteCar.
+func (mr *MockCarServiceInterfaceMockRecorder) DeleteCar(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCar", reflect.TypeOf((*MockCarServiceInterface)(nil).DeleteCar), ctx, id)
+}
+
+// GetCarByBrand mocks base method.
+func (m *MockCarServiceInterface) GetCarByBrand(ctx context.Context, brand string, fuzzy bool) (*[]models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarByBrand", ctx, brand, fuzzy)
+	ret0, _ := ret[0].(*[]models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarByBrand indicates an expected call of GetCarByBrand.
+func (mr *MockCarServiceInterfaceMockRecorder) GetCarByBrand(ctx, brand, fuzzy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarByBrand", reflect.TypeOf((*MockCarServiceInterface)(nil).GetCarByBrand), ctx, brand, fuzzy)
+}
+
+// GetCarByID mocks base method.
+func (m *MockCarServiceInterface) GetCarByID(ctx context.Context, id string) (*models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarByID", ctx, id)
+	ret0, _ := ret[0].(*models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarByID indicates an expected call of GetCarByID.
+func (mr *MockCarServiceInterfaceMockRecorder) GetCarByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarByID", reflect.TypeOf((*MockCarServiceInterface)(nil).GetCarByID), ctx, id)
+}
+
+// GetCarBySlug mocks base method.
+func (m *MockCarServiceInterface) GetCarBySlug(ctx context.Context, slug string) (*models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarBySlug", ctx, slug)
+	ret0, _ := ret[0].(*models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarBySlug indicates an expected call of GetCarBySlug.
+func (mr *MockCarServiceInterfaceMockRecorder) GetCarBySlug(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarBySlug", reflect.TypeOf((*MockCarServiceInterface)(nil).GetCarBySlug), ctx, slug)
+}
+
+// GetEligibility mocks base method.
+func (m *MockCarServiceInterface) GetEligibility(ctx context.Context, carID string) (*models.CarEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEligibility", ctx, carID)
+	ret0, _ := ret[0].(*models.CarEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEligibility indicates an expected call of GetEligibility.
+func (mr *MockCarServiceInterfaceMockRecorder) GetEligibility(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEligibility", reflect.TypeOf((*MockCarServiceInterface)(nil).GetEligibility), ctx, carID)
+}
+
+// GetFuelPolicy mocks base method.
+func (m *MockCarServiceInterface) GetFuelPolicy(ctx context.Context, carID string) (*models.CarFuelPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFuelPolicy", ctx, carID)
+	ret0, _ := ret[0].(*models.CarFuelPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFuelPolicy indicates an expected call of GetFuelPolicy.
+func (mr *MockCarServiceInterfaceMockRecorder) GetFuelPolicy(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFuelPolicy", reflect.TypeOf((*MockCarServiceInterface)(nil).GetFuelPolicy), ctx, carID)
+}
+
+// GetPriceHistory mocks base method.
+func (m *MockCarServiceInterface) GetPriceHistory(ctx context.Context, carID string) ([]models.CarPriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceHistory", ctx, carID)
+	ret0, _ := ret[0].([]models.CarPriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceHistory indicates an expected call of GetPriceHistory.
+func (mr *MockCarServiceInterfaceMockRecorder) GetPriceHistory(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceHistory", reflect.TypeOf((*MockCarServiceInterface)(nil).GetPriceHistory), ctx, carID)
+}
+
+// GetPublicCarByID mocks base method.
+func (m *MockCarServiceInterface) GetPublicCarByID(ctx context.Context, id string) (*models.PublicCar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicCarByID", ctx, id)
+	ret0, _ := ret[0].(*models.PublicCar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicCarByID indicates an expected call of GetPublicCarByID.
+func (mr *MockCarServiceInterfaceMockRecorder) GetPublicCarByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicCarByID", reflect.TypeOf((*MockCarServiceInterface)(nil).GetPublicCarByID), ctx, id)
+}
+
+// GetPublicCarBySlug mocks base method.
+func (m *MockCarServiceInterface) GetPublicCarBySlug(ctx context.Context, slug string) (*models.PublicCar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicCarBySlug", ctx, slug)
+	ret0, _ := ret[0].(*models.PublicCar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicCarBySlug indicates an expected call of GetPublicCarBySlug.
+func (mr *MockCarServiceInterfaceMockRecorder) GetPublicCarBySlug(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicCarBySlug", reflect.TypeOf((*MockCarServiceInterface)(nil).GetPublicCarBySlug), ctx, slug)
+}
+
+// GetQuality mocks base method.
+func (m *MockCarServiceInterface) GetQuality(ctx context.Context, carID string) (*models.CarQuality, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuality", ctx, carID)
+	ret0, _ := ret[0].(*models.CarQuality)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuality indicates an expected call of GetQuality.
+func (mr *MockCarServiceInterfaceMockRecorder) GetQuality(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuality", reflect.TypeOf((*MockCarServiceInterface)(nil).GetQuality), ctx, carID)
+}
+
+// ListCars mocks base method.
+func (m *MockCarServiceInterface) ListCars(ctx context.Context, filter models.CarFilter, page models.PageRequest) (*models.Page[models.Car], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCars", ctx, filter, page)
+	ret0, _ := ret[0].(*models.Page[models.Car])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCars indicates an expected call of ListCars.
+func (mr *MockCarServiceInterfaceMockRecorder) ListCars(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCars", reflect.TypeOf((*MockCarServiceInterface)(nil).ListCars), ctx, filter, page)
+}
+
+// ListImageUploads mocks base method.
+func (m *MockCarServiceInterface) ListImageUploads(ctx context.Context, carID string) ([]models.CarImageUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImageUploads", ctx, carID)
+	ret0, _ := ret[0].([]models.CarImageUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImageUploads indicates an expected call of ListImageUploads.
+func (mr *MockCarServiceInterfaceMockRecorder) ListImageUploads(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImageUploads", reflect.TypeOf((*MockCarServiceInterface)(nil).ListImageUploads), ctx, carID)
+}
+
+// ListPublicCars mocks base method.
+func (m *MockCarServiceInterface) ListPublicCars(ctx context.Context, filter models.CarFilter, page models.PageRequest) (*models.Page[models.PublicCar], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublicCars", ctx, filter, page)
+	ret0, _ := ret[0].(*models.Page[models.PublicCar])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublicCars indicates an expected call of ListPublicCars.
+func (mr *MockCarServiceInterfaceMockRecorder) ListPublicCars(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublicCars", reflect.TypeOf((*MockCarServiceInterface)(nil).ListPublicCars), ctx, filter, page)
+}
+
+// RecordFeaturedClick mocks base method.
+func (m *MockCarServiceInterface) RecordFeaturedClick(ctx context.Context, car models.Car, viewerID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordFeaturedClick", ctx, car, viewerID)
+}
+
+// RecordFeaturedClick indicates an expected call of RecordFeaturedClick.
+func (mr *MockCarServiceInterfaceMockRecorder) RecordFeaturedClick(ctx, car, viewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFeaturedClick", reflect.TypeOf((*MockCarServiceInterface)(nil).RecordFeaturedClick), ctx, car, viewerID)
+}
+
+// UpdateCar mocks base method.
+func (m *MockCarServiceInterface) UpdateCar(ctx context.Context, id string, carReq models.CarRequest) (*models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCar", ctx, id, carReq)
+	ret0, _ := ret[0].(*models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCar indicates an expected call of UpdateCar.
+func (mr *MockCarServiceInterfaceMockRecorder) UpdateCar(ctx, id, carReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCar", reflect.TypeOf((*MockCarServiceInterface)(nil).UpdateCar), ctx, id, carReq)
+}
+
+// UpdateCarStatus mocks base method.
+func (m *MockCarServiceInterface) UpdateCarStatus(ctx context.Context, id string, status models.CarStatus, byAdmin bool) (*models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCarStatus", ctx, id, status, byAdmin)
+	ret0, _ := ret[0].(*models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCarStatus indicates an expected call of UpdateCarStatus.
+func (mr *MockCarServiceInterfaceMockRecorder) UpdateCarStatus(ctx, id, status, byAdmin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCarStatus", reflect.TypeOf((*MockCarServiceInterface)(nil).UpdateCarStatus), ctx, id, status, byAdmin)
+}
+
+// UpdateEligibility mocks base method.
+func (m *MockCarServiceInterface) UpdateEligibility(ctx context.Context, userID, role, carID string, req models.CarEligibilityRequest) (*models.CarEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEligibility", ctx, userID, role, carID, req)
+	ret0, _ := ret[0].(*models.CarEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEligibility indicates an expected call of UpdateEligibility.
+func (mr *MockCarServiceInterfaceMockRecorder) UpdateEligibility(ctx, userID, role, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEligibility", reflect.TypeOf((*MockCarServiceInterface)(nil).UpdateEligibility), ctx, userID, role, carID, req)
+}
+
+// UpdateFuelPolicy mocks base method.
+func (m *MockCarServiceInterface) UpdateFuelPolicy(ctx context.Context, userID, role, carID string, req models.CarFuelPolicyRequest) (*models.CarFuelPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFuelPolicy", ctx, userID, role, carID, req)
+	ret0, _ := ret[0].(*models.CarFuelPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFuelPolicy indicates an expected call of UpdateFuelPolicy.
+func (mr *MockCarServiceInterfaceMockRecorder) UpdateFuelPolicy(ctx, userID, role, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFuelPolicy", reflect.TypeOf((*MockCarServiceInterface)(nil).UpdateFuelPolicy), ctx, userID, role, carID, req)
+}
+
+// MockAuthServiceInterface is a mock of AuthServiceInterface interface.
+type MockAuthServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthServiceInterfaceMockRecorder is the mock recorder for MockAuthServiceInterface.
+type MockAuthServiceInterfaceMockRecorder struct {
+	mock *MockAuthServiceInterface
+}
+
+// NewMockAuthServiceInterface creates a new mock instance.
+func NewMockAuthServiceInterface(ctrl *gomock.Controller) *MockAuthServiceInterface {
+	mock := &MockAuthServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockAuthServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthServiceInterface) EXPECT() *MockAuthServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ClearFailedLogins mocks base method.
+func (m *MockAuthServiceInterface) ClearFailedLogins(ctx context.Context, email string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearFailedLogins", ctx, email)
+}
+
+// ClearFailedLogins indicates an expected call of ClearFailedLogins.
+func (mr *MockAuthServiceInterfaceMockRecorder) ClearFailedLogins(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearFailedLogins", reflect.TypeOf((*MockAuthServiceInterface)(nil).ClearFailedLogins), ctx, email)
+}
+
+// LoginUser mocks base method.
+func (m *MockAuthServiceInterface) LoginUser(ctx context.Context, loginReq models.LoginRequest) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUser", ctx, loginReq)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginUser indicates an expected call of LoginUser.
+func (mr *MockAuthServiceInterfaceMockRecorder) LoginUser(ctx, loginReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockAuthServiceInterface)(nil).LoginUser), ctx, loginReq)
+}
+
+// RecordFailedLogin mocks base method.
+func (m *MockAuthServiceInterface) RecordFailedLogin(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailedLogin", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFailedLogin indicates an expected call of RecordFailedLogin.
+func (mr *MockAuthServiceInterfaceMockRecorder) RecordFailedLogin(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedLogin", reflect.TypeOf((*MockAuthServiceInterface)(nil).RecordFailedLogin), ctx, email)
+}
+
+// RegisterUser mocks base method.
+func (m *MockAuthServiceInterface) RegisterUser(ctx context.Context, userReq models.UserRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterUser", ctx, userReq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterUser indicates an expected call of RegisterUser.
+func (mr *MockAuthServiceInterfaceMockRecorder) RegisterUser(ctx, userReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockAuthServiceInterface)(nil).RegisterUser), ctx, userReq)
+}
+
+// SetPassword mocks base method.
+func (m *MockAuthServiceInterface) SetPassword(ctx context.Context, email, password string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, email, password)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockAuthServiceInterfaceMockRecorder) SetPassword(ctx, email, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockAuthServiceInterface)(nil).SetPassword), ctx, email, password)
+}
+
+// MockBookingServiceInterface is a mock of BookingServiceInterface interface.
+type MockBookingServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBookingServiceInterfaceMockRecorder is the mock recorder for MockBookingServiceInterface.
+type MockBookingServiceInterfaceMockRecorder struct {
+	mock *MockBookingServiceInterface
+}
+
+// NewMockBookingServiceInterface creates a new mock instance.
+func NewMockBookingServiceInterface(ctrl *gomock.Controller) *MockBookingServiceInterface {
+	mock := &MockBookingServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockBookingServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingServiceInterface) EXPECT() *MockBookingServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CheckConflicts mocks base method.
+func (m *MockBookingServiceInterface) CheckConflicts(ctx context.Context, carID string, start, end time.Time) (*models.BookingAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckConflicts", ctx, carID, start, end)
+	ret0, _ := ret[0].(*models.BookingAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckConflicts indicates an expected call of CheckConflicts.
+func (mr *MockBookingServiceInterfaceMockRecorder) CheckConflicts(ctx, carID, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckConflicts", reflect.TypeOf((*MockBookingServiceInterface)(nil).CheckConflicts), ctx, carID, start, end)
+}
+
+// CollectPayment mocks base method.
+func (m *MockBookingServiceInterface) CollectPayment(ctx context.Context, bookingID, paymentID, userID, role string, req models.PaymentCollectionRequest) (*models.PaymentCollectionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectPayment", ctx, bookingID, paymentID, userID, role, req)
+	ret0, _ := ret[0].(*models.PaymentCollectionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectPayment indicates an expected call of CollectPayment.
+func (mr *MockBookingServiceInterfaceMockRecorder) CollectPayment(ctx, bookingID, paymentID, userID, role, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectPayment", reflect.TypeOf((*MockBookingServiceInterface)(nil).CollectPayment), ctx, bookingID, paymentID, userID, role, req)
+}
+
+// CreateBooking mocks base method.
+func (m *MockBookingServiceInterface) CreateBooking(ctx context.Context, bookingReq models.BookingRequest) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBooking", ctx, bookingReq)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBooking indicates an expected call of CreateBooking.
+func (mr *MockBookingServiceInterfaceMockRecorder) CreateBooking(ctx, bookingReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBooking", reflect.TypeOf((*MockBookingServiceInterface)(nil).CreateBooking), ctx, bookingReq)
+}
+
+// DeleteBooking mocks base method.
+func (m *MockBookingServiceInterface) DeleteBooking(ctx context.Context, id string) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBooking", ctx, id)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBooking indicates an expected call of DeleteBooking.
+func (mr *MockBookingServiceInterfaceMockRecorder) DeleteBooking(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBooking", reflect.TypeOf((*MockBookingServiceInterface)(nil).DeleteBooking), ctx, id)
+}
+
+// DetectOverdueTrips mocks base method.
+func (m *MockBookingServiceInterface) DetectOverdueTrips(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectOverdueTrips", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetectOverdueTrips indicates an expected call of DetectOverdueTrips.
+func (mr *MockBookingServiceInterfaceMockRecorder) DetectOverdueTrips(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectOverdueTrips", reflect.TypeOf((*MockBookingServiceInterface)(nil).DetectOverdueTrips), ctx)
+}
+
+// ExpireUnpaidBookings mocks base method.
+func (m *MockBookingServiceInterface) ExpireUnpaidBookings(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireUnpaidBookings", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireUnpaidBookings indicates an expected call of ExpireUnpaidBookings.
+func (mr *MockBookingServiceInterfaceMockRecorder) ExpireUnpaidBookings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireUnpaidBookings", reflect.TypeOf((*MockBookingServiceInterface)(nil).ExpireUnpaidBookings), ctx)
+}
+
+// ExtendBooking mocks base method.
+func (m *MockBookingServiceInterface) ExtendBooking(ctx context.Context, bookingID, userID, role string, req models.ExtensionRequest) (*models.BookingExtension, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendBooking", ctx, bookingID, userID, role, req)
+	ret0, _ := ret[0].(*models.BookingExtension)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExtendBooking indicates an expected call of ExtendBooking.
+func (mr *MockBookingServiceInterfaceMockRecorder) ExtendBooking(ctx, bookingID, userID, role, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendBooking", reflect.TypeOf((*MockBookingServiceInterface)(nil).ExtendBooking), ctx, bookingID, userID, role, req)
+}
+
+// GetActiveTrips mocks base method.
+func (m *MockBookingServiceInterface) GetActiveTrips(ctx context.Context, ownerID string) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveTrips", ctx, ownerID)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveTrips indicates an expected call of GetActiveTrips.
+func (mr *MockBookingServiceInterfaceMockRecorder) GetActiveTrips(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveTrips", reflect.TypeOf((*MockBookingServiceInterface)(nil).GetActiveTrips), ctx, ownerID)
+}
+
+// GetBookingByID mocks base method.
+func (m *MockBookingServiceInterface) GetBookingByID(ctx context.Context, id string) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingByID", ctx, id)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingByID indicates an expected call of GetBookingByID.
+func (mr *MockBookingServiceInterfaceMockRecorder) GetBookingByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingByID", reflect.TypeOf((*MockBookingServiceInterface)(nil).GetBookingByID), ctx, id)
+}
+
+// GetBookingsByCarID mocks base method.
+func (m *MockBookingServiceInterface) GetBookingsByCarID(ctx context.Context, carID string) (*[]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingsByCarID", ctx, carID)
+	ret0, _ := ret[0].(*[]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingsByCarID indicates an expected call of GetBookingsByCarID.
+func (mr *MockBookingServiceInterfaceMockRecorder) GetBookingsByCarID(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByCarID", reflect.TypeOf((*MockBookingServiceInterface)(nil).GetBookingsByCarID), ctx, carID)
+}
+
+// GetBookingsByCustomerID mocks base method.
+func (m *MockBookingServiceInterface) GetBookingsByCustomerID(ctx context.Context, customerID string) (*[]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingsByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].(*[]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingsByCustomerID indicates an expected call of GetBookingsByCustomerID.
+func (mr *MockBookingServiceInterfaceMockRecorder) GetBookingsByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByCustomerID", reflect.TypeOf((*MockBookingServiceInterface)(nil).GetBookingsByCustomerID), ctx, customerID)
+}
+
+// GetBookingsByOwnerID mocks base method.
+func (m *MockBookingServiceInterface) GetBookingsByOwnerID(ctx context.Context, ownerID string) (*[]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingsByOwnerID", ctx, ownerID)
+	ret0, _ := ret[0].(*[]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingsByOwnerID indicates an expected call of GetBookingsByOwnerID.
+func (mr *MockBookingServiceInterfaceMockRecorder) GetBookingsByOwnerID(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByOwnerID", reflect.TypeOf((*MockBookingServiceInterface)(nil).GetBookingsByOwnerID), ctx, ownerID)
+}
+
+// GetDocuments mocks base method.
+func (m *MockBookingServiceInterface) GetDocuments(ctx context.Context, bookingID, userID, role string) ([]models.FinancialDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocuments", ctx, bookingID, userID, role)
+	ret0, _ := ret[0].([]models.FinancialDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocuments indicates an expected call of GetDocuments.
+func (mr *MockBookingServiceInterfaceMockRecorder) GetDocuments(ctx, bookingID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocuments", reflect.TypeOf((*MockBookingServiceInterface)(nil).GetDocuments), ctx, bookingID, userID, role)
+}
+
+// GetInspections mocks base method.
+func (m *MockBookingServiceInterface) GetInspections(ctx context.Context, bookingID string) ([]models.BookingInspection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInspections", ctx, bookingID)
+	ret0, _ := ret[0].([]models.BookingInspection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInspections indicates an expected call of GetInspections.
+func (mr *MockBookingServiceInterfaceMockRecorder) GetInspections(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInspections", reflect.TypeOf((*MockBookingServiceInterface)(nil).GetInspections), ctx, bookingID)
+}
+
+// GetPaymentCollections mocks base method.
+func (m *MockBookingServiceInterface) GetPaymentCollections(ctx context.Context, bookingID, userID, role string) ([]models.PaymentCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentCollections", ctx, bookingID, userID, role)
+	ret0, _ := ret[0].([]models.PaymentCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentCollections indicates an expected call of GetPaymentCollections.
+func (mr *MockBookingServiceInterfaceMockRecorder) GetPaymentCollections(ctx, bookingID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentCollections", reflect.TypeOf((*MockBookingServiceInterface)(nil).GetPaymentCollections), ctx, bookingID, userID, role)
+}
+
+// ListBookings mocks base method.
+func (m *MockBookingServiceInterface) ListBookings(ctx context.Context, filter models.BookingFilter, page models.PageRequest) (*models.Page[models.Booking], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookings", ctx, filter, page)
+	ret0, _ := ret[0].(*models.Page[models.Booking])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookings indicates an expected call of ListBookings.
+func (mr *MockBookingServiceInterfaceMockRecorder) ListBookings(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookings", reflect.TypeOf((*MockBookingServiceInterface)(nil).ListBookings), ctx, filter, page)
+}
+
+// QuoteBooking mocks base method.
+func (m *MockBookingServiceInterface) QuoteBooking(ctx context.Context, bookingReq models.BookingRequest) (*models.BookingQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteBooking", ctx, bookingReq)
+	ret0, _ := ret[0].(*models.BookingQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteBooking indicates an expected call of QuoteBooking.
+func (mr *MockBookingServiceInterfaceMockRecorder) QuoteBooking(ctx, bookingReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteBooking", reflect.TypeOf((*MockBookingServiceInterface)(nil).QuoteBooking), ctx, bookingReq)
+}
+
+// QuoteExtension mocks base method.
+func (m *MockBookingServiceInterface) QuoteExtension(ctx context.Context, bookingID, userID, role string, end time.Time) (*models.ExtensionQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteExtension", ctx, bookingID, userID, role, end)
+	ret0, _ := ret[0].(*models.ExtensionQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteExtension indicates an expected call of QuoteExtension.
+func (mr *MockBookingServiceInterfaceMockRecorder) QuoteExtension(ctx, bookingID, userID, role, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteExtension", reflect.TypeOf((*MockBookingServiceInterface)(nil).QuoteExtension), ctx, bookingID, userID, role, end)
+}
+
+// RecomputeBookingTotals mocks base method.
+func (m *MockBookingServiceInterface) RecomputeBookingTotals(ctx context.Context, bookingID *uuid.UUID) (models.MaintenanceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeBookingTotals", ctx, bookingID)
+	ret0, _ := ret[0].(models.MaintenanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecomputeBookingTotals indicates an expected call of RecomputeBookingTotals.
+func (mr *MockBookingServiceInterfaceMockRecorder) RecomputeBookingTotals(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeBookingTotals", reflect.TypeOf((*MockBookingServiceInterface)(nil).RecomputeBookingTotals), ctx, bookingID)
+}
+
+// RecordInspection mocks base method.
+func (m *MockBookingServiceInterface) RecordInspection(ctx context.Context, bookingID, userID, role string, kind models.InspectionKind, req models.InspectionRequest) (*models.BookingInspection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordInspection", ctx, bookingID, userID, role, kind, req)
+	ret0, _ := ret[0].(*models.BookingInspection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordInspection indicates an expected call of RecordInspection.
+func (mr *MockBookingServiceInterfaceMockRecorder) RecordInspection(ctx, bookingID, userID, role, kind, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordInspection", reflect.TypeOf((*MockBookingServiceInterface)(nil).RecordInspection), ctx, bookingID, userID, role, kind, req)
+}
+
+// RemindHandBacks mocks base method.
+func (m *MockBookingServiceInterface) RemindHandBacks(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemindHandBacks", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemindHandBacks indicates an expected call of RemindHandBacks.
+func (mr *MockBookingServiceInterfaceMockRecorder) RemindHandBacks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemindHandBacks", reflect.TypeOf((*MockBookingServiceInterface)(nil).RemindHandBacks), ctx)
+}
+
+// UpdateBookingStatus mocks base method.
+func (m *MockBookingServiceInterface) UpdateBookingStatus(ctx context.Context, id string, status models.BookingStatus) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBookingStatus", ctx, id, status)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBookingStatus indicates an expected call of UpdateBookingStatus.
+func (mr *MockBookingServiceInterfaceMockRecorder) UpdateBookingStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBookingStatus", reflect.TypeOf((*MockBookingServiceInterface)(nil).UpdateBookingStatus), ctx, id, status)
+}
+
+// MockTelemetryServiceInterface is a mock of TelemetryServiceInterface interface.
+type MockTelemetryServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTelemetryServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTelemetryServiceInterfaceMockRecorder is the mock recorder for MockTelemetryServiceInterface.
+type MockTelemetryServiceInterfaceMockRecorder struct {
+	mock *MockTelemetryServiceInterface
+}
+
+// NewMockTelemetryServiceInterface creates a new mock instance.
+func NewMockTelemetryServiceInterface(ctrl *gomock.Controller) *MockTelemetryServiceInterface {
+	mock := &MockTelemetryServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTelemetryServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTelemetryServiceInterface) EXPECT() *MockTelemetryServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateGeofence mocks base method.
+func (m *MockTelemetryServiceInterface) CreateGeofence(ctx context.Context, userID, role, carID string, req models.GeofenceRequest) (*models.Geofence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGeofence", ctx, userID, role, carID, req)
+	ret0, _ := ret[0].(*models.Geofence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGeofence indicates an expected call of CreateGeofence.
+func (mr *MockTelemetryServiceInterfaceMockRecorder) CreateGeofence(ctx, userID, role, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGeofence", reflect.TypeOf((*MockTelemetryServiceInterface)(nil).CreateGeofence), ctx, userID, role, carID, req)
+}
+
+// DeleteGeofence mocks base method.
+func (m *MockTelemetryServiceInterface) DeleteGeofence(ctx context.Context, userID, role, carID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGeofence", ctx, userID, role, carID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGeofence indicates an expected call of DeleteGeofence.
+func (mr *MockTelemetryServiceInterfaceMockRecorder) DeleteGeofence(ctx, userID, role, carID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGeofence", reflect.TypeOf((*MockTelemetryServiceInterface)(nil).DeleteGeofence), ctx, userID, role, carID, id)
+}
+
+// EvaluateGeofences mocks base method.
+func (m *MockTelemetryServiceInterface) EvaluateGeofences(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluateGeofences", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EvaluateGeofences indicates an expected call of EvaluateGeofences.
+func (mr *MockTelemetryServiceInterfaceMockRecorder) EvaluateGeofences(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateGeofences", reflect.TypeOf((*MockTelemetryServiceInterface)(nil).EvaluateGeofences), ctx)
+}
+
+// GetGeofenceBreaches mocks base method.
+func (m *MockTelemetryServiceInterface) GetGeofenceBreaches(ctx context.Context, userID, role, carID string) ([]models.GeofenceBreach, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofenceBreaches", ctx, userID, role, carID)
+	ret0, _ := ret[0].([]models.GeofenceBreach)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofenceBreaches indicates an expected call of GetGeofenceBreaches.
+func (mr *MockTelemetryServiceInterfaceMockRecorder) GetGeofenceBreaches(ctx, userID, role, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofenceBreaches", reflect.TypeOf((*MockTelemetryServiceInterface)(nil).GetGeofenceBreaches), ctx, userID, role, carID)
+}
+
+// GetGeofences mocks base method.
+func (m *MockTelemetryServiceInterface) GetGeofences(ctx context.Context, userID, role, carID string) ([]models.Geofence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofences", ctx, userID, role, carID)
+	ret0, _ := ret[0].([]models.Geofence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofences indicates an expected call of GetGeofences.
+func (mr *MockTelemetryServiceInterfaceMockRecorder) GetGeofences(ctx, userID, role, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofences", reflect.TypeOf((*MockTelemetryServiceInterface)(nil).GetGeofences), ctx, userID, role, carID)
+}
+
+// GetLatestSnapshot mocks base method.
+func (m *MockTelemetryServiceInterface) GetLatestSnapshot(ctx context.Context, userID, role, carID string) (*models.TelemetryReading, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestSnapshot", ctx, userID, role, carID)
+	ret0, _ := ret[0].(*models.TelemetryReading)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestSnapshot indicates an expected call of GetLatestSnapshot.
+func (mr *MockTelemetryServiceInterfaceMockRecorder) GetLatestSnapshot(ctx, userID, role, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSnapshot", reflect.TypeOf((*MockTelemetryServiceInterface)(nil).GetLatestSnapshot), ctx, userID, role, carID)
+}
+
+// IngestReading mocks base method.
+func (m *MockTelemetryServiceInterface) IngestReading(ctx context.Context, deviceID, timestamp, signature string, body []byte) (*models.TelemetryReading, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IngestReading", ctx, deviceID, timestamp, signature, body)
+	ret0, _ := ret[0].(*models.TelemetryReading)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IngestReading indicates an expected call of IngestReading.
+func (mr *MockTelemetryServiceInterfaceMockRecorder) IngestReading(ctx, deviceID, timestamp, signature, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestReading", reflect.TypeOf((*MockTelemetryServiceInterface)(nil).IngestReading), ctx, deviceID, timestamp, signature, body)
+}
+
+// LatestHandoverReadings mocks base method.
+func (m *MockTelemetryServiceInterface) LatestHandoverReadings(ctx context.Context, carID string) (*int, *float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestHandoverReadings", ctx, carID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(*float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LatestHandoverReadings indicates an expected call of LatestHandoverReadings.
+func (mr *MockTelemetryServiceInterfaceMockRecorder) LatestHandoverReadings(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestHandoverReadings", reflect.TypeOf((*MockTelemetryServiceInterface)(nil).LatestHandoverReadings), ctx, carID)
+}
+
+// ProvisionDevice mocks base method.
+func (m *MockTelemetryServiceInterface) ProvisionDevice(ctx context.Context, userID, role, carID string) (*models.TelemetryDeviceCredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProvisionDevice", ctx, userID, role, carID)
+	ret0, _ := ret[0].(*models.TelemetryDeviceCredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProvisionDevice indicates an expected call of ProvisionDevice.
+func (mr *MockTelemetryServiceInterfaceMockRecorder) ProvisionDevice(ctx, userID, role, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProvisionDevice", reflect.TypeOf((*MockTelemetryServiceInterface)(nil).ProvisionDevice), ctx, userID, role, carID)
+}
+
+// MockPaymentServiceInterface is a mock of PaymentServiceInterface interface.
+type MockPaymentServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentServiceInterfaceMockRecorder is the mock recorder for MockPaymentServiceInterface.
+type MockPaymentServiceInterfaceMockRecorder struct {
+	mock *MockPaymentServiceInterface
+}
+
+// NewMockPaymentServiceInterface creates a new mock instance.
+func NewMockPaymentServiceInterface(ctrl *gomock.Controller) *MockPaymentServiceInterface {
+	mock := &MockPaymentServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockPaymentServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentServiceInterface) EXPECT() *MockPaymentServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CaptureAuthorizedPayments mocks base method.
+func (m *MockPaymentServiceInterface) CaptureAuthorizedPayments(ctx context.Context, bookingID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureAuthorizedPayments", ctx, bookingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CaptureAuthorizedPayments indicates an expected call of CaptureAuthorizedPayments.
+func (mr *MockPaymentServiceInterfaceMockRecorder) CaptureAuthorizedPayments(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureAuthorizedPayments", reflect.TypeOf((*MockPaymentServiceInterface)(nil).CaptureAuthorizedPayments), ctx, bookingID)
+}
+
+// CreateOrder mocks base method.
+func (m *MockPaymentServiceInterface) CreateOrder(ctx context.Context, amount float64, receipt string) (*models.RazorpayOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, amount, receipt)
+	ret0, _ := ret[0].(*models.RazorpayOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockPaymentServiceInterfaceMockRecorder) CreateOrder(ctx, amount, receipt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockPaymentServiceInterface)(nil).CreateOrder), ctx, amount, receipt)
+}
+
+// CreatePayment mocks base method.
+func (m *MockPaymentServiceInterface) CreatePayment(ctx context.Context, req *models.PaymentRequest) (*models.RazorpayOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, req)
+	ret0, _ := ret[0].(*models.RazorpayOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockPaymentServiceInterfaceMockRecorder) CreatePayment(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockPaymentServiceInterface)(nil).CreatePayment), ctx, req)
+}
+
+// CreatePaymentLink mocks base method.
+func (m *MockPaymentServiceInterface) CreatePaymentLink(ctx context.Context, bookingID string, req models.PaymentLinkRequest) (*models.PaymentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentLink", ctx, bookingID, req)
+	ret0, _ := ret[0].(*models.PaymentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentLink indicates an expected call of CreatePaymentLink.
+func (mr *MockPaymentServiceInterfaceMockRecorder) CreatePaymentLink(ctx, bookingID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentLink", reflect.TypeOf((*MockPaymentServiceInterface)(nil).CreatePaymentLink), ctx, bookingID, req)
+}
+
+// GetCollections mocks base method.
+func (m *MockPaymentServiceInterface) GetCollections(ctx context.Context, bookingID string) ([]models.PaymentCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", ctx, bookingID)
+	ret0, _ := ret[0].([]models.PaymentCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockPaymentServiceInterfaceMockRecorder) GetCollections(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockPaymentServiceInterface)(nil).GetCollections), ctx, bookingID)
+}
+
+// GetPaymentByBookingID mocks base method.
+func (m *MockPaymentServiceInterface) GetPaymentByBookingID(ctx context.Context, bookingID string) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByBookingID", ctx, bookingID)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByBookingID indicates an expected call of GetPaymentByBookingID.
+func (mr *MockPaymentServiceInterfaceMockRecorder) GetPaymentByBookingID(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByBookingID", reflect.TypeOf((*MockPaymentServiceInterface)(nil).GetPaymentByBookingID), ctx, bookingID)
+}
+
+// GetPaymentByID mocks base method.
+func (m *MockPaymentServiceInterface) GetPaymentByID(ctx context.Context, id string) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByID", ctx, id)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByID indicates an expected call of GetPaymentByID.
+func (mr *MockPaymentServiceInterfaceMockRecorder) GetPaymentByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByID", reflect.TypeOf((*MockPaymentServiceInterface)(nil).GetPaymentByID), ctx, id)
+}
+
+// GetPaymentDetail mocks base method.
+func (m *MockPaymentServiceInterface) GetPaymentDetail(ctx context.Context, id string) (*models.PaymentDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentDetail", ctx, id)
+	ret0, _ := ret[0].(*models.PaymentDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentDetail indicates an expected call of GetPaymentDetail.
+func (mr *MockPaymentServiceInterfaceMockRecorder) GetPaymentDetail(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentDetail", reflect.TypeOf((*MockPaymentServiceInterface)(nil).GetPaymentDetail), ctx, id)
+}
+
+// GetPaymentsByUserID mocks base method.
+func (m *MockPaymentServiceInterface) GetPaymentsByUserID(ctx context.Context, userID string) (*[]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentsByUserID", ctx, userID)
+	ret0, _ := ret[0].(*[]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentsByUserID indicates an expected call of GetPaymentsByUserID.
+func (mr *MockPaymentServiceInterfaceMockRecorder) GetPaymentsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentsByUserID", reflect.TypeOf((*MockPaymentServiceInterface)(nil).GetPaymentsByUserID), ctx, userID)
+}
+
+// HandleRazorpayWebhook mocks base method.
+func (m *MockPaymentServiceInterface) HandleRazorpayWebhook(ctx context.Context, signature string, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleRazorpayWebhook", ctx, signature, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleRazorpayWebhook indicates an expected call of HandleRazorpayWebhook.
+func (mr *MockPaymentServiceInterfaceMockRecorder) HandleRazorpayWebhook(ctx, signature, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRazorpayWebhook", reflect.TypeOf((*MockPaymentServiceInterface)(nil).HandleRazorpayWebhook), ctx, signature, body)
+}
+
+// ListPayments mocks base method.
+func (m *MockPaymentServiceInterface) ListPayments(ctx context.Context, filter models.PaymentFilter, page models.PageRequest) (*models.Page[models.Payment], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayments", ctx, filter, page)
+	ret0, _ := ret[0].(*models.Page[models.Payment])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayments indicates an expected call of ListPayments.
+func (mr *MockPaymentServiceInterfaceMockRecorder) ListPayments(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayments", reflect.TypeOf((*MockPaymentServiceInterface)(nil).ListPayments), ctx, filter, page)
+}
+
+// ProcessRefund mocks base method.
+func (m *MockPaymentServiceInterface) ProcessRefund(ctx context.Context, paymentID string, amount float64) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessRefund", ctx, paymentID, amount)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessRefund indicates an expected call of ProcessRefund.
+func (mr *MockPaymentServiceInterfaceMockRecorder) ProcessRefund(ctx, paymentID, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessRefund", reflect.TypeOf((*MockPaymentServiceInterface)(nil).ProcessRefund), ctx, paymentID, amount)
+}
+
+// ReconcilePendingPayments mocks base method.
+func (m *MockPaymentServiceInterface) ReconcilePendingPayments(ctx context.Context, olderThan time.Duration) (models.MaintenanceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcilePendingPayments", ctx, olderThan)
+	ret0, _ := ret[0].(models.MaintenanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcilePendingPayments indicates an expected call of ReconcilePendingPayments.
+func (mr *MockPaymentServiceInterfaceMockRecorder) ReconcilePendingPayments(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcilePendingPayments", reflect.TypeOf((*MockPaymentServiceInterface)(nil).ReconcilePendingPayments), ctx, olderThan)
+}
+
+// RecordCollection mocks base method.
+func (m *MockPaymentServiceInterface) RecordCollection(ctx context.Context, bookingID, paymentID, collectedBy string, req models.PaymentCollectionRequest) (*models.PaymentCollection, *models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordCollection", ctx, bookingID, paymentID, collectedBy, req)
+	ret0, _ := ret[0].(*models.PaymentCollection)
+	ret1, _ := ret[1].(*models.Payment)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RecordCollection indicates an expected call of RecordCollection.
+func (mr *MockPaymentServiceInterfaceMockRecorder) RecordCollection(ctx, bookingID, paymentID, collectedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordCollection", reflect.TypeOf((*MockPaymentServiceInterface)(nil).RecordCollection), ctx, bookingID, paymentID, collectedBy, req)
+}
+
+// ResyncPayment mocks base method.
+func (m *MockPaymentServiceInterface) ResyncPayment(ctx context.Context, id string) (models.MaintenanceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResyncPayment", ctx, id)
+	ret0, _ := ret[0].(models.MaintenanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResyncPayment indicates an expected call of ResyncPayment.
+func (mr *MockPaymentServiceInterfaceMockRecorder) ResyncPayment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResyncPayment", reflect.TypeOf((*MockPaymentServiceInterface)(nil).ResyncPayment), ctx, id)
+}
+
+// RetryPayment mocks base method.
+func (m *MockPaymentServiceInterface) RetryPayment(ctx context.Context, id, userID, role string) (*models.RazorpayOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryPayment", ctx, id, userID, role)
+	ret0, _ := ret[0].(*models.RazorpayOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryPayment indicates an expected call of RetryPayment.
+func (mr *MockPaymentServiceInterfaceMockRecorder) RetryPayment(ctx, id, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryPayment", reflect.TypeOf((*MockPaymentServiceInterface)(nil).RetryPayment), ctx, id, userID, role)
+}
+
+// VerifyOrderSignature mocks base method.
+func (m *MockPaymentServiceInterface) VerifyOrderSignature(req models.PaymentVerificationRequest) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyOrderSignature", req)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// VerifyOrderSignature indicates an expected call of VerifyOrderSignature.
+func (mr *MockPaymentServiceInterfaceMockRecorder) VerifyOrderSignature(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyOrderSignature", reflect.TypeOf((*MockPaymentServiceInterface)(nil).VerifyOrderSignature), req)
+}
+
+// VerifyPayment mocks base method.
+func (m *MockPaymentServiceInterface) VerifyPayment(ctx context.Context, req *models.PaymentVerificationRequest) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPayment", ctx, req)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyPayment indicates an expected call of VerifyPayment.
+func (mr *MockPaymentServiceInterfaceMockRecorder) VerifyPayment(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPayment", reflect.TypeOf((*MockPaymentServiceInterface)(nil).VerifyPayment), ctx, req)
+}
+
+// VoidAuthorizedPayments mocks base method.
+func (m *MockPaymentServiceInterface) VoidAuthorizedPayments(ctx context.Context, bookingID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidAuthorizedPayments", ctx, bookingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VoidAuthorizedPayments indicates an expected call of VoidAuthorizedPayments.
+func (mr *MockPaymentServiceInterfaceMockRecorder) VoidAuthorizedPayments(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidAuthorizedPayments", reflect.TypeOf((*MockPaymentServiceInterface)(nil).VoidAuthorizedPayments), ctx, bookingID)
+}
+
+// MockSitemapServiceInterface is a mock of SitemapServiceInterface interface.
+type MockSitemapServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSitemapServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSitemapServiceInterfaceMockRecorder is the mock recorder for MockSitemapServiceInterface.
+type MockSitemapServiceInterfaceMockRecorder struct {
+	mock *MockSitemapServiceInterface
+}
+
+// NewMockSitemapServiceInterface creates a new mock instance.
+func NewMockSitemapServiceInterface(ctrl *gomock.Controller) *MockSitemapServiceInterface {
+	mock := &MockSitemapServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockSitemapServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSitemapServiceInterface) EXPECT() *MockSitemapServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Feed mocks base method.
+func (m *MockSitemapServiceInterface) Feed(ctx context.Context) ([]byte, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Feed", ctx)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Feed indicates an expected call of Feed.
+func (mr *MockSitemapServiceInterfaceMockRecorder) Feed(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Feed", reflect.TypeOf((*MockSitemapServiceInterface)(nil).Feed), ctx)
+}
+
+// Refresh mocks base method.
+func (m *MockSitemapServiceInterface) Refresh(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockSitemapServiceInterfaceMockRecorder) Refresh(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockSitemapServiceInterface)(nil).Refresh), ctx)
+}
+
+// Sitemap mocks base method.
+func (m *MockSitemapServiceInterface) Sitemap(ctx context.Context) ([]byte, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sitemap", ctx)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Sitemap indicates an expected call of Sitemap.
+func (mr *MockSitemapServiceInterfaceMockRecorder) Sitemap(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sitemap", reflect.TypeOf((*MockSitemapServiceInterface)(nil).Sitemap), ctx)
+}
+
+// MockPayoutServiceInterface is a mock of PayoutServiceInterface interface.
+type MockPayoutServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayoutServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPayoutServiceInterfaceMockRecorder is the mock recorder for MockPayoutServiceInterface.
+type MockPayoutServiceInterfaceMockRecorder struct {
+	mock *MockPayoutServiceInterface
+}
+
+// NewMockPayoutServiceInterface creates a new mock instance.
+func NewMockPayoutServiceInterface(ctrl *gomock.Controller) *MockPayoutServiceInterface {
+	mock := &MockPayoutServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockPayoutServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayoutServiceInterface) EXPECT() *MockPayoutServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeletePayoutAccount mocks base method.
+func (m *MockPayoutServiceInterface) DeletePayoutAccount(ctx context.Context, ownerID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePayoutAccount", ctx, ownerID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePayoutAccount indicates an expected call of DeletePayoutAccount.
+func (mr *MockPayoutServiceInterfaceMockRecorder) DeletePayoutAccount(ctx, ownerID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayoutAccount", reflect.TypeOf((*MockPayoutServiceInterface)(nil).DeletePayoutAccount), ctx, ownerID, id)
+}
+
+// GetPayoutAccounts mocks base method.
+func (m *MockPayoutServiceInterface) GetPayoutAccounts(ctx context.Context, ownerID string) (*[]models.PayoutAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayoutAccounts", ctx, ownerID)
+	ret0, _ := ret[0].(*[]models.PayoutAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayoutAccounts indicates an expected call of GetPayoutAccounts.
+func (mr *MockPayoutServiceInterfaceMockRecorder) GetPayoutAccounts(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayoutAccounts", reflect.TypeOf((*MockPayoutServiceInterface)(nil).GetPayoutAccounts), ctx, ownerID)
+}
+
+// GetPayoutHolds mocks base method.
+func (m *MockPayoutServiceInterface) GetPayoutHolds(ctx context.Context, ownerID string) ([]models.PayoutHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayoutHolds", ctx, ownerID)
+	ret0, _ := ret[0].([]models.PayoutHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayoutHolds indicates an expected call of GetPayoutHolds.
+func (mr *MockPayoutServiceInterfaceMockRecorder) GetPayoutHolds(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayoutHolds", reflect.TypeOf((*MockPayoutServiceInterface)(nil).GetPayoutHolds), ctx, ownerID)
+}
+
+// GetVerifiedPayoutAccount mocks base method.
+func (m *MockPayoutServiceInterface) GetVerifiedPayoutAccount(ctx context.Context, ownerID string) (*models.PayoutAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerifiedPayoutAccount", ctx, ownerID)
+	ret0, _ := ret[0].(*models.PayoutAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerifiedPayoutAccount indicates an expected call of GetVerifiedPayoutAccount.
+func (mr *MockPayoutServiceInterfaceMockRecorder) GetVerifiedPayoutAccount(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifiedPayoutAccount", reflect.TypeOf((*MockPayoutServiceInterface)(nil).GetVerifiedPayoutAccount), ctx, ownerID)
+}
+
+// RegisterPayoutAccount mocks base method.
+func (m *MockPayoutServiceInterface) RegisterPayoutAccount(ctx context.Context, ownerID string, req models.PayoutAccountRequest) (*models.PayoutAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterPayoutAccount", ctx, ownerID, req)
+	ret0, _ := ret[0].(*models.PayoutAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterPayoutAccount indicates an expected call of RegisterPayoutAccount.
+func (mr *MockPayoutServiceInterfaceMockRecorder) RegisterPayoutAccount(ctx, ownerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPayoutAccount", reflect.TypeOf((*MockPayoutServiceInterface)(nil).RegisterPayoutAccount), ctx, ownerID, req)
+}
+
+// VerifyPayoutAccount mocks base method.
+func (m *MockPayoutServiceInterface) VerifyPayoutAccount(ctx context.Context, ownerID, id string) (*models.PayoutAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPayoutAccount", ctx, ownerID, id)
+	ret0, _ := ret[0].(*models.PayoutAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyPayoutAccount indicates an expected call of VerifyPayoutAccount.
+func (mr *MockPayoutServiceInterfaceMockRecorder) VerifyPayoutAccount(ctx, ownerID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPayoutAccount", reflect.TypeOf((*MockPayoutServiceInterface)(nil).VerifyPayoutAccount), ctx, ownerID, id)
+}
+
+// MockNotificationServiceInterface is a mock of NotificationServiceInterface interface.
+type MockNotificationServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationServiceInterfaceMockRecorder is the mock recorder for MockNotificationServiceInterface.
+type MockNotificationServiceInterfaceMockRecorder struct {
+	mock *MockNotificationServiceInterface
+}
+
+// NewMockNotificationServiceInterface creates a new mock instance.
+func NewMockNotificationServiceInterface(ctrl *gomock.Controller) *MockNotificationServiceInterface {
+	mock := &MockNotificationServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationServiceInterface) EXPECT() *MockNotificationServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockNotificationServiceInterface) Notify(ctx context.Context, user models.User, subject, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, user, subject, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotificationServiceInterfaceMockRecorder) Notify(ctx, user, subject, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotificationServiceInterface)(nil).Notify), ctx, user, subject, message)
+}
+
+// MockEmailSenderInterface is a mock of EmailSenderInterface interface.
+type MockEmailSenderInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailSenderInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailSenderInterfaceMockRecorder is the mock recorder for MockEmailSenderInterface.
+type MockEmailSenderInterfaceMockRecorder struct {
+	mock *MockEmailSenderInterface
+}
+
+// NewMockEmailSenderInterface creates a new mock instance.
+func NewMockEmailSenderInterface(ctrl *gomock.Controller) *MockEmailSenderInterface {
+	mock := &MockEmailSenderInterface{ctrl: ctrl}
+	mock.recorder = &MockEmailSenderInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailSenderInterface) EXPECT() *MockEmailSenderInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SendEmail mocks base method.
+func (m *MockEmailSenderInterface) SendEmail(ctx context.Context, to, subject, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmail", ctx, to, subject, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmail indicates an expected call of SendEmail.
+func (mr *MockEmailSenderInterfaceMockRecorder) SendEmail(ctx, to, subject, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmail", reflect.TypeOf((*MockEmailSenderInterface)(nil).SendEmail), ctx, to, subject, body)
+}
+
+// MockSecurityMonitorInterface is a mock of SecurityMonitorInterface interface.
+type MockSecurityMonitorInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecurityMonitorInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSecurityMonitorInterfaceMockRecorder is the mock recorder for MockSecurityMonitorInterface.
+type MockSecurityMonitorInterfaceMockRecorder struct {
+	mock *MockSecurityMonitorInterface
+}
+
+// NewMockSecurityMonitorInterface creates a new mock instance.
+func NewMockSecurityMonitorInterface(ctrl *gomock.Controller) *MockSecurityMonitorInterface {
+	mock := &MockSecurityMonitorInterface{ctrl: ctrl}
+	mock.recorder = &MockSecurityMonitorInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecurityMonitorInterface) EXPECT() *MockSecurityMonitorInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RecordBookingCancellation mocks base method.
+func (m *MockSecurityMonitorInterface) RecordBookingCancellation(ctx context.Context, customerID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordBookingCancellation", ctx, customerID)
+}
+
+// RecordBookingCancellation indicates an expected call of RecordBookingCancellation.
+func (mr *MockSecurityMonitorInterfaceMockRecorder) RecordBookingCancellation(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordBookingCancellation", reflect.TypeOf((*MockSecurityMonitorInterface)(nil).RecordBookingCancellation), ctx, customerID)
+}
+
+// RecordHighRiskBooking mocks base method.
+func (m *MockSecurityMonitorInterface) RecordHighRiskBooking(ctx context.Context, booking models.Booking, assessment models.RiskAssessment) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordHighRiskBooking", ctx, booking, assessment)
+}
+
+// RecordHighRiskBooking indicates an expected call of RecordHighRiskBooking.
+func (mr *MockSecurityMonitorInterfaceMockRecorder) RecordHighRiskBooking(ctx, booking, assessment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordHighRiskBooking", reflect.TypeOf((*MockSecurityMonitorInterface)(nil).RecordHighRiskBooking), ctx, booking, assessment)
+}
+
+// RecordLogin mocks base method.
+func (m *MockSecurityMonitorInterface) RecordLogin(ctx context.Context, user models.User, ipAddress, userAgent string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLogin", ctx, user, ipAddress, userAgent)
+}
+
+// RecordLogin indicates an expected call of RecordLogin.
+func (mr *MockSecurityMonitorInterfaceMockRecorder) RecordLogin(ctx, user, ipAddress, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLogin", reflect.TypeOf((*MockSecurityMonitorInterface)(nil).RecordLogin), ctx, user, ipAddress, userAgent)
+}
+
+// RecordPaymentFailure mocks base method.
+func (m *MockSecurityMonitorInterface) RecordPaymentFailure(ctx context.Context, customerID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordPaymentFailure", ctx, customerID)
+}
+
+// RecordPaymentFailure indicates an expected call of RecordPaymentFailure.
+func (mr *MockSecurityMonitorInterfaceMockRecorder) RecordPaymentFailure(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPaymentFailure", reflect.TypeOf((*MockSecurityMonitorInterface)(nil).RecordPaymentFailure), ctx, customerID)
+}
+
+// MockRiskScorerInterface is a mock of RiskScorerInterface interface.
+type MockRiskScorerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRiskScorerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockRiskScorerInterfaceMockRecorder is the mock recorder for MockRiskScorerInterface.
+type MockRiskScorerInterfaceMockRecorder struct {
+	mock *MockRiskScorerInterface
+}
+
+// NewMockRiskScorerInterface creates a new mock instance.
+func NewMockRiskScorerInterface(ctrl *gomock.Controller) *MockRiskScorerInterface {
+	mock := &MockRiskScorerInterface{ctrl: ctrl}
+	mock.recorder = &MockRiskScorerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRiskScorerInterface) EXPECT() *MockRiskScorerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AssessBooking mocks base method.
+func (m *MockRiskScorerInterface) AssessBooking(ctx context.Context, booking models.Booking, stage models.RiskStage) (models.RiskAssessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssessBooking", ctx, booking, stage)
+	ret0, _ := ret[0].(models.RiskAssessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssessBooking indicates an expected call of AssessBooking.
+func (mr *MockRiskScorerInterfaceMockRecorder) AssessBooking(ctx, booking, stage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssessBooking", reflect.TypeOf((*MockRiskScorerInterface)(nil).AssessBooking), ctx, booking, stage)
+}
+
+// MockGeocoderInterface is a mock of GeocoderInterface interface.
+type MockGeocoderInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeocoderInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockGeocoderInterfaceMockRecorder is the mock recorder for MockGeocoderInterface.
+type MockGeocoderInterfaceMockRecorder struct {
+	mock *MockGeocoderInterface
+}
+
+// NewMockGeocoderInterface creates a new mock instance.
+func NewMockGeocoderInterface(ctrl *gomock.Controller) *MockGeocoderInterface {
+	mock := &MockGeocoderInterface{ctrl: ctrl}
+	mock.recorder = &MockGeocoderInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeocoderInterface) EXPECT() *MockGeocoderInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Geocode mocks base method.
+func (m *MockGeocoderInterface) Geocode(ctx context.Context, address string) (models.GeocodedAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Geocode", ctx, address)
+	ret0, _ := ret[0].(models.GeocodedAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Geocode indicates an expected call of Geocode.
+func (mr *MockGeocoderInterfaceMockRecorder) Geocode(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Geocode", reflect.TypeOf((*MockGeocoderInterface)(nil).Geocode), ctx, address)
+}
+
+// MockSecurityServiceInterface is a mock of SecurityServiceInterface interface.
+type MockSecurityServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecurityServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSecurityServiceInterfaceMockRecorder is the mock recorder for MockSecurityServiceInterface.
+type MockSecurityServiceInterfaceMockRecorder struct {
+	mock *MockSecurityServiceInterface
+}
+
+// NewMockSecurityServiceInterface creates a new mock instance.
+func NewMockSecurityServiceInterface(ctrl *gomock.Controller) *MockSecurityServiceInterface {
+	mock := &MockSecurityServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockSecurityServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecurityServiceInterface) EXPECT() *MockSecurityServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetSecurityEventByID mocks base method.
+func (m *MockSecurityServiceInterface) GetSecurityEventByID(ctx context.Context, id string) (*models.SecurityEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityEventByID", ctx, id)
+	ret0, _ := ret[0].(*models.SecurityEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurityEventByID indicates an expected call of GetSecurityEventByID.
+func (mr *MockSecurityServiceInterfaceMockRecorder) GetSecurityEventByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityEventByID", reflect.TypeOf((*MockSecurityServiceInterface)(nil).GetSecurityEventByID), ctx, id)
+}
+
+// ListSecurityEvents mocks base method.
+func (m *MockSecurityServiceInterface) ListSecurityEvents(ctx context.Context, filter models.SecurityEventFilter, page models.PageRequest) (*models.Page[models.SecurityEvent], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityEvents", ctx, filter, page)
+	ret0, _ := ret[0].(*models.Page[models.SecurityEvent])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityEvents indicates an expected call of ListSecurityEvents.
+func (mr *MockSecurityServiceInterfaceMockRecorder) ListSecurityEvents(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityEvents", reflect.TypeOf((*MockSecurityServiceInterface)(nil).ListSecurityEvents), ctx, filter, page)
+}
+
+// RecordBookingCancellation mocks base method.
+func (m *MockSecurityServiceInterface) RecordBookingCancellation(ctx context.Context, customerID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordBookingCancellation", ctx, customerID)
+}
+
+// RecordBookingCancellation indicates an expected call of RecordBookingCancellation.
+func (mr *MockSecurityServiceInterfaceMockRecorder) RecordBookingCancellation(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordBookingCancellation", reflect.TypeOf((*MockSecurityServiceInterface)(nil).RecordBookingCancellation), ctx, customerID)
+}
+
+// RecordHighRiskBooking mocks base method.
+func (m *MockSecurityServiceInterface) RecordHighRiskBooking(ctx context.Context, booking models.Booking, assessment models.RiskAssessment) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordHighRiskBooking", ctx, booking, assessment)
+}
+
+// RecordHighRiskBooking indicates an expected call of RecordHighRiskBooking.
+func (mr *MockSecurityServiceInterfaceMockRecorder) RecordHighRiskBooking(ctx, booking, assessment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordHighRiskBooking", reflect.TypeOf((*MockSecurityServiceInterface)(nil).RecordHighRiskBooking), ctx, booking, assessment)
+}
+
+// RecordLogin mocks base method.
+func (m *MockSecurityServiceInterface) RecordLogin(ctx context.Context, user models.User, ipAddress, userAgent string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLogin", ctx, user, ipAddress, userAgent)
+}
+
+// RecordLogin indicates an expected call of RecordLogin.
+func (mr *MockSecurityServiceInterfaceMockRecorder) RecordLogin(ctx, user, ipAddress, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLogin", reflect.TypeOf((*MockSecurityServiceInterface)(nil).RecordLogin), ctx, user, ipAddress, userAgent)
+}
+
+// RecordPaymentFailure mocks base method.
+func (m *MockSecurityServiceInterface) RecordPaymentFailure(ctx context.Context, customerID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordPaymentFailure", ctx, customerID)
+}
+
+// RecordPaymentFailure indicates an expected call of RecordPaymentFailure.
+func (mr *MockSecurityServiceInterfaceMockRecorder) RecordPaymentFailure(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPaymentFailure", reflect.TypeOf((*MockSecurityServiceInterface)(nil).RecordPaymentFailure), ctx, customerID)
+}
+
+// ReviewSecurityEvent mocks base method.
+func (m *MockSecurityServiceInterface) ReviewSecurityEvent(ctx context.Context, id, reviewerID string, review models.SecurityEventReview) (*models.SecurityEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewSecurityEvent", ctx, id, reviewerID, review)
+	ret0, _ := ret[0].(*models.SecurityEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewSecurityEvent indicates an expected call of ReviewSecurityEvent.
+func (mr *MockSecurityServiceInterfaceMockRecorder) ReviewSecurityEvent(ctx, id, reviewerID, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewSecurityEvent", reflect.TypeOf((*MockSecurityServiceInterface)(nil).ReviewSecurityEvent), ctx, id, reviewerID, review)
+}
+
+// MockLocationServiceInterface is a mock of LocationServiceInterface interface.
+type MockLocationServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockLocationServiceInterfaceMockRecorder is the mock recorder for MockLocationServiceInterface.
+type MockLocationServiceInterfaceMockRecorder struct {
+	mock *MockLocationServiceInterface
+}
+
+// NewMockLocationServiceInterface creates a new mock instance.
+func NewMockLocationServiceInterface(ctrl *gomock.Controller) *MockLocationServiceInterface {
+	mock := &MockLocationServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockLocationServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationServiceInterface) EXPECT() *MockLocationServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AttachCar mocks base method.
+func (m *MockLocationServiceInterface) AttachCar(ctx context.Context, userID, role, carID, locationID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachCar", ctx, userID, role, carID, locationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachCar indicates an expected call of AttachCar.
+func (mr *MockLocationServiceInterfaceMockRecorder) AttachCar(ctx, userID, role, carID, locationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachCar", reflect.TypeOf((*MockLocationServiceInterface)(nil).AttachCar), ctx, userID, role, carID, locationID)
+}
+
+// CreateLocation mocks base method.
+func (m *MockLocationServiceInterface) CreateLocation(ctx context.Context, req models.LocationRequest) (*models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLocation", ctx, req)
+	ret0, _ := ret[0].(*models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLocation indicates an expected call of CreateLocation.
+func (mr *MockLocationServiceInterfaceMockRecorder) CreateLocation(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLocation", reflect.TypeOf((*MockLocationServiceInterface)(nil).CreateLocation), ctx, req)
+}
+
+// DeleteLocation mocks base method.
+func (m *MockLocationServiceInterface) DeleteLocation(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLocation", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLocation indicates an expected call of DeleteLocation.
+func (mr *MockLocationServiceInterfaceMockRecorder) DeleteLocation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLocation", reflect.TypeOf((*MockLocationServiceInterface)(nil).DeleteLocation), ctx, id)
+}
+
+// DeleteRelocationFee mocks base method.
+func (m *MockLocationServiceInterface) DeleteRelocationFee(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRelocationFee", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRelocationFee indicates an expected call of DeleteRelocationFee.
+func (mr *MockLocationServiceInterfaceMockRecorder) DeleteRelocationFee(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRelocationFee", reflect.TypeOf((*MockLocationServiceInterface)(nil).DeleteRelocationFee), ctx, id)
+}
+
+// DetachCar mocks base method.
+func (m *MockLocationServiceInterface) DetachCar(ctx context.Context, userID, role, carID, locationID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachCar", ctx, userID, role, carID, locationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachCar indicates an expected call of DetachCar.
+func (mr *MockLocationServiceInterfaceMockRecorder) DetachCar(ctx, userID, role, carID, locationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachCar", reflect.TypeOf((*MockLocationServiceInterface)(nil).DetachCar), ctx, userID, role, carID, locationID)
+}
+
+// GetCarLocations mocks base method.
+func (m *MockLocationServiceInterface) GetCarLocations(ctx context.Context, carID string) ([]models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarLocations", ctx, carID)
+	ret0, _ := ret[0].([]models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarLocations indicates an expected call of GetCarLocations.
+func (mr *MockLocationServiceInterfaceMockRecorder) GetCarLocations(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarLocations", reflect.TypeOf((*MockLocationServiceInterface)(nil).GetCarLocations), ctx, carID)
+}
+
+// GetDeliveryOption mocks base method.
+func (m *MockLocationServiceInterface) GetDeliveryOption(ctx context.Context, carID string) (*models.DeliveryOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryOption", ctx, carID)
+	ret0, _ := ret[0].(*models.DeliveryOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryOption indicates an expected call of GetDeliveryOption.
+func (mr *MockLocationServiceInterfaceMockRecorder) GetDeliveryOption(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryOption", reflect.TypeOf((*MockLocationServiceInterface)(nil).GetDeliveryOption), ctx, carID)
+}
+
+// GetLocationByID mocks base method.
+func (m *MockLocationServiceInterface) GetLocationByID(ctx context.Context, id string) (*models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocationByID", ctx, id)
+	ret0, _ := ret[0].(*models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocationByID indicates an expected call of GetLocationByID.
+func (mr *MockLocationServiceInterfaceMockRecorder) GetLocationByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationByID", reflect.TypeOf((*MockLocationServiceInterface)(nil).GetLocationByID), ctx, id)
+}
+
+// ListLocations mocks base method.
+func (m *MockLocationServiceInterface) ListLocations(ctx context.Context, city string, includeInactive bool) ([]models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLocations", ctx, city, includeInactive)
+	ret0, _ := ret[0].([]models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLocations indicates an expected call of ListLocations.
+func (mr *MockLocationServiceInterfaceMockRecorder) ListLocations(ctx, city, includeInactive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLocations", reflect.TypeOf((*MockLocationServiceInterface)(nil).ListLocations), ctx, city, includeInactive)
+}
+
+// ListRelocationFees mocks base method.
+func (m *MockLocationServiceInterface) ListRelocationFees(ctx context.Context) ([]models.RelocationFee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRelocationFees", ctx)
+	ret0, _ := ret[0].([]models.RelocationFee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRelocationFees indicates an expected call of ListRelocationFees.
+func (mr *MockLocationServiceInterfaceMockRecorder) ListRelocationFees(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRelocationFees", reflect.TypeOf((*MockLocationServiceInterface)(nil).ListRelocationFees), ctx)
+}
+
+// SetRelocationFee mocks base method.
+func (m *MockLocationServiceInterface) SetRelocationFee(ctx context.Context, req models.RelocationFeeRequest) (*models.RelocationFee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRelocationFee", ctx, req)
+	ret0, _ := ret[0].(*models.RelocationFee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRelocationFee indicates an expected call of SetRelocationFee.
+func (mr *MockLocationServiceInterfaceMockRecorder) SetRelocationFee(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRelocationFee", reflect.TypeOf((*MockLocationServiceInterface)(nil).SetRelocationFee), ctx, req)
+}
+
+// UpdateDeliveryOption mocks base method.
+func (m *MockLocationServiceInterface) UpdateDeliveryOption(ctx context.Context, userID, role, carID string, req models.DeliveryOptionRequest) (*models.DeliveryOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeliveryOption", ctx, userID, role, carID, req)
+	ret0, _ := ret[0].(*models.DeliveryOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDeliveryOption indicates an expected call of UpdateDeliveryOption.
+func (mr *MockLocationServiceInterfaceMockRecorder) UpdateDeliveryOption(ctx, userID, role, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeliveryOption", reflect.TypeOf((*MockLocationServiceInterface)(nil).UpdateDeliveryOption), ctx, userID, role, carID, req)
+}
+
+// UpdateLocation mocks base method.
+func (m *MockLocationServiceInterface) UpdateLocation(ctx context.Context, id string, req models.LocationRequest) (*models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocation", ctx, id, req)
+	ret0, _ := ret[0].(*models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLocation indicates an expected call of UpdateLocation.
+func (mr *MockLocationServiceInterfaceMockRecorder) UpdateLocation(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockLocationServiceInterface)(nil).UpdateLocation), ctx, id, req)
+}
+
+// MockCarEventListenerInterface is a mock of CarEventListenerInterface interface.
+type MockCarEventListenerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarEventListenerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCarEventListenerInterfaceMockRecorder is the mock recorder for MockCarEventListenerInterface.
+type MockCarEventListenerInterfaceMockRecorder struct {
+	mock *MockCarEventListenerInterface
+}
+
+// NewMockCarEventListenerInterface creates a new mock instance.
+func NewMockCarEventListenerInterface(ctrl *gomock.Controller) *MockCarEventListenerInterface {
+	mock := &MockCarEventListenerInterface{ctrl: ctrl}
+	mock.recorder = &MockCarEventListenerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarEventListenerInterface) EXPECT() *MockCarEventListenerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// HandleCarEvent mocks base method.
+func (m *MockCarEventListenerInterface) HandleCarEvent(ctx context.Context, event models.CarEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleCarEvent", ctx, event)
+}
+
+// HandleCarEvent indicates an expected call of HandleCarEvent.
+func (mr *MockCarEventListenerInterfaceMockRecorder) HandleCarEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCarEvent", reflect.TypeOf((*MockCarEventListenerInterface)(nil).HandleCarEvent), ctx, event)
+}
+
+// MockAlertServiceInterface is a mock of AlertServiceInterface interface.
+type MockAlertServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAlertServiceInterfaceMockRecorder is the mock recorder for MockAlertServiceInterface.
+type MockAlertServiceInterfaceMockRecorder struct {
+	mock *MockAlertServiceInterface
+}
+
+// NewMockAlertServiceInterface creates a new mock instance.
+func NewMockAlertServiceInterface(ctrl *gomock.Controller) *MockAlertServiceInterface {
+	mock := &MockAlertServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockAlertServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertServiceInterface) EXPECT() *MockAlertServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetSubscriptions mocks base method.
+func (m *MockAlertServiceInterface) GetSubscriptions(ctx context.Context, userID string) ([]models.CarAlertSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptions", ctx, userID)
+	ret0, _ := ret[0].([]models.CarAlertSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptions indicates an expected call of GetSubscriptions.
+func (mr *MockAlertServiceInterfaceMockRecorder) GetSubscriptions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockAlertServiceInterface)(nil).GetSubscriptions), ctx, userID)
+}
+
+// HandleCarEvent mocks base method.
+func (m *MockAlertServiceInterface) HandleCarEvent(ctx context.Context, event models.CarEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleCarEvent", ctx, event)
+}
+
+// HandleCarEvent indicates an expected call of HandleCarEvent.
+func (mr *MockAlertServiceInterfaceMockRecorder) HandleCarEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCarEvent", reflect.TypeOf((*MockAlertServiceInterface)(nil).HandleCarEvent), ctx, event)
+}
+
+// Subscribe mocks base method.
+func (m *MockAlertServiceInterface) Subscribe(ctx context.Context, userID, carID string, req models.CarAlertSubscriptionRequest) (*models.CarAlertSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, userID, carID, req)
+	ret0, _ := ret[0].(*models.CarAlertSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockAlertServiceInterfaceMockRecorder) Subscribe(ctx, userID, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockAlertServiceInterface)(nil).Subscribe), ctx, userID, carID, req)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockAlertServiceInterface) Unsubscribe(ctx context.Context, userID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockAlertServiceInterfaceMockRecorder) Unsubscribe(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockAlertServiceInterface)(nil).Unsubscribe), ctx, userID, id)
+}
+
+// MockFavoriteServiceInterface is a mock of FavoriteServiceInterface interface.
+type MockFavoriteServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFavoriteServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockFavoriteServiceInterfaceMockRecorder is the mock recorder for MockFavoriteServiceInterface.
+type MockFavoriteServiceInterfaceMockRecorder struct {
+	mock *MockFavoriteServiceInterface
+}
+
+// NewMockFavoriteServiceInterface creates a new mock instance.
+func NewMockFavoriteServiceInterface(ctrl *gomock.Controller) *MockFavoriteServiceInterface {
+	mock := &MockFavoriteServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockFavoriteServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFavoriteServiceInterface) EXPECT() *MockFavoriteServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddFavorite mocks base method.
+func (m *MockFavoriteServiceInterface) AddFavorite(ctx context.Context, userID, carID string) (*models.Favorite, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFavorite", ctx, userID, carID)
+	ret0, _ := ret[0].(*models.Favorite)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddFavorite indicates an expected call of AddFavorite.
+func (mr *MockFavoriteServiceInterfaceMockRecorder) AddFavorite(ctx, userID, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavorite", reflect.TypeOf((*MockFavoriteServiceInterface)(nil).AddFavorite), ctx, userID, carID)
+}
+
+// ListFavorites mocks base method.
+func (m *MockFavoriteServiceInterface) ListFavorites(ctx context.Context, userID string, page models.PageRequest) (*models.Page[models.Favorite], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFavorites", ctx, userID, page)
+	ret0, _ := ret[0].(*models.Page[models.Favorite])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFavorites indicates an expected call of ListFavorites.
+func (mr *MockFavoriteServiceInterfaceMockRecorder) ListFavorites(ctx, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFavorites", reflect.TypeOf((*MockFavoriteServiceInterface)(nil).ListFavorites), ctx, userID, page)
+}
+
+// RemoveFavorite mocks base method.
+func (m *MockFavoriteServiceInterface) RemoveFavorite(ctx context.Context, userID, carID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFavorite", ctx, userID, carID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFavorite indicates an expected call of RemoveFavorite.
+func (mr *MockFavoriteServiceInterfaceMockRecorder) RemoveFavorite(ctx, userID, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavorite", reflect.TypeOf((*MockFavoriteServiceInterface)(nil).RemoveFavorite), ctx, userID, carID)
+}
+
+// MockAnalyticsServiceInterface is a mock of AnalyticsServiceInterface interface.
+type MockAnalyticsServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAnalyticsServiceInterfaceMockRecorder is the mock recorder for MockAnalyticsServiceInterface.
+type MockAnalyticsServiceInterfaceMockRecorder struct {
+	mock *MockAnalyticsServiceInterface
+}
+
+// NewMockAnalyticsServiceInterface creates a new mock instance.
+func NewMockAnalyticsServiceInterface(ctrl *gomock.Controller) *MockAnalyticsServiceInterface {
+	mock := &MockAnalyticsServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsServiceInterface) EXPECT() *MockAnalyticsServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetBookingFunnel mocks base method.
+func (m *MockAnalyticsServiceInterface) GetBookingFunnel(ctx context.Context, r models.DashboardRange) (*models.Dashboard[models.BookingFunnel], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingFunnel", ctx, r)
+	ret0, _ := ret[0].(*models.Dashboard[models.BookingFunnel])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingFunnel indicates an expected call of GetBookingFunnel.
+func (mr *MockAnalyticsServiceInterfaceMockRecorder) GetBookingFunnel(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingFunnel", reflect.TypeOf((*MockAnalyticsServiceInterface)(nil).GetBookingFunnel), ctx, r)
+}
+
+// GetCarUtilization mocks base method.
+func (m *MockAnalyticsServiceInterface) GetCarUtilization(ctx context.Context, r models.DashboardRange, limit int) (*models.Dashboard[[]models.CarUtilization], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarUtilization", ctx, r, limit)
+	ret0, _ := ret[0].(*models.Dashboard[[]models.CarUtilization])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarUtilization indicates an expected call of GetCarUtilization.
+func (mr *MockAnalyticsServiceInterfaceMockRecorder) GetCarUtilization(ctx, r, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarUtilization", reflect.TypeOf((*MockAnalyticsServiceInterface)(nil).GetCarUtilization), ctx, r, limit)
+}
+
+// GetOwnerEarnings mocks base method.
+func (m *MockAnalyticsServiceInterface) GetOwnerEarnings(ctx context.Context, r models.DashboardRange, limit int) (*models.Dashboard[[]models.OwnerEarnings], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerEarnings", ctx, r, limit)
+	ret0, _ := ret[0].(*models.Dashboard[[]models.OwnerEarnings])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerEarnings indicates an expected call of GetOwnerEarnings.
+func (mr *MockAnalyticsServiceInterfaceMockRecorder) GetOwnerEarnings(ctx, r, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerEarnings", reflect.TypeOf((*MockAnalyticsServiceInterface)(nil).GetOwnerEarnings), ctx, r, limit)
+}
+
+// GetTimeSeries mocks base method.
+func (m *MockAnalyticsServiceInterface) GetTimeSeries(ctx context.Context, query models.TimeSeriesQuery) (*models.TimeSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeSeries", ctx, query)
+	ret0, _ := ret[0].(*models.TimeSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeSeries indicates an expected call of GetTimeSeries.
+func (mr *MockAnalyticsServiceInterfaceMockRecorder) GetTimeSeries(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeSeries", reflect.TypeOf((*MockAnalyticsServiceInterface)(nil).GetTimeSeries), ctx, query)
+}
+
+// RefreshDashboards mocks base method.
+func (m *MockAnalyticsServiceInterface) RefreshDashboards(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshDashboards", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshDashboards indicates an expected call of RefreshDashboards.
+func (mr *MockAnalyticsServiceInterfaceMockRecorder) RefreshDashboards(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshDashboards", reflect.TypeOf((*MockAnalyticsServiceInterface)(nil).RefreshDashboards), ctx)
+}
+
+// MockObjectStorageInterface is a mock of ObjectStorageInterface interface.
+type MockObjectStorageInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectStorageInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockObjectStorageInterfaceMockRecorder is the mock recorder for MockObjectStorageInterface.
+type MockObjectStorageInterfaceMockRecorder struct {
+	mock *MockObjectStorageInterface
+}
+
+// NewMockObjectStorageInterface creates a new mock instance.
+func NewMockObjectStorageInterface(ctrl *gomock.Controller) *MockObjectStorageInterface {
+	mock := &MockObjectStorageInterface{ctrl: ctrl}
+	mock.recorder = &MockObjectStorageInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectStorageInterface) EXPECT() *MockObjectStorageInterfaceMockRecorder {
+	return m.recorder
+}
+
+// PutObject mocks base method.
+func (m *MockObjectStorageInterface) PutObject(ctx context.Context, key string, body []byte, contentType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObject", ctx, key, body, contentType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutObject indicates an expected call of PutObject.
+func (mr *MockObjectStorageInterfaceMockRecorder) PutObject(ctx, key, body, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockObjectStorageInterface)(nil).PutObject), ctx, key, body, contentType)
+}
+
+// MockWarehouseExportServiceInterface is a mock of WarehouseExportServiceInterface interface.
+type MockWarehouseExportServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWarehouseExportServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockWarehouseExportServiceInterfaceMockRecorder is the mock recorder for MockWarehouseExportServiceInterface.
+type MockWarehouseExportServiceInterfaceMockRecorder struct {
+	mock *MockWarehouseExportServiceInterface
+}
+
+// NewMockWarehouseExportServiceInterface creates a new mock instance.
+func NewMockWarehouseExportServiceInterface(ctrl *gomock.Controller) *MockWarehouseExportServiceInterface {
+	mock := &MockWarehouseExportServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockWarehouseExportServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWarehouseExportServiceInterface) EXPECT() *MockWarehouseExportServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Backfill mocks base method.
+func (m *MockWarehouseExportServiceInterface) Backfill(ctx context.Context, req models.WarehouseBackfillRequest) ([]models.WarehouseExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backfill", ctx, req)
+	ret0, _ := ret[0].([]models.WarehouseExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backfill indicates an expected call of Backfill.
+func (mr *MockWarehouseExportServiceInterfaceMockRecorder) Backfill(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backfill", reflect.TypeOf((*MockWarehouseExportServiceInterface)(nil).Backfill), ctx, req)
+}
+
+// ExportIncremental mocks base method.
+func (m *MockWarehouseExportServiceInterface) ExportIncremental(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportIncremental", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportIncremental indicates an expected call of ExportIncremental.
+func (mr *MockWarehouseExportServiceInterfaceMockRecorder) ExportIncremental(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportIncremental", reflect.TypeOf((*MockWarehouseExportServiceInterface)(nil).ExportIncremental), ctx)
+}
+
+// MockSearchServiceInterface is a mock of SearchServiceInterface interface.
+type MockSearchServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchServiceInterfaceMockRecorder is the mock recorder for MockSearchServiceInterface.
+type MockSearchServiceInterfaceMockRecorder struct {
+	mock *MockSearchServiceInterface
+}
+
+// NewMockSearchServiceInterface creates a new mock instance.
+func NewMockSearchServiceInterface(ctrl *gomock.Controller) *MockSearchServiceInterface {
+	mock := &MockSearchServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockSearchServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchServiceInterface) EXPECT() *MockSearchServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchServiceInterface) Search(ctx context.Context, q string) ([]models.SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, q)
+	ret0, _ := ret[0].([]models.SearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchServiceInterfaceMockRecorder) Search(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchServiceInterface)(nil).Search), ctx, q)
+}
+
+// MockOperatorServiceInterface is a mock of OperatorServiceInterface interface.
+type MockOperatorServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperatorServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOperatorServiceInterfaceMockRecorder is the mock recorder for MockOperatorServiceInterface.
+type MockOperatorServiceInterfaceMockRecorder struct {
+	mock *MockOperatorServiceInterface
+}
+
+// NewMockOperatorServiceInterface creates a new mock instance.
+func NewMockOperatorServiceInterface(ctrl *gomock.Controller) *MockOperatorServiceInterface {
+	mock := &MockOperatorServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockOperatorServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperatorServiceInterface) EXPECT() *MockOperatorServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateOperator mocks base method.
+func (m *MockOperatorServiceInterface) CreateOperator(ctx context.Context, req models.OperatorRequest) (*models.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOperator", ctx, req)
+	ret0, _ := ret[0].(*models.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOperator indicates an expected call of CreateOperator.
+func (mr *MockOperatorServiceInterfaceMockRecorder) CreateOperator(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOperator", reflect.TypeOf((*MockOperatorServiceInterface)(nil).CreateOperator), ctx, req)
+}
+
+// GetOperator mocks base method.
+func (m *MockOperatorServiceInterface) GetOperator(ctx context.Context, id string) (*models.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperator", ctx, id)
+	ret0, _ := ret[0].(*models.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperator indicates an expected call of GetOperator.
+func (mr *MockOperatorServiceInterfaceMockRecorder) GetOperator(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperator", reflect.TypeOf((*MockOperatorServiceInterface)(nil).GetOperator), ctx, id)
+}
+
+// ListOperators mocks base method.
+func (m *MockOperatorServiceInterface) ListOperators(ctx context.Context) ([]models.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOperators", ctx)
+	ret0, _ := ret[0].([]models.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOperators indicates an expected call of ListOperators.
+func (mr *MockOperatorServiceInterfaceMockRecorder) ListOperators(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperators", reflect.TypeOf((*MockOperatorServiceInterface)(nil).ListOperators), ctx)
+}
+
+// ResolveDomain mocks base method.
+func (m *MockOperatorServiceInterface) ResolveDomain(ctx context.Context, host string) (*models.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveDomain", ctx, host)
+	ret0, _ := ret[0].(*models.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveDomain indicates an expected call of ResolveDomain.
+func (mr *MockOperatorServiceInterfaceMockRecorder) ResolveDomain(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveDomain", reflect.TypeOf((*MockOperatorServiceInterface)(nil).ResolveDomain), ctx, host)
+}
+
+// UpdateOperator mocks base method.
+func (m *MockOperatorServiceInterface) UpdateOperator(ctx context.Context, id string, req models.OperatorRequest) (*models.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOperator", ctx, id, req)
+	ret0, _ := ret[0].(*models.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOperator indicates an expected call of UpdateOperator.
+func (mr *MockOperatorServiceInterfaceMockRecorder) UpdateOperator(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOperator", reflect.TypeOf((*MockOperatorServiceInterface)(nil).UpdateOperator), ctx, id, req)
+}
+
+// MockInboxServiceInterface is a mock of InboxServiceInterface interface.
+type MockInboxServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockInboxServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockInboxServiceInterfaceMockRecorder is the mock recorder for MockInboxServiceInterface.
+type MockInboxServiceInterfaceMockRecorder struct {
+	mock *MockInboxServiceInterface
+}
+
+// NewMockInboxServiceInterface creates a new mock instance.
+func NewMockInboxServiceInterface(ctrl *gomock.Controller) *MockInboxServiceInterface {
+	mock := &MockInboxServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockInboxServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInboxServiceInterface) EXPECT() *MockInboxServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockInboxServiceInterface) CountUnread(ctx context.Context, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockInboxServiceInterfaceMockRecorder) CountUnread(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockInboxServiceInterface)(nil).CountUnread), ctx, userID)
+}
+
+// ListNotifications mocks base method.
+func (m *MockInboxServiceInterface) ListNotifications(ctx context.Context, userID string, filter models.NotificationFilter, page models.PageRequest) (*models.Page[models.Notification], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, userID, filter, page)
+	ret0, _ := ret[0].(*models.Page[models.Notification])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockInboxServiceInterfaceMockRecorder) ListNotifications(ctx, userID, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockInboxServiceInterface)(nil).ListNotifications), ctx, userID, filter, page)
+}
+
+// MarkRead mocks base method.
+func (m *MockInboxServiceInterface) MarkRead(ctx context.Context, userID, id string) (*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userID, id)
+	ret0, _ := ret[0].(*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockInboxServiceInterfaceMockRecorder) MarkRead(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockInboxServiceInterface)(nil).MarkRead), ctx, userID, id)
+}
+
+// Notify mocks base method.
+func (m *MockInboxServiceInterface) Notify(ctx context.Context, user models.User, subject, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, user, subject, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockInboxServiceInterfaceMockRecorder) Notify(ctx, user, subject, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockInboxServiceInterface)(nil).Notify), ctx, user, subject, message)
+}
+
+// MockTemplatedNotifierInterface is a mock of TemplatedNotifierInterface interface.
+type MockTemplatedNotifierInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplatedNotifierInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTemplatedNotifierInterfaceMockRecorder is the mock recorder for MockTemplatedNotifierInterface.
+type MockTemplatedNotifierInterfaceMockRecorder struct {
+	mock *MockTemplatedNotifierInterface
+}
+
+// NewMockTemplatedNotifierInterface creates a new mock instance.
+func NewMockTemplatedNotifierInterface(ctrl *gomock.Controller) *MockTemplatedNotifierInterface {
+	mock := &MockTemplatedNotifierInterface{ctrl: ctrl}
+	mock.recorder = &MockTemplatedNotifierInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplatedNotifierInterface) EXPECT() *MockTemplatedNotifierInterfaceMockRecorder {
+	return m.recorder
+}
+
+// NotifyWithTemplate mocks base method.
+func (m *MockTemplatedNotifierInterface) NotifyWithTemplate(ctx context.Context, userID, key string, data map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyWithTemplate", ctx, userID, key, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyWithTemplate indicates an expected call of NotifyWithTemplate.
+func (mr *MockTemplatedNotifierInterfaceMockRecorder) NotifyWithTemplate(ctx, userID, key, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyWithTemplate", reflect.TypeOf((*MockTemplatedNotifierInterface)(nil).NotifyWithTemplate), ctx, userID, key, data)
+}
+
+// MockEmailTemplateServiceInterface is a mock of EmailTemplateServiceInterface interface.
+type MockEmailTemplateServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailTemplateServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailTemplateServiceInterfaceMockRecorder is the mock recorder for MockEmailTemplateServiceInterface.
+type MockEmailTemplateServiceInterfaceMockRecorder struct {
+	mock *MockEmailTemplateServiceInterface
+}
+
+// NewMockEmailTemplateServiceInterface creates a new mock instance.
+func NewMockEmailTemplateServiceInterface(ctrl *gomock.Controller) *MockEmailTemplateServiceInterface {
+	mock := &MockEmailTemplateServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockEmailTemplateServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailTemplateServiceInterface) EXPECT() *MockEmailTemplateServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateTemplate mocks base method.
+func (m *MockEmailTemplateServiceInterface) CreateTemplate(ctx context.Context, req models.EmailTemplateRequest, createdBy string) (*models.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", ctx, req, createdBy)
+	ret0, _ := ret[0].(*models.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockEmailTemplateServiceInterfaceMockRecorder) CreateTemplate(ctx, req, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockEmailTemplateServiceInterface)(nil).CreateTemplate), ctx, req, createdBy)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockEmailTemplateServiceInterface) DeleteTemplate(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockEmailTemplateServiceInterfaceMockRecorder) DeleteTemplate(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockEmailTemplateServiceInterface)(nil).DeleteTemplate), ctx, key)
+}
+
+// GetTemplate mocks base method.
+func (m *MockEmailTemplateServiceInterface) GetTemplate(ctx context.Context, key string) (*models.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, key)
+	ret0, _ := ret[0].(*models.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockEmailTemplateServiceInterfaceMockRecorder) GetTemplate(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockEmailTemplateServiceInterface)(nil).GetTemplate), ctx, key)
+}
+
+// ListTemplateVersions mocks base method.
+func (m *MockEmailTemplateServiceInterface) ListTemplateVersions(ctx context.Context, key string) ([]models.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplateVersions", ctx, key)
+	ret0, _ := ret[0].([]models.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplateVersions indicates an expected call of ListTemplateVersions.
+func (mr *MockEmailTemplateServiceInterfaceMockRecorder) ListTemplateVersions(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplateVersions", reflect.TypeOf((*MockEmailTemplateServiceInterface)(nil).ListTemplateVersions), ctx, key)
+}
+
+// ListTemplates mocks base method.
+func (m *MockEmailTemplateServiceInterface) ListTemplates(ctx context.Context) ([]models.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx)
+	ret0, _ := ret[0].([]models.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockEmailTemplateServiceInterfaceMockRecorder) ListTemplates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockEmailTemplateServiceInterface)(nil).ListTemplates), ctx)
+}
+
+// NotifyWithTemplate mocks base method.
+func (m *MockEmailTemplateServiceInterface) NotifyWithTemplate(ctx context.Context, userID, key string, data map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyWithTemplate", ctx, userID, key, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyWithTemplate indicates an expected call of NotifyWithTemplate.
+func (mr *MockEmailTemplateServiceInterfaceMockRecorder) NotifyWithTemplate(ctx, userID, key, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyWithTemplate", reflect.TypeOf((*MockEmailTemplateServiceInterface)(nil).NotifyWithTemplate), ctx, userID, key, data)
+}
+
+// PreviewTemplate mocks base method.
+func (m *MockEmailTemplateServiceInterface) PreviewTemplate(ctx context.Context, key string, req models.EmailTemplatePreviewRequest) (*models.RenderedEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewTemplate", ctx, key, req)
+	ret0, _ := ret[0].(*models.RenderedEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewTemplate indicates an expected call of PreviewTemplate.
+func (mr *MockEmailTemplateServiceInterfaceMockRecorder) PreviewTemplate(ctx, key, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewTemplate", reflect.TypeOf((*MockEmailTemplateServiceInterface)(nil).PreviewTemplate), ctx, key, req)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockEmailTemplateServiceInterface) UpdateTemplate(ctx context.Context, key string, req models.EmailTemplateRequest, createdBy string) (*models.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", ctx, key, req, createdBy)
+	ret0, _ := ret[0].(*models.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockEmailTemplateServiceInterfaceMockRecorder) UpdateTemplate(ctx, key, req, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockEmailTemplateServiceInterface)(nil).UpdateTemplate), ctx, key, req, createdBy)
+}
+
+// MockKYCServiceInterface is a mock of KYCServiceInterface interface.
+type MockKYCServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockKYCServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockKYCServiceInterfaceMockRecorder is the mock recorder for MockKYCServiceInterface.
+type MockKYCServiceInterfaceMockRecorder struct {
+	mock *MockKYCServiceInterface
+}
+
+// NewMockKYCServiceInterface creates a new mock instance.
+func NewMockKYCServiceInterface(ctrl *gomock.Controller) *MockKYCServiceInterface {
+	mock := &MockKYCServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockKYCServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKYCServiceInterface) EXPECT() *MockKYCServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetVerifiedKYC mocks base method.
+func (m *MockKYCServiceInterface) GetVerifiedKYC(ctx context.Context, userID string) (*models.VerifiedKYC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerifiedKYC", ctx, userID)
+	ret0, _ := ret[0].(*models.VerifiedKYC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerifiedKYC indicates an expected call of GetVerifiedKYC.
+func (mr *MockKYCServiceInterfaceMockRecorder) GetVerifiedKYC(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifiedKYC", reflect.TypeOf((*MockKYCServiceInterface)(nil).GetVerifiedKYC), ctx, userID)
+}
+
+// VerifyKYC mocks base method.
+func (m *MockKYCServiceInterface) VerifyKYC(ctx context.Context, adminID, userID string, req models.KYCRequest) (*models.VerifiedKYC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyKYC", ctx, adminID, userID, req)
+	ret0, _ := ret[0].(*models.VerifiedKYC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyKYC indicates an expected call of VerifyKYC.
+func (mr *MockKYCServiceInterfaceMockRecorder) VerifyKYC(ctx, adminID, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyKYC", reflect.TypeOf((*MockKYCServiceInterface)(nil).VerifyKYC), ctx, adminID, userID, req)
+}
+
+// MockVacationServiceInterface is a mock of VacationServiceInterface interface.
+type MockVacationServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockVacationServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockVacationServiceInterfaceMockRecorder is the mock recorder for MockVacationServiceInterface.
+type MockVacationServiceInterfaceMockRecorder struct {
+	mock *MockVacationServiceInterface
+}
+
+// NewMockVacationServiceInterface creates a new mock instance.
+func NewMockVacationServiceInterface(ctrl *gomock.Controller) *MockVacationServiceInterface {
+	mock := &MockVacationServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockVacationServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVacationServiceInterface) EXPECT() *MockVacationServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// EndVacation mocks base method.
+func (m *MockVacationServiceInterface) EndVacation(ctx context.Context, ownerID, id string) (*models.OwnerVacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndVacation", ctx, ownerID, id)
+	ret0, _ := ret[0].(*models.OwnerVacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EndVacation indicates an expected call of EndVacation.
+func (mr *MockVacationServiceInterfaceMockRecorder) EndVacation(ctx, ownerID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndVacation", reflect.TypeOf((*MockVacationServiceInterface)(nil).EndVacation), ctx, ownerID, id)
+}
+
+// GetVacations mocks base method.
+func (m *MockVacationServiceInterface) GetVacations(ctx context.Context, ownerID string) ([]models.OwnerVacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVacations", ctx, ownerID)
+	ret0, _ := ret[0].([]models.OwnerVacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVacations indicates an expected call of GetVacations.
+func (mr *MockVacationServiceInterfaceMockRecorder) GetVacations(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVacations", reflect.TypeOf((*MockVacationServiceInterface)(nil).GetVacations), ctx, ownerID)
+}
+
+// StartVacation mocks base method.
+func (m *MockVacationServiceInterface) StartVacation(ctx context.Context, ownerID string, req models.VacationRequest) (*models.OwnerVacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartVacation", ctx, ownerID, req)
+	ret0, _ := ret[0].(*models.OwnerVacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartVacation indicates an expected call of StartVacation.
+func (mr *MockVacationServiceInterfaceMockRecorder) StartVacation(ctx, ownerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartVacation", reflect.TypeOf((*MockVacationServiceInterface)(nil).StartVacation), ctx, ownerID, req)
+}
+
+// MockFleetServiceInterface is a mock of FleetServiceInterface interface.
+type MockFleetServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFleetServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockFleetServiceInterfaceMockRecorder is the mock recorder for MockFleetServiceInterface.
+type MockFleetServiceInterfaceMockRecorder struct {
+	mock *MockFleetServiceInterface
+}
+
+// NewMockFleetServiceInterface creates a new mock instance.
+func NewMockFleetServiceInterface(ctrl *gomock.Controller) *MockFleetServiceInterface {
+	mock := &MockFleetServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockFleetServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFleetServiceInterface) EXPECT() *MockFleetServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddCar mocks base method.
+func (m *MockFleetServiceInterface) AddCar(ctx context.Context, ownerID, id, carID string) (*models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCar", ctx, ownerID, id, carID)
+	ret0, _ := ret[0].(*models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCar indicates an expected call of AddCar.
+func (mr *MockFleetServiceInterfaceMockRecorder) AddCar(ctx, ownerID, id, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCar", reflect.TypeOf((*MockFleetServiceInterface)(nil).AddCar), ctx, ownerID, id, carID)
+}
+
+// CreateFleet mocks base method.
+func (m *MockFleetServiceInterface) CreateFleet(ctx context.Context, ownerID string, req models.FleetRequest) (*models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFleet", ctx, ownerID, req)
+	ret0, _ := ret[0].(*models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFleet indicates an expected call of CreateFleet.
+func (mr *MockFleetServiceInterfaceMockRecorder) CreateFleet(ctx, ownerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFleet", reflect.TypeOf((*MockFleetServiceInterface)(nil).CreateFleet), ctx, ownerID, req)
+}
+
+// DeleteFleet mocks base method.
+func (m *MockFleetServiceInterface) DeleteFleet(ctx context.Context, ownerID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFleet", ctx, ownerID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFleet indicates an expected call of DeleteFleet.
+func (mr *MockFleetServiceInterfaceMockRecorder) DeleteFleet(ctx, ownerID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFleet", reflect.TypeOf((*MockFleetServiceInterface)(nil).DeleteFleet), ctx, ownerID, id)
+}
+
+// GetFleet mocks base method.
+func (m *MockFleetServiceInterface) GetFleet(ctx context.Context, ownerID, id string) (*models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFleet", ctx, ownerID, id)
+	ret0, _ := ret[0].(*models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFleet indicates an expected call of GetFleet.
+func (mr *MockFleetServiceInterfaceMockRecorder) GetFleet(ctx, ownerID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFleet", reflect.TypeOf((*MockFleetServiceInterface)(nil).GetFleet), ctx, ownerID, id)
+}
+
+// GetFleets mocks base method.
+func (m *MockFleetServiceInterface) GetFleets(ctx context.Context, ownerID string) ([]models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFleets", ctx, ownerID)
+	ret0, _ := ret[0].([]models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFleets indicates an expected call of GetFleets.
+func (mr *MockFleetServiceInterfaceMockRecorder) GetFleets(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFleets", reflect.TypeOf((*MockFleetServiceInterface)(nil).GetFleets), ctx, ownerID)
+}
+
+// RemoveCar mocks base method.
+func (m *MockFleetServiceInterface) RemoveCar(ctx context.Context, ownerID, id, carID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCar", ctx, ownerID, id, carID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCar indicates an expected call of RemoveCar.
+func (mr *MockFleetServiceInterfaceMockRecorder) RemoveCar(ctx, ownerID, id, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCar", reflect.TypeOf((*MockFleetServiceInterface)(nil).RemoveCar), ctx, ownerID, id, carID)
+}
+
+// UpdateFleet mocks base method.
+func (m *MockFleetServiceInterface) UpdateFleet(ctx context.Context, ownerID, id string, req models.FleetRequest) (*models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFleet", ctx, ownerID, id, req)
+	ret0, _ := ret[0].(*models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFleet indicates an expected call of UpdateFleet.
+func (mr *MockFleetServiceInterfaceMockRecorder) UpdateFleet(ctx, ownerID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFleet", reflect.TypeOf((*MockFleetServiceInterface)(nil).UpdateFleet), ctx, ownerID, id, req)
+}
+
+// MockCarAttributeServiceInterface is a mock of CarAttributeServiceInterface interface.
+type MockCarAttributeServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarAttributeServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCarAttributeServiceInterfaceMockRecorder is the mock recorder for MockCarAttributeServiceInterface.
+type MockCarAttributeServiceInterfaceMockRecorder struct {
+	mock *MockCarAttributeServiceInterface
+}
+
+// NewMockCarAttributeServiceInterface creates a new mock instance.
+func NewMockCarAttributeServiceInterface(ctrl *gomock.Controller) *MockCarAttributeServiceInterface {
+	mock := &MockCarAttributeServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCarAttributeServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarAttributeServiceInterface) EXPECT() *MockCarAttributeServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateCarAttribute mocks base method.
+func (m *MockCarAttributeServiceInterface) CreateCarAttribute(ctx context.Context, req models.CarAttributeRequest) (*models.CarAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCarAttribute", ctx, req)
+	ret0, _ := ret[0].(*models.CarAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCarAttribute indicates an expected call of CreateCarAttribute.
+func (mr *MockCarAttributeServiceInterfaceMockRecorder) CreateCarAttribute(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCarAttribute", reflect.TypeOf((*MockCarAttributeServiceInterface)(nil).CreateCarAttribute), ctx, req)
+}
+
+// DeleteCarAttribute mocks base method.
+func (m *MockCarAttributeServiceInterface) DeleteCarAttribute(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCarAttribute", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCarAttribute indicates an expected call of DeleteCarAttribute.
+func (mr *MockCarAttributeServiceInterfaceMockRecorder) DeleteCarAttribute(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCarAttribute", reflect.TypeOf((*MockCarAttributeServiceInterface)(nil).DeleteCarAttribute), ctx, key)
+}
+
+// ListCarAttributes mocks base method.
+func (m *MockCarAttributeServiceInterface) ListCarAttributes(ctx context.Context) ([]models.CarAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCarAttributes", ctx)
+	ret0, _ := ret[0].([]models.CarAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCarAttributes indicates an expected call of ListCarAttributes.
+func (mr *MockCarAttributeServiceInterfaceMockRecorder) ListCarAttributes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCarAttributes", reflect.TypeOf((*MockCarAttributeServiceInterface)(nil).ListCarAttributes), ctx)
+}
+
+// Schema mocks base method.
+func (m *MockCarAttributeServiceInterface) Schema(ctx context.Context) (models.CarAttributeSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schema", ctx)
+	ret0, _ := ret[0].(models.CarAttributeSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schema indicates an expected call of Schema.
+func (mr *MockCarAttributeServiceInterfaceMockRecorder) Schema(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schema", reflect.TypeOf((*MockCarAttributeServiceInterface)(nil).Schema), ctx)
+}
+
+// UpdateCarAttribute mocks base method.
+func (m *MockCarAttributeServiceInterface) UpdateCarAttribute(ctx context.Context, key string, req models.CarAttributeRequest) (*models.CarAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCarAttribute", ctx, key, req)
+	ret0, _ := ret[0].(*models.CarAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCarAttribute indicates an expected call of UpdateCarAttribute.
+func (mr *MockCarAttributeServiceInterfaceMockRecorder) UpdateCarAttribute(ctx, key, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCarAttribute", reflect.TypeOf((*MockCarAttributeServiceInterface)(nil).UpdateCarAttribute), ctx, key, req)
+}
+
+// MockFeatureServiceInterface is a mock of FeatureServiceInterface interface.
+type MockFeatureServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeatureServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockFeatureServiceInterfaceMockRecorder is the mock recorder for MockFeatureServiceInterface.
+type MockFeatureServiceInterfaceMockRecorder struct {
+	mock *MockFeatureServiceInterface
+}
+
+// NewMockFeatureServiceInterface creates a new mock instance.
+func NewMockFeatureServiceInterface(ctrl *gomock.Controller) *MockFeatureServiceInterface {
+	mock := &MockFeatureServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockFeatureServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeatureServiceInterface) EXPECT() *MockFeatureServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateFeature mocks base method.
+func (m *MockFeatureServiceInterface) CreateFeature(ctx context.Context, req models.FeatureRequest) (*models.Feature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeature", ctx, req)
+	ret0, _ := ret[0].(*models.Feature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeature indicates an expected call of CreateFeature.
+func (mr *MockFeatureServiceInterfaceMockRecorder) CreateFeature(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeature", reflect.TypeOf((*MockFeatureServiceInterface)(nil).CreateFeature), ctx, req)
+}
+
+// ListFeatures mocks base method.
+func (m *MockFeatureServiceInterface) ListFeatures(ctx context.Context) ([]models.Feature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeatures", ctx)
+	ret0, _ := ret[0].([]models.Feature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeatures indicates an expected call of ListFeatures.
+func (mr *MockFeatureServiceInterfaceMockRecorder) ListFeatures(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeatures", reflect.TypeOf((*MockFeatureServiceInterface)(nil).ListFeatures), ctx)
+}
+
+// NormalizeCarFeatures mocks base method.
+func (m *MockFeatureServiceInterface) NormalizeCarFeatures(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormalizeCarFeatures", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NormalizeCarFeatures indicates an expected call of NormalizeCarFeatures.
+func (mr *MockFeatureServiceInterfaceMockRecorder) NormalizeCarFeatures(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeCarFeatures", reflect.TypeOf((*MockFeatureServiceInterface)(nil).NormalizeCarFeatures), ctx)
+}
+
+// Taxonomy mocks base method.
+func (m *MockFeatureServiceInterface) Taxonomy(ctx context.Context) (models.FeatureTaxonomy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Taxonomy", ctx)
+	ret0, _ := ret[0].(models.FeatureTaxonomy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Taxonomy indicates an expected call of Taxonomy.
+func (mr *MockFeatureServiceInterfaceMockRecorder) Taxonomy(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Taxonomy", reflect.TypeOf((*MockFeatureServiceInterface)(nil).Taxonomy), ctx)
+}
+
+// UpdateFeature mocks base method.
+func (m *MockFeatureServiceInterface) UpdateFeature(ctx context.Context, key string, req models.FeatureRequest) (*models.Feature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFeature", ctx, key, req)
+	ret0, _ := ret[0].(*models.Feature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFeature indicates an expected call of UpdateFeature.
+func (mr *MockFeatureServiceInterfaceMockRecorder) UpdateFeature(ctx, key, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFeature", reflect.TypeOf((*MockFeatureServiceInterface)(nil).UpdateFeature), ctx, key, req)
+}
+
+// MockBrandServiceInterface is a mock of BrandServiceInterface interface.
+type MockBrandServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrandServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBrandServiceInterfaceMockRecorder is the mock recorder for MockBrandServiceInterface.
+type MockBrandServiceInterfaceMockRecorder struct {
+	mock *MockBrandServiceInterface
+}
+
+// NewMockBrandServiceInterface creates a new mock instance.
+func NewMockBrandServiceInterface(ctrl *gomock.Controller) *MockBrandServiceInterface {
+	mock := &MockBrandServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockBrandServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBrandServiceInterface) EXPECT() *MockBrandServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ListBrands mocks base method.
+func (m *MockBrandServiceInterface) ListBrands(ctx context.Context, query string) ([]models.CarBrand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBrands", ctx, query)
+	ret0, _ := ret[0].([]models.CarBrand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrands indicates an expected call of ListBrands.
+func (mr *MockBrandServiceInterfaceMockRecorder) ListBrands(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrands", reflect.TypeOf((*MockBrandServiceInterface)(nil).ListBrands), ctx, query)
+}
+
+// ListModels mocks base method.
+func (m *MockBrandServiceInterface) ListModels(ctx context.Context, brandID, query string) ([]models.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModels", ctx, brandID, query)
+	ret0, _ := ret[0].([]models.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModels indicates an expected call of ListModels.
+func (mr *MockBrandServiceInterfaceMockRecorder) ListModels(ctx, brandID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModels", reflect.TypeOf((*MockBrandServiceInterface)(nil).ListModels), ctx, brandID, query)
+}
+
+// ResolveBrandModel mocks base method.
+func (m *MockBrandServiceInterface) ResolveBrandModel(ctx context.Context, brand, model string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveBrandModel", ctx, brand, model)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveBrandModel indicates an expected call of ResolveBrandModel.
+func (mr *MockBrandServiceInterfaceMockRecorder) ResolveBrandModel(ctx, brand, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveBrandModel", reflect.TypeOf((*MockBrandServiceInterface)(nil).ResolveBrandModel), ctx, brand, model)
+}
+
+// MockDisputeServiceInterface is a mock of DisputeServiceInterface interface.
+type MockDisputeServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisputeServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockDisputeServiceInterfaceMockRecorder is the mock recorder for MockDisputeServiceInterface.
+type MockDisputeServiceInterfaceMockRecorder struct {
+	mock *MockDisputeServiceInterface
+}
+
+// NewMockDisputeServiceInterface creates a new mock instance.
+func NewMockDisputeServiceInterface(ctrl *gomock.Controller) *MockDisputeServiceInterface {
+	mock := &MockDisputeServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockDisputeServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisputeServiceInterface) EXPECT() *MockDisputeServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddEvidence mocks base method.
+func (m *MockDisputeServiceInterface) AddEvidence(ctx context.Context, id, userID string, req models.DisputeEvidenceRequest) (*models.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEvidence", ctx, id, userID, req)
+	ret0, _ := ret[0].(*models.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEvidence indicates an expected call of AddEvidence.
+func (mr *MockDisputeServiceInterfaceMockRecorder) AddEvidence(ctx, id, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvidence", reflect.TypeOf((*MockDisputeServiceInterface)(nil).AddEvidence), ctx, id, userID, req)
+}
+
+// GetDispute mocks base method.
+func (m *MockDisputeServiceInterface) GetDispute(ctx context.Context, id string) (*models.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDispute", ctx, id)
+	ret0, _ := ret[0].(*models.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDispute indicates an expected call of GetDispute.
+func (mr *MockDisputeServiceInterfaceMockRecorder) GetDispute(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispute", reflect.TypeOf((*MockDisputeServiceInterface)(nil).GetDispute), ctx, id)
+}
+
+// HandleDisputeEvent mocks base method.
+func (m *MockDisputeServiceInterface) HandleDisputeEvent(ctx context.Context, event models.RazorpayWebhookEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleDisputeEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleDisputeEvent indicates an expected call of HandleDisputeEvent.
+func (mr *MockDisputeServiceInterfaceMockRecorder) HandleDisputeEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDisputeEvent", reflect.TypeOf((*MockDisputeServiceInterface)(nil).HandleDisputeEvent), ctx, event)
+}
+
+// ListDisputes mocks base method.
+func (m *MockDisputeServiceInterface) ListDisputes(ctx context.Context, filter models.DisputeFilter) ([]models.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisputes", ctx, filter)
+	ret0, _ := ret[0].([]models.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDisputes indicates an expected call of ListDisputes.
+func (mr *MockDisputeServiceInterfaceMockRecorder) ListDisputes(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisputes", reflect.TypeOf((*MockDisputeServiceInterface)(nil).ListDisputes), ctx, filter)
+}
+
+// RecordOutcome mocks base method.
+func (m *MockDisputeServiceInterface) RecordOutcome(ctx context.Context, id string, req models.DisputeOutcomeRequest) (*models.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOutcome", ctx, id, req)
+	ret0, _ := ret[0].(*models.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordOutcome indicates an expected call of RecordOutcome.
+func (mr *MockDisputeServiceInterfaceMockRecorder) RecordOutcome(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOutcome", reflect.TypeOf((*MockDisputeServiceInterface)(nil).RecordOutcome), ctx, id, req)
+}
+
+// MockSequenceServiceInterface is a mock of SequenceServiceInterface interface.
+type MockSequenceServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSequenceServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSequenceServiceInterfaceMockRecorder is the mock recorder for MockSequenceServiceInterface.
+type MockSequenceServiceInterfaceMockRecorder struct {
+	mock *MockSequenceServiceInterface
+}
+
+// NewMockSequenceServiceInterface creates a new mock instance.
+func NewMockSequenceServiceInterface(ctrl *gomock.Controller) *MockSequenceServiceInterface {
+	mock := &MockSequenceServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockSequenceServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSequenceServiceInterface) EXPECT() *MockSequenceServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetBookingDocuments mocks base method.
+func (m *MockSequenceServiceInterface) GetBookingDocuments(ctx context.Context, bookingID string) ([]models.FinancialDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingDocuments", ctx, bookingID)
+	ret0, _ := ret[0].([]models.FinancialDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingDocuments indicates an expected call of GetBookingDocuments.
+func (mr *MockSequenceServiceInterfaceMockRecorder) GetBookingDocuments(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingDocuments", reflect.TypeOf((*MockSequenceServiceInterface)(nil).GetBookingDocuments), ctx, bookingID)
+}
+
+// IssueCreditNote mocks base method.
+func (m *MockSequenceServiceInterface) IssueCreditNote(ctx context.Context, adjustment models.BookingAdjustment) (*models.FinancialDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueCreditNote", ctx, adjustment)
+	ret0, _ := ret[0].(*models.FinancialDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueCreditNote indicates an expected call of IssueCreditNote.
+func (mr *MockSequenceServiceInterfaceMockRecorder) IssueCreditNote(ctx, adjustment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueCreditNote", reflect.TypeOf((*MockSequenceServiceInterface)(nil).IssueCreditNote), ctx, adjustment)
+}
+
+// IssueInvoice mocks base method.
+func (m *MockSequenceServiceInterface) IssueInvoice(ctx context.Context, booking models.Booking) (*models.FinancialDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueInvoice", ctx, booking)
+	ret0, _ := ret[0].(*models.FinancialDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueInvoice indicates an expected call of IssueInvoice.
+func (mr *MockSequenceServiceInterfaceMockRecorder) IssueInvoice(ctx, booking any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueInvoice", reflect.TypeOf((*MockSequenceServiceInterface)(nil).IssueInvoice), ctx, booking)
+}
+
+// IssueReceipt mocks base method.
+func (m *MockSequenceServiceInterface) IssueReceipt(ctx context.Context, payment models.Payment) (*models.FinancialDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueReceipt", ctx, payment)
+	ret0, _ := ret[0].(*models.FinancialDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueReceipt indicates an expected call of IssueReceipt.
+func (mr *MockSequenceServiceInterfaceMockRecorder) IssueReceipt(ctx, payment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueReceipt", reflect.TypeOf((*MockSequenceServiceInterface)(nil).IssueReceipt), ctx, payment)
+}
+
+// MockAdjustmentServiceInterface is a mock of AdjustmentServiceInterface interface.
+type MockAdjustmentServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdjustmentServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAdjustmentServiceInterfaceMockRecorder is the mock recorder for MockAdjustmentServiceInterface.
+type MockAdjustmentServiceInterfaceMockRecorder struct {
+	mock *MockAdjustmentServiceInterface
+}
+
+// NewMockAdjustmentServiceInterface creates a new mock instance.
+func NewMockAdjustmentServiceInterface(ctrl *gomock.Controller) *MockAdjustmentServiceInterface {
+	mock := &MockAdjustmentServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockAdjustmentServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdjustmentServiceInterface) EXPECT() *MockAdjustmentServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateAdjustment mocks base method.
+func (m *MockAdjustmentServiceInterface) CreateAdjustment(ctx context.Context, bookingID, userID string, req models.BookingAdjustmentRequest) (*models.BookingAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdjustment", ctx, bookingID, userID, req)
+	ret0, _ := ret[0].(*models.BookingAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdjustment indicates an expected call of CreateAdjustment.
+func (mr *MockAdjustmentServiceInterfaceMockRecorder) CreateAdjustment(ctx, bookingID, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdjustment", reflect.TypeOf((*MockAdjustmentServiceInterface)(nil).CreateAdjustment), ctx, bookingID, userID, req)
+}
+
+// GetBookingAdjustments mocks base method.
+func (m *MockAdjustmentServiceInterface) GetBookingAdjustments(ctx context.Context, bookingID, userID, role string) ([]models.BookingAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingAdjustments", ctx, bookingID, userID, role)
+	ret0, _ := ret[0].([]models.BookingAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingAdjustments indicates an expected call of GetBookingAdjustments.
+func (mr *MockAdjustmentServiceInterfaceMockRecorder) GetBookingAdjustments(ctx, bookingID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingAdjustments", reflect.TypeOf((*MockAdjustmentServiceInterface)(nil).GetBookingAdjustments), ctx, bookingID, userID, role)
+}
+
+// ListAdjustments mocks base method.
+func (m *MockAdjustmentServiceInterface) ListAdjustments(ctx context.Context, filter models.AdjustmentFilter) ([]models.BookingAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdjustments", ctx, filter)
+	ret0, _ := ret[0].([]models.BookingAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdjustments indicates an expected call of ListAdjustments.
+func (mr *MockAdjustmentServiceInterfaceMockRecorder) ListAdjustments(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdjustments", reflect.TypeOf((*MockAdjustmentServiceInterface)(nil).ListAdjustments), ctx, filter)
+}
+
+// ReviewAdjustment mocks base method.
+func (m *MockAdjustmentServiceInterface) ReviewAdjustment(ctx context.Context, id, userID string, req models.AdjustmentReviewRequest) (*models.BookingAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewAdjustment", ctx, id, userID, req)
+	ret0, _ := ret[0].(*models.BookingAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewAdjustment indicates an expected call of ReviewAdjustment.
+func (mr *MockAdjustmentServiceInterfaceMockRecorder) ReviewAdjustment(ctx, id, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewAdjustment", reflect.TypeOf((*MockAdjustmentServiceInterface)(nil).ReviewAdjustment), ctx, id, userID, req)
+}
+
+// MockStatementServiceInterface is a mock of StatementServiceInterface interface.
+type MockStatementServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementServiceInterfaceMockRecorder is the mock recorder for MockStatementServiceInterface.
+type MockStatementServiceInterfaceMockRecorder struct {
+	mock *MockStatementServiceInterface
+}
+
+// NewMockStatementServiceInterface creates a new mock instance.
+func NewMockStatementServiceInterface(ctrl *gomock.Controller) *MockStatementServiceInterface {
+	mock := &MockStatementServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockStatementServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementServiceInterface) EXPECT() *MockStatementServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DownloadStatement mocks base method.
+func (m *MockStatementServiceInterface) DownloadStatement(ctx context.Context, id, userID string) (*models.StatementFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadStatement", ctx, id, userID)
+	ret0, _ := ret[0].(*models.StatementFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadStatement indicates an expected call of DownloadStatement.
+func (mr *MockStatementServiceInterfaceMockRecorder) DownloadStatement(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadStatement", reflect.TypeOf((*MockStatementServiceInterface)(nil).DownloadStatement), ctx, id, userID)
+}
+
+// GetStatement mocks base method.
+func (m *MockStatementServiceInterface) GetStatement(ctx context.Context, id, userID string) (*models.PaymentStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", ctx, id, userID)
+	ret0, _ := ret[0].(*models.PaymentStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockStatementServiceInterfaceMockRecorder) GetStatement(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockStatementServiceInterface)(nil).GetStatement), ctx, id, userID)
+}
+
+// RenderPendingStatements mocks base method.
+func (m *MockStatementServiceInterface) RenderPendingStatements(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderPendingStatements", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenderPendingStatements indicates an expected call of RenderPendingStatements.
+func (mr *MockStatementServiceInterfaceMockRecorder) RenderPendingStatements(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderPendingStatements", reflect.TypeOf((*MockStatementServiceInterface)(nil).RenderPendingStatements), ctx)
+}
+
+// RequestStatement mocks base method.
+func (m *MockStatementServiceInterface) RequestStatement(ctx context.Context, userID string, req models.StatementRequest) (*models.StatementFile, *models.PaymentStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestStatement", ctx, userID, req)
+	ret0, _ := ret[0].(*models.StatementFile)
+	ret1, _ := ret[1].(*models.PaymentStatement)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RequestStatement indicates an expected call of RequestStatement.
+func (mr *MockStatementServiceInterfaceMockRecorder) RequestStatement(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestStatement", reflect.TypeOf((*MockStatementServiceInterface)(nil).RequestStatement), ctx, userID, req)
+}
+
+// MockSettingServiceInterface is a mock of SettingServiceInterface interface.
+type MockSettingServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettingServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSettingServiceInterfaceMockRecorder is the mock recorder for MockSettingServiceInterface.
+type MockSettingServiceInterfaceMockRecorder struct {
+	mock *MockSettingServiceInterface
+}
+
+// NewMockSettingServiceInterface creates a new mock instance.
+func NewMockSettingServiceInterface(ctrl *gomock.Controller) *MockSettingServiceInterface {
+	mock := &MockSettingServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockSettingServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettingServiceInterface) EXPECT() *MockSettingServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetDepreciationSettings mocks base method.
+func (m *MockSettingServiceInterface) GetDepreciationSettings(ctx context.Context) (*models.DepreciationSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepreciationSettings", ctx)
+	ret0, _ := ret[0].(*models.DepreciationSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepreciationSettings indicates an expected call of GetDepreciationSettings.
+func (mr *MockSettingServiceInterfaceMockRecorder) GetDepreciationSettings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepreciationSettings", reflect.TypeOf((*MockSettingServiceInterface)(nil).GetDepreciationSettings), ctx)
+}
+
+// GetRankingWeights mocks base method.
+func (m *MockSettingServiceInterface) GetRankingWeights(ctx context.Context) (*models.RankingWeights, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRankingWeights", ctx)
+	ret0, _ := ret[0].(*models.RankingWeights)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRankingWeights indicates an expected call of GetRankingWeights.
+func (mr *MockSettingServiceInterfaceMockRecorder) GetRankingWeights(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRankingWeights", reflect.TypeOf((*MockSettingServiceInterface)(nil).GetRankingWeights), ctx)
+}
+
+// UpdateDepreciationSettings mocks base method.
+func (m *MockSettingServiceInterface) UpdateDepreciationSettings(ctx context.Context, settings models.DepreciationSettings) (*models.DepreciationSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDepreciationSettings", ctx, settings)
+	ret0, _ := ret[0].(*models.DepreciationSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDepreciationSettings indicates an expected call of UpdateDepreciationSettings.
+func (mr *MockSettingServiceInterfaceMockRecorder) UpdateDepreciationSettings(ctx, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDepreciationSettings", reflect.TypeOf((*MockSettingServiceInterface)(nil).UpdateDepreciationSettings), ctx, settings)
+}
+
+// UpdateRankingWeights mocks base method.
+func (m *MockSettingServiceInterface) UpdateRankingWeights(ctx context.Context, weights models.RankingWeights) (*models.RankingWeights, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRankingWeights", ctx, weights)
+	ret0, _ := ret[0].(*models.RankingWeights)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRankingWeights indicates an expected call of UpdateRankingWeights.
+func (mr *MockSettingServiceInterfaceMockRecorder) UpdateRankingWeights(ctx, weights any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRankingWeights", reflect.TypeOf((*MockSettingServiceInterface)(nil).UpdateRankingWeights), ctx, weights)
+}
+
+// MockFeaturedServiceInterface is a mock of FeaturedServiceInterface interface.
+type MockFeaturedServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeaturedServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockFeaturedServiceInterfaceMockRecorder is the mock recorder for MockFeaturedServiceInterface.
+type MockFeaturedServiceInterfaceMockRecorder struct {
+	mock *MockFeaturedServiceInterface
+}
+
+// NewMockFeaturedServiceInterface creates a new mock instance.
+func NewMockFeaturedServiceInterface(ctrl *gomock.Controller) *MockFeaturedServiceInterface {
+	mock := &MockFeaturedServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockFeaturedServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeaturedServiceInterface) EXPECT() *MockFeaturedServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ConfirmFeatured mocks base method.
+func (m *MockFeaturedServiceInterface) ConfirmFeatured(ctx context.Context, userID, role, id string, req models.PaymentVerificationRequest) (*models.FeaturedListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmFeatured", ctx, userID, role, id, req)
+	ret0, _ := ret[0].(*models.FeaturedListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmFeatured indicates an expected call of ConfirmFeatured.
+func (mr *MockFeaturedServiceInterfaceMockRecorder) ConfirmFeatured(ctx, userID, role, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmFeatured", reflect.TypeOf((*MockFeaturedServiceInterface)(nil).ConfirmFeatured), ctx, userID, role, id, req)
+}
+
+// GetOwnerFeatured mocks base method.
+func (m *MockFeaturedServiceInterface) GetOwnerFeatured(ctx context.Context, ownerID string) ([]models.FeaturedListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerFeatured", ctx, ownerID)
+	ret0, _ := ret[0].([]models.FeaturedListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerFeatured indicates an expected call of GetOwnerFeatured.
+func (mr *MockFeaturedServiceInterfaceMockRecorder) GetOwnerFeatured(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerFeatured", reflect.TypeOf((*MockFeaturedServiceInterface)(nil).GetOwnerFeatured), ctx, ownerID)
+}
+
+// PurchaseFeatured mocks base method.
+func (m *MockFeaturedServiceInterface) PurchaseFeatured(ctx context.Context, userID, role, carID string, req models.FeaturedRequest) (*models.FeaturedPurchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurchaseFeatured", ctx, userID, role, carID, req)
+	ret0, _ := ret[0].(*models.FeaturedPurchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurchaseFeatured indicates an expected call of PurchaseFeatured.
+func (mr *MockFeaturedServiceInterfaceMockRecorder) PurchaseFeatured(ctx, userID, role, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseFeatured", reflect.TypeOf((*MockFeaturedServiceInterface)(nil).PurchaseFeatured), ctx, userID, role, carID, req)
+}
+
+// MockProfileServiceInterface is a mock of ProfileServiceInterface interface.
+type MockProfileServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockProfileServiceInterfaceMockRecorder is the mock recorder for MockProfileServiceInterface.
+type MockProfileServiceInterfaceMockRecorder struct {
+	mock *MockProfileServiceInterface
+}
+
+// NewMockProfileServiceInterface creates a new mock instance.
+func NewMockProfileServiceInterface(ctrl *gomock.Controller) *MockProfileServiceInterface {
+	mock := &MockProfileServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockProfileServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileServiceInterface) EXPECT() *MockProfileServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetPublicProfile mocks base method.
+func (m *MockProfileServiceInterface) GetPublicProfile(ctx context.Context, ownerID string) (*models.PublicProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicProfile", ctx, ownerID)
+	ret0, _ := ret[0].(*models.PublicProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicProfile indicates an expected call of GetPublicProfile.
+func (mr *MockProfileServiceInterfaceMockRecorder) GetPublicProfile(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicProfile", reflect.TypeOf((*MockProfileServiceInterface)(nil).GetPublicProfile), ctx, ownerID)
+}
+
+// MockBlockServiceInterface is a mock of BlockServiceInterface interface.
+type MockBlockServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBlockServiceInterfaceMockRecorder is the mock recorder for MockBlockServiceInterface.
+type MockBlockServiceInterfaceMockRecorder struct {
+	mock *MockBlockServiceInterface
+}
+
+// NewMockBlockServiceInterface creates a new mock instance.
+func NewMockBlockServiceInterface(ctrl *gomock.Controller) *MockBlockServiceInterface {
+	mock := &MockBlockServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockBlockServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockServiceInterface) EXPECT() *MockBlockServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// BlockUser mocks base method.
+func (m *MockBlockServiceInterface) BlockUser(ctx context.Context, userID string, req models.BlockRequest) (*models.UserBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUser", ctx, userID, req)
+	ret0, _ := ret[0].(*models.UserBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockUser indicates an expected call of BlockUser.
+func (mr *MockBlockServiceInterfaceMockRecorder) BlockUser(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUser", reflect.TypeOf((*MockBlockServiceInterface)(nil).BlockUser), ctx, userID, req)
+}
+
+// GetBlocks mocks base method.
+func (m *MockBlockServiceInterface) GetBlocks(ctx context.Context, userID string) ([]models.UserBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocks", ctx, userID)
+	ret0, _ := ret[0].([]models.UserBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocks indicates an expected call of GetBlocks.
+func (mr *MockBlockServiceInterfaceMockRecorder) GetBlocks(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockBlockServiceInterface)(nil).GetBlocks), ctx, userID)
+}
+
+// UnblockUser mocks base method.
+func (m *MockBlockServiceInterface) UnblockUser(ctx context.Context, userID, blockedID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnblockUser", ctx, userID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnblockUser indicates an expected call of UnblockUser.
+func (mr *MockBlockServiceInterfaceMockRecorder) UnblockUser(ctx, userID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockUser", reflect.TypeOf((*MockBlockServiceInterface)(nil).UnblockUser), ctx, userID, blockedID)
+}
+
+// MockMaintenanceServiceInterface is a mock of MaintenanceServiceInterface interface.
+type MockMaintenanceServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockMaintenanceServiceInterfaceMockRecorder is the mock recorder for MockMaintenanceServiceInterface.
+type MockMaintenanceServiceInterfaceMockRecorder struct {
+	mock *MockMaintenanceServiceInterface
+}
+
+// NewMockMaintenanceServiceInterface creates a new mock instance.
+func NewMockMaintenanceServiceInterface(ctrl *gomock.Controller) *MockMaintenanceServiceInterface {
+	mock := &MockMaintenanceServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceServiceInterface) EXPECT() *MockMaintenanceServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetJob mocks base method.
+func (m *MockMaintenanceServiceInterface) GetJob(ctx context.Context, id string) (*models.MaintenanceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, id)
+	ret0, _ := ret[0].(*models.MaintenanceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockMaintenanceServiceInterfaceMockRecorder) GetJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockMaintenanceServiceInterface)(nil).GetJob), ctx, id)
+}
+
+// ListJobs mocks base method.
+func (m *MockMaintenanceServiceInterface) ListJobs(ctx context.Context, task string) ([]models.MaintenanceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobs", ctx, task)
+	ret0, _ := ret[0].([]models.MaintenanceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobs indicates an expected call of ListJobs.
+func (mr *MockMaintenanceServiceInterfaceMockRecorder) ListJobs(ctx, task any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockMaintenanceServiceInterface)(nil).ListJobs), ctx, task)
+}
+
+// RequestJob mocks base method.
+func (m *MockMaintenanceServiceInterface) RequestJob(ctx context.Context, task, userID string, req models.MaintenanceJobRequest) (*models.MaintenanceJob, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestJob", ctx, task, userID, req)
+	ret0, _ := ret[0].(*models.MaintenanceJob)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RequestJob indicates an expected call of RequestJob.
+func (mr *MockMaintenanceServiceInterfaceMockRecorder) RequestJob(ctx, task, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestJob", reflect.TypeOf((*MockMaintenanceServiceInterface)(nil).RequestJob), ctx, task, userID, req)
+}
+
+// RunQueuedJobs mocks base method.
+func (m *MockMaintenanceServiceInterface) RunQueuedJobs(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunQueuedJobs", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunQueuedJobs indicates an expected call of RunQueuedJobs.
+func (mr *MockMaintenanceServiceInterfaceMockRecorder) RunQueuedJobs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunQueuedJobs", reflect.TypeOf((*MockMaintenanceServiceInterface)(nil).RunQueuedJobs), ctx)
+}
+
+// MockDebugCaptureServiceInterface is a mock of DebugCaptureServiceInterface interface.
+type MockDebugCaptureServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDebugCaptureServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockDebugCaptureServiceInterfaceMockRecorder is the mock recorder for MockDebugCaptureServiceInterface.
+type MockDebugCaptureServiceInterfaceMockRecorder struct {
+	mock *MockDebugCaptureServiceInterface
+}
+
+// NewMockDebugCaptureServiceInterface creates a new mock instance.
+func NewMockDebugCaptureServiceInterface(ctrl *gomock.Controller) *MockDebugCaptureServiceInterface {
+	mock := &MockDebugCaptureServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockDebugCaptureServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDebugCaptureServiceInterface) EXPECT() *MockDebugCaptureServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetCapture mocks base method.
+func (m *MockDebugCaptureServiceInterface) GetCapture(ctx context.Context, id string) (*models.DebugCapture, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCapture", ctx, id)
+	ret0, _ := ret[0].(*models.DebugCapture)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCapture indicates an expected call of GetCapture.
+func (mr *MockDebugCaptureServiceInterfaceMockRecorder) GetCapture(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapture", reflect.TypeOf((*MockDebugCaptureServiceInterface)(nil).GetCapture), ctx, id)
+}
+
+// ListCaptures mocks base method.
+func (m *MockDebugCaptureServiceInterface) ListCaptures(ctx context.Context, filter models.DebugCaptureFilter, page models.PageRequest) (*models.Page[models.DebugCapture], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCaptures", ctx, filter, page)
+	ret0, _ := ret[0].(*models.Page[models.DebugCapture])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCaptures indicates an expected call of ListCaptures.
+func (mr *MockDebugCaptureServiceInterfaceMockRecorder) ListCaptures(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCaptures", reflect.TypeOf((*MockDebugCaptureServiceInterface)(nil).ListCaptures), ctx, filter, page)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockDebugCaptureServiceInterface) PurgeExpired(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockDebugCaptureServiceInterfaceMockRecorder) PurgeExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockDebugCaptureServiceInterface)(nil).PurgeExpired), ctx)
+}
+
+// SaveCapture mocks base method.
+func (m *MockDebugCaptureServiceInterface) SaveCapture(ctx context.Context, capture models.DebugCapture) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCapture", ctx, capture)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCapture indicates an expected call of SaveCapture.
+func (mr *MockDebugCaptureServiceInterfaceMockRecorder) SaveCapture(ctx, capture any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCapture", reflect.TypeOf((*MockDebugCaptureServiceInterface)(nil).SaveCapture), ctx, capture)
+}
+
+// MockLoadTestServiceInterface is a mock of LoadTestServiceInterface interface.
+type MockLoadTestServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoadTestServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockLoadTestServiceInterfaceMockRecorder is the mock recorder for MockLoadTestServiceInterface.
+type MockLoadTestServiceInterfaceMockRecorder struct {
+	mock *MockLoadTestServiceInterface
+}
+
+// NewMockLoadTestServiceInterface creates a new mock instance.
+func NewMockLoadTestServiceInterface(ctrl *gomock.Controller) *MockLoadTestServiceInterface {
+	mock := &MockLoadTestServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockLoadTestServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoadTestServiceInterface) EXPECT() *MockLoadTestServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateFixtures mocks base method.
+func (m *MockLoadTestServiceInterface) CreateFixtures(ctx context.Context, req models.LoadTestFixtureRequest) (*models.LoadTestFixtures, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFixtures", ctx, req)
+	ret0, _ := ret[0].(*models.LoadTestFixtures)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFixtures indicates an expected call of CreateFixtures.
+func (mr *MockLoadTestServiceInterfaceMockRecorder) CreateFixtures(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFixtures", reflect.TypeOf((*MockLoadTestServiceInterface)(nil).CreateFixtures), ctx, req)
+}
+
+// DeleteFixtures mocks base method.
+func (m *MockLoadTestServiceInterface) DeleteFixtures(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFixtures", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFixtures indicates an expected call of DeleteFixtures.
+func (mr *MockLoadTestServiceInterfaceMockRecorder) DeleteFixtures(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFixtures", reflect.TypeOf((*MockLoadTestServiceInterface)(nil).DeleteFixtures), ctx, id)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockLoadTestServiceInterface) PurgeExpired(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockLoadTestServiceInterfaceMockRecorder) PurgeExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockLoadTestServiceInterface)(nil).PurgeExpired), ctx)
+}
+
+// MockCarImageUploadServiceInterface is a mock of CarImageUploadServiceInterface interface.
+type MockCarImageUploadServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarImageUploadServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCarImageUploadServiceInterfaceMockRecorder is the mock recorder for MockCarImageUploadServiceInterface.
+type MockCarImageUploadServiceInterfaceMockRecorder struct {
+	mock *MockCarImageUploadServiceInterface
+}
+
+// NewMockCarImageUploadServiceInterface creates a new mock instance.
+func NewMockCarImageUploadServiceInterface(ctrl *gomock.Controller) *MockCarImageUploadServiceInterface {
+	mock := &MockCarImageUploadServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCarImageUploadServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarImageUploadServiceInterface) EXPECT() *MockCarImageUploadServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ProcessPendingUploads mocks base method.
+func (m *MockCarImageUploadServiceInterface) ProcessPendingUploads(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPendingUploads", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessPendingUploads indicates an expected call of ProcessPendingUploads.
+func (mr *MockCarImageUploadServiceInterfaceMockRecorder) ProcessPendingUploads(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPendingUploads", reflect.TypeOf((*MockCarImageUploadServiceInterface)(nil).ProcessPendingUploads), ctx)
+}
+
+// MockUserServiceInterface is a mock of UserServiceInterface interface.
+type MockUserServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServiceInterfaceMockRecorder is the mock recorder for MockUserServiceInterface.
+type MockUserServiceInterfaceMockRecorder struct {
+	mock *MockUserServiceInterface
+}
+
+// NewMockUserServiceInterface creates a new mock instance.
+func NewMockUserServiceInterface(ctrl *gomock.Controller) *MockUserServiceInterface {
+	mock := &MockUserServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockUserServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserServiceInterface) EXPECT() *MockUserServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteCurrentUser mocks base method.
+func (m *MockUserServiceInterface) DeleteCurrentUser(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCurrentUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCurrentUser indicates an expected call of DeleteCurrentUser.
+func (mr *MockUserServiceInterfaceMockRecorder) DeleteCurrentUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCurrentUser", reflect.TypeOf((*MockUserServiceInterface)(nil).DeleteCurrentUser), ctx, userID)
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserServiceInterface) DeleteUser(ctx context.Context, id string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserServiceInterfaceMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserServiceInterface)(nil).DeleteUser), ctx, id)
+}
+
+// GetCurrentUser mocks base method.
+func (m *MockUserServiceInterface) GetCurrentUser(ctx context.Context, userID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUser", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentUser indicates an expected call of GetCurrentUser.
+func (mr *MockUserServiceInterfaceMockRecorder) GetCurrentUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUser", reflect.TypeOf((*MockUserServiceInterface)(nil).GetCurrentUser), ctx, userID)
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserServiceInterface) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserServiceInterfaceMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUserByID), ctx, id)
+}
+
+// GetUsersByRole mocks base method.
+func (m *MockUserServiceInterface) GetUsersByRole(ctx context.Context, role string) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByRole", ctx, role)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByRole indicates an expected call of GetUsersByRole.
+func (mr *MockUserServiceInterfaceMockRecorder) GetUsersByRole(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByRole", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUsersByRole), ctx, role)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserServiceInterface) ListUsers(ctx context.Context, page models.PageRequest) (*models.Page[models.User], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, page)
+	ret0, _ := ret[0].(*models.Page[models.User])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserServiceInterfaceMockRecorder) ListUsers(ctx, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserServiceInterface)(nil).ListUsers), ctx, page)
+}
+
+// UpdateCurrentProfile mocks base method.
+func (m *MockUserServiceInterface) UpdateCurrentProfile(ctx context.Context, userID string, patch map[string]any) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCurrentProfile", ctx, userID, patch)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCurrentProfile indicates an expected call of UpdateCurrentProfile.
+func (mr *MockUserServiceInterfaceMockRecorder) UpdateCurrentProfile(ctx, userID, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentProfile", reflect.TypeOf((*MockUserServiceInterface)(nil).UpdateCurrentProfile), ctx, userID, patch)
+}
+
+// UpdateCurrentUser mocks base method.
+func (m *MockUserServiceInterface) UpdateCurrentUser(ctx context.Context, userID string, req models.UserRequest) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCurrentUser", ctx, userID, req)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCurrentUser indicates an expected call of UpdateCurrentUser.
+func (mr *MockUserServiceInterfaceMockRecorder) UpdateCurrentUser(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentUser", reflect.TypeOf((*MockUserServiceInterface)(nil).UpdateCurrentUser), ctx, userID, req)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUserServiceInterface) UpdateUser(ctx context.Context, id string, req models.UserRequest) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, id, req)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserServiceInterfaceMockRecorder) UpdateUser(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserServiceInterface)(nil).UpdateUser), ctx, id, req)
+}
+
+// MockAccountingServiceInterface is a mock of AccountingServiceInterface interface.
+type MockAccountingServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountingServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountingServiceInterfaceMockRecorder is the mock recorder for MockAccountingServiceInterface.
+type MockAccountingServiceInterfaceMockRecorder struct {
+	mock *MockAccountingServiceInterface
+}
+
+// NewMockAccountingServiceInterface creates a new mock instance.
+func NewMockAccountingServiceInterface(ctrl *gomock.Controller) *MockAccountingServiceInterface {
+	mock := &MockAccountingServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockAccountingServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountingServiceInterface) EXPECT() *MockAccountingServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockAccountingServiceInterface) Export(ctx context.Context, req models.AccountingExportRequest) (*models.AccountingExportFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, req)
+	ret0, _ := ret[0].(*models.AccountingExportFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockAccountingServiceInterfaceMockRecorder) Export(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockAccountingServiceInterface)(nil).Export), ctx, req)
+}
+
+// MockValuationServiceInterface is a mock of ValuationServiceInterface interface.
+type MockValuationServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockValuationServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockValuationServiceInterfaceMockRecorder is the mock recorder for MockValuationServiceInterface.
+type MockValuationServiceInterfaceMockRecorder struct {
+	mock *MockValuationServiceInterface
+}
+
+// NewMockValuationServiceInterface creates a new mock instance.
+func NewMockValuationServiceInterface(ctrl *gomock.Controller) *MockValuationServiceInterface {
+	mock := &MockValuationServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockValuationServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValuationServiceInterface) EXPECT() *MockValuationServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ExportFleetValuation mocks base method.
+func (m *MockValuationServiceInterface) ExportFleetValuation(ctx context.Context, ownerID string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportFleetValuation", ctx, ownerID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportFleetValuation indicates an expected call of ExportFleetValuation.
+func (mr *MockValuationServiceInterfaceMockRecorder) ExportFleetValuation(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportFleetValuation", reflect.TypeOf((*MockValuationServiceInterface)(nil).ExportFleetValuation), ctx, ownerID)
+}
+
+// GetFleetValuation mocks base method.
+func (m *MockValuationServiceInterface) GetFleetValuation(ctx context.Context, ownerID string) (*models.FleetValuation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFleetValuation", ctx, ownerID)
+	ret0, _ := ret[0].(*models.FleetValuation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFleetValuation indicates an expected call of GetFleetValuation.
+func (mr *MockValuationServiceInterfaceMockRecorder) GetFleetValuation(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFleetValuation", reflect.TypeOf((*MockValuationServiceInterface)(nil).GetFleetValuation), ctx, ownerID)
+}
+
+// SetCarPurchase mocks base method.
+func (m *MockValuationServiceInterface) SetCarPurchase(ctx context.Context, ownerID, carID string, req models.CarPurchaseRequest) (*models.CarPurchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCarPurchase", ctx, ownerID, carID, req)
+	ret0, _ := ret[0].(*models.CarPurchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCarPurchase indicates an expected call of SetCarPurchase.
+func (mr *MockValuationServiceInterfaceMockRecorder) SetCarPurchase(ctx, ownerID, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCarPurchase", reflect.TypeOf((*MockValuationServiceInterface)(nil).SetCarPurchase), ctx, ownerID, carID, req)
+}
+
+// MockPasswordResetServiceInterface is a mock of PasswordResetServiceInterface interface.
+type MockPasswordResetServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordResetServiceInterfaceMockRecorder is the mock recorder for MockPasswordResetServiceInterface.
+type MockPasswordResetServiceInterfaceMockRecorder struct {
+	mock *MockPasswordResetServiceInterface
+}
+
+// NewMockPasswordResetServiceInterface creates a new mock instance.
+func NewMockPasswordResetServiceInterface(ctrl *gomock.Controller) *MockPasswordResetServiceInterface {
+	mock := &MockPasswordResetServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetServiceInterface) EXPECT() *MockPasswordResetServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockPasswordResetServiceInterface) RequestPasswordReset(ctx context.Context, req models.ForgotPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockPasswordResetServiceInterfaceMockRecorder) RequestPasswordReset(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockPasswordResetServiceInterface)(nil).RequestPasswordReset), ctx, req)
+}
+
+// ResetPassword mocks base method.
+func (m *MockPasswordResetServiceInterface) ResetPassword(ctx context.Context, req models.ResetPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockPasswordResetServiceInterfaceMockRecorder) ResetPassword(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockPasswordResetServiceInterface)(nil).ResetPassword), ctx, req)
+}
+
+// MockRefreshTokenServiceInterface is a mock of RefreshTokenServiceInterface interface.
+type MockRefreshTokenServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockRefreshTokenServiceInterfaceMockRecorder is the mock recorder for MockRefreshTokenServiceInterface.
+type MockRefreshTokenServiceInterfaceMockRecorder struct {
+	mock *MockRefreshTokenServiceInterface
+}
+
+// NewMockRefreshTokenServiceInterface creates a new mock instance.
+func NewMockRefreshTokenServiceInterface(ctrl *gomock.Controller) *MockRefreshTokenServiceInterface {
+	mock := &MockRefreshTokenServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenServiceInterface) EXPECT() *MockRefreshTokenServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// IssueRefreshToken mocks base method.
+func (m *MockRefreshTokenServiceInterface) IssueRefreshToken(ctx context.Context, user models.User) (models.IssuedRefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueRefreshToken", ctx, user)
+	ret0, _ := ret[0].(models.IssuedRefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueRefreshToken indicates an expected call of IssueRefreshToken.
+func (mr *MockRefreshTokenServiceInterfaceMockRecorder) IssueRefreshToken(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueRefreshToken", reflect.TypeOf((*MockRefreshTokenServiceInterface)(nil).IssueRefreshToken), ctx, user)
+}
+
+// RevokeRefreshToken mocks base method.
+func (m *MockRefreshTokenServiceInterface) RevokeRefreshToken(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken.
+func (mr *MockRefreshTokenServiceInterfaceMockRecorder) RevokeRefreshToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockRefreshTokenServiceInterface)(nil).RevokeRefreshToken), ctx, token)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockRefreshTokenServiceInterface) RotateRefreshToken(ctx context.Context, token string) (models.User, models.IssuedRefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, token)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(models.IssuedRefreshToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockRefreshTokenServiceInterfaceMockRecorder) RotateRefreshToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockRefreshTokenServiceInterface)(nil).RotateRefreshToken), ctx, token)
+}
+
+// MockTwoFactorServiceInterface is a mock of TwoFactorServiceInterface interface.
+type MockTwoFactorServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTwoFactorServiceInterfaceMockRecorder is the mock recorder for MockTwoFactorServiceInterface.
+type MockTwoFactorServiceInterfaceMockRecorder struct {
+	mock *MockTwoFactorServiceInterface
+}
+
+// NewMockTwoFactorServiceInterface creates a new mock instance.
+func NewMockTwoFactorServiceInterface(ctrl *gomock.Controller) *MockTwoFactorServiceInterface {
+	mock := &MockTwoFactorServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorServiceInterface) EXPECT() *MockTwoFactorServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// BeginSetup mocks base method.
+func (m *MockTwoFactorServiceInterface) BeginSetup(ctx context.Context, userID string) (*models.TwoFactorSetup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginSetup", ctx, userID)
+	ret0, _ := ret[0].(*models.TwoFactorSetup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginSetup indicates an expected call of BeginSetup.
+func (mr *MockTwoFactorServiceInterfaceMockRecorder) BeginSetup(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginSetup", reflect.TypeOf((*MockTwoFactorServiceInterface)(nil).BeginSetup), ctx, userID)
+}
+
+// VerifyLogin mocks base method.
+func (m *MockTwoFactorServiceInterface) VerifyLogin(ctx context.Context, user models.User, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLogin", ctx, user, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyLogin indicates an expected call of VerifyLogin.
+func (mr *MockTwoFactorServiceInterfaceMockRecorder) VerifyLogin(ctx, user, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLogin", reflect.TypeOf((*MockTwoFactorServiceInterface)(nil).VerifyLogin), ctx, user, code)
+}
+
+// VerifySetup mocks base method.
+func (m *MockTwoFactorServiceInterface) VerifySetup(ctx context.Context, userID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySetup", ctx, userID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifySetup indicates an expected call of VerifySetup.
+func (mr *MockTwoFactorServiceInterfaceMockRecorder) VerifySetup(ctx, userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySetup", reflect.TypeOf((*MockTwoFactorServiceInterface)(nil).VerifySetup), ctx, userID, code)
+}
+
+// MockOnboardingServiceInterface is a mock of OnboardingServiceInterface interface.
+type MockOnboardingServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOnboardingServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOnboardingServiceInterfaceMockRecorder is the mock recorder for MockOnboardingServiceInterface.
+type MockOnboardingServiceInterfaceMockRecorder struct {
+	mock *MockOnboardingServiceInterface
+}
+
+// NewMockOnboardingServiceInterface creates a new mock instance.
+func NewMockOnboardingServiceInterface(ctrl *gomock.Controller) *MockOnboardingServiceInterface {
+	mock := &MockOnboardingServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockOnboardingServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOnboardingServiceInterface) EXPECT() *MockOnboardingServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetProgress mocks base method.
+func (m *MockOnboardingServiceInterface) GetProgress(ctx context.Context, ownerID string) (*models.OnboardingProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgress", ctx, ownerID)
+	ret0, _ := ret[0].(*models.OnboardingProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgress indicates an expected call of GetProgress.
+func (mr *MockOnboardingServiceInterfaceMockRecorder) GetProgress(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgress", reflect.TypeOf((*MockOnboardingServiceInterface)(nil).GetProgress), ctx, ownerID)
+}
+
+// MockSmartLockProviderInterface is a mock of SmartLockProviderInterface interface.
+type MockSmartLockProviderInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmartLockProviderInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSmartLockProviderInterfaceMockRecorder is the mock recorder for MockSmartLockProviderInterface.
+type MockSmartLockProviderInterfaceMockRecorder struct {
+	mock *MockSmartLockProviderInterface
+}
+
+// NewMockSmartLockProviderInterface creates a new mock instance.
+func NewMockSmartLockProviderInterface(ctrl *gomock.Controller) *MockSmartLockProviderInterface {
+	mock := &MockSmartLockProviderInterface{ctrl: ctrl}
+	mock.recorder = &MockSmartLockProviderInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmartLockProviderInterface) EXPECT() *MockSmartLockProviderInterfaceMockRecorder {
+	return m.recorder
+}
+
+// IssueKey mocks base method.
+func (m *MockSmartLockProviderInterface) IssueKey(ctx context.Context, grant models.DigitalKeyGrant) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueKey", ctx, grant)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueKey indicates an expected call of IssueKey.
+func (mr *MockSmartLockProviderInterfaceMockRecorder) IssueKey(ctx, grant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueKey", reflect.TypeOf((*MockSmartLockProviderInterface)(nil).IssueKey), ctx, grant)
+}
+
+// Name mocks base method.
+func (m *MockSmartLockProviderInterface) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockSmartLockProviderInterfaceMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockSmartLockProviderInterface)(nil).Name))
+}
+
+// RevokeKey mocks base method.
+func (m *MockSmartLockProviderInterface) RevokeKey(ctx context.Context, lockRef, providerKeyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeKey", ctx, lockRef, providerKeyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeKey indicates an expected call of RevokeKey.
+func (mr *MockSmartLockProviderInterfaceMockRecorder) RevokeKey(ctx, lockRef, providerKeyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeKey", reflect.TypeOf((*MockSmartLockProviderInterface)(nil).RevokeKey), ctx, lockRef, providerKeyID)
+}
+
+// MockDigitalKeyListenerInterface is a mock of DigitalKeyListenerInterface interface.
+type MockDigitalKeyListenerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigitalKeyListenerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockDigitalKeyListenerInterfaceMockRecorder is the mock recorder for MockDigitalKeyListenerInterface.
+type MockDigitalKeyListenerInterfaceMockRecorder struct {
+	mock *MockDigitalKeyListenerInterface
+}
+
+// NewMockDigitalKeyListenerInterface creates a new mock instance.
+func NewMockDigitalKeyListenerInterface(ctrl *gomock.Controller) *MockDigitalKeyListenerInterface {
+	mock := &MockDigitalKeyListenerInterface{ctrl: ctrl}
+	mock.recorder = &MockDigitalKeyListenerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigitalKeyListenerInterface) EXPECT() *MockDigitalKeyListenerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// IssueBookingKey mocks base method.
+func (m *MockDigitalKeyListenerInterface) IssueBookingKey(ctx context.Context, booking models.Booking) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IssueBookingKey", ctx, booking)
+}
+
+// IssueBookingKey indicates an expected call of IssueBookingKey.
+func (mr *MockDigitalKeyListenerInterfaceMockRecorder) IssueBookingKey(ctx, booking any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueBookingKey", reflect.TypeOf((*MockDigitalKeyListenerInterface)(nil).IssueBookingKey), ctx, booking)
+}
+
+// RevokeBookingKeys mocks base method.
+func (m *MockDigitalKeyListenerInterface) RevokeBookingKeys(ctx context.Context, booking models.Booking) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeBookingKeys", ctx, booking)
+}
+
+// RevokeBookingKeys indicates an expected call of RevokeBookingKeys.
+func (mr *MockDigitalKeyListenerInterfaceMockRecorder) RevokeBookingKeys(ctx, booking any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeBookingKeys", reflect.TypeOf((*MockDigitalKeyListenerInterface)(nil).RevokeBookingKeys), ctx, booking)
+}
+
+// MockSmartLockServiceInterface is a mock of SmartLockServiceInterface interface.
+type MockSmartLockServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmartLockServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSmartLockServiceInterfaceMockRecorder is the mock recorder for MockSmartLockServiceInterface.
+type MockSmartLockServiceInterfaceMockRecorder struct {
+	mock *MockSmartLockServiceInterface
+}
+
+// NewMockSmartLockServiceInterface creates a new mock instance.
+func NewMockSmartLockServiceInterface(ctrl *gomock.Controller) *MockSmartLockServiceInterface {
+	mock := &MockSmartLockServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockSmartLockServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmartLockServiceInterface) EXPECT() *MockSmartLockServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteSmartLock mocks base method.
+func (m *MockSmartLockServiceInterface) DeleteSmartLock(ctx context.Context, userID, role, carID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSmartLock", ctx, userID, role, carID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSmartLock indicates an expected call of DeleteSmartLock.
+func (mr *MockSmartLockServiceInterfaceMockRecorder) DeleteSmartLock(ctx, userID, role, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSmartLock", reflect.TypeOf((*MockSmartLockServiceInterface)(nil).DeleteSmartLock), ctx, userID, role, carID)
+}
+
+// GetBookingDigitalKeys mocks base method.
+func (m *MockSmartLockServiceInterface) GetBookingDigitalKeys(ctx context.Context, userID, role, bookingID string) ([]models.DigitalKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingDigitalKeys", ctx, userID, role, bookingID)
+	ret0, _ := ret[0].([]models.DigitalKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingDigitalKeys indicates an expected call of GetBookingDigitalKeys.
+func (mr *MockSmartLockServiceInterfaceMockRecorder) GetBookingDigitalKeys(ctx, userID, role, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingDigitalKeys", reflect.TypeOf((*MockSmartLockServiceInterface)(nil).GetBookingDigitalKeys), ctx, userID, role, bookingID)
+}
+
+// GetBookingLockEvents mocks base method.
+func (m *MockSmartLockServiceInterface) GetBookingLockEvents(ctx context.Context, userID, role, bookingID string) ([]models.LockEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingLockEvents", ctx, userID, role, bookingID)
+	ret0, _ := ret[0].([]models.LockEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingLockEvents indicates an expected call of GetBookingLockEvents.
+func (mr *MockSmartLockServiceInterfaceMockRecorder) GetBookingLockEvents(ctx, userID, role, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingLockEvents", reflect.TypeOf((*MockSmartLockServiceInterface)(nil).GetBookingLockEvents), ctx, userID, role, bookingID)
+}
+
+// GetSmartLock mocks base method.
+func (m *MockSmartLockServiceInterface) GetSmartLock(ctx context.Context, userID, role, carID string) (*models.SmartLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartLock", ctx, userID, role, carID)
+	ret0, _ := ret[0].(*models.SmartLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSmartLock indicates an expected call of GetSmartLock.
+func (mr *MockSmartLockServiceInterfaceMockRecorder) GetSmartLock(ctx, userID, role, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartLock", reflect.TypeOf((*MockSmartLockServiceInterface)(nil).GetSmartLock), ctx, userID, role, carID)
+}
+
+// HandleWebhook mocks base method.
+func (m *MockSmartLockServiceInterface) HandleWebhook(ctx context.Context, signature string, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebhook", ctx, signature, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleWebhook indicates an expected call of HandleWebhook.
+func (mr *MockSmartLockServiceInterfaceMockRecorder) HandleWebhook(ctx, signature, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebhook", reflect.TypeOf((*MockSmartLockServiceInterface)(nil).HandleWebhook), ctx, signature, body)
+}
+
+// IssueBookingKey mocks base method.
+func (m *MockSmartLockServiceInterface) IssueBookingKey(ctx context.Context, booking models.Booking) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IssueBookingKey", ctx, booking)
+}
+
+// IssueBookingKey indicates an expected call of IssueBookingKey.
+func (mr *MockSmartLockServiceInterfaceMockRecorder) IssueBookingKey(ctx, booking any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueBookingKey", reflect.TypeOf((*MockSmartLockServiceInterface)(nil).IssueBookingKey), ctx, booking)
+}
+
+// ReissueBookingKey mocks base method.
+func (m *MockSmartLockServiceInterface) ReissueBookingKey(ctx context.Context, userID, role, bookingID string) (*models.DigitalKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReissueBookingKey", ctx, userID, role, bookingID)
+	ret0, _ := ret[0].(*models.DigitalKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReissueBookingKey indicates an expected call of ReissueBookingKey.
+func (mr *MockSmartLockServiceInterfaceMockRecorder) ReissueBookingKey(ctx, userID, role, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReissueBookingKey", reflect.TypeOf((*MockSmartLockServiceInterface)(nil).ReissueBookingKey), ctx, userID, role, bookingID)
+}
+
+// RevokeBookingKeys mocks base method.
+func (m *MockSmartLockServiceInterface) RevokeBookingKeys(ctx context.Context, booking models.Booking) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeBookingKeys", ctx, booking)
+}
+
+// RevokeBookingKeys indicates an expected call of RevokeBookingKeys.
+func (mr *MockSmartLockServiceInterfaceMockRecorder) RevokeBookingKeys(ctx, booking any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeBookingKeys", reflect.TypeOf((*MockSmartLockServiceInterface)(nil).RevokeBookingKeys), ctx, booking)
+}
+
+// SetSmartLock mocks base method.
+func (m *MockSmartLockServiceInterface) SetSmartLock(ctx context.Context, userID, role, carID string, req models.SmartLockRequest) (*models.SmartLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSmartLock", ctx, userID, role, carID, req)
+	ret0, _ := ret[0].(*models.SmartLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSmartLock indicates an expected call of SetSmartLock.
+func (mr *MockSmartLockServiceInterfaceMockRecorder) SetSmartLock(ctx, userID, role, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSmartLock", reflect.TypeOf((*MockSmartLockServiceInterface)(nil).SetSmartLock), ctx, userID, role, carID, req)
+}
+
+// MockIncidentServiceInterface is a mock of IncidentServiceInterface interface.
+type MockIncidentServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncidentServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockIncidentServiceInterfaceMockRecorder is the mock recorder for MockIncidentServiceInterface.
+type MockIncidentServiceInterfaceMockRecorder struct {
+	mock *MockIncidentServiceInterface
+}
+
+// NewMockIncidentServiceInterface creates a new mock instance.
+func NewMockIncidentServiceInterface(ctrl *gomock.Controller) *MockIncidentServiceInterface {
+	mock := &MockIncidentServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockIncidentServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncidentServiceInterface) EXPECT() *MockIncidentServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddIncidentDocument mocks base method.
+func (m *MockIncidentServiceInterface) AddIncidentDocument(ctx context.Context, userID, role, id string, req models.IncidentDocumentRequest) (*models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIncidentDocument", ctx, userID, role, id, req)
+	ret0, _ := ret[0].(*models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddIncidentDocument indicates an expected call of AddIncidentDocument.
+func (mr *MockIncidentServiceInterfaceMockRecorder) AddIncidentDocument(ctx, userID, role, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIncidentDocument", reflect.TypeOf((*MockIncidentServiceInterface)(nil).AddIncidentDocument), ctx, userID, role, id, req)
+}
+
+// GetBookingIncidents mocks base method.
+func (m *MockIncidentServiceInterface) GetBookingIncidents(ctx context.Context, userID, role, bookingID string) ([]models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingIncidents", ctx, userID, role, bookingID)
+	ret0, _ := ret[0].([]models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingIncidents indicates an expected call of GetBookingIncidents.
+func (mr *MockIncidentServiceInterfaceMockRecorder) GetBookingIncidents(ctx, userID, role, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingIncidents", reflect.TypeOf((*MockIncidentServiceInterface)(nil).GetBookingIncidents), ctx, userID, role, bookingID)
+}
+
+// GetIncident mocks base method.
+func (m *MockIncidentServiceInterface) GetIncident(ctx context.Context, userID, role, id string) (*models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncident", ctx, userID, role, id)
+	ret0, _ := ret[0].(*models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncident indicates an expected call of GetIncident.
+func (mr *MockIncidentServiceInterfaceMockRecorder) GetIncident(ctx, userID, role, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncident", reflect.TypeOf((*MockIncidentServiceInterface)(nil).GetIncident), ctx, userID, role, id)
+}
+
+// ListIncidents mocks base method.
+func (m *MockIncidentServiceInterface) ListIncidents(ctx context.Context, filter models.IncidentFilter) ([]models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIncidents", ctx, filter)
+	ret0, _ := ret[0].([]models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIncidents indicates an expected call of ListIncidents.
+func (mr *MockIncidentServiceInterfaceMockRecorder) ListIncidents(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIncidents", reflect.TypeOf((*MockIncidentServiceInterface)(nil).ListIncidents), ctx, filter)
+}
+
+// ReportIncident mocks base method.
+func (m *MockIncidentServiceInterface) ReportIncident(ctx context.Context, userID, role, bookingID string, req models.IncidentRequest) (*models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportIncident", ctx, userID, role, bookingID, req)
+	ret0, _ := ret[0].(*models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportIncident indicates an expected call of ReportIncident.
+func (mr *MockIncidentServiceInterfaceMockRecorder) ReportIncident(ctx, userID, role, bookingID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportIncident", reflect.TypeOf((*MockIncidentServiceInterface)(nil).ReportIncident), ctx, userID, role, bookingID, req)
+}
+
+// UpdateIncidentStatus mocks base method.
+func (m *MockIncidentServiceInterface) UpdateIncidentStatus(ctx context.Context, userID, role, id string, req models.IncidentStatusRequest) (*models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIncidentStatus", ctx, userID, role, id, req)
+	ret0, _ := ret[0].(*models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIncidentStatus indicates an expected call of UpdateIncidentStatus.
+func (mr *MockIncidentServiceInterfaceMockRecorder) UpdateIncidentStatus(ctx, userID, role, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIncidentStatus", reflect.TypeOf((*MockIncidentServiceInterface)(nil).UpdateIncidentStatus), ctx, userID, role, id, req)
+}
+
+// MockChecklistServiceInterface is a mock of ChecklistServiceInterface interface.
+type MockChecklistServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecklistServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockChecklistServiceInterfaceMockRecorder is the mock recorder for MockChecklistServiceInterface.
+type MockChecklistServiceInterfaceMockRecorder struct {
+	mock *MockChecklistServiceInterface
+}
+
+// NewMockChecklistServiceInterface creates a new mock instance.
+func NewMockChecklistServiceInterface(ctrl *gomock.Controller) *MockChecklistServiceInterface {
+	mock := &MockChecklistServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockChecklistServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecklistServiceInterface) EXPECT() *MockChecklistServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentTemplate mocks base method.
+func (m *MockChecklistServiceInterface) GetCurrentTemplate(ctx context.Context) (*models.ChecklistTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentTemplate", ctx)
+	ret0, _ := ret[0].(*models.ChecklistTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentTemplate indicates an expected call of GetCurrentTemplate.
+func (mr *MockChecklistServiceInterfaceMockRecorder) GetCurrentTemplate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentTemplate", reflect.TypeOf((*MockChecklistServiceInterface)(nil).GetCurrentTemplate), ctx)
+}
+
+// GetTemplateVersion mocks base method.
+func (m *MockChecklistServiceInterface) GetTemplateVersion(ctx context.Context, version int) (*models.ChecklistTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateVersion", ctx, version)
+	ret0, _ := ret[0].(*models.ChecklistTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateVersion indicates an expected call of GetTemplateVersion.
+func (mr *MockChecklistServiceInterfaceMockRecorder) GetTemplateVersion(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateVersion", reflect.TypeOf((*MockChecklistServiceInterface)(nil).GetTemplateVersion), ctx, version)
+}
+
+// ListTemplateVersions mocks base method.
+func (m *MockChecklistServiceInterface) ListTemplateVersions(ctx context.Context) ([]models.ChecklistTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplateVersions", ctx)
+	ret0, _ := ret[0].([]models.ChecklistTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplateVersions indicates an expected call of ListTemplateVersions.
+func (mr *MockChecklistServiceInterfaceMockRecorder) ListTemplateVersions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplateVersions", reflect.TypeOf((*MockChecklistServiceInterface)(nil).ListTemplateVersions), ctx)
+}
+
+// SaveTemplate mocks base method.
+func (m *MockChecklistServiceInterface) SaveTemplate(ctx context.Context, req models.ChecklistTemplateRequest, createdBy string) (*models.ChecklistTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTemplate", ctx, req, createdBy)
+	ret0, _ := ret[0].(*models.ChecklistTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTemplate indicates an expected call of SaveTemplate.
+func (mr *MockChecklistServiceInterfaceMockRecorder) SaveTemplate(ctx, req, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTemplate", reflect.TypeOf((*MockChecklistServiceInterface)(nil).SaveTemplate), ctx, req, createdBy)
+}
+
+// MockBotCheckInterface is a mock of BotCheckInterface interface.
+type MockBotCheckInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBotCheckInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBotCheckInterfaceMockRecorder is the mock recorder for MockBotCheckInterface.
+type MockBotCheckInterfaceMockRecorder struct {
+	mock *MockBotCheckInterface
+}
+
+// NewMockBotCheckInterface creates a new mock instance.
+func NewMockBotCheckInterface(ctrl *gomock.Controller) *MockBotCheckInterface {
+	mock := &MockBotCheckInterface{ctrl: ctrl}
+	mock.recorder = &MockBotCheckInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBotCheckInterface) EXPECT() *MockBotCheckInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Challenge mocks base method.
+func (m *MockBotCheckInterface) Challenge(ctx context.Context) (*models.BotChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Challenge", ctx)
+	ret0, _ := ret[0].(*models.BotChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Challenge indicates an expected call of Challenge.
+func (mr *MockBotCheckInterfaceMockRecorder) Challenge(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Challenge", reflect.TypeOf((*MockBotCheckInterface)(nil).Challenge), ctx)
+}
+
+// Name mocks base method.
+func (m *MockBotCheckInterface) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockBotCheckInterfaceMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockBotCheckInterface)(nil).Name))
+}
+
+// Verify mocks base method.
+func (m *MockBotCheckInterface) Verify(ctx context.Context, token, remoteIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, token, remoteIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockBotCheckInterfaceMockRecorder) Verify(ctx, token, remoteIP any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockBotCheckInterface)(nil).Verify), ctx, token, remoteIP)
+}
@@ -0,0 +1,140 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/secrets"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// NotificationService implements the InboxServiceInterface. Every notification is stored in the
+// user's in-app inbox and sent as e-mail over SMTP. Without SMTP_HOST configured, the e-mail is
+// written to the log instead so local development works without a mail server.
+type NotificationService struct {
+	notificationStore store.NotificationStoreInterface
+	host              string
+	port              string
+	from              string
+}
+
+// NewNotificationService creates a new notification service from SMTP_* and EMAIL_FROM settings
+func NewNotificationService(notificationStore store.NotificationStoreInterface) *NotificationService {
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	from := os.Getenv("EMAIL_FROM")
+	if from == "" {
+		from = "no-reply@carzone.local"
+	}
+
+	return &NotificationService{
+		notificationStore: notificationStore,
+		host:              os.Getenv("SMTP_HOST"),
+		port:              port,
+		from:              from,
+	}
+}
+
+// Notify stores a plain-text message in the user's inbox and sends it to their e-mail address.
+// A failure to store the message is logged and does not stop the e-mail.
+func (s *NotificationService) Notify(ctx context.Context, user models.User, subject, message string) error {
+	tracer := otel.Tracer("NotificationService")
+	ctx, span := tracer.Start(ctx, "Notify-Service")
+	defer span.End()
+
+	if _, err := s.notificationStore.CreateNotification(ctx, user.ID.String(), subject, message); err != nil {
+		log.Printf("Failed to store notification for user %s in inbox: %v", user.ID, err)
+	}
+
+	if user.Email == "" {
+		return fmt.Errorf("user %s has no e-mail address", user.ID)
+	}
+
+	return s.SendEmail(ctx, user.Email, subject, "Hi "+user.UserName+",\r\n\r\n"+message+"\r\n\r\n- The CarZone team\r\n")
+}
+
+// SendEmail sends a plain-text e-mail over SMTP without keeping it in an inbox, for messages
+// such as password reset links that must only reach the address. Without SMTP_HOST the e-mail
+// is written to the log.
+func (s *NotificationService) SendEmail(ctx context.Context, to, subject, body string) error {
+	tracer := otel.Tracer("NotificationService")
+	_, span := tracer.Start(ctx, "SendEmail-Service")
+	defer span.End()
+
+	if s.host == "" {
+		log.Printf("E-mail to %s (SMTP not configured): %s - %s", to, subject, body)
+		return nil
+	}
+
+	// Header values must not carry line breaks, otherwise extra headers could be injected
+	headerValue := strings.NewReplacer("\r", " ", "\n", " ")
+	message := "From: " + s.from + "\r\n" +
+		"To: " + headerValue.Replace(to) + "\r\n" +
+		"Subject: " + headerValue.Replace(subject) + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n\r\n" + body
+
+	var auth smtp.Auth
+	if username := secrets.Get("SMTP_USERNAME"); username != "" {
+		auth = smtp.PlainAuth("", username, secrets.Get("SMTP_PASSWORD"), s.host)
+	}
+
+	if err := smtp.SendMail(net.JoinHostPort(s.host, s.port), auth, s.from, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send e-mail: %v", err)
+	}
+
+	return nil
+}
+
+// ListNotifications retrieves one page of the user's notifications, newest first
+func (s *NotificationService) ListNotifications(ctx context.Context, userID string, filter models.NotificationFilter, page models.PageRequest) (*models.Page[models.Notification], error) {
+	tracer := otel.Tracer("NotificationService")
+	ctx, span := tracer.Start(ctx, "ListNotifications-Service")
+	defer span.End()
+
+	notifications, err := s.notificationStore.ListNotifications(ctx, userID, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.NewPage(notifications, page, models.Notification.PageCursor)
+	return &result, nil
+}
+
+// MarkRead marks one of the user's notifications as read
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id string) (*models.Notification, error) {
+	tracer := otel.Tracer("NotificationService")
+	ctx, span := tracer.Start(ctx, "MarkRead-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("no notification found with the given ID")
+	}
+
+	notification, err := s.notificationStore.MarkRead(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// CountUnread counts the user's unread notifications
+func (s *NotificationService) CountUnread(ctx context.Context, userID string) (int, error) {
+	tracer := otel.Tracer("NotificationService")
+	ctx, span := tracer.Start(ctx, "CountUnread-Service")
+	defer span.End()
+
+	return s.notificationStore.CountUnread(ctx, userID)
+}
@@ -0,0 +1,192 @@
+// Package onboarding reports how far an owner has got in setting up their account to earn from
+// their cars: a complete profile, verified payout details, a first active car and identity
+// documents checked by an admin. Progress is derived from the account on every read, so there is
+// nothing to keep in step; each open step says what to do next and which request does it.
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// OnboardingService implements the OnboardingServiceInterface
+type OnboardingService struct {
+	userStore   store.UserStoreInterface
+	payoutStore store.PayoutStoreInterface
+	carStore    store.CarStoreInterface
+	kycStore    store.KYCStoreInterface
+}
+
+// NewOnboardingService creates a new onboarding service
+func NewOnboardingService(userStore store.UserStoreInterface, payoutStore store.PayoutStoreInterface, carStore store.CarStoreInterface, kycStore store.KYCStoreInterface) *OnboardingService {
+	return &OnboardingService{
+		userStore:   userStore,
+		payoutStore: payoutStore,
+		carStore:    carStore,
+		kycStore:    kycStore,
+	}
+}
+
+// GetProgress retrieves an owner's onboarding steps, in the order guided UIs walk through them
+func (s *OnboardingService) GetProgress(ctx context.Context, ownerID string) (*models.OnboardingProgress, error) {
+	tracer := otel.Tracer("OnboardingService")
+	ctx, span := tracer.Start(ctx, "GetProgress-Service")
+	defer span.End()
+
+	id, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errors.New("invalid owner ID")
+	}
+	user, err := s.userStore.GetUserByID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	payout, err := s.payoutStep(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	car, err := s.firstCarStep(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := s.documentsStep(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := models.NewOnboardingProgress(id, []models.OnboardingStep{profileStep(user), payout, car, documents})
+	return &progress, nil
+}
+
+// profileStep checks the profile fields renters see or support needs to reach the owner
+func profileStep(user models.User) models.OnboardingStep {
+	step := models.OnboardingStep{Key: models.OnboardingProfileComplete, Title: "Complete your profile"}
+
+	var missing []string
+	if strings.TrimSpace(user.Phone) == "" {
+		missing = append(missing, "phone number")
+	}
+	if name, _ := user.ProfileData[models.ProfileDisplayName].(string); strings.TrimSpace(name) == "" {
+		missing = append(missing, "display name")
+	}
+	address, _ := user.ProfileData[models.ProfileAddress].(map[string]interface{})
+	if city, _ := address["city"].(string); strings.TrimSpace(city) == "" {
+		missing = append(missing, "city")
+	}
+
+	if len(missing) == 0 {
+		step.Done = true
+		return step
+	}
+	step.Hint = "Add your " + strings.Join(missing, ", ")
+	if missing[0] == "phone number" {
+		step.Method, step.Link = "PUT", "/users/me"
+	} else {
+		step.Method, step.Link = "PATCH", "/users/me/profile"
+	}
+	return step
+}
+
+// payoutStep checks for a payout account the gateway verified, the newest one counting first
+func (s *OnboardingService) payoutStep(ctx context.Context, ownerID string) (models.OnboardingStep, error) {
+	step := models.OnboardingStep{Key: models.OnboardingPayoutDetails, Title: "Add payout details"}
+
+	accounts, err := s.payoutStore.GetPayoutAccountsByOwnerID(ctx, ownerID)
+	if err != nil {
+		return step, err
+	}
+
+	var pending *models.PayoutAccount
+	for i, account := range accounts {
+		switch account.Status {
+		case models.PayoutAccountStatusVerified:
+			step.Done = true
+			step.CompletedAt = account.VerifiedAt
+			return step, nil
+		case models.PayoutAccountStatusPending:
+			if pending == nil {
+				pending = &accounts[i]
+			}
+		}
+	}
+
+	if pending != nil {
+		step.Hint = "Your payout account is being verified; check its status again in a few minutes"
+		step.Method, step.Link = "POST", "/owners/me/payout-accounts/"+pending.ID.String()+"/verify"
+		return step, nil
+	}
+	if len(accounts) > 0 {
+		step.Hint = "Your payout account could not be verified; add another bank account or UPI ID"
+	} else {
+		step.Hint = "Add the bank account or UPI ID your earnings are paid to"
+	}
+	step.Method, step.Link = "POST", "/owners/me/payout-accounts"
+	return step, nil
+}
+
+// firstCarStep checks for an active car, otherwise follows the owner's newest car through review
+func (s *OnboardingService) firstCarStep(ctx context.Context, ownerID uuid.UUID) (models.OnboardingStep, error) {
+	step := models.OnboardingStep{Key: models.OnboardingFirstCarListed, Title: "List your first car"}
+
+	active, err := s.carStore.CountCars(ctx, models.CarFilter{OwnerID: &ownerID, Status: models.CarStatusActive})
+	if err != nil {
+		return step, err
+	}
+	if active > 0 {
+		step.Done = true
+		return step, nil
+	}
+
+	cars, err := s.carStore.ListCars(ctx, models.CarFilter{OwnerID: &ownerID}, models.PageRequest{Limit: 1})
+	if err != nil {
+		return step, err
+	}
+	if len(cars) == 0 {
+		step.Hint = "Add your car with photos, a description and its daily price"
+		step.Method, step.Link = "POST", "/cars"
+		return step, nil
+	}
+
+	car := cars[0]
+	switch car.Status {
+	case models.CarStatusDraft:
+		step.Hint = "Submit " + car.Name + " for review to get it listed"
+		step.Method, step.Link = "PUT", "/cars/"+car.ID.String()+"/status"
+	case models.CarStatusPendingReview:
+		step.Hint = car.Name + " is waiting for an admin to review it"
+	default:
+		step.Hint = "Put " + car.Name + " back on the catalog or add another car"
+		step.Method, step.Link = "PUT", "/cars/"+car.ID.String()+"/status"
+	}
+	return step, nil
+}
+
+// documentsStep checks whether an admin verified the owner's identity documents
+func (s *OnboardingService) documentsStep(ctx context.Context, user models.User) (models.OnboardingStep, error) {
+	step := models.OnboardingStep{Key: models.OnboardingDocumentsVerified, Title: "Verify your identity"}
+
+	kyc, err := s.kycStore.GetVerifiedKYC(ctx, user.ID.String())
+	if err == nil {
+		step.Done = true
+		step.CompletedAt = &kyc.VerifiedAt
+		return step, nil
+	}
+	if !strings.Contains(err.Error(), "no verified KYC found") {
+		return step, err
+	}
+
+	if user.LicenseNumber == "" {
+		step.Hint = "Add your driving licence number so an admin can check your documents"
+		step.Method, step.Link = "PATCH", "/users/me/profile"
+	} else {
+		step.Hint = "An admin is checking your documents"
+	}
+	return step, nil
+}
@@ -0,0 +1,133 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"go.opentelemetry.io/otel"
+)
+
+// domainCacheTTL bounds how long a host name lookup is reused. Every request resolves its
+// host, so lookups are cached rather than hitting the database each time.
+const domainCacheTTL = time.Minute
+
+// cachedOperator is a host name lookup result; operator is nil for hosts without an operator
+type cachedOperator struct {
+	operator *models.Operator
+	expires  time.Time
+}
+
+// OperatorService implements the OperatorServiceInterface
+type OperatorService struct {
+	operatorStore store.OperatorStoreInterface
+
+	mu      sync.Mutex
+	domains map[string]cachedOperator
+}
+
+// NewOperatorService creates a new operator service
+func NewOperatorService(operatorStore store.OperatorStoreInterface) *OperatorService {
+	return &OperatorService{
+		operatorStore: operatorStore,
+		domains:       make(map[string]cachedOperator),
+	}
+}
+
+// CreateOperator validates and stores a new operator
+func (s *OperatorService) CreateOperator(ctx context.Context, req models.OperatorRequest) (*models.Operator, error) {
+	tracer := otel.Tracer("OperatorService")
+	ctx, span := tracer.Start(ctx, "CreateOperator-Service")
+	defer span.End()
+
+	if err := models.ValidateOperatorRequest(req); err != nil {
+		return nil, err
+	}
+
+	operator, err := s.operatorStore.CreateOperator(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.clearDomainCache()
+
+	return &operator, nil
+}
+
+// GetOperator retrieves an operator by ID
+func (s *OperatorService) GetOperator(ctx context.Context, id string) (*models.Operator, error) {
+	tracer := otel.Tracer("OperatorService")
+	ctx, span := tracer.Start(ctx, "GetOperator-Service")
+	defer span.End()
+
+	operator, err := s.operatorStore.GetOperatorByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &operator, nil
+}
+
+// ListOperators retrieves every operator
+func (s *OperatorService) ListOperators(ctx context.Context) ([]models.Operator, error) {
+	tracer := otel.Tracer("OperatorService")
+	ctx, span := tracer.Start(ctx, "ListOperators-Service")
+	defer span.End()
+
+	return s.operatorStore.ListOperators(ctx)
+}
+
+// UpdateOperator validates and replaces an operator's settings. The default operator cannot be
+// deactivated or given a domain, since it serves the shared API host.
+func (s *OperatorService) UpdateOperator(ctx context.Context, id string, req models.OperatorRequest) (*models.Operator, error) {
+	tracer := otel.Tracer("OperatorService")
+	ctx, span := tracer.Start(ctx, "UpdateOperator-Service")
+	defer span.End()
+
+	if err := models.ValidateOperatorRequest(req); err != nil {
+		return nil, err
+	}
+	if id == tenant.DefaultOperatorID.String() && (!req.IsActive || req.Domain != nil) {
+		return nil, errors.New("the default operator must stay active and cannot have a domain")
+	}
+
+	operator, err := s.operatorStore.UpdateOperator(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	s.clearDomainCache()
+
+	return &operator, nil
+}
+
+// ResolveDomain returns the operator serving a host name, using cached lookups
+func (s *OperatorService) ResolveDomain(ctx context.Context, host string) (*models.Operator, error) {
+	s.mu.Lock()
+	cached, ok := s.domains[host]
+	s.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.operator, nil
+	}
+
+	operator, err := s.operatorStore.GetOperatorByDomain(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.domains[host] = cachedOperator{operator: operator, expires: time.Now().Add(domainCacheTTL)}
+	s.mu.Unlock()
+
+	return operator, nil
+}
+
+// clearDomainCache drops cached lookups after an operator's domain or status may have changed.
+// Other instances pick up the change when their cache entries expire.
+func (s *OperatorService) clearDomainCache() {
+	s.mu.Lock()
+	s.domains = make(map[string]cachedOperator)
+	s.mu.Unlock()
+}
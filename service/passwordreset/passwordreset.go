@@ -0,0 +1,129 @@
+// Package passwordreset lets users who forgot their password set a new one through a link sent
+// to their e-mail address. Links carry a random token of which only a hash is stored; each
+// works once and for models.PasswordResetTokenTTL.
+package passwordreset
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// PasswordResetService implements the PasswordResetServiceInterface
+type PasswordResetService struct {
+	resetStore  store.PasswordResetStoreInterface
+	userStore   store.UserStoreInterface
+	emailSender service.EmailSenderInterface
+	baseURL     string
+}
+
+// NewPasswordResetService creates a new password reset service. Reset links point to the
+// /reset-password page under baseURL, never to the host a request names, so a forged Host
+// header cannot send tokens elsewhere.
+func NewPasswordResetService(resetStore store.PasswordResetStoreInterface, userStore store.UserStoreInterface, emailSender service.EmailSenderInterface, baseURL string) *PasswordResetService {
+	return &PasswordResetService{
+		resetStore:  resetStore,
+		userStore:   userStore,
+		emailSender: emailSender,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// RequestPasswordReset e-mails a reset link to the account with the address. Whether there is
+// such an account is not reported, so the endpoint cannot be used to find out who has one;
+// accounts that were sent MaxPasswordResetRequests links within the window get no more.
+func (s *PasswordResetService) RequestPasswordReset(ctx context.Context, req models.ForgotPasswordRequest) error {
+	tracer := otel.Tracer("PasswordResetService")
+	ctx, span := tracer.Start(ctx, "RequestPasswordReset-Service")
+	defer span.End()
+
+	if _, err := mail.ParseAddress(req.Email); err != nil {
+		return errors.New("invalid email format")
+	}
+
+	user, err := s.userStore.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		if strings.Contains(err.Error(), "user not found") {
+			return nil
+		}
+		return err
+	}
+
+	issued, err := s.resetStore.CountPasswordResetTokens(ctx, user.ID, time.Now().Add(-models.PasswordResetRequestWindow))
+	if err != nil {
+		return err
+	}
+	if issued >= models.MaxPasswordResetRequests {
+		log.Printf("Not sending password reset link to user %s: %d sent within %s", user.ID, issued, models.PasswordResetRequestWindow)
+		return nil
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+	resetToken, err := s.resetStore.CreatePasswordResetToken(ctx, user.ID, hashToken(token), time.Now().Add(models.PasswordResetTokenTTL))
+	if err != nil {
+		return err
+	}
+
+	link := s.baseURL + "/reset-password?token=" + url.QueryEscape(token)
+	body := fmt.Sprintf("Hi %s,\r\n\r\nWe received a request to reset the password of your CarZone account. "+
+		"Open this link to choose a new one:\r\n\r\n%s\r\n\r\nThe link works once and expires at %s. "+
+		"If you did not ask for it, you can ignore this e-mail; your password stays the same.\r\n\r\n- The CarZone team\r\n",
+		user.UserName, link, resetToken.ExpiresAt.UTC().Format(time.RFC1123))
+	if err := s.emailSender.SendEmail(ctx, user.Email, "Reset your CarZone password", body); err != nil {
+		// Failing the request would tell the caller that the address has an account
+		log.Printf("Failed to send password reset link to user %s: %v", user.ID, err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password with the token of a reset link and uses the link up
+func (s *PasswordResetService) ResetPassword(ctx context.Context, req models.ResetPasswordRequest) error {
+	tracer := otel.Tracer("PasswordResetService")
+	ctx, span := tracer.Start(ctx, "ResetPassword-Service")
+	defer span.End()
+
+	if err := models.ValidateResetPasswordRequest(req); err != nil {
+		return err
+	}
+
+	userID, err := s.resetStore.ResetPassword(ctx, hashToken(req.Token), req.Password)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Password of user %s reset with an e-mailed link", userID)
+	return nil
+}
+
+// newToken returns 32 random bytes, base64url-encoded for use in a link
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hex SHA-256 of a token, which is what the store keeps
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
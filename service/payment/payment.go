@@ -9,6 +9,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"math"
 	"net/http"
 	"os"
 	"strings"
@@ -17,26 +19,67 @@ import (
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 
+	"github.com/PrateekKumar15/CarZone/dependency"
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/secrets"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/statemachine"
 	"github.com/PrateekKumar15/CarZone/store"
 )
 
 // PaymentService implements the PaymentServiceInterface for payment operations
 type PaymentService struct {
-	paymentStore      store.PaymentStoreInterface
-	bookingStore      store.BookingStoreInterface
-	razorpayKeyID     string
-	razorpayKeySecret string
+	paymentStore     store.PaymentStoreInterface
+	bookingStore     store.BookingStoreInterface
+	securityMonitor  service.SecurityMonitorInterface
+	riskScorer       service.RiskScorerInterface
+	userStore        store.UserStoreInterface
+	disputes         service.DisputeServiceInterface // Handles payment.dispute.* webhook events
+	documents        service.SequenceServiceInterface
+	linkTTL          time.Duration // How long payment links stay payable
+	captureAtPickup  bool          // Hold booking payments on the card and capture them at pickup
+	authorizationTTL time.Duration // How long Razorpay keeps an uncaptured payment held
+	razorpayBaseURL  string        // Razorpay API, without a trailing slash
+	statuses         *statemachine.Machine[models.PaymentStatus, models.Payment]
 }
 
-// NewPaymentService creates a new payment service
-func NewPaymentService(paymentStore store.PaymentStoreInterface, bookingStore store.BookingStoreInterface) *PaymentService {
-	return &PaymentService{
-		paymentStore:      paymentStore,
-		bookingStore:      bookingStore,
-		razorpayKeyID:     os.Getenv("RAZORPAY_KEY_ID"),
-		razorpayKeySecret: os.Getenv("RAZORPAY_KEY_SECRET"),
+// NewPaymentService creates a new payment service.
+// PAYMENT_LINK_TTL (default 72h, at least 15m) sets how long payment links stay payable.
+// PAYMENT_CAPTURE_MODE=manual (default automatic) holds booking payments on the customer's
+// card and captures them at pickup; PAYMENT_AUTHORIZATION_TTL (default 120h, at least 1h) is
+// how long a hold lasts, so bookings starting later than that are captured at once.
+// RAZORPAY_API_URL (default https://api.razorpay.com/v1) points Razorpay calls at another
+// server, such as the stub of integration runs.
+func NewPaymentService(paymentStore store.PaymentStoreInterface, bookingStore store.BookingStoreInterface, securityMonitor service.SecurityMonitorInterface, riskScorer service.RiskScorerInterface, userStore store.UserStoreInterface, disputes service.DisputeServiceInterface, documents service.SequenceServiceInterface) *PaymentService {
+	linkTTL, err := time.ParseDuration(os.Getenv("PAYMENT_LINK_TTL"))
+	if err != nil || linkTTL < 15*time.Minute { // Razorpay rejects links expiring sooner
+		linkTTL = 72 * time.Hour
 	}
+	authorizationTTL, err := time.ParseDuration(os.Getenv("PAYMENT_AUTHORIZATION_TTL"))
+	if err != nil || authorizationTTL < time.Hour {
+		authorizationTTL = 120 * time.Hour
+	}
+	razorpayBaseURL := strings.TrimSuffix(os.Getenv("RAZORPAY_API_URL"), "/")
+	if razorpayBaseURL == "" {
+		razorpayBaseURL = "https://api.razorpay.com/v1"
+	}
+	s := &PaymentService{
+		paymentStore:     paymentStore,
+		bookingStore:     bookingStore,
+		securityMonitor:  securityMonitor,
+		riskScorer:       riskScorer,
+		userStore:        userStore,
+		disputes:         disputes,
+		documents:        documents,
+		linkTTL:          linkTTL,
+		captureAtPickup:  os.Getenv("PAYMENT_CAPTURE_MODE") == "manual",
+		authorizationTTL: authorizationTTL,
+		razorpayBaseURL:  razorpayBaseURL,
+	}
+	s.statuses = models.PaymentStatusMachine.Clone().
+		OnEnter(models.PaymentStatusFailed, s.onFailed).
+		OnEnter(models.PaymentStatusCompleted, s.onCompleted)
+	return s
 }
 
 // GetPaymentByID retrieves a payment by ID
@@ -87,7 +130,7 @@ func (s *PaymentService) CreatePayment(ctx context.Context, req *models.PaymentR
 	}
 
 	// Verify booking exists
-	_, err := s.bookingStore.GetBookingByID(ctx, req.BookingID.String())
+	booking, err := s.bookingStore.GetBookingByID(ctx, req.BookingID.String())
 	if err != nil {
 		return nil, errors.New("booking not found")
 	}
@@ -101,7 +144,16 @@ func (s *PaymentService) CreatePayment(ctx context.Context, req *models.PaymentR
 	// Create Razorpay order if method is Razorpay
 	var razorpayOrder *models.RazorpayOrderResponse
 	if req.Method == models.PaymentMethodRazorpay {
-		razorpayOrder, err = s.createRazorpayOrder(ctx, payment)
+		// The authorization is stored before the order, so a verified payment on a manual-capture
+		// order is never taken for a captured one
+		manualCapture := s.capturesAtPickup(booking)
+		if manualCapture {
+			if _, err := s.paymentStore.CreatePaymentAuthorization(ctx, payment); err != nil {
+				return nil, err
+			}
+		}
+
+		razorpayOrder, err = s.createRazorpayOrder(ctx, payment, manualCapture)
 		if err != nil {
 			fmt.Printf("DEBUG: Failed to create Razorpay order: %v\n", err)
 			return nil, err
@@ -118,6 +170,10 @@ func (s *PaymentService) CreatePayment(ctx context.Context, req *models.PaymentR
 		}
 
 		fmt.Printf("DEBUG: Updated payment record with order ID: %s\n", *updatedPayment.RazorpayOrderID)
+
+		if _, err := s.paymentStore.CreatePaymentAttempt(ctx, payment.ID, razorpayOrder.ID); err != nil {
+			return nil, err
+		}
 	}
 
 	fmt.Printf("DEBUG: Returning Razorpay order response: %+v\n", razorpayOrder)
@@ -161,10 +217,24 @@ func (s *PaymentService) VerifyPayment(ctx context.Context, req *models.PaymentV
 			fmt.Printf("DEBUG: Failed to update payment status to failed: %v\n", err)
 			return nil, err
 		}
+		s.statuses.Entered(ctx, failedPayment, statemachine.Transition[models.PaymentStatus]{From: payment.Status, To: models.PaymentStatusFailed})
+		errorCode := models.SignatureMismatchErrorCode
+		s.recordAttemptOutcome(ctx, req.RazorpayOrderID, models.PaymentStatusFailed, &req.RazorpayPaymentID, &errorCode, nil)
 		return &failedPayment, errors.New("payment verification failed")
 	}
 
 	fmt.Printf("DEBUG: Signature verification successful\n")
+	s.screenCapturedPayment(ctx, payment)
+
+	// Manual-capture payments are only held on the card until pickup
+	authorization, err := s.paymentStore.GetPaymentAuthorization(ctx, payment.ID.String())
+	if err != nil && !strings.Contains(err.Error(), "no payment authorization found") {
+		return nil, err
+	}
+	if err == nil {
+		return s.authorizePayment(ctx, payment, authorization, req)
+	}
+
 	// Update payment status to completed
 	completedPayment, err := s.paymentStore.UpdatePaymentStatus(ctx, payment.ID.String(),
 		models.PaymentStatusCompleted, &req.RazorpayPaymentID, nil)
@@ -174,6 +244,8 @@ func (s *PaymentService) VerifyPayment(ctx context.Context, req *models.PaymentV
 	}
 
 	fmt.Printf("DEBUG: Payment updated successfully to completed status\n")
+	s.recordAttemptOutcome(ctx, req.RazorpayOrderID, models.PaymentStatusCompleted, &req.RazorpayPaymentID, nil, nil)
+	s.statuses.Entered(ctx, completedPayment, statemachine.Transition[models.PaymentStatus]{From: payment.Status, To: models.PaymentStatusCompleted})
 	return &completedPayment, nil
 }
 
@@ -187,7 +259,17 @@ func (s *PaymentService) UpdatePaymentStatus(ctx context.Context, id string, sta
 		return nil, errors.New("payment ID cannot be empty")
 	}
 
-	if err := s.validatePaymentStatus(status); err != nil {
+	if err := s.statuses.CheckState(status); err != nil {
+		return nil, err
+	}
+
+	current, err := s.paymentStore.GetPaymentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	transition := statemachine.Transition[models.PaymentStatus]{From: current.Status, To: status}
+	if err := s.statuses.Validate(ctx, current, transition); err != nil {
 		return nil, err
 	}
 
@@ -196,11 +278,66 @@ func (s *PaymentService) UpdatePaymentStatus(ctx context.Context, id string, sta
 		return nil, err
 	}
 
+	s.statuses.Entered(ctx, payment, transition)
+
 	return &payment, nil
 }
 
-// createRazorpayOrder creates an order in Razorpay
-func (s *PaymentService) createRazorpayOrder(ctx context.Context, payment models.Payment) (*models.RazorpayOrderResponse, error) {
+// onFailed reports a failed payment to the security monitor
+func (s *PaymentService) onFailed(ctx context.Context, payment models.Payment, _ statemachine.Transition[models.PaymentStatus]) {
+	s.recordPaymentFailure(ctx, payment)
+}
+
+// onCompleted issues the numbered receipt of a completed payment
+func (s *PaymentService) onCompleted(ctx context.Context, payment models.Payment, _ statemachine.Transition[models.PaymentStatus]) {
+	if _, err := s.documents.IssueReceipt(ctx, payment); err != nil {
+		log.Printf("Failed to issue receipt for payment %s: %v", payment.ID, err)
+	}
+}
+
+// recordPaymentFailure reports a failed payment to the security monitor under the paying customer
+func (s *PaymentService) recordPaymentFailure(ctx context.Context, payment models.Payment) {
+	booking, err := s.bookingStore.GetBookingByID(ctx, payment.BookingID.String())
+	if err != nil {
+		fmt.Printf("DEBUG: Failed to load booking for failed payment %s: %v\n", payment.ID.String(), err)
+		return
+	}
+	s.securityMonitor.RecordPaymentFailure(ctx, booking.CustomerID.String())
+}
+
+// screenCapturedPayment scores the booking of a payment that passed verification. The money
+// is already captured by Razorpay, so a risky booking is not rejected but held in
+// under_review instead of proceeding to confirmation.
+func (s *PaymentService) screenCapturedPayment(ctx context.Context, payment models.Payment) {
+	booking, err := s.bookingStore.GetBookingByID(ctx, payment.BookingID.String())
+	if err != nil {
+		fmt.Printf("DEBUG: Failed to load booking for risk assessment of payment %s: %v\n", payment.ID.String(), err)
+		return
+	}
+	if booking.Status != models.BookingStatusPending {
+		return
+	}
+
+	assessment, err := s.riskScorer.AssessBooking(ctx, booking, models.RiskStagePaymentCapture)
+	if err != nil {
+		fmt.Printf("DEBUG: Risk assessment failed for booking %s: %v\n", booking.ID.String(), err)
+		return
+	}
+	if !assessment.RequiresReview {
+		return
+	}
+
+	held, err := s.bookingStore.UpdateBookingStatus(ctx, booking.ID.String(), models.BookingStatusUnderReview)
+	if err != nil {
+		fmt.Printf("DEBUG: Failed to hold booking %s for review: %v\n", booking.ID.String(), err)
+		return
+	}
+	s.securityMonitor.RecordHighRiskBooking(ctx, held, assessment)
+}
+
+// createRazorpayOrder creates an order in Razorpay. Payments on a manual-capture order are only
+// authorized and must be captured before Razorpay's capture window ends.
+func (s *PaymentService) createRazorpayOrder(ctx context.Context, payment models.Payment, manualCapture bool) (*models.RazorpayOrderResponse, error) {
 	// Convert amount to paise (Razorpay works with smallest currency unit)
 	amountInPaise := int(payment.Amount * 100)
 
@@ -211,6 +348,49 @@ func (s *PaymentService) createRazorpayOrder(ctx context.Context, payment models
 		Currency: "INR",
 		Receipt:  fmt.Sprintf("bk_%s_%d", bookingIDShort, time.Now().Unix()%10000),
 	}
+	if manualCapture {
+		orderReq.Payment = &models.RazorpayOrderPayment{
+			Capture: "manual",
+			CaptureOptions: models.RazorpayCaptureOption{
+				ManualExpiryPeriod: int(s.authorizationTTL / time.Minute),
+				RefundSpeed:        "optimum",
+			},
+		}
+	}
+
+	return s.postRazorpayOrder(ctx, orderReq)
+}
+
+// CreateOrder creates a Razorpay order for a charge that is not a booking payment, such as
+// featured placement; the caller keeps the order ID and confirms it with VerifyOrderSignature
+func (s *PaymentService) CreateOrder(ctx context.Context, amount float64, receipt string) (*models.RazorpayOrderResponse, error) {
+	tracer := otel.Tracer("PaymentService")
+	ctx, span := tracer.Start(ctx, "CreateOrder-Service")
+	defer span.End()
+
+	if amount <= 0 {
+		return nil, errors.New("amount must be greater than zero")
+	}
+	return s.postRazorpayOrder(ctx, models.RazorpayOrderRequest{
+		Amount:   int(math.Round(amount * 100)),
+		Currency: "INR",
+		Receipt:  receipt,
+	})
+}
+
+// VerifyOrderSignature reports whether Razorpay checkout's signature over the order and payment
+// IDs is genuine
+func (s *PaymentService) VerifyOrderSignature(req models.PaymentVerificationRequest) bool {
+	if err := s.validateVerificationRequest(req); err != nil {
+		return false
+	}
+	return s.verifyRazorpaySignature(req)
+}
+
+// postRazorpayOrder creates an order through the Razorpay Orders API
+func (s *PaymentService) postRazorpayOrder(ctx context.Context, orderReq models.RazorpayOrderRequest) (_ *models.RazorpayOrderResponse, err error) {
+	ctx, done := dependency.Start(ctx, dependency.Razorpay, "CreateOrder")
+	defer func() { done(err) }()
 
 	jsonData, err := json.Marshal(orderReq)
 	if err != nil {
@@ -218,13 +398,13 @@ func (s *PaymentService) createRazorpayOrder(ctx context.Context, payment models
 	}
 
 	// Create HTTP request to Razorpay
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.razorpay.com/v1/orders", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", s.razorpayBaseURL+"/orders", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.SetBasicAuth(s.razorpayKeyID, s.razorpayKeySecret)
+	req.SetBasicAuth(secrets.Get("RAZORPAY_KEY_ID"), secrets.Get("RAZORPAY_KEY_SECRET"))
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -261,7 +441,7 @@ func (s *PaymentService) verifyRazorpaySignature(verificationReq models.PaymentV
 
 	data := verificationReq.RazorpayOrderID + "|" + verificationReq.RazorpayPaymentID
 
-	h := hmac.New(sha256.New, []byte(s.razorpayKeySecret))
+	h := hmac.New(sha256.New, []byte(secrets.Get("RAZORPAY_KEY_SECRET")))
 	h.Write([]byte(data))
 	expectedSignature := hex.EncodeToString(h.Sum(nil))
 
@@ -322,25 +502,6 @@ func (s *PaymentService) validateVerificationRequest(req models.PaymentVerificat
 	return nil
 }
 
-// validatePaymentStatus validates payment status values
-func (s *PaymentService) validatePaymentStatus(status models.PaymentStatus) error {
-	validStatuses := []models.PaymentStatus{
-		models.PaymentStatusPending,
-		models.PaymentStatusCompleted,
-		models.PaymentStatusFailed,
-		models.PaymentStatusRefunded,
-		models.PaymentStatusCancelled,
-	}
-
-	for _, validStatus := range validStatuses {
-		if status == validStatus {
-			return nil
-		}
-	}
-
-	return errors.New("invalid payment status")
-}
-
 // GetPaymentByBookingID retrieves payment record associated with a booking
 func (s *PaymentService) GetPaymentByBookingID(ctx context.Context, bookingID string) (*models.Payment, error) {
 	tracer := otel.Tracer("PaymentService")
@@ -422,16 +583,736 @@ func (s *PaymentService) ProcessRefund(ctx context.Context, paymentID string, am
 	return &refundedPayment, nil
 }
 
-// GetAllPayments retrieves all payment records with business filtering
-func (s *PaymentService) GetAllPayments(ctx context.Context) (*[]models.Payment, error) {
+// ListPayments retrieves one cursor-paginated page of payments matching the filter
+func (s *PaymentService) ListPayments(ctx context.Context, filter models.PaymentFilter, page models.PageRequest) (*models.Page[models.Payment], error) {
 	tracer := otel.Tracer("PaymentService")
-	ctx, span := tracer.Start(ctx, "GetAllPayments-Service")
+	ctx, span := tracer.Start(ctx, "ListPayments-Service")
 	defer span.End()
 
-	payments, err := s.paymentStore.GetAllPayments(ctx)
+	payments, err := s.paymentStore.ListPayments(ctx, filter, page)
 	if err != nil {
 		return nil, err
 	}
 
-	return &payments, nil
+	result := models.NewPage(payments, page, models.Payment.PageCursor)
+	return &result, nil
+}
+
+// CreatePaymentLink creates a pending payment for a booking and a Razorpay Payment Link that
+// Razorpay e-mails and texts to the customer, for bookings taken over the phone
+func (s *PaymentService) CreatePaymentLink(ctx context.Context, bookingID string, req models.PaymentLinkRequest) (*models.PaymentLink, error) {
+	tracer := otel.Tracer("PaymentService")
+	ctx, span := tracer.Start(ctx, "CreatePaymentLink-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		return nil, errors.New("invalid booking ID")
+	}
+
+	booking, err := s.bookingStore.GetBookingByID(ctx, bookingID)
+	if err != nil || booking.ID == uuid.Nil {
+		return nil, errors.New("booking not found")
+	}
+	if booking.Status != models.BookingStatusPending && booking.Status != models.BookingStatusConfirmed {
+		return nil, errors.New("payment links can only be sent for pending or confirmed bookings")
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = booking.TotalAmount
+	}
+	if amount <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		description = fmt.Sprintf("CarZone booking %s", booking.ID.String()[:8])
+	}
+
+	customer, err := s.userStore.GetUserByID(ctx, booking.CustomerID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.paymentStore.CreatePayment(ctx, models.PaymentRequest{
+		BookingID:   booking.ID,
+		Amount:      amount,
+		Method:      models.PaymentMethodRazorpay,
+		Description: description,
+		Notes:       "Payment link",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.linkTTL)
+	razorpayLink, err := s.createRazorpayPaymentLink(ctx, payment, customer, expiresAt)
+	if err != nil {
+		// Nothing can be paid against the payment without its link
+		if _, cancelErr := s.paymentStore.UpdatePaymentStatus(ctx, payment.ID.String(), models.PaymentStatusCancelled, nil, nil); cancelErr != nil {
+			log.Printf("Failed to cancel payment %s after its payment link failed: %v", payment.ID, cancelErr)
+		}
+		return nil, err
+	}
+
+	link, err := s.paymentStore.CreatePaymentLink(ctx, models.PaymentLink{
+		PaymentID:      payment.ID,
+		BookingID:      booking.ID,
+		RazorpayLinkID: razorpayLink.ID,
+		ShortURL:       razorpayLink.ShortURL,
+		Amount:         amount,
+		ExpiresAt:      expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// HandleRazorpayWebhook applies Razorpay's payment link events: a paid link completes its
+// payment, an expired or cancelled one cancels it. Dispute events are handed to the dispute
+// service. Razorpay retries deliveries that fail, so events for links that were already
+// settled are acknowledged without changes.
+func (s *PaymentService) HandleRazorpayWebhook(ctx context.Context, signature string, body []byte) error {
+	tracer := otel.Tracer("PaymentService")
+	ctx, span := tracer.Start(ctx, "HandleRazorpayWebhook-Service")
+	defer span.End()
+
+	secret := secrets.Get("RAZORPAY_WEBHOOK_SECRET")
+	if secret == "" {
+		return errors.New("razorpay webhooks are not configured")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(signature)) {
+		return errors.New("invalid webhook signature")
+	}
+
+	var event models.RazorpayWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return errors.New("invalid webhook payload")
+	}
+
+	if strings.HasPrefix(event.Event, "payment.dispute.") {
+		return s.disputes.HandleDisputeEvent(ctx, event)
+	}
+
+	var linkStatus models.PaymentLinkStatus
+	var paymentStatus models.PaymentStatus
+	switch event.Event {
+	case "payment.failed":
+		return s.applyPaymentFailed(ctx, event)
+	case "payment_link.paid":
+		linkStatus, paymentStatus = models.PaymentLinkStatusPaid, models.PaymentStatusCompleted
+	case "payment_link.expired":
+		linkStatus, paymentStatus = models.PaymentLinkStatusExpired, models.PaymentStatusCancelled
+	case "payment_link.cancelled":
+		linkStatus, paymentStatus = models.PaymentLinkStatusCancelled, models.PaymentStatusCancelled
+	default:
+		return nil
+	}
+
+	link, err := s.paymentStore.GetPaymentLinkByRazorpayID(ctx, event.Payload.PaymentLink.Entity.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no payment link found") {
+			log.Printf("Ignoring %s webhook for unknown payment link %s", event.Event, event.Payload.PaymentLink.Entity.ID)
+			return nil
+		}
+		return err
+	}
+	if link.Status != models.PaymentLinkStatusCreated {
+		return nil
+	}
+
+	// The payment is settled before the link, so a failed delivery is applied in full on retry
+	payment, err := s.paymentStore.GetPaymentByID(ctx, link.PaymentID.String())
+	if err != nil {
+		return err
+	}
+	if payment.Status == models.PaymentStatusPending {
+		var razorpayPaymentID *string
+		if paymentStatus == models.PaymentStatusCompleted {
+			s.screenCapturedPayment(ctx, payment)
+			razorpayPaymentID = &event.Payload.Payment.Entity.ID
+		}
+		updated, err := s.paymentStore.UpdatePaymentStatus(ctx, payment.ID.String(), paymentStatus, razorpayPaymentID, nil)
+		if err != nil {
+			return err
+		}
+		s.statuses.Entered(ctx, updated, statemachine.Transition[models.PaymentStatus]{From: payment.Status, To: paymentStatus})
+	}
+
+	return s.paymentStore.UpdatePaymentLinkStatus(ctx, link.ID, linkStatus)
+}
+
+// createRazorpayPaymentLink creates a Payment Link in Razorpay, notifying the customer by
+// e-mail and, when they have a phone number, by SMS
+func (s *PaymentService) createRazorpayPaymentLink(ctx context.Context, payment models.Payment, customer models.User, expiresAt time.Time) (_ *models.RazorpayPaymentLinkResponse, err error) {
+	ctx, done := dependency.Start(ctx, dependency.Razorpay, "CreatePaymentLink")
+	defer func() { done(err) }()
+
+	linkReq := models.RazorpayPaymentLinkRequest{
+		Amount:      int(payment.Amount * 100),
+		Currency:    "INR",
+		Description: payment.Description,
+		ReferenceID: payment.ID.String(),
+		ExpireBy:    expiresAt.Unix(),
+		Customer: models.RazorpayCustomer{
+			Name:    customer.UserName,
+			Email:   customer.Email,
+			Contact: customer.Phone,
+		},
+		Notify:         models.RazorpayNotify{Email: customer.Email != "", SMS: customer.Phone != ""},
+		ReminderEnable: true,
+	}
+
+	jsonData, err := json.Marshal(linkReq)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.razorpayBaseURL+"/payment_links", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(secrets.Get("RAZORPAY_KEY_ID"), secrets.Get("RAZORPAY_KEY_SECRET"))
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make Razorpay API request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var respBody bytes.Buffer
+		respBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("failed to create Razorpay payment link: status %d, response: %s", resp.StatusCode, respBody.String())
+	}
+
+	var linkResp models.RazorpayPaymentLinkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&linkResp); err != nil {
+		return nil, fmt.Errorf("failed to decode Razorpay response: %v", err)
+	}
+
+	return &linkResp, nil
+}
+
+// RecordCollection marks a pending offline payment collected. The amount must match the
+// payment; partial collections are not supported.
+func (s *PaymentService) RecordCollection(ctx context.Context, bookingID, paymentID, collectedBy string, req models.PaymentCollectionRequest) (*models.PaymentCollection, *models.Payment, error) {
+	tracer := otel.Tracer("PaymentService")
+	ctx, span := tracer.Start(ctx, "RecordCollection-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(paymentID); err != nil {
+		return nil, nil, errors.New("invalid payment ID")
+	}
+	collector, err := uuid.Parse(collectedBy)
+	if err != nil {
+		return nil, nil, errors.New("invalid user ID")
+	}
+	if err := models.ValidatePaymentCollectionRequest(req); err != nil {
+		return nil, nil, err
+	}
+
+	payment, err := s.paymentStore.GetPaymentByID(ctx, paymentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if payment.BookingID.String() != bookingID {
+		return nil, nil, errors.New("no payment found with the given ID")
+	}
+	if payment.Method == models.PaymentMethodRazorpay {
+		return nil, nil, errors.New("only offline payments can be marked collected")
+	}
+
+	transition := statemachine.Transition[models.PaymentStatus]{From: payment.Status, To: models.PaymentStatusCompleted}
+	if err := s.statuses.Validate(ctx, payment, transition); err != nil {
+		return nil, nil, err
+	}
+	if math.Abs(req.Amount-payment.Amount) >= 0.01 {
+		return nil, nil, fmt.Errorf("amount must equal the payment amount of %.2f", payment.Amount)
+	}
+
+	collectedAt := time.Now()
+	if req.CollectedAt != nil {
+		collectedAt = *req.CollectedAt
+	}
+
+	collection, completed, err := s.paymentStore.RecordPaymentCollection(ctx, models.PaymentCollection{
+		PaymentID:     payment.ID,
+		BookingID:     payment.BookingID,
+		Amount:        req.Amount,
+		ReceiptNumber: strings.TrimSpace(req.ReceiptNumber),
+		Notes:         strings.TrimSpace(req.Notes),
+		CollectedBy:   collector,
+		CollectedAt:   collectedAt,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.statuses.Entered(ctx, completed, transition)
+
+	return &collection, &completed, nil
+}
+
+// GetCollections retrieves the offline collections recorded for a booking
+func (s *PaymentService) GetCollections(ctx context.Context, bookingID string) ([]models.PaymentCollection, error) {
+	tracer := otel.Tracer("PaymentService")
+	ctx, span := tracer.Start(ctx, "GetCollections-Service")
+	defer span.End()
+
+	return s.paymentStore.GetPaymentCollectionsByBookingID(ctx, bookingID)
+}
+
+// RetryPayment opens a new Razorpay order for a failed payment so the customer can pay again
+// against the same payment. Only the booking's customer or an admin may retry.
+func (s *PaymentService) RetryPayment(ctx context.Context, id, userID, role string) (*models.RazorpayOrderResponse, error) {
+	tracer := otel.Tracer("PaymentService")
+	ctx, span := tracer.Start(ctx, "RetryPayment-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid payment ID")
+	}
+
+	payment, err := s.paymentStore.GetPaymentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := s.bookingStore.GetBookingByID(ctx, payment.BookingID.String())
+	if err != nil {
+		return nil, err
+	}
+	if role != "admin" && booking.CustomerID.String() != userID {
+		return nil, errors.New("no payment found with the given ID")
+	}
+
+	if payment.Method != models.PaymentMethodRazorpay {
+		return nil, errors.New("only Razorpay payments can be retried")
+	}
+	if payment.Status != models.PaymentStatusFailed {
+		return nil, errors.New("only failed payments can be retried")
+	}
+	if booking.Status == models.BookingStatusCancelled || booking.Status == models.BookingStatusCompleted {
+		return nil, fmt.Errorf("payments cannot be retried for %s bookings", booking.Status)
+	}
+
+	transition := statemachine.Transition[models.PaymentStatus]{From: payment.Status, To: models.PaymentStatusPending}
+	if err := s.statuses.Validate(ctx, payment, transition); err != nil {
+		return nil, err
+	}
+
+	// A payment held until pickup is retried on a manual-capture order as well
+	_, err = s.paymentStore.GetPaymentAuthorization(ctx, payment.ID.String())
+	if err != nil && !strings.Contains(err.Error(), "no payment authorization found") {
+		return nil, err
+	}
+	order, err := s.createRazorpayOrder(ctx, payment, err == nil)
+	if err != nil {
+		return nil, err
+	}
+
+	retried, err := s.paymentStore.RetryPayment(ctx, payment.ID, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.statuses.Entered(ctx, retried, transition)
+
+	return order, nil
+}
+
+// GetPaymentDetail retrieves a payment with every gateway attempt made on it, for admins
+// resolving disputes
+func (s *PaymentService) GetPaymentDetail(ctx context.Context, id string) (*models.PaymentDetail, error) {
+	tracer := otel.Tracer("PaymentService")
+	ctx, span := tracer.Start(ctx, "GetPaymentDetail-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid payment ID")
+	}
+
+	payment, err := s.paymentStore.GetPaymentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.paymentStore.GetPaymentAttempts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.PaymentDetail{Payment: payment, Attempts: attempts}
+	authorization, err := s.paymentStore.GetPaymentAuthorization(ctx, id)
+	if err == nil {
+		detail.Authorization = &authorization
+	} else if !strings.Contains(err.Error(), "no payment authorization found") {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// applyPaymentFailed records Razorpay's error on the attempt made on the failed payment's
+// order and fails the payment if it is still pending. Checkout does not report failures to
+// the API, so this is where they are recorded.
+func (s *PaymentService) applyPaymentFailed(ctx context.Context, event models.RazorpayWebhookEvent) error {
+	entity := event.Payload.Payment.Entity
+	if entity.OrderID == "" {
+		return nil // Payment link payments have no order and no attempt
+	}
+
+	payment, err := s.paymentStore.GetPaymentByRazorpayOrderID(ctx, entity.OrderID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no payment found") {
+			log.Printf("Ignoring payment.failed webhook for unknown order %s", entity.OrderID)
+			return nil
+		}
+		return err
+	}
+
+	// A failure reported for an earlier attempt only updates that attempt
+	s.recordAttemptOutcome(ctx, entity.OrderID, models.PaymentStatusFailed, &entity.ID,
+		nonEmpty(entity.ErrorCode), nonEmpty(entity.ErrorDescription))
+
+	if payment.Status != models.PaymentStatusPending {
+		return nil
+	}
+	failed, err := s.paymentStore.UpdatePaymentStatus(ctx, payment.ID.String(), models.PaymentStatusFailed, &entity.ID, nil)
+	if err != nil {
+		return err
+	}
+	s.statuses.Entered(ctx, failed, statemachine.Transition[models.PaymentStatus]{From: payment.Status, To: models.PaymentStatusFailed})
+
+	return nil
+}
+
+// reconcileBatchSize caps the payments one ReconcilePendingPayments run resyncs
+const reconcileBatchSize = 500
+
+// ReconcilePendingPayments resyncs the Razorpay payments left pending for longer than
+// olderThan. A payment that fails to resync is logged, counted as checked and skipped.
+func (s *PaymentService) ReconcilePendingPayments(ctx context.Context, olderThan time.Duration) (models.MaintenanceResult, error) {
+	tracer := otel.Tracer("PaymentService")
+	ctx, span := tracer.Start(ctx, "ReconcilePendingPayments-Service")
+	defer span.End()
+
+	ids, err := s.paymentStore.GetStalePendingPaymentIDs(ctx, time.Now().Add(-olderThan), reconcileBatchSize)
+	if err != nil {
+		return models.MaintenanceResult{}, err
+	}
+
+	var result models.MaintenanceResult
+	for _, id := range ids {
+		resynced, err := s.ResyncPayment(ctx, id.String())
+		if err != nil {
+			log.Printf("Failed to reconcile payment %s: %v", id, err)
+			result.Examined++
+			continue
+		}
+		result.Add(resynced)
+	}
+	return result, nil
+}
+
+// ResyncPayment applies the outcome Razorpay recorded for a pending payment whose checkout
+// verification or webhook never arrived: a captured attempt completes the payment, an attempt
+// authorized on a manual-capture order holds it, and a failed attempt fails it. Payments that
+// are no longer pending, or whose order has no settled attempt, are left as they are.
+func (s *PaymentService) ResyncPayment(ctx context.Context, id string) (models.MaintenanceResult, error) {
+	tracer := otel.Tracer("PaymentService")
+	ctx, span := tracer.Start(ctx, "ResyncPayment-Service")
+	defer span.End()
+
+	payment, err := s.paymentStore.GetPaymentByID(ctx, id)
+	if err != nil {
+		return models.MaintenanceResult{}, err
+	}
+	result := models.MaintenanceResult{Examined: 1}
+	if payment.Status != models.PaymentStatusPending {
+		return result, nil
+	}
+	if payment.RazorpayOrderID == nil {
+		return result, errors.New("payment has no Razorpay order to resync")
+	}
+	orderID := *payment.RazorpayOrderID
+
+	attempts, err := s.fetchRazorpayOrderPayments(ctx, orderID)
+	if err != nil {
+		return result, err
+	}
+	attempt := settledAttempt(attempts)
+	if attempt == nil {
+		return result, nil
+	}
+
+	authorization, err := s.paymentStore.GetPaymentAuthorization(ctx, id)
+	if err != nil && !strings.Contains(err.Error(), "no payment authorization found") {
+		return result, err
+	}
+	manualCapture := err == nil
+
+	switch attempt.Status {
+	case "authorized":
+		// Razorpay captures auto-capture orders itself; the capture is picked up by a later resync
+		if !manualCapture {
+			return result, nil
+		}
+		req := &models.PaymentVerificationRequest{RazorpayOrderID: orderID, RazorpayPaymentID: attempt.ID}
+		if _, err := s.authorizePayment(ctx, payment, authorization, req); err != nil {
+			return result, err
+		}
+	case "captured":
+		if manualCapture && authorization.Status == models.PaymentAuthorizationPending {
+			if _, err := s.paymentStore.UpdatePaymentAuthorization(ctx, authorization.ID, models.PaymentAuthorizationPending,
+				models.PaymentAuthorizationCaptured, &attempt.ID, nil); err != nil {
+				return result, err
+			}
+		}
+		s.screenCapturedPayment(ctx, payment)
+		completed, err := s.paymentStore.UpdatePaymentStatus(ctx, id, models.PaymentStatusCompleted, &attempt.ID, nil)
+		if err != nil {
+			return result, err
+		}
+		s.recordAttemptOutcome(ctx, orderID, models.PaymentStatusCompleted, &attempt.ID, nil, nil)
+		s.statuses.Entered(ctx, completed, statemachine.Transition[models.PaymentStatus]{From: payment.Status, To: models.PaymentStatusCompleted})
+	case "failed":
+		failed, err := s.paymentStore.UpdatePaymentStatus(ctx, id, models.PaymentStatusFailed, &attempt.ID, nil)
+		if err != nil {
+			return result, err
+		}
+		s.recordAttemptOutcome(ctx, orderID, models.PaymentStatusFailed, &attempt.ID,
+			nonEmpty(attempt.ErrorCode), nonEmpty(attempt.ErrorDescription))
+		s.statuses.Entered(ctx, failed, statemachine.Transition[models.PaymentStatus]{From: payment.Status, To: models.PaymentStatusFailed})
+	}
+
+	log.Printf("Resynced payment %s from Razorpay attempt %s (%s)", id, attempt.ID, attempt.Status)
+	result.Changed = 1
+	return result, nil
+}
+
+// settledAttempt picks the attempt that decides an order's outcome: a captured attempt over an
+// authorized one, and either over a failure. Refunded attempts were settled through CarZone and
+// attempts still in progress decide nothing.
+func settledAttempt(attempts []models.RazorpayPaymentEntity) *models.RazorpayPaymentEntity {
+	rank := map[string]int{"failed": 1, "authorized": 2, "captured": 3}
+	var settled *models.RazorpayPaymentEntity
+	for i := range attempts {
+		if rank[attempts[i].Status] > 0 && (settled == nil || rank[attempts[i].Status] > rank[settled.Status]) {
+			settled = &attempts[i]
+		}
+	}
+	return settled
+}
+
+// fetchRazorpayOrderPayments lists the attempts made to pay a Razorpay order
+func (s *PaymentService) fetchRazorpayOrderPayments(ctx context.Context, orderID string) (_ []models.RazorpayPaymentEntity, err error) {
+	ctx, done := dependency.Start(ctx, dependency.Razorpay, "FetchOrderPayments")
+	defer func() { done(err) }()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", s.razorpayBaseURL+"/orders/"+orderID+"/payments", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(secrets.Get("RAZORPAY_KEY_ID"), secrets.Get("RAZORPAY_KEY_SECRET"))
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make Razorpay API request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var respBody bytes.Buffer
+		respBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("failed to fetch Razorpay order payments: status %d, response: %s", resp.StatusCode, respBody.String())
+	}
+
+	var payments models.RazorpayOrderPayments
+	if err := json.NewDecoder(resp.Body).Decode(&payments); err != nil {
+		return nil, fmt.Errorf("failed to decode Razorpay response: %v", err)
+	}
+	return payments.Items, nil
+}
+
+// recordAttemptOutcome stores the outcome of the attempt made on an order. The payment row
+// is already updated, so a failure here is logged rather than returned.
+func (s *PaymentService) recordAttemptOutcome(ctx context.Context, orderID string, status models.PaymentStatus, razorpayPaymentID, errorCode, errorDescription *string) {
+	if err := s.paymentStore.UpdatePaymentAttempt(ctx, orderID, status, razorpayPaymentID, errorCode, errorDescription); err != nil {
+		log.Printf("Failed to record %s attempt for order %s: %v", status, orderID, err)
+	}
+}
+
+// nonEmpty returns a pointer to the string, or nil when it is empty
+func nonEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// capturesAtPickup reports whether a Razorpay payment for a booking is held on the card and
+// captured at pickup. Bookings already under way pay at once, as do those starting after the
+// hold would lapse.
+func (s *PaymentService) capturesAtPickup(booking models.Booking) bool {
+	if !s.captureAtPickup {
+		return false
+	}
+	if booking.Status != models.BookingStatusPending && booking.Status != models.BookingStatusConfirmed {
+		return false
+	}
+	return time.Until(booking.StartDate) < s.authorizationTTL
+}
+
+// authorizePayment records a verified payment on a manual-capture order as held on the card
+func (s *PaymentService) authorizePayment(ctx context.Context, payment models.Payment, authorization models.PaymentAuthorization, req *models.PaymentVerificationRequest) (*models.Payment, error) {
+	expiresAt := time.Now().Add(s.authorizationTTL)
+	if _, err := s.paymentStore.UpdatePaymentAuthorization(ctx, authorization.ID, models.PaymentAuthorizationPending,
+		models.PaymentAuthorizationAuthorized, &req.RazorpayPaymentID, &expiresAt); err != nil {
+		return nil, err
+	}
+
+	authorized, err := s.paymentStore.UpdatePaymentStatus(ctx, payment.ID.String(),
+		models.PaymentStatusAuthorized, &req.RazorpayPaymentID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAttemptOutcome(ctx, req.RazorpayOrderID, models.PaymentStatusAuthorized, &req.RazorpayPaymentID, nil, nil)
+	s.statuses.Entered(ctx, authorized, statemachine.Transition[models.PaymentStatus]{From: payment.Status, To: models.PaymentStatusAuthorized})
+	return &authorized, nil
+}
+
+// CaptureAuthorizedPayments captures the payments held for a booking, at pickup. Captures that
+// Razorpay already made are recorded without capturing again.
+func (s *PaymentService) CaptureAuthorizedPayments(ctx context.Context, bookingID string) error {
+	tracer := otel.Tracer("PaymentService")
+	ctx, span := tracer.Start(ctx, "CaptureAuthorizedPayments-Service")
+	defer span.End()
+
+	authorizations, err := s.paymentStore.GetPaymentAuthorizationsByBookingID(ctx, bookingID, models.PaymentAuthorizationAuthorized)
+	if err != nil {
+		return err
+	}
+
+	for _, authorization := range authorizations {
+		if authorization.RazorpayPaymentID == nil {
+			return fmt.Errorf("payment %s has no Razorpay payment to capture", authorization.PaymentID)
+		}
+		if err := s.captureRazorpayPayment(ctx, *authorization.RazorpayPaymentID, authorization.Amount); err != nil {
+			return fmt.Errorf("payment %s could not be captured: %v", authorization.PaymentID, err)
+		}
+
+		if _, err := s.paymentStore.UpdatePaymentAuthorization(ctx, authorization.ID, models.PaymentAuthorizationAuthorized,
+			models.PaymentAuthorizationCaptured, nil, nil); err != nil {
+			return err
+		}
+		captured, err := s.paymentStore.UpdatePaymentStatus(ctx, authorization.PaymentID.String(),
+			models.PaymentStatusCompleted, authorization.RazorpayPaymentID, nil)
+		if err != nil {
+			return err
+		}
+		s.statuses.Entered(ctx, captured, statemachine.Transition[models.PaymentStatus]{From: models.PaymentStatusAuthorized, To: models.PaymentStatusCompleted})
+	}
+
+	return nil
+}
+
+// VoidAuthorizedPayments releases the payments held for a booking cancelled before pickup. The
+// payments are voided even when Razorpay cannot release a hold at once, since Razorpay refunds
+// payments left uncaptured when the capture window ends.
+func (s *PaymentService) VoidAuthorizedPayments(ctx context.Context, bookingID string) error {
+	tracer := otel.Tracer("PaymentService")
+	ctx, span := tracer.Start(ctx, "VoidAuthorizedPayments-Service")
+	defer span.End()
+
+	authorizations, err := s.paymentStore.GetPaymentAuthorizationsByBookingID(ctx, bookingID, models.PaymentAuthorizationAuthorized)
+	if err != nil {
+		return err
+	}
+
+	for _, authorization := range authorizations {
+		// Marked voided first so a concurrent pickup can no longer capture it
+		if _, err := s.paymentStore.UpdatePaymentAuthorization(ctx, authorization.ID, models.PaymentAuthorizationAuthorized,
+			models.PaymentAuthorizationVoided, nil, nil); err != nil {
+			return err
+		}
+		voided, err := s.paymentStore.UpdatePaymentStatus(ctx, authorization.PaymentID.String(),
+			models.PaymentStatusVoided, authorization.RazorpayPaymentID, nil)
+		if err != nil {
+			return err
+		}
+		s.statuses.Entered(ctx, voided, statemachine.Transition[models.PaymentStatus]{From: models.PaymentStatusAuthorized, To: models.PaymentStatusVoided})
+
+		if authorization.RazorpayPaymentID != nil {
+			if err := s.releaseRazorpayPayment(ctx, *authorization.RazorpayPaymentID, authorization.Amount); err != nil {
+				log.Printf("Failed to release hold of payment %s, Razorpay releases it at %v: %v",
+					authorization.PaymentID, authorization.ExpiresAt, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// captureRazorpayPayment captures an authorized Razorpay payment in full
+func (s *PaymentService) captureRazorpayPayment(ctx context.Context, razorpayPaymentID string, amount float64) error {
+	body, err := json.Marshal(map[string]interface{}{"amount": int(math.Round(amount * 100)), "currency": "INR"})
+	if err != nil {
+		return err
+	}
+
+	err = s.postRazorpayPayment(ctx, razorpayPaymentID, "capture", body)
+	if err != nil && strings.Contains(err.Error(), "already been captured") {
+		return nil // Captured by an earlier attempt whose result was not recorded
+	}
+	return err
+}
+
+// releaseRazorpayPayment refunds an authorized Razorpay payment, which releases its hold on the
+// customer's card without capturing it
+func (s *PaymentService) releaseRazorpayPayment(ctx context.Context, razorpayPaymentID string, amount float64) error {
+	body, err := json.Marshal(map[string]interface{}{"amount": int(math.Round(amount * 100)), "speed": "optimum"})
+	if err != nil {
+		return err
+	}
+
+	return s.postRazorpayPayment(ctx, razorpayPaymentID, "refund", body)
+}
+
+// postRazorpayPayment calls an action of the Razorpay payments API, e.g. capture
+func (s *PaymentService) postRazorpayPayment(ctx context.Context, razorpayPaymentID, action string, body []byte) (err error) {
+	ctx, done := dependency.Start(ctx, dependency.Razorpay, strings.ToUpper(action[:1])+action[1:]+"Payment")
+	defer func() { done(err) }()
+
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		s.razorpayBaseURL+"/payments/"+razorpayPaymentID+"/"+action, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(secrets.Get("RAZORPAY_KEY_ID"), secrets.Get("RAZORPAY_KEY_SECRET"))
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make Razorpay API request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var respBody bytes.Buffer
+		respBody.ReadFrom(resp.Body)
+		return fmt.Errorf("razorpay %s failed: status %d, response: %s", action, resp.StatusCode, respBody.String())
+	}
+
+	return nil
 }
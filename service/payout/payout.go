@@ -0,0 +1,331 @@
+package payout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/dependency"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/secrets"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// pennyDropAmount is the amount in paise credited to verify a bank account
+const pennyDropAmount = 100
+
+// PayoutService implements the PayoutServiceInterface for owner payout details
+type PayoutService struct {
+	payoutStore      store.PayoutStoreInterface
+	userStore        store.UserStoreInterface
+	razorpayXAccount string // RazorpayX business account number used for penny drops
+	razorpayBaseURL  string
+}
+
+// NewPayoutService creates a new payout service. RAZORPAY_API_URL (default
+// https://api.razorpay.com/v1) points Razorpay calls at another server.
+func NewPayoutService(payoutStore store.PayoutStoreInterface, userStore store.UserStoreInterface) *PayoutService {
+	razorpayBaseURL := strings.TrimSuffix(os.Getenv("RAZORPAY_API_URL"), "/")
+	if razorpayBaseURL == "" {
+		razorpayBaseURL = "https://api.razorpay.com/v1"
+	}
+	return &PayoutService{
+		payoutStore:      payoutStore,
+		userStore:        userStore,
+		razorpayXAccount: os.Getenv("RAZORPAYX_ACCOUNT_NUMBER"),
+		razorpayBaseURL:  razorpayBaseURL,
+	}
+}
+
+// RegisterPayoutAccount stores an owner's payout details and starts verification
+func (s *PayoutService) RegisterPayoutAccount(ctx context.Context, ownerID string, req models.PayoutAccountRequest) (*models.PayoutAccount, error) {
+	tracer := otel.Tracer("PayoutService")
+	ctx, span := tracer.Start(ctx, "RegisterPayoutAccount-Service")
+	defer span.End()
+
+	if err := models.ValidatePayoutAccountRequest(req); err != nil {
+		return nil, err
+	}
+
+	owner, err := s.userStore.GetUserByID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.payoutStore.CreatePayoutAccount(ctx, ownerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startVerification(ctx, owner, account)
+}
+
+// VerifyPayoutAccount refreshes the verification state of a payout account.
+// Pending accounts are checked against the gateway; accounts that never reached
+// the gateway (e.g. keys were missing) are submitted again.
+func (s *PayoutService) VerifyPayoutAccount(ctx context.Context, ownerID, id string) (*models.PayoutAccount, error) {
+	tracer := otel.Tracer("PayoutService")
+	ctx, span := tracer.Start(ctx, "VerifyPayoutAccount-Service")
+	defer span.End()
+
+	account, err := s.getOwnedAccount(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Status == models.PayoutAccountStatusVerified {
+		return account, nil
+	}
+
+	if account.RazorpayValidationID == nil {
+		owner, err := s.userStore.GetUserByID(ctx, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		return s.startVerification(ctx, owner, *account)
+	}
+
+	var validation models.RazorpayValidationResponse
+	if err := s.razorpayRequest(ctx, "FetchFundAccountValidation", http.MethodGet, "/fund_accounts/validations/"+*account.RazorpayValidationID, nil, &validation); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.payoutStore.UpdatePayoutAccountVerification(ctx, account.ID.String(), validationUpdate(validation))
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// GetPayoutAccounts retrieves all payout accounts of an owner
+func (s *PayoutService) GetPayoutAccounts(ctx context.Context, ownerID string) (*[]models.PayoutAccount, error) {
+	tracer := otel.Tracer("PayoutService")
+	ctx, span := tracer.Start(ctx, "GetPayoutAccounts-Service")
+	defer span.End()
+
+	accounts, err := s.payoutStore.GetPayoutAccountsByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if accounts == nil {
+		accounts = []models.PayoutAccount{}
+	}
+
+	return &accounts, nil
+}
+
+// DeletePayoutAccount removes one of the owner's payout accounts
+func (s *PayoutService) DeletePayoutAccount(ctx context.Context, ownerID, id string) error {
+	tracer := otel.Tracer("PayoutService")
+	ctx, span := tracer.Start(ctx, "DeletePayoutAccount-Service")
+	defer span.End()
+
+	if _, err := s.getOwnedAccount(ctx, ownerID, id); err != nil {
+		return err
+	}
+
+	return s.payoutStore.DeletePayoutAccount(ctx, id)
+}
+
+// GetVerifiedPayoutAccount returns the most recently registered verified account of an owner.
+// This is the fund account the payout subsystem credits owner earnings to, so it is withheld
+// while a dispute on one of the owner's bookings holds their payouts.
+func (s *PayoutService) GetVerifiedPayoutAccount(ctx context.Context, ownerID string) (*models.PayoutAccount, error) {
+	tracer := otel.Tracer("PayoutService")
+	ctx, span := tracer.Start(ctx, "GetVerifiedPayoutAccount-Service")
+	defer span.End()
+
+	holds, err := s.payoutStore.GetPayoutHoldsByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, hold := range holds {
+		if hold.ReleasedAt == nil {
+			return nil, errors.New("payouts are on hold while a dispute is open")
+		}
+	}
+
+	accounts, err := s.payoutStore.GetPayoutAccountsByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range accounts {
+		if account.Status == models.PayoutAccountStatusVerified && account.RazorpayFundAccountID != nil {
+			return &account, nil
+		}
+	}
+
+	return nil, errors.New("owner has no verified payout account")
+}
+
+// GetPayoutHolds retrieves the holds disputes placed on an owner's payouts, active ones first
+func (s *PayoutService) GetPayoutHolds(ctx context.Context, ownerID string) ([]models.PayoutHold, error) {
+	tracer := otel.Tracer("PayoutService")
+	ctx, span := tracer.Start(ctx, "GetPayoutHolds-Service")
+	defer span.End()
+
+	return s.payoutStore.GetPayoutHoldsByOwnerID(ctx, ownerID)
+}
+
+// getOwnedAccount loads a payout account and checks it belongs to the owner
+func (s *PayoutService) getOwnedAccount(ctx context.Context, ownerID, id string) (*models.PayoutAccount, error) {
+	account, err := s.payoutStore.GetPayoutAccountByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account.OwnerID.String() != ownerID {
+		// Report foreign accounts as missing so IDs cannot be probed
+		return nil, errors.New("no payout account found with the given ID")
+	}
+	return &account, nil
+}
+
+// startVerification registers the owner as a RazorpayX contact, creates a fund account
+// and requests a penny-drop validation. Without gateway credentials the account stays pending.
+func (s *PayoutService) startVerification(ctx context.Context, owner models.User, account models.PayoutAccount) (*models.PayoutAccount, error) {
+	if secrets.Get("RAZORPAY_KEY_ID") == "" || secrets.Get("RAZORPAY_KEY_SECRET") == "" || s.razorpayXAccount == "" {
+		log.Printf("Razorpay payout credentials not configured; payout account %s left pending", account.ID)
+		return &account, nil
+	}
+
+	update := models.PayoutVerificationUpdate{Status: models.PayoutAccountStatusPending}
+
+	contactID := account.RazorpayContactID
+	if contactID == nil {
+		var contact models.RazorpayEntity
+		err := s.razorpayRequest(ctx, "CreateContact", http.MethodPost, "/contacts", models.RazorpayContactRequest{
+			Name:        account.AccountHolderName,
+			Email:       owner.Email,
+			Contact:     owner.Phone,
+			Type:        "vendor",
+			ReferenceID: owner.ID.String(),
+		}, &contact)
+		if err != nil {
+			return nil, err
+		}
+		contactID = &contact.ID
+		update.RazorpayContactID = contactID
+	}
+
+	fundAccountID := account.RazorpayFundAccountID
+	if fundAccountID == nil {
+		fundReq := models.RazorpayFundAccountRequest{ContactID: *contactID, AccountType: string(account.AccountType)}
+		if account.AccountType == models.PayoutAccountTypeBank {
+			fundReq.BankAccount = &models.RazorpayBankAccount{
+				Name:          account.AccountHolderName,
+				IFSC:          account.IFSC,
+				AccountNumber: account.AccountNumber,
+			}
+		} else {
+			fundReq.VPA = &models.RazorpayVPA{Address: account.VPA}
+		}
+
+		var fundAccount models.RazorpayEntity
+		if err := s.razorpayRequest(ctx, "CreateFundAccount", http.MethodPost, "/fund_accounts", fundReq, &fundAccount); err != nil {
+			return nil, err
+		}
+		fundAccountID = &fundAccount.ID
+		update.RazorpayFundAccountID = fundAccountID
+	}
+
+	var validation models.RazorpayValidationResponse
+	err := s.razorpayRequest(ctx, "CreateFundAccountValidation", http.MethodPost, "/fund_accounts/validations", models.RazorpayValidationRequest{
+		AccountNumber: s.razorpayXAccount,
+		FundAccount:   models.RazorpayEntity{ID: *fundAccountID},
+		Amount:        pennyDropAmount,
+		Currency:      "INR",
+	}, &validation)
+	if err != nil {
+		return nil, err
+	}
+
+	result := validationUpdate(validation)
+	update.RazorpayValidationID = result.RazorpayValidationID
+	update.RegisteredName = result.RegisteredName
+	update.FailureReason = result.FailureReason
+	update.Status = result.Status
+
+	updated, err := s.payoutStore.UpdatePayoutAccountVerification(ctx, account.ID.String(), update)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// validationUpdate maps a RazorpayX validation result onto a verification update
+func validationUpdate(validation models.RazorpayValidationResponse) models.PayoutVerificationUpdate {
+	update := models.PayoutVerificationUpdate{
+		RazorpayValidationID: &validation.ID,
+		Status:               models.PayoutAccountStatusPending,
+	}
+
+	switch validation.Status {
+	case "completed":
+		if validation.Results.AccountStatus == "active" {
+			update.Status = models.PayoutAccountStatusVerified
+			if validation.Results.RegisteredName != "" {
+				update.RegisteredName = &validation.Results.RegisteredName
+			}
+		} else {
+			reason := "account reported as " + validation.Results.AccountStatus
+			update.Status = models.PayoutAccountStatusFailed
+			update.FailureReason = &reason
+		}
+	case "failed":
+		reason := "validation failed at payment gateway"
+		update.Status = models.PayoutAccountStatusFailed
+		update.FailureReason = &reason
+	}
+
+	return update
+}
+
+// razorpayRequest calls the Razorpay API and decodes the JSON response into out. operation
+// names the call in metrics and traces, as the path may hold IDs.
+func (s *PayoutService) razorpayRequest(ctx context.Context, operation, method, path string, body interface{}, out interface{}) (err error) {
+	ctx, done := dependency.Start(ctx, dependency.Razorpay, operation)
+	defer func() { done(err) }()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.razorpayBaseURL+path, &payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(secrets.Get("RAZORPAY_KEY_ID"), secrets.Get("RAZORPAY_KEY_SECRET"))
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make Razorpay API request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		var respBody bytes.Buffer
+		respBody.ReadFrom(resp.Body)
+		return fmt.Errorf("razorpay request %s %s failed: status %d, response: %s", method, path, resp.StatusCode, respBody.String())
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Razorpay response: %v", err)
+	}
+
+	return nil
+}
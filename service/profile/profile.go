@@ -0,0 +1,68 @@
+package profile
+
+import (
+	"context"
+	"errors"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// maxProfileListings bounds the active listings shown on a profile; the newest are shown
+const maxProfileListings = 20
+
+// ProfileService implements the ProfileServiceInterface. Profiles only show owners, and only
+// the fields PublicProfile whitelists.
+type ProfileService struct {
+	userStore store.UserStoreInterface
+	carStore  store.CarStoreInterface
+}
+
+// NewProfileService creates a new profile service
+func NewProfileService(userStore store.UserStoreInterface, carStore store.CarStoreInterface) *ProfileService {
+	return &ProfileService{
+		userStore: userStore,
+		carStore:  carStore,
+	}
+}
+
+// GetPublicProfile builds an owner's public profile from their account, booking history and
+// active cars
+func (s *ProfileService) GetPublicProfile(ctx context.Context, ownerID string) (*models.PublicProfile, error) {
+	tracer := otel.Tracer("ProfileService")
+	ctx, span := tracer.Start(ctx, "GetPublicProfile-Service")
+	defer span.End()
+
+	id, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	profile, err := s.userStore.GetPublicProfile(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.carStore.GetOwnerStats(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	stats.Apply(&profile)
+
+	cars, err := s.carStore.ListCars(ctx, models.CarFilter{Status: models.CarStatusActive, OwnerID: &id},
+		models.PageRequest{Limit: maxProfileListings})
+	if err != nil {
+		return nil, err
+	}
+	profile.ActiveListings = make([]models.PublicCar, 0, len(cars))
+	for i, car := range cars {
+		if i == maxProfileListings {
+			break
+		}
+		profile.ActiveListings = append(profile.ActiveListings, car.Public())
+	}
+
+	return &profile, nil
+}
@@ -0,0 +1,157 @@
+// Package refreshtoken keeps sessions going past the short life of access tokens. Login hands
+// out a refresh token next to the access token; exchanging it at /auth/refresh returns a new
+// access token and a new refresh token, and the old one stops working. A refresh token that is
+// presented again after it was exchanged has been copied, so the whole session is ended.
+package refreshtoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// RefreshTokenService implements the RefreshTokenServiceInterface
+type RefreshTokenService struct {
+	refreshTokenStore store.RefreshTokenStoreInterface
+	userStore         store.UserStoreInterface
+	ttl               time.Duration // How long a refresh token works if it is not exchanged
+}
+
+// NewRefreshTokenService creates a new refresh token service. REFRESH_TOKEN_TTL (default 720h)
+// is how long a refresh token works; a session unused for that long has to log in again.
+func NewRefreshTokenService(refreshTokenStore store.RefreshTokenStoreInterface, userStore store.UserStoreInterface) *RefreshTokenService {
+	ttl, err := time.ParseDuration(os.Getenv("REFRESH_TOKEN_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = 30 * 24 * time.Hour
+	}
+	return &RefreshTokenService{
+		refreshTokenStore: refreshTokenStore,
+		userStore:         userStore,
+		ttl:               ttl,
+	}
+}
+
+// IssueRefreshToken starts a session for a user who just logged in or registered
+func (s *RefreshTokenService) IssueRefreshToken(ctx context.Context, user models.User) (models.IssuedRefreshToken, error) {
+	tracer := otel.Tracer("RefreshTokenService")
+	ctx, span := tracer.Start(ctx, "IssueRefreshToken-Service")
+	defer span.End()
+
+	token, err := newToken()
+	if err != nil {
+		return models.IssuedRefreshToken{}, err
+	}
+
+	stored, err := s.refreshTokenStore.CreateRefreshToken(ctx, user.ID, hashToken(token), time.Now().Add(s.ttl))
+	if err != nil {
+		return models.IssuedRefreshToken{}, err
+	}
+
+	return models.IssuedRefreshToken{Token: token, ExpiresAt: stored.ExpiresAt}, nil
+}
+
+// RotateRefreshToken exchanges a refresh token for its successor and returns the user to issue
+// a new access token for. The user is read afresh, so a changed role takes effect and a deleted
+// account cannot refresh.
+func (s *RefreshTokenService) RotateRefreshToken(ctx context.Context, token string) (models.User, models.IssuedRefreshToken, error) {
+	tracer := otel.Tracer("RefreshTokenService")
+	ctx, span := tracer.Start(ctx, "RotateRefreshToken-Service")
+	defer span.End()
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return models.User{}, models.IssuedRefreshToken{}, errors.New("refresh token is required")
+	}
+
+	stored, err := s.refreshTokenStore.GetRefreshToken(ctx, hashToken(token))
+	if err != nil {
+		return models.User{}, models.IssuedRefreshToken{}, err
+	}
+	if stored.RevokedAt != nil || !time.Now().Before(stored.ExpiresAt) {
+		return models.User{}, models.IssuedRefreshToken{}, errors.New("refresh token is invalid or has expired")
+	}
+	if stored.RotatedAt != nil {
+		return models.User{}, models.IssuedRefreshToken{}, s.endReusedSession(ctx, stored)
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, stored.UserID.String())
+	if err != nil {
+		if strings.Contains(err.Error(), "user not found") {
+			return models.User{}, models.IssuedRefreshToken{}, errors.New("refresh token is invalid or has expired")
+		}
+		return models.User{}, models.IssuedRefreshToken{}, err
+	}
+
+	next, err := newToken()
+	if err != nil {
+		return models.User{}, models.IssuedRefreshToken{}, err
+	}
+	rotated, err := s.refreshTokenStore.RotateRefreshToken(ctx, stored.ID, hashToken(next), time.Now().Add(s.ttl))
+	if err != nil {
+		// Another request exchanged the token between the read and the rotation
+		if strings.Contains(err.Error(), "already used") {
+			return models.User{}, models.IssuedRefreshToken{}, s.endReusedSession(ctx, stored)
+		}
+		return models.User{}, models.IssuedRefreshToken{}, err
+	}
+
+	return user, models.IssuedRefreshToken{Token: next, ExpiresAt: rotated.ExpiresAt}, nil
+}
+
+// RevokeRefreshToken ends the session a refresh token belongs to, at logout. Unknown tokens
+// are ignored, so logging out twice is harmless.
+func (s *RefreshTokenService) RevokeRefreshToken(ctx context.Context, token string) error {
+	tracer := otel.Tracer("RefreshTokenService")
+	ctx, span := tracer.Start(ctx, "RevokeRefreshToken-Service")
+	defer span.End()
+
+	if strings.TrimSpace(token) == "" {
+		return nil
+	}
+
+	stored, err := s.refreshTokenStore.GetRefreshToken(ctx, hashToken(strings.TrimSpace(token)))
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid or has expired") {
+			return nil
+		}
+		return err
+	}
+
+	return s.refreshTokenStore.RevokeRefreshTokenFamily(ctx, stored.FamilyID)
+}
+
+// endReusedSession revokes the session of a refresh token presented after it was exchanged.
+// Either the client or whoever copied the token is refused from then on; both log in again.
+func (s *RefreshTokenService) endReusedSession(ctx context.Context, stored models.RefreshToken) error {
+	log.Printf("Refresh token of user %s reused after rotation; ending session %s", stored.UserID, stored.FamilyID)
+	if err := s.refreshTokenStore.RevokeRefreshTokenFamily(ctx, stored.FamilyID); err != nil {
+		return err
+	}
+	return errors.New("refresh token was already used, please log in again")
+}
+
+// newToken returns 32 random bytes, base64url-encoded
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hex SHA-256 of a token, which is what the store keeps
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+package risk
+
+import (
+	"context"
+	"log"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// Rule is one pluggable risk check. A rule returns a zero-score signal when it finds nothing;
+// a rule error is logged and the rule skipped, so one broken check never blocks bookings.
+type Rule interface {
+	// Name identifies the rule in assessments and logs
+	Name() string
+
+	// Evaluate scores one booking attempt
+	Evaluate(ctx context.Context, subject models.RiskSubject) (models.RiskSignal, error)
+}
+
+// RiskService implements the RiskScorerInterface by summing the scores of its rules
+type RiskService struct {
+	userStore       store.UserStoreInterface
+	rules           []Rule
+	reviewThreshold int
+}
+
+// NewRiskService creates a risk scorer. Bookings scoring reviewThreshold or more are held for manual review.
+func NewRiskService(userStore store.UserStoreInterface, reviewThreshold int, rules ...Rule) *RiskService {
+	return &RiskService{
+		userStore:       userStore,
+		rules:           rules,
+		reviewThreshold: reviewThreshold,
+	}
+}
+
+// ReviewThresholdFromEnv reads RISK_REVIEW_THRESHOLD, defaulting to 50
+func ReviewThresholdFromEnv() int {
+	return intFromEnv("RISK_REVIEW_THRESHOLD", 50)
+}
+
+// AssessBooking runs every rule against the booking and its customer
+func (s *RiskService) AssessBooking(ctx context.Context, booking models.Booking, stage models.RiskStage) (models.RiskAssessment, error) {
+	tracer := otel.Tracer("RiskService")
+	ctx, span := tracer.Start(ctx, "AssessBooking-Service")
+	defer span.End()
+
+	assessment := models.RiskAssessment{Stage: stage, Threshold: s.reviewThreshold}
+
+	customer, err := s.userStore.GetUserByID(ctx, booking.CustomerID.String())
+	if err != nil {
+		return assessment, err
+	}
+
+	subject := models.RiskSubject{
+		Stage:     stage,
+		Booking:   booking,
+		Customer:  customer,
+		IPAddress: middleware.ClientIPFromContext(ctx),
+		Country:   middleware.CountryFromContext(ctx),
+	}
+
+	for _, rule := range s.rules {
+		signal, err := rule.Evaluate(ctx, subject)
+		if err != nil {
+			log.Printf("Risk rule %s failed for booking %s: %v", rule.Name(), booking.ID, err)
+			continue
+		}
+		if signal.Score <= 0 {
+			continue
+		}
+		signal.Rule = rule.Name()
+		assessment.Signals = append(assessment.Signals, signal)
+		assessment.Score += signal.Score
+	}
+
+	assessment.RequiresReview = assessment.Score >= s.reviewThreshold
+	return assessment, nil
+}
@@ -0,0 +1,180 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+)
+
+// defaultDisposableEmailDomains are throwaway inbox providers commonly used to create
+// accounts that cannot be traced. RISK_DISPOSABLE_EMAIL_DOMAINS extends the list.
+var defaultDisposableEmailDomains = []string{
+	"10minutemail.com", "guerrillamail.com", "mailinator.com", "maildrop.cc", "sharklasers.com",
+	"temp-mail.org", "tempmail.com", "throwawaymail.com", "trashmail.com", "yopmail.com",
+	"getnada.com", "dispostable.com", "fakeinbox.com", "mintemail.com", "mohmal.com",
+}
+
+// RulesFromEnv builds the default rule set, configured through RISK_* environment variables
+func RulesFromEnv(securityStore store.SecurityStoreInterface) []Rule {
+	disposable := append([]string{}, defaultDisposableEmailDomains...)
+	disposable = append(disposable, splitList(os.Getenv("RISK_DISPOSABLE_EMAIL_DOMAINS"))...)
+
+	expectedCountries := splitList(os.Getenv("RISK_EXPECTED_COUNTRIES"))
+	if len(expectedCountries) == 0 {
+		expectedCountries = []string{"IN"} // Payments are INR only
+	}
+
+	return []Rule{
+		NewVelocityRule(securityStore,
+			intFromEnv("RISK_VELOCITY_MAX_BOOKINGS", 3),
+			durationFromEnv("RISK_VELOCITY_WINDOW", time.Hour),
+			intFromEnv("RISK_VELOCITY_SCORE", 40)),
+		NewGeoMismatchRule(expectedCountries, intFromEnv("RISK_GEO_MISMATCH_SCORE", 30)),
+		NewDisposableEmailRule(disposable, intFromEnv("RISK_DISPOSABLE_EMAIL_SCORE", 50)),
+	}
+}
+
+// VelocityRule flags customers creating many bookings in a short window
+type VelocityRule struct {
+	securityStore store.SecurityStoreInterface
+	maxBookings   int
+	window        time.Duration
+	score         int
+}
+
+// NewVelocityRule creates a rule scoring customers with more than maxBookings bookings within window
+func NewVelocityRule(securityStore store.SecurityStoreInterface, maxBookings int, window time.Duration, score int) *VelocityRule {
+	return &VelocityRule{securityStore: securityStore, maxBookings: maxBookings, window: window, score: score}
+}
+
+// Name identifies the rule
+func (r *VelocityRule) Name() string {
+	return "booking_velocity"
+}
+
+// Evaluate counts the customer's recent bookings
+func (r *VelocityRule) Evaluate(ctx context.Context, subject models.RiskSubject) (models.RiskSignal, error) {
+	count, err := r.securityStore.CountBookingsCreatedSince(ctx, subject.Customer.ID.String(), time.Now().Add(-r.window))
+	if err != nil {
+		return models.RiskSignal{}, err
+	}
+	if count <= r.maxBookings {
+		return models.RiskSignal{}, nil
+	}
+	return models.RiskSignal{
+		Score:  r.score,
+		Reason: fmt.Sprintf("%d bookings created in the last %s", count, r.window),
+	}, nil
+}
+
+// GeoMismatchRule flags requests coming from outside the countries the platform serves
+type GeoMismatchRule struct {
+	expected map[string]bool
+	score    int
+}
+
+// NewGeoMismatchRule creates a rule scoring requests whose country is not in expectedCountries
+func NewGeoMismatchRule(expectedCountries []string, score int) *GeoMismatchRule {
+	expected := make(map[string]bool, len(expectedCountries))
+	for _, country := range expectedCountries {
+		expected[strings.ToUpper(country)] = true
+	}
+	return &GeoMismatchRule{expected: expected, score: score}
+}
+
+// Name identifies the rule
+func (r *GeoMismatchRule) Name() string {
+	return "geo_mismatch"
+}
+
+// Evaluate compares the request country with the expected countries.
+// Requests without a known country (no geo header configured) are not scored.
+func (r *GeoMismatchRule) Evaluate(ctx context.Context, subject models.RiskSubject) (models.RiskSignal, error) {
+	if subject.Country == "" || r.expected[subject.Country] {
+		return models.RiskSignal{}, nil
+	}
+	return models.RiskSignal{
+		Score:  r.score,
+		Reason: fmt.Sprintf("request from %s, outside the served countries", subject.Country),
+	}, nil
+}
+
+// DisposableEmailRule flags customers registered with a throwaway e-mail address
+type DisposableEmailRule struct {
+	domains map[string]bool
+	score   int
+}
+
+// NewDisposableEmailRule creates a rule scoring customers whose e-mail domain is in domains
+func NewDisposableEmailRule(domains []string, score int) *DisposableEmailRule {
+	set := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		set[strings.ToLower(domain)] = true
+	}
+	return &DisposableEmailRule{domains: set, score: score}
+}
+
+// Name identifies the rule
+func (r *DisposableEmailRule) Name() string {
+	return "disposable_email"
+}
+
+// Evaluate checks the customer's e-mail domain, including subdomains of listed providers
+func (r *DisposableEmailRule) Evaluate(ctx context.Context, subject models.RiskSubject) (models.RiskSignal, error) {
+	at := strings.LastIndex(subject.Customer.Email, "@")
+	if at < 0 {
+		return models.RiskSignal{}, nil
+	}
+
+	domain := strings.ToLower(subject.Customer.Email[at+1:])
+	for candidate := domain; candidate != ""; {
+		if r.domains[candidate] {
+			return models.RiskSignal{
+				Score:  r.score,
+				Reason: "disposable e-mail domain " + domain,
+			}, nil
+		}
+		_, parent, found := strings.Cut(candidate, ".")
+		if !found {
+			break
+		}
+		candidate = parent
+	}
+
+	return models.RiskSignal{}, nil
+}
+
+// splitList splits a comma-separated setting into trimmed, non-empty values
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// intFromEnv parses a positive integer setting, returning fallback when unset or invalid
+func intFromEnv(name string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// durationFromEnv parses a positive duration setting, returning fallback when unset or invalid
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/PrateekKumar15/CarZone/dependency"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -52,11 +53,13 @@ func (s *S3Service) UploadBase64Image(ctx context.Context, base64Data, fileName
 	uniqueName := fmt.Sprintf("cars/%s-%d.jpg", uuid.New().String(), time.Now().Unix())
 
 	// Upload to S3
-	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+	callCtx, done := dependency.Start(ctx, dependency.S3, "PutObject")
+	_, err = s.client.PutObject(callCtx, &s3.PutObjectInput{
 		Bucket: aws.String(s.bucketName),
 		Key:    aws.String(uniqueName),
 		Body:   bytes.NewReader(imageData),
 	})
+	done(err)
 	if err != nil {
 		return "", err
 	}
@@ -76,10 +79,25 @@ func (s *S3Service) DeleteImage(ctx context.Context, imageURL string) error {
 
 	key := strings.Join(parts[3:], "/")
 
-	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+	callCtx, done := dependency.Start(ctx, dependency.S3, "DeleteObject")
+	_, err := s.client.DeleteObject(callCtx, &s3.DeleteObjectInput{
 		Bucket: aws.String(s.bucketName),
 		Key:    aws.String(key),
 	})
+	done(err)
 
 	return err
 }
+
+// PutObject uploads a document under the given key
+func (s *S3Service) PutObject(ctx context.Context, key string, body []byte, contentType string) error {
+	callCtx, done := dependency.Start(ctx, dependency.S3, "PutObject")
+	_, err := s.client.PutObject(callCtx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String(contentType),
+	})
+	done(err)
+	return err
+}
@@ -0,0 +1,75 @@
+// Package scheduler runs periodic background jobs such as cache refreshes and cleanups.
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// Job is a unit of background work executed on a fixed interval
+type Job struct {
+	Name     string                          // Human-readable name used in logs and traces
+	Interval time.Duration                   // Time between the end of one run and the start of the next
+	Run      func(ctx context.Context) error // Work to perform; errors are logged and the job keeps running
+}
+
+// Scheduler owns a set of jobs and runs each one in its own goroutine
+type Scheduler struct {
+	jobs []Job
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates an empty scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Register adds a job; it must be called before Start
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every registered job once immediately and then on its interval
+// until ctx is cancelled. It returns without waiting for the jobs.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Wait blocks until every job goroutine has exited after ctx cancellation
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// loop executes a single job until the context is cancelled
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(job.Interval):
+		}
+	}
+}
+
+// runOnce executes one run of a job inside its own trace span
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	tracer := otel.Tracer("Scheduler")
+	ctx, span := tracer.Start(ctx, job.Name+"-Job")
+	defer span.End()
+
+	if err := job.Run(ctx); err != nil {
+		log.Printf("Scheduled job %s failed: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,57 @@
+package search
+
+import (
+	"context"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// SearchService implements the SearchServiceInterface for support staff
+type SearchService struct {
+	searchStore store.SearchStoreInterface
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(searchStore store.SearchStoreInterface) *SearchService {
+	return &SearchService{searchStore: searchStore}
+}
+
+// Search interprets the term, runs every applicable lookup and links each hit to its record
+func (s *SearchService) Search(ctx context.Context, q string) ([]models.SearchHit, error) {
+	tracer := otel.Tracer("SearchService")
+	ctx, span := tracer.Start(ctx, "Search-Service")
+	defer span.End()
+
+	query, err := models.ParseSearchQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := s.searchStore.Search(ctx, query, models.MaxSearchHits)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range hits {
+		hits[i].Link = hitLink(hits[i])
+	}
+	return hits, nil
+}
+
+// hitLink returns the API path of a hit's record. Users have no detail endpoint, so they link
+// to their bookings.
+func hitLink(hit models.SearchHit) string {
+	switch hit.Type {
+	case models.SearchHitUser:
+		return "/bookings/customer/" + hit.ID.String()
+	case models.SearchHitCar:
+		return "/cars/" + hit.ID.String()
+	case models.SearchHitBooking:
+		return "/bookings/" + hit.ID.String()
+	case models.SearchHitPayment:
+		return "/payments/" + hit.ID.String()
+	}
+	return ""
+}
@@ -0,0 +1,278 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/middleware"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// Thresholds holds the velocity limits above which activity is reported as suspicious
+type Thresholds struct {
+	PaymentFailures      int // Failed payments tolerated within PaymentFailureWindow
+	PaymentFailureWindow time.Duration
+	Cancellations        int // Cancelled bookings tolerated within CancellationWindow
+	CancellationWindow   time.Duration
+}
+
+// ThresholdsFromEnv reads SECURITY_* velocity limits, falling back to conservative defaults
+func ThresholdsFromEnv() Thresholds {
+	return Thresholds{
+		PaymentFailures:      intFromEnv("SECURITY_PAYMENT_FAILURE_THRESHOLD", 3),
+		PaymentFailureWindow: durationFromEnv("SECURITY_PAYMENT_FAILURE_WINDOW", time.Hour),
+		Cancellations:        intFromEnv("SECURITY_CANCELLATION_THRESHOLD", 3),
+		CancellationWindow:   durationFromEnv("SECURITY_CANCELLATION_WINDOW", 24*time.Hour),
+	}
+}
+
+// SecurityService implements the SecurityServiceInterface. It turns login, payment and
+// booking activity into security events and notifies the affected user.
+type SecurityService struct {
+	securityStore       store.SecurityStoreInterface
+	userStore           store.UserStoreInterface
+	notificationService service.NotificationServiceInterface
+	thresholds          Thresholds
+}
+
+// NewSecurityService creates a new security service
+func NewSecurityService(securityStore store.SecurityStoreInterface, userStore store.UserStoreInterface, notificationService service.NotificationServiceInterface, thresholds Thresholds) *SecurityService {
+	return &SecurityService{
+		securityStore:       securityStore,
+		userStore:           userStore,
+		notificationService: notificationService,
+		thresholds:          thresholds,
+	}
+}
+
+// RecordLogin remembers the device of a successful login and raises an event when it is new.
+// Detection problems are logged and never fail the login itself.
+func (s *SecurityService) RecordLogin(ctx context.Context, user models.User, ipAddress, userAgent string) {
+	tracer := otel.Tracer("SecurityService")
+	ctx, span := tracer.Start(ctx, "RecordLogin-Service")
+	defer span.End()
+
+	isNewDevice, isFirstLogin, err := s.securityStore.RecordLogin(ctx, user.ID.String(), ipAddress, userAgent)
+	if err != nil {
+		log.Printf("Failed to record login device for user %s: %v", user.ID, err)
+		return
+	}
+	// The first login of an account establishes its baseline, so there is nothing to compare against
+	if !isNewDevice || isFirstLogin {
+		return
+	}
+
+	s.raise(ctx, user, models.SecurityEvent{
+		UserID:    user.ID,
+		Type:      models.SecurityEventNewLoginLocation,
+		Severity:  models.SecurityEventSeverityLow,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	}, "New sign-in to your CarZone account",
+		fmt.Sprintf("Your account was just signed in to from a new device or location (IP address %s). If this was not you, change your password immediately and contact support.", ipAddress))
+}
+
+// RecordPaymentFailure raises an event when a customer fails too many payments in a short window,
+// a common sign of card testing or a compromised account.
+func (s *SecurityService) RecordPaymentFailure(ctx context.Context, customerID string) {
+	tracer := otel.Tracer("SecurityService")
+	ctx, span := tracer.Start(ctx, "RecordPaymentFailure-Service")
+	defer span.End()
+
+	since := time.Now().Add(-s.thresholds.PaymentFailureWindow)
+	failures, err := s.securityStore.CountFailedPaymentsSince(ctx, customerID, since)
+	if err != nil {
+		log.Printf("Failed to count failed payments for user %s: %v", customerID, err)
+		return
+	}
+	if failures < s.thresholds.PaymentFailures {
+		return
+	}
+
+	s.raiseForUser(ctx, customerID, since, models.SecurityEvent{
+		Type:     models.SecurityEventRepeatedPaymentFailures,
+		Severity: models.SecurityEventSeverityHigh,
+		Details: map[string]interface{}{
+			"failed_payments": failures,
+			"window":          s.thresholds.PaymentFailureWindow.String(),
+		},
+	}, "Several payments on your CarZone account failed",
+		fmt.Sprintf("We noticed %d failed payment attempts on your account in the last %s. If you did not make these attempts, please contact support.", failures, s.thresholds.PaymentFailureWindow))
+}
+
+// RecordBookingCancellation raises an event when a customer books and cancels repeatedly,
+// which can indicate inventory blocking or testing of stolen payment methods.
+func (s *SecurityService) RecordBookingCancellation(ctx context.Context, customerID string) {
+	tracer := otel.Tracer("SecurityService")
+	ctx, span := tracer.Start(ctx, "RecordBookingCancellation-Service")
+	defer span.End()
+
+	since := time.Now().Add(-s.thresholds.CancellationWindow)
+	cancellations, err := s.securityStore.CountCancelledBookingsSince(ctx, customerID, since)
+	if err != nil {
+		log.Printf("Failed to count cancelled bookings for user %s: %v", customerID, err)
+		return
+	}
+	if cancellations < s.thresholds.Cancellations {
+		return
+	}
+
+	s.raiseForUser(ctx, customerID, since, models.SecurityEvent{
+		Type:     models.SecurityEventRapidBookingCancellations,
+		Severity: models.SecurityEventSeverityMedium,
+		Details: map[string]interface{}{
+			"cancelled_bookings": cancellations,
+			"window":             s.thresholds.CancellationWindow.String(),
+		},
+	}, "Unusual booking activity on your CarZone account",
+		fmt.Sprintf("%d bookings on your account were cancelled in the last %s. If you did not cancel them, please contact support.", cancellations, s.thresholds.CancellationWindow))
+}
+
+// RecordHighRiskBooking puts a booking held by risk scoring into the review queue
+// and tells the customer their booking is being checked.
+func (s *SecurityService) RecordHighRiskBooking(ctx context.Context, booking models.Booking, assessment models.RiskAssessment) {
+	tracer := otel.Tracer("SecurityService")
+	ctx, span := tracer.Start(ctx, "RecordHighRiskBooking-Service")
+	defer span.End()
+
+	user, err := s.userStore.GetUserByID(ctx, booking.CustomerID.String())
+	if err != nil {
+		log.Printf("Failed to load user %s for security event: %v", booking.CustomerID, err)
+		return
+	}
+
+	signals := make([]map[string]interface{}, 0, len(assessment.Signals))
+	for _, signal := range assessment.Signals {
+		signals = append(signals, map[string]interface{}{"rule": signal.Rule, "score": signal.Score, "reason": signal.Reason})
+	}
+
+	s.raise(ctx, user, models.SecurityEvent{
+		UserID:    user.ID,
+		Type:      models.SecurityEventHighRiskBooking,
+		Severity:  models.SecurityEventSeverityHigh,
+		IPAddress: middleware.ClientIPFromContext(ctx),
+		Details: map[string]interface{}{
+			"booking_id": booking.ID.String(),
+			"stage":      string(assessment.Stage),
+			"score":      assessment.Score,
+			"threshold":  assessment.Threshold,
+			"signals":    signals,
+		},
+	}, "Your CarZone booking is being reviewed",
+		"Your booking is being reviewed by our team before it is confirmed. This usually takes a few hours; we will update you as soon as it is done.")
+}
+
+// ListSecurityEvents retrieves one page of the admin review queue
+func (s *SecurityService) ListSecurityEvents(ctx context.Context, filter models.SecurityEventFilter, page models.PageRequest) (*models.Page[models.SecurityEvent], error) {
+	tracer := otel.Tracer("SecurityService")
+	ctx, span := tracer.Start(ctx, "ListSecurityEvents-Service")
+	defer span.End()
+
+	if filter.UserID != "" {
+		if _, err := uuid.Parse(filter.UserID); err != nil {
+			return nil, fmt.Errorf("invalid user_id: %v", err)
+		}
+	}
+
+	events, err := s.securityStore.ListSecurityEvents(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.NewPage(events, page, models.SecurityEvent.PageCursor)
+	return &result, nil
+}
+
+// GetSecurityEventByID retrieves a single security event
+func (s *SecurityService) GetSecurityEventByID(ctx context.Context, id string) (*models.SecurityEvent, error) {
+	tracer := otel.Tracer("SecurityService")
+	ctx, span := tracer.Start(ctx, "GetSecurityEventByID-Service")
+	defer span.End()
+
+	event, err := s.securityStore.GetSecurityEventByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// ReviewSecurityEvent records an admin's verdict on an open security event
+func (s *SecurityService) ReviewSecurityEvent(ctx context.Context, id, reviewerID string, review models.SecurityEventReview) (*models.SecurityEvent, error) {
+	tracer := otel.Tracer("SecurityService")
+	ctx, span := tracer.Start(ctx, "ReviewSecurityEvent-Service")
+	defer span.End()
+
+	if err := models.ValidateSecurityEventReview(review); err != nil {
+		return nil, err
+	}
+
+	event, err := s.securityStore.ReviewSecurityEvent(ctx, id, reviewerID, review)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// raiseForUser loads the affected user and raises the event unless an open one of the
+// same type already covers the current window, so one burst yields one review item.
+func (s *SecurityService) raiseForUser(ctx context.Context, userID string, since time.Time, event models.SecurityEvent, subject, message string) {
+	open, err := s.securityStore.HasOpenSecurityEventSince(ctx, userID, event.Type, since)
+	if err != nil {
+		log.Printf("Failed to check open security events for user %s: %v", userID, err)
+		return
+	}
+	if open {
+		return
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to load user %s for security event: %v", userID, err)
+		return
+	}
+
+	event.UserID = user.ID
+	s.raise(ctx, user, event, subject, message)
+}
+
+// raise stores the event and notifies the affected user
+func (s *SecurityService) raise(ctx context.Context, user models.User, event models.SecurityEvent, subject, message string) {
+	created, err := s.securityStore.CreateSecurityEvent(ctx, event)
+	if err != nil {
+		log.Printf("Failed to store %s security event for user %s: %v", event.Type, user.ID, err)
+		return
+	}
+	log.Printf("Security event %s raised: %s (%s) for user %s", created.ID, created.Type, created.Severity, user.ID)
+
+	if err := s.notificationService.Notify(ctx, user, subject, message); err != nil {
+		log.Printf("Failed to notify user %s about security event %s: %v", user.ID, created.ID, err)
+	}
+}
+
+// intFromEnv parses a positive integer setting, returning fallback when unset or invalid
+func intFromEnv(name string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// durationFromEnv parses a positive duration setting, returning fallback when unset or invalid
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
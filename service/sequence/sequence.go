@@ -0,0 +1,90 @@
+package sequence
+
+import (
+	"context"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// SequenceService implements the SequenceServiceInterface
+type SequenceService struct {
+	sequenceStore store.SequenceStoreInterface
+}
+
+// NewSequenceService creates a new sequence service
+func NewSequenceService(sequenceStore store.SequenceStoreInterface) *SequenceService {
+	return &SequenceService{
+		sequenceStore: sequenceStore,
+	}
+}
+
+// IssueInvoice numbers the invoice of a booking for its total amount
+func (s *SequenceService) IssueInvoice(ctx context.Context, booking models.Booking) (*models.FinancialDocument, error) {
+	tracer := otel.Tracer("SequenceService")
+	ctx, span := tracer.Start(ctx, "IssueInvoice-Service")
+	defer span.End()
+
+	invoice, err := s.sequenceStore.IssueDocument(ctx, models.FinancialDocument{
+		Series:     models.DocumentSeriesInvoice,
+		FiscalYear: models.FiscalYear(time.Now()),
+		BookingID:  booking.ID,
+		Amount:     booking.TotalAmount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+// IssueReceipt numbers the receipt of a payment for the amount paid
+func (s *SequenceService) IssueReceipt(ctx context.Context, payment models.Payment) (*models.FinancialDocument, error) {
+	tracer := otel.Tracer("SequenceService")
+	ctx, span := tracer.Start(ctx, "IssueReceipt-Service")
+	defer span.End()
+
+	receipt, err := s.sequenceStore.IssueDocument(ctx, models.FinancialDocument{
+		Series:     models.DocumentSeriesReceipt,
+		FiscalYear: models.FiscalYear(time.Now()),
+		BookingID:  payment.BookingID,
+		PaymentID:  &payment.ID,
+		Amount:     payment.Amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &receipt, nil
+}
+
+// IssueCreditNote numbers the credit note of an adjustment crediting a booking
+func (s *SequenceService) IssueCreditNote(ctx context.Context, adjustment models.BookingAdjustment) (*models.FinancialDocument, error) {
+	tracer := otel.Tracer("SequenceService")
+	ctx, span := tracer.Start(ctx, "IssueCreditNote-Service")
+	defer span.End()
+
+	creditNote, err := s.sequenceStore.IssueDocument(ctx, models.FinancialDocument{
+		Series:       models.DocumentSeriesCreditNote,
+		FiscalYear:   models.FiscalYear(time.Now()),
+		BookingID:    adjustment.BookingID,
+		AdjustmentID: &adjustment.ID,
+		Amount:       adjustment.Amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &creditNote, nil
+}
+
+// GetBookingDocuments retrieves the invoices and receipts issued for a booking
+func (s *SequenceService) GetBookingDocuments(ctx context.Context, bookingID string) ([]models.FinancialDocument, error) {
+	tracer := otel.Tracer("SequenceService")
+	ctx, span := tracer.Start(ctx, "GetBookingDocuments-Service")
+	defer span.End()
+
+	return s.sequenceStore.GetDocumentsByBookingID(ctx, bookingID)
+}
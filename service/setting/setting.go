@@ -0,0 +1,112 @@
+package setting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// SettingService implements the SettingServiceInterface. Each setting is stored as JSON under
+// its key and decoded into its model here, so settings added later need no new tables.
+type SettingService struct {
+	settingStore store.SettingStoreInterface
+}
+
+// NewSettingService creates a new setting service
+func NewSettingService(settingStore store.SettingStoreInterface) *SettingService {
+	return &SettingService{
+		settingStore: settingStore,
+	}
+}
+
+// GetRankingWeights retrieves the operator's search ranking weights, or the defaults when they
+// were never configured
+func (s *SettingService) GetRankingWeights(ctx context.Context) (*models.RankingWeights, error) {
+	tracer := otel.Tracer("SettingService")
+	ctx, span := tracer.Start(ctx, "GetRankingWeights-Service")
+	defer span.End()
+
+	weights := models.DefaultRankingWeights
+	setting, err := s.settingStore.GetSetting(ctx, models.SettingSearchRanking)
+	if err != nil {
+		if strings.Contains(err.Error(), "no setting found") {
+			return &weights, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(setting.Value, &weights); err != nil {
+		return nil, fmt.Errorf("stored search ranking weights are invalid: %v", err)
+	}
+
+	return &weights, nil
+}
+
+// UpdateRankingWeights validates and stores the operator's search ranking weights
+func (s *SettingService) UpdateRankingWeights(ctx context.Context, weights models.RankingWeights) (*models.RankingWeights, error) {
+	tracer := otel.Tracer("SettingService")
+	ctx, span := tracer.Start(ctx, "UpdateRankingWeights-Service")
+	defer span.End()
+
+	if err := models.ValidateRankingWeights(weights); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(weights)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.settingStore.PutSetting(ctx, models.SettingSearchRanking, value); err != nil {
+		return nil, err
+	}
+
+	return &weights, nil
+}
+
+// GetDepreciationSettings retrieves the operator's depreciation curves, or the defaults when
+// they were never configured
+func (s *SettingService) GetDepreciationSettings(ctx context.Context) (*models.DepreciationSettings, error) {
+	tracer := otel.Tracer("SettingService")
+	ctx, span := tracer.Start(ctx, "GetDepreciationSettings-Service")
+	defer span.End()
+
+	settings := models.DefaultDepreciationSettings
+	setting, err := s.settingStore.GetSetting(ctx, models.SettingDepreciation)
+	if err != nil {
+		if strings.Contains(err.Error(), "no setting found") {
+			return &settings, nil
+		}
+		return nil, err
+	}
+	settings = models.DepreciationSettings{}
+	if err := json.Unmarshal(setting.Value, &settings); err != nil {
+		return nil, fmt.Errorf("stored depreciation settings are invalid: %v", err)
+	}
+
+	return &settings, nil
+}
+
+// UpdateDepreciationSettings validates and stores the operator's depreciation curves
+func (s *SettingService) UpdateDepreciationSettings(ctx context.Context, settings models.DepreciationSettings) (*models.DepreciationSettings, error) {
+	tracer := otel.Tracer("SettingService")
+	ctx, span := tracer.Start(ctx, "UpdateDepreciationSettings-Service")
+	defer span.End()
+
+	if err := models.ValidateDepreciationSettings(settings); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.settingStore.PutSetting(ctx, models.SettingDepreciation, value); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
@@ -0,0 +1,150 @@
+// Package sitemap generates sitemap.xml and a JSON listings feed for the public catalog.
+package sitemap
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// sitemapNamespace is the XML namespace required by the sitemaps.org protocol
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// urlSet is the root element of sitemap.xml
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL is a single <url> entry in sitemap.xml
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// feedItem is a single listing in the JSON feed
+type feedItem struct {
+	ID        string    `json:"id"`
+	Slug      string    `json:"slug"`
+	URL       string    `json:"url"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// listingsFeed is the JSON feed document
+type listingsFeed struct {
+	GeneratedAt time.Time  `json:"generated_at"`
+	Items       []feedItem `json:"items"`
+}
+
+// SitemapService renders the sitemap and feed from active listings and keeps
+// the latest rendering in memory so requests never hit the database
+type SitemapService struct {
+	store   store.CarStoreInterface
+	baseURL string
+
+	mu          sync.RWMutex
+	sitemap     []byte
+	feed        []byte
+	generatedAt time.Time
+}
+
+// NewSitemapService creates a sitemap service; baseURL is the public site root used in <loc> entries
+func NewSitemapService(store store.CarStoreInterface, baseURL string) *SitemapService {
+	return &SitemapService{store: store, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Refresh regenerates the sitemap and feed from the current active listings
+func (s *SitemapService) Refresh(ctx context.Context) error {
+	tracer := otel.Tracer("SitemapService")
+	ctx, span := tracer.Start(ctx, "Refresh-Service")
+	defer span.End()
+
+	entries, err := s.store.ListCarSitemapEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	set := urlSet{Xmlns: sitemapNamespace, URLs: make([]sitemapURL, 0, len(entries)+1)}
+	set.URLs = append(set.URLs, sitemapURL{Loc: s.baseURL + "/cars", LastMod: now.Format("2006-01-02")})
+	feed := listingsFeed{GeneratedAt: now, Items: make([]feedItem, 0, len(entries))}
+
+	for _, entry := range entries {
+		loc := s.listingURL(entry)
+		set.URLs = append(set.URLs, sitemapURL{Loc: loc, LastMod: entry.UpdatedAt.UTC().Format("2006-01-02")})
+		feed.Items = append(feed.Items, feedItem{
+			ID:        entry.ID.String(),
+			Slug:      entry.Slug,
+			URL:       loc,
+			UpdatedAt: entry.UpdatedAt.UTC(),
+		})
+	}
+
+	sitemapBody, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return err
+	}
+	sitemapBody = append([]byte(xml.Header), sitemapBody...)
+
+	feedBody, err := json.Marshal(feed)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.sitemap = sitemapBody
+	s.feed = feedBody
+	s.generatedAt = now
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Sitemap returns the latest sitemap.xml and when it was generated.
+// It renders on demand if the scheduled refresh has not run yet.
+func (s *SitemapService) Sitemap(ctx context.Context) ([]byte, time.Time, error) {
+	if err := s.ensureGenerated(ctx); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sitemap, s.generatedAt, nil
+}
+
+// Feed returns the latest JSON listings feed and when it was generated.
+// It renders on demand if the scheduled refresh has not run yet.
+func (s *SitemapService) Feed(ctx context.Context) ([]byte, time.Time, error) {
+	if err := s.ensureGenerated(ctx); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.feed, s.generatedAt, nil
+}
+
+// ensureGenerated renders the documents once if nothing has been generated yet
+func (s *SitemapService) ensureGenerated(ctx context.Context) error {
+	s.mu.RLock()
+	ready := s.sitemap != nil
+	s.mu.RUnlock()
+
+	if ready {
+		return nil
+	}
+	return s.Refresh(ctx)
+}
+
+// listingURL builds the public page URL for a listing
+func (s *SitemapService) listingURL(entry models.CarSitemapEntry) string {
+	return s.baseURL + "/cars/" + entry.Slug
+}
@@ -0,0 +1,157 @@
+package smartlock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/dependency"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/secrets"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/google/uuid"
+)
+
+// ProvidersFromEnv returns the providers configured through the environment: the HTTP provider
+// when SMART_LOCK_API_URL is set, otherwise the log provider, which only logs keys so bookings
+// of cars with locks work in development
+func ProvidersFromEnv() []service.SmartLockProviderInterface {
+	if baseURL := os.Getenv("SMART_LOCK_API_URL"); baseURL != "" {
+		return []service.SmartLockProviderInterface{NewHTTPProvider(baseURL)}
+	}
+	return []service.SmartLockProviderInterface{LogProvider{}}
+}
+
+// HTTPProvider issues keys through a provider's REST API, or a gateway in front of it:
+//
+//	POST   {SMART_LOCK_API_URL}/locks/{lock_ref}/keys      {"reference", "valid_from", "valid_until"} -> {"id"}
+//	DELETE {SMART_LOCK_API_URL}/locks/{lock_ref}/keys/{id}
+//
+// Requests carry SMART_LOCK_API_KEY as a bearer token.
+type HTTPProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPProvider creates an HTTP provider for the API at baseURL
+func NewHTTPProvider(baseURL string) *HTTPProvider {
+	return &HTTPProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name identifies the HTTP provider
+func (p *HTTPProvider) Name() string {
+	return "http"
+}
+
+// IssueKey creates a key valid in the grant's window
+func (p *HTTPProvider) IssueKey(ctx context.Context, grant models.DigitalKeyGrant) (_ string, err error) {
+	ctx, done := dependency.Start(ctx, dependency.SmartLock, "IssueKey")
+	defer func() { done(err) }()
+
+	payload, err := json.Marshal(map[string]string{
+		"reference":   grant.BookingID.String(),
+		"valid_from":  grant.ValidFrom.UTC().Format(time.RFC3339),
+		"valid_until": grant.ValidUntil.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := p.do(ctx, http.MethodPost, "/locks/"+url.PathEscape(grant.LockRef)+"/keys", payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", providerError(resp)
+	}
+
+	var key struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&key); err != nil || key.ID == "" {
+		return "", errors.New("smart lock provider returned no key ID")
+	}
+
+	return key.ID, nil
+}
+
+// RevokeKey deletes a key; one the provider no longer knows has already expired or been removed
+func (p *HTTPProvider) RevokeKey(ctx context.Context, lockRef, providerKeyID string) (err error) {
+	ctx, done := dependency.Start(ctx, dependency.SmartLock, "RevokeKey")
+	defer func() { done(err) }()
+
+	resp, err := p.do(ctx, http.MethodDelete, "/locks/"+url.PathEscape(lockRef)+"/keys/"+url.PathEscape(providerKeyID), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return providerError(resp)
+	}
+}
+
+// do sends an authenticated request to the provider's API
+func (p *HTTPProvider) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+secrets.Get("SMART_LOCK_API_KEY"))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("smart lock request failed: %v", err)
+	}
+	return resp, nil
+}
+
+// providerError describes a response the provider's API refused a request with
+func providerError(resp *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("smart lock provider responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+}
+
+// LogProvider stands in for a provider when none is configured. It logs the keys it is asked
+// for and hands out random key IDs, which open nothing.
+type LogProvider struct{}
+
+// Name identifies the log provider
+func (LogProvider) Name() string {
+	return "log"
+}
+
+// IssueKey logs the key and returns a random ID for it
+func (LogProvider) IssueKey(_ context.Context, grant models.DigitalKeyGrant) (string, error) {
+	keyID := uuid.New().String()
+	log.Printf("Smart lock provider not configured; digital key %s to lock %s for booking %s valid %s to %s",
+		keyID, grant.LockRef, grant.BookingID, grant.ValidFrom.Format(time.RFC3339), grant.ValidUntil.Format(time.RFC3339))
+	return keyID, nil
+}
+
+// RevokeKey logs the revocation
+func (LogProvider) RevokeKey(_ context.Context, lockRef, providerKeyID string) error {
+	log.Printf("Smart lock provider not configured; digital key %s to lock %s revoked", providerKeyID, lockRef)
+	return nil
+}
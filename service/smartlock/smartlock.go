@@ -0,0 +1,349 @@
+// Package smartlock provides keyless entry to cars fitted with smart locks. A booking's customer
+// gets a digital key when the booking is confirmed, valid from shortly before pickup until
+// shortly after the return is due, and loses it when the booking is completed or cancelled.
+// Lock and unlock events the provider reports are logged against the booking whose key was used.
+package smartlock
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/secrets"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// SmartLockService implements the SmartLockServiceInterface
+type SmartLockService struct {
+	smartLockStore store.SmartLockStoreInterface
+	bookingStore   store.BookingStoreInterface
+	carStore       store.CarStoreInterface
+	providers      map[string]service.SmartLockProviderInterface
+	keyMargin      time.Duration // How long before pickup a key opens the car and after the due return it still does
+}
+
+// NewSmartLockService creates a new smart lock service with the providers cars' locks may be
+// linked with. SMART_LOCK_KEY_MARGIN (default 30m) is how long before a booking starts its key
+// opens the car, and how long after it ends the key still does.
+func NewSmartLockService(smartLockStore store.SmartLockStoreInterface, bookingStore store.BookingStoreInterface, carStore store.CarStoreInterface, providers ...service.SmartLockProviderInterface) *SmartLockService {
+	keyMargin, err := time.ParseDuration(os.Getenv("SMART_LOCK_KEY_MARGIN"))
+	if err != nil || keyMargin < 0 {
+		keyMargin = 30 * time.Minute
+	}
+	byName := make(map[string]service.SmartLockProviderInterface, len(providers))
+	for _, provider := range providers {
+		byName[provider.Name()] = provider
+	}
+	return &SmartLockService{
+		smartLockStore: smartLockStore,
+		bookingStore:   bookingStore,
+		carStore:       carStore,
+		providers:      byName,
+		keyMargin:      keyMargin,
+	}
+}
+
+// GetSmartLock returns the lock fitted in a car to its owner or an admin
+func (s *SmartLockService) GetSmartLock(ctx context.Context, userID, role, carID string) (*models.SmartLock, error) {
+	tracer := otel.Tracer("SmartLockService")
+	ctx, span := tracer.Start(ctx, "GetSmartLock-Service")
+	defer span.End()
+
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return nil, err
+	}
+
+	lock, err := s.smartLockStore.GetSmartLockByCarID(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lock, nil
+}
+
+// SetSmartLock links a car to its lock. Bookings confirmed from now on get keys to the new
+// lock; keys already issued are not moved to it, see ReissueBookingKey.
+func (s *SmartLockService) SetSmartLock(ctx context.Context, userID, role, carID string, req models.SmartLockRequest) (*models.SmartLock, error) {
+	tracer := otel.Tracer("SmartLockService")
+	ctx, span := tracer.Start(ctx, "SetSmartLock-Service")
+	defer span.End()
+
+	if err := models.ValidateSmartLockRequest(req); err != nil {
+		return nil, err
+	}
+	if _, ok := s.providers[req.Provider]; !ok {
+		return nil, fmt.Errorf("smart lock provider %q is not configured; available providers are %s", req.Provider, strings.Join(s.providerNames(), ", "))
+	}
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return nil, err
+	}
+
+	lock, err := s.smartLockStore.UpsertSmartLock(ctx, carID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lock, nil
+}
+
+// DeleteSmartLock unlinks a car from its lock; keys already issued keep working until revoked
+func (s *SmartLockService) DeleteSmartLock(ctx context.Context, userID, role, carID string) error {
+	tracer := otel.Tracer("SmartLockService")
+	ctx, span := tracer.Start(ctx, "DeleteSmartLock-Service")
+	defer span.End()
+
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return err
+	}
+
+	return s.smartLockStore.DeleteSmartLock(ctx, carID)
+}
+
+// GetBookingDigitalKeys lists the keys of a booking to its customer, the car's owner or an admin
+func (s *SmartLockService) GetBookingDigitalKeys(ctx context.Context, userID, role, bookingID string) ([]models.DigitalKey, error) {
+	tracer := otel.Tracer("SmartLockService")
+	ctx, span := tracer.Start(ctx, "GetBookingDigitalKeys-Service")
+	defer span.End()
+
+	if _, err := s.authorizeBooking(ctx, userID, role, bookingID, true); err != nil {
+		return nil, err
+	}
+
+	return s.smartLockStore.GetDigitalKeysByBookingID(ctx, bookingID)
+}
+
+// ReissueBookingKey revokes a booking's current key and issues a new one to the car's lock
+func (s *SmartLockService) ReissueBookingKey(ctx context.Context, userID, role, bookingID string) (*models.DigitalKey, error) {
+	tracer := otel.Tracer("SmartLockService")
+	ctx, span := tracer.Start(ctx, "ReissueBookingKey-Service")
+	defer span.End()
+
+	booking, err := s.authorizeBooking(ctx, userID, role, bookingID, false)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status != models.BookingStatusConfirmed && booking.Status != models.BookingStatusInProgress {
+		return nil, fmt.Errorf("digital keys can only be issued for confirmed or in progress bookings, booking is %s", booking.Status)
+	}
+
+	lock, err := s.smartLockStore.GetSmartLockByCarID(ctx, booking.CarID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	s.RevokeBookingKeys(ctx, booking)
+	key, err := s.issueKey(ctx, booking, lock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// GetBookingLockEvents lists the lock events of a booking to its customer, the car's owner or an admin
+func (s *SmartLockService) GetBookingLockEvents(ctx context.Context, userID, role, bookingID string) ([]models.LockEvent, error) {
+	tracer := otel.Tracer("SmartLockService")
+	ctx, span := tracer.Start(ctx, "GetBookingLockEvents-Service")
+	defer span.End()
+
+	if _, err := s.authorizeBooking(ctx, userID, role, bookingID, true); err != nil {
+		return nil, err
+	}
+
+	return s.smartLockStore.GetLockEventsByBookingID(ctx, bookingID)
+}
+
+// IssueBookingKey issues a key to the car's lock for a confirmed booking. Cars without a lock
+// are skipped; a provider failure is recorded on the key so the owner can reissue it.
+func (s *SmartLockService) IssueBookingKey(ctx context.Context, booking models.Booking) {
+	tracer := otel.Tracer("SmartLockService")
+	ctx, span := tracer.Start(ctx, "IssueBookingKey-Service")
+	defer span.End()
+
+	lock, err := s.smartLockStore.GetSmartLockByCarID(ctx, booking.CarID.String())
+	if err != nil {
+		if !strings.Contains(err.Error(), "no smart lock found") {
+			log.Printf("Failed to look up the smart lock of car %s for booking %s: %v", booking.CarID, booking.ID, err)
+		}
+		return
+	}
+
+	key, err := s.issueKey(ctx, booking, lock)
+	if err != nil {
+		log.Printf("Failed to record digital key for booking %s: %v", booking.ID, err)
+		return
+	}
+	if key.Status == models.DigitalKeyStatusFailed {
+		log.Printf("Smart lock provider %s did not issue a digital key for booking %s: %s", key.Provider, booking.ID, *key.Error)
+	}
+}
+
+// RevokeBookingKeys revokes the issued keys of a booking. Keys the provider fails to revoke stay
+// issued and are logged; they still expire at the end of their window.
+func (s *SmartLockService) RevokeBookingKeys(ctx context.Context, booking models.Booking) {
+	tracer := otel.Tracer("SmartLockService")
+	ctx, span := tracer.Start(ctx, "RevokeBookingKeys-Service")
+	defer span.End()
+
+	keys, err := s.smartLockStore.GetDigitalKeysByBookingID(ctx, booking.ID.String())
+	if err != nil {
+		log.Printf("Failed to list digital keys of booking %s: %v", booking.ID, err)
+		return
+	}
+
+	for _, key := range keys {
+		if key.Status != models.DigitalKeyStatusIssued {
+			continue
+		}
+		provider, ok := s.providers[key.Provider]
+		if !ok {
+			log.Printf("Cannot revoke digital key %s of booking %s: smart lock provider %q is not configured", key.ID, booking.ID, key.Provider)
+			continue
+		}
+		if err := provider.RevokeKey(ctx, key.LockRef, *key.ProviderKeyID); err != nil {
+			log.Printf("Failed to revoke digital key %s of booking %s: %v", key.ID, booking.ID, err)
+			continue
+		}
+		if _, err := s.smartLockStore.RevokeDigitalKey(ctx, key.ID.String()); err != nil {
+			log.Printf("Failed to record revocation of digital key %s of booking %s: %v", key.ID, booking.ID, err)
+		}
+	}
+}
+
+// HandleWebhook logs a lock or unlock event posted by a provider. The signature is the hex
+// HMAC-SHA256 of the body keyed with SMART_LOCK_WEBHOOK_SECRET. Events of locks not linked to a
+// car are acknowledged and dropped, and redelivered events are stored once.
+func (s *SmartLockService) HandleWebhook(ctx context.Context, signature string, body []byte) error {
+	tracer := otel.Tracer("SmartLockService")
+	ctx, span := tracer.Start(ctx, "HandleWebhook-Service")
+	defer span.End()
+
+	secret := secrets.Get("SMART_LOCK_WEBHOOK_SECRET")
+	if secret == "" {
+		return errors.New("smart lock webhooks are not configured")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(signature)) {
+		return errors.New("invalid webhook signature")
+	}
+
+	var event models.SmartLockWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return errors.New("invalid webhook payload")
+	}
+	if err := models.ValidateSmartLockWebhookEvent(event); err != nil {
+		return err
+	}
+
+	lock, err := s.smartLockStore.GetSmartLockByRef(ctx, event.Provider, event.LockRef)
+	if err != nil {
+		if strings.Contains(err.Error(), "no smart lock found") {
+			log.Printf("Ignoring %s event %s of unknown smart lock %s/%s", event.Action, event.ID, event.Provider, event.LockRef)
+			return nil
+		}
+		return err
+	}
+
+	lockEvent := models.LockEvent{
+		CarID:           lock.CarID,
+		Action:          event.Action,
+		ProviderEventID: event.ID,
+		OccurredAt:      event.OccurredAt,
+	}
+	key, err := s.smartLockStore.FindDigitalKeyForEvent(ctx, lock.CarID, event.ProviderKeyID, event.OccurredAt)
+	if err == nil {
+		lockEvent.BookingID = &key.BookingID
+		lockEvent.DigitalKeyID = &key.ID
+	} else if !strings.Contains(err.Error(), "no digital key found") {
+		return err
+	}
+
+	_, _, err = s.smartLockStore.CreateLockEvent(ctx, lockEvent)
+	return err
+}
+
+// issueKey asks the lock's provider for a key covering the booking and records the outcome
+func (s *SmartLockService) issueKey(ctx context.Context, booking models.Booking, lock models.SmartLock) (models.DigitalKey, error) {
+	key := models.DigitalKey{
+		BookingID:  booking.ID,
+		CarID:      booking.CarID,
+		Provider:   lock.Provider,
+		LockRef:    lock.LockRef,
+		ValidFrom:  booking.StartDate.Add(-s.keyMargin),
+		ValidUntil: booking.EndDate.Add(s.keyMargin),
+		Status:     models.DigitalKeyStatusIssued,
+	}
+
+	var providerKeyID string
+	var err error
+	if provider, ok := s.providers[lock.Provider]; ok {
+		providerKeyID, err = provider.IssueKey(ctx, models.DigitalKeyGrant{
+			BookingID:  booking.ID,
+			LockRef:    lock.LockRef,
+			ValidFrom:  key.ValidFrom,
+			ValidUntil: key.ValidUntil,
+		})
+	} else {
+		err = fmt.Errorf("smart lock provider %q is not configured", lock.Provider)
+	}
+	if err != nil {
+		message := err.Error()
+		key.Status = models.DigitalKeyStatusFailed
+		key.Error = &message
+	} else {
+		key.ProviderKeyID = &providerKeyID
+	}
+
+	return s.smartLockStore.CreateDigitalKey(ctx, key)
+}
+
+// authorizeCar checks that the user owns the car, or is an admin
+func (s *SmartLockService) authorizeCar(ctx context.Context, userID, role, carID string) error {
+	car, err := s.carStore.GetCarByID(ctx, carID)
+	if err != nil {
+		return err
+	}
+	if role == "admin" {
+		return nil
+	}
+	if car.OwnerID == nil || car.OwnerID.String() != userID {
+		return errors.New("no car found with the given ID")
+	}
+	return nil
+}
+
+// authorizeBooking returns the booking if the user owns its car, is an admin or, when
+// customers are allowed, is its customer
+func (s *SmartLockService) authorizeBooking(ctx context.Context, userID, role, bookingID string, allowCustomer bool) (models.Booking, error) {
+	booking, err := s.bookingStore.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return models.Booking{}, err
+	}
+	if role == "admin" || booking.OwnerID.String() == userID || (allowCustomer && booking.CustomerID.String() == userID) {
+		return booking, nil
+	}
+	return models.Booking{}, errors.New("no booking found with the given ID")
+}
+
+// providerNames lists the configured providers for error messages
+func (s *SmartLockService) providerNames() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,330 @@
+// Package statement renders a customer's payments and refunds over a period as a PDF or CSV
+// statement for expense reporting. Short periods are rendered while the customer waits; longer
+// ones are queued, rendered by a background job and announced with a notification.
+package statement
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// Statements claimed per run of the render job, and how long a claim lasts before another
+// instance may take the statement over
+const (
+	renderBatchSize  = 10
+	renderClaimStale = 15 * time.Minute
+)
+
+// StatementService implements the StatementServiceInterface
+type StatementService struct {
+	statementStore      store.StatementStoreInterface
+	userStore           store.UserStoreInterface
+	notificationService service.NotificationServiceInterface
+	maxSyncDays         int // Longer periods are rendered in the background
+}
+
+// NewStatementService creates a new statement service.
+// STATEMENT_SYNC_MAX_DAYS (default 92) is the longest period rendered during the request.
+func NewStatementService(statementStore store.StatementStoreInterface, userStore store.UserStoreInterface, notificationService service.NotificationServiceInterface) *StatementService {
+	maxSyncDays, err := strconv.Atoi(os.Getenv("STATEMENT_SYNC_MAX_DAYS"))
+	if err != nil || maxSyncDays < 1 {
+		maxSyncDays = 92
+	}
+	return &StatementService{
+		statementStore:      statementStore,
+		userStore:           userStore,
+		notificationService: notificationService,
+		maxSyncDays:         maxSyncDays,
+	}
+}
+
+// RequestStatement renders the statement of a customer's payments when the period is short
+// enough, otherwise it queues the statement and returns it for the customer to follow.
+func (s *StatementService) RequestStatement(ctx context.Context, userID string, req models.StatementRequest) (*models.StatementFile, *models.PaymentStatement, error) {
+	tracer := otel.Tracer("StatementService")
+	ctx, span := tracer.Start(ctx, "RequestStatement-Service")
+	defer span.End()
+
+	customerID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, nil, errors.New("invalid user ID")
+	}
+
+	if req.Days() <= s.maxSyncDays {
+		file, err := s.render(ctx, userID, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return file, nil, nil
+	}
+
+	statement, err := s.statementStore.CreateStatement(ctx, models.PaymentStatement{
+		UserID: customerID,
+		From:   req.From,
+		To:     req.To,
+		Format: req.Format,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, &statement, nil
+}
+
+// GetStatement retrieves a queued statement of the customer
+func (s *StatementService) GetStatement(ctx context.Context, id, userID string) (*models.PaymentStatement, error) {
+	tracer := otel.Tracer("StatementService")
+	ctx, span := tracer.Start(ctx, "GetStatement-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid statement ID")
+	}
+
+	statement, err := s.statementStore.GetStatement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if statement.UserID.String() != userID {
+		return nil, errors.New("no statement found with the given ID")
+	}
+
+	return &statement, nil
+}
+
+// DownloadStatement retrieves the file of a queued statement once it has been rendered
+func (s *StatementService) DownloadStatement(ctx context.Context, id, userID string) (*models.StatementFile, error) {
+	tracer := otel.Tracer("StatementService")
+	ctx, span := tracer.Start(ctx, "DownloadStatement-Service")
+	defer span.End()
+
+	statement, err := s.GetStatement(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if statement.Status == models.StatementStatusFailed {
+		return nil, errors.New("statement could not be rendered, please request it again")
+	}
+
+	body, err := s.statementStore.GetStatementContent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	req := statement.Request()
+	return &models.StatementFile{Name: req.FileName(), ContentType: req.Format.ContentType(), Body: body}, nil
+}
+
+// RenderPendingStatements renders queued statements and notifies their customers. A statement
+// that fails is marked failed and the customer is told to request it again.
+func (s *StatementService) RenderPendingStatements(ctx context.Context) error {
+	statements, err := s.statementStore.ClaimPendingStatements(ctx, renderBatchSize, renderClaimStale)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		req := statement.Request()
+		subject := "Your CarZone payment statement is ready"
+		message := fmt.Sprintf("Your statement for %s is ready to download at /payments/me/statements/%s/download.",
+			describePeriod(req), statement.ID)
+
+		file, err := s.render(ctx, statement.UserID.String(), req)
+		if err != nil {
+			log.Printf("Failed to render statement %s: %v", statement.ID, err)
+			if err := s.statementStore.CompleteStatement(ctx, statement.ID, models.StatementStatusFailed, nil, err.Error()); err != nil {
+				log.Printf("Failed to record statement %s failure: %v", statement.ID, err)
+				continue
+			}
+			subject = "Your CarZone payment statement could not be prepared"
+			message = fmt.Sprintf("We could not prepare your statement for %s. Please request it again.", describePeriod(req))
+		} else if err := s.statementStore.CompleteStatement(ctx, statement.ID, models.StatementStatusReady, file.Body, ""); err != nil {
+			log.Printf("Failed to store statement %s: %v", statement.ID, err)
+			continue
+		}
+
+		customer, err := s.userStore.GetUserByID(ctx, statement.UserID.String())
+		if err != nil {
+			log.Printf("Failed to look up customer of statement %s: %v", statement.ID, err)
+			continue
+		}
+		if err := s.notificationService.Notify(ctx, customer, subject, message); err != nil {
+			log.Printf("Failed to notify customer about statement %s: %v", statement.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// render builds the statement of a customer's payments over a period and encodes it
+func (s *StatementService) render(ctx context.Context, userID string, req models.StatementRequest) (*models.StatementFile, error) {
+	customer, err := s.userStore.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.statementStore.GetStatementPayments(ctx, userID, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := buildStatement(customer, req, payments)
+
+	var body []byte
+	switch req.Format {
+	case models.StatementFormatCSV:
+		body, err = encodeCSV(statement)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		body = renderPDF(statementText(statement))
+	}
+
+	return &models.StatementFile{Name: req.FileName(), ContentType: req.Format.ContentType(), Body: body}, nil
+}
+
+// buildStatement lists each payment made in the period, and a refund line for each payment
+// refunded in it, oldest first. The whole payment is shown as refunded, as only the refunded
+// status is recorded.
+func buildStatement(customer models.User, req models.StatementRequest, payments []models.Payment) models.Statement {
+	statement := models.Statement{
+		CustomerName:  customer.UserName,
+		CustomerEmail: customer.Email,
+		From:          req.From,
+		To:            req.To,
+		Lines:         []models.StatementLine{},
+		GeneratedAt:   time.Now(),
+	}
+
+	inPeriod := func(t time.Time) bool { return !t.Before(req.From) && t.Before(req.To) }
+
+	var refunds []models.StatementLine
+	for _, payment := range payments {
+		line := models.StatementLine{
+			Date:        payment.CreatedAt,
+			Kind:        models.StatementLinePayment,
+			PaymentID:   payment.ID,
+			BookingID:   payment.BookingID,
+			Description: payment.Description,
+			Method:      payment.Method,
+			Amount:      payment.Amount,
+		}
+		if payment.TransactionID != nil {
+			line.TransactionID = *payment.TransactionID
+		} else if payment.RazorpayPaymentID != nil {
+			line.TransactionID = *payment.RazorpayPaymentID
+		}
+
+		if inPeriod(payment.CreatedAt) {
+			statement.Lines = append(statement.Lines, line)
+			statement.TotalPaid += payment.Amount
+		}
+		if payment.Status == models.PaymentStatusRefunded && inPeriod(payment.UpdatedAt) {
+			refund := line
+			refund.Date = payment.UpdatedAt
+			refund.Kind = models.StatementLineRefund
+			refund.Amount = -payment.Amount
+			refunds = append(refunds, refund)
+			statement.TotalRefunded += payment.Amount
+		}
+	}
+
+	// Refunds are merged in date order after the payments they follow
+	for _, refund := range refunds {
+		i := len(statement.Lines)
+		for i > 0 && statement.Lines[i-1].Date.After(refund.Date) {
+			i--
+		}
+		statement.Lines = append(statement.Lines, models.StatementLine{})
+		copy(statement.Lines[i+1:], statement.Lines[i:])
+		statement.Lines[i] = refund
+	}
+
+	return statement
+}
+
+// encodeCSV writes one row per statement line, amounts in INR with refunds negative
+func encodeCSV(statement models.Statement) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{{"date", "type", "payment_id", "booking_id", "description", "method", "transaction_id", "amount_inr"}}
+	for _, line := range statement.Lines {
+		rows = append(rows, []string{
+			line.Date.Format("2006-01-02"),
+			string(line.Kind),
+			line.PaymentID.String(),
+			line.BookingID.String(),
+			line.Description,
+			string(line.Method),
+			line.TransactionID,
+			strconv.FormatFloat(line.Amount, 'f', 2, 64),
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// statementText lays out the statement as the lines of the PDF
+func statementText(statement models.Statement) []string {
+	req := models.StatementRequest{From: statement.From, To: statement.To}
+	lines := []string{
+		"CarZone - Payment Statement",
+		"",
+		"Customer:  " + statement.CustomerName + " <" + statement.CustomerEmail + ">",
+		"Period:    " + describePeriod(req),
+		"Generated: " + statement.GeneratedAt.Format("02 Jan 2006 15:04 MST"),
+		"",
+		fmt.Sprintf("%-10s  %-7s  %-8s  %-33s  %-10s  %13s", "Date", "Type", "Booking", "Description", "Method", "Amount (INR)"),
+		strings.Repeat("-", 92),
+	}
+
+	for _, line := range statement.Lines {
+		lines = append(lines, fmt.Sprintf("%-10s  %-7s  %-8s  %-33s  %-10s  %13s",
+			line.Date.Format("2006-01-02"), line.Kind, line.BookingID.String()[:8],
+			truncate(line.Description, 33), line.Method, strconv.FormatFloat(line.Amount, 'f', 2, 64)))
+	}
+	if len(statement.Lines) == 0 {
+		lines = append(lines, "No payments or refunds in this period.")
+	}
+
+	lines = append(lines,
+		strings.Repeat("-", 92),
+		fmt.Sprintf("%-77s  %13s", "Total paid", strconv.FormatFloat(statement.TotalPaid, 'f', 2, 64)),
+		fmt.Sprintf("%-77s  %13s", "Total refunded", strconv.FormatFloat(-statement.TotalRefunded, 'f', 2, 64)),
+		fmt.Sprintf("%-77s  %13s", "Net", strconv.FormatFloat(statement.TotalPaid-statement.TotalRefunded, 'f', 2, 64)),
+	)
+
+	return lines
+}
+
+// describePeriod formats the days a statement covers, e.g. 01 Apr 2024 to 30 Jun 2024
+func describePeriod(req models.StatementRequest) string {
+	return req.From.Format("02 Jan 2006") + " to " + req.To.AddDate(0, 0, -1).Format("02 Jan 2006")
+}
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-3]) + "..."
+}
@@ -0,0 +1,302 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// signatureTolerance bounds the age of a signed request so captured payloads cannot be replayed later
+const signatureTolerance = 5 * time.Minute
+
+// geofenceBreachListLimit caps the breach history returned for a car
+const geofenceBreachListLimit = 100
+
+// TelemetryService implements the TelemetryServiceInterface for car telematics
+type TelemetryService struct {
+	telemetryStore      store.TelemetryStoreInterface
+	carStore            store.CarStoreInterface
+	userStore           store.UserStoreInterface
+	notificationService service.NotificationServiceInterface
+	autofillMaxAge      time.Duration // Oldest telemetry reading still trusted for handover auto-fill
+}
+
+// NewTelemetryService creates a new telemetry service.
+// TELEMETRY_AUTOFILL_MAX_AGE (default 30m) limits how old an auto-filled handover reading may be.
+func NewTelemetryService(telemetryStore store.TelemetryStoreInterface, carStore store.CarStoreInterface, userStore store.UserStoreInterface, notificationService service.NotificationServiceInterface) *TelemetryService {
+	maxAge, err := time.ParseDuration(os.Getenv("TELEMETRY_AUTOFILL_MAX_AGE"))
+	if err != nil || maxAge <= 0 {
+		maxAge = 30 * time.Minute
+	}
+	return &TelemetryService{
+		telemetryStore:      telemetryStore,
+		carStore:            carStore,
+		userStore:           userStore,
+		notificationService: notificationService,
+		autofillMaxAge:      maxAge,
+	}
+}
+
+// IngestReading authenticates a device payload and stores it. The signature is the hex
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the device secret; timestamp is Unix seconds.
+func (s *TelemetryService) IngestReading(ctx context.Context, deviceID, timestamp, signature string, body []byte) (*models.TelemetryReading, error) {
+	tracer := otel.Tracer("TelemetryService")
+	ctx, span := tracer.Start(ctx, "IngestReading-Service")
+	defer span.End()
+
+	if deviceID == "" || timestamp == "" || signature == "" {
+		return nil, errors.New("invalid telemetry signature")
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid telemetry signature")
+	}
+	if age := time.Since(time.Unix(unix, 0)); age > signatureTolerance || age < -signatureTolerance {
+		return nil, errors.New("telemetry signature has expired")
+	}
+
+	device, err := s.telemetryStore.GetDeviceByID(ctx, deviceID)
+	if err != nil {
+		if err.Error() == "no telemetry device found with the given ID" {
+			// Unknown devices are indistinguishable from bad signatures to the caller
+			return nil, errors.New("invalid telemetry signature")
+		}
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, []byte(device.Secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, errors.New("invalid telemetry signature")
+	}
+
+	var payload models.TelemetryPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.New("invalid telemetry payload: " + err.Error())
+	}
+	if err := models.ValidateTelemetryPayload(payload); err != nil {
+		return nil, err
+	}
+
+	reading, err := s.telemetryStore.CreateReading(ctx, device, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reading, nil
+}
+
+// ProvisionDevice issues a new signing secret for the car's device. Calling it again
+// rotates the secret and invalidates the old one. The secret is only returned here.
+func (s *TelemetryService) ProvisionDevice(ctx context.Context, userID, role, carID string) (*models.TelemetryDeviceCredentials, error) {
+	tracer := otel.Tracer("TelemetryService")
+	ctx, span := tracer.Start(ctx, "ProvisionDevice-Service")
+	defer span.End()
+
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	secret := hex.EncodeToString(raw)
+
+	device, err := s.telemetryStore.UpsertDevice(ctx, carID, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TelemetryDeviceCredentials{
+		DeviceID: device.ID,
+		CarID:    device.CarID,
+		Secret:   secret,
+	}, nil
+}
+
+// GetLatestSnapshot returns the car's latest telemetry to its owner or an admin
+func (s *TelemetryService) GetLatestSnapshot(ctx context.Context, userID, role, carID string) (*models.TelemetryReading, error) {
+	tracer := otel.Tracer("TelemetryService")
+	ctx, span := tracer.Start(ctx, "GetLatestSnapshot-Service")
+	defer span.End()
+
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.telemetryStore.GetLatestSnapshot(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// LatestHandoverReadings returns the odometer and fuel readings recent enough to
+// auto-fill a checkout or check-in; either is nil when none qualifies
+func (s *TelemetryService) LatestHandoverReadings(ctx context.Context, carID string) (*int, *float64, error) {
+	tracer := otel.Tracer("TelemetryService")
+	ctx, span := tracer.Start(ctx, "LatestHandoverReadings-Service")
+	defer span.End()
+
+	return s.telemetryStore.GetLatestOdometerAndFuel(ctx, carID, time.Now().Add(-s.autofillMaxAge))
+}
+
+// CreateGeofence defines a region the car must stay in during rentals
+func (s *TelemetryService) CreateGeofence(ctx context.Context, userID, role, carID string, req models.GeofenceRequest) (*models.Geofence, error) {
+	tracer := otel.Tracer("TelemetryService")
+	ctx, span := tracer.Start(ctx, "CreateGeofence-Service")
+	defer span.End()
+
+	if err := models.ValidateGeofenceRequest(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return nil, err
+	}
+
+	geofence, err := s.telemetryStore.CreateGeofence(ctx, carID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &geofence, nil
+}
+
+// GetGeofences lists the geofences of a car
+func (s *TelemetryService) GetGeofences(ctx context.Context, userID, role, carID string) ([]models.Geofence, error) {
+	tracer := otel.Tracer("TelemetryService")
+	ctx, span := tracer.Start(ctx, "GetGeofences-Service")
+	defer span.End()
+
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return nil, err
+	}
+
+	return s.telemetryStore.GetGeofencesByCarID(ctx, carID)
+}
+
+// DeleteGeofence removes a geofence of a car
+func (s *TelemetryService) DeleteGeofence(ctx context.Context, userID, role, carID, id string) error {
+	tracer := otel.Tracer("TelemetryService")
+	ctx, span := tracer.Start(ctx, "DeleteGeofence-Service")
+	defer span.End()
+
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return err
+	}
+
+	return s.telemetryStore.DeleteGeofence(ctx, carID, id)
+}
+
+// GetGeofenceBreaches lists the most recent geofence breaches of a car
+func (s *TelemetryService) GetGeofenceBreaches(ctx context.Context, userID, role, carID string) ([]models.GeofenceBreach, error) {
+	tracer := otel.Tracer("TelemetryService")
+	ctx, span := tracer.Start(ctx, "GetGeofenceBreaches-Service")
+	defer span.End()
+
+	if err := s.authorizeCar(ctx, userID, role, carID); err != nil {
+		return nil, err
+	}
+
+	return s.telemetryStore.GetGeofenceBreachesByCarID(ctx, carID, geofenceBreachListLimit)
+}
+
+// EvaluateGeofences compares the latest position of every car on an active rental with its
+// geofences. A car leaving a region opens a breach and notifies the owner once; the breach is
+// resolved when the car is seen back inside. Failures of single checks are logged and skipped.
+func (s *TelemetryService) EvaluateGeofences(ctx context.Context) error {
+	tracer := otel.Tracer("TelemetryService")
+	ctx, span := tracer.Start(ctx, "EvaluateGeofences-Service")
+	defer span.End()
+
+	checks, err := s.telemetryStore.ListGeofenceChecks(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, check := range checks {
+		inside, distance := check.Geofence.Contains(check.Latitude, check.Longitude)
+
+		if inside {
+			if check.OpenBreachID != nil {
+				if err := s.telemetryStore.ResolveGeofenceBreach(ctx, check.OpenBreachID.String(), check.RecordedAt); err != nil {
+					log.Printf("Failed to resolve geofence breach %s: %v", check.OpenBreachID, err)
+				}
+			}
+			continue
+		}
+
+		if check.OpenBreachID != nil {
+			continue
+		}
+
+		breach, created, err := s.telemetryStore.OpenGeofenceBreach(ctx, check, distance)
+		if err != nil {
+			log.Printf("Failed to record geofence breach for booking %s: %v", check.BookingID, err)
+			continue
+		}
+		if created {
+			s.notifyBreach(ctx, check, breach)
+		}
+	}
+
+	return nil
+}
+
+// notifyBreach tells the car owner that the car left a geofence. Delivery failures are only logged.
+func (s *TelemetryService) notifyBreach(ctx context.Context, check models.GeofenceCheck, breach models.GeofenceBreach) {
+	owner, err := s.userStore.GetUserByID(ctx, check.OwnerID.String())
+	if err != nil {
+		log.Printf("Failed to load owner %s for geofence alert: %v", check.OwnerID, err)
+		return
+	}
+
+	name := check.Geofence.Name
+	if name == "" {
+		name = "the allowed area"
+	}
+	message := fmt.Sprintf("Your car %s left %s during booking %s.\n\n"+
+		"Last position: %.5f, %.5f at %s, %.1f km from the centre (allowed radius %.1f km).",
+		breach.CarID, name, breach.BookingID, breach.Latitude, breach.Longitude,
+		breach.DetectedAt.UTC().Format(time.RFC1123), breach.DistanceKm, check.Geofence.RadiusKm)
+
+	if err := s.notificationService.Notify(ctx, owner, "CarZone geofence alert", message); err != nil {
+		log.Printf("Failed to send geofence alert for breach %s: %v", breach.ID, err)
+	}
+}
+
+// authorizeCar allows admins and the car's owner. Other users get the same error as for a
+// missing car so car telemetry cannot be probed.
+func (s *TelemetryService) authorizeCar(ctx context.Context, userID, role, carID string) error {
+	car, err := s.carStore.GetCarByID(ctx, carID)
+	if err != nil {
+		return err
+	}
+	if role == "admin" {
+		return nil
+	}
+	if car.OwnerID == nil || car.OwnerID.String() != userID {
+		return errors.New("no car found with the given ID")
+	}
+	return nil
+}
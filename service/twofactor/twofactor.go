@@ -0,0 +1,209 @@
+// Package twofactor adds a second factor to password logins: a time-based one-time password
+// (TOTP, RFC 6238) from an authenticator app, or one of ten single-use backup codes for when
+// the phone is lost. Setting it up is two steps so a mistyped secret cannot lock a user out:
+// the secret is only enforced once the app has produced a valid code for it.
+package twofactor
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	totpPeriod      = 30 // Seconds each code is valid for
+	totpDigits      = 6
+	totpSkew        = 1  // Steps either side of now that are accepted, for clocks that drift
+	backupCodeCount = 10 // Backup codes handed out when two-factor authentication is enabled
+)
+
+// secretEncoding is how secrets are shown to authenticator apps
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// backupCodeEncoding spells backup codes in lowercase letters and digits that are hard to confuse
+var backupCodeEncoding = base32.NewEncoding("abcdefghijkmnpqrstuvwxyz23456789").WithPadding(base32.NoPadding)
+
+// TwoFactorService implements the TwoFactorServiceInterface
+type TwoFactorService struct {
+	userStore store.UserStoreInterface
+	issuer    string // Account name prefix shown in authenticator apps
+}
+
+// NewTwoFactorService creates a new two-factor service. TOTP_ISSUER (default CarZone) is the
+// name authenticator apps show next to the account.
+func NewTwoFactorService(userStore store.UserStoreInterface) *TwoFactorService {
+	issuer := strings.TrimSpace(os.Getenv("TOTP_ISSUER"))
+	if issuer == "" {
+		issuer = "CarZone"
+	}
+	return &TwoFactorService{
+		userStore: userStore,
+		issuer:    issuer,
+	}
+}
+
+// BeginSetup generates a TOTP secret for a user. Starting again before the secret is confirmed
+// replaces it, so a user who abandoned setup can retry.
+func (s *TwoFactorService) BeginSetup(ctx context.Context, userID string) (*models.TwoFactorSetup, error) {
+	tracer := otel.Tracer("TwoFactorService")
+	ctx, span := tracer.Start(ctx, "BeginSetup-Service")
+	defer span.End()
+
+	user, err := s.userStore.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 20)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	secret := secretEncoding.EncodeToString(raw)
+
+	if err := s.userStore.SetPendingTwoFactor(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	label := url.PathEscape(s.issuer + ":" + user.Email)
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", s.issuer)
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+
+	return &models.TwoFactorSetup{
+		Secret:     secret,
+		OTPAuthURL: "otpauth://totp/" + label + "?" + query.Encode(),
+	}, nil
+}
+
+// VerifySetup confirms the pending secret with a code from the app, enables two-factor
+// authentication and returns the backup codes, which are not shown again
+func (s *TwoFactorService) VerifySetup(ctx context.Context, userID string, code string) ([]string, error) {
+	tracer := otel.Tracer("TwoFactorService")
+	ctx, span := tracer.Start(ctx, "VerifySetup-Service")
+	defer span.End()
+
+	twoFactor, err := s.userStore.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor.Enabled() {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+	if twoFactor.Secret == "" {
+		return nil, errors.New("two-factor setup was not started, call /auth/2fa/enable first")
+	}
+
+	step, ok := matchCode(twoFactor.Secret, normalizeCode(code), time.Now())
+	if !ok {
+		return nil, errors.New("invalid two-factor code")
+	}
+
+	codes := make([]string, backupCodeCount)
+	hashes := make([]string, backupCodeCount)
+	for i := range codes {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		encoded := backupCodeEncoding.EncodeToString(raw)
+		codes[i] = encoded[:4] + "-" + encoded[4:]
+		hashes[i] = hashBackupCode(encoded)
+	}
+
+	if err := s.userStore.EnableTwoFactor(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// VerifyLogin checks the second factor of a user whose password was accepted. A six-digit code
+// is checked against the app's secret and works once; anything else is taken for a backup code,
+// which is used up.
+func (s *TwoFactorService) VerifyLogin(ctx context.Context, user models.User, code string) error {
+	tracer := otel.Tracer("TwoFactorService")
+	ctx, span := tracer.Start(ctx, "VerifyLogin-Service")
+	defer span.End()
+
+	twoFactor, err := s.userStore.GetTwoFactor(ctx, user.ID.String())
+	if err != nil {
+		return err
+	}
+	if !twoFactor.Enabled() {
+		return nil
+	}
+
+	code = normalizeCode(code)
+	if code == "" {
+		return errors.New("two-factor code is required")
+	}
+
+	if len(code) == totpDigits {
+		step, ok := matchCode(twoFactor.Secret, code, time.Now())
+		if !ok {
+			return errors.New("invalid two-factor code")
+		}
+		return s.userStore.UseTwoFactorStep(ctx, user.ID.String(), step)
+	}
+
+	return s.userStore.UseBackupCode(ctx, user.ID.String(), hashBackupCode(code))
+}
+
+// matchCode returns the time step whose TOTP code matches, looking totpSkew steps either side of now
+func matchCode(secret, code string, now time.Time) (int64, bool) {
+	key, err := secretEncoding.DecodeString(secret)
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if hmac.Equal([]byte(totpCode(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpCode computes the code of a time step (RFC 4226 dynamic truncation over HMAC-SHA1)
+func totpCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+// normalizeCode drops the spaces and hyphens people type into codes and lowercases backup codes
+func normalizeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
+
+// hashBackupCode returns the hex SHA-256 of a normalized backup code, which is what the store keeps
+func hashBackupCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,259 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/identity"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UserService implements the UserServiceInterface. Admins use it to look after the accounts of
+// the platform, and users to read, correct and close their own account.
+type UserService struct {
+	userStore store.UserStoreInterface
+}
+
+// NewUserService creates a new user service
+func NewUserService(userStore store.UserStoreInterface) *UserService {
+	return &UserService{
+		userStore: userStore,
+	}
+}
+
+// ListUsers retrieves one page of users, newest first
+func (s *UserService) ListUsers(ctx context.Context, page models.PageRequest) (*models.Page[models.User], error) {
+	tracer := otel.Tracer("UserService")
+	ctx, span := tracer.Start(ctx, "ListUsers-Service")
+	defer span.End()
+
+	users, err := s.userStore.ListUsers(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.NewPage(users, page, models.User.PageCursor)
+	return &result, nil
+}
+
+// GetUsersByRole retrieves all users with a role
+func (s *UserService) GetUsersByRole(ctx context.Context, role string) ([]models.User, error) {
+	tracer := otel.Tracer("UserService")
+	ctx, span := tracer.Start(ctx, "GetUsersByRole-Service")
+	defer span.End()
+
+	if role != "owner" && role != "renter" && role != "admin" {
+		return nil, errors.New("role must be one of: owner, renter, admin")
+	}
+
+	users, err := s.userStore.GetUsersByRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	if users == nil {
+		users = []models.User{}
+	}
+
+	return users, nil
+}
+
+// GetUserByID retrieves a user
+func (s *UserService) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	tracer := otel.Tracer("UserService")
+	ctx, span := tracer.Start(ctx, "GetUserByID-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("no user found with the given ID")
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, id)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, errors.New("no user found with the given ID")
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UpdateUser replaces a user's account details, password included; a new password ends the
+// user's sessions. Admins cannot take the admin role away from themselves, so the platform is
+// never left without the admin doing it.
+func (s *UserService) UpdateUser(ctx context.Context, id string, req models.UserRequest) (*models.User, error) {
+	tracer := otel.Tracer("UserService")
+	ctx, span := tracer.Start(ctx, "UpdateUser-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("no user found with the given ID")
+	}
+	if err := models.ValidateUserRequest(req); err != nil {
+		return nil, err
+	}
+	if admin, ok := identity.FromContext(ctx); ok && admin.ID == id && req.Role != "admin" {
+		return nil, errors.New("admins cannot remove their own admin role")
+	}
+
+	user, err := s.userStore.UpdateUser(ctx, id, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("no user found with the given ID")
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// DeleteUser deletes a user and returns the deleted record. Users who rented cars are kept for
+// their booking history, and admins cannot delete themselves.
+func (s *UserService) DeleteUser(ctx context.Context, id string) (*models.User, error) {
+	tracer := otel.Tracer("UserService")
+	ctx, span := tracer.Start(ctx, "DeleteUser-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("no user found with the given ID")
+	}
+	if admin, ok := identity.FromContext(ctx); ok && admin.ID == id {
+		return nil, errors.New("admins cannot delete their own account")
+	}
+
+	user, err := s.userStore.DeleteUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetCurrentUser retrieves the signed-in user's own account
+func (s *UserService) GetCurrentUser(ctx context.Context, userID string) (*models.User, error) {
+	tracer := otel.Tracer("UserService")
+	ctx, span := tracer.Start(ctx, "GetCurrentUser-Service")
+	defer span.End()
+
+	return s.GetUserByID(ctx, userID)
+}
+
+// UpdateCurrentUser replaces the signed-in user's own account details, password included.
+// Users keep the role they signed up with; an empty role in req keeps it too. Changing the
+// e-mail address or password takes the current password, so a stolen access token cannot take
+// the account over, and a new password ends every session's refresh token, as a reset does.
+func (s *UserService) UpdateCurrentUser(ctx context.Context, userID string, req models.UserRequest) (*models.User, error) {
+	tracer := otel.Tracer("UserService")
+	ctx, span := tracer.Start(ctx, "UpdateCurrentUser-Service")
+	defer span.End()
+
+	current, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Role == "" {
+		req.Role = current.Role
+	}
+	if req.Role != current.Role {
+		return nil, errors.New("users cannot change their own role")
+	}
+	if err := models.ValidateUserRequest(req); err != nil {
+		return nil, err
+	}
+
+	// The request always carries a password; it only changes when the stored hash does not match it
+	emailChanged := !strings.EqualFold(strings.TrimSpace(req.Email), current.Email)
+	passwordChanged, err := s.passwordDiffers(ctx, current.Email, req.Password)
+	if err != nil {
+		return nil, err
+	}
+	if emailChanged || passwordChanged {
+		if req.CurrentPassword == "" {
+			return nil, errors.New("current_password is required to change the e-mail address or password")
+		}
+		wrong, err := s.passwordDiffers(ctx, current.Email, req.CurrentPassword)
+		if err != nil {
+			return nil, err
+		}
+		if wrong {
+			return nil, errors.New("current password is incorrect")
+		}
+	}
+
+	user, err := s.userStore.UpdateUser(ctx, userID, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("no user found with the given ID")
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// passwordDiffers reports whether password is not the one the user with the e-mail address
+// signs in with
+func (s *UserService) passwordDiffers(ctx context.Context, email, password string) (bool, error) {
+	_, err := s.userStore.GetUser(ctx, email, password)
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return true, nil
+	}
+	return false, err
+}
+
+// UpdateCurrentProfile merges a patch into the signed-in user's profile data. Only the profile
+// fields users keep themselves can be set; a licence number in the patch is stored encrypted
+// with the account instead.
+func (s *UserService) UpdateCurrentProfile(ctx context.Context, userID string, patch map[string]interface{}) (*models.User, error) {
+	tracer := otel.Tracer("UserService")
+	ctx, span := tracer.Start(ctx, "UpdateCurrentProfile-Service")
+	defer span.End()
+
+	if err := models.ValidateProfileDataPatch(patch); err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	profileData, err := models.MergeProfileData(current.ProfileData, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var licenseNumber *string
+	if license, ok := patch[models.ProfileLicenseNumber]; ok {
+		number, _ := license.(string) // null removes it
+		licenseNumber = &number
+	}
+	if err := s.userStore.UpdateProfile(ctx, userID, profileData, licenseNumber); err != nil {
+		if strings.Contains(err.Error(), "user not found") {
+			return nil, errors.New("no user found with the given ID")
+		}
+		return nil, err
+	}
+
+	return s.GetUserByID(ctx, userID)
+}
+
+// DeleteCurrentUser closes the signed-in user's own account. Users who rented cars are kept for
+// their booking history.
+func (s *UserService) DeleteCurrentUser(ctx context.Context, userID string) error {
+	tracer := otel.Tracer("UserService")
+	ctx, span := tracer.Start(ctx, "DeleteCurrentUser-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(userID); err != nil {
+		return errors.New("no user found with the given ID")
+	}
+
+	_, err := s.userStore.DeleteUser(ctx, userID)
+	return err
+}
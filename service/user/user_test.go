@@ -0,0 +1,120 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store/mocks"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testAccount is the signed-in user of the tests, whose password is "OldPassword123!"
+var testAccount = models.User{
+	ID:       uuid.MustParse("7f6c1a8e-3d2b-4c5a-9e8f-1a2b3c4d5e6f"),
+	Email:    "asha@example.com",
+	UserName: "asha",
+	Phone:    "+919800000002",
+	Role:     "owner",
+}
+
+// newTestUserService creates a service over mock stores; GetUser checks passwords against
+// testAccount's
+func newTestUserService(t *testing.T) (*UserService, *mocks.MockUserStoreInterface) {
+	ctrl := gomock.NewController(t)
+	users := mocks.NewMockUserStoreInterface(ctrl)
+
+	users.EXPECT().GetUserByID(gomock.Any(), testAccount.ID.String()).Return(testAccount, nil).AnyTimes()
+	users.EXPECT().GetUser(gomock.Any(), testAccount.Email, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, password string) (models.User, error) {
+			if password != "OldPassword123!" {
+				return models.User{}, bcrypt.ErrMismatchedHashAndPassword
+			}
+			return testAccount, nil
+		}).AnyTimes()
+
+	return NewUserService(users), users
+}
+
+func TestUpdateCurrentUserRequiresCurrentPassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.UserRequest
+		wantErr string
+	}{
+		{
+			name:    "new e-mail without current password",
+			req:     models.UserRequest{Email: "mallory@example.com", Password: "OldPassword123!"},
+			wantErr: "current_password is required",
+		},
+		{
+			name:    "new password without current password",
+			req:     models.UserRequest{Email: testAccount.Email, Password: "NewPassword123!"},
+			wantErr: "current_password is required",
+		},
+		{
+			name:    "new password with wrong current password",
+			req:     models.UserRequest{Email: testAccount.Email, Password: "NewPassword123!", CurrentPassword: "guess"},
+			wantErr: "current password is incorrect",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestUserService(t)
+			tt.req.UserName, tt.req.Phone = testAccount.UserName, testAccount.Phone
+
+			_, err := s.UpdateCurrentUser(context.Background(), testAccount.ID.String(), tt.req)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateCurrentUserPasswordChangeWithCurrentPassword(t *testing.T) {
+	s, users := newTestUserService(t)
+	req := models.UserRequest{Email: testAccount.Email, Password: "NewPassword123!", CurrentPassword: "OldPassword123!",
+		UserName: testAccount.UserName, Phone: testAccount.Phone}
+
+	users.EXPECT().UpdateUser(gomock.Any(), testAccount.ID.String(), gomock.Any()).Return(testAccount, nil)
+
+	if _, err := s.UpdateCurrentUser(context.Background(), testAccount.ID.String(), req); err != nil {
+		t.Fatalf("UpdateCurrentUser: %v", err)
+	}
+}
+
+func TestUpdateCurrentUserWithoutCredentialChange(t *testing.T) {
+	s, users := newTestUserService(t)
+	req := models.UserRequest{Email: testAccount.Email, Password: "OldPassword123!",
+		UserName: "asha_rentals", Phone: testAccount.Phone}
+
+	users.EXPECT().UpdateUser(gomock.Any(), testAccount.ID.String(), gomock.Any()).Return(testAccount, nil)
+
+	if _, err := s.UpdateCurrentUser(context.Background(), testAccount.ID.String(), req); err != nil {
+		t.Fatalf("UpdateCurrentUser: %v", err)
+	}
+}
+
+func TestUpdateCurrentProfileWritesLicenceNumberWithProfile(t *testing.T) {
+	s, users := newTestUserService(t)
+	patch := map[string]interface{}{"display_name": "Asha", models.ProfileLicenseNumber: "MH1220110062821"}
+
+	users.EXPECT().UpdateProfile(gomock.Any(), testAccount.ID.String(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, profileData map[string]interface{}, licenseNumber *string) error {
+			if _, ok := profileData[models.ProfileLicenseNumber]; ok {
+				t.Errorf("profile data carries the licence number: %v", profileData)
+			}
+			if licenseNumber == nil || *licenseNumber != "MH1220110062821" {
+				t.Errorf("licenseNumber = %v, want MH1220110062821", licenseNumber)
+			}
+			return nil
+		})
+
+	if _, err := s.UpdateCurrentProfile(context.Background(), testAccount.ID.String(), patch); err != nil {
+		t.Fatalf("UpdateCurrentProfile: %v", err)
+	}
+}
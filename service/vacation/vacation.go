@@ -0,0 +1,82 @@
+package vacation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// VacationService implements the VacationServiceInterface. Blocking and unblocking an owner's
+// fleet is a single store operation; the service validates requests and tells car event
+// listeners when a lifted vacation frees dates.
+type VacationService struct {
+	vacationStore store.VacationStoreInterface
+	carEvents     service.CarEventListenerInterface
+}
+
+// NewVacationService creates a new vacation service
+func NewVacationService(vacationStore store.VacationStoreInterface, carEvents service.CarEventListenerInterface) *VacationService {
+	return &VacationService{
+		vacationStore: vacationStore,
+		carEvents:     carEvents,
+	}
+}
+
+// StartVacation validates the period and blocks all of the owner's cars for it
+func (s *VacationService) StartVacation(ctx context.Context, ownerID string, req models.VacationRequest) (*models.OwnerVacation, error) {
+	tracer := otel.Tracer("VacationService")
+	ctx, span := tracer.Start(ctx, "StartVacation-Service")
+	defer span.End()
+
+	if err := models.ValidateVacationRequest(req); err != nil {
+		return nil, err
+	}
+
+	vacation, err := s.vacationStore.CreateVacation(ctx, ownerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vacation, nil
+}
+
+// GetVacations retrieves the owner's vacations
+func (s *VacationService) GetVacations(ctx context.Context, ownerID string) ([]models.OwnerVacation, error) {
+	tracer := otel.Tracer("VacationService")
+	ctx, span := tracer.Start(ctx, "GetVacations-Service")
+	defer span.End()
+
+	return s.vacationStore.ListVacations(ctx, ownerID)
+}
+
+// EndVacation lifts a vacation and alerts users waiting for its dates on any of the cars
+func (s *VacationService) EndVacation(ctx context.Context, ownerID, id string) (*models.OwnerVacation, error) {
+	tracer := otel.Tracer("VacationService")
+	ctx, span := tracer.Start(ctx, "EndVacation-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("invalid vacation ID")
+	}
+
+	vacation, err := s.vacationStore.DeleteVacation(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, carID := range vacation.CarIDs {
+		s.carEvents.HandleCarEvent(ctx, models.CarEvent{
+			Type:          models.CarEventDatesReleased,
+			CarID:         carID,
+			ReleasedStart: vacation.StartDate,
+			ReleasedEnd:   vacation.EndDate,
+		})
+	}
+
+	return &vacation, nil
+}
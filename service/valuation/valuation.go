@@ -0,0 +1,153 @@
+package valuation
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/service"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// ValuationService implements the ValuationServiceInterface
+type ValuationService struct {
+	valuationStore store.ValuationStoreInterface
+	carStore       store.CarStoreInterface
+	settingService service.SettingServiceInterface
+}
+
+// NewValuationService creates a new valuation service. Cars are depreciated with the curves
+// configured in settingService.
+func NewValuationService(valuationStore store.ValuationStoreInterface, carStore store.CarStoreInterface, settingService service.SettingServiceInterface) *ValuationService {
+	return &ValuationService{
+		valuationStore: valuationStore,
+		carStore:       carStore,
+		settingService: settingService,
+	}
+}
+
+// GetFleetValuation estimates the current value of the owner's cars from what they paid, the
+// cars' age and their mileage
+func (s *ValuationService) GetFleetValuation(ctx context.Context, ownerID string) (*models.FleetValuation, error) {
+	tracer := otel.Tracer("ValuationService")
+	ctx, span := tracer.Start(ctx, "GetFleetValuation-Service")
+	defer span.End()
+
+	settings, err := s.settingService.GetDepreciationSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cars, err := s.valuationStore.ListValuationCars(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	valuation := models.FleetValuation{Cars: cars, ValuedAt: time.Now()}
+	for i := range valuation.Cars {
+		car := &valuation.Cars[i]
+		car.AgeYears = car.AgeAt(valuation.ValuedAt)
+		if car.PurchasePrice == nil {
+			valuation.UnpricedCars++
+			continue
+		}
+
+		price := *car.PurchasePrice
+		estimate := settings.Curve(car.FuelType).Estimate(price, car.AgeYears, car.MileageKm)
+		depreciation := math.Round((1-estimate/price)*10000) / 100
+		car.EstimatedValue = &estimate
+		car.Depreciation = &depreciation
+
+		valuation.TotalPurchasePrice += price
+		valuation.TotalEstimatedValue += estimate
+	}
+	valuation.TotalEstimatedValue = math.Round(valuation.TotalEstimatedValue*100) / 100
+
+	return &valuation, nil
+}
+
+// ExportFleetValuation renders the owner's fleet valuation as CSV, one row per car. Cars
+// without a purchase price have empty price and estimate columns.
+func (s *ValuationService) ExportFleetValuation(ctx context.Context, ownerID string) ([]byte, error) {
+	tracer := otel.Tracer("ValuationService")
+	ctx, span := tracer.Start(ctx, "ExportFleetValuation-Service")
+	defer span.End()
+
+	valuation, err := s.GetFleetValuation(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{{"Car ID", "Name", "Brand", "Model", "Year", "Fuel Type", "Mileage (km)", "Last Check-in",
+		"Purchase Price", "Purchased On", "Age (years)", "Estimated Value", "Depreciation (%)"}}
+	for _, car := range valuation.Cars {
+		rows = append(rows, []string{
+			car.CarID.String(), car.Name, car.Brand, car.Model, strconv.Itoa(car.Year), car.FuelType,
+			strconv.Itoa(car.MileageKm), formatDate(car.LastCheckinAt), formatAmount(car.PurchasePrice),
+			formatDate(car.PurchasedOn), strconv.FormatFloat(car.AgeYears, 'f', 2, 64),
+			formatAmount(car.EstimatedValue), formatAmount(car.Depreciation),
+		})
+	}
+	rows = append(rows, []string{"", "Total", "", "", "", "", "", "",
+		strconv.FormatFloat(valuation.TotalPurchasePrice, 'f', 2, 64), "", "",
+		strconv.FormatFloat(valuation.TotalEstimatedValue, 'f', 2, 64), ""})
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SetCarPurchase validates and records what the owner paid for one of their cars
+func (s *ValuationService) SetCarPurchase(ctx context.Context, ownerID, carID string, req models.CarPurchaseRequest) (*models.CarPurchase, error) {
+	tracer := otel.Tracer("ValuationService")
+	ctx, span := tracer.Start(ctx, "SetCarPurchase-Service")
+	defer span.End()
+
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, errors.New("invalid car ID")
+	}
+
+	car, err := s.carStore.GetCarByID(ctx, carID)
+	if err != nil || car.ID == uuid.Nil || car.OwnerID == nil || car.OwnerID.String() != ownerID {
+		return nil, errors.New("no car found with the given ID among your cars")
+	}
+
+	purchasedOn, err := models.ParseCarPurchaseRequest(req, car.Year, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	purchase, err := s.valuationStore.PutCarPurchase(ctx, carID, ownerID, req.PurchasePrice, purchasedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &purchase, nil
+}
+
+// formatDate writes an optional date as YYYY-MM-DD, or nothing
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// formatAmount writes an optional amount with two decimals, or nothing
+func formatAmount(amount *float64) string {
+	if amount == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*amount, 'f', 2, 64)
+}
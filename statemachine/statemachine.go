@@ -0,0 +1,153 @@
+// Package statemachine validates and runs status changes of entities such as bookings, cars
+// and payments. Each entity declares its machine once, in models: its states, the moves
+// allowed between them and guards that can veto a move. Services clone the declared machine
+// and attach hooks, which run once a move has been stored.
+//
+// Adding a status to an entity is a matter of declaring the state and its moves; services
+// pick it up without changes.
+package statemachine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Transition is a requested move of a subject from one state to another
+type Transition[S ~string] struct {
+	From    S
+	To      S
+	ByAdmin bool // Whether an admin requested the move; guards use it to reserve moves for admins
+}
+
+// Guard vetoes a move by returning an error. It runs after the move was found allowed.
+type Guard[S ~string, T any] func(ctx context.Context, subject T, t Transition[S]) error
+
+// Hook reacts to a stored move. Hooks cannot fail the move; they log their own errors.
+type Hook[S ~string, T any] func(ctx context.Context, subject T, t Transition[S])
+
+type edge[S ~string] struct {
+	from S
+	to   S
+}
+
+// Machine holds the states of an entity whose status is of type S and whose records are of
+// type T
+type Machine[S ~string, T any] struct {
+	entity string
+	states []S       // In declaration order, for error messages
+	next   map[S][]S // Moves allowed from each state
+	guards map[edge[S]][]Guard[S, T]
+	hooks  map[S][]Hook[S, T] // Run when a state is entered
+}
+
+// New creates an empty machine. The entity name is used in error messages, e.g. "booking".
+func New[S ~string, T any](entity string) *Machine[S, T] {
+	return &Machine[S, T]{
+		entity: entity,
+		next:   map[S][]S{},
+		guards: map[edge[S]][]Guard[S, T]{},
+		hooks:  map[S][]Hook[S, T]{},
+	}
+}
+
+// State declares a state and the states it may move to. A state without moves is terminal.
+func (m *Machine[S, T]) State(state S, next ...S) *Machine[S, T] {
+	if _, ok := m.next[state]; !ok {
+		m.states = append(m.states, state)
+	}
+	m.next[state] = append(m.next[state], next...)
+	return m
+}
+
+// Guard adds a guard to the move from one state to another
+func (m *Machine[S, T]) Guard(from, to S, guard Guard[S, T]) *Machine[S, T] {
+	key := edge[S]{from: from, to: to}
+	m.guards[key] = append(m.guards[key], guard)
+	return m
+}
+
+// OnEnter adds a hook run after a subject moved into the state
+func (m *Machine[S, T]) OnEnter(state S, hook Hook[S, T]) *Machine[S, T] {
+	m.hooks[state] = append(m.hooks[state], hook)
+	return m
+}
+
+// Clone returns a copy of the machine that can be given its own guards and hooks without
+// affecting the original
+func (m *Machine[S, T]) Clone() *Machine[S, T] {
+	clone := New[S, T](m.entity)
+	clone.states = append(clone.states, m.states...)
+	for state, next := range m.next {
+		clone.next[state] = append([]S{}, next...)
+	}
+	for key, guards := range m.guards {
+		clone.guards[key] = append([]Guard[S, T]{}, guards...)
+	}
+	for state, hooks := range m.hooks {
+		clone.hooks[state] = append([]Hook[S, T]{}, hooks...)
+	}
+	return clone
+}
+
+// Valid reports whether the state is declared
+func (m *Machine[S, T]) Valid(state S) bool {
+	_, ok := m.next[state]
+	return ok
+}
+
+// Terminal reports whether the state is declared and has no moves out of it
+func (m *Machine[S, T]) Terminal(state S) bool {
+	next, ok := m.next[state]
+	return ok && len(next) == 0
+}
+
+// States returns the declared states in declaration order
+func (m *Machine[S, T]) States() []S {
+	return append([]S{}, m.states...)
+}
+
+// CheckState returns an error naming the declared states if the state is not one of them
+func (m *Machine[S, T]) CheckState(state S) error {
+	if m.Valid(state) {
+		return nil
+	}
+	names := make([]string, len(m.states))
+	for i, s := range m.states {
+		names[i] = string(s)
+	}
+	return fmt.Errorf("%s status must be one of: %s", m.entity, strings.Join(names, ", "))
+}
+
+// Validate checks that the subject may make the move: both states are declared, the move is
+// allowed and no guard vetoes it
+func (m *Machine[S, T]) Validate(ctx context.Context, subject T, t Transition[S]) error {
+	next, ok := m.next[t.From]
+	if !ok {
+		return fmt.Errorf("invalid current %s status %s", m.entity, t.From)
+	}
+	if err := m.CheckState(t.To); err != nil {
+		return err
+	}
+
+	for _, state := range next {
+		if state != t.To {
+			continue
+		}
+		for _, guard := range m.guards[edge[S]{from: t.From, to: t.To}] {
+			if err := guard(ctx, subject, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("invalid status transition from %s to %s", t.From, t.To)
+}
+
+// Entered runs the hooks of the state the subject moved into. Call it once the move is stored.
+func (m *Machine[S, T]) Entered(ctx context.Context, subject T, t Transition[S]) {
+	for _, hook := range m.hooks[t.To] {
+		hook(ctx, subject, t)
+	}
+}
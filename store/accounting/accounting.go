@@ -0,0 +1,77 @@
+package accounting
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"go.opentelemetry.io/otel"
+)
+
+// AccountingStore reads the numbered documents and refunds that make up the ledger
+type AccountingStore struct {
+	db *sql.DB
+}
+
+// New creates a new accounting store
+func New(db *sql.DB) AccountingStore {
+	return AccountingStore{db: db}
+}
+
+// GetLedgerEntries retrieves the invoices, receipts and credit notes issued in a period and the
+// payments refunded in it, oldest first. A refund is numbered RF- and the number of the refunded
+// payment's receipt, or the start of the payment ID when none was issued.
+func (s AccountingStore) GetLedgerEntries(ctx context.Context, from, to time.Time) ([]models.AccountingEntry, error) {
+	tracer := otel.Tracer("AccountingStore")
+	ctx, span := tracer.Start(ctx, "GetLedgerEntries-Store")
+	defer span.End()
+
+	query := `
+		SELECT d.series, d.number, d.issued_at, d.booking_id, d.payment_id, d.amount, u.username
+		FROM financial_document d
+		INNER JOIN booking b ON d.booking_id = b.id
+		INNER JOIN users u ON b.customer_id = u.id
+		WHERE d.issued_at >= $1 AND d.issued_at < $2
+		UNION ALL
+		SELECT 'REFUND', 'RF-' || COALESCE(r.number, left(p.id::text, 8)), p.updated_at, p.booking_id, p.id, p.amount, u.username
+		FROM payment p
+		INNER JOIN booking b ON p.booking_id = b.id
+		INNER JOIN users u ON b.customer_id = u.id
+		LEFT JOIN financial_document r ON r.payment_id = p.id AND r.series = $3
+		WHERE p.status = $4 AND p.updated_at >= $1 AND p.updated_at < $2
+		ORDER BY 3, 2`
+
+	rows, err := s.db.QueryContext(ctx, query, from, to, models.DocumentSeriesReceipt, models.PaymentStatusRefunded)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.AccountingEntry{}
+	for rows.Next() {
+		var entry models.AccountingEntry
+		var series string
+		err := rows.Scan(&series, &entry.Number, &entry.Date, &entry.BookingID, &entry.PaymentID, &entry.Amount, &entry.Customer)
+		if err != nil {
+			return nil, err
+		}
+
+		switch models.DocumentSeries(series) {
+		case models.DocumentSeriesInvoice:
+			entry.Kind = models.AccountingEntryInvoice
+		case models.DocumentSeriesReceipt:
+			entry.Kind = models.AccountingEntryReceipt
+		case models.DocumentSeriesCreditNote:
+			entry.Kind = models.AccountingEntryCreditNote
+		case "REFUND":
+			entry.Kind = models.AccountingEntryRefund
+		default:
+			return nil, fmt.Errorf("document %s is of unknown series %q", entry.Number, series)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,179 @@
+package adjustment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// adjustmentColumns lists the columns read by every adjustment query, in scanAdjustment order
+const adjustmentColumns = `id, booking_id, kind, amount, reason_code, notes, status, requested_by, reviewed_by,
+	review_note, payment_id, document_number, created_at, applied_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// AdjustmentStore persists credit notes and manual charges raised against bookings
+type AdjustmentStore struct {
+	db *sql.DB
+}
+
+// New creates a new adjustment store
+func New(db *sql.DB) AdjustmentStore {
+	return AdjustmentStore{db: db}
+}
+
+// CreateAdjustment stores a new adjustment waiting to be applied
+func (s AdjustmentStore) CreateAdjustment(ctx context.Context, adjustment models.BookingAdjustment) (models.BookingAdjustment, error) {
+	tracer := otel.Tracer("AdjustmentStore")
+	ctx, span := tracer.Start(ctx, "CreateAdjustment-Store")
+	defer span.End()
+
+	query := `INSERT INTO booking_adjustment (id, booking_id, kind, amount, reason_code, notes, status,
+	         requested_by, review_note, created_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, '', $9)
+	         RETURNING ` + adjustmentColumns
+
+	return scanAdjustment(s.db.QueryRowContext(ctx, query, uuid.New(), adjustment.BookingID, adjustment.Kind,
+		adjustment.Amount, adjustment.ReasonCode, adjustment.Notes, models.AdjustmentStatusPendingApproval,
+		adjustment.RequestedBy, time.Now()))
+}
+
+// GetAdjustment retrieves an adjustment by ID
+func (s AdjustmentStore) GetAdjustment(ctx context.Context, id string) (models.BookingAdjustment, error) {
+	tracer := otel.Tracer("AdjustmentStore")
+	ctx, span := tracer.Start(ctx, "GetAdjustment-Store")
+	defer span.End()
+
+	adjustment, err := scanAdjustment(s.db.QueryRowContext(ctx,
+		`SELECT `+adjustmentColumns+` FROM booking_adjustment WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.BookingAdjustment{}, errors.New("no adjustment found with the given ID")
+		}
+		return models.BookingAdjustment{}, err
+	}
+
+	return adjustment, nil
+}
+
+// ListAdjustments retrieves adjustments, oldest first so the approval queue is worked in order
+func (s AdjustmentStore) ListAdjustments(ctx context.Context, filter models.AdjustmentFilter) ([]models.BookingAdjustment, error) {
+	tracer := otel.Tracer("AdjustmentStore")
+	ctx, span := tracer.Start(ctx, "ListAdjustments-Store")
+	defer span.End()
+
+	return s.list(ctx, `SELECT `+adjustmentColumns+` FROM booking_adjustment
+	         WHERE ($1 = '' OR status = $1)
+	         ORDER BY created_at`, string(filter.Status))
+}
+
+// ListAdjustmentsByBookingID retrieves the adjustments of a booking, oldest first
+func (s AdjustmentStore) ListAdjustmentsByBookingID(ctx context.Context, bookingID string) ([]models.BookingAdjustment, error) {
+	tracer := otel.Tracer("AdjustmentStore")
+	ctx, span := tracer.Start(ctx, "ListAdjustmentsByBookingID-Store")
+	defer span.End()
+
+	return s.list(ctx, `SELECT `+adjustmentColumns+` FROM booking_adjustment
+	         WHERE booking_id = $1
+	         ORDER BY created_at`, bookingID)
+}
+
+// ClaimAdjustmentReview records the second admin reviewing a pending adjustment. Only one
+// admin can claim an adjustment, so it is applied or rejected once.
+func (s AdjustmentStore) ClaimAdjustmentReview(ctx context.Context, id, reviewedBy uuid.UUID, note string) (models.BookingAdjustment, error) {
+	tracer := otel.Tracer("AdjustmentStore")
+	ctx, span := tracer.Start(ctx, "ClaimAdjustmentReview-Store")
+	defer span.End()
+
+	query := `UPDATE booking_adjustment SET reviewed_by = $2, review_note = $3
+	         WHERE id = $1 AND status = $4 AND reviewed_by IS NULL
+	         RETURNING ` + adjustmentColumns
+
+	adjustment, err := scanAdjustment(s.db.QueryRowContext(ctx, query, id, reviewedBy, note,
+		models.AdjustmentStatusPendingApproval))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.BookingAdjustment{}, errors.New("adjustment is no longer pending approval")
+		}
+		return models.BookingAdjustment{}, err
+	}
+
+	return adjustment, nil
+}
+
+// ReleaseAdjustmentReview returns a claimed adjustment to the approval queue, for reviews whose
+// approval could not be applied
+func (s AdjustmentStore) ReleaseAdjustmentReview(ctx context.Context, id uuid.UUID) error {
+	tracer := otel.Tracer("AdjustmentStore")
+	ctx, span := tracer.Start(ctx, "ReleaseAdjustmentReview-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `UPDATE booking_adjustment SET reviewed_by = NULL, review_note = ''
+	         WHERE id = $1 AND status = $2`, id, models.AdjustmentStatusPendingApproval)
+	return err
+}
+
+// CompleteAdjustment records that a pending adjustment was applied, with the payment or credit
+// note it produced, or rejected
+func (s AdjustmentStore) CompleteAdjustment(ctx context.Context, id uuid.UUID, status models.AdjustmentStatus, paymentID *uuid.UUID, documentNumber *string) (models.BookingAdjustment, error) {
+	tracer := otel.Tracer("AdjustmentStore")
+	ctx, span := tracer.Start(ctx, "CompleteAdjustment-Store")
+	defer span.End()
+
+	var appliedAt *time.Time
+	if status == models.AdjustmentStatusApplied {
+		now := time.Now()
+		appliedAt = &now
+	}
+
+	query := `UPDATE booking_adjustment SET status = $2, payment_id = $3, document_number = $4, applied_at = $5
+	         WHERE id = $1 AND status = $6
+	         RETURNING ` + adjustmentColumns
+
+	adjustment, err := scanAdjustment(s.db.QueryRowContext(ctx, query, id, status, paymentID, documentNumber,
+		appliedAt, models.AdjustmentStatusPendingApproval))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.BookingAdjustment{}, errors.New("adjustment is no longer pending approval")
+		}
+		return models.BookingAdjustment{}, err
+	}
+
+	return adjustment, nil
+}
+
+// list runs an adjustment query
+func (s AdjustmentStore) list(ctx context.Context, query string, args ...interface{}) ([]models.BookingAdjustment, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	adjustments := []models.BookingAdjustment{}
+	for rows.Next() {
+		adjustment, err := scanAdjustment(rows)
+		if err != nil {
+			return nil, err
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+
+	return adjustments, rows.Err()
+}
+
+// scanAdjustment reads one booking_adjustment row
+func scanAdjustment(row rowScanner) (models.BookingAdjustment, error) {
+	var a models.BookingAdjustment
+	err := row.Scan(&a.ID, &a.BookingID, &a.Kind, &a.Amount, &a.ReasonCode, &a.Notes, &a.Status, &a.RequestedBy,
+		&a.ReviewedBy, &a.ReviewNote, &a.PaymentID, &a.DocumentNumber, &a.CreatedAt, &a.AppliedAt)
+	return a, err
+}
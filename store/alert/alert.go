@@ -0,0 +1,136 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// subscriptionColumns lists the columns read by every subscription query
+const subscriptionColumns = `id, user_id, car_id, price_drop, target_price, availability,
+	start_date, end_date, last_notified_at, created_at, updated_at`
+
+// AlertStore persists users' price-drop and availability subscriptions to cars
+type AlertStore struct {
+	db *sql.DB
+}
+
+// New creates a new alert store
+func New(db *sql.DB) AlertStore {
+	return AlertStore{db: db}
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// UpsertSubscription subscribes a user to a car, replacing the criteria of an existing subscription
+func (s AlertStore) UpsertSubscription(ctx context.Context, userID, carID string, req models.CarAlertSubscriptionRequest) (models.CarAlertSubscription, error) {
+	tracer := otel.Tracer("AlertStore")
+	ctx, span := tracer.Start(ctx, "UpsertSubscription-Store")
+	defer span.End()
+
+	query := `INSERT INTO car_alert_subscription (id, user_id, car_id, price_drop, target_price, availability,
+	         start_date, end_date, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+	         ON CONFLICT (user_id, car_id) DO UPDATE SET price_drop = EXCLUDED.price_drop,
+	           target_price = EXCLUDED.target_price, availability = EXCLUDED.availability,
+	           start_date = EXCLUDED.start_date, end_date = EXCLUDED.end_date, updated_at = EXCLUDED.updated_at
+	         RETURNING ` + subscriptionColumns
+
+	subscription, err := scanSubscription(s.db.QueryRowContext(ctx, query, uuid.New(), userID, carID,
+		req.PriceDrop, req.TargetPrice, req.Availability, req.StartDate, req.EndDate, time.Now()))
+	if err != nil {
+		if strings.Contains(err.Error(), "fk_car_alert_subscription_car_id") {
+			return models.CarAlertSubscription{}, errors.New("no car found with the given ID")
+		}
+		return models.CarAlertSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+// GetSubscriptionsByUserID retrieves a user's subscriptions, newest first
+func (s AlertStore) GetSubscriptionsByUserID(ctx context.Context, userID string) ([]models.CarAlertSubscription, error) {
+	tracer := otel.Tracer("AlertStore")
+	ctx, span := tracer.Start(ctx, "GetSubscriptionsByUserID-Store")
+	defer span.End()
+
+	return s.querySubscriptions(ctx, `SELECT `+subscriptionColumns+` FROM car_alert_subscription
+	         WHERE user_id = $1 ORDER BY created_at DESC`, userID)
+}
+
+// GetSubscriptionsByCarID retrieves every subscription to a car
+func (s AlertStore) GetSubscriptionsByCarID(ctx context.Context, carID string) ([]models.CarAlertSubscription, error) {
+	tracer := otel.Tracer("AlertStore")
+	ctx, span := tracer.Start(ctx, "GetSubscriptionsByCarID-Store")
+	defer span.End()
+
+	return s.querySubscriptions(ctx, `SELECT `+subscriptionColumns+` FROM car_alert_subscription
+	         WHERE car_id = $1`, carID)
+}
+
+// DeleteSubscription removes one of the user's subscriptions. Other users' subscriptions are reported as missing.
+func (s AlertStore) DeleteSubscription(ctx context.Context, id, userID string) error {
+	tracer := otel.Tracer("AlertStore")
+	ctx, span := tracer.Start(ctx, "DeleteSubscription-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM car_alert_subscription WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no alert subscription found with the given ID")
+	}
+
+	return nil
+}
+
+// MarkNotified records when a subscriber was last alerted
+func (s AlertStore) MarkNotified(ctx context.Context, id string, at time.Time) error {
+	tracer := otel.Tracer("AlertStore")
+	ctx, span := tracer.Start(ctx, "MarkNotified-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `UPDATE car_alert_subscription SET last_notified_at = $1 WHERE id = $2`, at, id)
+	return err
+}
+
+// querySubscriptions runs a query selecting subscriptionColumns and collects the rows
+func (s AlertStore) querySubscriptions(ctx context.Context, query string, args ...interface{}) ([]models.CarAlertSubscription, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []models.CarAlertSubscription
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+// scanSubscription reads one car_alert_subscription row
+func scanSubscription(row rowScanner) (models.CarAlertSubscription, error) {
+	var sub models.CarAlertSubscription
+	err := row.Scan(&sub.ID, &sub.UserID, &sub.CarID, &sub.PriceDrop, &sub.TargetPrice, &sub.Availability,
+		&sub.StartDate, &sub.EndDate, &sub.LastNotifiedAt, &sub.CreatedAt, &sub.UpdatedAt)
+	return sub, err
+}
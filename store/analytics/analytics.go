@@ -0,0 +1,78 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"go.opentelemetry.io/otel"
+)
+
+// metricSources maps each metric to the rows it aggregates: a bucketing timestamp and the value
+// summed per bucket. Only these fixed fragments are ever concatenated into SQL.
+var metricSources = map[models.TimeSeriesMetric]string{
+	models.TimeSeriesBookings:      `SELECT created_at AS at, 1 AS value FROM booking`,
+	models.TimeSeriesRevenue:       `SELECT updated_at AS at, amount AS value FROM payment WHERE status = 'completed'`,
+	models.TimeSeriesSignups:       `SELECT created_at AS at, 1 AS value FROM users`,
+	models.TimeSeriesCancellations: `SELECT updated_at AS at, 1 AS value FROM booking WHERE status = 'cancelled'`,
+}
+
+// AnalyticsStore runs aggregate queries for the admin dashboards
+type AnalyticsStore struct {
+	db *sql.DB
+}
+
+// New creates a new analytics store
+func New(db *sql.DB) AnalyticsStore {
+	return AnalyticsStore{db: db}
+}
+
+// GetTimeSeries buckets a metric with date_trunc. Every bucket in the range is returned, with
+// zero for buckets that have no rows, so charts need no gap filling.
+func (s AnalyticsStore) GetTimeSeries(ctx context.Context, query models.TimeSeriesQuery) ([]models.TimeSeriesPoint, error) {
+	tracer := otel.Tracer("AnalyticsStore")
+	ctx, span := tracer.Start(ctx, "GetTimeSeries-Store")
+	defer span.End()
+
+	source, ok := metricSources[query.Metric]
+	if !ok {
+		return nil, errors.New("unknown metric")
+	}
+
+	return s.timeSeries(ctx, source, query)
+}
+
+// timeSeries buckets the rows of a source query, which may use parameters from $4 on given
+// in args
+func (s AnalyticsStore) timeSeries(ctx context.Context, source string, query models.TimeSeriesQuery, args ...interface{}) ([]models.TimeSeriesPoint, error) {
+	// $1 is the date_trunc field, which doubles as the bucket step ('1 day', '1 week', ...)
+	sqlQuery := `WITH buckets AS (
+	             SELECT generate_series(date_trunc($1, $2::timestamp), $3::timestamp - interval '1 microsecond',
+	                    ('1 ' || $1)::interval) AS bucket_start
+	         ), source AS (` + source + `)
+	         SELECT b.bucket_start, COALESCE(SUM(src.value), 0)
+	         FROM buckets b
+	         LEFT JOIN source src ON date_trunc($1, src.at) = b.bucket_start AND src.at >= $2 AND src.at < $3
+	         GROUP BY b.bucket_start
+	         ORDER BY b.bucket_start`
+
+	// Timestamps are stored without a time zone in UTC
+	args = append([]interface{}{string(query.Interval), query.From.UTC(), query.To.UTC()}, args...)
+	rows, err := s.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []models.TimeSeriesPoint
+	for rows.Next() {
+		var point models.TimeSeriesPoint
+		if err := rows.Scan(&point.BucketStart, &point.Value); err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+
+	return points, rows.Err()
+}
@@ -0,0 +1,287 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// platformSubject is the subject_id of platform-wide rows in the dashboard read model
+const platformSubject = `'00000000-0000-0000-0000-000000000000'::uuid`
+
+// changeOverlap is how far before the previous refresh changes are looked for again, so rows
+// written by transactions still open during that refresh are not missed
+const changeOverlap = 10 * time.Minute
+
+// dashboardSources computes each read model metric per subject and UTC day from the
+// transactional tables. Only these fixed fragments are ever concatenated into SQL.
+var dashboardSources = map[models.DashboardMetric]string{
+	models.DashboardBookings: `SELECT ` + platformSubject + ` AS subject_id, created_at::date AS day, COUNT(*) AS value
+	         FROM booking GROUP BY 2`,
+	models.DashboardRevenue: `SELECT ` + platformSubject + ` AS subject_id, updated_at::date AS day, SUM(amount) AS value
+	         FROM payment WHERE status = 'completed' GROUP BY 2`,
+	models.DashboardSignups: `SELECT ` + platformSubject + ` AS subject_id, created_at::date AS day, COUNT(*) AS value
+	         FROM users GROUP BY 2`,
+	models.DashboardCancellations: `SELECT ` + platformSubject + ` AS subject_id, updated_at::date AS day, COUNT(*) AS value
+	         FROM booking WHERE status = 'cancelled' GROUP BY 2`,
+	models.DashboardFunnelConfirm: `SELECT ` + platformSubject + ` AS subject_id, created_at::date AS day, COUNT(*) AS value
+	         FROM booking WHERE status IN ('confirmed', 'in_progress', 'completed') GROUP BY 2`,
+	models.DashboardFunnelComplete: `SELECT ` + platformSubject + ` AS subject_id, created_at::date AS day, COUNT(*) AS value
+	         FROM booking WHERE status = 'completed' GROUP BY 2`,
+	models.DashboardFunnelCancel: `SELECT ` + platformSubject + ` AS subject_id, created_at::date AS day, COUNT(*) AS value
+	         FROM booking WHERE status = 'cancelled' GROUP BY 2`,
+	models.DashboardOwnerBookings: `SELECT owner_id AS subject_id, created_at::date AS day, COUNT(*) AS value
+	         FROM booking WHERE owner_id IS NOT NULL GROUP BY 1, 2`,
+	models.DashboardOwnerRevenue: `SELECT b.owner_id AS subject_id, p.updated_at::date AS day, SUM(p.amount) AS value
+	         FROM payment p INNER JOIN booking b ON p.booking_id = b.id
+	         WHERE p.status = 'completed' AND b.owner_id IS NOT NULL GROUP BY 1, 2`,
+	// Each day a booking spans gets the hours of the booking falling on it
+	models.DashboardCarBookedHours: `SELECT b.car_id AS subject_id, d::date AS day,
+	             SUM(EXTRACT(EPOCH FROM LEAST(b.end_date, d + interval '1 day') - GREATEST(b.start_date, d)) / 3600) AS value
+	         FROM booking b,
+	             generate_series(date_trunc('day', b.start_date), b.end_date - interval '1 microsecond', interval '1 day') d
+	         WHERE b.status IN ('confirmed', 'in_progress', 'completed') GROUP BY 1, 2`,
+}
+
+// changedDaysQuery lists the UTC days whose aggregates may have changed since $1: the days
+// bookings changed since then were created, updated or span, and the days of changed payments
+// and new users
+const changedDaysQuery = `SELECT DISTINCT day FROM (
+	             SELECT created_at::date AS day FROM booking WHERE updated_at >= $1 OR created_at >= $1
+	             UNION SELECT updated_at::date FROM booking WHERE updated_at >= $1
+	             UNION SELECT generate_series(start_date::date, end_date::date, interval '1 day')::date
+	                 FROM booking WHERE updated_at >= $1 OR created_at >= $1
+	             UNION SELECT updated_at::date FROM payment WHERE updated_at >= $1
+	             UNION SELECT created_at::date FROM users WHERE created_at >= $1
+	         ) changed WHERE day IS NOT NULL`
+
+// RefreshDashboards brings the dashboard read model up to date. Only the days touched by
+// changes since the previous refresh are recomputed, unless the model has never been built or
+// was last rebuilt more than rebuildEvery ago, in which case every day is. Rebuilds also catch
+// deleted rows and payments whose day moved, which changes alone do not reveal. Concurrent
+// refreshes wait for each other.
+func (s AnalyticsStore) RefreshDashboards(ctx context.Context, rebuildEvery time.Duration) (models.DashboardRefresh, error) {
+	tracer := otel.Tracer("AnalyticsStore")
+	ctx, span := tracer.Start(ctx, "RefreshDashboards-Store")
+	defer span.End()
+
+	// Timestamps are stored without a time zone in UTC
+	refresh := models.DashboardRefresh{RefreshedAt: time.Now().UTC()}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return refresh, err
+	}
+	defer tx.Rollback()
+
+	var refreshedAt, rebuiltAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `SELECT refreshed_at, rebuilt_at FROM dashboard_refresh_state FOR UPDATE`).
+		Scan(&refreshedAt, &rebuiltAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return refresh, errors.New("dashboard refresh state is missing")
+		}
+		return refresh, err
+	}
+
+	// A nil day list recomputes every day
+	var days pq.StringArray
+	refresh.Full = !refreshedAt.Valid || !rebuiltAt.Valid || refresh.RefreshedAt.Sub(rebuiltAt.Time) >= rebuildEvery
+	if !refresh.Full {
+		rows, err := tx.QueryContext(ctx, changedDaysQuery, refreshedAt.Time.Add(-changeOverlap))
+		if err != nil {
+			return refresh, err
+		}
+		days = pq.StringArray{}
+		for rows.Next() {
+			var day time.Time
+			if err := rows.Scan(&day); err != nil {
+				rows.Close()
+				return refresh, err
+			}
+			days = append(days, day.Format("2006-01-02"))
+		}
+		if err := rows.Close(); err != nil {
+			return refresh, err
+		}
+		refresh.Days = len(days)
+	}
+
+	if refresh.Full || len(days) > 0 {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM dashboard_daily_metric WHERE $1::date[] IS NULL OR day = ANY($1::date[])`, days); err != nil {
+			return refresh, err
+		}
+		for metric, source := range dashboardSources {
+			_, err := tx.ExecContext(ctx, `INSERT INTO dashboard_daily_metric (metric, subject_id, day, value)
+			         SELECT $1, src.subject_id, src.day, src.value FROM (`+source+`) src
+			         WHERE $2::date[] IS NULL OR src.day = ANY($2::date[])`, string(metric), days)
+			if err != nil {
+				return refresh, err
+			}
+		}
+	}
+
+	if refresh.Full {
+		err = tx.QueryRowContext(ctx, `UPDATE dashboard_refresh_state SET refreshed_at = $1, rebuilt_at = $1
+		         RETURNING (SELECT COUNT(DISTINCT day) FROM dashboard_daily_metric)`, refresh.RefreshedAt).Scan(&refresh.Days)
+	} else {
+		_, err = tx.ExecContext(ctx, `UPDATE dashboard_refresh_state SET refreshed_at = $1`, refresh.RefreshedAt)
+	}
+	if err != nil {
+		return refresh, err
+	}
+
+	return refresh, tx.Commit()
+}
+
+// GetDashboardRefreshedAt returns when the dashboard read model was last refreshed, or nil if
+// it has never been built
+func (s AnalyticsStore) GetDashboardRefreshedAt(ctx context.Context) (*time.Time, error) {
+	tracer := otel.Tracer("AnalyticsStore")
+	ctx, span := tracer.Start(ctx, "GetDashboardRefreshedAt-Store")
+	defer span.End()
+
+	var refreshedAt sql.NullTime
+	err := s.db.QueryRowContext(ctx, `SELECT refreshed_at FROM dashboard_refresh_state`).Scan(&refreshedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	if !refreshedAt.Valid {
+		return nil, nil
+	}
+	return &refreshedAt.Time, nil
+}
+
+// GetProjectedTimeSeries buckets a platform-wide metric from the daily read model. Buckets must
+// be days or longer; each day counts in full when it starts inside the range.
+func (s AnalyticsStore) GetProjectedTimeSeries(ctx context.Context, query models.TimeSeriesQuery) ([]models.TimeSeriesPoint, error) {
+	tracer := otel.Tracer("AnalyticsStore")
+	ctx, span := tracer.Start(ctx, "GetProjectedTimeSeries-Store")
+	defer span.End()
+
+	if query.Interval == models.TimeSeriesHour {
+		return nil, errors.New("hourly buckets are not kept in the read model")
+	}
+	if _, ok := metricSources[query.Metric]; !ok {
+		return nil, errors.New("unknown metric")
+	}
+
+	return s.timeSeries(ctx, `SELECT day::timestamp AS at, value FROM dashboard_daily_metric
+	         WHERE metric = $4 AND subject_id = `+platformSubject, query, string(query.Metric))
+}
+
+// GetOwnerEarnings ranks owners by completed payments over a range, most first
+func (s AnalyticsStore) GetOwnerEarnings(ctx context.Context, r models.DashboardRange, limit int) ([]models.OwnerEarnings, error) {
+	tracer := otel.Tracer("AnalyticsStore")
+	ctx, span := tracer.Start(ctx, "GetOwnerEarnings-Store")
+	defer span.End()
+
+	query := `SELECT m.subject_id,
+	             COALESCE(SUM(m.value) FILTER (WHERE m.metric = $3), 0)::int,
+	             COALESCE(SUM(m.value) FILTER (WHERE m.metric = $4), 0),
+	             u.username, u.email
+	         FROM dashboard_daily_metric m
+	         LEFT JOIN users u ON u.id = m.subject_id
+	         WHERE m.metric IN ($3, $4) AND m.day >= $1 AND m.day < $2
+	         GROUP BY m.subject_id, u.username, u.email
+	         ORDER BY 3 DESC, 2 DESC, m.subject_id
+	         LIMIT $5`
+
+	rows, err := s.db.QueryContext(ctx, query, r.From, r.To, models.DashboardOwnerBookings, models.DashboardOwnerRevenue, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	earnings := []models.OwnerEarnings{}
+	for rows.Next() {
+		var e models.OwnerEarnings
+		var name, email sql.NullString
+		if err := rows.Scan(&e.OwnerID, &e.Bookings, &e.Revenue, &name, &email); err != nil {
+			return nil, err
+		}
+		if name.Valid {
+			e.Owner = &models.UserSnapshot{Name: name.String, Email: email.String}
+		}
+		earnings = append(earnings, e)
+	}
+
+	return earnings, rows.Err()
+}
+
+// GetCarUtilization ranks cars by the hours bookings held them over a range, most first
+func (s AnalyticsStore) GetCarUtilization(ctx context.Context, r models.DashboardRange, limit int) ([]models.CarUtilization, error) {
+	tracer := otel.Tracer("AnalyticsStore")
+	ctx, span := tracer.Start(ctx, "GetCarUtilization-Store")
+	defer span.End()
+
+	query := `SELECT m.subject_id, SUM(m.value),
+	             CASE WHEN c.id IS NULL THEN NULL ELSE jsonb_build_object('name', c.name, 'brand', c.brand,
+	                 'model', c.model, 'year', c.year, 'price', c.price, 'images', to_jsonb(c.images), 'slug', c.slug) END
+	         FROM dashboard_daily_metric m
+	         LEFT JOIN car c ON c.id = m.subject_id
+	         WHERE m.metric = $3 AND m.day >= $1 AND m.day < $2
+	         GROUP BY m.subject_id, c.id
+	         ORDER BY 2 DESC, m.subject_id
+	         LIMIT $4`
+
+	rows, err := s.db.QueryContext(ctx, query, r.From, r.To, models.DashboardCarBookedHours, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hours := float64(r.Days() * 24)
+	utilization := []models.CarUtilization{}
+	for rows.Next() {
+		var u models.CarUtilization
+		var carJSON []byte
+		if err := rows.Scan(&u.CarID, &u.BookedHours, &carJSON); err != nil {
+			return nil, err
+		}
+		if len(carJSON) > 0 {
+			if err := json.Unmarshal(carJSON, &u.Car); err != nil {
+				return nil, err
+			}
+		}
+		if hours > 0 {
+			u.Utilization = u.BookedHours / hours
+		}
+		utilization = append(utilization, u)
+	}
+
+	return utilization, rows.Err()
+}
+
+// GetBookingFunnel counts the bookings created over a range by where they ended up
+func (s AnalyticsStore) GetBookingFunnel(ctx context.Context, r models.DashboardRange) (models.BookingFunnel, error) {
+	tracer := otel.Tracer("AnalyticsStore")
+	ctx, span := tracer.Start(ctx, "GetBookingFunnel-Store")
+	defer span.End()
+
+	var funnel models.BookingFunnel
+	err := s.db.QueryRowContext(ctx, `SELECT
+	             COALESCE(SUM(value) FILTER (WHERE metric = $3), 0)::int,
+	             COALESCE(SUM(value) FILTER (WHERE metric = $4), 0)::int,
+	             COALESCE(SUM(value) FILTER (WHERE metric = $5), 0)::int,
+	             COALESCE(SUM(value) FILTER (WHERE metric = $6), 0)::int
+	         FROM dashboard_daily_metric
+	         WHERE subject_id = `+platformSubject+` AND metric IN ($3, $4, $5, $6) AND day >= $1 AND day < $2`,
+		r.From, r.To, models.DashboardBookings, models.DashboardFunnelConfirm, models.DashboardFunnelComplete,
+		models.DashboardFunnelCancel).Scan(&funnel.Created, &funnel.Confirmed, &funnel.Completed, &funnel.Cancelled)
+	if err != nil {
+		return funnel, err
+	}
+
+	if funnel.Created > 0 {
+		funnel.ConfirmationRate = float64(funnel.Confirmed) / float64(funnel.Created)
+		funnel.CompletionRate = float64(funnel.Completed) / float64(funnel.Created)
+	}
+	return funnel, nil
+}
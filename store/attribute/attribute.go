@@ -0,0 +1,161 @@
+package attribute
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// attributeColumns lists the columns read by every attribute query, in scanAttribute order
+const attributeColumns = `a.key, a.name, a.type, a.options, a.required,
+	(SELECT COUNT(*) FROM car c WHERE c.operator_id = a.operator_id AND c.attributes ? a.key),
+	a.created_at, a.updated_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// CarAttributeStore persists the custom car attributes of the operator a request acts for
+type CarAttributeStore struct {
+	db *sql.DB
+}
+
+// New creates a new car attribute store
+func New(db *sql.DB) CarAttributeStore {
+	return CarAttributeStore{db: db}
+}
+
+// ListCarAttributes retrieves the operator's attribute definitions ordered by key
+func (s CarAttributeStore) ListCarAttributes(ctx context.Context) ([]models.CarAttribute, error) {
+	tracer := otel.Tracer("CarAttributeStore")
+	ctx, span := tracer.Start(ctx, "ListCarAttributes-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+attributeColumns+` FROM car_attribute a
+	         WHERE a.operator_id = $1 ORDER BY a.key`, tenant.ForInsert(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attributes := []models.CarAttribute{}
+	for rows.Next() {
+		attribute, err := scanAttribute(rows)
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, attribute)
+	}
+
+	return attributes, rows.Err()
+}
+
+// CreateCarAttribute defines an attribute for the operator
+func (s CarAttributeStore) CreateCarAttribute(ctx context.Context, req models.CarAttributeRequest) (models.CarAttribute, error) {
+	tracer := otel.Tracer("CarAttributeStore")
+	ctx, span := tracer.Start(ctx, "CreateCarAttribute-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO car_attribute (operator_id, key, name, type, options, required, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`, tenant.ForInsert(ctx), req.Key, req.Name, req.Type,
+		pq.StringArray(req.Options), req.Required, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "car_attribute_pkey") {
+			return models.CarAttribute{}, errors.New("an attribute with this key already exists")
+		}
+		return models.CarAttribute{}, err
+	}
+
+	return s.getAttribute(ctx, req.Key)
+}
+
+// UpdateCarAttribute replaces the name, options and required flag of an attribute. The type
+// is kept, as stored values would no longer match another one.
+func (s CarAttributeStore) UpdateCarAttribute(ctx context.Context, key string, req models.CarAttributeRequest) (models.CarAttribute, error) {
+	tracer := otel.Tracer("CarAttributeStore")
+	ctx, span := tracer.Start(ctx, "UpdateCarAttribute-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `UPDATE car_attribute SET name = $3, options = $4, required = $5, updated_at = $6
+	         WHERE operator_id = $1 AND key = $2`, tenant.ForInsert(ctx), key, req.Name, pq.StringArray(req.Options),
+		req.Required, time.Now())
+	if err != nil {
+		return models.CarAttribute{}, err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return models.CarAttribute{}, err
+	} else if n == 0 {
+		return models.CarAttribute{}, errors.New("no attribute found with the given key")
+	}
+
+	return s.getAttribute(ctx, key)
+}
+
+// DeleteCarAttribute removes an attribute and its values from the operator's cars
+func (s CarAttributeStore) DeleteCarAttribute(ctx context.Context, key string) error {
+	tracer := otel.Tracer("CarAttributeStore")
+	ctx, span := tracer.Start(ctx, "DeleteCarAttribute-Store")
+	defer span.End()
+
+	operatorID := tenant.ForInsert(ctx)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM car_attribute WHERE operator_id = $1 AND key = $2`, operatorID, key)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no attribute found with the given key")
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE car SET attributes = attributes - $2::text, updated_at = $3
+	         WHERE operator_id = $1 AND attributes ? $2`, operatorID, key, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// getAttribute retrieves one of the operator's attribute definitions
+func (s CarAttributeStore) getAttribute(ctx context.Context, key string) (models.CarAttribute, error) {
+	attribute, err := scanAttribute(s.db.QueryRowContext(ctx, `SELECT `+attributeColumns+` FROM car_attribute a
+	         WHERE a.operator_id = $1 AND a.key = $2`, tenant.ForInsert(ctx), key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.CarAttribute{}, errors.New("no attribute found with the given key")
+		}
+		return models.CarAttribute{}, err
+	}
+	return attribute, nil
+}
+
+// scanAttribute reads one car_attribute row selected with attributeColumns
+func scanAttribute(row rowScanner) (models.CarAttribute, error) {
+	var a models.CarAttribute
+	var options pq.StringArray
+	err := row.Scan(&a.Key, &a.Name, &a.Type, &options, &a.Required, &a.CarCount, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return models.CarAttribute{}, err
+	}
+	if len(options) > 0 {
+		a.Options = []string(options)
+	}
+	return a, nil
+}
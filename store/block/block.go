@@ -0,0 +1,111 @@
+package block
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"go.opentelemetry.io/otel"
+)
+
+// BlockStore persists the users each user has blocked
+type BlockStore struct {
+	db *sql.DB
+}
+
+// New creates a new block store
+func New(db *sql.DB) BlockStore {
+	return BlockStore{db: db}
+}
+
+// CreateBlock blocks a user. Blocking a user twice keeps the first block.
+func (s BlockStore) CreateBlock(ctx context.Context, blockerID, blockedID string) (models.UserBlock, error) {
+	tracer := otel.Tracer("BlockStore")
+	ctx, span := tracer.Start(ctx, "CreateBlock-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO user_block (blocker_id, blocked_id, created_at)
+	         VALUES ($1, $2, $3) ON CONFLICT (blocker_id, blocked_id) DO NOTHING`, blockerID, blockedID, time.Now())
+	if err != nil {
+		return models.UserBlock{}, err
+	}
+
+	var block models.UserBlock
+	err = s.db.QueryRowContext(ctx, `SELECT b.blocker_id, b.blocked_id, u.username, b.created_at
+	         FROM user_block b JOIN users u ON u.id = b.blocked_id
+	         WHERE b.blocker_id = $1 AND b.blocked_id = $2`, blockerID, blockedID).
+		Scan(&block.BlockerID, &block.BlockedID, &block.BlockedName, &block.CreatedAt)
+	if err != nil {
+		return models.UserBlock{}, err
+	}
+
+	return block, nil
+}
+
+// DeleteBlock lifts a block
+func (s BlockStore) DeleteBlock(ctx context.Context, blockerID, blockedID string) error {
+	tracer := otel.Tracer("BlockStore")
+	ctx, span := tracer.Start(ctx, "DeleteBlock-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM user_block WHERE blocker_id = $1 AND blocked_id = $2`,
+		blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("no block found for the given user")
+	}
+
+	return nil
+}
+
+// ListBlocks retrieves the users a user has blocked, most recent first
+func (s BlockStore) ListBlocks(ctx context.Context, blockerID string) ([]models.UserBlock, error) {
+	tracer := otel.Tracer("BlockStore")
+	ctx, span := tracer.Start(ctx, "ListBlocks-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT b.blocker_id, b.blocked_id, u.username, b.created_at
+	         FROM user_block b JOIN users u ON u.id = b.blocked_id
+	         WHERE b.blocker_id = $1 ORDER BY b.created_at DESC`, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocks := []models.UserBlock{}
+	for rows.Next() {
+		var block models.UserBlock
+		if err := rows.Scan(&block.BlockerID, &block.BlockedID, &block.BlockedName, &block.CreatedAt); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, rows.Err()
+}
+
+// IsBlocked reports whether either user has blocked the other. Bookings check it today;
+// direct messaging between users must check it too once it exists.
+func (s BlockStore) IsBlocked(ctx context.Context, userID, otherID string) (bool, error) {
+	tracer := otel.Tracer("BlockStore")
+	ctx, span := tracer.Start(ctx, "IsBlocked-Store")
+	defer span.End()
+
+	var blocked bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM user_block
+	         WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1))`,
+		userID, otherID).Scan(&blocked)
+	if err != nil {
+		return false, err
+	}
+
+	return blocked, nil
+}
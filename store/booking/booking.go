@@ -3,20 +3,56 @@ package booking
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/PrateekKumar15/CarZone/identity"
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/PrateekKumar15/CarZone/tenant"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 )
 
+// bookingColumns lists the columns read by every booking query, in scanBooking order
+const bookingColumns = `id, customer_id, car_id, owner_id, status, total_amount,
+	         start_date, end_date, notes, created_at, updated_at,
+	         pickup_location_id, dropoff_location_id, pickup_fee, dropoff_fee,
+	         delivery_address, delivery_latitude, delivery_longitude, delivery_distance_km, delivery_fee,
+	         relocation_fee, add_ons, add_ons_fee, prepaid_fuel_fee, late_fee, refuel_fee, overdue_at, car_snapshot,
+	         customer_snapshot, owner_snapshot, parent_booking_id`
+
+// carSnapshotQuery captures the booked car's listing, in the same statement that inserts the
+// booking, so it matches what the customer booked
+const carSnapshotQuery = `SELECT jsonb_build_object('name', name, 'brand', brand, 'model', model, 'year', year,
+	         'price', price, 'images', to_jsonb(images), 'slug', slug) FROM car WHERE id = $3`
+
+// userSnapshotQuery captures a user's display name and email as they were when the booking was
+// made, so booking and payment lists keep them after the user changes or is anonymized
+const userSnapshotQuery = `SELECT jsonb_build_object('name', username, 'email', email) FROM users WHERE id = `
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// getBookingsByCarIDQuery runs for every availability check, so it is prepared once when the
+// store is created
+const getBookingsByCarIDQuery = `SELECT ` + bookingColumns + `
+	         FROM booking WHERE car_id = $1 AND ($2::uuid IS NULL OR operator_id = $2)
+	         ORDER BY created_at DESC`
+
 type BookingStore struct {
 	db *sql.DB
+
+	getBookingsByCarID *store.PreparedQuery
 }
 
 func New(db *sql.DB) BookingStore {
-	return BookingStore{db: db}
+	return BookingStore{db: db, getBookingsByCarID: store.Prepare(db, getBookingsByCarIDQuery)}
 }
 
 func (s BookingStore) GetBookingByID(ctx context.Context, id string) (models.Booking, error) {
@@ -24,17 +60,12 @@ func (s BookingStore) GetBookingByID(ctx context.Context, id string) (models.Boo
 	ctx, span := tracer.Start(ctx, "GetBookingByID-Store")
 	defer span.End()
 
-	var booking models.Booking
-
-	query := `SELECT id, customer_id, car_id, owner_id, status, total_amount, 
-	         start_date, end_date, notes, created_at, updated_at 
-	         FROM booking WHERE id = $1`
-
-	row := s.db.QueryRowContext(ctx, query, id)
-	err := row.Scan(&booking.ID, &booking.CustomerID, &booking.CarID, &booking.OwnerID,
-		&booking.Status, &booking.TotalAmount, &booking.StartDate,
-		&booking.EndDate, &booking.Notes, &booking.CreatedAt, &booking.UpdatedAt)
+	// Users other than admins only see bookings they are the customer or car owner of
+	query := `SELECT ` + bookingColumns + `
+	         FROM booking WHERE id = $1 AND ($2::uuid IS NULL OR operator_id = $2)
+	           AND ($3::uuid IS NULL OR customer_id = $3 OR owner_id = $3)`
 
+	booking, err := scanBooking(s.db.QueryRowContext(ctx, query, id, tenant.Scope(ctx), identity.Scope(ctx)))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return models.Booking{}, errors.New("no booking found with the given ID")
@@ -52,22 +83,18 @@ func (s BookingStore) GetBookingsByCustomerID(ctx context.Context, customerID st
 
 	var bookings []models.Booking
 
-	query := `SELECT id, customer_id, car_id, owner_id, status, total_amount, 
-	         start_date, end_date, notes, created_at, updated_at 
-	         FROM booking WHERE customer_id = $1 ORDER BY created_at DESC`
+	query := `SELECT ` + bookingColumns + `
+	         FROM booking WHERE customer_id = $1 AND ($2::uuid IS NULL OR operator_id = $2)
+	         ORDER BY created_at DESC`
 
-	rows, err := s.db.QueryContext(ctx, query, customerID)
+	rows, err := s.db.QueryContext(ctx, query, customerID, tenant.Scope(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var booking models.Booking
-		err = rows.Scan(&booking.ID, &booking.CustomerID, &booking.CarID, &booking.OwnerID,
-			&booking.Status, &booking.TotalAmount, &booking.StartDate,
-			&booking.EndDate, &booking.Notes, &booking.CreatedAt, &booking.UpdatedAt)
-
+		booking, err := scanBooking(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -84,22 +111,14 @@ func (s BookingStore) GetBookingsByCarID(ctx context.Context, carID string) ([]m
 
 	var bookings []models.Booking
 
-	query := `SELECT id, customer_id, car_id, owner_id, status, total_amount, 
-	         start_date, end_date, notes, created_at, updated_at 
-	         FROM booking WHERE car_id = $1 ORDER BY created_at DESC`
-
-	rows, err := s.db.QueryContext(ctx, query, carID)
+	rows, err := s.getBookingsByCarID.QueryContext(ctx, carID, tenant.Scope(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var booking models.Booking
-		err = rows.Scan(&booking.ID, &booking.CustomerID, &booking.CarID, &booking.OwnerID,
-			&booking.Status, &booking.TotalAmount, &booking.StartDate,
-			&booking.EndDate, &booking.Notes, &booking.CreatedAt, &booking.UpdatedAt)
-
+		booking, err := scanBooking(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -116,22 +135,18 @@ func (s BookingStore) GetBookingsByOwnerID(ctx context.Context, ownerID string)
 
 	var bookings []models.Booking
 
-	query := `SELECT id, customer_id, car_id, owner_id, status, total_amount, 
-	         start_date, end_date, notes, created_at, updated_at 
-	         FROM booking WHERE owner_id = $1 ORDER BY created_at DESC`
+	query := `SELECT ` + bookingColumns + `
+	         FROM booking WHERE owner_id = $1 AND ($2::uuid IS NULL OR operator_id = $2)
+	         ORDER BY created_at DESC`
 
-	rows, err := s.db.QueryContext(ctx, query, ownerID)
+	rows, err := s.db.QueryContext(ctx, query, ownerID, tenant.Scope(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var booking models.Booking
-		err = rows.Scan(&booking.ID, &booking.CustomerID, &booking.CarID, &booking.OwnerID,
-			&booking.Status, &booking.TotalAmount, &booking.StartDate,
-			&booking.EndDate, &booking.Notes, &booking.CreatedAt, &booking.UpdatedAt)
-
+		booking, err := scanBooking(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -141,7 +156,7 @@ func (s BookingStore) GetBookingsByOwnerID(ctx context.Context, ownerID string)
 	return bookings, nil
 }
 
-func (s BookingStore) CreateBooking(ctx context.Context, bookingReq models.BookingRequest, totalAmount float64) (models.Booking, error) {
+func (s BookingStore) CreateBooking(ctx context.Context, bookingReq models.BookingRequest, quote models.BookingQuote) (models.Booking, error) {
 	tracer := otel.Tracer("BookingStore")
 	ctx, span := tracer.Start(ctx, "CreateBooking-Store")
 	defer span.End()
@@ -161,29 +176,53 @@ func (s BookingStore) CreateBooking(ctx context.Context, bookingReq models.Booki
 		err = tx.Commit()
 	}()
 
+	// Bookings belong to the operator of the booked car
 	// Generate new UUID for booking
 	bookingId := uuid.New()
 	createdAt := time.Now()
 	updatedAt := createdAt
 
 	query := `INSERT INTO booking (id, customer_id, car_id, owner_id, status, total_amount, 
-	         start_date, end_date, notes, created_at, updated_at)
-	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-	         RETURNING id, customer_id, car_id, owner_id, status, total_amount, 
-	         start_date, end_date, notes, created_at, updated_at`
-
-	err = tx.QueryRowContext(ctx, query, bookingId, bookingReq.CustomerID, bookingReq.CarID,
-		bookingReq.OwnerID, models.BookingStatusPending, totalAmount,
-		bookingReq.StartDate, bookingReq.EndDate, bookingReq.Notes, createdAt, updatedAt).Scan(
-		&createdBooking.ID, &createdBooking.CustomerID, &createdBooking.CarID, &createdBooking.OwnerID,
-		&createdBooking.Status, &createdBooking.TotalAmount,
-		&createdBooking.StartDate, &createdBooking.EndDate, &createdBooking.Notes,
-		&createdBooking.CreatedAt, &createdBooking.UpdatedAt)
+	         start_date, end_date, notes, created_at, updated_at,
+	         pickup_location_id, dropoff_location_id, pickup_fee, dropoff_fee,
+	         delivery_address, delivery_latitude, delivery_longitude, delivery_distance_km, delivery_fee,
+	         relocation_fee, add_ons, add_ons_fee, prepaid_fuel_fee, operator_id, car_snapshot,
+	         customer_snapshot, owner_snapshot, parent_booking_id)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24,
+	                 (SELECT operator_id FROM car WHERE id = $3), (` + carSnapshotQuery + `),
+	                 (` + userSnapshotQuery + `$2), (` + userSnapshotQuery + `$4), $25)
+	         RETURNING ` + bookingColumns
+
+	var deliveryAddress sql.NullString
+	var deliveryLatitude, deliveryLongitude, deliveryDistance sql.NullFloat64
+	if d := quote.Delivery; d != nil {
+		deliveryAddress = sql.NullString{String: d.Address, Valid: true}
+		deliveryLatitude = sql.NullFloat64{Float64: d.Latitude, Valid: true}
+		deliveryLongitude = sql.NullFloat64{Float64: d.Longitude, Valid: true}
+		deliveryDistance = sql.NullFloat64{Float64: d.DistanceKm, Valid: true}
+	}
 
+	addOns := quote.AddOns
+	if addOns == nil {
+		addOns = []models.BookingAddOn{}
+	}
+	addOnsJSON, err := json.Marshal(addOns)
 	if err != nil {
 		return models.Booking{}, err
 	}
 
+	price := quote.Price
+	createdBooking, err = scanBooking(tx.QueryRowContext(ctx, query, bookingId, bookingReq.CustomerID, bookingReq.CarID,
+		bookingReq.OwnerID, models.BookingStatusPending, price.Total,
+		bookingReq.StartDate, bookingReq.EndDate, bookingReq.Notes, createdAt, updatedAt,
+		bookingReq.PickupLocationID, bookingReq.DropoffLocationID, price.PickupFee, price.DropoffFee,
+		deliveryAddress, deliveryLatitude, deliveryLongitude, deliveryDistance, price.DeliveryFee,
+		price.RelocationFee, addOnsJSON, price.AddOnsFee, price.PrepaidFuelFee, bookingReq.ParentBookingID))
+
+	if err != nil {
+		return models.Booking{}, overlapError(err)
+	}
+
 	return createdBooking, nil
 }
 
@@ -207,21 +246,27 @@ func (s BookingStore) UpdateBookingStatus(ctx context.Context, id string, status
 		err = tx.Commit()
 	}()
 
-	query := `UPDATE booking SET status = $1, updated_at = $2 WHERE id = $3 
-	         RETURNING id, customer_id, car_id, owner_id, status, total_amount, 
-	         start_date, end_date, notes, created_at, updated_at`
+	query := `UPDATE booking SET status = $1, updated_at = $2 WHERE id = $3 AND ($4::uuid IS NULL OR operator_id = $4)
+	         RETURNING ` + bookingColumns
 
-	err = tx.QueryRowContext(ctx, query, status, time.Now(), id).Scan(
-		&updatedBooking.ID, &updatedBooking.CustomerID, &updatedBooking.CarID, &updatedBooking.OwnerID,
-		&updatedBooking.Status, &updatedBooking.TotalAmount,
-		&updatedBooking.StartDate, &updatedBooking.EndDate, &updatedBooking.Notes,
-		&updatedBooking.CreatedAt, &updatedBooking.UpdatedAt)
+	updatedBooking, err = scanBooking(tx.QueryRowContext(ctx, query, status, time.Now(), id, tenant.Scope(ctx)))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return models.Booking{}, errors.New("no booking found with the given ID")
 		}
-		return models.Booking{}, err
+		return models.Booking{}, overlapError(err)
+	}
+
+	// A completed rental leaves the car in the city of its drop-off location, which matters
+	// for one-way rentals that end in another city
+	if status == models.BookingStatusCompleted && updatedBooking.DropoffLocationID != nil {
+		_, err = tx.ExecContext(ctx, `UPDATE car SET location_city = l.city, updated_at = $1
+		         FROM location l WHERE l.id = $2 AND car.id = $3`,
+			time.Now(), *updatedBooking.DropoffLocationID, updatedBooking.CarID)
+		if err != nil {
+			return models.Booking{}, err
+		}
 	}
 
 	return updatedBooking, nil
@@ -248,14 +293,10 @@ func (s BookingStore) DeleteBooking(ctx context.Context, id string) (models.Book
 	}()
 
 	// First get the booking data before deleting
-	query := `SELECT id, customer_id, car_id, owner_id, status, total_amount, 
-	         start_date, end_date, notes, created_at, updated_at 
-	         FROM booking WHERE id = $1`
+	query := `SELECT ` + bookingColumns + `
+	         FROM booking WHERE id = $1 AND ($2::uuid IS NULL OR operator_id = $2)`
 
-	err = tx.QueryRowContext(ctx, query, id).Scan(&deletedBooking.ID, &deletedBooking.CustomerID,
-		&deletedBooking.CarID, &deletedBooking.OwnerID, &deletedBooking.Status,
-		&deletedBooking.TotalAmount, &deletedBooking.StartDate, &deletedBooking.EndDate,
-		&deletedBooking.Notes, &deletedBooking.CreatedAt, &deletedBooking.UpdatedAt)
+	deletedBooking, err = scanBooking(tx.QueryRowContext(ctx, query, id, tenant.Scope(ctx)))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -267,7 +308,7 @@ func (s BookingStore) DeleteBooking(ctx context.Context, id string) (models.Book
 	// Now delete the booking
 	result, err := tx.ExecContext(ctx, "DELETE FROM booking WHERE id = $1", id)
 	if err != nil {
-		return models.Booking{}, err
+		return models.Booking{}, store.ConstraintViolation(err, store.ErrBookingHasPayments)
 	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
@@ -280,34 +321,446 @@ func (s BookingStore) DeleteBooking(ctx context.Context, id string) (models.Book
 	return deletedBooking, nil
 }
 
-func (s BookingStore) GetAllBookings(ctx context.Context) ([]models.Booking, error) {
+// ListBookings retrieves one page of bookings matching the filter, newest first.
+// It fetches page.Limit+1 rows so the caller can tell whether another page exists.
+func (s BookingStore) ListBookings(ctx context.Context, filter models.BookingFilter, page models.PageRequest) ([]models.Booking, error) {
 	tracer := otel.Tracer("BookingStore")
-	ctx, span := tracer.Start(ctx, "GetAllBookings-Store")
+	ctx, span := tracer.Start(ctx, "ListBookings-Store")
 	defer span.End()
 
 	var bookings []models.Booking
+	var conditions []string
+	var args []interface{}
+
+	if operatorID := tenant.Scope(ctx); operatorID != nil {
+		args = append(args, *operatorID)
+		conditions = append(conditions, fmt.Sprintf("operator_id = $%d", len(args)))
+	}
+	if filter.CustomerID != "" {
+		args = append(args, filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+	if filter.CarID != "" {
+		args = append(args, filter.CarID)
+		conditions = append(conditions, fmt.Sprintf("car_id = $%d", len(args)))
+	}
+	if filter.OwnerID != "" {
+		args = append(args, filter.OwnerID)
+		conditions = append(conditions, fmt.Sprintf("owner_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	// Keyset condition: continue strictly past the cursor in its direction of travel
+	if page.Cursor != nil {
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) %s ($%d, $%d)", page.KeysetComparator(), len(args)-1, len(args)))
+	}
 
-	query := `SELECT id, customer_id, car_id, owner_id, status, total_amount, 
-	         start_date, end_date, notes, created_at, updated_at 
-	         FROM booking ORDER BY created_at DESC`
+	query := `SELECT ` + bookingColumns + `
+	         FROM booking`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, page.Limit+1)
+	query += fmt.Sprintf(" ORDER BY created_at %[1]s, id %[1]s LIMIT $%[2]d", page.SortOrder(), len(args))
 
-	rows, err := s.db.QueryContext(ctx, query)
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var booking models.Booking
-		err = rows.Scan(&booking.ID, &booking.CustomerID, &booking.CarID, &booking.OwnerID,
-			&booking.Status, &booking.TotalAmount, &booking.StartDate,
-			&booking.EndDate, &booking.Notes, &booking.CreatedAt, &booking.UpdatedAt)
-
+		booking, err := scanBooking(rows)
 		if err != nil {
 			return nil, err
 		}
 		bookings = append(bookings, booking)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
 	return bookings, nil
 }
+
+// ListActiveTrips retrieves the owner's bookings whose car is currently out with the customer,
+// those due back first
+func (s BookingStore) ListActiveTrips(ctx context.Context, ownerID string) ([]models.Booking, error) {
+	tracer := otel.Tracer("BookingStore")
+	ctx, span := tracer.Start(ctx, "ListActiveTrips-Store")
+	defer span.End()
+
+	query := `SELECT ` + bookingColumns + `
+	         FROM booking WHERE owner_id = $1 AND status = $2 AND ($3::uuid IS NULL OR operator_id = $3)
+	         ORDER BY end_date, id`
+
+	rows, err := s.db.QueryContext(ctx, query, ownerID, models.BookingStatusInProgress, tenant.Scope(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookings []models.Booking
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, rows.Err()
+}
+
+// tripEnd is when a booking's trip is due back: its end date, or the end of its latest
+// confirmed extension (models.Booking.TripEnd)
+const tripEnd = `GREATEST(booking.end_date, (SELECT MAX(e.end_date) FROM booking e
+	WHERE e.parent_booking_id = booking.id AND e.status = 'confirmed'))`
+
+// MarkOverdueTrips flags the trips still in progress after their end date, or that of their
+// latest confirmed extension, and returns the ones flagged by this call. Trips already flagged
+// are not returned again.
+func (s BookingStore) MarkOverdueTrips(ctx context.Context, now time.Time) ([]models.Booking, error) {
+	tracer := otel.Tracer("BookingStore")
+	ctx, span := tracer.Start(ctx, "MarkOverdueTrips-Store")
+	defer span.End()
+
+	query := `UPDATE booking SET overdue_at = $1
+	         WHERE status = $2 AND ` + tripEnd + ` < $1 AND overdue_at IS NULL
+	         RETURNING ` + bookingColumns
+
+	rows, err := s.db.QueryContext(ctx, query, now, models.BookingStatusInProgress)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookings []models.Booking
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, rows.Err()
+}
+
+// MarkHandBackReminders flags the trips in progress that are due back between now and dueBefore
+// and whose customer has not been reminded of that end yet, and returns the ones flagged by this
+// call. Extending a trip moves its end, so the customer is reminded again before the new one.
+func (s BookingStore) MarkHandBackReminders(ctx context.Context, dueBefore, now time.Time) ([]models.Booking, error) {
+	tracer := otel.Tracer("BookingStore")
+	ctx, span := tracer.Start(ctx, "MarkHandBackReminders-Store")
+	defer span.End()
+
+	query := `UPDATE booking SET handback_reminded_for = ` + tripEnd + `
+	         WHERE status = 'in_progress' AND ` + tripEnd + ` BETWEEN $2 AND $1
+	           AND handback_reminded_for IS DISTINCT FROM ` + tripEnd + `
+	         RETURNING ` + bookingColumns
+
+	return s.queryBookings(ctx, query, dueBefore, now)
+}
+
+// GetBookingExtensions retrieves the extensions of a trip, earliest first
+func (s BookingStore) GetBookingExtensions(ctx context.Context, parentID string) ([]models.Booking, error) {
+	tracer := otel.Tracer("BookingStore")
+	ctx, span := tracer.Start(ctx, "GetBookingExtensions-Store")
+	defer span.End()
+
+	query := `SELECT ` + bookingColumns + `
+	         FROM booking WHERE parent_booking_id = $1
+	         ORDER BY start_date ASC`
+
+	return s.queryBookings(ctx, query, parentID)
+}
+
+// unpaidPending matches pending bookings created before $1 without a completed payment or a
+// payment held on the customer's card
+const unpaidPending = `status = 'pending' AND created_at < $1
+	AND NOT EXISTS (SELECT 1 FROM payment p WHERE p.booking_id = booking.id AND p.status IN ('completed', 'authorized'))`
+
+// MarkExpiringBookings flags the unpaid pending bookings created before createdBefore whose
+// customer has not been warned of their expiry yet, and returns the ones flagged by this call
+func (s BookingStore) MarkExpiringBookings(ctx context.Context, createdBefore, now time.Time) ([]models.Booking, error) {
+	tracer := otel.Tracer("BookingStore")
+	ctx, span := tracer.Start(ctx, "MarkExpiringBookings-Store")
+	defer span.End()
+
+	query := `UPDATE booking SET expiry_warned_at = $2
+	         WHERE ` + unpaidPending + ` AND expiry_warned_at IS NULL
+	         RETURNING ` + bookingColumns
+
+	return s.queryBookings(ctx, query, createdBefore, now)
+}
+
+// ExpireUnpaidBookings cancels the unpaid pending bookings created before createdBefore and
+// returns them
+func (s BookingStore) ExpireUnpaidBookings(ctx context.Context, createdBefore, now time.Time) ([]models.Booking, error) {
+	tracer := otel.Tracer("BookingStore")
+	ctx, span := tracer.Start(ctx, "ExpireUnpaidBookings-Store")
+	defer span.End()
+
+	query := `UPDATE booking SET status = 'cancelled', updated_at = $2
+	         WHERE ` + unpaidPending + `
+	         RETURNING ` + bookingColumns
+
+	return s.queryBookings(ctx, query, createdBefore, now)
+}
+
+// GetRepriceableBookings retrieves the pending bookings whose price can still change: those
+// with no payment under way, held or made. One booking is checked when bookingID is set.
+func (s BookingStore) GetRepriceableBookings(ctx context.Context, bookingID *uuid.UUID) ([]models.Booking, error) {
+	tracer := otel.Tracer("BookingStore")
+	ctx, span := tracer.Start(ctx, "GetRepriceableBookings-Store")
+	defer span.End()
+
+	query := `SELECT ` + bookingColumns + ` FROM booking
+	         WHERE status = 'pending' AND ($1::uuid IS NULL OR id = $1) AND ($2::uuid IS NULL OR operator_id = $2)
+	           AND NOT EXISTS (SELECT 1 FROM payment p WHERE p.booking_id = booking.id
+	               AND p.status IN ('pending', 'authorized', 'completed'))
+	         ORDER BY created_at`
+
+	return s.queryBookings(ctx, query, bookingID, tenant.Scope(ctx))
+}
+
+// UpdateBookingTotal reprices a pending booking from one total to another. It reports false,
+// changing nothing, when the booking is no longer pending or its total changed meanwhile.
+func (s BookingStore) UpdateBookingTotal(ctx context.Context, id uuid.UUID, from, to float64) (bool, error) {
+	tracer := otel.Tracer("BookingStore")
+	ctx, span := tracer.Start(ctx, "UpdateBookingTotal-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `UPDATE booking SET total_amount = $3, updated_at = $4
+	         WHERE id = $1 AND total_amount = $2 AND status = 'pending'`, id, from, to, time.Now())
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// queryBookings runs a query returning booking rows and scans them
+func (s BookingStore) queryBookings(ctx context.Context, query string, args ...interface{}) ([]models.Booking, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookings []models.Booking
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, rows.Err()
+}
+
+// AddReturnCharges adds the late return and refuel fees settled at check-in to a booking's
+// price breakdown and total
+func (s BookingStore) AddReturnCharges(ctx context.Context, id string, lateFee, refuelFee float64) (models.Booking, error) {
+	tracer := otel.Tracer("BookingStore")
+	ctx, span := tracer.Start(ctx, "AddReturnCharges-Store")
+	defer span.End()
+
+	query := `UPDATE booking SET late_fee = late_fee + $2, refuel_fee = refuel_fee + $3,
+	           total_amount = total_amount + $2 + $3, updated_at = $4
+	         WHERE id = $1
+	         RETURNING ` + bookingColumns
+
+	booking, err := scanBooking(s.db.QueryRowContext(ctx, query, id, lateFee, refuelFee, time.Now()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Booking{}, errors.New("no booking found with the given ID")
+		}
+		return models.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// inspectionColumns lists the columns read by every booking inspection query
+const inspectionColumns = `id, booking_id, kind, odometer_km, odometer_source, fuel_level, fuel_source,
+	notes, checklist_version, checklist, recorded_by, recorded_at`
+
+// CreateInspection stores a checkout or check-in record and moves the booking along with it:
+// checkout starts the trip (in_progress) and check-in completes it. A check-in odometer reading
+// also advances the car's mileage, so the listing stays current without manual edits.
+func (s BookingStore) CreateInspection(ctx context.Context, inspection models.BookingInspection, carID string) (models.BookingInspection, error) {
+	tracer := otel.Tracer("BookingStore")
+	ctx, span := tracer.Start(ctx, "CreateInspection-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.BookingInspection{}, err
+	}
+	defer tx.Rollback()
+
+	var checklistJSON []byte
+	if inspection.ChecklistVersion != nil {
+		if checklistJSON, err = json.Marshal(inspection.Checklist); err != nil {
+			return models.BookingInspection{}, err
+		}
+	}
+
+	query := `INSERT INTO booking_inspection (id, booking_id, kind, odometer_km, odometer_source, fuel_level,
+	         fuel_source, notes, checklist_version, checklist, recorded_by, recorded_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	         RETURNING ` + inspectionColumns
+
+	created, err := scanInspection(tx.QueryRowContext(ctx, query, uuid.New(), inspection.BookingID, inspection.Kind,
+		inspection.OdometerKm, inspection.OdometerSource, inspection.FuelLevel, inspection.FuelSource,
+		inspection.Notes, inspection.ChecklistVersion, checklistJSON, inspection.RecordedBy, time.Now()))
+	if err != nil {
+		if strings.Contains(err.Error(), "unique_booking_inspection_kind") {
+			return models.BookingInspection{}, fmt.Errorf("booking already has a %s record", inspection.Kind)
+		}
+		return models.BookingInspection{}, err
+	}
+
+	// Only a booking in the expected status moves, so bookings checked out before trips were
+	// tracked keep their status when checked in
+	from, to := models.BookingStatusConfirmed, models.BookingStatusInProgress
+	if inspection.Kind == models.InspectionKindCheckin {
+		from, to = models.BookingStatusInProgress, models.BookingStatusCompleted
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE booking SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`,
+		to, time.Now(), inspection.BookingID, from)
+	if err != nil {
+		return models.BookingInspection{}, err
+	}
+
+	if inspection.Kind == models.InspectionKindCheckin && inspection.OdometerKm != nil {
+		// Never move mileage backwards, e.g. when an old booking is checked in late
+		_, err = tx.ExecContext(ctx, `UPDATE car SET mileage = GREATEST(mileage, $1) WHERE id = $2`, *inspection.OdometerKm, carID)
+		if err != nil {
+			return models.BookingInspection{}, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.BookingInspection{}, err
+	}
+
+	return created, nil
+}
+
+// GetInspectionsByBookingID retrieves the checkout and check-in records of a booking, oldest first
+func (s BookingStore) GetInspectionsByBookingID(ctx context.Context, bookingID string) ([]models.BookingInspection, error) {
+	tracer := otel.Tracer("BookingStore")
+	ctx, span := tracer.Start(ctx, "GetInspectionsByBookingID-Store")
+	defer span.End()
+
+	query := `SELECT ` + inspectionColumns + ` FROM booking_inspection WHERE booking_id = $1 ORDER BY recorded_at`
+
+	rows, err := s.db.QueryContext(ctx, query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var inspections []models.BookingInspection
+	for rows.Next() {
+		inspection, err := scanInspection(rows)
+		if err != nil {
+			return nil, err
+		}
+		inspections = append(inspections, inspection)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return inspections, nil
+}
+
+// scanInspection reads one booking inspection row from a *sql.Row or *sql.Rows
+func scanInspection(row rowScanner) (models.BookingInspection, error) {
+	var inspection models.BookingInspection
+	var checklistJSON []byte
+	err := row.Scan(&inspection.ID, &inspection.BookingID, &inspection.Kind, &inspection.OdometerKm,
+		&inspection.OdometerSource, &inspection.FuelLevel, &inspection.FuelSource, &inspection.Notes,
+		&inspection.ChecklistVersion, &checklistJSON, &inspection.RecordedBy, &inspection.RecordedAt)
+	if err != nil {
+		return inspection, err
+	}
+	if len(checklistJSON) > 0 {
+		if err := json.Unmarshal(checklistJSON, &inspection.Checklist); err != nil {
+			return inspection, err
+		}
+	}
+	return inspection, nil
+}
+
+// scanBooking reads one booking row selected with bookingColumns.
+// The rental part of the price breakdown is whatever the location fees leave of the total.
+func scanBooking(row rowScanner) (models.Booking, error) {
+	var booking models.Booking
+	var deliveryAddress sql.NullString
+	var deliveryLatitude, deliveryLongitude, deliveryDistance sql.NullFloat64
+	var addOnsJSON, carSnapshotJSON, customerJSON, ownerJSON []byte
+	price := &booking.PriceBreakdown
+	err := row.Scan(&booking.ID, &booking.CustomerID, &booking.CarID, &booking.OwnerID,
+		&booking.Status, &booking.TotalAmount, &booking.StartDate,
+		&booking.EndDate, &booking.Notes, &booking.CreatedAt, &booking.UpdatedAt,
+		&booking.PickupLocationID, &booking.DropoffLocationID, &price.PickupFee, &price.DropoffFee,
+		&deliveryAddress, &deliveryLatitude, &deliveryLongitude, &deliveryDistance, &price.DeliveryFee,
+		&price.RelocationFee, &addOnsJSON, &price.AddOnsFee, &price.PrepaidFuelFee, &price.LateFee, &price.RefuelFee, &booking.OverdueAt,
+		&carSnapshotJSON, &customerJSON, &ownerJSON, &booking.ParentBookingID)
+	if err != nil {
+		return models.Booking{}, err
+	}
+
+	if len(carSnapshotJSON) > 0 {
+		if err := json.Unmarshal(carSnapshotJSON, &booking.Car); err != nil {
+			return models.Booking{}, err
+		}
+	}
+	if booking.Customer, err = models.ParseUserSnapshot(customerJSON); err != nil {
+		return models.Booking{}, err
+	}
+	if booking.Owner, err = models.ParseUserSnapshot(ownerJSON); err != nil {
+		return models.Booking{}, err
+	}
+
+	if len(addOnsJSON) > 0 {
+		if err := json.Unmarshal(addOnsJSON, &booking.AddOns); err != nil {
+			return models.Booking{}, err
+		}
+	}
+
+	if deliveryAddress.Valid {
+		booking.Delivery = &models.BookingDelivery{
+			Address:    deliveryAddress.String,
+			Latitude:   deliveryLatitude.Float64,
+			Longitude:  deliveryLongitude.Float64,
+			DistanceKm: deliveryDistance.Float64,
+		}
+	}
+
+	price.Total = booking.TotalAmount
+	price.RentalAmount = booking.TotalAmount - price.PickupFee - price.DropoffFee - price.DeliveryFee - price.RelocationFee - price.AddOnsFee -
+		price.PrepaidFuelFee - price.LateFee - price.RefuelFee
+	return booking, nil
+}
+
+// overlapError turns violations of the exclude_booking_car_period constraint, hit when a
+// concurrent request booked the car for an overlapping period first, into a message the
+// handler can map to 409
+func overlapError(err error) error {
+	if strings.Contains(err.Error(), "exclude_booking_car_period") {
+		return errors.New("booking overlaps another rental of this car that was just made")
+	}
+	return err
+}
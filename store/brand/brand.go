@@ -0,0 +1,116 @@
+package brand
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"go.opentelemetry.io/otel"
+)
+
+// BrandStore reads the car brand and model reference data
+type BrandStore struct {
+	db *sql.DB
+}
+
+// New creates a new brand store
+func New(db *sql.DB) BrandStore {
+	return BrandStore{db: db}
+}
+
+// ListBrands retrieves up to limit brands whose name starts with prefix, ordered by name
+func (s BrandStore) ListBrands(ctx context.Context, prefix string, limit int) ([]models.CarBrand, error) {
+	tracer := otel.Tracer("BrandStore")
+	ctx, span := tracer.Start(ctx, "ListBrands-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name, created_at FROM car_brand
+	         WHERE LOWER(name) LIKE $1 || '%' ORDER BY name LIMIT $2`, escapeLike(prefix), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	brands := []models.CarBrand{}
+	for rows.Next() {
+		var brand models.CarBrand
+		if err := rows.Scan(&brand.ID, &brand.Name, &brand.CreatedAt); err != nil {
+			return nil, err
+		}
+		brands = append(brands, brand)
+	}
+
+	return brands, rows.Err()
+}
+
+// ListModels retrieves up to limit models of a brand whose name starts with prefix, ordered by name
+func (s BrandStore) ListModels(ctx context.Context, brandID, prefix string, limit int) ([]models.CarModel, error) {
+	tracer := otel.Tracer("BrandStore")
+	ctx, span := tracer.Start(ctx, "ListModels-Store")
+	defer span.End()
+
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM car_brand WHERE id = $1)`, brandID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("no brand found with the given ID")
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, brand_id, name, created_at FROM car_model
+	         WHERE brand_id = $1 AND LOWER(name) LIKE $2 || '%' ORDER BY name LIMIT $3`, brandID, escapeLike(prefix), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	carModels := []models.CarModel{}
+	for rows.Next() {
+		var model models.CarModel
+		if err := rows.Scan(&model.ID, &model.BrandID, &model.Name, &model.CreatedAt); err != nil {
+			return nil, err
+		}
+		carModels = append(carModels, model)
+	}
+
+	return carModels, rows.Err()
+}
+
+// FindBrandModel looks up a brand and one of its models by name, ignoring case. The model is
+// nil when the brand exists but has no model of that name.
+func (s BrandStore) FindBrandModel(ctx context.Context, brand, model string) (*models.CarBrand, *models.CarModel, error) {
+	tracer := otel.Tracer("BrandStore")
+	ctx, span := tracer.Start(ctx, "FindBrandModel-Store")
+	defer span.End()
+
+	var b models.CarBrand
+	var modelID, modelName sql.NullString
+	var modelCreatedAt sql.NullTime
+	err := s.db.QueryRowContext(ctx, `SELECT b.id, b.name, b.created_at, m.id, m.name, m.created_at
+	         FROM car_brand b LEFT JOIN car_model m ON m.brand_id = b.id AND LOWER(m.name) = LOWER($2)
+	         WHERE LOWER(b.name) = LOWER($1)`, strings.TrimSpace(brand), strings.TrimSpace(model)).
+		Scan(&b.ID, &b.Name, &b.CreatedAt, &modelID, &modelName, &modelCreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	if !modelID.Valid {
+		return &b, nil, nil
+	}
+	m := models.CarModel{BrandID: b.ID, Name: modelName.String, CreatedAt: modelCreatedAt.Time}
+	if err := m.ID.Scan(modelID.String); err != nil {
+		return nil, nil, err
+	}
+	return &b, &m, nil
+}
+
+// escapeLike lowercases an autocomplete prefix and escapes the LIKE wildcards in it
+func escapeLike(prefix string) string {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+}
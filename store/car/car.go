@@ -5,20 +5,121 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"time"
 
+	"github.com/PrateekKumar15/CarZone/identity"
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/PrateekKumar15/CarZone/tenant"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"go.opentelemetry.io/otel"
 )
 
+// availableNowColumn derives is_available when a car is read: the owner has the car listed and
+// no booking holding it, vacation or fleet blackout covers the current moment. The stored flag is
+// only the owner's listing switch, read back as listed, so availability never drifts from
+// bookings however they change.
+const availableNowColumn = `(car.is_available
+	         AND NOT EXISTS (SELECT 1 FROM booking b WHERE b.car_id = car.id
+	             AND b.status IN ('pending', 'under_review', 'confirmed', 'in_progress')
+	             AND b.start_date <= NOW() AND b.end_date > NOW())
+	         AND NOT EXISTS (SELECT 1 FROM car_blackout cb WHERE cb.car_id = car.id
+	             AND cb.start_date <= NOW() AND cb.end_date > NOW())
+	         AND NOT EXISTS (SELECT 1 FROM fleet f, jsonb_array_elements(f.blackouts) fb WHERE f.id = car.fleet_id
+	             AND (fb->>'start_date')::timestamptz <= NOW() AND (fb->>'end_date')::timestamptz > NOW()))`
+
+// rankingJoins adds what ranked listings score cars on. Each derived table prefixes its columns
+// so the unqualified car columns of ListCars stay unambiguous:
+//   - trips: the car's completed trips, and those returned on time without a payment dispute
+//   - decisions: bookings the car's owner confirmed, against all confirmed or cancelled ones;
+//     cancellations do not record who cancelled, so all of them count against the owner
+//   - market: the median daily price of active cars in the car's city and operator
+const rankingJoins = `
+	LEFT JOIN (SELECT b.car_id AS trips_car_id, COUNT(*) AS trips_total,
+	        COUNT(*) FILTER (WHERE b.overdue_at IS NULL
+	            AND NOT EXISTS (SELECT 1 FROM dispute d WHERE d.booking_id = b.id)) AS trips_clean
+	     FROM booking b WHERE b.status = 'completed' GROUP BY b.car_id) trips ON trips_car_id = car.id
+	LEFT JOIN (SELECT b.owner_id AS decisions_owner_id, COUNT(*) AS decisions_total,
+	        COUNT(*) FILTER (WHERE b.status <> 'cancelled') AS decisions_accepted
+	     FROM booking b WHERE b.status IN ('confirmed', 'in_progress', 'completed', 'cancelled')
+	     GROUP BY b.owner_id) decisions ON decisions_owner_id = car.owner_id
+	LEFT JOIN (SELECT c.operator_id AS market_operator_id, c.location_city AS market_city,
+	        percentile_cont(0.5) WITHIN GROUP (ORDER BY c.price) AS market_median_price
+	     FROM car c WHERE c.status = 'active' GROUP BY c.operator_id, c.location_city) market
+	     ON market_operator_id = car.operator_id AND market_city = car.location_city`
+
+// carQualityFacts are the expressions of models.CarQualityFacts over the car table, in field order
+var carQualityFacts = []string{
+	`COALESCE(cardinality(car.images), 0)`,
+	`char_length(COALESCE(car.description, ''))`,
+	`COALESCE((car.engine->>'horsepower')::int, 0) > 0`,
+	`COALESCE(car.mileage, 0) > 0`,
+	`COALESCE(car.features, '{}'::jsonb) NOT IN ('{}'::jsonb, 'null'::jsonb)`,
+	`COALESCE(car.license_plate, '') <> ''`,
+	`(car.owner_id IS NULL OR EXISTS (SELECT 1 FROM user_kyc k WHERE k.user_id = car.owner_id))`,
+}
+
+// carQualitySignal is models.ScoreCarQuality in SQL, for ranking on listing quality
+var carQualitySignal = fmt.Sprintf(`(0.3 * LEAST(%[1]s, %[8]d) / %[8]d.0 + 0.25 * LEAST(%[2]s, %[9]d) / %[9]d.0
+	     + 0.25 * ((%[3]s)::int + (%[4]s)::int + (%[5]s)::int + (%[6]s)::int) / 4.0 + 0.2 * (%[7]s)::int)`,
+	carQualityFacts[0], carQualityFacts[1], carQualityFacts[2], carQualityFacts[3], carQualityFacts[4],
+	carQualityFacts[5], carQualityFacts[6], models.QualityPhotoTarget, models.QualityDescriptionTarget)
+
+// rankingSignals are the expressions of the ranking signals over rankingJoins, in
+// models.RankingSignals order, each between 0 and 1. Recency halves after 30 days; price
+// competitiveness is 0.5 at the city median, 1 for a free car and 0 at twice the median; photo
+// count saturates at five photos; featured is 1 while a paid placement runs; quality is the
+// listing's quality score.
+var rankingSignals = []string{
+	`1 / (1 + EXTRACT(EPOCH FROM NOW() - car.created_at) / 2592000)`,
+	`COALESCE(trips_clean::float / NULLIF(trips_total, 0), 0.5)`,
+	`COALESCE(decisions_accepted::float / NULLIF(decisions_total, 0), 0.5)`,
+	`COALESCE(GREATEST(0, LEAST(1, 0.5 + (market_median_price - car.price::float) / (2 * NULLIF(market_median_price, 0)))), 0.5)`,
+	`LEAST(COALESCE(cardinality(car.images), 0), 5) / 5.0`,
+	`CASE WHEN EXISTS (SELECT 1 FROM featured_listing fl WHERE fl.car_id = car.id AND fl.status = 'paid'
+	     AND fl.start_date <= NOW() AND fl.end_date > NOW()) THEN 1 ELSE 0 END`,
+	carQualitySignal,
+}
+
+// availableNowColumnJoined is availableNowColumn for queries reading the car table as c
+var availableNowColumnJoined = strings.ReplaceAll(availableNowColumn, "car.", "c.")
+
+// Lookups of a single car by ID run on every car page, booking and payment, so they are
+// prepared once when the store is created
+var (
+	getCarByIDQuery = `SELECT id, owner_id, name, model, year, brand, fuel_type, engine, location_city, 
+	         location_state, location_country, price, status, ` + availableNowColumn + `, is_available, 
+	         features, attributes, description, images, mileage, slug, created_at, updated_at 
+	         FROM car WHERE id = $1 AND ($2::uuid IS NULL OR operator_id = $2)`
+
+	// Join query to get car data with owner information (INNER JOIN since owner is mandatory)
+	getCarWithOwnerByIDQuery = `SELECT 
+		c.id, c.owner_id, c.name, c.model, c.year, c.brand, c.fuel_type, c.engine, 
+		c.location_city, c.location_state, c.location_country, c.price, c.status, ` + availableNowColumnJoined + `, c.is_available, c.features, c.attributes, c.description, c.images, 
+		c.mileage, c.slug, c.created_at, c.updated_at,
+		u.id, u.username, u.email, u.phone, u.role, u.profile_data, u.created_at, u.updated_at
+		FROM car c 
+		INNER JOIN users u ON c.owner_id = u.id 
+		WHERE c.id = $1 AND ($2::uuid IS NULL OR c.operator_id = $2)`
+)
+
 type CarStore struct {
 	db *sql.DB
+
+	getCarByID          *store.PreparedQuery
+	getCarWithOwnerByID *store.PreparedQuery
 }
 
 func New(db *sql.DB) CarStore {
-	return CarStore{db: db}
+	return CarStore{
+		db:                  db,
+		getCarByID:          store.Prepare(db, getCarByIDQuery),
+		getCarWithOwnerByID: store.Prepare(db, getCarWithOwnerByIDQuery),
+	}
 }
 
 func (s CarStore) GetCarByID(ctx context.Context, id string) (models.Car, error) {
@@ -27,19 +128,14 @@ func (s CarStore) GetCarByID(ctx context.Context, id string) (models.Car, error)
 	defer span.End()
 
 	var car models.Car
-	var engineJSON, featuresJSON []byte
+	var engineJSON, featuresJSON, attributesJSON []byte
 	var images pq.StringArray
 
-	query := `SELECT id, owner_id, name, model, year, brand, fuel_type, engine, location_city, 
-	         location_state, location_country, price, status, is_available, 
-	         features, description, images, mileage, created_at, updated_at 
-	         FROM car WHERE id = $1`
-
-	row := s.db.QueryRowContext(ctx, query, id)
+	row := s.getCarByID.QueryRowContext(ctx, id, tenant.Scope(ctx))
 	err := row.Scan(&car.ID, &car.OwnerID, &car.Name, &car.Model, &car.Year, &car.Brand,
 		&car.FuelType, &engineJSON, &car.LocationCity, &car.LocationState, &car.LocationCountry,
-		&car.Price, &car.Status, &car.IsAvailable, &featuresJSON,
-		&car.Description, &images, &car.Mileage, &car.CreatedAt, &car.UpdatedAt)
+		&car.Price, &car.Status, &car.IsAvailable, &car.Listed, &featuresJSON, &attributesJSON,
+		&car.Description, &images, &car.Mileage, &car.Slug, &car.CreatedAt, &car.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -56,6 +152,52 @@ func (s CarStore) GetCarByID(ctx context.Context, id string) (models.Car, error)
 	if err = json.Unmarshal(featuresJSON, &car.Features); err != nil {
 		return models.Car{}, err
 	}
+	if err = json.Unmarshal(attributesJSON, &car.Attributes); err != nil {
+		return models.Car{}, err
+	}
+	car.Images = []string(images)
+
+	return car, nil
+}
+
+// GetCarBySlug retrieves a car by its URL slug
+func (s CarStore) GetCarBySlug(ctx context.Context, slug string) (models.Car, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "GetCarBySlug-Store")
+	defer span.End()
+
+	var car models.Car
+	var engineJSON, featuresJSON, attributesJSON []byte
+	var images pq.StringArray
+
+	query := `SELECT id, owner_id, name, model, year, brand, fuel_type, engine, location_city, 
+	         location_state, location_country, price, status, ` + availableNowColumn + `, is_available, 
+	         features, attributes, description, images, mileage, slug, created_at, updated_at 
+	         FROM car WHERE slug = $1 AND ($2::uuid IS NULL OR operator_id = $2)`
+
+	row := s.db.QueryRowContext(ctx, query, slug, tenant.Scope(ctx))
+	err := row.Scan(&car.ID, &car.OwnerID, &car.Name, &car.Model, &car.Year, &car.Brand,
+		&car.FuelType, &engineJSON, &car.LocationCity, &car.LocationState, &car.LocationCountry,
+		&car.Price, &car.Status, &car.IsAvailable, &car.Listed, &featuresJSON, &attributesJSON,
+		&car.Description, &images, &car.Mileage, &car.Slug, &car.CreatedAt, &car.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Car{}, nil // No car found with the given slug
+		}
+		return models.Car{}, err
+	}
+
+	// Parse JSON fields
+	if err = json.Unmarshal(engineJSON, &car.Engine); err != nil {
+		return models.Car{}, err
+	}
+	if err = json.Unmarshal(featuresJSON, &car.Features); err != nil {
+		return models.Car{}, err
+	}
+	if err = json.Unmarshal(attributesJSON, &car.Attributes); err != nil {
+		return models.Car{}, err
+	}
 	car.Images = []string(images)
 
 	return car, nil
@@ -69,25 +211,15 @@ func (s CarStore) GetCarWithOwnerByID(ctx context.Context, id string) (models.Ca
 
 	var car models.Car
 	var owner models.User
-	var engineJSON, featuresJSON, ownerProfileDataJSON []byte
+	var engineJSON, featuresJSON, attributesJSON, ownerProfileDataJSON []byte
 	var images pq.StringArray
 
-	// Join query to get car data with owner information (INNER JOIN since owner is mandatory)
-	query := `SELECT 
-		c.id, c.owner_id, c.name, c.model, c.year, c.brand, c.fuel_type, c.engine, 
-		c.location_city, c.location_state, c.location_country, c.price, c.status, c.is_available, c.features, c.description, c.images, 
-		c.mileage, c.created_at, c.updated_at,
-		u.id, u.username, u.email, u.phone, u.role, u.profile_data, u.created_at, u.updated_at
-		FROM car c 
-		INNER JOIN users u ON c.owner_id = u.id 
-		WHERE c.id = $1`
-
-	row := s.db.QueryRowContext(ctx, query, id)
+	row := s.getCarWithOwnerByID.QueryRowContext(ctx, id, tenant.Scope(ctx))
 	err := row.Scan(
 		&car.ID, &car.OwnerID, &car.Name, &car.Model, &car.Year, &car.Brand,
 		&car.FuelType, &engineJSON, &car.LocationCity, &car.LocationState, &car.LocationCountry,
-		&car.Price, &car.Status, &car.IsAvailable, &featuresJSON,
-		&car.Description, &images, &car.Mileage, &car.CreatedAt, &car.UpdatedAt,
+		&car.Price, &car.Status, &car.IsAvailable, &car.Listed, &featuresJSON, &attributesJSON,
+		&car.Description, &images, &car.Mileage, &car.Slug, &car.CreatedAt, &car.UpdatedAt,
 		&owner.ID, &owner.UserName, &owner.Email, &owner.Phone, &owner.Role,
 		&ownerProfileDataJSON, &owner.CreatedAt, &owner.UpdatedAt)
 
@@ -105,6 +237,9 @@ func (s CarStore) GetCarWithOwnerByID(ctx context.Context, id string) (models.Ca
 	if err = json.Unmarshal(featuresJSON, &car.Features); err != nil {
 		return models.Car{}, err
 	}
+	if err = json.Unmarshal(attributesJSON, &car.Attributes); err != nil {
+		return models.Car{}, err
+	}
 	car.Images = []string(images)
 
 	// Parse owner profile data (owner is mandatory)
@@ -121,18 +256,21 @@ func (s CarStore) GetCarWithOwnerByID(ctx context.Context, id string) (models.Ca
 	return car, nil
 }
 
-func (s CarStore) GetCarByBrand(ctx context.Context, brand string) ([]models.Car, error) {
+// GetCarByBrand retrieves the cars of a brand ignoring case, optionally also those of similarly
+// spelled brands, ranked by how closely their brand matches
+func (s CarStore) GetCarByBrand(ctx context.Context, brand string, fuzzy bool) ([]models.Car, error) {
 	tracer := otel.Tracer("CarStore")
 	ctx, span := tracer.Start(ctx, "GetCarByBrand-Store")
 	defer span.End()
 
 	var cars []models.Car
 	query := `SELECT id, owner_id, name, model, year, brand, fuel_type, engine, location_city, 
-	         location_state, location_country, price, status, is_available, 
-	         features, description, images, mileage, created_at, updated_at 
-	         FROM car WHERE brand = $1`
+	         location_state, location_country, price, status, ` + availableNowColumn + `, is_available, 
+	         features, attributes, description, images, mileage, slug, created_at, updated_at 
+	         FROM car WHERE ` + brandCondition(1, fuzzy) + ` AND ($3::uuid IS NULL OR operator_id = $3)
+	         ORDER BY similarity(brand, $2) DESC, created_at DESC`
 
-	rows, err := s.db.QueryContext(ctx, query, brand)
+	rows, err := s.db.QueryContext(ctx, query, escapeLike(brand), strings.TrimSpace(brand), tenant.Scope(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -140,13 +278,13 @@ func (s CarStore) GetCarByBrand(ctx context.Context, brand string) ([]models.Car
 
 	for rows.Next() {
 		var car models.Car
-		var engineJSON, featuresJSON []byte
+		var engineJSON, featuresJSON, attributesJSON []byte
 		var images pq.StringArray
 
 		err = rows.Scan(&car.ID, &car.OwnerID, &car.Name, &car.Model, &car.Year, &car.Brand,
 			&car.FuelType, &engineJSON, &car.LocationCity, &car.LocationState, &car.LocationCountry,
-			&car.Price, &car.Status, &car.IsAvailable, &featuresJSON,
-			&car.Description, &images, &car.Mileage, &car.CreatedAt, &car.UpdatedAt)
+			&car.Price, &car.Status, &car.IsAvailable, &car.Listed, &featuresJSON, &attributesJSON,
+			&car.Description, &images, &car.Mileage, &car.Slug, &car.CreatedAt, &car.UpdatedAt)
 
 		if err != nil {
 			return nil, err
@@ -160,6 +298,9 @@ func (s CarStore) GetCarByBrand(ctx context.Context, brand string) ([]models.Car
 		if err = json.Unmarshal(featuresJSON, &car.Features); err != nil {
 			return nil, err
 		}
+		if err = json.Unmarshal(attributesJSON, &car.Attributes); err != nil {
+			return nil, err
+		}
 		car.Images = []string(images)
 
 		cars = append(cars, car)
@@ -191,6 +332,10 @@ func (s CarStore) CreateCar(ctx context.Context, carReq models.CarRequest) (mode
 	if err != nil {
 		return models.Car{}, err
 	}
+	attributesJSON, err := json.Marshal(carReq.Attributes)
+	if err != nil {
+		return models.Car{}, err
+	}
 	images := pq.StringArray(carReq.Images)
 
 	// Begin transaction
@@ -208,26 +353,34 @@ func (s CarStore) CreateCar(ctx context.Context, carReq models.CarRequest) (mode
 
 	query := `INSERT INTO car (id, owner_id, name, model, year, brand, fuel_type, engine, 
 	         location_city, location_state, location_country, price, status,
-	         is_available, features, description, images, mileage, created_at, updated_at) 
-	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+	         is_available, features, description, images, mileage, created_at, updated_at, license_plate, operator_id, attributes) 
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, NULLIF($21, ''), $22, $23)
 	         RETURNING id, owner_id, name, model, year, brand, fuel_type, engine, location_city, 
-	         location_state, location_country, price, status, is_available, 
-	         features, description, images, mileage, created_at, updated_at`
+	         location_state, location_country, price, status, ` + availableNowColumn + `, is_available, 
+	         features, attributes, description, images, mileage, slug, created_at, updated_at`
 
-	var returnedEngineJSON, returnedPriceJSON, returnedFeaturesJSON []byte
+	var returnedEngineJSON, returnedPriceJSON, returnedFeaturesJSON, returnedAttributesJSON []byte
 	var returnedImages pq.StringArray
 
 	err = tx.QueryRowContext(ctx, query, carId, carReq.OwnerID, carReq.Name, carReq.Model, carReq.Year,
 		carReq.Brand, carReq.FuelType, engineJSON, carReq.LocationCity, carReq.LocationState,
 		carReq.LocationCountry, carReq.Price, carReq.Status, carReq.IsAvailable,
-		featuresJSON, carReq.Description, images, carReq.Mileage, createdAt, updatedAt).Scan(
+		featuresJSON, carReq.Description, images, carReq.Mileage, createdAt, updatedAt,
+		models.NormalizeLicensePlate(carReq.LicensePlate), tenant.ForInsert(ctx), attributesJSON).Scan(
 		&createdCar.ID, &createdCar.OwnerID, &createdCar.Name, &createdCar.Model, &createdCar.Year,
 		&createdCar.Brand, &createdCar.FuelType, &returnedEngineJSON, &createdCar.LocationCity,
 		&createdCar.LocationState, &createdCar.LocationCountry, &returnedPriceJSON, &createdCar.Status,
-		&createdCar.IsAvailable, &returnedFeaturesJSON,
-		&createdCar.Description, &returnedImages, &createdCar.Mileage, &createdCar.CreatedAt, &createdCar.UpdatedAt)
+		&createdCar.IsAvailable, &createdCar.Listed, &returnedFeaturesJSON, &returnedAttributesJSON,
+		&createdCar.Description, &returnedImages, &createdCar.Mileage, &createdCar.Slug, &createdCar.CreatedAt, &createdCar.UpdatedAt)
 
 	if err != nil {
+		if strings.Contains(err.Error(), "unique_car_license_plate") {
+			err = errors.New("a car with this license plate already exists")
+		}
+		return models.Car{}, err
+	}
+
+	if err = recordPriceChange(ctx, tx, createdCar.ID, nil, carReq.Price, createdAt); err != nil {
 		return models.Car{}, err
 	}
 
@@ -238,6 +391,9 @@ func (s CarStore) CreateCar(ctx context.Context, carReq models.CarRequest) (mode
 	if err = json.Unmarshal(returnedFeaturesJSON, &createdCar.Features); err != nil {
 		return models.Car{}, err
 	}
+	if err = json.Unmarshal(returnedAttributesJSON, &createdCar.Attributes); err != nil {
+		return models.Car{}, err
+	}
 	createdCar.Images = []string(returnedImages)
 
 	return createdCar, nil
@@ -260,6 +416,10 @@ func (s CarStore) UpdateCar(ctx context.Context, id string, carReq models.CarReq
 	if err != nil {
 		return models.Car{}, err
 	}
+	attributesJSON, err := json.Marshal(carReq.Attributes)
+	if err != nil {
+		return models.Car{}, err
+	}
 	images := pq.StringArray(carReq.Images)
 
 	// Begin transaction
@@ -275,30 +435,51 @@ func (s CarStore) UpdateCar(ctx context.Context, id string, carReq models.CarReq
 		err = tx.Commit()
 	}()
 
+	// Locking the row orders concurrent updates, so each records the price it replaced
+	var previousPrice float64
+	err = tx.QueryRowContext(ctx, `SELECT price FROM car WHERE id = $1 AND ($2::uuid IS NULL OR operator_id = $2) FOR UPDATE`,
+		id, tenant.Scope(ctx)).Scan(&previousPrice)
+	if err != nil {
+		return models.Car{}, err
+	}
+
 	query := `UPDATE car SET owner_id = $1, name = $2, model = $3, year = $4, brand = $5, fuel_type = $6, 
 	         engine = $7, location_city = $8, location_state = $9, location_country = $10, price = $11, 
-	         status = $12, is_available = $13, features = $14, description = $15, 
-	         images = $16, mileage = $17, updated_at = $18 WHERE id = $19 
+	         status = $12, is_available = $13, features = $14, attributes = $22, description = $15, 
+	         images = $16, mileage = $17, updated_at = $18,
+	         license_plate = COALESCE(NULLIF($20, ''), license_plate)
+	         WHERE id = $19 AND ($21::uuid IS NULL OR operator_id = $21) 
 	         RETURNING id, owner_id, name, model, year, brand, fuel_type, engine, location_city, 
-	         location_state, location_country, price, status, is_available, 
-	         features, description, images, mileage, created_at, updated_at`
+	         location_state, location_country, price, status, ` + availableNowColumn + `, is_available, 
+	         features, attributes, description, images, mileage, slug, created_at, updated_at`
 
-	var returnedEngineJSON, returnedPriceJSON, returnedFeaturesJSON []byte
+	var returnedEngineJSON, returnedPriceJSON, returnedFeaturesJSON, returnedAttributesJSON []byte
 	var returnedImages pq.StringArray
 
 	err = tx.QueryRowContext(ctx, query, carReq.OwnerID, carReq.Name, carReq.Model, carReq.Year,
 		carReq.Brand, carReq.FuelType, engineJSON, carReq.LocationCity, carReq.LocationState,
 		carReq.LocationCountry, carReq.Price, carReq.Status, carReq.IsAvailable,
-		featuresJSON, carReq.Description, images, carReq.Mileage, time.Now(), id).Scan(
+		featuresJSON, carReq.Description, images, carReq.Mileage, time.Now(), id,
+		models.NormalizeLicensePlate(carReq.LicensePlate), tenant.Scope(ctx), attributesJSON).Scan(
 		&updatedCar.ID, &updatedCar.OwnerID, &updatedCar.Name, &updatedCar.Model, &updatedCar.Year,
 		&updatedCar.Brand, &updatedCar.FuelType, &returnedEngineJSON, &updatedCar.LocationCity,
-		&updatedCar.LocationState, &updatedCar.LocationCountry, &returnedPriceJSON, &updatedCar.Status, &updatedCar.IsAvailable, &returnedFeaturesJSON,
-		&updatedCar.Description, &returnedImages, &updatedCar.Mileage, &updatedCar.CreatedAt, &updatedCar.UpdatedAt)
+		&updatedCar.LocationState, &updatedCar.LocationCountry, &returnedPriceJSON, &updatedCar.Status, &updatedCar.IsAvailable, &updatedCar.Listed, &returnedFeaturesJSON, &returnedAttributesJSON,
+		&updatedCar.Description, &returnedImages, &updatedCar.Mileage, &updatedCar.Slug, &updatedCar.CreatedAt, &updatedCar.UpdatedAt)
 
 	if err != nil {
+		if strings.Contains(err.Error(), "unique_car_license_plate") {
+			err = errors.New("a car with this license plate already exists")
+		}
 		return models.Car{}, err
 	}
 
+	// Prices are stored to two decimals, so smaller differences are not a change
+	if math.Round(carReq.Price*100) != math.Round(previousPrice*100) {
+		if err = recordPriceChange(ctx, tx, updatedCar.ID, &previousPrice, carReq.Price, updatedCar.UpdatedAt); err != nil {
+			return models.Car{}, err
+		}
+	}
+
 	// Parse returned JSON fields
 	if err = json.Unmarshal(returnedEngineJSON, &updatedCar.Engine); err != nil {
 		return models.Car{}, err
@@ -306,6 +487,9 @@ func (s CarStore) UpdateCar(ctx context.Context, id string, carReq models.CarReq
 	if err = json.Unmarshal(returnedFeaturesJSON, &updatedCar.Features); err != nil {
 		return models.Car{}, err
 	}
+	if err = json.Unmarshal(returnedAttributesJSON, &updatedCar.Attributes); err != nil {
+		return models.Car{}, err
+	}
 	updatedCar.Images = []string(returnedImages)
 
 	return updatedCar, nil
@@ -333,18 +517,18 @@ func (s CarStore) DeleteCar(ctx context.Context, id string) (models.Car, error)
 
 	// First get the car data before deleting
 	query := `SELECT id, owner_id, name, model, year, brand, fuel_type, engine, location_city, 
-	         location_state, location_country, price, status, is_available, 
-	         features, description, images, mileage, created_at, updated_at 
-	         FROM car WHERE id = $1`
+	         location_state, location_country, price, status, ` + availableNowColumn + `, is_available, 
+	         features, attributes, description, images, mileage, slug, created_at, updated_at 
+	         FROM car WHERE id = $1 AND ($2::uuid IS NULL OR operator_id = $2)`
 
-	var engineJSON, featuresJSON []byte
+	var engineJSON, featuresJSON, attributesJSON []byte
 	var images pq.StringArray
 
-	err = tx.QueryRowContext(ctx, query, id).Scan(&deletedCar.ID, &deletedCar.OwnerID, &deletedCar.Name,
+	err = tx.QueryRowContext(ctx, query, id, tenant.Scope(ctx)).Scan(&deletedCar.ID, &deletedCar.OwnerID, &deletedCar.Name,
 		&deletedCar.Model, &deletedCar.Year, &deletedCar.Brand, &deletedCar.FuelType, &engineJSON,
 		&deletedCar.LocationCity, &deletedCar.LocationState, &deletedCar.LocationCountry, &deletedCar.Price,
-		&deletedCar.Status, &deletedCar.IsAvailable, &featuresJSON,
-		&deletedCar.Description, &images, &deletedCar.Mileage, &deletedCar.CreatedAt, &deletedCar.UpdatedAt)
+		&deletedCar.Status, &deletedCar.IsAvailable, &deletedCar.Listed, &featuresJSON, &attributesJSON,
+		&deletedCar.Description, &images, &deletedCar.Mileage, &deletedCar.Slug, &deletedCar.CreatedAt, &deletedCar.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -362,7 +546,7 @@ func (s CarStore) DeleteCar(ctx context.Context, id string) (models.Car, error)
 	// Now delete the car
 	result, err := tx.ExecContext(ctx, "DELETE FROM car WHERE id = $1", id)
 	if err != nil {
-		return models.Car{}, err
+		return models.Car{}, store.ConstraintViolation(err, store.ErrCarHasBookings)
 	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
@@ -375,19 +559,52 @@ func (s CarStore) DeleteCar(ctx context.Context, id string) (models.Car, error)
 	return deletedCar, nil
 }
 
-func (s CarStore) GetAllCars(ctx context.Context) ([]models.Car, error) {
+// ListCars retrieves one page of cars matching the filter, ordered from newest to oldest.
+// It fetches page.Limit+1 rows so the caller can tell whether another page exists.
+func (s CarStore) ListCars(ctx context.Context, filter models.CarFilter, page models.PageRequest) ([]models.Car, error) {
 	tracer := otel.Tracer("CarStore")
-	ctx, span := tracer.Start(ctx, "GetAllCars-Store")
+	ctx, span := tracer.Start(ctx, "ListCars-Store")
 	defer span.End()
 
 	var cars []models.Car
 
 	query := `SELECT id, owner_id, name, model, year, brand, fuel_type, engine, location_city, 
-	         location_state, location_country, price, status, is_available, 
-	         features, description, images, mileage, created_at, updated_at 
-	         FROM car`
+	         location_state, location_country, price, status, ` + availableNowColumn + `, is_available, 
+	         features, attributes, description, images, mileage, slug, created_at, updated_at`
+	ranked := filter.Ranking != nil
+	if ranked {
+		query += ", " + strings.Join(rankingSignals, ", ") + " FROM car" + rankingJoins
+	} else {
+		query += " FROM car"
+	}
 
-	rows, err := s.db.QueryContext(ctx, query)
+	conditions, args, err := filterConditions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	// Keyset condition: continue strictly past the cursor in its direction of travel. Ranked
+	// listings are a single page, as scores change as cars age and get booked.
+	if page.Cursor != nil && !ranked {
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) %s ($%d, $%d)", page.KeysetComparator(), len(args)-1, len(args)))
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if ranked {
+		var score []string
+		for i, weight := range filter.Ranking.Values() {
+			args = append(args, weight)
+			score = append(score, fmt.Sprintf("$%d::float8 * %s", len(args), rankingSignals[i]))
+		}
+		args = append(args, page.Limit+1)
+		query += fmt.Sprintf(" ORDER BY %s DESC, created_at DESC, id DESC LIMIT $%d", strings.Join(score, " + "), len(args))
+	} else {
+		args = append(args, page.Limit+1)
+		query += fmt.Sprintf(" ORDER BY created_at %[1]s, id %[1]s LIMIT $%[2]d", page.SortOrder(), len(args))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -395,17 +612,28 @@ func (s CarStore) GetAllCars(ctx context.Context) ([]models.Car, error) {
 
 	for rows.Next() {
 		var car models.Car
-		var engineJSON, featuresJSON []byte
+		var engineJSON, featuresJSON, attributesJSON []byte
 		var images pq.StringArray
+		var signals models.RankingSignals
 
-		err = rows.Scan(&car.ID, &car.OwnerID, &car.Name, &car.Model, &car.Year, &car.Brand,
+		dest := []interface{}{&car.ID, &car.OwnerID, &car.Name, &car.Model, &car.Year, &car.Brand,
 			&car.FuelType, &engineJSON, &car.LocationCity, &car.LocationState, &car.LocationCountry,
-			&car.Price, &car.Status, &car.IsAvailable, &featuresJSON,
-			&car.Description, &images, &car.Mileage, &car.CreatedAt, &car.UpdatedAt)
+			&car.Price, &car.Status, &car.IsAvailable, &car.Listed, &featuresJSON, &attributesJSON,
+			&car.Description, &images, &car.Mileage, &car.Slug, &car.CreatedAt, &car.UpdatedAt}
+		if ranked {
+			dest = append(dest, &signals.Recency, &signals.Rating, &signals.AcceptanceRate,
+				&signals.PriceCompetitiveness, &signals.PhotoCount, &signals.Featured, &signals.Quality)
+		}
 
+		err = rows.Scan(dest...)
 		if err != nil {
 			return nil, err
 		}
+		if ranked {
+			ranking := filter.Ranking.Rank(signals)
+			car.Ranking = &ranking
+			car.Featured = signals.Featured == 1
+		}
 
 		// Parse JSON fields
 		if err = json.Unmarshal(engineJSON, &car.Engine); err != nil {
@@ -414,6 +642,9 @@ func (s CarStore) GetAllCars(ctx context.Context) ([]models.Car, error) {
 		if err = json.Unmarshal(featuresJSON, &car.Features); err != nil {
 			return nil, err
 		}
+		if err = json.Unmarshal(attributesJSON, &car.Attributes); err != nil {
+			return nil, err
+		}
 		car.Images = []string(images)
 
 		cars = append(cars, car)
@@ -425,3 +656,473 @@ func (s CarStore) GetAllCars(ctx context.Context) ([]models.Car, error) {
 
 	return cars, nil
 }
+
+// CountCars counts every car matching the filter, the total of all pages ListCars returns for it
+func (s CarStore) CountCars(ctx context.Context, filter models.CarFilter) (int, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "CountCars-Store")
+	defer span.End()
+
+	conditions, args, err := filterConditions(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	query := `SELECT COUNT(*) FROM car`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	err = s.db.QueryRowContext(ctx, query, args...).Scan(&total)
+	return total, err
+}
+
+// filterConditions builds the WHERE conditions of a car filter and their arguments, numbered
+// from $1. The conditions use unqualified car columns.
+func filterConditions(ctx context.Context, filter models.CarFilter) ([]string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+
+	if operatorID := tenant.Scope(ctx); operatorID != nil {
+		args = append(args, *operatorID)
+		conditions = append(conditions, fmt.Sprintf("operator_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	// Containment of {"feature": true, ...} is answered from the GIN index on features
+	if len(filter.Features) > 0 {
+		wanted := make(map[string]bool, len(filter.Features))
+		for _, feature := range filter.Features {
+			wanted[feature] = true
+		}
+		wantedJSON, err := json.Marshal(wanted)
+		if err != nil {
+			return nil, nil, err
+		}
+		args = append(args, wantedJSON)
+		conditions = append(conditions, fmt.Sprintf("features @> $%d::jsonb", len(args)))
+	}
+	// Custom attribute values are matched the same way, from the GIN index on attributes
+	if len(filter.Attributes) > 0 {
+		wantedJSON, err := json.Marshal(filter.Attributes)
+		if err != nil {
+			return nil, nil, err
+		}
+		args = append(args, wantedJSON)
+		conditions = append(conditions, fmt.Sprintf("attributes @> $%d::jsonb", len(args)))
+	}
+	if filter.Brand != "" {
+		args = append(args, escapeLike(filter.Brand), strings.TrimSpace(filter.Brand))
+		conditions = append(conditions, brandCondition(len(args)-1, filter.BrandFuzzy))
+	}
+	if filter.OwnerID != nil {
+		args = append(args, *filter.OwnerID)
+		conditions = append(conditions, fmt.Sprintf("owner_id = $%d", len(args)))
+	}
+	// Search criteria; text matches the whole value, ignoring case
+	for _, match := range []struct{ column, value string }{
+		{"model", filter.Model}, {"location_city", filter.City}, {"location_state", filter.State},
+	} {
+		if match.value != "" {
+			args = append(args, escapeLike(match.value))
+			conditions = append(conditions, fmt.Sprintf("%s ILIKE $%d", match.column, len(args)))
+		}
+	}
+	if filter.FuelType != "" {
+		args = append(args, filter.FuelType)
+		conditions = append(conditions, fmt.Sprintf("fuel_type = $%d", len(args)))
+	}
+	if filter.MinPrice != nil {
+		args = append(args, *filter.MinPrice)
+		conditions = append(conditions, fmt.Sprintf("price >= $%d", len(args)))
+	}
+	if filter.MaxPrice != nil {
+		args = append(args, *filter.MaxPrice)
+		conditions = append(conditions, fmt.Sprintf("price <= $%d", len(args)))
+	}
+	if filter.MinYear != nil {
+		args = append(args, *filter.MinYear)
+		conditions = append(conditions, fmt.Sprintf("year >= $%d", len(args)))
+	}
+	if filter.MaxYear != nil {
+		args = append(args, *filter.MaxYear)
+		conditions = append(conditions, fmt.Sprintf("year <= $%d", len(args)))
+	}
+	if filter.IsAvailable != nil {
+		args = append(args, *filter.IsAvailable)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", availableNowColumn, len(args)))
+	}
+	return conditions, args, nil
+}
+
+// GetBookableCarIDs returns which of the given cars can be booked for a period, in one query
+// for a whole page of search results. A car is bookable when it is available and no booking
+// holding it, owner vacation or fleet blackout overlaps the period.
+func (s CarStore) GetBookableCarIDs(ctx context.Context, carIDs []uuid.UUID, start, end time.Time) (map[uuid.UUID]bool, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "GetBookableCarIDs-Store")
+	defer span.End()
+
+	bookable := make(map[uuid.UUID]bool, len(carIDs))
+	if len(carIDs) == 0 {
+		return bookable, nil
+	}
+
+	ids := make(pq.StringArray, len(carIDs))
+	for i, id := range carIDs {
+		ids[i] = id.String()
+	}
+	holding := pq.StringArray{string(models.BookingStatusPending), string(models.BookingStatusUnderReview),
+		string(models.BookingStatusConfirmed), string(models.BookingStatusInProgress)}
+
+	// Fleet blackouts are stored as RFC 3339 strings, so they are compared as instants
+	query := `SELECT c.id FROM car c
+	         WHERE c.id = ANY($1::uuid[]) AND ($5::uuid IS NULL OR c.operator_id = $5)
+	         AND c.is_available
+	         AND NOT EXISTS (SELECT 1 FROM booking b
+	             WHERE b.car_id = c.id AND b.status = ANY($4) AND b.start_date < $3 AND b.end_date > $2)
+	         AND NOT EXISTS (SELECT 1 FROM car_blackout cb
+	             WHERE cb.car_id = c.id AND cb.start_date < $3 AND cb.end_date > $2)
+	         AND NOT EXISTS (SELECT 1 FROM fleet f, jsonb_array_elements(f.blackouts) fb
+	             WHERE f.id = c.fleet_id
+	             AND (fb->>'start_date')::timestamptz < $7 AND (fb->>'end_date')::timestamptz > $6)`
+
+	rows, err := s.db.QueryContext(ctx, query, ids, start, end, holding, tenant.Scope(ctx), start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		bookable[id] = true
+	}
+
+	return bookable, rows.Err()
+}
+
+// recordPriceChange adds a change of a car's price to its history in the transaction that
+// made it, attributed to the user the context acts for
+func recordPriceChange(ctx context.Context, tx *sql.Tx, carID uuid.UUID, oldPrice *float64, newPrice float64, changedAt time.Time) error {
+	var changedBy *uuid.UUID
+	role := "system"
+	if user, ok := identity.FromContext(ctx); ok {
+		role = user.Role
+		if userID, err := uuid.Parse(user.ID); err == nil {
+			changedBy = &userID
+		}
+	}
+
+	_, err := tx.ExecContext(ctx, `INSERT INTO car_price_history (id, car_id, old_price, new_price, changed_by, changed_by_role, changed_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7)`, uuid.New(), carID, oldPrice, newPrice, changedBy, role, changedAt)
+	return err
+}
+
+// GetPriceHistory retrieves every change of a car's price, newest first
+func (s CarStore) GetPriceHistory(ctx context.Context, carID string) ([]models.CarPriceChange, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "GetPriceHistory-Store")
+	defer span.End()
+
+	query := `SELECT id, car_id, old_price, new_price, changed_by, changed_by_role, changed_at
+	         FROM car_price_history WHERE car_id = $1 ORDER BY changed_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, carID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []models.CarPriceChange{}
+	for rows.Next() {
+		var change models.CarPriceChange
+		err := rows.Scan(&change.ID, &change.CarID, &change.OldPrice, &change.NewPrice, &change.ChangedBy,
+			&change.ChangedByRole, &change.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
+
+// GetQualityFacts reads what a car's listing quality is computed from
+func (s CarStore) GetQualityFacts(ctx context.Context, carID string) (models.CarQualityFacts, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "GetQualityFacts-Store")
+	defer span.End()
+
+	query := `SELECT ` + strings.Join(carQualityFacts, ", ") + `
+	         FROM car WHERE id = $1 AND ($2::uuid IS NULL OR operator_id = $2)`
+
+	var facts models.CarQualityFacts
+	err := s.db.QueryRowContext(ctx, query, carID, tenant.Scope(ctx)).Scan(&facts.PhotoCount,
+		&facts.DescriptionLength, &facts.HasHorsepower, &facts.HasMileage, &facts.HasFeatures,
+		&facts.HasLicensePlate, &facts.OwnerVerified)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return facts, errors.New("no car found with the given ID")
+		}
+		return facts, err
+	}
+
+	return facts, nil
+}
+
+// GetPriceDrops returns which of the given cars had their price lowered by their latest change
+// since a point in time, in one query for a whole page of listings
+func (s CarStore) GetPriceDrops(ctx context.Context, carIDs []uuid.UUID, since time.Time) (map[uuid.UUID]models.CarPriceDrop, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "GetPriceDrops-Store")
+	defer span.End()
+
+	drops := make(map[uuid.UUID]models.CarPriceDrop)
+	if len(carIDs) == 0 {
+		return drops, nil
+	}
+
+	ids := make(pq.StringArray, len(carIDs))
+	for i, id := range carIDs {
+		ids[i] = id.String()
+	}
+
+	// A raise after a drop ends the badge, so only each car's latest change counts
+	query := `SELECT car_id, old_price, new_price, changed_at FROM (
+	             SELECT DISTINCT ON (car_id) car_id, old_price, new_price, changed_at FROM car_price_history
+	             WHERE car_id = ANY($1::uuid[]) AND changed_at >= $2
+	             ORDER BY car_id, changed_at DESC) latest
+	         WHERE old_price > new_price`
+
+	rows, err := s.db.QueryContext(ctx, query, ids, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var carID uuid.UUID
+		var oldPrice, newPrice float64
+		var drop models.CarPriceDrop
+		if err := rows.Scan(&carID, &oldPrice, &newPrice, &drop.DroppedAt); err != nil {
+			return nil, err
+		}
+		drop.PreviousPrice = oldPrice
+		drop.PercentOff = math.Round((oldPrice - newPrice) / oldPrice * 100)
+		drops[carID] = drop
+	}
+
+	return drops, rows.Err()
+}
+
+// ListCarSitemapEntries retrieves id, slug and last-modified time for every active car
+func (s CarStore) ListCarSitemapEntries(ctx context.Context) ([]models.CarSitemapEntry, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "ListCarSitemapEntries-Store")
+	defer span.End()
+
+	query := `SELECT id, slug, updated_at FROM car WHERE status = 'active' ORDER BY updated_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.CarSitemapEntry
+	for rows.Next() {
+		var entry models.CarSitemapEntry
+		if err = rows.Scan(&entry.ID, &entry.Slug, &entry.UpdatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// UpdateCarStatus moves a car from one lifecycle status to another and sets its availability.
+// The update only applies while the car is still in the expected status, so concurrent
+// transitions cannot both succeed.
+func (s CarStore) UpdateCarStatus(ctx context.Context, id string, from, to models.CarStatus, isAvailable bool) (models.Car, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "UpdateCarStatus-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `UPDATE car SET status = $3, is_available = $4, updated_at = $5
+	         WHERE id = $1 AND status = $2 AND ($6::uuid IS NULL OR operator_id = $6)`,
+		id, from, to, isAvailable, time.Now(), tenant.Scope(ctx))
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return models.Car{}, err
+	} else if n == 0 {
+		return models.Car{}, errors.New("car status was changed by someone else, reload and try again")
+	}
+
+	return s.GetCarByID(ctx, id)
+}
+
+// GetFuelPolicy retrieves a car's fuel policy
+func (s CarStore) GetFuelPolicy(ctx context.Context, carID string) (models.CarFuelPolicy, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "GetFuelPolicy-Store")
+	defer span.End()
+
+	var policy models.CarFuelPolicy
+	err := s.db.QueryRowContext(ctx, `SELECT car_id, policy, tank_capacity_liters, updated_at
+	         FROM car_fuel_policy WHERE car_id = $1`, carID).
+		Scan(&policy.CarID, &policy.Policy, &policy.TankCapacityLiters, &policy.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.CarFuelPolicy{}, errors.New("no fuel policy is set for this car")
+		}
+		return models.CarFuelPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// UpsertFuelPolicy creates or replaces a car's fuel policy
+func (s CarStore) UpsertFuelPolicy(ctx context.Context, carID string, req models.CarFuelPolicyRequest) (models.CarFuelPolicy, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "UpsertFuelPolicy-Store")
+	defer span.End()
+
+	query := `INSERT INTO car_fuel_policy (car_id, policy, tank_capacity_liters, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $4)
+	         ON CONFLICT (car_id) DO UPDATE SET policy = EXCLUDED.policy,
+	           tank_capacity_liters = EXCLUDED.tank_capacity_liters, updated_at = EXCLUDED.updated_at
+	         RETURNING car_id, policy, tank_capacity_liters, updated_at`
+
+	var policy models.CarFuelPolicy
+	err := s.db.QueryRowContext(ctx, query, carID, req.Policy, req.TankCapacityLiters, time.Now()).
+		Scan(&policy.CarID, &policy.Policy, &policy.TankCapacityLiters, &policy.UpdatedAt)
+	if err != nil {
+		return models.CarFuelPolicy{}, fmt.Errorf("failed to save fuel policy: %v", err)
+	}
+
+	return policy, nil
+}
+
+// GetEligibility retrieves a car's renter eligibility rules. A car without rules gets zero
+// minimums, which restrict nobody.
+func (s CarStore) GetEligibility(ctx context.Context, carID string) (models.CarEligibility, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "GetEligibility-Store")
+	defer span.End()
+
+	var eligibility models.CarEligibility
+	err := s.db.QueryRowContext(ctx, `SELECT car_id, min_renter_age, min_license_years, updated_at
+	         FROM car_eligibility WHERE car_id = $1`, carID).
+		Scan(&eligibility.CarID, &eligibility.MinRenterAge, &eligibility.MinLicenseYears, &eligibility.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			id, _ := uuid.Parse(carID)
+			return models.CarEligibility{CarID: id}, nil
+		}
+		return models.CarEligibility{}, err
+	}
+
+	return eligibility, nil
+}
+
+// UpsertEligibility creates or replaces a car's renter eligibility rules
+func (s CarStore) UpsertEligibility(ctx context.Context, carID string, req models.CarEligibilityRequest) (models.CarEligibility, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "UpsertEligibility-Store")
+	defer span.End()
+
+	query := `INSERT INTO car_eligibility (car_id, min_renter_age, min_license_years, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $4)
+	         ON CONFLICT (car_id) DO UPDATE SET min_renter_age = EXCLUDED.min_renter_age,
+	           min_license_years = EXCLUDED.min_license_years, updated_at = EXCLUDED.updated_at
+	         RETURNING car_id, min_renter_age, min_license_years, updated_at`
+
+	var eligibility models.CarEligibility
+	err := s.db.QueryRowContext(ctx, query, carID, req.MinRenterAge, req.MinLicenseYears, time.Now()).
+		Scan(&eligibility.CarID, &eligibility.MinRenterAge, &eligibility.MinLicenseYears, &eligibility.UpdatedAt)
+	if err != nil {
+		return models.CarEligibility{}, fmt.Errorf("failed to save eligibility rules: %v", err)
+	}
+
+	return eligibility, nil
+}
+
+// GetOwnerStats counts an owner's completed trips and booking decisions, defined as in the
+// rating and acceptance_rate ranking signals
+func (s CarStore) GetOwnerStats(ctx context.Context, ownerID string) (models.OwnerStats, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "GetOwnerStats-Store")
+	defer span.End()
+
+	query := `SELECT COUNT(*) FILTER (WHERE b.status = 'completed'),
+	           COUNT(*) FILTER (WHERE b.status = 'completed' AND b.overdue_at IS NULL
+	               AND NOT EXISTS (SELECT 1 FROM dispute d WHERE d.booking_id = b.id)),
+	           COUNT(*) FILTER (WHERE b.status IN ('confirmed', 'in_progress', 'completed', 'cancelled')),
+	           COUNT(*) FILTER (WHERE b.status IN ('confirmed', 'in_progress', 'completed'))
+	         FROM booking b WHERE b.owner_id = $1`
+
+	var stats models.OwnerStats
+	err := s.db.QueryRowContext(ctx, query, ownerID).
+		Scan(&stats.CompletedTrips, &stats.CleanTrips, &stats.DecidedBookings, &stats.AcceptedBookings)
+	if err != nil {
+		return models.OwnerStats{}, err
+	}
+
+	return stats, nil
+}
+
+// RebuildListedFlags unlists the cars whose status is not active, as status changes do when
+// made through the API, so cars changed outside it cannot be listed again by their owner's
+// stale switch. One car is checked when carID is set.
+func (s CarStore) RebuildListedFlags(ctx context.Context, carID *uuid.UUID) (models.MaintenanceResult, error) {
+	tracer := otel.Tracer("CarStore")
+	ctx, span := tracer.Start(ctx, "RebuildListedFlags-Store")
+	defer span.End()
+
+	query := `WITH scope AS (
+	             SELECT id, status, is_available FROM car
+	             WHERE ($1::uuid IS NULL OR id = $1) AND ($2::uuid IS NULL OR operator_id = $2)
+	         ), unlisted AS (
+	             UPDATE car SET is_available = false, updated_at = $3
+	             FROM scope WHERE car.id = scope.id AND scope.is_available AND scope.status <> $4
+	             RETURNING car.id)
+	         SELECT (SELECT COUNT(*) FROM scope), (SELECT COUNT(*) FROM unlisted)`
+
+	var result models.MaintenanceResult
+	err := s.db.QueryRowContext(ctx, query, carID, tenant.Scope(ctx), time.Now(), models.CarStatusActive).
+		Scan(&result.Examined, &result.Changed)
+	if err != nil {
+		return models.MaintenanceResult{}, err
+	}
+
+	return result, nil
+}
+
+// brandCondition matches car.brand against the escaped brand in parameter $n, ignoring case.
+// Fuzzy matching also accepts brands whose trigram similarity to the raw brand in parameter
+// $n+1 reaches pg_trgm.similarity_threshold (0.3 by default), so "toyta" finds Toyota.
+// Trigrams ignore case, and both operators are answered from the trigram index on brand.
+func brandCondition(n int, fuzzy bool) string {
+	if !fuzzy {
+		return fmt.Sprintf("brand ILIKE $%d", n)
+	}
+	return fmt.Sprintf("(brand ILIKE $%d OR brand %% $%d)", n, n+1)
+}
+
+// escapeLike escapes the ILIKE wildcards in a value so it only matches itself
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.TrimSpace(value))
+}
@@ -0,0 +1,216 @@
+package carimage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// carImageUploadColumns lists the columns read by every upload query, in scanCarImageUpload
+// order. The image data is only read when the upload job claims an upload.
+const carImageUploadColumns = `id, car_id, position, status, attempts, next_attempt_at, url, error, created_at, updated_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// CarImageUploadStore persists the images sent with cars until they are uploaded to Cloudinary
+type CarImageUploadStore struct {
+	db *sql.DB
+}
+
+// New creates a new car image upload store
+func New(db *sql.DB) CarImageUploadStore {
+	return CarImageUploadStore{db: db}
+}
+
+// CreateCarImageUploads keeps the images sent with a car for the upload job, due at once and
+// in the order they were sent
+func (s CarImageUploadStore) CreateCarImageUploads(ctx context.Context, carID uuid.UUID, images []string) (uploads []models.CarImageUpload, err error) {
+	tracer := otel.Tracer("CarImageUploadStore")
+	ctx, span := tracer.Start(ctx, "CreateCarImageUploads-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	now := time.Now()
+	for position, image := range images {
+		row := tx.QueryRowContext(ctx, `INSERT INTO car_image_upload (id, car_id, position, image_data, status, next_attempt_at, created_at, updated_at)
+		         VALUES ($1, $2, $3, $4, $5, $6, $6, $6)
+		         RETURNING `+carImageUploadColumns,
+			uuid.New(), carID, position, image, models.CarImageUploadPending, now)
+		upload, err := scanCarImageUpload(row)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, upload)
+	}
+
+	return uploads, nil
+}
+
+// ListCarImageUploads retrieves the image uploads of a car in the order they were sent
+func (s CarImageUploadStore) ListCarImageUploads(ctx context.Context, carID string) ([]models.CarImageUpload, error) {
+	tracer := otel.Tracer("CarImageUploadStore")
+	ctx, span := tracer.Start(ctx, "ListCarImageUploads-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+carImageUploadColumns+` FROM car_image_upload
+	         WHERE car_id = $1
+	         ORDER BY created_at, position`, carID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	uploads := []models.CarImageUpload{}
+	for rows.Next() {
+		upload, err := scanCarImageUpload(rows)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, upload)
+	}
+
+	return uploads, rows.Err()
+}
+
+// ClaimDueCarImageUploads marks up to limit due uploads as uploading, counts the attempt and
+// returns them with their image data, oldest first. Uploads left uploading for longer than
+// staleAfter, by an instance that stopped, are claimed again. Concurrent callers never claim
+// the same upload.
+func (s CarImageUploadStore) ClaimDueCarImageUploads(ctx context.Context, limit int, staleAfter time.Duration) ([]models.CarImageUpload, error) {
+	tracer := otel.Tracer("CarImageUploadStore")
+	ctx, span := tracer.Start(ctx, "ClaimDueCarImageUploads-Store")
+	defer span.End()
+
+	query := `UPDATE car_image_upload SET status = $1, attempts = attempts + 1, updated_at = $2
+	         WHERE id IN (
+	             SELECT id FROM car_image_upload
+	             WHERE (status = $3 AND next_attempt_at <= $2) OR (status = $1 AND updated_at < $4)
+	             ORDER BY next_attempt_at, created_at, position
+	             LIMIT $5
+	             FOR UPDATE SKIP LOCKED)
+	         RETURNING ` + carImageUploadColumns + `, image_data`
+
+	now := time.Now()
+	rows, err := s.db.QueryContext(ctx, query, models.CarImageUploadUploading, now, models.CarImageUploadPending,
+		now.Add(-staleAfter), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	uploads := []models.CarImageUpload{}
+	for rows.Next() {
+		var data sql.NullString
+		upload, err := scanCarImageUpload(rows, &data)
+		if err != nil {
+			return nil, err
+		}
+		upload.Data = data.String
+		uploads = append(uploads, upload)
+	}
+
+	return uploads, rows.Err()
+}
+
+// AttachCarImageUpload adds the URL of an uploaded image to its car's images and marks the
+// upload uploaded, dropping the image data. It returns the car's ID.
+func (s CarImageUploadStore) AttachCarImageUpload(ctx context.Context, id uuid.UUID, url string) (carID uuid.UUID, err error) {
+	tracer := otel.Tracer("CarImageUploadStore")
+	ctx, span := tracer.Start(ctx, "AttachCarImageUpload-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	now := time.Now()
+	err = tx.QueryRowContext(ctx, `UPDATE car_image_upload
+	         SET status = $2, url = $3, image_data = NULL, error = '', updated_at = $4
+	         WHERE id = $1 AND status = $5
+	         RETURNING car_id`, id, models.CarImageUploadUploaded, url, now, models.CarImageUploadUploading).Scan(&carID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, errors.New("no image upload in progress with the given ID")
+		}
+		return uuid.Nil, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE car SET images = array_append(COALESCE(images, '{}'), $2), updated_at = $3
+	         WHERE id = $1`, carID, url, now); err != nil {
+		return uuid.Nil, err
+	}
+
+	return carID, nil
+}
+
+// RescheduleCarImageUpload puts an upload whose attempt failed back in the queue, due at nextAttemptAt
+func (s CarImageUploadStore) RescheduleCarImageUpload(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, uploadError string) error {
+	tracer := otel.Tracer("CarImageUploadStore")
+	ctx, span := tracer.Start(ctx, "RescheduleCarImageUpload-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `UPDATE car_image_upload
+	         SET status = $2, next_attempt_at = $3, error = $4, updated_at = $5
+	         WHERE id = $1`, id, models.CarImageUploadPending, nextAttemptAt, uploadError, time.Now())
+	return err
+}
+
+// FailCarImageUpload gives up on an upload after its last failed attempt, dropping the image data
+func (s CarImageUploadStore) FailCarImageUpload(ctx context.Context, id uuid.UUID, uploadError string) error {
+	tracer := otel.Tracer("CarImageUploadStore")
+	ctx, span := tracer.Start(ctx, "FailCarImageUpload-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `UPDATE car_image_upload
+	         SET status = $2, image_data = NULL, error = $3, updated_at = $4
+	         WHERE id = $1`, id, models.CarImageUploadFailed, uploadError, time.Now())
+	return err
+}
+
+// scanCarImageUpload reads one car_image_upload row, and any columns selected after the usual
+// ones into extra. The next attempt is only reported while the upload is pending.
+func scanCarImageUpload(row rowScanner, extra ...interface{}) (models.CarImageUpload, error) {
+	var upload models.CarImageUpload
+	var nextAttemptAt time.Time
+	var url sql.NullString
+	dest := []interface{}{&upload.ID, &upload.CarID, &upload.Position, &upload.Status, &upload.Attempts, &nextAttemptAt,
+		&url, &upload.Error, &upload.CreatedAt, &upload.UpdatedAt}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return upload, err
+	}
+	if upload.Status == models.CarImageUploadPending {
+		upload.NextAttemptAt = &nextAttemptAt
+	}
+	if url.Valid {
+		upload.URL = &url.String
+	}
+	return upload, nil
+}
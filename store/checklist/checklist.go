@@ -0,0 +1,131 @@
+package checklist
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// checklistColumns lists the columns read by every checklist template query, in scanTemplate order
+const checklistColumns = `id, version, items, created_by, created_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// ChecklistStore persists the versions of the handover checklist
+type ChecklistStore struct {
+	db *sql.DB
+}
+
+// New creates a new checklist store
+func New(db *sql.DB) ChecklistStore {
+	return ChecklistStore{db: db}
+}
+
+// AddTemplateVersion stores the next version of the checklist; the first save is version 1
+func (s ChecklistStore) AddTemplateVersion(ctx context.Context, req models.ChecklistTemplateRequest, createdBy string) (models.ChecklistTemplate, error) {
+	tracer := otel.Tracer("ChecklistStore")
+	ctx, span := tracer.Start(ctx, "AddTemplateVersion-Store")
+	defer span.End()
+
+	itemsJSON, err := json.Marshal(req.Items)
+	if err != nil {
+		return models.ChecklistTemplate{}, err
+	}
+
+	query := `INSERT INTO checklist_template (id, version, items, created_by, created_at)
+	         SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4 FROM checklist_template
+	         RETURNING ` + checklistColumns
+
+	tmpl, err := scanTemplate(s.db.QueryRowContext(ctx, query, uuid.New(), itemsJSON, createdBy, time.Now()))
+	if err != nil {
+		if strings.Contains(err.Error(), "unique_checklist_template_version") {
+			return models.ChecklistTemplate{}, errors.New("checklist was changed by someone else, reload and try again")
+		}
+		return models.ChecklistTemplate{}, err
+	}
+
+	return tmpl, nil
+}
+
+// GetCurrentTemplate retrieves the highest version of the checklist
+func (s ChecklistStore) GetCurrentTemplate(ctx context.Context) (models.ChecklistTemplate, error) {
+	tracer := otel.Tracer("ChecklistStore")
+	ctx, span := tracer.Start(ctx, "GetCurrentTemplate-Store")
+	defer span.End()
+
+	query := `SELECT ` + checklistColumns + ` FROM checklist_template ORDER BY version DESC LIMIT 1`
+
+	tmpl, err := scanTemplate(s.db.QueryRowContext(ctx, query))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ChecklistTemplate{}, errors.New("no checklist template found")
+		}
+		return models.ChecklistTemplate{}, err
+	}
+
+	return tmpl, nil
+}
+
+// GetTemplateVersion retrieves one version of the checklist
+func (s ChecklistStore) GetTemplateVersion(ctx context.Context, version int) (models.ChecklistTemplate, error) {
+	tracer := otel.Tracer("ChecklistStore")
+	ctx, span := tracer.Start(ctx, "GetTemplateVersion-Store")
+	defer span.End()
+
+	query := `SELECT ` + checklistColumns + ` FROM checklist_template WHERE version = $1`
+
+	tmpl, err := scanTemplate(s.db.QueryRowContext(ctx, query, version))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ChecklistTemplate{}, errors.New("no checklist template found with the given version")
+		}
+		return models.ChecklistTemplate{}, err
+	}
+
+	return tmpl, nil
+}
+
+// ListTemplateVersions retrieves every version of the checklist, newest first
+func (s ChecklistStore) ListTemplateVersions(ctx context.Context) ([]models.ChecklistTemplate, error) {
+	tracer := otel.Tracer("ChecklistStore")
+	ctx, span := tracer.Start(ctx, "ListTemplateVersions-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+checklistColumns+` FROM checklist_template ORDER BY version DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []models.ChecklistTemplate
+	for rows.Next() {
+		tmpl, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+
+	return templates, rows.Err()
+}
+
+// scanTemplate reads one checklist_template row
+func scanTemplate(row rowScanner) (models.ChecklistTemplate, error) {
+	var t models.ChecklistTemplate
+	var itemsJSON []byte
+	if err := row.Scan(&t.ID, &t.Version, &itemsJSON, &t.CreatedBy, &t.CreatedAt); err != nil {
+		return t, err
+	}
+	err := json.Unmarshal(itemsJSON, &t.Items)
+	return t, err
+}
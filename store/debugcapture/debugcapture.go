@@ -0,0 +1,164 @@
+package debugcapture
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"go.opentelemetry.io/otel"
+)
+
+// debugCaptureColumns lists the columns read by every debug capture query
+const debugCaptureColumns = `id, reason, user_id, method, path, query, client_ip, request_headers,
+	request_body, status, response_headers, response_body, duration_ms, created_at, expires_at`
+
+// DebugCaptureStore persists sampled request and response captures until they expire
+type DebugCaptureStore struct {
+	db *sql.DB
+}
+
+// New creates a new debug capture store
+func New(db *sql.DB) DebugCaptureStore {
+	return DebugCaptureStore{db: db}
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// CreateDebugCapture stores a capture
+func (s DebugCaptureStore) CreateDebugCapture(ctx context.Context, capture models.DebugCapture) error {
+	tracer := otel.Tracer("DebugCaptureStore")
+	ctx, span := tracer.Start(ctx, "CreateDebugCapture-Store")
+	defer span.End()
+
+	requestHeaders, err := json.Marshal(capture.RequestHeaders)
+	if err != nil {
+		return err
+	}
+	responseHeaders, err := json.Marshal(capture.ResponseHeaders)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO debug_capture (id, reason, user_id, method, path, query, client_ip, request_headers,
+	         request_body, status, response_headers, response_body, duration_ms, created_at, expires_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err = s.db.ExecContext(ctx, query, capture.ID, capture.Reason, capture.UserID, capture.Method,
+		capture.Path, capture.Query, capture.ClientIP, requestHeaders, capture.RequestBody, capture.Status,
+		responseHeaders, capture.ResponseBody, capture.DurationMs, capture.CreatedAt, capture.ExpiresAt)
+	return err
+}
+
+// GetDebugCaptureByID retrieves an unexpired capture by its ID
+func (s DebugCaptureStore) GetDebugCaptureByID(ctx context.Context, id string) (models.DebugCapture, error) {
+	tracer := otel.Tracer("DebugCaptureStore")
+	ctx, span := tracer.Start(ctx, "GetDebugCaptureByID-Store")
+	defer span.End()
+
+	query := `SELECT ` + debugCaptureColumns + ` FROM debug_capture WHERE id = $1 AND expires_at > NOW()`
+
+	capture, err := scanDebugCapture(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DebugCapture{}, errors.New("no debug capture found with the given ID")
+		}
+		return models.DebugCapture{}, err
+	}
+
+	return capture, nil
+}
+
+// ListDebugCaptures retrieves one page of unexpired captures matching the filter, newest first
+func (s DebugCaptureStore) ListDebugCaptures(ctx context.Context, filter models.DebugCaptureFilter, page models.PageRequest) ([]models.DebugCapture, error) {
+	tracer := otel.Tracer("DebugCaptureStore")
+	ctx, span := tracer.Start(ctx, "ListDebugCaptures-Store")
+	defer span.End()
+
+	conditions := []string{"expires_at > NOW()"}
+	var args []interface{}
+
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if filter.Path != "" {
+		args = append(args, strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(filter.Path)+"%")
+		conditions = append(conditions, fmt.Sprintf("path LIKE $%d", len(args)))
+	}
+	if filter.MinStatus > 0 {
+		args = append(args, filter.MinStatus)
+		conditions = append(conditions, fmt.Sprintf("status >= $%d", len(args)))
+	}
+	// Keyset condition: continue strictly past the cursor in its direction of travel
+	if page.Cursor != nil {
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) %s ($%d, $%d)", page.KeysetComparator(), len(args)-1, len(args)))
+	}
+
+	args = append(args, page.Limit+1)
+	query := `SELECT ` + debugCaptureColumns + ` FROM debug_capture WHERE ` + strings.Join(conditions, " AND ") +
+		fmt.Sprintf(" ORDER BY created_at %[1]s, id %[1]s LIMIT $%[2]d", page.SortOrder(), len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var captures []models.DebugCapture
+	for rows.Next() {
+		capture, err := scanDebugCapture(rows)
+		if err != nil {
+			return nil, err
+		}
+		captures = append(captures, capture)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return captures, nil
+}
+
+// DeleteExpiredDebugCaptures removes the captures past their expiry and returns how many
+func (s DebugCaptureStore) DeleteExpiredDebugCaptures(ctx context.Context) (int64, error) {
+	tracer := otel.Tracer("DebugCaptureStore")
+	ctx, span := tracer.Start(ctx, "DeleteExpiredDebugCaptures-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM debug_capture WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// scanDebugCapture reads one capture row
+func scanDebugCapture(row rowScanner) (models.DebugCapture, error) {
+	var capture models.DebugCapture
+	var requestHeaders, responseHeaders []byte
+
+	err := row.Scan(&capture.ID, &capture.Reason, &capture.UserID, &capture.Method, &capture.Path,
+		&capture.Query, &capture.ClientIP, &requestHeaders, &capture.RequestBody, &capture.Status,
+		&responseHeaders, &capture.ResponseBody, &capture.DurationMs, &capture.CreatedAt, &capture.ExpiresAt)
+	if err != nil {
+		return models.DebugCapture{}, err
+	}
+
+	if err = json.Unmarshal(requestHeaders, &capture.RequestHeaders); err != nil {
+		return models.DebugCapture{}, err
+	}
+	if err = json.Unmarshal(responseHeaders, &capture.ResponseHeaders); err != nil {
+		return models.DebugCapture{}, err
+	}
+
+	return capture, nil
+}
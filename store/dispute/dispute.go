@@ -0,0 +1,200 @@
+package dispute
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// disputeColumns lists the columns read by every dispute query, in scanDispute order
+const disputeColumns = `id, payment_id, booking_id, owner_id, razorpay_dispute_id, amount, reason_code,
+	reason_description, phase, status, respond_by, outcome_notes, resolved_at, created_at, updated_at`
+
+// evidenceColumns lists the columns read by every dispute evidence query, in scanEvidence order
+const evidenceColumns = `id, dispute_id, kind, description, document_url, added_by, created_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// DisputeStore persists chargebacks raised against payments and the evidence attached to them
+type DisputeStore struct {
+	db *sql.DB
+}
+
+// New creates a new dispute store
+func New(db *sql.DB) DisputeStore {
+	return DisputeStore{db: db}
+}
+
+// CreateDispute stores a dispute reported by Razorpay. A dispute that is already stored is
+// returned unchanged with created false, so repeated webhook deliveries are harmless.
+func (s DisputeStore) CreateDispute(ctx context.Context, dispute models.Dispute) (models.Dispute, bool, error) {
+	tracer := otel.Tracer("DisputeStore")
+	ctx, span := tracer.Start(ctx, "CreateDispute-Store")
+	defer span.End()
+
+	now := time.Now()
+	query := `INSERT INTO dispute (id, payment_id, booking_id, owner_id, razorpay_dispute_id, amount, reason_code,
+	         reason_description, phase, status, respond_by, outcome_notes, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, '', $12, $12)
+	         ON CONFLICT (razorpay_dispute_id) DO NOTHING
+	         RETURNING ` + disputeColumns
+
+	created, err := scanDispute(s.db.QueryRowContext(ctx, query, uuid.New(), dispute.PaymentID, dispute.BookingID,
+		dispute.OwnerID, dispute.RazorpayDisputeID, dispute.Amount, dispute.ReasonCode, dispute.ReasonDescription,
+		dispute.Phase, dispute.Status, dispute.RespondBy, now))
+	if err == sql.ErrNoRows {
+		existing, err := s.GetDisputeByRazorpayID(ctx, dispute.RazorpayDisputeID)
+		return existing, false, err
+	}
+	if err != nil {
+		return models.Dispute{}, false, err
+	}
+
+	return created, true, nil
+}
+
+// GetDispute retrieves a dispute with its evidence
+func (s DisputeStore) GetDispute(ctx context.Context, id string) (models.Dispute, error) {
+	tracer := otel.Tracer("DisputeStore")
+	ctx, span := tracer.Start(ctx, "GetDispute-Store")
+	defer span.End()
+
+	dispute, err := scanDispute(s.db.QueryRowContext(ctx, `SELECT `+disputeColumns+` FROM dispute WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Dispute{}, errors.New("no dispute found with the given ID")
+		}
+		return models.Dispute{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+evidenceColumns+` FROM dispute_evidence
+	         WHERE dispute_id = $1 ORDER BY created_at, id`, id)
+	if err != nil {
+		return models.Dispute{}, err
+	}
+	defer rows.Close()
+
+	dispute.Evidence = []models.DisputeEvidence{}
+	for rows.Next() {
+		evidence, err := scanEvidence(rows)
+		if err != nil {
+			return models.Dispute{}, err
+		}
+		dispute.Evidence = append(dispute.Evidence, evidence)
+	}
+
+	return dispute, rows.Err()
+}
+
+// GetDisputeByRazorpayID retrieves a dispute by Razorpay's ID for it, as sent in webhooks
+func (s DisputeStore) GetDisputeByRazorpayID(ctx context.Context, razorpayDisputeID string) (models.Dispute, error) {
+	tracer := otel.Tracer("DisputeStore")
+	ctx, span := tracer.Start(ctx, "GetDisputeByRazorpayID-Store")
+	defer span.End()
+
+	dispute, err := scanDispute(s.db.QueryRowContext(ctx,
+		`SELECT `+disputeColumns+` FROM dispute WHERE razorpay_dispute_id = $1`, razorpayDisputeID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Dispute{}, errors.New("no dispute found with the given ID")
+		}
+		return models.Dispute{}, err
+	}
+
+	return dispute, nil
+}
+
+// ListDisputes retrieves disputes, those due for a response soonest first
+func (s DisputeStore) ListDisputes(ctx context.Context, filter models.DisputeFilter) ([]models.Dispute, error) {
+	tracer := otel.Tracer("DisputeStore")
+	ctx, span := tracer.Start(ctx, "ListDisputes-Store")
+	defer span.End()
+
+	query := `SELECT ` + disputeColumns + ` FROM dispute
+	         WHERE ($1 = '' OR status = $1)
+	         ORDER BY respond_by ASC NULLS LAST, created_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, string(filter.Status))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	disputes := []models.Dispute{}
+	for rows.Next() {
+		dispute, err := scanDispute(rows)
+		if err != nil {
+			return nil, err
+		}
+		disputes = append(disputes, dispute)
+	}
+
+	return disputes, rows.Err()
+}
+
+// UpdateDisputeStatus records a new status and phase of a dispute. Resolved statuses set
+// resolved_at; notes, when given, replace the outcome notes.
+func (s DisputeStore) UpdateDisputeStatus(ctx context.Context, id uuid.UUID, status models.DisputeStatus, phase string, notes *string) (models.Dispute, error) {
+	tracer := otel.Tracer("DisputeStore")
+	ctx, span := tracer.Start(ctx, "UpdateDisputeStatus-Store")
+	defer span.End()
+
+	now := time.Now()
+	var resolvedAt *time.Time
+	if status.Resolved() {
+		resolvedAt = &now
+	}
+
+	query := `UPDATE dispute SET status = $2, phase = COALESCE(NULLIF($3, ''), phase),
+	         outcome_notes = COALESCE($4, outcome_notes), resolved_at = COALESCE(resolved_at, $5), updated_at = $6
+	         WHERE id = $1
+	         RETURNING ` + disputeColumns
+
+	dispute, err := scanDispute(s.db.QueryRowContext(ctx, query, id, status, phase, notes, resolvedAt, now))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Dispute{}, errors.New("no dispute found with the given ID")
+		}
+		return models.Dispute{}, err
+	}
+
+	return dispute, nil
+}
+
+// AddEvidence attaches a document to a dispute
+func (s DisputeStore) AddEvidence(ctx context.Context, disputeID, addedBy uuid.UUID, req models.DisputeEvidenceRequest) (models.DisputeEvidence, error) {
+	tracer := otel.Tracer("DisputeStore")
+	ctx, span := tracer.Start(ctx, "AddEvidence-Store")
+	defer span.End()
+
+	query := `INSERT INTO dispute_evidence (` + evidenceColumns + `)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7)
+	         RETURNING ` + evidenceColumns
+
+	return scanEvidence(s.db.QueryRowContext(ctx, query, uuid.New(), disputeID, req.Kind, req.Description,
+		req.DocumentURL, addedBy, time.Now()))
+}
+
+// scanDispute reads one dispute row without its evidence
+func scanDispute(row rowScanner) (models.Dispute, error) {
+	var d models.Dispute
+	err := row.Scan(&d.ID, &d.PaymentID, &d.BookingID, &d.OwnerID, &d.RazorpayDisputeID, &d.Amount, &d.ReasonCode,
+		&d.ReasonDescription, &d.Phase, &d.Status, &d.RespondBy, &d.OutcomeNotes, &d.ResolvedAt,
+		&d.CreatedAt, &d.UpdatedAt)
+	return d, err
+}
+
+// scanEvidence reads one dispute_evidence row
+func scanEvidence(row rowScanner) (models.DisputeEvidence, error) {
+	var e models.DisputeEvidence
+	err := row.Scan(&e.ID, &e.DisputeID, &e.Kind, &e.Description, &e.DocumentURL, &e.AddedBy, &e.CreatedAt)
+	return e, err
+}
@@ -0,0 +1,163 @@
+package emailtemplate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// templateColumns lists the columns read by every template query, in scanTemplate order
+const templateColumns = `id, key, version, subject, body, created_by, created_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// EmailTemplateStore persists the versions of e-mail templates
+type EmailTemplateStore struct {
+	db *sql.DB
+}
+
+// New creates a new e-mail template store
+func New(db *sql.DB) EmailTemplateStore {
+	return EmailTemplateStore{db: db}
+}
+
+// CreateTemplate stores version 1 of a new template key
+func (s EmailTemplateStore) CreateTemplate(ctx context.Context, req models.EmailTemplateRequest, createdBy string) (models.EmailTemplate, error) {
+	tracer := otel.Tracer("EmailTemplateStore")
+	ctx, span := tracer.Start(ctx, "CreateTemplate-Store")
+	defer span.End()
+
+	query := `INSERT INTO email_template (id, key, version, subject, body, created_by, created_at)
+	         VALUES ($1, $2, 1, $3, $4, $5, $6)
+	         RETURNING ` + templateColumns
+
+	tmpl, err := scanTemplate(s.db.QueryRowContext(ctx, query, uuid.New(), req.Key, req.Subject, req.Body, createdBy, time.Now()))
+	if err != nil {
+		if strings.Contains(err.Error(), "unique_email_template_version") {
+			return models.EmailTemplate{}, errors.New("an email template with this key already exists")
+		}
+		return models.EmailTemplate{}, err
+	}
+
+	return tmpl, nil
+}
+
+// AddTemplateVersion stores the next version of an existing template key
+func (s EmailTemplateStore) AddTemplateVersion(ctx context.Context, key string, req models.EmailTemplateRequest, createdBy string) (models.EmailTemplate, error) {
+	tracer := otel.Tracer("EmailTemplateStore")
+	ctx, span := tracer.Start(ctx, "AddTemplateVersion-Store")
+	defer span.End()
+
+	// Selecting from the key's own versions means unknown keys insert nothing
+	query := `INSERT INTO email_template (id, key, version, subject, body, created_by, created_at)
+	         SELECT $1, key, MAX(version) + 1, $3, $4, $5, $6 FROM email_template WHERE key = $2 GROUP BY key
+	         RETURNING ` + templateColumns
+
+	tmpl, err := scanTemplate(s.db.QueryRowContext(ctx, query, uuid.New(), key, req.Subject, req.Body, createdBy, time.Now()))
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			return models.EmailTemplate{}, errors.New("no email template found with the given key")
+		case strings.Contains(err.Error(), "unique_email_template_version"):
+			return models.EmailTemplate{}, errors.New("email template was changed by someone else, reload and try again")
+		}
+		return models.EmailTemplate{}, err
+	}
+
+	return tmpl, nil
+}
+
+// GetTemplate retrieves the current (highest) version of a template key
+func (s EmailTemplateStore) GetTemplate(ctx context.Context, key string) (models.EmailTemplate, error) {
+	tracer := otel.Tracer("EmailTemplateStore")
+	ctx, span := tracer.Start(ctx, "GetTemplate-Store")
+	defer span.End()
+
+	query := `SELECT ` + templateColumns + ` FROM email_template WHERE key = $1 ORDER BY version DESC LIMIT 1`
+
+	tmpl, err := scanTemplate(s.db.QueryRowContext(ctx, query, key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.EmailTemplate{}, errors.New("no email template found with the given key")
+		}
+		return models.EmailTemplate{}, err
+	}
+
+	return tmpl, nil
+}
+
+// ListTemplates retrieves the current version of every template key, ordered by key
+func (s EmailTemplateStore) ListTemplates(ctx context.Context) ([]models.EmailTemplate, error) {
+	tracer := otel.Tracer("EmailTemplateStore")
+	ctx, span := tracer.Start(ctx, "ListTemplates-Store")
+	defer span.End()
+
+	return s.queryTemplates(ctx, `SELECT DISTINCT ON (key) `+templateColumns+` FROM email_template
+	         ORDER BY key, version DESC`)
+}
+
+// ListTemplateVersions retrieves every version of a template key, newest first
+func (s EmailTemplateStore) ListTemplateVersions(ctx context.Context, key string) ([]models.EmailTemplate, error) {
+	tracer := otel.Tracer("EmailTemplateStore")
+	ctx, span := tracer.Start(ctx, "ListTemplateVersions-Store")
+	defer span.End()
+
+	return s.queryTemplates(ctx, `SELECT `+templateColumns+` FROM email_template
+	         WHERE key = $1 ORDER BY version DESC`, key)
+}
+
+// DeleteTemplate removes every version of a template key
+func (s EmailTemplateStore) DeleteTemplate(ctx context.Context, key string) error {
+	tracer := otel.Tracer("EmailTemplateStore")
+	ctx, span := tracer.Start(ctx, "DeleteTemplate-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM email_template WHERE key = $1`, key)
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no email template found with the given key")
+	}
+
+	return nil
+}
+
+// queryTemplates runs a query selecting templateColumns and collects the rows
+func (s EmailTemplateStore) queryTemplates(ctx context.Context, query string, args ...interface{}) ([]models.EmailTemplate, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []models.EmailTemplate
+	for rows.Next() {
+		tmpl, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+
+	return templates, rows.Err()
+}
+
+// scanTemplate reads one email_template row
+func scanTemplate(row rowScanner) (models.EmailTemplate, error) {
+	var t models.EmailTemplate
+	err := row.Scan(&t.ID, &t.Key, &t.Version, &t.Subject, &t.Body, &t.CreatedBy, &t.CreatedAt)
+	return t, err
+}
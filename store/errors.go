@@ -0,0 +1,58 @@
+package store
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ConstraintError is a write the database rejected because it would break a unique or foreign
+// key constraint. Stores return one of the errors below in place of the driver error, so callers
+// can tell a conflict from a failed query with errors.Is, and handlers can match the message.
+type ConstraintError struct {
+	Constraint string // Name of the violated constraint in schema.sql
+	Message    string // What the conflict means to the caller
+}
+
+func (e *ConstraintError) Error() string {
+	return e.Message
+}
+
+// Is matches any ConstraintError of the same constraint
+func (e *ConstraintError) Is(target error) bool {
+	t, ok := target.(*ConstraintError)
+	return ok && t.Constraint == e.Constraint
+}
+
+var (
+	// ErrDuplicateEmail is returned when a user is created or updated with a registered e-mail address
+	ErrDuplicateEmail = &ConstraintError{Constraint: "unique_users_email", Message: "user with this email already exists"}
+	// ErrDuplicatePhone is returned when a user is created or updated with a registered phone number
+	ErrDuplicatePhone = &ConstraintError{Constraint: "unique_users_phone_hash", Message: "user with this phone number already exists"}
+	// ErrDuplicateRazorpayOrder is returned when a Razorpay order is recorded on a second payment
+	ErrDuplicateRazorpayOrder = &ConstraintError{Constraint: "unique_payment_razorpay_order_id", Message: "a payment for this Razorpay order already exists"}
+	// ErrCarHasBookings is returned when a car with bookings is deleted; cars are retired instead
+	ErrCarHasBookings = &ConstraintError{Constraint: "fk_booking_car_id", Message: "car has bookings and cannot be deleted, retire it instead"}
+	// ErrUserHasBookings is returned when a user who rented cars is deleted
+	ErrUserHasBookings = &ConstraintError{Constraint: "fk_booking_customer_id", Message: "user has bookings and cannot be deleted"}
+	// ErrBookingHasPayments is returned when a booking with payments is deleted; it is cancelled instead
+	ErrBookingHasPayments = &ConstraintError{Constraint: "fk_payment_booking_id", Message: "booking has payments and cannot be deleted, cancel it instead"}
+	// ErrDuplicateLockRef is returned when a car is linked to a smart lock fitted in another car
+	ErrDuplicateLockRef = &ConstraintError{Constraint: "unique_car_smart_lock_provider_lock_ref", Message: "this smart lock is already linked to another car"}
+)
+
+// ConstraintViolation returns the candidate whose constraint err violates, or err unchanged.
+// Callers list only the candidates a statement can violate in the sense they describe: a foreign
+// key rejects both deleting a referenced row and inserting a row that references nothing.
+func ConstraintViolation(err error, candidates ...*ConstraintError) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	for _, candidate := range candidates {
+		if pqErr.Constraint == candidate.Constraint {
+			return candidate
+		}
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+package favorite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// FavoriteStore persists the cars users saved to their wishlist
+type FavoriteStore struct {
+	db *sql.DB
+}
+
+// New creates a new favorite store
+func New(db *sql.DB) FavoriteStore {
+	return FavoriteStore{db: db}
+}
+
+// AddFavorite saves a car to the user's wishlist. Saving a car again keeps the original
+// favorite; created reports whether the car was newly saved.
+func (s FavoriteStore) AddFavorite(ctx context.Context, userID, carID string) (favorite models.Favorite, created bool, err error) {
+	tracer := otel.Tracer("FavoriteStore")
+	ctx, span := tracer.Start(ctx, "AddFavorite-Store")
+	defer span.End()
+
+	// The second branch sees the table as it was before the insert, so it finds the existing favorite
+	query := `WITH inserted AS (
+	           INSERT INTO car_favorite (id, user_id, car_id, created_at)
+	           VALUES ($1, $2, $3, $4)
+	           ON CONFLICT (user_id, car_id) DO NOTHING
+	           RETURNING id, user_id, car_id, created_at
+	         )
+	         SELECT id, user_id, car_id, created_at, TRUE FROM inserted
+	         UNION ALL
+	         SELECT id, user_id, car_id, created_at, FALSE FROM car_favorite
+	         WHERE user_id = $2 AND car_id = $3 AND NOT EXISTS (SELECT 1 FROM inserted)`
+
+	err = s.db.QueryRowContext(ctx, query, uuid.New(), userID, carID, time.Now()).
+		Scan(&favorite.ID, &favorite.UserID, &favorite.CarID, &favorite.CreatedAt, &created)
+	if err != nil {
+		if strings.Contains(err.Error(), "fk_car_favorite_car_id") {
+			return models.Favorite{}, false, errors.New("no car found with the given ID")
+		}
+		return models.Favorite{}, false, err
+	}
+
+	return favorite, created, nil
+}
+
+// RemoveFavorite removes a car from the user's wishlist
+func (s FavoriteStore) RemoveFavorite(ctx context.Context, userID, carID string) error {
+	tracer := otel.Tracer("FavoriteStore")
+	ctx, span := tracer.Start(ctx, "RemoveFavorite-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM car_favorite WHERE user_id = $1 AND car_id = $2`, userID, carID)
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no favorite found for the given car")
+	}
+
+	return nil
+}
+
+// ListFavorites retrieves one page of the user's favorites with their cars, most recently saved first
+func (s FavoriteStore) ListFavorites(ctx context.Context, userID string, page models.PageRequest) ([]models.Favorite, error) {
+	tracer := otel.Tracer("FavoriteStore")
+	ctx, span := tracer.Start(ctx, "ListFavorites-Store")
+	defer span.End()
+
+	args := []interface{}{userID}
+	query := `SELECT f.id, f.user_id, f.car_id, f.created_at,
+	         c.name, c.brand, c.model, c.year, c.price, c.images, c.slug, c.status, c.is_available
+	         FROM car_favorite f JOIN car c ON c.id = f.car_id
+	         WHERE f.user_id = $1`
+
+	// Keyset condition: continue strictly past the cursor in its direction of travel
+	if page.Cursor != nil {
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+		query += fmt.Sprintf(" AND (f.created_at, f.id) %s ($%d, $%d)", page.KeysetComparator(), len(args)-1, len(args))
+	}
+	args = append(args, page.Limit+1)
+	query += fmt.Sprintf(" ORDER BY f.created_at %[1]s, f.id %[1]s LIMIT $%[2]d", page.SortOrder(), len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var favorites []models.Favorite
+	for rows.Next() {
+		var favorite models.Favorite
+		var car models.FavoriteCar
+		var images pq.StringArray
+		err := rows.Scan(&favorite.ID, &favorite.UserID, &favorite.CarID, &favorite.CreatedAt,
+			&car.Name, &car.Brand, &car.Model, &car.Year, &car.Price, &images, &car.Slug, &car.Status, &car.IsAvailable)
+		if err != nil {
+			return nil, err
+		}
+		car.Images = []string(images)
+		favorite.Car = &car
+		favorites = append(favorites, favorite)
+	}
+
+	return favorites, rows.Err()
+}
@@ -0,0 +1,175 @@
+package feature
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// featureColumns lists the columns read by every feature query, in scanFeature order. Car
+// counts come from car, so queries must pass the tenant scope as $1.
+const featureColumns = `f.key, f.name, f.aliases,
+	(SELECT COUNT(*) FROM car c WHERE c.features @> jsonb_build_object(f.key, true)
+	   AND ($1::uuid IS NULL OR c.operator_id = $1)),
+	f.created_at, f.updated_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// FeatureStore persists the curated features taxonomy and rewrites car features onto it
+type FeatureStore struct {
+	db *sql.DB
+}
+
+// New creates a new feature store
+func New(db *sql.DB) FeatureStore {
+	return FeatureStore{db: db}
+}
+
+// ListFeatures retrieves the taxonomy ordered by key
+func (s FeatureStore) ListFeatures(ctx context.Context) ([]models.Feature, error) {
+	tracer := otel.Tracer("FeatureStore")
+	ctx, span := tracer.Start(ctx, "ListFeatures-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+featureColumns+` FROM feature f ORDER BY f.key`, tenant.Scope(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	features := []models.Feature{}
+	for rows.Next() {
+		feature, err := scanFeature(rows)
+		if err != nil {
+			return nil, err
+		}
+		features = append(features, feature)
+	}
+
+	return features, rows.Err()
+}
+
+// CreateFeature adds a feature to the taxonomy
+func (s FeatureStore) CreateFeature(ctx context.Context, req models.FeatureRequest) (models.Feature, error) {
+	tracer := otel.Tracer("FeatureStore")
+	ctx, span := tracer.Start(ctx, "CreateFeature-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO feature (key, name, aliases, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $4)`, req.Key, req.Name, pq.StringArray(req.Aliases), time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "feature_pkey") {
+			return models.Feature{}, errors.New("a feature with this key already exists")
+		}
+		return models.Feature{}, err
+	}
+
+	return s.getFeature(ctx, req.Key)
+}
+
+// UpdateFeature replaces the name and aliases of a feature
+func (s FeatureStore) UpdateFeature(ctx context.Context, key string, req models.FeatureRequest) (models.Feature, error) {
+	tracer := otel.Tracer("FeatureStore")
+	ctx, span := tracer.Start(ctx, "UpdateFeature-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `UPDATE feature SET name = $2, aliases = $3, updated_at = $4 WHERE key = $1`,
+		key, req.Name, pq.StringArray(req.Aliases), time.Now())
+	if err != nil {
+		return models.Feature{}, err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return models.Feature{}, err
+	} else if n == 0 {
+		return models.Feature{}, errors.New("no feature found with the given key")
+	}
+
+	return s.getFeature(ctx, key)
+}
+
+// getFeature retrieves one feature of the taxonomy
+func (s FeatureStore) getFeature(ctx context.Context, key string) (models.Feature, error) {
+	feature, err := scanFeature(s.db.QueryRowContext(ctx, `SELECT `+featureColumns+` FROM feature f WHERE f.key = $2`,
+		tenant.Scope(ctx), key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Feature{}, errors.New("no feature found with the given key")
+		}
+		return models.Feature{}, err
+	}
+	return feature, nil
+}
+
+// ListNonCanonicalCarFeatures retrieves the features of every car listing a key that is not
+// a canonical key of the taxonomy
+func (s FeatureStore) ListNonCanonicalCarFeatures(ctx context.Context) ([]models.CarFeatures, error) {
+	tracer := otel.Tracer("FeatureStore")
+	ctx, span := tracer.Start(ctx, "ListNonCanonicalCarFeatures-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, features FROM car
+	         WHERE jsonb_typeof(features) = 'object'
+	           AND EXISTS (SELECT 1 FROM jsonb_object_keys(features) k WHERE k NOT IN (SELECT key FROM feature))`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cars []models.CarFeatures
+	for rows.Next() {
+		var car models.CarFeatures
+		var featuresJSON []byte
+		if err := rows.Scan(&car.CarID, &featuresJSON); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(featuresJSON, &car.Features); err != nil {
+			return nil, err
+		}
+		cars = append(cars, car)
+	}
+
+	return cars, rows.Err()
+}
+
+// UpdateCarFeatures replaces the features of a car without touching its other fields
+func (s FeatureStore) UpdateCarFeatures(ctx context.Context, carID string, features map[string]interface{}) error {
+	tracer := otel.Tracer("FeatureStore")
+	ctx, span := tracer.Start(ctx, "UpdateCarFeatures-Store")
+	defer span.End()
+
+	featuresJSON, err := json.Marshal(features)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `UPDATE car SET features = $2, updated_at = $3 WHERE id = $1`,
+		carID, featuresJSON, time.Now())
+	return err
+}
+
+// scanFeature reads one feature row selected with featureColumns
+func scanFeature(row rowScanner) (models.Feature, error) {
+	var f models.Feature
+	var aliases pq.StringArray
+	err := row.Scan(&f.Key, &f.Name, &aliases, &f.CarCount, &f.CreatedAt, &f.UpdatedAt)
+	if err != nil {
+		return models.Feature{}, err
+	}
+	f.Aliases = []string(aliases)
+	if f.Aliases == nil {
+		f.Aliases = []string{}
+	}
+	return f, nil
+}
@@ -0,0 +1,180 @@
+package featured
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// featuredColumns lists the columns read by every featured listing query, in scanFeatured order
+const featuredColumns = `id, car_id, owner_id, start_date, end_date, amount, status, razorpay_order_id,
+	razorpay_payment_id, impressions, clicks, created_at, updated_at`
+
+// activeFeatured matches paid placements whose period covers the current time
+const activeFeatured = `status = 'paid' AND start_date <= NOW() AND end_date > NOW()`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// FeaturedStore persists featured placements and their impression and click counts
+type FeaturedStore struct {
+	db *sql.DB
+}
+
+// New creates a new featured listing store
+func New(db *sql.DB) FeaturedStore {
+	return FeaturedStore{db: db}
+}
+
+// CreateFeatured records a pending placement. The car's placements are locked while the period
+// is checked, so two overlapping purchases cannot both go through.
+func (s FeaturedStore) CreateFeatured(ctx context.Context, carID, ownerID uuid.UUID, req models.FeaturedRequest, amount float64, pendingTTL time.Duration) (featured models.FeaturedListing, err error) {
+	tracer := otel.Tracer("FeaturedStore")
+	ctx, span := tracer.Start(ctx, "CreateFeatured-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.FeaturedListing{}, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `SELECT id FROM car WHERE id = $1 FOR UPDATE`, carID); err != nil {
+		return models.FeaturedListing{}, err
+	}
+
+	// Unpaid purchases only hold the period until their order goes stale
+	var overlapping bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM featured_listing
+	         WHERE car_id = $1 AND start_date < $3 AND end_date > $2
+	           AND (status = 'paid' OR (status = 'pending' AND created_at > $4)))`,
+		carID, req.StartDate, req.EndDate, time.Now().Add(-pendingTTL)).Scan(&overlapping)
+	if err != nil {
+		return models.FeaturedListing{}, err
+	}
+	if overlapping {
+		return models.FeaturedListing{}, errors.New("the car is already featured during this period")
+	}
+
+	now := time.Now()
+	featured, err = scanFeatured(tx.QueryRowContext(ctx, `INSERT INTO featured_listing
+	         (id, car_id, owner_id, start_date, end_date, amount, status, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, 'pending', $7, $7)
+	         RETURNING `+featuredColumns, uuid.New(), carID, ownerID, req.StartDate, req.EndDate, amount, now))
+	if err != nil {
+		return models.FeaturedListing{}, err
+	}
+
+	return featured, tx.Commit()
+}
+
+// SetFeaturedOrder records the Razorpay order a pending placement is paid through
+func (s FeaturedStore) SetFeaturedOrder(ctx context.Context, id uuid.UUID, orderID string) (models.FeaturedListing, error) {
+	tracer := otel.Tracer("FeaturedStore")
+	ctx, span := tracer.Start(ctx, "SetFeaturedOrder-Store")
+	defer span.End()
+
+	return s.queryFeatured(ctx, `UPDATE featured_listing SET razorpay_order_id = $2, updated_at = NOW()
+	         WHERE id = $1 RETURNING `+featuredColumns, id, orderID)
+}
+
+// GetFeatured retrieves a placement by ID
+func (s FeaturedStore) GetFeatured(ctx context.Context, id string) (models.FeaturedListing, error) {
+	tracer := otel.Tracer("FeaturedStore")
+	ctx, span := tracer.Start(ctx, "GetFeatured-Store")
+	defer span.End()
+
+	return s.queryFeatured(ctx, `SELECT `+featuredColumns+` FROM featured_listing WHERE id = $1`, id)
+}
+
+// SettleFeatured moves a pending placement to paid or failed. Placements that are no longer
+// pending are returned unchanged, so repeated confirmations are harmless.
+func (s FeaturedStore) SettleFeatured(ctx context.Context, id uuid.UUID, status models.FeaturedStatus, paymentID string) (models.FeaturedListing, error) {
+	tracer := otel.Tracer("FeaturedStore")
+	ctx, span := tracer.Start(ctx, "SettleFeatured-Store")
+	defer span.End()
+
+	featured, err := s.queryFeatured(ctx, `UPDATE featured_listing SET status = $2, razorpay_payment_id = $3, updated_at = NOW()
+	         WHERE id = $1 AND status = 'pending' RETURNING `+featuredColumns, id, status, paymentID)
+	if err != nil && err.Error() == "no featured listing found with the given ID" {
+		return s.GetFeatured(ctx, id.String())
+	}
+	return featured, err
+}
+
+// ListFeaturedByOwner retrieves the owner's placements, latest period first
+func (s FeaturedStore) ListFeaturedByOwner(ctx context.Context, ownerID string) ([]models.FeaturedListing, error) {
+	tracer := otel.Tracer("FeaturedStore")
+	ctx, span := tracer.Start(ctx, "ListFeaturedByOwner-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+featuredColumns+` FROM featured_listing
+	         WHERE owner_id = $1 ORDER BY start_date DESC, created_at DESC`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	listings := []models.FeaturedListing{}
+	for rows.Next() {
+		featured, err := scanFeatured(rows)
+		if err != nil {
+			return nil, err
+		}
+		listings = append(listings, featured)
+	}
+
+	return listings, rows.Err()
+}
+
+// RecordImpressions counts one impression on the running placement of each car
+func (s FeaturedStore) RecordImpressions(ctx context.Context, carIDs []uuid.UUID) error {
+	tracer := otel.Tracer("FeaturedStore")
+	ctx, span := tracer.Start(ctx, "RecordImpressions-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `UPDATE featured_listing SET impressions = impressions + 1
+	         WHERE car_id = ANY($1::uuid[]) AND `+activeFeatured, pq.Array(carIDs))
+	return err
+}
+
+// RecordClick counts a click on the running placement of a car, if it has one
+func (s FeaturedStore) RecordClick(ctx context.Context, carID string) error {
+	tracer := otel.Tracer("FeaturedStore")
+	ctx, span := tracer.Start(ctx, "RecordClick-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `UPDATE featured_listing SET clicks = clicks + 1
+	         WHERE car_id = $1 AND `+activeFeatured, carID)
+	return err
+}
+
+// queryFeatured runs a query returning one placement
+func (s FeaturedStore) queryFeatured(ctx context.Context, query string, args ...interface{}) (models.FeaturedListing, error) {
+	featured, err := scanFeatured(s.db.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return models.FeaturedListing{}, errors.New("no featured listing found with the given ID")
+	}
+	return featured, err
+}
+
+// scanFeatured reads one row selected with featuredColumns
+func scanFeatured(row rowScanner) (models.FeaturedListing, error) {
+	var featured models.FeaturedListing
+	err := row.Scan(&featured.ID, &featured.CarID, &featured.OwnerID, &featured.StartDate, &featured.EndDate,
+		&featured.Amount, &featured.Status, &featured.RazorpayOrderID, &featured.RazorpayPaymentID,
+		&featured.Impressions, &featured.Clicks, &featured.CreatedAt, &featured.UpdatedAt)
+	return featured, err
+}
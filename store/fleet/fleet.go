@@ -0,0 +1,294 @@
+package fleet
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// fleetColumns lists the columns read by every fleet query, in scanFleet order. Member cars are
+// aggregated from car, so queries must join it as c and group by f.id.
+const fleetColumns = `f.id, f.owner_id, f.name, f.pricing_rules, f.add_ons, f.blackouts, f.created_at, f.updated_at,
+	COALESCE(array_agg(c.id ORDER BY c.id) FILTER (WHERE c.id IS NOT NULL), '{}')`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// FleetStore persists owners' fleets, their shared settings and which cars belong to them
+type FleetStore struct {
+	db *sql.DB
+}
+
+// New creates a new fleet store
+func New(db *sql.DB) FleetStore {
+	return FleetStore{db: db}
+}
+
+// CreateFleet stores a new, empty fleet for the owner
+func (s FleetStore) CreateFleet(ctx context.Context, ownerID string, req models.FleetRequest) (models.Fleet, error) {
+	tracer := otel.Tracer("FleetStore")
+	ctx, span := tracer.Start(ctx, "CreateFleet-Store")
+	defer span.End()
+
+	rules, addOns, blackouts, err := marshalSettings(req)
+	if err != nil {
+		return models.Fleet{}, err
+	}
+
+	now := time.Now()
+	// A new fleet has no cars yet
+	query := `INSERT INTO fleet (id, owner_id, name, pricing_rules, add_ons, blackouts, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+	         RETURNING id, owner_id, name, pricing_rules, add_ons, blackouts, created_at, updated_at, '{}'::uuid[]`
+
+	fleet, err := scanFleet(s.db.QueryRowContext(ctx, query, uuid.New(), ownerID, strings.TrimSpace(req.Name),
+		rules, addOns, blackouts, now))
+	if err != nil {
+		if strings.Contains(err.Error(), "unique_fleet_owner_name") {
+			return models.Fleet{}, errors.New("a fleet with this name already exists")
+		}
+		return models.Fleet{}, err
+	}
+
+	return fleet, nil
+}
+
+// GetFleet retrieves one of the owner's fleets with its member cars
+func (s FleetStore) GetFleet(ctx context.Context, id, ownerID string) (models.Fleet, error) {
+	tracer := otel.Tracer("FleetStore")
+	ctx, span := tracer.Start(ctx, "GetFleet-Store")
+	defer span.End()
+
+	query := `SELECT ` + fleetColumns + `
+	         FROM fleet f LEFT JOIN car c ON c.fleet_id = f.id
+	         WHERE f.id = $1 AND f.owner_id = $2 GROUP BY f.id`
+
+	fleet, err := scanFleet(s.db.QueryRowContext(ctx, query, id, ownerID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Fleet{}, errors.New("no fleet found with the given ID")
+		}
+		return models.Fleet{}, err
+	}
+
+	return fleet, nil
+}
+
+// GetFleetByCarID retrieves the fleet a car belongs to
+func (s FleetStore) GetFleetByCarID(ctx context.Context, carID string) (models.Fleet, error) {
+	tracer := otel.Tracer("FleetStore")
+	ctx, span := tracer.Start(ctx, "GetFleetByCarID-Store")
+	defer span.End()
+
+	query := `SELECT ` + fleetColumns + `
+	         FROM fleet f LEFT JOIN car c ON c.fleet_id = f.id
+	         WHERE f.id = (SELECT fleet_id FROM car WHERE id = $1) GROUP BY f.id`
+
+	fleet, err := scanFleet(s.db.QueryRowContext(ctx, query, carID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Fleet{}, errors.New("car is not in a fleet")
+		}
+		return models.Fleet{}, err
+	}
+
+	return fleet, nil
+}
+
+// ListFleets retrieves the owner's fleets ordered by name
+func (s FleetStore) ListFleets(ctx context.Context, ownerID string) ([]models.Fleet, error) {
+	tracer := otel.Tracer("FleetStore")
+	ctx, span := tracer.Start(ctx, "ListFleets-Store")
+	defer span.End()
+
+	query := `SELECT ` + fleetColumns + `
+	         FROM fleet f LEFT JOIN car c ON c.fleet_id = f.id
+	         WHERE f.owner_id = $1 GROUP BY f.id ORDER BY f.name`
+
+	rows, err := s.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fleets []models.Fleet
+	for rows.Next() {
+		fleet, err := scanFleet(rows)
+		if err != nil {
+			return nil, err
+		}
+		fleets = append(fleets, fleet)
+	}
+
+	return fleets, rows.Err()
+}
+
+// UpdateFleet replaces the name and settings of one of the owner's fleets
+func (s FleetStore) UpdateFleet(ctx context.Context, id, ownerID string, req models.FleetRequest) (models.Fleet, error) {
+	tracer := otel.Tracer("FleetStore")
+	ctx, span := tracer.Start(ctx, "UpdateFleet-Store")
+	defer span.End()
+
+	rules, addOns, blackouts, err := marshalSettings(req)
+	if err != nil {
+		return models.Fleet{}, err
+	}
+
+	result, err := s.db.ExecContext(ctx, `UPDATE fleet SET name = $3, pricing_rules = $4, add_ons = $5,
+	         blackouts = $6, updated_at = $7 WHERE id = $1 AND owner_id = $2`,
+		id, ownerID, strings.TrimSpace(req.Name), rules, addOns, blackouts, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "unique_fleet_owner_name") {
+			return models.Fleet{}, errors.New("a fleet with this name already exists")
+		}
+		return models.Fleet{}, err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return models.Fleet{}, err
+	} else if n == 0 {
+		return models.Fleet{}, errors.New("no fleet found with the given ID")
+	}
+
+	return s.GetFleet(ctx, id, ownerID)
+}
+
+// DeleteFleet removes one of the owner's fleets; its cars stay listed without fleet settings
+func (s FleetStore) DeleteFleet(ctx context.Context, id, ownerID string) error {
+	tracer := otel.Tracer("FleetStore")
+	ctx, span := tracer.Start(ctx, "DeleteFleet-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM fleet WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no fleet found with the given ID")
+	}
+
+	return nil
+}
+
+// AddCar moves one of the owner's cars into one of their fleets, leaving any previous fleet
+func (s FleetStore) AddCar(ctx context.Context, id, ownerID, carID string) error {
+	tracer := otel.Tracer("FleetStore")
+	ctx, span := tracer.Start(ctx, "AddCar-Store")
+	defer span.End()
+
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM fleet WHERE id = $1 AND owner_id = $2)`,
+		id, ownerID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("no fleet found with the given ID")
+	}
+
+	result, err := s.db.ExecContext(ctx, `UPDATE car SET fleet_id = $1, updated_at = $4 WHERE id = $3 AND owner_id = $2`,
+		id, ownerID, carID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no car found with the given ID among your cars")
+	}
+
+	return nil
+}
+
+// RemoveCar takes a car out of one of the owner's fleets
+func (s FleetStore) RemoveCar(ctx context.Context, id, ownerID, carID string) error {
+	tracer := otel.Tracer("FleetStore")
+	ctx, span := tracer.Start(ctx, "RemoveCar-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `UPDATE car SET fleet_id = NULL, updated_at = $4
+	         WHERE id = $3 AND fleet_id = $1 AND EXISTS (SELECT 1 FROM fleet WHERE id = $1 AND owner_id = $2)`,
+		id, ownerID, carID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no car found with the given ID in this fleet")
+	}
+
+	return nil
+}
+
+// marshalSettings encodes a fleet request's settings for the JSONB columns, storing empty
+// lists rather than null
+func marshalSettings(req models.FleetRequest) (rules, addOns, blackouts []byte, err error) {
+	if req.PricingRules == nil {
+		req.PricingRules = []models.FleetPricingRule{}
+	}
+	if req.AddOns == nil {
+		req.AddOns = []models.FleetAddOn{}
+	}
+	if req.Blackouts == nil {
+		req.Blackouts = []models.FleetBlackout{}
+	}
+
+	if rules, err = json.Marshal(req.PricingRules); err != nil {
+		return nil, nil, nil, err
+	}
+	if addOns, err = json.Marshal(req.AddOns); err != nil {
+		return nil, nil, nil, err
+	}
+	if blackouts, err = json.Marshal(req.Blackouts); err != nil {
+		return nil, nil, nil, err
+	}
+	return rules, addOns, blackouts, nil
+}
+
+// scanFleet reads one fleet row with its aggregated member cars
+func scanFleet(row rowScanner) (models.Fleet, error) {
+	var f models.Fleet
+	var rulesJSON, addOnsJSON, blackoutsJSON []byte
+	var carIDs pq.StringArray
+	err := row.Scan(&f.ID, &f.OwnerID, &f.Name, &rulesJSON, &addOnsJSON, &blackoutsJSON, &f.CreatedAt, &f.UpdatedAt, &carIDs)
+	if err != nil {
+		return models.Fleet{}, err
+	}
+
+	if err := json.Unmarshal(rulesJSON, &f.PricingRules); err != nil {
+		return models.Fleet{}, err
+	}
+	if err := json.Unmarshal(addOnsJSON, &f.AddOns); err != nil {
+		return models.Fleet{}, err
+	}
+	if err := json.Unmarshal(blackoutsJSON, &f.Blackouts); err != nil {
+		return models.Fleet{}, err
+	}
+
+	f.CarIDs = make([]uuid.UUID, 0, len(carIDs))
+	for _, raw := range carIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return models.Fleet{}, err
+		}
+		f.CarIDs = append(f.CarIDs, id)
+	}
+
+	return f, nil
+}
@@ -0,0 +1,192 @@
+package incident
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// incidentColumns lists the columns read by every incident query, in scanIncident order
+const incidentColumns = `id, booking_id, car_id, customer_id, owner_id, reported_by, kind, description, occurred_at,
+	location, police_report_number, photo_urls, insurance_claim_number, status, resolution_notes, resolved_at,
+	created_at, updated_at`
+
+// documentColumns lists the columns read by every incident document query, in scanDocument order
+const documentColumns = `id, incident_id, kind, description, document_url, added_by, created_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// IncidentStore persists incidents reported against bookings and the documents attached to them
+type IncidentStore struct {
+	db *sql.DB
+}
+
+// New creates a new incident store
+func New(db *sql.DB) IncidentStore {
+	return IncidentStore{db: db}
+}
+
+// CreateIncident stores a newly reported incident
+func (s IncidentStore) CreateIncident(ctx context.Context, incident models.Incident) (models.Incident, error) {
+	tracer := otel.Tracer("IncidentStore")
+	ctx, span := tracer.Start(ctx, "CreateIncident-Store")
+	defer span.End()
+
+	now := time.Now()
+	query := `INSERT INTO incident (id, booking_id, car_id, customer_id, owner_id, reported_by, kind, description,
+	         occurred_at, location, police_report_number, photo_urls, status, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $14)
+	         RETURNING ` + incidentColumns
+
+	return scanIncident(s.db.QueryRowContext(ctx, query, uuid.New(), incident.BookingID, incident.CarID,
+		incident.CustomerID, incident.OwnerID, incident.ReportedBy, incident.Kind, incident.Description,
+		incident.OccurredAt, incident.Location, incident.PoliceReportNumber, pq.StringArray(incident.PhotoURLs),
+		models.IncidentStatusReported, now))
+}
+
+// GetIncident retrieves an incident with its documents
+func (s IncidentStore) GetIncident(ctx context.Context, id string) (models.Incident, error) {
+	tracer := otel.Tracer("IncidentStore")
+	ctx, span := tracer.Start(ctx, "GetIncident-Store")
+	defer span.End()
+
+	incident, err := scanIncident(s.db.QueryRowContext(ctx, `SELECT `+incidentColumns+` FROM incident WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Incident{}, errors.New("no incident found with the given ID")
+		}
+		return models.Incident{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+documentColumns+` FROM incident_document
+	         WHERE incident_id = $1 ORDER BY created_at, id`, id)
+	if err != nil {
+		return models.Incident{}, err
+	}
+	defer rows.Close()
+
+	incident.Documents = []models.IncidentDocument{}
+	for rows.Next() {
+		document, err := scanDocument(rows)
+		if err != nil {
+			return models.Incident{}, err
+		}
+		incident.Documents = append(incident.Documents, document)
+	}
+
+	return incident, rows.Err()
+}
+
+// GetIncidentsByBookingID retrieves the incidents reported against a booking, oldest first
+func (s IncidentStore) GetIncidentsByBookingID(ctx context.Context, bookingID string) ([]models.Incident, error) {
+	tracer := otel.Tracer("IncidentStore")
+	ctx, span := tracer.Start(ctx, "GetIncidentsByBookingID-Store")
+	defer span.End()
+
+	return s.queryIncidents(ctx, `SELECT `+incidentColumns+` FROM incident
+	         WHERE booking_id = $1 ORDER BY created_at, id`, bookingID)
+}
+
+// ListIncidents retrieves incidents, the longest outstanding first
+func (s IncidentStore) ListIncidents(ctx context.Context, filter models.IncidentFilter) ([]models.Incident, error) {
+	tracer := otel.Tracer("IncidentStore")
+	ctx, span := tracer.Start(ctx, "ListIncidents-Store")
+	defer span.End()
+
+	return s.queryIncidents(ctx, `SELECT `+incidentColumns+` FROM incident
+	         WHERE ($1 = '' OR status = $1)
+	         ORDER BY created_at, id`, string(filter.Status))
+}
+
+// UpdateIncidentStatus moves an incident from one status to another. Terminal statuses set
+// resolved_at; a claim number or notes, when given, replace the stored ones.
+func (s IncidentStore) UpdateIncidentStatus(ctx context.Context, id uuid.UUID, from, to models.IncidentStatus, claimNumber, notes *string) (models.Incident, error) {
+	tracer := otel.Tracer("IncidentStore")
+	ctx, span := tracer.Start(ctx, "UpdateIncidentStatus-Store")
+	defer span.End()
+
+	now := time.Now()
+	var resolvedAt *time.Time
+	if models.IncidentStatusMachine.Terminal(to) {
+		resolvedAt = &now
+	}
+
+	query := `UPDATE incident SET status = $3, insurance_claim_number = COALESCE($4, insurance_claim_number),
+	         resolution_notes = COALESCE($5, resolution_notes), resolved_at = COALESCE(resolved_at, $6), updated_at = $7
+	         WHERE id = $1 AND status = $2
+	         RETURNING ` + incidentColumns
+
+	incident, err := scanIncident(s.db.QueryRowContext(ctx, query, id, from, to, claimNumber, notes, resolvedAt, now))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Incident{}, errors.New("incident status was changed by someone else, reload and try again")
+		}
+		return models.Incident{}, err
+	}
+
+	return incident, nil
+}
+
+// AddDocument attaches a document to an incident
+func (s IncidentStore) AddDocument(ctx context.Context, incidentID, addedBy uuid.UUID, req models.IncidentDocumentRequest) (models.IncidentDocument, error) {
+	tracer := otel.Tracer("IncidentStore")
+	ctx, span := tracer.Start(ctx, "AddDocument-Store")
+	defer span.End()
+
+	query := `INSERT INTO incident_document (` + documentColumns + `)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7)
+	         RETURNING ` + documentColumns
+
+	return scanDocument(s.db.QueryRowContext(ctx, query, uuid.New(), incidentID, req.Kind, req.Description,
+		req.DocumentURL, addedBy, time.Now()))
+}
+
+// queryIncidents runs a query returning incident rows, without their documents
+func (s IncidentStore) queryIncidents(ctx context.Context, query string, args ...interface{}) ([]models.Incident, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	incidents := []models.Incident{}
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, err
+		}
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, rows.Err()
+}
+
+// scanIncident reads one incident row without its documents
+func scanIncident(row rowScanner) (models.Incident, error) {
+	var i models.Incident
+	var photos pq.StringArray
+	err := row.Scan(&i.ID, &i.BookingID, &i.CarID, &i.CustomerID, &i.OwnerID, &i.ReportedBy, &i.Kind, &i.Description,
+		&i.OccurredAt, &i.Location, &i.PoliceReportNumber, &photos, &i.InsuranceClaimNumber, &i.Status,
+		&i.ResolutionNotes, &i.ResolvedAt, &i.CreatedAt, &i.UpdatedAt)
+	i.PhotoURLs = []string(photos)
+	if i.PhotoURLs == nil {
+		i.PhotoURLs = []string{}
+	}
+	return i, err
+}
+
+// scanDocument reads one incident_document row
+func scanDocument(row rowScanner) (models.IncidentDocument, error) {
+	var d models.IncidentDocument
+	err := row.Scan(&d.ID, &d.IncidentID, &d.Kind, &d.Description, &d.DocumentURL, &d.AddedBy, &d.CreatedAt)
+	return d, err
+}
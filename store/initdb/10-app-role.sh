@@ -0,0 +1,16 @@
+#!/bin/bash
+# Creates the role the API connects as, run by the postgres image on a new data directory.
+# POSTGRES_USER is a superuser, and superusers bypass the row-level security policies of
+# store/schema.sql, so the API uses APP_DB_USER instead: a role that owns the schema but is
+# neither a superuser nor BYPASSRLS. The extensions of the schema need a superuser and are
+# created here.
+set -euo pipefail
+
+psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" \
+	-v app_user="$APP_DB_USER" -v app_password="$APP_DB_PASSWORD" -v db_name="$POSTGRES_DB" <<-'EOSQL'
+	CREATE ROLE :"app_user" LOGIN NOSUPERUSER NOBYPASSRLS NOCREATEDB NOCREATEROLE PASSWORD :'app_password';
+	GRANT CONNECT, CREATE ON DATABASE :"db_name" TO :"app_user";
+	GRANT CREATE ON SCHEMA public TO :"app_user";
+	CREATE EXTENSION IF NOT EXISTS pg_trgm;
+	CREATE EXTENSION IF NOT EXISTS btree_gist;
+EOSQL
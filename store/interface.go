@@ -4,8 +4,12 @@
 // All store implementations must adhere to these interfaces to ensure consistency.
 package store
 
+//go:generate mockgen -source=interface.go -destination=mocks/mocks.go -package=mocks
+
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/PrateekKumar15/CarZone/models"
 	"github.com/google/uuid"
@@ -34,14 +38,15 @@ type CarStoreInterface interface {
 	//   - error: Error if car not found or database operation fails
 	GetCarWithOwnerByID(ctx context.Context, id string) (models.Car, error)
 
-	// GetCarByBrand retrieves multiple car records filtered by brand name.
+	// GetCarByBrand retrieves multiple car records filtered by brand name, ignoring case.
 	// Parameters:
 	//   - ctx: Request context for cancellation and timeout
-	//   - brand: Brand name to filter by (e.g., "Toyota", "BMW")
+	//   - brand: Brand name to filter by (e.g., "Toyota", "bmw")
+	//   - fuzzy: Also match similar brand names (trigram similarity) to tolerate typos
 	// Returns:
-	//   - []models.Car: Slice of car records matching the brand
+	//   - []models.Car: Slice of car records matching the brand, most similar brands first
 	//   - error: Error if database operation fails
-	GetCarByBrand(ctx context.Context, brand string) ([]models.Car, error)
+	GetCarByBrand(ctx context.Context, brand string, fuzzy bool) ([]models.Car, error)
 
 	// CreateCar inserts a new car record into the database.
 	// The method generates a new UUID for the car and handles all creation logic.
@@ -74,7 +79,148 @@ type CarStoreInterface interface {
 	//   - error: Error if car not found or deletion fails
 	DeleteCar(ctx context.Context, id string) (models.Car, error)
 
-	GetAllCars(ctx context.Context) ([]models.Car, error)
+	// ListCars retrieves one page of cars matching the filter, newest first or, when the filter
+	// carries ranking weights, highest ranking score first with each car's Ranking set.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional status, feature and search criteria (brand, model, fuel, location, price, year, availability) and ranking weights
+	//   - page: Page size and optional cursor to continue from
+	// Returns:
+	//   - []models.Car: Up to page.Limit+1 car records (the extra row signals another page)
+	//   - error: Error if database operation fails
+	ListCars(ctx context.Context, filter models.CarFilter, page models.PageRequest) ([]models.Car, error)
+
+	// CountCars counts every car matching the filter, across all pages of ListCars.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: The same restrictions as for ListCars; sort and ranking weights are ignored
+	// Returns:
+	//   - int: Number of matching cars
+	//   - error: Error if database operation fails
+	CountCars(ctx context.Context, filter models.CarFilter) (int, error)
+
+	// GetBookableCarIDs checks a set of cars for a period in a single query.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carIDs: Cars to check, e.g. one page of search results
+	//   - start, end: Period the cars would be rented for
+	// Returns:
+	//   - map[uuid.UUID]bool: True for each car that is available with no overlapping booking or blackout
+	//   - error: Error if database operation fails
+	GetBookableCarIDs(ctx context.Context, carIDs []uuid.UUID, start, end time.Time) (map[uuid.UUID]bool, error)
+
+	// GetPriceHistory retrieves every change of a car's daily price.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - []models.CarPriceChange: Changes with who made them, newest first
+	//   - error: Error if database operation fails
+	GetPriceHistory(ctx context.Context, carID string) ([]models.CarPriceChange, error)
+
+	// GetPriceDrops checks a set of cars for a recent price reduction in a single query.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carIDs: Cars to check, e.g. one page of search results
+	//   - since: Only changes from then on count
+	// Returns:
+	//   - map[uuid.UUID]models.CarPriceDrop: The drop of each car whose latest change since then lowered its price
+	//   - error: Error if database operation fails
+	GetPriceDrops(ctx context.Context, carIDs []uuid.UUID, since time.Time) (map[uuid.UUID]models.CarPriceDrop, error)
+
+	// GetQualityFacts reads what a car's listing quality is computed from.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - models.CarQualityFacts: Photo count, description length, specs filled in and owner verification
+	//   - error: Car not found or data access error
+	GetQualityFacts(ctx context.Context, carID string) (models.CarQualityFacts, error)
+
+	// GetCarBySlug retrieves a car by its URL slug.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - slug: Unique URL-friendly identifier of the car
+	// Returns:
+	//   - models.Car: The car record if found, empty car if not found
+	//   - error: Error if database operation fails
+	GetCarBySlug(ctx context.Context, slug string) (models.Car, error)
+
+	// ListCarSitemapEntries retrieves the id, slug and update time of every active car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.CarSitemapEntry: One entry per publicly listed car, most recently updated first
+	//   - error: Error if database operation fails
+	ListCarSitemapEntries(ctx context.Context) ([]models.CarSitemapEntry, error)
+
+	// UpdateCarStatus moves a car from one lifecycle status to another.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the car
+	//   - from: Status the car is expected to be in
+	//   - to: New status
+	//   - isAvailable: Availability that goes with the new status
+	// Returns:
+	//   - models.Car: The updated car record
+	//   - error: Error if the car is no longer in the expected status or database operation fails
+	UpdateCarStatus(ctx context.Context, id string, from, to models.CarStatus, isAvailable bool) (models.Car, error)
+
+	// GetFuelPolicy retrieves a car's fuel policy.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - models.CarFuelPolicy: The car's fuel settings
+	//   - error: Error if no policy is set for the car or database operation fails
+	GetFuelPolicy(ctx context.Context, carID string) (models.CarFuelPolicy, error)
+
+	// UpsertFuelPolicy creates or replaces a car's fuel policy.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - req: New fuel settings
+	// Returns:
+	//   - models.CarFuelPolicy: The stored fuel settings
+	//   - error: Error if database operation fails
+	UpsertFuelPolicy(ctx context.Context, carID string, req models.CarFuelPolicyRequest) (models.CarFuelPolicy, error)
+
+	// GetEligibility retrieves a car's renter eligibility rules.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - models.CarEligibility: The car's rules; zero minimums when none are set
+	//   - error: Error if database operation fails
+	GetEligibility(ctx context.Context, carID string) (models.CarEligibility, error)
+
+	// UpsertEligibility creates or replaces a car's renter eligibility rules.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - req: New minimum age and licence years
+	// Returns:
+	//   - models.CarEligibility: The stored rules
+	//   - error: Error if database operation fails
+	UpsertEligibility(ctx context.Context, carID string, req models.CarEligibilityRequest) (models.CarEligibility, error)
+
+	// GetOwnerStats counts an owner's completed trips and booking decisions.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Unique identifier of the owner
+	// Returns:
+	//   - models.OwnerStats: Trip and decision counts; zero for an owner without bookings
+	//   - error: Error if database operation fails
+	GetOwnerStats(ctx context.Context, ownerID string) (models.OwnerStats, error)
+
+	// RebuildListedFlags unlists the cars whose status is not active.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Car to check; every car in scope when nil
+	// Returns:
+	//   - models.MaintenanceResult: Cars checked and cars unlisted
+	//   - error: Error if database operation fails
+	RebuildListedFlags(ctx context.Context, carID *uuid.UUID) (models.MaintenanceResult, error)
 }
 
 // UserStoreInterface defines the contract for user authentication and management operations.
@@ -110,6 +256,16 @@ type UserStoreInterface interface {
 	//   - error: Error if user not found or database operation fails
 	GetUserByID(ctx context.Context, userID string) (models.User, error)
 
+	// GetUserByEmail retrieves a user by e-mail address without checking a password, e.g. to
+	// send a password reset link. Only users of the operator the request acts for are found.
+	// Parameters:
+	//   - ctx: Request context carrying the operator
+	//   - email: User's email address
+	// Returns:
+	//   - models.User: User record if found
+	//   - error: Error if user not found or database operation fails
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+
 	// UpdateUser modifies an existing user record.
 	// Parameters:
 	//   - ctx: Request context for transaction management
@@ -129,6 +285,17 @@ type UserStoreInterface interface {
 	//   - error: Error if user not found or update fails
 	UpdateProfileData(ctx context.Context, userID string, profileData map[string]interface{}) error
 
+	// UpdateProfile updates the profile_data field and the encrypted driving licence number of a
+	// user together, so neither is written without the other.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: User's unique identifier
+	//   - profileData: Profile data as map[string]interface{}
+	//   - licenseNumber: Plaintext licence number, empty to remove it, nil to keep it
+	// Returns:
+	//   - error: Error if user not found or update fails
+	UpdateProfile(ctx context.Context, userID string, profileData map[string]interface{}, licenseNumber *string) error
+
 	// DeleteUser removes a user record from the database.
 	// Parameters:
 	//   - ctx: Request context for transaction management
@@ -146,6 +313,25 @@ type UserStoreInterface interface {
 	//   - error: Error if database operation fails
 	GetAllUsers(ctx context.Context) ([]models.User, error)
 
+	// ListUsers retrieves one page of users ordered by creation time, newest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - page: Page size and optional cursor to continue from
+	// Returns:
+	//   - []models.User: Up to page.Limit+1 user records (the extra row signals another page)
+	//   - error: Error if database operation fails
+	ListUsers(ctx context.Context, page models.PageRequest) ([]models.User, error)
+
+	// GetPublicProfile retrieves the publicly visible fields of a car owner.
+	// Only the ID, display name and month of sign-up are read.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Owner's unique identifier (UUID)
+	// Returns:
+	//   - models.PublicProfile: Profile without statistics or listings
+	//   - error: Error if no owner has the ID or database operation fails
+	GetPublicProfile(ctx context.Context, ownerID string) (models.PublicProfile, error)
+
 	// GetUsersByRole retrieves all users with a specific role.
 	// Parameters:
 	//   - ctx: Request context for cancellation and timeout
@@ -154,6 +340,72 @@ type UserStoreInterface interface {
 	//   - []models.User: Slice of users with specified role
 	//   - error: Error if database operation fails
 	GetUsersByRole(ctx context.Context, role string) ([]models.User, error)
+
+	// ReindexPhoneSearch recomputes the phone number blind index support search uses.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - models.MaintenanceResult: Users checked and users whose index was rewritten
+	//   - error: Decryption or database error
+	ReindexPhoneSearch(ctx context.Context) (models.MaintenanceResult, error)
+
+	// SetPassword replaces the password of the user with an e-mail address.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - email: E-mail address of the user
+	//   - password: New plain-text password; it is stored hashed
+	// Returns:
+	//   - uuid.UUID: ID of the updated user
+	//   - error: Error if no user has the e-mail address or database operation fails
+	SetPassword(ctx context.Context, email, password string) (uuid.UUID, error)
+
+	// GetTwoFactor reads a user's two-factor authentication state.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Unique identifier of the user
+	// Returns:
+	//   - models.TwoFactor: Decrypted TOTP secret, when it was confirmed and unused backup code hashes
+	//   - error: Error if the user does not exist or database operation fails
+	GetTwoFactor(ctx context.Context, userID string) (models.TwoFactor, error)
+
+	// SetPendingTwoFactor stores a TOTP secret, encrypted, that is not enforced until confirmed.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Unique identifier of the user
+	//   - secret: Base32 TOTP secret
+	// Returns:
+	//   - error: Error if two-factor authentication is already enabled or database operation fails
+	SetPendingTwoFactor(ctx context.Context, userID string, secret string) error
+
+	// EnableTwoFactor starts enforcing a user's pending TOTP secret.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Unique identifier of the user
+	//   - step: Time step of the code that confirmed the secret
+	//   - backupCodeHashes: SHA-256 hashes of the user's backup codes
+	// Returns:
+	//   - error: Error if two-factor authentication is already enabled or database operation fails
+	EnableTwoFactor(ctx context.Context, userID string, step int64, backupCodeHashes []string) error
+
+	// UseTwoFactorStep records the time step of a code accepted at login.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Unique identifier of the user
+	//   - step: Time step of the accepted code
+	// Returns:
+	//   - error: Error if a code of that step or a later one was already used, or database operation fails
+	UseTwoFactorStep(ctx context.Context, userID string, step int64) error
+
+	// UseBackupCode uses up one of a user's backup codes.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Unique identifier of the user
+	//   - codeHash: SHA-256 hash of the backup code
+	// Returns:
+	//   - error: Error if the user has no such unused code or database operation fails
+	UseBackupCode(ctx context.Context, userID string, codeHash string) error
+
+	EncryptedStoreInterface
 }
 
 // BookingStoreInterface defines the contract for booking data access operations.
@@ -200,10 +452,12 @@ type BookingStoreInterface interface {
 	// Parameters:
 	//   - ctx: Request context for transaction management
 	//   - bookingReq: Booking data to be inserted
+	//   - quote: Itemised price and, for door delivery, the geocoded delivery address
 	// Returns:
 	//   - models.Booking: The created booking record with generated ID and timestamps
-	//   - error: Error if creation fails or validation errors occur
-	CreateBooking(ctx context.Context, bookingReq models.BookingRequest, totalAmount float64) (models.Booking, error)
+	//   - error: Error if creation fails or validation errors occur; "overlaps another rental"
+	//     when a booking holding the car for an overlapping period already exists
+	CreateBooking(ctx context.Context, bookingReq models.BookingRequest, quote models.BookingQuote) (models.Booking, error)
 
 	// UpdateBookingStatus updates the status of an existing booking.
 	// Parameters:
@@ -212,7 +466,8 @@ type BookingStoreInterface interface {
 	//   - status: New booking status
 	// Returns:
 	//   - models.Booking: The updated booking record
-	//   - error: Error if booking not found or update operation fails
+	//   - error: Error if booking not found or update operation fails; "overlaps another rental"
+	//     when the status would make the booking hold the car over another rental
 	UpdateBookingStatus(ctx context.Context, id string, status models.BookingStatus) (models.Booking, error)
 
 	// DeleteBooking removes a booking record from the database.
@@ -224,13 +479,246 @@ type BookingStoreInterface interface {
 	//   - error: Error if booking not found or deletion fails
 	DeleteBooking(ctx context.Context, id string) (models.Booking, error)
 
-	// GetAllBookings retrieves all booking records.
+	// ListBookings retrieves one page of bookings matching the filter, newest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional customer, car, owner, or status restriction
+	//   - page: Page size and optional cursor to continue from
+	// Returns:
+	//   - []models.Booking: Up to page.Limit+1 booking records (the extra row signals another page)
+	//   - error: Error if database operation fails
+	ListBookings(ctx context.Context, filter models.BookingFilter, page models.PageRequest) ([]models.Booking, error)
+
+	// ListActiveTrips retrieves the owner's in-progress bookings, due back first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Owner of the cars
+	// Returns:
+	//   - []models.Booking: Trips under way, overdue ones included
+	//   - error: Error if database operation fails
+	ListActiveTrips(ctx context.Context, ownerID string) ([]models.Booking, error)
+
+	// AddReturnCharges adds the fees settled at check-in to a booking's price breakdown and total amount.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the booking
+	//   - lateFee: Late return fee in INR
+	//   - refuelFee: Fee for missing fuel in INR
+	// Returns:
+	//   - models.Booking: Updated booking
+	//   - error: Error if the booking does not exist or the update fails
+	AddReturnCharges(ctx context.Context, id string, lateFee, refuelFee float64) (models.Booking, error)
+
+	// MarkOverdueTrips flags in-progress bookings whose end date has passed.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - now: Time to compare end dates with, recorded as overdue_at
+	// Returns:
+	//   - []models.Booking: Bookings flagged by this call
+	//   - error: Error if database operation fails
+	MarkOverdueTrips(ctx context.Context, now time.Time) ([]models.Booking, error)
+
+	// MarkExpiringBookings flags unpaid pending bookings whose customer is due an expiry warning.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - createdBefore: Bookings created before this time are due a warning
+	//   - now: Time recorded as expiry_warned_at
+	// Returns:
+	//   - []models.Booking: Bookings flagged by this call
+	//   - error: Error if database operation fails
+	MarkExpiringBookings(ctx context.Context, createdBefore, now time.Time) ([]models.Booking, error)
+
+	// ExpireUnpaidBookings cancels pending bookings that were not paid in time.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - createdBefore: Bookings created before this time have expired
+	//   - now: Time recorded as updated_at
+	// Returns:
+	//   - []models.Booking: Bookings cancelled by this call
+	//   - error: Error if database operation fails
+	ExpireUnpaidBookings(ctx context.Context, createdBefore, now time.Time) ([]models.Booking, error)
+
+	// MarkHandBackReminders flags trips in progress whose customer is due a hand-back reminder.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - dueBefore: Trips due back before this time are due a reminder
+	//   - now: Trips already due back are left to overdue detection
+	// Returns:
+	//   - []models.Booking: Trips flagged by this call
+	//   - error: Error if database operation fails
+	MarkHandBackReminders(ctx context.Context, dueBefore, now time.Time) ([]models.Booking, error)
+
+	// GetBookingExtensions retrieves the extensions of a trip.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - parentID: Booking whose trip was extended
+	// Returns:
+	//   - []models.Booking: Extension bookings of any status, earliest first
+	//   - error: Error if database operation fails
+	GetBookingExtensions(ctx context.Context, parentID string) ([]models.Booking, error)
+
+	// GetRepriceableBookings retrieves pending bookings with no payment under way, held or made.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Booking to check; every booking in scope when nil
+	// Returns:
+	//   - []models.Booking: Bookings whose price may still change, oldest first
+	//   - error: Error if database operation fails
+	GetRepriceableBookings(ctx context.Context, bookingID *uuid.UUID) ([]models.Booking, error)
+
+	// UpdateBookingTotal reprices a pending booking if its total is still the one read.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Booking to reprice
+	//   - from: Total the booking was read with
+	//   - to: New total
+	// Returns:
+	//   - bool: False when the booking was no longer pending or its total changed meanwhile
+	//   - error: Error if database operation fails
+	UpdateBookingTotal(ctx context.Context, id uuid.UUID, from, to float64) (bool, error)
+
+	// CreateInspection stores a checkout or check-in record of a booking.
+	// Checkout starts the trip and check-in completes it; check-in odometer readings also
+	// advance the car's mileage.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - inspection: Readings, their sources and the recording user
+	//   - carID: Car of the booking, whose mileage a check-in updates
+	// Returns:
+	//   - models.BookingInspection: Created record with generated fields
+	//   - error: Error if the booking already has a record of this kind or insertion fails
+	CreateInspection(ctx context.Context, inspection models.BookingInspection, carID string) (models.BookingInspection, error)
+
+	// GetInspectionsByBookingID retrieves the checkout and check-in records of a booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Unique identifier of the booking
+	// Returns:
+	//   - []models.BookingInspection: Records, oldest first
+	//   - error: Error if database operation fails
+	GetInspectionsByBookingID(ctx context.Context, bookingID string) ([]models.BookingInspection, error)
+}
+
+// TelemetryStoreInterface defines the contract for telematics devices and their readings.
+// Device secrets are encrypted at rest and returned decrypted.
+type TelemetryStoreInterface interface {
+	// UpsertDevice provisions a car's device, replacing the secret of an existing one.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - carID: Unique identifier of the car
+	//   - secret: New HMAC signing secret
+	// Returns:
+	//   - models.TelemetryDevice: The device; its ID is kept when the secret is replaced
+	//   - error: Error if encryption or the database operation fails
+	UpsertDevice(ctx context.Context, carID, secret string) (models.TelemetryDevice, error)
+
+	// GetDeviceByID retrieves a device with its decrypted secret.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the device
+	// Returns:
+	//   - models.TelemetryDevice: The device record
+	//   - error: Error if not found or database operation fails
+	GetDeviceByID(ctx context.Context, id string) (models.TelemetryDevice, error)
+
+	// CreateReading stores one reading from a device and marks the device as seen.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - device: Authenticated device that sent the reading
+	//   - payload: Validated reading
+	// Returns:
+	//   - models.TelemetryReading: Stored reading
+	//   - error: Error if insertion fails
+	CreateReading(ctx context.Context, device models.TelemetryDevice, payload models.TelemetryPayload) (models.TelemetryReading, error)
+
+	// GetLatestSnapshot merges the newest value of every metric reported for a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - models.TelemetryReading: Latest snapshot
+	//   - error: Error if the car never reported or database operation fails
+	GetLatestSnapshot(ctx context.Context, carID string) (models.TelemetryReading, error)
+
+	// GetLatestOdometerAndFuel returns the newest odometer and fuel readings of a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - notBefore: Readings older than this are ignored
+	// Returns:
+	//   - *int: Odometer in km, nil if none was reported
+	//   - *float64: Fuel level percentage, nil if none was reported
+	//   - error: Error if database operation fails
+	GetLatestOdometerAndFuel(ctx context.Context, carID string, notBefore time.Time) (*int, *float64, error)
+
+	// CreateGeofence stores a circular geofence on a car, optionally limited to one booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - createdBy: User defining the geofence
+	//   - req: Validated geofence definition
+	// Returns:
+	//   - models.Geofence: Created geofence
+	//   - error: Error if the booking belongs to another car or insertion fails
+	CreateGeofence(ctx context.Context, carID, createdBy string, req models.GeofenceRequest) (models.Geofence, error)
+
+	// GetGeofencesByCarID retrieves the geofences defined on a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - []models.Geofence: Geofences, newest first
+	//   - error: Error if database operation fails
+	GetGeofencesByCarID(ctx context.Context, carID string) ([]models.Geofence, error)
+
+	// DeleteGeofence removes a geofence from a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - id: Unique identifier of the geofence
+	// Returns:
+	//   - error: Error if not found or database operation fails
+	DeleteGeofence(ctx context.Context, carID, id string) error
+
+	// GetGeofenceBreachesByCarID retrieves the most recent geofence breaches of a car.
 	// Parameters:
 	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - limit: Maximum number of breaches returned
 	// Returns:
-	//   - []models.Booking: Slice of all booking records
+	//   - []models.GeofenceBreach: Breaches, newest first
 	//   - error: Error if database operation fails
-	GetAllBookings(ctx context.Context) ([]models.Booking, error)
+	GetGeofenceBreachesByCarID(ctx context.Context, carID string, limit int) ([]models.GeofenceBreach, error)
+
+	// ListGeofenceChecks returns each geofence applying to an active rental with the car's latest position.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.GeofenceCheck: Geofence, rental, position and any open breach
+	//   - error: Error if database operation fails
+	ListGeofenceChecks(ctx context.Context) ([]models.GeofenceCheck, error)
+
+	// OpenGeofenceBreach records a car leaving a geofence during a rental.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - check: Geofence, rental and position that is outside the region
+	//   - distanceKm: Distance of the position from the geofence centre
+	// Returns:
+	//   - models.GeofenceBreach: Created breach
+	//   - bool: False when a breach was already open for the geofence and rental
+	//   - error: Error if database operation fails
+	OpenGeofenceBreach(ctx context.Context, check models.GeofenceCheck, distanceKm float64) (models.GeofenceBreach, bool, error)
+
+	// ResolveGeofenceBreach closes an open breach once the car is back inside.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the breach
+	//   - resolvedAt: Time of the reading showing the car back inside
+	// Returns:
+	//   - error: Error if database operation fails
+	ResolveGeofenceBreach(ctx context.Context, id string, resolvedAt time.Time) error
+
+	EncryptedStoreInterface
 }
 
 // PaymentStoreInterface defines the contract for payment data access operations.
@@ -265,6 +753,15 @@ type PaymentStoreInterface interface {
 	//   - error: Error if payment not found or database operation fails
 	GetPaymentByRazorpayOrderID(ctx context.Context, orderID string) (models.Payment, error)
 
+	// GetPaymentByRazorpayPaymentID retrieves the payment a Razorpay payment was made for.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - razorpayPaymentID: Razorpay payment identifier (pay_...)
+	// Returns:
+	//   - models.Payment: The payment record if found
+	//   - error: Error if payment not found or database operation fails
+	GetPaymentByRazorpayPaymentID(ctx context.Context, razorpayPaymentID string) (models.Payment, error)
+
 	// CreatePayment inserts a new payment record into the database.
 	// Parameters:
 	//   - ctx: Request context for transaction management
@@ -314,11 +811,1983 @@ type PaymentStoreInterface interface {
 	//   - error: Error if database operation fails
 	GetPaymentsByUserID(ctx context.Context, userID string) ([]models.Payment, error)
 
-	// GetAllPayments retrieves all payment records from the database.
+	// ListPayments retrieves one page of payments matching the filter, newest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional paying-user restriction
+	//   - page: Page size and optional cursor to continue from
+	// Returns:
+	//   - []models.Payment: Up to page.Limit+1 payment records (the extra row signals another page)
+	//   - error: Error if database operation fails
+	ListPayments(ctx context.Context, filter models.PaymentFilter, page models.PageRequest) ([]models.Payment, error)
+
+	// CreatePaymentLink stores a Razorpay payment link created for a pending payment.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - link: Payment, booking, Razorpay ID, URL, amount and expiry of the link
+	// Returns:
+	//   - models.PaymentLink: The stored link with status created
+	//   - error: Error if database operation fails
+	CreatePaymentLink(ctx context.Context, link models.PaymentLink) (models.PaymentLink, error)
+
+	// GetPaymentLinkByRazorpayID retrieves a payment link by Razorpay's ID for it.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - razorpayLinkID: Razorpay payment link ID (plink_...)
+	// Returns:
+	//   - models.PaymentLink: The payment link
+	//   - error: Error if not found or database operation fails
+	GetPaymentLinkByRazorpayID(ctx context.Context, razorpayLinkID string) (models.PaymentLink, error)
+
+	// UpdatePaymentLinkStatus records the status Razorpay reported for a payment link.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Payment link ID
+	//   - status: New status
+	// Returns:
+	//   - error: Error if not found or database operation fails
+	UpdatePaymentLinkStatus(ctx context.Context, id uuid.UUID, status models.PaymentLinkStatus) error
+
+	// RecordPaymentCollection completes a pending payment and stores its offline collection
+	// in one transaction.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - collection: Payment, booking, amount, receipt number and collector
+	// Returns:
+	//   - models.PaymentCollection: The stored collection
+	//   - models.Payment: The completed payment
+	//   - error: Error if the payment is no longer pending or database operation fails
+	RecordPaymentCollection(ctx context.Context, collection models.PaymentCollection) (models.PaymentCollection, models.Payment, error)
+
+	// GetPaymentCollectionsByBookingID retrieves the offline collections recorded for a booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Booking ID
+	// Returns:
+	//   - []models.PaymentCollection: Collections, oldest first
+	//   - error: Error if database operation fails
+	GetPaymentCollectionsByBookingID(ctx context.Context, bookingID string) ([]models.PaymentCollection, error)
+
+	// CreatePaymentAttempt records a new gateway attempt when a payment's Razorpay order is created.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - paymentID: Payment the order was created for
+	//   - orderID: Razorpay order ID
+	// Returns:
+	//   - models.PaymentAttempt: The pending attempt
+	//   - error: Error if database operation fails
+	CreatePaymentAttempt(ctx context.Context, paymentID uuid.UUID, orderID string) (models.PaymentAttempt, error)
+
+	// UpdatePaymentAttempt records the outcome of the attempt made on a Razorpay order.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - orderID: Razorpay order ID of the attempt
+	//   - status: Outcome (completed or failed)
+	//   - razorpayPaymentID: Optional Razorpay payment ID
+	//   - errorCode, errorDescription: Optional failure details
+	// Returns:
+	//   - error: Error if no attempt has the order or database operation fails
+	UpdatePaymentAttempt(ctx context.Context, orderID string, status models.PaymentStatus, razorpayPaymentID, errorCode, errorDescription *string) error
+
+	// GetPaymentAttempts retrieves every gateway attempt of a payment.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - paymentID: Payment ID
+	// Returns:
+	//   - []models.PaymentAttempt: Attempts, oldest first
+	//   - error: Error if database operation fails
+	GetPaymentAttempts(ctx context.Context, paymentID string) ([]models.PaymentAttempt, error)
+
+	// RetryPayment moves a failed payment back to pending with a new Razorpay order and records
+	// the new attempt, in one transaction.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - paymentID: Failed payment
+	//   - orderID: Razorpay order created for the retry
+	// Returns:
+	//   - models.Payment: The pending payment
+	//   - error: Error if the payment is no longer failed or database operation fails
+	RetryPayment(ctx context.Context, paymentID uuid.UUID, orderID string) (models.Payment, error)
+
+	// CreatePaymentAuthorization marks a payment as captured at pickup; repeated calls return
+	// the existing authorization.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - payment: Payment whose order is created in manual-capture mode
+	// Returns:
+	//   - models.PaymentAuthorization: The pending authorization
+	//   - error: Error if database operation fails
+	CreatePaymentAuthorization(ctx context.Context, payment models.Payment) (models.PaymentAuthorization, error)
+
+	// GetPaymentAuthorization retrieves the authorization of a manual-capture payment.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - paymentID: Unique identifier of the payment
+	// Returns:
+	//   - models.PaymentAuthorization: The authorization
+	//   - error: Error if the payment is captured automatically or database operation fails
+	GetPaymentAuthorization(ctx context.Context, paymentID string) (models.PaymentAuthorization, error)
+
+	// GetPaymentAuthorizationsByBookingID retrieves the authorizations of a booking's payments in a status.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Unique identifier of the booking
+	//   - status: Authorization status to match
+	// Returns:
+	//   - []models.PaymentAuthorization: Matching authorizations, oldest first
+	//   - error: Error if database operation fails
+	GetPaymentAuthorizationsByBookingID(ctx context.Context, bookingID string, status models.PaymentAuthorizationStatus) ([]models.PaymentAuthorization, error)
+
+	// UpdatePaymentAuthorization moves an authorization from one status to the next.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the authorization
+	//   - from: Status the authorization must still be in
+	//   - to: New status
+	//   - razorpayPaymentID: Razorpay payment holding the amount, nil to keep the current one
+	//   - expiresAt: When the hold lapses, nil to keep the current one
+	// Returns:
+	//   - models.PaymentAuthorization: The updated authorization
+	//   - error: Error if no longer in the from status or database operation fails
+	UpdatePaymentAuthorization(ctx context.Context, id uuid.UUID, from, to models.PaymentAuthorizationStatus, razorpayPaymentID *string, expiresAt *time.Time) (models.PaymentAuthorization, error)
+
+	// GetStalePendingPaymentIDs retrieves Razorpay payments still pending that were last
+	// updated before a time, oldest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - updatedBefore: Only payments untouched since before this time
+	//   - limit: Maximum number of payments
+	// Returns:
+	//   - []uuid.UUID: IDs of the matching payments
+	//   - error: Error if database operation fails
+	GetStalePendingPaymentIDs(ctx context.Context, updatedBefore time.Time, limit int) ([]uuid.UUID, error)
+}
+
+// PayoutStoreInterface defines the contract for owner payout account persistence.
+// Implementations must encrypt account numbers and UPI IDs at rest and return them
+// decrypted, so callers never handle ciphertext.
+type PayoutStoreInterface interface {
+	// CreatePayoutAccount stores a new payout account in pending state.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - ownerID: Unique identifier of the owning user
+	//   - req: Validated payout details (bank account or UPI ID)
+	// Returns:
+	//   - models.PayoutAccount: Created account with generated fields
+	//   - error: Error if encryption or insertion fails
+	CreatePayoutAccount(ctx context.Context, ownerID string, req models.PayoutAccountRequest) (models.PayoutAccount, error)
+
+	// GetPayoutAccountByID retrieves a payout account by its unique identifier.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the payout account
+	// Returns:
+	//   - models.PayoutAccount: The payout account record
+	//   - error: Error if not found or database operation fails
+	GetPayoutAccountByID(ctx context.Context, id string) (models.PayoutAccount, error)
+
+	// GetPayoutAccountsByOwnerID retrieves all payout accounts of an owner.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Unique identifier of the owning user
+	// Returns:
+	//   - []models.PayoutAccount: Owner's payout accounts, newest first
+	//   - error: Error if database operation fails
+	GetPayoutAccountsByOwnerID(ctx context.Context, ownerID string) ([]models.PayoutAccount, error)
+
+	// UpdatePayoutAccountVerification records gateway identifiers and verification status.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - id: Unique identifier of the payout account
+	//   - update: Gateway identifiers and outcome; nil identifiers keep existing values
+	// Returns:
+	//   - models.PayoutAccount: Updated payout account record
+	//   - error: Error if not found or update fails
+	UpdatePayoutAccountVerification(ctx context.Context, id string, update models.PayoutVerificationUpdate) (models.PayoutAccount, error)
+
+	// DeletePayoutAccount removes a payout account.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - id: Unique identifier of the payout account
+	// Returns:
+	//   - error: Error if not found or deletion fails
+	DeletePayoutAccount(ctx context.Context, id string) error
+
+	// CreatePayoutHold freezes an owner's payouts for a dispute; repeated calls are ignored.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - hold: Owner, dispute, booking and disputed amount
+	// Returns:
+	//   - error: Error if database operation fails
+	CreatePayoutHold(ctx context.Context, hold models.PayoutHold) error
+
+	// ReleasePayoutHold lifts the hold a dispute placed on payouts.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - disputeID: Dispute that placed the hold
+	// Returns:
+	//   - error: Error if database operation fails
+	ReleasePayoutHold(ctx context.Context, disputeID uuid.UUID) error
+
+	// GetPayoutHoldsByOwnerID retrieves an owner's payout holds.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Unique identifier of the owner
+	// Returns:
+	//   - []models.PayoutHold: Holds, active ones first
+	//   - error: Error if database operation fails
+	GetPayoutHoldsByOwnerID(ctx context.Context, ownerID string) ([]models.PayoutHold, error)
+
+	EncryptedStoreInterface
+}
+
+// SecurityStoreInterface defines the contract for security event and login device persistence.
+// It backs suspicious activity detection and the admin review queue.
+type SecurityStoreInterface interface {
+	// CreateSecurityEvent stores a new security event in open state.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - event: Event to store; ID, status and created_at are generated
+	// Returns:
+	//   - models.SecurityEvent: Created event with generated fields
+	//   - error: Error if insertion fails
+	CreateSecurityEvent(ctx context.Context, event models.SecurityEvent) (models.SecurityEvent, error)
+
+	// GetSecurityEventByID retrieves a security event by its unique identifier.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the security event
+	// Returns:
+	//   - models.SecurityEvent: The security event record
+	//   - error: Error if not found or database operation fails
+	GetSecurityEventByID(ctx context.Context, id string) (models.SecurityEvent, error)
+
+	// ListSecurityEvents retrieves one page of security events, newest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional status, type and user constraints
+	//   - page: Page size and cursor; implementations fetch page.Limit+1 rows in page.SortOrder()
+	// Returns:
+	//   - []models.SecurityEvent: Matching events
+	//   - error: Error if database operation fails
+	ListSecurityEvents(ctx context.Context, filter models.SecurityEventFilter, page models.PageRequest) ([]models.SecurityEvent, error)
+
+	// ReviewSecurityEvent records an admin's verdict on an open security event.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - id: Unique identifier of the security event
+	//   - reviewerID: Unique identifier of the reviewing admin
+	//   - review: Verdict and optional notes
+	// Returns:
+	//   - models.SecurityEvent: Updated security event
+	//   - error: Error if not found, already reviewed or update fails
+	ReviewSecurityEvent(ctx context.Context, id, reviewerID string, review models.SecurityEventReview) (models.SecurityEvent, error)
+
+	// HasOpenSecurityEventSince reports whether an open event of the given type exists for the user.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Unique identifier of the affected user
+	//   - eventType: Type of event to look for
+	//   - since: Only events raised at or after this time count
+	// Returns:
+	//   - bool: True if such an event exists
+	//   - error: Error if database operation fails
+	HasOpenSecurityEventSince(ctx context.Context, userID string, eventType models.SecurityEventType, since time.Time) (bool, error)
+
+	// RecordLogin remembers the IP address and user agent of a successful login.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - userID: Unique identifier of the user who logged in
+	//   - ipAddress: Client IP address
+	//   - userAgent: Client user agent
+	// Returns:
+	//   - bool: True if the user had never logged in from this IP address and user agent
+	//   - bool: True if this is the user's first recorded login
+	//   - error: Error if database operation fails
+	RecordLogin(ctx context.Context, userID, ipAddress, userAgent string) (bool, bool, error)
+
+	// CountFailedPaymentsSince counts a customer's failed payments.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - customerID: Unique identifier of the paying customer
+	//   - since: Start of the counting window
+	// Returns:
+	//   - int: Number of failed payments in the window
+	//   - error: Error if database operation fails
+	CountFailedPaymentsSince(ctx context.Context, customerID string, since time.Time) (int, error)
+
+	// CountCancelledBookingsSince counts a customer's cancelled bookings.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - customerID: Unique identifier of the customer
+	//   - since: Start of the counting window
+	// Returns:
+	//   - int: Number of bookings cancelled in the window
+	//   - error: Error if database operation fails
+	CountCancelledBookingsSince(ctx context.Context, customerID string, since time.Time) (int, error)
+
+	// CountBookingsCreatedSince counts the bookings a customer created.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - customerID: Unique identifier of the customer
+	//   - since: Start of the counting window
+	// Returns:
+	//   - int: Number of bookings created in the window
+	//   - error: Error if database operation fails
+	CountBookingsCreatedSince(ctx context.Context, customerID string, since time.Time) (int, error)
+}
+
+// EncryptedStoreInterface is implemented by stores that keep encrypted columns.
+// It lets a background job move old rows onto the active encryption key after a rotation.
+type EncryptedStoreInterface interface {
+	// RotateEncryptionKeys re-encrypts values sealed with a retired key (or stored as
+	// legacy plaintext) using the active key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - int: Number of rows rewritten
+	//   - error: Decryption or database error
+	RotateEncryptionKeys(ctx context.Context) (int, error)
+}
+
+// LocationStoreInterface defines the contract for pickup/drop-off locations and the cars
+// available at them.
+type LocationStoreInterface interface {
+	// CreateLocation stores a new location.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Validated location details
+	// Returns:
+	//   - models.Location: Created location
+	//   - error: Error if the code is taken or insertion fails
+	CreateLocation(ctx context.Context, req models.LocationRequest) (models.Location, error)
+
+	// GetLocationByID retrieves a location by its ID.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the location
+	// Returns:
+	//   - models.Location: The location
+	//   - error: Error if not found or database operation fails
+	GetLocationByID(ctx context.Context, id string) (models.Location, error)
+
+	// ListLocations retrieves locations ordered by city and name.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - city: Only locations in this city when not empty
+	//   - activeOnly: Skip inactive locations
+	// Returns:
+	//   - []models.Location: Matching locations
+	//   - error: Error if database operation fails
+	ListLocations(ctx context.Context, city string, activeOnly bool) ([]models.Location, error)
+
+	// UpdateLocation replaces the details of a location.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the location
+	//   - req: Validated location details
+	// Returns:
+	//   - models.Location: Updated location
+	//   - error: Error if not found, the code is taken, or the update fails
+	UpdateLocation(ctx context.Context, id string, req models.LocationRequest) (models.Location, error)
+
+	// DeleteLocation removes a location and detaches its cars.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the location
+	// Returns:
+	//   - error: Error if not found or database operation fails
+	DeleteLocation(ctx context.Context, id string) error
+
+	// AttachCar makes a car available at a location.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - locationID: Unique identifier of the location
+	// Returns:
+	//   - error: Error if the location does not exist or insertion fails
+	AttachCar(ctx context.Context, carID, locationID string) error
+
+	// DetachCar removes a car from a location.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - locationID: Unique identifier of the location
+	// Returns:
+	//   - error: Error if the car is not attached or database operation fails
+	DetachCar(ctx context.Context, carID, locationID string) error
+
+	// GetLocationsByCarID retrieves the locations a car is available at.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - []models.Location: Locations ordered by city and name
+	//   - error: Error if database operation fails
+	GetLocationsByCarID(ctx context.Context, carID string) ([]models.Location, error)
+
+	// GetCarLocation retrieves a location only if the car is attached to it.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - locationID: Unique identifier of the location
+	// Returns:
+	//   - models.Location: The location
+	//   - error: Error if the car is not available there or database operation fails
+	GetCarLocation(ctx context.Context, carID, locationID string) (models.Location, error)
+
+	// GetDeliveryOption retrieves a car's door delivery settings.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - models.DeliveryOption: The delivery settings
+	//   - error: Error if the car has no delivery settings or database operation fails
+	GetDeliveryOption(ctx context.Context, carID string) (models.DeliveryOption, error)
+
+	// UpsertDeliveryOption creates or replaces a car's door delivery settings.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - req: Delivery settings
+	// Returns:
+	//   - models.DeliveryOption: The stored delivery settings
+	//   - error: Error if database operation fails
+	UpsertDeliveryOption(ctx context.Context, carID string, req models.DeliveryOptionRequest) (models.DeliveryOption, error)
+
+	// ListRelocationFees retrieves the one-way fee matrix.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.RelocationFee: Fees ordered by from_city and to_city
+	//   - error: Error if database operation fails
+	ListRelocationFees(ctx context.Context) ([]models.RelocationFee, error)
+
+	// GetRelocationFee retrieves the fee of a one-way rental between two cities.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - fromCity: City the rental starts in (case-insensitive)
+	//   - toCity: City the car is returned in (case-insensitive)
+	// Returns:
+	//   - models.RelocationFee: The fee of the city pair
+	//   - error: Error if the city pair is not offered or database operation fails
+	GetRelocationFee(ctx context.Context, fromCity, toCity string) (models.RelocationFee, error)
+
+	// UpsertRelocationFee creates or replaces the fee of a city pair.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: City pair and fee
+	// Returns:
+	//   - models.RelocationFee: The stored fee
+	//   - error: Error if database operation fails
+	UpsertRelocationFee(ctx context.Context, req models.RelocationFeeRequest) (models.RelocationFee, error)
+
+	// DeleteRelocationFee removes a city pair from the fee matrix.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the relocation fee
+	// Returns:
+	//   - error: Error if not found or database operation fails
+	DeleteRelocationFee(ctx context.Context, id string) error
+}
+
+// AlertStoreInterface defines the contract for price-drop and availability subscriptions to cars.
+type AlertStoreInterface interface {
+	// UpsertSubscription subscribes a user to a car, replacing the criteria of an existing subscription.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Subscribing user
+	//   - carID: Car to watch
+	//   - req: Alert criteria
+	// Returns:
+	//   - models.CarAlertSubscription: The stored subscription
+	//   - error: Error if the car does not exist or database operation fails
+	UpsertSubscription(ctx context.Context, userID, carID string, req models.CarAlertSubscriptionRequest) (models.CarAlertSubscription, error)
+
+	// GetSubscriptionsByUserID retrieves a user's subscriptions, newest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Subscribing user
+	// Returns:
+	//   - []models.CarAlertSubscription: The user's subscriptions
+	//   - error: Error if database operation fails
+	GetSubscriptionsByUserID(ctx context.Context, userID string) ([]models.CarAlertSubscription, error)
+
+	// GetSubscriptionsByCarID retrieves every subscription to a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Watched car
+	// Returns:
+	//   - []models.CarAlertSubscription: Subscriptions to the car
+	//   - error: Error if database operation fails
+	GetSubscriptionsByCarID(ctx context.Context, carID string) ([]models.CarAlertSubscription, error)
+
+	// DeleteSubscription removes one of the user's subscriptions.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the subscription
+	//   - userID: Subscribing user; other users' subscriptions are reported as missing
+	// Returns:
+	//   - error: Error if not found or database operation fails
+	DeleteSubscription(ctx context.Context, id, userID string) error
+
+	// MarkNotified records when a subscriber was last alerted.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the subscription
+	//   - at: Time the alert was sent
+	// Returns:
+	//   - error: Error if database operation fails
+	MarkNotified(ctx context.Context, id string, at time.Time) error
+}
+
+// FavoriteStoreInterface defines the contract for the cars users saved to their wishlist.
+type FavoriteStoreInterface interface {
+	// AddFavorite saves a car to the user's wishlist; saving it again keeps the original favorite.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: User saving the car
+	//   - carID: Car to save
+	// Returns:
+	//   - models.Favorite: The stored favorite, without its car
+	//   - bool: True if the car was newly saved
+	//   - error: Error if the car does not exist or database operation fails
+	AddFavorite(ctx context.Context, userID, carID string) (models.Favorite, bool, error)
+
+	// RemoveFavorite removes a car from the user's wishlist.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: User who saved the car
+	//   - carID: Saved car
+	// Returns:
+	//   - error: Error if the car is not saved or database operation fails
+	RemoveFavorite(ctx context.Context, userID, carID string) error
+
+	// ListFavorites retrieves one page of the user's favorites with their cars, most recently saved first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: User who saved the cars
+	//   - page: Page size and cursor; implementations fetch page.Limit+1 rows in page.SortOrder()
+	// Returns:
+	//   - []models.Favorite: Favorites with their cars
+	//   - error: Error if database operation fails
+	ListFavorites(ctx context.Context, userID string, page models.PageRequest) ([]models.Favorite, error)
+}
+
+// AnalyticsStoreInterface defines the contract for aggregate queries behind the admin dashboards.
+type AnalyticsStoreInterface interface {
+	// GetTimeSeries buckets a metric over a time range.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - query: Metric, bucket width and range
+	// Returns:
+	//   - []models.TimeSeriesPoint: One point per bucket in order, zero for empty buckets
+	//   - error: Error if database operation fails
+	GetTimeSeries(ctx context.Context, query models.TimeSeriesQuery) ([]models.TimeSeriesPoint, error)
+
+	// RefreshDashboards recomputes the days of the dashboard read model changed since the last
+	// refresh, or every day when a full rebuild is due.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - rebuildEvery: How often every day is rebuilt, to catch deletions
+	// Returns:
+	//   - models.DashboardRefresh: Whether it was a rebuild and how many days were recomputed
+	//   - error: Error if database operation fails
+	RefreshDashboards(ctx context.Context, rebuildEvery time.Duration) (models.DashboardRefresh, error)
+
+	// GetDashboardRefreshedAt returns when the dashboard read model was last refreshed.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - *time.Time: Last refresh, nil if the read model has never been built
+	//   - error: Error if database operation fails
+	GetDashboardRefreshedAt(ctx context.Context) (*time.Time, error)
+
+	// GetProjectedTimeSeries buckets a metric from the daily read model.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - query: Metric, bucket width of a day or longer, and range
+	// Returns:
+	//   - []models.TimeSeriesPoint: One point per bucket in order, zero for empty buckets
+	//   - error: Error if the interval is hourly or database operation fails
+	GetProjectedTimeSeries(ctx context.Context, query models.TimeSeriesQuery) ([]models.TimeSeriesPoint, error)
+
+	// GetOwnerEarnings ranks owners by completed payments over a range.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - r: Days covered
+	//   - limit: Maximum number of owners
+	// Returns:
+	//   - []models.OwnerEarnings: Owners, highest revenue first
+	//   - error: Error if database operation fails
+	GetOwnerEarnings(ctx context.Context, r models.DashboardRange, limit int) ([]models.OwnerEarnings, error)
+
+	// GetCarUtilization ranks cars by booked hours over a range.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - r: Days covered
+	//   - limit: Maximum number of cars
+	// Returns:
+	//   - []models.CarUtilization: Cars, most booked first
+	//   - error: Error if database operation fails
+	GetCarUtilization(ctx context.Context, r models.DashboardRange, limit int) ([]models.CarUtilization, error)
+
+	// GetBookingFunnel counts the bookings created over a range by outcome.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - r: Days covered
+	// Returns:
+	//   - models.BookingFunnel: Counts and conversion rates
+	//   - error: Error if database operation fails
+	GetBookingFunnel(ctx context.Context, r models.DashboardRange) (models.BookingFunnel, error)
+}
+
+// WarehouseStoreInterface defines the contract for reading incremental changes for the data warehouse export.
+type WarehouseStoreInterface interface {
+	// GetWatermark returns the updated_at up to which an entity was exported.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - entity: Exported entity
+	// Returns:
+	//   - time.Time: Export watermark; zero if the entity was never exported
+	//   - error: Error if database operation fails
+	GetWatermark(ctx context.Context, entity models.WarehouseEntity) (time.Time, error)
+
+	// SetWatermark records that an entity was exported up to the given updated_at.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - entity: Exported entity
+	//   - exportedUntil: New watermark
+	//   - rows: Number of rows in the export, kept for monitoring
+	// Returns:
+	//   - error: Error if database operation fails
+	SetWatermark(ctx context.Context, entity models.WarehouseEntity, exportedUntil time.Time, rows int) error
+
+	// GetChangedRows returns the PII-free rows of an entity changed in (from, to].
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - entity: Exported entity
+	//   - from: Exclusive lower bound of updated_at
+	//   - to: Inclusive upper bound of updated_at
+	// Returns:
+	//   - models.WarehouseRows: Column names and row values as text
+	//   - error: Error if the entity is unknown or database operation fails
+	GetChangedRows(ctx context.Context, entity models.WarehouseEntity, from, to time.Time) (models.WarehouseRows, error)
+}
+
+// SearchStoreInterface defines the contract for admin support search across users, cars, bookings and payments.
+type SearchStoreInterface interface {
+	// Search returns the records matching any interpretation of a search term, newest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - query: Parsed search term
+	//   - limit: Maximum number of hits
+	// Returns:
+	//   - []models.SearchHit: Matching records without links
+	//   - error: Error if database operation fails
+	Search(ctx context.Context, query models.SearchQuery, limit int) ([]models.SearchHit, error)
+}
+
+// OperatorStoreInterface defines the contract for marketplace operator persistence.
+type OperatorStoreInterface interface {
+	// CreateOperator stores a new operator.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Validated operator settings
+	// Returns:
+	//   - models.Operator: The created operator
+	//   - error: Error if the slug or domain is taken or database operation fails
+	CreateOperator(ctx context.Context, req models.OperatorRequest) (models.Operator, error)
+
+	// GetOperatorByID retrieves an operator by its ID.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Operator UUID
+	// Returns:
+	//   - models.Operator: The operator
+	//   - error: Error if not found or database operation fails
+	GetOperatorByID(ctx context.Context, id string) (models.Operator, error)
+
+	// GetOperatorByDomain retrieves the operator serving a white-label host name.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - domain: Normalized host name
+	// Returns:
+	//   - *models.Operator: The operator, or nil when no operator uses the domain
+	//   - error: Error if database operation fails
+	GetOperatorByDomain(ctx context.Context, domain string) (*models.Operator, error)
+
+	// ListOperators retrieves every operator.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.Operator: Operators ordered by name
+	//   - error: Error if database operation fails
+	ListOperators(ctx context.Context) ([]models.Operator, error)
+
+	// UpdateOperator replaces an operator's settings.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Operator UUID
+	//   - req: Validated operator settings
+	// Returns:
+	//   - models.Operator: The updated operator
+	//   - error: Error if not found, the slug or domain is taken, or database operation fails
+	UpdateOperator(ctx context.Context, id string, req models.OperatorRequest) (models.Operator, error)
+}
+
+// NotificationStoreInterface defines the contract for the in-app notification inbox.
+type NotificationStoreInterface interface {
+	// CreateNotification stores a new unread notification in a user's inbox.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Recipient
+	//   - subject: Short summary of the message
+	//   - message: Plain-text body
+	// Returns:
+	//   - models.Notification: The stored notification
+	//   - error: Error if database operation fails
+	CreateNotification(ctx context.Context, userID, subject, message string) (models.Notification, error)
+
+	// ListNotifications retrieves one page of a user's notifications, newest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Inbox owner
+	//   - filter: Optional unread-only filter
+	//   - page: Page size and cursor; the store fetches page.Limit+1 rows
+	// Returns:
+	//   - []models.Notification: Notifications in page.SortOrder
+	//   - error: Error if database operation fails
+	ListNotifications(ctx context.Context, userID string, filter models.NotificationFilter, page models.PageRequest) ([]models.Notification, error)
+
+	// MarkRead marks one of the user's notifications as read.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the notification
+	//   - userID: Inbox owner; other users' notifications are reported as missing
+	// Returns:
+	//   - models.Notification: The notification with its read time
+	//   - error: Error if not found or database operation fails
+	MarkRead(ctx context.Context, id, userID string) (models.Notification, error)
+
+	// CountUnread counts the user's unread notifications.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Inbox owner
+	// Returns:
+	//   - int: Number of unread notifications
+	//   - error: Error if database operation fails
+	CountUnread(ctx context.Context, userID string) (int, error)
+}
+
+// EmailTemplateStoreInterface defines the contract for versioned e-mail template persistence.
+type EmailTemplateStoreInterface interface {
+	// CreateTemplate stores version 1 of a new template key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Validated key, subject and body
+	//   - createdBy: Admin saving the template
+	// Returns:
+	//   - models.EmailTemplate: The stored version
+	//   - error: Error if the key already exists or database operation fails
+	CreateTemplate(ctx context.Context, req models.EmailTemplateRequest, createdBy string) (models.EmailTemplate, error)
+
+	// AddTemplateVersion stores the next version of an existing template key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Template key
+	//   - req: Validated subject and body
+	//   - createdBy: Admin saving the version
+	// Returns:
+	//   - models.EmailTemplate: The stored version
+	//   - error: Error if the key does not exist, a concurrent save won, or database operation fails
+	AddTemplateVersion(ctx context.Context, key string, req models.EmailTemplateRequest, createdBy string) (models.EmailTemplate, error)
+
+	// GetTemplate retrieves the current (highest) version of a template key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Template key
+	// Returns:
+	//   - models.EmailTemplate: The current version
+	//   - error: Error if not found or database operation fails
+	GetTemplate(ctx context.Context, key string) (models.EmailTemplate, error)
+
+	// ListTemplates retrieves the current version of every template key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.EmailTemplate: Current versions ordered by key
+	//   - error: Error if database operation fails
+	ListTemplates(ctx context.Context) ([]models.EmailTemplate, error)
+
+	// ListTemplateVersions retrieves every version of a template key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Template key
+	// Returns:
+	//   - []models.EmailTemplate: Versions, newest first; empty for unknown keys
+	//   - error: Error if database operation fails
+	ListTemplateVersions(ctx context.Context, key string) ([]models.EmailTemplate, error)
+
+	// DeleteTemplate removes every version of a template key.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Template key
+	// Returns:
+	//   - error: Error if not found or database operation fails
+	DeleteTemplate(ctx context.Context, key string) error
+}
+
+// KYCStoreInterface defines the contract for the identity data admins verified for renters.
+type KYCStoreInterface interface {
+	// GetVerifiedKYC retrieves the verified identity data of a user.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Unique identifier of the user
+	// Returns:
+	//   - models.VerifiedKYC: Verified date of birth and licence issue date
+	//   - error: Error if the user has not been verified or database operation fails
+	GetVerifiedKYC(ctx context.Context, userID string) (models.VerifiedKYC, error)
+
+	// UpsertVerifiedKYC records or replaces the verified identity data of a user.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: User whose documents were checked
+	//   - verifiedBy: Admin who checked them
+	//   - dateOfBirth, licenseIssuedOn: Dates read from the documents
+	// Returns:
+	//   - models.VerifiedKYC: The stored record
+	//   - error: Error if the user does not exist or database operation fails
+	UpsertVerifiedKYC(ctx context.Context, userID, verifiedBy string, dateOfBirth, licenseIssuedOn time.Time) (models.VerifiedKYC, error)
+}
+
+// VacationStoreInterface defines the contract for owner vacations, which block all of an
+// owner's cars for a period.
+type VacationStoreInterface interface {
+	// CreateVacation blocks every car of the owner for the period in a single transaction.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Owner going on vacation
+	//   - req: Validated period and optional note
+	// Returns:
+	//   - models.OwnerVacation: The vacation with the cars it blocked
+	//   - error: Error if the owner has no cars, the period overlaps another vacation or open
+	//     bookings, or database operation fails
+	CreateVacation(ctx context.Context, ownerID string, req models.VacationRequest) (models.OwnerVacation, error)
+
+	// ListVacations retrieves the owner's vacations.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Owner whose vacations to list
+	// Returns:
+	//   - []models.OwnerVacation: Vacations, latest first
+	//   - error: Error if database operation fails
+	ListVacations(ctx context.Context, ownerID string) ([]models.OwnerVacation, error)
+
+	// DeleteVacation lifts a vacation, unblocking all its cars in a single transaction.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the vacation
+	//   - ownerID: Owner; other owners' vacations are reported as missing
+	// Returns:
+	//   - models.OwnerVacation: The removed vacation with the cars it had blocked
+	//   - error: Error if not found or database operation fails
+	DeleteVacation(ctx context.Context, id string, ownerID string) (models.OwnerVacation, error)
+
+	// IsCarBlocked reports whether a vacation blocks the car for any part of a period.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Car to check
+	//   - start, end: Period to check
+	// Returns:
+	//   - bool: True if the car is blocked for part of the period
+	//   - error: Error if database operation fails
+	IsCarBlocked(ctx context.Context, carID string, start, end time.Time) (bool, error)
+
+	// ListCarBlackouts retrieves the vacation blackouts of a car overlapping a period.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Car to check
+	//   - start, end: Period to check
+	// Returns:
+	//   - []models.CarBlackout: Overlapping blackouts, earliest first
+	//   - error: Error if database operation fails
+	ListCarBlackouts(ctx context.Context, carID string, start, end time.Time) ([]models.CarBlackout, error)
+}
+
+// FleetStoreInterface defines the contract for owners' fleets: groups of cars sharing pricing
+// rules, add-ons and blackout dates.
+type FleetStoreInterface interface {
+	// CreateFleet stores a new, empty fleet.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Owner of the fleet
+	//   - req: Validated name and settings
+	// Returns:
+	//   - models.Fleet: The stored fleet
+	//   - error: Error if the owner already has a fleet with the name or database operation fails
+	CreateFleet(ctx context.Context, ownerID string, req models.FleetRequest) (models.Fleet, error)
+
+	// GetFleet retrieves one of the owner's fleets with its member cars.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the fleet
+	//   - ownerID: Owner; other owners' fleets are reported as missing
+	// Returns:
+	//   - models.Fleet: The fleet
+	//   - error: Error if not found or database operation fails
+	GetFleet(ctx context.Context, id, ownerID string) (models.Fleet, error)
+
+	// GetFleetByCarID retrieves the fleet a car belongs to.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Car whose fleet to look up
+	// Returns:
+	//   - models.Fleet: The car's fleet
+	//   - error: "car is not in a fleet" if it has none, or database error
+	GetFleetByCarID(ctx context.Context, carID string) (models.Fleet, error)
+
+	// ListFleets retrieves the owner's fleets.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Owner whose fleets to list
+	// Returns:
+	//   - []models.Fleet: Fleets ordered by name
+	//   - error: Error if database operation fails
+	ListFleets(ctx context.Context, ownerID string) ([]models.Fleet, error)
+
+	// UpdateFleet replaces the name and settings of one of the owner's fleets.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the fleet
+	//   - ownerID: Owner; other owners' fleets are reported as missing
+	//   - req: Validated name and settings
+	// Returns:
+	//   - models.Fleet: The updated fleet
+	//   - error: Error if not found, the name is taken or database operation fails
+	UpdateFleet(ctx context.Context, id, ownerID string, req models.FleetRequest) (models.Fleet, error)
+
+	// DeleteFleet removes one of the owner's fleets; its cars leave the fleet.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the fleet
+	//   - ownerID: Owner; other owners' fleets are reported as missing
+	// Returns:
+	//   - error: Error if not found or database operation fails
+	DeleteFleet(ctx context.Context, id, ownerID string) error
+
+	// AddCar moves one of the owner's cars into one of their fleets. A car is in at most one fleet.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the fleet
+	//   - ownerID: Owner of both the fleet and the car
+	//   - carID: Car to add
+	// Returns:
+	//   - error: Error if the fleet or car is not found or database operation fails
+	AddCar(ctx context.Context, id, ownerID, carID string) error
+
+	// RemoveCar takes a car out of one of the owner's fleets.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the fleet
+	//   - ownerID: Owner of the fleet
+	//   - carID: Car to remove
+	// Returns:
+	//   - error: Error if the car is not in the fleet or database operation fails
+	RemoveCar(ctx context.Context, id, ownerID, carID string) error
+}
+
+// FeatureStoreInterface defines the contract for the curated features taxonomy cars' features
+// are validated against.
+type FeatureStoreInterface interface {
+	// ListFeatures retrieves the taxonomy with the number of cars offering each feature.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.Feature: Every feature ordered by key
+	//   - error: Error if database operation fails
+	ListFeatures(ctx context.Context) ([]models.Feature, error)
+
+	// CreateFeature adds a feature to the taxonomy.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Validated key, name and aliases
+	// Returns:
+	//   - models.Feature: The stored feature
+	//   - error: Error if the key is taken or database operation fails
+	CreateFeature(ctx context.Context, req models.FeatureRequest) (models.Feature, error)
+
+	// UpdateFeature replaces the name and aliases of a feature.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Canonical key of the feature
+	//   - req: Validated name and aliases
+	// Returns:
+	//   - models.Feature: The updated feature
+	//   - error: Error if not found or database operation fails
+	UpdateFeature(ctx context.Context, key string, req models.FeatureRequest) (models.Feature, error)
+
+	// ListNonCanonicalCarFeatures retrieves the features of cars listing keys outside the taxonomy.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.CarFeatures: Features of each such car
+	//   - error: Error if database operation fails
+	ListNonCanonicalCarFeatures(ctx context.Context) ([]models.CarFeatures, error)
+
+	// UpdateCarFeatures replaces the features of a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - features: New features map
+	// Returns:
+	//   - error: Error if database operation fails
+	UpdateCarFeatures(ctx context.Context, carID string, features map[string]interface{}) error
+}
+
+// CarAttributeStoreInterface defines the contract for the custom car attributes of the
+// operator a request acts for.
+type CarAttributeStoreInterface interface {
+	// ListCarAttributes retrieves the operator's attribute definitions.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - []models.CarAttribute: Every definition ordered by key, with the number of cars using it
+	//   - error: Error if database operation fails
+	ListCarAttributes(ctx context.Context) ([]models.CarAttribute, error)
+
+	// CreateCarAttribute defines an attribute for the operator.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Validated key, name, type, options and required flag
+	// Returns:
+	//   - models.CarAttribute: The stored definition
+	//   - error: Error if the key is taken or database operation fails
+	CreateCarAttribute(ctx context.Context, req models.CarAttributeRequest) (models.CarAttribute, error)
+
+	// UpdateCarAttribute replaces the name, options and required flag of an attribute.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Key of the attribute
+	//   - req: Validated name, options and required flag
+	// Returns:
+	//   - models.CarAttribute: The updated definition
+	//   - error: Error if not found or database operation fails
+	UpdateCarAttribute(ctx context.Context, key string, req models.CarAttributeRequest) (models.CarAttribute, error)
+
+	// DeleteCarAttribute removes an attribute and its values from the operator's cars.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: Key of the attribute
+	// Returns:
+	//   - error: Error if not found or database operation fails
+	DeleteCarAttribute(ctx context.Context, key string) error
+}
+
+// BrandStoreInterface defines the contract for the car brand and model reference data.
+type BrandStoreInterface interface {
+	// ListBrands retrieves brands for an autocomplete.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - prefix: Case-insensitive start of the brand name; empty lists every brand
+	//   - limit: Maximum number of brands
+	// Returns:
+	//   - []models.CarBrand: Matching brands ordered by name
+	//   - error: Error if database operation fails
+	ListBrands(ctx context.Context, prefix string, limit int) ([]models.CarBrand, error)
+
+	// ListModels retrieves a brand's models for an autocomplete.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - brandID: Unique identifier of the brand
+	//   - prefix: Case-insensitive start of the model name; empty lists every model
+	//   - limit: Maximum number of models
+	// Returns:
+	//   - []models.CarModel: Matching models ordered by name
+	//   - error: Error if the brand is not found or database operation fails
+	ListModels(ctx context.Context, brandID, prefix string, limit int) ([]models.CarModel, error)
+
+	// FindBrandModel looks up a brand and one of its models by name, ignoring case.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - brand: Brand name
+	//   - model: Model name
+	// Returns:
+	//   - *models.CarBrand: The brand, nil if unknown
+	//   - *models.CarModel: The model, nil if the brand has no model of that name
+	//   - error: Error if database operation fails
+	FindBrandModel(ctx context.Context, brand, model string) (*models.CarBrand, *models.CarModel, error)
+}
+
+// DisputeStoreInterface defines the contract for chargebacks and their evidence.
+type DisputeStoreInterface interface {
+	// CreateDispute stores a dispute reported by Razorpay unless it is already stored.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - dispute: Payment, booking, owner and Razorpay's dispute details
+	// Returns:
+	//   - models.Dispute: The stored dispute
+	//   - bool: Whether it was created by this call
+	//   - error: Error if database operation fails
+	CreateDispute(ctx context.Context, dispute models.Dispute) (models.Dispute, bool, error)
+
+	// GetDispute retrieves a dispute with its evidence.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the dispute
+	// Returns:
+	//   - models.Dispute: The dispute and its evidence, oldest first
+	//   - error: Error if not found or database operation fails
+	GetDispute(ctx context.Context, id string) (models.Dispute, error)
+
+	// GetDisputeByRazorpayID retrieves a dispute by Razorpay's ID for it.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - razorpayDisputeID: Razorpay dispute ID (disp_...)
+	// Returns:
+	//   - models.Dispute: The dispute without its evidence
+	//   - error: Error if not found or database operation fails
+	GetDisputeByRazorpayID(ctx context.Context, razorpayDisputeID string) (models.Dispute, error)
+
+	// ListDisputes retrieves disputes, those due for a response soonest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional status
+	// Returns:
+	//   - []models.Dispute: Disputes without their evidence
+	//   - error: Error if database operation fails
+	ListDisputes(ctx context.Context, filter models.DisputeFilter) ([]models.Dispute, error)
+
+	// UpdateDisputeStatus records a new status and phase of a dispute.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the dispute
+	//   - status: New status; resolved statuses set resolved_at
+	//   - phase: New phase, empty to keep the current one
+	//   - notes: Optional outcome notes
+	// Returns:
+	//   - models.Dispute: The updated dispute without its evidence
+	//   - error: Error if not found or database operation fails
+	UpdateDisputeStatus(ctx context.Context, id uuid.UUID, status models.DisputeStatus, phase string, notes *string) (models.Dispute, error)
+
+	// AddEvidence attaches a document to a dispute.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - disputeID: Unique identifier of the dispute
+	//   - addedBy: Admin attaching the document
+	//   - req: Kind, description and document URL
+	// Returns:
+	//   - models.DisputeEvidence: The stored evidence
+	//   - error: Error if database operation fails
+	AddEvidence(ctx context.Context, disputeID, addedBy uuid.UUID, req models.DisputeEvidenceRequest) (models.DisputeEvidence, error)
+}
+
+// SequenceStoreInterface defines the contract for gapless numbering of financial documents.
+type SequenceStoreInterface interface {
+	// IssueDocument numbers and stores an invoice or receipt, or returns the one already issued
+	// for the same booking or payment.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - doc: Series, fiscal year, booking, payment and amount
+	// Returns:
+	//   - models.FinancialDocument: The issued document with its number
+	//   - error: Error if database operation fails
+	IssueDocument(ctx context.Context, doc models.FinancialDocument) (models.FinancialDocument, error)
+
+	// GetDocumentsByBookingID retrieves the documents issued for a booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Unique identifier of the booking
+	// Returns:
+	//   - []models.FinancialDocument: Documents, oldest first
+	//   - error: Error if database operation fails
+	GetDocumentsByBookingID(ctx context.Context, bookingID string) ([]models.FinancialDocument, error)
+}
+
+// AdjustmentStoreInterface defines the contract for credit notes and manual charges on bookings.
+type AdjustmentStoreInterface interface {
+	// CreateAdjustment stores a new adjustment in pending_approval status.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - adjustment: Booking, kind, amount, reason and requesting admin
+	// Returns:
+	//   - models.BookingAdjustment: The stored adjustment
+	//   - error: Error if database operation fails
+	CreateAdjustment(ctx context.Context, adjustment models.BookingAdjustment) (models.BookingAdjustment, error)
+
+	// GetAdjustment retrieves an adjustment by ID.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the adjustment
+	// Returns:
+	//   - models.BookingAdjustment: The adjustment
+	//   - error: Error if not found or database operation fails
+	GetAdjustment(ctx context.Context, id string) (models.BookingAdjustment, error)
+
+	// ListAdjustments retrieves adjustments, oldest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional status
+	// Returns:
+	//   - []models.BookingAdjustment: Matching adjustments
+	//   - error: Error if database operation fails
+	ListAdjustments(ctx context.Context, filter models.AdjustmentFilter) ([]models.BookingAdjustment, error)
+
+	// ListAdjustmentsByBookingID retrieves the adjustments of a booking, oldest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Unique identifier of the booking
+	// Returns:
+	//   - []models.BookingAdjustment: The booking's adjustments
+	//   - error: Error if database operation fails
+	ListAdjustmentsByBookingID(ctx context.Context, bookingID string) ([]models.BookingAdjustment, error)
+
+	// ClaimAdjustmentReview records the admin reviewing a pending adjustment; only one can.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the adjustment
+	//   - reviewedBy: Reviewing admin
+	//   - note: Review note
+	// Returns:
+	//   - models.BookingAdjustment: The claimed adjustment
+	//   - error: Error if no longer pending or database operation fails
+	ClaimAdjustmentReview(ctx context.Context, id, reviewedBy uuid.UUID, note string) (models.BookingAdjustment, error)
+
+	// ReleaseAdjustmentReview returns a claimed adjustment to the approval queue.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the adjustment
+	// Returns:
+	//   - error: Error if database operation fails
+	ReleaseAdjustmentReview(ctx context.Context, id uuid.UUID) error
+
+	// CompleteAdjustment marks a pending adjustment applied or rejected.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the adjustment
+	//   - status: applied or rejected
+	//   - paymentID: Payment requested for a charge
+	//   - documentNumber: Number of the credit note
+	// Returns:
+	//   - models.BookingAdjustment: The completed adjustment
+	//   - error: Error if no longer pending or database operation fails
+	CompleteAdjustment(ctx context.Context, id uuid.UUID, status models.AdjustmentStatus, paymentID *uuid.UUID, documentNumber *string) (models.BookingAdjustment, error)
+}
+
+// StatementStoreInterface defines the contract for customers' payment statements.
+type StatementStoreInterface interface {
+	// GetStatementPayments retrieves a customer's payments made or refunded in a period.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: Customer whose bookings the payments are for
+	//   - from: Start of the period, inclusive
+	//   - to: End of the period, exclusive
+	// Returns:
+	//   - []models.Payment: Completed and refunded payments, oldest first
+	//   - error: Error if database operation fails
+	GetStatementPayments(ctx context.Context, userID string, from, to time.Time) ([]models.Payment, error)
+
+	// CreateStatement queues a statement for background rendering.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - statement: Customer, period and format
+	// Returns:
+	//   - models.PaymentStatement: The pending statement
+	//   - error: Error if database operation fails
+	CreateStatement(ctx context.Context, statement models.PaymentStatement) (models.PaymentStatement, error)
+
+	// GetStatement retrieves a queued statement by ID.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the statement
+	// Returns:
+	//   - models.PaymentStatement: The statement, without its file
+	//   - error: Error if not found or database operation fails
+	GetStatement(ctx context.Context, id string) (models.PaymentStatement, error)
+
+	// GetStatementContent retrieves the rendered file of a ready statement.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the statement
+	// Returns:
+	//   - []byte: The PDF or CSV file
+	//   - error: Error if not ready or database operation fails
+	GetStatementContent(ctx context.Context, id string) ([]byte, error)
+
+	// ClaimPendingStatements marks queued statements as rendering so one instance renders each.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - limit: Maximum number of statements to claim
+	//   - staleAfter: Age after which a rendering claim is taken over
+	// Returns:
+	//   - []models.PaymentStatement: Claimed statements, oldest first
+	//   - error: Error if database operation fails
+	ClaimPendingStatements(ctx context.Context, limit int, staleAfter time.Duration) ([]models.PaymentStatement, error)
+
+	// CompleteStatement stores a rendered statement or records why rendering failed.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the statement
+	//   - status: ready or failed
+	//   - content: The rendered file, nil when failed
+	//   - renderError: Why rendering failed, empty when ready
+	// Returns:
+	//   - error: Error if database operation fails
+	CompleteStatement(ctx context.Context, id uuid.UUID, status models.StatementStatus, content []byte, renderError string) error
+}
+
+// SettingStoreInterface defines the contract for the settings of the operator a request acts
+// for, stored as JSON under their keys.
+type SettingStoreInterface interface {
+	// GetSetting retrieves one of the operator's settings.
+	// Parameters:
+	//   - ctx: Request context carrying the operator
+	//   - key: Setting key, e.g. models.SettingSearchRanking
+	// Returns:
+	//   - models.Setting: The stored value
+	//   - error: Error if the setting was never stored or database operation fails
+	GetSetting(ctx context.Context, key string) (models.Setting, error)
+
+	// PutSetting creates or replaces one of the operator's settings.
+	// Parameters:
+	//   - ctx: Request context carrying the operator
+	//   - key: Setting key
+	//   - value: Validated JSON value
+	// Returns:
+	//   - models.Setting: The stored value
+	//   - error: Error if database operation fails
+	PutSetting(ctx context.Context, key string, value json.RawMessage) (models.Setting, error)
+}
+
+// FeaturedStoreInterface defines the contract for featured placements owners buy for their
+// cars, and the impressions and clicks they collect.
+type FeaturedStoreInterface interface {
+	// CreateFeatured records a pending placement after checking the car has no other placement
+	// in the period.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID, ownerID: Car to feature and its owner
+	//   - req: Validated period
+	//   - amount: Price in INR
+	//   - pendingTTL: How long unpaid placements hold their period
+	// Returns:
+	//   - models.FeaturedListing: The pending placement
+	//   - error: Error if the period overlaps another placement or database operation fails
+	CreateFeatured(ctx context.Context, carID, ownerID uuid.UUID, req models.FeaturedRequest, amount float64, pendingTTL time.Duration) (models.FeaturedListing, error)
+
+	// SetFeaturedOrder records the Razorpay order a placement is paid through.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Placement ID
+	//   - orderID: Razorpay order ID
+	// Returns:
+	//   - models.FeaturedListing: The updated placement
+	//   - error: Error if the placement does not exist or database operation fails
+	SetFeaturedOrder(ctx context.Context, id uuid.UUID, orderID string) (models.FeaturedListing, error)
+
+	// GetFeatured retrieves a placement by ID.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Placement ID
+	// Returns:
+	//   - models.FeaturedListing: The placement
+	//   - error: Error if the placement does not exist or database operation fails
+	GetFeatured(ctx context.Context, id string) (models.FeaturedListing, error)
+
+	// SettleFeatured moves a pending placement to paid or failed; settled placements are
+	// returned unchanged.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Placement ID
+	//   - status: FeaturedStatusPaid or FeaturedStatusFailed
+	//   - paymentID: Razorpay payment ID
+	// Returns:
+	//   - models.FeaturedListing: The placement after settling
+	//   - error: Error if the placement does not exist or database operation fails
+	SettleFeatured(ctx context.Context, id uuid.UUID, status models.FeaturedStatus, paymentID string) (models.FeaturedListing, error)
+
+	// ListFeaturedByOwner retrieves an owner's placements, latest period first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Owner whose placements to list
+	// Returns:
+	//   - []models.FeaturedListing: Placements with their impressions and clicks
+	//   - error: Error if database operation fails
+	ListFeaturedByOwner(ctx context.Context, ownerID string) ([]models.FeaturedListing, error)
+
+	// RecordImpressions counts an impression on the running placement of each car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carIDs: Featured cars shown in a listing
+	// Returns:
+	//   - error: Error if database operation fails
+	RecordImpressions(ctx context.Context, carIDs []uuid.UUID) error
+
+	// RecordClick counts a click on the running placement of a car, if it has one.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Car whose page was opened
+	// Returns:
+	//   - error: Error if database operation fails
+	RecordClick(ctx context.Context, carID string) error
+}
+
+// BlockStoreInterface defines the contract for the users each user has blocked.
+type BlockStoreInterface interface {
+	// CreateBlock blocks a user; blocking a user twice keeps the first block.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - blockerID: User who blocks
+	//   - blockedID: User being blocked
+	// Returns:
+	//   - models.UserBlock: The block
+	//   - error: Error if database operation fails
+	CreateBlock(ctx context.Context, blockerID, blockedID string) (models.UserBlock, error)
+
+	// DeleteBlock lifts a block.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - blockerID: User who blocked
+	//   - blockedID: User who was blocked
+	// Returns:
+	//   - error: Error if there is no such block or database operation fails
+	DeleteBlock(ctx context.Context, blockerID, blockedID string) error
+
+	// ListBlocks retrieves the users a user has blocked.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - blockerID: User whose block list to read
+	// Returns:
+	//   - []models.UserBlock: Blocks, most recent first
+	//   - error: Error if database operation fails
+	ListBlocks(ctx context.Context, blockerID string) ([]models.UserBlock, error)
+
+	// IsBlocked reports whether either of two users has blocked the other.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID, otherID: The pair of users
+	// Returns:
+	//   - bool: True when a block exists in either direction
+	//   - error: Error if database operation fails
+	IsBlocked(ctx context.Context, userID, otherID string) (bool, error)
+}
+
+// MaintenanceStoreInterface defines the contract for the maintenance jobs admins queue to
+// repair derived data.
+type MaintenanceStoreInterface interface {
+	// CreateJob queues a maintenance job unless the same task is already queued or running.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - job: Task, target, operator and requesting admin
+	// Returns:
+	//   - models.MaintenanceJob: The new job, or the active job for the same task and target
+	//   - bool: True when a job was created
+	//   - error: Error if database operation fails
+	CreateJob(ctx context.Context, job models.MaintenanceJob) (models.MaintenanceJob, bool, error)
+
+	// GetJob retrieves a maintenance job.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the job
+	// Returns:
+	//   - models.MaintenanceJob: The job
+	//   - error: Error if the job is not found or database operation fails
+	GetJob(ctx context.Context, id string) (models.MaintenanceJob, error)
+
+	// ListJobs retrieves the latest maintenance jobs.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - task: Task to list; every task when empty
+	//   - limit: Maximum number of jobs
+	// Returns:
+	//   - []models.MaintenanceJob: Jobs, newest first
+	//   - error: Error if database operation fails
+	ListJobs(ctx context.Context, task models.MaintenanceTask, limit int) ([]models.MaintenanceJob, error)
+
+	// ClaimQueuedJobs marks queued jobs, and running jobs abandoned by a stopped instance, as
+	// running for the caller.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - limit: Maximum number of jobs to claim
+	//   - staleAfter: How long a running job may go before it is claimed again
+	// Returns:
+	//   - []models.MaintenanceJob: Claimed jobs, oldest first
+	//   - error: Error if database operation fails
+	ClaimQueuedJobs(ctx context.Context, limit int, staleAfter time.Duration) ([]models.MaintenanceJob, error)
+
+	// CompleteJob records the outcome of a maintenance job.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Job that finished
+	//   - status: succeeded or failed
+	//   - result: Records checked and repaired
+	//   - jobError: Why the job failed, empty on success
+	// Returns:
+	//   - error: Error if database operation fails
+	CompleteJob(ctx context.Context, id uuid.UUID, status models.MaintenanceJobStatus, result models.MaintenanceResult, jobError string) error
+}
+
+// MigrationStoreInterface defines the contract for applying SQL migrations to an existing
+// database and recording which have run.
+type MigrationStoreInterface interface {
+	// GetApplied returns the applied migrations.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - map[string]time.Time: When each applied migration ran, by name
+	//   - error: Error if database operation fails
+	GetApplied(ctx context.Context) (map[string]time.Time, error)
+
+	// Apply runs a migration's SQL and records it in one transaction.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - name: Migration name, usually its file name
+	//   - script: SQL to run; empty only records the migration
+	// Returns:
+	//   - bool: False when another run already applied the migration
+	//   - error: Error if the SQL fails or database operation fails
+	Apply(ctx context.Context, name, script string) (bool, error)
+}
+
+// DebugCaptureStoreInterface defines the contract for debug capture persistence.
+type DebugCaptureStoreInterface interface {
+	// CreateDebugCapture stores a capture.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - capture: Capture with its ID, timestamps and expiry set
+	// Returns:
+	//   - error: Error if insertion fails
+	CreateDebugCapture(ctx context.Context, capture models.DebugCapture) error
+
+	// GetDebugCaptureByID retrieves an unexpired capture by its unique identifier.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the capture
+	// Returns:
+	//   - models.DebugCapture: The capture
+	//   - error: Error if not found, expired or database operation fails
+	GetDebugCaptureByID(ctx context.Context, id string) (models.DebugCapture, error)
+
+	// ListDebugCaptures retrieves one page of unexpired captures, newest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional user, path prefix and minimum status
+	//   - page: Page size and cursor; implementations fetch page.Limit+1 rows in page.SortOrder()
+	// Returns:
+	//   - []models.DebugCapture: Matching captures
+	//   - error: Error if database operation fails
+	ListDebugCaptures(ctx context.Context, filter models.DebugCaptureFilter, page models.PageRequest) ([]models.DebugCapture, error)
+
+	// DeleteExpiredDebugCaptures removes the captures past their expiry.
+	// Parameters:
+	//   - ctx: Context of the scheduled run
+	// Returns:
+	//   - int64: Number of captures deleted
+	//   - error: Error if database operation fails
+	DeleteExpiredDebugCaptures(ctx context.Context) (int64, error)
+}
+
+// LoadTestStoreInterface defines the contract for the disposable entities of load test runs.
+type LoadTestStoreInterface interface {
+	// CreateLoadTestRun stores a run once its users and cars are created.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - run: Run with its ID, entity IDs, timestamps and expiry set
+	// Returns:
+	//   - error: Error if insertion fails
+	CreateLoadTestRun(ctx context.Context, run models.LoadTestRun) error
+
+	// DeleteLoadTestRun deletes a run with its cars and users, and through them their bookings.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - id: Unique identifier of the run
+	// Returns:
+	//   - error: Error if not found or deletion fails
+	DeleteLoadTestRun(ctx context.Context, id string) error
+
+	// DeleteExpiredLoadTestRuns deletes the runs past their expiry with their cars and users.
+	// Parameters:
+	//   - ctx: Context of the scheduled run
+	// Returns:
+	//   - int64: Number of runs deleted
+	//   - error: Error if database operation fails
+	DeleteExpiredLoadTestRuns(ctx context.Context) (int64, error)
+}
+
+// CarImageUploadStoreInterface defines the contract for the images sent with cars, kept until
+// the upload job has stored them in Cloudinary.
+type CarImageUploadStoreInterface interface {
+	// CreateCarImageUploads keeps images sent with a car for the upload job.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Car the images belong to
+	//   - images: Base64 image data, in the order sent
+	// Returns:
+	//   - []models.CarImageUpload: The queued uploads
+	//   - error: Error if database operation fails
+	CreateCarImageUploads(ctx context.Context, carID uuid.UUID, images []string) ([]models.CarImageUpload, error)
+
+	// ListCarImageUploads retrieves the image uploads of a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - []models.CarImageUpload: Uploads, in the order the images were sent
+	//   - error: Error if database operation fails
+	ListCarImageUploads(ctx context.Context, carID string) ([]models.CarImageUpload, error)
+
+	// ClaimDueCarImageUploads marks due uploads, and uploads abandoned by a stopped instance,
+	// as uploading for the caller and counts the attempt.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - limit: Maximum number of uploads to claim
+	//   - staleAfter: How long an upload may stay uploading before it is claimed again
+	// Returns:
+	//   - []models.CarImageUpload: Claimed uploads with their image data
+	//   - error: Error if database operation fails
+	ClaimDueCarImageUploads(ctx context.Context, limit int, staleAfter time.Duration) ([]models.CarImageUpload, error)
+
+	// AttachCarImageUpload adds the URL of an uploaded image to its car and marks the upload uploaded.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the upload
+	//   - url: Cloudinary URL of the image
+	// Returns:
+	//   - uuid.UUID: ID of the car the image was added to
+	//   - error: Error if the upload is not in progress or database operation fails
+	AttachCarImageUpload(ctx context.Context, id uuid.UUID, url string) (uuid.UUID, error)
+
+	// RescheduleCarImageUpload queues an upload whose attempt failed again.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the upload
+	//   - nextAttemptAt: When to try again
+	//   - uploadError: Why the attempt failed
+	// Returns:
+	//   - error: Error if database operation fails
+	RescheduleCarImageUpload(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, uploadError string) error
+
+	// FailCarImageUpload gives up on an upload and drops its image data.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the upload
+	//   - uploadError: Why the last attempt failed
+	// Returns:
+	//   - error: Error if database operation fails
+	FailCarImageUpload(ctx context.Context, id uuid.UUID, uploadError string) error
+}
+
+// AccountingStoreInterface defines the contract for reading the ledger exported to accounting software.
+type AccountingStoreInterface interface {
+	// GetLedgerEntries retrieves the invoices, receipts and credit notes issued in a period and
+	// the payments refunded in it.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - from: Start of the period, inclusive
+	//   - to: End of the period, exclusive
+	// Returns:
+	//   - []models.AccountingEntry: Entries oldest first
+	//   - error: Error if database operation fails
+	GetLedgerEntries(ctx context.Context, from, to time.Time) ([]models.AccountingEntry, error)
+}
+
+// ValuationStoreInterface defines the contract for the data of the fleet valuation report.
+type ValuationStoreInterface interface {
+	// ListValuationCars retrieves an owner's cars that are not retired, with their purchase
+	// and latest check-in.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - ownerID: Owner of the cars
+	// Returns:
+	//   - []models.CarValuation: Cars by name, without estimates
+	//   - error: Error if database operation fails
+	ListValuationCars(ctx context.Context, ownerID string) ([]models.CarValuation, error)
+
+	// PutCarPurchase records or replaces what an owner paid for one of their cars.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Car bought
+	//   - ownerID: Owner the car must belong to
+	//   - purchasePrice: Price paid in INR
+	//   - purchasedOn: Purchase date, or nil when unknown
+	// Returns:
+	//   - models.CarPurchase: The stored purchase
+	//   - error: Error if the owner has no such car or database operation fails
+	PutCarPurchase(ctx context.Context, carID, ownerID string, purchasePrice float64, purchasedOn *time.Time) (models.CarPurchase, error)
+}
+
+// PasswordResetStoreInterface defines the contract for password reset link persistence.
+type PasswordResetStoreInterface interface {
+	// CreatePasswordResetToken stores a reset link; the user's earlier unused links stop working.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: User the link resets
+	//   - tokenHash: Hex SHA-256 of the token sent in the link
+	//   - expiresAt: When the link stops working
+	// Returns:
+	//   - models.PasswordResetToken: The stored link
+	//   - error: Error if database operation fails
+	CreatePasswordResetToken(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (models.PasswordResetToken, error)
+
+	// CountPasswordResetTokens counts the reset links issued to a user since a time.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: User the links reset
+	//   - since: Start of the counted period
+	// Returns:
+	//   - int: Number of links
+	//   - error: Error if database operation fails
+	CountPasswordResetTokens(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+
+	// ResetPassword uses up a reset link and replaces its user's password.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - tokenHash: Hex SHA-256 of the token sent in the link
+	//   - password: New plaintext password, hashed before it is stored
+	// Returns:
+	//   - uuid.UUID: ID of the user whose password was reset
+	//   - error: Error if the link is unknown, used or expired, or database operation fails
+	ResetPassword(ctx context.Context, tokenHash, password string) (uuid.UUID, error)
+}
+
+// RefreshTokenStoreInterface defines the contract for refresh token persistence.
+type RefreshTokenStoreInterface interface {
+	// CreateRefreshToken stores the first token of a new session; the user's expired tokens are removed.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - userID: User the token refreshes access for
+	//   - tokenHash: Hex SHA-256 of the token handed to the client
+	//   - expiresAt: When the token stops working
+	// Returns:
+	//   - models.RefreshToken: The stored token
+	//   - error: Error if database operation fails
+	CreateRefreshToken(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (models.RefreshToken, error)
+
+	// GetRefreshToken retrieves a token by its hash, whether or not it still works.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - tokenHash: Hex SHA-256 of the token
+	// Returns:
+	//   - models.RefreshToken: The token
+	//   - error: Error if the token is unknown or database operation fails
+	GetRefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error)
+
+	// RotateRefreshToken uses up a token and stores its successor in the same session.
+	// Parameters:
+	//   - ctx: Request context for transaction management
+	//   - id: Unique identifier of the token being exchanged
+	//   - newTokenHash: Hex SHA-256 of the successor
+	//   - expiresAt: When the successor stops working
+	// Returns:
+	//   - models.RefreshToken: The successor
+	//   - error: Error if the token was already used, revoked or expired, or database operation fails
+	RotateRefreshToken(ctx context.Context, id uuid.UUID, newTokenHash string, expiresAt time.Time) (models.RefreshToken, error)
+
+	// RevokeRefreshTokenFamily revokes every token of a session.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - familyID: Session the tokens were rotated in
+	// Returns:
+	//   - error: Error if database operation fails
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error
+}
+
+// SmartLockStoreInterface defines the contract for the smart locks fitted in cars, the digital
+// keys issued to them for bookings and the lock events their providers report.
+type SmartLockStoreInterface interface {
+	// UpsertSmartLock links a car to its lock, replacing any lock it was linked to.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	//   - req: Validated provider and lock reference
+	// Returns:
+	//   - models.SmartLock: The stored link
+	//   - error: ErrDuplicateLockRef if the lock is linked to another car, or database operation fails
+	UpsertSmartLock(ctx context.Context, carID string, req models.SmartLockRequest) (models.SmartLock, error)
+
+	// GetSmartLockByCarID retrieves the lock fitted in a car.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - models.SmartLock: The lock
+	//   - error: Error if the car has no lock or database operation fails
+	GetSmartLockByCarID(ctx context.Context, carID string) (models.SmartLock, error)
+
+	// GetSmartLockByRef retrieves the car a provider's lock is fitted in.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - provider: Name of the provider integration
+	//   - lockRef: The provider's identifier of the lock
+	// Returns:
+	//   - models.SmartLock: The lock
+	//   - error: Error if no car has the lock or database operation fails
+	GetSmartLockByRef(ctx context.Context, provider, lockRef string) (models.SmartLock, error)
+
+	// DeleteSmartLock unlinks a car from its lock.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Unique identifier of the car
+	// Returns:
+	//   - error: Error if the car has no lock or database operation fails
+	DeleteSmartLock(ctx context.Context, carID string) error
+
+	// CreateDigitalKey records a key requested from a provider for a booking.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - key: The key, issued or failed
+	// Returns:
+	//   - models.DigitalKey: The stored key
+	//   - error: Error if insertion fails
+	CreateDigitalKey(ctx context.Context, key models.DigitalKey) (models.DigitalKey, error)
+
+	// GetDigitalKeysByBookingID retrieves the keys requested for a booking, oldest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Unique identifier of the booking
+	// Returns:
+	//   - []models.DigitalKey: The keys
+	//   - error: Error if database operation fails
+	GetDigitalKeysByBookingID(ctx context.Context, bookingID string) ([]models.DigitalKey, error)
+
+	// RevokeDigitalKey marks an issued key revoked.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the key
+	// Returns:
+	//   - models.DigitalKey: The revoked key
+	//   - error: Error if the key is not issued or database operation fails
+	RevokeDigitalKey(ctx context.Context, id string) (models.DigitalKey, error)
+
+	// FindDigitalKeyForEvent finds the key a lock event of a car was made with.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - carID: Car whose lock reported the event
+	//   - providerKeyID: The provider's key ID if it reported one, otherwise empty
+	//   - occurredAt: When the event happened, matched against key validity without a key ID
+	// Returns:
+	//   - models.DigitalKey: The key
+	//   - error: Error if no key matches or database operation fails
+	FindDigitalKeyForEvent(ctx context.Context, carID uuid.UUID, providerKeyID string, occurredAt time.Time) (models.DigitalKey, error)
+
+	// CreateLockEvent stores a lock event once per provider event ID.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - event: The event with its car and, when known, booking and key
+	// Returns:
+	//   - models.LockEvent: The stored event; empty for a redelivered event
+	//   - bool: Whether the event was stored, false if it already was
+	//   - error: Error if insertion fails
+	CreateLockEvent(ctx context.Context, event models.LockEvent) (models.LockEvent, bool, error)
+
+	// GetLockEventsByBookingID retrieves the lock events logged against a booking, oldest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Unique identifier of the booking
+	// Returns:
+	//   - []models.LockEvent: The events
+	//   - error: Error if database operation fails
+	GetLockEventsByBookingID(ctx context.Context, bookingID string) ([]models.LockEvent, error)
+}
+
+// IncidentStoreInterface defines the contract for incidents reported against bookings and
+// their documents.
+type IncidentStoreInterface interface {
+	// CreateIncident stores a newly reported incident in the reported status.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - incident: Booking, car, customer, owner, reporter and the report
+	// Returns:
+	//   - models.Incident: The stored incident
+	//   - error: Error if insertion fails
+	CreateIncident(ctx context.Context, incident models.Incident) (models.Incident, error)
+
+	// GetIncident retrieves an incident with its documents.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the incident
+	// Returns:
+	//   - models.Incident: The incident and its documents, oldest first
+	//   - error: Error if not found or database operation fails
+	GetIncident(ctx context.Context, id string) (models.Incident, error)
+
+	// GetIncidentsByBookingID retrieves the incidents reported against a booking, oldest first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - bookingID: Unique identifier of the booking
+	// Returns:
+	//   - []models.Incident: Incidents without their documents
+	//   - error: Error if database operation fails
+	GetIncidentsByBookingID(ctx context.Context, bookingID string) ([]models.Incident, error)
+
+	// ListIncidents retrieves incidents, the longest outstanding first.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - filter: Optional status
+	// Returns:
+	//   - []models.Incident: Incidents without their documents
+	//   - error: Error if database operation fails
+	ListIncidents(ctx context.Context, filter models.IncidentFilter) ([]models.Incident, error)
+
+	// UpdateIncidentStatus moves an incident from one status to another.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - id: Unique identifier of the incident
+	//   - from: Status the incident must still be in
+	//   - to: New status; terminal statuses set resolved_at
+	//   - claimNumber: Optional insurance claim number
+	//   - notes: Optional resolution notes
+	// Returns:
+	//   - models.Incident: The updated incident without its documents
+	//   - error: Error if its status changed meanwhile or database operation fails
+	UpdateIncidentStatus(ctx context.Context, id uuid.UUID, from, to models.IncidentStatus, claimNumber, notes *string) (models.Incident, error)
+
+	// AddDocument attaches a document to an incident.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - incidentID: Unique identifier of the incident
+	//   - addedBy: User attaching the document
+	//   - req: Kind, description and document URL
+	// Returns:
+	//   - models.IncidentDocument: The stored document
+	//   - error: Error if database operation fails
+	AddDocument(ctx context.Context, incidentID, addedBy uuid.UUID, req models.IncidentDocumentRequest) (models.IncidentDocument, error)
+}
+
+// ChecklistStoreInterface defines the contract for versioned handover checklist persistence.
+type ChecklistStoreInterface interface {
+	// AddTemplateVersion stores the next version of the checklist.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - req: Validated checklist items
+	//   - createdBy: Admin saving the version
+	// Returns:
+	//   - models.ChecklistTemplate: The stored version
+	//   - error: Error if a concurrent save won or database operation fails
+	AddTemplateVersion(ctx context.Context, req models.ChecklistTemplateRequest, createdBy string) (models.ChecklistTemplate, error)
+
+	// GetCurrentTemplate retrieves the highest version of the checklist.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	// Returns:
+	//   - models.ChecklistTemplate: The current version
+	//   - error: Error if no checklist was saved yet or database operation fails
+	GetCurrentTemplate(ctx context.Context) (models.ChecklistTemplate, error)
+
+	// GetTemplateVersion retrieves one version of the checklist.
+	// Parameters:
+	//   - ctx: Request context for cancellation and timeout
+	//   - version: Checklist version
+	// Returns:
+	//   - models.ChecklistTemplate: The version
+	//   - error: Error if not found or database operation fails
+	GetTemplateVersion(ctx context.Context, version int) (models.ChecklistTemplate, error)
+
+	// ListTemplateVersions retrieves every version of the checklist.
 	// Parameters:
 	//   - ctx: Request context for cancellation and timeout
 	// Returns:
-	//   - []models.Payment: Slice of all payment records
+	//   - []models.ChecklistTemplate: Versions, newest first
 	//   - error: Error if database operation fails
-	GetAllPayments(ctx context.Context) ([]models.Payment, error)
+	ListTemplateVersions(ctx context.Context) ([]models.ChecklistTemplate, error)
 }
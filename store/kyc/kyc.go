@@ -0,0 +1,68 @@
+package kyc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"go.opentelemetry.io/otel"
+)
+
+// KYCStore persists the identity data admins verified for renters
+type KYCStore struct {
+	db *sql.DB
+}
+
+// New creates a new KYC store
+func New(db *sql.DB) KYCStore {
+	return KYCStore{db: db}
+}
+
+// GetVerifiedKYC retrieves the verified identity data of a user
+func (s KYCStore) GetVerifiedKYC(ctx context.Context, userID string) (models.VerifiedKYC, error) {
+	tracer := otel.Tracer("KYCStore")
+	ctx, span := tracer.Start(ctx, "GetVerifiedKYC-Store")
+	defer span.End()
+
+	var kyc models.VerifiedKYC
+	err := s.db.QueryRowContext(ctx, `SELECT user_id, date_of_birth, license_issued_on, verified_by, verified_at
+	         FROM user_kyc WHERE user_id = $1`, userID).
+		Scan(&kyc.UserID, &kyc.DateOfBirth, &kyc.LicenseIssuedOn, &kyc.VerifiedBy, &kyc.VerifiedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.VerifiedKYC{}, errors.New("no verified KYC found for the given user")
+		}
+		return models.VerifiedKYC{}, err
+	}
+
+	return kyc, nil
+}
+
+// UpsertVerifiedKYC records or replaces the verified identity data of a user
+func (s KYCStore) UpsertVerifiedKYC(ctx context.Context, userID, verifiedBy string, dateOfBirth, licenseIssuedOn time.Time) (models.VerifiedKYC, error) {
+	tracer := otel.Tracer("KYCStore")
+	ctx, span := tracer.Start(ctx, "UpsertVerifiedKYC-Store")
+	defer span.End()
+
+	query := `INSERT INTO user_kyc (user_id, date_of_birth, license_issued_on, verified_by, verified_at)
+	         SELECT id, $2, $3, $4, $5 FROM users WHERE id = $1
+	         ON CONFLICT (user_id) DO UPDATE SET date_of_birth = EXCLUDED.date_of_birth,
+	           license_issued_on = EXCLUDED.license_issued_on, verified_by = EXCLUDED.verified_by,
+	           verified_at = EXCLUDED.verified_at
+	         RETURNING user_id, date_of_birth, license_issued_on, verified_by, verified_at`
+
+	var kyc models.VerifiedKYC
+	err := s.db.QueryRowContext(ctx, query, userID, dateOfBirth, licenseIssuedOn, verifiedBy, time.Now()).
+		Scan(&kyc.UserID, &kyc.DateOfBirth, &kyc.LicenseIssuedOn, &kyc.VerifiedBy, &kyc.VerifiedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.VerifiedKYC{}, errors.New("no user found with the given ID")
+		}
+		return models.VerifiedKYC{}, fmt.Errorf("failed to save verified KYC: %v", err)
+	}
+
+	return kyc, nil
+}
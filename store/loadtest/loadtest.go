@@ -0,0 +1,101 @@
+package loadtest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// LoadTestStore records the entities of load test runs and deletes them with their run
+type LoadTestStore struct {
+	db *sql.DB
+}
+
+// New creates a new load test store
+func New(db *sql.DB) LoadTestStore {
+	return LoadTestStore{db: db}
+}
+
+// CreateLoadTestRun stores a run
+func (s LoadTestStore) CreateLoadTestRun(ctx context.Context, run models.LoadTestRun) error {
+	tracer := otel.Tracer("LoadTestStore")
+	ctx, span := tracer.Start(ctx, "CreateLoadTestRun-Store")
+	defer span.End()
+
+	query := `INSERT INTO loadtest_run (id, created_by, user_ids, car_ids, created_at, expires_at)
+	         VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := s.db.ExecContext(ctx, query, run.ID, run.CreatedBy, pq.Array(run.UserIDs), pq.Array(run.CarIDs),
+		run.CreatedAt, run.ExpiresAt)
+	return err
+}
+
+// DeleteLoadTestRun deletes a run with its cars and users in one transaction. Bookings of the
+// cars and renters go with them through the cascades of booking.
+func (s LoadTestStore) DeleteLoadTestRun(ctx context.Context, id string) error {
+	tracer := otel.Tracer("LoadTestStore")
+	ctx, span := tracer.Start(ctx, "DeleteLoadTestRun-Store")
+	defer span.End()
+
+	deleted, err := s.deleteRuns(ctx, `id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errors.New("no load test run found with the given ID")
+	}
+	return nil
+}
+
+// DeleteExpiredLoadTestRuns deletes the runs past their expiry with their cars and users, and
+// returns how many runs
+func (s LoadTestStore) DeleteExpiredLoadTestRuns(ctx context.Context) (int64, error) {
+	tracer := otel.Tracer("LoadTestStore")
+	ctx, span := tracer.Start(ctx, "DeleteExpiredLoadTestRuns-Store")
+	defer span.End()
+
+	return s.deleteRuns(ctx, `expires_at <= NOW()`)
+}
+
+// deleteRuns deletes the runs matching condition, and returns how many runs. Bookings of their
+// cars or by their users go first, as cars and renters with bookings cannot be deleted, then
+// their cars so owners no longer have listings, then their users.
+func (s LoadTestStore) deleteRuns(ctx context.Context, condition string, args ...interface{}) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM booking WHERE car_id IN (
+		SELECT unnest(car_ids) FROM loadtest_run WHERE `+condition+`) OR customer_id IN (
+		SELECT unnest(user_ids) FROM loadtest_run WHERE `+condition+`)`, args...)
+	if err != nil {
+		return 0, store.ConstraintViolation(err, store.ErrBookingHasPayments)
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM car WHERE id IN (
+		SELECT unnest(car_ids) FROM loadtest_run WHERE `+condition+`)`, args...)
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM users WHERE id IN (
+		SELECT unnest(user_ids) FROM loadtest_run WHERE `+condition+`)`, args...)
+	if err != nil {
+		return 0, err
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM loadtest_run WHERE `+condition, args...)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, tx.Commit()
+}
@@ -0,0 +1,393 @@
+package location
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// locationColumns lists the columns read by every location query
+const locationColumns = `l.id, l.code, l.name, l.type, l.address, l.city, l.latitude, l.longitude,
+	l.pickup_fee, l.dropoff_fee, l.is_active, l.created_at, l.updated_at`
+
+// LocationStore persists pickup/drop-off locations and the cars available at them
+type LocationStore struct {
+	db *sql.DB
+}
+
+// New creates a new location store
+func New(db *sql.DB) LocationStore {
+	return LocationStore{db: db}
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// CreateLocation stores a new location
+func (s LocationStore) CreateLocation(ctx context.Context, req models.LocationRequest) (models.Location, error) {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "CreateLocation-Store")
+	defer span.End()
+
+	now := time.Now()
+	query := `INSERT INTO location AS l (id, code, name, type, address, city, latitude, longitude,
+	         pickup_fee, dropoff_fee, is_active, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+	         RETURNING ` + locationColumns
+
+	location, err := scanLocation(s.db.QueryRowContext(ctx, query, uuid.New(), strings.ToUpper(strings.TrimSpace(req.Code)),
+		strings.TrimSpace(req.Name), req.Type, req.Address, req.City, req.Latitude, req.Longitude,
+		req.PickupFee, req.DropoffFee, req.IsActive, now))
+	if err != nil {
+		if strings.Contains(err.Error(), "location_code_key") {
+			return models.Location{}, errors.New("a location with this code already exists")
+		}
+		return models.Location{}, err
+	}
+
+	return location, nil
+}
+
+// GetLocationByID retrieves a location by its ID
+func (s LocationStore) GetLocationByID(ctx context.Context, id string) (models.Location, error) {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "GetLocationByID-Store")
+	defer span.End()
+
+	query := `SELECT ` + locationColumns + ` FROM location l WHERE l.id = $1`
+
+	location, err := scanLocation(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Location{}, errors.New("no location found with the given ID")
+		}
+		return models.Location{}, err
+	}
+
+	return location, nil
+}
+
+// ListLocations retrieves locations ordered by city and name, optionally limited to one
+// city (case-insensitive) and to active locations
+func (s LocationStore) ListLocations(ctx context.Context, city string, activeOnly bool) ([]models.Location, error) {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "ListLocations-Store")
+	defer span.End()
+
+	var conditions []string
+	var args []interface{}
+
+	if city != "" {
+		args = append(args, city)
+		conditions = append(conditions, fmt.Sprintf("LOWER(l.city) = LOWER($%d)", len(args)))
+	}
+	if activeOnly {
+		conditions = append(conditions, "l.is_active")
+	}
+
+	query := `SELECT ` + locationColumns + ` FROM location l`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY l.city, l.name"
+
+	return s.queryLocations(ctx, query, args...)
+}
+
+// UpdateLocation replaces the details of a location. Fee changes only affect new bookings.
+func (s LocationStore) UpdateLocation(ctx context.Context, id string, req models.LocationRequest) (models.Location, error) {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "UpdateLocation-Store")
+	defer span.End()
+
+	query := `UPDATE location AS l SET code = $1, name = $2, type = $3, address = $4, city = $5, latitude = $6,
+	         longitude = $7, pickup_fee = $8, dropoff_fee = $9, is_active = $10, updated_at = $11
+	         WHERE l.id = $12
+	         RETURNING ` + locationColumns
+
+	location, err := scanLocation(s.db.QueryRowContext(ctx, query, strings.ToUpper(strings.TrimSpace(req.Code)),
+		strings.TrimSpace(req.Name), req.Type, req.Address, req.City, req.Latitude, req.Longitude,
+		req.PickupFee, req.DropoffFee, req.IsActive, time.Now(), id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Location{}, errors.New("no location found with the given ID")
+		}
+		if strings.Contains(err.Error(), "location_code_key") {
+			return models.Location{}, errors.New("a location with this code already exists")
+		}
+		return models.Location{}, err
+	}
+
+	return location, nil
+}
+
+// DeleteLocation removes a location. Cars are detached from it and past bookings keep
+// their charged fees but lose the reference.
+func (s LocationStore) DeleteLocation(ctx context.Context, id string) error {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "DeleteLocation-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM location WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no location found with the given ID")
+	}
+
+	return nil
+}
+
+// AttachCar makes a car available at a location. Attaching twice is a no-op.
+func (s LocationStore) AttachCar(ctx context.Context, carID, locationID string) error {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "AttachCar-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO car_location (car_id, location_id, created_at)
+	         VALUES ($1, $2, $3) ON CONFLICT (car_id, location_id) DO NOTHING`, carID, locationID, time.Now())
+	if err != nil && strings.Contains(err.Error(), "fk_car_location_location_id") {
+		return errors.New("no location found with the given ID")
+	}
+	return err
+}
+
+// DetachCar removes a car from a location
+func (s LocationStore) DetachCar(ctx context.Context, carID, locationID string) error {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "DetachCar-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM car_location WHERE car_id = $1 AND location_id = $2`, carID, locationID)
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("car is not attached to this location")
+	}
+
+	return nil
+}
+
+// GetLocationsByCarID retrieves the locations a car is available at
+func (s LocationStore) GetLocationsByCarID(ctx context.Context, carID string) ([]models.Location, error) {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "GetLocationsByCarID-Store")
+	defer span.End()
+
+	query := `SELECT ` + locationColumns + ` FROM location l
+	         JOIN car_location cl ON cl.location_id = l.id
+	         WHERE cl.car_id = $1 ORDER BY l.city, l.name`
+
+	return s.queryLocations(ctx, query, carID)
+}
+
+// GetCarLocation retrieves a location only if the car is attached to it
+func (s LocationStore) GetCarLocation(ctx context.Context, carID, locationID string) (models.Location, error) {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "GetCarLocation-Store")
+	defer span.End()
+
+	query := `SELECT ` + locationColumns + ` FROM location l
+	         JOIN car_location cl ON cl.location_id = l.id
+	         WHERE cl.car_id = $1 AND l.id = $2`
+
+	location, err := scanLocation(s.db.QueryRowContext(ctx, query, carID, locationID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Location{}, errors.New("location is not available for this car")
+		}
+		return models.Location{}, err
+	}
+
+	return location, nil
+}
+
+// GetDeliveryOption retrieves a car's door delivery settings
+func (s LocationStore) GetDeliveryOption(ctx context.Context, carID string) (models.DeliveryOption, error) {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "GetDeliveryOption-Store")
+	defer span.End()
+
+	query := `SELECT car_id, enabled, origin_latitude, origin_longitude, max_radius_km, base_fee, fee_per_km, updated_at
+	         FROM car_delivery_option WHERE car_id = $1`
+
+	option, err := scanDeliveryOption(s.db.QueryRowContext(ctx, query, carID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DeliveryOption{}, errors.New("delivery is not offered for this car")
+		}
+		return models.DeliveryOption{}, err
+	}
+
+	return option, nil
+}
+
+// UpsertDeliveryOption creates or replaces a car's door delivery settings
+func (s LocationStore) UpsertDeliveryOption(ctx context.Context, carID string, req models.DeliveryOptionRequest) (models.DeliveryOption, error) {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "UpsertDeliveryOption-Store")
+	defer span.End()
+
+	query := `INSERT INTO car_delivery_option (car_id, enabled, origin_latitude, origin_longitude, max_radius_km, base_fee, fee_per_km, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+	         ON CONFLICT (car_id) DO UPDATE SET enabled = EXCLUDED.enabled,
+	           origin_latitude = EXCLUDED.origin_latitude, origin_longitude = EXCLUDED.origin_longitude,
+	           max_radius_km = EXCLUDED.max_radius_km, base_fee = EXCLUDED.base_fee, fee_per_km = EXCLUDED.fee_per_km,
+	           updated_at = EXCLUDED.updated_at
+	         RETURNING car_id, enabled, origin_latitude, origin_longitude, max_radius_km, base_fee, fee_per_km, updated_at`
+
+	option, err := scanDeliveryOption(s.db.QueryRowContext(ctx, query, carID, req.Enabled, req.OriginLatitude,
+		req.OriginLongitude, req.MaxRadiusKm, req.BaseFee, req.FeePerKm, time.Now()))
+	if err != nil {
+		return models.DeliveryOption{}, fmt.Errorf("failed to save delivery option: %v", err)
+	}
+
+	return option, nil
+}
+
+// relocationFeeColumns lists the columns read by every relocation fee query
+const relocationFeeColumns = `id, from_city, to_city, fee, created_at, updated_at`
+
+// ListRelocationFees retrieves the one-way fee matrix ordered by city pair
+func (s LocationStore) ListRelocationFees(ctx context.Context) ([]models.RelocationFee, error) {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "ListRelocationFees-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+relocationFeeColumns+` FROM relocation_fee ORDER BY from_city, to_city`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fees []models.RelocationFee
+	for rows.Next() {
+		fee, err := scanRelocationFee(rows)
+		if err != nil {
+			return nil, err
+		}
+		fees = append(fees, fee)
+	}
+
+	return fees, rows.Err()
+}
+
+// GetRelocationFee retrieves the fee of a one-way rental between two cities (case-insensitive)
+func (s LocationStore) GetRelocationFee(ctx context.Context, fromCity, toCity string) (models.RelocationFee, error) {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "GetRelocationFee-Store")
+	defer span.End()
+
+	query := `SELECT ` + relocationFeeColumns + ` FROM relocation_fee
+	         WHERE LOWER(from_city) = LOWER($1) AND LOWER(to_city) = LOWER($2)`
+
+	fee, err := scanRelocationFee(s.db.QueryRowContext(ctx, query, strings.TrimSpace(fromCity), strings.TrimSpace(toCity)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.RelocationFee{}, fmt.Errorf("one-way rentals from %s to %s are not offered", fromCity, toCity)
+		}
+		return models.RelocationFee{}, err
+	}
+
+	return fee, nil
+}
+
+// UpsertRelocationFee creates or replaces the fee of a city pair
+func (s LocationStore) UpsertRelocationFee(ctx context.Context, req models.RelocationFeeRequest) (models.RelocationFee, error) {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "UpsertRelocationFee-Store")
+	defer span.End()
+
+	query := `INSERT INTO relocation_fee (id, from_city, to_city, fee, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $5)
+	         ON CONFLICT (LOWER(from_city), LOWER(to_city)) DO UPDATE SET fee = EXCLUDED.fee, updated_at = EXCLUDED.updated_at
+	         RETURNING ` + relocationFeeColumns
+
+	fee, err := scanRelocationFee(s.db.QueryRowContext(ctx, query, uuid.New(), strings.TrimSpace(req.FromCity),
+		strings.TrimSpace(req.ToCity), req.Fee, time.Now()))
+	if err != nil {
+		return models.RelocationFee{}, fmt.Errorf("failed to save relocation fee: %v", err)
+	}
+
+	return fee, nil
+}
+
+// DeleteRelocationFee removes a city pair from the one-way fee matrix
+func (s LocationStore) DeleteRelocationFee(ctx context.Context, id string) error {
+	tracer := otel.Tracer("LocationStore")
+	ctx, span := tracer.Start(ctx, "DeleteRelocationFee-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM relocation_fee WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no relocation fee found with the given ID")
+	}
+
+	return nil
+}
+
+// queryLocations runs a query selecting locationColumns and collects the rows
+func (s LocationStore) queryLocations(ctx context.Context, query string, args ...interface{}) ([]models.Location, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var locations []models.Location
+	for rows.Next() {
+		location, err := scanLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+
+	return locations, rows.Err()
+}
+
+// scanLocation reads one location row
+func scanLocation(row rowScanner) (models.Location, error) {
+	var l models.Location
+	err := row.Scan(&l.ID, &l.Code, &l.Name, &l.Type, &l.Address, &l.City, &l.Latitude, &l.Longitude,
+		&l.PickupFee, &l.DropoffFee, &l.IsActive, &l.CreatedAt, &l.UpdatedAt)
+	return l, err
+}
+
+// scanDeliveryOption reads one car_delivery_option row
+func scanDeliveryOption(row rowScanner) (models.DeliveryOption, error) {
+	var o models.DeliveryOption
+	err := row.Scan(&o.CarID, &o.Enabled, &o.OriginLatitude, &o.OriginLongitude, &o.MaxRadiusKm,
+		&o.BaseFee, &o.FeePerKm, &o.UpdatedAt)
+	return o, err
+}
+
+// scanRelocationFee reads one relocation_fee row
+func scanRelocationFee(row rowScanner) (models.RelocationFee, error) {
+	var f models.RelocationFee
+	err := row.Scan(&f.ID, &f.FromCity, &f.ToCity, &f.Fee, &f.CreatedAt, &f.UpdatedAt)
+	return f, err
+}
@@ -0,0 +1,167 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// maintenanceJobColumns lists the columns read by every maintenance job query, in
+// scanMaintenanceJob order
+const maintenanceJobColumns = `id, task, target_id, operator_id, requested_by, status, examined, changed, error,
+	created_at, started_at, completed_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// MaintenanceStore persists the maintenance jobs admins queue and the runner works through
+type MaintenanceStore struct {
+	db *sql.DB
+}
+
+// New creates a new maintenance store
+func New(db *sql.DB) MaintenanceStore {
+	return MaintenanceStore{db: db}
+}
+
+// CreateJob queues a maintenance job. While a job for the same task, target and operator is
+// queued or running, that job is returned instead and created is false.
+func (s MaintenanceStore) CreateJob(ctx context.Context, job models.MaintenanceJob) (models.MaintenanceJob, bool, error) {
+	tracer := otel.Tracer("MaintenanceStore")
+	ctx, span := tracer.Start(ctx, "CreateJob-Store")
+	defer span.End()
+
+	// The partial unique index on active jobs turns a duplicate request into no insert
+	row := s.db.QueryRowContext(ctx, `INSERT INTO maintenance_job (id, task, target_id, operator_id, requested_by, status, created_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7)
+	         ON CONFLICT DO NOTHING
+	         RETURNING `+maintenanceJobColumns,
+		uuid.New(), job.Task, job.TargetID, job.OperatorID, job.RequestedBy, models.MaintenanceJobQueued, time.Now())
+	created, err := scanMaintenanceJob(row)
+	if err == nil {
+		return created, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return models.MaintenanceJob{}, false, err
+	}
+
+	row = s.db.QueryRowContext(ctx, `SELECT `+maintenanceJobColumns+` FROM maintenance_job
+	         WHERE task = $1 AND target_id IS NOT DISTINCT FROM $2 AND operator_id IS NOT DISTINCT FROM $3
+	           AND status IN ('queued', 'running')`, job.Task, job.TargetID, job.OperatorID)
+	active, err := scanMaintenanceJob(row)
+	if err != nil {
+		return models.MaintenanceJob{}, false, err
+	}
+	return active, false, nil
+}
+
+// GetJob retrieves a maintenance job, limited to the caller's operator when scoped
+func (s MaintenanceStore) GetJob(ctx context.Context, id string) (models.MaintenanceJob, error) {
+	tracer := otel.Tracer("MaintenanceStore")
+	ctx, span := tracer.Start(ctx, "GetJob-Store")
+	defer span.End()
+
+	row := s.db.QueryRowContext(ctx, `SELECT `+maintenanceJobColumns+` FROM maintenance_job
+	         WHERE id = $1 AND ($2::uuid IS NULL OR operator_id = $2)`, id, tenant.Scope(ctx))
+	job, err := scanMaintenanceJob(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.MaintenanceJob{}, errors.New("no maintenance job found with the given ID")
+		}
+		return models.MaintenanceJob{}, err
+	}
+	return job, nil
+}
+
+// ListJobs retrieves the latest maintenance jobs, newest first, optionally of one task,
+// limited to the caller's operator when scoped
+func (s MaintenanceStore) ListJobs(ctx context.Context, task models.MaintenanceTask, limit int) ([]models.MaintenanceJob, error) {
+	tracer := otel.Tracer("MaintenanceStore")
+	ctx, span := tracer.Start(ctx, "ListJobs-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+maintenanceJobColumns+` FROM maintenance_job
+	         WHERE ($1 = '' OR task = $1) AND ($2::uuid IS NULL OR operator_id = $2)
+	         ORDER BY created_at DESC
+	         LIMIT $3`, string(task), tenant.Scope(ctx), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []models.MaintenanceJob{}
+	for rows.Next() {
+		job, err := scanMaintenanceJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// ClaimQueuedJobs marks up to limit queued jobs as running and returns them, oldest first.
+// Jobs left running for longer than staleAfter, by an instance that stopped, are claimed
+// again. Concurrent callers never claim the same job.
+func (s MaintenanceStore) ClaimQueuedJobs(ctx context.Context, limit int, staleAfter time.Duration) ([]models.MaintenanceJob, error) {
+	tracer := otel.Tracer("MaintenanceStore")
+	ctx, span := tracer.Start(ctx, "ClaimQueuedJobs-Store")
+	defer span.End()
+
+	query := `UPDATE maintenance_job SET status = $1, started_at = $2
+	         WHERE id IN (
+	             SELECT id FROM maintenance_job
+	             WHERE status = $3 OR (status = $1 AND started_at < $4)
+	             ORDER BY created_at
+	             LIMIT $5
+	             FOR UPDATE SKIP LOCKED)
+	         RETURNING ` + maintenanceJobColumns
+
+	now := time.Now()
+	rows, err := s.db.QueryContext(ctx, query, models.MaintenanceJobRunning, now, models.MaintenanceJobQueued,
+		now.Add(-staleAfter), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []models.MaintenanceJob{}
+	for rows.Next() {
+		job, err := scanMaintenanceJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// CompleteJob records the outcome of a maintenance job
+func (s MaintenanceStore) CompleteJob(ctx context.Context, id uuid.UUID, status models.MaintenanceJobStatus, result models.MaintenanceResult, jobError string) error {
+	tracer := otel.Tracer("MaintenanceStore")
+	ctx, span := tracer.Start(ctx, "CompleteJob-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `UPDATE maintenance_job
+	         SET status = $2, examined = $3, changed = $4, error = $5, completed_at = $6
+	         WHERE id = $1`, id, status, result.Examined, result.Changed, jobError, time.Now())
+	return err
+}
+
+// scanMaintenanceJob reads one maintenance_job row
+func scanMaintenanceJob(row rowScanner) (models.MaintenanceJob, error) {
+	var job models.MaintenanceJob
+	err := row.Scan(&job.ID, &job.Task, &job.TargetID, &job.OperatorID, &job.RequestedBy, &job.Status, &job.Examined,
+		&job.Changed, &job.Error, &job.CreatedAt, &job.StartedAt, &job.CompletedAt)
+	return job, err
+}
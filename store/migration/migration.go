@@ -0,0 +1,101 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// createTableQuery creates the table of applied migrations on first use, so databases built
+// from schema.sql before migrations existed need no manual step
+const createTableQuery = `CREATE TABLE IF NOT EXISTS schema_migration (
+    name VARCHAR(255) PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+// lockQuery serializes migration runs across concurrent invocations
+const lockQuery = `SELECT pg_advisory_xact_lock(hashtext('schema_migration'))`
+
+// MigrationStore applies SQL migrations and records which have run
+type MigrationStore struct {
+	db *sql.DB
+}
+
+// New creates a new migration store
+func New(db *sql.DB) MigrationStore {
+	return MigrationStore{db: db}
+}
+
+// GetApplied returns when each applied migration ran, by name
+func (s MigrationStore) GetApplied(ctx context.Context) (map[string]time.Time, error) {
+	tracer := otel.Tracer("MigrationStore")
+	ctx, span := tracer.Start(ctx, "GetApplied-Store")
+	defer span.End()
+
+	if _, err := s.db.ExecContext(ctx, createTableQuery); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT name, applied_at FROM schema_migration`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[string]time.Time{}
+	for rows.Next() {
+		var name string
+		var appliedAt time.Time
+		if err := rows.Scan(&name, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[name] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// Apply runs a migration and records it in one transaction, so a failed migration leaves no
+// trace. A migration another run applied first is skipped and applied is false. With an
+// empty script the migration is only recorded, for baselining databases built from schema.sql.
+func (s MigrationStore) Apply(ctx context.Context, name, script string) (applied bool, err error) {
+	tracer := otel.Tracer("MigrationStore")
+	ctx, span := tracer.Start(ctx, "Apply-Store")
+	defer span.End()
+
+	if _, err := s.db.ExecContext(ctx, createTableQuery); err != nil {
+		return false, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil || !applied {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if _, err = tx.ExecContext(ctx, lockQuery); err != nil {
+		return false, err
+	}
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM schema_migration WHERE name = $1)`, name).Scan(&exists)
+	if err != nil || exists {
+		return false, err
+	}
+
+	if script != "" {
+		if _, err = tx.ExecContext(ctx, script); err != nil {
+			return false, err
+		}
+	}
+	if _, err = tx.ExecContext(ctx, `INSERT INTO schema_migration (name) VALUES ($1)`, name); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,5137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	json "encoding/json"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/PrateekKumar15/CarZone/models"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCarStoreInterface is a mock of CarStoreInterface interface.
+type MockCarStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCarStoreInterfaceMockRecorder is the mock recorder for MockCarStoreInterface.
+type MockCarStoreInterfaceMockRecorder struct {
+	mock *MockCarStoreInterface
+}
+
+// NewMockCarStoreInterface creates a new mock instance.
+func NewMockCarStoreInterface(ctrl *gomock.Controller) *MockCarStoreInterface {
+	mock := &MockCarStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockCarStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarStoreInterface) EXPECT() *MockCarStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountCars mocks base method.
+func (m *MockCarStoreInterface) CountCars(ctx context.Context, filter models.CarFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCars", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCars indicates an expected call of CountCars.
+func (mr *MockCarStoreInterfaceMockRecorder) CountCars(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCars", reflect.TypeOf((*MockCarStoreInterface)(nil).CountCars), ctx, filter)
+}
+
+// CreateCar mocks base method.
+func (m *MockCarStoreInterface) CreateCar(ctx context.Context, carReq models.CarRequest) (models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCar", ctx, carReq)
+	ret0, _ := ret[0].(models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCar indicates an expected call of CreateCar.
+func (mr *MockCarStoreInterfaceMockRecorder) CreateCar(ctx, carReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCar", reflect.TypeOf((*MockCarStoreInterface)(nil).CreateCar), ctx, carReq)
+}
+
+// DeleteCar mocks base method.
+func (m *MockCarStoreInterface) DeleteCar(ctx context.Context, id string) (models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCar", ctx, id)
+	ret0, _ := ret[0].(models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCar indicates an expected call of DeleteCar.
+func (mr *MockCarStoreInterfaceMockRecorder) DeleteCar(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCar", reflect.TypeOf((*MockCarStoreInterface)(nil).DeleteCar), ctx, id)
+}
+
+// GetBookableCarIDs mocks base method.
+func (m *MockCarStoreInterface) GetBookableCarIDs(ctx context.Context, carIDs []uuid.UUID, start, end time.Time) (map[uuid.UUID]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookableCarIDs", ctx, carIDs, start, end)
+	ret0, _ := ret[0].(map[uuid.UUID]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookableCarIDs indicates an expected call of GetBookableCarIDs.
+func (mr *MockCarStoreInterfaceMockRecorder) GetBookableCarIDs(ctx, carIDs, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookableCarIDs", reflect.TypeOf((*MockCarStoreInterface)(nil).GetBookableCarIDs), ctx, carIDs, start, end)
+}
+
+// GetCarByBrand mocks base method.
+func (m *MockCarStoreInterface) GetCarByBrand(ctx context.Context, brand string, fuzzy bool) ([]models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarByBrand", ctx, brand, fuzzy)
+	ret0, _ := ret[0].([]models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarByBrand indicates an expected call of GetCarByBrand.
+func (mr *MockCarStoreInterfaceMockRecorder) GetCarByBrand(ctx, brand, fuzzy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarByBrand", reflect.TypeOf((*MockCarStoreInterface)(nil).GetCarByBrand), ctx, brand, fuzzy)
+}
+
+// GetCarByID mocks base method.
+func (m *MockCarStoreInterface) GetCarByID(ctx context.Context, id string) (models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarByID", ctx, id)
+	ret0, _ := ret[0].(models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarByID indicates an expected call of GetCarByID.
+func (mr *MockCarStoreInterfaceMockRecorder) GetCarByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarByID", reflect.TypeOf((*MockCarStoreInterface)(nil).GetCarByID), ctx, id)
+}
+
+// GetCarBySlug mocks base method.
+func (m *MockCarStoreInterface) GetCarBySlug(ctx context.Context, slug string) (models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarBySlug", ctx, slug)
+	ret0, _ := ret[0].(models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarBySlug indicates an expected call of GetCarBySlug.
+func (mr *MockCarStoreInterfaceMockRecorder) GetCarBySlug(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarBySlug", reflect.TypeOf((*MockCarStoreInterface)(nil).GetCarBySlug), ctx, slug)
+}
+
+// GetCarWithOwnerByID mocks base method.
+func (m *MockCarStoreInterface) GetCarWithOwnerByID(ctx context.Context, id string) (models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarWithOwnerByID", ctx, id)
+	ret0, _ := ret[0].(models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarWithOwnerByID indicates an expected call of GetCarWithOwnerByID.
+func (mr *MockCarStoreInterfaceMockRecorder) GetCarWithOwnerByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarWithOwnerByID", reflect.TypeOf((*MockCarStoreInterface)(nil).GetCarWithOwnerByID), ctx, id)
+}
+
+// GetEligibility mocks base method.
+func (m *MockCarStoreInterface) GetEligibility(ctx context.Context, carID string) (models.CarEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEligibility", ctx, carID)
+	ret0, _ := ret[0].(models.CarEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEligibility indicates an expected call of GetEligibility.
+func (mr *MockCarStoreInterfaceMockRecorder) GetEligibility(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEligibility", reflect.TypeOf((*MockCarStoreInterface)(nil).GetEligibility), ctx, carID)
+}
+
+// GetFuelPolicy mocks base method.
+func (m *MockCarStoreInterface) GetFuelPolicy(ctx context.Context, carID string) (models.CarFuelPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFuelPolicy", ctx, carID)
+	ret0, _ := ret[0].(models.CarFuelPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFuelPolicy indicates an expected call of GetFuelPolicy.
+func (mr *MockCarStoreInterfaceMockRecorder) GetFuelPolicy(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFuelPolicy", reflect.TypeOf((*MockCarStoreInterface)(nil).GetFuelPolicy), ctx, carID)
+}
+
+// GetOwnerStats mocks base method.
+func (m *MockCarStoreInterface) GetOwnerStats(ctx context.Context, ownerID string) (models.OwnerStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerStats", ctx, ownerID)
+	ret0, _ := ret[0].(models.OwnerStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerStats indicates an expected call of GetOwnerStats.
+func (mr *MockCarStoreInterfaceMockRecorder) GetOwnerStats(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerStats", reflect.TypeOf((*MockCarStoreInterface)(nil).GetOwnerStats), ctx, ownerID)
+}
+
+// GetPriceDrops mocks base method.
+func (m *MockCarStoreInterface) GetPriceDrops(ctx context.Context, carIDs []uuid.UUID, since time.Time) (map[uuid.UUID]models.CarPriceDrop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceDrops", ctx, carIDs, since)
+	ret0, _ := ret[0].(map[uuid.UUID]models.CarPriceDrop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceDrops indicates an expected call of GetPriceDrops.
+func (mr *MockCarStoreInterfaceMockRecorder) GetPriceDrops(ctx, carIDs, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceDrops", reflect.TypeOf((*MockCarStoreInterface)(nil).GetPriceDrops), ctx, carIDs, since)
+}
+
+// GetPriceHistory mocks base method.
+func (m *MockCarStoreInterface) GetPriceHistory(ctx context.Context, carID string) ([]models.CarPriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceHistory", ctx, carID)
+	ret0, _ := ret[0].([]models.CarPriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceHistory indicates an expected call of GetPriceHistory.
+func (mr *MockCarStoreInterfaceMockRecorder) GetPriceHistory(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceHistory", reflect.TypeOf((*MockCarStoreInterface)(nil).GetPriceHistory), ctx, carID)
+}
+
+// GetQualityFacts mocks base method.
+func (m *MockCarStoreInterface) GetQualityFacts(ctx context.Context, carID string) (models.CarQualityFacts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQualityFacts", ctx, carID)
+	ret0, _ := ret[0].(models.CarQualityFacts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQualityFacts indicates an expected call of GetQualityFacts.
+func (mr *MockCarStoreInterfaceMockRecorder) GetQualityFacts(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQualityFacts", reflect.TypeOf((*MockCarStoreInterface)(nil).GetQualityFacts), ctx, carID)
+}
+
+// ListCarSitemapEntries mocks base method.
+func (m *MockCarStoreInterface) ListCarSitemapEntries(ctx context.Context) ([]models.CarSitemapEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCarSitemapEntries", ctx)
+	ret0, _ := ret[0].([]models.CarSitemapEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCarSitemapEntries indicates an expected call of ListCarSitemapEntries.
+func (mr *MockCarStoreInterfaceMockRecorder) ListCarSitemapEntries(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCarSitemapEntries", reflect.TypeOf((*MockCarStoreInterface)(nil).ListCarSitemapEntries), ctx)
+}
+
+// ListCars mocks base method.
+func (m *MockCarStoreInterface) ListCars(ctx context.Context, filter models.CarFilter, page models.PageRequest) ([]models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCars", ctx, filter, page)
+	ret0, _ := ret[0].([]models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCars indicates an expected call of ListCars.
+func (mr *MockCarStoreInterfaceMockRecorder) ListCars(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCars", reflect.TypeOf((*MockCarStoreInterface)(nil).ListCars), ctx, filter, page)
+}
+
+// RebuildListedFlags mocks base method.
+func (m *MockCarStoreInterface) RebuildListedFlags(ctx context.Context, carID *uuid.UUID) (models.MaintenanceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildListedFlags", ctx, carID)
+	ret0, _ := ret[0].(models.MaintenanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebuildListedFlags indicates an expected call of RebuildListedFlags.
+func (mr *MockCarStoreInterfaceMockRecorder) RebuildListedFlags(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildListedFlags", reflect.TypeOf((*MockCarStoreInterface)(nil).RebuildListedFlags), ctx, carID)
+}
+
+// UpdateCar mocks base method.
+func (m *MockCarStoreInterface) UpdateCar(ctx context.Context, id string, carReq models.CarRequest) (models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCar", ctx, id, carReq)
+	ret0, _ := ret[0].(models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCar indicates an expected call of UpdateCar.
+func (mr *MockCarStoreInterfaceMockRecorder) UpdateCar(ctx, id, carReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCar", reflect.TypeOf((*MockCarStoreInterface)(nil).UpdateCar), ctx, id, carReq)
+}
+
+// UpdateCarStatus mocks base method.
+func (m *MockCarStoreInterface) UpdateCarStatus(ctx context.Context, id string, from, to models.CarStatus, isAvailable bool) (models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCarStatus", ctx, id, from, to, isAvailable)
+	ret0, _ := ret[0].(models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCarStatus indicates an expected call of UpdateCarStatus.
+func (mr *MockCarStoreInterfaceMockRecorder) UpdateCarStatus(ctx, id, from, to, isAvailable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCarStatus", reflect.TypeOf((*MockCarStoreInterface)(nil).UpdateCarStatus), ctx, id, from, to, isAvailable)
+}
+
+// UpsertEligibility mocks base method.
+func (m *MockCarStoreInterface) UpsertEligibility(ctx context.Context, carID string, req models.CarEligibilityRequest) (models.CarEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEligibility", ctx, carID, req)
+	ret0, _ := ret[0].(models.CarEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertEligibility indicates an expected call of UpsertEligibility.
+func (mr *MockCarStoreInterfaceMockRecorder) UpsertEligibility(ctx, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEligibility", reflect.TypeOf((*MockCarStoreInterface)(nil).UpsertEligibility), ctx, carID, req)
+}
+
+// UpsertFuelPolicy mocks base method.
+func (m *MockCarStoreInterface) UpsertFuelPolicy(ctx context.Context, carID string, req models.CarFuelPolicyRequest) (models.CarFuelPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertFuelPolicy", ctx, carID, req)
+	ret0, _ := ret[0].(models.CarFuelPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertFuelPolicy indicates an expected call of UpsertFuelPolicy.
+func (mr *MockCarStoreInterfaceMockRecorder) UpsertFuelPolicy(ctx, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertFuelPolicy", reflect.TypeOf((*MockCarStoreInterface)(nil).UpsertFuelPolicy), ctx, carID, req)
+}
+
+// MockUserStoreInterface is a mock of UserStoreInterface interface.
+type MockUserStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserStoreInterfaceMockRecorder is the mock recorder for MockUserStoreInterface.
+type MockUserStoreInterfaceMockRecorder struct {
+	mock *MockUserStoreInterface
+}
+
+// NewMockUserStoreInterface creates a new mock instance.
+func NewMockUserStoreInterface(ctrl *gomock.Controller) *MockUserStoreInterface {
+	mock := &MockUserStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockUserStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserStoreInterface) EXPECT() *MockUserStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockUserStoreInterface) CreateUser(ctx context.Context, userReq models.UserRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, userReq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUserStoreInterfaceMockRecorder) CreateUser(ctx, userReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserStoreInterface)(nil).CreateUser), ctx, userReq)
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserStoreInterface) DeleteUser(ctx context.Context, id string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserStoreInterfaceMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserStoreInterface)(nil).DeleteUser), ctx, id)
+}
+
+// EnableTwoFactor mocks base method.
+func (m *MockUserStoreInterface) EnableTwoFactor(ctx context.Context, userID string, step int64, backupCodeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTwoFactor", ctx, userID, step, backupCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTwoFactor indicates an expected call of EnableTwoFactor.
+func (mr *MockUserStoreInterfaceMockRecorder) EnableTwoFactor(ctx, userID, step, backupCodeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactor", reflect.TypeOf((*MockUserStoreInterface)(nil).EnableTwoFactor), ctx, userID, step, backupCodeHashes)
+}
+
+// GetAllUsers mocks base method.
+func (m *MockUserStoreInterface) GetAllUsers(ctx context.Context) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUsers", ctx)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUsers indicates an expected call of GetAllUsers.
+func (mr *MockUserStoreInterfaceMockRecorder) GetAllUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockUserStoreInterface)(nil).GetAllUsers), ctx)
+}
+
+// GetPublicProfile mocks base method.
+func (m *MockUserStoreInterface) GetPublicProfile(ctx context.Context, ownerID string) (models.PublicProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicProfile", ctx, ownerID)
+	ret0, _ := ret[0].(models.PublicProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicProfile indicates an expected call of GetPublicProfile.
+func (mr *MockUserStoreInterfaceMockRecorder) GetPublicProfile(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicProfile", reflect.TypeOf((*MockUserStoreInterface)(nil).GetPublicProfile), ctx, ownerID)
+}
+
+// GetTwoFactor mocks base method.
+func (m *MockUserStoreInterface) GetTwoFactor(ctx context.Context, userID string) (models.TwoFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwoFactor", ctx, userID)
+	ret0, _ := ret[0].(models.TwoFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactor indicates an expected call of GetTwoFactor.
+func (mr *MockUserStoreInterfaceMockRecorder) GetTwoFactor(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactor", reflect.TypeOf((*MockUserStoreInterface)(nil).GetTwoFactor), ctx, userID)
+}
+
+// GetUser mocks base method.
+func (m *MockUserStoreInterface) GetUser(ctx context.Context, email, password string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, email, password)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserStoreInterfaceMockRecorder) GetUser(ctx, email, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserStoreInterface)(nil).GetUser), ctx, email, password)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockUserStoreInterface) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockUserStoreInterfaceMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserStoreInterface)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserStoreInterface) GetUserByID(ctx context.Context, userID string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserStoreInterfaceMockRecorder) GetUserByID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserStoreInterface)(nil).GetUserByID), ctx, userID)
+}
+
+// GetUsersByRole mocks base method.
+func (m *MockUserStoreInterface) GetUsersByRole(ctx context.Context, role string) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByRole", ctx, role)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByRole indicates an expected call of GetUsersByRole.
+func (mr *MockUserStoreInterfaceMockRecorder) GetUsersByRole(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByRole", reflect.TypeOf((*MockUserStoreInterface)(nil).GetUsersByRole), ctx, role)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserStoreInterface) ListUsers(ctx context.Context, page models.PageRequest) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, page)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserStoreInterfaceMockRecorder) ListUsers(ctx, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserStoreInterface)(nil).ListUsers), ctx, page)
+}
+
+// ReindexPhoneSearch mocks base method.
+func (m *MockUserStoreInterface) ReindexPhoneSearch(ctx context.Context) (models.MaintenanceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReindexPhoneSearch", ctx)
+	ret0, _ := ret[0].(models.MaintenanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReindexPhoneSearch indicates an expected call of ReindexPhoneSearch.
+func (mr *MockUserStoreInterfaceMockRecorder) ReindexPhoneSearch(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReindexPhoneSearch", reflect.TypeOf((*MockUserStoreInterface)(nil).ReindexPhoneSearch), ctx)
+}
+
+// RotateEncryptionKeys mocks base method.
+func (m *MockUserStoreInterface) RotateEncryptionKeys(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateEncryptionKeys", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateEncryptionKeys indicates an expected call of RotateEncryptionKeys.
+func (mr *MockUserStoreInterfaceMockRecorder) RotateEncryptionKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateEncryptionKeys", reflect.TypeOf((*MockUserStoreInterface)(nil).RotateEncryptionKeys), ctx)
+}
+
+// SetPassword mocks base method.
+func (m *MockUserStoreInterface) SetPassword(ctx context.Context, email, password string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, email, password)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockUserStoreInterfaceMockRecorder) SetPassword(ctx, email, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockUserStoreInterface)(nil).SetPassword), ctx, email, password)
+}
+
+// SetPendingTwoFactor mocks base method.
+func (m *MockUserStoreInterface) SetPendingTwoFactor(ctx context.Context, userID, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPendingTwoFactor", ctx, userID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPendingTwoFactor indicates an expected call of SetPendingTwoFactor.
+func (mr *MockUserStoreInterfaceMockRecorder) SetPendingTwoFactor(ctx, userID, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingTwoFactor", reflect.TypeOf((*MockUserStoreInterface)(nil).SetPendingTwoFactor), ctx, userID, secret)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockUserStoreInterface) UpdateProfile(ctx context.Context, userID string, profileData map[string]any, licenseNumber *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, userID, profileData, licenseNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserStoreInterfaceMockRecorder) UpdateProfile(ctx, userID, profileData, licenseNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserStoreInterface)(nil).UpdateProfile), ctx, userID, profileData, licenseNumber)
+}
+
+// UpdateProfileData mocks base method.
+func (m *MockUserStoreInterface) UpdateProfileData(ctx context.Context, userID string, profileData map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfileData", ctx, userID, profileData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfileData indicates an expected call of UpdateProfileData.
+func (mr *MockUserStoreInterfaceMockRecorder) UpdateProfileData(ctx, userID, profileData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfileData", reflect.TypeOf((*MockUserStoreInterface)(nil).UpdateProfileData), ctx, userID, profileData)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUserStoreInterface) UpdateUser(ctx context.Context, id string, userReq models.UserRequest) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, id, userReq)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserStoreInterfaceMockRecorder) UpdateUser(ctx, id, userReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserStoreInterface)(nil).UpdateUser), ctx, id, userReq)
+}
+
+// UseBackupCode mocks base method.
+func (m *MockUserStoreInterface) UseBackupCode(ctx context.Context, userID, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseBackupCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseBackupCode indicates an expected call of UseBackupCode.
+func (mr *MockUserStoreInterfaceMockRecorder) UseBackupCode(ctx, userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseBackupCode", reflect.TypeOf((*MockUserStoreInterface)(nil).UseBackupCode), ctx, userID, codeHash)
+}
+
+// UseTwoFactorStep mocks base method.
+func (m *MockUserStoreInterface) UseTwoFactorStep(ctx context.Context, userID string, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTwoFactorStep", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTwoFactorStep indicates an expected call of UseTwoFactorStep.
+func (mr *MockUserStoreInterfaceMockRecorder) UseTwoFactorStep(ctx, userID, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTwoFactorStep", reflect.TypeOf((*MockUserStoreInterface)(nil).UseTwoFactorStep), ctx, userID, step)
+}
+
+// MockBookingStoreInterface is a mock of BookingStoreInterface interface.
+type MockBookingStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBookingStoreInterfaceMockRecorder is the mock recorder for MockBookingStoreInterface.
+type MockBookingStoreInterfaceMockRecorder struct {
+	mock *MockBookingStoreInterface
+}
+
+// NewMockBookingStoreInterface creates a new mock instance.
+func NewMockBookingStoreInterface(ctrl *gomock.Controller) *MockBookingStoreInterface {
+	mock := &MockBookingStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockBookingStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingStoreInterface) EXPECT() *MockBookingStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddReturnCharges mocks base method.
+func (m *MockBookingStoreInterface) AddReturnCharges(ctx context.Context, id string, lateFee, refuelFee float64) (models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReturnCharges", ctx, id, lateFee, refuelFee)
+	ret0, _ := ret[0].(models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReturnCharges indicates an expected call of AddReturnCharges.
+func (mr *MockBookingStoreInterfaceMockRecorder) AddReturnCharges(ctx, id, lateFee, refuelFee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReturnCharges", reflect.TypeOf((*MockBookingStoreInterface)(nil).AddReturnCharges), ctx, id, lateFee, refuelFee)
+}
+
+// CreateBooking mocks base method.
+func (m *MockBookingStoreInterface) CreateBooking(ctx context.Context, bookingReq models.BookingRequest, quote models.BookingQuote) (models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBooking", ctx, bookingReq, quote)
+	ret0, _ := ret[0].(models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBooking indicates an expected call of CreateBooking.
+func (mr *MockBookingStoreInterfaceMockRecorder) CreateBooking(ctx, bookingReq, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBooking", reflect.TypeOf((*MockBookingStoreInterface)(nil).CreateBooking), ctx, bookingReq, quote)
+}
+
+// CreateInspection mocks base method.
+func (m *MockBookingStoreInterface) CreateInspection(ctx context.Context, inspection models.BookingInspection, carID string) (models.BookingInspection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInspection", ctx, inspection, carID)
+	ret0, _ := ret[0].(models.BookingInspection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInspection indicates an expected call of CreateInspection.
+func (mr *MockBookingStoreInterfaceMockRecorder) CreateInspection(ctx, inspection, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInspection", reflect.TypeOf((*MockBookingStoreInterface)(nil).CreateInspection), ctx, inspection, carID)
+}
+
+// DeleteBooking mocks base method.
+func (m *MockBookingStoreInterface) DeleteBooking(ctx context.Context, id string) (models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBooking", ctx, id)
+	ret0, _ := ret[0].(models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBooking indicates an expected call of DeleteBooking.
+func (mr *MockBookingStoreInterfaceMockRecorder) DeleteBooking(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBooking", reflect.TypeOf((*MockBookingStoreInterface)(nil).DeleteBooking), ctx, id)
+}
+
+// ExpireUnpaidBookings mocks base method.
+func (m *MockBookingStoreInterface) ExpireUnpaidBookings(ctx context.Context, createdBefore, now time.Time) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireUnpaidBookings", ctx, createdBefore, now)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireUnpaidBookings indicates an expected call of ExpireUnpaidBookings.
+func (mr *MockBookingStoreInterfaceMockRecorder) ExpireUnpaidBookings(ctx, createdBefore, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireUnpaidBookings", reflect.TypeOf((*MockBookingStoreInterface)(nil).ExpireUnpaidBookings), ctx, createdBefore, now)
+}
+
+// GetBookingByID mocks base method.
+func (m *MockBookingStoreInterface) GetBookingByID(ctx context.Context, id string) (models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingByID", ctx, id)
+	ret0, _ := ret[0].(models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingByID indicates an expected call of GetBookingByID.
+func (mr *MockBookingStoreInterfaceMockRecorder) GetBookingByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingByID", reflect.TypeOf((*MockBookingStoreInterface)(nil).GetBookingByID), ctx, id)
+}
+
+// GetBookingExtensions mocks base method.
+func (m *MockBookingStoreInterface) GetBookingExtensions(ctx context.Context, parentID string) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingExtensions", ctx, parentID)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingExtensions indicates an expected call of GetBookingExtensions.
+func (mr *MockBookingStoreInterfaceMockRecorder) GetBookingExtensions(ctx, parentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingExtensions", reflect.TypeOf((*MockBookingStoreInterface)(nil).GetBookingExtensions), ctx, parentID)
+}
+
+// GetBookingsByCarID mocks base method.
+func (m *MockBookingStoreInterface) GetBookingsByCarID(ctx context.Context, carID string) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingsByCarID", ctx, carID)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingsByCarID indicates an expected call of GetBookingsByCarID.
+func (mr *MockBookingStoreInterfaceMockRecorder) GetBookingsByCarID(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByCarID", reflect.TypeOf((*MockBookingStoreInterface)(nil).GetBookingsByCarID), ctx, carID)
+}
+
+// GetBookingsByCustomerID mocks base method.
+func (m *MockBookingStoreInterface) GetBookingsByCustomerID(ctx context.Context, customerID string) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingsByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingsByCustomerID indicates an expected call of GetBookingsByCustomerID.
+func (mr *MockBookingStoreInterfaceMockRecorder) GetBookingsByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByCustomerID", reflect.TypeOf((*MockBookingStoreInterface)(nil).GetBookingsByCustomerID), ctx, customerID)
+}
+
+// GetBookingsByOwnerID mocks base method.
+func (m *MockBookingStoreInterface) GetBookingsByOwnerID(ctx context.Context, ownerID string) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingsByOwnerID", ctx, ownerID)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingsByOwnerID indicates an expected call of GetBookingsByOwnerID.
+func (mr *MockBookingStoreInterfaceMockRecorder) GetBookingsByOwnerID(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByOwnerID", reflect.TypeOf((*MockBookingStoreInterface)(nil).GetBookingsByOwnerID), ctx, ownerID)
+}
+
+// GetInspectionsByBookingID mocks base method.
+func (m *MockBookingStoreInterface) GetInspectionsByBookingID(ctx context.Context, bookingID string) ([]models.BookingInspection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInspectionsByBookingID", ctx, bookingID)
+	ret0, _ := ret[0].([]models.BookingInspection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInspectionsByBookingID indicates an expected call of GetInspectionsByBookingID.
+func (mr *MockBookingStoreInterfaceMockRecorder) GetInspectionsByBookingID(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInspectionsByBookingID", reflect.TypeOf((*MockBookingStoreInterface)(nil).GetInspectionsByBookingID), ctx, bookingID)
+}
+
+// GetRepriceableBookings mocks base method.
+func (m *MockBookingStoreInterface) GetRepriceableBookings(ctx context.Context, bookingID *uuid.UUID) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepriceableBookings", ctx, bookingID)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepriceableBookings indicates an expected call of GetRepriceableBookings.
+func (mr *MockBookingStoreInterfaceMockRecorder) GetRepriceableBookings(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepriceableBookings", reflect.TypeOf((*MockBookingStoreInterface)(nil).GetRepriceableBookings), ctx, bookingID)
+}
+
+// ListActiveTrips mocks base method.
+func (m *MockBookingStoreInterface) ListActiveTrips(ctx context.Context, ownerID string) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveTrips", ctx, ownerID)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveTrips indicates an expected call of ListActiveTrips.
+func (mr *MockBookingStoreInterfaceMockRecorder) ListActiveTrips(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveTrips", reflect.TypeOf((*MockBookingStoreInterface)(nil).ListActiveTrips), ctx, ownerID)
+}
+
+// ListBookings mocks base method.
+func (m *MockBookingStoreInterface) ListBookings(ctx context.Context, filter models.BookingFilter, page models.PageRequest) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookings", ctx, filter, page)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookings indicates an expected call of ListBookings.
+func (mr *MockBookingStoreInterfaceMockRecorder) ListBookings(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookings", reflect.TypeOf((*MockBookingStoreInterface)(nil).ListBookings), ctx, filter, page)
+}
+
+// MarkExpiringBookings mocks base method.
+func (m *MockBookingStoreInterface) MarkExpiringBookings(ctx context.Context, createdBefore, now time.Time) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkExpiringBookings", ctx, createdBefore, now)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkExpiringBookings indicates an expected call of MarkExpiringBookings.
+func (mr *MockBookingStoreInterfaceMockRecorder) MarkExpiringBookings(ctx, createdBefore, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExpiringBookings", reflect.TypeOf((*MockBookingStoreInterface)(nil).MarkExpiringBookings), ctx, createdBefore, now)
+}
+
+// MarkHandBackReminders mocks base method.
+func (m *MockBookingStoreInterface) MarkHandBackReminders(ctx context.Context, dueBefore, now time.Time) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkHandBackReminders", ctx, dueBefore, now)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkHandBackReminders indicates an expected call of MarkHandBackReminders.
+func (mr *MockBookingStoreInterfaceMockRecorder) MarkHandBackReminders(ctx, dueBefore, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkHandBackReminders", reflect.TypeOf((*MockBookingStoreInterface)(nil).MarkHandBackReminders), ctx, dueBefore, now)
+}
+
+// MarkOverdueTrips mocks base method.
+func (m *MockBookingStoreInterface) MarkOverdueTrips(ctx context.Context, now time.Time) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOverdueTrips", ctx, now)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOverdueTrips indicates an expected call of MarkOverdueTrips.
+func (mr *MockBookingStoreInterfaceMockRecorder) MarkOverdueTrips(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdueTrips", reflect.TypeOf((*MockBookingStoreInterface)(nil).MarkOverdueTrips), ctx, now)
+}
+
+// UpdateBookingStatus mocks base method.
+func (m *MockBookingStoreInterface) UpdateBookingStatus(ctx context.Context, id string, status models.BookingStatus) (models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBookingStatus", ctx, id, status)
+	ret0, _ := ret[0].(models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBookingStatus indicates an expected call of UpdateBookingStatus.
+func (mr *MockBookingStoreInterfaceMockRecorder) UpdateBookingStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBookingStatus", reflect.TypeOf((*MockBookingStoreInterface)(nil).UpdateBookingStatus), ctx, id, status)
+}
+
+// UpdateBookingTotal mocks base method.
+func (m *MockBookingStoreInterface) UpdateBookingTotal(ctx context.Context, id uuid.UUID, from, to float64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBookingTotal", ctx, id, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBookingTotal indicates an expected call of UpdateBookingTotal.
+func (mr *MockBookingStoreInterfaceMockRecorder) UpdateBookingTotal(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBookingTotal", reflect.TypeOf((*MockBookingStoreInterface)(nil).UpdateBookingTotal), ctx, id, from, to)
+}
+
+// MockTelemetryStoreInterface is a mock of TelemetryStoreInterface interface.
+type MockTelemetryStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTelemetryStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTelemetryStoreInterfaceMockRecorder is the mock recorder for MockTelemetryStoreInterface.
+type MockTelemetryStoreInterfaceMockRecorder struct {
+	mock *MockTelemetryStoreInterface
+}
+
+// NewMockTelemetryStoreInterface creates a new mock instance.
+func NewMockTelemetryStoreInterface(ctrl *gomock.Controller) *MockTelemetryStoreInterface {
+	mock := &MockTelemetryStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockTelemetryStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTelemetryStoreInterface) EXPECT() *MockTelemetryStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateGeofence mocks base method.
+func (m *MockTelemetryStoreInterface) CreateGeofence(ctx context.Context, carID, createdBy string, req models.GeofenceRequest) (models.Geofence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGeofence", ctx, carID, createdBy, req)
+	ret0, _ := ret[0].(models.Geofence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGeofence indicates an expected call of CreateGeofence.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) CreateGeofence(ctx, carID, createdBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGeofence", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).CreateGeofence), ctx, carID, createdBy, req)
+}
+
+// CreateReading mocks base method.
+func (m *MockTelemetryStoreInterface) CreateReading(ctx context.Context, device models.TelemetryDevice, payload models.TelemetryPayload) (models.TelemetryReading, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReading", ctx, device, payload)
+	ret0, _ := ret[0].(models.TelemetryReading)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReading indicates an expected call of CreateReading.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) CreateReading(ctx, device, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReading", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).CreateReading), ctx, device, payload)
+}
+
+// DeleteGeofence mocks base method.
+func (m *MockTelemetryStoreInterface) DeleteGeofence(ctx context.Context, carID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGeofence", ctx, carID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGeofence indicates an expected call of DeleteGeofence.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) DeleteGeofence(ctx, carID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGeofence", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).DeleteGeofence), ctx, carID, id)
+}
+
+// GetDeviceByID mocks base method.
+func (m *MockTelemetryStoreInterface) GetDeviceByID(ctx context.Context, id string) (models.TelemetryDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceByID", ctx, id)
+	ret0, _ := ret[0].(models.TelemetryDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceByID indicates an expected call of GetDeviceByID.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) GetDeviceByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceByID", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).GetDeviceByID), ctx, id)
+}
+
+// GetGeofenceBreachesByCarID mocks base method.
+func (m *MockTelemetryStoreInterface) GetGeofenceBreachesByCarID(ctx context.Context, carID string, limit int) ([]models.GeofenceBreach, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofenceBreachesByCarID", ctx, carID, limit)
+	ret0, _ := ret[0].([]models.GeofenceBreach)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofenceBreachesByCarID indicates an expected call of GetGeofenceBreachesByCarID.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) GetGeofenceBreachesByCarID(ctx, carID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofenceBreachesByCarID", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).GetGeofenceBreachesByCarID), ctx, carID, limit)
+}
+
+// GetGeofencesByCarID mocks base method.
+func (m *MockTelemetryStoreInterface) GetGeofencesByCarID(ctx context.Context, carID string) ([]models.Geofence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofencesByCarID", ctx, carID)
+	ret0, _ := ret[0].([]models.Geofence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofencesByCarID indicates an expected call of GetGeofencesByCarID.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) GetGeofencesByCarID(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofencesByCarID", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).GetGeofencesByCarID), ctx, carID)
+}
+
+// GetLatestOdometerAndFuel mocks base method.
+func (m *MockTelemetryStoreInterface) GetLatestOdometerAndFuel(ctx context.Context, carID string, notBefore time.Time) (*int, *float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestOdometerAndFuel", ctx, carID, notBefore)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(*float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLatestOdometerAndFuel indicates an expected call of GetLatestOdometerAndFuel.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) GetLatestOdometerAndFuel(ctx, carID, notBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestOdometerAndFuel", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).GetLatestOdometerAndFuel), ctx, carID, notBefore)
+}
+
+// GetLatestSnapshot mocks base method.
+func (m *MockTelemetryStoreInterface) GetLatestSnapshot(ctx context.Context, carID string) (models.TelemetryReading, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestSnapshot", ctx, carID)
+	ret0, _ := ret[0].(models.TelemetryReading)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestSnapshot indicates an expected call of GetLatestSnapshot.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) GetLatestSnapshot(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSnapshot", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).GetLatestSnapshot), ctx, carID)
+}
+
+// ListGeofenceChecks mocks base method.
+func (m *MockTelemetryStoreInterface) ListGeofenceChecks(ctx context.Context) ([]models.GeofenceCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGeofenceChecks", ctx)
+	ret0, _ := ret[0].([]models.GeofenceCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGeofenceChecks indicates an expected call of ListGeofenceChecks.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) ListGeofenceChecks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGeofenceChecks", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).ListGeofenceChecks), ctx)
+}
+
+// OpenGeofenceBreach mocks base method.
+func (m *MockTelemetryStoreInterface) OpenGeofenceBreach(ctx context.Context, check models.GeofenceCheck, distanceKm float64) (models.GeofenceBreach, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenGeofenceBreach", ctx, check, distanceKm)
+	ret0, _ := ret[0].(models.GeofenceBreach)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenGeofenceBreach indicates an expected call of OpenGeofenceBreach.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) OpenGeofenceBreach(ctx, check, distanceKm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenGeofenceBreach", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).OpenGeofenceBreach), ctx, check, distanceKm)
+}
+
+// ResolveGeofenceBreach mocks base method.
+func (m *MockTelemetryStoreInterface) ResolveGeofenceBreach(ctx context.Context, id string, resolvedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveGeofenceBreach", ctx, id, resolvedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveGeofenceBreach indicates an expected call of ResolveGeofenceBreach.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) ResolveGeofenceBreach(ctx, id, resolvedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveGeofenceBreach", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).ResolveGeofenceBreach), ctx, id, resolvedAt)
+}
+
+// RotateEncryptionKeys mocks base method.
+func (m *MockTelemetryStoreInterface) RotateEncryptionKeys(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateEncryptionKeys", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateEncryptionKeys indicates an expected call of RotateEncryptionKeys.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) RotateEncryptionKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateEncryptionKeys", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).RotateEncryptionKeys), ctx)
+}
+
+// UpsertDevice mocks base method.
+func (m *MockTelemetryStoreInterface) UpsertDevice(ctx context.Context, carID, secret string) (models.TelemetryDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertDevice", ctx, carID, secret)
+	ret0, _ := ret[0].(models.TelemetryDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertDevice indicates an expected call of UpsertDevice.
+func (mr *MockTelemetryStoreInterfaceMockRecorder) UpsertDevice(ctx, carID, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDevice", reflect.TypeOf((*MockTelemetryStoreInterface)(nil).UpsertDevice), ctx, carID, secret)
+}
+
+// MockPaymentStoreInterface is a mock of PaymentStoreInterface interface.
+type MockPaymentStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentStoreInterfaceMockRecorder is the mock recorder for MockPaymentStoreInterface.
+type MockPaymentStoreInterfaceMockRecorder struct {
+	mock *MockPaymentStoreInterface
+}
+
+// NewMockPaymentStoreInterface creates a new mock instance.
+func NewMockPaymentStoreInterface(ctrl *gomock.Controller) *MockPaymentStoreInterface {
+	mock := &MockPaymentStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockPaymentStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentStoreInterface) EXPECT() *MockPaymentStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreatePayment mocks base method.
+func (m *MockPaymentStoreInterface) CreatePayment(ctx context.Context, paymentReq models.PaymentRequest) (models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, paymentReq)
+	ret0, _ := ret[0].(models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockPaymentStoreInterfaceMockRecorder) CreatePayment(ctx, paymentReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockPaymentStoreInterface)(nil).CreatePayment), ctx, paymentReq)
+}
+
+// CreatePaymentAttempt mocks base method.
+func (m *MockPaymentStoreInterface) CreatePaymentAttempt(ctx context.Context, paymentID uuid.UUID, orderID string) (models.PaymentAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentAttempt", ctx, paymentID, orderID)
+	ret0, _ := ret[0].(models.PaymentAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentAttempt indicates an expected call of CreatePaymentAttempt.
+func (mr *MockPaymentStoreInterfaceMockRecorder) CreatePaymentAttempt(ctx, paymentID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentAttempt", reflect.TypeOf((*MockPaymentStoreInterface)(nil).CreatePaymentAttempt), ctx, paymentID, orderID)
+}
+
+// CreatePaymentAuthorization mocks base method.
+func (m *MockPaymentStoreInterface) CreatePaymentAuthorization(ctx context.Context, payment models.Payment) (models.PaymentAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentAuthorization", ctx, payment)
+	ret0, _ := ret[0].(models.PaymentAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentAuthorization indicates an expected call of CreatePaymentAuthorization.
+func (mr *MockPaymentStoreInterfaceMockRecorder) CreatePaymentAuthorization(ctx, payment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentAuthorization", reflect.TypeOf((*MockPaymentStoreInterface)(nil).CreatePaymentAuthorization), ctx, payment)
+}
+
+// CreatePaymentLink mocks base method.
+func (m *MockPaymentStoreInterface) CreatePaymentLink(ctx context.Context, link models.PaymentLink) (models.PaymentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentLink", ctx, link)
+	ret0, _ := ret[0].(models.PaymentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentLink indicates an expected call of CreatePaymentLink.
+func (mr *MockPaymentStoreInterfaceMockRecorder) CreatePaymentLink(ctx, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentLink", reflect.TypeOf((*MockPaymentStoreInterface)(nil).CreatePaymentLink), ctx, link)
+}
+
+// DeletePayment mocks base method.
+func (m *MockPaymentStoreInterface) DeletePayment(ctx context.Context, id string) (models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePayment", ctx, id)
+	ret0, _ := ret[0].(models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePayment indicates an expected call of DeletePayment.
+func (mr *MockPaymentStoreInterfaceMockRecorder) DeletePayment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayment", reflect.TypeOf((*MockPaymentStoreInterface)(nil).DeletePayment), ctx, id)
+}
+
+// GetPaymentAttempts mocks base method.
+func (m *MockPaymentStoreInterface) GetPaymentAttempts(ctx context.Context, paymentID string) ([]models.PaymentAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentAttempts", ctx, paymentID)
+	ret0, _ := ret[0].([]models.PaymentAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentAttempts indicates an expected call of GetPaymentAttempts.
+func (mr *MockPaymentStoreInterfaceMockRecorder) GetPaymentAttempts(ctx, paymentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentAttempts", reflect.TypeOf((*MockPaymentStoreInterface)(nil).GetPaymentAttempts), ctx, paymentID)
+}
+
+// GetPaymentAuthorization mocks base method.
+func (m *MockPaymentStoreInterface) GetPaymentAuthorization(ctx context.Context, paymentID string) (models.PaymentAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentAuthorization", ctx, paymentID)
+	ret0, _ := ret[0].(models.PaymentAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentAuthorization indicates an expected call of GetPaymentAuthorization.
+func (mr *MockPaymentStoreInterfaceMockRecorder) GetPaymentAuthorization(ctx, paymentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentAuthorization", reflect.TypeOf((*MockPaymentStoreInterface)(nil).GetPaymentAuthorization), ctx, paymentID)
+}
+
+// GetPaymentAuthorizationsByBookingID mocks base method.
+func (m *MockPaymentStoreInterface) GetPaymentAuthorizationsByBookingID(ctx context.Context, bookingID string, status models.PaymentAuthorizationStatus) ([]models.PaymentAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentAuthorizationsByBookingID", ctx, bookingID, status)
+	ret0, _ := ret[0].([]models.PaymentAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentAuthorizationsByBookingID indicates an expected call of GetPaymentAuthorizationsByBookingID.
+func (mr *MockPaymentStoreInterfaceMockRecorder) GetPaymentAuthorizationsByBookingID(ctx, bookingID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentAuthorizationsByBookingID", reflect.TypeOf((*MockPaymentStoreInterface)(nil).GetPaymentAuthorizationsByBookingID), ctx, bookingID, status)
+}
+
+// GetPaymentByID mocks base method.
+func (m *MockPaymentStoreInterface) GetPaymentByID(ctx context.Context, id string) (models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByID", ctx, id)
+	ret0, _ := ret[0].(models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByID indicates an expected call of GetPaymentByID.
+func (mr *MockPaymentStoreInterfaceMockRecorder) GetPaymentByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByID", reflect.TypeOf((*MockPaymentStoreInterface)(nil).GetPaymentByID), ctx, id)
+}
+
+// GetPaymentByRazorpayOrderID mocks base method.
+func (m *MockPaymentStoreInterface) GetPaymentByRazorpayOrderID(ctx context.Context, orderID string) (models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByRazorpayOrderID", ctx, orderID)
+	ret0, _ := ret[0].(models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByRazorpayOrderID indicates an expected call of GetPaymentByRazorpayOrderID.
+func (mr *MockPaymentStoreInterfaceMockRecorder) GetPaymentByRazorpayOrderID(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByRazorpayOrderID", reflect.TypeOf((*MockPaymentStoreInterface)(nil).GetPaymentByRazorpayOrderID), ctx, orderID)
+}
+
+// GetPaymentByRazorpayPaymentID mocks base method.
+func (m *MockPaymentStoreInterface) GetPaymentByRazorpayPaymentID(ctx context.Context, razorpayPaymentID string) (models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByRazorpayPaymentID", ctx, razorpayPaymentID)
+	ret0, _ := ret[0].(models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByRazorpayPaymentID indicates an expected call of GetPaymentByRazorpayPaymentID.
+func (mr *MockPaymentStoreInterfaceMockRecorder) GetPaymentByRazorpayPaymentID(ctx, razorpayPaymentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByRazorpayPaymentID", reflect.TypeOf((*MockPaymentStoreInterface)(nil).GetPaymentByRazorpayPaymentID), ctx, razorpayPaymentID)
+}
+
+// GetPaymentCollectionsByBookingID mocks base method.
+func (m *MockPaymentStoreInterface) GetPaymentCollectionsByBookingID(ctx context.Context, bookingID string) ([]models.PaymentCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentCollectionsByBookingID", ctx, bookingID)
+	ret0, _ := ret[0].([]models.PaymentCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentCollectionsByBookingID indicates an expected call of GetPaymentCollectionsByBookingID.
+func (mr *MockPaymentStoreInterfaceMockRecorder) GetPaymentCollectionsByBookingID(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentCollectionsByBookingID", reflect.TypeOf((*MockPaymentStoreInterface)(nil).GetPaymentCollectionsByBookingID), ctx, bookingID)
+}
+
+// GetPaymentLinkByRazorpayID mocks base method.
+func (m *MockPaymentStoreInterface) GetPaymentLinkByRazorpayID(ctx context.Context, razorpayLinkID string) (models.PaymentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentLinkByRazorpayID", ctx, razorpayLinkID)
+	ret0, _ := ret[0].(models.PaymentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentLinkByRazorpayID indicates an expected call of GetPaymentLinkByRazorpayID.
+func (mr *MockPaymentStoreInterfaceMockRecorder) GetPaymentLinkByRazorpayID(ctx, razorpayLinkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentLinkByRazorpayID", reflect.TypeOf((*MockPaymentStoreInterface)(nil).GetPaymentLinkByRazorpayID), ctx, razorpayLinkID)
+}
+
+// GetPaymentsByBookingID mocks base method.
+func (m *MockPaymentStoreInterface) GetPaymentsByBookingID(ctx context.Context, bookingID string) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentsByBookingID", ctx, bookingID)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentsByBookingID indicates an expected call of GetPaymentsByBookingID.
+func (mr *MockPaymentStoreInterfaceMockRecorder) GetPaymentsByBookingID(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentsByBookingID", reflect.TypeOf((*MockPaymentStoreInterface)(nil).GetPaymentsByBookingID), ctx, bookingID)
+}
+
+// GetPaymentsByUserID mocks base method.
+func (m *MockPaymentStoreInterface) GetPaymentsByUserID(ctx context.Context, userID string) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentsByUserID indicates an expected call of GetPaymentsByUserID.
+func (mr *MockPaymentStoreInterfaceMockRecorder) GetPaymentsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentsByUserID", reflect.TypeOf((*MockPaymentStoreInterface)(nil).GetPaymentsByUserID), ctx, userID)
+}
+
+// GetStalePendingPaymentIDs mocks base method.
+func (m *MockPaymentStoreInterface) GetStalePendingPaymentIDs(ctx context.Context, updatedBefore time.Time, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStalePendingPaymentIDs", ctx, updatedBefore, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStalePendingPaymentIDs indicates an expected call of GetStalePendingPaymentIDs.
+func (mr *MockPaymentStoreInterfaceMockRecorder) GetStalePendingPaymentIDs(ctx, updatedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStalePendingPaymentIDs", reflect.TypeOf((*MockPaymentStoreInterface)(nil).GetStalePendingPaymentIDs), ctx, updatedBefore, limit)
+}
+
+// ListPayments mocks base method.
+func (m *MockPaymentStoreInterface) ListPayments(ctx context.Context, filter models.PaymentFilter, page models.PageRequest) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayments", ctx, filter, page)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayments indicates an expected call of ListPayments.
+func (mr *MockPaymentStoreInterfaceMockRecorder) ListPayments(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayments", reflect.TypeOf((*MockPaymentStoreInterface)(nil).ListPayments), ctx, filter, page)
+}
+
+// RecordPaymentCollection mocks base method.
+func (m *MockPaymentStoreInterface) RecordPaymentCollection(ctx context.Context, collection models.PaymentCollection) (models.PaymentCollection, models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPaymentCollection", ctx, collection)
+	ret0, _ := ret[0].(models.PaymentCollection)
+	ret1, _ := ret[1].(models.Payment)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RecordPaymentCollection indicates an expected call of RecordPaymentCollection.
+func (mr *MockPaymentStoreInterfaceMockRecorder) RecordPaymentCollection(ctx, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPaymentCollection", reflect.TypeOf((*MockPaymentStoreInterface)(nil).RecordPaymentCollection), ctx, collection)
+}
+
+// RetryPayment mocks base method.
+func (m *MockPaymentStoreInterface) RetryPayment(ctx context.Context, paymentID uuid.UUID, orderID string) (models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryPayment", ctx, paymentID, orderID)
+	ret0, _ := ret[0].(models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryPayment indicates an expected call of RetryPayment.
+func (mr *MockPaymentStoreInterfaceMockRecorder) RetryPayment(ctx, paymentID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryPayment", reflect.TypeOf((*MockPaymentStoreInterface)(nil).RetryPayment), ctx, paymentID, orderID)
+}
+
+// UpdatePaymentAttempt mocks base method.
+func (m *MockPaymentStoreInterface) UpdatePaymentAttempt(ctx context.Context, orderID string, status models.PaymentStatus, razorpayPaymentID, errorCode, errorDescription *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentAttempt", ctx, orderID, status, razorpayPaymentID, errorCode, errorDescription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentAttempt indicates an expected call of UpdatePaymentAttempt.
+func (mr *MockPaymentStoreInterfaceMockRecorder) UpdatePaymentAttempt(ctx, orderID, status, razorpayPaymentID, errorCode, errorDescription any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentAttempt", reflect.TypeOf((*MockPaymentStoreInterface)(nil).UpdatePaymentAttempt), ctx, orderID, status, razorpayPaymentID, errorCode, errorDescription)
+}
+
+// UpdatePaymentAuthorization mocks base method.
+func (m *MockPaymentStoreInterface) UpdatePaymentAuthorization(ctx context.Context, id uuid.UUID, from, to models.PaymentAuthorizationStatus, razorpayPaymentID *string, expiresAt *time.Time) (models.PaymentAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentAuthorization", ctx, id, from, to, razorpayPaymentID, expiresAt)
+	ret0, _ := ret[0].(models.PaymentAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentAuthorization indicates an expected call of UpdatePaymentAuthorization.
+func (mr *MockPaymentStoreInterfaceMockRecorder) UpdatePaymentAuthorization(ctx, id, from, to, razorpayPaymentID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentAuthorization", reflect.TypeOf((*MockPaymentStoreInterface)(nil).UpdatePaymentAuthorization), ctx, id, from, to, razorpayPaymentID, expiresAt)
+}
+
+// UpdatePaymentLinkStatus mocks base method.
+func (m *MockPaymentStoreInterface) UpdatePaymentLinkStatus(ctx context.Context, id uuid.UUID, status models.PaymentLinkStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentLinkStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentLinkStatus indicates an expected call of UpdatePaymentLinkStatus.
+func (mr *MockPaymentStoreInterfaceMockRecorder) UpdatePaymentLinkStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentLinkStatus", reflect.TypeOf((*MockPaymentStoreInterface)(nil).UpdatePaymentLinkStatus), ctx, id, status)
+}
+
+// UpdatePaymentStatus mocks base method.
+func (m *MockPaymentStoreInterface) UpdatePaymentStatus(ctx context.Context, id string, status models.PaymentStatus, paymentID, transactionID *string) (models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", ctx, id, status, paymentID, transactionID)
+	ret0, _ := ret[0].(models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus.
+func (mr *MockPaymentStoreInterfaceMockRecorder) UpdatePaymentStatus(ctx, id, status, paymentID, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockPaymentStoreInterface)(nil).UpdatePaymentStatus), ctx, id, status, paymentID, transactionID)
+}
+
+// UpdatePaymentWithRazorpayDetails mocks base method.
+func (m *MockPaymentStoreInterface) UpdatePaymentWithRazorpayDetails(ctx context.Context, paymentID uuid.UUID, orderID string) (models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentWithRazorpayDetails", ctx, paymentID, orderID)
+	ret0, _ := ret[0].(models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentWithRazorpayDetails indicates an expected call of UpdatePaymentWithRazorpayDetails.
+func (mr *MockPaymentStoreInterfaceMockRecorder) UpdatePaymentWithRazorpayDetails(ctx, paymentID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentWithRazorpayDetails", reflect.TypeOf((*MockPaymentStoreInterface)(nil).UpdatePaymentWithRazorpayDetails), ctx, paymentID, orderID)
+}
+
+// MockPayoutStoreInterface is a mock of PayoutStoreInterface interface.
+type MockPayoutStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayoutStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPayoutStoreInterfaceMockRecorder is the mock recorder for MockPayoutStoreInterface.
+type MockPayoutStoreInterfaceMockRecorder struct {
+	mock *MockPayoutStoreInterface
+}
+
+// NewMockPayoutStoreInterface creates a new mock instance.
+func NewMockPayoutStoreInterface(ctrl *gomock.Controller) *MockPayoutStoreInterface {
+	mock := &MockPayoutStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockPayoutStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayoutStoreInterface) EXPECT() *MockPayoutStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreatePayoutAccount mocks base method.
+func (m *MockPayoutStoreInterface) CreatePayoutAccount(ctx context.Context, ownerID string, req models.PayoutAccountRequest) (models.PayoutAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayoutAccount", ctx, ownerID, req)
+	ret0, _ := ret[0].(models.PayoutAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayoutAccount indicates an expected call of CreatePayoutAccount.
+func (mr *MockPayoutStoreInterfaceMockRecorder) CreatePayoutAccount(ctx, ownerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayoutAccount", reflect.TypeOf((*MockPayoutStoreInterface)(nil).CreatePayoutAccount), ctx, ownerID, req)
+}
+
+// CreatePayoutHold mocks base method.
+func (m *MockPayoutStoreInterface) CreatePayoutHold(ctx context.Context, hold models.PayoutHold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayoutHold", ctx, hold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePayoutHold indicates an expected call of CreatePayoutHold.
+func (mr *MockPayoutStoreInterfaceMockRecorder) CreatePayoutHold(ctx, hold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayoutHold", reflect.TypeOf((*MockPayoutStoreInterface)(nil).CreatePayoutHold), ctx, hold)
+}
+
+// DeletePayoutAccount mocks base method.
+func (m *MockPayoutStoreInterface) DeletePayoutAccount(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePayoutAccount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePayoutAccount indicates an expected call of DeletePayoutAccount.
+func (mr *MockPayoutStoreInterfaceMockRecorder) DeletePayoutAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayoutAccount", reflect.TypeOf((*MockPayoutStoreInterface)(nil).DeletePayoutAccount), ctx, id)
+}
+
+// GetPayoutAccountByID mocks base method.
+func (m *MockPayoutStoreInterface) GetPayoutAccountByID(ctx context.Context, id string) (models.PayoutAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayoutAccountByID", ctx, id)
+	ret0, _ := ret[0].(models.PayoutAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayoutAccountByID indicates an expected call of GetPayoutAccountByID.
+func (mr *MockPayoutStoreInterfaceMockRecorder) GetPayoutAccountByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayoutAccountByID", reflect.TypeOf((*MockPayoutStoreInterface)(nil).GetPayoutAccountByID), ctx, id)
+}
+
+// GetPayoutAccountsByOwnerID mocks base method.
+func (m *MockPayoutStoreInterface) GetPayoutAccountsByOwnerID(ctx context.Context, ownerID string) ([]models.PayoutAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayoutAccountsByOwnerID", ctx, ownerID)
+	ret0, _ := ret[0].([]models.PayoutAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayoutAccountsByOwnerID indicates an expected call of GetPayoutAccountsByOwnerID.
+func (mr *MockPayoutStoreInterfaceMockRecorder) GetPayoutAccountsByOwnerID(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayoutAccountsByOwnerID", reflect.TypeOf((*MockPayoutStoreInterface)(nil).GetPayoutAccountsByOwnerID), ctx, ownerID)
+}
+
+// GetPayoutHoldsByOwnerID mocks base method.
+func (m *MockPayoutStoreInterface) GetPayoutHoldsByOwnerID(ctx context.Context, ownerID string) ([]models.PayoutHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayoutHoldsByOwnerID", ctx, ownerID)
+	ret0, _ := ret[0].([]models.PayoutHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayoutHoldsByOwnerID indicates an expected call of GetPayoutHoldsByOwnerID.
+func (mr *MockPayoutStoreInterfaceMockRecorder) GetPayoutHoldsByOwnerID(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayoutHoldsByOwnerID", reflect.TypeOf((*MockPayoutStoreInterface)(nil).GetPayoutHoldsByOwnerID), ctx, ownerID)
+}
+
+// ReleasePayoutHold mocks base method.
+func (m *MockPayoutStoreInterface) ReleasePayoutHold(ctx context.Context, disputeID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleasePayoutHold", ctx, disputeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleasePayoutHold indicates an expected call of ReleasePayoutHold.
+func (mr *MockPayoutStoreInterfaceMockRecorder) ReleasePayoutHold(ctx, disputeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleasePayoutHold", reflect.TypeOf((*MockPayoutStoreInterface)(nil).ReleasePayoutHold), ctx, disputeID)
+}
+
+// RotateEncryptionKeys mocks base method.
+func (m *MockPayoutStoreInterface) RotateEncryptionKeys(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateEncryptionKeys", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateEncryptionKeys indicates an expected call of RotateEncryptionKeys.
+func (mr *MockPayoutStoreInterfaceMockRecorder) RotateEncryptionKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateEncryptionKeys", reflect.TypeOf((*MockPayoutStoreInterface)(nil).RotateEncryptionKeys), ctx)
+}
+
+// UpdatePayoutAccountVerification mocks base method.
+func (m *MockPayoutStoreInterface) UpdatePayoutAccountVerification(ctx context.Context, id string, update models.PayoutVerificationUpdate) (models.PayoutAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayoutAccountVerification", ctx, id, update)
+	ret0, _ := ret[0].(models.PayoutAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePayoutAccountVerification indicates an expected call of UpdatePayoutAccountVerification.
+func (mr *MockPayoutStoreInterfaceMockRecorder) UpdatePayoutAccountVerification(ctx, id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayoutAccountVerification", reflect.TypeOf((*MockPayoutStoreInterface)(nil).UpdatePayoutAccountVerification), ctx, id, update)
+}
+
+// MockSecurityStoreInterface is a mock of SecurityStoreInterface interface.
+type MockSecurityStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecurityStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSecurityStoreInterfaceMockRecorder is the mock recorder for MockSecurityStoreInterface.
+type MockSecurityStoreInterfaceMockRecorder struct {
+	mock *MockSecurityStoreInterface
+}
+
+// NewMockSecurityStoreInterface creates a new mock instance.
+func NewMockSecurityStoreInterface(ctrl *gomock.Controller) *MockSecurityStoreInterface {
+	mock := &MockSecurityStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockSecurityStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecurityStoreInterface) EXPECT() *MockSecurityStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountBookingsCreatedSince mocks base method.
+func (m *MockSecurityStoreInterface) CountBookingsCreatedSince(ctx context.Context, customerID string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBookingsCreatedSince", ctx, customerID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBookingsCreatedSince indicates an expected call of CountBookingsCreatedSince.
+func (mr *MockSecurityStoreInterfaceMockRecorder) CountBookingsCreatedSince(ctx, customerID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBookingsCreatedSince", reflect.TypeOf((*MockSecurityStoreInterface)(nil).CountBookingsCreatedSince), ctx, customerID, since)
+}
+
+// CountCancelledBookingsSince mocks base method.
+func (m *MockSecurityStoreInterface) CountCancelledBookingsSince(ctx context.Context, customerID string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCancelledBookingsSince", ctx, customerID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCancelledBookingsSince indicates an expected call of CountCancelledBookingsSince.
+func (mr *MockSecurityStoreInterfaceMockRecorder) CountCancelledBookingsSince(ctx, customerID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCancelledBookingsSince", reflect.TypeOf((*MockSecurityStoreInterface)(nil).CountCancelledBookingsSince), ctx, customerID, since)
+}
+
+// CountFailedPaymentsSince mocks base method.
+func (m *MockSecurityStoreInterface) CountFailedPaymentsSince(ctx context.Context, customerID string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFailedPaymentsSince", ctx, customerID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFailedPaymentsSince indicates an expected call of CountFailedPaymentsSince.
+func (mr *MockSecurityStoreInterfaceMockRecorder) CountFailedPaymentsSince(ctx, customerID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFailedPaymentsSince", reflect.TypeOf((*MockSecurityStoreInterface)(nil).CountFailedPaymentsSince), ctx, customerID, since)
+}
+
+// CreateSecurityEvent mocks base method.
+func (m *MockSecurityStoreInterface) CreateSecurityEvent(ctx context.Context, event models.SecurityEvent) (models.SecurityEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecurityEvent", ctx, event)
+	ret0, _ := ret[0].(models.SecurityEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecurityEvent indicates an expected call of CreateSecurityEvent.
+func (mr *MockSecurityStoreInterfaceMockRecorder) CreateSecurityEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityEvent", reflect.TypeOf((*MockSecurityStoreInterface)(nil).CreateSecurityEvent), ctx, event)
+}
+
+// GetSecurityEventByID mocks base method.
+func (m *MockSecurityStoreInterface) GetSecurityEventByID(ctx context.Context, id string) (models.SecurityEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityEventByID", ctx, id)
+	ret0, _ := ret[0].(models.SecurityEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurityEventByID indicates an expected call of GetSecurityEventByID.
+func (mr *MockSecurityStoreInterfaceMockRecorder) GetSecurityEventByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityEventByID", reflect.TypeOf((*MockSecurityStoreInterface)(nil).GetSecurityEventByID), ctx, id)
+}
+
+// HasOpenSecurityEventSince mocks base method.
+func (m *MockSecurityStoreInterface) HasOpenSecurityEventSince(ctx context.Context, userID string, eventType models.SecurityEventType, since time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOpenSecurityEventSince", ctx, userID, eventType, since)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasOpenSecurityEventSince indicates an expected call of HasOpenSecurityEventSince.
+func (mr *MockSecurityStoreInterfaceMockRecorder) HasOpenSecurityEventSince(ctx, userID, eventType, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOpenSecurityEventSince", reflect.TypeOf((*MockSecurityStoreInterface)(nil).HasOpenSecurityEventSince), ctx, userID, eventType, since)
+}
+
+// ListSecurityEvents mocks base method.
+func (m *MockSecurityStoreInterface) ListSecurityEvents(ctx context.Context, filter models.SecurityEventFilter, page models.PageRequest) ([]models.SecurityEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityEvents", ctx, filter, page)
+	ret0, _ := ret[0].([]models.SecurityEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityEvents indicates an expected call of ListSecurityEvents.
+func (mr *MockSecurityStoreInterfaceMockRecorder) ListSecurityEvents(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityEvents", reflect.TypeOf((*MockSecurityStoreInterface)(nil).ListSecurityEvents), ctx, filter, page)
+}
+
+// RecordLogin mocks base method.
+func (m *MockSecurityStoreInterface) RecordLogin(ctx context.Context, userID, ipAddress, userAgent string) (bool, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLogin", ctx, userID, ipAddress, userAgent)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RecordLogin indicates an expected call of RecordLogin.
+func (mr *MockSecurityStoreInterfaceMockRecorder) RecordLogin(ctx, userID, ipAddress, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLogin", reflect.TypeOf((*MockSecurityStoreInterface)(nil).RecordLogin), ctx, userID, ipAddress, userAgent)
+}
+
+// ReviewSecurityEvent mocks base method.
+func (m *MockSecurityStoreInterface) ReviewSecurityEvent(ctx context.Context, id, reviewerID string, review models.SecurityEventReview) (models.SecurityEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewSecurityEvent", ctx, id, reviewerID, review)
+	ret0, _ := ret[0].(models.SecurityEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewSecurityEvent indicates an expected call of ReviewSecurityEvent.
+func (mr *MockSecurityStoreInterfaceMockRecorder) ReviewSecurityEvent(ctx, id, reviewerID, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewSecurityEvent", reflect.TypeOf((*MockSecurityStoreInterface)(nil).ReviewSecurityEvent), ctx, id, reviewerID, review)
+}
+
+// MockEncryptedStoreInterface is a mock of EncryptedStoreInterface interface.
+type MockEncryptedStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptedStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockEncryptedStoreInterfaceMockRecorder is the mock recorder for MockEncryptedStoreInterface.
+type MockEncryptedStoreInterfaceMockRecorder struct {
+	mock *MockEncryptedStoreInterface
+}
+
+// NewMockEncryptedStoreInterface creates a new mock instance.
+func NewMockEncryptedStoreInterface(ctrl *gomock.Controller) *MockEncryptedStoreInterface {
+	mock := &MockEncryptedStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockEncryptedStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptedStoreInterface) EXPECT() *MockEncryptedStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RotateEncryptionKeys mocks base method.
+func (m *MockEncryptedStoreInterface) RotateEncryptionKeys(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateEncryptionKeys", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateEncryptionKeys indicates an expected call of RotateEncryptionKeys.
+func (mr *MockEncryptedStoreInterfaceMockRecorder) RotateEncryptionKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateEncryptionKeys", reflect.TypeOf((*MockEncryptedStoreInterface)(nil).RotateEncryptionKeys), ctx)
+}
+
+// MockLocationStoreInterface is a mock of LocationStoreInterface interface.
+type MockLocationStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockLocationStoreInterfaceMockRecorder is the mock recorder for MockLocationStoreInterface.
+type MockLocationStoreInterfaceMockRecorder struct {
+	mock *MockLocationStoreInterface
+}
+
+// NewMockLocationStoreInterface creates a new mock instance.
+func NewMockLocationStoreInterface(ctrl *gomock.Controller) *MockLocationStoreInterface {
+	mock := &MockLocationStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockLocationStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationStoreInterface) EXPECT() *MockLocationStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AttachCar mocks base method.
+func (m *MockLocationStoreInterface) AttachCar(ctx context.Context, carID, locationID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachCar", ctx, carID, locationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachCar indicates an expected call of AttachCar.
+func (mr *MockLocationStoreInterfaceMockRecorder) AttachCar(ctx, carID, locationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachCar", reflect.TypeOf((*MockLocationStoreInterface)(nil).AttachCar), ctx, carID, locationID)
+}
+
+// CreateLocation mocks base method.
+func (m *MockLocationStoreInterface) CreateLocation(ctx context.Context, req models.LocationRequest) (models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLocation", ctx, req)
+	ret0, _ := ret[0].(models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLocation indicates an expected call of CreateLocation.
+func (mr *MockLocationStoreInterfaceMockRecorder) CreateLocation(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLocation", reflect.TypeOf((*MockLocationStoreInterface)(nil).CreateLocation), ctx, req)
+}
+
+// DeleteLocation mocks base method.
+func (m *MockLocationStoreInterface) DeleteLocation(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLocation", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLocation indicates an expected call of DeleteLocation.
+func (mr *MockLocationStoreInterfaceMockRecorder) DeleteLocation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLocation", reflect.TypeOf((*MockLocationStoreInterface)(nil).DeleteLocation), ctx, id)
+}
+
+// DeleteRelocationFee mocks base method.
+func (m *MockLocationStoreInterface) DeleteRelocationFee(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRelocationFee", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRelocationFee indicates an expected call of DeleteRelocationFee.
+func (mr *MockLocationStoreInterfaceMockRecorder) DeleteRelocationFee(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRelocationFee", reflect.TypeOf((*MockLocationStoreInterface)(nil).DeleteRelocationFee), ctx, id)
+}
+
+// DetachCar mocks base method.
+func (m *MockLocationStoreInterface) DetachCar(ctx context.Context, carID, locationID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachCar", ctx, carID, locationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachCar indicates an expected call of DetachCar.
+func (mr *MockLocationStoreInterfaceMockRecorder) DetachCar(ctx, carID, locationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachCar", reflect.TypeOf((*MockLocationStoreInterface)(nil).DetachCar), ctx, carID, locationID)
+}
+
+// GetCarLocation mocks base method.
+func (m *MockLocationStoreInterface) GetCarLocation(ctx context.Context, carID, locationID string) (models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarLocation", ctx, carID, locationID)
+	ret0, _ := ret[0].(models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarLocation indicates an expected call of GetCarLocation.
+func (mr *MockLocationStoreInterfaceMockRecorder) GetCarLocation(ctx, carID, locationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarLocation", reflect.TypeOf((*MockLocationStoreInterface)(nil).GetCarLocation), ctx, carID, locationID)
+}
+
+// GetDeliveryOption mocks base method.
+func (m *MockLocationStoreInterface) GetDeliveryOption(ctx context.Context, carID string) (models.DeliveryOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryOption", ctx, carID)
+	ret0, _ := ret[0].(models.DeliveryOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryOption indicates an expected call of GetDeliveryOption.
+func (mr *MockLocationStoreInterfaceMockRecorder) GetDeliveryOption(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryOption", reflect.TypeOf((*MockLocationStoreInterface)(nil).GetDeliveryOption), ctx, carID)
+}
+
+// GetLocationByID mocks base method.
+func (m *MockLocationStoreInterface) GetLocationByID(ctx context.Context, id string) (models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocationByID", ctx, id)
+	ret0, _ := ret[0].(models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocationByID indicates an expected call of GetLocationByID.
+func (mr *MockLocationStoreInterfaceMockRecorder) GetLocationByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationByID", reflect.TypeOf((*MockLocationStoreInterface)(nil).GetLocationByID), ctx, id)
+}
+
+// GetLocationsByCarID mocks base method.
+func (m *MockLocationStoreInterface) GetLocationsByCarID(ctx context.Context, carID string) ([]models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocationsByCarID", ctx, carID)
+	ret0, _ := ret[0].([]models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocationsByCarID indicates an expected call of GetLocationsByCarID.
+func (mr *MockLocationStoreInterfaceMockRecorder) GetLocationsByCarID(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationsByCarID", reflect.TypeOf((*MockLocationStoreInterface)(nil).GetLocationsByCarID), ctx, carID)
+}
+
+// GetRelocationFee mocks base method.
+func (m *MockLocationStoreInterface) GetRelocationFee(ctx context.Context, fromCity, toCity string) (models.RelocationFee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelocationFee", ctx, fromCity, toCity)
+	ret0, _ := ret[0].(models.RelocationFee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelocationFee indicates an expected call of GetRelocationFee.
+func (mr *MockLocationStoreInterfaceMockRecorder) GetRelocationFee(ctx, fromCity, toCity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelocationFee", reflect.TypeOf((*MockLocationStoreInterface)(nil).GetRelocationFee), ctx, fromCity, toCity)
+}
+
+// ListLocations mocks base method.
+func (m *MockLocationStoreInterface) ListLocations(ctx context.Context, city string, activeOnly bool) ([]models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLocations", ctx, city, activeOnly)
+	ret0, _ := ret[0].([]models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLocations indicates an expected call of ListLocations.
+func (mr *MockLocationStoreInterfaceMockRecorder) ListLocations(ctx, city, activeOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLocations", reflect.TypeOf((*MockLocationStoreInterface)(nil).ListLocations), ctx, city, activeOnly)
+}
+
+// ListRelocationFees mocks base method.
+func (m *MockLocationStoreInterface) ListRelocationFees(ctx context.Context) ([]models.RelocationFee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRelocationFees", ctx)
+	ret0, _ := ret[0].([]models.RelocationFee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRelocationFees indicates an expected call of ListRelocationFees.
+func (mr *MockLocationStoreInterfaceMockRecorder) ListRelocationFees(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRelocationFees", reflect.TypeOf((*MockLocationStoreInterface)(nil).ListRelocationFees), ctx)
+}
+
+// UpdateLocation mocks base method.
+func (m *MockLocationStoreInterface) UpdateLocation(ctx context.Context, id string, req models.LocationRequest) (models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocation", ctx, id, req)
+	ret0, _ := ret[0].(models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLocation indicates an expected call of UpdateLocation.
+func (mr *MockLocationStoreInterfaceMockRecorder) UpdateLocation(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockLocationStoreInterface)(nil).UpdateLocation), ctx, id, req)
+}
+
+// UpsertDeliveryOption mocks base method.
+func (m *MockLocationStoreInterface) UpsertDeliveryOption(ctx context.Context, carID string, req models.DeliveryOptionRequest) (models.DeliveryOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertDeliveryOption", ctx, carID, req)
+	ret0, _ := ret[0].(models.DeliveryOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertDeliveryOption indicates an expected call of UpsertDeliveryOption.
+func (mr *MockLocationStoreInterfaceMockRecorder) UpsertDeliveryOption(ctx, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDeliveryOption", reflect.TypeOf((*MockLocationStoreInterface)(nil).UpsertDeliveryOption), ctx, carID, req)
+}
+
+// UpsertRelocationFee mocks base method.
+func (m *MockLocationStoreInterface) UpsertRelocationFee(ctx context.Context, req models.RelocationFeeRequest) (models.RelocationFee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRelocationFee", ctx, req)
+	ret0, _ := ret[0].(models.RelocationFee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertRelocationFee indicates an expected call of UpsertRelocationFee.
+func (mr *MockLocationStoreInterfaceMockRecorder) UpsertRelocationFee(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRelocationFee", reflect.TypeOf((*MockLocationStoreInterface)(nil).UpsertRelocationFee), ctx, req)
+}
+
+// MockAlertStoreInterface is a mock of AlertStoreInterface interface.
+type MockAlertStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAlertStoreInterfaceMockRecorder is the mock recorder for MockAlertStoreInterface.
+type MockAlertStoreInterfaceMockRecorder struct {
+	mock *MockAlertStoreInterface
+}
+
+// NewMockAlertStoreInterface creates a new mock instance.
+func NewMockAlertStoreInterface(ctrl *gomock.Controller) *MockAlertStoreInterface {
+	mock := &MockAlertStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockAlertStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertStoreInterface) EXPECT() *MockAlertStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockAlertStoreInterface) DeleteSubscription(ctx context.Context, id, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockAlertStoreInterfaceMockRecorder) DeleteSubscription(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockAlertStoreInterface)(nil).DeleteSubscription), ctx, id, userID)
+}
+
+// GetSubscriptionsByCarID mocks base method.
+func (m *MockAlertStoreInterface) GetSubscriptionsByCarID(ctx context.Context, carID string) ([]models.CarAlertSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionsByCarID", ctx, carID)
+	ret0, _ := ret[0].([]models.CarAlertSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionsByCarID indicates an expected call of GetSubscriptionsByCarID.
+func (mr *MockAlertStoreInterfaceMockRecorder) GetSubscriptionsByCarID(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionsByCarID", reflect.TypeOf((*MockAlertStoreInterface)(nil).GetSubscriptionsByCarID), ctx, carID)
+}
+
+// GetSubscriptionsByUserID mocks base method.
+func (m *MockAlertStoreInterface) GetSubscriptionsByUserID(ctx context.Context, userID string) ([]models.CarAlertSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]models.CarAlertSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionsByUserID indicates an expected call of GetSubscriptionsByUserID.
+func (mr *MockAlertStoreInterfaceMockRecorder) GetSubscriptionsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionsByUserID", reflect.TypeOf((*MockAlertStoreInterface)(nil).GetSubscriptionsByUserID), ctx, userID)
+}
+
+// MarkNotified mocks base method.
+func (m *MockAlertStoreInterface) MarkNotified(ctx context.Context, id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotified", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotified indicates an expected call of MarkNotified.
+func (mr *MockAlertStoreInterfaceMockRecorder) MarkNotified(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotified", reflect.TypeOf((*MockAlertStoreInterface)(nil).MarkNotified), ctx, id, at)
+}
+
+// UpsertSubscription mocks base method.
+func (m *MockAlertStoreInterface) UpsertSubscription(ctx context.Context, userID, carID string, req models.CarAlertSubscriptionRequest) (models.CarAlertSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSubscription", ctx, userID, carID, req)
+	ret0, _ := ret[0].(models.CarAlertSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertSubscription indicates an expected call of UpsertSubscription.
+func (mr *MockAlertStoreInterfaceMockRecorder) UpsertSubscription(ctx, userID, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSubscription", reflect.TypeOf((*MockAlertStoreInterface)(nil).UpsertSubscription), ctx, userID, carID, req)
+}
+
+// MockFavoriteStoreInterface is a mock of FavoriteStoreInterface interface.
+type MockFavoriteStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFavoriteStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockFavoriteStoreInterfaceMockRecorder is the mock recorder for MockFavoriteStoreInterface.
+type MockFavoriteStoreInterfaceMockRecorder struct {
+	mock *MockFavoriteStoreInterface
+}
+
+// NewMockFavoriteStoreInterface creates a new mock instance.
+func NewMockFavoriteStoreInterface(ctrl *gomock.Controller) *MockFavoriteStoreInterface {
+	mock := &MockFavoriteStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockFavoriteStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFavoriteStoreInterface) EXPECT() *MockFavoriteStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddFavorite mocks base method.
+func (m *MockFavoriteStoreInterface) AddFavorite(ctx context.Context, userID, carID string) (models.Favorite, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFavorite", ctx, userID, carID)
+	ret0, _ := ret[0].(models.Favorite)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddFavorite indicates an expected call of AddFavorite.
+func (mr *MockFavoriteStoreInterfaceMockRecorder) AddFavorite(ctx, userID, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavorite", reflect.TypeOf((*MockFavoriteStoreInterface)(nil).AddFavorite), ctx, userID, carID)
+}
+
+// ListFavorites mocks base method.
+func (m *MockFavoriteStoreInterface) ListFavorites(ctx context.Context, userID string, page models.PageRequest) ([]models.Favorite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFavorites", ctx, userID, page)
+	ret0, _ := ret[0].([]models.Favorite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFavorites indicates an expected call of ListFavorites.
+func (mr *MockFavoriteStoreInterfaceMockRecorder) ListFavorites(ctx, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFavorites", reflect.TypeOf((*MockFavoriteStoreInterface)(nil).ListFavorites), ctx, userID, page)
+}
+
+// RemoveFavorite mocks base method.
+func (m *MockFavoriteStoreInterface) RemoveFavorite(ctx context.Context, userID, carID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFavorite", ctx, userID, carID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFavorite indicates an expected call of RemoveFavorite.
+func (mr *MockFavoriteStoreInterfaceMockRecorder) RemoveFavorite(ctx, userID, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavorite", reflect.TypeOf((*MockFavoriteStoreInterface)(nil).RemoveFavorite), ctx, userID, carID)
+}
+
+// MockAnalyticsStoreInterface is a mock of AnalyticsStoreInterface interface.
+type MockAnalyticsStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAnalyticsStoreInterfaceMockRecorder is the mock recorder for MockAnalyticsStoreInterface.
+type MockAnalyticsStoreInterfaceMockRecorder struct {
+	mock *MockAnalyticsStoreInterface
+}
+
+// NewMockAnalyticsStoreInterface creates a new mock instance.
+func NewMockAnalyticsStoreInterface(ctrl *gomock.Controller) *MockAnalyticsStoreInterface {
+	mock := &MockAnalyticsStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsStoreInterface) EXPECT() *MockAnalyticsStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetBookingFunnel mocks base method.
+func (m *MockAnalyticsStoreInterface) GetBookingFunnel(ctx context.Context, r models.DashboardRange) (models.BookingFunnel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingFunnel", ctx, r)
+	ret0, _ := ret[0].(models.BookingFunnel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingFunnel indicates an expected call of GetBookingFunnel.
+func (mr *MockAnalyticsStoreInterfaceMockRecorder) GetBookingFunnel(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingFunnel", reflect.TypeOf((*MockAnalyticsStoreInterface)(nil).GetBookingFunnel), ctx, r)
+}
+
+// GetCarUtilization mocks base method.
+func (m *MockAnalyticsStoreInterface) GetCarUtilization(ctx context.Context, r models.DashboardRange, limit int) ([]models.CarUtilization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarUtilization", ctx, r, limit)
+	ret0, _ := ret[0].([]models.CarUtilization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarUtilization indicates an expected call of GetCarUtilization.
+func (mr *MockAnalyticsStoreInterfaceMockRecorder) GetCarUtilization(ctx, r, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarUtilization", reflect.TypeOf((*MockAnalyticsStoreInterface)(nil).GetCarUtilization), ctx, r, limit)
+}
+
+// GetDashboardRefreshedAt mocks base method.
+func (m *MockAnalyticsStoreInterface) GetDashboardRefreshedAt(ctx context.Context) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDashboardRefreshedAt", ctx)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDashboardRefreshedAt indicates an expected call of GetDashboardRefreshedAt.
+func (mr *MockAnalyticsStoreInterfaceMockRecorder) GetDashboardRefreshedAt(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDashboardRefreshedAt", reflect.TypeOf((*MockAnalyticsStoreInterface)(nil).GetDashboardRefreshedAt), ctx)
+}
+
+// GetOwnerEarnings mocks base method.
+func (m *MockAnalyticsStoreInterface) GetOwnerEarnings(ctx context.Context, r models.DashboardRange, limit int) ([]models.OwnerEarnings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerEarnings", ctx, r, limit)
+	ret0, _ := ret[0].([]models.OwnerEarnings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerEarnings indicates an expected call of GetOwnerEarnings.
+func (mr *MockAnalyticsStoreInterfaceMockRecorder) GetOwnerEarnings(ctx, r, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerEarnings", reflect.TypeOf((*MockAnalyticsStoreInterface)(nil).GetOwnerEarnings), ctx, r, limit)
+}
+
+// GetProjectedTimeSeries mocks base method.
+func (m *MockAnalyticsStoreInterface) GetProjectedTimeSeries(ctx context.Context, query models.TimeSeriesQuery) ([]models.TimeSeriesPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectedTimeSeries", ctx, query)
+	ret0, _ := ret[0].([]models.TimeSeriesPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectedTimeSeries indicates an expected call of GetProjectedTimeSeries.
+func (mr *MockAnalyticsStoreInterfaceMockRecorder) GetProjectedTimeSeries(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectedTimeSeries", reflect.TypeOf((*MockAnalyticsStoreInterface)(nil).GetProjectedTimeSeries), ctx, query)
+}
+
+// GetTimeSeries mocks base method.
+func (m *MockAnalyticsStoreInterface) GetTimeSeries(ctx context.Context, query models.TimeSeriesQuery) ([]models.TimeSeriesPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeSeries", ctx, query)
+	ret0, _ := ret[0].([]models.TimeSeriesPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeSeries indicates an expected call of GetTimeSeries.
+func (mr *MockAnalyticsStoreInterfaceMockRecorder) GetTimeSeries(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeSeries", reflect.TypeOf((*MockAnalyticsStoreInterface)(nil).GetTimeSeries), ctx, query)
+}
+
+// RefreshDashboards mocks base method.
+func (m *MockAnalyticsStoreInterface) RefreshDashboards(ctx context.Context, rebuildEvery time.Duration) (models.DashboardRefresh, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshDashboards", ctx, rebuildEvery)
+	ret0, _ := ret[0].(models.DashboardRefresh)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshDashboards indicates an expected call of RefreshDashboards.
+func (mr *MockAnalyticsStoreInterfaceMockRecorder) RefreshDashboards(ctx, rebuildEvery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshDashboards", reflect.TypeOf((*MockAnalyticsStoreInterface)(nil).RefreshDashboards), ctx, rebuildEvery)
+}
+
+// MockWarehouseStoreInterface is a mock of WarehouseStoreInterface interface.
+type MockWarehouseStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWarehouseStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockWarehouseStoreInterfaceMockRecorder is the mock recorder for MockWarehouseStoreInterface.
+type MockWarehouseStoreInterfaceMockRecorder struct {
+	mock *MockWarehouseStoreInterface
+}
+
+// NewMockWarehouseStoreInterface creates a new mock instance.
+func NewMockWarehouseStoreInterface(ctrl *gomock.Controller) *MockWarehouseStoreInterface {
+	mock := &MockWarehouseStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockWarehouseStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWarehouseStoreInterface) EXPECT() *MockWarehouseStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetChangedRows mocks base method.
+func (m *MockWarehouseStoreInterface) GetChangedRows(ctx context.Context, entity models.WarehouseEntity, from, to time.Time) (models.WarehouseRows, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangedRows", ctx, entity, from, to)
+	ret0, _ := ret[0].(models.WarehouseRows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangedRows indicates an expected call of GetChangedRows.
+func (mr *MockWarehouseStoreInterfaceMockRecorder) GetChangedRows(ctx, entity, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangedRows", reflect.TypeOf((*MockWarehouseStoreInterface)(nil).GetChangedRows), ctx, entity, from, to)
+}
+
+// GetWatermark mocks base method.
+func (m *MockWarehouseStoreInterface) GetWatermark(ctx context.Context, entity models.WarehouseEntity) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatermark", ctx, entity)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatermark indicates an expected call of GetWatermark.
+func (mr *MockWarehouseStoreInterfaceMockRecorder) GetWatermark(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatermark", reflect.TypeOf((*MockWarehouseStoreInterface)(nil).GetWatermark), ctx, entity)
+}
+
+// SetWatermark mocks base method.
+func (m *MockWarehouseStoreInterface) SetWatermark(ctx context.Context, entity models.WarehouseEntity, exportedUntil time.Time, rows int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWatermark", ctx, entity, exportedUntil, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWatermark indicates an expected call of SetWatermark.
+func (mr *MockWarehouseStoreInterfaceMockRecorder) SetWatermark(ctx, entity, exportedUntil, rows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWatermark", reflect.TypeOf((*MockWarehouseStoreInterface)(nil).SetWatermark), ctx, entity, exportedUntil, rows)
+}
+
+// MockSearchStoreInterface is a mock of SearchStoreInterface interface.
+type MockSearchStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchStoreInterfaceMockRecorder is the mock recorder for MockSearchStoreInterface.
+type MockSearchStoreInterfaceMockRecorder struct {
+	mock *MockSearchStoreInterface
+}
+
+// NewMockSearchStoreInterface creates a new mock instance.
+func NewMockSearchStoreInterface(ctrl *gomock.Controller) *MockSearchStoreInterface {
+	mock := &MockSearchStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockSearchStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchStoreInterface) EXPECT() *MockSearchStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchStoreInterface) Search(ctx context.Context, query models.SearchQuery, limit int) ([]models.SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit)
+	ret0, _ := ret[0].([]models.SearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchStoreInterfaceMockRecorder) Search(ctx, query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchStoreInterface)(nil).Search), ctx, query, limit)
+}
+
+// MockOperatorStoreInterface is a mock of OperatorStoreInterface interface.
+type MockOperatorStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperatorStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOperatorStoreInterfaceMockRecorder is the mock recorder for MockOperatorStoreInterface.
+type MockOperatorStoreInterfaceMockRecorder struct {
+	mock *MockOperatorStoreInterface
+}
+
+// NewMockOperatorStoreInterface creates a new mock instance.
+func NewMockOperatorStoreInterface(ctrl *gomock.Controller) *MockOperatorStoreInterface {
+	mock := &MockOperatorStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockOperatorStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperatorStoreInterface) EXPECT() *MockOperatorStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateOperator mocks base method.
+func (m *MockOperatorStoreInterface) CreateOperator(ctx context.Context, req models.OperatorRequest) (models.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOperator", ctx, req)
+	ret0, _ := ret[0].(models.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOperator indicates an expected call of CreateOperator.
+func (mr *MockOperatorStoreInterfaceMockRecorder) CreateOperator(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOperator", reflect.TypeOf((*MockOperatorStoreInterface)(nil).CreateOperator), ctx, req)
+}
+
+// GetOperatorByDomain mocks base method.
+func (m *MockOperatorStoreInterface) GetOperatorByDomain(ctx context.Context, domain string) (*models.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperatorByDomain", ctx, domain)
+	ret0, _ := ret[0].(*models.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperatorByDomain indicates an expected call of GetOperatorByDomain.
+func (mr *MockOperatorStoreInterfaceMockRecorder) GetOperatorByDomain(ctx, domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperatorByDomain", reflect.TypeOf((*MockOperatorStoreInterface)(nil).GetOperatorByDomain), ctx, domain)
+}
+
+// GetOperatorByID mocks base method.
+func (m *MockOperatorStoreInterface) GetOperatorByID(ctx context.Context, id string) (models.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperatorByID", ctx, id)
+	ret0, _ := ret[0].(models.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperatorByID indicates an expected call of GetOperatorByID.
+func (mr *MockOperatorStoreInterfaceMockRecorder) GetOperatorByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperatorByID", reflect.TypeOf((*MockOperatorStoreInterface)(nil).GetOperatorByID), ctx, id)
+}
+
+// ListOperators mocks base method.
+func (m *MockOperatorStoreInterface) ListOperators(ctx context.Context) ([]models.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOperators", ctx)
+	ret0, _ := ret[0].([]models.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOperators indicates an expected call of ListOperators.
+func (mr *MockOperatorStoreInterfaceMockRecorder) ListOperators(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperators", reflect.TypeOf((*MockOperatorStoreInterface)(nil).ListOperators), ctx)
+}
+
+// UpdateOperator mocks base method.
+func (m *MockOperatorStoreInterface) UpdateOperator(ctx context.Context, id string, req models.OperatorRequest) (models.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOperator", ctx, id, req)
+	ret0, _ := ret[0].(models.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOperator indicates an expected call of UpdateOperator.
+func (mr *MockOperatorStoreInterfaceMockRecorder) UpdateOperator(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOperator", reflect.TypeOf((*MockOperatorStoreInterface)(nil).UpdateOperator), ctx, id, req)
+}
+
+// MockNotificationStoreInterface is a mock of NotificationStoreInterface interface.
+type MockNotificationStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationStoreInterfaceMockRecorder is the mock recorder for MockNotificationStoreInterface.
+type MockNotificationStoreInterfaceMockRecorder struct {
+	mock *MockNotificationStoreInterface
+}
+
+// NewMockNotificationStoreInterface creates a new mock instance.
+func NewMockNotificationStoreInterface(ctrl *gomock.Controller) *MockNotificationStoreInterface {
+	mock := &MockNotificationStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockNotificationStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationStoreInterface) EXPECT() *MockNotificationStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockNotificationStoreInterface) CountUnread(ctx context.Context, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationStoreInterfaceMockRecorder) CountUnread(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotificationStoreInterface)(nil).CountUnread), ctx, userID)
+}
+
+// CreateNotification mocks base method.
+func (m *MockNotificationStoreInterface) CreateNotification(ctx context.Context, userID, subject, message string) (models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, userID, subject, message)
+	ret0, _ := ret[0].(models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockNotificationStoreInterfaceMockRecorder) CreateNotification(ctx, userID, subject, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockNotificationStoreInterface)(nil).CreateNotification), ctx, userID, subject, message)
+}
+
+// ListNotifications mocks base method.
+func (m *MockNotificationStoreInterface) ListNotifications(ctx context.Context, userID string, filter models.NotificationFilter, page models.PageRequest) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, userID, filter, page)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockNotificationStoreInterfaceMockRecorder) ListNotifications(ctx, userID, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockNotificationStoreInterface)(nil).ListNotifications), ctx, userID, filter, page)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationStoreInterface) MarkRead(ctx context.Context, id, userID string) (models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, id, userID)
+	ret0, _ := ret[0].(models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationStoreInterfaceMockRecorder) MarkRead(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationStoreInterface)(nil).MarkRead), ctx, id, userID)
+}
+
+// MockEmailTemplateStoreInterface is a mock of EmailTemplateStoreInterface interface.
+type MockEmailTemplateStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailTemplateStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailTemplateStoreInterfaceMockRecorder is the mock recorder for MockEmailTemplateStoreInterface.
+type MockEmailTemplateStoreInterfaceMockRecorder struct {
+	mock *MockEmailTemplateStoreInterface
+}
+
+// NewMockEmailTemplateStoreInterface creates a new mock instance.
+func NewMockEmailTemplateStoreInterface(ctrl *gomock.Controller) *MockEmailTemplateStoreInterface {
+	mock := &MockEmailTemplateStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockEmailTemplateStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailTemplateStoreInterface) EXPECT() *MockEmailTemplateStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddTemplateVersion mocks base method.
+func (m *MockEmailTemplateStoreInterface) AddTemplateVersion(ctx context.Context, key string, req models.EmailTemplateRequest, createdBy string) (models.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTemplateVersion", ctx, key, req, createdBy)
+	ret0, _ := ret[0].(models.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTemplateVersion indicates an expected call of AddTemplateVersion.
+func (mr *MockEmailTemplateStoreInterfaceMockRecorder) AddTemplateVersion(ctx, key, req, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTemplateVersion", reflect.TypeOf((*MockEmailTemplateStoreInterface)(nil).AddTemplateVersion), ctx, key, req, createdBy)
+}
+
+// CreateTemplate mocks base method.
+func (m *MockEmailTemplateStoreInterface) CreateTemplate(ctx context.Context, req models.EmailTemplateRequest, createdBy string) (models.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", ctx, req, createdBy)
+	ret0, _ := ret[0].(models.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockEmailTemplateStoreInterfaceMockRecorder) CreateTemplate(ctx, req, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockEmailTemplateStoreInterface)(nil).CreateTemplate), ctx, req, createdBy)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockEmailTemplateStoreInterface) DeleteTemplate(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockEmailTemplateStoreInterfaceMockRecorder) DeleteTemplate(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockEmailTemplateStoreInterface)(nil).DeleteTemplate), ctx, key)
+}
+
+// GetTemplate mocks base method.
+func (m *MockEmailTemplateStoreInterface) GetTemplate(ctx context.Context, key string) (models.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, key)
+	ret0, _ := ret[0].(models.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockEmailTemplateStoreInterfaceMockRecorder) GetTemplate(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockEmailTemplateStoreInterface)(nil).GetTemplate), ctx, key)
+}
+
+// ListTemplateVersions mocks base method.
+func (m *MockEmailTemplateStoreInterface) ListTemplateVersions(ctx context.Context, key string) ([]models.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplateVersions", ctx, key)
+	ret0, _ := ret[0].([]models.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplateVersions indicates an expected call of ListTemplateVersions.
+func (mr *MockEmailTemplateStoreInterfaceMockRecorder) ListTemplateVersions(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplateVersions", reflect.TypeOf((*MockEmailTemplateStoreInterface)(nil).ListTemplateVersions), ctx, key)
+}
+
+// ListTemplates mocks base method.
+func (m *MockEmailTemplateStoreInterface) ListTemplates(ctx context.Context) ([]models.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx)
+	ret0, _ := ret[0].([]models.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockEmailTemplateStoreInterfaceMockRecorder) ListTemplates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockEmailTemplateStoreInterface)(nil).ListTemplates), ctx)
+}
+
+// MockKYCStoreInterface is a mock of KYCStoreInterface interface.
+type MockKYCStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockKYCStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockKYCStoreInterfaceMockRecorder is the mock recorder for MockKYCStoreInterface.
+type MockKYCStoreInterfaceMockRecorder struct {
+	mock *MockKYCStoreInterface
+}
+
+// NewMockKYCStoreInterface creates a new mock instance.
+func NewMockKYCStoreInterface(ctrl *gomock.Controller) *MockKYCStoreInterface {
+	mock := &MockKYCStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockKYCStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKYCStoreInterface) EXPECT() *MockKYCStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetVerifiedKYC mocks base method.
+func (m *MockKYCStoreInterface) GetVerifiedKYC(ctx context.Context, userID string) (models.VerifiedKYC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerifiedKYC", ctx, userID)
+	ret0, _ := ret[0].(models.VerifiedKYC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerifiedKYC indicates an expected call of GetVerifiedKYC.
+func (mr *MockKYCStoreInterfaceMockRecorder) GetVerifiedKYC(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifiedKYC", reflect.TypeOf((*MockKYCStoreInterface)(nil).GetVerifiedKYC), ctx, userID)
+}
+
+// UpsertVerifiedKYC mocks base method.
+func (m *MockKYCStoreInterface) UpsertVerifiedKYC(ctx context.Context, userID, verifiedBy string, dateOfBirth, licenseIssuedOn time.Time) (models.VerifiedKYC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertVerifiedKYC", ctx, userID, verifiedBy, dateOfBirth, licenseIssuedOn)
+	ret0, _ := ret[0].(models.VerifiedKYC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertVerifiedKYC indicates an expected call of UpsertVerifiedKYC.
+func (mr *MockKYCStoreInterfaceMockRecorder) UpsertVerifiedKYC(ctx, userID, verifiedBy, dateOfBirth, licenseIssuedOn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVerifiedKYC", reflect.TypeOf((*MockKYCStoreInterface)(nil).UpsertVerifiedKYC), ctx, userID, verifiedBy, dateOfBirth, licenseIssuedOn)
+}
+
+// MockVacationStoreInterface is a mock of VacationStoreInterface interface.
+type MockVacationStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockVacationStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockVacationStoreInterfaceMockRecorder is the mock recorder for MockVacationStoreInterface.
+type MockVacationStoreInterfaceMockRecorder struct {
+	mock *MockVacationStoreInterface
+}
+
+// NewMockVacationStoreInterface creates a new mock instance.
+func NewMockVacationStoreInterface(ctrl *gomock.Controller) *MockVacationStoreInterface {
+	mock := &MockVacationStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockVacationStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVacationStoreInterface) EXPECT() *MockVacationStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateVacation mocks base method.
+func (m *MockVacationStoreInterface) CreateVacation(ctx context.Context, ownerID string, req models.VacationRequest) (models.OwnerVacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVacation", ctx, ownerID, req)
+	ret0, _ := ret[0].(models.OwnerVacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVacation indicates an expected call of CreateVacation.
+func (mr *MockVacationStoreInterfaceMockRecorder) CreateVacation(ctx, ownerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVacation", reflect.TypeOf((*MockVacationStoreInterface)(nil).CreateVacation), ctx, ownerID, req)
+}
+
+// DeleteVacation mocks base method.
+func (m *MockVacationStoreInterface) DeleteVacation(ctx context.Context, id, ownerID string) (models.OwnerVacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVacation", ctx, id, ownerID)
+	ret0, _ := ret[0].(models.OwnerVacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteVacation indicates an expected call of DeleteVacation.
+func (mr *MockVacationStoreInterfaceMockRecorder) DeleteVacation(ctx, id, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVacation", reflect.TypeOf((*MockVacationStoreInterface)(nil).DeleteVacation), ctx, id, ownerID)
+}
+
+// IsCarBlocked mocks base method.
+func (m *MockVacationStoreInterface) IsCarBlocked(ctx context.Context, carID string, start, end time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCarBlocked", ctx, carID, start, end)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCarBlocked indicates an expected call of IsCarBlocked.
+func (mr *MockVacationStoreInterfaceMockRecorder) IsCarBlocked(ctx, carID, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCarBlocked", reflect.TypeOf((*MockVacationStoreInterface)(nil).IsCarBlocked), ctx, carID, start, end)
+}
+
+// ListCarBlackouts mocks base method.
+func (m *MockVacationStoreInterface) ListCarBlackouts(ctx context.Context, carID string, start, end time.Time) ([]models.CarBlackout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCarBlackouts", ctx, carID, start, end)
+	ret0, _ := ret[0].([]models.CarBlackout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCarBlackouts indicates an expected call of ListCarBlackouts.
+func (mr *MockVacationStoreInterfaceMockRecorder) ListCarBlackouts(ctx, carID, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCarBlackouts", reflect.TypeOf((*MockVacationStoreInterface)(nil).ListCarBlackouts), ctx, carID, start, end)
+}
+
+// ListVacations mocks base method.
+func (m *MockVacationStoreInterface) ListVacations(ctx context.Context, ownerID string) ([]models.OwnerVacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVacations", ctx, ownerID)
+	ret0, _ := ret[0].([]models.OwnerVacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVacations indicates an expected call of ListVacations.
+func (mr *MockVacationStoreInterfaceMockRecorder) ListVacations(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVacations", reflect.TypeOf((*MockVacationStoreInterface)(nil).ListVacations), ctx, ownerID)
+}
+
+// MockFleetStoreInterface is a mock of FleetStoreInterface interface.
+type MockFleetStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFleetStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockFleetStoreInterfaceMockRecorder is the mock recorder for MockFleetStoreInterface.
+type MockFleetStoreInterfaceMockRecorder struct {
+	mock *MockFleetStoreInterface
+}
+
+// NewMockFleetStoreInterface creates a new mock instance.
+func NewMockFleetStoreInterface(ctrl *gomock.Controller) *MockFleetStoreInterface {
+	mock := &MockFleetStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockFleetStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFleetStoreInterface) EXPECT() *MockFleetStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddCar mocks base method.
+func (m *MockFleetStoreInterface) AddCar(ctx context.Context, id, ownerID, carID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCar", ctx, id, ownerID, carID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCar indicates an expected call of AddCar.
+func (mr *MockFleetStoreInterfaceMockRecorder) AddCar(ctx, id, ownerID, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCar", reflect.TypeOf((*MockFleetStoreInterface)(nil).AddCar), ctx, id, ownerID, carID)
+}
+
+// CreateFleet mocks base method.
+func (m *MockFleetStoreInterface) CreateFleet(ctx context.Context, ownerID string, req models.FleetRequest) (models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFleet", ctx, ownerID, req)
+	ret0, _ := ret[0].(models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFleet indicates an expected call of CreateFleet.
+func (mr *MockFleetStoreInterfaceMockRecorder) CreateFleet(ctx, ownerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFleet", reflect.TypeOf((*MockFleetStoreInterface)(nil).CreateFleet), ctx, ownerID, req)
+}
+
+// DeleteFleet mocks base method.
+func (m *MockFleetStoreInterface) DeleteFleet(ctx context.Context, id, ownerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFleet", ctx, id, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFleet indicates an expected call of DeleteFleet.
+func (mr *MockFleetStoreInterfaceMockRecorder) DeleteFleet(ctx, id, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFleet", reflect.TypeOf((*MockFleetStoreInterface)(nil).DeleteFleet), ctx, id, ownerID)
+}
+
+// GetFleet mocks base method.
+func (m *MockFleetStoreInterface) GetFleet(ctx context.Context, id, ownerID string) (models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFleet", ctx, id, ownerID)
+	ret0, _ := ret[0].(models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFleet indicates an expected call of GetFleet.
+func (mr *MockFleetStoreInterfaceMockRecorder) GetFleet(ctx, id, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFleet", reflect.TypeOf((*MockFleetStoreInterface)(nil).GetFleet), ctx, id, ownerID)
+}
+
+// GetFleetByCarID mocks base method.
+func (m *MockFleetStoreInterface) GetFleetByCarID(ctx context.Context, carID string) (models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFleetByCarID", ctx, carID)
+	ret0, _ := ret[0].(models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFleetByCarID indicates an expected call of GetFleetByCarID.
+func (mr *MockFleetStoreInterfaceMockRecorder) GetFleetByCarID(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFleetByCarID", reflect.TypeOf((*MockFleetStoreInterface)(nil).GetFleetByCarID), ctx, carID)
+}
+
+// ListFleets mocks base method.
+func (m *MockFleetStoreInterface) ListFleets(ctx context.Context, ownerID string) ([]models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFleets", ctx, ownerID)
+	ret0, _ := ret[0].([]models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFleets indicates an expected call of ListFleets.
+func (mr *MockFleetStoreInterfaceMockRecorder) ListFleets(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFleets", reflect.TypeOf((*MockFleetStoreInterface)(nil).ListFleets), ctx, ownerID)
+}
+
+// RemoveCar mocks base method.
+func (m *MockFleetStoreInterface) RemoveCar(ctx context.Context, id, ownerID, carID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCar", ctx, id, ownerID, carID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCar indicates an expected call of RemoveCar.
+func (mr *MockFleetStoreInterfaceMockRecorder) RemoveCar(ctx, id, ownerID, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCar", reflect.TypeOf((*MockFleetStoreInterface)(nil).RemoveCar), ctx, id, ownerID, carID)
+}
+
+// UpdateFleet mocks base method.
+func (m *MockFleetStoreInterface) UpdateFleet(ctx context.Context, id, ownerID string, req models.FleetRequest) (models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFleet", ctx, id, ownerID, req)
+	ret0, _ := ret[0].(models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFleet indicates an expected call of UpdateFleet.
+func (mr *MockFleetStoreInterfaceMockRecorder) UpdateFleet(ctx, id, ownerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFleet", reflect.TypeOf((*MockFleetStoreInterface)(nil).UpdateFleet), ctx, id, ownerID, req)
+}
+
+// MockFeatureStoreInterface is a mock of FeatureStoreInterface interface.
+type MockFeatureStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeatureStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockFeatureStoreInterfaceMockRecorder is the mock recorder for MockFeatureStoreInterface.
+type MockFeatureStoreInterfaceMockRecorder struct {
+	mock *MockFeatureStoreInterface
+}
+
+// NewMockFeatureStoreInterface creates a new mock instance.
+func NewMockFeatureStoreInterface(ctrl *gomock.Controller) *MockFeatureStoreInterface {
+	mock := &MockFeatureStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockFeatureStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeatureStoreInterface) EXPECT() *MockFeatureStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateFeature mocks base method.
+func (m *MockFeatureStoreInterface) CreateFeature(ctx context.Context, req models.FeatureRequest) (models.Feature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeature", ctx, req)
+	ret0, _ := ret[0].(models.Feature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeature indicates an expected call of CreateFeature.
+func (mr *MockFeatureStoreInterfaceMockRecorder) CreateFeature(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeature", reflect.TypeOf((*MockFeatureStoreInterface)(nil).CreateFeature), ctx, req)
+}
+
+// ListFeatures mocks base method.
+func (m *MockFeatureStoreInterface) ListFeatures(ctx context.Context) ([]models.Feature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeatures", ctx)
+	ret0, _ := ret[0].([]models.Feature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeatures indicates an expected call of ListFeatures.
+func (mr *MockFeatureStoreInterfaceMockRecorder) ListFeatures(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeatures", reflect.TypeOf((*MockFeatureStoreInterface)(nil).ListFeatures), ctx)
+}
+
+// ListNonCanonicalCarFeatures mocks base method.
+func (m *MockFeatureStoreInterface) ListNonCanonicalCarFeatures(ctx context.Context) ([]models.CarFeatures, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNonCanonicalCarFeatures", ctx)
+	ret0, _ := ret[0].([]models.CarFeatures)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNonCanonicalCarFeatures indicates an expected call of ListNonCanonicalCarFeatures.
+func (mr *MockFeatureStoreInterfaceMockRecorder) ListNonCanonicalCarFeatures(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNonCanonicalCarFeatures", reflect.TypeOf((*MockFeatureStoreInterface)(nil).ListNonCanonicalCarFeatures), ctx)
+}
+
+// UpdateCarFeatures mocks base method.
+func (m *MockFeatureStoreInterface) UpdateCarFeatures(ctx context.Context, carID string, features map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCarFeatures", ctx, carID, features)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCarFeatures indicates an expected call of UpdateCarFeatures.
+func (mr *MockFeatureStoreInterfaceMockRecorder) UpdateCarFeatures(ctx, carID, features any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCarFeatures", reflect.TypeOf((*MockFeatureStoreInterface)(nil).UpdateCarFeatures), ctx, carID, features)
+}
+
+// UpdateFeature mocks base method.
+func (m *MockFeatureStoreInterface) UpdateFeature(ctx context.Context, key string, req models.FeatureRequest) (models.Feature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFeature", ctx, key, req)
+	ret0, _ := ret[0].(models.Feature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFeature indicates an expected call of UpdateFeature.
+func (mr *MockFeatureStoreInterfaceMockRecorder) UpdateFeature(ctx, key, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFeature", reflect.TypeOf((*MockFeatureStoreInterface)(nil).UpdateFeature), ctx, key, req)
+}
+
+// MockCarAttributeStoreInterface is a mock of CarAttributeStoreInterface interface.
+type MockCarAttributeStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarAttributeStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCarAttributeStoreInterfaceMockRecorder is the mock recorder for MockCarAttributeStoreInterface.
+type MockCarAttributeStoreInterfaceMockRecorder struct {
+	mock *MockCarAttributeStoreInterface
+}
+
+// NewMockCarAttributeStoreInterface creates a new mock instance.
+func NewMockCarAttributeStoreInterface(ctrl *gomock.Controller) *MockCarAttributeStoreInterface {
+	mock := &MockCarAttributeStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockCarAttributeStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarAttributeStoreInterface) EXPECT() *MockCarAttributeStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateCarAttribute mocks base method.
+func (m *MockCarAttributeStoreInterface) CreateCarAttribute(ctx context.Context, req models.CarAttributeRequest) (models.CarAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCarAttribute", ctx, req)
+	ret0, _ := ret[0].(models.CarAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCarAttribute indicates an expected call of CreateCarAttribute.
+func (mr *MockCarAttributeStoreInterfaceMockRecorder) CreateCarAttribute(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCarAttribute", reflect.TypeOf((*MockCarAttributeStoreInterface)(nil).CreateCarAttribute), ctx, req)
+}
+
+// DeleteCarAttribute mocks base method.
+func (m *MockCarAttributeStoreInterface) DeleteCarAttribute(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCarAttribute", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCarAttribute indicates an expected call of DeleteCarAttribute.
+func (mr *MockCarAttributeStoreInterfaceMockRecorder) DeleteCarAttribute(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCarAttribute", reflect.TypeOf((*MockCarAttributeStoreInterface)(nil).DeleteCarAttribute), ctx, key)
+}
+
+// ListCarAttributes mocks base method.
+func (m *MockCarAttributeStoreInterface) ListCarAttributes(ctx context.Context) ([]models.CarAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCarAttributes", ctx)
+	ret0, _ := ret[0].([]models.CarAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCarAttributes indicates an expected call of ListCarAttributes.
+func (mr *MockCarAttributeStoreInterfaceMockRecorder) ListCarAttributes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCarAttributes", reflect.TypeOf((*MockCarAttributeStoreInterface)(nil).ListCarAttributes), ctx)
+}
+
+// UpdateCarAttribute mocks base method.
+func (m *MockCarAttributeStoreInterface) UpdateCarAttribute(ctx context.Context, key string, req models.CarAttributeRequest) (models.CarAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCarAttribute", ctx, key, req)
+	ret0, _ := ret[0].(models.CarAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCarAttribute indicates an expected call of UpdateCarAttribute.
+func (mr *MockCarAttributeStoreInterfaceMockRecorder) UpdateCarAttribute(ctx, key, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCarAttribute", reflect.TypeOf((*MockCarAttributeStoreInterface)(nil).UpdateCarAttribute), ctx, key, req)
+}
+
+// MockBrandStoreInterface is a mock of BrandStoreInterface interface.
+type MockBrandStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrandStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBrandStoreInterfaceMockRecorder is the mock recorder for MockBrandStoreInterface.
+type MockBrandStoreInterfaceMockRecorder struct {
+	mock *MockBrandStoreInterface
+}
+
+// NewMockBrandStoreInterface creates a new mock instance.
+func NewMockBrandStoreInterface(ctrl *gomock.Controller) *MockBrandStoreInterface {
+	mock := &MockBrandStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockBrandStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBrandStoreInterface) EXPECT() *MockBrandStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindBrandModel mocks base method.
+func (m *MockBrandStoreInterface) FindBrandModel(ctx context.Context, brand, model string) (*models.CarBrand, *models.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBrandModel", ctx, brand, model)
+	ret0, _ := ret[0].(*models.CarBrand)
+	ret1, _ := ret[1].(*models.CarModel)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindBrandModel indicates an expected call of FindBrandModel.
+func (mr *MockBrandStoreInterfaceMockRecorder) FindBrandModel(ctx, brand, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBrandModel", reflect.TypeOf((*MockBrandStoreInterface)(nil).FindBrandModel), ctx, brand, model)
+}
+
+// ListBrands mocks base method.
+func (m *MockBrandStoreInterface) ListBrands(ctx context.Context, prefix string, limit int) ([]models.CarBrand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBrands", ctx, prefix, limit)
+	ret0, _ := ret[0].([]models.CarBrand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrands indicates an expected call of ListBrands.
+func (mr *MockBrandStoreInterfaceMockRecorder) ListBrands(ctx, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrands", reflect.TypeOf((*MockBrandStoreInterface)(nil).ListBrands), ctx, prefix, limit)
+}
+
+// ListModels mocks base method.
+func (m *MockBrandStoreInterface) ListModels(ctx context.Context, brandID, prefix string, limit int) ([]models.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModels", ctx, brandID, prefix, limit)
+	ret0, _ := ret[0].([]models.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModels indicates an expected call of ListModels.
+func (mr *MockBrandStoreInterfaceMockRecorder) ListModels(ctx, brandID, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModels", reflect.TypeOf((*MockBrandStoreInterface)(nil).ListModels), ctx, brandID, prefix, limit)
+}
+
+// MockDisputeStoreInterface is a mock of DisputeStoreInterface interface.
+type MockDisputeStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisputeStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockDisputeStoreInterfaceMockRecorder is the mock recorder for MockDisputeStoreInterface.
+type MockDisputeStoreInterfaceMockRecorder struct {
+	mock *MockDisputeStoreInterface
+}
+
+// NewMockDisputeStoreInterface creates a new mock instance.
+func NewMockDisputeStoreInterface(ctrl *gomock.Controller) *MockDisputeStoreInterface {
+	mock := &MockDisputeStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockDisputeStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisputeStoreInterface) EXPECT() *MockDisputeStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddEvidence mocks base method.
+func (m *MockDisputeStoreInterface) AddEvidence(ctx context.Context, disputeID, addedBy uuid.UUID, req models.DisputeEvidenceRequest) (models.DisputeEvidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEvidence", ctx, disputeID, addedBy, req)
+	ret0, _ := ret[0].(models.DisputeEvidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEvidence indicates an expected call of AddEvidence.
+func (mr *MockDisputeStoreInterfaceMockRecorder) AddEvidence(ctx, disputeID, addedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvidence", reflect.TypeOf((*MockDisputeStoreInterface)(nil).AddEvidence), ctx, disputeID, addedBy, req)
+}
+
+// CreateDispute mocks base method.
+func (m *MockDisputeStoreInterface) CreateDispute(ctx context.Context, dispute models.Dispute) (models.Dispute, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDispute", ctx, dispute)
+	ret0, _ := ret[0].(models.Dispute)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateDispute indicates an expected call of CreateDispute.
+func (mr *MockDisputeStoreInterfaceMockRecorder) CreateDispute(ctx, dispute any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDispute", reflect.TypeOf((*MockDisputeStoreInterface)(nil).CreateDispute), ctx, dispute)
+}
+
+// GetDispute mocks base method.
+func (m *MockDisputeStoreInterface) GetDispute(ctx context.Context, id string) (models.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDispute", ctx, id)
+	ret0, _ := ret[0].(models.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDispute indicates an expected call of GetDispute.
+func (mr *MockDisputeStoreInterfaceMockRecorder) GetDispute(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispute", reflect.TypeOf((*MockDisputeStoreInterface)(nil).GetDispute), ctx, id)
+}
+
+// GetDisputeByRazorpayID mocks base method.
+func (m *MockDisputeStoreInterface) GetDisputeByRazorpayID(ctx context.Context, razorpayDisputeID string) (models.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisputeByRazorpayID", ctx, razorpayDisputeID)
+	ret0, _ := ret[0].(models.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDisputeByRazorpayID indicates an expected call of GetDisputeByRazorpayID.
+func (mr *MockDisputeStoreInterfaceMockRecorder) GetDisputeByRazorpayID(ctx, razorpayDisputeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisputeByRazorpayID", reflect.TypeOf((*MockDisputeStoreInterface)(nil).GetDisputeByRazorpayID), ctx, razorpayDisputeID)
+}
+
+// ListDisputes mocks base method.
+func (m *MockDisputeStoreInterface) ListDisputes(ctx context.Context, filter models.DisputeFilter) ([]models.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisputes", ctx, filter)
+	ret0, _ := ret[0].([]models.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDisputes indicates an expected call of ListDisputes.
+func (mr *MockDisputeStoreInterfaceMockRecorder) ListDisputes(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisputes", reflect.TypeOf((*MockDisputeStoreInterface)(nil).ListDisputes), ctx, filter)
+}
+
+// UpdateDisputeStatus mocks base method.
+func (m *MockDisputeStoreInterface) UpdateDisputeStatus(ctx context.Context, id uuid.UUID, status models.DisputeStatus, phase string, notes *string) (models.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDisputeStatus", ctx, id, status, phase, notes)
+	ret0, _ := ret[0].(models.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDisputeStatus indicates an expected call of UpdateDisputeStatus.
+func (mr *MockDisputeStoreInterfaceMockRecorder) UpdateDisputeStatus(ctx, id, status, phase, notes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDisputeStatus", reflect.TypeOf((*MockDisputeStoreInterface)(nil).UpdateDisputeStatus), ctx, id, status, phase, notes)
+}
+
+// MockSequenceStoreInterface is a mock of SequenceStoreInterface interface.
+type MockSequenceStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSequenceStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSequenceStoreInterfaceMockRecorder is the mock recorder for MockSequenceStoreInterface.
+type MockSequenceStoreInterfaceMockRecorder struct {
+	mock *MockSequenceStoreInterface
+}
+
+// NewMockSequenceStoreInterface creates a new mock instance.
+func NewMockSequenceStoreInterface(ctrl *gomock.Controller) *MockSequenceStoreInterface {
+	mock := &MockSequenceStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockSequenceStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSequenceStoreInterface) EXPECT() *MockSequenceStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetDocumentsByBookingID mocks base method.
+func (m *MockSequenceStoreInterface) GetDocumentsByBookingID(ctx context.Context, bookingID string) ([]models.FinancialDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocumentsByBookingID", ctx, bookingID)
+	ret0, _ := ret[0].([]models.FinancialDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocumentsByBookingID indicates an expected call of GetDocumentsByBookingID.
+func (mr *MockSequenceStoreInterfaceMockRecorder) GetDocumentsByBookingID(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocumentsByBookingID", reflect.TypeOf((*MockSequenceStoreInterface)(nil).GetDocumentsByBookingID), ctx, bookingID)
+}
+
+// IssueDocument mocks base method.
+func (m *MockSequenceStoreInterface) IssueDocument(ctx context.Context, doc models.FinancialDocument) (models.FinancialDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueDocument", ctx, doc)
+	ret0, _ := ret[0].(models.FinancialDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueDocument indicates an expected call of IssueDocument.
+func (mr *MockSequenceStoreInterfaceMockRecorder) IssueDocument(ctx, doc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueDocument", reflect.TypeOf((*MockSequenceStoreInterface)(nil).IssueDocument), ctx, doc)
+}
+
+// MockAdjustmentStoreInterface is a mock of AdjustmentStoreInterface interface.
+type MockAdjustmentStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdjustmentStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAdjustmentStoreInterfaceMockRecorder is the mock recorder for MockAdjustmentStoreInterface.
+type MockAdjustmentStoreInterfaceMockRecorder struct {
+	mock *MockAdjustmentStoreInterface
+}
+
+// NewMockAdjustmentStoreInterface creates a new mock instance.
+func NewMockAdjustmentStoreInterface(ctrl *gomock.Controller) *MockAdjustmentStoreInterface {
+	mock := &MockAdjustmentStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockAdjustmentStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdjustmentStoreInterface) EXPECT() *MockAdjustmentStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ClaimAdjustmentReview mocks base method.
+func (m *MockAdjustmentStoreInterface) ClaimAdjustmentReview(ctx context.Context, id, reviewedBy uuid.UUID, note string) (models.BookingAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimAdjustmentReview", ctx, id, reviewedBy, note)
+	ret0, _ := ret[0].(models.BookingAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimAdjustmentReview indicates an expected call of ClaimAdjustmentReview.
+func (mr *MockAdjustmentStoreInterfaceMockRecorder) ClaimAdjustmentReview(ctx, id, reviewedBy, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimAdjustmentReview", reflect.TypeOf((*MockAdjustmentStoreInterface)(nil).ClaimAdjustmentReview), ctx, id, reviewedBy, note)
+}
+
+// CompleteAdjustment mocks base method.
+func (m *MockAdjustmentStoreInterface) CompleteAdjustment(ctx context.Context, id uuid.UUID, status models.AdjustmentStatus, paymentID *uuid.UUID, documentNumber *string) (models.BookingAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAdjustment", ctx, id, status, paymentID, documentNumber)
+	ret0, _ := ret[0].(models.BookingAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteAdjustment indicates an expected call of CompleteAdjustment.
+func (mr *MockAdjustmentStoreInterfaceMockRecorder) CompleteAdjustment(ctx, id, status, paymentID, documentNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAdjustment", reflect.TypeOf((*MockAdjustmentStoreInterface)(nil).CompleteAdjustment), ctx, id, status, paymentID, documentNumber)
+}
+
+// CreateAdjustment mocks base method.
+func (m *MockAdjustmentStoreInterface) CreateAdjustment(ctx context.Context, adjustment models.BookingAdjustment) (models.BookingAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdjustment", ctx, adjustment)
+	ret0, _ := ret[0].(models.BookingAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdjustment indicates an expected call of CreateAdjustment.
+func (mr *MockAdjustmentStoreInterfaceMockRecorder) CreateAdjustment(ctx, adjustment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdjustment", reflect.TypeOf((*MockAdjustmentStoreInterface)(nil).CreateAdjustment), ctx, adjustment)
+}
+
+// GetAdjustment mocks base method.
+func (m *MockAdjustmentStoreInterface) GetAdjustment(ctx context.Context, id string) (models.BookingAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdjustment", ctx, id)
+	ret0, _ := ret[0].(models.BookingAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdjustment indicates an expected call of GetAdjustment.
+func (mr *MockAdjustmentStoreInterfaceMockRecorder) GetAdjustment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdjustment", reflect.TypeOf((*MockAdjustmentStoreInterface)(nil).GetAdjustment), ctx, id)
+}
+
+// ListAdjustments mocks base method.
+func (m *MockAdjustmentStoreInterface) ListAdjustments(ctx context.Context, filter models.AdjustmentFilter) ([]models.BookingAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdjustments", ctx, filter)
+	ret0, _ := ret[0].([]models.BookingAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdjustments indicates an expected call of ListAdjustments.
+func (mr *MockAdjustmentStoreInterfaceMockRecorder) ListAdjustments(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdjustments", reflect.TypeOf((*MockAdjustmentStoreInterface)(nil).ListAdjustments), ctx, filter)
+}
+
+// ListAdjustmentsByBookingID mocks base method.
+func (m *MockAdjustmentStoreInterface) ListAdjustmentsByBookingID(ctx context.Context, bookingID string) ([]models.BookingAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdjustmentsByBookingID", ctx, bookingID)
+	ret0, _ := ret[0].([]models.BookingAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdjustmentsByBookingID indicates an expected call of ListAdjustmentsByBookingID.
+func (mr *MockAdjustmentStoreInterfaceMockRecorder) ListAdjustmentsByBookingID(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdjustmentsByBookingID", reflect.TypeOf((*MockAdjustmentStoreInterface)(nil).ListAdjustmentsByBookingID), ctx, bookingID)
+}
+
+// ReleaseAdjustmentReview mocks base method.
+func (m *MockAdjustmentStoreInterface) ReleaseAdjustmentReview(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseAdjustmentReview", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseAdjustmentReview indicates an expected call of ReleaseAdjustmentReview.
+func (mr *MockAdjustmentStoreInterfaceMockRecorder) ReleaseAdjustmentReview(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseAdjustmentReview", reflect.TypeOf((*MockAdjustmentStoreInterface)(nil).ReleaseAdjustmentReview), ctx, id)
+}
+
+// MockStatementStoreInterface is a mock of StatementStoreInterface interface.
+type MockStatementStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementStoreInterfaceMockRecorder is the mock recorder for MockStatementStoreInterface.
+type MockStatementStoreInterfaceMockRecorder struct {
+	mock *MockStatementStoreInterface
+}
+
+// NewMockStatementStoreInterface creates a new mock instance.
+func NewMockStatementStoreInterface(ctrl *gomock.Controller) *MockStatementStoreInterface {
+	mock := &MockStatementStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockStatementStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementStoreInterface) EXPECT() *MockStatementStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ClaimPendingStatements mocks base method.
+func (m *MockStatementStoreInterface) ClaimPendingStatements(ctx context.Context, limit int, staleAfter time.Duration) ([]models.PaymentStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingStatements", ctx, limit, staleAfter)
+	ret0, _ := ret[0].([]models.PaymentStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingStatements indicates an expected call of ClaimPendingStatements.
+func (mr *MockStatementStoreInterfaceMockRecorder) ClaimPendingStatements(ctx, limit, staleAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingStatements", reflect.TypeOf((*MockStatementStoreInterface)(nil).ClaimPendingStatements), ctx, limit, staleAfter)
+}
+
+// CompleteStatement mocks base method.
+func (m *MockStatementStoreInterface) CompleteStatement(ctx context.Context, id uuid.UUID, status models.StatementStatus, content []byte, renderError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteStatement", ctx, id, status, content, renderError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteStatement indicates an expected call of CompleteStatement.
+func (mr *MockStatementStoreInterfaceMockRecorder) CompleteStatement(ctx, id, status, content, renderError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteStatement", reflect.TypeOf((*MockStatementStoreInterface)(nil).CompleteStatement), ctx, id, status, content, renderError)
+}
+
+// CreateStatement mocks base method.
+func (m *MockStatementStoreInterface) CreateStatement(ctx context.Context, statement models.PaymentStatement) (models.PaymentStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStatement", ctx, statement)
+	ret0, _ := ret[0].(models.PaymentStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStatement indicates an expected call of CreateStatement.
+func (mr *MockStatementStoreInterfaceMockRecorder) CreateStatement(ctx, statement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatement", reflect.TypeOf((*MockStatementStoreInterface)(nil).CreateStatement), ctx, statement)
+}
+
+// GetStatement mocks base method.
+func (m *MockStatementStoreInterface) GetStatement(ctx context.Context, id string) (models.PaymentStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", ctx, id)
+	ret0, _ := ret[0].(models.PaymentStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockStatementStoreInterfaceMockRecorder) GetStatement(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockStatementStoreInterface)(nil).GetStatement), ctx, id)
+}
+
+// GetStatementContent mocks base method.
+func (m *MockStatementStoreInterface) GetStatementContent(ctx context.Context, id string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatementContent", ctx, id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatementContent indicates an expected call of GetStatementContent.
+func (mr *MockStatementStoreInterfaceMockRecorder) GetStatementContent(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatementContent", reflect.TypeOf((*MockStatementStoreInterface)(nil).GetStatementContent), ctx, id)
+}
+
+// GetStatementPayments mocks base method.
+func (m *MockStatementStoreInterface) GetStatementPayments(ctx context.Context, userID string, from, to time.Time) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatementPayments", ctx, userID, from, to)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatementPayments indicates an expected call of GetStatementPayments.
+func (mr *MockStatementStoreInterfaceMockRecorder) GetStatementPayments(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatementPayments", reflect.TypeOf((*MockStatementStoreInterface)(nil).GetStatementPayments), ctx, userID, from, to)
+}
+
+// MockSettingStoreInterface is a mock of SettingStoreInterface interface.
+type MockSettingStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettingStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSettingStoreInterfaceMockRecorder is the mock recorder for MockSettingStoreInterface.
+type MockSettingStoreInterfaceMockRecorder struct {
+	mock *MockSettingStoreInterface
+}
+
+// NewMockSettingStoreInterface creates a new mock instance.
+func NewMockSettingStoreInterface(ctrl *gomock.Controller) *MockSettingStoreInterface {
+	mock := &MockSettingStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockSettingStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettingStoreInterface) EXPECT() *MockSettingStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetSetting mocks base method.
+func (m *MockSettingStoreInterface) GetSetting(ctx context.Context, key string) (models.Setting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSetting", ctx, key)
+	ret0, _ := ret[0].(models.Setting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSetting indicates an expected call of GetSetting.
+func (mr *MockSettingStoreInterfaceMockRecorder) GetSetting(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetting", reflect.TypeOf((*MockSettingStoreInterface)(nil).GetSetting), ctx, key)
+}
+
+// PutSetting mocks base method.
+func (m *MockSettingStoreInterface) PutSetting(ctx context.Context, key string, value json.RawMessage) (models.Setting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutSetting", ctx, key, value)
+	ret0, _ := ret[0].(models.Setting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutSetting indicates an expected call of PutSetting.
+func (mr *MockSettingStoreInterfaceMockRecorder) PutSetting(ctx, key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSetting", reflect.TypeOf((*MockSettingStoreInterface)(nil).PutSetting), ctx, key, value)
+}
+
+// MockFeaturedStoreInterface is a mock of FeaturedStoreInterface interface.
+type MockFeaturedStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeaturedStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockFeaturedStoreInterfaceMockRecorder is the mock recorder for MockFeaturedStoreInterface.
+type MockFeaturedStoreInterfaceMockRecorder struct {
+	mock *MockFeaturedStoreInterface
+}
+
+// NewMockFeaturedStoreInterface creates a new mock instance.
+func NewMockFeaturedStoreInterface(ctrl *gomock.Controller) *MockFeaturedStoreInterface {
+	mock := &MockFeaturedStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockFeaturedStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeaturedStoreInterface) EXPECT() *MockFeaturedStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateFeatured mocks base method.
+func (m *MockFeaturedStoreInterface) CreateFeatured(ctx context.Context, carID, ownerID uuid.UUID, req models.FeaturedRequest, amount float64, pendingTTL time.Duration) (models.FeaturedListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeatured", ctx, carID, ownerID, req, amount, pendingTTL)
+	ret0, _ := ret[0].(models.FeaturedListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeatured indicates an expected call of CreateFeatured.
+func (mr *MockFeaturedStoreInterfaceMockRecorder) CreateFeatured(ctx, carID, ownerID, req, amount, pendingTTL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeatured", reflect.TypeOf((*MockFeaturedStoreInterface)(nil).CreateFeatured), ctx, carID, ownerID, req, amount, pendingTTL)
+}
+
+// GetFeatured mocks base method.
+func (m *MockFeaturedStoreInterface) GetFeatured(ctx context.Context, id string) (models.FeaturedListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeatured", ctx, id)
+	ret0, _ := ret[0].(models.FeaturedListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeatured indicates an expected call of GetFeatured.
+func (mr *MockFeaturedStoreInterfaceMockRecorder) GetFeatured(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeatured", reflect.TypeOf((*MockFeaturedStoreInterface)(nil).GetFeatured), ctx, id)
+}
+
+// ListFeaturedByOwner mocks base method.
+func (m *MockFeaturedStoreInterface) ListFeaturedByOwner(ctx context.Context, ownerID string) ([]models.FeaturedListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeaturedByOwner", ctx, ownerID)
+	ret0, _ := ret[0].([]models.FeaturedListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeaturedByOwner indicates an expected call of ListFeaturedByOwner.
+func (mr *MockFeaturedStoreInterfaceMockRecorder) ListFeaturedByOwner(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeaturedByOwner", reflect.TypeOf((*MockFeaturedStoreInterface)(nil).ListFeaturedByOwner), ctx, ownerID)
+}
+
+// RecordClick mocks base method.
+func (m *MockFeaturedStoreInterface) RecordClick(ctx context.Context, carID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClick", ctx, carID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClick indicates an expected call of RecordClick.
+func (mr *MockFeaturedStoreInterfaceMockRecorder) RecordClick(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClick", reflect.TypeOf((*MockFeaturedStoreInterface)(nil).RecordClick), ctx, carID)
+}
+
+// RecordImpressions mocks base method.
+func (m *MockFeaturedStoreInterface) RecordImpressions(ctx context.Context, carIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordImpressions", ctx, carIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordImpressions indicates an expected call of RecordImpressions.
+func (mr *MockFeaturedStoreInterfaceMockRecorder) RecordImpressions(ctx, carIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordImpressions", reflect.TypeOf((*MockFeaturedStoreInterface)(nil).RecordImpressions), ctx, carIDs)
+}
+
+// SetFeaturedOrder mocks base method.
+func (m *MockFeaturedStoreInterface) SetFeaturedOrder(ctx context.Context, id uuid.UUID, orderID string) (models.FeaturedListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFeaturedOrder", ctx, id, orderID)
+	ret0, _ := ret[0].(models.FeaturedListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetFeaturedOrder indicates an expected call of SetFeaturedOrder.
+func (mr *MockFeaturedStoreInterfaceMockRecorder) SetFeaturedOrder(ctx, id, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeaturedOrder", reflect.TypeOf((*MockFeaturedStoreInterface)(nil).SetFeaturedOrder), ctx, id, orderID)
+}
+
+// SettleFeatured mocks base method.
+func (m *MockFeaturedStoreInterface) SettleFeatured(ctx context.Context, id uuid.UUID, status models.FeaturedStatus, paymentID string) (models.FeaturedListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleFeatured", ctx, id, status, paymentID)
+	ret0, _ := ret[0].(models.FeaturedListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleFeatured indicates an expected call of SettleFeatured.
+func (mr *MockFeaturedStoreInterfaceMockRecorder) SettleFeatured(ctx, id, status, paymentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleFeatured", reflect.TypeOf((*MockFeaturedStoreInterface)(nil).SettleFeatured), ctx, id, status, paymentID)
+}
+
+// MockBlockStoreInterface is a mock of BlockStoreInterface interface.
+type MockBlockStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBlockStoreInterfaceMockRecorder is the mock recorder for MockBlockStoreInterface.
+type MockBlockStoreInterfaceMockRecorder struct {
+	mock *MockBlockStoreInterface
+}
+
+// NewMockBlockStoreInterface creates a new mock instance.
+func NewMockBlockStoreInterface(ctrl *gomock.Controller) *MockBlockStoreInterface {
+	mock := &MockBlockStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockBlockStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockStoreInterface) EXPECT() *MockBlockStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateBlock mocks base method.
+func (m *MockBlockStoreInterface) CreateBlock(ctx context.Context, blockerID, blockedID string) (models.UserBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBlock", ctx, blockerID, blockedID)
+	ret0, _ := ret[0].(models.UserBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBlock indicates an expected call of CreateBlock.
+func (mr *MockBlockStoreInterfaceMockRecorder) CreateBlock(ctx, blockerID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlock", reflect.TypeOf((*MockBlockStoreInterface)(nil).CreateBlock), ctx, blockerID, blockedID)
+}
+
+// DeleteBlock mocks base method.
+func (m *MockBlockStoreInterface) DeleteBlock(ctx context.Context, blockerID, blockedID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlock", ctx, blockerID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlock indicates an expected call of DeleteBlock.
+func (mr *MockBlockStoreInterfaceMockRecorder) DeleteBlock(ctx, blockerID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlock", reflect.TypeOf((*MockBlockStoreInterface)(nil).DeleteBlock), ctx, blockerID, blockedID)
+}
+
+// IsBlocked mocks base method.
+func (m *MockBlockStoreInterface) IsBlocked(ctx context.Context, userID, otherID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlocked", ctx, userID, otherID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockBlockStoreInterfaceMockRecorder) IsBlocked(ctx, userID, otherID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockBlockStoreInterface)(nil).IsBlocked), ctx, userID, otherID)
+}
+
+// ListBlocks mocks base method.
+func (m *MockBlockStoreInterface) ListBlocks(ctx context.Context, blockerID string) ([]models.UserBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBlocks", ctx, blockerID)
+	ret0, _ := ret[0].([]models.UserBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBlocks indicates an expected call of ListBlocks.
+func (mr *MockBlockStoreInterfaceMockRecorder) ListBlocks(ctx, blockerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlocks", reflect.TypeOf((*MockBlockStoreInterface)(nil).ListBlocks), ctx, blockerID)
+}
+
+// MockMaintenanceStoreInterface is a mock of MaintenanceStoreInterface interface.
+type MockMaintenanceStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockMaintenanceStoreInterfaceMockRecorder is the mock recorder for MockMaintenanceStoreInterface.
+type MockMaintenanceStoreInterfaceMockRecorder struct {
+	mock *MockMaintenanceStoreInterface
+}
+
+// NewMockMaintenanceStoreInterface creates a new mock instance.
+func NewMockMaintenanceStoreInterface(ctrl *gomock.Controller) *MockMaintenanceStoreInterface {
+	mock := &MockMaintenanceStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceStoreInterface) EXPECT() *MockMaintenanceStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ClaimQueuedJobs mocks base method.
+func (m *MockMaintenanceStoreInterface) ClaimQueuedJobs(ctx context.Context, limit int, staleAfter time.Duration) ([]models.MaintenanceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimQueuedJobs", ctx, limit, staleAfter)
+	ret0, _ := ret[0].([]models.MaintenanceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimQueuedJobs indicates an expected call of ClaimQueuedJobs.
+func (mr *MockMaintenanceStoreInterfaceMockRecorder) ClaimQueuedJobs(ctx, limit, staleAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimQueuedJobs", reflect.TypeOf((*MockMaintenanceStoreInterface)(nil).ClaimQueuedJobs), ctx, limit, staleAfter)
+}
+
+// CompleteJob mocks base method.
+func (m *MockMaintenanceStoreInterface) CompleteJob(ctx context.Context, id uuid.UUID, status models.MaintenanceJobStatus, result models.MaintenanceResult, jobError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteJob", ctx, id, status, result, jobError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteJob indicates an expected call of CompleteJob.
+func (mr *MockMaintenanceStoreInterfaceMockRecorder) CompleteJob(ctx, id, status, result, jobError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteJob", reflect.TypeOf((*MockMaintenanceStoreInterface)(nil).CompleteJob), ctx, id, status, result, jobError)
+}
+
+// CreateJob mocks base method.
+func (m *MockMaintenanceStoreInterface) CreateJob(ctx context.Context, job models.MaintenanceJob) (models.MaintenanceJob, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", ctx, job)
+	ret0, _ := ret[0].(models.MaintenanceJob)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockMaintenanceStoreInterfaceMockRecorder) CreateJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockMaintenanceStoreInterface)(nil).CreateJob), ctx, job)
+}
+
+// GetJob mocks base method.
+func (m *MockMaintenanceStoreInterface) GetJob(ctx context.Context, id string) (models.MaintenanceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, id)
+	ret0, _ := ret[0].(models.MaintenanceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockMaintenanceStoreInterfaceMockRecorder) GetJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockMaintenanceStoreInterface)(nil).GetJob), ctx, id)
+}
+
+// ListJobs mocks base method.
+func (m *MockMaintenanceStoreInterface) ListJobs(ctx context.Context, task models.MaintenanceTask, limit int) ([]models.MaintenanceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobs", ctx, task, limit)
+	ret0, _ := ret[0].([]models.MaintenanceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobs indicates an expected call of ListJobs.
+func (mr *MockMaintenanceStoreInterfaceMockRecorder) ListJobs(ctx, task, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockMaintenanceStoreInterface)(nil).ListJobs), ctx, task, limit)
+}
+
+// MockMigrationStoreInterface is a mock of MigrationStoreInterface interface.
+type MockMigrationStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMigrationStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockMigrationStoreInterfaceMockRecorder is the mock recorder for MockMigrationStoreInterface.
+type MockMigrationStoreInterfaceMockRecorder struct {
+	mock *MockMigrationStoreInterface
+}
+
+// NewMockMigrationStoreInterface creates a new mock instance.
+func NewMockMigrationStoreInterface(ctrl *gomock.Controller) *MockMigrationStoreInterface {
+	mock := &MockMigrationStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockMigrationStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMigrationStoreInterface) EXPECT() *MockMigrationStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockMigrationStoreInterface) Apply(ctx context.Context, name, script string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, name, script)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockMigrationStoreInterfaceMockRecorder) Apply(ctx, name, script any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockMigrationStoreInterface)(nil).Apply), ctx, name, script)
+}
+
+// GetApplied mocks base method.
+func (m *MockMigrationStoreInterface) GetApplied(ctx context.Context) (map[string]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplied", ctx)
+	ret0, _ := ret[0].(map[string]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplied indicates an expected call of GetApplied.
+func (mr *MockMigrationStoreInterfaceMockRecorder) GetApplied(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplied", reflect.TypeOf((*MockMigrationStoreInterface)(nil).GetApplied), ctx)
+}
+
+// MockDebugCaptureStoreInterface is a mock of DebugCaptureStoreInterface interface.
+type MockDebugCaptureStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDebugCaptureStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockDebugCaptureStoreInterfaceMockRecorder is the mock recorder for MockDebugCaptureStoreInterface.
+type MockDebugCaptureStoreInterfaceMockRecorder struct {
+	mock *MockDebugCaptureStoreInterface
+}
+
+// NewMockDebugCaptureStoreInterface creates a new mock instance.
+func NewMockDebugCaptureStoreInterface(ctrl *gomock.Controller) *MockDebugCaptureStoreInterface {
+	mock := &MockDebugCaptureStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockDebugCaptureStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDebugCaptureStoreInterface) EXPECT() *MockDebugCaptureStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateDebugCapture mocks base method.
+func (m *MockDebugCaptureStoreInterface) CreateDebugCapture(ctx context.Context, capture models.DebugCapture) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDebugCapture", ctx, capture)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDebugCapture indicates an expected call of CreateDebugCapture.
+func (mr *MockDebugCaptureStoreInterfaceMockRecorder) CreateDebugCapture(ctx, capture any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDebugCapture", reflect.TypeOf((*MockDebugCaptureStoreInterface)(nil).CreateDebugCapture), ctx, capture)
+}
+
+// DeleteExpiredDebugCaptures mocks base method.
+func (m *MockDebugCaptureStoreInterface) DeleteExpiredDebugCaptures(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredDebugCaptures", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredDebugCaptures indicates an expected call of DeleteExpiredDebugCaptures.
+func (mr *MockDebugCaptureStoreInterfaceMockRecorder) DeleteExpiredDebugCaptures(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredDebugCaptures", reflect.TypeOf((*MockDebugCaptureStoreInterface)(nil).DeleteExpiredDebugCaptures), ctx)
+}
+
+// GetDebugCaptureByID mocks base method.
+func (m *MockDebugCaptureStoreInterface) GetDebugCaptureByID(ctx context.Context, id string) (models.DebugCapture, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDebugCaptureByID", ctx, id)
+	ret0, _ := ret[0].(models.DebugCapture)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDebugCaptureByID indicates an expected call of GetDebugCaptureByID.
+func (mr *MockDebugCaptureStoreInterfaceMockRecorder) GetDebugCaptureByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDebugCaptureByID", reflect.TypeOf((*MockDebugCaptureStoreInterface)(nil).GetDebugCaptureByID), ctx, id)
+}
+
+// ListDebugCaptures mocks base method.
+func (m *MockDebugCaptureStoreInterface) ListDebugCaptures(ctx context.Context, filter models.DebugCaptureFilter, page models.PageRequest) ([]models.DebugCapture, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDebugCaptures", ctx, filter, page)
+	ret0, _ := ret[0].([]models.DebugCapture)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDebugCaptures indicates an expected call of ListDebugCaptures.
+func (mr *MockDebugCaptureStoreInterfaceMockRecorder) ListDebugCaptures(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDebugCaptures", reflect.TypeOf((*MockDebugCaptureStoreInterface)(nil).ListDebugCaptures), ctx, filter, page)
+}
+
+// MockLoadTestStoreInterface is a mock of LoadTestStoreInterface interface.
+type MockLoadTestStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoadTestStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockLoadTestStoreInterfaceMockRecorder is the mock recorder for MockLoadTestStoreInterface.
+type MockLoadTestStoreInterfaceMockRecorder struct {
+	mock *MockLoadTestStoreInterface
+}
+
+// NewMockLoadTestStoreInterface creates a new mock instance.
+func NewMockLoadTestStoreInterface(ctrl *gomock.Controller) *MockLoadTestStoreInterface {
+	mock := &MockLoadTestStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockLoadTestStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoadTestStoreInterface) EXPECT() *MockLoadTestStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateLoadTestRun mocks base method.
+func (m *MockLoadTestStoreInterface) CreateLoadTestRun(ctx context.Context, run models.LoadTestRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoadTestRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLoadTestRun indicates an expected call of CreateLoadTestRun.
+func (mr *MockLoadTestStoreInterfaceMockRecorder) CreateLoadTestRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadTestRun", reflect.TypeOf((*MockLoadTestStoreInterface)(nil).CreateLoadTestRun), ctx, run)
+}
+
+// DeleteExpiredLoadTestRuns mocks base method.
+func (m *MockLoadTestStoreInterface) DeleteExpiredLoadTestRuns(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredLoadTestRuns", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredLoadTestRuns indicates an expected call of DeleteExpiredLoadTestRuns.
+func (mr *MockLoadTestStoreInterfaceMockRecorder) DeleteExpiredLoadTestRuns(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredLoadTestRuns", reflect.TypeOf((*MockLoadTestStoreInterface)(nil).DeleteExpiredLoadTestRuns), ctx)
+}
+
+// DeleteLoadTestRun mocks base method.
+func (m *MockLoadTestStoreInterface) DeleteLoadTestRun(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoadTestRun", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoadTestRun indicates an expected call of DeleteLoadTestRun.
+func (mr *MockLoadTestStoreInterfaceMockRecorder) DeleteLoadTestRun(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadTestRun", reflect.TypeOf((*MockLoadTestStoreInterface)(nil).DeleteLoadTestRun), ctx, id)
+}
+
+// MockCarImageUploadStoreInterface is a mock of CarImageUploadStoreInterface interface.
+type MockCarImageUploadStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarImageUploadStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCarImageUploadStoreInterfaceMockRecorder is the mock recorder for MockCarImageUploadStoreInterface.
+type MockCarImageUploadStoreInterfaceMockRecorder struct {
+	mock *MockCarImageUploadStoreInterface
+}
+
+// NewMockCarImageUploadStoreInterface creates a new mock instance.
+func NewMockCarImageUploadStoreInterface(ctrl *gomock.Controller) *MockCarImageUploadStoreInterface {
+	mock := &MockCarImageUploadStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockCarImageUploadStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarImageUploadStoreInterface) EXPECT() *MockCarImageUploadStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AttachCarImageUpload mocks base method.
+func (m *MockCarImageUploadStoreInterface) AttachCarImageUpload(ctx context.Context, id uuid.UUID, url string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachCarImageUpload", ctx, id, url)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachCarImageUpload indicates an expected call of AttachCarImageUpload.
+func (mr *MockCarImageUploadStoreInterfaceMockRecorder) AttachCarImageUpload(ctx, id, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachCarImageUpload", reflect.TypeOf((*MockCarImageUploadStoreInterface)(nil).AttachCarImageUpload), ctx, id, url)
+}
+
+// ClaimDueCarImageUploads mocks base method.
+func (m *MockCarImageUploadStoreInterface) ClaimDueCarImageUploads(ctx context.Context, limit int, staleAfter time.Duration) ([]models.CarImageUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueCarImageUploads", ctx, limit, staleAfter)
+	ret0, _ := ret[0].([]models.CarImageUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueCarImageUploads indicates an expected call of ClaimDueCarImageUploads.
+func (mr *MockCarImageUploadStoreInterfaceMockRecorder) ClaimDueCarImageUploads(ctx, limit, staleAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueCarImageUploads", reflect.TypeOf((*MockCarImageUploadStoreInterface)(nil).ClaimDueCarImageUploads), ctx, limit, staleAfter)
+}
+
+// CreateCarImageUploads mocks base method.
+func (m *MockCarImageUploadStoreInterface) CreateCarImageUploads(ctx context.Context, carID uuid.UUID, images []string) ([]models.CarImageUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCarImageUploads", ctx, carID, images)
+	ret0, _ := ret[0].([]models.CarImageUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCarImageUploads indicates an expected call of CreateCarImageUploads.
+func (mr *MockCarImageUploadStoreInterfaceMockRecorder) CreateCarImageUploads(ctx, carID, images any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCarImageUploads", reflect.TypeOf((*MockCarImageUploadStoreInterface)(nil).CreateCarImageUploads), ctx, carID, images)
+}
+
+// FailCarImageUpload mocks base method.
+func (m *MockCarImageUploadStoreInterface) FailCarImageUpload(ctx context.Context, id uuid.UUID, uploadError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailCarImageUpload", ctx, id, uploadError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailCarImageUpload indicates an expected call of FailCarImageUpload.
+func (mr *MockCarImageUploadStoreInterfaceMockRecorder) FailCarImageUpload(ctx, id, uploadError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailCarImageUpload", reflect.TypeOf((*MockCarImageUploadStoreInterface)(nil).FailCarImageUpload), ctx, id, uploadError)
+}
+
+// ListCarImageUploads mocks base method.
+func (m *MockCarImageUploadStoreInterface) ListCarImageUploads(ctx context.Context, carID string) ([]models.CarImageUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCarImageUploads", ctx, carID)
+	ret0, _ := ret[0].([]models.CarImageUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCarImageUploads indicates an expected call of ListCarImageUploads.
+func (mr *MockCarImageUploadStoreInterfaceMockRecorder) ListCarImageUploads(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCarImageUploads", reflect.TypeOf((*MockCarImageUploadStoreInterface)(nil).ListCarImageUploads), ctx, carID)
+}
+
+// RescheduleCarImageUpload mocks base method.
+func (m *MockCarImageUploadStoreInterface) RescheduleCarImageUpload(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, uploadError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleCarImageUpload", ctx, id, nextAttemptAt, uploadError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleCarImageUpload indicates an expected call of RescheduleCarImageUpload.
+func (mr *MockCarImageUploadStoreInterfaceMockRecorder) RescheduleCarImageUpload(ctx, id, nextAttemptAt, uploadError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleCarImageUpload", reflect.TypeOf((*MockCarImageUploadStoreInterface)(nil).RescheduleCarImageUpload), ctx, id, nextAttemptAt, uploadError)
+}
+
+// MockAccountingStoreInterface is a mock of AccountingStoreInterface interface.
+type MockAccountingStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountingStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountingStoreInterfaceMockRecorder is the mock recorder for MockAccountingStoreInterface.
+type MockAccountingStoreInterfaceMockRecorder struct {
+	mock *MockAccountingStoreInterface
+}
+
+// NewMockAccountingStoreInterface creates a new mock instance.
+func NewMockAccountingStoreInterface(ctrl *gomock.Controller) *MockAccountingStoreInterface {
+	mock := &MockAccountingStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockAccountingStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountingStoreInterface) EXPECT() *MockAccountingStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetLedgerEntries mocks base method.
+func (m *MockAccountingStoreInterface) GetLedgerEntries(ctx context.Context, from, to time.Time) ([]models.AccountingEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLedgerEntries", ctx, from, to)
+	ret0, _ := ret[0].([]models.AccountingEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLedgerEntries indicates an expected call of GetLedgerEntries.
+func (mr *MockAccountingStoreInterfaceMockRecorder) GetLedgerEntries(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerEntries", reflect.TypeOf((*MockAccountingStoreInterface)(nil).GetLedgerEntries), ctx, from, to)
+}
+
+// MockValuationStoreInterface is a mock of ValuationStoreInterface interface.
+type MockValuationStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockValuationStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockValuationStoreInterfaceMockRecorder is the mock recorder for MockValuationStoreInterface.
+type MockValuationStoreInterfaceMockRecorder struct {
+	mock *MockValuationStoreInterface
+}
+
+// NewMockValuationStoreInterface creates a new mock instance.
+func NewMockValuationStoreInterface(ctrl *gomock.Controller) *MockValuationStoreInterface {
+	mock := &MockValuationStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockValuationStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValuationStoreInterface) EXPECT() *MockValuationStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ListValuationCars mocks base method.
+func (m *MockValuationStoreInterface) ListValuationCars(ctx context.Context, ownerID string) ([]models.CarValuation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListValuationCars", ctx, ownerID)
+	ret0, _ := ret[0].([]models.CarValuation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListValuationCars indicates an expected call of ListValuationCars.
+func (mr *MockValuationStoreInterfaceMockRecorder) ListValuationCars(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListValuationCars", reflect.TypeOf((*MockValuationStoreInterface)(nil).ListValuationCars), ctx, ownerID)
+}
+
+// PutCarPurchase mocks base method.
+func (m *MockValuationStoreInterface) PutCarPurchase(ctx context.Context, carID, ownerID string, purchasePrice float64, purchasedOn *time.Time) (models.CarPurchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutCarPurchase", ctx, carID, ownerID, purchasePrice, purchasedOn)
+	ret0, _ := ret[0].(models.CarPurchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutCarPurchase indicates an expected call of PutCarPurchase.
+func (mr *MockValuationStoreInterfaceMockRecorder) PutCarPurchase(ctx, carID, ownerID, purchasePrice, purchasedOn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutCarPurchase", reflect.TypeOf((*MockValuationStoreInterface)(nil).PutCarPurchase), ctx, carID, ownerID, purchasePrice, purchasedOn)
+}
+
+// MockPasswordResetStoreInterface is a mock of PasswordResetStoreInterface interface.
+type MockPasswordResetStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordResetStoreInterfaceMockRecorder is the mock recorder for MockPasswordResetStoreInterface.
+type MockPasswordResetStoreInterfaceMockRecorder struct {
+	mock *MockPasswordResetStoreInterface
+}
+
+// NewMockPasswordResetStoreInterface creates a new mock instance.
+func NewMockPasswordResetStoreInterface(ctrl *gomock.Controller) *MockPasswordResetStoreInterface {
+	mock := &MockPasswordResetStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetStoreInterface) EXPECT() *MockPasswordResetStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountPasswordResetTokens mocks base method.
+func (m *MockPasswordResetStoreInterface) CountPasswordResetTokens(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPasswordResetTokens", ctx, userID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPasswordResetTokens indicates an expected call of CountPasswordResetTokens.
+func (mr *MockPasswordResetStoreInterfaceMockRecorder) CountPasswordResetTokens(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPasswordResetTokens", reflect.TypeOf((*MockPasswordResetStoreInterface)(nil).CountPasswordResetTokens), ctx, userID, since)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockPasswordResetStoreInterface) CreatePasswordResetToken(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (models.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", ctx, userID, tokenHash, expiresAt)
+	ret0, _ := ret[0].(models.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockPasswordResetStoreInterfaceMockRecorder) CreatePasswordResetToken(ctx, userID, tokenHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockPasswordResetStoreInterface)(nil).CreatePasswordResetToken), ctx, userID, tokenHash, expiresAt)
+}
+
+// ResetPassword mocks base method.
+func (m *MockPasswordResetStoreInterface) ResetPassword(ctx context.Context, tokenHash, password string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, tokenHash, password)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockPasswordResetStoreInterfaceMockRecorder) ResetPassword(ctx, tokenHash, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockPasswordResetStoreInterface)(nil).ResetPassword), ctx, tokenHash, password)
+}
+
+// MockRefreshTokenStoreInterface is a mock of RefreshTokenStoreInterface interface.
+type MockRefreshTokenStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockRefreshTokenStoreInterfaceMockRecorder is the mock recorder for MockRefreshTokenStoreInterface.
+type MockRefreshTokenStoreInterfaceMockRecorder struct {
+	mock *MockRefreshTokenStoreInterface
+}
+
+// NewMockRefreshTokenStoreInterface creates a new mock instance.
+func NewMockRefreshTokenStoreInterface(ctrl *gomock.Controller) *MockRefreshTokenStoreInterface {
+	mock := &MockRefreshTokenStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenStoreInterface) EXPECT() *MockRefreshTokenStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MockRefreshTokenStoreInterface) CreateRefreshToken(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, userID, tokenHash, expiresAt)
+	ret0, _ := ret[0].(models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockRefreshTokenStoreInterfaceMockRecorder) CreateRefreshToken(ctx, userID, tokenHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockRefreshTokenStoreInterface)(nil).CreateRefreshToken), ctx, userID, tokenHash, expiresAt)
+}
+
+// GetRefreshToken mocks base method.
+func (m *MockRefreshTokenStoreInterface) GetRefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshToken", ctx, tokenHash)
+	ret0, _ := ret[0].(models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshToken indicates an expected call of GetRefreshToken.
+func (mr *MockRefreshTokenStoreInterfaceMockRecorder) GetRefreshToken(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshToken", reflect.TypeOf((*MockRefreshTokenStoreInterface)(nil).GetRefreshToken), ctx, tokenHash)
+}
+
+// RevokeRefreshTokenFamily mocks base method.
+func (m *MockRefreshTokenStoreInterface) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokenFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokenFamily indicates an expected call of RevokeRefreshTokenFamily.
+func (mr *MockRefreshTokenStoreInterfaceMockRecorder) RevokeRefreshTokenFamily(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokenFamily", reflect.TypeOf((*MockRefreshTokenStoreInterface)(nil).RevokeRefreshTokenFamily), ctx, familyID)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockRefreshTokenStoreInterface) RotateRefreshToken(ctx context.Context, id uuid.UUID, newTokenHash string, expiresAt time.Time) (models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, id, newTokenHash, expiresAt)
+	ret0, _ := ret[0].(models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockRefreshTokenStoreInterfaceMockRecorder) RotateRefreshToken(ctx, id, newTokenHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockRefreshTokenStoreInterface)(nil).RotateRefreshToken), ctx, id, newTokenHash, expiresAt)
+}
+
+// MockSmartLockStoreInterface is a mock of SmartLockStoreInterface interface.
+type MockSmartLockStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmartLockStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSmartLockStoreInterfaceMockRecorder is the mock recorder for MockSmartLockStoreInterface.
+type MockSmartLockStoreInterfaceMockRecorder struct {
+	mock *MockSmartLockStoreInterface
+}
+
+// NewMockSmartLockStoreInterface creates a new mock instance.
+func NewMockSmartLockStoreInterface(ctrl *gomock.Controller) *MockSmartLockStoreInterface {
+	mock := &MockSmartLockStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockSmartLockStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmartLockStoreInterface) EXPECT() *MockSmartLockStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateDigitalKey mocks base method.
+func (m *MockSmartLockStoreInterface) CreateDigitalKey(ctx context.Context, key models.DigitalKey) (models.DigitalKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDigitalKey", ctx, key)
+	ret0, _ := ret[0].(models.DigitalKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDigitalKey indicates an expected call of CreateDigitalKey.
+func (mr *MockSmartLockStoreInterfaceMockRecorder) CreateDigitalKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDigitalKey", reflect.TypeOf((*MockSmartLockStoreInterface)(nil).CreateDigitalKey), ctx, key)
+}
+
+// CreateLockEvent mocks base method.
+func (m *MockSmartLockStoreInterface) CreateLockEvent(ctx context.Context, event models.LockEvent) (models.LockEvent, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLockEvent", ctx, event)
+	ret0, _ := ret[0].(models.LockEvent)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateLockEvent indicates an expected call of CreateLockEvent.
+func (mr *MockSmartLockStoreInterfaceMockRecorder) CreateLockEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLockEvent", reflect.TypeOf((*MockSmartLockStoreInterface)(nil).CreateLockEvent), ctx, event)
+}
+
+// DeleteSmartLock mocks base method.
+func (m *MockSmartLockStoreInterface) DeleteSmartLock(ctx context.Context, carID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSmartLock", ctx, carID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSmartLock indicates an expected call of DeleteSmartLock.
+func (mr *MockSmartLockStoreInterfaceMockRecorder) DeleteSmartLock(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSmartLock", reflect.TypeOf((*MockSmartLockStoreInterface)(nil).DeleteSmartLock), ctx, carID)
+}
+
+// FindDigitalKeyForEvent mocks base method.
+func (m *MockSmartLockStoreInterface) FindDigitalKeyForEvent(ctx context.Context, carID uuid.UUID, providerKeyID string, occurredAt time.Time) (models.DigitalKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDigitalKeyForEvent", ctx, carID, providerKeyID, occurredAt)
+	ret0, _ := ret[0].(models.DigitalKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDigitalKeyForEvent indicates an expected call of FindDigitalKeyForEvent.
+func (mr *MockSmartLockStoreInterfaceMockRecorder) FindDigitalKeyForEvent(ctx, carID, providerKeyID, occurredAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDigitalKeyForEvent", reflect.TypeOf((*MockSmartLockStoreInterface)(nil).FindDigitalKeyForEvent), ctx, carID, providerKeyID, occurredAt)
+}
+
+// GetDigitalKeysByBookingID mocks base method.
+func (m *MockSmartLockStoreInterface) GetDigitalKeysByBookingID(ctx context.Context, bookingID string) ([]models.DigitalKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigitalKeysByBookingID", ctx, bookingID)
+	ret0, _ := ret[0].([]models.DigitalKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigitalKeysByBookingID indicates an expected call of GetDigitalKeysByBookingID.
+func (mr *MockSmartLockStoreInterfaceMockRecorder) GetDigitalKeysByBookingID(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigitalKeysByBookingID", reflect.TypeOf((*MockSmartLockStoreInterface)(nil).GetDigitalKeysByBookingID), ctx, bookingID)
+}
+
+// GetLockEventsByBookingID mocks base method.
+func (m *MockSmartLockStoreInterface) GetLockEventsByBookingID(ctx context.Context, bookingID string) ([]models.LockEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLockEventsByBookingID", ctx, bookingID)
+	ret0, _ := ret[0].([]models.LockEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLockEventsByBookingID indicates an expected call of GetLockEventsByBookingID.
+func (mr *MockSmartLockStoreInterfaceMockRecorder) GetLockEventsByBookingID(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLockEventsByBookingID", reflect.TypeOf((*MockSmartLockStoreInterface)(nil).GetLockEventsByBookingID), ctx, bookingID)
+}
+
+// GetSmartLockByCarID mocks base method.
+func (m *MockSmartLockStoreInterface) GetSmartLockByCarID(ctx context.Context, carID string) (models.SmartLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartLockByCarID", ctx, carID)
+	ret0, _ := ret[0].(models.SmartLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSmartLockByCarID indicates an expected call of GetSmartLockByCarID.
+func (mr *MockSmartLockStoreInterfaceMockRecorder) GetSmartLockByCarID(ctx, carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartLockByCarID", reflect.TypeOf((*MockSmartLockStoreInterface)(nil).GetSmartLockByCarID), ctx, carID)
+}
+
+// GetSmartLockByRef mocks base method.
+func (m *MockSmartLockStoreInterface) GetSmartLockByRef(ctx context.Context, provider, lockRef string) (models.SmartLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartLockByRef", ctx, provider, lockRef)
+	ret0, _ := ret[0].(models.SmartLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSmartLockByRef indicates an expected call of GetSmartLockByRef.
+func (mr *MockSmartLockStoreInterfaceMockRecorder) GetSmartLockByRef(ctx, provider, lockRef any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartLockByRef", reflect.TypeOf((*MockSmartLockStoreInterface)(nil).GetSmartLockByRef), ctx, provider, lockRef)
+}
+
+// RevokeDigitalKey mocks base method.
+func (m *MockSmartLockStoreInterface) RevokeDigitalKey(ctx context.Context, id string) (models.DigitalKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeDigitalKey", ctx, id)
+	ret0, _ := ret[0].(models.DigitalKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeDigitalKey indicates an expected call of RevokeDigitalKey.
+func (mr *MockSmartLockStoreInterfaceMockRecorder) RevokeDigitalKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeDigitalKey", reflect.TypeOf((*MockSmartLockStoreInterface)(nil).RevokeDigitalKey), ctx, id)
+}
+
+// UpsertSmartLock mocks base method.
+func (m *MockSmartLockStoreInterface) UpsertSmartLock(ctx context.Context, carID string, req models.SmartLockRequest) (models.SmartLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSmartLock", ctx, carID, req)
+	ret0, _ := ret[0].(models.SmartLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertSmartLock indicates an expected call of UpsertSmartLock.
+func (mr *MockSmartLockStoreInterfaceMockRecorder) UpsertSmartLock(ctx, carID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSmartLock", reflect.TypeOf((*MockSmartLockStoreInterface)(nil).UpsertSmartLock), ctx, carID, req)
+}
+
+// MockIncidentStoreInterface is a mock of IncidentStoreInterface interface.
+type MockIncidentStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncidentStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockIncidentStoreInterfaceMockRecorder is the mock recorder for MockIncidentStoreInterface.
+type MockIncidentStoreInterfaceMockRecorder struct {
+	mock *MockIncidentStoreInterface
+}
+
+// NewMockIncidentStoreInterface creates a new mock instance.
+func NewMockIncidentStoreInterface(ctrl *gomock.Controller) *MockIncidentStoreInterface {
+	mock := &MockIncidentStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockIncidentStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncidentStoreInterface) EXPECT() *MockIncidentStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddDocument mocks base method.
+func (m *MockIncidentStoreInterface) AddDocument(ctx context.Context, incidentID, addedBy uuid.UUID, req models.IncidentDocumentRequest) (models.IncidentDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDocument", ctx, incidentID, addedBy, req)
+	ret0, _ := ret[0].(models.IncidentDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDocument indicates an expected call of AddDocument.
+func (mr *MockIncidentStoreInterfaceMockRecorder) AddDocument(ctx, incidentID, addedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDocument", reflect.TypeOf((*MockIncidentStoreInterface)(nil).AddDocument), ctx, incidentID, addedBy, req)
+}
+
+// CreateIncident mocks base method.
+func (m *MockIncidentStoreInterface) CreateIncident(ctx context.Context, incident models.Incident) (models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIncident", ctx, incident)
+	ret0, _ := ret[0].(models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIncident indicates an expected call of CreateIncident.
+func (mr *MockIncidentStoreInterfaceMockRecorder) CreateIncident(ctx, incident any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncident", reflect.TypeOf((*MockIncidentStoreInterface)(nil).CreateIncident), ctx, incident)
+}
+
+// GetIncident mocks base method.
+func (m *MockIncidentStoreInterface) GetIncident(ctx context.Context, id string) (models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncident", ctx, id)
+	ret0, _ := ret[0].(models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncident indicates an expected call of GetIncident.
+func (mr *MockIncidentStoreInterfaceMockRecorder) GetIncident(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncident", reflect.TypeOf((*MockIncidentStoreInterface)(nil).GetIncident), ctx, id)
+}
+
+// GetIncidentsByBookingID mocks base method.
+func (m *MockIncidentStoreInterface) GetIncidentsByBookingID(ctx context.Context, bookingID string) ([]models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncidentsByBookingID", ctx, bookingID)
+	ret0, _ := ret[0].([]models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncidentsByBookingID indicates an expected call of GetIncidentsByBookingID.
+func (mr *MockIncidentStoreInterfaceMockRecorder) GetIncidentsByBookingID(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentsByBookingID", reflect.TypeOf((*MockIncidentStoreInterface)(nil).GetIncidentsByBookingID), ctx, bookingID)
+}
+
+// ListIncidents mocks base method.
+func (m *MockIncidentStoreInterface) ListIncidents(ctx context.Context, filter models.IncidentFilter) ([]models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIncidents", ctx, filter)
+	ret0, _ := ret[0].([]models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIncidents indicates an expected call of ListIncidents.
+func (mr *MockIncidentStoreInterfaceMockRecorder) ListIncidents(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIncidents", reflect.TypeOf((*MockIncidentStoreInterface)(nil).ListIncidents), ctx, filter)
+}
+
+// UpdateIncidentStatus mocks base method.
+func (m *MockIncidentStoreInterface) UpdateIncidentStatus(ctx context.Context, id uuid.UUID, from, to models.IncidentStatus, claimNumber, notes *string) (models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIncidentStatus", ctx, id, from, to, claimNumber, notes)
+	ret0, _ := ret[0].(models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIncidentStatus indicates an expected call of UpdateIncidentStatus.
+func (mr *MockIncidentStoreInterfaceMockRecorder) UpdateIncidentStatus(ctx, id, from, to, claimNumber, notes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIncidentStatus", reflect.TypeOf((*MockIncidentStoreInterface)(nil).UpdateIncidentStatus), ctx, id, from, to, claimNumber, notes)
+}
+
+// MockChecklistStoreInterface is a mock of ChecklistStoreInterface interface.
+type MockChecklistStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecklistStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockChecklistStoreInterfaceMockRecorder is the mock recorder for MockChecklistStoreInterface.
+type MockChecklistStoreInterfaceMockRecorder struct {
+	mock *MockChecklistStoreInterface
+}
+
+// NewMockChecklistStoreInterface creates a new mock instance.
+func NewMockChecklistStoreInterface(ctrl *gomock.Controller) *MockChecklistStoreInterface {
+	mock := &MockChecklistStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockChecklistStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecklistStoreInterface) EXPECT() *MockChecklistStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddTemplateVersion mocks base method.
+func (m *MockChecklistStoreInterface) AddTemplateVersion(ctx context.Context, req models.ChecklistTemplateRequest, createdBy string) (models.ChecklistTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTemplateVersion", ctx, req, createdBy)
+	ret0, _ := ret[0].(models.ChecklistTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTemplateVersion indicates an expected call of AddTemplateVersion.
+func (mr *MockChecklistStoreInterfaceMockRecorder) AddTemplateVersion(ctx, req, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTemplateVersion", reflect.TypeOf((*MockChecklistStoreInterface)(nil).AddTemplateVersion), ctx, req, createdBy)
+}
+
+// GetCurrentTemplate mocks base method.
+func (m *MockChecklistStoreInterface) GetCurrentTemplate(ctx context.Context) (models.ChecklistTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentTemplate", ctx)
+	ret0, _ := ret[0].(models.ChecklistTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentTemplate indicates an expected call of GetCurrentTemplate.
+func (mr *MockChecklistStoreInterfaceMockRecorder) GetCurrentTemplate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentTemplate", reflect.TypeOf((*MockChecklistStoreInterface)(nil).GetCurrentTemplate), ctx)
+}
+
+// GetTemplateVersion mocks base method.
+func (m *MockChecklistStoreInterface) GetTemplateVersion(ctx context.Context, version int) (models.ChecklistTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateVersion", ctx, version)
+	ret0, _ := ret[0].(models.ChecklistTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateVersion indicates an expected call of GetTemplateVersion.
+func (mr *MockChecklistStoreInterfaceMockRecorder) GetTemplateVersion(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateVersion", reflect.TypeOf((*MockChecklistStoreInterface)(nil).GetTemplateVersion), ctx, version)
+}
+
+// ListTemplateVersions mocks base method.
+func (m *MockChecklistStoreInterface) ListTemplateVersions(ctx context.Context) ([]models.ChecklistTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplateVersions", ctx)
+	ret0, _ := ret[0].([]models.ChecklistTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplateVersions indicates an expected call of ListTemplateVersions.
+func (mr *MockChecklistStoreInterfaceMockRecorder) ListTemplateVersions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplateVersions", reflect.TypeOf((*MockChecklistStoreInterface)(nil).ListTemplateVersions), ctx)
+}
@@ -0,0 +1,122 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// notificationColumns lists the columns read by every notification query
+const notificationColumns = `id, user_id, subject, message, read_at, created_at`
+
+// NotificationStore persists the in-app notification inbox of every user
+type NotificationStore struct {
+	db *sql.DB
+}
+
+// New creates a new notification store
+func New(db *sql.DB) NotificationStore {
+	return NotificationStore{db: db}
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// CreateNotification stores a new unread notification in a user's inbox
+func (s NotificationStore) CreateNotification(ctx context.Context, userID, subject, message string) (models.Notification, error) {
+	tracer := otel.Tracer("NotificationStore")
+	ctx, span := tracer.Start(ctx, "CreateNotification-Store")
+	defer span.End()
+
+	query := `INSERT INTO notification (id, user_id, subject, message, created_at)
+	         VALUES ($1, $2, $3, $4, $5)
+	         RETURNING ` + notificationColumns
+
+	return scanNotification(s.db.QueryRowContext(ctx, query, uuid.New(), userID, subject, message, time.Now()))
+}
+
+// ListNotifications retrieves one page of a user's notifications, newest first
+func (s NotificationStore) ListNotifications(ctx context.Context, userID string, filter models.NotificationFilter, page models.PageRequest) ([]models.Notification, error) {
+	tracer := otel.Tracer("NotificationStore")
+	ctx, span := tracer.Start(ctx, "ListNotifications-Store")
+	defer span.End()
+
+	args := []interface{}{userID}
+	query := `SELECT ` + notificationColumns + ` FROM notification WHERE user_id = $1`
+
+	if filter.UnreadOnly {
+		query += ` AND read_at IS NULL`
+	}
+	// Keyset condition: continue strictly past the cursor in its direction of travel
+	if page.Cursor != nil {
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+		query += fmt.Sprintf(" AND (created_at, id) %s ($%d, $%d)", page.KeysetComparator(), len(args)-1, len(args))
+	}
+	args = append(args, page.Limit+1)
+	query += fmt.Sprintf(" ORDER BY created_at %[1]s, id %[1]s LIMIT $%[2]d", page.SortOrder(), len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, rows.Err()
+}
+
+// MarkRead marks one of the user's notifications as read. Notifications that were already
+// read keep their original read time; other users' notifications are reported as missing.
+func (s NotificationStore) MarkRead(ctx context.Context, id, userID string) (models.Notification, error) {
+	tracer := otel.Tracer("NotificationStore")
+	ctx, span := tracer.Start(ctx, "MarkRead-Store")
+	defer span.End()
+
+	query := `UPDATE notification SET read_at = COALESCE(read_at, $1)
+	         WHERE id = $2 AND user_id = $3
+	         RETURNING ` + notificationColumns
+
+	notification, err := scanNotification(s.db.QueryRowContext(ctx, query, time.Now(), id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Notification{}, errors.New("no notification found with the given ID")
+		}
+		return models.Notification{}, err
+	}
+
+	return notification, nil
+}
+
+// CountUnread counts the user's unread notifications
+func (s NotificationStore) CountUnread(ctx context.Context, userID string) (int, error) {
+	tracer := otel.Tracer("NotificationStore")
+	ctx, span := tracer.Start(ctx, "CountUnread-Store")
+	defer span.End()
+
+	var count int
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notification WHERE user_id = $1 AND read_at IS NULL`, userID).Scan(&count)
+	return count, err
+}
+
+// scanNotification reads one notification row
+func scanNotification(row rowScanner) (models.Notification, error) {
+	var n models.Notification
+	err := row.Scan(&n.ID, &n.UserID, &n.Subject, &n.Message, &n.ReadAt, &n.CreatedAt)
+	return n, err
+}
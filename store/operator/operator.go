@@ -0,0 +1,178 @@
+package operator
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// operatorColumns lists the columns read by every operator query, in scanOperator order
+const operatorColumns = `id, slug, name, domain, branding, commission_rate, is_active, created_at, updated_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// OperatorStore persists marketplace operators
+type OperatorStore struct {
+	db *sql.DB
+}
+
+// New creates a new operator store
+func New(db *sql.DB) OperatorStore {
+	return OperatorStore{db: db}
+}
+
+// CreateOperator stores a new operator
+func (s OperatorStore) CreateOperator(ctx context.Context, req models.OperatorRequest) (models.Operator, error) {
+	tracer := otel.Tracer("OperatorStore")
+	ctx, span := tracer.Start(ctx, "CreateOperator-Store")
+	defer span.End()
+
+	branding, err := json.Marshal(req.Branding)
+	if err != nil {
+		return models.Operator{}, err
+	}
+
+	query := `INSERT INTO operator (id, slug, name, domain, branding, commission_rate, is_active, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+	         RETURNING ` + operatorColumns
+
+	operator, err := scanOperator(s.db.QueryRowContext(ctx, query, uuid.New(), req.Slug, strings.TrimSpace(req.Name),
+		normalizedDomain(req.Domain), branding, req.CommissionRate, req.IsActive, time.Now()))
+	if err != nil {
+		return models.Operator{}, conflictError(err)
+	}
+
+	return operator, nil
+}
+
+// GetOperatorByID retrieves an operator by its ID
+func (s OperatorStore) GetOperatorByID(ctx context.Context, id string) (models.Operator, error) {
+	tracer := otel.Tracer("OperatorStore")
+	ctx, span := tracer.Start(ctx, "GetOperatorByID-Store")
+	defer span.End()
+
+	operator, err := scanOperator(s.db.QueryRowContext(ctx, `SELECT `+operatorColumns+` FROM operator WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Operator{}, errors.New("no operator found with the given ID")
+		}
+		return models.Operator{}, err
+	}
+
+	return operator, nil
+}
+
+// GetOperatorByDomain retrieves the operator serving a white-label host name.
+// Returns nil without an error when no operator uses the domain.
+func (s OperatorStore) GetOperatorByDomain(ctx context.Context, domain string) (*models.Operator, error) {
+	tracer := otel.Tracer("OperatorStore")
+	ctx, span := tracer.Start(ctx, "GetOperatorByDomain-Store")
+	defer span.End()
+
+	operator, err := scanOperator(s.db.QueryRowContext(ctx, `SELECT `+operatorColumns+` FROM operator WHERE domain = $1`, domain))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &operator, nil
+}
+
+// ListOperators retrieves every operator ordered by name
+func (s OperatorStore) ListOperators(ctx context.Context) ([]models.Operator, error) {
+	tracer := otel.Tracer("OperatorStore")
+	ctx, span := tracer.Start(ctx, "ListOperators-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+operatorColumns+` FROM operator ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	operators := []models.Operator{}
+	for rows.Next() {
+		operator, err := scanOperator(rows)
+		if err != nil {
+			return nil, err
+		}
+		operators = append(operators, operator)
+	}
+
+	return operators, rows.Err()
+}
+
+// UpdateOperator replaces an operator's settings
+func (s OperatorStore) UpdateOperator(ctx context.Context, id string, req models.OperatorRequest) (models.Operator, error) {
+	tracer := otel.Tracer("OperatorStore")
+	ctx, span := tracer.Start(ctx, "UpdateOperator-Store")
+	defer span.End()
+
+	branding, err := json.Marshal(req.Branding)
+	if err != nil {
+		return models.Operator{}, err
+	}
+
+	query := `UPDATE operator SET slug = $1, name = $2, domain = $3, branding = $4, commission_rate = $5,
+	         is_active = $6, updated_at = $7
+	         WHERE id = $8
+	         RETURNING ` + operatorColumns
+
+	operator, err := scanOperator(s.db.QueryRowContext(ctx, query, req.Slug, strings.TrimSpace(req.Name),
+		normalizedDomain(req.Domain), branding, req.CommissionRate, req.IsActive, time.Now(), id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Operator{}, errors.New("no operator found with the given ID")
+		}
+		return models.Operator{}, conflictError(err)
+	}
+
+	return operator, nil
+}
+
+// normalizedDomain stores domains in the form Host headers are compared in
+func normalizedDomain(domain *string) *string {
+	if domain == nil || strings.TrimSpace(*domain) == "" {
+		return nil
+	}
+	normalized := models.NormalizeHost(*domain)
+	return &normalized
+}
+
+// conflictError turns unique violations into messages the handler can map to 409
+func conflictError(err error) error {
+	switch {
+	case strings.Contains(err.Error(), "unique_operator_slug"):
+		return errors.New("an operator with this slug already exists")
+	case strings.Contains(err.Error(), "unique_operator_domain"):
+		return errors.New("an operator with this domain already exists")
+	}
+	return err
+}
+
+// scanOperator reads one operator row selected with operatorColumns
+func scanOperator(row rowScanner) (models.Operator, error) {
+	var operator models.Operator
+	var branding []byte
+	err := row.Scan(&operator.ID, &operator.Slug, &operator.Name, &operator.Domain, &branding,
+		&operator.CommissionRate, &operator.IsActive, &operator.CreatedAt, &operator.UpdatedAt)
+	if err != nil {
+		return models.Operator{}, err
+	}
+	if err := json.Unmarshal(branding, &operator.Branding); err != nil {
+		return models.Operator{}, err
+	}
+	return operator, nil
+}
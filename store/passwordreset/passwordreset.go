@@ -0,0 +1,127 @@
+package passwordreset
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordResetStore persists password reset links by the hash of their token
+type PasswordResetStore struct {
+	db *sql.DB
+}
+
+// New creates a new password reset store
+func New(db *sql.DB) PasswordResetStore {
+	return PasswordResetStore{db: db}
+}
+
+// CreatePasswordResetToken stores a reset link for a user. Earlier links of the user that were
+// not used stop working, so only the newest e-mail does, and links older than a day are removed.
+func (s PasswordResetStore) CreatePasswordResetToken(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (token models.PasswordResetToken, err error) {
+	tracer := otel.Tracer("PasswordResetStore")
+	ctx, span := tracer.Start(ctx, "CreatePasswordResetToken-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return token, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	now := time.Now()
+	if _, err = tx.ExecContext(ctx, `DELETE FROM password_reset_tokens WHERE user_id = $1 AND created_at < $2`,
+		userID, now.Add(-24*time.Hour)); err != nil {
+		return token, err
+	}
+	if _, err = tx.ExecContext(ctx, `UPDATE password_reset_tokens SET expires_at = $2
+	         WHERE user_id = $1 AND used_at IS NULL AND expires_at > $2`, userID, now); err != nil {
+		return token, err
+	}
+
+	err = tx.QueryRowContext(ctx, `INSERT INTO password_reset_tokens (user_id, token_hash, expires_at, created_at)
+	         VALUES ($1, $2, $3, $4)
+	         RETURNING id, user_id, expires_at, used_at, created_at`, userID, tokenHash, expiresAt, now).Scan(
+		&token.ID, &token.UserID, &token.ExpiresAt, &token.UsedAt, &token.CreatedAt)
+	return token, err
+}
+
+// CountPasswordResetTokens counts the reset links issued to a user since the given time
+func (s PasswordResetStore) CountPasswordResetTokens(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	tracer := otel.Tracer("PasswordResetStore")
+	ctx, span := tracer.Start(ctx, "CountPasswordResetTokens-Store")
+	defer span.End()
+
+	var count int
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM password_reset_tokens WHERE user_id = $1 AND created_at >= $2`,
+		userID, since).Scan(&count)
+	return count, err
+}
+
+// ResetPassword uses up the reset link with the token hash and replaces its user's password,
+// returning the user's ID. A link works once and only until it expires; the user's other
+// unused links stop working with it, and so do their refresh tokens, signing out every session
+// once its access token expires.
+func (s PasswordResetStore) ResetPassword(ctx context.Context, tokenHash, password string) (userID uuid.UUID, err error) {
+	tracer := otel.Tracer("PasswordResetStore")
+	ctx, span := tracer.Start(ctx, "ResetPassword-Store")
+	defer span.End()
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// Marking the link used in the same statement that checks it keeps two requests from both using it
+	now := time.Now()
+	err = tx.QueryRowContext(ctx, `UPDATE password_reset_tokens SET used_at = $2
+	         WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+	         RETURNING user_id`, tokenHash, now).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, errors.New("reset token is invalid or has expired")
+		}
+		return uuid.Nil, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE password_reset_tokens SET expires_at = $2
+	         WHERE user_id = $1 AND used_at IS NULL AND expires_at > $2`, userID, now); err != nil {
+		return uuid.Nil, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = $2
+	         WHERE user_id = $1 AND revoked_at IS NULL`, userID, now); err != nil {
+		return uuid.Nil, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE users SET password_hash = $2, updated_at = $3 WHERE id = $1`,
+		userID, string(hashedPassword), now.UTC()); err != nil {
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
@@ -5,22 +5,33 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/PrateekKumar15/CarZone/tenant"
 )
 
+// getPaymentByRazorpayOrderIDQuery runs for every checkout, verification and webhook, so it
+// is prepared once when the store is created
+const getPaymentByRazorpayOrderIDQuery = `SELECT id, booking_id, razorpay_order_id, razorpay_payment_id, amount, currency, 
+	         status, method, transaction_id, description, notes, created_at, updated_at 
+	         FROM payment WHERE razorpay_order_id = $1 AND ($2::uuid IS NULL OR operator_id = $2)`
+
 // PaymentStore implements payment data access operations
 type PaymentStore struct {
 	db *sql.DB
+
+	getPaymentByRazorpayOrderID *store.PreparedQuery
 }
 
 // New creates a new PaymentStore instance
 func New(db *sql.DB) *PaymentStore {
-	return &PaymentStore{db: db}
+	return &PaymentStore{db: db, getPaymentByRazorpayOrderID: store.Prepare(db, getPaymentByRazorpayOrderIDQuery)}
 }
 
 // GetPaymentByID retrieves a payment by its ID
@@ -33,9 +44,9 @@ func (s *PaymentStore) GetPaymentByID(ctx context.Context, id string) (models.Pa
 
 	query := `SELECT id, booking_id, razorpay_order_id, razorpay_payment_id, amount, currency, 
 	         status, method, transaction_id, description, notes, created_at, updated_at 
-	         FROM payment WHERE id = $1`
+	         FROM payment WHERE id = $1 AND ($2::uuid IS NULL OR operator_id = $2)`
 
-	row := s.db.QueryRowContext(ctx, query, id)
+	row := s.db.QueryRowContext(ctx, query, id, tenant.Scope(ctx))
 	err := row.Scan(&payment.ID, &payment.BookingID, &payment.RazorpayOrderID, &payment.RazorpayPaymentID,
 		&payment.Amount, &payment.Currency, &payment.Status, &payment.Method, &payment.TransactionID,
 		&payment.Description, &payment.Notes, &payment.CreatedAt, &payment.UpdatedAt)
@@ -60,9 +71,10 @@ func (s *PaymentStore) GetPaymentsByBookingID(ctx context.Context, bookingID str
 
 	query := `SELECT id, booking_id, razorpay_order_id, razorpay_payment_id, amount, currency, 
 	         status, method, transaction_id, description, notes, created_at, updated_at 
-	         FROM payment WHERE booking_id = $1 ORDER BY created_at DESC`
+	         FROM payment WHERE booking_id = $1 AND ($2::uuid IS NULL OR operator_id = $2)
+	         ORDER BY created_at DESC`
 
-	rows, err := s.db.QueryContext(ctx, query, bookingID)
+	rows, err := s.db.QueryContext(ctx, query, bookingID, tenant.Scope(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -91,11 +103,7 @@ func (s *PaymentStore) GetPaymentByRazorpayOrderID(ctx context.Context, orderID
 
 	var payment models.Payment
 
-	query := `SELECT id, booking_id, razorpay_order_id, razorpay_payment_id, amount, currency, 
-	         status, method, transaction_id, description, notes, created_at, updated_at 
-	         FROM payment WHERE razorpay_order_id = $1`
-
-	row := s.db.QueryRowContext(ctx, query, orderID)
+	row := s.getPaymentByRazorpayOrderID.QueryRowContext(ctx, orderID, tenant.Scope(ctx))
 	err := row.Scan(&payment.ID, &payment.BookingID, &payment.RazorpayOrderID, &payment.RazorpayPaymentID,
 		&payment.Amount, &payment.Currency, &payment.Status, &payment.Method, &payment.TransactionID,
 		&payment.Description, &payment.Notes, &payment.CreatedAt, &payment.UpdatedAt)
@@ -110,6 +118,37 @@ func (s *PaymentStore) GetPaymentByRazorpayOrderID(ctx context.Context, orderID
 	return payment, nil
 }
 
+// GetPaymentByRazorpayPaymentID retrieves the payment a Razorpay payment was made for, by its
+// latest or an earlier attempt
+func (s *PaymentStore) GetPaymentByRazorpayPaymentID(ctx context.Context, razorpayPaymentID string) (models.Payment, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "GetPaymentByRazorpayPaymentID-Store")
+	defer span.End()
+
+	var payment models.Payment
+
+	query := `SELECT id, booking_id, razorpay_order_id, razorpay_payment_id, amount, currency,
+	         status, method, transaction_id, description, notes, created_at, updated_at
+	         FROM payment WHERE (razorpay_payment_id = $1
+	         OR id IN (SELECT payment_id FROM payment_attempt WHERE razorpay_payment_id = $1))
+	         AND ($2::uuid IS NULL OR operator_id = $2)
+	         LIMIT 1`
+
+	row := s.db.QueryRowContext(ctx, query, razorpayPaymentID, tenant.Scope(ctx))
+	err := row.Scan(&payment.ID, &payment.BookingID, &payment.RazorpayOrderID, &payment.RazorpayPaymentID,
+		&payment.Amount, &payment.Currency, &payment.Status, &payment.Method, &payment.TransactionID,
+		&payment.Description, &payment.Notes, &payment.CreatedAt, &payment.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Payment{}, errors.New("no payment found with the given Razorpay payment ID")
+		}
+		return models.Payment{}, err
+	}
+
+	return payment, nil
+}
+
 // CreatePayment creates a new payment record
 func (s *PaymentStore) CreatePayment(ctx context.Context, paymentReq models.PaymentRequest) (models.Payment, error) {
 	tracer := otel.Tracer("PaymentStore")
@@ -131,14 +170,15 @@ func (s *PaymentStore) CreatePayment(ctx context.Context, paymentReq models.Paym
 		err = tx.Commit()
 	}()
 
+	// Payments belong to the operator of the paid booking
 	// Generate new UUID for payment
 	paymentId := uuid.New()
 	createdAt := time.Now()
 	updatedAt := createdAt
 
 	query := `INSERT INTO payment (id, booking_id, amount, currency, status, method, 
-	         description, notes, created_at, updated_at)
-	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	         description, notes, created_at, updated_at, operator_id)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, (SELECT operator_id FROM booking WHERE id = $2))
 	         RETURNING id, booking_id, razorpay_order_id, razorpay_payment_id, amount, currency, 
 	         status, method, transaction_id, description, notes, created_at, updated_at`
 
@@ -179,11 +219,11 @@ func (s *PaymentStore) UpdatePaymentWithRazorpayDetails(ctx context.Context, pay
 		err = tx.Commit()
 	}()
 
-	query := `UPDATE payment SET razorpay_order_id = $1, updated_at = $2 WHERE id = $3 
+	query := `UPDATE payment SET razorpay_order_id = $1, updated_at = $2 WHERE id = $3 AND ($4::uuid IS NULL OR operator_id = $4)
 	         RETURNING id, booking_id, razorpay_order_id, razorpay_payment_id, amount, currency, 
 	         status, method, transaction_id, description, notes, created_at, updated_at`
 
-	err = tx.QueryRowContext(ctx, query, orderID, time.Now(), paymentID).Scan(
+	err = tx.QueryRowContext(ctx, query, orderID, time.Now(), paymentID, tenant.Scope(ctx)).Scan(
 		&updatedPayment.ID, &updatedPayment.BookingID, &updatedPayment.RazorpayOrderID,
 		&updatedPayment.RazorpayPaymentID, &updatedPayment.Amount, &updatedPayment.Currency,
 		&updatedPayment.Status, &updatedPayment.Method, &updatedPayment.TransactionID,
@@ -194,7 +234,7 @@ func (s *PaymentStore) UpdatePaymentWithRazorpayDetails(ctx context.Context, pay
 		if err == sql.ErrNoRows {
 			return models.Payment{}, errors.New("no payment found with the given ID")
 		}
-		return models.Payment{}, err
+		return models.Payment{}, store.ConstraintViolation(err, store.ErrDuplicateRazorpayOrder)
 	}
 
 	return updatedPayment, nil
@@ -238,11 +278,11 @@ func (s *PaymentStore) UpdatePaymentStatus(ctx context.Context, id string, statu
 	}()
 
 	query := `UPDATE payment SET status = $1, razorpay_payment_id = $2, transaction_id = $3, updated_at = $4 
-	         WHERE id = $5 
+	         WHERE id = $5 AND ($6::uuid IS NULL OR operator_id = $6)
 	         RETURNING id, booking_id, razorpay_order_id, razorpay_payment_id, amount, currency, 
 	         status, method, transaction_id, description, notes, created_at, updated_at`
 
-	err = tx.QueryRowContext(ctx, query, status, paymentID, transactionID, time.Now(), id).Scan(
+	err = tx.QueryRowContext(ctx, query, status, paymentID, transactionID, time.Now(), id, tenant.Scope(ctx)).Scan(
 		&updatedPayment.ID, &updatedPayment.BookingID, &updatedPayment.RazorpayOrderID,
 		&updatedPayment.RazorpayPaymentID, &updatedPayment.Amount, &updatedPayment.Currency,
 		&updatedPayment.Status, &updatedPayment.Method, &updatedPayment.TransactionID,
@@ -293,9 +333,9 @@ func (s *PaymentStore) DeletePayment(ctx context.Context, id string) (models.Pay
 	// First get the payment data before deleting
 	query := `SELECT id, booking_id, razorpay_order_id, razorpay_payment_id, amount, currency, 
 	         status, method, transaction_id, description, notes, created_at, updated_at 
-	         FROM payment WHERE id = $1`
+	         FROM payment WHERE id = $1 AND ($2::uuid IS NULL OR operator_id = $2)`
 
-	err = tx.QueryRowContext(ctx, query, id).Scan(&deletedPayment.ID, &deletedPayment.BookingID,
+	err = tx.QueryRowContext(ctx, query, id, tenant.Scope(ctx)).Scan(&deletedPayment.ID, &deletedPayment.BookingID,
 		&deletedPayment.RazorpayOrderID, &deletedPayment.RazorpayPaymentID, &deletedPayment.Amount,
 		&deletedPayment.Currency, &deletedPayment.Status, &deletedPayment.Method,
 		&deletedPayment.TransactionID, &deletedPayment.Description, &deletedPayment.Notes,
@@ -341,10 +381,10 @@ func (ps *PaymentStore) GetPaymentsByUserID(ctx context.Context, userID string)
 			   p.notes, p.created_at, p.updated_at
 		FROM payment p
 		INNER JOIN booking b ON p.booking_id = b.id
-		WHERE b.customer_id = $1
+		WHERE b.customer_id = $1 AND ($2::uuid IS NULL OR p.operator_id = $2)
 		ORDER BY p.created_at DESC`
 
-	rows, err := ps.db.QueryContext(ctx, query, userID)
+	rows, err := ps.db.QueryContext(ctx, query, userID, tenant.Scope(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -370,20 +410,43 @@ func (ps *PaymentStore) GetPaymentsByUserID(ctx context.Context, userID string)
 	return payments, nil
 }
 
-// GetAllPayments retrieves all payment records
-func (ps *PaymentStore) GetAllPayments(ctx context.Context) ([]models.Payment, error) {
+// ListPayments retrieves one page of payments matching the filter, newest first.
+// It fetches page.Limit+1 rows so the caller can tell whether another page exists.
+func (ps *PaymentStore) ListPayments(ctx context.Context, filter models.PaymentFilter, page models.PageRequest) ([]models.Payment, error) {
 	tracer := otel.Tracer("PaymentStore")
-	ctx, span := tracer.Start(ctx, "GetAllPayments-Store")
+	ctx, span := tracer.Start(ctx, "ListPayments-Store")
 	defer span.End()
 
+	var conditions []string
+	var args []interface{}
+
 	query := `
 		SELECT p.id, p.booking_id, p.razorpay_order_id, p.razorpay_payment_id, p.amount, 
 			   p.currency, p.status, p.method, p.transaction_id, p.description,
-			   p.notes, p.created_at, p.updated_at
+			   p.notes, p.created_at, p.updated_at, b.customer_snapshot, b.owner_snapshot
 		FROM payment p
-		ORDER BY p.created_at DESC`
+		INNER JOIN booking b ON p.booking_id = b.id`
 
-	rows, err := ps.db.QueryContext(ctx, query)
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("b.customer_id = $%d", len(args)))
+	}
+	if operatorID := tenant.Scope(ctx); operatorID != nil {
+		args = append(args, *operatorID)
+		conditions = append(conditions, fmt.Sprintf("p.operator_id = $%d", len(args)))
+	}
+	// Keyset condition: continue strictly past the cursor in its direction of travel
+	if page.Cursor != nil {
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(p.created_at, p.id) %s ($%d, $%d)", page.KeysetComparator(), len(args)-1, len(args)))
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, page.Limit+1)
+	query += fmt.Sprintf(" ORDER BY p.created_at %[1]s, p.id %[1]s LIMIT $%[2]d", page.SortOrder(), len(args))
+
+	rows, err := ps.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -391,11 +454,7 @@ func (ps *PaymentStore) GetAllPayments(ctx context.Context) ([]models.Payment, e
 
 	var payments []models.Payment
 	for rows.Next() {
-		var payment models.Payment
-		err := rows.Scan(&payment.ID, &payment.BookingID, &payment.RazorpayOrderID,
-			&payment.RazorpayPaymentID, &payment.Amount, &payment.Currency, &payment.Status,
-			&payment.Method, &payment.TransactionID, &payment.Description,
-			&payment.Notes, &payment.CreatedAt, &payment.UpdatedAt)
+		payment, err := scanListedPayment(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -408,3 +467,439 @@ func (ps *PaymentStore) GetAllPayments(ctx context.Context) ([]models.Payment, e
 
 	return payments, nil
 }
+
+// GetStalePendingPaymentIDs retrieves the pending Razorpay payments last updated before
+// updatedBefore, oldest first
+func (ps *PaymentStore) GetStalePendingPaymentIDs(ctx context.Context, updatedBefore time.Time, limit int) ([]uuid.UUID, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "GetStalePendingPaymentIDs-Store")
+	defer span.End()
+
+	query := `SELECT id FROM payment
+	         WHERE status = $1 AND razorpay_order_id IS NOT NULL AND updated_at < $2
+	           AND ($3::uuid IS NULL OR operator_id = $3)
+	         ORDER BY updated_at LIMIT $4`
+
+	rows, err := ps.db.QueryContext(ctx, query, models.PaymentStatusPending, updatedBefore, tenant.Scope(ctx), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// scanListedPayment reads a payment row of a list, followed by its booking's customer and owner
+// snapshots
+func scanListedPayment(rows *sql.Rows) (models.Payment, error) {
+	var payment models.Payment
+	var customerJSON, ownerJSON []byte
+	err := rows.Scan(&payment.ID, &payment.BookingID, &payment.RazorpayOrderID,
+		&payment.RazorpayPaymentID, &payment.Amount, &payment.Currency, &payment.Status,
+		&payment.Method, &payment.TransactionID, &payment.Description,
+		&payment.Notes, &payment.CreatedAt, &payment.UpdatedAt, &customerJSON, &ownerJSON)
+	if err != nil {
+		return models.Payment{}, err
+	}
+
+	if payment.Customer, err = models.ParseUserSnapshot(customerJSON); err != nil {
+		return models.Payment{}, err
+	}
+	if payment.Owner, err = models.ParseUserSnapshot(ownerJSON); err != nil {
+		return models.Payment{}, err
+	}
+	return payment, nil
+}
+
+// paymentLinkColumns lists the columns read by every payment link query, in scanPaymentLink order
+const paymentLinkColumns = `id, payment_id, booking_id, razorpay_link_id, short_url, amount, status, expires_at, created_at, updated_at`
+
+// CreatePaymentLink stores a Razorpay payment link created for a pending payment
+func (s *PaymentStore) CreatePaymentLink(ctx context.Context, link models.PaymentLink) (models.PaymentLink, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "CreatePaymentLink-Store")
+	defer span.End()
+
+	now := time.Now()
+	query := `INSERT INTO payment_link (` + paymentLinkColumns + `)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+	         RETURNING ` + paymentLinkColumns
+
+	return scanPaymentLink(s.db.QueryRowContext(ctx, query, uuid.New(), link.PaymentID, link.BookingID,
+		link.RazorpayLinkID, link.ShortURL, link.Amount, models.PaymentLinkStatusCreated, link.ExpiresAt, now))
+}
+
+// GetPaymentLinkByRazorpayID retrieves a payment link by Razorpay's ID for it, as sent in webhooks
+func (s *PaymentStore) GetPaymentLinkByRazorpayID(ctx context.Context, razorpayLinkID string) (models.PaymentLink, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "GetPaymentLinkByRazorpayID-Store")
+	defer span.End()
+
+	link, err := scanPaymentLink(s.db.QueryRowContext(ctx,
+		`SELECT `+paymentLinkColumns+` FROM payment_link WHERE razorpay_link_id = $1`, razorpayLinkID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PaymentLink{}, errors.New("no payment link found with the given ID")
+		}
+		return models.PaymentLink{}, err
+	}
+
+	return link, nil
+}
+
+// UpdatePaymentLinkStatus records the status Razorpay reported for a payment link
+func (s *PaymentStore) UpdatePaymentLinkStatus(ctx context.Context, id uuid.UUID, status models.PaymentLinkStatus) error {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "UpdatePaymentLinkStatus-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `UPDATE payment_link SET status = $2, updated_at = $3 WHERE id = $1`,
+		id, status, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no payment link found with the given ID")
+	}
+
+	return nil
+}
+
+// scanPaymentLink reads one payment_link row
+func scanPaymentLink(row *sql.Row) (models.PaymentLink, error) {
+	var link models.PaymentLink
+	err := row.Scan(&link.ID, &link.PaymentID, &link.BookingID, &link.RazorpayLinkID, &link.ShortURL,
+		&link.Amount, &link.Status, &link.ExpiresAt, &link.CreatedAt, &link.UpdatedAt)
+	return link, err
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// paymentCollectionColumns lists the columns read by every payment collection query, in
+// scanPaymentCollection order
+const paymentCollectionColumns = `id, payment_id, booking_id, amount, receipt_number, notes, collected_by, collected_at, created_at`
+
+// RecordPaymentCollection completes a pending payment with the receipt number as its
+// transaction ID and stores the collection, in one transaction
+func (s *PaymentStore) RecordPaymentCollection(ctx context.Context, collection models.PaymentCollection) (models.PaymentCollection, models.Payment, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "RecordPaymentCollection-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.PaymentCollection{}, models.Payment{}, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var payment models.Payment
+	// The status check makes a concurrent collection or checkout of the same payment lose
+	err = tx.QueryRowContext(ctx, `UPDATE payment SET status = $2, transaction_id = $3, updated_at = $4
+	         WHERE id = $1 AND status = $5 AND ($6::uuid IS NULL OR operator_id = $6)
+	         RETURNING id, booking_id, razorpay_order_id, razorpay_payment_id, amount, currency,
+	         status, method, transaction_id, description, notes, created_at, updated_at`,
+		collection.PaymentID, models.PaymentStatusCompleted, collection.ReceiptNumber, now,
+		models.PaymentStatusPending, tenant.Scope(ctx)).Scan(
+		&payment.ID, &payment.BookingID, &payment.RazorpayOrderID,
+		&payment.RazorpayPaymentID, &payment.Amount, &payment.Currency,
+		&payment.Status, &payment.Method, &payment.TransactionID,
+		&payment.Description, &payment.Notes, &payment.CreatedAt, &payment.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PaymentCollection{}, models.Payment{}, errors.New("payment is no longer pending")
+		}
+		return models.PaymentCollection{}, models.Payment{}, err
+	}
+
+	saved, err := scanPaymentCollection(tx.QueryRowContext(ctx, `INSERT INTO payment_collection (`+paymentCollectionColumns+`)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	         RETURNING `+paymentCollectionColumns,
+		uuid.New(), collection.PaymentID, collection.BookingID, collection.Amount, collection.ReceiptNumber,
+		collection.Notes, collection.CollectedBy, collection.CollectedAt, now))
+	if err != nil {
+		return models.PaymentCollection{}, models.Payment{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.PaymentCollection{}, models.Payment{}, err
+	}
+
+	return saved, payment, nil
+}
+
+// GetPaymentCollectionsByBookingID retrieves the offline collections recorded for a booking, oldest first
+func (s *PaymentStore) GetPaymentCollectionsByBookingID(ctx context.Context, bookingID string) ([]models.PaymentCollection, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "GetPaymentCollectionsByBookingID-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+paymentCollectionColumns+` FROM payment_collection
+	         WHERE booking_id = $1 ORDER BY collected_at, id`, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := []models.PaymentCollection{}
+	for rows.Next() {
+		collection, err := scanPaymentCollection(rows)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+
+	return collections, rows.Err()
+}
+
+// scanPaymentCollection reads one payment_collection row
+func scanPaymentCollection(row rowScanner) (models.PaymentCollection, error) {
+	var c models.PaymentCollection
+	err := row.Scan(&c.ID, &c.PaymentID, &c.BookingID, &c.Amount, &c.ReceiptNumber, &c.Notes,
+		&c.CollectedBy, &c.CollectedAt, &c.CreatedAt)
+	return c, err
+}
+
+// paymentAttemptColumns lists the columns read by every payment attempt query, in scanPaymentAttempt order
+const paymentAttemptColumns = `id, payment_id, razorpay_order_id, razorpay_payment_id, status, error_code, error_description, created_at, updated_at`
+
+// CreatePaymentAttempt records a new gateway attempt of a payment when its Razorpay order is created
+func (s *PaymentStore) CreatePaymentAttempt(ctx context.Context, paymentID uuid.UUID, orderID string) (models.PaymentAttempt, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "CreatePaymentAttempt-Store")
+	defer span.End()
+
+	query := `INSERT INTO payment_attempt (id, payment_id, razorpay_order_id, status, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $5)
+	         RETURNING ` + paymentAttemptColumns
+
+	return scanPaymentAttempt(s.db.QueryRowContext(ctx, query, uuid.New(), paymentID, orderID,
+		models.PaymentStatusPending, time.Now()))
+}
+
+// UpdatePaymentAttempt records the outcome of the attempt made on a Razorpay order. Omitted
+// payment IDs and errors keep their stored values.
+func (s *PaymentStore) UpdatePaymentAttempt(ctx context.Context, orderID string, status models.PaymentStatus, razorpayPaymentID, errorCode, errorDescription *string) error {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "UpdatePaymentAttempt-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `UPDATE payment_attempt SET status = $2,
+	         razorpay_payment_id = COALESCE($3, razorpay_payment_id),
+	         error_code = COALESCE($4, error_code),
+	         error_description = COALESCE($5, error_description),
+	         updated_at = $6
+	         WHERE razorpay_order_id = $1`,
+		orderID, status, razorpayPaymentID, errorCode, errorDescription, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no payment attempt found for the given order")
+	}
+
+	return nil
+}
+
+// GetPaymentAttempts retrieves every gateway attempt of a payment, oldest first
+func (s *PaymentStore) GetPaymentAttempts(ctx context.Context, paymentID string) ([]models.PaymentAttempt, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "GetPaymentAttempts-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+paymentAttemptColumns+` FROM payment_attempt
+	         WHERE payment_id = $1 ORDER BY created_at, id`, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := []models.PaymentAttempt{}
+	for rows.Next() {
+		attempt, err := scanPaymentAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, rows.Err()
+}
+
+// RetryPayment moves a failed payment back to pending with a new Razorpay order and records
+// the new attempt, in one transaction. The failed attempt keeps its own row.
+func (s *PaymentStore) RetryPayment(ctx context.Context, paymentID uuid.UUID, orderID string) (models.Payment, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "RetryPayment-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Payment{}, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var payment models.Payment
+	// The status check makes a concurrent retry of the same payment lose
+	err = tx.QueryRowContext(ctx, `UPDATE payment SET status = $2, razorpay_order_id = $3, razorpay_payment_id = NULL,
+	         transaction_id = NULL, updated_at = $4
+	         WHERE id = $1 AND status = $5 AND ($6::uuid IS NULL OR operator_id = $6)
+	         RETURNING id, booking_id, razorpay_order_id, razorpay_payment_id, amount, currency,
+	         status, method, transaction_id, description, notes, created_at, updated_at`,
+		paymentID, models.PaymentStatusPending, orderID, now, models.PaymentStatusFailed, tenant.Scope(ctx)).Scan(
+		&payment.ID, &payment.BookingID, &payment.RazorpayOrderID,
+		&payment.RazorpayPaymentID, &payment.Amount, &payment.Currency,
+		&payment.Status, &payment.Method, &payment.TransactionID,
+		&payment.Description, &payment.Notes, &payment.CreatedAt, &payment.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Payment{}, errors.New("payment is no longer failed")
+		}
+		return models.Payment{}, store.ConstraintViolation(err, store.ErrDuplicateRazorpayOrder)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO payment_attempt (id, payment_id, razorpay_order_id, status, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $5)`, uuid.New(), paymentID, orderID, models.PaymentStatusPending, now)
+	if err != nil {
+		return models.Payment{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Payment{}, err
+	}
+
+	return payment, nil
+}
+
+// scanPaymentAttempt reads one payment_attempt row
+func scanPaymentAttempt(row rowScanner) (models.PaymentAttempt, error) {
+	var a models.PaymentAttempt
+	err := row.Scan(&a.ID, &a.PaymentID, &a.RazorpayOrderID, &a.RazorpayPaymentID, &a.Status,
+		&a.ErrorCode, &a.ErrorDescription, &a.CreatedAt, &a.UpdatedAt)
+	return a, err
+}
+
+// paymentAuthorizationColumns lists the columns read by every payment authorization query, in
+// scanPaymentAuthorization order
+const paymentAuthorizationColumns = `id, payment_id, booking_id, amount, status, razorpay_payment_id, expires_at,
+	authorized_at, captured_at, voided_at, created_at, updated_at`
+
+// CreatePaymentAuthorization marks a payment as captured at pickup. Creating it again for the
+// same payment returns the existing authorization.
+func (s *PaymentStore) CreatePaymentAuthorization(ctx context.Context, payment models.Payment) (models.PaymentAuthorization, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "CreatePaymentAuthorization-Store")
+	defer span.End()
+
+	now := time.Now()
+	_, err := s.db.ExecContext(ctx, `INSERT INTO payment_authorization (id, payment_id, booking_id, amount, status, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $6)
+	         ON CONFLICT (payment_id) DO NOTHING`,
+		uuid.New(), payment.ID, payment.BookingID, payment.Amount, models.PaymentAuthorizationPending, now)
+	if err != nil {
+		return models.PaymentAuthorization{}, err
+	}
+
+	return s.GetPaymentAuthorization(ctx, payment.ID.String())
+}
+
+// GetPaymentAuthorization retrieves the authorization of a manual-capture payment
+func (s *PaymentStore) GetPaymentAuthorization(ctx context.Context, paymentID string) (models.PaymentAuthorization, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "GetPaymentAuthorization-Store")
+	defer span.End()
+
+	authorization, err := scanPaymentAuthorization(s.db.QueryRowContext(ctx,
+		`SELECT `+paymentAuthorizationColumns+` FROM payment_authorization WHERE payment_id = $1`, paymentID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PaymentAuthorization{}, errors.New("no payment authorization found for the given payment")
+		}
+		return models.PaymentAuthorization{}, err
+	}
+
+	return authorization, nil
+}
+
+// GetPaymentAuthorizationsByBookingID retrieves the authorizations of a booking's payments in a status
+func (s *PaymentStore) GetPaymentAuthorizationsByBookingID(ctx context.Context, bookingID string, status models.PaymentAuthorizationStatus) ([]models.PaymentAuthorization, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "GetPaymentAuthorizationsByBookingID-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+paymentAuthorizationColumns+` FROM payment_authorization
+	         WHERE booking_id = $1 AND status = $2 ORDER BY created_at, id`, bookingID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	authorizations := []models.PaymentAuthorization{}
+	for rows.Next() {
+		authorization, err := scanPaymentAuthorization(rows)
+		if err != nil {
+			return nil, err
+		}
+		authorizations = append(authorizations, authorization)
+	}
+
+	return authorizations, rows.Err()
+}
+
+// UpdatePaymentAuthorization moves an authorization from one status to the next, stamping the
+// time it was authorized, captured or voided. The status check makes a concurrent capture and
+// void of the same hold lose.
+func (s *PaymentStore) UpdatePaymentAuthorization(ctx context.Context, id uuid.UUID, from, to models.PaymentAuthorizationStatus, razorpayPaymentID *string, expiresAt *time.Time) (models.PaymentAuthorization, error) {
+	tracer := otel.Tracer("PaymentStore")
+	ctx, span := tracer.Start(ctx, "UpdatePaymentAuthorization-Store")
+	defer span.End()
+
+	query := `UPDATE payment_authorization SET status = $3,
+	         razorpay_payment_id = COALESCE($4, razorpay_payment_id),
+	         expires_at = COALESCE($5, expires_at),
+	         authorized_at = CASE WHEN $3 = $7 THEN $6 ELSE authorized_at END,
+	         captured_at = CASE WHEN $3 = $8 THEN $6 ELSE captured_at END,
+	         voided_at = CASE WHEN $3 = $9 THEN $6 ELSE voided_at END,
+	         updated_at = $6
+	         WHERE id = $1 AND status = $2
+	         RETURNING ` + paymentAuthorizationColumns
+
+	authorization, err := scanPaymentAuthorization(s.db.QueryRowContext(ctx, query, id, from, to, razorpayPaymentID,
+		expiresAt, time.Now(), models.PaymentAuthorizationAuthorized, models.PaymentAuthorizationCaptured,
+		models.PaymentAuthorizationVoided))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PaymentAuthorization{}, fmt.Errorf("payment authorization is no longer %s", from)
+		}
+		return models.PaymentAuthorization{}, err
+	}
+
+	return authorization, nil
+}
+
+// scanPaymentAuthorization reads one payment_authorization row
+func scanPaymentAuthorization(row rowScanner) (models.PaymentAuthorization, error) {
+	var a models.PaymentAuthorization
+	err := row.Scan(&a.ID, &a.PaymentID, &a.BookingID, &a.Amount, &a.Status, &a.RazorpayPaymentID, &a.ExpiresAt,
+		&a.AuthorizedAt, &a.CapturedAt, &a.VoidedAt, &a.CreatedAt, &a.UpdatedAt)
+	return a, err
+}
@@ -0,0 +1,338 @@
+package payout
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/encryption"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// payoutAccountColumns lists the columns read by every payout account query
+const payoutAccountColumns = `id, owner_id, account_type, account_holder_name, ifsc, account_number_encrypted,
+	vpa_encrypted, masked_identifier, status, razorpay_contact_id, razorpay_fund_account_id,
+	razorpay_validation_id, registered_name, failure_reason, verified_at, created_at, updated_at`
+
+// PayoutStore persists owner payout accounts, encrypting account numbers and UPI IDs at rest
+type PayoutStore struct {
+	db     *sql.DB
+	cipher *encryption.Cipher
+}
+
+// New creates a new payout store
+func New(db *sql.DB, cipher *encryption.Cipher) PayoutStore {
+	return PayoutStore{db: db, cipher: cipher}
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// CreatePayoutAccount stores a new payout account in pending state
+func (s PayoutStore) CreatePayoutAccount(ctx context.Context, ownerID string, req models.PayoutAccountRequest) (models.PayoutAccount, error) {
+	tracer := otel.Tracer("PayoutStore")
+	ctx, span := tracer.Start(ctx, "CreatePayoutAccount-Store")
+	defer span.End()
+
+	var accountNumberEncrypted, vpaEncrypted *string
+	var ifsc *string
+	var masked string
+
+	switch req.AccountType {
+	case models.PayoutAccountTypeBank:
+		encrypted, err := s.cipher.Encrypt(req.AccountNumber)
+		if err != nil {
+			return models.PayoutAccount{}, err
+		}
+		upperIFSC := strings.ToUpper(req.IFSC)
+		accountNumberEncrypted = &encrypted
+		ifsc = &upperIFSC
+		masked = models.MaskAccountNumber(req.AccountNumber)
+	case models.PayoutAccountTypeVPA:
+		encrypted, err := s.cipher.Encrypt(req.VPA)
+		if err != nil {
+			return models.PayoutAccount{}, err
+		}
+		vpaEncrypted = &encrypted
+		masked = models.MaskVPA(req.VPA)
+	}
+
+	now := time.Now()
+	query := `INSERT INTO payout_account (id, owner_id, account_type, account_holder_name, ifsc,
+	         account_number_encrypted, vpa_encrypted, masked_identifier, status, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	         RETURNING ` + payoutAccountColumns
+
+	row := s.db.QueryRowContext(ctx, query, uuid.New(), ownerID, req.AccountType, req.AccountHolderName, ifsc,
+		accountNumberEncrypted, vpaEncrypted, masked, models.PayoutAccountStatusPending, now, now)
+
+	return s.scanPayoutAccount(row)
+}
+
+// GetPayoutAccountByID retrieves a payout account by its ID
+func (s PayoutStore) GetPayoutAccountByID(ctx context.Context, id string) (models.PayoutAccount, error) {
+	tracer := otel.Tracer("PayoutStore")
+	ctx, span := tracer.Start(ctx, "GetPayoutAccountByID-Store")
+	defer span.End()
+
+	query := `SELECT ` + payoutAccountColumns + ` FROM payout_account WHERE id = $1`
+
+	account, err := s.scanPayoutAccount(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PayoutAccount{}, errors.New("no payout account found with the given ID")
+		}
+		return models.PayoutAccount{}, err
+	}
+
+	return account, nil
+}
+
+// GetPayoutAccountsByOwnerID retrieves all payout accounts registered by an owner, newest first
+func (s PayoutStore) GetPayoutAccountsByOwnerID(ctx context.Context, ownerID string) ([]models.PayoutAccount, error) {
+	tracer := otel.Tracer("PayoutStore")
+	ctx, span := tracer.Start(ctx, "GetPayoutAccountsByOwnerID-Store")
+	defer span.End()
+
+	query := `SELECT ` + payoutAccountColumns + ` FROM payout_account WHERE owner_id = $1 ORDER BY created_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []models.PayoutAccount
+	for rows.Next() {
+		account, err := s.scanPayoutAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// UpdatePayoutAccountVerification records gateway identifiers and the verification outcome
+func (s PayoutStore) UpdatePayoutAccountVerification(ctx context.Context, id string, update models.PayoutVerificationUpdate) (models.PayoutAccount, error) {
+	tracer := otel.Tracer("PayoutStore")
+	ctx, span := tracer.Start(ctx, "UpdatePayoutAccountVerification-Store")
+	defer span.End()
+
+	var verifiedAt *time.Time
+	if update.Status == models.PayoutAccountStatusVerified {
+		now := time.Now()
+		verifiedAt = &now
+	}
+
+	// COALESCE keeps identifiers from earlier attempts when this update does not carry them
+	query := `UPDATE payout_account SET
+	         razorpay_contact_id = COALESCE($1, razorpay_contact_id),
+	         razorpay_fund_account_id = COALESCE($2, razorpay_fund_account_id),
+	         razorpay_validation_id = COALESCE($3, razorpay_validation_id),
+	         registered_name = COALESCE($4, registered_name),
+	         failure_reason = $5, status = $6, verified_at = COALESCE($7, verified_at), updated_at = $8
+	         WHERE id = $9
+	         RETURNING ` + payoutAccountColumns
+
+	row := s.db.QueryRowContext(ctx, query, update.RazorpayContactID, update.RazorpayFundAccountID,
+		update.RazorpayValidationID, update.RegisteredName, update.FailureReason, update.Status,
+		verifiedAt, time.Now(), id)
+
+	account, err := s.scanPayoutAccount(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PayoutAccount{}, errors.New("no payout account found with the given ID")
+		}
+		return models.PayoutAccount{}, err
+	}
+
+	return account, nil
+}
+
+// DeletePayoutAccount removes a payout account
+func (s PayoutStore) DeletePayoutAccount(ctx context.Context, id string) error {
+	tracer := otel.Tracer("PayoutStore")
+	ctx, span := tracer.Start(ctx, "DeletePayoutAccount-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, "DELETE FROM payout_account WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("no payout account found with the given ID")
+	}
+
+	return nil
+}
+
+// CreatePayoutHold freezes an owner's payouts for a dispute. A dispute holds payouts once, so
+// repeated calls are ignored.
+func (s PayoutStore) CreatePayoutHold(ctx context.Context, hold models.PayoutHold) error {
+	tracer := otel.Tracer("PayoutStore")
+	ctx, span := tracer.Start(ctx, "CreatePayoutHold-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO payout_hold (id, owner_id, dispute_id, booking_id, amount, created_at)
+	         VALUES ($1, $2, $3, $4, $5, $6)
+	         ON CONFLICT (dispute_id) DO NOTHING`,
+		uuid.New(), hold.OwnerID, hold.DisputeID, hold.BookingID, hold.Amount, time.Now())
+	return err
+}
+
+// ReleasePayoutHold lifts the hold a dispute placed on payouts, if it is still active
+func (s PayoutStore) ReleasePayoutHold(ctx context.Context, disputeID uuid.UUID) error {
+	tracer := otel.Tracer("PayoutStore")
+	ctx, span := tracer.Start(ctx, "ReleasePayoutHold-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `UPDATE payout_hold SET released_at = $2 WHERE dispute_id = $1 AND released_at IS NULL`,
+		disputeID, time.Now())
+	return err
+}
+
+// GetPayoutHoldsByOwnerID retrieves an owner's payout holds, active ones first, newest first
+func (s PayoutStore) GetPayoutHoldsByOwnerID(ctx context.Context, ownerID string) ([]models.PayoutHold, error) {
+	tracer := otel.Tracer("PayoutStore")
+	ctx, span := tracer.Start(ctx, "GetPayoutHoldsByOwnerID-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, owner_id, dispute_id, booking_id, amount, created_at, released_at
+	         FROM payout_hold WHERE owner_id = $1
+	         ORDER BY released_at IS NOT NULL, created_at DESC`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holds := []models.PayoutHold{}
+	for rows.Next() {
+		var hold models.PayoutHold
+		if err := rows.Scan(&hold.ID, &hold.OwnerID, &hold.DisputeID, &hold.BookingID, &hold.Amount,
+			&hold.CreatedAt, &hold.ReleasedAt); err != nil {
+			return nil, err
+		}
+		holds = append(holds, hold)
+	}
+
+	return holds, rows.Err()
+}
+
+// scanPayoutAccount reads one row and decrypts the sensitive columns
+func (s PayoutStore) scanPayoutAccount(row rowScanner) (models.PayoutAccount, error) {
+	var account models.PayoutAccount
+	var ifsc, accountNumberEncrypted, vpaEncrypted sql.NullString
+
+	err := row.Scan(&account.ID, &account.OwnerID, &account.AccountType, &account.AccountHolderName,
+		&ifsc, &accountNumberEncrypted, &vpaEncrypted, &account.MaskedIdentifier, &account.Status,
+		&account.RazorpayContactID, &account.RazorpayFundAccountID, &account.RazorpayValidationID,
+		&account.RegisteredName, &account.FailureReason, &account.VerifiedAt,
+		&account.CreatedAt, &account.UpdatedAt)
+	if err != nil {
+		return models.PayoutAccount{}, err
+	}
+
+	account.IFSC = ifsc.String
+	if accountNumberEncrypted.Valid {
+		if account.AccountNumber, err = s.cipher.Decrypt(accountNumberEncrypted.String); err != nil {
+			return models.PayoutAccount{}, err
+		}
+	}
+	if vpaEncrypted.Valid {
+		if account.VPA, err = s.cipher.Decrypt(vpaEncrypted.String); err != nil {
+			return models.PayoutAccount{}, err
+		}
+	}
+
+	return account, nil
+}
+
+// RotateEncryptionKeys re-encrypts account numbers and UPI IDs that were sealed with
+// a retired key. Rows changed concurrently are skipped and picked up on the next run.
+// Returns the number of rows rewritten.
+func (s PayoutStore) RotateEncryptionKeys(ctx context.Context) (int, error) {
+	tracer := otel.Tracer("PayoutStore")
+	ctx, span := tracer.Start(ctx, "RotateEncryptionKeys-Store")
+	defer span.End()
+
+	type storedValues struct {
+		id            string
+		accountNumber sql.NullString
+		vpa           sql.NullString
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT id, account_number_encrypted, vpa_encrypted FROM payout_account")
+	if err != nil {
+		return 0, err
+	}
+
+	var stale []storedValues
+	for rows.Next() {
+		var v storedValues
+		if err := rows.Scan(&v.id, &v.accountNumber, &v.vpa); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if s.cipher.NeedsRotation(v.accountNumber.String) || s.cipher.NeedsRotation(v.vpa.String) {
+			stale = append(stale, v)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	rotated := 0
+	for _, v := range stale {
+		accountNumber, err := rotateNullable(s.cipher, v.accountNumber)
+		if err != nil {
+			return rotated, err
+		}
+		vpa, err := rotateNullable(s.cipher, v.vpa)
+		if err != nil {
+			return rotated, err
+		}
+
+		// Only overwrite the values that were read so a concurrent update is never lost
+		result, err := s.db.ExecContext(ctx, `UPDATE payout_account
+		         SET account_number_encrypted = $1, vpa_encrypted = $2
+		         WHERE id = $3 AND account_number_encrypted IS NOT DISTINCT FROM $4 AND vpa_encrypted IS NOT DISTINCT FROM $5`,
+			accountNumber, vpa, v.id, v.accountNumber, v.vpa)
+		if err != nil {
+			return rotated, err
+		}
+		if n, err := result.RowsAffected(); err == nil && n > 0 {
+			rotated++
+		}
+	}
+
+	return rotated, nil
+}
+
+// rotateNullable re-encrypts a nullable column value with the active key
+func rotateNullable(c *encryption.Cipher, value sql.NullString) (sql.NullString, error) {
+	if !value.Valid {
+		return value, nil
+	}
+	rotated, err := c.Rotate(value.String)
+	if err != nil {
+		return value, err
+	}
+	return sql.NullString{String: rotated, Valid: true}, nil
+}
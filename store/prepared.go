@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+)
+
+// PreparedQuery is a hot query parsed once by PostgreSQL when its store is created, rather
+// than on every call. database/sql prepares it again on each new pooled connection by itself.
+// When preparing fails, for example because the schema has not been created yet, the query
+// is sent unprepared and preparing is retried on the next call.
+type PreparedQuery struct {
+	db         *sql.DB
+	query      string
+	unprepared bool // Created while PrepareQueries was off
+
+	mu   sync.Mutex
+	stmt *sql.Stmt
+}
+
+// PrepareQueries turns preparing on for the stores created afterwards. The integration
+// benchmarks turn it off to measure what preparing saves.
+var PrepareQueries = true
+
+// Prepare prepares a query for a store. It never fails; see PreparedQuery.
+func Prepare(db *sql.DB, query string) *PreparedQuery {
+	q := &PreparedQuery{db: db, query: query, unprepared: !PrepareQueries}
+	q.statement(context.Background())
+	return q
+}
+
+// QueryRowContext runs the query with the given arguments, expecting at most one row
+func (q *PreparedQuery) QueryRowContext(ctx context.Context, args ...interface{}) *sql.Row {
+	if stmt := q.statement(ctx); stmt != nil {
+		return stmt.QueryRowContext(ctx, args...)
+	}
+	return q.db.QueryRowContext(ctx, q.query, args...)
+}
+
+// QueryContext runs the query with the given arguments
+func (q *PreparedQuery) QueryContext(ctx context.Context, args ...interface{}) (*sql.Rows, error) {
+	if stmt := q.statement(ctx); stmt != nil {
+		return stmt.QueryContext(ctx, args...)
+	}
+	return q.db.QueryContext(ctx, q.query, args...)
+}
+
+// statement returns the prepared statement, preparing it if that has not succeeded yet
+func (q *PreparedQuery) statement(ctx context.Context) *sql.Stmt {
+	if q.unprepared {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.stmt == nil {
+		stmt, err := q.db.PrepareContext(ctx, q.query)
+		if err != nil {
+			log.Printf("Failed to prepare query, running it unprepared: %v", err)
+			return nil
+		}
+		q.stmt = stmt
+	}
+	return q.stmt
+}
@@ -0,0 +1,123 @@
+package refreshtoken
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// refreshTokenColumns lists the columns read by every refresh token query, in scanRefreshToken order
+const refreshTokenColumns = `id, user_id, family_id, expires_at, rotated_at, revoked_at, created_at`
+
+// RefreshTokenStore persists refresh tokens by the hash of their token
+type RefreshTokenStore struct {
+	db *sql.DB
+}
+
+// New creates a new refresh token store
+func New(db *sql.DB) RefreshTokenStore {
+	return RefreshTokenStore{db: db}
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// CreateRefreshToken stores the first token of a new family, issued at login. The user's
+// tokens that expired are removed.
+func (s RefreshTokenStore) CreateRefreshToken(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (models.RefreshToken, error) {
+	tracer := otel.Tracer("RefreshTokenStore")
+	ctx, span := tracer.Start(ctx, "CreateRefreshToken-Store")
+	defer span.End()
+
+	now := time.Now()
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1 AND expires_at < $2`, userID, now); err != nil {
+		return models.RefreshToken{}, err
+	}
+
+	query := `INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+	         VALUES ($1, $2, $3, $4, $5, $6)
+	         RETURNING ` + refreshTokenColumns
+
+	return scanRefreshToken(s.db.QueryRowContext(ctx, query, uuid.New(), userID, uuid.New(), tokenHash, expiresAt, now))
+}
+
+// GetRefreshToken retrieves a token by its hash, whatever its state
+func (s RefreshTokenStore) GetRefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	tracer := otel.Tracer("RefreshTokenStore")
+	ctx, span := tracer.Start(ctx, "GetRefreshToken-Store")
+	defer span.End()
+
+	token, err := scanRefreshToken(s.db.QueryRowContext(ctx,
+		`SELECT `+refreshTokenColumns+` FROM refresh_tokens WHERE token_hash = $1`, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.RefreshToken{}, errors.New("refresh token is invalid or has expired")
+		}
+		return models.RefreshToken{}, err
+	}
+
+	return token, nil
+}
+
+// RotateRefreshToken uses up a token and stores its successor in the same family. A token
+// rotates once: of two requests presenting it, the second gets an error.
+func (s RefreshTokenStore) RotateRefreshToken(ctx context.Context, id uuid.UUID, newTokenHash string, expiresAt time.Time) (token models.RefreshToken, err error) {
+	tracer := otel.Tracer("RefreshTokenStore")
+	ctx, span := tracer.Start(ctx, "RotateRefreshToken-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return token, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// Marking the token rotated in the same statement that checks it keeps two requests from both using it
+	now := time.Now()
+	var userID, familyID uuid.UUID
+	err = tx.QueryRowContext(ctx, `UPDATE refresh_tokens SET rotated_at = $2
+	         WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL AND expires_at > $2
+	         RETURNING user_id, family_id`, id, now).Scan(&userID, &familyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return token, errors.New("refresh token was already used")
+		}
+		return token, err
+	}
+
+	token, err = scanRefreshToken(tx.QueryRowContext(ctx, `INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+	         VALUES ($1, $2, $3, $4, $5, $6)
+	         RETURNING `+refreshTokenColumns, uuid.New(), userID, familyID, newTokenHash, expiresAt, now))
+	return token, err
+}
+
+// RevokeRefreshTokenFamily ends a session: no token rotated from the same login works any more
+func (s RefreshTokenStore) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	tracer := otel.Tracer("RefreshTokenStore")
+	ctx, span := tracer.Start(ctx, "RevokeRefreshTokenFamily-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = $2
+	         WHERE family_id = $1 AND revoked_at IS NULL`, familyID, time.Now())
+	return err
+}
+
+// scanRefreshToken reads one refresh_tokens row
+func scanRefreshToken(row rowScanner) (models.RefreshToken, error) {
+	var t models.RefreshToken
+	err := row.Scan(&t.ID, &t.UserID, &t.FamilyID, &t.ExpiresAt, &t.RotatedAt, &t.RevokedAt, &t.CreatedAt)
+	return t, err
+}
@@ -7,15 +7,102 @@
 -- =============================================================================
 
 -- Drop existing tables if they exist (for complete reset)
+DROP TABLE IF EXISTS car_model CASCADE;
+DROP TABLE IF EXISTS car_brand CASCADE;
+DROP TABLE IF EXISTS car_attribute CASCADE;
+DROP TABLE IF EXISTS setting CASCADE;
+DROP TABLE IF EXISTS feature CASCADE;
+DROP TABLE IF EXISTS fleet CASCADE;
+DROP TABLE IF EXISTS car_blackout CASCADE;
+DROP TABLE IF EXISTS owner_vacation CASCADE;
+DROP TABLE IF EXISTS email_template CASCADE;
+DROP TABLE IF EXISTS notification CASCADE;
+DROP TABLE IF EXISTS warehouse_export_watermark CASCADE;
+DROP TABLE IF EXISTS car_alert_subscription CASCADE;
+DROP TABLE IF EXISTS car_favorite CASCADE;
+DROP TABLE IF EXISTS car_image_upload CASCADE;
+DROP TABLE IF EXISTS car_purchase CASCADE;
+DROP TABLE IF EXISTS car_price_history CASCADE;
+DROP TABLE IF EXISTS relocation_fee CASCADE;
+DROP TABLE IF EXISTS car_fuel_policy CASCADE;
+DROP TABLE IF EXISTS car_eligibility CASCADE;
+DROP TABLE IF EXISTS user_kyc CASCADE;
+DROP TABLE IF EXISTS featured_listing CASCADE;
+DROP TABLE IF EXISTS user_block CASCADE;
+DROP TABLE IF EXISTS maintenance_job CASCADE;
+DROP TABLE IF EXISTS debug_capture CASCADE;
+DROP TABLE IF EXISTS loadtest_run CASCADE;
+DROP TABLE IF EXISTS car_delivery_option CASCADE;
+DROP TABLE IF EXISTS car_location CASCADE;
+DROP TABLE IF EXISTS location CASCADE;
+DROP TABLE IF EXISTS geofence_breach CASCADE;
+DROP TABLE IF EXISTS geofence CASCADE;
+DROP TABLE IF EXISTS booking_inspection CASCADE;
+DROP TABLE IF EXISTS checklist_template CASCADE;
+DROP TABLE IF EXISTS car_telemetry CASCADE;
+DROP TABLE IF EXISTS telemetry_device CASCADE;
+DROP TABLE IF EXISTS incident_document CASCADE;
+DROP TABLE IF EXISTS incident CASCADE;
+DROP TABLE IF EXISTS lock_event CASCADE;
+DROP TABLE IF EXISTS digital_key CASCADE;
+DROP TABLE IF EXISTS car_smart_lock CASCADE;
+DROP TABLE IF EXISTS refresh_tokens CASCADE;
+DROP TABLE IF EXISTS password_reset_tokens CASCADE;
+DROP TABLE IF EXISTS user_login_device CASCADE;
+DROP TABLE IF EXISTS security_event CASCADE;
+DROP TABLE IF EXISTS payment_statement CASCADE;
+DROP TABLE IF EXISTS payment_authorization CASCADE;
+DROP TABLE IF EXISTS dashboard_refresh_state CASCADE;
+DROP TABLE IF EXISTS dashboard_daily_metric CASCADE;
+DROP TABLE IF EXISTS financial_document CASCADE;
+DROP TABLE IF EXISTS booking_adjustment CASCADE;
+DROP TABLE IF EXISTS document_sequence CASCADE;
+DROP TABLE IF EXISTS payout_hold CASCADE;
+DROP TABLE IF EXISTS dispute_evidence CASCADE;
+DROP TABLE IF EXISTS dispute CASCADE;
+DROP TABLE IF EXISTS payout_account CASCADE;
+DROP TABLE IF EXISTS payment_link CASCADE;
+DROP TABLE IF EXISTS payment_collection CASCADE;
+DROP TABLE IF EXISTS payment_attempt CASCADE;
 DROP TABLE IF EXISTS payment CASCADE;
 DROP TABLE IF EXISTS booking CASCADE;
 DROP TABLE IF EXISTS car CASCADE;
 DROP TABLE IF EXISTS users CASCADE;
+DROP TABLE IF EXISTS operator CASCADE;
+
+-- Trigram matching for case-insensitive and typo-tolerant brand searches
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Equality on UUIDs inside GiST indexes, for the booking overlap exclusion constraint
+CREATE EXTENSION IF NOT EXISTS btree_gist;
 
 -- =============================================================================
 -- TABLE DEFINITIONS
 -- =============================================================================
 
+-- Operator Table Definition
+-- Marketplace operators (tenants); each white-label deployment is one operator
+CREATE TABLE operator (
+    -- Primary key: Unique identifier for each operator
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Operator identity and white-label settings
+    slug VARCHAR(50) NOT NULL CONSTRAINT unique_operator_slug UNIQUE, -- URL-friendly identifier
+    name VARCHAR(100) NOT NULL,                                  -- Marketplace display name
+    domain VARCHAR(255) CONSTRAINT unique_operator_domain UNIQUE, -- White-label host name (NULL for none)
+    branding JSONB NOT NULL DEFAULT '{}',                        -- Branding: {logo_url, primary_color, support_email}
+    commission_rate DECIMAL(5,4) NOT NULL DEFAULT 0,             -- Platform share of booking totals
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,                     -- Inactive operators' domains stop serving
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,              -- Record creation timestamp
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP               -- Last update timestamp
+);
+
+-- The default operator owns single-operator deployments; its admins are platform admins
+INSERT INTO operator (id, slug, name) VALUES
+    ('00000000-0000-0000-0000-000000000001', 'default', 'CarZone');
+
 -- Users Table Definition
 -- Stores user account information for authentication and authorization
 CREATE TABLE users (
@@ -24,15 +111,29 @@ CREATE TABLE users (
     
     -- User account information
     username VARCHAR(255) NOT NULL,                              -- User's username
-    email VARCHAR(255) NOT NULL UNIQUE,                          -- User's email address (unique)
+    email VARCHAR(255) NOT NULL,                                 -- User's email address (unique)
     password_hash VARCHAR(255) NOT NULL,                         -- Hashed password for security
-    phone VARCHAR(20),                                           -- User's phone number
+    phone TEXT,                                                  -- User's phone number (application-encrypted)
+    phone_hash VARCHAR(64),                                      -- Keyed hash of the phone number for admin search and uniqueness
+    license_number TEXT NOT NULL DEFAULT '',                     -- Driving licence number (application-encrypted, optional)
     role VARCHAR(50) DEFAULT 'user',                            -- User role (user, admin, owner)
     profile_data JSONB,                                          -- Additional profile information as JSON
+    operator_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001', -- Reference to operator.id (tenant)
+
+    -- TOTP two-factor authentication, enforced at login once totp_enabled_at is set
+    totp_secret TEXT,                                            -- Base32 secret (application-encrypted); pending until confirmed
+    totp_enabled_at TIMESTAMP,                                   -- When a code confirmed the secret
+    totp_last_step BIGINT,                                       -- Time step of the last accepted code, so codes work once
+    totp_backup_codes TEXT[],                                    -- SHA-256 hashes of unused backup codes
     
     -- Audit trail columns for tracking changes
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,              -- Account creation timestamp
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP               -- Last update timestamp
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,              -- Last update timestamp
+
+    -- One account per e-mail address and per phone number. Phone numbers are encrypted, so their
+    -- blind index is unique instead; it is NULL, and unchecked, while no index key is configured.
+    CONSTRAINT unique_users_email UNIQUE (email),
+    CONSTRAINT unique_users_phone_hash UNIQUE (phone_hash)
 );
 
 -- Car Table Definition  
@@ -44,6 +145,7 @@ CREATE TABLE car (
     
     -- Car ownership and basic information
     owner_id UUID,                                               -- Reference to users.id (nullable for system cars)
+    operator_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001', -- Reference to operator.id (tenant)
     name VARCHAR(255) NOT NULL,                                  -- Display name/model of the car
     brand VARCHAR(255) NOT NULL,                                 -- Manufacturer brand (e.g., "Toyota", "Tesla")
     model VARCHAR(255) NOT NULL,                                 -- Specific model name
@@ -62,112 +164,1892 @@ CREATE TABLE car (
     price DECIMAL(10,2) NOT NULL,                               -- Daily rental price
     
     -- Status and availability
-    status VARCHAR(50) DEFAULT 'active',                         -- active, maintenance, inactive
+    status VARCHAR(50) DEFAULT 'draft',                          -- draft, pending_review, active, maintenance, retired
     availability_type VARCHAR(50) NOT NULL DEFAULT 'rental',     -- rental only
-    is_available BOOLEAN DEFAULT true,                           -- Current availability status
+    is_available BOOLEAN DEFAULT false,                          -- Bookable right now; only true while the car is active
     
     -- Additional information
     features JSONB,                                              -- Car features as JSON (GPS, AC, etc.)
+    attributes JSONB NOT NULL DEFAULT '{}',                      -- Values of the operator's custom attributes, keyed by car_attribute.key
     description TEXT,                                            -- Detailed description
     images TEXT[],                                               -- Array of image URLs
     mileage INTEGER DEFAULT 0,                                   -- Current mileage
+    slug VARCHAR(255) NOT NULL UNIQUE,                           -- URL-friendly identifier, filled by trigger on insert
+    license_plate VARCHAR(20) CONSTRAINT unique_car_license_plate UNIQUE, -- Normalized registration plate (optional)
+    fleet_id UUID,                                               -- Reference to fleet.id (settings inherited at quote time)
     
     -- Audit trail columns
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,              -- Record creation timestamp
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP               -- Last update timestamp
 );
 
--- Booking Table Definition
--- Stores booking information for car rentals and sales
-CREATE TABLE booking (
-    -- Primary key: Unique identifier for each booking
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    
-    -- Relationship fields
-    customer_id UUID NOT NULL,                                   -- Reference to users.id (customer)
-    car_id UUID NOT NULL,                                        -- Reference to car.id
-    owner_id UUID,                                               -- Reference to users.id (car owner, nullable for system cars)
-    
-    -- Booking details (all bookings are rentals)
-    status VARCHAR(50) DEFAULT 'pending',                        -- pending, confirmed, active, completed, cancelled
-    total_amount DECIMAL(10,2) NOT NULL,                         -- Total booking amount
-    start_date TIMESTAMP NOT NULL,                               -- Start date for rental
-    end_date TIMESTAMP NOT NULL,                                 -- End date for rental
-    notes TEXT,                                                  -- Additional notes or special requests
-    
-    -- Audit trail columns
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,              -- Booking creation timestamp
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP               -- Last update timestamp
-);
+-- Booking Table Definition
+-- Stores booking information for car rentals and sales
+CREATE TABLE booking (
+    -- Primary key: Unique identifier for each booking
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    
+    -- Relationship fields
+    customer_id UUID NOT NULL,                                   -- Reference to users.id (customer)
+    car_id UUID NOT NULL,                                        -- Reference to car.id
+    owner_id UUID,                                               -- Reference to users.id (car owner, nullable for system cars)
+    operator_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001', -- Reference to operator.id (tenant)
+    
+    -- Booking details (all bookings are rentals)
+    status VARCHAR(50) DEFAULT 'pending',                        -- pending, under_review, confirmed, in_progress, completed, cancelled
+    total_amount DECIMAL(10,2) NOT NULL,                         -- Total booking amount
+    start_date TIMESTAMP NOT NULL,                               -- Start date for rental
+    end_date TIMESTAMP NOT NULL,                                 -- End date for rental
+    notes TEXT,                                                  -- Additional notes or special requests
+
+    -- Pickup/drop-off points and their fees (included in total_amount)
+    pickup_location_id UUID,                                     -- Reference to location.id
+    dropoff_location_id UUID,                                    -- Reference to location.id
+    pickup_fee DECIMAL(10,2) NOT NULL DEFAULT 0,                 -- Pickup fee charged at booking time
+    dropoff_fee DECIMAL(10,2) NOT NULL DEFAULT 0,                -- Drop-off fee charged at booking time
+
+    -- Door delivery instead of a pickup location (fee included in total_amount)
+    delivery_address TEXT,                                       -- Geocoded delivery address
+    delivery_latitude DECIMAL(9,6),                              -- Geocoded latitude of the address
+    delivery_longitude DECIMAL(9,6),                             -- Geocoded longitude of the address
+    delivery_distance_km DECIMAL(6,1),                           -- Distance from the car's delivery origin
+    delivery_fee DECIMAL(10,2) NOT NULL DEFAULT 0,               -- Delivery fee charged at booking time
+    relocation_fee DECIMAL(10,2) NOT NULL DEFAULT 0,             -- One-way fee when returned in another city
+    add_ons JSONB NOT NULL DEFAULT '[]',                         -- Fleet add-ons chosen: [{code, name, amount}]
+    add_ons_fee DECIMAL(10,2) NOT NULL DEFAULT 0,                -- Sum of the chosen add-ons
+    prepaid_fuel_fee DECIMAL(10,2) NOT NULL DEFAULT 0,           -- Full tank paid up front under the prepaid fuel policy
+    late_fee DECIMAL(10,2) NOT NULL DEFAULT 0,                   -- Charged at check-in for a late return
+    refuel_fee DECIMAL(10,2) NOT NULL DEFAULT 0,                 -- Charged at check-in for fuel missing under full-to-full
+    overdue_at TIMESTAMP,                                        -- Set when still in progress after end_date
+    expiry_warned_at TIMESTAMP,                                  -- Set when the customer was reminded to pay an unpaid pending booking
+    parent_booking_id UUID,                                      -- Reference to booking.id; set on extensions of a trip
+    handback_reminded_for TIMESTAMP,                             -- Trip end the customer was last reminded to hand the car back by
+    car_snapshot JSONB,                                          -- Car as booked: {name, brand, model, year, price, images, slug}
+    customer_snapshot JSONB,                                     -- Customer as they booked: {name, email}
+    owner_snapshot JSONB,                                        -- Owner when the car was booked: {name, email}
+    
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,              -- Booking creation timestamp
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP               -- Last update timestamp
+);
+
+-- Payment Table Definition
+-- Stores payment information for bookings with Razorpay integration
+CREATE TABLE payment (
+    -- Primary key: Unique identifier for each payment
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    
+    -- Relationship fields
+    booking_id UUID NOT NULL,                                    -- Reference to booking.id
+    operator_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001', -- Reference to operator.id (tenant)
+    
+    -- Razorpay specific fields
+    razorpay_order_id VARCHAR(255),                             -- Razorpay order ID
+    razorpay_payment_id VARCHAR(255),                           -- Razorpay payment ID
+    
+    -- Payment details
+    amount DECIMAL(10,2) NOT NULL,                              -- Payment amount in INR
+    currency VARCHAR(3) DEFAULT 'INR',                          -- Currency code
+    status VARCHAR(50) DEFAULT 'pending',                       -- pending, authorized, completed, failed, refunded, cancelled, voided
+    method VARCHAR(50) NOT NULL,                                -- razorpay, cash, card, upi, netbanking
+    transaction_id VARCHAR(255),                                -- Transaction reference ID
+    description TEXT,                                           -- Payment description
+    notes TEXT,                                                 -- Additional payment notes
+    
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- Payment creation timestamp
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- Last update timestamp
+
+    -- A Razorpay order pays for exactly one payment, so webhooks find a single row
+    CONSTRAINT unique_payment_razorpay_order_id UNIQUE (razorpay_order_id)
+);
+
+-- Payment Link Table Definition
+-- Stores Razorpay Payment Links sent to customers for bookings taken over the phone
+CREATE TABLE payment_link (
+    -- Primary key: Unique identifier for each payment link
+    id UUID PRIMARY KEY,
+
+    -- Relationship fields
+    payment_id UUID NOT NULL,                                   -- Reference to payment.id (settled by the link)
+    booking_id UUID NOT NULL,                                   -- Reference to booking.id
+
+    -- Link details
+    razorpay_link_id VARCHAR(255) NOT NULL UNIQUE,              -- Razorpay payment link ID (plink_...)
+    short_url TEXT NOT NULL,                                    -- Hosted checkout URL sent to the customer
+    amount DECIMAL(10,2) NOT NULL,                              -- Amount requested in INR
+    status VARCHAR(20) NOT NULL DEFAULT 'created',              -- created, paid, expired, cancelled
+    expires_at TIMESTAMP NOT NULL,                              -- When Razorpay stops accepting payment
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- Link creation timestamp
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- Last webhook update timestamp
+);
+
+-- Payment Attempt Table Definition
+-- Every gateway attempt of a payment, one per Razorpay order; the payment row only holds the latest
+CREATE TABLE payment_attempt (
+    -- Primary key: Unique identifier for each attempt
+    id UUID PRIMARY KEY,
+
+    -- Relationship fields
+    payment_id UUID NOT NULL,                                   -- Reference to payment.id
+
+    -- Gateway details of the attempt
+    razorpay_order_id VARCHAR(255) NOT NULL UNIQUE,             -- Razorpay order the attempt was made on
+    razorpay_payment_id VARCHAR(255),                           -- Razorpay payment ID, once the customer paid
+    status VARCHAR(50) NOT NULL DEFAULT 'pending',              -- pending, authorized, completed, failed
+    error_code VARCHAR(100),                                    -- Razorpay error code, or SIGNATURE_MISMATCH
+    error_description TEXT,                                     -- Razorpay error description
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- When the order was created
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- When the attempt last changed status
+);
+
+-- Payment Collection Table Definition
+-- Audit trail of offline payments (cash, card machine, UPI) collected in person by owners or admins
+CREATE TABLE payment_collection (
+    -- Primary key: Unique identifier for each collection
+    id UUID PRIMARY KEY,
+
+    -- Relationship fields
+    payment_id UUID NOT NULL UNIQUE,                            -- Reference to payment.id (collected once)
+    booking_id UUID NOT NULL,                                   -- Reference to booking.id
+    collected_by UUID,                                          -- Reference to users.id (owner or admin)
+
+    -- Collection details
+    amount DECIMAL(10,2) NOT NULL,                              -- Amount collected in INR
+    receipt_number VARCHAR(64) NOT NULL,                        -- Receipt handed to the customer
+    notes TEXT NOT NULL DEFAULT '',                             -- Collector's notes
+    collected_at TIMESTAMP NOT NULL,                            -- When the money changed hands
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- When the collection was recorded
+);
+
+-- Dispute Table Definition
+-- Chargebacks customers raised with their bank against a payment, as reported by Razorpay webhooks
+CREATE TABLE dispute (
+    -- Primary key: Unique identifier for each dispute
+    id UUID PRIMARY KEY,
+
+    -- Relationship fields
+    payment_id UUID NOT NULL,                                   -- Reference to payment.id
+    booking_id UUID NOT NULL,                                   -- Reference to booking.id
+    owner_id UUID,                                              -- Owner whose payouts are held, reference to users.id
+
+    -- Razorpay's dispute details
+    razorpay_dispute_id VARCHAR(255) NOT NULL UNIQUE,           -- Razorpay dispute ID (disp_...)
+    amount DECIMAL(10,2) NOT NULL,                              -- Disputed amount in INR
+    reason_code VARCHAR(100) NOT NULL DEFAULT '',               -- Card network reason code
+    reason_description TEXT NOT NULL DEFAULT '',                -- Reason given by the bank
+    phase VARCHAR(50) NOT NULL DEFAULT '',                      -- chargeback, pre_arbitration, arbitration
+    status VARCHAR(50) NOT NULL DEFAULT 'open',                 -- open, under_review, won, lost, closed
+    respond_by TIMESTAMP,                                       -- Deadline for submitting evidence
+
+    -- Outcome
+    outcome_notes TEXT NOT NULL DEFAULT '',                     -- Notes recorded by the admin who closed it
+    resolved_at TIMESTAMP,                                      -- When it was won, lost or closed
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- When the dispute was first reported
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- When the dispute last changed
+);
+
+-- Dispute Evidence Table Definition
+-- Documents admins attached to contest a dispute
+CREATE TABLE dispute_evidence (
+    -- Primary key: Unique identifier for each document
+    id UUID PRIMARY KEY,
+
+    -- Relationship fields
+    dispute_id UUID NOT NULL,                                   -- Reference to dispute.id
+    added_by UUID NOT NULL,                                     -- Admin who attached it, reference to users.id
+
+    -- Evidence details
+    kind VARCHAR(50) NOT NULL,                                  -- invoice, agreement, inspection, communication, other
+    description TEXT NOT NULL,                                  -- What the document shows
+    document_url TEXT NOT NULL DEFAULT '',                      -- Where the document is stored
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- When it was attached
+);
+
+-- Payout Hold Table Definition
+-- Freezes an owner's payouts while a dispute on one of their bookings is open
+CREATE TABLE payout_hold (
+    -- Primary key: Unique identifier for each hold
+    id UUID PRIMARY KEY,
+
+    -- Relationship fields
+    owner_id UUID NOT NULL,                                     -- Owner whose payouts are held, reference to users.id
+    dispute_id UUID NOT NULL UNIQUE,                            -- Dispute that placed the hold, reference to dispute.id
+    booking_id UUID NOT NULL,                                   -- Disputed booking, reference to booking.id
+
+    -- Hold details
+    amount DECIMAL(10,2) NOT NULL,                              -- Disputed amount in INR
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- When payouts were frozen
+    released_at TIMESTAMP                                       -- When the dispute was resolved, NULL while active
+);
+
+-- Booking Adjustment Table Definition
+-- Credit notes and manual charges admins raise against bookings, with second-admin approval
+CREATE TABLE booking_adjustment (
+    -- Primary key: Unique identifier for each adjustment
+    id UUID PRIMARY KEY,
+
+    -- Relationship fields
+    booking_id UUID NOT NULL,                                   -- Reference to booking.id
+    requested_by UUID NOT NULL,                                 -- Admin who raised it, reference to users.id
+    reviewed_by UUID,                                           -- Second admin who approved or rejected it
+    payment_id UUID,                                            -- Payment requested for a charge
+
+    -- Adjustment details
+    kind VARCHAR(20) NOT NULL,                                  -- credit_note, charge
+    amount DECIMAL(10,2) NOT NULL,                              -- Amount in INR, always positive
+    reason_code VARCHAR(50) NOT NULL,                           -- billing_error, service_issue, goodwill, damage, ...
+    notes TEXT NOT NULL DEFAULT '',                             -- Explanation by the raising admin
+    status VARCHAR(20) NOT NULL DEFAULT 'pending_approval',     -- pending_approval, applied, rejected
+    review_note TEXT NOT NULL DEFAULT '',                       -- Explanation by the reviewing admin
+    document_number VARCHAR(50),                                -- Number of the credit note
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- When it was raised
+    applied_at TIMESTAMP                                        -- When the credit note or payment was issued
+);
+
+-- Document Sequence Table Definition
+-- Counters numbering invoices and receipts; rows are locked while a document is issued
+CREATE TABLE document_sequence (
+    series VARCHAR(10) NOT NULL,                                -- INV, RCP, CN
+    fiscal_year VARCHAR(7) NOT NULL,                            -- Indian fiscal year, e.g. 2024-25
+    last_value BIGINT NOT NULL DEFAULT 0,                       -- Last number issued in the series and year
+
+    PRIMARY KEY (series, fiscal_year)
+);
+
+-- Financial Document Table Definition
+-- Numbered invoices of bookings, receipts of payments and credit notes; never changed once issued
+CREATE TABLE financial_document (
+    -- Primary key: Unique identifier for each document
+    id UUID PRIMARY KEY,
+
+    -- Numbering
+    series VARCHAR(10) NOT NULL,                                -- INV, RCP, CN
+    fiscal_year VARCHAR(7) NOT NULL,                            -- Indian fiscal year, e.g. 2024-25
+    sequence BIGINT NOT NULL,                                   -- Position in the series within the year
+    number VARCHAR(50) NOT NULL UNIQUE,                         -- Printed number, e.g. INV/2024-25/000042
+
+    -- Relationship fields
+    booking_id UUID NOT NULL,                                   -- Reference to booking.id
+    payment_id UUID,                                            -- Reference to payment.id, set on receipts
+    adjustment_id UUID,                                         -- Reference to booking_adjustment.id, set on credit notes
+
+    -- Document details
+    amount DECIMAL(10,2) NOT NULL,                              -- Invoiced or received amount in INR
+
+    -- Audit trail columns
+    issued_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,              -- When the number was issued
+
+    UNIQUE (series, fiscal_year, sequence)
+);
+
+-- Payment Statement Table Definition
+-- Statements of a customer's payments queued for background rendering because their period was long
+CREATE TABLE payment_statement (
+    -- Primary key: Unique identifier for each statement
+    id UUID PRIMARY KEY,
+
+    -- Relationship fields
+    user_id UUID NOT NULL,                                      -- Customer, reference to users.id
+
+    -- Request
+    period_from DATE NOT NULL,                                  -- First day covered
+    period_to DATE NOT NULL,                                    -- Day after the last day covered
+    format VARCHAR(10) NOT NULL,                                -- pdf, csv
+
+    -- Rendering
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',              -- pending, rendering, ready, failed
+    content BYTEA,                                              -- Rendered file once ready
+    error TEXT NOT NULL DEFAULT '',                             -- Why rendering failed
+    claimed_at TIMESTAMP,                                       -- When the render job took it
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- When it was requested
+    completed_at TIMESTAMP                                      -- When it became ready or failed
+);
+
+-- Payment Authorization Table Definition
+-- Payments held on the customer's card when they pay and captured at pickup, or voided when the
+-- booking is cancelled first
+CREATE TABLE payment_authorization (
+    -- Primary key: Unique identifier for each authorization
+    id UUID PRIMARY KEY,
+
+    -- Relationship fields
+    payment_id UUID NOT NULL UNIQUE,                            -- Reference to payment.id, one hold per payment
+    booking_id UUID NOT NULL,                                   -- Reference to booking.id
+
+    -- Hold details
+    amount DECIMAL(10,2) NOT NULL,                              -- Amount held in INR
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',              -- pending, authorized, captured, voided
+    razorpay_payment_id VARCHAR(255),                           -- Razorpay payment holding the amount
+    expires_at TIMESTAMP,                                       -- When Razorpay releases the hold uncaptured
+
+    -- Audit trail columns
+    authorized_at TIMESTAMP,                                    -- When the customer paid
+    captured_at TIMESTAMP,                                      -- When it was captured at pickup
+    voided_at TIMESTAMP,                                        -- When it was released
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- When the manual-capture order was created
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- Last status change
+);
+
+-- Dashboard Daily Metric Table Definition
+-- Read model of the admin dashboards: one aggregate per metric, subject and UTC day, refreshed
+-- from bookings, payments and users by the RefreshDashboards job
+CREATE TABLE dashboard_daily_metric (
+    metric VARCHAR(30) NOT NULL,                                -- bookings, revenue, owner_revenue, car_booked_hours, ...
+    subject_id UUID NOT NULL,                                   -- Owner or car, all zeros for platform-wide metrics
+    day DATE NOT NULL,                                          -- UTC day aggregated
+    value DECIMAL(14,2) NOT NULL,                               -- Count, INR amount or hours
+
+    PRIMARY KEY (metric, subject_id, day)
+);
+
+-- Dashboard Refresh State Table Definition
+-- Single row recording how far the dashboard read model has been refreshed; locked by each refresh
+CREATE TABLE dashboard_refresh_state (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),             -- Only one row
+    refreshed_at TIMESTAMP,                                     -- Source changes up to here are included
+    rebuilt_at TIMESTAMP                                        -- Last rebuild of every day
+);
+
+-- The read model starts unbuilt; the first refresh rebuilds it
+INSERT INTO dashboard_refresh_state (id) VALUES (TRUE);
+
+-- Payout Account Table Definition
+-- Stores owner bank accounts and UPI IDs used for payouts, verified through RazorpayX
+CREATE TABLE payout_account (
+    -- Primary key: Unique identifier for each payout account
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationship fields
+    owner_id UUID NOT NULL,                                     -- Reference to users.id
+
+    -- Account details (account number and UPI ID are AES-GCM encrypted by the application, see encryption package)
+    account_type VARCHAR(20) NOT NULL,                          -- bank_account, vpa
+    account_holder_name VARCHAR(255) NOT NULL,                  -- Name on the bank account
+    ifsc VARCHAR(11),                                           -- IFSC code for bank accounts
+    account_number_encrypted TEXT,                              -- Encrypted bank account number
+    vpa_encrypted TEXT,                                         -- Encrypted UPI ID
+    masked_identifier VARCHAR(255) NOT NULL,                    -- Display-safe identifier, e.g. XXXXXX1234
+
+    -- Verification state
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',              -- pending, verified, failed
+    razorpay_contact_id VARCHAR(255),                           -- RazorpayX contact ID
+    razorpay_fund_account_id VARCHAR(255),                      -- RazorpayX fund account ID (payout target)
+    razorpay_validation_id VARCHAR(255),                        -- Penny-drop validation ID
+    registered_name VARCHAR(255),                               -- Account holder name reported by the bank
+    failure_reason TEXT,                                        -- Why verification failed
+    verified_at TIMESTAMP,                                      -- When verification succeeded
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- Registration timestamp
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- Last update timestamp
+);
+
+-- Security Event Table Definition
+-- Stores suspicious account activity (new login devices, payment failures, cancellation bursts) for admin review
+CREATE TABLE security_event (
+    -- Primary key: Unique identifier for each security event
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationship fields
+    user_id UUID NOT NULL,                                      -- Reference to users.id (affected account)
+
+    -- Event information
+    type VARCHAR(50) NOT NULL,                                  -- login_new_device, repeated_payment_failures, rapid_booking_cancellations, high_risk_booking
+    severity VARCHAR(20) NOT NULL,                              -- low, medium, high
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',                 -- Client IP that triggered the event, if known
+    user_agent TEXT NOT NULL DEFAULT '',                        -- Client user agent, if known
+    details JSONB DEFAULT '{}',                                 -- Type-specific context (counts, windows, booking IDs)
+
+    -- Review state
+    status VARCHAR(20) NOT NULL DEFAULT 'open',                 -- open, reviewed, dismissed
+    reviewed_by UUID,                                           -- Reference to users.id (reviewing admin)
+    review_notes TEXT,                                          -- Admin's notes on the verdict
+    reviewed_at TIMESTAMP,                                      -- When the event was reviewed
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- When the event was raised
+);
+
+-- User Login Device Table Definition
+-- Remembers the IP address and user agent combinations each user has logged in from
+CREATE TABLE user_login_device (
+    -- Primary key: Unique identifier for each known device
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationship fields
+    user_id UUID NOT NULL,                                      -- Reference to users.id
+
+    -- Device fingerprint
+    ip_address VARCHAR(45) NOT NULL,                            -- Client IP address
+    user_agent TEXT NOT NULL,                                   -- Client user agent
+
+    -- Usage tracking
+    first_seen_at TIMESTAMP NOT NULL,                           -- First login from this device
+    last_seen_at TIMESTAMP NOT NULL,                            -- Most recent login from this device
+    login_count INTEGER NOT NULL DEFAULT 1,                     -- Number of logins from this device
+
+    UNIQUE (user_id, ip_address, user_agent)
+);
+
+-- Password Reset Tokens Table Definition
+-- Links e-mailed to users who forgot their password; each works once, until it expires
+CREATE TABLE password_reset_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),              -- Unique identifier
+    user_id UUID NOT NULL,                                      -- Reference to users.id
+    token_hash VARCHAR(64) NOT NULL UNIQUE,                     -- Hex SHA-256 of the token; the token itself is only in the e-mail
+    expires_at TIMESTAMP NOT NULL,                              -- The link stops working at this time
+    used_at TIMESTAMP,                                          -- When the password was reset with it
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Refresh Tokens Table Definition
+-- Long-lived tokens exchanged at /auth/refresh for access tokens; each works once and is
+-- replaced by a successor of the same family, the session started by one login
+CREATE TABLE refresh_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),              -- Unique identifier
+    user_id UUID NOT NULL,                                      -- Reference to users.id
+    family_id UUID NOT NULL,                                    -- Shared by every token rotated from the same login
+    token_hash VARCHAR(64) NOT NULL UNIQUE,                     -- Hex SHA-256 of the token; the token itself is only with the client
+    expires_at TIMESTAMP NOT NULL,                              -- The token stops working at this time
+    rotated_at TIMESTAMP,                                       -- When it was exchanged for its successor
+    revoked_at TIMESTAMP,                                       -- When its session ended: logout, reuse or a password reset
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Location Table Definition
+-- Named pickup/drop-off points (airports, branches) with the fees charged for using them
+CREATE TABLE location (
+    -- Primary key: Unique identifier for each location
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Location information
+    code VARCHAR(20) NOT NULL UNIQUE,                           -- Short code, e.g. BLR-T1
+    name VARCHAR(100) NOT NULL,                                 -- Display name
+    type VARCHAR(20) NOT NULL,                                  -- airport, branch
+    address TEXT NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+
+    -- Fees in INR
+    pickup_fee DECIMAL(10,2) NOT NULL DEFAULT 0,                -- Charged when a rental starts here
+    dropoff_fee DECIMAL(10,2) NOT NULL DEFAULT 0,               -- Charged when a rental ends here
+
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,                    -- Inactive locations cannot be chosen for new bookings
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Car Location Table Definition
+-- Locations each car can be picked up from and returned to
+CREATE TABLE car_location (
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+    location_id UUID NOT NULL,                                  -- Reference to location.id
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (car_id, location_id)
+);
+
+-- Car Alert Subscription Table Definition
+-- Users watching a car for price drops and opened-up dates
+CREATE TABLE car_alert_subscription (
+    id UUID PRIMARY KEY,                                        -- Unique identifier
+    user_id UUID NOT NULL,                                      -- Reference to users.id
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+    price_drop BOOLEAN NOT NULL DEFAULT FALSE,                  -- Alert when the daily price goes down
+    target_price DECIMAL(10,2),                                 -- Only alert at or below this price
+    availability BOOLEAN NOT NULL DEFAULT FALSE,                -- Alert when blocked dates open up
+    start_date TIMESTAMP,                                       -- Dates the user wants (optional)
+    end_date TIMESTAMP,
+    last_notified_at TIMESTAMP,                                 -- When the user was last alerted
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_car_alert_subscription UNIQUE (user_id, car_id)
+);
+
+-- Car Favorite Table Definition
+-- Cars users saved to their wishlist
+CREATE TABLE car_favorite (
+    id UUID PRIMARY KEY,                                        -- Unique identifier
+    user_id UUID NOT NULL,                                      -- Reference to users.id
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- When the car was saved
+
+    CONSTRAINT unique_car_favorite UNIQUE (user_id, car_id)
+);
+
+-- Car Image Upload Table Definition
+-- Images sent with a car, kept until the upload job has stored them in Cloudinary and added
+-- their URLs to car.images
+CREATE TABLE car_image_upload (
+    id UUID PRIMARY KEY,                                        -- Unique identifier
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+    position INTEGER NOT NULL,                                  -- Order of the image in the request
+    image_data TEXT,                                            -- Base64 image as sent; cleared once uploaded or given up
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',              -- pending, uploading, uploaded, failed
+    attempts INTEGER NOT NULL DEFAULT 0,                        -- Upload attempts made so far
+    next_attempt_at TIMESTAMP NOT NULL,                         -- When a pending upload is tried next
+    url TEXT,                                                   -- Cloudinary URL once uploaded
+    error TEXT NOT NULL DEFAULT '',                             -- Why the last attempt failed
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- Also when the upload job claimed it
+
+    CHECK (status IN ('pending', 'uploading', 'uploaded', 'failed'))
+);
+
+-- Car Purchase Table Definition
+-- What owners paid for their cars, which the fleet valuation report depreciates; never shown publicly
+CREATE TABLE car_purchase (
+    car_id UUID PRIMARY KEY,                                    -- Reference to car.id
+    purchase_price DECIMAL(12,2) NOT NULL,                      -- Price paid in INR
+    purchased_on DATE,                                          -- Purchase date; without it age counts from the model year
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (purchase_price > 0)
+);
+
+-- Car Price History Table Definition
+-- Every change of a car's daily price, with who made it; listing a car records its first price
+CREATE TABLE car_price_history (
+    id UUID PRIMARY KEY,                                        -- Unique identifier
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+    old_price DECIMAL(10,2),                                    -- Price before the change; NULL when the car was listed
+    new_price DECIMAL(10,2) NOT NULL,                           -- Price from the change on
+    changed_by UUID,                                            -- Reference to users.id; NULL for background jobs
+    changed_by_role VARCHAR(20) NOT NULL DEFAULT 'system',      -- Role the change was made as, or system
+    changed_at TIMESTAMP NOT NULL                               -- When the price changed
+);
+
+-- Warehouse Export Watermark Table Definition
+-- How far each entity has been exported to the data warehouse bucket
+CREATE TABLE warehouse_export_watermark (
+    entity VARCHAR(20) PRIMARY KEY,                             -- cars, bookings, payments or users
+    exported_until TIMESTAMP NOT NULL,                          -- Rows with updated_at up to here are exported
+    last_rows INTEGER NOT NULL DEFAULT 0,                       -- Rows in the latest incremental export
+    last_run_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP             -- When the latest export finished
+);
+
+-- Notification Table Definition
+-- In-app inbox; every message sent to a user through the notification service is kept here
+CREATE TABLE notification (
+    id UUID PRIMARY KEY,                                        -- Unique identifier
+    user_id UUID NOT NULL,                                      -- Reference to users.id (recipient)
+    subject VARCHAR(255) NOT NULL,                              -- Short summary shown in the inbox
+    message TEXT NOT NULL,                                      -- Plain-text body
+    read_at TIMESTAMP,                                          -- When the user read it (NULL while unread)
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Email Template Table Definition
+-- Versions of admin-managed e-mail copy; the highest version of a key is the one sent
+CREATE TABLE email_template (
+    id UUID PRIMARY KEY,                                        -- Unique identifier
+    key VARCHAR(50) NOT NULL,                                   -- Template key, e.g. booking_confirmed
+    version INTEGER NOT NULL,                                   -- 1 for the first save, incremented on every save
+    subject VARCHAR(255) NOT NULL,                              -- Go text/template for the subject line
+    body TEXT NOT NULL,                                         -- Go text/template for the plain-text body
+    created_by UUID,                                            -- Reference to users.id (admin who saved it)
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_email_template_version UNIQUE (key, version)
+);
+
+-- Owner Vacation Table Definition
+-- Periods in which an owner has blocked every car they own
+CREATE TABLE owner_vacation (
+    id UUID PRIMARY KEY,                                        -- Unique identifier
+    owner_id UUID NOT NULL,                                     -- Reference to users.id (owner)
+    start_date TIMESTAMP NOT NULL,                              -- First blocked moment
+    end_date TIMESTAMP NOT NULL,                                -- Cars are bookable again from here
+    note TEXT,                                                  -- Owner's own reminder, e.g. "Goa trip"
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Car Blackout Table Definition
+-- Per-car blocked periods; booking conflict checks read these alongside bookings
+CREATE TABLE car_blackout (
+    id UUID PRIMARY KEY,                                        -- Unique identifier
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+    vacation_id UUID NOT NULL,                                  -- Reference to owner_vacation.id (the bulk block it belongs to)
+    start_date TIMESTAMP NOT NULL,                              -- First blocked moment
+    end_date TIMESTAMP NOT NULL                                 -- Car is bookable again from here
+);
+
+-- Fleet Table Definition
+-- Owner-defined groups of cars; member cars inherit the fleet's settings when quoted
+CREATE TABLE fleet (
+    id UUID PRIMARY KEY,                                        -- Unique identifier
+    owner_id UUID NOT NULL,                                     -- Reference to users.id (owner)
+    name VARCHAR(100) NOT NULL,                                 -- Owner's name for the group, e.g. "SUVs"
+    pricing_rules JSONB NOT NULL DEFAULT '[]',                  -- [{type: weekend|long_rental, percent, min_days}]
+    add_ons JSONB NOT NULL DEFAULT '[]',                        -- [{code, name, price, per_day}]
+    blackouts JSONB NOT NULL DEFAULT '[]',                      -- [{start_date, end_date, reason}]
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_fleet_owner_name UNIQUE (owner_id, name)
+);
+
+-- Feature Table Definition
+-- Curated taxonomy of car features; car.features may only use these keys, and aliases
+-- submitted by owners are rewritten to the key
+CREATE TABLE feature (
+    key VARCHAR(50) PRIMARY KEY,                                -- Canonical key used in car.features, e.g. air_conditioning
+    name VARCHAR(100) NOT NULL,                                 -- Display name
+    aliases TEXT[] NOT NULL DEFAULT '{}',                       -- Other keys owners send, e.g. {ac, aircon}
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Car Attribute Table Definition
+-- Extra car attributes each operator defines, e.g. pet_friendly; car.attributes holds the
+-- values and is validated against these definitions when a car is saved
+CREATE TABLE car_attribute (
+    operator_id UUID NOT NULL,                                  -- Reference to operator.id (tenant)
+    key VARCHAR(50) NOT NULL,                                   -- Key used in car.attributes, e.g. pet_friendly
+    name VARCHAR(100) NOT NULL,                                 -- Label shown on car forms and pages
+    type VARCHAR(20) NOT NULL,                                  -- boolean, number, text, select
+    options TEXT[] NOT NULL DEFAULT '{}',                       -- Allowed values of select attributes
+    required BOOLEAN NOT NULL DEFAULT FALSE,                    -- Cars cannot be saved without a value
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (operator_id, key),
+    CONSTRAINT check_car_attribute_type CHECK (type IN ('boolean', 'number', 'text', 'select'))
+);
+
+-- Setting Table Definition
+-- Values each operator configures through /admin/settings, e.g. search_ranking weights;
+-- keys without a row use the application's defaults
+CREATE TABLE setting (
+    operator_id UUID NOT NULL,                                  -- Reference to operator.id (tenant)
+    key VARCHAR(50) NOT NULL,                                   -- Setting key, e.g. search_ranking
+    value JSONB NOT NULL,                                       -- Validated by the application for each key
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (operator_id, key)
+);
+
+-- Built-in features; admins add more through /admin/features
+INSERT INTO feature (key, name, aliases) VALUES
+    ('air_conditioning', 'Air conditioning', '{ac, a_c, aircon, air_con}'),
+    ('all_wheel_drive', 'All-wheel drive', '{awd, 4wd, four_wheel_drive}'),
+    ('android_auto', 'Android Auto', '{}'),
+    ('apple_carplay', 'Apple CarPlay', '{carplay}'),
+    ('autopilot', 'Autopilot', '{}'),
+    ('backup_camera', 'Backup camera', '{reverse_camera, rear_camera, rearview_camera}'),
+    ('bluetooth', 'Bluetooth', '{bt}'),
+    ('child_seat', 'Child seat', '{baby_seat}'),
+    ('convertible', 'Convertible roof', '{}'),
+    ('cruise_control', 'Cruise control', '{}'),
+    ('gps', 'GPS', '{}'),
+    ('heated_seats', 'Heated seats', '{seat_heating}'),
+    ('hybrid_system', 'Hybrid system', '{hybrid}'),
+    ('keyless_entry', 'Keyless entry', '{keyless}'),
+    ('leather_seats', 'Leather seats', '{leather}'),
+    ('navigation', 'Navigation system', '{sat_nav, satnav}'),
+    ('premium_audio', 'Premium audio', '{}'),
+    ('premium_connectivity', 'Premium connectivity', '{}'),
+    ('sport_mode', 'Sport mode', '{}'),
+    ('sunroof', 'Sunroof', '{moonroof}'),
+    ('supercharging', 'Supercharging', '{}'),
+    ('third_row_seating', 'Third-row seating', '{third_row, 7_seater}'),
+    ('usb_charging', 'USB charging', '{usb}');
+
+-- Car Brand Table Definition
+-- Reference list of manufacturers for autocompletes; a car's brand must be one of these
+-- unless the owner chose other_brand_model
+CREATE TABLE car_brand (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),              -- Unique identifier
+    name VARCHAR(100) NOT NULL CONSTRAINT unique_car_brand_name UNIQUE, -- Display name, e.g. Maruti Suzuki
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Car Model Table Definition
+-- Reference list of each brand's models; a car's model must belong to its brand
+CREATE TABLE car_model (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),              -- Unique identifier
+    brand_id UUID NOT NULL,                                     -- Brand (FK to car_brand.id)
+    name VARCHAR(100) NOT NULL,                                 -- Display name, e.g. Swift
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_car_model_brand_name UNIQUE (brand_id, name)
+);
+
+-- Common manufacturers and their models
+INSERT INTO car_brand (name) VALUES
+    ('Audi'),
+    ('BMW'),
+    ('Chevrolet'),
+    ('Ford'),
+    ('Honda'),
+    ('Hyundai'),
+    ('Jeep'),
+    ('Kia'),
+    ('Mahindra'),
+    ('Maruti Suzuki'),
+    ('Mazda'),
+    ('Mercedes-Benz'),
+    ('MG'),
+    ('Nissan'),
+    ('Renault'),
+    ('Skoda'),
+    ('Tata'),
+    ('Tesla'),
+    ('Toyota'),
+    ('Volkswagen');
+
+INSERT INTO car_model (brand_id, name)
+SELECT b.id, m.name FROM (VALUES
+    ('Audi', 'A3'),
+    ('Audi', 'A4'),
+    ('Audi', 'A6'),
+    ('Audi', 'Q3'),
+    ('Audi', 'Q5'),
+    ('Audi', 'Q7'),
+    ('BMW', '1 Series'),
+    ('BMW', '3 Series'),
+    ('BMW', '5 Series'),
+    ('BMW', 'X1'),
+    ('BMW', 'X3'),
+    ('BMW', 'X5'),
+    ('Chevrolet', 'Beat'),
+    ('Chevrolet', 'Cruze'),
+    ('Chevrolet', 'Malibu'),
+    ('Chevrolet', 'Spark'),
+    ('Ford', 'EcoSport'),
+    ('Ford', 'Endeavour'),
+    ('Ford', 'Escape'),
+    ('Ford', 'Figo'),
+    ('Ford', 'Focus'),
+    ('Ford', 'Mustang'),
+    ('Honda', 'Accord'),
+    ('Honda', 'Amaze'),
+    ('Honda', 'City'),
+    ('Honda', 'Civic'),
+    ('Honda', 'CR-V'),
+    ('Honda', 'Jazz'),
+    ('Hyundai', 'Creta'),
+    ('Hyundai', 'Elantra'),
+    ('Hyundai', 'i10'),
+    ('Hyundai', 'i20'),
+    ('Hyundai', 'Tucson'),
+    ('Hyundai', 'Venue'),
+    ('Hyundai', 'Verna'),
+    ('Jeep', 'Compass'),
+    ('Jeep', 'Meridian'),
+    ('Jeep', 'Wrangler'),
+    ('Kia', 'Carens'),
+    ('Kia', 'Seltos'),
+    ('Kia', 'Sonet'),
+    ('Mahindra', 'Bolero'),
+    ('Mahindra', 'Scorpio'),
+    ('Mahindra', 'Thar'),
+    ('Mahindra', 'XUV300'),
+    ('Mahindra', 'XUV700'),
+    ('Maruti Suzuki', 'Alto'),
+    ('Maruti Suzuki', 'Baleno'),
+    ('Maruti Suzuki', 'Brezza'),
+    ('Maruti Suzuki', 'Dzire'),
+    ('Maruti Suzuki', 'Ertiga'),
+    ('Maruti Suzuki', 'Swift'),
+    ('Maruti Suzuki', 'Wagon R'),
+    ('Mazda', 'CX-5'),
+    ('Mazda', 'Mazda3'),
+    ('Mazda', 'MX-5 Miata'),
+    ('Mercedes-Benz', 'A-Class'),
+    ('Mercedes-Benz', 'C-Class'),
+    ('Mercedes-Benz', 'E-Class'),
+    ('Mercedes-Benz', 'GLA'),
+    ('Mercedes-Benz', 'GLC'),
+    ('MG', 'Astor'),
+    ('MG', 'Hector'),
+    ('MG', 'ZS EV'),
+    ('Nissan', 'Altima'),
+    ('Nissan', 'Leaf'),
+    ('Nissan', 'Magnite'),
+    ('Renault', 'Duster'),
+    ('Renault', 'Kiger'),
+    ('Renault', 'Kwid'),
+    ('Renault', 'Triber'),
+    ('Skoda', 'Kushaq'),
+    ('Skoda', 'Octavia'),
+    ('Skoda', 'Slavia'),
+    ('Tata', 'Altroz'),
+    ('Tata', 'Harrier'),
+    ('Tata', 'Nexon'),
+    ('Tata', 'Punch'),
+    ('Tata', 'Safari'),
+    ('Tata', 'Tiago'),
+    ('Tesla', 'Model 3'),
+    ('Tesla', 'Model S'),
+    ('Tesla', 'Model X'),
+    ('Tesla', 'Model Y'),
+    ('Toyota', 'Camry'),
+    ('Toyota', 'Corolla'),
+    ('Toyota', 'Fortuner'),
+    ('Toyota', 'Innova Crysta'),
+    ('Toyota', 'Prius'),
+    ('Toyota', 'RAV4'),
+    ('Volkswagen', 'Golf'),
+    ('Volkswagen', 'Jetta'),
+    ('Volkswagen', 'Polo'),
+    ('Volkswagen', 'Taigun'),
+    ('Volkswagen', 'Virtus')
+) AS m(brand, name) JOIN car_brand b ON b.name = m.brand;
+
+-- Relocation Fee Table Definition
+-- One-way rental fee matrix; city pairs without a row are not offered one-way
+CREATE TABLE relocation_fee (
+    id UUID PRIMARY KEY,                                        -- Unique identifier
+    from_city VARCHAR(100) NOT NULL,                            -- City the rental starts in
+    to_city VARCHAR(100) NOT NULL,                              -- City the car is returned in
+    fee DECIMAL(10,2) NOT NULL,                                 -- Fee in INR
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Car Delivery Option Table Definition
+-- Owner's offer to deliver a car to the renter's address, priced by distance
+CREATE TABLE car_delivery_option (
+    car_id UUID PRIMARY KEY,                                    -- Reference to car.id
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,                      -- Whether delivery can be booked
+    origin_latitude DECIMAL(9,6) NOT NULL,                      -- Where deliveries start
+    origin_longitude DECIMAL(9,6) NOT NULL,                     -- Where deliveries start
+    max_radius_km DECIMAL(6,1) NOT NULL,                        -- Farthest address the owner delivers to
+    base_fee DECIMAL(10,2) NOT NULL DEFAULT 0,                  -- Flat fee per delivery
+    fee_per_km DECIMAL(10,2) NOT NULL DEFAULT 0,                -- Fee per kilometre from the origin
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Car Fuel Policy Table Definition
+-- Stores how fuel is paid for on each car; cars without a row are not charged for fuel
+CREATE TABLE car_fuel_policy (
+    car_id UUID PRIMARY KEY,                                    -- Reference to car.id
+    policy VARCHAR(20) NOT NULL DEFAULT 'full_to_full',         -- full_to_full or prepaid
+    tank_capacity_liters DECIMAL(5,1) NOT NULL,                 -- Prices a prepaid tank and missing fuel
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Car Eligibility Table Definition
+-- Stores who may rent each car; cars without a row accept any renter
+CREATE TABLE car_eligibility (
+    car_id UUID PRIMARY KEY,                                    -- Reference to car.id
+    min_renter_age INTEGER NOT NULL DEFAULT 0,                  -- Years of age when the rental starts, 0 for none
+    min_license_years INTEGER NOT NULL DEFAULT 0,               -- Years the licence has been held when the rental starts
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- User KYC Table Definition
+-- Stores the dates an admin read from a renter's identity documents and driving licence
+CREATE TABLE user_kyc (
+    user_id UUID PRIMARY KEY,                                   -- Reference to users.id
+    date_of_birth DATE NOT NULL,
+    license_issued_on DATE NOT NULL,                            -- Date the driving licence was first issued
+    verified_by UUID NOT NULL,                                  -- Admin who checked the documents
+    verified_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Featured Listing Table Definition
+-- Periods owners paid for to have a car boosted in ranked listings, with the impressions and
+-- clicks each collected
+CREATE TABLE featured_listing (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+    owner_id UUID NOT NULL,                                     -- Reference to users.id (owner who paid)
+    start_date TIMESTAMP NOT NULL,
+    end_date TIMESTAMP NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,                              -- Price in INR
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid', 'failed')),
+    razorpay_order_id VARCHAR(255),
+    razorpay_payment_id VARCHAR(255),
+    impressions INTEGER NOT NULL DEFAULT 0,                     -- Times shown in a ranked listing while running
+    clicks INTEGER NOT NULL DEFAULT 0,                          -- Times the car's page was opened while running
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (end_date > start_date)
+);
+
+-- User Block Table Definition
+-- Users each user has blocked; a blocked pair cannot book with each other
+CREATE TABLE user_block (
+    blocker_id UUID NOT NULL,                                   -- Reference to users.id (who blocked)
+    blocked_id UUID NOT NULL,                                   -- Reference to users.id (who was blocked)
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+-- Maintenance Job Table Definition
+-- Runbook tasks admins queue to repair derived data, kept as the audit trail of every run
+CREATE TABLE maintenance_job (
+    -- Primary key: Unique identifier for each job
+    id UUID PRIMARY KEY,
+
+    -- Request
+    task VARCHAR(50) NOT NULL,                                  -- rebuild_car_availability, recompute_booking_totals, resync_payment, reindex_search
+    target_id UUID,                                             -- Car, booking or payment the task is limited to; all when NULL
+    operator_id UUID,                                           -- Reference to operator.id; all operators when NULL
+    requested_by UUID NOT NULL,                                 -- Reference to users.id (admin)
+
+    -- Outcome
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',               -- queued, running, succeeded, failed
+    examined INTEGER NOT NULL DEFAULT 0,                        -- Records checked
+    changed INTEGER NOT NULL DEFAULT 0,                         -- Records repaired
+    error TEXT NOT NULL DEFAULT '',                             -- Why the job failed
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- When it was requested
+    started_at TIMESTAMP,                                       -- When the job runner claimed it
+    completed_at TIMESTAMP,                                     -- When it succeeded or failed
+
+    CHECK (task IN ('rebuild_car_availability', 'recompute_booking_totals', 'resync_payment', 'reindex_search')),
+    CHECK (status IN ('queued', 'running', 'succeeded', 'failed'))
+);
+
+-- Debug Capture Table Definition
+-- Sanitized copies of sampled requests and their responses, purged after DEBUG_CAPTURE_TTL.
+-- No foreign keys: captures are throwaway and must not block deleting users.
+CREATE TABLE debug_capture (
+    -- Primary key: Unique identifier for each capture
+    id UUID PRIMARY KEY,
+
+    -- Request
+    reason VARCHAR(20) NOT NULL,                                -- sampled, user, route, header
+    user_id UUID,                                               -- Caller, when the request carried a valid token
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    query TEXT NOT NULL DEFAULT '',                             -- Sensitive parameters redacted
+    client_ip VARCHAR(45) NOT NULL DEFAULT '',
+    request_headers JSONB NOT NULL DEFAULT '{}',                -- Credentials redacted
+    request_body TEXT NOT NULL DEFAULT '',                      -- Redacted and truncated, or a note of what was omitted
+
+    -- Response
+    status INTEGER NOT NULL,
+    response_headers JSONB NOT NULL DEFAULT '{}',
+    response_body TEXT NOT NULL DEFAULT '',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+
+    -- Retention
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,                              -- Deleted by the purge job after this
+
+    CHECK (reason IN ('sampled', 'user', 'route', 'header'))
+);
+
+-- Load Test Run Table Definition
+-- Users and cars created by POST /admin/loadtest/fixtures, deleted together when the run is
+-- deleted or expires. Their bookings go with them through the cascades of booking.
+-- No foreign keys: the IDs are what is left to delete, not references to keep valid.
+CREATE TABLE loadtest_run (
+    -- Primary key: Unique identifier for each run
+    id UUID PRIMARY KEY,
+
+    created_by UUID NOT NULL,                                   -- Admin who created the fixtures
+    user_ids UUID[] NOT NULL DEFAULT '{}',                      -- Owners and renters
+    car_ids UUID[] NOT NULL DEFAULT '{}',
+
+    -- Retention
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL                               -- Deleted by the purge job after this
+);
+
+-- Telemetry Device Table Definition
+-- Stores the telematics unit bound to each car and the secret it signs payloads with
+CREATE TABLE telemetry_device (
+    -- Primary key: Unique identifier for each device, sent in X-Telemetry-Device
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationship fields
+    car_id UUID NOT NULL UNIQUE,                                -- Reference to car.id (one device per car)
+
+    -- Authentication
+    secret_encrypted TEXT NOT NULL,                             -- HMAC signing secret, encrypted at rest
+
+    -- Usage tracking
+    last_seen_at TIMESTAMP,                                     -- When the device last posted a reading
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- Bumped when the secret is rotated
+);
+
+-- Car Telemetry Table Definition
+-- Time series of readings reported by telematics devices; any metric may be missing from a reading
+CREATE TABLE car_telemetry (
+    -- Primary key: Unique identifier for each reading
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationship fields
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+    device_id UUID NOT NULL,                                    -- Reference to telemetry_device.id
+
+    -- Timing
+    recorded_at TIMESTAMP NOT NULL,                             -- When the device took the reading
+    received_at TIMESTAMP NOT NULL,                             -- When the API stored it
+
+    -- Readings
+    latitude DOUBLE PRECISION,                                  -- GPS latitude
+    longitude DOUBLE PRECISION,                                 -- GPS longitude
+    odometer_km INTEGER,                                        -- Odometer in kilometres
+    fuel_level NUMERIC(5,2),                                    -- Fuel tank level, percent
+    battery_level NUMERIC(5,2)                                  -- EV state of charge, percent
+);
+
+-- Car Smart Lock Table Definition
+-- Links a car to the lock fitted in it at a smart-lock provider
+CREATE TABLE car_smart_lock (
+    -- Primary key: one lock per car
+    car_id UUID PRIMARY KEY,                                    -- Reference to car.id
+
+    -- Provider reference
+    provider VARCHAR(50) NOT NULL,                              -- Name of the provider integration, e.g. http
+    lock_ref VARCHAR(100) NOT NULL,                             -- The provider's identifier of the lock
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_car_smart_lock_provider_lock_ref UNIQUE (provider, lock_ref) -- A lock is fitted in one car
+);
+
+-- Digital Key Table Definition
+-- Time-boxed keys issued to a car's lock when its booking is confirmed
+CREATE TABLE digital_key (
+    -- Primary key: Unique identifier for each key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationship fields
+    booking_id UUID NOT NULL,                                   -- Reference to booking.id
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+
+    -- Provider reference
+    provider VARCHAR(50) NOT NULL,                              -- Provider the key was requested from
+    lock_ref VARCHAR(100) NOT NULL,                             -- Lock the key opens
+    provider_key_id VARCHAR(255),                               -- The provider's identifier of the key; NULL when issuing failed
+
+    -- Validity
+    valid_from TIMESTAMP NOT NULL,
+    valid_until TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,                                -- issued, revoked or failed
+    error TEXT,                                                 -- Why the provider did not issue the key
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP                                        -- When the booking was completed or cancelled
+);
+
+-- Lock Event Table Definition
+-- Lock and unlock events reported by smart-lock providers, logged against the booking whose key was used
+CREATE TABLE lock_event (
+    -- Primary key: Unique identifier for each event
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationship fields
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+    booking_id UUID,                                            -- Reference to booking.id; NULL outside bookings
+    digital_key_id UUID,                                        -- Reference to digital_key.id, when known
+
+    -- Event
+    action VARCHAR(10) NOT NULL,                                -- lock or unlock
+    provider_event_id VARCHAR(255) NOT NULL UNIQUE,             -- Redelivered events are stored once
+    occurred_at TIMESTAMP NOT NULL,                             -- When the lock reported it
+    received_at TIMESTAMP NOT NULL                              -- When the API stored it
+);
+
+-- Incident Table Definition
+-- Accidents, thefts and other mishaps reported against active bookings, tracked until resolved
+CREATE TABLE incident (
+    -- Primary key: Unique identifier for each incident
+    id UUID PRIMARY KEY,
+
+    -- Relationship fields
+    booking_id UUID NOT NULL,                                   -- Reference to booking.id
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+    customer_id UUID NOT NULL,                                  -- The booking's customer, reference to users.id
+    owner_id UUID NOT NULL,                                     -- The booking's owner, reference to users.id
+    reported_by UUID NOT NULL,                                  -- Customer or owner who reported it, reference to users.id
+
+    -- Report
+    kind VARCHAR(20) NOT NULL,                                  -- accident, damage, theft, breakdown, other
+    description TEXT NOT NULL,                                  -- What happened
+    occurred_at TIMESTAMP NOT NULL,                             -- When it happened
+    location TEXT NOT NULL DEFAULT '',                          -- Where it happened, as described by the reporter
+    police_report_number VARCHAR(100) NOT NULL DEFAULT '',      -- FIR or police report number, if one was filed
+    photo_urls TEXT[] NOT NULL DEFAULT '{}',                    -- Photos of the scene and damage
+
+    -- Tracking
+    insurance_claim_number VARCHAR(100) NOT NULL DEFAULT '',    -- Set when the claim is filed
+    status VARCHAR(20) NOT NULL DEFAULT 'reported',             -- reported, under_review, claim_filed, resolved, closed
+    resolution_notes TEXT NOT NULL DEFAULT '',                  -- Notes on how it was settled
+    resolved_at TIMESTAMP,                                      -- When it was resolved or closed
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- When it was reported
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- When it last changed
+);
+
+-- Incident Document Table Definition
+-- Insurance policies, claim forms, police reports and estimates attached to an incident
+CREATE TABLE incident_document (
+    -- Primary key: Unique identifier for each document
+    id UUID PRIMARY KEY,
+
+    -- Relationship fields
+    incident_id UUID NOT NULL,                                  -- Reference to incident.id
+    added_by UUID NOT NULL,                                     -- User who attached it, reference to users.id
+
+    -- Document details
+    kind VARCHAR(50) NOT NULL,                                  -- insurance_policy, insurance_claim, police_report, repair_estimate, other
+    description TEXT NOT NULL,                                  -- What the document is
+    document_url TEXT NOT NULL,                                 -- Where the document is stored
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- When it was attached
+);
+
+-- Checklist Template Table Definition
+-- Versions of the admin-defined checklist answered at checkout and check-in; the highest version
+-- is answered at checkout, and a check-in answers the version of its checkout
+CREATE TABLE checklist_template (
+    id UUID PRIMARY KEY,                                        -- Unique identifier
+    version INTEGER NOT NULL,                                   -- 1 for the first save, incremented on every save
+    items JSONB NOT NULL,                                       -- Items to check, [{key, label, section, type}]
+    created_by UUID,                                            -- Reference to users.id (admin who saved it)
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_checklist_template_version UNIQUE (version)
+);
+
+-- Booking Inspection Table Definition
+-- Records odometer and fuel level when a rental car is handed over (checkout) and returned (checkin)
+CREATE TABLE booking_inspection (
+    -- Primary key: Unique identifier for each inspection
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationship fields
+    booking_id UUID NOT NULL,                                   -- Reference to booking.id
+    recorded_by UUID NOT NULL,                                  -- Reference to users.id (owner or admin doing the handover)
+
+    -- Inspection information
+    kind VARCHAR(20) NOT NULL,                                  -- checkout, checkin
+    odometer_km INTEGER,                                        -- Odometer in kilometres
+    odometer_source VARCHAR(20),                                -- manual, telemetry
+    fuel_level NUMERIC(5,2),                                    -- Fuel tank level, percent
+    fuel_source VARCHAR(20),                                    -- manual, telemetry
+    notes TEXT NOT NULL DEFAULT '',                             -- Damage, cleanliness and other remarks
+    checklist_version INTEGER,                                  -- Reference to checklist_template.version answered
+    checklist JSONB,                                            -- Answers to the checklist items, [{key, value, note}]
+
+    -- Audit trail columns
+    recorded_at TIMESTAMP NOT NULL,                             -- When the handover was recorded
+
+    CONSTRAINT unique_booking_inspection_kind UNIQUE (booking_id, kind)
+);
+
+-- Geofence Table Definition
+-- Circular regions a rented car must stay in; booking_id limits a geofence to one rental
+CREATE TABLE geofence (
+    -- Primary key: Unique identifier for each geofence
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationship fields
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+    booking_id UUID,                                            -- Reference to booking.id; NULL applies to every rental
+    created_by UUID NOT NULL,                                   -- Reference to users.id (owner or admin)
+
+    -- Region
+    name VARCHAR(100) NOT NULL DEFAULT '',                      -- Label used in alerts
+    center_latitude DOUBLE PRECISION NOT NULL,
+    center_longitude DOUBLE PRECISION NOT NULL,
+    radius_km DOUBLE PRECISION NOT NULL,
+
+    -- Audit trail columns
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Geofence Breach Table Definition
+-- Records a car leaving a geofence during a rental; open until the car is seen back inside
+CREATE TABLE geofence_breach (
+    -- Primary key: Unique identifier for each breach
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationship fields
+    geofence_id UUID NOT NULL,                                  -- Reference to geofence.id
+    booking_id UUID NOT NULL,                                   -- Reference to booking.id (rental during which it happened)
+    car_id UUID NOT NULL,                                       -- Reference to car.id
+
+    -- Position that was outside the region
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    distance_km DOUBLE PRECISION NOT NULL,                      -- Distance from the geofence centre
+
+    -- Timing
+    detected_at TIMESTAMP NOT NULL,                             -- Time of the reading outside the region
+    resolved_at TIMESTAMP                                       -- Time of the first reading back inside
+);
+
+-- =============================================================================
+-- CONSTRAINTS AND RELATIONSHIPS
+-- =============================================================================
+
+-- Foreign Key Constraints: Every user, car, booking and payment belongs to an operator
+ALTER TABLE users
+ADD CONSTRAINT fk_user_operator_id
+FOREIGN KEY (operator_id)
+REFERENCES operator(id)
+ON DELETE RESTRICT;                                              -- Operators with data cannot be deleted
+
+ALTER TABLE car
+ADD CONSTRAINT fk_car_operator_id
+FOREIGN KEY (operator_id)
+REFERENCES operator(id)
+ON DELETE RESTRICT;                                              -- Operators with data cannot be deleted
+
+ALTER TABLE booking
+ADD CONSTRAINT fk_booking_operator_id
+FOREIGN KEY (operator_id)
+REFERENCES operator(id)
+ON DELETE RESTRICT;                                              -- Operators with data cannot be deleted
+
+ALTER TABLE payment
+ADD CONSTRAINT fk_payment_operator_id
+FOREIGN KEY (operator_id)
+REFERENCES operator(id)
+ON DELETE RESTRICT;                                              -- Operators with data cannot be deleted
+
+ALTER TABLE car_attribute
+ADD CONSTRAINT fk_car_attribute_operator_id
+FOREIGN KEY (operator_id)
+REFERENCES operator(id)
+ON DELETE CASCADE;                                               -- Definitions go with their operator
+
+ALTER TABLE setting
+ADD CONSTRAINT fk_setting_operator_id
+FOREIGN KEY (operator_id)
+REFERENCES operator(id)
+ON DELETE CASCADE;                                               -- Settings go with their operator
+
+-- Foreign Key Constraint: Establish relationship between car and user (owner)
+ALTER TABLE car
+ADD CONSTRAINT fk_car_owner_id
+FOREIGN KEY (owner_id)
+REFERENCES users(id)
+ON DELETE SET NULL;                                              -- Set owner_id to NULL when user is deleted
+
+-- Foreign Key Constraints for booking table
+-- Bookings and payments are the rental and financial history, so deleting a renter, a car or
+-- a booking they depend on is refused; cars are retired and bookings cancelled instead
+ALTER TABLE booking
+ADD CONSTRAINT fk_booking_customer_id
+FOREIGN KEY (customer_id)
+REFERENCES users(id)
+ON DELETE RESTRICT;                                              -- Keep renters who have bookings
+
+ALTER TABLE booking
+ADD CONSTRAINT fk_booking_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE RESTRICT;                                              -- Keep cars that have bookings
+
+ALTER TABLE booking
+ADD CONSTRAINT fk_booking_owner_id
+FOREIGN KEY (owner_id)
+REFERENCES users(id)
+ON DELETE SET NULL;                                              -- Set owner_id to NULL when owner is deleted
+
+-- Exclusion Constraint: no two rentals holding a car may overlap. Backs the conflict check of
+-- the booking service, which concurrent requests can both pass. Periods are half-open
+-- timestamp ranges, as the service compares them, so a rental may start when another ends.
+ALTER TABLE booking
+ADD CONSTRAINT exclude_booking_car_period
+EXCLUDE USING gist (car_id WITH =, tsrange(start_date, end_date) WITH &&)
+WHERE (status IN ('pending', 'under_review', 'confirmed', 'in_progress'));
+
+ALTER TABLE booking
+ADD CONSTRAINT fk_booking_parent_booking_id
+FOREIGN KEY (parent_booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;                                               -- Extensions go with their trip
+
+-- Foreign Key Constraints for payment table
+ALTER TABLE payment
+ADD CONSTRAINT fk_payment_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE RESTRICT;                                              -- Keep bookings that have payments
+
+-- Foreign Key Constraints for payment_link table
+ALTER TABLE payment_link
+ADD CONSTRAINT fk_payment_link_payment_id
+FOREIGN KEY (payment_id)
+REFERENCES payment(id)
+ON DELETE CASCADE;                                               -- Delete link when its payment is deleted
+
+ALTER TABLE payment_link
+ADD CONSTRAINT fk_payment_link_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;                                               -- Delete link when booking is deleted
+
+-- Foreign Key Constraint: Establish relationship between payment_attempt and payment
+ALTER TABLE payment_attempt
+ADD CONSTRAINT fk_payment_attempt_payment_id
+FOREIGN KEY (payment_id)
+REFERENCES payment(id)
+ON DELETE CASCADE;                                               -- Delete attempts when their payment is deleted
+
+-- Foreign Key Constraints for payment_collection table
+ALTER TABLE payment_collection
+ADD CONSTRAINT fk_payment_collection_payment_id
+FOREIGN KEY (payment_id)
+REFERENCES payment(id)
+ON DELETE CASCADE;                                               -- Delete collection when its payment is deleted
+
+ALTER TABLE payment_collection
+ADD CONSTRAINT fk_payment_collection_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;                                               -- Delete collection when booking is deleted
+
+ALTER TABLE payment_collection
+ADD CONSTRAINT fk_payment_collection_collected_by
+FOREIGN KEY (collected_by)
+REFERENCES users(id)
+ON DELETE SET NULL;                                              -- Keep the record when the collector is deleted
+
+-- Foreign Key Constraints for dispute table
+ALTER TABLE dispute
+ADD CONSTRAINT fk_dispute_payment_id
+FOREIGN KEY (payment_id)
+REFERENCES payment(id)
+ON DELETE CASCADE;                                               -- Delete dispute when its payment is deleted
+
+ALTER TABLE dispute
+ADD CONSTRAINT fk_dispute_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;                                               -- Delete dispute when its booking is deleted
+
+ALTER TABLE dispute
+ADD CONSTRAINT fk_dispute_owner_id
+FOREIGN KEY (owner_id)
+REFERENCES users(id)
+ON DELETE SET NULL;                                              -- Keep the dispute when the owner is deleted
+
+-- Foreign Key Constraints for dispute_evidence table
+ALTER TABLE dispute_evidence
+ADD CONSTRAINT fk_dispute_evidence_dispute_id
+FOREIGN KEY (dispute_id)
+REFERENCES dispute(id)
+ON DELETE CASCADE;                                               -- Delete evidence with its dispute
+
+ALTER TABLE dispute_evidence
+ADD CONSTRAINT fk_dispute_evidence_added_by
+FOREIGN KEY (added_by)
+REFERENCES users(id)
+ON DELETE RESTRICT;                                              -- Keep track of who attached evidence
+
+-- Foreign Key Constraints for payout_hold table
+ALTER TABLE payout_hold
+ADD CONSTRAINT fk_payout_hold_owner_id
+FOREIGN KEY (owner_id)
+REFERENCES users(id)
+ON DELETE CASCADE;                                               -- Delete holds when the owner is deleted
+
+ALTER TABLE payout_hold
+ADD CONSTRAINT fk_payout_hold_dispute_id
+FOREIGN KEY (dispute_id)
+REFERENCES dispute(id)
+ON DELETE CASCADE;                                               -- Delete hold with its dispute
+
+ALTER TABLE payout_hold
+ADD CONSTRAINT fk_payout_hold_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;                                               -- Delete hold when its booking is deleted
+
+-- Foreign Key Constraints for booking_adjustment table
+ALTER TABLE booking_adjustment
+ADD CONSTRAINT fk_booking_adjustment_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE RESTRICT;                                              -- Applied adjustments must be kept
+
+ALTER TABLE booking_adjustment
+ADD CONSTRAINT fk_booking_adjustment_requested_by
+FOREIGN KEY (requested_by)
+REFERENCES users(id)
+ON DELETE RESTRICT;                                              -- Keep track of who raised it
+
+ALTER TABLE booking_adjustment
+ADD CONSTRAINT fk_booking_adjustment_reviewed_by
+FOREIGN KEY (reviewed_by)
+REFERENCES users(id)
+ON DELETE RESTRICT;                                              -- Keep track of who approved it
+
+ALTER TABLE booking_adjustment
+ADD CONSTRAINT fk_booking_adjustment_payment_id
+FOREIGN KEY (payment_id)
+REFERENCES payment(id)
+ON DELETE RESTRICT;                                              -- Keep the payment a charge requested
+
+-- Foreign Key Constraints for financial_document table
+ALTER TABLE financial_document
+ADD CONSTRAINT fk_financial_document_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE RESTRICT;                                              -- Issued documents must be kept
+
+ALTER TABLE financial_document
+ADD CONSTRAINT fk_financial_document_payment_id
+FOREIGN KEY (payment_id)
+REFERENCES payment(id)
+ON DELETE RESTRICT;                                              -- Issued documents must be kept
+
+ALTER TABLE financial_document
+ADD CONSTRAINT fk_financial_document_adjustment_id
+FOREIGN KEY (adjustment_id)
+REFERENCES booking_adjustment(id)
+ON DELETE RESTRICT;                                              -- Issued documents must be kept
+
+-- Foreign Key Constraints for payment_authorization table
+ALTER TABLE payment_authorization
+ADD CONSTRAINT fk_payment_authorization_payment_id
+FOREIGN KEY (payment_id)
+REFERENCES payment(id)
+ON DELETE CASCADE;                                               -- Delete the hold with its payment
+
+ALTER TABLE payment_authorization
+ADD CONSTRAINT fk_payment_authorization_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;                                               -- Delete the hold with its booking
+
+-- Foreign Key Constraint: Establish relationship between payment_statement and users (customer)
+ALTER TABLE payment_statement
+ADD CONSTRAINT fk_payment_statement_user_id
+FOREIGN KEY (user_id)
+REFERENCES users(id)
+ON DELETE CASCADE;                                               -- Statements go with the customer
+
+-- Foreign Key Constraint: Establish relationship between payout_account and users (owner)
+ALTER TABLE payout_account
+ADD CONSTRAINT fk_payout_account_owner_id
+FOREIGN KEY (owner_id)
+REFERENCES users(id)
+ON DELETE CASCADE;                                               -- Delete payout details when owner is deleted
+
+-- Foreign Key Constraints for security_event table
+ALTER TABLE security_event
+ADD CONSTRAINT fk_security_event_user_id
+FOREIGN KEY (user_id)
+REFERENCES users(id)
+ON DELETE CASCADE;                                               -- Delete events when the affected user is deleted
+
+ALTER TABLE security_event
+ADD CONSTRAINT fk_security_event_reviewed_by
+FOREIGN KEY (reviewed_by)
+REFERENCES users(id)
+ON DELETE SET NULL;                                              -- Keep the event when the reviewing admin is deleted
+
+-- Foreign Key Constraint: Establish relationship between user_login_device and users
+ALTER TABLE user_login_device
+ADD CONSTRAINT fk_user_login_device_user_id
+FOREIGN KEY (user_id)
+REFERENCES users(id)
+ON DELETE CASCADE;                                               -- Forget devices when the user is deleted
+
+-- Foreign Key Constraint: Establish relationship between password_reset_tokens and users
+ALTER TABLE password_reset_tokens
+ADD CONSTRAINT fk_password_reset_tokens_user_id
+FOREIGN KEY (user_id)
+REFERENCES users(id)
+ON DELETE CASCADE;                                               -- Reset links go with the account
+
+-- Foreign Key Constraint: Establish relationship between refresh_tokens and users
+ALTER TABLE refresh_tokens
+ADD CONSTRAINT fk_refresh_tokens_user_id
+FOREIGN KEY (user_id)
+REFERENCES users(id)
+ON DELETE CASCADE;                                               -- Sessions end with the account
+
+-- Foreign Key Constraints for location tables
+ALTER TABLE car_location
+ADD CONSTRAINT fk_car_location_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE car_location
+ADD CONSTRAINT fk_car_location_location_id
+FOREIGN KEY (location_id)
+REFERENCES location(id)
+ON DELETE CASCADE;                                               -- Detach cars when a location is deleted
+
+ALTER TABLE car_alert_subscription
+ADD CONSTRAINT fk_car_alert_subscription_user_id
+FOREIGN KEY (user_id)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+ALTER TABLE car_alert_subscription
+ADD CONSTRAINT fk_car_alert_subscription_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;                                               -- Stop alerts when the car is deleted
+
+ALTER TABLE car_favorite
+ADD CONSTRAINT fk_car_favorite_user_id
+FOREIGN KEY (user_id)
+REFERENCES users(id)
+ON DELETE CASCADE;
 
--- Payment Table Definition
--- Stores payment information for bookings with Razorpay integration
-CREATE TABLE payment (
-    -- Primary key: Unique identifier for each payment
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    
-    -- Relationship fields
-    booking_id UUID NOT NULL,                                    -- Reference to booking.id
-    
-    -- Razorpay specific fields
-    razorpay_order_id VARCHAR(255),                             -- Razorpay order ID
-    razorpay_payment_id VARCHAR(255),                           -- Razorpay payment ID
-    
-    -- Payment details
-    amount DECIMAL(10,2) NOT NULL,                              -- Payment amount in INR
-    currency VARCHAR(3) DEFAULT 'INR',                          -- Currency code
-    status VARCHAR(50) DEFAULT 'pending',                       -- pending, completed, failed, refunded, cancelled
-    method VARCHAR(50) NOT NULL,                                -- razorpay, cash, card, upi, netbanking
-    transaction_id VARCHAR(255),                                -- Transaction reference ID
-    description TEXT,                                           -- Payment description
-    notes TEXT,                                                 -- Additional payment notes
-    
-    -- Audit trail columns
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,             -- Payment creation timestamp
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP              -- Last update timestamp
-);
+ALTER TABLE car_favorite
+ADD CONSTRAINT fk_car_favorite_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;                                               -- Drop the car from wishlists when it is deleted
 
--- =============================================================================
--- CONSTRAINTS AND RELATIONSHIPS
--- =============================================================================
+ALTER TABLE car_image_upload
+ADD CONSTRAINT fk_car_image_upload_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;                                               -- Stop uploading images of a deleted car
+
+ALTER TABLE car_purchase
+ADD CONSTRAINT fk_car_purchase_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;                                               -- Forget the purchase of a deleted car
+
+ALTER TABLE car_price_history
+ADD CONSTRAINT fk_car_price_history_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;                                               -- Forget the prices of a deleted car
+
+ALTER TABLE car_price_history
+ADD CONSTRAINT fk_car_price_history_changed_by
+FOREIGN KEY (changed_by)
+REFERENCES users(id)
+ON DELETE SET NULL;                                              -- Keep the history when the user is deleted
+
+ALTER TABLE notification
+ADD CONSTRAINT fk_notification_user_id
+FOREIGN KEY (user_id)
+REFERENCES users(id)
+ON DELETE CASCADE;                                               -- Delete the inbox when the user is deleted
+
+ALTER TABLE email_template
+ADD CONSTRAINT fk_email_template_created_by
+FOREIGN KEY (created_by)
+REFERENCES users(id)
+ON DELETE SET NULL;                                              -- Keep template history when the admin is deleted
+
+ALTER TABLE owner_vacation
+ADD CONSTRAINT fk_owner_vacation_owner_id
+FOREIGN KEY (owner_id)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+ALTER TABLE car_blackout
+ADD CONSTRAINT fk_car_blackout_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;                                               -- Drop blackouts of deleted cars
+
+ALTER TABLE car_blackout
+ADD CONSTRAINT fk_car_blackout_vacation_id
+FOREIGN KEY (vacation_id)
+REFERENCES owner_vacation(id)
+ON DELETE CASCADE;
+
+ALTER TABLE fleet
+ADD CONSTRAINT fk_fleet_owner_id
+FOREIGN KEY (owner_id)
+REFERENCES users(id)
+ON DELETE CASCADE;
 
--- Foreign Key Constraint: Establish relationship between car and user (owner)
 ALTER TABLE car
-ADD CONSTRAINT fk_car_owner_id
+ADD CONSTRAINT fk_car_fleet_id
+FOREIGN KEY (fleet_id)
+REFERENCES fleet(id)
+ON DELETE SET NULL;                                              -- Cars leave a deleted fleet
+
+ALTER TABLE car_delivery_option
+ADD CONSTRAINT fk_car_delivery_option_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE car_fuel_policy
+ADD CONSTRAINT fk_car_fuel_policy_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE car_eligibility
+ADD CONSTRAINT fk_car_eligibility_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE user_kyc
+ADD CONSTRAINT fk_user_kyc_user_id
+FOREIGN KEY (user_id)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+ALTER TABLE user_kyc
+ADD CONSTRAINT fk_user_kyc_verified_by
+FOREIGN KEY (verified_by)
+REFERENCES users(id);
+
+ALTER TABLE featured_listing
+ADD CONSTRAINT fk_featured_listing_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE featured_listing
+ADD CONSTRAINT fk_featured_listing_owner_id
 FOREIGN KEY (owner_id)
+REFERENCES users(id);
+
+ALTER TABLE user_block
+ADD CONSTRAINT fk_user_block_blocker_id
+FOREIGN KEY (blocker_id)
 REFERENCES users(id)
-ON DELETE SET NULL;                                              -- Set owner_id to NULL when user is deleted
+ON DELETE CASCADE;
 
--- Foreign Key Constraints for booking table
-ALTER TABLE booking
-ADD CONSTRAINT fk_booking_customer_id
-FOREIGN KEY (customer_id)
+ALTER TABLE user_block
+ADD CONSTRAINT fk_user_block_blocked_id
+FOREIGN KEY (blocked_id)
 REFERENCES users(id)
-ON DELETE CASCADE;                                               -- Delete booking when customer is deleted
+ON DELETE CASCADE;
+
+ALTER TABLE maintenance_job
+ADD CONSTRAINT fk_maintenance_job_requested_by
+FOREIGN KEY (requested_by)
+REFERENCES users(id);
+
+ALTER TABLE maintenance_job
+ADD CONSTRAINT fk_maintenance_job_operator_id
+FOREIGN KEY (operator_id)
+REFERENCES operator(id)
+ON DELETE CASCADE;
+
+ALTER TABLE car_model
+ADD CONSTRAINT fk_car_model_brand_id
+FOREIGN KEY (brand_id)
+REFERENCES car_brand(id)
+ON DELETE CASCADE;
 
 ALTER TABLE booking
-ADD CONSTRAINT fk_booking_car_id
+ADD CONSTRAINT fk_booking_pickup_location_id
+FOREIGN KEY (pickup_location_id)
+REFERENCES location(id)
+ON DELETE SET NULL;                                              -- Bookings keep their charged fees
+
+ALTER TABLE booking
+ADD CONSTRAINT fk_booking_dropoff_location_id
+FOREIGN KEY (dropoff_location_id)
+REFERENCES location(id)
+ON DELETE SET NULL;
+
+-- Foreign Key Constraints for telemetry tables
+ALTER TABLE telemetry_device
+ADD CONSTRAINT fk_telemetry_device_car_id
 FOREIGN KEY (car_id)
 REFERENCES car(id)
-ON DELETE CASCADE;                                               -- Delete booking when car is deleted
+ON DELETE CASCADE;                                               -- Remove the device when the car is deleted
 
-ALTER TABLE booking
-ADD CONSTRAINT fk_booking_owner_id
+ALTER TABLE car_telemetry
+ADD CONSTRAINT fk_car_telemetry_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;                                               -- Delete readings when the car is deleted
+
+ALTER TABLE car_telemetry
+ADD CONSTRAINT fk_car_telemetry_device_id
+FOREIGN KEY (device_id)
+REFERENCES telemetry_device(id)
+ON DELETE CASCADE;
+
+-- Foreign Key Constraints for smart lock tables
+ALTER TABLE car_smart_lock
+ADD CONSTRAINT fk_car_smart_lock_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;                                               -- Forget the lock when the car is deleted
+
+ALTER TABLE digital_key
+ADD CONSTRAINT fk_digital_key_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;
+
+ALTER TABLE digital_key
+ADD CONSTRAINT fk_digital_key_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE lock_event
+ADD CONSTRAINT fk_lock_event_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE lock_event
+ADD CONSTRAINT fk_lock_event_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE SET NULL;                                              -- Keep the car's history when a booking is deleted
+
+ALTER TABLE lock_event
+ADD CONSTRAINT fk_lock_event_digital_key_id
+FOREIGN KEY (digital_key_id)
+REFERENCES digital_key(id)
+ON DELETE SET NULL;
+
+-- Foreign Key Constraints for incident tables
+ALTER TABLE incident
+ADD CONSTRAINT fk_incident_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;                                               -- Delete incidents with their booking
+
+ALTER TABLE incident
+ADD CONSTRAINT fk_incident_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE incident
+ADD CONSTRAINT fk_incident_customer_id
+FOREIGN KEY (customer_id)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+ALTER TABLE incident
+ADD CONSTRAINT fk_incident_owner_id
 FOREIGN KEY (owner_id)
 REFERENCES users(id)
-ON DELETE SET NULL;                                              -- Set owner_id to NULL when owner is deleted
+ON DELETE CASCADE;
 
--- Foreign Key Constraints for payment table
-ALTER TABLE payment
-ADD CONSTRAINT fk_payment_booking_id
+ALTER TABLE incident
+ADD CONSTRAINT fk_incident_reported_by
+FOREIGN KEY (reported_by)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+ALTER TABLE incident_document
+ADD CONSTRAINT fk_incident_document_incident_id
+FOREIGN KEY (incident_id)
+REFERENCES incident(id)
+ON DELETE CASCADE;                                               -- Delete documents with their incident
+
+ALTER TABLE incident_document
+ADD CONSTRAINT fk_incident_document_added_by
+FOREIGN KEY (added_by)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+-- Foreign Key Constraints for geofence tables
+ALTER TABLE geofence
+ADD CONSTRAINT fk_geofence_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+ALTER TABLE geofence
+ADD CONSTRAINT fk_geofence_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;                                               -- Booking-specific geofences go with their booking
+
+ALTER TABLE geofence
+ADD CONSTRAINT fk_geofence_created_by
+FOREIGN KEY (created_by)
+REFERENCES users(id)
+ON DELETE CASCADE;
+
+ALTER TABLE geofence_breach
+ADD CONSTRAINT fk_geofence_breach_geofence_id
+FOREIGN KEY (geofence_id)
+REFERENCES geofence(id)
+ON DELETE CASCADE;
+
+ALTER TABLE geofence_breach
+ADD CONSTRAINT fk_geofence_breach_booking_id
+FOREIGN KEY (booking_id)
+REFERENCES booking(id)
+ON DELETE CASCADE;
+
+ALTER TABLE geofence_breach
+ADD CONSTRAINT fk_geofence_breach_car_id
+FOREIGN KEY (car_id)
+REFERENCES car(id)
+ON DELETE CASCADE;
+
+-- Foreign Key Constraints for booking_inspection table
+ALTER TABLE booking_inspection
+ADD CONSTRAINT fk_booking_inspection_booking_id
 FOREIGN KEY (booking_id)
 REFERENCES booking(id)
-ON DELETE CASCADE;                                               -- Delete payment when booking is deleted
+ON DELETE CASCADE;                                               -- Delete inspections when the booking is deleted
+
+ALTER TABLE booking_inspection
+ADD CONSTRAINT fk_booking_inspection_recorded_by
+FOREIGN KEY (recorded_by)
+REFERENCES users(id)
+ON DELETE RESTRICT;                                              -- Keep handover records attributable
+
+ALTER TABLE booking_inspection
+ADD CONSTRAINT fk_booking_inspection_checklist_version
+FOREIGN KEY (checklist_version)
+REFERENCES checklist_template(version)
+ON DELETE RESTRICT;                                              -- Keep the items recorded answers refer to
+
+-- Foreign Key Constraints for checklist_template table
+ALTER TABLE checklist_template
+ADD CONSTRAINT fk_checklist_template_created_by
+FOREIGN KEY (created_by)
+REFERENCES users(id)
+ON DELETE SET NULL;                                              -- Keep checklist history when the admin is deleted
 
 -- Check constraints for data validation
 ALTER TABLE booking
 ADD CONSTRAINT check_booking_status 
-CHECK (status IN ('pending', 'confirmed', 'active', 'completed', 'cancelled'));
+CHECK (status IN ('pending', 'confirmed', 'in_progress', 'completed', 'cancelled', 'under_review'));
 
 ALTER TABLE booking
 ADD CONSTRAINT check_booking_dates 
@@ -180,7 +2062,7 @@ CHECK (total_amount > 0);
 -- Check constraints for payment validation
 ALTER TABLE payment
 ADD CONSTRAINT check_payment_status 
-CHECK (status IN ('pending', 'completed', 'failed', 'refunded', 'cancelled'));
+CHECK (status IN ('pending', 'authorized', 'completed', 'failed', 'refunded', 'cancelled', 'voided'));
 
 ALTER TABLE payment
 ADD CONSTRAINT check_payment_method 
@@ -194,6 +2076,164 @@ ALTER TABLE payment
 ADD CONSTRAINT check_payment_currency 
 CHECK (currency = 'INR');
 
+ALTER TABLE payment_link
+ADD CONSTRAINT check_payment_link_status
+CHECK (status IN ('created', 'paid', 'expired', 'cancelled'));
+
+ALTER TABLE payment_link
+ADD CONSTRAINT check_payment_link_amount
+CHECK (amount > 0);
+
+ALTER TABLE payment_attempt
+ADD CONSTRAINT check_payment_attempt_status
+CHECK (status IN ('pending', 'authorized', 'completed', 'failed'));
+
+ALTER TABLE payment_collection
+ADD CONSTRAINT check_payment_collection_amount
+CHECK (amount > 0);
+
+ALTER TABLE dispute
+ADD CONSTRAINT check_dispute_status
+CHECK (status IN ('open', 'under_review', 'won', 'lost', 'closed'));
+
+ALTER TABLE dispute
+ADD CONSTRAINT check_dispute_amount
+CHECK (amount >= 0);
+
+ALTER TABLE dispute_evidence
+ADD CONSTRAINT check_dispute_evidence_kind
+CHECK (kind IN ('invoice', 'agreement', 'inspection', 'communication', 'other'));
+
+ALTER TABLE financial_document
+ADD CONSTRAINT check_financial_document_series
+CHECK (series IN ('INV', 'RCP', 'CN'));
+
+ALTER TABLE booking_adjustment
+ADD CONSTRAINT check_booking_adjustment_kind
+CHECK (kind IN ('credit_note', 'charge'));
+
+ALTER TABLE booking_adjustment
+ADD CONSTRAINT check_booking_adjustment_status
+CHECK (status IN ('pending_approval', 'applied', 'rejected'));
+
+ALTER TABLE booking_adjustment
+ADD CONSTRAINT check_booking_adjustment_amount
+CHECK (amount > 0);
+
+ALTER TABLE payment_authorization
+ADD CONSTRAINT check_payment_authorization_status
+CHECK (status IN ('pending', 'authorized', 'captured', 'voided'));
+
+ALTER TABLE payment_authorization
+ADD CONSTRAINT check_payment_authorization_amount
+CHECK (amount > 0);
+
+ALTER TABLE payment_statement
+ADD CONSTRAINT check_payment_statement_format
+CHECK (format IN ('pdf', 'csv'));
+
+ALTER TABLE payment_statement
+ADD CONSTRAINT check_payment_statement_status
+CHECK (status IN ('pending', 'rendering', 'ready', 'failed'));
+
+ALTER TABLE payment_statement
+ADD CONSTRAINT check_payment_statement_period
+CHECK (period_to > period_from);
+
+ALTER TABLE payout_account
+ADD CONSTRAINT check_payout_account_type
+CHECK (account_type IN ('bank_account', 'vpa'));
+
+ALTER TABLE payout_account
+ADD CONSTRAINT check_payout_account_status
+CHECK (status IN ('pending', 'verified', 'failed'));
+
+ALTER TABLE security_event
+ADD CONSTRAINT check_security_event_severity
+CHECK (severity IN ('low', 'medium', 'high'));
+
+ALTER TABLE security_event
+ADD CONSTRAINT check_security_event_status
+CHECK (status IN ('open', 'reviewed', 'dismissed'));
+
+ALTER TABLE car_telemetry
+ADD CONSTRAINT check_car_telemetry_levels
+CHECK ((fuel_level IS NULL OR fuel_level BETWEEN 0 AND 100) AND (battery_level IS NULL OR battery_level BETWEEN 0 AND 100));
+
+ALTER TABLE digital_key
+ADD CONSTRAINT check_digital_key_status
+CHECK (status IN ('issued', 'revoked', 'failed'));
+
+ALTER TABLE lock_event
+ADD CONSTRAINT check_lock_event_action
+CHECK (action IN ('lock', 'unlock'));
+
+ALTER TABLE incident
+ADD CONSTRAINT check_incident_kind
+CHECK (kind IN ('accident', 'damage', 'theft', 'breakdown', 'other'));
+
+ALTER TABLE incident
+ADD CONSTRAINT check_incident_status
+CHECK (status IN ('reported', 'under_review', 'claim_filed', 'resolved', 'closed'));
+
+ALTER TABLE incident_document
+ADD CONSTRAINT check_incident_document_kind
+CHECK (kind IN ('insurance_policy', 'insurance_claim', 'police_report', 'repair_estimate', 'other'));
+
+ALTER TABLE booking_inspection
+ADD CONSTRAINT check_booking_inspection_kind
+CHECK (kind IN ('checkout', 'checkin'));
+
+ALTER TABLE booking_inspection
+ADD CONSTRAINT check_booking_inspection_sources
+CHECK ((odometer_source IS NULL OR odometer_source IN ('manual', 'telemetry')) AND (fuel_source IS NULL OR fuel_source IN ('manual', 'telemetry')));
+
+ALTER TABLE booking_inspection
+ADD CONSTRAINT check_booking_inspection_checklist
+CHECK ((checklist_version IS NULL) = (checklist IS NULL));
+
+ALTER TABLE location
+ADD CONSTRAINT check_location_type
+CHECK (type IN ('airport', 'branch'));
+
+ALTER TABLE location
+ADD CONSTRAINT check_location_fees
+CHECK (pickup_fee >= 0 AND dropoff_fee >= 0);
+
+ALTER TABLE car_delivery_option
+ADD CONSTRAINT check_car_delivery_option
+CHECK (origin_latitude BETWEEN -90 AND 90 AND origin_longitude BETWEEN -180 AND 180
+       AND max_radius_km > 0 AND base_fee >= 0 AND fee_per_km >= 0);
+
+ALTER TABLE car_fuel_policy
+ADD CONSTRAINT check_car_fuel_policy
+CHECK (policy IN ('full_to_full', 'prepaid') AND tank_capacity_liters > 0);
+
+ALTER TABLE car_alert_subscription
+ADD CONSTRAINT check_car_alert_subscription
+CHECK ((price_drop OR availability) AND (target_price IS NULL OR target_price > 0)
+       AND ((start_date IS NULL AND end_date IS NULL) OR end_date > start_date));
+
+ALTER TABLE relocation_fee
+ADD CONSTRAINT check_relocation_fee
+CHECK (fee >= 0 AND LOWER(from_city) <> LOWER(to_city));
+
+ALTER TABLE owner_vacation
+ADD CONSTRAINT check_owner_vacation_dates
+CHECK (end_date > start_date);
+
+ALTER TABLE car_blackout
+ADD CONSTRAINT check_car_blackout_dates
+CHECK (end_date > start_date);
+
+ALTER TABLE booking
+ADD CONSTRAINT check_booking_delivery
+CHECK (delivery_address IS NULL OR pickup_location_id IS NULL);
+
+ALTER TABLE geofence
+ADD CONSTRAINT check_geofence_region
+CHECK (center_latitude BETWEEN -90 AND 90 AND center_longitude BETWEEN -180 AND 180 AND radius_km > 0);
+
 -- Check constraints for data validation
 ALTER TABLE car
 ADD CONSTRAINT check_availability_type 
@@ -201,7 +2241,7 @@ CHECK (availability_type IN ('rental'));
 
 ALTER TABLE car
 ADD CONSTRAINT check_status 
-CHECK (status IN ('active', 'maintenance', 'inactive'));
+CHECK (status IN ('draft', 'pending_review', 'active', 'maintenance', 'retired'));
 
 ALTER TABLE car
 ADD CONSTRAINT check_fuel_type 
@@ -211,14 +2251,22 @@ CHECK (fuel_type IN ('Petrol', 'Diesel', 'Electric', 'Hybrid', 'CNG'));
 -- INDEXES FOR PERFORMANCE
 -- =============================================================================
 
--- Index on user email for fast authentication queries
-CREATE INDEX idx_users_email ON users(email);
+-- User e-mail and phone_hash lookups use the indexes of their unique constraints
+
+-- Index for admin support search by e-mail in any case
+CREATE INDEX idx_users_email_lower ON users(LOWER(email));
 
 -- Index on user role for authorization queries
 CREATE INDEX idx_users_role ON users(role);
 
 -- Index on car brand for fast brand-based queries
 CREATE INDEX idx_car_brand ON car(brand);
+-- Trigram index for ILIKE and fuzzy (%) brand matching
+CREATE INDEX idx_car_brand_trgm ON car USING gin(brand gin_trgm_ops);
+
+-- Case-insensitive indexes for brand and model autocompletes and lookups
+CREATE INDEX idx_car_brand_lower_name ON car_brand(LOWER(name) text_pattern_ops);
+CREATE INDEX idx_car_model_brand_lower_name ON car_model(brand_id, LOWER(name) text_pattern_ops);
 
 -- Index on car year for year-based filtering
 CREATE INDEX idx_car_year ON car(year);
@@ -234,6 +2282,10 @@ CREATE INDEX idx_car_status ON car(status);
 
 -- JSONB indexes for engine and price searches
 CREATE INDEX idx_car_engine_gin ON car USING gin(engine);
+-- Containment index for feature filters (features @> '{"gps": true}')
+CREATE INDEX idx_car_features_gin ON car USING gin(features jsonb_path_ops);
+-- Containment index for custom attribute filters (attributes @> '{"pet_friendly": true}')
+CREATE INDEX idx_car_attributes_gin ON car USING gin(attributes jsonb_path_ops);
 -- Specific index for common price queries
 CREATE INDEX idx_car_engine_horsepower ON car USING btree((engine->>'horsepower'));
 CREATE INDEX idx_car_price ON car USING btree(price);
@@ -246,16 +2298,162 @@ CREATE INDEX idx_booking_status ON booking(status);
 -- Removed: booking_type index (no longer needed for rental-only platform)
 CREATE INDEX idx_booking_dates ON booking(start_date, end_date);
 CREATE INDEX idx_booking_created_at ON booking(created_at);
+-- Extensions of a trip
+CREATE INDEX idx_booking_parent_booking_id ON booking(parent_booking_id) WHERE parent_booking_id IS NOT NULL;
+-- Pending bookings by age, for expiring unpaid ones
+CREATE INDEX idx_booking_pending_created_at ON booking(created_at) WHERE status = 'pending';
 
 -- Payment table indexes for performance
 CREATE INDEX idx_payment_booking_id ON payment(booking_id);
 CREATE INDEX idx_payment_status ON payment(status);
 CREATE INDEX idx_payment_method ON payment(method);
-CREATE INDEX idx_payment_razorpay_order_id ON payment(razorpay_order_id);
 CREATE INDEX idx_payment_razorpay_payment_id ON payment(razorpay_payment_id);
 CREATE INDEX idx_payment_transaction_id ON payment(transaction_id);
 CREATE INDEX idx_payment_created_at ON payment(created_at);
 
+-- Keyset pagination indexes matching ORDER BY created_at DESC, id DESC
+CREATE INDEX idx_users_created_at_id ON users(created_at DESC, id DESC);
+CREATE INDEX idx_car_created_at_id ON car(created_at DESC, id DESC);
+CREATE INDEX idx_booking_created_at_id ON booking(created_at DESC, id DESC);
+CREATE INDEX idx_payment_created_at_id ON payment(created_at DESC, id DESC);
+
+-- Tenant-scoped queries filter by operator first
+CREATE INDEX idx_users_operator_id ON users(operator_id);
+CREATE INDEX idx_car_operator_id ON car(operator_id);
+CREATE INDEX idx_booking_operator_id ON booking(operator_id);
+CREATE INDEX idx_payment_operator_id ON payment(operator_id);
+
+-- Payment links of a payment or booking
+CREATE INDEX idx_payment_link_payment_id ON payment_link(payment_id);
+CREATE INDEX idx_payment_link_booking_id ON payment_link(booking_id);
+
+-- Earlier attempts of a payment
+CREATE INDEX idx_payment_attempt_payment_id ON payment_attempt(payment_id, created_at);
+CREATE INDEX idx_payment_attempt_status_updated_at ON payment_attempt(status, updated_at);
+
+-- Offline collections of a booking
+CREATE INDEX idx_payment_collection_booking_id ON payment_collection(booking_id, collected_at);
+
+-- Dispute queue by status and response deadline
+CREATE INDEX idx_dispute_status_respond_by ON dispute(status, respond_by);
+CREATE INDEX idx_dispute_payment_id ON dispute(payment_id);
+CREATE INDEX idx_dispute_booking_id ON dispute(booking_id);
+
+-- Evidence of a dispute
+CREATE INDEX idx_dispute_evidence_dispute_id ON dispute_evidence(dispute_id, created_at);
+
+-- Active payout holds of an owner
+CREATE INDEX idx_payout_hold_owner_id ON payout_hold(owner_id, released_at);
+
+-- Documents of a booking; one invoice per booking, one receipt per payment and one credit
+-- note per adjustment
+CREATE INDEX idx_financial_document_booking_id ON financial_document(booking_id, issued_at);
+-- Documents issued in a period, for the accounting export
+CREATE INDEX idx_financial_document_issued_at ON financial_document(issued_at);
+CREATE UNIQUE INDEX idx_financial_document_subject
+    ON financial_document(series, booking_id, COALESCE(payment_id, '00000000-0000-0000-0000-000000000000'::uuid),
+                          COALESCE(adjustment_id, '00000000-0000-0000-0000-000000000000'::uuid));
+
+-- Adjustments of a booking and the approval queue
+CREATE INDEX idx_booking_adjustment_booking_id ON booking_adjustment(booking_id, created_at);
+CREATE INDEX idx_booking_adjustment_status ON booking_adjustment(status, created_at);
+
+-- Held payments of a booking, captured at pickup or voided on cancellation
+CREATE INDEX idx_payment_authorization_booking_id ON payment_authorization(booking_id, status);
+
+-- Statement render queue
+CREATE INDEX idx_payment_statement_status ON payment_statement(status, created_at);
+
+-- Dashboard rankings over a range of days
+CREATE INDEX idx_dashboard_daily_metric_day ON dashboard_daily_metric(metric, day);
+
+-- Payout account lookups by owner
+CREATE INDEX idx_payout_account_owner_id ON payout_account(owner_id);
+
+-- Security event review queue and per-user lookups
+CREATE INDEX idx_security_event_status_created_at_id ON security_event(status, created_at DESC, id DESC);
+CREATE INDEX idx_security_event_created_at_id ON security_event(created_at DESC, id DESC);
+CREATE INDEX idx_security_event_user_type ON security_event(user_id, type, created_at);
+CREATE INDEX idx_password_reset_tokens_user_created_at ON password_reset_tokens(user_id, created_at);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+-- Velocity checks over recent payments and cancellations
+CREATE INDEX idx_payment_status_updated_at ON payment(status, updated_at);
+CREATE INDEX idx_booking_customer_status_updated_at ON booking(customer_id, status, updated_at);
+
+-- Dashboard time series of cancellations
+CREATE INDEX idx_booking_status_updated_at ON booking(status, updated_at);
+
+-- Active trips per owner and the overdue trip sweep
+CREATE INDEX idx_booking_in_progress_end_date ON booking(owner_id, end_date) WHERE status = 'in_progress';
+
+-- Latest telemetry per car
+CREATE INDEX idx_car_telemetry_car_recorded_at ON car_telemetry(car_id, recorded_at DESC);
+
+-- Digital keys and lock events per booking; keys of a car by validity for attributing events
+CREATE INDEX idx_digital_key_booking_id ON digital_key(booking_id);
+CREATE INDEX idx_digital_key_car_valid_from ON digital_key(car_id, valid_from);
+CREATE INDEX idx_lock_event_booking_occurred_at ON lock_event(booking_id, occurred_at);
+
+-- Incidents per booking, the admin queue by status, and documents per incident
+CREATE INDEX idx_incident_booking_id ON incident(booking_id, created_at);
+CREATE INDEX idx_incident_status_created_at ON incident(status, created_at);
+CREATE INDEX idx_incident_document_incident_id ON incident_document(incident_id, created_at);
+
+-- Price history of a car, and its latest change for price drop badges
+CREATE INDEX idx_car_price_history_car_changed_at ON car_price_history(car_id, changed_at DESC);
+
+-- Location lookups by city and cars by location
+CREATE INDEX idx_location_city ON location(LOWER(city));
+CREATE INDEX idx_car_location_location_id ON car_location(location_id);
+CREATE INDEX idx_car_alert_subscription_car_id ON car_alert_subscription(car_id);
+-- Wishlist pages per user; favorites of a car for its cascade on delete
+CREATE INDEX idx_car_favorite_user_created_at_id ON car_favorite(user_id, created_at DESC, id DESC);
+CREATE INDEX idx_car_favorite_car_id ON car_favorite(car_id);
+-- Uploads of a car; uploads the job has yet to finish, by when they are due
+CREATE INDEX idx_car_image_upload_car_id ON car_image_upload(car_id, created_at, position);
+CREATE INDEX idx_car_image_upload_due ON car_image_upload(next_attempt_at) WHERE status IN ('pending', 'uploading');
+-- Inbox pages per user and the unread badge count
+CREATE INDEX idx_notification_user_created_at_id ON notification(user_id, created_at DESC, id DESC);
+CREATE INDEX idx_notification_user_unread ON notification(user_id) WHERE read_at IS NULL;
+-- Vacations per owner and blackout lookups during booking conflict checks
+CREATE INDEX idx_owner_vacation_owner_start_date ON owner_vacation(owner_id, start_date DESC);
+CREATE INDEX idx_car_blackout_car_dates ON car_blackout(car_id, start_date, end_date);
+CREATE INDEX idx_car_blackout_vacation_id ON car_blackout(vacation_id);
+-- Fleet members
+CREATE INDEX idx_car_fleet_id ON car(fleet_id);
+
+CREATE UNIQUE INDEX idx_relocation_fee_cities ON relocation_fee(LOWER(from_city), LOWER(to_city));
+
+-- Geofence lookups per car, and at most one open breach per geofence and rental
+CREATE INDEX idx_geofence_car_id ON geofence(car_id);
+CREATE UNIQUE INDEX idx_geofence_breach_open ON geofence_breach(geofence_id, booking_id) WHERE resolved_at IS NULL;
+CREATE INDEX idx_geofence_breach_car_detected_at ON geofence_breach(car_id, detected_at DESC);
+
+-- Running featured placements of a car, and an owner's placements
+CREATE INDEX idx_featured_listing_car_period ON featured_listing(car_id, status, start_date, end_date);
+CREATE INDEX idx_featured_listing_owner_id ON featured_listing(owner_id, start_date DESC);
+
+-- Blocks against a user, for checking a pair in both directions
+CREATE INDEX idx_user_block_blocked_id ON user_block(blocked_id, blocker_id);
+
+-- One queued or running job per task, target and operator, so repeated requests are not queued twice
+CREATE UNIQUE INDEX idx_maintenance_job_active ON maintenance_job(task,
+    COALESCE(target_id, '00000000-0000-0000-0000-000000000000'),
+    COALESCE(operator_id, '00000000-0000-0000-0000-000000000000'))
+    WHERE status IN ('queued', 'running');
+
+-- Job runner queue and the latest jobs, newest first
+CREATE INDEX idx_maintenance_job_status ON maintenance_job(status, created_at);
+CREATE INDEX idx_maintenance_job_created_at ON maintenance_job(created_at DESC);
+
+-- Debug capture listings newest first, per user, and the purge of expired captures
+CREATE INDEX idx_debug_capture_created_at ON debug_capture(created_at DESC, id DESC);
+CREATE INDEX idx_debug_capture_user_id ON debug_capture(user_id, created_at DESC) WHERE user_id IS NOT NULL;
+CREATE INDEX idx_debug_capture_expires_at ON debug_capture(expires_at);
+CREATE INDEX idx_loadtest_run_expires_at ON loadtest_run(expires_at);
+
 -- =============================================================================
 -- TRIGGERS FOR AUTOMATIC TIMESTAMP UPDATES
 -- =============================================================================
@@ -269,7 +2467,31 @@ BEGIN
 END;
 $$ language 'plpgsql';
 
+-- Function to derive a car slug ("toyota-camry-2023-c0000001") when none is supplied
+-- The id prefix keeps slugs unique across identical brand/model/year listings
+CREATE OR REPLACE FUNCTION set_car_slug()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.slug IS NULL OR NEW.slug = '' THEN
+        NEW.slug = lower(trim(both '-' from regexp_replace(
+            NEW.brand || '-' || NEW.model || '-' || NEW.year, '[^a-zA-Z0-9]+', '-', 'g'
+        ))) || '-' || left(NEW.id::text, 8);
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER set_car_slug_before_insert
+    BEFORE INSERT ON car
+    FOR EACH ROW
+    EXECUTE FUNCTION set_car_slug();
+
 -- Triggers to automatically update updated_at when records are modified
+CREATE TRIGGER update_operator_updated_at
+    BEFORE UPDATE ON operator
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
 CREATE TRIGGER update_users_updated_at 
     BEFORE UPDATE ON users 
     FOR EACH ROW 
@@ -290,6 +2512,165 @@ CREATE TRIGGER update_payment_updated_at
     FOR EACH ROW 
     EXECUTE FUNCTION update_updated_at_column();
 
+CREATE TRIGGER update_payment_attempt_updated_at
+    BEFORE UPDATE ON payment_attempt
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_payment_authorization_updated_at
+    BEFORE UPDATE ON payment_authorization
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_dispute_updated_at
+    BEFORE UPDATE ON dispute
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_payment_link_updated_at
+    BEFORE UPDATE ON payment_link
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_payout_account_updated_at
+    BEFORE UPDATE ON payout_account
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_location_updated_at
+    BEFORE UPDATE ON location
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_car_alert_subscription_updated_at
+    BEFORE UPDATE ON car_alert_subscription
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_relocation_fee_updated_at
+    BEFORE UPDATE ON relocation_fee
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_car_delivery_option_updated_at
+    BEFORE UPDATE ON car_delivery_option
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_car_fuel_policy_updated_at
+    BEFORE UPDATE ON car_fuel_policy
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_car_eligibility_updated_at
+    BEFORE UPDATE ON car_eligibility
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_featured_listing_updated_at
+    BEFORE UPDATE ON featured_listing
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_feature_updated_at
+    BEFORE UPDATE ON feature
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_telemetry_device_updated_at
+    BEFORE UPDATE ON telemetry_device
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_incident_updated_at
+    BEFORE UPDATE ON incident
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- =============================================================================
+-- ROW-LEVEL SECURITY
+-- =============================================================================
+
+-- A second line of defense behind the tenant and ownership conditions of the stores' queries.
+-- The API sets app.operator_id, app.user_id and app.role on its connection before each
+-- statement of a request; background jobs and public requests leave them empty and are not
+-- restricted. FORCE applies the policies to the table owner the API connects as. Superusers and
+-- roles with BYPASSRLS are never restricted, so the API must not connect as one; see
+-- store/initdb for the role docker-compose.yml creates for it.
+
+-- Session variables of the current request, NULL when not set
+CREATE OR REPLACE FUNCTION app_operator_id()
+RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.operator_id', true), '')::uuid
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION app_user_id()
+RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.user_id', true), '')::uuid
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION app_role()
+RETURNS TEXT AS $$
+    SELECT NULLIF(current_setting('app.role', true), '')
+$$ LANGUAGE sql STABLE;
+
+-- Whether the request may see rows of the given operator
+CREATE OR REPLACE FUNCTION app_in_tenant(row_operator_id UUID)
+RETURNS BOOLEAN AS $$
+    SELECT app_operator_id() IS NULL OR row_operator_id = app_operator_id()
+$$ LANGUAGE sql STABLE;
+
+-- Whether the request may act on rows of the given users: admins, requests without a user and
+-- the users themselves
+CREATE OR REPLACE FUNCTION app_acts_for(VARIADIC user_ids UUID[])
+RETURNS BOOLEAN AS $$
+    SELECT app_user_id() IS NULL OR app_role() = 'admin' OR app_user_id() = ANY(user_ids)
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE car ENABLE ROW LEVEL SECURITY;
+ALTER TABLE car FORCE ROW LEVEL SECURITY;
+ALTER TABLE booking ENABLE ROW LEVEL SECURITY;
+ALTER TABLE booking FORCE ROW LEVEL SECURITY;
+ALTER TABLE payment ENABLE ROW LEVEL SECURITY;
+ALTER TABLE payment FORCE ROW LEVEL SECURITY;
+
+-- Rows are allowed by default and narrowed by the restrictive policies below
+CREATE POLICY car_access ON car USING (true);
+CREATE POLICY booking_access ON booking USING (true);
+CREATE POLICY payment_access ON payment USING (true);
+
+-- No request sees or writes another operator's rows
+CREATE POLICY car_tenant ON car AS RESTRICTIVE
+    USING (app_in_tenant(operator_id)) WITH CHECK (app_in_tenant(operator_id));
+CREATE POLICY booking_tenant ON booking AS RESTRICTIVE
+    USING (app_in_tenant(operator_id)) WITH CHECK (app_in_tenant(operator_id));
+CREATE POLICY payment_tenant ON payment AS RESTRICTIVE
+    USING (app_in_tenant(operator_id)) WITH CHECK (app_in_tenant(operator_id));
+
+-- Listings are public, but only their owner lists, changes or removes a car
+CREATE POLICY car_owner_insert ON car AS RESTRICTIVE FOR INSERT
+    WITH CHECK (app_acts_for(owner_id));
+CREATE POLICY car_owner_update ON car AS RESTRICTIVE FOR UPDATE
+    USING (app_acts_for(owner_id));
+CREATE POLICY car_owner_delete ON car AS RESTRICTIVE FOR DELETE
+    USING (app_acts_for(owner_id));
+
+-- Bookings stay readable within the operator because availability and conflict checks count
+-- other renters' bookings of a car; only the renter and the owner book or change them
+CREATE POLICY booking_party_insert ON booking AS RESTRICTIVE FOR INSERT
+    WITH CHECK (app_acts_for(customer_id));
+CREATE POLICY booking_party_update ON booking AS RESTRICTIVE FOR UPDATE
+    USING (app_acts_for(customer_id, owner_id));
+CREATE POLICY booking_party_delete ON booking AS RESTRICTIVE FOR DELETE
+    USING (app_acts_for(customer_id, owner_id));
+
+-- Payments are only seen and changed by the renter and the owner of their booking
+CREATE POLICY payment_party ON payment AS RESTRICTIVE
+    USING (app_user_id() IS NULL OR app_role() = 'admin' OR EXISTS (
+        SELECT 1 FROM booking b WHERE b.id = payment.booking_id AND app_acts_for(b.customer_id, b.owner_id)))
+    WITH CHECK (app_user_id() IS NULL OR app_role() = 'admin' OR EXISTS (
+        SELECT 1 FROM booking b WHERE b.id = payment.booking_id AND app_acts_for(b.customer_id, b.owner_id)));
+
 -- =============================================================================
 -- SAMPLE DATA FOR TESTING AND DEVELOPMENT
 -- =============================================================================
@@ -574,6 +2955,19 @@ INSERT INTO booking (id, customer_id, car_id, owner_id, status, total_amount, st
      '2024-03-15 11:00:00', '2024-03-20 11:00:00',
      'Customer wants to try electric vehicle before potential purchase. Special EV orientation requested.');
 
+-- Sample bookings record the car as it is listed now
+UPDATE booking b
+SET car_snapshot = jsonb_build_object('name', c.name, 'brand', c.brand, 'model', c.model, 'year', c.year,
+    'price', c.price, 'images', to_jsonb(c.images), 'slug', c.slug)
+FROM car c
+WHERE c.id = b.car_id AND b.car_snapshot IS NULL;
+
+-- and their customers and owners as they are now
+UPDATE booking b
+SET customer_snapshot = (SELECT jsonb_build_object('name', username, 'email', email) FROM users WHERE id = b.customer_id),
+    owner_snapshot = (SELECT jsonb_build_object('name', username, 'email', email) FROM users WHERE id = b.owner_id)
+WHERE b.customer_snapshot IS NULL;
+
 -- =============================================================================
 -- VERIFICATION QUERIES
 -- =============================================================================
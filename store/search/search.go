@@ -0,0 +1,100 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PrateekKumar15/CarZone/encryption"
+	"github.com/PrateekKumar15/CarZone/models"
+	"go.opentelemetry.io/otel"
+)
+
+// searchQuery finds the users, cars, payments and bookings matching a support search. Each
+// interpretation of the term is a separate branch that can use its own index; parameters that
+// do not apply are NULL and match nothing. Bookings are also found through the customer, car
+// or payment that matched.
+//
+// Parameters: $1 e-mail, $2 phone blind index, $3 license plate, $4 raw term, $5 record ID, $6 limit
+const searchQuery = `
+	WITH matched_users AS (
+	    SELECT id, 'email' AS matched_on FROM users WHERE LOWER(email) = $1
+	    UNION ALL SELECT id, 'phone' FROM users WHERE phone_hash = $2
+	    UNION ALL SELECT id, 'id' FROM users WHERE id = $5
+	), matched_cars AS (
+	    SELECT id, 'license_plate' AS matched_on FROM car WHERE license_plate = $3
+	    UNION ALL SELECT id, 'id' FROM car WHERE id = $5
+	), matched_payments AS (
+	    SELECT id, 'payment_id' AS matched_on FROM payment WHERE razorpay_payment_id = $4
+	    UNION ALL SELECT id, 'order_id' FROM payment WHERE razorpay_order_id = $4
+	    UNION ALL SELECT id, 'transaction_id' FROM payment WHERE transaction_id = $4
+	    UNION ALL SELECT id, 'id' FROM payment WHERE id = $5
+	), matched_bookings AS (
+	    SELECT id, 'id' AS matched_on FROM booking WHERE id = $5
+	    UNION ALL SELECT b.id, 'customer_' || mu.matched_on FROM booking b JOIN matched_users mu ON mu.id = b.customer_id
+	    UNION ALL SELECT b.id, 'car_' || mc.matched_on FROM booking b JOIN matched_cars mc ON mc.id = b.car_id
+	    UNION ALL SELECT p.booking_id, mp.matched_on FROM payment p JOIN matched_payments mp ON mp.id = p.id
+	)
+	SELECT 'user', u.id, u.username, u.email, mu.matched_on, u.created_at
+	FROM matched_users mu JOIN users u ON u.id = mu.id
+	UNION ALL
+	SELECT 'car', c.id, c.name, c.brand || ' ' || c.model || ', ' || c.location_city, mc.matched_on, c.created_at
+	FROM matched_cars mc JOIN car c ON c.id = mc.id
+	UNION ALL
+	SELECT 'booking', b.id, COALESCE(c.name, ''),
+	       b.status || ', ' || to_char(b.start_date, 'YYYY-MM-DD') || ' to ' || to_char(b.end_date, 'YYYY-MM-DD'),
+	       mb.matched_on, b.created_at
+	FROM matched_bookings mb JOIN booking b ON b.id = mb.id LEFT JOIN car c ON c.id = b.car_id
+	UNION ALL
+	SELECT 'payment', p.id, p.amount::text || ' ' || p.currency, p.status || ', ' || p.method, mp.matched_on, p.created_at
+	FROM matched_payments mp JOIN payment p ON p.id = mp.id
+	ORDER BY 6 DESC
+	LIMIT $6`
+
+// SearchStore looks up records for admin support search
+type SearchStore struct {
+	db     *sql.DB
+	cipher *encryption.Cipher
+}
+
+// New creates a new search store. The cipher computes the phone number blind index.
+func New(db *sql.DB, cipher *encryption.Cipher) SearchStore {
+	return SearchStore{db: db, cipher: cipher}
+}
+
+// Search returns the records matching any interpretation of a search term, newest first.
+// A record found in several ways is returned once, with the first way it matched.
+func (s SearchStore) Search(ctx context.Context, query models.SearchQuery, limit int) ([]models.SearchHit, error) {
+	tracer := otel.Tracer("SearchStore")
+	ctx, span := tracer.Start(ctx, "Search-Store")
+	defer span.End()
+
+	var phoneHash *string
+	if query.PhoneKey != nil {
+		if hash := s.cipher.BlindIndex(*query.PhoneKey); hash != "" {
+			phoneHash = &hash
+		}
+	}
+
+	rows, err := s.db.QueryContext(ctx, searchQuery, query.Email, phoneHash, query.LicensePlate, query.Text, query.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := []models.SearchHit{}
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var hit models.SearchHit
+		if err := rows.Scan(&hit.Type, &hit.ID, &hit.Title, &hit.Detail, &hit.MatchedOn, &hit.CreatedAt); err != nil {
+			return nil, err
+		}
+		key := string(hit.Type) + ":" + hit.ID.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		hits = append(hits, hit)
+	}
+
+	return hits, rows.Err()
+}
@@ -0,0 +1,278 @@
+package security
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// securityEventColumns lists the columns read by every security event query
+const securityEventColumns = `id, user_id, type, severity, status, ip_address, user_agent, details,
+	reviewed_by, review_notes, reviewed_at, created_at`
+
+// SecurityStore persists security events and the devices users have logged in from
+type SecurityStore struct {
+	db *sql.DB
+}
+
+// New creates a new security store
+func New(db *sql.DB) SecurityStore {
+	return SecurityStore{db: db}
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// CreateSecurityEvent stores a new open security event
+func (s SecurityStore) CreateSecurityEvent(ctx context.Context, event models.SecurityEvent) (models.SecurityEvent, error) {
+	tracer := otel.Tracer("SecurityStore")
+	ctx, span := tracer.Start(ctx, "CreateSecurityEvent-Store")
+	defer span.End()
+
+	details, err := json.Marshal(event.Details)
+	if err != nil {
+		return models.SecurityEvent{}, err
+	}
+
+	query := `INSERT INTO security_event (id, user_id, type, severity, status, ip_address, user_agent, details, created_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	         RETURNING ` + securityEventColumns
+
+	row := s.db.QueryRowContext(ctx, query, uuid.New(), event.UserID, event.Type, event.Severity,
+		models.SecurityEventStatusOpen, event.IPAddress, event.UserAgent, details, time.Now())
+
+	return scanSecurityEvent(row)
+}
+
+// GetSecurityEventByID retrieves a security event by its ID
+func (s SecurityStore) GetSecurityEventByID(ctx context.Context, id string) (models.SecurityEvent, error) {
+	tracer := otel.Tracer("SecurityStore")
+	ctx, span := tracer.Start(ctx, "GetSecurityEventByID-Store")
+	defer span.End()
+
+	query := `SELECT ` + securityEventColumns + ` FROM security_event WHERE id = $1`
+
+	event, err := scanSecurityEvent(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.SecurityEvent{}, errors.New("no security event found with the given ID")
+		}
+		return models.SecurityEvent{}, err
+	}
+
+	return event, nil
+}
+
+// ListSecurityEvents retrieves one page of security events matching the filter, newest first
+func (s SecurityStore) ListSecurityEvents(ctx context.Context, filter models.SecurityEventFilter, page models.PageRequest) ([]models.SecurityEvent, error) {
+	tracer := otel.Tracer("SecurityStore")
+	ctx, span := tracer.Start(ctx, "ListSecurityEvents-Store")
+	defer span.End()
+
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	// Keyset condition: continue strictly past the cursor in its direction of travel
+	if page.Cursor != nil {
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) %s ($%d, $%d)", page.KeysetComparator(), len(args)-1, len(args)))
+	}
+
+	query := `SELECT ` + securityEventColumns + ` FROM security_event`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, page.Limit+1)
+	query += fmt.Sprintf(" ORDER BY created_at %[1]s, id %[1]s LIMIT $%[2]d", page.SortOrder(), len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.SecurityEvent
+	for rows.Next() {
+		event, err := scanSecurityEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// ReviewSecurityEvent closes an open security event with the admin's verdict
+func (s SecurityStore) ReviewSecurityEvent(ctx context.Context, id, reviewerID string, review models.SecurityEventReview) (models.SecurityEvent, error) {
+	tracer := otel.Tracer("SecurityStore")
+	ctx, span := tracer.Start(ctx, "ReviewSecurityEvent-Store")
+	defer span.End()
+
+	var notes *string
+	if trimmed := strings.TrimSpace(review.Notes); trimmed != "" {
+		notes = &trimmed
+	}
+
+	query := `UPDATE security_event
+	         SET status = $1, reviewed_by = $2, review_notes = $3, reviewed_at = $4
+	         WHERE id = $5 AND status = $6
+	         RETURNING ` + securityEventColumns
+
+	event, err := scanSecurityEvent(s.db.QueryRowContext(ctx, query, review.Status, reviewerID, notes,
+		time.Now(), id, models.SecurityEventStatusOpen))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// Distinguish a missing event from one that was already reviewed
+			if _, getErr := s.GetSecurityEventByID(ctx, id); getErr != nil {
+				return models.SecurityEvent{}, getErr
+			}
+			return models.SecurityEvent{}, errors.New("security event has already been reviewed")
+		}
+		return models.SecurityEvent{}, err
+	}
+
+	return event, nil
+}
+
+// HasOpenSecurityEventSince reports whether the user already has an open event of the given type
+// raised at or after since. It keeps one burst of activity from flooding the review queue.
+func (s SecurityStore) HasOpenSecurityEventSince(ctx context.Context, userID string, eventType models.SecurityEventType, since time.Time) (bool, error) {
+	tracer := otel.Tracer("SecurityStore")
+	ctx, span := tracer.Start(ctx, "HasOpenSecurityEventSince-Store")
+	defer span.End()
+
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM security_event
+	         WHERE user_id = $1 AND type = $2 AND status = $3 AND created_at >= $4)`
+	err := s.db.QueryRowContext(ctx, query, userID, eventType, models.SecurityEventStatusOpen, since).Scan(&exists)
+	return exists, err
+}
+
+// RecordLogin remembers the IP address and user agent a user logged in from.
+// It reports whether this combination had not been seen before, and whether it is
+// the user's very first recorded login (in which case nothing is "new" yet).
+func (s SecurityStore) RecordLogin(ctx context.Context, userID, ipAddress, userAgent string) (isNewDevice bool, isFirstLogin bool, err error) {
+	tracer := otel.Tracer("SecurityStore")
+	ctx, span := tracer.Start(ctx, "RecordLogin-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, false, err
+	}
+	defer tx.Rollback()
+
+	var knownDevices int
+	if err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_login_device WHERE user_id = $1`, userID).Scan(&knownDevices); err != nil {
+		return false, false, err
+	}
+
+	// xmax = 0 only for freshly inserted rows, so it tells inserts and updates apart
+	query := `INSERT INTO user_login_device (id, user_id, ip_address, user_agent, first_seen_at, last_seen_at, login_count)
+	         VALUES ($1, $2, $3, $4, $5, $5, 1)
+	         ON CONFLICT (user_id, ip_address, user_agent)
+	         DO UPDATE SET last_seen_at = EXCLUDED.last_seen_at, login_count = user_login_device.login_count + 1
+	         RETURNING (xmax = 0)`
+	var inserted bool
+	if err = tx.QueryRowContext(ctx, query, uuid.New(), userID, ipAddress, userAgent, time.Now()).Scan(&inserted); err != nil {
+		return false, false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, false, err
+	}
+
+	return inserted, knownDevices == 0, nil
+}
+
+// CountFailedPaymentsSince counts the customer's failed gateway attempts, and failed payments
+// made without one, updated at or after since
+func (s SecurityStore) CountFailedPaymentsSince(ctx context.Context, customerID string, since time.Time) (int, error) {
+	tracer := otel.Tracer("SecurityStore")
+	ctx, span := tracer.Start(ctx, "CountFailedPaymentsSince-Store")
+	defer span.End()
+
+	var count int
+	query := `SELECT (SELECT COUNT(*) FROM payment_attempt a
+	         JOIN payment p ON p.id = a.payment_id
+	         JOIN booking b ON b.id = p.booking_id
+	         WHERE b.customer_id = $1 AND a.status = $2 AND a.updated_at >= $3)
+	         + (SELECT COUNT(*) FROM payment p
+	         JOIN booking b ON b.id = p.booking_id
+	         WHERE b.customer_id = $1 AND p.status = $2 AND p.updated_at >= $3
+	         AND NOT EXISTS (SELECT 1 FROM payment_attempt a WHERE a.payment_id = p.id))`
+	err := s.db.QueryRowContext(ctx, query, customerID, models.PaymentStatusFailed, since).Scan(&count)
+	return count, err
+}
+
+// CountCancelledBookingsSince counts the customer's bookings cancelled at or after since
+func (s SecurityStore) CountCancelledBookingsSince(ctx context.Context, customerID string, since time.Time) (int, error) {
+	tracer := otel.Tracer("SecurityStore")
+	ctx, span := tracer.Start(ctx, "CountCancelledBookingsSince-Store")
+	defer span.End()
+
+	var count int
+	query := `SELECT COUNT(*) FROM booking WHERE customer_id = $1 AND status = $2 AND updated_at >= $3`
+	err := s.db.QueryRowContext(ctx, query, customerID, models.BookingStatusCancelled, since).Scan(&count)
+	return count, err
+}
+
+// CountBookingsCreatedSince counts the bookings a customer created at or after since
+func (s SecurityStore) CountBookingsCreatedSince(ctx context.Context, customerID string, since time.Time) (int, error) {
+	tracer := otel.Tracer("SecurityStore")
+	ctx, span := tracer.Start(ctx, "CountBookingsCreatedSince-Store")
+	defer span.End()
+
+	var count int
+	query := `SELECT COUNT(*) FROM booking WHERE customer_id = $1 AND created_at >= $2`
+	err := s.db.QueryRowContext(ctx, query, customerID, since).Scan(&count)
+	return count, err
+}
+
+// scanSecurityEvent reads one security event row
+func scanSecurityEvent(row rowScanner) (models.SecurityEvent, error) {
+	var event models.SecurityEvent
+	var details []byte
+
+	err := row.Scan(&event.ID, &event.UserID, &event.Type, &event.Severity, &event.Status,
+		&event.IPAddress, &event.UserAgent, &details, &event.ReviewedBy, &event.ReviewNotes,
+		&event.ReviewedAt, &event.CreatedAt)
+	if err != nil {
+		return models.SecurityEvent{}, err
+	}
+
+	if len(details) > 0 {
+		if err := json.Unmarshal(details, &event.Details); err != nil {
+			return models.SecurityEvent{}, err
+		}
+	}
+
+	return event, nil
+}
@@ -0,0 +1,127 @@
+package sequence
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// documentColumns lists the columns read by every document query, in scanDocument order
+const documentColumns = `id, series, fiscal_year, sequence, number, booking_id, payment_id, adjustment_id, amount, issued_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// SequenceStore numbers financial documents from per-series, per-fiscal-year counters
+type SequenceStore struct {
+	db *sql.DB
+}
+
+// New creates a new sequence store
+func New(db *sql.DB) SequenceStore {
+	return SequenceStore{db: db}
+}
+
+// IssueDocument numbers and stores a document. The counter row is locked until the document
+// is committed, so concurrent issues wait for each other and a failed insert gives its number
+// back: numbers within a series and fiscal year have no gaps. A booking gets one invoice, a
+// payment one receipt and an adjustment one credit note; issuing again returns the document
+// already issued.
+func (s SequenceStore) IssueDocument(ctx context.Context, doc models.FinancialDocument) (models.FinancialDocument, error) {
+	tracer := otel.Tracer("SequenceStore")
+	ctx, span := tracer.Start(ctx, "IssueDocument-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.FinancialDocument{}, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO document_sequence (series, fiscal_year, last_value)
+	         VALUES ($1, $2, 0)
+	         ON CONFLICT (series, fiscal_year) DO NOTHING`, doc.Series, doc.FiscalYear)
+	if err != nil {
+		return models.FinancialDocument{}, err
+	}
+
+	var last int64
+	err = tx.QueryRowContext(ctx, `SELECT last_value FROM document_sequence
+	         WHERE series = $1 AND fiscal_year = $2
+	         FOR UPDATE`, doc.Series, doc.FiscalYear).Scan(&last)
+	if err != nil {
+		return models.FinancialDocument{}, err
+	}
+
+	// Checked under the lock, so a concurrent issue for the same subject is seen
+	existing, err := scanDocument(tx.QueryRowContext(ctx, `SELECT `+documentColumns+` FROM financial_document
+	         WHERE series = $1 AND booking_id = $2 AND payment_id IS NOT DISTINCT FROM $3
+	         AND adjustment_id IS NOT DISTINCT FROM $4`,
+		doc.Series, doc.BookingID, doc.PaymentID, doc.AdjustmentID))
+	if err == nil {
+		return existing, nil
+	}
+	if err != sql.ErrNoRows {
+		return models.FinancialDocument{}, err
+	}
+
+	sequence := last + 1
+	if _, err := tx.ExecContext(ctx, `UPDATE document_sequence SET last_value = $3
+	         WHERE series = $1 AND fiscal_year = $2`, doc.Series, doc.FiscalYear, sequence); err != nil {
+		return models.FinancialDocument{}, err
+	}
+
+	issued, err := scanDocument(tx.QueryRowContext(ctx, `INSERT INTO financial_document (`+documentColumns+`)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	         RETURNING `+documentColumns,
+		uuid.New(), doc.Series, doc.FiscalYear, sequence, models.DocumentNumber(doc.Series, doc.FiscalYear, sequence),
+		doc.BookingID, doc.PaymentID, doc.AdjustmentID, doc.Amount, time.Now()))
+	if err != nil {
+		return models.FinancialDocument{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.FinancialDocument{}, err
+	}
+
+	return issued, nil
+}
+
+// GetDocumentsByBookingID retrieves the documents issued for a booking, oldest first
+func (s SequenceStore) GetDocumentsByBookingID(ctx context.Context, bookingID string) ([]models.FinancialDocument, error) {
+	tracer := otel.Tracer("SequenceStore")
+	ctx, span := tracer.Start(ctx, "GetDocumentsByBookingID-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+documentColumns+` FROM financial_document
+	         WHERE booking_id = $1 ORDER BY issued_at, number`, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := []models.FinancialDocument{}
+	for rows.Next() {
+		doc, err := scanDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, doc)
+	}
+
+	return documents, rows.Err()
+}
+
+// scanDocument reads one financial_document row
+func scanDocument(row rowScanner) (models.FinancialDocument, error) {
+	var d models.FinancialDocument
+	err := row.Scan(&d.ID, &d.Series, &d.FiscalYear, &d.Sequence, &d.Number, &d.BookingID, &d.PaymentID,
+		&d.AdjustmentID, &d.Amount, &d.IssuedAt)
+	return d, err
+}
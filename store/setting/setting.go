@@ -0,0 +1,62 @@
+package setting
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"go.opentelemetry.io/otel"
+)
+
+// SettingStore persists the settings of the operator a request acts for
+type SettingStore struct {
+	db *sql.DB
+}
+
+// New creates a new setting store
+func New(db *sql.DB) SettingStore {
+	return SettingStore{db: db}
+}
+
+// GetSetting retrieves one of the operator's settings
+func (s SettingStore) GetSetting(ctx context.Context, key string) (models.Setting, error) {
+	tracer := otel.Tracer("SettingStore")
+	ctx, span := tracer.Start(ctx, "GetSetting-Store")
+	defer span.End()
+
+	var setting models.Setting
+	err := s.db.QueryRowContext(ctx, `SELECT key, value, updated_at FROM setting
+	         WHERE operator_id = $1 AND key = $2`, tenant.ForInsert(ctx), key).
+		Scan(&setting.Key, &setting.Value, &setting.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Setting{}, errors.New("no setting found with the given key")
+		}
+		return models.Setting{}, err
+	}
+
+	return setting, nil
+}
+
+// PutSetting creates or replaces one of the operator's settings
+func (s SettingStore) PutSetting(ctx context.Context, key string, value json.RawMessage) (models.Setting, error) {
+	tracer := otel.Tracer("SettingStore")
+	ctx, span := tracer.Start(ctx, "PutSetting-Store")
+	defer span.End()
+
+	var setting models.Setting
+	err := s.db.QueryRowContext(ctx, `INSERT INTO setting (operator_id, key, value, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $4)
+	         ON CONFLICT (operator_id, key) DO UPDATE SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at
+	         RETURNING key, value, updated_at`, tenant.ForInsert(ctx), key, []byte(value), time.Now()).
+		Scan(&setting.Key, &setting.Value, &setting.UpdatedAt)
+	if err != nil {
+		return models.Setting{}, err
+	}
+
+	return setting, nil
+}
@@ -0,0 +1,279 @@
+package smartlock
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// digitalKeyColumns lists the columns read by every digital key query
+const digitalKeyColumns = `id, booking_id, car_id, provider, lock_ref, provider_key_id, valid_from, valid_until,
+	status, error, created_at, revoked_at`
+
+// lockEventColumns lists the columns read by every lock event query
+const lockEventColumns = `id, car_id, booking_id, digital_key_id, action, provider_event_id, occurred_at, received_at`
+
+// SmartLockStore persists the smart locks fitted in cars, the digital keys issued to them for
+// bookings and the lock and unlock events their providers report
+type SmartLockStore struct {
+	db *sql.DB
+}
+
+// New creates a new smart lock store
+func New(db *sql.DB) SmartLockStore {
+	return SmartLockStore{db: db}
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// UpsertSmartLock links a car to its lock, replacing the lock it was linked to before.
+// Keys already issued keep opening the old lock until they expire or are revoked.
+func (s SmartLockStore) UpsertSmartLock(ctx context.Context, carID string, req models.SmartLockRequest) (models.SmartLock, error) {
+	tracer := otel.Tracer("SmartLockStore")
+	ctx, span := tracer.Start(ctx, "UpsertSmartLock-Store")
+	defer span.End()
+
+	query := `INSERT INTO car_smart_lock (car_id, provider, lock_ref, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $4)
+	         ON CONFLICT (car_id) DO UPDATE SET provider = EXCLUDED.provider, lock_ref = EXCLUDED.lock_ref, updated_at = EXCLUDED.updated_at
+	         RETURNING car_id, provider, lock_ref, created_at, updated_at`
+
+	lock, err := scanSmartLock(s.db.QueryRowContext(ctx, query, carID, req.Provider, req.LockRef, time.Now()))
+	if err != nil {
+		return models.SmartLock{}, store.ConstraintViolation(err, store.ErrDuplicateLockRef)
+	}
+
+	return lock, nil
+}
+
+// GetSmartLockByCarID retrieves the lock fitted in a car
+func (s SmartLockStore) GetSmartLockByCarID(ctx context.Context, carID string) (models.SmartLock, error) {
+	tracer := otel.Tracer("SmartLockStore")
+	ctx, span := tracer.Start(ctx, "GetSmartLockByCarID-Store")
+	defer span.End()
+
+	query := `SELECT car_id, provider, lock_ref, created_at, updated_at FROM car_smart_lock WHERE car_id = $1`
+
+	lock, err := scanSmartLock(s.db.QueryRowContext(ctx, query, carID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.SmartLock{}, errors.New("no smart lock found for the given car")
+		}
+		return models.SmartLock{}, err
+	}
+
+	return lock, nil
+}
+
+// GetSmartLockByRef retrieves the car a provider's lock is fitted in
+func (s SmartLockStore) GetSmartLockByRef(ctx context.Context, provider, lockRef string) (models.SmartLock, error) {
+	tracer := otel.Tracer("SmartLockStore")
+	ctx, span := tracer.Start(ctx, "GetSmartLockByRef-Store")
+	defer span.End()
+
+	query := `SELECT car_id, provider, lock_ref, created_at, updated_at FROM car_smart_lock
+	         WHERE provider = $1 AND lock_ref = $2`
+
+	lock, err := scanSmartLock(s.db.QueryRowContext(ctx, query, provider, lockRef))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.SmartLock{}, errors.New("no smart lock found with the given reference")
+		}
+		return models.SmartLock{}, err
+	}
+
+	return lock, nil
+}
+
+// DeleteSmartLock unlinks a car from its lock. Its keys and lock events are kept.
+func (s SmartLockStore) DeleteSmartLock(ctx context.Context, carID string) error {
+	tracer := otel.Tracer("SmartLockStore")
+	ctx, span := tracer.Start(ctx, "DeleteSmartLock-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM car_smart_lock WHERE car_id = $1`, carID)
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no smart lock found for the given car")
+	}
+
+	return nil
+}
+
+// CreateDigitalKey records a key requested from a provider, whether it was issued or failed
+func (s SmartLockStore) CreateDigitalKey(ctx context.Context, key models.DigitalKey) (models.DigitalKey, error) {
+	tracer := otel.Tracer("SmartLockStore")
+	ctx, span := tracer.Start(ctx, "CreateDigitalKey-Store")
+	defer span.End()
+
+	query := `INSERT INTO digital_key (id, booking_id, car_id, provider, lock_ref, provider_key_id, valid_from, valid_until, status, error, created_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	         RETURNING ` + digitalKeyColumns
+
+	return scanDigitalKey(s.db.QueryRowContext(ctx, query, uuid.New(), key.BookingID, key.CarID, key.Provider, key.LockRef,
+		key.ProviderKeyID, key.ValidFrom, key.ValidUntil, key.Status, key.Error, time.Now()))
+}
+
+// GetDigitalKeysByBookingID retrieves the keys issued for a booking, oldest first
+func (s SmartLockStore) GetDigitalKeysByBookingID(ctx context.Context, bookingID string) ([]models.DigitalKey, error) {
+	tracer := otel.Tracer("SmartLockStore")
+	ctx, span := tracer.Start(ctx, "GetDigitalKeysByBookingID-Store")
+	defer span.End()
+
+	query := `SELECT ` + digitalKeyColumns + ` FROM digital_key WHERE booking_id = $1 ORDER BY created_at, id`
+
+	rows, err := s.db.QueryContext(ctx, query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []models.DigitalKey
+	for rows.Next() {
+		key, err := scanDigitalKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RevokeDigitalKey marks an issued key revoked
+func (s SmartLockStore) RevokeDigitalKey(ctx context.Context, id string) (models.DigitalKey, error) {
+	tracer := otel.Tracer("SmartLockStore")
+	ctx, span := tracer.Start(ctx, "RevokeDigitalKey-Store")
+	defer span.End()
+
+	query := `UPDATE digital_key SET status = $2, revoked_at = $3
+	         WHERE id = $1 AND status = $4
+	         RETURNING ` + digitalKeyColumns
+
+	key, err := scanDigitalKey(s.db.QueryRowContext(ctx, query, id, models.DigitalKeyStatusRevoked, time.Now(), models.DigitalKeyStatusIssued))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DigitalKey{}, errors.New("no issued digital key found with the given ID")
+		}
+		return models.DigitalKey{}, err
+	}
+
+	return key, nil
+}
+
+// FindDigitalKeyForEvent finds the key a lock event of a car was made with: the key with the
+// provider's key ID when one is given, otherwise the newest key of the car that was valid at
+// the time and not yet revoked
+func (s SmartLockStore) FindDigitalKeyForEvent(ctx context.Context, carID uuid.UUID, providerKeyID string, occurredAt time.Time) (models.DigitalKey, error) {
+	tracer := otel.Tracer("SmartLockStore")
+	ctx, span := tracer.Start(ctx, "FindDigitalKeyForEvent-Store")
+	defer span.End()
+
+	var row *sql.Row
+	if providerKeyID != "" {
+		row = s.db.QueryRowContext(ctx, `SELECT `+digitalKeyColumns+` FROM digital_key
+		         WHERE car_id = $1 AND provider_key_id = $2
+		         ORDER BY created_at DESC LIMIT 1`, carID, providerKeyID)
+	} else {
+		row = s.db.QueryRowContext(ctx, `SELECT `+digitalKeyColumns+` FROM digital_key
+		         WHERE car_id = $1 AND status != $3 AND valid_from <= $2 AND valid_until >= $2
+		           AND (revoked_at IS NULL OR revoked_at > $2)
+		         ORDER BY created_at DESC LIMIT 1`, carID, occurredAt, models.DigitalKeyStatusFailed)
+	}
+
+	key, err := scanDigitalKey(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DigitalKey{}, errors.New("no digital key found for the lock event")
+		}
+		return models.DigitalKey{}, err
+	}
+
+	return key, nil
+}
+
+// CreateLockEvent stores a lock event. Providers redeliver events, so an event whose
+// provider ID is already stored is not stored again, and false is returned for it.
+func (s SmartLockStore) CreateLockEvent(ctx context.Context, event models.LockEvent) (models.LockEvent, bool, error) {
+	tracer := otel.Tracer("SmartLockStore")
+	ctx, span := tracer.Start(ctx, "CreateLockEvent-Store")
+	defer span.End()
+
+	query := `INSERT INTO lock_event (id, car_id, booking_id, digital_key_id, action, provider_event_id, occurred_at, received_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	         ON CONFLICT (provider_event_id) DO NOTHING
+	         RETURNING ` + lockEventColumns
+
+	stored, err := scanLockEvent(s.db.QueryRowContext(ctx, query, uuid.New(), event.CarID, event.BookingID, event.DigitalKeyID,
+		event.Action, event.ProviderEventID, event.OccurredAt, time.Now()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.LockEvent{}, false, nil
+		}
+		return models.LockEvent{}, false, err
+	}
+
+	return stored, true, nil
+}
+
+// GetLockEventsByBookingID retrieves the lock events logged against a booking, oldest first
+func (s SmartLockStore) GetLockEventsByBookingID(ctx context.Context, bookingID string) ([]models.LockEvent, error) {
+	tracer := otel.Tracer("SmartLockStore")
+	ctx, span := tracer.Start(ctx, "GetLockEventsByBookingID-Store")
+	defer span.End()
+
+	query := `SELECT ` + lockEventColumns + ` FROM lock_event WHERE booking_id = $1 ORDER BY occurred_at, id`
+
+	rows, err := s.db.QueryContext(ctx, query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.LockEvent
+	for rows.Next() {
+		event, err := scanLockEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// scanSmartLock reads one car smart lock row
+func scanSmartLock(row rowScanner) (models.SmartLock, error) {
+	var l models.SmartLock
+	err := row.Scan(&l.CarID, &l.Provider, &l.LockRef, &l.CreatedAt, &l.UpdatedAt)
+	return l, err
+}
+
+// scanDigitalKey reads one digital key row
+func scanDigitalKey(row rowScanner) (models.DigitalKey, error) {
+	var k models.DigitalKey
+	err := row.Scan(&k.ID, &k.BookingID, &k.CarID, &k.Provider, &k.LockRef, &k.ProviderKeyID, &k.ValidFrom, &k.ValidUntil,
+		&k.Status, &k.Error, &k.CreatedAt, &k.RevokedAt)
+	return k, err
+}
+
+// scanLockEvent reads one lock event row
+func scanLockEvent(row rowScanner) (models.LockEvent, error) {
+	var e models.LockEvent
+	err := row.Scan(&e.ID, &e.CarID, &e.BookingID, &e.DigitalKeyID, &e.Action, &e.ProviderEventID, &e.OccurredAt, &e.ReceivedAt)
+	return e, err
+}
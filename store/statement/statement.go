@@ -0,0 +1,182 @@
+package statement
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// statementColumns lists the columns read by every statement query, in scanStatement order
+const statementColumns = `id, user_id, period_from, period_to, format, status, error, created_at, completed_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// StatementStore reads the payments that go on a customer's statement and keeps statements
+// rendered in the background
+type StatementStore struct {
+	db *sql.DB
+}
+
+// New creates a new statement store
+func New(db *sql.DB) StatementStore {
+	return StatementStore{db: db}
+}
+
+// GetStatementPayments retrieves a customer's completed and refunded payments made in a period,
+// and payments refunded in it, oldest first
+func (s StatementStore) GetStatementPayments(ctx context.Context, userID string, from, to time.Time) ([]models.Payment, error) {
+	tracer := otel.Tracer("StatementStore")
+	ctx, span := tracer.Start(ctx, "GetStatementPayments-Store")
+	defer span.End()
+
+	query := `
+		SELECT p.id, p.booking_id, p.razorpay_order_id, p.razorpay_payment_id, p.amount,
+			   p.currency, p.status, p.method, p.transaction_id, p.description,
+			   p.notes, p.created_at, p.updated_at
+		FROM payment p
+		INNER JOIN booking b ON p.booking_id = b.id
+		WHERE b.customer_id = $1
+		AND p.status IN ($4, $5)
+		AND ((p.created_at >= $2 AND p.created_at < $3)
+			OR (p.status = $5 AND p.updated_at >= $2 AND p.updated_at < $3))
+		ORDER BY p.created_at, p.id`
+
+	rows, err := s.db.QueryContext(ctx, query, userID, from, to, models.PaymentStatusCompleted, models.PaymentStatusRefunded)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := []models.Payment{}
+	for rows.Next() {
+		var payment models.Payment
+		err := rows.Scan(&payment.ID, &payment.BookingID, &payment.RazorpayOrderID,
+			&payment.RazorpayPaymentID, &payment.Amount, &payment.Currency, &payment.Status,
+			&payment.Method, &payment.TransactionID, &payment.Description,
+			&payment.Notes, &payment.CreatedAt, &payment.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+
+	return payments, rows.Err()
+}
+
+// CreateStatement queues a statement for the render job
+func (s StatementStore) CreateStatement(ctx context.Context, statement models.PaymentStatement) (models.PaymentStatement, error) {
+	tracer := otel.Tracer("StatementStore")
+	ctx, span := tracer.Start(ctx, "CreateStatement-Store")
+	defer span.End()
+
+	query := `INSERT INTO payment_statement (id, user_id, period_from, period_to, format, status, error, created_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, '', $7)
+	         RETURNING ` + statementColumns
+
+	// The period is stored as the calendar dates it was requested for, whatever the time zone
+	return scanStatement(s.db.QueryRowContext(ctx, query, uuid.New(), statement.UserID,
+		statement.From.Format("2006-01-02"), statement.To.Format("2006-01-02"), statement.Format,
+		models.StatementStatusPending, time.Now()))
+}
+
+// GetStatement retrieves a statement by ID, without its file
+func (s StatementStore) GetStatement(ctx context.Context, id string) (models.PaymentStatement, error) {
+	tracer := otel.Tracer("StatementStore")
+	ctx, span := tracer.Start(ctx, "GetStatement-Store")
+	defer span.End()
+
+	statement, err := scanStatement(s.db.QueryRowContext(ctx,
+		`SELECT `+statementColumns+` FROM payment_statement WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PaymentStatement{}, errors.New("no statement found with the given ID")
+		}
+		return models.PaymentStatement{}, err
+	}
+
+	return statement, nil
+}
+
+// GetStatementContent retrieves the rendered file of a ready statement
+func (s StatementStore) GetStatementContent(ctx context.Context, id string) ([]byte, error) {
+	tracer := otel.Tracer("StatementStore")
+	ctx, span := tracer.Start(ctx, "GetStatementContent-Store")
+	defer span.End()
+
+	var content []byte
+	err := s.db.QueryRowContext(ctx, `SELECT content FROM payment_statement WHERE id = $1 AND status = $2`,
+		id, models.StatementStatusReady).Scan(&content)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("statement is not ready yet")
+		}
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// ClaimPendingStatements marks up to limit queued statements as rendering and returns them,
+// oldest first. Statements left rendering for longer than staleAfter, by an instance that
+// stopped, are claimed again. Concurrent callers never claim the same statement.
+func (s StatementStore) ClaimPendingStatements(ctx context.Context, limit int, staleAfter time.Duration) ([]models.PaymentStatement, error) {
+	tracer := otel.Tracer("StatementStore")
+	ctx, span := tracer.Start(ctx, "ClaimPendingStatements-Store")
+	defer span.End()
+
+	query := `UPDATE payment_statement SET status = $1, claimed_at = $2
+	         WHERE id IN (
+	             SELECT id FROM payment_statement
+	             WHERE status = $3 OR (status = $1 AND claimed_at < $4)
+	             ORDER BY created_at
+	             LIMIT $5
+	             FOR UPDATE SKIP LOCKED)
+	         RETURNING ` + statementColumns
+
+	now := time.Now()
+	rows, err := s.db.QueryContext(ctx, query, models.StatementStatusRendering, now, models.StatementStatusPending,
+		now.Add(-staleAfter), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statements := []models.PaymentStatement{}
+	for rows.Next() {
+		statement, err := scanStatement(rows)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+
+	return statements, rows.Err()
+}
+
+// CompleteStatement stores the rendered file of a statement, or why rendering failed
+func (s StatementStore) CompleteStatement(ctx context.Context, id uuid.UUID, status models.StatementStatus, content []byte, renderError string) error {
+	tracer := otel.Tracer("StatementStore")
+	ctx, span := tracer.Start(ctx, "CompleteStatement-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `UPDATE payment_statement
+	         SET status = $2, content = $3, error = $4, completed_at = $5
+	         WHERE id = $1`, id, status, content, renderError, time.Now())
+	return err
+}
+
+// scanStatement reads one payment_statement row
+func scanStatement(row rowScanner) (models.PaymentStatement, error) {
+	var st models.PaymentStatement
+	err := row.Scan(&st.ID, &st.UserID, &st.From, &st.To, &st.Format, &st.Status, &st.Error, &st.CreatedAt,
+		&st.CompletedAt)
+	return st, err
+}
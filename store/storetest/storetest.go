@@ -0,0 +1,404 @@
+// Package storetest checks that implementations of the store interfaces keep the behaviour the
+// services rely on: what a lookup of a missing record returns, which error a conflicting or
+// stale write produces, and that keys stay unique. Services tell these cases apart by the
+// error text (e.g. strings.Contains(err.Error(), "no booking found")), so a reimplementation,
+// such as a move to pgx or sqlc, must keep them exactly to avoid behavioural drift.
+//
+// Like testing/fstest, each check returns an error listing every violation, so the suites run
+// from any test of an implementation:
+//
+//	func TestBookingStore(t *testing.T) {
+//		if err := storetest.TestBookingStore(context.Background(), booking.New(db)); err != nil {
+//			t.Fatal(err)
+//		}
+//	}
+//
+// The checks need a database with store/schema.sql applied. Some create rows with random keys
+// and leave them behind, so run them against a scratch database only.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/google/uuid"
+)
+
+// checker collects the violations of one suite
+type checker struct {
+	suite string
+	errs  []error
+}
+
+// errorf records a violation
+func (c *checker) errorf(format string, args ...interface{}) {
+	c.errs = append(c.errs, fmt.Errorf(c.suite+": "+format, args...))
+}
+
+// wantError records a violation unless err is set and its text contains want
+func (c *checker) wantError(call string, err error, want string) {
+	switch {
+	case err == nil:
+		c.errorf("%s: expected an error containing %q, got none", call, want)
+	case !strings.Contains(err.Error(), want):
+		c.errorf("%s: expected an error containing %q, got %q", call, want, err)
+	}
+}
+
+// wantNoError records a violation when err is set
+func (c *checker) wantNoError(call string, err error) bool {
+	if err != nil {
+		c.errorf("%s: unexpected error: %v", call, err)
+		return false
+	}
+	return true
+}
+
+// err returns every violation joined, or nil
+func (c *checker) err() error {
+	return errors.Join(c.errs...)
+}
+
+// missingID is an ID no record has
+func missingID() string {
+	return uuid.NewString()
+}
+
+// uniqueKey returns a lowercase key no record has, valid as a feature or attribute key
+func uniqueKey(prefix string) string {
+	return prefix + "_" + strings.ReplaceAll(uuid.NewString()[:13], "-", "")
+}
+
+// TestCarStore checks the lookup and status-transition semantics of a car store. Lookups of a
+// missing car return a zero car without an error, while deleting one is an error. A status
+// change whose expected current status does not match is reported as a concurrent change,
+// whether the car moved on or never existed.
+func TestCarStore(ctx context.Context, s store.CarStoreInterface) error {
+	c := &checker{suite: "CarStore"}
+	id := missingID()
+
+	if car, err := s.GetCarByID(ctx, id); c.wantNoError("GetCarByID of a missing car", err) && car.ID != uuid.Nil {
+		c.errorf("GetCarByID of a missing car: expected a zero car, got %s", car.ID)
+	}
+	if car, err := s.GetCarWithOwnerByID(ctx, id); c.wantNoError("GetCarWithOwnerByID of a missing car", err) && car.ID != uuid.Nil {
+		c.errorf("GetCarWithOwnerByID of a missing car: expected a zero car, got %s", car.ID)
+	}
+	if car, err := s.GetCarBySlug(ctx, "missing-"+id); c.wantNoError("GetCarBySlug of a missing slug", err) && car.ID != uuid.Nil {
+		c.errorf("GetCarBySlug of a missing slug: expected a zero car, got %s", car.ID)
+	}
+
+	owner := uuid.New()
+	if total, err := s.CountCars(ctx, models.CarFilter{OwnerID: &owner}); c.wantNoError("CountCars of an owner without cars", err) && total != 0 {
+		c.errorf("CountCars of an owner without cars: expected 0, got %d", total)
+	}
+
+	_, err := s.DeleteCar(ctx, id)
+	c.wantError("DeleteCar of a missing car", err, "no car found")
+
+	_, err = s.UpdateCarStatus(ctx, id, models.CarStatusDraft, models.CarStatusPendingReview, false)
+	c.wantError("UpdateCarStatus of a missing car", err, "changed by someone else")
+
+	_, err = s.GetFuelPolicy(ctx, id)
+	c.wantError("GetFuelPolicy of a car without one", err, "no fuel policy")
+
+	return c.err()
+}
+
+// TestUserStore checks the lookup and uniqueness semantics of a user store. It registers a
+// user with a random e-mail address, which it leaves behind.
+func TestUserStore(ctx context.Context, s store.UserStoreInterface) error {
+	c := &checker{suite: "UserStore"}
+	id := missingID()
+
+	_, err := s.GetUserByID(ctx, id)
+	c.wantError("GetUserByID of a missing user", err, "user not found")
+
+	_, err = s.UpdateUser(ctx, id, models.UserRequest{UserName: "missing"})
+	c.wantError("UpdateUser of a missing user", err, "no user found")
+
+	_, err = s.DeleteUser(ctx, id)
+	c.wantError("DeleteUser of a missing user", err, "no user found")
+
+	_, err = s.GetPublicProfile(ctx, id)
+	c.wantError("GetPublicProfile of a missing owner", err, "no owner found")
+
+	_, err = s.SetPassword(ctx, "missing-"+id+"@example.com", "contract-password")
+	c.wantError("SetPassword of a missing user", err, "user not found")
+
+	_, err = s.GetUserByEmail(ctx, "missing-"+id+"@example.com")
+	c.wantError("GetUserByEmail of a missing user", err, "user not found")
+
+	// A registered e-mail address cannot be registered again
+	req := models.UserRequest{
+		Email:    "contract-" + id + "@example.com",
+		Password: "contract-password",
+		UserName: "contract-" + id[:8],
+		Phone:    fmt.Sprintf("+9198%08d", time.Now().UnixNano()%100000000),
+		Role:     "renter",
+	}
+	if !c.wantNoError("CreateUser", s.CreateUser(ctx, req)) {
+		return c.err()
+	}
+	c.wantError("CreateUser with a registered e-mail address", s.CreateUser(ctx, req), "already exists")
+
+	// Credentials are checked on lookup, and a new password replaces the old one
+	user, err := s.GetUser(ctx, req.Email, req.Password)
+	if c.wantNoError("GetUser with the right password", err) && user.Email != req.Email {
+		c.errorf("GetUser: expected %s, got %s", req.Email, user.Email)
+	}
+	if _, err = s.GetUser(ctx, req.Email, "wrong-password"); err == nil {
+		c.errorf("GetUser with a wrong password: expected an error, got none")
+	}
+	if byEmail, err := s.GetUserByEmail(ctx, req.Email); c.wantNoError("GetUserByEmail", err) && byEmail.ID != user.ID {
+		c.errorf("GetUserByEmail: expected the ID %s, got %s", user.ID, byEmail.ID)
+	}
+	if updatedID, err := s.SetPassword(ctx, req.Email, "rotated-password"); c.wantNoError("SetPassword", err) && updatedID != user.ID {
+		c.errorf("SetPassword: expected the ID %s, got %s", user.ID, updatedID)
+	}
+	if _, err = s.GetUser(ctx, req.Email, req.Password); err == nil {
+		c.errorf("GetUser with the replaced password: expected an error, got none")
+	}
+
+	return c.err()
+}
+
+// TestBookingStore checks that every lookup or write of a missing booking reports it
+func TestBookingStore(ctx context.Context, s store.BookingStoreInterface) error {
+	c := &checker{suite: "BookingStore"}
+	id := missingID()
+
+	_, err := s.GetBookingByID(ctx, id)
+	c.wantError("GetBookingByID of a missing booking", err, "no booking found")
+
+	_, err = s.UpdateBookingStatus(ctx, id, models.BookingStatusCancelled)
+	c.wantError("UpdateBookingStatus of a missing booking", err, "no booking found")
+
+	_, err = s.DeleteBooking(ctx, id)
+	c.wantError("DeleteBooking of a missing booking", err, "no booking found")
+
+	if bookings, err := s.GetBookingExtensions(ctx, id); c.wantNoError("GetBookingExtensions of a missing booking", err) && len(bookings) > 0 {
+		c.errorf("GetBookingExtensions of a missing booking: expected none, got %d", len(bookings))
+	}
+
+	return c.err()
+}
+
+// TestPaymentStore checks that every lookup or write of a missing payment, attempt,
+// authorization or payment link reports it
+func TestPaymentStore(ctx context.Context, s store.PaymentStoreInterface) error {
+	c := &checker{suite: "PaymentStore"}
+	id := missingID()
+
+	_, err := s.GetPaymentByID(ctx, id)
+	c.wantError("GetPaymentByID of a missing payment", err, "no payment found")
+
+	_, err = s.GetPaymentByRazorpayOrderID(ctx, "order_missing"+id[:8])
+	c.wantError("GetPaymentByRazorpayOrderID of a missing order", err, "no payment found")
+
+	_, err = s.GetPaymentByRazorpayPaymentID(ctx, "pay_missing"+id[:8])
+	c.wantError("GetPaymentByRazorpayPaymentID of a missing payment", err, "no payment found")
+
+	_, err = s.UpdatePaymentStatus(ctx, id, models.PaymentStatusFailed, nil, nil)
+	c.wantError("UpdatePaymentStatus of a missing payment", err, "no payment found")
+
+	_, err = s.DeletePayment(ctx, id)
+	c.wantError("DeletePayment of a missing payment", err, "no payment found")
+
+	err = s.UpdatePaymentAttempt(ctx, "order_missing"+id[:8], models.PaymentStatusFailed, nil, nil, nil)
+	c.wantError("UpdatePaymentAttempt of a missing order", err, "no payment attempt found")
+
+	_, err = s.GetPaymentAuthorization(ctx, id)
+	c.wantError("GetPaymentAuthorization of a missing payment", err, "no payment authorization found")
+
+	_, err = s.GetPaymentLinkByRazorpayID(ctx, "plink_missing"+id[:8])
+	c.wantError("GetPaymentLinkByRazorpayID of a missing link", err, "no payment link found")
+
+	err = s.UpdatePaymentLinkStatus(ctx, uuid.New(), models.PaymentLinkStatusCancelled)
+	c.wantError("UpdatePaymentLinkStatus of a missing link", err, "no payment link found")
+
+	return c.err()
+}
+
+// TestFeatureStore checks that feature keys are unique. It adds a feature with a random key,
+// which it leaves behind.
+func TestFeatureStore(ctx context.Context, s store.FeatureStoreInterface) error {
+	c := &checker{suite: "FeatureStore"}
+	key := uniqueKey("contract")
+
+	_, err := s.UpdateFeature(ctx, key, models.FeatureRequest{Key: key, Name: "Missing"})
+	c.wantError("UpdateFeature of a missing feature", err, "no feature found")
+
+	req := models.FeatureRequest{Key: key, Name: "Contract feature"}
+	if _, err = s.CreateFeature(ctx, req); !c.wantNoError("CreateFeature", err) {
+		return c.err()
+	}
+	_, err = s.CreateFeature(ctx, req)
+	c.wantError("CreateFeature with a taken key", err, "already exists")
+
+	return c.err()
+}
+
+// TestCarAttributeStore checks that attribute keys are unique and that changes to a missing
+// attribute are reported. The attribute it adds is deleted again.
+func TestCarAttributeStore(ctx context.Context, s store.CarAttributeStoreInterface) error {
+	c := &checker{suite: "CarAttributeStore"}
+	key := uniqueKey("contract")
+
+	_, err := s.UpdateCarAttribute(ctx, key, models.CarAttributeRequest{Key: key, Name: "Missing", Type: models.CarAttributeText})
+	c.wantError("UpdateCarAttribute of a missing attribute", err, "no attribute found")
+
+	c.wantError("DeleteCarAttribute of a missing attribute", s.DeleteCarAttribute(ctx, key), "no attribute found")
+
+	req := models.CarAttributeRequest{Key: key, Name: "Contract attribute", Type: models.CarAttributeText}
+	if _, err = s.CreateCarAttribute(ctx, req); !c.wantNoError("CreateCarAttribute", err) {
+		return c.err()
+	}
+	_, err = s.CreateCarAttribute(ctx, req)
+	c.wantError("CreateCarAttribute with a taken key", err, "already exists")
+
+	if c.wantNoError("DeleteCarAttribute", s.DeleteCarAttribute(ctx, key)) {
+		c.wantError("DeleteCarAttribute of a deleted attribute", s.DeleteCarAttribute(ctx, key), "no attribute found")
+	}
+
+	return c.err()
+}
+
+// TestEmailTemplateStore checks that template keys are unique, that versions are added to
+// existing keys only and that the latest version is read back. The template it adds is
+// deleted again.
+func TestEmailTemplateStore(ctx context.Context, s store.EmailTemplateStoreInterface) error {
+	c := &checker{suite: "EmailTemplateStore"}
+	key := uniqueKey("contract")
+	createdBy := missingID()
+
+	_, err := s.GetTemplate(ctx, key)
+	c.wantError("GetTemplate of a missing key", err, "no email template found")
+
+	_, err = s.AddTemplateVersion(ctx, key, models.EmailTemplateRequest{Subject: "Missing", Body: "Missing"}, createdBy)
+	c.wantError("AddTemplateVersion of a missing key", err, "no email template found")
+
+	c.wantError("DeleteTemplate of a missing key", s.DeleteTemplate(ctx, key), "no email template found")
+
+	req := models.EmailTemplateRequest{Key: key, Subject: "Contract v1", Body: "Version one"}
+	first, err := s.CreateTemplate(ctx, req, createdBy)
+	if !c.wantNoError("CreateTemplate", err) {
+		return c.err()
+	}
+	_, err = s.CreateTemplate(ctx, req, createdBy)
+	c.wantError("CreateTemplate with a taken key", err, "already exists")
+
+	second, err := s.AddTemplateVersion(ctx, key, models.EmailTemplateRequest{Subject: "Contract v2", Body: "Version two"}, createdBy)
+	if c.wantNoError("AddTemplateVersion", err) && second.Version != first.Version+1 {
+		c.errorf("AddTemplateVersion: expected version %d, got %d", first.Version+1, second.Version)
+	}
+	if latest, err := s.GetTemplate(ctx, key); c.wantNoError("GetTemplate", err) && latest.Subject != "Contract v2" {
+		c.errorf("GetTemplate: expected the latest version, got subject %q", latest.Subject)
+	}
+
+	c.wantNoError("DeleteTemplate", s.DeleteTemplate(ctx, key))
+
+	return c.err()
+}
+
+// TestSettingStore checks that a lookup of a setting never stored is reported, so services
+// fall back to defaults
+func TestSettingStore(ctx context.Context, s store.SettingStoreInterface) error {
+	c := &checker{suite: "SettingStore"}
+
+	_, err := s.GetSetting(ctx, uniqueKey("contract"))
+	c.wantError("GetSetting of a missing key", err, "no setting found")
+
+	return c.err()
+}
+
+// TestSecurityStore checks that a lookup of a missing security event is reported
+func TestSecurityStore(ctx context.Context, s store.SecurityStoreInterface) error {
+	c := &checker{suite: "SecurityStore"}
+
+	_, err := s.GetSecurityEventByID(ctx, missingID())
+	c.wantError("GetSecurityEventByID of a missing event", err, "no security event found")
+
+	return c.err()
+}
+
+// TestMaintenanceStore checks that a lookup of a missing maintenance job is reported
+func TestMaintenanceStore(ctx context.Context, s store.MaintenanceStoreInterface) error {
+	c := &checker{suite: "MaintenanceStore"}
+
+	_, err := s.GetJob(ctx, missingID())
+	c.wantError("GetJob of a missing job", err, "no maintenance job found")
+
+	return c.err()
+}
+
+// TestDebugCaptureStore checks that expired captures are hidden and purged while fresh ones
+// are readable. It adds two captures; the expired one is purged by the check.
+func TestDebugCaptureStore(ctx context.Context, s store.DebugCaptureStoreInterface) error {
+	c := &checker{suite: "DebugCaptureStore"}
+
+	_, err := s.GetDebugCaptureByID(ctx, missingID())
+	c.wantError("GetDebugCaptureByID of a missing capture", err, "no debug capture found")
+
+	now := time.Now()
+	newCapture := func(expiresAt time.Time) models.DebugCapture {
+		return models.DebugCapture{
+			ID:              uuid.New(),
+			Reason:          models.DebugCaptureReasonHeader,
+			Method:          "GET",
+			Path:            "/contract",
+			RequestHeaders:  map[string]string{},
+			Status:          200,
+			ResponseHeaders: map[string]string{},
+			CreatedAt:       now,
+			ExpiresAt:       expiresAt,
+		}
+	}
+
+	expired := newCapture(now.Add(-time.Minute))
+	fresh := newCapture(now.Add(time.Hour))
+	if !c.wantNoError("CreateDebugCapture", s.CreateDebugCapture(ctx, expired)) ||
+		!c.wantNoError("CreateDebugCapture", s.CreateDebugCapture(ctx, fresh)) {
+		return c.err()
+	}
+
+	_, err = s.GetDebugCaptureByID(ctx, expired.ID.String())
+	c.wantError("GetDebugCaptureByID of an expired capture", err, "no debug capture found")
+	if got, err := s.GetDebugCaptureByID(ctx, fresh.ID.String()); c.wantNoError("GetDebugCaptureByID", err) && got.Path != fresh.Path {
+		c.errorf("GetDebugCaptureByID: expected path %s, got %s", fresh.Path, got.Path)
+	}
+
+	if purged, err := s.DeleteExpiredDebugCaptures(ctx); c.wantNoError("DeleteExpiredDebugCaptures", err) && purged < 1 {
+		c.errorf("DeleteExpiredDebugCaptures: expected the expired capture to be purged, got %d", purged)
+	}
+	if _, err = s.GetDebugCaptureByID(ctx, fresh.ID.String()); err != nil {
+		c.errorf("DeleteExpiredDebugCaptures: purged an unexpired capture: %v", err)
+	}
+
+	return c.err()
+}
+
+// TestMigrationStore checks that a migration is applied once: a second Apply of the same name
+// reports that it did not run. It records a migration with a random name.
+func TestMigrationStore(ctx context.Context, s store.MigrationStoreInterface) error {
+	c := &checker{suite: "MigrationStore"}
+	name := "contract_" + uuid.NewString() + ".sql"
+
+	if ran, err := s.Apply(ctx, name, ""); c.wantNoError("Apply", err) && !ran {
+		c.errorf("Apply of a new migration: expected it to run")
+	}
+	if ran, err := s.Apply(ctx, name, ""); c.wantNoError("Apply", err) && ran {
+		c.errorf("Apply of an applied migration: expected it not to run again")
+	}
+	if applied, err := s.GetApplied(ctx); c.wantNoError("GetApplied", err) {
+		if _, ok := applied[name]; !ok {
+			c.errorf("GetApplied: expected %s to be recorded", name)
+		}
+	}
+
+	return c.err()
+}
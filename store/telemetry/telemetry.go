@@ -0,0 +1,429 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/encryption"
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// telemetryReadingColumns lists the columns read by every telemetry reading query
+const telemetryReadingColumns = `id, car_id, device_id, recorded_at, received_at, latitude, longitude,
+	odometer_km, fuel_level, battery_level`
+
+// TelemetryStore persists telematics devices and their time-series readings.
+// Device signing secrets are encrypted at rest.
+type TelemetryStore struct {
+	db     *sql.DB
+	cipher *encryption.Cipher
+}
+
+// New creates a new telemetry store
+func New(db *sql.DB, cipher *encryption.Cipher) TelemetryStore {
+	return TelemetryStore{db: db, cipher: cipher}
+}
+
+// geofenceColumns lists the columns read by every geofence query
+const geofenceColumns = `id, car_id, booking_id, name, center_latitude, center_longitude, radius_km, created_by, created_at`
+
+// geofenceBreachColumns lists the columns read by every geofence breach query
+const geofenceBreachColumns = `id, geofence_id, booking_id, car_id, latitude, longitude, distance_km, detected_at, resolved_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// UpsertDevice provisions the telematics device of a car, replacing the secret of any
+// existing device so a leaked secret can be revoked by provisioning again
+func (s TelemetryStore) UpsertDevice(ctx context.Context, carID, secret string) (models.TelemetryDevice, error) {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "UpsertDevice-Store")
+	defer span.End()
+
+	encrypted, err := s.cipher.Encrypt(secret)
+	if err != nil {
+		return models.TelemetryDevice{}, err
+	}
+
+	now := time.Now()
+	query := `INSERT INTO telemetry_device (id, car_id, secret_encrypted, created_at, updated_at)
+	         VALUES ($1, $2, $3, $4, $4)
+	         ON CONFLICT (car_id) DO UPDATE SET secret_encrypted = EXCLUDED.secret_encrypted, updated_at = EXCLUDED.updated_at
+	         RETURNING id, car_id, secret_encrypted, last_seen_at, created_at, updated_at`
+
+	return s.scanDevice(s.db.QueryRowContext(ctx, query, uuid.New(), carID, encrypted, now))
+}
+
+// GetDeviceByID retrieves a telematics device with its decrypted secret
+func (s TelemetryStore) GetDeviceByID(ctx context.Context, id string) (models.TelemetryDevice, error) {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "GetDeviceByID-Store")
+	defer span.End()
+
+	query := `SELECT id, car_id, secret_encrypted, last_seen_at, created_at, updated_at
+	         FROM telemetry_device WHERE id = $1`
+
+	device, err := s.scanDevice(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.TelemetryDevice{}, errors.New("no telemetry device found with the given ID")
+		}
+		return models.TelemetryDevice{}, err
+	}
+
+	return device, nil
+}
+
+// CreateReading stores one reading and marks the device as seen
+func (s TelemetryStore) CreateReading(ctx context.Context, device models.TelemetryDevice, payload models.TelemetryPayload) (models.TelemetryReading, error) {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "CreateReading-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.TelemetryReading{}, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	query := `INSERT INTO car_telemetry (id, car_id, device_id, recorded_at, received_at, latitude, longitude,
+	         odometer_km, fuel_level, battery_level)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	         RETURNING ` + telemetryReadingColumns
+
+	reading, err := scanReading(tx.QueryRowContext(ctx, query, uuid.New(), device.CarID, device.ID,
+		payload.RecordedAt, now, payload.Latitude, payload.Longitude, payload.OdometerKm,
+		payload.FuelLevel, payload.BatteryLevel))
+	if err != nil {
+		return models.TelemetryReading{}, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE telemetry_device SET last_seen_at = $1 WHERE id = $2`, now, device.ID); err != nil {
+		return models.TelemetryReading{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.TelemetryReading{}, err
+	}
+
+	return reading, nil
+}
+
+// GetLatestSnapshot merges the most recent value of every metric reported for a car.
+// Devices often send partial payloads (e.g. location only), so each field comes from
+// the newest reading that carries it; RecordedAt is that of the newest reading overall.
+func (s TelemetryStore) GetLatestSnapshot(ctx context.Context, carID string) (models.TelemetryReading, error) {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "GetLatestSnapshot-Store")
+	defer span.End()
+
+	query := `SELECT latest.id, latest.car_id, latest.device_id, latest.recorded_at, latest.received_at,
+	         (SELECT latitude FROM car_telemetry WHERE car_id = $1 AND latitude IS NOT NULL ORDER BY recorded_at DESC LIMIT 1),
+	         (SELECT longitude FROM car_telemetry WHERE car_id = $1 AND longitude IS NOT NULL ORDER BY recorded_at DESC LIMIT 1),
+	         (SELECT odometer_km FROM car_telemetry WHERE car_id = $1 AND odometer_km IS NOT NULL ORDER BY recorded_at DESC LIMIT 1),
+	         (SELECT fuel_level FROM car_telemetry WHERE car_id = $1 AND fuel_level IS NOT NULL ORDER BY recorded_at DESC LIMIT 1),
+	         (SELECT battery_level FROM car_telemetry WHERE car_id = $1 AND battery_level IS NOT NULL ORDER BY recorded_at DESC LIMIT 1)
+	         FROM (SELECT id, car_id, device_id, recorded_at, received_at FROM car_telemetry
+	               WHERE car_id = $1 ORDER BY recorded_at DESC LIMIT 1) latest`
+
+	reading, err := scanReading(s.db.QueryRowContext(ctx, query, carID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.TelemetryReading{}, errors.New("no telemetry found for the given car")
+		}
+		return models.TelemetryReading{}, err
+	}
+
+	return reading, nil
+}
+
+// GetLatestOdometerAndFuel returns the newest odometer and fuel readings recorded at or after
+// notBefore; either is nil when the car reported none in that period. Used to auto-fill handovers.
+func (s TelemetryStore) GetLatestOdometerAndFuel(ctx context.Context, carID string, notBefore time.Time) (odometerKm *int, fuelLevel *float64, err error) {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "GetLatestOdometerAndFuel-Store")
+	defer span.End()
+
+	query := `SELECT
+	         (SELECT odometer_km FROM car_telemetry WHERE car_id = $1 AND odometer_km IS NOT NULL AND recorded_at >= $2 ORDER BY recorded_at DESC LIMIT 1),
+	         (SELECT fuel_level FROM car_telemetry WHERE car_id = $1 AND fuel_level IS NOT NULL AND recorded_at >= $2 ORDER BY recorded_at DESC LIMIT 1)`
+
+	err = s.db.QueryRowContext(ctx, query, carID, notBefore).Scan(&odometerKm, &fuelLevel)
+	return odometerKm, fuelLevel, err
+}
+
+// CreateGeofence stores a geofence on a car. A booking-specific geofence must belong to a
+// booking of the same car.
+func (s TelemetryStore) CreateGeofence(ctx context.Context, carID, createdBy string, req models.GeofenceRequest) (models.Geofence, error) {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "CreateGeofence-Store")
+	defer span.End()
+
+	query := `INSERT INTO geofence (id, car_id, booking_id, name, center_latitude, center_longitude, radius_km, created_by, created_at)
+	         SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9
+	         WHERE $3::uuid IS NULL OR EXISTS (SELECT 1 FROM booking WHERE id = $3 AND car_id = $2)
+	         RETURNING ` + geofenceColumns
+
+	geofence, err := scanGeofence(s.db.QueryRowContext(ctx, query, uuid.New(), carID, req.BookingID, req.Name,
+		req.CenterLatitude, req.CenterLongitude, req.RadiusKm, createdBy, time.Now()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Geofence{}, errors.New("booking does not belong to this car")
+		}
+		return models.Geofence{}, err
+	}
+
+	return geofence, nil
+}
+
+// GetGeofencesByCarID retrieves the geofences defined on a car, newest first
+func (s TelemetryStore) GetGeofencesByCarID(ctx context.Context, carID string) ([]models.Geofence, error) {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "GetGeofencesByCarID-Store")
+	defer span.End()
+
+	query := `SELECT ` + geofenceColumns + ` FROM geofence WHERE car_id = $1 ORDER BY created_at DESC, id DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, carID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var geofences []models.Geofence
+	for rows.Next() {
+		geofence, err := scanGeofence(rows)
+		if err != nil {
+			return nil, err
+		}
+		geofences = append(geofences, geofence)
+	}
+
+	return geofences, rows.Err()
+}
+
+// DeleteGeofence removes a geofence of a car together with its breach history
+func (s TelemetryStore) DeleteGeofence(ctx context.Context, carID, id string) error {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "DeleteGeofence-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM geofence WHERE id = $1 AND car_id = $2`, id, carID)
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("no geofence found with the given ID")
+	}
+
+	return nil
+}
+
+// GetGeofenceBreachesByCarID retrieves the most recent geofence breaches of a car, newest first
+func (s TelemetryStore) GetGeofenceBreachesByCarID(ctx context.Context, carID string, limit int) ([]models.GeofenceBreach, error) {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "GetGeofenceBreachesByCarID-Store")
+	defer span.End()
+
+	query := `SELECT ` + geofenceBreachColumns + ` FROM geofence_breach
+	         WHERE car_id = $1 ORDER BY detected_at DESC, id DESC LIMIT $2`
+
+	rows, err := s.db.QueryContext(ctx, query, carID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var breaches []models.GeofenceBreach
+	for rows.Next() {
+		breach, err := scanGeofenceBreach(rows)
+		if err != nil {
+			return nil, err
+		}
+		breaches = append(breaches, breach)
+	}
+
+	return breaches, rows.Err()
+}
+
+// ListGeofenceChecks returns every geofence that applies to an active rental, with the car's
+// latest position reported since the rental started. A rental is active while the booking is
+// confirmed and not yet checked in, and either checked out or within its booked dates.
+func (s TelemetryStore) ListGeofenceChecks(ctx context.Context) ([]models.GeofenceCheck, error) {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "ListGeofenceChecks-Store")
+	defer span.End()
+
+	query := `SELECT g.id, g.car_id, g.booking_id, g.name, g.center_latitude, g.center_longitude, g.radius_km,
+	         g.created_by, g.created_at, b.id, b.owner_id, loc.latitude, loc.longitude, loc.recorded_at,
+	         (SELECT gb.id FROM geofence_breach gb WHERE gb.geofence_id = g.id AND gb.booking_id = b.id AND gb.resolved_at IS NULL)
+	         FROM geofence g
+	         JOIN booking b ON b.car_id = g.car_id AND (g.booking_id IS NULL OR g.booking_id = b.id)
+	         CROSS JOIN LATERAL (
+	             SELECT t.latitude, t.longitude, t.recorded_at FROM car_telemetry t
+	             WHERE t.car_id = g.car_id AND t.latitude IS NOT NULL AND t.recorded_at >= b.start_date
+	             ORDER BY t.recorded_at DESC LIMIT 1
+	         ) loc
+	         WHERE b.status IN ('confirmed', 'in_progress')
+	           AND NOT EXISTS (SELECT 1 FROM booking_inspection i WHERE i.booking_id = b.id AND i.kind = 'checkin')
+	           AND (EXISTS (SELECT 1 FROM booking_inspection i WHERE i.booking_id = b.id AND i.kind = 'checkout')
+	                OR NOW() BETWEEN b.start_date AND b.end_date)`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checks []models.GeofenceCheck
+	for rows.Next() {
+		var check models.GeofenceCheck
+		g := &check.Geofence
+		err := rows.Scan(&g.ID, &g.CarID, &g.BookingID, &g.Name, &g.CenterLatitude, &g.CenterLongitude, &g.RadiusKm,
+			&g.CreatedBy, &g.CreatedAt, &check.BookingID, &check.OwnerID, &check.Latitude, &check.Longitude,
+			&check.RecordedAt, &check.OpenBreachID)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+
+	return checks, rows.Err()
+}
+
+// OpenGeofenceBreach records the car leaving a geofence. It returns false when a breach is
+// already open for the geofence and booking, so each exit is reported once.
+func (s TelemetryStore) OpenGeofenceBreach(ctx context.Context, check models.GeofenceCheck, distanceKm float64) (models.GeofenceBreach, bool, error) {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "OpenGeofenceBreach-Store")
+	defer span.End()
+
+	query := `INSERT INTO geofence_breach (id, geofence_id, booking_id, car_id, latitude, longitude, distance_km, detected_at)
+	         VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	         ON CONFLICT (geofence_id, booking_id) WHERE resolved_at IS NULL DO NOTHING
+	         RETURNING ` + geofenceBreachColumns
+
+	breach, err := scanGeofenceBreach(s.db.QueryRowContext(ctx, query, uuid.New(), check.Geofence.ID, check.BookingID,
+		check.Geofence.CarID, check.Latitude, check.Longitude, distanceKm, check.RecordedAt))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.GeofenceBreach{}, false, nil
+		}
+		return models.GeofenceBreach{}, false, err
+	}
+
+	return breach, true, nil
+}
+
+// ResolveGeofenceBreach closes an open breach once the car is back inside the geofence
+func (s TelemetryStore) ResolveGeofenceBreach(ctx context.Context, id string, resolvedAt time.Time) error {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "ResolveGeofenceBreach-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `UPDATE geofence_breach SET resolved_at = $1 WHERE id = $2 AND resolved_at IS NULL`, resolvedAt, id)
+	return err
+}
+
+// RotateEncryptionKeys re-encrypts device secrets sealed with a retired key
+func (s TelemetryStore) RotateEncryptionKeys(ctx context.Context) (int, error) {
+	tracer := otel.Tracer("TelemetryStore")
+	ctx, span := tracer.Start(ctx, "RotateEncryptionKeys-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, secret_encrypted FROM telemetry_device`)
+	if err != nil {
+		return 0, err
+	}
+
+	type deviceSecret struct {
+		id     string
+		secret string
+	}
+	var stale []deviceSecret
+	for rows.Next() {
+		var d deviceSecret
+		if err := rows.Scan(&d.id, &d.secret); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if s.cipher.NeedsRotation(d.secret) {
+			stale = append(stale, d)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	rotated := 0
+	for _, d := range stale {
+		secret, err := s.cipher.Rotate(d.secret)
+		if err != nil {
+			return rotated, err
+		}
+		// Only overwrite if the secret was not re-provisioned in the meantime
+		result, err := s.db.ExecContext(ctx, `UPDATE telemetry_device SET secret_encrypted = $1
+		         WHERE id = $2 AND secret_encrypted = $3`, secret, d.id, d.secret)
+		if err != nil {
+			return rotated, err
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			rotated++
+		}
+	}
+
+	return rotated, nil
+}
+
+// scanDevice reads one device row and decrypts its secret
+func (s TelemetryStore) scanDevice(row rowScanner) (models.TelemetryDevice, error) {
+	var device models.TelemetryDevice
+	var encrypted string
+
+	err := row.Scan(&device.ID, &device.CarID, &encrypted, &device.LastSeenAt, &device.CreatedAt, &device.UpdatedAt)
+	if err != nil {
+		return models.TelemetryDevice{}, err
+	}
+
+	device.Secret, err = s.cipher.Decrypt(encrypted)
+	if err != nil {
+		return models.TelemetryDevice{}, err
+	}
+
+	return device, nil
+}
+
+// scanReading reads one telemetry reading row
+func scanReading(row rowScanner) (models.TelemetryReading, error) {
+	var reading models.TelemetryReading
+	err := row.Scan(&reading.ID, &reading.CarID, &reading.DeviceID, &reading.RecordedAt, &reading.ReceivedAt,
+		&reading.Latitude, &reading.Longitude, &reading.OdometerKm, &reading.FuelLevel, &reading.BatteryLevel)
+	return reading, err
+}
+
+// scanGeofence reads one geofence row
+func scanGeofence(row rowScanner) (models.Geofence, error) {
+	var g models.Geofence
+	err := row.Scan(&g.ID, &g.CarID, &g.BookingID, &g.Name, &g.CenterLatitude, &g.CenterLongitude, &g.RadiusKm,
+		&g.CreatedBy, &g.CreatedAt)
+	return g, err
+}
+
+// scanGeofenceBreach reads one geofence breach row
+func scanGeofenceBreach(row rowScanner) (models.GeofenceBreach, error) {
+	var b models.GeofenceBreach
+	err := row.Scan(&b.ID, &b.GeofenceID, &b.BookingID, &b.CarID, &b.Latitude, &b.Longitude, &b.DistanceKm,
+		&b.DetectedAt, &b.ResolvedAt)
+	return b, err
+}
@@ -5,20 +5,29 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/PrateekKumar15/CarZone/encryption"
 	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/PrateekKumar15/CarZone/store"
+	"github.com/PrateekKumar15/CarZone/tenant"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"go.opentelemetry.io/otel"
 	"golang.org/x/crypto/bcrypt"
 )
 
 // Assuming models.UserRequest is defined in your models package
+// Phone and licence numbers are encrypted with cipher before they are written
+// and decrypted transparently when users are read.
 type UserStore struct {
-	db *sql.DB
+	db     *sql.DB
+	cipher *encryption.Cipher
 }
 
-func New(db *sql.DB) UserStore {
-	return UserStore{db: db}
+func New(db *sql.DB, cipher *encryption.Cipher) UserStore {
+	return UserStore{db: db, cipher: cipher}
 }
 
 func (s UserStore) CreateUser(ctx context.Context, user models.UserRequest) (err error) {
@@ -53,13 +62,13 @@ func (s UserStore) CreateUser(ctx context.Context, user models.UserRequest) (err
 		return err
 	}
 	if exists {
-		return errors.New("user with this email already exists")
+		return store.ErrDuplicateEmail
 	}
 
 	// Insert user into the users table using the transaction
 	query := `
-		INSERT INTO users (username, email, password_hash, phone, role, profile_data, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO users (username, email, password_hash, phone, license_number, role, profile_data, created_at, updated_at, phone_hash, operator_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11)
 	`
 	now := time.Now().UTC()
 
@@ -69,11 +78,17 @@ func (s UserStore) CreateUser(ctx context.Context, user models.UserRequest) (err
 		return err
 	}
 
-	_, err = tx.ExecContext(ctx, query, user.UserName, user.Email, string(hashedPassword), user.Phone, user.Role, profileDataJSON, now, now)
+	phone, licenseNumber, err := s.encryptPII(user.Phone, user.LicenseNumber)
 	if err != nil {
 		return err
 	}
 
+	_, err = tx.ExecContext(ctx, query, user.UserName, user.Email, string(hashedPassword), phone, licenseNumber, user.Role, profileDataJSON, now, now, s.phoneIndex(user.Phone), tenant.ForInsert(ctx))
+	if err != nil {
+		// A concurrent registration can pass the e-mail check; the phone number is only checked here
+		return store.ConstraintViolation(err, store.ErrDuplicateEmail, store.ErrDuplicatePhone)
+	}
+
 	// Zero out the plain password in memory for safety
 	user.Password = ""
 
@@ -86,9 +101,10 @@ func (s UserStore) GetUser(ctx context.Context, email, password string) (models.
 	defer span.End()
 	var user models.User
 	var profileDataJSON []byte
-	query := "SELECT id, username, email, password_hash, phone, role, profile_data, created_at, updated_at FROM users WHERE email = $1"
-	err := s.db.QueryRowContext(ctx, query, email).Scan(
-		&user.ID, &user.UserName, &user.Email, &user.PasswordHash, &user.Phone, &user.Role, &profileDataJSON, &user.CreatedAt, &user.UpdatedAt)
+	query := `SELECT id, username, email, password_hash, phone, license_number, role, profile_data, created_at, updated_at, operator_id
+	         FROM users WHERE email = $1 AND ($2::uuid IS NULL OR operator_id = $2)`
+	err := s.db.QueryRowContext(ctx, query, email, tenant.Scope(ctx)).Scan(
+		&user.ID, &user.UserName, &user.Email, &user.PasswordHash, &user.Phone, &user.LicenseNumber, &user.Role, &profileDataJSON, &user.CreatedAt, &user.UpdatedAt, &user.OperatorID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return user, err // User not found
@@ -96,6 +112,10 @@ func (s UserStore) GetUser(ctx context.Context, email, password string) (models.
 		return user, err // Some other error
 	}
 
+	if err = s.decryptPII(&user); err != nil {
+		return user, err
+	}
+
 	// Unmarshal profile_data JSON
 	if len(profileDataJSON) > 0 {
 		err = json.Unmarshal(profileDataJSON, &user.ProfileData)
@@ -138,15 +158,15 @@ func (s UserStore) UpdateUser(ctx context.Context, id string, userReq models.Use
 		err = tx.Commit()
 	}()
 
-	// Check if a user with the given id exists and get current data
-	var exists bool
-	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", id).Scan(&exists)
+	// Check if a user with the given id exists and get the password they sign in with
+	var currentHash string
+	err = tx.QueryRowContext(ctx, "SELECT password_hash FROM users WHERE id = $1 FOR UPDATE", id).Scan(&currentHash)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return updatedUser, errors.New("no user found with the given ID")
+		}
 		return updatedUser, err
 	}
-	if !exists {
-		return updatedUser, sql.ErrNoRows
-	}
 
 	// Hash the new password (after confirming the user exists)
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(userReq.Password), bcrypt.DefaultCost)
@@ -154,21 +174,38 @@ func (s UserStore) UpdateUser(ctx context.Context, id string, userReq models.Use
 		return updatedUser, err
 	}
 
+	// A new password ends every session of the user, as a password reset does
+	now := time.Now().UTC()
+	if bcrypt.CompareHashAndPassword([]byte(currentHash), []byte(userReq.Password)) != nil {
+		if _, err = tx.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = $2
+		         WHERE user_id = $1 AND revoked_at IS NULL`, id, now); err != nil {
+			return updatedUser, err
+		}
+	}
+
 	// Update user in the users table using the transaction
 	query := `
 		UPDATE users
-		SET username = $1, email = $2, password_hash = $3, phone = $4, role = $5, updated_at = $6
-		WHERE id = $7
-		RETURNING id, username, email, phone, role, profile_data, created_at, updated_at
+		SET username = $1, email = $2, password_hash = $3, phone = $4, license_number = $5, role = $6, updated_at = $7,
+		    phone_hash = NULLIF($9, '')
+		WHERE id = $8
+		RETURNING id, username, email, phone, license_number, role, profile_data, created_at, updated_at
 	`
-	now := time.Now().UTC()
 	var profileDataJSON []byte
-	err = tx.QueryRowContext(ctx, query, userReq.UserName, userReq.Email, string(hashedPassword), userReq.Phone, userReq.Role, now, id).Scan(
-		&updatedUser.ID, &updatedUser.UserName, &updatedUser.Email, &updatedUser.Phone, &updatedUser.Role, &profileDataJSON, &updatedUser.CreatedAt, &updatedUser.UpdatedAt)
+	phone, licenseNumber, err := s.encryptPII(userReq.Phone, userReq.LicenseNumber)
+	if err != nil {
+		return updatedUser, err
+	}
+	err = tx.QueryRowContext(ctx, query, userReq.UserName, userReq.Email, string(hashedPassword), phone, licenseNumber, userReq.Role, now, id, s.phoneIndex(userReq.Phone)).Scan(
+		&updatedUser.ID, &updatedUser.UserName, &updatedUser.Email, &updatedUser.Phone, &updatedUser.LicenseNumber, &updatedUser.Role, &profileDataJSON, &updatedUser.CreatedAt, &updatedUser.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return updatedUser, errors.New("no user found with the given ID")
 		}
+		return updatedUser, store.ConstraintViolation(err, store.ErrDuplicateEmail, store.ErrDuplicatePhone)
+	}
+
+	if err = s.decryptPII(&updatedUser); err != nil {
 		return updatedUser, err
 	}
 
@@ -212,9 +249,9 @@ func (s UserStore) DeleteUser(ctx context.Context, id string) (models.User, erro
 
 	// Get user data before deleting (for audit purposes)
 	var profileDataJSON []byte
-	query := "SELECT id, username, email, phone, role, profile_data, created_at, updated_at FROM users WHERE id = $1"
+	query := "SELECT id, username, email, phone, license_number, role, profile_data, created_at, updated_at FROM users WHERE id = $1"
 	err = tx.QueryRowContext(ctx, query, id).Scan(
-		&deletedUser.ID, &deletedUser.UserName, &deletedUser.Email, &deletedUser.Phone, &deletedUser.Role, &profileDataJSON, &deletedUser.CreatedAt, &deletedUser.UpdatedAt)
+		&deletedUser.ID, &deletedUser.UserName, &deletedUser.Email, &deletedUser.Phone, &deletedUser.LicenseNumber, &deletedUser.Role, &profileDataJSON, &deletedUser.CreatedAt, &deletedUser.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return deletedUser, errors.New("no user found with the given ID")
@@ -222,6 +259,10 @@ func (s UserStore) DeleteUser(ctx context.Context, id string) (models.User, erro
 		return deletedUser, err
 	}
 
+	if err = s.decryptPII(&deletedUser); err != nil {
+		return deletedUser, err
+	}
+
 	// Unmarshal profile_data JSON
 	if len(profileDataJSON) > 0 {
 		err = json.Unmarshal(profileDataJSON, &deletedUser.ProfileData)
@@ -236,7 +277,7 @@ func (s UserStore) DeleteUser(ctx context.Context, id string) (models.User, erro
 	deleteQuery := "DELETE FROM users WHERE id = $1"
 	result, err := tx.ExecContext(ctx, deleteQuery, id)
 	if err != nil {
-		return deletedUser, err
+		return deletedUser, store.ConstraintViolation(err, store.ErrUserHasBookings)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -253,7 +294,7 @@ func (s UserStore) GetAllUsers(ctx context.Context) (users []models.User, err er
 	tracer := otel.Tracer("AuthStore")
 	ctx, span := tracer.Start(ctx, "GetAllUsers-Store")
 	defer span.End()
-	query := "SELECT id, username, email, phone, role, profile_data, created_at, updated_at FROM users"
+	query := "SELECT id, username, email, phone, license_number, role, profile_data, created_at, updated_at FROM users"
 	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -266,11 +307,15 @@ func (s UserStore) GetAllUsers(ctx context.Context) (users []models.User, err er
 	for rows.Next() {
 		var user models.User
 		var profileDataJSON []byte
-		err := rows.Scan(&user.ID, &user.UserName, &user.Email, &user.Phone, &user.Role, &profileDataJSON, &user.CreatedAt, &user.UpdatedAt)
+		err := rows.Scan(&user.ID, &user.UserName, &user.Email, &user.Phone, &user.LicenseNumber, &user.Role, &profileDataJSON, &user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
 
+		if err = s.decryptPII(&user); err != nil {
+			return nil, err
+		}
+
 		// Unmarshal profile_data JSON
 		if len(profileDataJSON) > 0 {
 			err = json.Unmarshal(profileDataJSON, &user.ProfileData)
@@ -289,6 +334,49 @@ func (s UserStore) GetAllUsers(ctx context.Context) (users []models.User, err er
 	return users, nil
 }
 
+// SetPassword replaces the password of the user with the given e-mail address and ends all of
+// their sessions, as a password reset does
+func (s UserStore) SetPassword(ctx context.Context, email, password string) (id uuid.UUID, err error) {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "SetPassword-Store")
+	defer span.End()
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	now := time.Now().UTC()
+	err = tx.QueryRowContext(ctx, `UPDATE users SET password_hash = $2, updated_at = $3
+	         WHERE email = $1 AND ($4::uuid IS NULL OR operator_id = $4)
+	         RETURNING id`, email, string(hashedPassword), now, tenant.Scope(ctx)).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, errors.New("user not found")
+		}
+		return uuid.Nil, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = $2
+	         WHERE user_id = $1 AND revoked_at IS NULL`, id, now); err != nil {
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
 // GetUserByID retrieves a user by their ID
 func (s UserStore) GetUserByID(ctx context.Context, userID string) (models.User, error) {
 	tracer := otel.Tracer("AuthStore")
@@ -297,9 +385,9 @@ func (s UserStore) GetUserByID(ctx context.Context, userID string) (models.User,
 
 	var user models.User
 	var profileDataJSON []byte
-	query := "SELECT id, username, email, phone, role, profile_data, created_at, updated_at FROM users WHERE id = $1"
+	query := "SELECT id, username, email, phone, license_number, role, profile_data, created_at, updated_at, operator_id FROM users WHERE id = $1"
 	err := s.db.QueryRowContext(ctx, query, userID).Scan(
-		&user.ID, &user.UserName, &user.Email, &user.Phone, &user.Role, &profileDataJSON, &user.CreatedAt, &user.UpdatedAt)
+		&user.ID, &user.UserName, &user.Email, &user.Phone, &user.LicenseNumber, &user.Role, &profileDataJSON, &user.CreatedAt, &user.UpdatedAt, &user.OperatorID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return user, errors.New("user not found")
@@ -307,6 +395,10 @@ func (s UserStore) GetUserByID(ctx context.Context, userID string) (models.User,
 		return user, err
 	}
 
+	if err = s.decryptPII(&user); err != nil {
+		return user, err
+	}
+
 	// Unmarshal profile_data JSON
 	if len(profileDataJSON) > 0 {
 		err = json.Unmarshal(profileDataJSON, &user.ProfileData)
@@ -320,6 +412,67 @@ func (s UserStore) GetUserByID(ctx context.Context, userID string) (models.User,
 	return user, nil
 }
 
+// GetUserByEmail retrieves the user with the given e-mail address among the users of the
+// operator the request acts for, without checking a password
+func (s UserStore) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "GetUserByEmail-Store")
+	defer span.End()
+
+	var user models.User
+	var profileDataJSON []byte
+	query := `SELECT id, username, email, phone, license_number, role, profile_data, created_at, updated_at, operator_id
+	         FROM users WHERE email = $1 AND ($2::uuid IS NULL OR operator_id = $2)`
+	err := s.db.QueryRowContext(ctx, query, email, tenant.Scope(ctx)).Scan(
+		&user.ID, &user.UserName, &user.Email, &user.Phone, &user.LicenseNumber, &user.Role, &profileDataJSON, &user.CreatedAt, &user.UpdatedAt, &user.OperatorID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return user, errors.New("user not found")
+		}
+		return user, err
+	}
+
+	if err = s.decryptPII(&user); err != nil {
+		return user, err
+	}
+
+	// Unmarshal profile_data JSON
+	if len(profileDataJSON) > 0 {
+		err = json.Unmarshal(profileDataJSON, &user.ProfileData)
+		if err != nil {
+			return user, err
+		}
+	} else {
+		user.ProfileData = make(map[string]interface{})
+	}
+
+	return user, nil
+}
+
+// GetPublicProfile retrieves only the publicly visible fields of an owner. The display name
+// is profile_data's display_name, falling back to the username.
+func (s UserStore) GetPublicProfile(ctx context.Context, ownerID string) (models.PublicProfile, error) {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "GetPublicProfile-Store")
+	defer span.End()
+
+	query := `SELECT id, COALESCE(NULLIF(TRIM(profile_data->>'display_name'), ''), username),
+	           date_trunc('month', created_at)
+	         FROM users WHERE id = $1 AND role = 'owner' AND ($2::uuid IS NULL OR operator_id = $2)`
+
+	var profile models.PublicProfile
+	err := s.db.QueryRowContext(ctx, query, ownerID, tenant.Scope(ctx)).
+		Scan(&profile.ID, &profile.DisplayName, &profile.MemberSince)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PublicProfile{}, errors.New("no owner found with the given ID")
+		}
+		return models.PublicProfile{}, err
+	}
+
+	return profile, nil
+}
+
 // UpdateProfileData updates only the profile_data field for a user
 func (s UserStore) UpdateProfileData(ctx context.Context, userID string, profileData map[string]interface{}) error {
 	tracer := otel.Tracer("AuthStore")
@@ -354,13 +507,183 @@ func (s UserStore) UpdateProfileData(ctx context.Context, userID string, profile
 	return nil
 }
 
+// UpdateProfile updates the profile_data field of a user and, unless licenseNumber is nil, the
+// encrypted driving licence number with it, in one statement; an empty licenseNumber removes it
+func (s UserStore) UpdateProfile(ctx context.Context, userID string, profileData map[string]interface{}, licenseNumber *string) error {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "UpdateProfile-Store")
+	defer span.End()
+
+	profileDataJSON, err := json.Marshal(profileData)
+	if err != nil {
+		return err
+	}
+	var encrypted interface{}
+	if licenseNumber != nil {
+		if encrypted, err = s.cipher.Encrypt(*licenseNumber); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		UPDATE users
+		SET profile_data = $1, updated_at = $2,
+		    license_number = CASE WHEN $4 THEN $5 ELSE license_number END
+		WHERE id = $3
+	`
+	result, err := s.db.ExecContext(ctx, query, profileDataJSON, time.Now().UTC(), userID, licenseNumber != nil, encrypted)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// GetTwoFactor reads a user's two-factor authentication state, decrypting the TOTP secret
+func (s UserStore) GetTwoFactor(ctx context.Context, userID string) (models.TwoFactor, error) {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "GetTwoFactor-Store")
+	defer span.End()
+
+	var twoFactor models.TwoFactor
+	var secret string
+	var backupCodes pq.StringArray
+	err := s.db.QueryRowContext(ctx, `SELECT COALESCE(totp_secret, ''), totp_enabled_at, COALESCE(totp_last_step, 0),
+	         COALESCE(totp_backup_codes, '{}') FROM users WHERE id = $1`, userID).Scan(
+		&secret, &twoFactor.EnabledAt, &twoFactor.LastUsedStep, &backupCodes)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return twoFactor, errors.New("user not found")
+		}
+		return twoFactor, err
+	}
+
+	if twoFactor.Secret, err = s.cipher.Decrypt(secret); err != nil {
+		return twoFactor, err
+	}
+	twoFactor.BackupCodeHashes = []string(backupCodes)
+
+	return twoFactor, nil
+}
+
+// SetPendingTwoFactor stores a new TOTP secret, encrypted, that is not enforced until
+// EnableTwoFactor confirms it. Starting setup again replaces a secret that was never confirmed.
+func (s UserStore) SetPendingTwoFactor(ctx context.Context, userID string, secret string) error {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "SetPendingTwoFactor-Store")
+	defer span.End()
+
+	encrypted, err := s.cipher.Encrypt(secret)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.ExecContext(ctx, `UPDATE users SET totp_secret = $1, totp_last_step = NULL, totp_backup_codes = NULL,
+	         updated_at = $2 WHERE id = $3 AND totp_enabled_at IS NULL`, encrypted, time.Now().UTC(), userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("two-factor authentication is already enabled")
+	}
+
+	return nil
+}
+
+// EnableTwoFactor starts enforcing the pending TOTP secret of a user, recording the time step
+// of the code that confirmed it and the hashes of the user's backup codes
+func (s UserStore) EnableTwoFactor(ctx context.Context, userID string, step int64, backupCodeHashes []string) error {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "EnableTwoFactor-Store")
+	defer span.End()
+
+	now := time.Now().UTC()
+	result, err := s.db.ExecContext(ctx, `UPDATE users SET totp_enabled_at = $1, totp_last_step = $2, totp_backup_codes = $3,
+	         updated_at = $1 WHERE id = $4 AND totp_enabled_at IS NULL AND totp_secret IS NOT NULL`,
+		now, step, pq.StringArray(backupCodeHashes), userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("two-factor authentication is already enabled")
+	}
+
+	return nil
+}
+
+// UseTwoFactorStep records that a user logged in with the code of a time step. It fails when a
+// code of that step or a later one was already accepted, so an intercepted code cannot be replayed.
+func (s UserStore) UseTwoFactorStep(ctx context.Context, userID string, step int64) error {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "UseTwoFactorStep-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `UPDATE users SET totp_last_step = $1
+	         WHERE id = $2 AND COALESCE(totp_last_step, 0) < $1`, step, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("two-factor code was already used, wait for the next one")
+	}
+
+	return nil
+}
+
+// UseBackupCode removes a backup code from a user's unused codes. It fails when the user has
+// no such code, including one removed by a concurrent login.
+func (s UserStore) UseBackupCode(ctx context.Context, userID string, codeHash string) error {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "UseBackupCode-Store")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, `UPDATE users SET totp_backup_codes = array_remove(totp_backup_codes, $1)
+	         WHERE id = $2 AND $1 = ANY(totp_backup_codes)`, codeHash, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("invalid two-factor code")
+	}
+
+	return nil
+}
+
 // GetUsersByRole retrieves all users with a specific role
 func (s UserStore) GetUsersByRole(ctx context.Context, role string) ([]models.User, error) {
 	tracer := otel.Tracer("AuthStore")
 	ctx, span := tracer.Start(ctx, "GetUsersByRole-Store")
 	defer span.End()
 
-	query := "SELECT id, username, email, phone, role, profile_data, created_at, updated_at FROM users WHERE role = $1"
+	query := "SELECT id, username, email, phone, license_number, role, profile_data, created_at, updated_at FROM users WHERE role = $1"
 	rows, err := s.db.QueryContext(ctx, query, role)
 	if err != nil {
 		return nil, err
@@ -371,11 +694,72 @@ func (s UserStore) GetUsersByRole(ctx context.Context, role string) ([]models.Us
 	for rows.Next() {
 		var user models.User
 		var profileDataJSON []byte
-		err := rows.Scan(&user.ID, &user.UserName, &user.Email, &user.Phone, &user.Role, &profileDataJSON, &user.CreatedAt, &user.UpdatedAt)
+		err := rows.Scan(&user.ID, &user.UserName, &user.Email, &user.Phone, &user.LicenseNumber, &user.Role, &profileDataJSON, &user.CreatedAt, &user.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = s.decryptPII(&user); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal profile_data JSON
+		if len(profileDataJSON) > 0 {
+			err = json.Unmarshal(profileDataJSON, &user.ProfileData)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			user.ProfileData = make(map[string]interface{})
+		}
+
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// ListUsers retrieves one page of users ordered from newest to oldest.
+// It fetches page.Limit+1 rows so the caller can tell whether another page exists.
+func (s UserStore) ListUsers(ctx context.Context, page models.PageRequest) ([]models.User, error) {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "ListUsers-Store")
+	defer span.End()
+
+	var args []interface{}
+	query := "SELECT id, username, email, phone, license_number, role, profile_data, created_at, updated_at FROM users"
+
+	// Keyset condition: continue strictly past the cursor in its direction of travel
+	if page.Cursor != nil {
+		query += " WHERE (created_at, id) " + page.KeysetComparator() + " ($1, $2)"
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+	}
+	query += fmt.Sprintf(" ORDER BY created_at %[1]s, id %[1]s LIMIT $%[2]d", page.SortOrder(), len(args)+1)
+	args = append(args, page.Limit+1)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		var profileDataJSON []byte
+		err := rows.Scan(&user.ID, &user.UserName, &user.Email, &user.Phone, &user.LicenseNumber, &user.Role, &profileDataJSON, &user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
 
+		if err = s.decryptPII(&user); err != nil {
+			return nil, err
+		}
+
 		// Unmarshal profile_data JSON
 		if len(profileDataJSON) > 0 {
 			err = json.Unmarshal(profileDataJSON, &user.ProfileData)
@@ -395,3 +779,168 @@ func (s UserStore) GetUsersByRole(ctx context.Context, role string) ([]models.Us
 
 	return users, nil
 }
+
+// encryptPII encrypts the phone and licence number columns of a user
+func (s UserStore) encryptPII(phone, licenseNumber string) (string, string, error) {
+	encryptedPhone, err := s.cipher.Encrypt(phone)
+	if err != nil {
+		return "", "", err
+	}
+	encryptedLicense, err := s.cipher.Encrypt(licenseNumber)
+	if err != nil {
+		return "", "", err
+	}
+	return encryptedPhone, encryptedLicense, nil
+}
+
+// phoneIndex returns the blind index stored in phone_hash for a plaintext phone number
+func (s UserStore) phoneIndex(phone string) string {
+	return s.cipher.BlindIndex(models.PhoneSearchKey(phone))
+}
+
+// decryptPII replaces the encrypted phone and licence number of a scanned user with plaintext
+func (s UserStore) decryptPII(user *models.User) (err error) {
+	if user.Phone, err = s.cipher.Decrypt(user.Phone); err != nil {
+		return err
+	}
+	user.LicenseNumber, err = s.cipher.Decrypt(user.LicenseNumber)
+	return err
+}
+
+// ReindexPhoneSearch recomputes the phone_hash support search finds users by from their
+// decrypted phone numbers, e.g. after the blind index key changed. Users whose hash is already
+// right are not rewritten.
+func (s UserStore) ReindexPhoneSearch(ctx context.Context) (models.MaintenanceResult, error) {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "ReindexPhoneSearch-Store")
+	defer span.End()
+
+	type indexedPhone struct {
+		id        string
+		phone     string
+		phoneHash string
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, COALESCE(phone, ''), COALESCE(phone_hash, '') FROM users
+	         WHERE ($1::uuid IS NULL OR operator_id = $1)`, tenant.Scope(ctx))
+	if err != nil {
+		return models.MaintenanceResult{}, err
+	}
+
+	var result models.MaintenanceResult
+	var stale []indexedPhone
+	for rows.Next() {
+		var v indexedPhone
+		if err := rows.Scan(&v.id, &v.phone, &v.phoneHash); err != nil {
+			rows.Close()
+			return result, err
+		}
+		result.Examined++
+
+		plainPhone, err := s.cipher.Decrypt(v.phone)
+		if err != nil {
+			rows.Close()
+			return result, err
+		}
+		index := ""
+		if plainPhone != "" {
+			index = s.phoneIndex(plainPhone)
+		}
+		if index != v.phoneHash {
+			v.phoneHash = index
+			stale = append(stale, v)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	for _, v := range stale {
+		// Skipped if the phone number changed meanwhile; that update set its own hash
+		updated, err := s.db.ExecContext(ctx, `UPDATE users SET phone_hash = NULLIF($1, '')
+		         WHERE id = $2 AND COALESCE(phone, '') = $3`, v.phoneHash, v.id, v.phone)
+		if err != nil {
+			return result, store.ConstraintViolation(err, store.ErrDuplicatePhone)
+		}
+		if n, err := updated.RowsAffected(); err == nil && n > 0 {
+			result.Changed++
+		}
+	}
+
+	return result, nil
+}
+
+// RotateEncryptionKeys re-encrypts phone and licence numbers and TOTP secrets sealed with a retired key,
+// and encrypts legacy plaintext rows. Phone numbers saved before blind indexing was
+// enabled get their phone_hash filled in. Returns the number of users rewritten.
+func (s UserStore) RotateEncryptionKeys(ctx context.Context) (int, error) {
+	tracer := otel.Tracer("AuthStore")
+	ctx, span := tracer.Start(ctx, "RotateEncryptionKeys-Store")
+	defer span.End()
+
+	type storedValues struct {
+		id            string
+		phone         string
+		licenseNumber string
+		phoneHash     string
+		totpSecret    string
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT id, COALESCE(phone, ''), license_number, COALESCE(phone_hash, ''), COALESCE(totp_secret, '') FROM users")
+	if err != nil {
+		return 0, err
+	}
+
+	indexEnabled := s.cipher.BlindIndex("0") != ""
+	var stale []storedValues
+	for rows.Next() {
+		var v storedValues
+		if err := rows.Scan(&v.id, &v.phone, &v.licenseNumber, &v.phoneHash, &v.totpSecret); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		missingIndex := v.phone != "" && v.phoneHash == "" && indexEnabled
+		if s.cipher.NeedsRotation(v.phone) || s.cipher.NeedsRotation(v.licenseNumber) || s.cipher.NeedsRotation(v.totpSecret) || missingIndex {
+			stale = append(stale, v)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	rotated := 0
+	for _, v := range stale {
+		phone, err := s.cipher.Rotate(v.phone)
+		if err != nil {
+			return rotated, err
+		}
+		licenseNumber, err := s.cipher.Rotate(v.licenseNumber)
+		if err != nil {
+			return rotated, err
+		}
+		totpSecret, err := s.cipher.Rotate(v.totpSecret)
+		if err != nil {
+			return rotated, err
+		}
+		plainPhone, err := s.cipher.Decrypt(phone)
+		if err != nil {
+			return rotated, err
+		}
+
+		// Only overwrite the values that were read so a concurrent update is never lost
+		result, err := s.db.ExecContext(ctx, `UPDATE users SET phone = $1, license_number = $2, phone_hash = NULLIF($6, ''),
+		         totp_secret = NULLIF($7, '')
+		         WHERE id = $3 AND COALESCE(phone, '') = $4 AND license_number = $5 AND COALESCE(totp_secret, '') = $8`,
+			phone, licenseNumber, v.id, v.phone, v.licenseNumber, s.phoneIndex(plainPhone), totpSecret, v.totpSecret)
+		if err != nil {
+			return rotated, err
+		}
+		if n, err := result.RowsAffected(); err == nil && n > 0 {
+			rotated++
+		}
+	}
+
+	return rotated, nil
+}
@@ -0,0 +1,253 @@
+package vacation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// vacationColumns lists the columns read by every vacation query, in scanVacation order. The
+// blocked cars are aggregated from car_blackout, so queries must join it as b and group by v.id.
+const vacationColumns = `v.id, v.owner_id, v.start_date, v.end_date, v.note, v.created_at,
+	COALESCE(array_agg(b.car_id) FILTER (WHERE b.car_id IS NOT NULL), '{}')`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// VacationStore persists owner vacations and the per-car blackouts they create
+type VacationStore struct {
+	db *sql.DB
+}
+
+// New creates a new vacation store
+func New(db *sql.DB) VacationStore {
+	return VacationStore{db: db}
+}
+
+// CreateVacation blocks every car of the owner for the requested period in one transaction.
+// The owner's cars are locked while the period is checked against their other vacations and
+// open bookings, so either all cars are blocked or none are.
+func (s VacationStore) CreateVacation(ctx context.Context, ownerID string, req models.VacationRequest) (models.OwnerVacation, error) {
+	tracer := otel.Tracer("VacationStore")
+	ctx, span := tracer.Start(ctx, "CreateVacation-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.OwnerVacation{}, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	var carIDs pq.StringArray
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(array_agg(id ORDER BY id), '{}') FROM
+	         (SELECT id FROM car WHERE owner_id = $1 ORDER BY id FOR UPDATE) owned`, ownerID).Scan(&carIDs)
+	if err != nil {
+		return models.OwnerVacation{}, err
+	}
+	if len(carIDs) == 0 {
+		err = errors.New("owner has no cars to block")
+		return models.OwnerVacation{}, err
+	}
+
+	var overlapping int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM owner_vacation
+	         WHERE owner_id = $1 AND start_date < $3 AND end_date > $2`, ownerID, req.StartDate, req.EndDate).Scan(&overlapping)
+	if err != nil {
+		return models.OwnerVacation{}, err
+	}
+	if overlapping > 0 {
+		err = errors.New("vacation overlaps an existing vacation")
+		return models.OwnerVacation{}, err
+	}
+
+	var booked int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM booking
+	         WHERE car_id = ANY($1::uuid[]) AND status IN ($4, $5, $6, $7) AND start_date < $3 AND end_date > $2`,
+		carIDs, req.StartDate, req.EndDate, models.BookingStatusPending, models.BookingStatusUnderReview,
+		models.BookingStatusConfirmed, models.BookingStatusInProgress).Scan(&booked)
+	if err != nil {
+		return models.OwnerVacation{}, err
+	}
+	if booked > 0 {
+		err = fmt.Errorf("vacation conflicts with %d open bookings, cancel or reschedule them first", booked)
+		return models.OwnerVacation{}, err
+	}
+
+	vacation := models.OwnerVacation{
+		ID:        uuid.New(),
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Note:      req.Note,
+		CreatedAt: time.Now(),
+	}
+	err = tx.QueryRowContext(ctx, `INSERT INTO owner_vacation (id, owner_id, start_date, end_date, note, created_at)
+	         VALUES ($1, $2, $3, $4, $5, $6) RETURNING owner_id`,
+		vacation.ID, ownerID, vacation.StartDate, vacation.EndDate, vacation.Note, vacation.CreatedAt).Scan(&vacation.OwnerID)
+	if err != nil {
+		return models.OwnerVacation{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO car_blackout (id, car_id, vacation_id, start_date, end_date)
+	         SELECT gen_random_uuid(), car_id, $1, $2, $3 FROM unnest($4::uuid[]) AS car_id`,
+		vacation.ID, vacation.StartDate, vacation.EndDate, carIDs)
+	if err != nil {
+		return models.OwnerVacation{}, err
+	}
+
+	vacation.CarIDs, err = parseCarIDs(carIDs)
+	if err != nil {
+		return models.OwnerVacation{}, err
+	}
+
+	return vacation, nil
+}
+
+// ListVacations retrieves the owner's vacations, latest first
+func (s VacationStore) ListVacations(ctx context.Context, ownerID string) ([]models.OwnerVacation, error) {
+	tracer := otel.Tracer("VacationStore")
+	ctx, span := tracer.Start(ctx, "ListVacations-Store")
+	defer span.End()
+
+	query := `SELECT ` + vacationColumns + `
+	         FROM owner_vacation v LEFT JOIN car_blackout b ON b.vacation_id = v.id
+	         WHERE v.owner_id = $1 GROUP BY v.id ORDER BY v.start_date DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var vacations []models.OwnerVacation
+	for rows.Next() {
+		vacation, err := scanVacation(rows)
+		if err != nil {
+			return nil, err
+		}
+		vacations = append(vacations, vacation)
+	}
+
+	return vacations, rows.Err()
+}
+
+// DeleteVacation lifts an owner's vacation, removing the blackouts of all its cars in one
+// transaction. The returned vacation lists the cars that were unblocked.
+func (s VacationStore) DeleteVacation(ctx context.Context, id string, ownerID string) (models.OwnerVacation, error) {
+	tracer := otel.Tracer("VacationStore")
+	ctx, span := tracer.Start(ctx, "DeleteVacation-Store")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.OwnerVacation{}, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `SELECT ` + vacationColumns + `
+	         FROM owner_vacation v LEFT JOIN car_blackout b ON b.vacation_id = v.id
+	         WHERE v.id = $1 AND v.owner_id = $2 GROUP BY v.id`
+
+	vacation, err := scanVacation(tx.QueryRowContext(ctx, query, id, ownerID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = errors.New("no vacation found with the given ID")
+		}
+		return models.OwnerVacation{}, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM car_blackout WHERE vacation_id = $1`, id); err != nil {
+		return models.OwnerVacation{}, err
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM owner_vacation WHERE id = $1`, id); err != nil {
+		return models.OwnerVacation{}, err
+	}
+
+	return vacation, nil
+}
+
+// IsCarBlocked reports whether any blackout of the car overlaps the given period
+func (s VacationStore) IsCarBlocked(ctx context.Context, carID string, start, end time.Time) (bool, error) {
+	tracer := otel.Tracer("VacationStore")
+	ctx, span := tracer.Start(ctx, "IsCarBlocked-Store")
+	defer span.End()
+
+	var blocked bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM car_blackout
+	         WHERE car_id = $1 AND start_date < $3 AND end_date > $2)`, carID, start, end).Scan(&blocked)
+	return blocked, err
+}
+
+// ListCarBlackouts retrieves the vacation blackouts of a car overlapping a period, earliest first
+func (s VacationStore) ListCarBlackouts(ctx context.Context, carID string, start, end time.Time) ([]models.CarBlackout, error) {
+	tracer := otel.Tracer("VacationStore")
+	ctx, span := tracer.Start(ctx, "ListCarBlackouts-Store")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT start_date, end_date FROM car_blackout
+	         WHERE car_id = $1 AND start_date < $3 AND end_date > $2 ORDER BY start_date`, carID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blackouts []models.CarBlackout
+	for rows.Next() {
+		var blackout models.CarBlackout
+		if err := rows.Scan(&blackout.StartDate, &blackout.EndDate); err != nil {
+			return nil, err
+		}
+		blackouts = append(blackouts, blackout)
+	}
+
+	return blackouts, rows.Err()
+}
+
+// scanVacation reads one owner_vacation row with its aggregated car IDs
+func scanVacation(row rowScanner) (models.OwnerVacation, error) {
+	var v models.OwnerVacation
+	var carIDs pq.StringArray
+	if err := row.Scan(&v.ID, &v.OwnerID, &v.StartDate, &v.EndDate, &v.Note, &v.CreatedAt, &carIDs); err != nil {
+		return models.OwnerVacation{}, err
+	}
+
+	ids, err := parseCarIDs(carIDs)
+	if err != nil {
+		return models.OwnerVacation{}, err
+	}
+	v.CarIDs = ids
+	return v, nil
+}
+
+// parseCarIDs converts a Postgres uuid array read as text into UUIDs
+func parseCarIDs(raw pq.StringArray) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, s := range raw {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
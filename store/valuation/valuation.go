@@ -0,0 +1,86 @@
+package valuation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"go.opentelemetry.io/otel"
+)
+
+// ValuationStore reads owners' cars for the fleet valuation report and keeps what owners paid
+// for them
+type ValuationStore struct {
+	db *sql.DB
+}
+
+// New creates a new valuation store
+func New(db *sql.DB) ValuationStore {
+	return ValuationStore{db: db}
+}
+
+// ListValuationCars retrieves an owner's cars that are not retired, with their purchase and
+// latest check-in odometer reading, by name. Estimates are left to the caller.
+func (s ValuationStore) ListValuationCars(ctx context.Context, ownerID string) ([]models.CarValuation, error) {
+	tracer := otel.Tracer("ValuationStore")
+	ctx, span := tracer.Start(ctx, "ListValuationCars-Store")
+	defer span.End()
+
+	query := `
+		SELECT c.id, c.name, c.brand, c.model, c.year, c.fuel_type, c.mileage, p.purchase_price, p.purchased_on,
+			   (SELECT MAX(i.recorded_at) FROM booking_inspection i
+			    INNER JOIN booking b ON i.booking_id = b.id
+			    WHERE b.car_id = c.id AND i.kind = $2 AND i.odometer_km IS NOT NULL)
+		FROM car c
+		LEFT JOIN car_purchase p ON p.car_id = c.id
+		WHERE c.owner_id = $1 AND c.status <> $3
+		ORDER BY c.name, c.id`
+
+	rows, err := s.db.QueryContext(ctx, query, ownerID, models.InspectionKindCheckin, models.CarStatusRetired)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cars := []models.CarValuation{}
+	for rows.Next() {
+		var car models.CarValuation
+		err := rows.Scan(&car.CarID, &car.Name, &car.Brand, &car.Model, &car.Year, &car.FuelType, &car.MileageKm,
+			&car.PurchasePrice, &car.PurchasedOn, &car.LastCheckinAt)
+		if err != nil {
+			return nil, err
+		}
+		cars = append(cars, car)
+	}
+
+	return cars, rows.Err()
+}
+
+// PutCarPurchase records or replaces what an owner paid for one of their cars
+func (s ValuationStore) PutCarPurchase(ctx context.Context, carID, ownerID string, purchasePrice float64, purchasedOn *time.Time) (models.CarPurchase, error) {
+	tracer := otel.Tracer("ValuationStore")
+	ctx, span := tracer.Start(ctx, "PutCarPurchase-Store")
+	defer span.End()
+
+	// Selecting from car keeps owners from pricing cars that are not theirs
+	query := `
+		INSERT INTO car_purchase (car_id, purchase_price, purchased_on, updated_at)
+		SELECT id, $3, $4, $5 FROM car WHERE id = $1 AND owner_id = $2
+		ON CONFLICT (car_id) DO UPDATE
+		SET purchase_price = EXCLUDED.purchase_price, purchased_on = EXCLUDED.purchased_on, updated_at = EXCLUDED.updated_at
+		RETURNING car_id, purchase_price, purchased_on, updated_at`
+
+	var purchase models.CarPurchase
+	err := s.db.QueryRowContext(ctx, query, carID, ownerID, purchasePrice, purchasedOn, time.Now()).Scan(
+		&purchase.CarID, &purchase.PurchasePrice, &purchase.PurchasedOn, &purchase.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return purchase, errors.New("no car found with the given ID among your cars")
+		}
+		return purchase, err
+	}
+
+	return purchase, nil
+}
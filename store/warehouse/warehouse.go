@@ -0,0 +1,114 @@
+package warehouse
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PrateekKumar15/CarZone/models"
+	"go.opentelemetry.io/otel"
+)
+
+// exportColumns lists the columns exported per entity. Personal data (names, e-mail addresses,
+// phone and licence numbers, notes, delivery addresses) and secrets are deliberately left out.
+var exportColumns = map[models.WarehouseEntity]struct {
+	table   string
+	columns []string
+}{
+	models.WarehouseUsers: {"users", []string{"id", "role", "created_at", "updated_at"}},
+	models.WarehouseCars: {"car", []string{"id", "owner_id", "name", "brand", "model", "year", "fuel_type",
+		"location_city", "location_state", "location_country", "price", "status", "is_available", "mileage",
+		"created_at", "updated_at"}},
+	models.WarehouseBookings: {"booking", []string{"id", "customer_id", "car_id", "owner_id", "status", "total_amount",
+		"start_date", "end_date", "pickup_location_id", "dropoff_location_id", "pickup_fee", "dropoff_fee",
+		"delivery_distance_km", "delivery_fee", "relocation_fee", "add_ons_fee", "prepaid_fuel_fee", "late_fee", "refuel_fee", "created_at",
+		"updated_at"}},
+	models.WarehousePayments: {"payment", []string{"id", "booking_id", "amount", "currency", "status", "method",
+		"created_at", "updated_at"}},
+}
+
+// WarehouseStore reads changed rows for the warehouse export and tracks how far each entity was exported
+type WarehouseStore struct {
+	db *sql.DB
+}
+
+// New creates a new warehouse store
+func New(db *sql.DB) WarehouseStore {
+	return WarehouseStore{db: db}
+}
+
+// GetWatermark returns the updated_at up to which an entity was exported; zero if never exported
+func (s WarehouseStore) GetWatermark(ctx context.Context, entity models.WarehouseEntity) (time.Time, error) {
+	tracer := otel.Tracer("WarehouseStore")
+	ctx, span := tracer.Start(ctx, "GetWatermark-Store")
+	defer span.End()
+
+	var watermark time.Time
+	err := s.db.QueryRowContext(ctx, `SELECT exported_until FROM warehouse_export_watermark WHERE entity = $1`,
+		string(entity)).Scan(&watermark)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	return watermark, err
+}
+
+// SetWatermark records that an entity was exported up to the given updated_at
+func (s WarehouseStore) SetWatermark(ctx context.Context, entity models.WarehouseEntity, exportedUntil time.Time, rows int) error {
+	tracer := otel.Tracer("WarehouseStore")
+	ctx, span := tracer.Start(ctx, "SetWatermark-Store")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO warehouse_export_watermark (entity, exported_until, last_rows, last_run_at)
+	         VALUES ($1, $2, $3, $4)
+	         ON CONFLICT (entity) DO UPDATE SET exported_until = EXCLUDED.exported_until,
+	           last_rows = EXCLUDED.last_rows, last_run_at = EXCLUDED.last_run_at`,
+		string(entity), exportedUntil, rows, time.Now())
+	return err
+}
+
+// GetChangedRows returns the rows of an entity with from < updated_at <= to, every value as text
+func (s WarehouseStore) GetChangedRows(ctx context.Context, entity models.WarehouseEntity, from, to time.Time) (models.WarehouseRows, error) {
+	tracer := otel.Tracer("WarehouseStore")
+	ctx, span := tracer.Start(ctx, "GetChangedRows-Store")
+	defer span.End()
+
+	source, ok := exportColumns[entity]
+	if !ok {
+		return models.WarehouseRows{}, fmt.Errorf("unknown warehouse entity %q", entity)
+	}
+
+	selects := make([]string, len(source.columns))
+	for i, column := range source.columns {
+		selects[i] = column + "::text"
+	}
+	query := `SELECT ` + strings.Join(selects, ", ") + ` FROM ` + source.table + `
+	         WHERE updated_at > $1 AND updated_at <= $2 ORDER BY updated_at, id`
+
+	rows, err := s.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return models.WarehouseRows{}, err
+	}
+	defer rows.Close()
+
+	result := models.WarehouseRows{Columns: source.columns}
+	values := make([]sql.NullString, len(source.columns))
+	dest := make([]interface{}, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return models.WarehouseRows{}, err
+		}
+		record := make([]string, len(values))
+		for i, value := range values {
+			record[i] = value.String // NULL becomes an empty field
+		}
+		result.Rows = append(result.Rows, record)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,50 @@
+// Package tenant carries the marketplace operator a request acts for. Cars, bookings,
+// payments and users belong to an operator. Stores read the operator from the context
+// and restrict their queries to it, so one operator never sees another's data.
+//
+// Requests get an operator from a white-label domain (middleware.TenantMiddleware) or
+// from the tid claim of their token (middleware.AuthMiddleware). Background jobs and
+// requests to the shared API domain without a token have none and are not scoped.
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// DefaultOperatorID owns single-operator deployments and every row created before
+// operators were introduced. Its admins are platform admins.
+var DefaultOperatorID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+type contextKey struct{}
+
+// WithOperator returns a context scoped to the given operator
+func WithOperator(ctx context.Context, operatorID uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, operatorID)
+}
+
+// OperatorFromContext returns the operator a context is scoped to, if any
+func OperatorFromContext(ctx context.Context) (uuid.UUID, bool) {
+	operatorID, ok := ctx.Value(contextKey{}).(uuid.UUID)
+	return operatorID, ok
+}
+
+// Scope returns the operator to filter queries by, or nil when the context is not scoped.
+// Stores pass it as a parameter to conditions of the form
+// ($n::uuid IS NULL OR operator_id = $n).
+func Scope(ctx context.Context) *uuid.UUID {
+	if operatorID, ok := OperatorFromContext(ctx); ok {
+		return &operatorID
+	}
+	return nil
+}
+
+// ForInsert returns the operator new rows are created for: the context's operator, or the
+// default operator when the context is not scoped
+func ForInsert(ctx context.Context) uuid.UUID {
+	if operatorID, ok := OperatorFromContext(ctx); ok {
+		return operatorID
+	}
+	return DefaultOperatorID
+}